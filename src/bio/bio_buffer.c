@@ -1062,6 +1062,7 @@ rw_completion(void *cb_arg, int err)
 
 done:
 	if (biod->bd_inflights == 0) {
+		bio_io_stats_complete(biod);
 		iod_dma_completion(biod, err);
 		if (biod->bd_async_post && biod->bd_buffer_prep) {
 			iod_release_buffer(biod);
@@ -1167,6 +1168,9 @@ nvme_rw(struct bio_desc *biod, struct bio_rsrvd_region *rg)
 	D_ASSERT(pg_cnt > pg_idx);
 	pg_cnt -= pg_idx;
 
+	if (!biod->bd_dma_issued)
+		biod->bd_dma_start = daos_getutime();
+
 	while (pg_cnt > 0) {
 
 		drain_inflight_ios(xs_ctxt, bxb);
@@ -1177,6 +1181,13 @@ nvme_rw(struct bio_desc *biod, struct bio_rsrvd_region *rg)
 		biod->bd_ctxt->bic_inflight_dmas++;
 
 		rw_cnt = (pg_cnt > bio_chk_sz) ? bio_chk_sz : pg_cnt;
+		if (biod->bd_type == BIO_IOD_TYPE_UPDATE) {
+			bxb->bxb_io_stats.bis_write_ops++;
+			bxb->bxb_io_stats.bis_write_bytes += rw_cnt * BIO_DMA_PAGE_SZ;
+		} else {
+			bxb->bxb_io_stats.bis_read_ops++;
+			bxb->bxb_io_stats.bis_read_bytes += rw_cnt * BIO_DMA_PAGE_SZ;
+		}
 
 		D_DEBUG(DB_IO, "%s blob:%p payload:%p, pg_idx:"DF_U64", pg_cnt:"DF_U64"/"DF_U64"\n",
 			biod->bd_type == BIO_IOD_TYPE_UPDATE ? "Write" : "Read",
@@ -1229,6 +1240,8 @@ dma_rw(struct bio_desc *biod)
 
 	D_ASSERT(biod->bd_inflights > 0);
 	biod->bd_inflights -= 1;
+	if (biod->bd_inflights == 0)
+		bio_io_stats_complete(biod);
 
 	if (!biod->bd_async_post) {
 		iod_dma_wait(biod);
@@ -1132,6 +1132,7 @@ bio_blob_unmap(struct bio_io_context *ioctxt, uint64_t off, uint64_t len)
 
 	ioctxt->bic_inflight_dmas++;
 	ba->bca_inflights = 1;
+	ioctxt->bic_xs_blobstore->bxb_io_stats.bis_unmap_ops++;
 	spdk_blob_io_unmap(ioctxt->bic_blob, channel,
 			   page2io_unit(ioctxt, pg_off, BIO_DMA_PAGE_SZ),
 			   page2io_unit(ioctxt, pg_cnt, BIO_DMA_PAGE_SZ),
@@ -1205,6 +1206,7 @@ blob_unmap_sgl(struct bio_io_context *ioctxt, d_sg_list_t *unmap_sgl, uint32_t b
 
 		ba->bca_inflights++;
 		bxb->bxb_blob_rw++;
+		bxb->bxb_io_stats.bis_unmap_ops++;
 
 		pg_off = (uint64_t)unmap_iov->iov_buf;
 		pg_cnt = unmap_iov->iov_len;
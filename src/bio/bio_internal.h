@@ -261,7 +261,10 @@ struct bio_dma_buffer {
 	  "", D_TM_COUNTER)						\
 	Y(bdh_host_bytes_written, "vendor/host_bytes_written",		\
 	  "Host bytes written (1 count = 32 MiB)",			\
-	  "", D_TM_COUNTER)
+	  "", D_TM_COUNTER)						\
+	Y(bdh_write_amp, "vendor/write_amplification",			\
+	  "NAND bytes written per host byte written",			\
+	  "%", D_TM_GAUGE)
 
 /*
  * SPDK device health monitoring.
@@ -365,9 +368,29 @@ struct bio_blobstore {
 };
 
 /* Per-xstream blobstore */
+/* Length of the latency sampling window of bio_io_stats, in microseconds */
+#define BIO_IO_LAT_WINDOW	(10ULL * 1000 * 1000)
+
+/* Per-xstream I/O statistics of a blobstore */
+struct bio_io_stats {
+	uint64_t		bis_read_ops;
+	uint64_t		bis_write_ops;
+	uint64_t		bis_unmap_ops;
+	uint64_t		bis_read_bytes;
+	uint64_t		bis_write_bytes;
+	/* Start time of current latency window */
+	uint64_t		bis_lat_start;
+	/* Latency histograms of the current and the last complete window */
+	uint64_t		bis_read_lat[2][BIO_IO_LAT_BUCKETS];
+	uint64_t		bis_write_lat[2][BIO_IO_LAT_BUCKETS];
+	unsigned int		bis_lat_cur;
+};
+
 struct bio_xs_blobstore {
 	/* In-flight blob read/write */
 	unsigned int		 bxb_blob_rw;
+	/* I/O statistics issued from this xstream */
+	struct bio_io_stats	 bxb_io_stats;
 	/* spdk io channel */
 	struct spdk_io_channel	*bxb_io_channel;
 	/* per bio blobstore */
@@ -448,6 +471,8 @@ struct bio_desc {
 	unsigned int		 bd_type;
 	/* Total bytes landed to data blob */
 	unsigned int		 bd_nvme_bytes;
+	/* Time when the first SPDK DMA transfer was issued */
+	uint64_t		 bd_dma_start;
 	/* Flags */
 	unsigned int		 bd_buffer_prep:1,
 				 bd_dma_issued:1,
@@ -636,6 +661,8 @@ void bio_export_health_stats(struct bio_blobstore *bb, char *bdev_name);
 void bio_export_vendor_health_stats(struct bio_blobstore *bb, char *bdev_name);
 void bio_set_vendor_id(struct bio_blobstore *bb, char *bdev_name);
 void auto_faulty_detect(struct bio_blobstore *bbs);
+void bio_io_stats_rotate(struct bio_io_stats *bis, uint64_t now);
+void bio_io_stats_complete(struct bio_desc *biod);
 
 /* bio_context.c */
 int bio_blob_close(struct bio_io_context *ctxt, bool async);
@@ -187,6 +187,125 @@ bio_get_bs_state(int *bs_state, uuid_t dev_uuid, struct bio_xs_context *xs)
 	return 0;
 }
 
+/* Start a new latency window once the current one is elapsed */
+void
+bio_io_stats_rotate(struct bio_io_stats *bis, uint64_t now)
+{
+	unsigned int	last;
+
+	if (now < bis->bis_lat_start + BIO_IO_LAT_WINDOW)
+		return;
+
+	last = bis->bis_lat_cur;
+	bis->bis_lat_cur = 1 - last;
+	memset(bis->bis_read_lat[bis->bis_lat_cur], 0, sizeof(bis->bis_read_lat[0]));
+	memset(bis->bis_write_lat[bis->bis_lat_cur], 0, sizeof(bis->bis_write_lat[0]));
+
+	/* No I/O in the past window, the histograms of the last one are stale */
+	if (now >= bis->bis_lat_start + 2 * BIO_IO_LAT_WINDOW) {
+		memset(bis->bis_read_lat[last], 0, sizeof(bis->bis_read_lat[0]));
+		memset(bis->bis_write_lat[last], 0, sizeof(bis->bis_write_lat[0]));
+	}
+	bis->bis_lat_start = now;
+}
+
+/* Bucket i holds latencies in [2^(i-1), 2^i) microseconds */
+static inline unsigned int
+io_lat_bucket(uint64_t lat)
+{
+	unsigned int	idx = 0;
+
+	while (lat > 0 && idx < BIO_IO_LAT_BUCKETS - 1) {
+		lat >>= 1;
+		idx++;
+	}
+	return idx;
+}
+
+/* Account the latency of an IOD once all its NVMe DMA transfers are done */
+void
+bio_io_stats_complete(struct bio_desc *biod)
+{
+	struct bio_io_stats	*bis;
+	uint64_t		 now;
+	unsigned int		 idx;
+
+	if (!biod->bd_dma_issued || biod->bd_dma_start == 0)
+		return;
+
+	D_ASSERT(biod->bd_ctxt->bic_xs_blobstore != NULL);
+	bis = &biod->bd_ctxt->bic_xs_blobstore->bxb_io_stats;
+	now = daos_getutime();
+	bio_io_stats_rotate(bis, now);
+
+	idx = io_lat_bucket(now - biod->bd_dma_start);
+	if (biod->bd_type == BIO_IOD_TYPE_UPDATE)
+		bis->bis_write_lat[bis->bis_lat_cur][idx]++;
+	else
+		bis->bis_read_lat[bis->bis_lat_cur][idx]++;
+	biod->bd_dma_start = 0;
+}
+
+/*
+ * Add the I/O stats of the device issued from current xstream, no locking is
+ * required since the stats are only updated by the same xstream.
+ */
+int
+bio_get_io_stats(struct bio_dev_io_stats *stats, uuid_t dev_uuid,
+		 struct bio_xs_context *xs)
+{
+	struct bio_xs_blobstore	*bxb;
+	struct bio_io_stats	*bis;
+	unsigned int		 last, i;
+
+	bxb = bio_xs_blobstore_by_devid(xs, dev_uuid);
+	if (!bxb)
+		return -DER_ENOENT;
+
+	bis = &bxb->bxb_io_stats;
+	bio_io_stats_rotate(bis, daos_getutime());
+	last = 1 - bis->bis_lat_cur;
+
+	stats->bis_read_ops += bis->bis_read_ops;
+	stats->bis_write_ops += bis->bis_write_ops;
+	stats->bis_unmap_ops += bis->bis_unmap_ops;
+	stats->bis_read_bytes += bis->bis_read_bytes;
+	stats->bis_write_bytes += bis->bis_write_bytes;
+	stats->bis_queue_depth += bxb->bxb_blob_rw;
+	for (i = 0; i < BIO_IO_LAT_BUCKETS; i++) {
+		stats->bis_read_lat[i] += bis->bis_read_lat[last][i];
+		stats->bis_write_lat[i] += bis->bis_write_lat[last][i];
+	}
+
+	return 0;
+}
+
+uint64_t
+bio_io_lat_percentile(const uint64_t *buckets, unsigned int permille)
+{
+	uint64_t	total = 0, target, sum = 0;
+	unsigned int	i;
+
+	D_ASSERT(permille <= 1000);
+	for (i = 0; i < BIO_IO_LAT_BUCKETS; i++)
+		total += buckets[i];
+	if (total == 0)
+		return 0;
+
+	target = (total * permille + 999) / 1000;
+	if (target == 0)
+		target = 1;
+
+	for (i = 0; i < BIO_IO_LAT_BUCKETS - 1; i++) {
+		sum += buckets[i];
+		if (sum >= target)
+			break;
+	}
+
+	/* Report the upper bound of the bucket */
+	return 1ULL << i;
+}
+
 /*
  * Call internal method to set BIO device state to FAULTY and trigger device
  * state transition. Called from the device owner xstream.
@@ -481,6 +600,12 @@ populate_intel_smart_stats(struct bio_dev_health *bdh)
 					 stats->host_bytes_written);
 		}
 	}
+
+	/* write amplification as a percentage, 100 means no amplification */
+	if (stats->host_bytes_written > 0)
+		d_tm_set_gauge(bdh->bdh_write_amp,
+			       stats->nand_bytes_written * 100 /
+			       stats->host_bytes_written);
 }
 
 static void
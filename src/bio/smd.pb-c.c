@@ -763,6 +763,237 @@ void   ctl__smd_manage_resp__free_unpacked
   assert(message->base.descriptor == &ctl__smd_manage_resp__descriptor);
   protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
 }
+void   ctl__bio_trim_req__init
+                     (Ctl__BioTrimReq         *message)
+{
+  static const Ctl__BioTrimReq init_value = CTL__BIO_TRIM_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__bio_trim_req__get_packed_size
+                     (const Ctl__BioTrimReq *message)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__bio_trim_req__pack
+                     (const Ctl__BioTrimReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__bio_trim_req__pack_to_buffer
+                     (const Ctl__BioTrimReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__BioTrimReq *
+       ctl__bio_trim_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__BioTrimReq *)
+     protobuf_c_message_unpack (&ctl__bio_trim_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__bio_trim_req__free_unpacked
+                     (Ctl__BioTrimReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__bio_trim_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__bio_trim_resp__device__init
+                     (Ctl__BioTrimResp__Device         *message)
+{
+  static const Ctl__BioTrimResp__Device init_value = CTL__BIO_TRIM_RESP__DEVICE__INIT;
+  *message = init_value;
+}
+void   ctl__bio_trim_resp__init
+                     (Ctl__BioTrimResp         *message)
+{
+  static const Ctl__BioTrimResp init_value = CTL__BIO_TRIM_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__bio_trim_resp__get_packed_size
+                     (const Ctl__BioTrimResp *message)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__bio_trim_resp__pack
+                     (const Ctl__BioTrimResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__bio_trim_resp__pack_to_buffer
+                     (const Ctl__BioTrimResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__bio_trim_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__BioTrimResp *
+       ctl__bio_trim_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__BioTrimResp *)
+     protobuf_c_message_unpack (&ctl__bio_trim_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__bio_trim_resp__free_unpacked
+                     (Ctl__BioTrimResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__bio_trim_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__bio_iostats_req__init
+                     (Ctl__BioIOStatsReq         *message)
+{
+  static const Ctl__BioIOStatsReq init_value = CTL__BIO_IOSTATS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__bio_iostats_req__get_packed_size
+                     (const Ctl__BioIOStatsReq *message)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__bio_iostats_req__pack
+                     (const Ctl__BioIOStatsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__bio_iostats_req__pack_to_buffer
+                     (const Ctl__BioIOStatsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__BioIOStatsReq *
+       ctl__bio_iostats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__BioIOStatsReq *)
+     protobuf_c_message_unpack (&ctl__bio_iostats_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__bio_iostats_req__free_unpacked
+                     (Ctl__BioIOStatsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__bio_iostats_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__bio_iostats_resp__init
+                     (Ctl__BioIOStatsResp         *message)
+{
+  static const Ctl__BioIOStatsResp init_value = CTL__BIO_IOSTATS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__bio_iostats_resp__get_packed_size
+                     (const Ctl__BioIOStatsResp *message)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__bio_iostats_resp__pack
+                     (const Ctl__BioIOStatsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__bio_iostats_resp__pack_to_buffer
+                     (const Ctl__BioIOStatsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__bio_iostats_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__BioIOStatsResp *
+       ctl__bio_iostats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__BioIOStatsResp *)
+     protobuf_c_message_unpack (&ctl__bio_iostats_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__bio_iostats_resp__free_unpacked
+                     (Ctl__BioIOStatsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__bio_iostats_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__device_iostats__init
+                     (Ctl__DeviceIOStats         *message)
+{
+  static const Ctl__DeviceIOStats init_value = CTL__DEVICE_IOSTATS__INIT;
+  *message = init_value;
+}
+size_t ctl__device_iostats__get_packed_size
+                     (const Ctl__DeviceIOStats *message)
+{
+  assert(message->base.descriptor == &ctl__device_iostats__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__device_iostats__pack
+                     (const Ctl__DeviceIOStats *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__device_iostats__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__device_iostats__pack_to_buffer
+                     (const Ctl__DeviceIOStats *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__device_iostats__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__DeviceIOStats *
+       ctl__device_iostats__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__DeviceIOStats *)
+     protobuf_c_message_unpack (&ctl__device_iostats__descriptor,
+                                allocator, len, data);
+}
+void   ctl__device_iostats__free_unpacked
+                     (Ctl__DeviceIOStats *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__device_iostats__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
 static const ProtobufCFieldDescriptor ctl__bio_health_req__field_descriptors[3] =
 {
   {
@@ -1683,7 +1914,7 @@ const ProtobufCMessageDescriptor ctl__nvme_controller__descriptor =
   (ProtobufCMessageInit) ctl__nvme_controller__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
-static const ProtobufCFieldDescriptor ctl__smd_device__field_descriptors[14] =
+static const ProtobufCFieldDescriptor ctl__smd_device__field_descriptors[15] =
 {
   {
     "uuid",
@@ -1853,12 +2084,25 @@ static const ProtobufCFieldDescriptor ctl__smd_device__field_descriptors[14] =
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+  {
+    "io_stats",
+    18,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_MESSAGE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SmdDevice, io_stats),
+    &ctl__device_iostats__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
 };
 static const unsigned ctl__smd_device__field_indices_by_name[] = {
   3,   /* field[3] = avail_bytes */
   4,   /* field[4] = cluster_size */
   12,   /* field[12] = ctrlr */
   13,   /* field[13] = ctrlr_namespace_id */
+  14,   /* field[14] = io_stats */
   7,   /* field[7] = meta_size */
   8,   /* field[8] = meta_wal_size */
   5,   /* field[5] = rank */
@@ -1874,7 +2118,7 @@ static const ProtobufCIntRange ctl__smd_device__number_ranges[2 + 1] =
 {
   { 1, 0 },
   { 6, 2 },
-  { 0, 14 }
+  { 0, 15 }
 };
 const ProtobufCMessageDescriptor ctl__smd_device__descriptor =
 {
@@ -1884,7 +2128,7 @@ const ProtobufCMessageDescriptor ctl__smd_device__descriptor =
   "Ctl__SmdDevice",
   "ctl",
   sizeof(Ctl__SmdDevice),
-  14,
+  15,
   ctl__smd_device__field_descriptors,
   ctl__smd_device__field_indices_by_name,
   2,  ctl__smd_device__number_ranges,
@@ -2093,7 +2337,7 @@ const ProtobufCMessageDescriptor ctl__smd_pool_resp__descriptor =
   (ProtobufCMessageInit) ctl__smd_pool_resp__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
-static const ProtobufCFieldDescriptor ctl__smd_query_req__field_descriptors[5] =
+static const ProtobufCFieldDescriptor ctl__smd_query_req__field_descriptors[7] =
 {
   {
     "omit_devices",
@@ -2155,9 +2399,35 @@ static const ProtobufCFieldDescriptor ctl__smd_query_req__field_descriptors[5] =
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+  {
+    "include_io_stats",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SmdQueryReq, include_io_stats),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "io_stats_period_ms",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SmdQueryReq, io_stats_period_ms),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
 };
 static const unsigned ctl__smd_query_req__field_indices_by_name[] = {
   2,   /* field[2] = include_bio_health */
+  5,   /* field[5] = include_io_stats */
+  6,   /* field[6] = io_stats_period_ms */
   0,   /* field[0] = omit_devices */
   1,   /* field[1] = omit_pools */
   4,   /* field[4] = rank */
@@ -2166,7 +2436,7 @@ static const unsigned ctl__smd_query_req__field_indices_by_name[] = {
 static const ProtobufCIntRange ctl__smd_query_req__number_ranges[1 + 1] =
 {
   { 1, 0 },
-  { 0, 5 }
+  { 0, 7 }
 };
 const ProtobufCMessageDescriptor ctl__smd_query_req__descriptor =
 {
@@ -2176,7 +2446,7 @@ const ProtobufCMessageDescriptor ctl__smd_query_req__descriptor =
   "Ctl__SmdQueryReq",
   "ctl",
   sizeof(Ctl__SmdQueryReq),
-  5,
+  7,
   ctl__smd_query_req__field_descriptors,
   ctl__smd_query_req__field_indices_by_name,
   1,  ctl__smd_query_req__number_ranges,
@@ -2797,6 +3067,598 @@ const ProtobufCMessageDescriptor ctl__smd_manage_resp__descriptor =
   (ProtobufCMessageInit) ctl__smd_manage_resp__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
+static const ProtobufCFieldDescriptor ctl__bio_trim_req__field_descriptors[1] =
+{
+  {
+    "max_blocks",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioTrimReq, max_blocks),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__bio_trim_req__field_indices_by_name[] = {
+  0,   /* field[0] = max_blocks */
+};
+static const ProtobufCIntRange ctl__bio_trim_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__bio_trim_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.BioTrimReq",
+  "BioTrimReq",
+  "Ctl__BioTrimReq",
+  "ctl",
+  sizeof(Ctl__BioTrimReq),
+  1,
+  ctl__bio_trim_req__field_descriptors,
+  ctl__bio_trim_req__field_indices_by_name,
+  1,  ctl__bio_trim_req__number_ranges,
+  (ProtobufCMessageInit) ctl__bio_trim_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__bio_trim_resp__device__field_descriptors[3] =
+{
+  {
+    "uuid",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioTrimResp__Device, uuid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "trimmed_blocks",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioTrimResp__Device, trimmed_blocks),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "block_size",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioTrimResp__Device, block_size),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__bio_trim_resp__device__field_indices_by_name[] = {
+  2,   /* field[2] = block_size */
+  1,   /* field[1] = trimmed_blocks */
+  0,   /* field[0] = uuid */
+};
+static const ProtobufCIntRange ctl__bio_trim_resp__device__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 3 }
+};
+const ProtobufCMessageDescriptor ctl__bio_trim_resp__device__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.BioTrimResp.Device",
+  "Device",
+  "Ctl__BioTrimResp__Device",
+  "ctl",
+  sizeof(Ctl__BioTrimResp__Device),
+  3,
+  ctl__bio_trim_resp__device__field_descriptors,
+  ctl__bio_trim_resp__device__field_indices_by_name,
+  1,  ctl__bio_trim_resp__device__number_ranges,
+  (ProtobufCMessageInit) ctl__bio_trim_resp__device__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__bio_trim_resp__field_descriptors[2] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioTrimResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "devices",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__BioTrimResp, n_devices),
+    offsetof(Ctl__BioTrimResp, devices),
+    &ctl__bio_trim_resp__device__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__bio_trim_resp__field_indices_by_name[] = {
+  1,   /* field[1] = devices */
+  0,   /* field[0] = status */
+};
+static const ProtobufCIntRange ctl__bio_trim_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__bio_trim_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.BioTrimResp",
+  "BioTrimResp",
+  "Ctl__BioTrimResp",
+  "ctl",
+  sizeof(Ctl__BioTrimResp),
+  2,
+  ctl__bio_trim_resp__field_descriptors,
+  ctl__bio_trim_resp__field_indices_by_name,
+  1,  ctl__bio_trim_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__bio_trim_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__bio_iostats_req__field_descriptors[1] =
+{
+  {
+    "dev_uuid",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsReq, dev_uuid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__bio_iostats_req__field_indices_by_name[] = {
+  0,   /* field[0] = dev_uuid */
+};
+static const ProtobufCIntRange ctl__bio_iostats_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__bio_iostats_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.BioIOStatsReq",
+  "BioIOStatsReq",
+  "Ctl__BioIOStatsReq",
+  "ctl",
+  sizeof(Ctl__BioIOStatsReq),
+  1,
+  ctl__bio_iostats_req__field_descriptors,
+  ctl__bio_iostats_req__field_indices_by_name,
+  1,  ctl__bio_iostats_req__number_ranges,
+  (ProtobufCMessageInit) ctl__bio_iostats_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__bio_iostats_resp__field_descriptors[15] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "dev_uuid",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, dev_uuid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "timestamp",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, timestamp),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_ops",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, read_ops),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_ops",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, write_ops),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "unmap_ops",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, unmap_ops),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_bytes",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, read_bytes),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_bytes",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, write_bytes),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "queue_depth",
+    9,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, queue_depth),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p50",
+    10,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, read_lat_p50),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p99",
+    11,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, read_lat_p99),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p999",
+    12,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, read_lat_p999),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p50",
+    13,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, write_lat_p50),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p99",
+    14,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, write_lat_p99),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p999",
+    15,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__BioIOStatsResp, write_lat_p999),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__bio_iostats_resp__field_indices_by_name[] = {
+  1,   /* field[1] = dev_uuid */
+  8,   /* field[8] = queue_depth */
+  6,   /* field[6] = read_bytes */
+  9,   /* field[9] = read_lat_p50 */
+  10,   /* field[10] = read_lat_p99 */
+  11,   /* field[11] = read_lat_p999 */
+  3,   /* field[3] = read_ops */
+  0,   /* field[0] = status */
+  2,   /* field[2] = timestamp */
+  5,   /* field[5] = unmap_ops */
+  7,   /* field[7] = write_bytes */
+  12,   /* field[12] = write_lat_p50 */
+  13,   /* field[13] = write_lat_p99 */
+  14,   /* field[14] = write_lat_p999 */
+  4,   /* field[4] = write_ops */
+};
+static const ProtobufCIntRange ctl__bio_iostats_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 15 }
+};
+const ProtobufCMessageDescriptor ctl__bio_iostats_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.BioIOStatsResp",
+  "BioIOStatsResp",
+  "Ctl__BioIOStatsResp",
+  "ctl",
+  sizeof(Ctl__BioIOStatsResp),
+  15,
+  ctl__bio_iostats_resp__field_descriptors,
+  ctl__bio_iostats_resp__field_indices_by_name,
+  1,  ctl__bio_iostats_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__bio_iostats_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__device_iostats__field_descriptors[12] =
+{
+  {
+    "period_ms",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, period_ms),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_iops",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_DOUBLE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, read_iops),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_iops",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_DOUBLE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, write_iops),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_bw",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_DOUBLE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, read_bw),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_bw",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_DOUBLE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, write_bw),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "queue_depth",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, queue_depth),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p50",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, read_lat_p50),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p99",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, read_lat_p99),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "read_lat_p999",
+    9,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, read_lat_p999),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p50",
+    10,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, write_lat_p50),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p99",
+    11,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, write_lat_p99),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "write_lat_p999",
+    12,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__DeviceIOStats, write_lat_p999),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__device_iostats__field_indices_by_name[] = {
+  0,   /* field[0] = period_ms */
+  5,   /* field[5] = queue_depth */
+  3,   /* field[3] = read_bw */
+  1,   /* field[1] = read_iops */
+  6,   /* field[6] = read_lat_p50 */
+  7,   /* field[7] = read_lat_p99 */
+  8,   /* field[8] = read_lat_p999 */
+  4,   /* field[4] = write_bw */
+  2,   /* field[2] = write_iops */
+  9,   /* field[9] = write_lat_p50 */
+  10,   /* field[10] = write_lat_p99 */
+  11,   /* field[11] = write_lat_p999 */
+};
+static const ProtobufCIntRange ctl__device_iostats__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 12 }
+};
+const ProtobufCMessageDescriptor ctl__device_iostats__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.DeviceIOStats",
+  "DeviceIOStats",
+  "Ctl__DeviceIOStats",
+  "ctl",
+  sizeof(Ctl__DeviceIOStats),
+  12,
+  ctl__device_iostats__field_descriptors,
+  ctl__device_iostats__field_indices_by_name,
+  1,  ctl__device_iostats__number_ranges,
+  (ProtobufCMessageInit) ctl__device_iostats__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
 static const ProtobufCEnumValue ctl__nvme_dev_state__enum_values_by_number[5] =
 {
   { "UNKNOWN", "CTL__NVME_DEV_STATE__UNKNOWN", 0 },
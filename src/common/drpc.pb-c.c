@@ -97,7 +97,97 @@ void   drpc__response__free_unpacked
   assert(message->base.descriptor == &drpc__response__descriptor);
   protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
 }
-static const ProtobufCFieldDescriptor drpc__call__field_descriptors[4] =
+void   drpc__call_batch__init
+                     (Drpc__CallBatch         *message)
+{
+  static const Drpc__CallBatch init_value = DRPC__CALL_BATCH__INIT;
+  *message = init_value;
+}
+size_t drpc__call_batch__get_packed_size
+                     (const Drpc__CallBatch *message)
+{
+  assert(message->base.descriptor == &drpc__call_batch__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t drpc__call_batch__pack
+                     (const Drpc__CallBatch *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &drpc__call_batch__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t drpc__call_batch__pack_to_buffer
+                     (const Drpc__CallBatch *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &drpc__call_batch__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Drpc__CallBatch *
+       drpc__call_batch__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Drpc__CallBatch *)
+     protobuf_c_message_unpack (&drpc__call_batch__descriptor,
+                                allocator, len, data);
+}
+void   drpc__call_batch__free_unpacked
+                     (Drpc__CallBatch *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &drpc__call_batch__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   drpc__response_batch__init
+                     (Drpc__ResponseBatch         *message)
+{
+  static const Drpc__ResponseBatch init_value = DRPC__RESPONSE_BATCH__INIT;
+  *message = init_value;
+}
+size_t drpc__response_batch__get_packed_size
+                     (const Drpc__ResponseBatch *message)
+{
+  assert(message->base.descriptor == &drpc__response_batch__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t drpc__response_batch__pack
+                     (const Drpc__ResponseBatch *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &drpc__response_batch__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t drpc__response_batch__pack_to_buffer
+                     (const Drpc__ResponseBatch *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &drpc__response_batch__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Drpc__ResponseBatch *
+       drpc__response_batch__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Drpc__ResponseBatch *)
+     protobuf_c_message_unpack (&drpc__response_batch__descriptor,
+                                allocator, len, data);
+}
+void   drpc__response_batch__free_unpacked
+                     (Drpc__ResponseBatch *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &drpc__response_batch__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+static const ProtobufCFieldDescriptor drpc__call__field_descriptors[5] =
 {
   {
     "module",
@@ -147,17 +237,30 @@ static const ProtobufCFieldDescriptor drpc__call__field_descriptors[4] =
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+  {
+    "traceparent",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Drpc__Call, traceparent),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
 };
 static const unsigned drpc__call__field_indices_by_name[] = {
   3,   /* field[3] = body */
   1,   /* field[1] = method */
   0,   /* field[0] = module */
   2,   /* field[2] = sequence */
+  4,   /* field[4] = traceparent */
 };
 static const ProtobufCIntRange drpc__call__number_ranges[1 + 1] =
 {
   { 1, 0 },
-  { 0, 4 }
+  { 0, 5 }
 };
 const ProtobufCMessageDescriptor drpc__call__descriptor =
 {
@@ -167,7 +270,7 @@ const ProtobufCMessageDescriptor drpc__call__descriptor =
   "Drpc__Call",
   "drpc",
   sizeof(Drpc__Call),
-  4,
+  5,
   drpc__call__field_descriptors,
   drpc__call__field_indices_by_name,
   1,  drpc__call__number_ranges,
@@ -238,6 +341,82 @@ const ProtobufCMessageDescriptor drpc__response__descriptor =
   (ProtobufCMessageInit) drpc__response__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
+static const ProtobufCFieldDescriptor drpc__call_batch__field_descriptors[1] =
+{
+  {
+    "calls",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Drpc__CallBatch, n_calls),
+    offsetof(Drpc__CallBatch, calls),
+    &drpc__call__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned drpc__call_batch__field_indices_by_name[] = {
+  0,   /* field[0] = calls */
+};
+static const ProtobufCIntRange drpc__call_batch__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor drpc__call_batch__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "drpc.CallBatch",
+  "CallBatch",
+  "Drpc__CallBatch",
+  "drpc",
+  sizeof(Drpc__CallBatch),
+  1,
+  drpc__call_batch__field_descriptors,
+  drpc__call_batch__field_indices_by_name,
+  1,  drpc__call_batch__number_ranges,
+  (ProtobufCMessageInit) drpc__call_batch__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor drpc__response_batch__field_descriptors[1] =
+{
+  {
+    "responses",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Drpc__ResponseBatch, n_responses),
+    offsetof(Drpc__ResponseBatch, responses),
+    &drpc__response__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned drpc__response_batch__field_indices_by_name[] = {
+  0,   /* field[0] = responses */
+};
+static const ProtobufCIntRange drpc__response_batch__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor drpc__response_batch__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "drpc.ResponseBatch",
+  "ResponseBatch",
+  "Drpc__ResponseBatch",
+  "drpc",
+  sizeof(Drpc__ResponseBatch),
+  1,
+  drpc__response_batch__field_descriptors,
+  drpc__response_batch__field_indices_by_name,
+  1,  drpc__response_batch__number_ranges,
+  (ProtobufCMessageInit) drpc__response_batch__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
 static const ProtobufCEnumValue drpc__status__enum_values_by_number[8] =
 {
   { "SUCCESS", "DRPC__STATUS__SUCCESS", 0 },
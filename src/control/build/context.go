@@ -8,6 +8,7 @@ package build
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/metadata"
@@ -18,6 +19,9 @@ const (
 	DaosComponentHeader = "x-daos-component"
 	// DaosVersionHeader defines the header name used to convey the component version.
 	DaosVersionHeader = "x-daos-version"
+	// DaosFeaturesHeader defines the header name used to convey the optional
+	// features supported by the component, as a comma-separated list.
+	DaosFeaturesHeader = "x-daos-features"
 )
 
 // FromContext returns a versioned component obtained from the context.
@@ -30,6 +34,13 @@ func FromContext(ctx context.Context) (*VersionedComponent, error) {
 	if !hasMD {
 		return nil, ErrNoCtxMetadata
 	}
+
+	return FromMetadata(md)
+}
+
+// FromMetadata returns a versioned component obtained from the metadata, e.g.
+// the headers of an RPC response.
+func FromMetadata(md metadata.MD) (*VersionedComponent, error) {
 	compName, hasName := md[DaosComponentHeader]
 	if !hasName {
 		return nil, ErrNoCtxMetadata
@@ -63,3 +74,47 @@ func ToContext(parent context.Context, comp Component, verStr string) (context.C
 		DaosVersionHeader, version.String(),
 	), nil
 }
+
+// FeaturesFromMetadata returns the features advertised in the metadata. The
+// returned flag is false if the metadata contains no feature list, which
+// indicates that the peer predates feature negotiation.
+func FeaturesFromMetadata(md metadata.MD) ([]Feature, bool) {
+	vals := md.Get(DaosFeaturesHeader)
+	if len(vals) == 0 {
+		return nil, false
+	}
+
+	var features []Feature
+	for _, val := range vals {
+		for _, name := range strings.Split(val, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				features = append(features, Feature(name))
+			}
+		}
+	}
+	return features, true
+}
+
+// FeaturesFromContext returns the features advertised in the incoming context.
+func FeaturesFromContext(ctx context.Context) ([]Feature, bool) {
+	md, hasMD := metadata.FromIncomingContext(ctx)
+	if !hasMD {
+		return nil, false
+	}
+	return FeaturesFromMetadata(md)
+}
+
+// FeaturesToContext adds the list of supported features to the outgoing context.
+func FeaturesToContext(parent context.Context, features []Feature) context.Context {
+	return metadata.AppendToOutgoingContext(parent, DaosFeaturesHeader, joinFeatures(features))
+}
+
+// ComponentMetadata returns metadata conveying the component, its version and
+// its supported features, for use as RPC response headers.
+func ComponentMetadata(comp Component, version Version, features []Feature) metadata.MD {
+	return metadata.Pairs(
+		DaosComponentHeader, comp.String(),
+		DaosVersionHeader, version.String(),
+		DaosFeaturesHeader, joinFeatures(features),
+	)
+}
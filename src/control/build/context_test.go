@@ -116,3 +116,66 @@ func TestBuild_ToContext(t *testing.T) {
 		})
 	}
 }
+
+func TestBuild_FeaturesFromMetadata(t *testing.T) {
+	for name, tc := range map[string]struct {
+		md            metadata.MD
+		expFeatures   []Feature
+		expAdvertised bool
+	}{
+		"no features header": {
+			md: metadata.Pairs(DaosVersionHeader, "2.6.0"),
+		},
+		"empty feature list": {
+			md:            metadata.Pairs(DaosFeaturesHeader, ""),
+			expAdvertised: true,
+		},
+		"features": {
+			md:            metadata.Pairs(DaosFeaturesHeader, "system_cleanup, job_stats"),
+			expFeatures:   []Feature{FeatureSystemCleanup, FeatureJobStats},
+			expAdvertised: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotFeatures, gotAdvertised := FeaturesFromMetadata(tc.md)
+
+			test.AssertEqual(t, tc.expAdvertised, gotAdvertised, "unexpected advertised flag")
+			if diff := cmp.Diff(tc.expFeatures, gotFeatures); diff != "" {
+				t.Fatalf("unexpected features (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuild_FeaturesToContext(t *testing.T) {
+	ctx := FeaturesToContext(test.Context(t), []Feature{FeatureSystemCleanup, FeatureJobStats})
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		t.Fatal("metadata.FromOutgoingContext failed")
+	}
+	gotFeatures, advertised := FeaturesFromContext(metadata.NewIncomingContext(test.Context(t), md))
+	test.AssertTrue(t, advertised, "features not advertised")
+	if diff := cmp.Diff([]Feature{FeatureSystemCleanup, FeatureJobStats}, gotFeatures); diff != "" {
+		t.Fatalf("unexpected features (-want, +got):\n%s", diff)
+	}
+
+	_, advertised = FeaturesFromContext(test.Context(t))
+	test.AssertFalse(t, advertised, "unexpected features in context without metadata")
+}
+
+func TestBuild_ComponentMetadata(t *testing.T) {
+	md := ComponentMetadata(ComponentServer, MustNewVersion("2.6.1"), []Feature{FeatureJobStats})
+
+	gotComp, err := FromMetadata(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, "server:2.6.1", gotComp.String(), "unexpected component")
+
+	gotFeatures, advertised := FeaturesFromMetadata(md)
+	test.AssertTrue(t, advertised, "features not advertised")
+	if diff := cmp.Diff([]Feature{FeatureJobStats}, gotFeatures); diff != "" {
+		t.Fatalf("unexpected features (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,85 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package build
+
+import "strings"
+
+// Feature is an optional capability of the management API. Components
+// within the supported compatibility window may differ in the set of
+// features they support, so features are advertised by the server and
+// should be checked by the caller before relying on them.
+type Feature string
+
+func (f Feature) String() string {
+	return string(f)
+}
+
+const (
+	// FeatureSystemCleanup indicates that the server supports revoking
+	// the pool handles held by a client machine.
+	FeatureSystemCleanup Feature = "system_cleanup"
+	// FeatureJobStats indicates that the server supports queries of the
+	// per-job I/O statistics aggregated by the MS leader.
+	FeatureJobStats Feature = "job_stats"
+)
+
+// ServerFeatures returns the optional management API features supported
+// by this version of the server.
+func ServerFeatures() []Feature {
+	return []Feature{
+		FeatureSystemCleanup,
+		FeatureJobStats,
+	}
+}
+
+// methodFeatures maps the management API methods that implement optional
+// features to the feature that the server must support.
+var methodFeatures = map[string]Feature{
+	"/mgmt.MgmtSvc/SystemCleanup": FeatureSystemCleanup,
+	"/mgmt.MgmtSvc/JobStatsQuery": FeatureJobStats,
+}
+
+// MethodFeature returns the optional feature implemented by the gRPC method,
+// if any.
+func MethodFeature(method string) (Feature, bool) {
+	f, found := methodFeatures[method]
+	return f, found
+}
+
+// RequiredMemberFeatures returns the features that every server must support
+// in order to join the system, as the MS relies on them being available on
+// all members. A joining server that advertises its features but lacks one of
+// these is refused, even if its version is within the compatibility window.
+func RequiredMemberFeatures() []Feature {
+	// Add features here once the MS depends on every member supporting
+	// them, e.g. a feature that changes how engines handle group updates.
+	return []Feature{}
+}
+
+// MissingFeatures returns the features in required that are not in supported.
+func MissingFeatures(supported, required []Feature) []Feature {
+	have := make(map[Feature]struct{}, len(supported))
+	for _, f := range supported {
+		have[f] = struct{}{}
+	}
+
+	var missing []Feature
+	for _, f := range required {
+		if _, found := have[f]; !found {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+func joinFeatures(features []Feature) string {
+	strs := make([]string, len(features))
+	for i, f := range features {
+		strs[i] = f.String()
+	}
+	return strings.Join(strs, ",")
+}
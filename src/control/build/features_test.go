@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package build
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestBuild_MethodFeature(t *testing.T) {
+	f, found := MethodFeature("/mgmt.MgmtSvc/JobStatsQuery")
+	test.AssertTrue(t, found, "feature not found")
+	test.AssertEqual(t, FeatureJobStats, f, "unexpected feature")
+
+	_, found = MethodFeature("/mgmt.MgmtSvc/SystemQuery")
+	test.AssertFalse(t, found, "unexpected feature for baseline method")
+
+	// Every method feature must be advertised by this server version.
+	var methodFeats []Feature
+	for _, f := range methodFeatures {
+		methodFeats = append(methodFeats, f)
+	}
+	test.AssertEqual(t, 0, len(MissingFeatures(ServerFeatures(), methodFeats)),
+		"method feature not advertised by server")
+}
+
+func TestBuild_MissingFeatures(t *testing.T) {
+	for name, tc := range map[string]struct {
+		supported  []Feature
+		required   []Feature
+		expMissing []Feature
+	}{
+		"none required": {
+			supported: []Feature{FeatureJobStats},
+		},
+		"all supported": {
+			supported: []Feature{FeatureJobStats, FeatureSystemCleanup},
+			required:  []Feature{FeatureSystemCleanup},
+		},
+		"missing": {
+			supported:  []Feature{FeatureJobStats},
+			required:   []Feature{FeatureSystemCleanup, FeatureJobStats},
+			expMissing: []Feature{FeatureSystemCleanup},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotMissing := MissingFeatures(tc.supported, tc.required)
+			if diff := cmp.Diff(tc.expMissing, gotMissing); diff != "" {
+				t.Fatalf("unexpected missing features (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	// This server version must satisfy its own membership requirements.
+	test.AssertEqual(t, 0, len(MissingFeatures(ServerFeatures(), RequiredMemberFeatures())), "server lacks member features")
+}
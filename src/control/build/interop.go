@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -96,6 +96,11 @@ func (rule *InteropRule) Matches(self, other *VersionedComponent) bool {
 	return true
 }
 
+func withinMinorDelta(self, other *VersionedComponent) bool {
+	return (self.Version.MajorDelta(other.Version) == 0 &&
+		self.Version.MinorDelta(other.Version) <= MaxMinorDelta)
+}
+
 // defaultRules are a set of default rules which should apply regardless
 // of release or caller.
 var defaultRules = []*InteropRule{
@@ -109,10 +114,16 @@ var defaultRules = []*InteropRule{
 		Other:         ComponentAgent,
 		Description:   "server and agent must be within 2 minor versions",
 		StopOnSuccess: true,
-		Check: func(self, other *VersionedComponent) bool {
-			return (self.Version.MajorDelta(other.Version) == 0 &&
-				self.Version.MinorDelta(other.Version) <= MaxMinorDelta)
-		},
+		Check:         withinMinorDelta,
+	},
+	// The same window applies when an agent checks the version reported
+	// by the management service.
+	{
+		Self:          ComponentAgent,
+		Other:         ComponentServer,
+		Description:   "agent and server must be within 2 minor versions",
+		StopOnSuccess: true,
+		Check:         withinMinorDelta,
 	},
 	// Should never happen, but just in case. We know that releases
 	// prior to 2.0.0 will never be compatible with 2.0.0 or above.
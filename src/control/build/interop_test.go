@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -106,6 +106,19 @@ func TestBuild_CheckCompatibility(t *testing.T) {
 			b:      testComponent(t, "agent", "2.6.0"),
 			expErr: errors.New("incompatible components"),
 		},
+		"2.6 agent compatible with 2.4 server": {
+			a: testComponent(t, "agent", "2.6.0"),
+			b: testComponent(t, "server", "2.4.0"),
+		},
+		"2.4 agent compatible with 2.6 server": {
+			a: testComponent(t, "agent", "2.4.0"),
+			b: testComponent(t, "server", "2.6.0"),
+		},
+		"2.8 agent not compatible with 2.4 server": {
+			a:      testComponent(t, "agent", "2.8.0"),
+			b:      testComponent(t, "server", "2.4.0"),
+			expErr: errors.New("incompatible components"),
+		},
 		"3.4 server not compatible with 2.2 agent": {
 			a:      testComponent(t, "server", "3.4.0"),
 			b:      testComponent(t, "agent", "2.2.0"),
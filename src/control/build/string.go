@@ -39,21 +39,24 @@ func String(name string) string {
 	return fmt.Sprintf("%s version %s", name, revString(DaosVersion))
 }
 
+// BinaryInfo is the structured representation of the binary build info.
+type BinaryInfo struct {
+	Name      string    `json:"name"`
+	Version   string    `json:"version"`
+	Revision  string    `json:"revision,omitempty"`
+	Dirty     bool      `json:"dirty,omitempty"`
+	Release   bool      `json:"release,omitempty"`
+	BuildHost string    `json:"build_host,omitempty"`
+	BuildTime time.Time `json:"build_time,omitempty"`
+}
+
 // MarshalJSON returns a JSON string containing a structured representation of
 // the binary build info.
 func MarshalJSON(name string) ([]byte, error) {
 	// Not a fatal error if the build time can't be parsed.
 	buildTime, _ := time.Parse(time.RFC3339, BuildTime)
 
-	return json.Marshal(&struct {
-		Name      string    `json:"name"`
-		Version   string    `json:"version"`
-		Revision  string    `json:"revision,omitempty"`
-		Dirty     bool      `json:"dirty,omitempty"`
-		Release   bool      `json:"release,omitempty"`
-		BuildHost string    `json:"build_host,omitempty"`
-		BuildTime time.Time `json:"build_time,omitempty"`
-	}{
+	return json.Marshal(&BinaryInfo{
 		Name:      name,
 		Version:   DaosVersion,
 		Revision:  Revision,
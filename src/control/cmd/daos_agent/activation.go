@@ -0,0 +1,156 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/systemd"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// minIdleCheckInterval limits how often the agent checks whether it
+	// has been idle for long enough to exit.
+	minIdleCheckInterval = time.Second
+)
+
+type listenFilesFn func() ([]*os.File, error)
+
+// sameSocket returns true if the paths refer to the same socket file, e.g.
+// because one of them is reached through a symlink such as /var/run.
+func sameSocket(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
+
+// useActivatedListeners sets the listeners of the dRPC servers whose sockets
+// were passed to the agent through socket activation, and returns true if the
+// agent was socket-activated. The servers of any other sockets create their
+// sockets when they are started. Passed sockets that don't match a server are
+// closed.
+func useActivatedListeners(log logging.Logger, listenFiles listenFilesFn, servers []*drpc.DomainSocketServer) (bool, error) {
+	files, err := listenFiles()
+	if err != nil {
+		return false, errors.Wrap(err, "socket activation")
+	}
+	if len(files) == 0 {
+		return false, nil
+	}
+
+	listeners := make([]net.Listener, 0, len(files))
+	defer func() {
+		for _, lis := range listeners {
+			if lis != nil {
+				lis.Close()
+			}
+		}
+	}()
+	for _, f := range files {
+		lis, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			return false, errors.Wrapf(err, "socket-activated fd %s", f.Name())
+		}
+		listeners = append(listeners, lis)
+		if lis.Addr().Network() != "unixpacket" {
+			return false, errors.Errorf("socket-activated fd %s is a %s socket; "+
+				"use ListenSequentialPacket= in the socket unit", f.Name(), lis.Addr().Network())
+		}
+	}
+
+	for _, srv := range servers {
+		for i, lis := range listeners {
+			if lis == nil || !sameSocket(srv.SocketPath(), lis.Addr().String()) {
+				continue
+			}
+			log.Debugf("using socket-activated listener for %s", srv.SocketPath())
+			srv.SetListener(lis)
+			listeners[i] = nil
+			break
+		}
+	}
+
+	for _, lis := range listeners {
+		if lis != nil {
+			log.Noticef("ignoring socket-activated listener on %s that is not a configured dRPC socket",
+				lis.Addr())
+		}
+	}
+
+	return true, nil
+}
+
+// activatedListeners passes the agent's sockets inherited through systemd
+// socket activation to the dRPC servers.
+func activatedListeners(log logging.Logger, servers []*drpc.DomainSocketServer) (bool, error) {
+	return useActivatedListeners(log, systemd.ListenFiles, servers)
+}
+
+// idleCheckInterval returns the interval at which to check for idleness, so
+// that the agent exits within a tenth of the timeout of becoming idle.
+func idleCheckInterval(timeout time.Duration) time.Duration {
+	if interval := timeout / 10; interval > minIdleCheckInterval {
+		return interval
+	}
+	return minIdleCheckInterval
+}
+
+// agentIdleTime returns the time for which no client has been connected to
+// any of the dRPC servers, or zero if the agent is monitoring client processes
+// with open pool handles, as it must remain running to clean up the handles
+// of processes that exit without closing them.
+func agentIdleTime(servers []*drpc.DomainSocketServer, procmon *procMon) time.Duration {
+	if procmon.monitoredProcs() > 0 {
+		return 0
+	}
+
+	var idle time.Duration
+	for i, srv := range servers {
+		srvIdle := srv.IdleTime()
+		if i == 0 || srvIdle < idle {
+			idle = srvIdle
+		}
+	}
+	return idle
+}
+
+// monitorIdle closes the idle channel once the agent has been idle for the
+// timeout.
+func monitorIdle(ctx context.Context, log logging.Logger, timeout time.Duration, servers []*drpc.DomainSocketServer, procmon *procMon, idle chan<- struct{}) {
+	ticker := time.NewTicker(idleCheckInterval(timeout))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if idleTime := agentIdleTime(servers, procmon); idleTime >= timeout {
+				log.Debugf("agent idle for %s", idleTime.Truncate(time.Second))
+				close(idle)
+				return
+			}
+		}
+	}
+}
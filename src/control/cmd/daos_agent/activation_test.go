@@ -0,0 +1,154 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// listenerFile returns a file for a new listening socket, as would be passed
+// by socket activation.
+func listenerFile(t *testing.T, network, path string) *os.File {
+	t.Helper()
+
+	lis, err := net.ListenUnix(network, &net.UnixAddr{Name: path, Net: network})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Leave the socket file in place when the listener is closed, as the
+	// service manager does.
+	lis.SetUnlinkOnClose(false)
+	defer lis.Close()
+
+	f, err := lis.File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestAgent_useActivatedListeners(t *testing.T) {
+	for name, tc := range map[string]struct {
+		listenFiles  func(t *testing.T, dir string) ([]*os.File, error)
+		expActivated bool
+		expInherited []string
+		expErr       error
+	}{
+		"not activated": {
+			listenFiles: func(*testing.T, string) ([]*os.File, error) {
+				return nil, nil
+			},
+		},
+		"activation error": {
+			listenFiles: func(*testing.T, string) ([]*os.File, error) {
+				return nil, errors.New("invalid LISTEN_FDS")
+			},
+			expErr: errors.New("socket activation: invalid LISTEN_FDS"),
+		},
+		"stream socket": {
+			listenFiles: func(t *testing.T, dir string) ([]*os.File, error) {
+				return []*os.File{listenerFile(t, "unix", filepath.Join(dir, "agent.sock"))}, nil
+			},
+			expErr: errors.New("use ListenSequentialPacket="),
+		},
+		"subset of sockets activated": {
+			listenFiles: func(t *testing.T, dir string) ([]*os.File, error) {
+				return []*os.File{
+					listenerFile(t, "unixpacket", filepath.Join(dir, "agent.sock")),
+					listenerFile(t, "unixpacket", filepath.Join(dir, "other.sock")),
+				}, nil
+			},
+			expActivated: true,
+			expInherited: []string{"agent.sock"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			var servers []*drpc.DomainSocketServer
+			for _, name := range []string{"agent.sock", "cred.sock"} {
+				srv, err := drpc.NewDomainSocketServer(log, filepath.Join(tmpDir, name), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+				servers = append(servers, srv)
+			}
+
+			activated, err := useActivatedListeners(log, func() ([]*os.File, error) {
+				return tc.listenFiles(t, tmpDir)
+			}, servers)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expActivated, activated, "unexpected activation result")
+
+			ctx := test.Context(t)
+			for _, srv := range servers {
+				if err := srv.Start(ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The server of an inherited socket accepts connections
+			// without having created the socket file, and the other
+			// servers create their own.
+			for _, name := range append(tc.expInherited, "cred.sock") {
+				conn, err := net.Dial("unixpacket", filepath.Join(tmpDir, name))
+				if err != nil {
+					t.Fatalf("%s: %s", name, err)
+				}
+				conn.Close()
+			}
+		})
+	}
+}
+
+func TestAgent_idleCheckInterval(t *testing.T) {
+	test.AssertEqual(t, minIdleCheckInterval, idleCheckInterval(5*time.Second), "unexpected short interval")
+	test.AssertEqual(t, 3*time.Minute, idleCheckInterval(30*time.Minute), "unexpected long interval")
+}
+
+func TestAgent_agentIdleTime(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	srv, err := drpc.NewDomainSocketServer(log, filepath.Join(tmpDir, "agent.sock"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(test.Context(t)); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	procmon := NewProcMon(log, nil, "")
+	test.AssertTrue(t, agentIdleTime([]*drpc.DomainSocketServer{srv}, procmon) > 0,
+		"expected agent to be idle")
+
+	// Processes with open pool handles keep the agent active.
+	procmon.numProcs = 1
+	test.AssertEqual(t, time.Duration(0), agentIdleTime([]*drpc.DomainSocketServer{srv}, procmon),
+		"expected agent to be active")
+}
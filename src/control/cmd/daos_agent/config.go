@@ -16,7 +16,10 @@ import (
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
+	"github.com/daos-stack/daos/src/control/lib/yamlcheck"
 	"github.com/daos-stack/daos/src/control/security"
 )
 
@@ -42,23 +45,32 @@ func (rm refreshMinutes) Duration() time.Duration {
 
 // Config defines the agent configuration.
 type Config struct {
-	SystemName          string                    `yaml:"name"`
-	AccessPoints        []string                  `yaml:"access_points"`
-	ControlPort         int                       `yaml:"port"`
-	RuntimeDir          string                    `yaml:"runtime_dir"`
-	LogFile             string                    `yaml:"log_file"`
-	LogLevel            common.ControlLogLevel    `yaml:"control_log_mask,omitempty"`
-	TransportConfig     *security.TransportConfig `yaml:"transport_config"`
-	DisableCache        bool                      `yaml:"disable_caching,omitempty"`
-	CacheExpiration     refreshMinutes            `yaml:"cache_expiration,omitempty"`
-	DisableAutoEvict    bool                      `yaml:"disable_auto_evict,omitempty"`
-	EvictOnStart        bool                      `yaml:"enable_evict_on_start,omitempty"`
-	ExcludeFabricIfaces common.StringSet          `yaml:"exclude_fabric_ifaces,omitempty"`
-	FabricInterfaces    []*NUMAFabricConfig       `yaml:"fabric_ifaces,omitempty"`
-	ProviderIdx         uint                      // TODO SRS-31: Enable with multiprovider functionality
-	TelemetryPort       int                       `yaml:"telemetry_port,omitempty"`
-	TelemetryEnabled    bool                      `yaml:"telemetry_enabled,omitempty"`
-	TelemetryRetain     time.Duration             `yaml:"telemetry_retain,omitempty"`
+	SystemName            string                    `yaml:"name"`
+	AccessPoints          []string                  `yaml:"access_points"`
+	AccessPointDiscovery  *AccessPointDiscovery     `yaml:"access_point_discovery,omitempty"`
+	ControlPort           int                       `yaml:"port"`
+	RuntimeDir            string                    `yaml:"runtime_dir"`
+	LogFile               string                    `yaml:"log_file"`
+	LogLevel              common.ControlLogLevel    `yaml:"control_log_mask,omitempty"`
+	TransportConfig       *security.TransportConfig `yaml:"transport_config"`
+	DisableCache          bool                      `yaml:"disable_caching,omitempty"`
+	CacheExpiration       refreshMinutes            `yaml:"cache_expiration,omitempty"`
+	DisableAutoEvict      bool                      `yaml:"disable_auto_evict,omitempty"`
+	DisableFabricFailover bool                      `yaml:"disable_fabric_failover,omitempty"`
+	EvictOnStart          bool                      `yaml:"enable_evict_on_start,omitempty"`
+	ExcludeFabricIfaces   common.StringSet          `yaml:"exclude_fabric_ifaces,omitempty"`
+	FabricInterfaces      []*NUMAFabricConfig       `yaml:"fabric_ifaces,omitempty"`
+	ProviderIdx           uint                      // TODO SRS-31: Enable with multiprovider functionality
+	TelemetryPort         int                       `yaml:"telemetry_port,omitempty"`
+	TelemetryEnabled      bool                      `yaml:"telemetry_enabled,omitempty"`
+	TelemetryRetain       time.Duration             `yaml:"telemetry_retain,omitempty"`
+	IdentityMapping       []*IdentityMapRule        `yaml:"identity_mapping,omitempty"`
+	CredentialRateLimit   *CredentialRateLimit      `yaml:"credential_rate_limit,omitempty"`
+	ReadOnly              bool                      `yaml:"read_only,omitempty"`
+	Profiling             *profiling.Config         `yaml:"profiling,omitempty"`
+	DrpcSockets           []*drpc.SocketConfig      `yaml:"drpc_sockets,omitempty"`
+	JobCorrelation        *JobCorrelationConfig     `yaml:"job_correlation,omitempty"`
+	IdleExitTimeout       time.Duration             `yaml:"idle_exit_timeout,omitempty"`
 }
 
 // TelemetryExportEnabled returns true if client telemetry export is enabled.
@@ -79,6 +91,26 @@ type FabricInterfaceConfig struct {
 	Domain    string `yaml:"domain"`
 }
 
+// agentConfigRules check for combinations of settings in the agent config
+// file that are not valid together.
+var agentConfigRules = []yamlcheck.Rule{
+	func(root *yamlcheck.Node) yamlcheck.Issues {
+		if root.Key("telemetry_port") != nil {
+			return nil
+		}
+
+		var issues yamlcheck.Issues
+		for _, n := range []*yamlcheck.Node{root.Key("telemetry_retain"), root.Key("telemetry_enabled")} {
+			if n == nil || n.Value() == "false" {
+				continue
+			}
+			issues = append(issues, n.Issue(n.Path()+" requires telemetry_port",
+				"set telemetry_port to the port on which client telemetry is exported"))
+		}
+		return issues
+	},
+}
+
 // LoadConfig reads a config file and uses it to populate a Config.
 func LoadConfig(cfgPath string) (*Config, error) {
 	if cfgPath == "" {
@@ -90,6 +122,9 @@ func LoadConfig(cfgPath string) (*Config, error) {
 	}
 
 	cfg := DefaultConfig()
+	if err := yamlcheck.Check(cfgPath, data, cfg, agentConfigRules...); err != nil {
+		return nil, err
+	}
 	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
 		return nil, errors.Wrapf(err, "parsing config: %s", cfgPath)
 	}
@@ -106,6 +141,38 @@ func LoadConfig(cfgPath string) (*Config, error) {
 		return nil, errors.New("telemetry_enabled requires telemetry_port")
 	}
 
+	if err := cfg.Profiling.Validate(); err != nil {
+		return nil, errors.Wrap(err, "profiling")
+	}
+
+	for i, rule := range cfg.IdentityMapping {
+		if err := rule.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "identity_mapping[%d]", i)
+		}
+	}
+
+	if err := cfg.CredentialRateLimit.Validate(); err != nil {
+		return nil, errors.Wrap(err, "credential_rate_limit")
+	}
+
+	if err := cfg.AccessPointDiscovery.Validate(); err != nil {
+		return nil, errors.Wrap(err, "access_point_discovery")
+	}
+
+	if err := cfg.JobCorrelation.Validate(); err != nil {
+		return nil, errors.Wrap(err, "job_correlation")
+	}
+
+	if cfg.IdleExitTimeout < 0 {
+		return nil, errors.New("idle_exit_timeout must not be negative")
+	}
+
+	for i, sc := range cfg.DrpcSockets {
+		if err := sc.Validate(drpc.ModuleSecurityAgent, drpc.ModuleMgmt); err != nil {
+			return nil, errors.Wrapf(err, "drpc_sockets[%d]", i)
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -16,6 +16,8 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
 	"github.com/daos-stack/daos/src/control/security"
 )
 
@@ -68,6 +70,125 @@ fabric_ifaces:
   -
      iface: ib3
      domain: mlx5_3
+identity_mapping:
+-
+  socket_path: /tmp/runtime/container.sock
+  uid: 100000
+  user: frodo
+  group: hobbits
+  groups: ["ringbearers"]
+-
+  cgroup: /machine.slice/libpod-1234
+  user: sam
+  group: hobbits
+credential_rate_limit:
+  per_uid_rate: 20
+  global_rate: 200
+  global_burst: 400
+  quotas:
+  -
+    uid: 0
+    rate: 0
+read_only: true
+profiling:
+  enabled: true
+  address: localhost:7070
+drpc_sockets:
+-
+  path: /tmp/runtime/daos_agent.sock
+  modules: [mgmt]
+-
+  path: /tmp/runtime/cred.sock
+  modules: [security_agent]
+  mode: "0660"
+  group: hobbits
+job_correlation:
+  helper: /usr/libexec/daos/daos_sockmon
+  args: ["--port", "31416"]
+idle_exit_timeout: 30m
+`)
+
+	badProfilingCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+profiling:
+  enabled: true
+  address: 0.0.0.0:6061
+`)
+
+	badIdleExitCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+idle_exit_timeout: -1m
+`)
+
+	badIdMapCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+identity_mapping:
+-
+  user: frodo
+  group: hobbits
+`)
+
+	badCredLimitCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+credential_rate_limit:
+  per_uid_burst: 10
+`)
+
+	badDiscoveryCfg := test.CreateTestFile(t, dir, `
+name: shire
+transport_config:
+  allow_insecure: true
+access_point_discovery:
+  dns_srv: _daos-mgmt._tcp.shire.example.com
+  url: https://discovery.shire.example.com/aps
+`)
+
+	badDrpcSocketCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+drpc_sockets:
+-
+  path: /tmp/runtime/srv.sock
+  modules: [srv]
+`)
+
+	badJobCorrelationCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+job_correlation:
+  helper: daos_sockmon
+`)
+
+	unknownKeyCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+transport_config:
+  allow_insecure: true
+  cert_dir: /etc/daos/certs
+`)
+
+	badTelemetryCfg := test.CreateTestFile(t, dir, `
+name: shire
+access_points: ["one:10001", "two:10001"]
+telemetry_retain: 1m
+transport_config:
+  allow_insecure: true
 `)
 
 	badLogMaskCfg := test.CreateTestFile(t, dir, `
@@ -95,7 +216,15 @@ transport_config:
 		},
 		"not a config file": {
 			path:   junkFile,
-			expErr: errors.New("yaml: unmarshal error"),
+			expErr: errors.New("1:1: expected a mapping of settings, got string"),
+		},
+		"unknown key": {
+			path:   unknownKeyCfg,
+			expErr: errors.New(`6:3: transport_config.cert_dir: unknown key "cert_dir"; did you mean "client_cert_dir"?`),
+		},
+		"telemetry retain without port": {
+			path:   badTelemetryCfg,
+			expErr: errors.New("4:19: telemetry_retain: telemetry_retain requires telemetry_port"),
 		},
 		"empty config file": {
 			path:      emptyFile,
@@ -120,6 +249,34 @@ transport_config:
 			path:   badLogMaskCfg,
 			expErr: errors.New("not a valid log level"),
 		},
+		"bad identity mapping": {
+			path:   badIdMapCfg,
+			expErr: errors.New("identity_mapping[0]: identity mapping rule requires socket_path or cgroup"),
+		},
+		"bad credential rate limit": {
+			path:   badCredLimitCfg,
+			expErr: errors.New("credential_rate_limit: burst requires a rate"),
+		},
+		"bad access point discovery": {
+			path:   badDiscoveryCfg,
+			expErr: errors.New("access_point_discovery: dns_srv and url cannot be set together"),
+		},
+		"bad drpc socket": {
+			path:   badDrpcSocketCfg,
+			expErr: errors.New("drpc_sockets[0]: dRPC module \"srv\" is not available"),
+		},
+		"bad job correlation": {
+			path:   badJobCorrelationCfg,
+			expErr: errors.New("job_correlation: helper \"daos_sockmon\" must be an absolute path"),
+		},
+		"remote profiling not allowed": {
+			path:   badProfilingCfg,
+			expErr: errors.New("profiling: profiling address \"0.0.0.0:6061\" is not a loopback address"),
+		},
+		"negative idle exit timeout": {
+			path:   badIdleExitCfg,
+			expErr: errors.New("idle_exit_timeout must not be negative"),
+		},
 		"all options": {
 			path: optCfg,
 			expResult: &Config{
@@ -165,6 +322,50 @@ transport_config:
 						},
 					},
 				},
+				IdentityMapping: []*IdentityMapRule{
+					{
+						SocketPath: "/tmp/runtime/container.sock",
+						UID:        func() *uint32 { u := uint32(100000); return &u }(),
+						User:       "frodo",
+						Group:      "hobbits",
+						Groups:     []string{"ringbearers"},
+					},
+					{
+						Cgroup: "/machine.slice/libpod-1234",
+						User:   "sam",
+						Group:  "hobbits",
+					},
+				},
+				CredentialRateLimit: &CredentialRateLimit{
+					PerUIDRate:  20,
+					GlobalRate:  200,
+					GlobalBurst: 400,
+					Quotas: []*CredentialQuota{
+						{UID: testUID(0)},
+					},
+				},
+				ReadOnly: true,
+				Profiling: &profiling.Config{
+					Enabled: true,
+					Address: "localhost:7070",
+				},
+				DrpcSockets: []*drpc.SocketConfig{
+					{
+						Path:    "/tmp/runtime/daos_agent.sock",
+						Modules: []string{"mgmt"},
+					},
+					{
+						Path:    "/tmp/runtime/cred.sock",
+						Modules: []string{"security_agent"},
+						Mode:    "0660",
+						Group:   "hobbits",
+					},
+				},
+				JobCorrelation: &JobCorrelationConfig{
+					Helper: "/usr/libexec/daos/daos_sockmon",
+					Args:   []string{"--port", "31416"},
+				},
+				IdleExitTimeout: 30 * time.Minute,
 			},
 		},
 	} {
@@ -0,0 +1,226 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// credLimitSweepInterval is the period at which idle per-UID buckets are
+// discarded.
+const credLimitSweepInterval = time.Minute
+
+// CredentialRateLimit limits the rate at which the agent handles security
+// requests, so that a runaway client on a shared node can't starve other
+// users of credentials. Rates are in requests per second, and a zero rate
+// applies no limit. Bursts default to the rate, rounded up.
+type CredentialRateLimit struct {
+	PerUIDRate  float64            `yaml:"per_uid_rate,omitempty"`
+	PerUIDBurst uint               `yaml:"per_uid_burst,omitempty"`
+	GlobalRate  float64            `yaml:"global_rate,omitempty"`
+	GlobalBurst uint               `yaml:"global_burst,omitempty"`
+	Quotas      []*CredentialQuota `yaml:"quotas,omitempty"`
+}
+
+// CredentialQuota replaces the per-UID limit for a single UID. A zero rate
+// exempts the UID from the per-UID limit, but not from the global limit.
+type CredentialQuota struct {
+	UID   *uint32 `yaml:"uid"`
+	Rate  float64 `yaml:"rate"`
+	Burst uint    `yaml:"burst,omitempty"`
+}
+
+func validRate(rate float64) bool {
+	return rate >= 0 && !math.IsInf(rate, 0)
+}
+
+// Validate checks that the rates are valid and that each UID has at most one
+// quota.
+func (crl *CredentialRateLimit) Validate() error {
+	if crl == nil {
+		return nil
+	}
+
+	if !validRate(crl.PerUIDRate) || !validRate(crl.GlobalRate) {
+		return errors.New("rates must be non-negative")
+	}
+	if (crl.PerUIDBurst > 0 && crl.PerUIDRate == 0) || (crl.GlobalBurst > 0 && crl.GlobalRate == 0) {
+		return errors.New("burst requires a rate")
+	}
+
+	seen := make(map[uint32]struct{})
+	for i, q := range crl.Quotas {
+		if q.UID == nil {
+			return errors.Errorf("quotas[%d]: uid is required", i)
+		}
+		if _, dup := seen[*q.UID]; dup {
+			return errors.Errorf("quotas[%d]: duplicate quota for uid %d", i, *q.UID)
+		}
+		seen[*q.UID] = struct{}{}
+
+		if !validRate(q.Rate) {
+			return errors.Errorf("quotas[%d]: rate must be non-negative", i)
+		}
+		if q.Burst > 0 && q.Rate == 0 {
+			return errors.Errorf("quotas[%d]: burst requires a rate", i)
+		}
+	}
+
+	return nil
+}
+
+// tokenBucket allows events at a sustained rate, with bursts of up to the
+// bucket size.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full tokenBucket, or nil if the rate is zero.
+func newTokenBucket(rate float64, burst uint, now time.Time) *tokenBucket {
+	if rate == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = uint(math.Ceil(rate))
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = math.Min(tb.burst, tb.tokens+elapsed.Seconds()*tb.rate)
+	}
+	tb.last = now
+}
+
+// credThrottledError indicates that a request exceeded a rate limit.
+type credThrottledError struct {
+	scope string
+	rate  float64
+}
+
+func (e *credThrottledError) Error() string {
+	return fmt.Sprintf("%s rate limit of %g requests/s exceeded", e.scope, e.rate)
+}
+
+// credLimiter applies the per-UID and global rate limits to security
+// requests.
+type credLimiter struct {
+	sync.Mutex
+	cfg       *CredentialRateLimit
+	quotas    map[uint32]*CredentialQuota
+	global    *tokenBucket
+	buckets   map[uint32]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// newCredLimiter returns a credLimiter for the supplied limits, or nil if no
+// limits have been configured.
+func newCredLimiter(cfg *CredentialRateLimit) *credLimiter {
+	if cfg == nil {
+		return nil
+	}
+
+	now := time.Now()
+	cl := &credLimiter{
+		cfg:       cfg,
+		quotas:    make(map[uint32]*CredentialQuota),
+		global:    newTokenBucket(cfg.GlobalRate, cfg.GlobalBurst, now),
+		buckets:   make(map[uint32]*tokenBucket),
+		lastSweep: now,
+		now:       time.Now,
+	}
+	for _, q := range cfg.Quotas {
+		cl.quotas[*q.UID] = q
+	}
+	return cl
+}
+
+// uidBucket returns the bucket for the UID, creating it if necessary. A nil
+// bucket is returned if the UID has no per-UID limit.
+func (cl *credLimiter) uidBucket(uid uint32, now time.Time) *tokenBucket {
+	if tb, found := cl.buckets[uid]; found {
+		return tb
+	}
+
+	rate, burst := cl.cfg.PerUIDRate, cl.cfg.PerUIDBurst
+	if q, found := cl.quotas[uid]; found {
+		rate, burst = q.Rate, q.Burst
+	}
+
+	tb := newTokenBucket(rate, burst, now)
+	if tb != nil {
+		cl.buckets[uid] = tb
+	}
+	return tb
+}
+
+// sweep discards the buckets of UIDs that have been idle long enough for
+// their buckets to refill, as they are equivalent to new buckets.
+func (cl *credLimiter) sweep(now time.Time) {
+	if now.Sub(cl.lastSweep) < credLimitSweepInterval {
+		return
+	}
+	cl.lastSweep = now
+
+	for uid, tb := range cl.buckets {
+		tb.refill(now)
+		if tb.tokens >= tb.burst {
+			delete(cl.buckets, uid)
+		}
+	}
+}
+
+// allow returns an error if a request from the UID would exceed either the
+// UID's limit or the global limit. A rejected request does not count against
+// either limit.
+func (cl *credLimiter) allow(uid uint32) error {
+	if cl == nil {
+		return nil
+	}
+
+	cl.Lock()
+	defer cl.Unlock()
+
+	now := cl.now()
+	cl.sweep(now)
+
+	ub := cl.uidBucket(uid, now)
+	if ub != nil {
+		ub.refill(now)
+		if ub.tokens < 1 {
+			return &credThrottledError{scope: fmt.Sprintf("uid %d", uid), rate: ub.rate}
+		}
+	}
+	if cl.global != nil {
+		cl.global.refill(now)
+		if cl.global.tokens < 1 {
+			return &credThrottledError{scope: "global", rate: cl.global.rate}
+		}
+		cl.global.tokens--
+	}
+	if ub != nil {
+		ub.tokens--
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAgent_CredentialRateLimit_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		crl    *CredentialRateLimit
+		expErr error
+	}{
+		"nil": {},
+		"valid": {
+			crl: &CredentialRateLimit{
+				PerUIDRate:  10,
+				PerUIDBurst: 20,
+				GlobalRate:  100,
+				Quotas: []*CredentialQuota{
+					{UID: testUID(0)},
+					{UID: testUID(1000), Rate: 50},
+				},
+			},
+		},
+		"negative rate": {
+			crl:    &CredentialRateLimit{GlobalRate: -1},
+			expErr: errors.New("non-negative"),
+		},
+		"infinite rate": {
+			crl:    &CredentialRateLimit{PerUIDRate: math.Inf(1)},
+			expErr: errors.New("non-negative"),
+		},
+		"burst without rate": {
+			crl:    &CredentialRateLimit{PerUIDBurst: 10},
+			expErr: errors.New("burst requires a rate"),
+		},
+		"quota without uid": {
+			crl: &CredentialRateLimit{
+				Quotas: []*CredentialQuota{{Rate: 1}},
+			},
+			expErr: errors.New("quotas[0]: uid is required"),
+		},
+		"duplicate quota": {
+			crl: &CredentialRateLimit{
+				Quotas: []*CredentialQuota{
+					{UID: testUID(1000), Rate: 1},
+					{UID: testUID(1000), Rate: 2},
+				},
+			},
+			expErr: errors.New("quotas[1]: duplicate quota for uid 1000"),
+		},
+		"quota burst without rate": {
+			crl: &CredentialRateLimit{
+				Quotas: []*CredentialQuota{{UID: testUID(1000), Burst: 5}},
+			},
+			expErr: errors.New("quotas[0]: burst requires a rate"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.crl.Validate())
+		})
+	}
+}
+
+func TestAgent_credLimiter(t *testing.T) {
+	if cl := newCredLimiter(nil); cl != nil {
+		t.Fatal("expected nil limiter")
+	}
+	var nilCL *credLimiter
+	if err := nilCL.allow(1000); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := newCredLimiter(&CredentialRateLimit{
+		PerUIDRate:  1,
+		PerUIDBurst: 2,
+		GlobalRate:  4,
+		GlobalBurst: 4,
+		Quotas: []*CredentialQuota{
+			{UID: testUID(0)},
+		},
+	})
+	now := time.Now()
+	cl.now = func() time.Time { return now }
+
+	allowN := func(uid uint32, n int) int {
+		var allowed int
+		for i := 0; i < n; i++ {
+			if cl.allow(uid) == nil {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	// UID 1000 may burst to 2 requests, and then 1 request per second.
+	test.AssertEqual(t, 2, allowN(1000, 5), "unexpected per-UID burst")
+	err := cl.allow(1000)
+	test.CmpErr(t, errors.New("uid 1000 rate limit of 1 requests/s exceeded"), err)
+
+	now = now.Add(time.Second)
+	test.AssertEqual(t, 1, allowN(1000, 5), "unexpected per-UID refill")
+
+	// UID 0 is exempt from the per-UID limit, but not the global limit,
+	// which has 3 requests left after the request from UID 1000.
+	test.AssertEqual(t, 3, allowN(0, 5), "unexpected global limit")
+	err = cl.allow(0)
+	test.CmpErr(t, errors.New("global rate limit of 4 requests/s exceeded"), err)
+
+	// Requests rejected by the global limit don't consume per-UID tokens.
+	test.AssertEqual(t, 0, allowN(1001, 2), "global limit not applied")
+	now = now.Add(500 * time.Millisecond)
+	test.AssertEqual(t, 2, allowN(1001, 2), "unexpected burst for new UID")
+
+	// Idle buckets are discarded once they have refilled.
+	now = now.Add(credLimitSweepInterval)
+	test.AssertEqual(t, 1, allowN(1002, 1), "request refused")
+	test.AssertEqual(t, 1, len(cl.buckets), "idle buckets not discarded")
+}
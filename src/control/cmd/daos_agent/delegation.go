@@ -0,0 +1,104 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// delegationTokenCmd requests a delegation token from the local agent on
+// behalf of the calling user. It is intended to be run by job launchers,
+// not by the agent's own user, so it does not load the agent configuration.
+type delegationTokenCmd struct {
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	runtimeDir string
+	JobID      string        `long:"job-id" description:"Restrict the token to processes in this job"`
+	Lifetime   time.Duration `long:"lifetime" description:"Requested token lifetime (default 1h, max 24h)"`
+}
+
+type delegationTokenResult struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+func (cmd *delegationTokenCmd) setRuntimeDir(dir string) {
+	cmd.runtimeDir = dir
+}
+
+func (cmd *delegationTokenCmd) Execute(_ []string) error {
+	runtimeDir := cmd.runtimeDir
+	if runtimeDir == "" {
+		runtimeDir = defaultRuntimeDir
+	}
+
+	ctx := context.Background()
+	resp, err := requestDelegationToken(ctx, drpc.NewClientConnection(filepath.Join(runtimeDir, agentSockName)), &auth.DelegationTokenReq{
+		Jobid:    cmd.JobID,
+		Lifetime: uint32(cmd.Lifetime.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := &delegationTokenResult{
+		Token:   resp.Token,
+		Expires: time.Unix(int64(resp.Expires), 0),
+	}
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(result, nil)
+	}
+
+	cmd.Debugf("delegation token expires at %s", result.Expires)
+	_, err = fmt.Println(result.Token)
+	return err
+}
+
+func requestDelegationToken(ctx context.Context, client drpc.DomainSocketClient, req *auth.DelegationTokenReq) (*auth.DelegationTokenResp, error) {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling delegation token request")
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		return nil, errors.Wrap(err, "connecting to agent")
+	}
+	defer client.Close()
+
+	drpcResp, err := client.SendMsg(ctx, &drpc.Call{
+		Module: drpc.ModuleSecurityAgent.ID(),
+		Method: drpc.MethodRequestDelegationToken.ID(),
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "requesting delegation token")
+	}
+	if drpcResp.Status != drpc.Status_SUCCESS {
+		return nil, errors.Errorf("delegation token request failed: %s", drpcResp.Status)
+	}
+
+	resp := new(auth.DelegationTokenResp)
+	if err := proto.Unmarshal(drpcResp.Body, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling delegation token response")
+	}
+	if resp.Status != 0 {
+		return nil, errors.Wrap(daos.Status(resp.Status), "agent failed to issue delegation token")
+	}
+
+	return resp, nil
+}
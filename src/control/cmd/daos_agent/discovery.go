@@ -0,0 +1,173 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	defaultDiscoveryTimeout = 10 * time.Second
+	maxDiscoveryRespSize    = 1 << 20
+)
+
+// AccessPointDiscovery configures the agent to locate the management service
+// access points at startup rather than reading them from the config, so that
+// the same agent config can be used with any system. Exactly one of a DNS SRV
+// record name or an HTTP(S) URL must be set. The URL must return a JSON object
+// of the form {"access_points": ["host1:10001", "host2"]}.
+type AccessPointDiscovery struct {
+	DNSSRV  string        `yaml:"dns_srv,omitempty"`
+	URL     string        `yaml:"url,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate checks that a single valid discovery method is configured.
+func (apd *AccessPointDiscovery) Validate() error {
+	if apd == nil {
+		return nil
+	}
+
+	switch {
+	case apd.DNSSRV == "" && apd.URL == "":
+		return errors.New("one of dns_srv or url is required")
+	case apd.DNSSRV != "" && apd.URL != "":
+		return errors.New("dns_srv and url cannot be set together")
+	case apd.Timeout < 0:
+		return errors.New("timeout must be non-negative")
+	}
+
+	if apd.URL != "" {
+		u, err := url.Parse(apd.URL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid url %q", apd.URL)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid url %q: must be an http or https URL", apd.URL)
+		}
+	}
+
+	return nil
+}
+
+type (
+	srvLookupFn func(ctx context.Context, name string) ([]*net.SRV, error)
+
+	accessPointDiscoverer struct {
+		log       logging.Logger
+		cfg       *AccessPointDiscovery
+		lookupSRV srvLookupFn
+		client    *http.Client
+	}
+
+	discoveryResp struct {
+		AccessPoints []string `json:"access_points"`
+	}
+)
+
+func defaultSRVLookup(ctx context.Context, name string) ([]*net.SRV, error) {
+	// An empty service and proto looks up the name directly.
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	return addrs, err
+}
+
+func newAccessPointDiscoverer(log logging.Logger, cfg *AccessPointDiscovery) *accessPointDiscoverer {
+	return &accessPointDiscoverer{
+		log:       log,
+		cfg:       cfg,
+		lookupSRV: defaultSRVLookup,
+		client:    http.DefaultClient,
+	}
+}
+
+// discover returns the access points published by the configured discovery
+// method.
+func (apd *accessPointDiscoverer) discover(parent context.Context) ([]string, error) {
+	timeout := apd.cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultDiscoveryTimeout
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	var aps []string
+	var err error
+	if apd.cfg.DNSSRV != "" {
+		aps, err = apd.fromDNS(ctx)
+	} else {
+		aps, err = apd.fromURL(ctx)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "access point discovery")
+	}
+	if len(aps) == 0 {
+		return nil, errors.New("access point discovery: no access points found")
+	}
+
+	apd.log.Debugf("discovered access points: %s", strings.Join(aps, ","))
+	return aps, nil
+}
+
+func (apd *accessPointDiscoverer) fromDNS(ctx context.Context) ([]string, error) {
+	addrs, err := apd.lookupSRV(ctx, apd.cfg.DNSSRV)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up SRV record %q", apd.cfg.DNSSRV)
+	}
+
+	aps := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		host := strings.TrimSuffix(addr.Target, ".")
+		if host == "" {
+			continue
+		}
+		aps = append(aps, net.JoinHostPort(host, fmt.Sprint(addr.Port)))
+	}
+
+	return aps, nil
+}
+
+func (apd *accessPointDiscoverer) fromURL(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apd.cfg.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := apd.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s returned %s", apd.cfg.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDiscoveryRespSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading response from %s", apd.cfg.URL)
+	}
+
+	var dr discoveryResp
+	if err := json.Unmarshal(data, &dr); err != nil {
+		return nil, errors.Wrapf(err, "decoding response from %s", apd.cfg.URL)
+	}
+
+	return dr.AccessPoints, nil
+}
@@ -0,0 +1,154 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_AccessPointDiscovery_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *AccessPointDiscovery
+		expErr error
+	}{
+		"nil": {},
+		"no method": {
+			cfg:    &AccessPointDiscovery{},
+			expErr: errors.New("one of dns_srv or url"),
+		},
+		"both methods": {
+			cfg: &AccessPointDiscovery{
+				DNSSRV: "_daos._tcp.example.com",
+				URL:    "http://example.com",
+			},
+			expErr: errors.New("cannot be set together"),
+		},
+		"negative timeout": {
+			cfg: &AccessPointDiscovery{
+				DNSSRV:  "_daos._tcp.example.com",
+				Timeout: -time.Second,
+			},
+			expErr: errors.New("non-negative"),
+		},
+		"bad url scheme": {
+			cfg: &AccessPointDiscovery{
+				URL: "ftp://example.com/aps",
+			},
+			expErr: errors.New("must be an http or https URL"),
+		},
+		"dns": {
+			cfg: &AccessPointDiscovery{
+				DNSSRV: "_daos._tcp.example.com",
+			},
+		},
+		"url": {
+			cfg: &AccessPointDiscovery{
+				URL:     "https://example.com/aps",
+				Timeout: time.Second,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestAgent_accessPointDiscoverer_discover(t *testing.T) {
+	for name, tc := range map[string]struct {
+		dnsSRV    string
+		srvAddrs  []*net.SRV
+		srvErr    error
+		status    int
+		body      string
+		expAPs    []string
+		expErr    error
+		expLookup string
+	}{
+		"dns lookup fails": {
+			dnsSRV: "_daos._tcp.example.com",
+			srvErr: errors.New("no such host"),
+			expErr: errors.New("no such host"),
+		},
+		"dns no records": {
+			dnsSRV: "_daos._tcp.example.com",
+			expErr: errors.New("no access points found"),
+		},
+		"dns success": {
+			dnsSRV: "_daos._tcp.example.com",
+			srvAddrs: []*net.SRV{
+				{Target: "ms1.example.com.", Port: 10001},
+				{Target: "ms2.example.com.", Port: 10002},
+			},
+			expAPs:    []string{"ms1.example.com:10001", "ms2.example.com:10002"},
+			expLookup: "_daos._tcp.example.com",
+		},
+		"url error status": {
+			status: http.StatusNotFound,
+			expErr: errors.New("404"),
+		},
+		"url bad json": {
+			status: http.StatusOK,
+			body:   "[",
+			expErr: errors.New("decoding response"),
+		},
+		"url no access points": {
+			status: http.StatusOK,
+			body:   `{"access_points": []}`,
+			expErr: errors.New("no access points found"),
+		},
+		"url success": {
+			status: http.StatusOK,
+			body:   `{"access_points": ["ms1:10001", "ms2"]}`,
+			expAPs: []string{"ms1:10001", "ms2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := &AccessPointDiscovery{DNSSRV: tc.dnsSRV}
+			if tc.dnsSRV == "" {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(tc.status)
+					w.Write([]byte(tc.body))
+				}))
+				defer srv.Close()
+				cfg.URL = srv.URL
+			}
+
+			var gotLookup string
+			apd := newAccessPointDiscoverer(log, cfg)
+			apd.lookupSRV = func(_ context.Context, name string) ([]*net.SRV, error) {
+				gotLookup = name
+				return tc.srvAddrs, tc.srvErr
+			}
+
+			gotAPs, gotErr := apd.discover(test.Context(t))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expAPs, gotAPs); diff != "" {
+				t.Fatalf("unexpected access points (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, tc.expLookup, gotLookup, "unexpected SRV lookup")
+		})
+	}
+}
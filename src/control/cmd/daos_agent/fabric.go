@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -32,6 +32,12 @@ type FabricInterface struct {
 	Domain      string
 	NetDevClass hardware.NetDevClass
 	hw          *hardware.FabricInterface
+	numaNode    int
+}
+
+// NUMANode returns the NUMA node the interface is associated with.
+func (f *FabricInterface) NUMANode() int {
+	return f.numaNode
 }
 
 // Providers returns a slice of the providers associated with the interface.
@@ -81,6 +87,7 @@ type NUMAFabric struct {
 	currentNumaDevIdx map[int]int // current device idx to use on each NUMA node
 	currentNUMANode   int         // current NUMA node to search
 	ignoreIfaces      common.StringSet
+	failedIfaces      *failedDevices
 
 	getAddrInterface func(name string) (addrFI, error)
 }
@@ -94,6 +101,7 @@ func (n *NUMAFabric) Add(numaNode int, fi *FabricInterface) error {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
 
+	fi.numaNode = numaNode
 	n.numaMap[numaNode] = append(n.numaMap[numaNode], fi)
 	return nil
 }
@@ -108,6 +116,13 @@ func (n *NUMAFabric) WithIgnoredDevices(ifaces common.StringSet) *NUMAFabric {
 	return n
 }
 
+// WithFailedDevices sets the set of fabric interfaces that have failed at runtime. Failed
+// interfaces are skipped when selecting a device.
+func (n *NUMAFabric) WithFailedDevices(failed *failedDevices) *NUMAFabric {
+	n.failedIfaces = failed
+	return n
+}
+
 // NumDevices gets the number of devices on a given NUMA node.
 func (n *NUMAFabric) NumDevices(numaNode int) int {
 	if n == nil {
@@ -197,6 +212,11 @@ func (n *NUMAFabric) getDeviceFromNUMA(numaNode int, netDevClass hardware.NetDev
 			continue
 		}
 
+		if n.failedIfaces.Has(fabricIF.Name) {
+			n.log.Tracef("device %s: excluded (failed)", fabricIF)
+			continue
+		}
+
 		// Manually-provided interfaces can be assumed to support what's needed by the system.
 		if fabricIF.NetDevClass != FabricDevClassManual {
 			if fabricIF.NetDevClass != netDevClass {
@@ -297,6 +317,27 @@ func (n *NUMAFabric) getNextDevIndex(numaNode int) int {
 	panic(fmt.Sprintf("no fabric interfaces on NUMA node %d", numaNode))
 }
 
+// deviceNames returns the sorted names of the fabric interfaces that may be selected,
+// excluding those on the ignore list.
+func (n *NUMAFabric) deviceNames() []string {
+	if n == nil {
+		return nil
+	}
+
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	names := common.NewStringSet()
+	for _, devs := range n.numaMap {
+		for _, fi := range devs {
+			if !n.ignoreIfaces.Has(fi.Name) {
+				names.Add(fi.Name)
+			}
+		}
+	}
+	return names.ToSlice()
+}
+
 // Find finds a specific fabric device by name. There may be more than one domain associated.
 func (n *NUMAFabric) Find(name string) ([]*FabricInterface, error) {
 	if n == nil {
@@ -438,6 +479,7 @@ func NUMAFabricFromConfig(log logging.Logger, cfg []*NUMAFabricConfig) *NUMAFabr
 					Name:        fi.Interface,
 					Domain:      fi.Domain,
 					NetDevClass: FabricDevClassManual,
+					numaNode:    node,
 				})
 		}
 	}
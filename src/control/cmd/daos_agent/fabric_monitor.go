@@ -0,0 +1,308 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+)
+
+// fabricCheckInterval is the interval between checks of the cached fabric interface states.
+const fabricCheckInterval = 5 * time.Second
+
+// failedDevices is a set of fabric interface names that have stopped working at runtime.
+// The set is owned by the InfoCache so that it outlives any individual fabric scan.
+type failedDevices struct {
+	sync.RWMutex
+	ifaces common.StringSet
+}
+
+func newFailedDevices() *failedDevices {
+	return &failedDevices{
+		ifaces: common.NewStringSet(),
+	}
+}
+
+// Has checks whether the named interface has been marked as failed.
+func (fd *failedDevices) Has(name string) bool {
+	if fd == nil {
+		return false
+	}
+
+	fd.RLock()
+	defer fd.RUnlock()
+
+	return fd.ifaces.Has(name)
+}
+
+// set marks or clears the named interface as failed. It returns true if the interface's
+// state changed.
+func (fd *failedDevices) set(name string, failed bool) bool {
+	fd.Lock()
+	defer fd.Unlock()
+
+	if fd.ifaces.Has(name) == failed {
+		return false
+	}
+	if failed {
+		fd.ifaces.Add(name)
+	} else {
+		delete(fd.ifaces, name)
+	}
+	return true
+}
+
+// isDevStateFailed checks whether a fabric interface in the given state can no longer be
+// handed out to clients. An unknown state is not considered a failure, as some devices don't
+// report their state.
+func isDevStateFailed(state hardware.NetDevState) bool {
+	return state == hardware.NetDevStateDown || state == hardware.NetDevStateNotReady
+}
+
+// cachedFabric returns the cached fabric data, if any.
+func (c *InfoCache) cachedFabric(ctx context.Context) (*NUMAFabric, error) {
+	if !c.IsFabricCacheEnabled() || !c.cache.Has(fabricKey) {
+		return nil, nil
+	}
+
+	item, release, err := c.cache.Get(ctx, fabricKey)
+	defer release()
+	if err != nil {
+		return nil, err
+	}
+
+	cfi, ok := item.(*cachedFabricInfo)
+	if !ok {
+		return nil, nil
+	}
+	return cfi.lastResults, nil
+}
+
+// cachedFabricDevices returns the names of the interfaces in the cached fabric data, if any.
+func (c *InfoCache) cachedFabricDevices(ctx context.Context) ([]string, error) {
+	nf, err := c.cachedFabric(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nf.deviceNames(), nil
+}
+
+// checkFabricDevices checks the state of each cached fabric interface. Interfaces that have gone
+// down are marked as failed, so that newly attaching clients are given the next-best interface
+// instead. Interfaces that have come back up are made available again.
+func (c *InfoCache) checkFabricDevices(ctx context.Context, handler events.Handler) {
+	names, err := c.cachedFabricDevices(ctx)
+	if err != nil {
+		c.log.Errorf("unable to get cached fabric interfaces: %s", err)
+		return
+	}
+
+	var newlyFailed []string
+	for _, name := range names {
+		state, err := c.devStateGetter.GetNetDevState(name)
+		if err != nil {
+			c.log.Debugf("unable to get state of fabric interface %s: %s", name, err)
+			continue
+		}
+
+		failed := isDevStateFailed(state)
+		if !c.failedIfaces.set(name, failed) {
+			continue
+		}
+		c.bumpGeneration()
+
+		if failed {
+			newlyFailed = append(newlyFailed, name)
+			continue
+		}
+		c.log.Noticef("fabric interface %s has recovered and may be selected for new clients", name)
+	}
+
+	if len(newlyFailed) == 0 {
+		return
+	}
+
+	remaining := 0
+	for _, name := range names {
+		if !c.failedIfaces.Has(name) {
+			remaining++
+		}
+	}
+
+	for _, name := range newlyFailed {
+		c.log.Errorf("fabric interface %s has failed and will not be selected for new clients (%d remaining)",
+			name, remaining)
+		if handler != nil {
+			handler.OnEvent(ctx, events.NewAgentFabricFailoverEvent("", name, remaining))
+		}
+	}
+}
+
+// addedDevices tracks the network interfaces present on the host so that interfaces added at
+// runtime can be detected. It is only accessed by the fabric monitor.
+type addedDevices struct {
+	known   common.StringSet
+	pending map[string]int
+}
+
+// addedIfaceChecks is the number of checks for which a newly added network interface is
+// rescanned before giving up on it becoming a usable fabric interface. Drivers may take a
+// little while to finish setting up a device after it first appears.
+const addedIfaceChecks = 3
+
+// update records the current set of interfaces and returns true if any are still waiting to be
+// found in a fabric scan. The first update only records the interfaces present at startup.
+func (ad *addedDevices) update(current common.StringSet) bool {
+	if ad.known == nil {
+		ad.known = current
+		return false
+	}
+	if ad.pending == nil {
+		ad.pending = make(map[string]int)
+	}
+
+	for name := range current {
+		if !ad.known.Has(name) {
+			ad.pending[name] = addedIfaceChecks
+		}
+	}
+	for name := range ad.pending {
+		if !current.Has(name) {
+			delete(ad.pending, name)
+		}
+	}
+	ad.known = current
+
+	return len(ad.pending) > 0
+}
+
+// fabricProviders returns the sorted names of the providers available for the named interface.
+func fabricProviders(nf *NUMAFabric, name string) []string {
+	fis, err := nf.Find(name)
+	if err != nil {
+		return nil
+	}
+
+	provs := common.NewStringSet()
+	for _, fi := range fis {
+		if fi.hw != nil {
+			provs.Add(fi.Providers()...)
+		}
+	}
+	return provs.ToSlice()
+}
+
+// checkAddedFabricDevices detects network interfaces that have been added since the last check.
+// If fabric data has already been cached, the fabric is rescanned so that new fabric interfaces
+// are primed with their providers and may be selected for new clients without restarting the
+// agent.
+func (c *InfoCache) checkAddedFabricDevices(ctx context.Context, handler events.Handler) {
+	if c.fabricCacheStatic.Load() || c.resetFabricScan == nil {
+		return
+	}
+
+	ifaces, err := c.netIfaces()
+	if err != nil {
+		c.log.Errorf("unable to get net interfaces: %s", err)
+		return
+	}
+	current := common.NewStringSet()
+	for _, iface := range ifaces {
+		if !c.ignoreIfaces.Has(iface.Name) {
+			current.Add(iface.Name)
+		}
+	}
+	if !c.addedIfaces.update(current) {
+		return
+	}
+
+	before, err := c.cachedFabric(ctx)
+	if err != nil {
+		c.log.Errorf("unable to get cached fabric interfaces: %s", err)
+		return
+	}
+	if before == nil {
+		// Nothing has been cached yet, so the first scan will include the new interfaces.
+		c.addedIfaces.pending = nil
+		return
+	}
+	known := common.NewStringSet(before.deviceNames()...)
+
+	added := common.NewStringSet()
+	for name := range c.addedIfaces.pending {
+		added.Add(name)
+	}
+	c.log.Debugf("network interfaces added: %s; rescanning fabric", added)
+	if err := c.resetFabricScan(); err != nil {
+		c.log.Errorf("unable to reset fabric scanner: %s", err)
+		return
+	}
+	if err := c.cache.Refresh(ctx, fabricKey); err != nil {
+		c.log.Errorf("unable to rescan fabric after network interfaces were added: %s", err)
+	} else {
+		c.reportAddedFabricDevices(ctx, known, handler)
+	}
+
+	for name, left := range c.addedIfaces.pending {
+		if left <= 1 {
+			c.log.Debugf("network interface %s not found in fabric scan", name)
+			delete(c.addedIfaces.pending, name)
+			continue
+		}
+		c.addedIfaces.pending[name] = left - 1
+	}
+}
+
+// reportAddedFabricDevices reports the interfaces in the cached fabric data that were not
+// previously known.
+func (c *InfoCache) reportAddedFabricDevices(ctx context.Context, known common.StringSet, handler events.Handler) {
+	nf, err := c.cachedFabric(ctx)
+	if err != nil {
+		c.log.Errorf("unable to get cached fabric interfaces: %s", err)
+		return
+	}
+
+	for _, name := range nf.deviceNames() {
+		delete(c.addedIfaces.pending, name)
+		if known.Has(name) {
+			continue
+		}
+
+		provs := fabricProviders(nf, name)
+		c.log.Noticef("fabric interface %s has been added and may be selected for new clients (providers: %s)",
+			name, strings.Join(provs, ", "))
+		if handler != nil {
+			handler.OnEvent(ctx, events.NewAgentFabricIfaceAddedEvent("", name, provs))
+		}
+	}
+}
+
+// monitorFabricDevices periodically checks the state of the cached fabric interfaces until the
+// context is canceled. If failover is enabled, interfaces that stop working are no longer
+// selected for new clients. Interfaces added at runtime are picked up by rescanning the fabric.
+func (c *InfoCache) monitorFabricDevices(ctx context.Context, interval time.Duration, failover bool, handler events.Handler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkAddedFabricDevices(ctx, handler)
+			if failover {
+				c.checkFabricDevices(ctx, handler)
+			}
+		}
+	}
+}
@@ -0,0 +1,363 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+type mockEventHandler struct {
+	rx []*events.RASEvent
+}
+
+func (m *mockEventHandler) OnEvent(_ context.Context, evt *events.RASEvent) {
+	m.rx = append(m.rx, evt)
+}
+
+func TestAgent_InfoCache_checkFabricDevices(t *testing.T) {
+	testFabric := func(log logging.Logger) *NUMAFabric {
+		nf := NUMAFabricFromConfig(log, []*NUMAFabricConfig{
+			{
+				NUMANode: 0,
+				Interfaces: []*FabricInterfaceConfig{
+					{Interface: "if0", Domain: "d0"},
+					{Interface: "if1", Domain: "d1"},
+				},
+			},
+		})
+		nf.getAddrInterface = getMockNetInterfaceSuccess
+		return nf
+	}
+
+	for name, tc := range map[string]struct {
+		noFabric     bool
+		startFailed  []string
+		states       []hardware.MockNetDevStateResult
+		expFailed    []string
+		expEvtIfaces []string
+		expEvtSev    events.RASSeverityID
+		expSelected  string
+		expSelectErr error
+	}{
+		"no cached fabric": {
+			noFabric: true,
+		},
+		"all ready": {
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateReady},
+			},
+			expSelected: "if0",
+		},
+		"one down": {
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateDown},
+				{State: hardware.NetDevStateReady},
+			},
+			expFailed:    []string{"if0"},
+			expEvtIfaces: []string{"if0"},
+			expEvtSev:    events.RASSeverityWarning,
+			expSelected:  "if1",
+		},
+		"already failed": {
+			startFailed: []string{"if0"},
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateNotReady},
+				{State: hardware.NetDevStateReady},
+			},
+			expFailed:   []string{"if0"},
+			expSelected: "if1",
+		},
+		"recovered": {
+			startFailed: []string{"if0"},
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateReady},
+			},
+			expSelected: "if0",
+		},
+		"unknown state is not failure": {
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateUnknown},
+				{Err: errors.New("mock GetNetDevState")},
+			},
+			expSelected: "if0",
+		},
+		"all down": {
+			states: []hardware.MockNetDevStateResult{
+				{State: hardware.NetDevStateDown},
+			},
+			expFailed:    []string{"if0", "if1"},
+			expEvtIfaces: []string{"if0", "if1"},
+			expEvtSev:    events.RASSeverityError,
+			expSelectErr: errors.New("no suitable fabric interface"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			params := testInfoCacheParams{
+				mockNetDevStateGetter: &hardware.MockNetDevStateProvider{
+					GetStateReturn: tc.states,
+				},
+			}
+			ic := newTestInfoCache(t, log, params)
+			if !tc.noFabric {
+				ic.EnableStaticFabricCache(test.Context(t), testFabric(log))
+			}
+			for _, name := range tc.startFailed {
+				ic.failedIfaces.set(name, true)
+			}
+
+			handler := &mockEventHandler{}
+			ic.checkFabricDevices(test.Context(t), handler)
+
+			if diff := cmp.Diff(common.NewStringSet(tc.expFailed...), ic.failedIfaces.ifaces); diff != "" {
+				t.Fatalf("unexpected failed interfaces (-want, +got):\n%s\n", diff)
+			}
+
+			var evtIfaces []string
+			for _, evt := range handler.rx {
+				test.AssertEqual(t, events.RASAgentFabricFailover, evt.ID, "")
+				test.AssertEqual(t, tc.expEvtSev, evt.Severity, "")
+				evtIfaces = append(evtIfaces, evt.HWID)
+			}
+			if diff := cmp.Diff(tc.expEvtIfaces, evtIfaces); diff != "" {
+				t.Fatalf("unexpected events (-want, +got):\n%s\n", diff)
+			}
+
+			if tc.noFabric {
+				return
+			}
+
+			fi, err := ic.GetFabricDevice(test.Context(t), &FabricIfaceParams{
+				Provider: "ofi+tcp",
+			})
+			test.CmpErr(t, tc.expSelectErr, err)
+			if tc.expSelectErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expSelected, fi.Name, "")
+		})
+	}
+}
+
+func TestAgent_InfoCache_checkAddedFabricDevices(t *testing.T) {
+	testFI := func(name string, provs ...string) *hardware.FabricInterface {
+		ps := hardware.NewFabricProviderSet()
+		for _, p := range provs {
+			ps.Add(&hardware.FabricProvider{Name: p})
+		}
+		return &hardware.FabricInterface{
+			Name:          name,
+			NetInterfaces: common.NewStringSet(name),
+			DeviceClass:   hardware.Ether,
+			Providers:     ps,
+		}
+	}
+	oneIface := hardware.NewFabricInterfaceSet(testFI("test0", "ofi+tcp"))
+	twoIfaces := hardware.NewFabricInterfaceSet(
+		testFI("test0", "ofi+tcp"),
+		testFI("test1", "ofi+tcp", "ofi+verbs"),
+	)
+	netIfaces := func(names ...string) []net.Interface {
+		ifaces := []net.Interface{}
+		for _, name := range names {
+			ifaces = append(ifaces, net.Interface{Name: name})
+		}
+		return ifaces
+	}
+
+	for name, tc := range map[string]struct {
+		static       bool
+		noFabric     bool
+		ignore       []string
+		ifaces       [][]net.Interface
+		scans        []*hardware.FabricInterfaceSet
+		scanErr      error
+		expResets    int
+		expEvtIfaces []string
+		expEvtMsgs   []string
+		expDevices   []string
+	}{
+		"first check records baseline": {
+			ifaces:     [][]net.Interface{netIfaces("test0", "test1")},
+			expDevices: []string{"test0"},
+		},
+		"no change": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0"),
+			},
+			expDevices: []string{"test0"},
+		},
+		"static fabric cache": {
+			static: true,
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+			},
+			expDevices: []string{"test0"},
+		},
+		"nothing cached": {
+			noFabric: true,
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+			},
+		},
+		"ignored interface added": {
+			ignore: []string{"test1"},
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+			},
+			expDevices: []string{"test0"},
+		},
+		"fabric interface added": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+				netIfaces("test0", "test1"),
+			},
+			scans:        []*hardware.FabricInterfaceSet{twoIfaces},
+			expResets:    1,
+			expEvtIfaces: []string{"test1"},
+			expEvtMsgs:   []string{"fabric interface test1 added with providers ofi+tcp, ofi+verbs"},
+			expDevices:   []string{"test0", "test1"},
+		},
+		"fabric interface found on retry": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+				netIfaces("test0", "test1"),
+				netIfaces("test0", "test1"),
+			},
+			scans:        []*hardware.FabricInterfaceSet{oneIface, twoIfaces},
+			expResets:    2,
+			expEvtIfaces: []string{"test1"},
+			expEvtMsgs:   []string{"fabric interface test1 added with providers ofi+tcp, ofi+verbs"},
+			expDevices:   []string{"test0", "test1"},
+		},
+		"non-fabric interface added": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "lo1"),
+				netIfaces("test0", "lo1"),
+				netIfaces("test0", "lo1"),
+				netIfaces("test0", "lo1"),
+				netIfaces("test0", "lo1"),
+			},
+			scans:      []*hardware.FabricInterfaceSet{oneIface},
+			expResets:  addedIfaceChecks,
+			expDevices: []string{"test0"},
+		},
+		"interface removed before scan finds it": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+				netIfaces("test0"),
+			},
+			scans:      []*hardware.FabricInterfaceSet{oneIface},
+			expResets:  1,
+			expDevices: []string{"test0"},
+		},
+		"rescan fails": {
+			ifaces: [][]net.Interface{
+				netIfaces("test0"),
+				netIfaces("test0", "test1"),
+			},
+			scanErr:    errors.New("mock scan"),
+			expResets:  1,
+			expDevices: []string{"test0"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			checks := 0
+			scans := 0
+			params := testInfoCacheParams{
+				mockNetIfaces: func() ([]net.Interface, error) {
+					return tc.ifaces[checks], nil
+				},
+				mockScanFabric: func(ctx context.Context, _ ...string) (*NUMAFabric, error) {
+					if tc.scanErr != nil {
+						return nil, tc.scanErr
+					}
+					set := tc.scans[len(tc.scans)-1]
+					if scans < len(tc.scans) {
+						set = tc.scans[scans]
+					}
+					scans++
+					return NUMAFabricFromScan(ctx, log, set), nil
+				},
+			}
+			ic := newTestInfoCache(t, log, params)
+			ic.ignoreIfaces = common.NewStringSet(tc.ignore...)
+			resets := 0
+			ic.resetFabricScan = func() error {
+				resets++
+				return nil
+			}
+			if tc.static {
+				ic.EnableStaticFabricCache(test.Context(t), NUMAFabricFromScan(test.Context(t), log, oneIface))
+			} else if !tc.noFabric {
+				if err := ic.cache.Set(&cachedFabricInfo{
+					cacheItem: cacheItem{
+						lastCached: time.Now(),
+					},
+					fetch:       ic.scanFabric,
+					lastResults: NUMAFabricFromScan(test.Context(t), log, oneIface),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			handler := &mockEventHandler{}
+			for checks = 0; checks < len(tc.ifaces); checks++ {
+				ic.checkAddedFabricDevices(test.Context(t), handler)
+			}
+
+			test.AssertEqual(t, tc.expResets, resets, "unexpected number of fabric scanner resets")
+
+			var evtIfaces, evtMsgs []string
+			for _, evt := range handler.rx {
+				test.AssertEqual(t, events.RASAgentFabricIfaceAdded, evt.ID, "")
+				test.AssertEqual(t, events.RASSeverityNotice, evt.Severity, "")
+				evtIfaces = append(evtIfaces, evt.HWID)
+				evtMsgs = append(evtMsgs, evt.Msg)
+			}
+			if diff := cmp.Diff(tc.expEvtIfaces, evtIfaces); diff != "" {
+				t.Fatalf("unexpected events (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expEvtMsgs, evtMsgs); diff != "" {
+				t.Fatalf("unexpected event messages (-want, +got):\n%s\n", diff)
+			}
+
+			devices, err := ic.cachedFabricDevices(test.Context(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expDevices, devices); diff != "" {
+				t.Fatalf("unexpected cached devices (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
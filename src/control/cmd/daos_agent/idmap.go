@@ -0,0 +1,246 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// IdentityMapRule maps client processes to a DAOS identity based on the agent
+// socket they connected to and/or the cgroup they belong to. This allows
+// processes running in a user namespace (e.g. an unprivileged container),
+// whose UID as seen by the agent has no meaningful local account, to be
+// assigned the intended DAOS principals.
+type IdentityMapRule struct {
+	SocketPath string   `yaml:"socket_path,omitempty"`
+	Cgroup     string   `yaml:"cgroup,omitempty"`
+	UID        *uint32  `yaml:"uid,omitempty"`
+	User       string   `yaml:"user"`
+	Group      string   `yaml:"group"`
+	Groups     []string `yaml:"groups,omitempty"`
+}
+
+func (r *IdentityMapRule) String() string {
+	var match []string
+	if r.SocketPath != "" {
+		match = append(match, "socket "+r.SocketPath)
+	}
+	if r.Cgroup != "" {
+		match = append(match, "cgroup "+r.Cgroup)
+	}
+	if r.UID != nil {
+		match = append(match, fmt.Sprintf("uid %d", *r.UID))
+	}
+	return fmt.Sprintf("%s -> %s:%s", strings.Join(match, ","), r.User, r.Group)
+}
+
+// Validate checks that the rule has at least one match criterion and a
+// complete identity to map to.
+func (r *IdentityMapRule) Validate() error {
+	if r.SocketPath == "" && r.Cgroup == "" {
+		return errors.New("identity mapping rule requires socket_path or cgroup")
+	}
+	if r.SocketPath != "" && !filepath.IsAbs(r.SocketPath) {
+		return errors.Errorf("identity mapping socket_path %q must be absolute", r.SocketPath)
+	}
+	if r.Cgroup != "" && !strings.HasPrefix(r.Cgroup, "/") {
+		return errors.Errorf("identity mapping cgroup %q must start with /", r.Cgroup)
+	}
+	if r.User == "" || r.Group == "" {
+		return errors.New("identity mapping rule requires user and group")
+	}
+	return nil
+}
+
+// Identity returns the DAOS identity assigned by the rule.
+func (r *IdentityMapRule) Identity() *auth.MappedIdentity {
+	return &auth.MappedIdentity{
+		User:   r.User,
+		Group:  r.Group,
+		Groups: r.Groups,
+	}
+}
+
+func (r *IdentityMapRule) matches(sockPath string, uid uint32, cgroups []string) bool {
+	if r.SocketPath != "" && filepath.Clean(r.SocketPath) != filepath.Clean(sockPath) {
+		return false
+	}
+	if r.UID != nil && *r.UID != uid {
+		return false
+	}
+	if r.Cgroup == "" {
+		return true
+	}
+
+	prefix := strings.TrimSuffix(r.Cgroup, "/")
+	for _, cg := range cgroups {
+		if cg == prefix || strings.HasPrefix(cg, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+type (
+	cgroupGetterFn func(pid int32) ([]string, error)
+
+	// identityMapper selects the first identity mapping rule that applies
+	// to a client process. The configured rules are followed by the rules
+	// added for container pods running on the node.
+	identityMapper struct {
+		rules      []*IdentityMapRule
+		getCgroups cgroupGetterFn
+
+		podRulesMu sync.RWMutex
+		podRules   map[string]*IdentityMapRule // pod UID -> rule
+	}
+)
+
+func newIdentityMapper(rules []*IdentityMapRule) *identityMapper {
+	return &identityMapper{
+		rules:      rules,
+		getCgroups: procCgroups,
+		podRules:   make(map[string]*IdentityMapRule),
+	}
+}
+
+// addPodRule adds or replaces the rule for a container pod.
+func (m *identityMapper) addPodRule(podUID string, rule *IdentityMapRule) error {
+	if m == nil {
+		return errors.New("identity mapping is not available")
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	m.podRulesMu.Lock()
+	defer m.podRulesMu.Unlock()
+
+	if m.podRules == nil {
+		m.podRules = make(map[string]*IdentityMapRule)
+	}
+	m.podRules[podUID] = rule
+	return nil
+}
+
+// removePodRule removes the rule for a container pod. It returns false if the
+// pod has no rule.
+func (m *identityMapper) removePodRule(podUID string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.podRulesMu.Lock()
+	defer m.podRulesMu.Unlock()
+
+	if _, found := m.podRules[podUID]; !found {
+		return false
+	}
+	delete(m.podRules, podUID)
+	return true
+}
+
+func (m *identityMapper) allRules() []*IdentityMapRule {
+	m.podRulesMu.RLock()
+	defer m.podRulesMu.RUnlock()
+
+	if len(m.podRules) == 0 {
+		return m.rules
+	}
+
+	rules := make([]*IdentityMapRule, 0, len(m.rules)+len(m.podRules))
+	rules = append(rules, m.rules...)
+	for _, r := range m.podRules {
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// socketPaths returns the distinct extra agent socket paths referenced by the
+// rules.
+func (m *identityMapper) socketPaths() []string {
+	if m == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var paths []string
+	for _, r := range m.rules {
+		if r.SocketPath == "" {
+			continue
+		}
+		path := filepath.Clean(r.SocketPath)
+		if _, found := seen[path]; found {
+			continue
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// lookup returns the rule that applies to the client process connected on the
+// given socket path, or nil if none does.
+func (m *identityMapper) lookup(sockPath string, info *security.DomainInfo) (*IdentityMapRule, error) {
+	if m == nil {
+		return nil, nil
+	}
+	rules := m.allRules()
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	var cgroups []string
+	for _, r := range rules {
+		if r.Cgroup != "" && cgroups == nil {
+			var err error
+			if cgroups, err = m.getCgroups(info.Pid()); err != nil {
+				return nil, errors.Wrapf(err, "getting cgroups for pid %d", info.Pid())
+			}
+		}
+		if r.matches(sockPath, info.Uid(), cgroups) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// procCgroups returns the cgroup paths of the process, one per hierarchy.
+func procCgroups(pid int32) ([]string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseCgroups(f)
+}
+
+func parseCgroups(r io.Reader) ([]string, error) {
+	cgroups := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// format is hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		cgroups = append(cgroups, fields[2])
+	}
+	return cgroups, scanner.Err()
+}
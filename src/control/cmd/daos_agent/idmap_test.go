@@ -0,0 +1,214 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+func testUID(uid uint32) *uint32 {
+	return &uid
+}
+
+func TestAgent_IdentityMapRule_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule   *IdentityMapRule
+		expErr error
+	}{
+		"no match criteria": {
+			rule:   &IdentityMapRule{User: "user", Group: "group"},
+			expErr: errors.New("requires socket_path or cgroup"),
+		},
+		"relative socket path": {
+			rule:   &IdentityMapRule{SocketPath: "agent.sock", User: "user", Group: "group"},
+			expErr: errors.New("must be absolute"),
+		},
+		"relative cgroup": {
+			rule:   &IdentityMapRule{Cgroup: "machine.slice", User: "user", Group: "group"},
+			expErr: errors.New("must start with /"),
+		},
+		"no user": {
+			rule:   &IdentityMapRule{Cgroup: "/machine.slice", Group: "group"},
+			expErr: errors.New("requires user and group"),
+		},
+		"no group": {
+			rule:   &IdentityMapRule{Cgroup: "/machine.slice", User: "user"},
+			expErr: errors.New("requires user and group"),
+		},
+		"valid": {
+			rule: &IdentityMapRule{SocketPath: "/run/agent.sock", Cgroup: "/machine.slice", User: "user", Group: "group"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.rule.Validate())
+		})
+	}
+}
+
+func TestAgent_identityMapper_lookup(t *testing.T) {
+	sockRule := &IdentityMapRule{SocketPath: "/run/container.sock", User: "sock", Group: "group"}
+	sockUIDRule := &IdentityMapRule{SocketPath: "/run/container.sock", UID: testUID(100000), User: "sockuid", Group: "group"}
+	cgroupRule := &IdentityMapRule{Cgroup: "/machine.slice/libpod-1234/", User: "cgroup", Group: "group"}
+
+	for name, tc := range map[string]struct {
+		rules     []*IdentityMapRule
+		sockPath  string
+		uid       uint32
+		cgroups   []string
+		cgroupErr error
+		expRule   *IdentityMapRule
+		expErr    error
+	}{
+		"no rules": {
+			sockPath: "/run/daos_agent.sock",
+		},
+		"socket match": {
+			rules:    []*IdentityMapRule{sockRule},
+			sockPath: "/run/container.sock",
+			expRule:  sockRule,
+		},
+		"socket mismatch": {
+			rules:    []*IdentityMapRule{sockRule},
+			sockPath: "/run/daos_agent.sock",
+		},
+		"first matching rule wins": {
+			rules:    []*IdentityMapRule{sockUIDRule, sockRule},
+			sockPath: "/run/container.sock",
+			uid:      100000,
+			expRule:  sockUIDRule,
+		},
+		"uid mismatch falls through": {
+			rules:    []*IdentityMapRule{sockUIDRule, sockRule},
+			sockPath: "/run/container.sock",
+			uid:      100001,
+			expRule:  sockRule,
+		},
+		"cgroup match": {
+			rules:    []*IdentityMapRule{cgroupRule},
+			sockPath: "/run/daos_agent.sock",
+			cgroups:  []string{"/user.slice", "/machine.slice/libpod-1234/container"},
+			expRule:  cgroupRule,
+		},
+		"cgroup exact match": {
+			rules:    []*IdentityMapRule{cgroupRule},
+			sockPath: "/run/daos_agent.sock",
+			cgroups:  []string{"/machine.slice/libpod-1234"},
+			expRule:  cgroupRule,
+		},
+		"cgroup prefix is not a parent": {
+			rules:    []*IdentityMapRule{cgroupRule},
+			sockPath: "/run/daos_agent.sock",
+			cgroups:  []string{"/machine.slice/libpod-12345"},
+		},
+		"cgroup lookup fails": {
+			rules:     []*IdentityMapRule{cgroupRule},
+			sockPath:  "/run/daos_agent.sock",
+			cgroupErr: errors.New("mock cgroups"),
+			expErr:    errors.New("mock cgroups"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mapper := newIdentityMapper(tc.rules)
+			mapper.getCgroups = func(pid int32) ([]string, error) {
+				test.AssertEqual(t, int32(42), pid, "unexpected pid")
+				return tc.cgroups, tc.cgroupErr
+			}
+
+			info := security.InitDomainInfo(&syscall.Ucred{Pid: 42, Uid: tc.uid}, "")
+			rule, err := mapper.lookup(tc.sockPath, info)
+			test.CmpErr(t, tc.expErr, err)
+			if rule != tc.expRule {
+				t.Fatalf("expected rule %v, got %v", tc.expRule, rule)
+			}
+		})
+	}
+}
+
+func TestAgent_identityMapper_podRules(t *testing.T) {
+	cfgRule := &IdentityMapRule{SocketPath: "/run/container.sock", User: "cfg", Group: "group"}
+	podRule := &IdentityMapRule{Cgroup: "/kubepods/pod1", UID: testUID(101001), User: "alice", Group: "group"}
+
+	mapper := newIdentityMapper([]*IdentityMapRule{cfgRule})
+	mapper.getCgroups = func(int32) ([]string, error) {
+		return []string{"/kubepods/pod1/container"}, nil
+	}
+	info := security.InitDomainInfo(&syscall.Ucred{Pid: 42, Uid: 101001}, "")
+
+	test.CmpErr(t, errors.New("requires user and group"),
+		mapper.addPodRule("pod1", &IdentityMapRule{Cgroup: "/kubepods/pod1"}))
+	if err := mapper.addPodRule("pod1", podRule); err != nil {
+		t.Fatal(err)
+	}
+
+	// configured rules take precedence
+	rule, err := mapper.lookup("/run/container.sock", info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != cfgRule {
+		t.Fatalf("expected rule %v, got %v", cfgRule, rule)
+	}
+
+	rule, err = mapper.lookup("/run/daos_agent.sock", info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != podRule {
+		t.Fatalf("expected rule %v, got %v", podRule, rule)
+	}
+
+	test.AssertTrue(t, mapper.removePodRule("pod1"), "pod1 rule not found")
+	test.AssertFalse(t, mapper.removePodRule("pod1"), "pod1 rule removed twice")
+	rule, err = mapper.lookup("/run/daos_agent.sock", info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule != nil {
+		t.Fatalf("expected no rule, got %v", rule)
+	}
+}
+
+func TestAgent_identityMapper_socketPaths(t *testing.T) {
+	mapper := newIdentityMapper([]*IdentityMapRule{
+		{SocketPath: "/run/one.sock"},
+		{Cgroup: "/machine.slice"},
+		{SocketPath: "/run//one.sock"},
+		{SocketPath: "/run/two.sock"},
+	})
+
+	if diff := cmp.Diff([]string{"/run/one.sock", "/run/two.sock"}, mapper.socketPaths()); diff != "" {
+		t.Fatalf("unexpected socket paths (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestAgent_parseCgroups(t *testing.T) {
+	cgroups, err := parseCgroups(strings.NewReader(`12:memory:/machine.slice/libpod-1234
+1:name=systemd:/user.slice/user-1000.slice
+0::/machine.slice/libpod-1234/container
+malformed
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expCgroups := []string{
+		"/machine.slice/libpod-1234",
+		"/user.slice/user-1000.slice",
+		"/machine.slice/libpod-1234/container",
+	}
+	if diff := cmp.Diff(expCgroups, cgroups); diff != "" {
+		t.Fatalf("unexpected cgroups (-want, +got):\n%s\n", diff)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -32,21 +33,35 @@ const (
 	fabricKey     = "NUMAFabric"
 )
 
+// agentComponent returns the versioned component of this agent, or nil if the
+// version of the agent can't be determined.
+func agentComponent() *build.VersionedComponent {
+	self, err := build.NewVersionedComponent(build.ComponentAgent, build.DaosVersion)
+	if err != nil {
+		return nil
+	}
+	return self
+}
+
 type getAttachInfoFn func(ctx context.Context, rpcClient control.UnaryInvoker, req *control.GetAttachInfoReq) (*control.GetAttachInfoResp, error)
 type fabricScanFn func(ctx context.Context, providers ...string) (*NUMAFabric, error)
 
 // NewInfoCache creates a new InfoCache with appropriate parameters set.
 func NewInfoCache(ctx context.Context, log logging.Logger, client control.UnaryInvoker, cfg *Config) *InfoCache {
+	scanner := hwprov.DefaultFabricScanner(log)
 	ic := &InfoCache{
 		log:             log,
+		self:            agentComponent(),
 		ignoreIfaces:    cfg.ExcludeFabricIfaces,
 		client:          client,
 		cache:           cache.NewItemCache(log),
 		getAttachInfoCb: control.GetAttachInfo,
-		fabricScan:      getFabricScanFn(log, cfg, hwprov.DefaultFabricScanner(log)),
+		fabricScan:      getFabricScanFn(log, cfg, scanner),
+		resetFabricScan: scanner.Reset,
 		netIfaces:       net.Interfaces,
 		devClassGetter:  hwprov.DefaultNetDevClassProvider(log),
 		devStateGetter:  hwprov.DefaultNetDevStateProvider(log),
+		failedIfaces:    newFailedDevices(),
 	}
 
 	ic.clientTelemetryEnabled.Store(cfg.TelemetryEnabled)
@@ -199,15 +214,19 @@ func (cfi *cachedFabricInfo) Refresh(ctx context.Context) error {
 
 // InfoCache is a cache for the results of expensive operations needed by the agent.
 type InfoCache struct {
+	generation              uint64 // accessed atomically; first for 64-bit alignment
 	log                     logging.Logger
+	self                    *build.VersionedComponent
 	cache                   *cache.ItemCache
 	fabricCacheDisabled     atm.Bool
+	fabricCacheStatic       atm.Bool
 	attachInfoCacheDisabled atm.Bool
 	clientTelemetryEnabled  atm.Bool
 	clientTelemetryRetain   atm.Bool
 
 	getAttachInfoCb getAttachInfoFn
 	fabricScan      fabricScanFn
+	resetFabricScan func() error
 	netIfaces       func() ([]net.Interface, error)
 	devClassGetter  hardware.NetDevClassProvider
 	devStateGetter  hardware.NetDevStateProvider
@@ -216,6 +235,22 @@ type InfoCache struct {
 	attachInfoRefresh time.Duration
 	providers         common.StringSet
 	ignoreIfaces      common.StringSet
+	failedIfaces      *failedDevices
+	addedIfaces       addedDevices
+}
+
+// Generation returns a counter that is advanced whenever the cached attach info or fabric
+// data may have changed. Anything derived from the cached data is out of date if the
+// generation has moved on since it was built.
+func (c *InfoCache) Generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&c.generation)
+}
+
+func (c *InfoCache) bumpGeneration() {
+	atomic.AddUint64(&c.generation, 1)
 }
 
 // AddProvider adds a fabric provider to the scan list.
@@ -243,6 +278,7 @@ func (c *InfoCache) DisableAttachInfoCache() {
 		return
 	}
 	c.attachInfoCacheDisabled.Store(true)
+	c.bumpGeneration()
 }
 
 // EnableAttachInfoCache enables a refreshable GetAttachInfo cache.
@@ -252,6 +288,7 @@ func (c *InfoCache) EnableAttachInfoCache(interval time.Duration) {
 	}
 	c.attachInfoRefresh = interval
 	c.attachInfoCacheDisabled.Store(false)
+	c.bumpGeneration()
 }
 
 // IsFabricCacheEnabled checks whether the NUMAFabric cache is enabled.
@@ -268,6 +305,7 @@ func (c *InfoCache) DisableFabricCache() {
 		return
 	}
 	c.fabricCacheDisabled.Store(true)
+	c.bumpGeneration()
 }
 
 // EnableFabricCache enables a refreshable local fabric cache.
@@ -276,6 +314,8 @@ func (c *InfoCache) EnableFabricCache() {
 		return
 	}
 	c.fabricCacheDisabled.Store(false)
+	c.fabricCacheStatic.Store(false)
+	c.bumpGeneration()
 }
 
 // EnableStaticFabricCache sets up a fabric cache based on a static value that cannot be refreshed.
@@ -290,12 +330,13 @@ func (c *InfoCache) EnableStaticFabricCache(ctx context.Context, nf *NUMAFabric)
 		fetch: func(context.Context, ...string) (*NUMAFabric, error) {
 			return nf, nil
 		},
-		lastResults: nf,
+		lastResults: nf.WithFailedDevices(c.failedIfaces),
 	}
 	if err := c.cache.Set(item); err != nil {
 		c.log.Errorf("error setting static fabric cache: %v", err)
 	}
 	c.EnableFabricCache()
+	c.fabricCacheStatic.Store(true)
 }
 
 func (c *InfoCache) getAttachInfo(ctx context.Context, rpcClient control.UnaryInvoker, req *control.GetAttachInfoReq) (*control.GetAttachInfoResp, error) {
@@ -310,7 +351,20 @@ func (c *InfoCache) getAttachInfo(ctx context.Context, rpcClient control.UnaryIn
 	if err != nil {
 		return nil, err
 	}
+
+	if c.self != nil {
+		if err := resp.CheckCompatibility(c.self); err != nil {
+			return nil, err
+		}
+	}
+	if resp.MSVersion == "" {
+		c.log.Debug("MS did not report a version; assuming no optional features")
+	} else {
+		c.log.Debugf("MS version %s, features: [%s]", resp.MSVersion, strings.Join(resp.Features, ", "))
+	}
+
 	c.addTelemetrySettings(resp)
+	c.bumpGeneration()
 	return resp, nil
 }
 
@@ -381,6 +435,11 @@ func copyGetAttachInfoResp(orig *control.GetAttachInfoResp) *control.GetAttachIn
 	cp.ServiceRanks = make([]*control.PrimaryServiceRank, len(orig.ServiceRanks))
 	_ = copy(cp.ServiceRanks, orig.ServiceRanks)
 
+	if orig.Features != nil {
+		cp.Features = make([]string, len(orig.Features))
+		_ = copy(cp.Features, orig.Features)
+	}
+
 	if orig.ClientNetHint.EnvVars != nil {
 		cp.ClientNetHint.EnvVars = make([]string, len(orig.ClientNetHint.EnvVars))
 		_ = copy(cp.ClientNetHint.EnvVars, orig.ClientNetHint.EnvVars)
@@ -433,7 +492,11 @@ func (c *InfoCache) getNUMAFabric(ctx context.Context, netDevClass hardware.NetD
 		if err := c.waitFabricReady(ctx, netDevClass); err != nil {
 			return nil, err
 		}
-		return c.fabricScan(ctx, providers...)
+		nf, err := c.fabricScan(ctx, providers...)
+		if err != nil {
+			return nil, err
+		}
+		return nf.WithFailedDevices(c.failedIfaces), nil
 	}
 
 	createItem := func() (cache.Item, error) {
@@ -441,7 +504,7 @@ func (c *InfoCache) getNUMAFabric(ctx context.Context, netDevClass hardware.NetD
 		if err := c.waitFabricReady(ctx, netDevClass); err != nil {
 			return nil, err
 		}
-		return newCachedFabricInfo(c.log, c.fabricScan), nil
+		return newCachedFabricInfo(c.log, c.scanFabric), nil
 	}
 
 	item, release, err := c.cache.GetOrCreate(ctx, fabricKey, createItem)
@@ -458,6 +521,16 @@ func (c *InfoCache) getNUMAFabric(ctx context.Context, netDevClass hardware.NetD
 	return cfi.lastResults, nil
 }
 
+func (c *InfoCache) scanFabric(ctx context.Context, providers ...string) (*NUMAFabric, error) {
+	nf, err := c.fabricScan(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.bumpGeneration()
+	return nf.WithFailedDevices(c.failedIfaces), nil
+}
+
 func (c *InfoCache) waitFabricReady(ctx context.Context, netDevClass hardware.NetDevClass) error {
 	ifaces, err := c.netIfaces()
 	if err != nil {
@@ -56,6 +56,7 @@ func newTestInfoCache(t *testing.T, log logging.Logger, params testInfoCachePara
 		netIfaces:       params.mockNetIfaces,
 		client:          params.ctlInvoker,
 		cache:           c,
+		failedIfaces:    newFailedDevices(),
 	}
 
 	ic.clientTelemetryEnabled.Store(params.enableClientTelemetry)
@@ -726,6 +727,9 @@ func TestAgent_InfoCache_GetAttachInfo(t *testing.T) {
 	telemEnabledResp.ClientNetHint.EnvVars = append(telemEnabledResp.ClientNetHint.EnvVars,
 		fmt.Sprintf("%s=1", telemetry.ClientMetricsEnabledEnv),
 	)
+	versionedResp := copyGetAttachInfoResp(ctlResp)
+	versionedResp.MSVersion = "2.6.0"
+	versionedResp.Features = []string{build.FeatureSystemCleanup.String()}
 	telemRetainedResp := copyGetAttachInfoResp(telemEnabledResp)
 	telemRetainedResp.ClientNetHint.EnvVars = append(telemRetainedResp.ClientNetHint.EnvVars,
 		fmt.Sprintf("%s=1", telemetry.ClientMetricsRetainEnv),
@@ -815,6 +819,27 @@ func TestAgent_InfoCache_GetAttachInfo(t *testing.T) {
 			expErr:    errors.New("mock remote"),
 			expRemote: true,
 		},
+		"MS version compatible": {
+			getInfoCache: func(l logging.Logger) *InfoCache {
+				ic := newTestInfoCache(t, l, testInfoCacheParams{})
+				ic.self = testComponent(t, build.ComponentAgent, "2.4.0")
+				return ic
+			},
+			remoteResp: copyGetAttachInfoResp(versionedResp),
+			expResp:    versionedResp,
+			expRemote:  true,
+			expCached:  true,
+		},
+		"MS version incompatible": {
+			getInfoCache: func(l logging.Logger) *InfoCache {
+				ic := newTestInfoCache(t, l, testInfoCacheParams{})
+				ic.self = testComponent(t, build.ComponentAgent, "2.2.0")
+				return ic
+			},
+			remoteResp: copyGetAttachInfoResp(versionedResp),
+			expErr:     errors.New("not compatible"),
+			expRemote:  true,
+		},
 		"enabled and cached": {
 			getInfoCache: func(l logging.Logger) *InfoCache {
 				ic := newTestInfoCache(t, l, testInfoCacheParams{})
@@ -1420,3 +1445,13 @@ func TestAgent_InfoCache_waitFabricReady(t *testing.T) {
 		})
 	}
 }
+
+func testComponent(t *testing.T, comp build.Component, version string) *build.VersionedComponent {
+	t.Helper()
+
+	vc, err := build.NewVersionedComponent(comp, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vc
+}
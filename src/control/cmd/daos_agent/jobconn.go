@@ -0,0 +1,401 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// jobConnHelperRestartDelay is the time to wait before restarting a job
+	// connection helper that has exited.
+	jobConnHelperRestartDelay = 10 * time.Second
+	// jobConnPruneInterval is the period at which the connections of
+	// processes that have exited are discarded, in case the helper missed
+	// their close events.
+	jobConnPruneInterval = 30 * time.Second
+
+	jobConnEventConnect = "connect"
+	jobConnEventClose   = "close"
+)
+
+// jobIDEnvVars are the environment variables checked, in order, for the job
+// ID of a client process if it has not set one with DAOS_JOBID_ENV.
+var jobIDEnvVars = []string{"DAOS_JOBID", "SLURM_JOB_ID", "PBS_JOBID"}
+
+// JobCorrelationConfig configures a helper program that reports the fabric
+// connections opened and closed by local processes, typically by tracing
+// them with eBPF. The agent correlates the connections with the jobs of
+// the processes, so that per-job connection counts can be reported when
+// several jobs share a node.
+type JobCorrelationConfig struct {
+	Helper string   `yaml:"helper"`
+	Args   []string `yaml:"args,omitempty"`
+}
+
+// Validate checks that the helper is an absolute path.
+func (jcc *JobCorrelationConfig) Validate() error {
+	if jcc == nil {
+		return nil
+	}
+
+	if jcc.Helper == "" {
+		return errors.New("helper is required")
+	}
+	if !filepath.IsAbs(jcc.Helper) {
+		return errors.Errorf("helper %q must be an absolute path", jcc.Helper)
+	}
+
+	return nil
+}
+
+// jobConnEvent is a line of the helper's output. Conn uniquely identifies the
+// connection, e.g. by socket cookie, so that its close can be matched.
+type jobConnEvent struct {
+	Event string `json:"event"`
+	Pid   int32  `json:"pid"`
+	Conn  uint64 `json:"conn"`
+	Peer  string `json:"peer,omitempty"`
+}
+
+// jobConnProcKey identifies a process by its pid and start time, so that a
+// process that reuses the pid of an exited one is not mistaken for it.
+type jobConnProcKey struct {
+	pid   int32
+	start uint64
+}
+
+type jobConnProc struct {
+	jobID string
+	conns int
+}
+
+// jobConnTracker keeps count of the open connections of the processes of
+// each job on the node, as reported by the job correlation helper. Processes
+// without a job ID are not tracked.
+type jobConnTracker struct {
+	sync.RWMutex
+	log           logging.Logger
+	getJobID      func(int32) (string, error)
+	procStartTime func(int32) (uint64, error)
+	conns         map[uint64]jobConnProcKey // connection -> process
+	procs         map[jobConnProcKey]*jobConnProc
+
+	connDesc *prometheus.Desc
+	procDesc *prometheus.Desc
+}
+
+func newJobConnTracker(log logging.Logger) *jobConnTracker {
+	return &jobConnTracker{
+		log:           log,
+		getJobID:      procJobID,
+		procStartTime: procStartTime,
+		conns:         make(map[uint64]jobConnProcKey),
+		procs:         make(map[jobConnProcKey]*jobConnProc),
+		connDesc: prometheus.NewDesc("daos_agent_job_connections",
+			"Open fabric connections of the job's processes on the node", []string{"job"}, nil),
+		procDesc: prometheus.NewDesc("daos_agent_job_processes",
+			"Processes of the job with open fabric connections on the node", []string{"job"}, nil),
+	}
+}
+
+// jobIDFromEnviron returns the job ID found in a process environment.
+func jobIDFromEnviron(environ []string) string {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+
+	vars := jobIDEnvVars
+	if name := env["DAOS_JOBID_ENV"]; name != "" {
+		vars = append([]string{name}, vars...)
+	}
+	for _, name := range vars {
+		if id := env[name]; id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// procJobID returns the job ID from the environment of the process.
+func procJobID(pid int32) (string, error) {
+	buf, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", pid))
+	if err != nil {
+		return "", err
+	}
+
+	return jobIDFromEnviron(strings.Split(string(buf), "\x00")), nil
+}
+
+// procStatStartTime returns the start time of a process, in clock ticks since
+// boot, from the contents of its /proc/<pid>/stat file.
+func procStatStartTime(stat []byte) (uint64, error) {
+	// The command name may contain spaces and parentheses, so the fields
+	// are counted from the end of it. The start time is field 22, and the
+	// first field after the command name is field 3.
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, errors.New("malformed stat: no command name")
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return 0, errors.Errorf("malformed stat: %d fields after command name", len(fields))
+	}
+
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "malformed stat start time")
+	}
+
+	return start, nil
+}
+
+// procStartTime returns the start time of the process.
+func procStartTime(pid int32) (uint64, error) {
+	buf, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	return procStatStartTime(buf)
+}
+
+func (jct *jobConnTracker) handleEvent(ev *jobConnEvent) error {
+	switch ev.Event {
+	case jobConnEventConnect:
+		jct.connect(ev.Pid, ev.Conn, ev.Peer)
+	case jobConnEventClose:
+		jct.close(ev.Conn)
+	default:
+		return errors.Errorf("unknown event %q", ev.Event)
+	}
+
+	return nil
+}
+
+func (jct *jobConnTracker) connect(pid int32, conn uint64, peer string) {
+	jct.Lock()
+	defer jct.Unlock()
+
+	if _, found := jct.conns[conn]; found {
+		return
+	}
+
+	start, err := jct.procStartTime(pid)
+	if err != nil {
+		jct.log.Debugf("pid %d: unable to get start time: %s", pid, err)
+		return
+	}
+	key := jobConnProcKey{pid: pid, start: start}
+
+	proc, found := jct.procs[key]
+	if !found {
+		jobID, err := jct.getJobID(pid)
+		if err != nil {
+			jct.log.Debugf("pid %d: unable to get job ID: %s", pid, err)
+			return
+		}
+		if jobID == "" {
+			return
+		}
+		proc = &jobConnProc{jobID: jobID}
+		jct.procs[key] = proc
+	}
+
+	proc.conns++
+	jct.conns[conn] = key
+	jct.log.Tracef("job %s: pid %d connected to %s", proc.jobID, pid, peer)
+}
+
+func (jct *jobConnTracker) close(conn uint64) {
+	jct.Lock()
+	defer jct.Unlock()
+
+	key, found := jct.conns[conn]
+	if !found {
+		return
+	}
+	delete(jct.conns, conn)
+
+	if proc, found := jct.procs[key]; found {
+		proc.conns--
+		if proc.conns <= 0 {
+			delete(jct.procs, key)
+		}
+	}
+}
+
+// prune discards the connections of processes that no longer exist, including
+// those whose pid has since been reused by another process.
+func (jct *jobConnTracker) prune() {
+	jct.Lock()
+	defer jct.Unlock()
+
+	for key, proc := range jct.procs {
+		if start, err := jct.procStartTime(key.pid); err == nil && start == key.start {
+			continue
+		}
+		jct.log.Debugf("job %s: pid %d exited with %d connections open", proc.jobID, key.pid, proc.conns)
+		delete(jct.procs, key)
+	}
+	for conn, key := range jct.conns {
+		if _, found := jct.procs[key]; !found {
+			delete(jct.conns, conn)
+		}
+	}
+}
+
+// jobConns returns the process and connection counts of each job, sorted by
+// job ID.
+func (jct *jobConnTracker) jobConns() []*mgmtpb.AgentJobConns {
+	if jct == nil {
+		return nil
+	}
+
+	jct.RLock()
+	defer jct.RUnlock()
+
+	byJob := make(map[string]*mgmtpb.AgentJobConns)
+	for _, proc := range jct.procs {
+		jc, found := byJob[proc.jobID]
+		if !found {
+			jc = &mgmtpb.AgentJobConns{Jobid: proc.jobID}
+			byJob[proc.jobID] = jc
+		}
+		jc.Processes++
+		jc.Connections += uint32(proc.conns)
+	}
+
+	jobs := make([]*mgmtpb.AgentJobConns, 0, len(byJob))
+	for _, jc := range byJob {
+		jobs = append(jobs, jc)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Jobid < jobs[j].Jobid
+	})
+
+	return jobs
+}
+
+// Describe implements prometheus.Collector.
+func (jct *jobConnTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jct.connDesc
+	ch <- jct.procDesc
+}
+
+// Collect implements prometheus.Collector.
+func (jct *jobConnTracker) Collect(ch chan<- prometheus.Metric) {
+	for _, jc := range jct.jobConns() {
+		ch <- prometheus.MustNewConstMetric(jct.connDesc, prometheus.GaugeValue, float64(jc.Connections), jc.Jobid)
+		ch <- prometheus.MustNewConstMetric(jct.procDesc, prometheus.GaugeValue, float64(jc.Processes), jc.Jobid)
+	}
+}
+
+// readEvents handles the events read from the helper's output until it is
+// closed.
+func (jct *jobConnTracker) readEvents(r *bufio.Scanner) error {
+	for r.Scan() {
+		line := bytes.TrimSpace(r.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		ev := new(jobConnEvent)
+		if err := json.Unmarshal(line, ev); err != nil {
+			jct.log.Errorf("job connection helper: invalid event %q: %s", line, err)
+			continue
+		}
+		if err := jct.handleEvent(ev); err != nil {
+			jct.log.Errorf("job connection helper: %s", err)
+		}
+	}
+
+	return r.Err()
+}
+
+// runHelper runs the helper until it exits.
+func (jct *jobConnTracker) runHelper(ctx context.Context, cfg *JobCorrelationConfig) error {
+	cmd := exec.CommandContext(ctx, cfg.Helper, cfg.Args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	jct.log.Debugf("started job connection helper %s (pid %d)", cfg.Helper, cmd.Process.Pid)
+
+	readErr := jct.readEvents(bufio.NewScanner(stdout))
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Errorf("%s: %s", err, msg)
+		}
+		return err
+	}
+
+	return readErr
+}
+
+// start runs the helper in the background, restarting it if it exits, and
+// periodically discards the connections of exited processes.
+func (jct *jobConnTracker) start(ctx context.Context, cfg *JobCorrelationConfig) {
+	go func() {
+		for {
+			err := jct.runHelper(ctx, cfg)
+			if ctx.Err() != nil {
+				return
+			}
+			jct.log.Errorf("job connection helper %s exited: %v; restarting in %s",
+				cfg.Helper, err, jobConnHelperRestartDelay)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jobConnHelperRestartDelay):
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(jobConnPruneInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				jct.prune()
+			}
+		}
+	}()
+}
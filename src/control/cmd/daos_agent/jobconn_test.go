@@ -0,0 +1,309 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_jobIDFromEnviron(t *testing.T) {
+	for name, tc := range map[string]struct {
+		environ []string
+		expID   string
+	}{
+		"no job": {
+			environ: []string{"HOME=/home/frodo", "PATH=/usr/bin"},
+		},
+		"slurm": {
+			environ: []string{"SLURM_JOB_ID=1234", "PBS_JOBID=5678"},
+			expID:   "1234",
+		},
+		"pbs": {
+			environ: []string{"PBS_JOBID=5678.pbs"},
+			expID:   "5678.pbs",
+		},
+		"daos jobid preferred": {
+			environ: []string{"SLURM_JOB_ID=1234", "DAOS_JOBID=mine"},
+			expID:   "mine",
+		},
+		"custom jobid variable": {
+			environ: []string{"DAOS_JOBID_ENV=MY_JOB", "MY_JOB=custom", "SLURM_JOB_ID=1234"},
+			expID:   "custom",
+		},
+		"custom jobid variable unset": {
+			environ: []string{"DAOS_JOBID_ENV=MY_JOB", "SLURM_JOB_ID=1234"},
+			expID:   "1234",
+		},
+		"empty value": {
+			environ: []string{"SLURM_JOB_ID=", "PBS_JOBID=5678"},
+			expID:   "5678",
+		},
+		"value containing separator": {
+			environ: []string{"DAOS_JOBID=a=b", ""},
+			expID:   "a=b",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expID, jobIDFromEnviron(tc.environ), "unexpected job ID")
+		})
+	}
+}
+
+func TestAgent_procStatStartTime(t *testing.T) {
+	for name, tc := range map[string]struct {
+		stat     string
+		expStart uint64
+		expErr   error
+	}{
+		"simple": {
+			stat:     "4242 (ior) S 4241 4242 4100 34816 4242 4194304 1234 0 0 0 12 3 0 0 20 0 1 0 987654 123456789 2345 18446744073709551615\n",
+			expStart: 987654,
+		},
+		"command name with spaces and parentheses": {
+			stat:     "4242 (my (odd) app) R 1 4242 4242 0 -1 4194560 100 0 0 0 0 0 0 0 20 0 4 0 5555 0 0\n",
+			expStart: 5555,
+		},
+		"no command name": {
+			stat:   "4242 ior S 1",
+			expErr: errors.New("no command name"),
+		},
+		"truncated": {
+			stat:   "4242 (ior) S 4241 4242",
+			expErr: errors.New("fields after command name"),
+		},
+		"bad start time": {
+			stat:   "4242 (ior) S 4241 4242 4100 34816 4242 4194304 1234 0 0 0 12 3 0 0 20 0 1 0 soon 0",
+			expErr: errors.New("start time"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			start, err := procStatStartTime([]byte(tc.stat))
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expStart, start, "unexpected start time")
+		})
+	}
+}
+
+// newTestJobConnTracker returns a tracker for the given processes, keyed by pid
+// with their start times. A process is not running if it has no start time.
+func newTestJobConnTracker(log logging.Logger, jobs map[int32]string, starts map[int32]uint64) *jobConnTracker {
+	jct := newJobConnTracker(log)
+	jct.getJobID = func(pid int32) (string, error) {
+		if _, found := starts[pid]; !found {
+			return "", errors.New("no such process")
+		}
+		return jobs[pid], nil
+	}
+	jct.procStartTime = func(pid int32) (uint64, error) {
+		start, found := starts[pid]
+		if !found {
+			return 0, errors.New("no such process")
+		}
+		return start, nil
+	}
+	return jct
+}
+
+func TestAgent_jobConnTracker(t *testing.T) {
+	jobs := map[int32]string{
+		101: "job1",
+		102: "job1",
+		201: "job2",
+		301: "",
+	}
+
+	for name, tc := range map[string]struct {
+		events      string
+		exited      []int32
+		reused      []int32
+		laterEvents string
+		expJobs     []*mgmtpb.AgentJobConns
+		expConns    int
+	}{
+		"no events": {
+			expJobs: []*mgmtpb.AgentJobConns{},
+		},
+		"connections of several jobs": {
+			events: `
+{"event":"connect","pid":101,"conn":1,"peer":"10.0.0.1:31416"}
+{"event":"connect","pid":101,"conn":2,"peer":"10.0.0.2:31416"}
+{"event":"connect","pid":102,"conn":3,"peer":"10.0.0.1:31416"}
+{"event":"connect","pid":201,"conn":4,"peer":"10.0.0.1:31416"}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 2, Connections: 3},
+				{Jobid: "job2", Processes: 1, Connections: 1},
+			},
+			expConns: 4,
+		},
+		"process without job ignored": {
+			events: `
+{"event":"connect","pid":301,"conn":1}
+{"event":"connect","pid":201,"conn":2}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job2", Processes: 1, Connections: 1},
+			},
+			expConns: 1,
+		},
+		"unknown process ignored": {
+			events: `
+{"event":"connect","pid":999,"conn":1}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{},
+		},
+		"duplicate connect": {
+			events: `
+{"event":"connect","pid":101,"conn":1}
+{"event":"connect","pid":101,"conn":1}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 1, Connections: 1},
+			},
+			expConns: 1,
+		},
+		"close": {
+			events: `
+{"event":"connect","pid":101,"conn":1}
+{"event":"connect","pid":101,"conn":2}
+{"event":"connect","pid":201,"conn":3}
+{"event":"close","pid":101,"conn":1}
+{"event":"close","pid":201,"conn":3}
+{"event":"close","pid":201,"conn":42}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 1, Connections: 1},
+			},
+			expConns: 1,
+		},
+		"bad events skipped": {
+			events: `
+not json
+{"event":"listen","pid":101,"conn":1}
+
+{"event":"connect","pid":102,"conn":2}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 1, Connections: 1},
+			},
+			expConns: 1,
+		},
+		"exited processes pruned": {
+			events: `
+{"event":"connect","pid":101,"conn":1}
+{"event":"connect","pid":102,"conn":2}
+{"event":"connect","pid":201,"conn":3}
+{"event":"connect","pid":201,"conn":4}
+`,
+			exited: []int32{102, 201},
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 1, Connections: 1},
+			},
+			expConns: 1,
+		},
+		"reused pid counted as new process": {
+			events: `
+{"event":"connect","pid":101,"conn":1}
+{"event":"connect","pid":101,"conn":2}
+{"event":"connect","pid":201,"conn":3}
+`,
+			reused: []int32{101},
+			laterEvents: `
+{"event":"connect","pid":101,"conn":4}
+`,
+			expJobs: []*mgmtpb.AgentJobConns{
+				{Jobid: "job1", Processes: 1, Connections: 1},
+				{Jobid: "job2", Processes: 1, Connections: 1},
+			},
+			expConns: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			starts := make(map[int32]uint64)
+			for pid := range jobs {
+				starts[pid] = 1000
+			}
+			jct := newTestJobConnTracker(log, jobs, starts)
+
+			if err := jct.readEvents(bufio.NewScanner(strings.NewReader(tc.events))); err != nil {
+				t.Fatal(err)
+			}
+			for _, pid := range tc.exited {
+				delete(starts, pid)
+			}
+			for _, pid := range tc.reused {
+				starts[pid]++
+			}
+			if err := jct.readEvents(bufio.NewScanner(strings.NewReader(tc.laterEvents))); err != nil {
+				t.Fatal(err)
+			}
+			jct.prune()
+
+			if diff := cmp.Diff(tc.expJobs, jct.jobConns(), protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected job connections (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, tc.expConns, len(jct.conns), "unexpected number of tracked connections")
+		})
+	}
+}
+
+func TestAgent_jobConnTracker_Collect(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	jct := newTestJobConnTracker(log, map[int32]string{101: "job1", 201: "job2"},
+		map[int32]uint64{101: 1000, 201: 1000})
+	for i, pid := range []int32{101, 101, 201} {
+		jct.connect(pid, uint64(i), "")
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(jct)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]map[string]float64)
+	for _, mf := range mfs {
+		test.AssertEqual(t, "GAUGE", mf.GetType().String(), "unexpected type of "+mf.GetName())
+		got[mf.GetName()] = make(map[string]float64)
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == "job" {
+					got[mf.GetName()][lp.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	exp := map[string]map[string]float64{
+		"daos_agent_job_connections": {"job1": 2, "job2": 1},
+		"daos_agent_job_processes":   {"job1": 1, "job2": 1},
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("unexpected metrics (-want, +got):\n%s\n", diff)
+	}
+}
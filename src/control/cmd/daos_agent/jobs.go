@@ -0,0 +1,430 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// cachedUser holds the account information of a user with jobs running on
+// the node, so that credential requests from the job's processes don't each
+// repeat the lookups in the account database.
+type cachedUser struct {
+	name     string
+	gid      uint32
+	groupIDs []uint32
+	groups   map[uint32]*user.Group
+	jobs     int
+}
+
+func (cu *cachedUser) Username() string {
+	return cu.name
+}
+
+func (cu *cachedUser) GroupIDs() ([]uint32, error) {
+	return append([]uint32{}, cu.groupIDs...), nil
+}
+
+func (cu *cachedUser) Gid() (uint32, error) {
+	return cu.gid, nil
+}
+
+// jobUserCache is an auth.UserExt that answers lookups for the users of jobs
+// registered by a job prolog from account information fetched when the job
+// started. The information is dropped by the job epilog once the user has no
+// other jobs on the node. Lookups for other users are passed through.
+type jobUserCache struct {
+	sync.RWMutex
+	log   logging.Logger
+	ext   auth.UserExt
+	jobs  map[string]uint32 // job ID -> uid
+	users map[uint32]*cachedUser
+}
+
+func newJobUserCache(log logging.Logger, ext auth.UserExt) *jobUserCache {
+	return &jobUserCache{
+		log:   log,
+		ext:   ext,
+		jobs:  make(map[string]uint32),
+		users: make(map[uint32]*cachedUser),
+	}
+}
+
+// fetchUser looks up the account information for the uid.
+func (juc *jobUserCache) fetchUser(uid uint32) (*cachedUser, error) {
+	u, err := juc.ext.LookupUserID(uid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up uid %d", uid)
+	}
+	gid, err := u.Gid()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting primary group of user %s", u.Username())
+	}
+	gids, err := u.GroupIDs()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting groups of user %s", u.Username())
+	}
+
+	cu := &cachedUser{
+		name:     u.Username(),
+		gid:      gid,
+		groupIDs: gids,
+		groups:   make(map[uint32]*user.Group),
+	}
+	for _, id := range append([]uint32{gid}, gids...) {
+		g, err := juc.ext.LookupGroupID(id)
+		if err != nil {
+			// left to be looked up on demand
+			juc.log.Debugf("unable to look up gid %d of user %s: %s", id, cu.name, err)
+			continue
+		}
+		cu.groups[id] = g
+	}
+
+	return cu, nil
+}
+
+// addJob fetches the account information of the user running the job,
+// replacing any cached information for that user.
+func (juc *jobUserCache) addJob(jobID string, uid uint32) error {
+	cu, err := juc.fetchUser(uid)
+	if err != nil {
+		return err
+	}
+
+	juc.Lock()
+	defer juc.Unlock()
+
+	if prevUID, found := juc.jobs[jobID]; found {
+		juc.releaseUser(prevUID)
+	}
+	if prev, found := juc.users[uid]; found {
+		cu.jobs = prev.jobs
+	}
+	cu.jobs++
+	juc.users[uid] = cu
+	juc.jobs[jobID] = uid
+
+	juc.log.Debugf("job %s: cached account information for user %s (uid %d)", jobID, cu.name, uid)
+	return nil
+}
+
+func (juc *jobUserCache) releaseUser(uid uint32) {
+	cu, found := juc.users[uid]
+	if !found {
+		return
+	}
+	cu.jobs--
+	if cu.jobs <= 0 {
+		delete(juc.users, uid)
+	}
+}
+
+// removeJob drops the cached account information for the job's user if
+// they have no other jobs on the node. It returns false if the job is unknown.
+func (juc *jobUserCache) removeJob(jobID string) bool {
+	juc.Lock()
+	defer juc.Unlock()
+
+	uid, found := juc.jobs[jobID]
+	if !found {
+		return false
+	}
+	delete(juc.jobs, jobID)
+	juc.releaseUser(uid)
+
+	juc.log.Debugf("job %s: released account information for uid %d", jobID, uid)
+	return true
+}
+
+// Current is not cached, as the agent's own user is not a job user.
+func (juc *jobUserCache) Current() (auth.User, error) {
+	return juc.ext.Current()
+}
+
+func (juc *jobUserCache) LookupUserID(uid uint32) (auth.User, error) {
+	juc.RLock()
+	cu, found := juc.users[uid]
+	juc.RUnlock()
+	if found {
+		return cu, nil
+	}
+
+	return juc.ext.LookupUserID(uid)
+}
+
+func (juc *jobUserCache) LookupGroupID(gid uint32) (*user.Group, error) {
+	juc.RLock()
+	for _, cu := range juc.users {
+		if g, found := cu.groups[gid]; found {
+			juc.RUnlock()
+			gc := *g
+			return &gc, nil
+		}
+	}
+	juc.RUnlock()
+
+	return juc.ext.LookupGroupID(gid)
+}
+
+// jobHookAllowed indicates whether the peer may register jobs with the agent.
+// Job prolog and epilog scripts are run by the job scheduler as root.
+func jobHookAllowed(cred *unix.Ucred) bool {
+	return cred != nil && (cred.Uid == 0 || int(cred.Uid) == os.Getuid())
+}
+
+func jobHookResp(status daos.Status) ([]byte, error) {
+	return proto.Marshal(&mgmtpb.JobHookResp{Status: int32(status)})
+}
+
+// handleJobProlog prepares the agent for a job starting on the node. The
+// attach info and local fabric caches are filled, and the account information
+// of the job's user is cached for use by credential requests from the job's
+// processes, so that they aren't all fetched at once by the starting ranks.
+func (mod *mgmtModule) handleJobProlog(ctx context.Context, reqb []byte, cred *unix.Ucred) ([]byte, error) {
+	pbReq := new(mgmtpb.JobPrologReq)
+	if err := proto.Unmarshal(reqb, pbReq); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	if !jobHookAllowed(cred) {
+		mod.log.Error("job prolog rejected: caller is not root or the agent user")
+		return jobHookResp(daos.NoPermission)
+	}
+	if pbReq.Jobid == "" {
+		return jobHookResp(daos.InvalidInput)
+	}
+	if pbReq.Sys == "" {
+		pbReq.Sys = mod.sys
+	}
+	if pbReq.Sys != mod.sys {
+		mod.log.Errorf("job %s: %s: unknown system name", pbReq.Jobid, pbReq.Sys)
+		return jobHookResp(daos.InvalidInput)
+	}
+	mod.log.Debugf("job %s: prolog for uid %d", pbReq.Jobid, pbReq.Uid)
+
+	if mod.jobUsers != nil {
+		if err := mod.jobUsers.addJob(pbReq.Jobid, pbReq.Uid); err != nil {
+			mod.log.Errorf("job %s: %s", pbReq.Jobid, err)
+			return jobHookResp(daos.Nonexistent)
+		}
+	}
+
+	if _, err := mod.getAttachInfo(ctx, 0, &mgmtpb.GetAttachInfoReq{Sys: pbReq.Sys}); err != nil {
+		mod.log.Errorf("job %s: unable to fetch attach info: %s", pbReq.Jobid, err)
+		if control.IsMSConnectionFailure(err) {
+			return jobHookResp(daos.Unreachable)
+		}
+		return jobHookResp(daos.MiscError)
+	}
+
+	return jobHookResp(daos.Success)
+}
+
+// handleJobEpilog releases the per-job cache entries of a finished job.
+// Jobs which weren't registered by a prolog are ignored.
+func (mod *mgmtModule) handleJobEpilog(_ context.Context, reqb []byte, cred *unix.Ucred) ([]byte, error) {
+	pbReq := new(mgmtpb.JobEpilogReq)
+	if err := proto.Unmarshal(reqb, pbReq); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	if !jobHookAllowed(cred) {
+		mod.log.Error("job epilog rejected: caller is not root or the agent user")
+		return jobHookResp(daos.NoPermission)
+	}
+	if pbReq.Jobid == "" {
+		return jobHookResp(daos.InvalidInput)
+	}
+	mod.log.Debugf("job %s: epilog", pbReq.Jobid)
+
+	if mod.jobUsers != nil && !mod.jobUsers.removeJob(pbReq.Jobid) {
+		mod.log.Debugf("job %s: no prolog was run", pbReq.Jobid)
+	}
+
+	return jobHookResp(daos.Success)
+}
+
+// jobCmd groups the commands run by job scheduler prolog and epilog scripts.
+type jobCmd struct {
+	Prolog jobPrologCmd `command:"prolog" description:"Prepare the local agent for a job starting on this node"`
+	Epilog jobEpilogCmd `command:"epilog" description:"Release local agent resources held for a finished job"`
+}
+
+// jobHookCmd is embedded by the job hook commands. Like the delegation-token
+// command, they only need the location of the agent socket.
+type jobHookCmd struct {
+	cmdutil.LogCmd
+	runtimeDir string
+	JobIDs     []string `long:"job-id" description:"Job ID, may be repeated (default: $SLURM_JOB_ID or $PBS_JOBID)"`
+}
+
+func (cmd *jobHookCmd) setRuntimeDir(dir string) {
+	cmd.runtimeDir = dir
+}
+
+func (cmd *jobHookCmd) jobIDs() ([]string, error) {
+	if len(cmd.JobIDs) > 0 {
+		return cmd.JobIDs, nil
+	}
+	for _, id := range []string{os.Getenv("SLURM_JOB_ID"), os.Getenv("PBS_JOBID")} {
+		if id != "" {
+			return []string{id}, nil
+		}
+	}
+	return nil, errors.New("no job ID supplied and none found in the environment")
+}
+
+func (cmd *jobHookCmd) client() drpc.DomainSocketClient {
+	return agentSocketClient(cmd.runtimeDir)
+}
+
+// agentSocketClient returns a client for the agent socket in the runtime
+// directory.
+func agentSocketClient(runtimeDir string) drpc.DomainSocketClient {
+	if runtimeDir == "" {
+		runtimeDir = defaultRuntimeDir
+	}
+	return drpc.NewClientConnection(filepath.Join(runtimeDir, agentSockName))
+}
+
+type jobPrologCmd struct {
+	jobHookCmd
+	User string `long:"user" description:"Name or UID of the user running the job (default: $SLURM_JOB_UID)"`
+}
+
+func (cmd *jobPrologCmd) uid() (uint32, error) {
+	name := cmd.User
+	if name == "" {
+		name = os.Getenv("SLURM_JOB_UID")
+	}
+	if name == "" {
+		return 0, errors.New("no job user supplied and none found in the environment")
+	}
+
+	if uid, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(uid), nil
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid uid for user %s", name)
+	}
+	return uint32(uid), nil
+}
+
+func (cmd *jobPrologCmd) Execute(_ []string) error {
+	jobIDs, err := cmd.jobIDs()
+	if err != nil {
+		return err
+	}
+	uid, err := cmd.uid()
+	if err != nil {
+		return err
+	}
+
+	reqs := make([]proto.Message, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		reqs = append(reqs, &mgmtpb.JobPrologReq{Jobid: id, Uid: uid})
+	}
+	return sendJobHooks(context.Background(), cmd.client(), drpc.MethodJobProlog, reqs...)
+}
+
+type jobEpilogCmd struct {
+	jobHookCmd
+}
+
+func (cmd *jobEpilogCmd) Execute(_ []string) error {
+	jobIDs, err := cmd.jobIDs()
+	if err != nil {
+		return err
+	}
+
+	reqs := make([]proto.Message, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		reqs = append(reqs, &mgmtpb.JobEpilogReq{Jobid: id})
+	}
+	return sendJobHooks(context.Background(), cmd.client(), drpc.MethodJobEpilog, reqs...)
+}
+
+func sendJobHook(ctx context.Context, client drpc.DomainSocketClient, method drpc.Method, req proto.Message) error {
+	return sendJobHooks(ctx, client, method, req)
+}
+
+// sendJobHooks sends the job hook requests to the agent in a single batch, so
+// that a hook run for several jobs at once takes a single round trip. Every
+// request is handled, and the first failure is returned.
+func sendJobHooks(ctx context.Context, client drpc.DomainSocketClient, method drpc.Method, reqs ...proto.Message) error {
+	calls := make([]*drpc.Call, 0, len(reqs))
+	for _, req := range reqs {
+		body, err := proto.Marshal(req)
+		if err != nil {
+			return errors.Wrapf(err, "marshalling %s request", method)
+		}
+		calls = append(calls, &drpc.Call{
+			Module: method.Module().ID(),
+			Method: method.ID(),
+			Body:   body,
+		})
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		return errors.Wrap(err, "connecting to agent")
+	}
+	defer client.Close()
+
+	drpcResps, err := drpc.SendBatch(ctx, client, calls)
+	if err != nil {
+		return errors.Wrapf(err, "sending %s requests", method)
+	}
+
+	var firstErr error
+	for _, drpcResp := range drpcResps {
+		err := checkJobHookResp(method, drpcResp)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func checkJobHookResp(method drpc.Method, drpcResp *drpc.Response) error {
+	if drpcResp.Status != drpc.Status_SUCCESS {
+		return errors.Errorf("%s request failed: %s", method, drpcResp.Status)
+	}
+
+	resp := new(mgmtpb.JobHookResp)
+	if err := proto.Unmarshal(drpcResp.Body, resp); err != nil {
+		return errors.Wrapf(err, "unmarshalling %s response", method)
+	}
+	if resp.Status != 0 {
+		return errors.Wrapf(daos.Status(resp.Status), "agent failed %s", method)
+	}
+
+	return nil
+}
@@ -0,0 +1,479 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security/auth"
+)
+
+// countingExt is a UserExt with a fixed set of users and groups that counts
+// the lookups made.
+type countingExt struct {
+	users        map[uint32]*cachedUser
+	userLookups  int
+	groupLookups int
+}
+
+func newCountingExt() *countingExt {
+	return &countingExt{
+		users: map[uint32]*cachedUser{
+			1001: {name: "alice", gid: 100, groupIDs: []uint32{100, 200}},
+			1002: {name: "bob", gid: 100, groupIDs: []uint32{100}},
+		},
+	}
+}
+
+func (ce *countingExt) Current() (auth.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (ce *countingExt) LookupUserID(uid uint32) (auth.User, error) {
+	ce.userLookups++
+	if u, found := ce.users[uid]; found {
+		return u, nil
+	}
+	return nil, user.UnknownUserIdError(uid)
+}
+
+func (ce *countingExt) LookupGroupID(gid uint32) (*user.Group, error) {
+	ce.groupLookups++
+	id := strconv.Itoa(int(gid))
+	return &user.Group{Gid: id, Name: "group" + id}, nil
+}
+
+func TestAgent_jobUserCache(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ext := newCountingExt()
+	juc := newJobUserCache(log, ext)
+
+	// uncached lookups are passed through
+	if _, err := juc.LookupUserID(1001); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, ext.userLookups, "unexpected user lookups")
+
+	test.CmpErr(t, errors.New("unknown userid 9999"), juc.addJob("job0", 9999))
+
+	if err := juc.addJob("job1", 1001); err != nil {
+		t.Fatal(err)
+	}
+	if err := juc.addJob("job2", 1001); err != nil {
+		t.Fatal(err)
+	}
+	ext.userLookups = 0
+	ext.groupLookups = 0
+
+	u, err := juc.LookupUserID(1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, "alice", u.Username(), "unexpected user")
+	g, err := juc.LookupGroupID(200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, "group200", g.Name, "unexpected group")
+	test.AssertEqual(t, 0, ext.userLookups, "cached user looked up")
+	test.AssertEqual(t, 0, ext.groupLookups, "cached group looked up")
+
+	// the user still has a job on the node
+	test.AssertTrue(t, juc.removeJob("job1"), "job1 not found")
+	if _, err := juc.LookupUserID(1001); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, ext.userLookups, "cached user looked up")
+
+	test.AssertTrue(t, juc.removeJob("job2"), "job2 not found")
+	test.AssertFalse(t, juc.removeJob("job2"), "job2 removed twice")
+	if _, err := juc.LookupUserID(1001); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := juc.LookupGroupID(200); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, ext.userLookups, "released user not looked up")
+	test.AssertEqual(t, 1, ext.groupLookups, "released group not looked up")
+
+	// re-registering a job with a different user releases the previous one
+	if err := juc.addJob("job3", 1001); err != nil {
+		t.Fatal(err)
+	}
+	if err := juc.addJob("job3", 1002); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, len(juc.users), "unexpected cached users")
+	if _, found := juc.users[1002]; !found {
+		t.Fatal("expected uid 1002 to be cached")
+	}
+}
+
+func TestAgent_handleJobProlog(t *testing.T) {
+	testSys := "test_sys"
+	rootCred := &unix.Ucred{Uid: 0}
+	agentCred := &unix.Ucred{Uid: uint32(os.Getuid())}
+	userCred := &unix.Ucred{Uid: uint32(os.Getuid()) + 1}
+
+	testFIS := hardware.NewFabricInterfaceSet(
+		&hardware.FabricInterface{
+			Name:          "test0",
+			NetInterfaces: common.NewStringSet("test0"),
+			DeviceClass:   hardware.Ether,
+			Providers:     hardware.NewFabricProviderSet(&hardware.FabricProvider{Name: "ofi+tcp"}),
+		})
+
+	for name, tc := range map[string]struct {
+		reqBytes      []byte
+		req           *mgmtpb.JobPrologReq
+		cred          *unix.Ucred
+		getAttachErr  error
+		expStatus     daos.Status
+		expErr        error
+		expCachedUser bool
+	}{
+		"garbage request": {
+			reqBytes: []byte("garbage"),
+			cred:     rootCred,
+			expErr:   drpc.UnmarshalingPayloadFailure(),
+		},
+		"not root": {
+			req:       &mgmtpb.JobPrologReq{Jobid: "job1", Uid: 1001},
+			cred:      userCred,
+			expStatus: daos.NoPermission,
+		},
+		"no job id": {
+			req:       &mgmtpb.JobPrologReq{Uid: 1001},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"wrong system": {
+			req:       &mgmtpb.JobPrologReq{Sys: "bad", Jobid: "job1", Uid: 1001},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"unknown user": {
+			req:       &mgmtpb.JobPrologReq{Jobid: "job1", Uid: 9999},
+			cred:      rootCred,
+			expStatus: daos.Nonexistent,
+		},
+		"attach info fails": {
+			req:           &mgmtpb.JobPrologReq{Jobid: "job1", Uid: 1001},
+			cred:          rootCred,
+			getAttachErr:  errors.New("mock GetAttachInfo"),
+			expStatus:     daos.MiscError,
+			expCachedUser: true,
+		},
+		"success as root": {
+			req:           &mgmtpb.JobPrologReq{Sys: testSys, Jobid: "job1", Uid: 1001},
+			cred:          rootCred,
+			expCachedUser: true,
+		},
+		"success as agent user": {
+			req:           &mgmtpb.JobPrologReq{Jobid: "job1", Uid: 1001},
+			cred:          agentCred,
+			expCachedUser: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var attachCalls int
+			ic := newTestInfoCache(t, log, testInfoCacheParams{
+				mockGetAttachInfo: func(_ context.Context, _ control.UnaryInvoker, _ *control.GetAttachInfoReq) (*control.GetAttachInfoResp, error) {
+					attachCalls++
+					if tc.getAttachErr != nil {
+						return nil, tc.getAttachErr
+					}
+					return &control.GetAttachInfoResp{
+						System: testSys,
+						ClientNetHint: control.ClientNetworkHint{
+							Provider:    "ofi+tcp",
+							NetDevClass: uint32(hardware.Ether),
+						},
+					}, nil
+				},
+				mockScanFabric: func(ctx context.Context, _ ...string) (*NUMAFabric, error) {
+					nf := NUMAFabricFromScan(ctx, log, testFIS)
+					nf.getAddrInterface = mockGetAddrInterface
+					return nf, nil
+				},
+			})
+
+			jobUsers := newJobUserCache(log, newCountingExt())
+			mod := &mgmtModule{
+				log:      log,
+				sys:      testSys,
+				cache:    ic,
+				jobUsers: jobUsers,
+			}
+
+			reqBytes := tc.reqBytes
+			if reqBytes == nil {
+				var err error
+				if reqBytes, err = proto.Marshal(tc.req); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotRespBytes, gotErr := mod.handleJobProlog(test.Context(t), reqBytes, tc.cred)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotResp := new(mgmtpb.JobHookResp)
+			if err := proto.Unmarshal(gotRespBytes, gotResp); err != nil {
+				t.Fatal(err)
+			}
+			expResp := &mgmtpb.JobHookResp{Status: int32(tc.expStatus)}
+			if diff := cmp.Diff(expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+
+			_, cached := jobUsers.users[1001]
+			test.AssertEqual(t, tc.expCachedUser, cached, "unexpected cached user state")
+			if tc.expStatus != daos.Success {
+				return
+			}
+
+			// the attach info cache has been filled by the prolog
+			if _, err := mod.getAttachInfo(test.Context(t), 0, &mgmtpb.GetAttachInfoReq{Sys: testSys}); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, 1, attachCalls, "unexpected number of MS requests")
+		})
+	}
+}
+
+func TestAgent_handleJobEpilog(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.JobEpilogReq
+		cred      *unix.Ucred
+		expStatus daos.Status
+		expCached bool
+	}{
+		"not root": {
+			req:       &mgmtpb.JobEpilogReq{Jobid: "job1"},
+			cred:      &unix.Ucred{Uid: uint32(os.Getuid()) + 1},
+			expStatus: daos.NoPermission,
+			expCached: true,
+		},
+		"no job id": {
+			req:       &mgmtpb.JobEpilogReq{},
+			cred:      &unix.Ucred{Uid: 0},
+			expStatus: daos.InvalidInput,
+			expCached: true,
+		},
+		"unknown job": {
+			req:       &mgmtpb.JobEpilogReq{Jobid: "job2"},
+			cred:      &unix.Ucred{Uid: 0},
+			expCached: true,
+		},
+		"success": {
+			req:  &mgmtpb.JobEpilogReq{Jobid: "job1"},
+			cred: &unix.Ucred{Uid: 0},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			jobUsers := newJobUserCache(log, newCountingExt())
+			if err := jobUsers.addJob("job1", 1001); err != nil {
+				t.Fatal(err)
+			}
+			mod := &mgmtModule{
+				log:      log,
+				jobUsers: jobUsers,
+			}
+
+			reqBytes, err := proto.Marshal(tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRespBytes, err := mod.handleJobEpilog(test.Context(t), reqBytes, tc.cred)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResp := new(mgmtpb.JobHookResp)
+			if err := proto.Unmarshal(gotRespBytes, gotResp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), gotResp.Status, "unexpected status")
+
+			_, cached := jobUsers.users[1001]
+			test.AssertEqual(t, tc.expCached, cached, "unexpected cached user state")
+		})
+	}
+}
+
+// mockAgentClient is a DomainSocketClient returning a canned response.
+type mockAgentClient struct {
+	sync.Mutex
+	connectErr error
+	resp       *drpc.Response
+	call       *drpc.Call
+}
+
+func (mac *mockAgentClient) IsConnected() bool             { return mac.connectErr == nil }
+func (mac *mockAgentClient) Connect(context.Context) error { return mac.connectErr }
+func (mac *mockAgentClient) Close() error                  { return nil }
+func (mac *mockAgentClient) GetSocketPath() string         { return agentSockName }
+
+func (mac *mockAgentClient) SendMsg(_ context.Context, call *drpc.Call) (*drpc.Response, error) {
+	mac.call = call
+	return mac.resp, nil
+}
+
+func TestAgent_sendJobHook(t *testing.T) {
+	okBody, err := proto.Marshal(&mgmtpb.JobHookResp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failBody, err := proto.Marshal(&mgmtpb.JobHookResp{Status: int32(daos.NoPermission)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		connectErr error
+		resp       *drpc.Response
+		expErr     error
+	}{
+		"connect fails": {
+			connectErr: errors.New("no socket"),
+			expErr:     errors.New("connecting to agent"),
+		},
+		"dRPC failure": {
+			resp:   &drpc.Response{Status: drpc.Status_FAILURE},
+			expErr: errors.New("JobProlog request failed"),
+		},
+		"agent rejects": {
+			resp:   &drpc.Response{Body: failBody},
+			expErr: daos.NoPermission,
+		},
+		"success": {
+			resp: &drpc.Response{Body: okBody},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &mockAgentClient{
+				connectErr: tc.connectErr,
+				resp:       tc.resp,
+			}
+
+			gotErr := sendJobHook(test.Context(t), client, drpc.MethodJobProlog,
+				&mgmtpb.JobPrologReq{Jobid: "job1", Uid: 1001})
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			expReq, err := proto.Marshal(&mgmtpb.JobPrologReq{Jobid: "job1", Uid: 1001})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expReq, client.call.Body); diff != "" {
+				t.Fatalf("unexpected request (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+// batchAgentClient answers batches of job hook calls with the given job hook
+// statuses, one per call.
+type batchAgentClient struct {
+	mockAgentClient
+	statuses []daos.Status
+	sent     int
+	calls    []*drpc.Call
+}
+
+func (bac *batchAgentClient) SendMsg(_ context.Context, call *drpc.Call) (*drpc.Response, error) {
+	bac.sent++
+
+	batch := new(drpc.CallBatch)
+	if err := proto.Unmarshal(call.Body, batch); err != nil {
+		return nil, err
+	}
+	bac.calls = batch.Calls
+
+	resps := new(drpc.ResponseBatch)
+	for i, c := range batch.Calls {
+		body, err := proto.Marshal(&mgmtpb.JobHookResp{Status: int32(bac.statuses[i])})
+		if err != nil {
+			return nil, err
+		}
+		resps.Responses = append(resps.Responses, &drpc.Response{Sequence: c.Sequence, Body: body})
+	}
+	body, err := proto.Marshal(resps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &drpc.Response{Sequence: call.Sequence, Body: body}, nil
+}
+
+func TestAgent_sendJobHooks(t *testing.T) {
+	for name, tc := range map[string]struct {
+		statuses []daos.Status
+		expErr   error
+	}{
+		"success": {
+			statuses: []daos.Status{daos.Success, daos.Success},
+		},
+		"one job fails": {
+			statuses: []daos.Status{daos.Success, daos.NoPermission},
+			expErr:   daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &batchAgentClient{statuses: tc.statuses}
+
+			gotErr := sendJobHooks(test.Context(t), client, drpc.MethodJobEpilog,
+				&mgmtpb.JobEpilogReq{Jobid: "job1"}, &mgmtpb.JobEpilogReq{Jobid: "job2"})
+			test.CmpErr(t, tc.expErr, gotErr)
+
+			test.AssertEqual(t, 1, client.sent, "expected the requests to be sent in one batch")
+			var gotJobs []string
+			for _, call := range client.calls {
+				req := new(mgmtpb.JobEpilogReq)
+				if err := proto.Unmarshal(call.Body, req); err != nil {
+					t.Fatal(err)
+				}
+				gotJobs = append(gotJobs, req.Jobid)
+			}
+			if diff := cmp.Diff([]string{"job1", "job2"}, gotJobs); diff != "" {
+				t.Fatalf("unexpected batched requests (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2023 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -40,6 +41,16 @@ type cliOptions struct {
 	DumpTopo   hwprov.DumpTopologyCmd `command:"dump-topology" description:"Dump system topology"`
 	NetScan    netScanCmd             `command:"net-scan" description:"Perform local network fabric scan"`
 	Support    supportCmd             `command:"support" description:"Perform debug tasks to help support team"`
+	DelegToken delegationTokenCmd     `command:"delegation-token" description:"Request a delegation token for a multi-node job"`
+	Job        jobCmd                 `command:"job" description:"Job scheduler prolog and epilog integration"`
+	Pod        podCmd                 `command:"pod" description:"Container storage plugin integration"`
+	Status     statusCmd              `command:"status" description:"Show the status of the local agent"`
+}
+
+// runtimeDirSetter is implemented by commands that only need the location of
+// the agent socket, and not the rest of the agent configuration.
+type runtimeDirSetter interface {
+	setRuntimeDir(string)
 }
 
 type (
@@ -150,10 +161,14 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 			log.ClearLevel(logging.LogLevelInfo)
 		}
 
-		switch cmd.(type) {
+		switch c := cmd.(type) {
 		case *versionCmd, *netScanCmd, *hwprov.DumpTopologyCmd:
 			// these commands don't need the rest of the setup
 			return cmd.Execute(args)
+		case runtimeDirSetter:
+			// may run as a user without access to the agent config
+			c.setRuntimeDir(opts.RuntimeDir)
+			return cmd.Execute(args)
 		}
 
 		if !opts.AllowProxy {
@@ -237,6 +252,14 @@ func processConfig(log logging.Logger, cmd flags.Commander, opts *cliOptions, cf
 		return nil, errors.Wrap(err, "Unable to load Certificate Data")
 	}
 
+	if cfg.AccessPointDiscovery != nil {
+		aps, err := newAccessPointDiscoverer(log, cfg.AccessPointDiscovery).discover(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		cfg.AccessPoints = aps
+	}
+
 	var err error
 	if cfg.AccessPoints, err = common.ParseHostList(cfg.AccessPoints, cfg.ControlPort); err != nil {
 		return nil, errors.Wrap(err, "Failed to parse config access_points")
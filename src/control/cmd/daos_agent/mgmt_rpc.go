@@ -26,6 +26,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/telemetry"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -42,12 +43,21 @@ type mgmtModule struct {
 	sys            string
 	ctlInvoker     control.Invoker
 	cache          *InfoCache
+	respCache      attachInfoRespCache
 	monitor        *procMon
+	jobUsers       *jobUserCache
+	jobConns       *jobConnTracker
+	idMap          *identityMapper
 	cliMetricsSrc  *promexp.ClientSource
 	useDefaultNUMA bool
+	readOnly       bool
 
 	numaGetter  hardware.ProcessNUMAProvider
 	providerIdx uint
+
+	topoGetter  hardware.TopologyProvider
+	coreSetsMu  sync.Mutex
+	numaCoreSet map[uint]string // NUMA node ID -> cpulist of its cores
 }
 
 func (mod *mgmtModule) HandleCall(ctx context.Context, session *drpc.Session, method drpc.Method, req []byte) ([]byte, error) {
@@ -73,6 +83,16 @@ func (mod *mgmtModule) HandleCall(ctx context.Context, session *drpc.Session, me
 		return nil, drpc.NewFailureWithMessage("agent is shutting down")
 	}
 
+	if mod.readOnly && method != drpc.MethodGetAttachInfo && method != drpc.MethodAgentStatus {
+		// Clients notify the agent when they exit regardless of
+		// whether they have done anything, so don't fail them.
+		if method == drpc.MethodNotifyExit {
+			return nil, nil
+		}
+		mod.log.Noticef("audit: rejected %s: pid %d uid %d gid %d: agent is in read-only mode", method, cred.Pid, cred.Uid, cred.Gid)
+		return nil, drpc.NewFailureWithMessage("agent is in read-only mode")
+	}
+
 	switch method {
 	case drpc.MethodGetAttachInfo:
 		return mod.handleGetAttachInfo(ctx, req, cred.Pid)
@@ -82,6 +102,16 @@ func (mod *mgmtModule) HandleCall(ctx context.Context, session *drpc.Session, me
 		return nil, mod.handleNotifyPoolConnect(ctx, req, cred.Pid)
 	case drpc.MethodNotifyPoolDisconnect:
 		return nil, mod.handleNotifyPoolDisconnect(ctx, req, cred.Pid)
+	case drpc.MethodJobProlog:
+		return mod.handleJobProlog(ctx, req, cred)
+	case drpc.MethodJobEpilog:
+		return mod.handleJobEpilog(ctx, req, cred)
+	case drpc.MethodPodPrepare:
+		return mod.handlePodPrepare(ctx, req, cred)
+	case drpc.MethodPodRelease:
+		return mod.handlePodRelease(ctx, req, cred)
+	case drpc.MethodAgentStatus:
+		return mod.handleAgentStatus(ctx, req, cred)
 	case drpc.MethodNotifyExit:
 		// There isn't anything we can do here if this fails so just
 		// call the disconnect handler and return success.
@@ -143,7 +173,10 @@ func (mod *mgmtModule) handleGetAttachInfo(ctx context.Context, reqb []byte, pid
 
 	resp, err := mod.getAttachInfo(ctx, int(numaNode), pbReq)
 	switch {
-	case fault.IsFaultCode(err, code.ServerWrongSystem):
+	case fault.IsFaultCode(err, code.ServerWrongSystem),
+		fault.IsFaultCode(err, code.ServerIncompatibleComponents),
+		fault.IsFaultCode(err, code.ClientIncompatibleComponents):
+		mod.log.Errorf("%s: %s", client, err)
 		resp = &mgmtpb.GetAttachInfoResp{Status: int32(daos.ControlIncompatible)}
 	case fault.IsFaultCode(err, code.SecurityInvalidCert):
 		resp = &mgmtpb.GetAttachInfoResp{Status: int32(daos.BadCert)}
@@ -180,13 +213,7 @@ func (mod *mgmtModule) getNUMANode(ctx context.Context, pid int32) (uint, error)
 }
 
 func (mod *mgmtModule) getAttachInfo(ctx context.Context, numaNode int, req *mgmtpb.GetAttachInfoReq) (*mgmtpb.GetAttachInfoResp, error) {
-	rawResp, err := mod.getAttachInfoResp(ctx, req.Sys)
-	if err != nil {
-		mod.log.Errorf("failed to fetch AttachInfo: %s", err.Error())
-		return nil, err
-	}
-
-	resp, err := mod.selectAttachInfo(ctx, rawResp, req.Interface, req.Domain)
+	resp, err := mod.getSelectedAttachInfo(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +237,15 @@ func (mod *mgmtModule) getAttachInfo(ctx context.Context, numaNode int, req *mgm
 
 		iface = fabricIF.Name
 		domain = fabricIF.Domain
+
+		// Only advise on core binding for an interface we selected, as we don't know
+		// the locality of one requested by the client.
+		resp.ClientNetHint.NumaNode = uint32(fabricIF.NUMANode())
+		resp.ClientNetHint.CoreSet = mod.getNUMACoreSet(ctx, uint(fabricIF.NUMANode()))
+		if resp.ClientNetHint.CoreSet != "" {
+			mod.log.Tracef("recommended cores for %s: %s (NUMA %d)", iface,
+				resp.ClientNetHint.CoreSet, resp.ClientNetHint.NumaNode)
+		}
 	}
 
 	resp.ClientNetHint.Interface = iface
@@ -223,6 +259,71 @@ func (mod *mgmtModule) getAttachInfo(ctx context.Context, numaNode int, req *mgm
 	return resp, nil
 }
 
+// getSelectedAttachInfo returns the attach info for the requested system with the provider
+// selected for the requested interface, using the response cache if the underlying caches
+// are enabled.
+func (mod *mgmtModule) getSelectedAttachInfo(ctx context.Context, req *mgmtpb.GetAttachInfoReq) (*mgmtpb.GetAttachInfoResp, error) {
+	key := attachInfoRespKey{sys: req.Sys, iface: req.Interface, domain: req.Domain}
+	useCache := mod.cache.IsAttachInfoCacheEnabled() && mod.cache.IsFabricCacheEnabled()
+	if useCache {
+		if resp, found := mod.respCache.get(key, mod.cache.Generation(), mod.cache.attachInfoRefresh); found {
+			return resp, nil
+		}
+	}
+
+	rawResp, err := mod.getAttachInfoResp(ctx, req.Sys)
+	if err != nil {
+		mod.log.Errorf("failed to fetch AttachInfo: %s", err.Error())
+		return nil, err
+	}
+
+	resp, err := mod.selectAttachInfo(ctx, rawResp, req.Interface, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if useCache {
+		// The first request for a key fills the underlying caches, so the generation must be
+		// read afterwards for the entry to be usable.
+		mod.respCache.set(key, mod.cache.Generation(), resp)
+	}
+	return resp, nil
+}
+
+// getNUMACoreSet returns the cores on the given NUMA node in cpulist format, or an
+// empty string if they can't be determined. The topology doesn't change while the agent
+// is running, so the core sets are only computed once.
+func (mod *mgmtModule) getNUMACoreSet(ctx context.Context, numaNode uint) string {
+	if mod.topoGetter == nil {
+		return ""
+	}
+
+	mod.coreSetsMu.Lock()
+	defer mod.coreSetsMu.Unlock()
+
+	if mod.numaCoreSet == nil {
+		topo, err := mod.topoGetter.GetTopology(ctx)
+		if err != nil {
+			mod.log.Errorf("failed to get topology for core binding hints: %s", err.Error())
+			return ""
+		}
+
+		mod.numaCoreSet = make(map[uint]string)
+		for id, node := range topo.NUMANodes {
+			cores := hostlist.NewNumericSet()
+			for _, core := range node.Cores {
+				cores.Add(core.ID)
+			}
+			if cores.Count() > 0 {
+				// Strip the brackets to produce cpulist format.
+				mod.numaCoreSet[id] = strings.Trim(cores.RangedString(), "[]")
+			}
+		}
+	}
+
+	return mod.numaCoreSet[numaNode]
+}
+
 func (mod *mgmtModule) getAttachInfoResp(ctx context.Context, sys string) (*mgmtpb.GetAttachInfoResp, error) {
 	ctlResp, err := mod.cache.GetAttachInfo(ctx, sys)
 	if err != nil {
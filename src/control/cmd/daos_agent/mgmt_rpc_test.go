@@ -94,12 +94,28 @@ func TestAgent_mgmtModule_getAttachInfo(t *testing.T) {
 		return out
 	}
 
+	withBindingHint := func(in *mgmtpb.GetAttachInfoResp, numaNode uint32, coreSet string) *mgmtpb.GetAttachInfoResp {
+		in.ClientNetHint.NumaNode = numaNode
+		in.ClientNetHint.CoreSet = coreSet
+		return in
+	}
+
+	testTopo := &hardware.Topology{
+		NUMANodes: hardware.NodeMap{
+			0: hardware.MockNUMANode(0, 4),
+			1: hardware.MockNUMANode(1, 4, 4).WithCPUCores([]hardware.CPUCore{
+				{ID: 12}, {ID: 13},
+			}),
+		},
+	}
+
 	for name, tc := range map[string]struct {
 		sysName           string
 		mockGetAttachInfo getAttachInfoFn
 		mockFabricScan    fabricScanFn
 		mockGetNetIfaces  func() ([]net.Interface, error)
 		numaGetter        *mockNUMAProvider
+		topoGetter        hardware.TopologyProvider
 		reqBytes          []byte
 		expResp           *mgmtpb.GetAttachInfoResp
 		expErr            error
@@ -140,11 +156,28 @@ func TestAgent_mgmtModule_getAttachInfo(t *testing.T) {
 		},
 		"success": {
 			reqBytes: reqBytes(&mgmtpb.GetAttachInfoReq{Sys: testSys}),
-			expResp:  respWith(testResp, "test1", "dev1"),
+			expResp:  withBindingHint(respWith(testResp, "test1", "dev1"), 1, ""),
 		},
 		"no sys succeeds": {
 			reqBytes: reqBytes(&mgmtpb.GetAttachInfoReq{}),
-			expResp:  respWith(testResp, "test1", "dev1"),
+			expResp:  withBindingHint(respWith(testResp, "test1", "dev1"), 1, ""),
+		},
+		"core binding hint": {
+			reqBytes:   reqBytes(&mgmtpb.GetAttachInfoReq{Sys: testSys}),
+			topoGetter: &hardware.MockTopologyProvider{GetTopoReturn: testTopo},
+			expResp:    withBindingHint(respWith(testResp, "test1", "dev1"), 1, "4-7,12-13"),
+		},
+		"get topology fails": {
+			reqBytes: reqBytes(&mgmtpb.GetAttachInfoReq{Sys: testSys}),
+			topoGetter: &hardware.MockTopologyProvider{
+				GetTopoErr: errors.New("mock GetTopology"),
+			},
+			expResp: withBindingHint(respWith(testResp, "test1", "dev1"), 1, ""),
+		},
+		"no binding hint for requested interface": {
+			reqBytes:   reqBytes(&mgmtpb.GetAttachInfoReq{Sys: testSys, Interface: "test1"}),
+			topoGetter: &hardware.MockTopologyProvider{GetTopoReturn: testTopo},
+			expResp:    respWith(testResp, "test1", "test1"),
 		},
 		"incompatible error": {
 			reqBytes: reqBytes(&mgmtpb.GetAttachInfoReq{}),
@@ -209,6 +242,7 @@ func TestAgent_mgmtModule_getAttachInfo(t *testing.T) {
 					mockNetIfaces:     tc.mockGetNetIfaces,
 				}),
 				numaGetter: tc.numaGetter,
+				topoGetter: tc.topoGetter,
 			}
 
 			respBytes, err := mod.handleGetAttachInfo(test.Context(t), tc.reqBytes, 123)
@@ -406,6 +440,50 @@ func TestAgent_mgmtModule_RefreshCache(t *testing.T) {
 	}
 }
 
+func TestAgent_mgmtModule_HandleCall_ReadOnly(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method drpc.Method
+		expErr error
+	}{
+		"notify pool connect refused": {
+			method: drpc.MethodNotifyPoolConnect,
+			expErr: drpc.NewFailureWithMessage("agent is in read-only mode"),
+		},
+		"job prolog refused": {
+			method: drpc.MethodJobProlog,
+			expErr: drpc.NewFailureWithMessage("agent is in read-only mode"),
+		},
+		"setup client telemetry refused": {
+			method: drpc.MethodSetupClientTelemetry,
+			expErr: drpc.NewFailureWithMessage("agent is in read-only mode"),
+		},
+		"notify exit ignored": {
+			method: drpc.MethodNotifyExit,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			conn, cleanup := setupTestUnixConn(t)
+			defer cleanup()
+
+			// No monitor is set, so the call would panic if it
+			// were handled.
+			mod := &mgmtModule{
+				log:      log,
+				readOnly: true,
+			}
+
+			resp, err := mod.HandleCall(test.Context(t), newTestSession(t, log, conn), tc.method, nil)
+			test.CmpErr(t, tc.expErr, err)
+			if resp != nil {
+				t.Fatalf("unexpected response %v", resp)
+			}
+		})
+	}
+}
+
 func TestAgent_handleSetupClientTelemetry(t *testing.T) {
 	testCreds := &unix.Ucred{
 		Uid: 123,
@@ -0,0 +1,270 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// podJobID returns the key under which the account information for a pod's
+// user is held in the job user cache, so that it can't collide with a job ID.
+func podJobID(podUID string) string {
+	return "pod:" + podUID
+}
+
+// hostID translates an ID in a user namespace to the corresponding ID on the
+// host. IDs are not translated if no mapping is supplied.
+func hostID(id uint32, idMap []*mgmtpb.IdMapRange) (uint32, error) {
+	if len(idMap) == 0 {
+		return id, nil
+	}
+
+	for _, r := range idMap {
+		if id >= r.NsId && uint64(id) < uint64(r.NsId)+uint64(r.Count) {
+			return r.HostId + (id - r.NsId), nil
+		}
+	}
+	return 0, errors.Errorf("id %d is not mapped in the user namespace", id)
+}
+
+// podIdentityRule returns an identity mapping rule that assigns the identity
+// of the account with the given uid to processes in the pod's cgroup that run
+// as the mapped host UID.
+func (mod *mgmtModule) podIdentityRule(cgroup string, uid, hostUID uint32) (*IdentityMapRule, error) {
+	if mod.jobUsers == nil {
+		return nil, errors.New("account information cache is not available")
+	}
+
+	u, err := mod.jobUsers.LookupUserID(uid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up uid %d", uid)
+	}
+	gid, err := u.Gid()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting primary group of user %s", u.Username())
+	}
+	group, err := mod.jobUsers.LookupGroupID(gid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up gid %d", gid)
+	}
+	gids, err := u.GroupIDs()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting groups of user %s", u.Username())
+	}
+
+	rule := &IdentityMapRule{
+		Cgroup: cgroup,
+		UID:    &hostUID,
+		User:   u.Username(),
+		Group:  group.Name,
+	}
+	for _, id := range gids {
+		g, err := mod.jobUsers.LookupGroupID(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up gid %d", id)
+		}
+		rule.Groups = append(rule.Groups, g.Name)
+	}
+
+	return rule, nil
+}
+
+// handlePodPrepare prepares the agent for a container pod starting on the
+// node, on behalf of a container storage plugin mounting DAOS storage in the
+// pod. As for a job prolog, the attach info and local fabric caches are
+// filled and the account information of the pod's user is cached. If the pod
+// runs in a user namespace, the pod's processes are mapped to the identity of
+// the pod's user rather than that of the host UID they run as.
+func (mod *mgmtModule) handlePodPrepare(ctx context.Context, reqb []byte, cred *unix.Ucred) ([]byte, error) {
+	pbReq := new(mgmtpb.PodPrepareReq)
+	if err := proto.Unmarshal(reqb, pbReq); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	if !jobHookAllowed(cred) {
+		mod.log.Error("pod prepare rejected: caller is not root or the agent user")
+		return jobHookResp(daos.NoPermission)
+	}
+	if pbReq.PodUid == "" {
+		return jobHookResp(daos.InvalidInput)
+	}
+	if pbReq.Sys == "" {
+		pbReq.Sys = mod.sys
+	}
+	if pbReq.Sys != mod.sys {
+		mod.log.Errorf("pod %s: %s: unknown system name", pbReq.PodUid, pbReq.Sys)
+		return jobHookResp(daos.InvalidInput)
+	}
+
+	hostUID, err := hostID(pbReq.Uid, pbReq.UidMap)
+	if err != nil {
+		mod.log.Errorf("pod %s: %s", pbReq.PodUid, err)
+		return jobHookResp(daos.InvalidInput)
+	}
+	if hostUID != pbReq.Uid && pbReq.Cgroup == "" {
+		mod.log.Errorf("pod %s: a cgroup is required for a pod in a user namespace", pbReq.PodUid)
+		return jobHookResp(daos.InvalidInput)
+	}
+	mod.log.Debugf("pod %s: prepare for uid %d (host uid %d)", pbReq.PodUid, pbReq.Uid, hostUID)
+
+	if mod.jobUsers != nil {
+		if err := mod.jobUsers.addJob(podJobID(pbReq.PodUid), pbReq.Uid); err != nil {
+			mod.log.Errorf("pod %s: %s", pbReq.PodUid, err)
+			return jobHookResp(daos.Nonexistent)
+		}
+	}
+
+	if hostUID != pbReq.Uid {
+		rule, err := mod.podIdentityRule(pbReq.Cgroup, pbReq.Uid, hostUID)
+		if err == nil {
+			err = mod.idMap.addPodRule(pbReq.PodUid, rule)
+		}
+		if err != nil {
+			mod.log.Errorf("pod %s: unable to map identity: %s", pbReq.PodUid, err)
+			if mod.jobUsers != nil {
+				mod.jobUsers.removeJob(podJobID(pbReq.PodUid))
+			}
+			return jobHookResp(daos.MiscError)
+		}
+		mod.log.Debugf("pod %s: mapped identity %s", pbReq.PodUid, rule)
+	} else {
+		// the pod may have been prepared before with a different mapping
+		mod.idMap.removePodRule(pbReq.PodUid)
+	}
+
+	if _, err := mod.getAttachInfo(ctx, 0, &mgmtpb.GetAttachInfoReq{Sys: pbReq.Sys}); err != nil {
+		mod.log.Errorf("pod %s: unable to fetch attach info: %s", pbReq.PodUid, err)
+		if control.IsMSConnectionFailure(err) {
+			return jobHookResp(daos.Unreachable)
+		}
+		return jobHookResp(daos.MiscError)
+	}
+
+	return jobHookResp(daos.Success)
+}
+
+// handlePodRelease releases the cache entries and identity mapping of a
+// finished pod. Pods which weren't prepared are ignored.
+func (mod *mgmtModule) handlePodRelease(_ context.Context, reqb []byte, cred *unix.Ucred) ([]byte, error) {
+	pbReq := new(mgmtpb.PodReleaseReq)
+	if err := proto.Unmarshal(reqb, pbReq); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	if !jobHookAllowed(cred) {
+		mod.log.Error("pod release rejected: caller is not root or the agent user")
+		return jobHookResp(daos.NoPermission)
+	}
+	if pbReq.PodUid == "" {
+		return jobHookResp(daos.InvalidInput)
+	}
+	mod.log.Debugf("pod %s: release", pbReq.PodUid)
+
+	mod.idMap.removePodRule(pbReq.PodUid)
+	if mod.jobUsers != nil && !mod.jobUsers.removeJob(podJobID(pbReq.PodUid)) {
+		mod.log.Debugf("pod %s: was not prepared", pbReq.PodUid)
+	}
+
+	return jobHookResp(daos.Success)
+}
+
+// podCmd groups the commands run by container storage plugins.
+type podCmd struct {
+	Prepare podPrepareCmd `command:"prepare" description:"Prepare the local agent for a container pod starting on this node"`
+	Release podReleaseCmd `command:"release" description:"Release local agent resources held for a finished container pod"`
+}
+
+// podHookCmd is embedded by the pod hook commands.
+type podHookCmd struct {
+	cmdutil.LogCmd
+	runtimeDir string
+	PodUID     string `long:"pod-uid" required:"1" description:"Unique ID of the pod"`
+}
+
+func (cmd *podHookCmd) setRuntimeDir(dir string) {
+	cmd.runtimeDir = dir
+}
+
+// idMapRangeFlag is a user namespace ID mapping range in the format
+// <ns-id>:<host-id>:<count>.
+type idMapRangeFlag struct {
+	nsID   uint32
+	hostID uint32
+	count  uint32
+}
+
+func (f *idMapRangeFlag) UnmarshalFlag(value string) error {
+	fields := strings.Split(value, ":")
+	if len(fields) != 3 {
+		return errors.Errorf("invalid id mapping %q (expected <ns-id>:<host-id>:<count>)", value)
+	}
+
+	var ids [3]uint32
+	for i, field := range fields {
+		id, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "invalid id mapping %q", value)
+		}
+		ids[i] = uint32(id)
+	}
+	if ids[2] == 0 {
+		return errors.Errorf("invalid id mapping %q: count must be non-zero", value)
+	}
+
+	f.nsID, f.hostID, f.count = ids[0], ids[1], ids[2]
+	return nil
+}
+
+func (f *idMapRangeFlag) String() string {
+	return fmt.Sprintf("%d:%d:%d", f.nsID, f.hostID, f.count)
+}
+
+type podPrepareCmd struct {
+	podHookCmd
+	Cgroup string           `long:"cgroup" description:"cgroup path of the pod (required with --uid-map)"`
+	UID    uint32           `long:"uid" required:"1" description:"UID of the pod's workload in its user namespace"`
+	UIDMap []idMapRangeFlag `long:"uid-map" description:"UID mapping of the pod's user namespace as <ns-id>:<host-id>:<count> (may be repeated)"`
+}
+
+func (cmd *podPrepareCmd) Execute(_ []string) error {
+	req := &mgmtpb.PodPrepareReq{
+		PodUid: cmd.PodUID,
+		Cgroup: cmd.Cgroup,
+		Uid:    cmd.UID,
+	}
+	for _, r := range cmd.UIDMap {
+		req.UidMap = append(req.UidMap, &mgmtpb.IdMapRange{
+			NsId:   r.nsID,
+			HostId: r.hostID,
+			Count:  r.count,
+		})
+	}
+
+	return sendJobHook(context.Background(), agentSocketClient(cmd.runtimeDir), drpc.MethodPodPrepare, req)
+}
+
+type podReleaseCmd struct {
+	podHookCmd
+}
+
+func (cmd *podReleaseCmd) Execute(_ []string) error {
+	return sendJobHook(context.Background(), agentSocketClient(cmd.runtimeDir), drpc.MethodPodRelease,
+		&mgmtpb.PodReleaseReq{PodUid: cmd.PodUID})
+}
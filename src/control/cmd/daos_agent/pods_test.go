@@ -0,0 +1,328 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_hostID(t *testing.T) {
+	userNS := []*mgmtpb.IdMapRange{
+		{NsId: 0, HostId: 100000, Count: 1000},
+		{NsId: 1000, HostId: 1001, Count: 1},
+	}
+
+	for name, tc := range map[string]struct {
+		id     uint32
+		idMap  []*mgmtpb.IdMapRange
+		expID  uint32
+		expErr error
+	}{
+		"no mapping": {
+			id:    1001,
+			expID: 1001,
+		},
+		"first range": {
+			id:    999,
+			idMap: userNS,
+			expID: 100999,
+		},
+		"second range": {
+			id:    1000,
+			idMap: userNS,
+			expID: 1001,
+		},
+		"not mapped": {
+			id:     1001,
+			idMap:  userNS,
+			expErr: errors.New("id 1001 is not mapped"),
+		},
+		"range at end of id space": {
+			id:    ^uint32(0),
+			idMap: []*mgmtpb.IdMapRange{{NsId: ^uint32(0), HostId: 5, Count: ^uint32(0)}},
+			expID: 5,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotID, gotErr := hostID(tc.id, tc.idMap)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expID, gotID, "unexpected host id")
+		})
+	}
+}
+
+func TestAgent_handlePodPrepare(t *testing.T) {
+	testSys := "test_sys"
+	rootCred := &unix.Ucred{Uid: 0}
+	userNS := []*mgmtpb.IdMapRange{{NsId: 0, HostId: 100000, Count: 65536}}
+
+	testFIS := hardware.NewFabricInterfaceSet(
+		&hardware.FabricInterface{
+			Name:          "test0",
+			NetInterfaces: common.NewStringSet("test0"),
+			DeviceClass:   hardware.Ether,
+			Providers:     hardware.NewFabricProviderSet(&hardware.FabricProvider{Name: "ofi+tcp"}),
+		})
+
+	for name, tc := range map[string]struct {
+		req           *mgmtpb.PodPrepareReq
+		cred          *unix.Ucred
+		getAttachErr  error
+		expStatus     daos.Status
+		expCachedUser bool
+		expRule       *IdentityMapRule
+	}{
+		"not root": {
+			req:       &mgmtpb.PodPrepareReq{PodUid: "pod1", Uid: 1001},
+			cred:      &unix.Ucred{Uid: uint32(os.Getuid()) + 1},
+			expStatus: daos.NoPermission,
+		},
+		"no pod uid": {
+			req:       &mgmtpb.PodPrepareReq{Uid: 1001},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"wrong system": {
+			req:       &mgmtpb.PodPrepareReq{Sys: "bad", PodUid: "pod1", Uid: 1001},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"uid not mapped": {
+			req: &mgmtpb.PodPrepareReq{
+				PodUid: "pod1",
+				Cgroup: "/kubepods/pod1",
+				Uid:    1001,
+				UidMap: []*mgmtpb.IdMapRange{{NsId: 0, HostId: 100000, Count: 1000}},
+			},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"user namespace without cgroup": {
+			req:       &mgmtpb.PodPrepareReq{PodUid: "pod1", Uid: 1001, UidMap: userNS},
+			cred:      rootCred,
+			expStatus: daos.InvalidInput,
+		},
+		"unknown user": {
+			req:       &mgmtpb.PodPrepareReq{PodUid: "pod1", Uid: 9999},
+			cred:      rootCred,
+			expStatus: daos.Nonexistent,
+		},
+		"attach info fails": {
+			req:           &mgmtpb.PodPrepareReq{PodUid: "pod1", Uid: 1001},
+			cred:          rootCred,
+			getAttachErr:  errors.New("mock GetAttachInfo"),
+			expStatus:     daos.MiscError,
+			expCachedUser: true,
+		},
+		"success": {
+			req:           &mgmtpb.PodPrepareReq{Sys: testSys, PodUid: "pod1", Uid: 1001},
+			cred:          rootCred,
+			expCachedUser: true,
+		},
+		"success in user namespace": {
+			req: &mgmtpb.PodPrepareReq{
+				PodUid: "pod1",
+				Cgroup: "/kubepods/pod1",
+				Uid:    1001,
+				UidMap: userNS,
+			},
+			cred:          rootCred,
+			expCachedUser: true,
+			expRule: &IdentityMapRule{
+				Cgroup: "/kubepods/pod1",
+				UID:    testUID(101001),
+				User:   "alice",
+				Group:  "group100",
+				Groups: []string{"group100", "group200"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ic := newTestInfoCache(t, log, testInfoCacheParams{
+				mockGetAttachInfo: func(_ context.Context, _ control.UnaryInvoker, _ *control.GetAttachInfoReq) (*control.GetAttachInfoResp, error) {
+					if tc.getAttachErr != nil {
+						return nil, tc.getAttachErr
+					}
+					return &control.GetAttachInfoResp{
+						System: testSys,
+						ClientNetHint: control.ClientNetworkHint{
+							Provider:    "ofi+tcp",
+							NetDevClass: uint32(hardware.Ether),
+						},
+					}, nil
+				},
+				mockScanFabric: func(ctx context.Context, _ ...string) (*NUMAFabric, error) {
+					nf := NUMAFabricFromScan(ctx, log, testFIS)
+					nf.getAddrInterface = mockGetAddrInterface
+					return nf, nil
+				},
+			})
+
+			jobUsers := newJobUserCache(log, newCountingExt())
+			idMap := newIdentityMapper(nil)
+			mod := &mgmtModule{
+				log:      log,
+				sys:      testSys,
+				cache:    ic,
+				jobUsers: jobUsers,
+				idMap:    idMap,
+			}
+
+			reqBytes, err := proto.Marshal(tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRespBytes, err := mod.handlePodPrepare(test.Context(t), reqBytes, tc.cred)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResp := new(mgmtpb.JobHookResp)
+			if err := proto.Unmarshal(gotRespBytes, gotResp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), gotResp.Status, "unexpected status")
+
+			_, cached := jobUsers.users[1001]
+			test.AssertEqual(t, tc.expCachedUser, cached, "unexpected cached user state")
+			if diff := cmp.Diff(tc.expRule, idMap.podRules["pod1"]); diff != "" {
+				t.Fatalf("unexpected identity mapping (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAgent_handlePodRelease(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.PodReleaseReq
+		cred      *unix.Ucred
+		expStatus daos.Status
+		expCached bool
+	}{
+		"not root": {
+			req:       &mgmtpb.PodReleaseReq{PodUid: "pod1"},
+			cred:      &unix.Ucred{Uid: uint32(os.Getuid()) + 1},
+			expStatus: daos.NoPermission,
+			expCached: true,
+		},
+		"no pod uid": {
+			req:       &mgmtpb.PodReleaseReq{},
+			cred:      &unix.Ucred{Uid: 0},
+			expStatus: daos.InvalidInput,
+			expCached: true,
+		},
+		"unknown pod": {
+			req:       &mgmtpb.PodReleaseReq{PodUid: "pod2"},
+			cred:      &unix.Ucred{Uid: 0},
+			expCached: true,
+		},
+		"success": {
+			req:  &mgmtpb.PodReleaseReq{PodUid: "pod1"},
+			cred: &unix.Ucred{Uid: 0},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			jobUsers := newJobUserCache(log, newCountingExt())
+			if err := jobUsers.addJob(podJobID("pod1"), 1001); err != nil {
+				t.Fatal(err)
+			}
+			idMap := newIdentityMapper(nil)
+			if err := idMap.addPodRule("pod1", &IdentityMapRule{
+				Cgroup: "/kubepods/pod1",
+				UID:    testUID(101001),
+				User:   "alice",
+				Group:  "group100",
+			}); err != nil {
+				t.Fatal(err)
+			}
+			mod := &mgmtModule{
+				log:      log,
+				jobUsers: jobUsers,
+				idMap:    idMap,
+			}
+
+			reqBytes, err := proto.Marshal(tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRespBytes, err := mod.handlePodRelease(test.Context(t), reqBytes, tc.cred)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResp := new(mgmtpb.JobHookResp)
+			if err := proto.Unmarshal(gotRespBytes, gotResp); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, int32(tc.expStatus), gotResp.Status, "unexpected status")
+
+			_, cached := jobUsers.users[1001]
+			test.AssertEqual(t, tc.expCached, cached, "unexpected cached user state")
+			_, mapped := idMap.podRules["pod1"]
+			test.AssertEqual(t, tc.expCached, mapped, "unexpected identity mapping state")
+		})
+	}
+}
+
+func TestAgent_idMapRangeFlag(t *testing.T) {
+	for name, tc := range map[string]struct {
+		value  string
+		expStr string
+		expErr error
+	}{
+		"valid": {
+			value:  "0:100000:65536",
+			expStr: "0:100000:65536",
+		},
+		"missing field": {
+			value:  "0:100000",
+			expErr: errors.New("expected <ns-id>:<host-id>:<count>"),
+		},
+		"not a number": {
+			value:  "0:root:1",
+			expErr: errors.New("invalid id mapping"),
+		},
+		"zero count": {
+			value:  "0:100000:0",
+			expErr: errors.New("count must be non-zero"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var f idMapRangeFlag
+			gotErr := f.UnmarshalFlag(tc.value)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.expStr, f.String(), "unexpected mapping")
+		})
+	}
+}
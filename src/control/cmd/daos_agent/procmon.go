@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -130,6 +131,7 @@ type procMon struct {
 	response   chan *procMonResponse
 	ctlInvoker control.Invoker
 	systemName string
+	numProcs   int32 // updated atomically after each request
 }
 
 // NewProcMon creates a new process monitor struct setting initializing the
@@ -337,9 +339,16 @@ func (p *procMon) handleRequests(ctx context.Context) {
 				p.cleanupLeakedHandles(ctx, info)
 			}
 		}
+		atomic.StoreInt32(&p.numProcs, int32(len(p.procs)))
 	}
 }
 
+// monitoredProcs returns the number of client processes with open pool
+// handles.
+func (p *procMon) monitoredProcs() int {
+	return int(atomic.LoadInt32(&p.numProcs))
+}
+
 // startMonitoring is the main driver which starts the process monitor. The
 // passed in context is used to terminate all monitoring in the event of shutdown.
 func (p *procMon) startMonitoring(ctx context.Context, cleanOnStart bool) {
@@ -0,0 +1,91 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+// attachInfoRespKey identifies the parts of a GetAttachInfo request that the selected
+// response depends on.
+type attachInfoRespKey struct {
+	sys    string
+	iface  string
+	domain string
+}
+
+type attachInfoRespEntry struct {
+	resp   *mgmtpb.GetAttachInfoResp
+	cached time.Time
+}
+
+// attachInfoRespCache holds GetAttachInfo responses after the MS response has been converted
+// and the provider selected for the requested interface, so that the many identical requests
+// from a large job starting up don't each repeat that work. The client interface is still
+// selected per-request, as devices are balanced between clients on the same NUMA node.
+//
+// Entries are tagged with the InfoCache generation they were built from, and are all dropped
+// when the attach info or fabric data is refreshed. They also expire after the attach info
+// refresh interval so that requests continue to reach the InfoCache, which decides when the
+// MS should be queried again.
+type attachInfoRespCache struct {
+	sync.Mutex
+	generation uint64
+	entries    map[attachInfoRespKey]*attachInfoRespEntry
+}
+
+func (rc *attachInfoRespCache) reset(generation uint64) {
+	rc.generation = generation
+	rc.entries = make(map[attachInfoRespKey]*attachInfoRespEntry)
+}
+
+// get returns a copy of the cached response for the key, if one exists that was built from the
+// current generation and hasn't expired.
+func (rc *attachInfoRespCache) get(key attachInfoRespKey, generation uint64, expiration time.Duration) (*mgmtpb.GetAttachInfoResp, bool) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	if rc.entries == nil || generation > rc.generation {
+		rc.reset(generation)
+		return nil, false
+	}
+
+	entry, found := rc.entries[key]
+	if !found || rc.generation != generation {
+		return nil, false
+	}
+	if expiration > 0 && entry.cached.Add(expiration).Before(time.Now()) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+
+	return proto.Clone(entry.resp).(*mgmtpb.GetAttachInfoResp), true
+}
+
+// set caches a copy of the response for the key.
+func (rc *attachInfoRespCache) set(key attachInfoRespKey, generation uint64, resp *mgmtpb.GetAttachInfoResp) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	if generation < rc.generation {
+		// Built from data that has since been refreshed.
+		return
+	}
+	if rc.entries == nil || rc.generation != generation {
+		rc.reset(generation)
+	}
+
+	rc.entries[key] = &attachInfoRespEntry{
+		resp:   proto.Clone(resp).(*mgmtpb.GetAttachInfoResp),
+		cached: time.Now(),
+	}
+}
@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_attachInfoRespCache(t *testing.T) {
+	key := attachInfoRespKey{sys: "test_sys"}
+	testResp := &mgmt.GetAttachInfoResp{
+		MsRanks:       []uint32{0, 1},
+		ClientNetHint: &mgmt.ClientNetHint{Provider: "ofi+tcp"},
+	}
+
+	for name, tc := range map[string]struct {
+		curGen     uint64
+		setGen     uint64
+		getKey     attachInfoRespKey
+		getGen     uint64
+		expiration time.Duration
+		wait       time.Duration
+		expResp    *mgmt.GetAttachInfoResp
+	}{
+		"hit": {
+			setGen:  1,
+			getKey:  key,
+			getGen:  1,
+			expResp: testResp,
+		},
+		"different key": {
+			setGen: 1,
+			getKey: attachInfoRespKey{sys: "test_sys", iface: "eth0"},
+			getGen: 1,
+		},
+		"generation advanced": {
+			setGen: 1,
+			getKey: key,
+			getGen: 2,
+		},
+		"set from stale generation": {
+			curGen: 2,
+			setGen: 1,
+			getKey: key,
+			getGen: 2,
+		},
+		"not expired": {
+			setGen:     1,
+			getKey:     key,
+			getGen:     1,
+			expiration: time.Minute,
+			expResp:    testResp,
+		},
+		"expired": {
+			setGen:     1,
+			getKey:     key,
+			getGen:     1,
+			expiration: time.Millisecond,
+			wait:       5 * time.Millisecond,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rc := new(attachInfoRespCache)
+			rc.reset(tc.curGen)
+			rc.set(key, tc.setGen, testResp)
+			time.Sleep(tc.wait)
+
+			resp, found := rc.get(tc.getKey, tc.getGen, tc.expiration)
+			test.AssertEqual(t, tc.expResp != nil, found, "unexpected cache hit result")
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("want-, got+:\n%s", diff)
+			}
+			if resp != nil && resp == testResp {
+				t.Fatal("cached response was not copied")
+			}
+		})
+	}
+}
+
+func TestAgent_mgmtModule_getAttachInfo_RespCache(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var msCalls uint32
+	ic := newTestInfoCache(t, log, testInfoCacheParams{
+		mockGetAttachInfo: func(_ context.Context, _ control.UnaryInvoker, _ *control.GetAttachInfoReq) (*control.GetAttachInfoResp, error) {
+			msCalls++
+			return &control.GetAttachInfoResp{
+				MSRanks: []uint32{msCalls},
+				ClientNetHint: control.ClientNetworkHint{
+					Provider:    "ofi+tcp",
+					NetDevClass: uint32(hardware.Ether),
+				},
+			}, nil
+		},
+		mockScanFabric: func(ctx context.Context, _ ...string) (*NUMAFabric, error) {
+			nf := NUMAFabricFromScan(ctx, log, hardware.NewFabricInterfaceSet(&hardware.FabricInterface{
+				Name:          "test0",
+				NetInterfaces: common.NewStringSet("test0"),
+				Providers:     testFabricProviderSet("ofi+tcp"),
+				DeviceClass:   hardware.Ether,
+			}))
+			nf.getAddrInterface = mockGetAddrInterface
+			return nf, nil
+		},
+	})
+
+	mod := &mgmtModule{
+		log:   log,
+		sys:   "test_sys",
+		cache: ic,
+	}
+
+	getMSRanks := func() []uint32 {
+		t.Helper()
+		resp, err := mod.getAttachInfo(test.Context(t), 0, &mgmt.GetAttachInfoReq{Sys: "test_sys"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.AssertEqual(t, "test0", resp.ClientNetHint.Interface, "")
+		return resp.MsRanks
+	}
+
+	test.AssertEqual(t, []uint32{1}, getMSRanks(), "first request")
+	test.AssertEqual(t, []uint32{1}, getMSRanks(), "cached request")
+	test.AssertEqual(t, 1, len(mod.respCache.entries), "expected one cached response")
+
+	if err := ic.Refresh(test.Context(t)); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, []uint32{2}, getMSRanks(), "request after refresh")
+
+	ic.DisableAttachInfoCache()
+	test.AssertEqual(t, []uint32{3}, getMSRanks(), "request with cache disabled")
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2022 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,9 @@ package main
 import (
 	"context"
 	"net"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/daos"
@@ -22,6 +25,11 @@ type SecurityModule struct {
 	log    logging.Logger
 	ext    auth.UserExt
 	config *security.TransportConfig
+	idMap  *identityMapper
+	limit  *credLimiter
+	// readOnly refuses all credential requests, so that untrusted
+	// processes can't act on the system through the agent.
+	readOnly bool
 }
 
 // NewSecurityModule creates a new module with the given initialized TransportConfig
@@ -36,20 +44,57 @@ func NewSecurityModule(log logging.Logger, tc *security.TransportConfig) *Securi
 
 // HandleCall is the handler for calls to the SecurityModule
 func (m *SecurityModule) HandleCall(_ context.Context, session *drpc.Session, method drpc.Method, body []byte) ([]byte, error) {
-	if method != drpc.MethodRequestCredentials {
-		return nil, drpc.UnknownMethodFailure()
+	switch method {
+	case drpc.MethodRequestCredentials:
+		return m.getCredential(session)
+	case drpc.MethodRequestDelegationToken:
+		return m.getDelegationToken(session, body)
+	case drpc.MethodRedeemDelegationToken:
+		return m.redeemDelegationToken(session, body)
 	}
 
-	return m.getCredential(session)
+	return nil, drpc.UnknownMethodFailure()
 }
 
-// getCredentials generates a signed user credential based on the data attached to
-// the Unix Domain Socket.
-func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
+// throttled returns true if the request exceeds a configured rate limit.
+// Each rejected request is recorded in the agent log as an audit record.
+func (m *SecurityModule) throttled(info *security.DomainInfo, method drpc.Method) bool {
+	err := m.limit.allow(info.Uid())
+	if err == nil {
+		return false
+	}
+
+	m.log.Noticef("audit: rejected %s: pid %d uid %d gid %d: %s", method, info.Pid(), info.Uid(), info.Gid(), err)
+	return true
+}
+
+// refused returns true if the agent is in read-only mode, in which case no
+// credentials are issued. Each refused request is recorded in the agent log
+// as an audit record.
+func (m *SecurityModule) refused(info *security.DomainInfo, method drpc.Method) bool {
+	if !m.readOnly {
+		return false
+	}
+
+	m.log.Noticef("audit: rejected %s: pid %d uid %d gid %d: agent is in read-only mode", method, info.Pid(), info.Uid(), info.Gid())
+	return true
+}
+
+func sessionUnixConn(session *drpc.Session) (*net.UnixConn, error) {
 	uConn, ok := session.Conn.(*net.UnixConn)
 	if !ok {
 		return nil, drpc.NewFailureWithMessage("connection is not a unix socket")
 	}
+	return uConn, nil
+}
+
+// getCredentials generates a signed user credential based on the data attached to
+// the Unix Domain Socket.
+func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
+	uConn, err := sessionUnixConn(session)
+	if err != nil {
+		return nil, err
+	}
 
 	info, err := security.DomainInfoFromUnixConn(m.log, uConn)
 	if err != nil {
@@ -57,6 +102,14 @@ func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
 		return m.credRespWithStatus(daos.MiscError)
 	}
 
+	if m.refused(info, drpc.MethodRequestCredentials) {
+		return m.credRespWithStatus(daos.NoPermission)
+	}
+
+	if m.throttled(info, drpc.MethodRequestCredentials) {
+		return m.credRespWithStatus(daos.Busy)
+	}
+
 	signingKey, err := m.config.PrivateKey()
 	if err != nil {
 		m.log.Errorf("%s: failed to get signing key: %s", info, err)
@@ -64,7 +117,19 @@ func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
 		return m.credRespWithStatus(daos.BadCert)
 	}
 
-	cred, err := auth.AuthSysRequestFromCreds(m.ext, info, signingKey)
+	rule, err := m.idMap.lookup(uConn.LocalAddr().String(), info)
+	if err != nil {
+		m.log.Errorf("%s: failed to apply identity mapping: %s", info, err)
+		return m.credRespWithStatus(daos.MiscError)
+	}
+
+	var cred *auth.Credential
+	if rule != nil {
+		m.log.Debugf("%s: mapped to identity %s", info, rule)
+		cred, err = auth.AuthSysRequestFromIdentity(rule.Identity(), info, signingKey)
+	} else {
+		cred, err = auth.AuthSysRequestFromCreds(m.ext, info, signingKey)
+	}
 	if err != nil {
 		m.log.Errorf("%s: failed to get AuthSys struct: %s", info, err)
 		return m.credRespWithStatus(daos.MiscError)
@@ -75,6 +140,120 @@ func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
 	return drpc.Marshal(resp)
 }
 
+// getDelegationToken generates a signed delegation token for the user attached
+// to the Unix Domain Socket. Processes running as the same user on other nodes
+// may redeem the token for a credential.
+func (m *SecurityModule) getDelegationToken(session *drpc.Session, body []byte) ([]byte, error) {
+	req := new(auth.DelegationTokenReq)
+	if err := proto.Unmarshal(body, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uConn, err := sessionUnixConn(session)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := security.DomainInfoFromUnixConn(m.log, uConn)
+	if err != nil {
+		m.log.Errorf("Unable to get credentials for client socket: %s", err)
+		return m.tokenRespWithStatus(daos.MiscError)
+	}
+
+	if m.refused(info, drpc.MethodRequestDelegationToken) {
+		return m.tokenRespWithStatus(daos.NoPermission)
+	}
+
+	if m.throttled(info, drpc.MethodRequestDelegationToken) {
+		return m.tokenRespWithStatus(daos.Busy)
+	}
+
+	// The token carries the identity from the local account database, which
+	// would not be the identity assigned to a mapped process.
+	rule, err := m.idMap.lookup(uConn.LocalAddr().String(), info)
+	if err != nil {
+		m.log.Errorf("%s: failed to apply identity mapping: %s", info, err)
+		return m.tokenRespWithStatus(daos.MiscError)
+	}
+	if rule != nil {
+		m.log.Errorf("%s: delegation tokens are not issued to processes mapped to identity %s", info, rule)
+		return m.tokenRespWithStatus(daos.NoPermission)
+	}
+
+	signingKey, err := m.config.PrivateKey()
+	if err != nil {
+		m.log.Errorf("%s: failed to get signing key: %s", info, err)
+		return m.tokenRespWithStatus(daos.BadCert)
+	}
+
+	lifetime := time.Duration(req.GetLifetime()) * time.Second
+	token, expires, err := auth.CreateDelegationToken(m.ext, info, req.GetJobid(), lifetime, signingKey)
+	if err != nil {
+		m.log.Errorf("%s: failed to create delegation token: %s", info, err)
+		return m.tokenRespWithStatus(daos.InvalidInput)
+	}
+
+	m.log.Debugf("%s: issued delegation token for job %q (expires %s)", info, req.GetJobid(), expires)
+	return drpc.Marshal(&auth.DelegationTokenResp{
+		Token:   token,
+		Expires: uint64(expires.Unix()),
+	})
+}
+
+// redeemDelegationToken exchanges a delegation token for a signed credential
+// without looking up the user's account information on this node.
+func (m *SecurityModule) redeemDelegationToken(session *drpc.Session, body []byte) ([]byte, error) {
+	req := new(auth.RedeemDelegationTokenReq)
+	if err := proto.Unmarshal(body, req); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	uConn, err := sessionUnixConn(session)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := security.DomainInfoFromUnixConn(m.log, uConn)
+	if err != nil {
+		m.log.Errorf("Unable to get credentials for client socket: %s", err)
+		return m.credRespWithStatus(daos.MiscError)
+	}
+
+	if m.refused(info, drpc.MethodRedeemDelegationToken) {
+		return m.credRespWithStatus(daos.NoPermission)
+	}
+
+	if m.throttled(info, drpc.MethodRedeemDelegationToken) {
+		return m.credRespWithStatus(daos.Busy)
+	}
+
+	signingKey, err := m.config.PrivateKey()
+	if err != nil {
+		m.log.Errorf("%s: failed to get signing key: %s", info, err)
+		return m.credRespWithStatus(daos.BadCert)
+	}
+
+	verifyKey, err := m.config.PublicKey()
+	if err != nil {
+		m.log.Errorf("%s: failed to get verification key: %s", info, err)
+		return m.credRespWithStatus(daos.BadCert)
+	}
+
+	cred, err := auth.RedeemDelegationToken(req.GetToken(), info, req.GetJobid(), verifyKey, signingKey)
+	if err != nil {
+		m.log.Errorf("%s: failed to redeem delegation token: %s", info, err)
+		return m.credRespWithStatus(daos.NoPermission)
+	}
+
+	m.log.Tracef("%s: successfully redeemed delegation token", info)
+	return drpc.Marshal(&auth.GetCredResp{Cred: cred})
+}
+
+func (m *SecurityModule) tokenRespWithStatus(status daos.Status) ([]byte, error) {
+	resp := &auth.DelegationTokenResp{Status: int32(status)}
+	return drpc.Marshal(resp)
+}
+
 func (m *SecurityModule) credRespWithStatus(status daos.Status) ([]byte, error) {
 	resp := &auth.GetCredResp{Status: int32(status)}
 	return drpc.Marshal(resp)
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,8 @@ package main
 import (
 	"errors"
 	"net"
+	"os"
+	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
@@ -115,6 +117,67 @@ func TestAgentSecurityModule_RequestCreds_OK(t *testing.T) {
 	expectCredResp(t, respBytes, 0, true)
 }
 
+func TestAgentSecurityModule_RequestCreds_Throttled(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+
+	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod.ext = auth.NewMockExtWithUser("agent-test", 0, 0)
+	mod.limit = newCredLimiter(&CredentialRateLimit{GlobalRate: 1})
+
+	respBytes, err := callRequestCreds(mod, t, log, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectCredResp(t, respBytes, 0, true)
+
+	respBytes, err = callRequestCreds(mod, t, log, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectCredResp(t, respBytes, int32(daos.Busy), false)
+
+	if !strings.Contains(buf.String(), "audit: rejected request agent credentials") {
+		t.Fatal("rejected request not audited")
+	}
+}
+
+func TestAgentSecurityModule_ReadOnly(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+
+	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod.ext = auth.NewMockExtWithUser("agent-test", uint32(os.Getuid()), uint32(os.Getgid()))
+	mod.readOnly = true
+
+	respBytes, err := callRequestCreds(mod, t, log, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectCredResp(t, respBytes, int32(daos.NoPermission), false)
+
+	tokenResp := callRequestDelegationToken(t, mod, log, conn, &auth.DelegationTokenReq{})
+	test.AssertEqual(t, int32(daos.NoPermission), tokenResp.Status, "unexpected token status")
+
+	respBytes, err = callRedeemDelegationToken(t, mod, log, conn, &auth.RedeemDelegationTokenReq{
+		Token: "token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectCredResp(t, respBytes, int32(daos.NoPermission), false)
+
+	if !strings.Contains(buf.String(), "audit: rejected request agent credentials") {
+		t.Fatal("rejected request not audited")
+	}
+}
+
 func TestAgentSecurityModule_RequestCreds_NotUnixConn(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -188,3 +251,193 @@ func TestAgentSecurityModule_RequestCreds_BadUid(t *testing.T) {
 
 	expectCredResp(t, respBytes, int32(daos.MiscError), false)
 }
+
+func callRequestDelegationToken(t *testing.T, mod *SecurityModule, log logging.Logger, conn net.Conn, req *auth.DelegationTokenReq) *auth.DelegationTokenResp {
+	t.Helper()
+
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	respBytes, err := mod.HandleCall(test.Context(t), newTestSession(t, log, conn), drpc.MethodRequestDelegationToken, reqBytes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %+v", err)
+	}
+
+	resp := new(auth.DelegationTokenResp)
+	if err := proto.Unmarshal(respBytes, resp); err != nil {
+		t.Fatalf("Couldn't unmarshal result: %v", err)
+	}
+	return resp
+}
+
+func callRedeemDelegationToken(t *testing.T, mod *SecurityModule, log logging.Logger, conn net.Conn, req *auth.RedeemDelegationTokenReq) ([]byte, error) {
+	t.Helper()
+
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mod.HandleCall(test.Context(t), newTestSession(t, log, conn), drpc.MethodRedeemDelegationToken, reqBytes)
+}
+
+func TestAgentSecurityModule_DelegationToken(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tokenReq      *auth.DelegationTokenReq
+		redeemJobID   string
+		badToken      bool
+		expTokenStat  daos.Status
+		expRedeemStat daos.Status
+	}{
+		"default lifetime": {
+			tokenReq: &auth.DelegationTokenReq{},
+		},
+		"job ID matches": {
+			tokenReq:    &auth.DelegationTokenReq{Jobid: "job1", Lifetime: 60},
+			redeemJobID: "job1",
+		},
+		"job ID mismatch": {
+			tokenReq:      &auth.DelegationTokenReq{Jobid: "job1"},
+			redeemJobID:   "job2",
+			expRedeemStat: daos.NoPermission,
+		},
+		"lifetime too long": {
+			tokenReq:     &auth.DelegationTokenReq{Lifetime: uint32(auth.MaxDelegationTokenLifetime.Seconds()) + 1},
+			expTokenStat: daos.InvalidInput,
+		},
+		"bad token": {
+			tokenReq:      &auth.DelegationTokenReq{},
+			badToken:      true,
+			expRedeemStat: daos.NoPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			conn, cleanup := setupTestUnixConn(t)
+			defer cleanup()
+
+			mod := NewSecurityModule(log, defaultTestTransportConfig())
+			mod.ext = auth.NewMockExtWithUser("agent-test", uint32(os.Getuid()), uint32(os.Getgid()))
+
+			tokenResp := callRequestDelegationToken(t, mod, log, conn, tc.tokenReq)
+			test.AssertEqual(t, int32(tc.expTokenStat), tokenResp.Status, "unexpected token status")
+			if tc.expTokenStat != 0 {
+				return
+			}
+			if tokenResp.Token == "" || tokenResp.Expires == 0 {
+				t.Fatalf("expected token and expiration in response: %+v", tokenResp)
+			}
+			if tc.badToken {
+				tokenResp.Token = "bad" + tokenResp.Token
+			}
+
+			// Redemption should not depend on local account lookups.
+			mod.ext = &auth.MockExt{
+				LookupUserIDErr:  errors.New("LookupUserID"),
+				LookupGroupIDErr: errors.New("LookupGroupID"),
+			}
+			respBytes, err := callRedeemDelegationToken(t, mod, log, conn, &auth.RedeemDelegationTokenReq{
+				Token: tokenResp.Token,
+				Jobid: tc.redeemJobID,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %+v", err)
+			}
+
+			expectCredResp(t, respBytes, int32(tc.expRedeemStat), tc.expRedeemStat == 0)
+		})
+	}
+}
+
+func TestAgentSecurityModule_RedeemDelegationToken_BadPayload(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	_, err := mod.HandleCall(test.Context(t), newTestSession(t, log, &net.TCPConn{}), drpc.MethodRedeemDelegationToken, []byte("garbage"))
+
+	test.CmpErr(t, drpc.UnmarshalingPayloadFailure(), err)
+}
+
+func TestAgentSecurityModule_RequestCreds_IdentityMapping(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule      func(sockPath string) *IdentityMapRule
+		cgroupErr error
+		expStatus daos.Status
+		expUser   string
+	}{
+		"no matching rule": {
+			rule: func(_ string) *IdentityMapRule {
+				return &IdentityMapRule{SocketPath: "/not/this.sock", User: "mapped", Group: "group"}
+			},
+			expUser: "agent-test@",
+		},
+		"socket rule": {
+			rule: func(sockPath string) *IdentityMapRule {
+				return &IdentityMapRule{SocketPath: sockPath, User: "mapped", Group: "group"}
+			},
+			expUser: "mapped@",
+		},
+		"cgroup lookup fails": {
+			rule: func(_ string) *IdentityMapRule {
+				return &IdentityMapRule{Cgroup: "/machine.slice", User: "mapped", Group: "group"}
+			},
+			cgroupErr: errors.New("mock cgroups"),
+			expStatus: daos.MiscError,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			conn, cleanup := setupTestUnixConn(t)
+			defer cleanup()
+
+			mod := NewSecurityModule(log, defaultTestTransportConfig())
+			mod.ext = auth.NewMockExtWithUser("agent-test", 0, 0)
+			mod.idMap = newIdentityMapper([]*IdentityMapRule{tc.rule(conn.LocalAddr().String())})
+			mod.idMap.getCgroups = func(_ int32) ([]string, error) {
+				return nil, tc.cgroupErr
+			}
+
+			respBytes, err := callRequestCreds(mod, t, log, conn)
+			if err != nil {
+				t.Fatalf("Expected no error, got %+v", err)
+			}
+
+			expectCredResp(t, respBytes, int32(tc.expStatus), tc.expStatus == 0)
+			if tc.expStatus != 0 {
+				return
+			}
+
+			resp := &auth.GetCredResp{}
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatal(err)
+			}
+			sys, err := auth.AuthSysFromAuthToken(resp.Cred.GetToken())
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expUser, sys.GetUser(), "unexpected user")
+		})
+	}
+}
+
+func TestAgentSecurityModule_DelegationToken_IdentityMapped(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	conn, cleanup := setupTestUnixConn(t)
+	defer cleanup()
+
+	mod := NewSecurityModule(log, defaultTestTransportConfig())
+	mod.ext = auth.NewMockExtWithUser("agent-test", 0, 0)
+	mod.idMap = newIdentityMapper([]*IdentityMapRule{
+		{SocketPath: conn.LocalAddr().String(), User: "mapped", Group: "group"},
+	})
+
+	resp := callRequestDelegationToken(t, mod, log, conn, &auth.DelegationTokenReq{})
+	test.AssertEqual(t, int32(daos.NoPermission), resp.Status, "unexpected token status")
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/daos-stack/daos/src/control/common/cmdutil"
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hardware/hwloc"
 	"github.com/daos-stack/daos/src/control/lib/hardware/hwprov"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
 	"github.com/daos-stack/daos/src/control/lib/systemd"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 )
@@ -45,6 +47,7 @@ type startCmd struct {
 	cmdutil.LogCmd
 	configCmd
 	ctlInvokerCmd
+	ReadOnly bool `long:"read-only" description:"Only serve requests that don't modify the system, refusing credential requests"`
 }
 
 func (cmd *startCmd) Execute(_ []string) error {
@@ -55,6 +58,13 @@ func (cmd *startCmd) Execute(_ []string) error {
 	cmd.Infof("Starting %s (pid %d)", versionString(), os.Getpid())
 	startedAt := time.Now()
 
+	if cmd.ReadOnly {
+		cmd.cfg.ReadOnly = true
+	}
+	if cmd.cfg.ReadOnly {
+		cmd.Notice("agent is in read-only mode; credential requests and requests that modify the system will be refused")
+	}
+
 	parent, shutdown := context.WithCancel(cmd.MustLogCtx())
 	defer shutdown()
 
@@ -64,15 +74,6 @@ func (cmd *startCmd) Execute(_ []string) error {
 	sockPath := filepath.Join(cmd.cfg.RuntimeDir, agentSockName)
 	cmd.Debugf("Full socket path is now: %s", sockPath)
 
-	// Agent socket file to be readable and writable by all.
-	createDrpcStart := time.Now()
-	drpcServer, err := drpc.NewDomainSocketServer(cmd.Logger, sockPath, 0666)
-	if err != nil {
-		cmd.Errorf("Unable to create socket server: %v", err)
-		return err
-	}
-	cmd.Debugf("created dRPC server: %s", time.Since(createDrpcStart))
-
 	cacheStart := time.Now()
 	cache := NewInfoCache(ctx, cmd.Logger, cmd.ctlInvoker, cmd.cfg)
 	if cmd.attachInfoCacheDisabled() {
@@ -86,18 +87,32 @@ func (cmd *startCmd) Execute(_ []string) error {
 	}
 	cmd.Debugf("created cache: %s", time.Since(cacheStart))
 
+	if cache.IsFabricCacheEnabled() {
+		go cache.monitorFabricDevices(ctx, fabricCheckInterval, !cmd.cfg.DisableFabricFailover,
+			control.NewEventLogger(cmd.Logger))
+		cmd.Debug("started fabric interface monitor")
+	}
+
 	procmonStart := time.Now()
 	procmon := NewProcMon(cmd.Logger, cmd.ctlInvoker, cmd.cfg.SystemName)
-	procmon.startMonitoring(ctx, cmd.cfg.EvictOnStart)
+	procmon.startMonitoring(ctx, cmd.cfg.EvictOnStart && !cmd.cfg.ReadOnly)
 	cmd.Debugf("started process monitor: %s", time.Since(procmonStart))
 
+	var jobConns *jobConnTracker
+	if jcc := cmd.cfg.JobCorrelation; jcc != nil {
+		jobConns = newJobConnTracker(cmd.Logger)
+		jobConns.start(ctx, jcc)
+		cmd.Debug("job connection correlation enabled")
+	}
+
 	var clientMetricSource *promexp.ClientSource
 	if cmd.cfg.TelemetryExportEnabled() {
+		var err error
 		if ctx, clientMetricSource, err = promexp.NewClientSource(ctx); err != nil {
 			return errors.Wrap(err, "unable to create client metrics source")
 		}
 		telemetryStart := time.Now()
-		shutdown, err := startPrometheusExporter(ctx, cmd, clientMetricSource, cmd.cfg)
+		shutdown, err := startPrometheusExporter(ctx, cmd, clientMetricSource, jobConns, cmd.cfg)
 		if err != nil {
 			return errors.Wrap(err, "unable to start prometheus exporter")
 		}
@@ -105,20 +120,81 @@ func (cmd *startCmd) Execute(_ []string) error {
 		cmd.Debugf("telemetry exporter started: %s", time.Since(telemetryStart))
 	}
 
+	if pc := cmd.cfg.Profiling; pc != nil && pc.Enabled {
+		profiler := profiling.NewServer(cmd.Logger, profiling.DefaultAgentAddress)
+		if err := profiler.Start(pc.Address); err != nil {
+			return err
+		}
+		defer profiler.Stop()
+	}
+
 	drpcRegStart := time.Now()
-	drpcServer.RegisterRPCModule(NewSecurityModule(cmd.Logger, cmd.cfg.TransportConfig))
+	secMod := NewSecurityModule(cmd.Logger, cmd.cfg.TransportConfig)
+	secMod.idMap = newIdentityMapper(cmd.cfg.IdentityMapping)
+	secMod.limit = newCredLimiter(cmd.cfg.CredentialRateLimit)
+	secMod.readOnly = cmd.cfg.ReadOnly
+	jobUsers := newJobUserCache(cmd.Logger, secMod.ext)
+	secMod.ext = jobUsers
 	mgmtMod := &mgmtModule{
 		log:           cmd.Logger,
 		sys:           cmd.cfg.SystemName,
 		ctlInvoker:    cmd.ctlInvoker,
 		cache:         cache,
 		numaGetter:    hwprov.DefaultProcessNUMAProvider(cmd.Logger),
+		topoGetter:    hwprov.DefaultTopologyProvider(cmd.Logger),
 		monitor:       procmon,
+		jobUsers:      jobUsers,
+		jobConns:      jobConns,
+		idMap:         secMod.idMap,
 		providerIdx:   cmd.cfg.ProviderIdx,
 		cliMetricsSrc: clientMetricSource,
+		readOnly:      cmd.cfg.ReadOnly,
+	}
+	cmd.Debugf("created dRPC modules: %s", time.Since(drpcRegStart))
+
+	// Agent socket file to be readable and writable by all, unless
+	// overridden in the config. Additional sockets may expose a subset of
+	// the modules with their own permissions.
+	createDrpcStart := time.Now()
+	drpcServers, err := drpc.NewDomainSocketServers(cmd.Logger, sockPath, 0666, cmd.cfg.DrpcSockets, secMod, mgmtMod)
+	if err != nil {
+		cmd.Errorf("Unable to create socket server: %v", err)
+		return err
+	}
+	cmd.Debugf("created dRPC servers: %s", time.Since(createDrpcStart))
+
+	configuredPaths := common.NewStringSet(sockPath)
+	for _, sc := range cmd.cfg.DrpcSockets {
+		configuredPaths.Add(sc.Path)
+	}
+
+	// Identity mapping rules may refer to additional sockets, e.g. for
+	// bind-mounting into containers. They serve the same modules.
+	extraServers := []*drpc.DomainSocketServer{}
+	for _, path := range secMod.idMap.socketPaths() {
+		if configuredPaths.Has(path) {
+			continue
+		}
+		srv, err := drpc.NewDomainSocketServer(cmd.Logger, path, 0666)
+		if err != nil {
+			return errors.Wrapf(err, "unable to create socket server for %s", path)
+		}
+		srv.RegisterRPCModule(secMod)
+		srv.RegisterRPCModule(mgmtMod)
+		extraServers = append(extraServers, srv)
+		cmd.Debugf("identity mapping socket path: %s", path)
+	}
+
+	// Sockets passed by systemd socket activation are used in place of
+	// creating them.
+	allServers := append(append([]*drpc.DomainSocketServer{}, drpcServers...), extraServers...)
+	activated, err := activatedListeners(cmd.Logger, allServers)
+	if err != nil {
+		return err
+	}
+	if activated {
+		cmd.Info("started by socket activation")
 	}
-	drpcServer.RegisterRPCModule(mgmtMod)
-	cmd.Debugf("registered dRPC modules: %s", time.Since(drpcRegStart))
 
 	hwlocStart := time.Now()
 	// Cache hwloc data in context on startup, since it'll be used extensively at runtime.
@@ -130,9 +206,15 @@ func (cmd *startCmd) Execute(_ []string) error {
 	cmd.Debugf("cached hwloc content: %s", time.Since(hwlocStart))
 
 	drpcSrvStart := time.Now()
-	err = drpcServer.Start(hwlocCtx)
-	if err != nil {
-		return errors.Wrap(err, "unable to start dRPC server")
+	for _, srv := range drpcServers {
+		if err := srv.Start(hwlocCtx); err != nil {
+			return errors.Wrap(err, "unable to start dRPC server")
+		}
+	}
+	for _, srv := range extraServers {
+		if err := srv.Start(hwlocCtx); err != nil {
+			return errors.Wrap(err, "unable to start identity mapping dRPC server")
+		}
 	}
 	cmd.Debugf("dRPC socket server started: %s", time.Since(drpcSrvStart))
 
@@ -143,6 +225,16 @@ func (cmd *startCmd) Execute(_ []string) error {
 	}
 	defer systemd.Stopping()
 
+	idle := make(chan struct{})
+	if cmd.cfg.IdleExitTimeout > 0 {
+		if activated {
+			go monitorIdle(ctx, cmd.Logger, cmd.cfg.IdleExitTimeout, allServers, procmon, idle)
+			cmd.Debugf("exiting after idle for %s", cmd.cfg.IdleExitTimeout)
+		} else {
+			cmd.Notice("idle_exit_timeout ignored as the agent was not started by socket activation")
+		}
+	}
+
 	// Setup signal handlers so we can block till we get SIGINT or SIGTERM
 	signals := make(chan os.Signal)
 	finish := make(chan struct{})
@@ -161,6 +253,10 @@ func (cmd *startCmd) Execute(_ []string) error {
 			case syscall.SIGPIPE:
 				cmd.Infof("Signal received.  Caught non-fatal %s; continuing", sig)
 			case syscall.SIGUSR1:
+				if cmd.cfg.ReadOnly {
+					cmd.Noticef("Signal received.  Caught %s; not flushing open pool handles in read-only mode", sig)
+					continue
+				}
 				cmd.Infof("Signal received.  Caught %s; flushing open pool handles", sig)
 				procmon.FlushAllHandles(ctx)
 			case syscall.SIGUSR2:
@@ -170,7 +266,7 @@ func (cmd *startCmd) Execute(_ []string) error {
 				shutdownRcvd = time.Now()
 				cmd.Infof("Signal received.  Caught %s; shutting down", sig)
 				shuttingDown.SetTrue()
-				if !cmd.cfg.DisableAutoEvict {
+				if !cmd.cfg.DisableAutoEvict && !cmd.cfg.ReadOnly {
 					procmon.FlushAllHandles(ctx)
 				}
 				close(finish)
@@ -178,7 +274,13 @@ func (cmd *startCmd) Execute(_ []string) error {
 			}
 		}
 	}()
-	<-finish
+	select {
+	case <-finish:
+	case <-idle:
+		shutdownRcvd = time.Now()
+		cmd.Infof("No clients for %s; exiting until the next connection", cmd.cfg.IdleExitTimeout)
+		shuttingDown.SetTrue()
+	}
 
 	cmd.Debugf("shutdown complete in %s", time.Since(shutdownRcvd))
 	return nil
@@ -0,0 +1,153 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// handleAgentStatus reports the state of the running agent. The per-job
+// connection counts are only reported to root or the agent user, as they
+// reveal the jobs of other users on the node.
+func (mod *mgmtModule) handleAgentStatus(_ context.Context, reqb []byte, cred *unix.Ucred) ([]byte, error) {
+	pbReq := new(mgmtpb.AgentStatusReq)
+	if err := proto.Unmarshal(reqb, pbReq); err != nil {
+		return nil, drpc.UnmarshalingPayloadFailure()
+	}
+
+	resp := &mgmtpb.AgentStatusResp{
+		Sys:            mod.sys,
+		Version:        build.DaosVersion,
+		Pid:            int32(os.Getpid()),
+		ReadOnly:       mod.readOnly,
+		JobCorrelation: mod.jobConns != nil,
+	}
+	if resp.JobCorrelation {
+		if jobHookAllowed(cred) {
+			resp.Jobs = mod.jobConns.jobConns()
+		} else {
+			resp.Status = int32(daos.NoPermission)
+		}
+	}
+
+	return proto.Marshal(resp)
+}
+
+// statusCmd reports the state of the local agent. Like the job hook commands,
+// it only needs the location of the agent socket.
+type statusCmd struct {
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	runtimeDir string
+}
+
+func (cmd *statusCmd) setRuntimeDir(dir string) {
+	cmd.runtimeDir = dir
+}
+
+func (cmd *statusCmd) Execute(_ []string) error {
+	resp := new(mgmtpb.AgentStatusResp)
+	if err := callAgent(context.Background(), agentSocketClient(cmd.runtimeDir), drpc.MethodAgentStatus,
+		&mgmtpb.AgentStatusReq{}, resp); err != nil {
+		return err
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, nil)
+	}
+
+	return printAgentStatus(os.Stdout, resp)
+}
+
+func printAgentStatus(out io.Writer, resp *mgmtpb.AgentStatusResp) error {
+	correlation := "disabled"
+	if resp.JobCorrelation {
+		correlation = "enabled"
+	}
+
+	ew := txtfmt.NewErrWriter(out)
+	fmt.Fprintln(ew, txtfmt.FormatEntity("Agent Status", []txtfmt.TableRow{
+		{"System": resp.Sys},
+		{"Version": resp.Version},
+		{"PID": strconv.Itoa(int(resp.Pid))},
+		{"Read-only": strconv.FormatBool(resp.ReadOnly)},
+		{"Job correlation": correlation},
+	}))
+
+	switch {
+	case !resp.JobCorrelation:
+		return ew.Err
+	case resp.Status == int32(daos.NoPermission):
+		fmt.Fprintln(ew, "Job connections are only reported to root or the agent user")
+		return ew.Err
+	case len(resp.Jobs) == 0:
+		fmt.Fprintln(ew, "No job connections")
+		return ew.Err
+	}
+
+	jobTitle := "Job ID"
+	procTitle := "Processes"
+	connTitle := "Connections"
+	tf := txtfmt.NewTableFormatter(jobTitle, procTitle, connTitle)
+	var table []txtfmt.TableRow
+	for _, jc := range resp.Jobs {
+		table = append(table, txtfmt.TableRow{
+			jobTitle:  jc.Jobid,
+			procTitle: strconv.Itoa(int(jc.Processes)),
+			connTitle: strconv.Itoa(int(jc.Connections)),
+		})
+	}
+	fmt.Fprint(ew, tf.Format(table))
+
+	return ew.Err
+}
+
+// callAgent sends a request to the agent and unmarshals its response.
+func callAgent(ctx context.Context, client drpc.DomainSocketClient, method drpc.Method, req, resp proto.Message) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return errors.Wrapf(err, "marshalling %s request", method)
+	}
+
+	if err := client.Connect(ctx); err != nil {
+		return errors.Wrap(err, "connecting to agent")
+	}
+	defer client.Close()
+
+	drpcResp, err := client.SendMsg(ctx, &drpc.Call{
+		Module: method.Module().ID(),
+		Method: method.ID(),
+		Body:   body,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "sending %s request", method)
+	}
+	if drpcResp.Status != drpc.Status_SUCCESS {
+		return errors.Errorf("%s request failed: %s", method, drpcResp.Status)
+	}
+
+	if err := proto.Unmarshal(drpcResp.Body, resp); err != nil {
+		return errors.Wrapf(err, "unmarshalling %s response", method)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_handleAgentStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		noCorrelation bool
+		readOnly      bool
+		cred          *unix.Ucred
+		expResp       *mgmtpb.AgentStatusResp
+	}{
+		"correlation disabled": {
+			noCorrelation: true,
+			readOnly:      true,
+			cred:          &unix.Ucred{Uid: uint32(os.Getuid()) + 1},
+			expResp: &mgmtpb.AgentStatusResp{
+				ReadOnly: true,
+			},
+		},
+		"not root": {
+			cred: &unix.Ucred{Uid: uint32(os.Getuid()) + 1},
+			expResp: &mgmtpb.AgentStatusResp{
+				Status:         int32(daos.NoPermission),
+				JobCorrelation: true,
+			},
+		},
+		"root": {
+			cred: &unix.Ucred{Uid: 0},
+			expResp: &mgmtpb.AgentStatusResp{
+				JobCorrelation: true,
+				Jobs: []*mgmtpb.AgentJobConns{
+					{Jobid: "job1", Processes: 1, Connections: 2},
+					{Jobid: "job2", Processes: 1, Connections: 1},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := &mgmtModule{
+				log:      log,
+				sys:      "shire",
+				readOnly: tc.readOnly,
+			}
+			if !tc.noCorrelation {
+				mod.jobConns = newTestJobConnTracker(log, map[int32]string{101: "job1", 201: "job2"},
+					map[int32]uint64{101: 1000, 201: 1000})
+				for i, pid := range []int32{101, 101, 201} {
+					mod.jobConns.connect(pid, uint64(i), "")
+				}
+			}
+
+			reqBytes, err := proto.Marshal(&mgmtpb.AgentStatusReq{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRespBytes, err := mod.handleAgentStatus(test.Context(t), reqBytes, tc.cred)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResp := new(mgmtpb.AgentStatusResp)
+			if err := proto.Unmarshal(gotRespBytes, gotResp); err != nil {
+				t.Fatal(err)
+			}
+			tc.expResp.Sys = "shire"
+			tc.expResp.Version = build.DaosVersion
+			tc.expResp.Pid = int32(os.Getpid())
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAgent_printAgentStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp      *mgmtpb.AgentStatusResp
+		expOutput string
+	}{
+		"correlation disabled": {
+			resp: &mgmtpb.AgentStatusResp{
+				Sys:     "shire",
+				Version: "2.6.0",
+				Pid:     1234,
+			},
+			expOutput: `
+Agent Status
+------------
+  System          : shire           
+  Version         : 2.6.0           
+  PID             : 1234            
+  Read-only       : false           
+  Job correlation : disabled        
+
+`,
+		},
+		"not permitted": {
+			resp: &mgmtpb.AgentStatusResp{
+				Status:         int32(daos.NoPermission),
+				Sys:            "shire",
+				Version:        "2.6.0",
+				Pid:            1234,
+				JobCorrelation: true,
+			},
+			expOutput: `
+Agent Status
+------------
+  System          : shire           
+  Version         : 2.6.0           
+  PID             : 1234            
+  Read-only       : false           
+  Job correlation : enabled         
+
+Job connections are only reported to root or the agent user
+`,
+		},
+		"no jobs": {
+			resp: &mgmtpb.AgentStatusResp{
+				Sys:            "shire",
+				Version:        "2.6.0",
+				Pid:            1234,
+				JobCorrelation: true,
+			},
+			expOutput: `
+Agent Status
+------------
+  System          : shire           
+  Version         : 2.6.0           
+  PID             : 1234            
+  Read-only       : false           
+  Job correlation : enabled         
+
+No job connections
+`,
+		},
+		"jobs": {
+			resp: &mgmtpb.AgentStatusResp{
+				Sys:            "shire",
+				Version:        "2.6.0",
+				Pid:            1234,
+				ReadOnly:       true,
+				JobCorrelation: true,
+				Jobs: []*mgmtpb.AgentJobConns{
+					{Jobid: "1234", Processes: 8, Connections: 64},
+					{Jobid: "5678", Processes: 1, Connections: 4},
+				},
+			},
+			expOutput: `
+Agent Status
+------------
+  System          : shire           
+  Version         : 2.6.0           
+  PID             : 1234            
+  Read-only       : true            
+  Job correlation : enabled         
+
+Job ID Processes Connections 
+------ --------- ----------- 
+1234   8         64          
+5678   1         4           
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := printAgentStatus(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expOutput, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestAgent_callAgent(t *testing.T) {
+	body, err := proto.Marshal(&mgmtpb.AgentStatusResp{Sys: "shire"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &mockAgentClient{resp: &drpc.Response{Body: body}}
+	resp := new(mgmtpb.AgentStatusResp)
+	if err := callAgent(test.Context(t), client, drpc.MethodAgentStatus, &mgmtpb.AgentStatusReq{}, resp); err != nil {
+		t.Fatal(err)
+	}
+
+	test.AssertEqual(t, drpc.MethodAgentStatus.ID(), client.call.Method, "unexpected method")
+	test.AssertEqual(t, "shire", resp.Sys, "unexpected system")
+}
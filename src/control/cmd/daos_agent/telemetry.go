@@ -15,7 +15,7 @@ import (
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
-func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promexp.ClientSource, cfg *Config) (func(), error) {
+func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promexp.ClientSource, jobConns *jobConnTracker, cfg *Config) (func(), error) {
 	expCfg := &promexp.ExporterConfig{
 		Port:  cfg.TelemetryPort,
 		Title: "DAOS Client Telemetry",
@@ -27,6 +27,9 @@ func startPrometheusExporter(ctx context.Context, log logging.Logger, cs *promex
 				return err
 			}
 			prometheus.MustRegister(c)
+			if jobConns != nil {
+				prometheus.MustRegister(jobConns)
+			}
 
 			return nil
 		},
@@ -175,6 +175,7 @@ func TestDaosServer_Auto_confGenCmd_Convert(t *testing.T) {
 	cmd.UseTmpfsSCM = true
 	cmd.ExtMetadataPath = "/opt/daos_md"
 	cmd.FabricPorts = "12345,13345"
+	cmd.BdevNUMA = "0000:81:00.0=1,0000:5d:05.5=0"
 
 	req := new(control.ConfGenerateReq)
 	if err := convert.Types(cmd, req); err != nil {
@@ -190,6 +191,10 @@ func TestDaosServer_Auto_confGenCmd_Convert(t *testing.T) {
 		UseTmpfsSCM:     true,
 		ExtMetadataPath: "/opt/daos_md",
 		FabricPorts:     []int{12345, 13345},
+		BdevNUMAOverrides: map[string]uint{
+			"0000:81:00.0": 1,
+			"0000:5d:05.5": 0,
+		},
 	}
 
 	if diff := cmp.Diff(expReq, req); diff != "" {
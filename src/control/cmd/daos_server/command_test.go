@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -18,6 +18,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
 )
 
 type cmdTest struct {
@@ -123,6 +124,9 @@ func TestPreExecCheckBypass(t *testing.T) {
 			cmdLine: "start",
 			expErr:  errors.New("ouch"),
 		},
+		"start simulated": {
+			cmdLine: "start --simulate=1",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -135,7 +139,11 @@ func TestPreExecCheckBypass(t *testing.T) {
 					},
 				},
 			}
-			err := parseOpts([]string{tc.cmdLine}, &opts, log)
+			opts.Start.config = config.DefaultServer()
+			opts.Start.start = func(logging.Logger, *config.Server) error {
+				return nil
+			}
+			err := parseOpts(strings.Fields(tc.cmdLine), &opts, log)
 			test.CmpErr(t, tc.expErr, err)
 		})
 	}
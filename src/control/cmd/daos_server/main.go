@@ -51,12 +51,13 @@ type mainOpts struct {
 	SCM      scmStorageCmd          `command:"scm" description:"Perform tasks related to locally-attached SCM storage"`
 	NVMe     nvmeStorageCmd         `command:"nvme" description:"Perform tasks related to locally-attached NVMe storage"`
 	Start    startCmd               `command:"start" description:"Start daos_server"`
-	Network  networkCmd             `command:"network" description:"Perform network device scan based on fabric provider"`
+	Network  networkCmd             `command:"network" description:"Perform network device scan and fabric tests"`
 	Version  versionCmd             `command:"version" description:"Print daos_server version"`
 	MgmtSvc  msCmdRoot              `command:"ms" description:"Perform tasks related to management service replicas"`
 	DumpTopo hwprov.DumpTopologyCmd `command:"dump-topology" description:"Dump system topology"`
 	Support  supportCmd             `command:"support" description:"Perform debug tasks to help support team"`
 	Config   configCmd              `command:"config" alias:"cfg" description:"Perform tasks related to configuration of hardware on the local server"`
+	Topology topologyCmd            `command:"topology" description:"Perform tasks related to the hardware topology of the local server"`
 
 	// Allow a set of tests to be run before executing commands.
 	preExecTests []execTestFn
@@ -113,12 +114,17 @@ func parseOpts(args []string, opts *mainOpts, log *logging.LeveledLogger) error
 			}
 		}
 
-		switch cmd.(type) {
+		runPreExecTests := true
+		switch c := cmd.(type) {
 		case *versionCmd:
 			// No pre-exec tests or setup needed for these commands; just
 			// execute them directly.
 			return cmd.Execute(nil)
-		default:
+		case *startCmd:
+			// Simulated engines make no privileged calls.
+			runPreExecTests = c.Simulate == 0
+		}
+		if runPreExecTests {
 			for _, test := range opts.preExecTests {
 				if err := test(); err != nil {
 					return err
@@ -0,0 +1,167 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+	"github.com/daos-stack/daos/src/control/system"
+	sdb "github.com/daos-stack/daos/src/control/system/raft"
+)
+
+// msInspectResult is the JSON representation of the system database state
+// read from a snapshot.
+type msInspectResult struct {
+	Path         string                  `json:"path"`
+	Index        uint64                  `json:"index"`
+	Term         uint64                  `json:"term"`
+	Time         time.Time               `json:"time"`
+	Members      []*system.Member        `json:"members,omitempty"`
+	Pools        []*system.PoolService   `json:"pools,omitempty"`
+	FaultDomains *system.FaultDomainTree `json:"fault_domains,omitempty"`
+}
+
+// msInspectCmd displays the system database state recorded in a raft
+// snapshot. The snapshot is only read, so the command is safe to run while
+// the control plane server is running.
+type msInspectCmd struct {
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+
+	Snapshot     string `short:"s" long:"snapshot" description:"Path to snapshot directory" required:"1"`
+	Members      bool   `short:"m" long:"members" description:"Show system members"`
+	Pools        bool   `short:"p" long:"pools" description:"Show pool services"`
+	FaultDomains bool   `short:"f" long:"fault-domains" description:"Show fault domain tree"`
+}
+
+func (cmd *msInspectCmd) Execute([]string) error {
+	sv, err := sdb.LoadSnapshotView(cmd.Snapshot)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load snapshot %q", cmd.Snapshot)
+	}
+
+	// Show everything unless specific sections were requested.
+	showAll := !cmd.Members && !cmd.Pools && !cmd.FaultDomains
+
+	result := &msInspectResult{
+		Path:  sv.Details.Path,
+		Index: sv.Details.Metadata.Index,
+		Term:  sv.Details.Metadata.Term,
+		Time:  sv.Details.Time(),
+	}
+	if showAll || cmd.Members {
+		result.Members = sv.Members()
+	}
+	if showAll || cmd.Pools {
+		result.Pools = sv.Pools()
+	}
+	if showAll || cmd.FaultDomains {
+		result.FaultDomains = sv.FaultDomainTree()
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(result, nil)
+	}
+
+	var buf strings.Builder
+	if err := printMSInspectResult(&buf, sv.Details, result); err != nil {
+		return err
+	}
+	cmd.Info(buf.String())
+
+	return nil
+}
+
+func printSnapshotMembers(out io.Writer, members []*system.Member) {
+	rankTitle := "Rank"
+	uuidTitle := "UUID"
+	addrTitle := "Control Address"
+	fdTitle := "Fault Domain"
+	stateTitle := "State"
+	reasonTitle := "Reason"
+
+	tf := txtfmt.NewTableFormatter(rankTitle, uuidTitle, addrTitle, fdTitle, stateTitle, reasonTitle)
+	tf.InitWriter(out)
+
+	var table []txtfmt.TableRow
+	for _, m := range members {
+		table = append(table, txtfmt.TableRow{
+			rankTitle:   m.Rank.String(),
+			uuidTitle:   m.UUID.String(),
+			addrTitle:   m.Addr.String(),
+			fdTitle:     m.FaultDomain.String(),
+			stateTitle:  m.State.String(),
+			reasonTitle: m.Info,
+		})
+	}
+
+	tf.Format(table)
+}
+
+func printSnapshotPools(out io.Writer, pools []*system.PoolService) {
+	labelTitle := "Label"
+	uuidTitle := "UUID"
+	stateTitle := "State"
+	repsTitle := "Service Replicas"
+
+	tf := txtfmt.NewTableFormatter(labelTitle, uuidTitle, stateTitle, repsTitle)
+	tf.InitWriter(out)
+
+	var table []txtfmt.TableRow
+	for _, ps := range pools {
+		table = append(table, txtfmt.TableRow{
+			labelTitle: ps.PoolLabel,
+			uuidTitle:  ps.PoolUUID.String(),
+			stateTitle: ps.State.String(),
+			repsTitle:  ranklist.RankSetFromRanks(ps.Replicas).RangedString(),
+		})
+	}
+
+	tf.Format(table)
+}
+
+func printMSInspectResult(out io.Writer, sInfo *sdb.SnapshotDetails, result *msInspectResult) error {
+	ew := txtfmt.NewErrWriter(out)
+
+	fmt.Fprintln(ew, "Snapshot info:")
+	printSnapshotDetails(txtfmt.NewIndentWriter(ew, txtfmt.WithPadCount(2)), sInfo)
+
+	if result.Members != nil {
+		fmt.Fprintln(ew)
+		if len(result.Members) == 0 {
+			fmt.Fprintln(ew, "No system members in snapshot")
+		} else {
+			fmt.Fprintln(ew, "System members:")
+			printSnapshotMembers(txtfmt.NewIndentWriter(ew, txtfmt.WithPadCount(2)), result.Members)
+		}
+	}
+
+	if result.Pools != nil {
+		fmt.Fprintln(ew)
+		if len(result.Pools) == 0 {
+			fmt.Fprintln(ew, "No pools in snapshot")
+		} else {
+			fmt.Fprintln(ew, "Pools:")
+			printSnapshotPools(txtfmt.NewIndentWriter(ew, txtfmt.WithPadCount(2)), result.Pools)
+		}
+	}
+
+	if result.FaultDomains != nil {
+		fmt.Fprintln(ew)
+		fmt.Fprint(ew, result.FaultDomains)
+	}
+
+	return ew.Err
+}
@@ -0,0 +1,96 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const testSnapshotPath = "../../system/raft/testdata/raft_recovery/snapshots/2-44-1713295644569"
+
+func TestDaosServer_msInspectCmd(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path       string
+		members    bool
+		pools      bool
+		fds        bool
+		expErr     error
+		expOut     []string
+		expMissing []string
+	}{
+		"bad snapshot path": {
+			path:   "bad/path",
+			expErr: errors.New("failed to load snapshot"),
+		},
+		"all sections": {
+			path: testSnapshotPath,
+			expOut: []string{
+				"Index: 44",
+				"System members:",
+				"Service Replicas",
+				"- rank8",
+				"pool0007",
+				"FaultDomainTree:",
+			},
+		},
+		"members only": {
+			path:    testSnapshotPath,
+			members: true,
+			expOut: []string{
+				"System members:",
+				"Joined",
+			},
+			expMissing: []string{"Service Replicas", "FaultDomainTree:"},
+		},
+		"pools and fault domains": {
+			path:  testSnapshotPath,
+			pools: true,
+			fds:   true,
+			expOut: []string{
+				"pool0000",
+				"Ready",
+				"FaultDomainTree:",
+			},
+			expMissing: []string{"System members:"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cmd := &msInspectCmd{
+				Snapshot:     tc.path,
+				Members:      tc.members,
+				Pools:        tc.pools,
+				FaultDomains: tc.fds,
+			}
+			cmd.Logger = log
+
+			test.CmpErr(t, tc.expErr, cmd.Execute(nil))
+			if tc.expErr != nil {
+				return
+			}
+
+			for _, exp := range tc.expOut {
+				if !strings.Contains(buf.String(), exp) {
+					t.Errorf("expected %q in output", exp)
+				}
+			}
+			for _, exp := range tc.expMissing {
+				if strings.Contains(buf.String(), exp) {
+					t.Errorf("unexpected %q in output", exp)
+				}
+			}
+		})
+	}
+}
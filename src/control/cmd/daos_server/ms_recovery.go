@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022-2023 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -26,6 +27,7 @@ type msCmdRoot struct {
 	Status  msStatusCmd   `command:"status" description:"Show status of the local management service replica"`
 	Recover msRecoveryCmd `command:"recover" description:"Recover the management service using this replica"`
 	Restore msRestoreCmd  `command:"restore" description:"Restore the management service from a snapshot"`
+	Inspect msInspectCmd  `command:"inspect" description:"Show the management service state recorded in a snapshot"`
 }
 
 type dbCfgCmd struct {
@@ -54,6 +56,9 @@ func printSnapshotDetails(out io.Writer, sInfo *sdb.SnapshotDetails) error {
 	fmt.Fprintf(ew, "Path: %s\n", sInfo.Path)
 	fmt.Fprintf(ew, "Index: %d\n", sInfo.Metadata.Index)
 	fmt.Fprintf(ew, "Term: %d\n", sInfo.Metadata.Term)
+	if snapTime := sInfo.Time(); !snapTime.IsZero() {
+		fmt.Fprintf(ew, "Time: %s\n", snapTime.Format(time.RFC3339))
+	}
 	fmt.Fprintf(ew, "DB Version: %d\n", sInfo.Version)
 	fmt.Fprintf(ew, "System Ranks: %s\n", sInfo.MemberRanks)
 	fmt.Fprintf(ew, "System Pools: %s\n", strings.Join(sInfo.Pools, ","))
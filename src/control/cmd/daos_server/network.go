@@ -7,16 +7,29 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hardware"
 	"github.com/daos-stack/daos/src/control/lib/hardware/hwprov"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
 	"github.com/daos-stack/daos/src/control/server/config"
 )
 
@@ -32,11 +45,21 @@ func initNetworkCmd(cmd *networkScanCmd) (fabricScanFn, *config.Server, error) {
 		return nil, nil, err
 	}
 
-	return hwprov.DefaultFabricScanner(cmd.Logger).Scan, cmd.config, nil
+	var filter *hardware.FabricInterfaceFilter
+	if cmd.config != nil {
+		filter = cmd.config.FabricScanFilter()
+	}
+	scanner, err := hwprov.DefaultFabricScannerWithFilter(cmd.Logger, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scanner.Scan, cmd.config, nil
 }
 
 type networkCmd struct {
 	Scan networkScanCmd `command:"scan" description:"Scan for network interface devices on local server"`
+	Test networkTestCmd `command:"test" description:"Measure fabric latency and bandwidth between servers"`
 }
 
 // networkScanCmd is the struct representing the command to scan the machine for network interface devices
@@ -149,3 +172,288 @@ func (cmd *networkScanCmd) Execute(_ []string) error {
 
 	return nil
 }
+
+const (
+	// networkTestTimeout bounds each fi_pingpong run against a peer.
+	networkTestTimeout = time.Minute
+	// networkTestRespawnDelay is the delay before a responder that has
+	// failed is restarted.
+	networkTestRespawnDelay = time.Second
+)
+
+type (
+	pingPongFn   func(ctx context.Context, args ...string) ([]byte, error)
+	ifaceAddrsFn func(name string) ([]net.Addr, error)
+
+	// networkTestEndpoint describes the primary fabric interface of an
+	// engine.
+	networkTestEndpoint struct {
+		engine   int
+		iface    string
+		provider string
+		addr     net.IP
+	}
+
+	// networkTestResult contains the performance measured between an
+	// engine's fabric interface and the same engine's interface on a peer.
+	networkTestResult struct {
+		Peer          string                     `json:"peer"`
+		Engine        int                        `json:"engine"`
+		Interface     string                     `json:"interface"`
+		Provider      string                     `json:"provider"`
+		LatencyUsec   float64                    `json:"latency_usec"`
+		BandwidthMBps float64                    `json:"bandwidth_mb_per_sec"`
+		Results       []*hardware.PingPongResult `json:"results,omitempty"`
+		Error         string                     `json:"error,omitempty"`
+	}
+)
+
+func runPingPong(ctx context.Context, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, hardware.PingPongCommand, args...).CombinedOutput()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, errors.Errorf("%s not found; install the libfabric fabtests package",
+				hardware.PingPongCommand)
+		}
+		return out, errors.Wrapf(err, "%s failed: %s", hardware.PingPongCommand,
+			strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// networkTestCmd runs fi_pingpong between the fabric interfaces configured for
+// the engines on this server and the same interfaces on each of the peers. The
+// peers must be running the command in listen mode for the test to connect.
+type networkTestCmd struct {
+	cmdutil.JSONOutputCmd
+	cmdutil.LogCmd
+	cfgCmd
+	Peers      string `long:"peers" description:"Hostlist of servers to test against (each must be running 'network test --listen')"`
+	Listen     bool   `long:"listen" description:"Respond to tests from other servers until interrupted"`
+	Port       int    `long:"port" description:"Base port for out-of-band address exchange, one port per engine" default:"47592"`
+	Iterations int    `short:"i" long:"iterations" description:"Number of round trips for each transfer size" default:"1000"`
+
+	pingPong   pingPongFn
+	ifaceAddrs ifaceAddrsFn
+}
+
+func interfaceAddrs(name string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return iface.Addrs()
+}
+
+func (cmd *networkTestCmd) getEndpoints() ([]*networkTestEndpoint, error) {
+	if cmd.config == nil || len(cmd.config.Engines) == 0 {
+		return nil, errors.New("network test requires a server config file with at least one engine")
+	}
+
+	var endpoints []*networkTestEndpoint
+	for idx, ec := range cmd.config.Engines {
+		daosProv, err := ec.Fabric.GetPrimaryProvider()
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d", idx)
+		}
+		provider, err := hardware.PingPongProvider(daosProv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d", idx)
+		}
+		iface, err := ec.Fabric.GetPrimaryInterface()
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d", idx)
+		}
+
+		addrs, err := cmd.ifaceAddrs(iface)
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d interface %q", idx, iface)
+		}
+		ep := &networkTestEndpoint{
+			engine:   idx,
+			iface:    iface,
+			provider: provider,
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+				ep.addr = ipNet.IP
+				break
+			}
+		}
+		if ep.addr == nil {
+			return nil, errors.Errorf("engine %d interface %q has no IPv4 address", idx, iface)
+		}
+
+		endpoints = append(endpoints, ep)
+	}
+
+	return endpoints, nil
+}
+
+func (cmd *networkTestCmd) pingPongArgs(ep *networkTestEndpoint) []string {
+	return []string{
+		"-p", ep.provider,
+		"-e", "rdm",
+		"-s", ep.addr.String(),
+		"-I", strconv.Itoa(cmd.Iterations),
+		"-S", "all",
+	}
+}
+
+// listen runs a responder for each engine interface, restarting it after
+// each test, until the context is canceled.
+func (cmd *networkTestCmd) listen(ctx context.Context, endpoints []*networkTestEndpoint) error {
+	var wg sync.WaitGroup
+	for _, ep := range endpoints {
+		wg.Add(1)
+		go func(ep *networkTestEndpoint) {
+			defer wg.Done()
+
+			args := append(cmd.pingPongArgs(ep), "-B", strconv.Itoa(cmd.Port+ep.engine))
+			cmd.Infof("engine %d: responding to tests on %s (%s) port %d", ep.engine,
+				ep.iface, ep.addr, cmd.Port+ep.engine)
+			for {
+				_, err := cmd.pingPong(ctx, args...)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					cmd.Errorf("engine %d: %s", ep.engine, err)
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(networkTestRespawnDelay):
+					}
+					continue
+				}
+				cmd.Debugf("engine %d: test completed", ep.engine)
+			}
+		}(ep)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// runTests runs the tests against each peer in turn so that the results are
+// not skewed by concurrent traffic.
+func (cmd *networkTestCmd) runTests(ctx context.Context, peers []string, endpoints []*networkTestEndpoint) []*networkTestResult {
+	var results []*networkTestResult
+	for _, peer := range peers {
+		for _, ep := range endpoints {
+			result := &networkTestResult{
+				Peer:      peer,
+				Engine:    ep.engine,
+				Interface: ep.iface,
+				Provider:  ep.provider,
+			}
+			results = append(results, result)
+
+			args := append(cmd.pingPongArgs(ep), "-P", strconv.Itoa(cmd.Port+ep.engine), peer)
+			testCtx, cancel := context.WithTimeout(ctx, networkTestTimeout)
+			out, err := cmd.pingPong(testCtx, args...)
+			cancel()
+			if err == nil {
+				result.Results, err = hardware.ParsePingPongOutput(bytes.NewReader(out))
+			}
+			if err != nil {
+				result.Error = err.Error()
+				continue
+			}
+
+			// Latency is reported for the smallest transfer and bandwidth
+			// is the highest achieved for any transfer size.
+			minBytes := result.Results[0].Bytes
+			result.LatencyUsec = result.Results[0].UsecPerXfer
+			for _, ppr := range result.Results {
+				if ppr.Bytes < minBytes {
+					minBytes = ppr.Bytes
+					result.LatencyUsec = ppr.UsecPerXfer
+				}
+				if ppr.MBPerSec > result.BandwidthMBps {
+					result.BandwidthMBps = ppr.MBPerSec
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+func printNetworkTestResults(results []*networkTestResult, out *strings.Builder) {
+	peerTitle := "Peer"
+	engineTitle := "Engine"
+	ifaceTitle := "Interface"
+	latTitle := "Latency (usec)"
+	bwTitle := "Bandwidth (MB/s)"
+
+	tf := txtfmt.NewTableFormatter(peerTitle, engineTitle, ifaceTitle, latTitle, bwTitle)
+	tf.InitWriter(out)
+
+	var table []txtfmt.TableRow
+	var failed []*networkTestResult
+	for _, result := range results {
+		row := txtfmt.TableRow{
+			peerTitle:   result.Peer,
+			engineTitle: strconv.Itoa(result.Engine),
+			ifaceTitle:  fmt.Sprintf("%s (%s)", result.Interface, result.Provider),
+			latTitle:    "-",
+			bwTitle:     "-",
+		}
+		if result.Error != "" {
+			failed = append(failed, result)
+		} else {
+			row[latTitle] = fmt.Sprintf("%.2f", result.LatencyUsec)
+			row[bwTitle] = fmt.Sprintf("%.2f", result.BandwidthMBps)
+		}
+		table = append(table, row)
+	}
+	tf.Format(table)
+
+	if len(failed) > 0 {
+		fmt.Fprintln(out)
+		for _, result := range failed {
+			fmt.Fprintf(out, "%s engine %d: %s\n", result.Peer, result.Engine, result.Error)
+		}
+	}
+}
+
+func (cmd *networkTestCmd) Execute(_ []string) error {
+	if cmd.Listen == (cmd.Peers != "") {
+		return errors.New("exactly one of --peers or --listen must be specified")
+	}
+	if cmd.pingPong == nil {
+		cmd.pingPong = runPingPong
+	}
+	if cmd.ifaceAddrs == nil {
+		cmd.ifaceAddrs = interfaceAddrs
+	}
+
+	endpoints, err := cmd.getEndpoints()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.MustLogCtx(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cmd.Listen {
+		return cmd.listen(ctx, endpoints)
+	}
+
+	peers, err := hostlist.CreateSet(cmd.Peers)
+	if err != nil {
+		return errors.Wrap(err, "parsing peers")
+	}
+	results := cmd.runTests(ctx, peers.Slice(), endpoints)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(results, nil)
+	}
+
+	var bld strings.Builder
+	printNetworkTestResults(results, &bld)
+	cmd.Info(bld.String())
+
+	return nil
+}
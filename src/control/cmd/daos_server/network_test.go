@@ -7,10 +7,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -19,6 +22,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hardware"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
 )
 
 var (
@@ -184,3 +188,211 @@ func TestDaosServer_Network_Commands_Config(t *testing.T) {
 		})
 	}
 }
+
+func networkTestCfg(providers ...string) *config.Server {
+	cfg := config.DefaultServer()
+	for i, prov := range providers {
+		cfg.Engines = append(cfg.Engines, engine.MockConfig().
+			WithFabricProvider(prov).
+			WithFabricInterface(fmt.Sprintf("eth%d", i)))
+	}
+	return cfg
+}
+
+func networkTestIfaceAddrs(name string) ([]net.Addr, error) {
+	switch name {
+	case "eth0":
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.1.1")}}, nil
+	case "eth1":
+		return []net.Addr{&net.IPNet{IP: net.ParseIP("10.0.2.1")}}, nil
+	}
+	return nil, errors.Errorf("unknown interface %q", name)
+}
+
+const testPingPongOutput = `bytes   #sent   #ack     total       time     MB/sec    usec/xfer   Mxfers/sec
+64      1k      =1k      125k        0.01s     10.38       6.17       0.16
+1m      100     =100     200m        0.03s   7037.41     149.00       0.01
+`
+
+func TestDaosServer_networkTestCmd(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg      *config.Server
+		peers    string
+		listen   bool
+		pingPong pingPongFn
+		expArgs  [][]string
+		expErr   error
+	}{
+		"neither peers nor listen": {
+			cfg:    networkTestCfg("ofi+tcp"),
+			expErr: errors.New("exactly one of --peers or --listen"),
+		},
+		"no engines": {
+			cfg:    networkTestCfg(),
+			peers:  "host1",
+			expErr: errors.New("at least one engine"),
+		},
+		"ucx provider": {
+			cfg:    networkTestCfg("ucx+dc_x"),
+			peers:  "host1",
+			expErr: errors.New("engine 0: provider \"ucx+dc_x\" is not a libfabric provider"),
+		},
+		"unknown interface": {
+			cfg:    networkTestCfg("ofi+tcp", "ofi+tcp", "ofi+tcp"),
+			peers:  "host1",
+			expErr: errors.New("engine 2 interface \"eth2\": unknown interface"),
+		},
+		"bad peers": {
+			cfg:    networkTestCfg("ofi+tcp"),
+			peers:  "host[1",
+			expErr: errors.New("parsing peers"),
+		},
+		"test peers": {
+			cfg:   networkTestCfg("ofi+tcp", "ofi+tcp"),
+			peers: "host[1-2]",
+			pingPong: func(_ context.Context, args ...string) ([]byte, error) {
+				if args[len(args)-1] == "host2" && args[3] == "10.0.2.1" {
+					return nil, errors.New("fi_pingpong failed")
+				}
+				return []byte(testPingPongOutput), nil
+			},
+			expArgs: [][]string{
+				{"-p", "tcp", "-e", "rdm", "-s", "10.0.1.1", "-I", "1000", "-S", "all", "-P", "47592", "host1"},
+				{"-p", "tcp", "-e", "rdm", "-s", "10.0.2.1", "-I", "1000", "-S", "all", "-P", "47593", "host1"},
+				{"-p", "tcp", "-e", "rdm", "-s", "10.0.1.1", "-I", "1000", "-S", "all", "-P", "47592", "host2"},
+				{"-p", "tcp", "-e", "rdm", "-s", "10.0.2.1", "-I", "1000", "-S", "all", "-P", "47593", "host2"},
+			},
+		},
+		"peers and listen": {
+			cfg:    networkTestCfg("ofi+tcp"),
+			peers:  "host1",
+			listen: true,
+			expErr: errors.New("exactly one of --peers or --listen"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var gotArgs [][]string
+			pingPong := tc.pingPong
+			if pingPong == nil {
+				pingPong = func(context.Context, ...string) ([]byte, error) {
+					return []byte(testPingPongOutput), nil
+				}
+			}
+
+			cmd := &networkTestCmd{
+				Peers:      tc.peers,
+				Listen:     tc.listen,
+				Port:       hardware.PingPongDefaultPort,
+				Iterations: 1000,
+				pingPong: func(ctx context.Context, args ...string) ([]byte, error) {
+					gotArgs = append(gotArgs, args)
+					return pingPong(ctx, args...)
+				},
+				ifaceAddrs: networkTestIfaceAddrs,
+			}
+			cmd.Logger = log
+			cmd.config = tc.cfg
+
+			test.CmpErr(t, tc.expErr, cmd.Execute(nil))
+			if diff := cmp.Diff(tc.expArgs, gotArgs); diff != "" {
+				t.Fatalf("unexpected fi_pingpong args (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDaosServer_networkTestCmd_runTests(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	cmd := &networkTestCmd{
+		Port: hardware.PingPongDefaultPort,
+		pingPong: func(_ context.Context, args ...string) ([]byte, error) {
+			if args[len(args)-1] == "host2" {
+				return nil, errors.New("fi_pingpong failed: connection refused")
+			}
+			return []byte(testPingPongOutput), nil
+		},
+	}
+	cmd.Logger = log
+
+	endpoints := []*networkTestEndpoint{
+		{engine: 0, iface: "eth0", provider: "tcp", addr: net.ParseIP("10.0.1.1")},
+	}
+	gotResults := cmd.runTests(test.Context(t), []string{"host1", "host2"}, endpoints)
+
+	expResults := []*networkTestResult{
+		{
+			Peer:          "host1",
+			Interface:     "eth0",
+			Provider:      "tcp",
+			LatencyUsec:   6.17,
+			BandwidthMBps: 7037.41,
+			Results: []*hardware.PingPongResult{
+				{Bytes: 64, Transfers: 1024, MBPerSec: 10.38, UsecPerXfer: 6.17},
+				{Bytes: 1 << 20, Transfers: 100, MBPerSec: 7037.41, UsecPerXfer: 149},
+			},
+		},
+		{
+			Peer:      "host2",
+			Interface: "eth0",
+			Provider:  "tcp",
+			Error:     "fi_pingpong failed: connection refused",
+		},
+	}
+	if diff := cmp.Diff(expResults, gotResults); diff != "" {
+		t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+	}
+
+	var bld strings.Builder
+	printNetworkTestResults(gotResults, &bld)
+	expOut := `
+Peer  Engine Interface  Latency (usec) Bandwidth (MB/s) 
+----  ------ ---------  -------------- ---------------- 
+host1 0      eth0 (tcp) 6.17           7037.41          
+host2 0      eth0 (tcp) -              -                
+
+host2 engine 0: fi_pingpong failed: connection refused
+`
+	if diff := cmp.Diff(strings.TrimLeft(expOut, "\n"), bld.String()); diff != "" {
+		t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestDaosServer_networkTestCmd_listen(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	defer cancel()
+
+	var gotArgs [][]string
+	cmd := &networkTestCmd{
+		Port:       hardware.PingPongDefaultPort,
+		Iterations: 10,
+		pingPong: func(_ context.Context, args ...string) ([]byte, error) {
+			gotArgs = append(gotArgs, args)
+			if len(gotArgs) == 2 {
+				// Stop the responder after it has been restarted.
+				cancel()
+			}
+			return nil, errors.New("fi_pingpong failed")
+		},
+	}
+	cmd.Logger = log
+
+	endpoints := []*networkTestEndpoint{
+		{engine: 1, iface: "eth1", provider: "verbs;ofi_rxm", addr: net.ParseIP("10.0.2.1")},
+	}
+	if err := cmd.listen(ctx, endpoints); err != nil {
+		t.Fatal(err)
+	}
+
+	expArgs := []string{"-p", "verbs;ofi_rxm", "-e", "rdm", "-s", "10.0.2.1", "-I", "10", "-S", "all", "-B", "47593"}
+	if diff := cmp.Diff([][]string{expArgs, expArgs}, gotArgs); diff != "" {
+		t.Fatalf("unexpected fi_pingpong args (-want, +got):\n%s\n", diff)
+	}
+}
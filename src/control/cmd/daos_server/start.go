@@ -31,6 +31,7 @@ type startCmd struct {
 	SocketDir   string  `short:"d" long:"socket_dir" description:"Location for all daos_server & daos_engine sockets"`
 	Insecure    bool    `short:"i" long:"insecure" description:"Allow for insecure connections"`
 	AutoFormat  bool    `long:"auto-format" description:"Automatically format storage on server start to bring-up engines without requiring dmg storage format command"`
+	Simulate    uint16  `long:"simulate" description:"Run the given number of simulated engines backed by simulated storage instead of the engines in the config file"`
 }
 
 func (cmd *startCmd) setCLIOverrides() error {
@@ -85,9 +86,11 @@ func (cmd *startCmd) configureLogging() error {
 	}
 
 	return cmdutil.ConfigureLogger(cmd.Logger, cmdutil.LogConfig{
-		LogFile:  cmd.config.ControlLogFile,
-		LogLevel: cmd.config.ControlLogMask,
-		JSON:     cmd.config.ControlLogJSON,
+		LogFile:         cmd.config.ControlLogFile,
+		LogLevel:        cmd.config.ControlLogMask,
+		JSON:            cmd.config.ControlLogJSON,
+		Syslog:          cmd.config.ControlLogSyslog,
+		SubsystemLevels: cmd.config.ControlLogSubsys,
 	})
 }
 
@@ -101,6 +104,7 @@ func (cmd *startCmd) Execute(args []string) error {
 	}
 
 	cmd.config.AutoFormat = cmd.AutoFormat
+	cmd.config.SimulateEngines = int(cmd.Simulate)
 
 	return cmd.start(cmd.Logger, cmd.config)
 }
@@ -235,6 +235,13 @@ func TestStartOptions(t *testing.T) {
 				return cfg.WithTransportConfig(insecureTransport)
 			},
 		},
+		"Simulate": {
+			argList: []string{"--simulate=2"},
+			expCfgFn: func(cfg *config.Server) *config.Server {
+				cfg.SimulateEngines = 2
+				return cfg
+			},
+		},
 	} {
 		t.Run(desc, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
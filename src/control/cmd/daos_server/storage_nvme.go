@@ -53,18 +53,22 @@ func validateVFIOSetting(targetUser string, reqDisableVFIO bool, iommuEnabled bo
 	return nil
 }
 
+func bdevCfgsFromCfg(cfg *config.Server) storage.TierConfigs {
+	var bdevCfgs storage.TierConfigs
+	for _, ec := range cfg.Engines {
+		bdevCfgs = append(bdevCfgs, ec.Storage.Tiers.BdevConfigs()...)
+	}
+
+	return bdevCfgs
+}
+
 func nvmeBdevsFromCfg(cfg *config.Server) *storage.BdevDeviceList {
 	if cfg == nil {
 		return nil
 	}
 
 	// Combine engine bdev_lists to create total device list.
-	var bdevCfgs storage.TierConfigs
-	for _, ec := range cfg.Engines {
-		bdevCfgs = append(bdevCfgs, ec.Storage.Tiers.BdevConfigs()...)
-	}
-
-	bds := bdevCfgs.NVMeBdevs()
+	bds := bdevCfgsFromCfg(cfg).NVMeBdevs()
 	if bds.Len() == 0 {
 		return nil
 	}
@@ -107,6 +111,19 @@ func updateNVMePrepReqFromConfig(log logging.Logger, cfg *config.Server, req *st
 		}
 	}
 
+	// Opal drives are provisioned with the configured key if necessary and
+	// then unlocked so that they can be used after binding to SPDK.
+	if opalDevs := bdevCfgsFromCfg(cfg).OpalDevices(); len(opalDevs) > 0 && !req.Reset_ {
+		key, err := cfg.EncryptionKey.GetKey()
+		if err != nil {
+			return errors.Wrap(err, "provisioning opal nvme drives")
+		}
+		log.Debugf("provisioning opal nvme drives %v", opalDevs)
+		req.OpalDevices = opalDevs
+		req.OpalProvision = true
+		req.EncryptionKey = key
+	}
+
 	return nil
 }
 
@@ -155,7 +155,10 @@ func genFiAffFn(fis *hardware.FabricInterfaceSet) config.EngineAffinityFn {
 }
 
 func getAffinitySource(ctx context.Context, log logging.Logger, cfg *config.Server) (config.EngineAffinityFn, error) {
-	scanner := hwprov.DefaultFabricScanner(log)
+	scanner, err := hwprov.DefaultFabricScannerWithFilter(log, cfg.FabricScanFilter())
+	if err != nil {
+		return nil, err
+	}
 
 	provs, err := cfg.Fabric.GetProviders()
 	if err != nil {
@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/hardware/hwprov"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// topologyCmd is the struct representing the top-level topology subcommand.
+type topologyCmd struct {
+	Export topologyExportCmd `command:"export" description:"Export a JSON snapshot of the NUMA, CPU, fabric and storage topology of the local server for offline analysis"`
+}
+
+type getTopologyFn func(context.Context, logging.Logger) (*hardware.Topology, error)
+
+func getLocalTopology(ctx context.Context, log logging.Logger) (*hardware.Topology, error) {
+	topo, err := hwprov.DefaultTopologyProvider(log).GetTopology(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching local hardware topology")
+	}
+
+	return topo, nil
+}
+
+type topologyExportCmd struct {
+	helperLogCmd
+	cmdutil.LogCmd
+
+	SkipPrep bool   `long:"skip-prep" description:"Skip preparation of devices during scan."`
+	Output   string `short:"o" long:"output" default:"stdout" description:"Write the snapshot to this location"`
+}
+
+// snapshot records the hardware detected on the local server.
+func (cmd *topologyExportCmd) snapshot(ctx context.Context, hostname string, getTopo getTopologyFn, getFabric getFabricFn, getStorage getStorageFn) (*control.TopologySnapshot, error) {
+	topo, err := getTopo(ctx, cmd.Logger)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Debugf("fetched topology with %d NUMA nodes", topo.NumNUMANodes())
+
+	// Record interfaces for all providers so that the snapshot can be used to generate
+	// configs for any of them.
+	hf, err := getFabric(ctx, cmd.Logger, "")
+	if err != nil {
+		return nil, err
+	}
+	cmd.Debugf("fetched host fabric info: %+v", hf)
+
+	hs, err := getStorage(ctx, cmd.Logger, cmd.SkipPrep)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Debugf("fetched host storage info: %+v", hs)
+
+	snap := &control.TopologySnapshot{
+		Version:  control.TopologySnapshotVersion,
+		Hostname: hostname,
+		Created:  time.Now(),
+		Topology: topo,
+		Fabric:   hf,
+		Storage:  hs,
+	}
+
+	return snap, snap.Validate()
+}
+
+// Execute is run when topologyExportCmd activates.
+//
+// Scan the local hardware and write the results as a topology snapshot that can be consumed by
+// "dmg config generate" on a machine without access to this server.
+func (cmd *topologyExportCmd) Execute(_ []string) error {
+	if err := common.CheckDupeProcess(); err != nil {
+		return err
+	}
+
+	if err := cmd.setHelperLogFile(); err != nil {
+		return err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return errors.Wrap(err, "fetching hostname")
+	}
+
+	snap, err := cmd.snapshot(cmd.MustLogCtx(), hostname, getLocalTopology, getLocalFabric,
+		getLocalStorage)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if cmd.Output == "stdout" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(cmd.Output, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %q", cmd.Output)
+	}
+	cmd.Infof("Topology snapshot written to %s", cmd.Output)
+
+	return nil
+}
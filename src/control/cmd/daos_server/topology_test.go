@@ -0,0 +1,122 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestDaosServer_Topology_Commands(t *testing.T) {
+	runCmdTests(t, []cmdTest{
+		{
+			"Export with defaults",
+			"topology export",
+			printCommand(t, &topologyExportCmd{Output: "stdout"}),
+			nil,
+		},
+		{
+			"Export to file without prep",
+			"topology export --skip-prep -o /tmp/topo.json",
+			printCommand(t, &topologyExportCmd{SkipPrep: true, Output: "/tmp/topo.json"}),
+			nil,
+		},
+	})
+}
+
+func TestDaosServer_Topology_snapshot(t *testing.T) {
+	topo := &hardware.Topology{
+		NUMANodes: hardware.NodeMap{
+			0: hardware.MockNUMANode(0, 8),
+			1: hardware.MockNUMANode(1, 8, 8),
+		},
+	}
+	hf := &control.HostFabric{
+		Interfaces: []*control.HostFabricInterface{
+			{Provider: "ofi+tcp", Device: "eth0", NumaNode: 0, NetDevClass: 1},
+		},
+		Providers:    []string{"ofi+tcp"},
+		NumaCount:    2,
+		CoresPerNuma: 8,
+	}
+	hs := &control.HostStorage{
+		NvmeDevices: storage.MockNvmeControllers(2),
+		MemInfo:     control.MockMemInfo(),
+	}
+
+	for name, tc := range map[string]struct {
+		skipPrep   bool
+		topoErr    error
+		fabricErr  error
+		storageErr error
+		expErr     error
+	}{
+		"topology fails": {
+			topoErr: errors.New("topology failed"),
+			expErr:  errors.New("topology failed"),
+		},
+		"fabric scan fails": {
+			fabricErr: errors.New("fabric failed"),
+			expErr:    errors.New("fabric failed"),
+		},
+		"storage scan fails": {
+			storageErr: errors.New("storage failed"),
+			expErr:     errors.New("storage failed"),
+		},
+		"success": {},
+		"success; skip prep": {
+			skipPrep: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cmd := &topologyExportCmd{SkipPrep: tc.skipPrep}
+			cmd.Logger = log
+
+			var gotProvider string
+			var gotSkipPrep bool
+			getTopo := func(_ context.Context, _ logging.Logger) (*hardware.Topology, error) {
+				return topo, tc.topoErr
+			}
+			getFabric := func(_ context.Context, _ logging.Logger, prov string) (*control.HostFabric, error) {
+				gotProvider = prov
+				return hf, tc.fabricErr
+			}
+			getStorage := func(_ context.Context, _ logging.Logger, skipPrep bool) (*control.HostStorage, error) {
+				gotSkipPrep = skipPrep
+				return hs, tc.storageErr
+			}
+
+			snap, err := cmd.snapshot(test.Context(t), "host1", getTopo, getFabric, getStorage)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, "", gotProvider, "expected interfaces for all providers")
+			test.AssertEqual(t, tc.skipPrep, gotSkipPrep, "unexpected skip prep")
+			test.AssertEqual(t, control.TopologySnapshotVersion, snap.Version, "unexpected version")
+			test.AssertEqual(t, "host1", snap.Hostname, "unexpected hostname")
+			if snap.Created.IsZero() {
+				t.Fatal("expected creation time to be set")
+			}
+			if snap.Topology != topo || snap.Fabric != hf || snap.Storage != hs {
+				t.Fatal("expected snapshot to contain scan results")
+			}
+		})
+	}
+}
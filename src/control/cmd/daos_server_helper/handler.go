@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -200,6 +200,31 @@ func (h *scmScanHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.Res
 	return pbin.NewResponseWithPayload(sRes)
 }
 
+// scmHealthQueryHandler implements the ScmHealthQuery method.
+type scmHealthQueryHandler struct {
+	scmHandler
+}
+
+func (h *scmHealthQueryHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.Response {
+	if req == nil {
+		return getNilRequestResp()
+	}
+
+	var qReq storage.ScmHealthQueryRequest
+	if err := json.Unmarshal(req.Payload, &qReq); err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	h.setupProvider(log)
+
+	qRes, err := h.scmProvider.QueryHealth(qReq)
+	if err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	return pbin.NewResponseWithPayload(qRes)
+}
+
 // scmPrepHandler implements the ScmPrepare method.
 type scmPrepHandler struct {
 	scmHandler
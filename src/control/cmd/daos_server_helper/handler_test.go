@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -633,6 +633,82 @@ func TestDaosAdmin_ScmScanHandler(t *testing.T) {
 	}
 }
 
+func TestDaosAdmin_ScmHealthQueryHandler(t *testing.T) {
+	scmHealthReqPayload, err := json.Marshal(storage.ScmHealthQueryRequest{
+		ForwardableRequest: pbin.ForwardableRequest{Forwarded: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockHealth := []*storage.ScmModuleHealth{
+		{
+			UID:                "8089-a2-1839-000010ce",
+			HealthState:        "Healthy",
+			MediaTemperature:   36,
+			SpareCapacity:      100,
+			DirtyShutdownCount: 1,
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		req        *pbin.Request
+		smbc       *scm.MockBackendConfig
+		expPayload *storage.ScmHealthQueryResponse
+		expErr     *fault.Fault
+	}{
+		"nil request": {
+			expErr: pbin.PrivilegedHelperRequestFailed("nil request"),
+		},
+		"ScmHealthQuery nil payload": {
+			req: &pbin.Request{
+				Method: "ScmHealthQuery",
+			},
+			expErr: nilPayloadErr,
+		},
+		"ScmHealthQuery success": {
+			req: &pbin.Request{
+				Method:  "ScmHealthQuery",
+				Payload: scmHealthReqPayload,
+			},
+			smbc: &scm.MockBackendConfig{
+				GetModuleHealthRes: mockHealth,
+			},
+			expPayload: &storage.ScmHealthQueryResponse{
+				Modules: mockHealth,
+			},
+		},
+		"ScmHealthQuery failure": {
+			req: &pbin.Request{
+				Method:  "ScmHealthQuery",
+				Payload: scmHealthReqPayload,
+			},
+			smbc: &scm.MockBackendConfig{
+				GetModuleHealthErr: errors.New("query failed"),
+			},
+			expErr: pbin.PrivilegedHelperRequestFailed("query failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			sp := scm.NewMockProvider(log, tc.smbc, nil)
+			handler := &scmHealthQueryHandler{scmHandler: scmHandler{scmProvider: sp}}
+
+			resp := handler.Handle(log, tc.req)
+
+			if diff := cmp.Diff(tc.expErr, resp.Error); diff != "" {
+				t.Errorf("got wrong fault (-want, +got)\n%s\n", diff)
+			}
+			if tc.expPayload == nil {
+				tc.expPayload = &storage.ScmHealthQueryResponse{}
+			}
+			expectPayload(t, resp, &storage.ScmHealthQueryResponse{}, tc.expPayload)
+		})
+	}
+}
+
 func TestDaosAdmin_BdevScanHandler(t *testing.T) {
 	bdevScanReqPayload, err := json.Marshal(storage.BdevScanRequest{
 		ForwardableRequest: pbin.ForwardableRequest{Forwarded: true},
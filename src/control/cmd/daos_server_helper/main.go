@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -40,6 +40,7 @@ func addMethodHandlers(app *pbin.App) {
 	app.AddHandler("ScmFormat", &scmFormatCheckHandler{})
 	app.AddHandler("ScmCheckFormat", &scmFormatCheckHandler{})
 	app.AddHandler("ScmScan", &scmScanHandler{})
+	app.AddHandler("ScmHealthQuery", &scmHealthQueryHandler{})
 	app.AddHandler("ScmPrepare", &scmPrepHandler{})
 
 	app.AddHandler("BdevPrepare", &bdevPrepHandler{})
@@ -0,0 +1,216 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// scanConfigArgs returns the client config path and whether command defaults
+// are disabled, as given on the command line. They are needed before the
+// command line is parsed so that aliases and default flags can be applied.
+func scanConfigArgs(p *flags.Parser, args []string) (cfgPath string, noDefaults bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return
+		case arg == "--no-defaults":
+			noDefaults = true
+		case arg == "--config-path":
+			if i+1 < len(args) {
+				i++
+				cfgPath = args[i]
+			}
+		case strings.HasPrefix(arg, "--config-path="):
+			cfgPath = strings.TrimPrefix(arg, "--config-path=")
+		case isOption(arg) && !strings.HasPrefix(arg, "--"):
+			// Short options may be combined, e.g. -jo <path>.
+			shorts := []rune(arg[1:])
+			for j, r := range shorts {
+				if r == 'o' {
+					if j < len(shorts)-1 {
+						cfgPath = string(shorts[j+1:])
+					} else if i+1 < len(args) {
+						i++
+						cfgPath = args[i]
+					}
+					break
+				}
+				if opt := p.FindOptionByShortName(r); opt == nil || optionTakesValue(opt) {
+					break
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// optionTakesValue returns true if the option requires an argument.
+func optionTakesValue(opt *flags.Option) bool {
+	if opt == nil || opt.OptionalArgument {
+		return false
+	}
+
+	t := reflect.TypeOf(opt.Value())
+	if t != nil && t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t == nil || t.Kind() != reflect.Bool
+}
+
+// skipOption returns the index of the argument following the option at
+// args[i] and its value, if any.
+func skipOption(cmd *flags.Command, args []string, i int) int {
+	arg := args[i]
+	if strings.HasPrefix(arg, "--") {
+		if strings.Contains(arg, "=") {
+			return i + 1
+		}
+		if optionTakesValue(cmd.FindOptionByLongName(arg[2:])) {
+			return i + 2
+		}
+		return i + 1
+	}
+
+	shorts := []rune(arg[1:])
+	for j, r := range shorts {
+		if optionTakesValue(cmd.FindOptionByShortName(r)) {
+			if j == len(shorts)-1 {
+				return i + 2
+			}
+			break
+		}
+	}
+	return i + 1
+}
+
+func isOption(arg string) bool {
+	return strings.HasPrefix(arg, "-") && arg != "-" && arg != "--"
+}
+
+// resolveCommand returns the canonical names of the commands in the supplied
+// path, which may use command aliases, e.g. "sys query".
+func resolveCommand(p *flags.Parser, path string) ([]string, error) {
+	var names []string
+	cmd := p.Command
+	for _, word := range strings.Fields(path) {
+		if cmd = cmd.Find(word); cmd == nil {
+			return nil, errors.Errorf("unknown command %q", path)
+		}
+		names = append(names, cmd.Name)
+	}
+	return names, nil
+}
+
+// applyCommandDefaults expands an alias at the start of the command line and
+// inserts the default flags configured for the command after the command
+// name, so that flags given on the command line take precedence. The
+// expanded command line and the default flags that were added are returned.
+func applyCommandDefaults(p *flags.Parser, cfg *control.Config, args []string) ([]string, []string, error) {
+	if cfg == nil || (len(cfg.Aliases) == 0 && len(cfg.DefaultFlags) == 0) {
+		return args, nil, nil
+	}
+
+	for name := range cfg.Aliases {
+		if p.Find(name) != nil {
+			return nil, nil, errors.Errorf("alias %q in %s conflicts with a dmg command", name, cfg.Path)
+		}
+	}
+
+	// Skip any leading global options to find the first command word.
+	i := 0
+	for i < len(args) && isOption(args[i]) {
+		i = skipOption(p.Command, args, i)
+	}
+	if i < len(args) {
+		if cmdLine, found := cfg.Aliases[args[i]]; found {
+			expanded := append([]string{}, args[:i]...)
+			expanded = append(expanded, strings.Fields(cmdLine)...)
+			args = append(expanded, args[i+1:]...)
+		}
+	}
+
+	// Find the end of the command path, e.g. "pool query".
+	var path []string
+	insertAt := -1
+	cmd := p.Command
+	for i = 0; i < len(args) && args[i] != "--"; {
+		if isOption(args[i]) {
+			i = skipOption(cmd, args, i)
+			continue
+		}
+		sub := cmd.Find(args[i])
+		if sub == nil {
+			break
+		}
+		cmd = sub
+		path = append(path, sub.Name)
+		i++
+		insertAt = i
+	}
+	if insertAt < 0 {
+		return args, nil, nil
+	}
+
+	// Apply the defaults for all commands first, then those for each
+	// command in the path, so that the most specific defaults win.
+	type cmdDefaults struct {
+		depth int
+		flags []string
+	}
+	var matched []cmdDefaults
+	for key, keyFlags := range cfg.DefaultFlags {
+		if strings.TrimSpace(key) == control.AllCommands {
+			matched = append(matched, cmdDefaults{0, keyFlags})
+			continue
+		}
+		names, err := resolveCommand(p, key)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "default flags in %s", cfg.Path)
+		}
+		if len(names) > len(path) || strings.Join(names, " ") != strings.Join(path[:len(names)], " ") {
+			continue
+		}
+		matched = append(matched, cmdDefaults{len(names), keyFlags})
+	}
+	if len(matched) == 0 {
+		return args, nil, nil
+	}
+	sort.Slice(matched, func(a, b int) bool {
+		return matched[a].depth < matched[b].depth
+	})
+
+	var defaults []string
+	for _, m := range matched {
+		defaults = append(defaults, m.flags...)
+	}
+
+	expanded := append([]string{}, args[:insertAt]...)
+	expanded = append(expanded, defaults...)
+	return append(expanded, args[insertAt:]...), defaults, nil
+}
+
+// defaultsError adds the default flags to an error caused by them, if any.
+func defaultsError(err error, cfgPath string, defaults []string) error {
+	if err == nil || len(defaults) == 0 {
+		return err
+	}
+	if fe, ok := errors.Cause(err).(*flags.Error); !ok || fe.Type == flags.ErrHelp {
+		return err
+	}
+	return errors.Wrapf(err, "command line included default flags %q from %s (use --no-defaults to ignore them)",
+		strings.Join(defaults, " "), cfgPath)
+}
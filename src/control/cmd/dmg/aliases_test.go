@@ -0,0 +1,174 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestDmg_scanConfigArgs(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args          string
+		expPath       string
+		expNoDefaults bool
+	}{
+		"none": {
+			args: "system query",
+		},
+		"short": {
+			args:    "-o /tmp/dmg.yml system query",
+			expPath: "/tmp/dmg.yml",
+		},
+		"short attached": {
+			args:    "-o/tmp/dmg.yml system query",
+			expPath: "/tmp/dmg.yml",
+		},
+		"short combined": {
+			args:    "-jo /tmp/dmg.yml system query",
+			expPath: "/tmp/dmg.yml",
+		},
+		"long": {
+			args:    "system query --config-path /tmp/dmg.yml",
+			expPath: "/tmp/dmg.yml",
+		},
+		"long with equals": {
+			args:    "--config-path=/tmp/dmg.yml system query",
+			expPath: "/tmp/dmg.yml",
+		},
+		"value containing o": {
+			args: "system query -lfoo",
+		},
+		"no defaults": {
+			args:          "--no-defaults system query",
+			expNoDefaults: true,
+		},
+		"after terminator": {
+			args: "pool create -- -o --no-defaults",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := flags.NewParser(&cliOptions{}, flags.Default)
+
+			gotPath, gotNoDefaults := scanConfigArgs(p, strings.Fields(tc.args))
+			test.AssertEqual(t, tc.expPath, gotPath, "unexpected config path")
+			test.AssertEqual(t, tc.expNoDefaults, gotNoDefaults, "unexpected no-defaults")
+		})
+	}
+}
+
+func TestDmg_applyCommandDefaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		aliases     map[string]string
+		defaults    map[string][]string
+		args        string
+		expArgs     string
+		expDefaults []string
+		expErr      error
+	}{
+		"nothing configured": {
+			args:    "system query",
+			expArgs: "system query",
+		},
+		"alias": {
+			aliases: map[string]string{"health": "system query --verbose"},
+			args:    "health",
+			expArgs: "system query --verbose",
+		},
+		"alias with global options and args": {
+			aliases: map[string]string{"health": "system query --verbose"},
+			args:    "-o /tmp/dmg.yml -d health --ranks 0-3",
+			expArgs: "-o /tmp/dmg.yml -d system query --verbose --ranks 0-3",
+		},
+		"alias not at start is not expanded": {
+			aliases: map[string]string{"health": "system query"},
+			args:    "pool query health",
+			expArgs: "pool query health",
+		},
+		"alias conflicts with command": {
+			aliases: map[string]string{"sys": "system query"},
+			args:    "system query",
+			expErr:  errors.New("conflicts with a dmg command"),
+		},
+		"default flags for all commands": {
+			defaults:    map[string][]string{control.AllCommands: {"--json"}},
+			args:        "pool list",
+			expArgs:     "pool list --json",
+			expDefaults: []string{"--json"},
+		},
+		"default flags before positional args": {
+			defaults:    map[string][]string{"pool query": {"--health-only"}},
+			args:        "pool query tank",
+			expArgs:     "pool query --health-only tank",
+			expDefaults: []string{"--health-only"},
+		},
+		"default flags with command aliases": {
+			defaults:    map[string][]string{"sto scan": {"--host-list=storage[1-8]"}},
+			args:        "-j storage scan --verbose",
+			expArgs:     "-j storage scan --host-list=storage[1-8] --verbose",
+			expDefaults: []string{"--host-list=storage[1-8]"},
+		},
+		"default flags ordered from least to most specific": {
+			defaults: map[string][]string{
+				"system query":      {"--verbose"},
+				control.AllCommands: {"--json"},
+				"system":            {"--debug"},
+				"system stop":       {"--force"},
+				"storage scan":      {"--nvme-health"},
+			},
+			args:        "system query",
+			expArgs:     "system query --json --debug --verbose",
+			expDefaults: []string{"--json", "--debug", "--verbose"},
+		},
+		"alias and default flags": {
+			aliases:     map[string]string{"health": "system query --verbose"},
+			defaults:    map[string][]string{"system query": {"--ranks=0-7"}},
+			args:        "health",
+			expArgs:     "system query --ranks=0-7 --verbose",
+			expDefaults: []string{"--ranks=0-7"},
+		},
+		"default flags for unknown command": {
+			defaults: map[string][]string{"system frobnicate": {"--json"}},
+			args:     "system query",
+			expErr:   errors.New("unknown command"),
+		},
+		"no command": {
+			defaults: map[string][]string{control.AllCommands: {"--json"}},
+			args:     "--help",
+			expArgs:  "--help",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := flags.NewParser(&cliOptions{}, flags.Default)
+			cfg := &control.Config{
+				Aliases:      tc.aliases,
+				DefaultFlags: tc.defaults,
+				Path:         "/tmp/dmg.yml",
+			}
+
+			gotArgs, gotDefaults, gotErr := applyCommandDefaults(p, cfg, strings.Fields(tc.args))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expArgs, strings.Join(gotArgs, " ")); diff != "" {
+				t.Fatalf("unexpected args (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expDefaults, gotDefaults); diff != "" {
+				t.Fatalf("unexpected defaults (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
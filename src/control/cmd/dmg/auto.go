@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -30,7 +30,8 @@ var confGenRemoteCall confGenRemoteFn = control.ConfGenerateRemote
 
 // configCmd is the struct representing the top-level config subcommand.
 type configCmd struct {
-	Generate configGenCmd `command:"generate" alias:"gen" description:"Generate DAOS server configuration file based on discoverable hardware devices"`
+	Generate configGenCmd   `command:"generate" alias:"gen" description:"Generate DAOS server configuration file based on discoverable hardware devices"`
+	Apply    configApplyCmd `command:"apply" description:"Validate a DAOS server configuration file against the hardware of remote servers and optionally save it for their next restart"`
 }
 
 type configGenCmd struct {
@@ -40,25 +41,41 @@ type configGenCmd struct {
 	hostListCmd
 	cmdutil.JSONOutputCmd
 	cmdutil.ConfGenCmd
+
+	Snapshots []string `long:"topology-snapshot" description:"Generate from a topology snapshot file exported with 'daos_server topology export' rather than scanning hosts. Repeat for each host"`
 }
 
 func (cmd *configGenCmd) confGen(ctx context.Context) (*config.Server, error) {
 	cmd.Debugf("ConfGen called with command parameters %+v", cmd)
 
-	// check cli then config for hostlist, default to localhost
-	hl := cmd.getHostList()
-	if len(hl) == 0 && cmd.config != nil {
-		hl = cmd.config.HostList
-	}
-	if len(hl) == 0 {
-		hl = []string{"localhost"}
-	}
-
 	req := control.ConfGenerateRemoteReq{
 		ConfGenerateReq: control.ConfGenerateReq{},
 		Client:          cmd.ctlInvoker,
-		HostList:        hl,
 	}
+
+	if len(cmd.Snapshots) > 0 {
+		// hardware details are taken from the snapshots so no hosts are contacted
+		if len(cmd.getHostList()) > 0 {
+			return nil, errors.New("--host-list may not be used with --topology-snapshot")
+		}
+		for _, path := range cmd.Snapshots {
+			snap, err := control.ReadTopologySnapshot(path)
+			if err != nil {
+				return nil, err
+			}
+			req.Snapshots = append(req.Snapshots, snap)
+		}
+	} else {
+		// check cli then config for hostlist, default to localhost
+		req.HostList = cmd.getHostList()
+		if len(req.HostList) == 0 && cmd.config != nil {
+			req.HostList = cmd.config.HostList
+		}
+		if len(req.HostList) == 0 {
+			req.HostList = []string{"localhost"}
+		}
+	}
+
 	if err := convert.Types(&cmd.ConfGenCmd, &req.ConfGenerateReq); err != nil {
 		return nil, err
 	}
@@ -8,7 +8,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -262,6 +264,7 @@ func TestAuto_confGenCmd_Convert(t *testing.T) {
 	cmd.UseTmpfsSCM = true
 	cmd.ExtMetadataPath = "/opt/daos_md"
 	cmd.FabricPorts = "12345,13345"
+	cmd.BdevNUMA = "0000:81:00.0=1,0000:5d:05.5=0"
 
 	req := new(control.ConfGenerateReq)
 	if err := convert.Types(cmd.ConfGenCmd, req); err != nil {
@@ -277,6 +280,10 @@ func TestAuto_confGenCmd_Convert(t *testing.T) {
 		UseTmpfsSCM:     true,
 		ExtMetadataPath: "/opt/daos_md",
 		FabricPorts:     []int{12345, 13345},
+		BdevNUMAOverrides: map[string]uint{
+			"0000:81:00.0": 1,
+			"0000:5d:05.5": 0,
+		},
 	}
 
 	if diff := cmp.Diff(expReq, req); diff != "" {
@@ -284,6 +291,107 @@ func TestAuto_confGenCmd_Convert(t *testing.T) {
 	}
 }
 
+func TestAuto_confGen_snapshots(t *testing.T) {
+	writeSnapshot := func(t *testing.T, dir, hostname string) string {
+		snap := &control.TopologySnapshot{
+			Version:  control.TopologySnapshotVersion,
+			Hostname: hostname,
+			Topology: &hardware.Topology{
+				NUMANodes: hardware.NodeMap{
+					0: hardware.MockNUMANode(0, 24),
+					1: hardware.MockNUMANode(1, 24, 24),
+				},
+			},
+			Fabric: &control.HostFabric{
+				NumaCount:    2,
+				CoresPerNuma: 24,
+			},
+			Storage: &control.HostStorage{
+				NvmeDevices: storage.MockNvmeControllers(2),
+				MemInfo:     control.MockMemInfo(),
+			},
+		}
+		data, err := json.Marshal(snap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, hostname+".json")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for name, tc := range map[string]struct {
+		hostlist []string
+		hosts    []string
+		missing  bool
+		expHosts []string
+		expErr   error
+	}{
+		"missing snapshot": {
+			hosts:   []string{"host1"},
+			missing: true,
+			expErr:  errors.New("reading topology snapshot"),
+		},
+		"hostlist and snapshots": {
+			hostlist: []string{"host1"},
+			hosts:    []string{"host1"},
+			expErr:   errors.New("may not be used with --topology-snapshot"),
+		},
+		"multiple snapshots": {
+			hosts:    []string{"host1", "host2"},
+			expHosts: []string{"host1", "host2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var gotReq *control.ConfGenerateRemoteReq
+			origGenRemCall := confGenRemoteCall
+			confGenRemoteCall = func(_ context.Context, req control.ConfGenerateRemoteReq) (*control.ConfGenerateRemoteResp, error) {
+				gotReq = &req
+				return &control.ConfGenerateRemoteResp{}, nil
+			}
+			defer func() {
+				confGenRemoteCall = origGenRemCall
+			}()
+
+			dir := t.TempDir()
+			cmd := &configGenCmd{}
+			cmd.AccessPoints = "localhost"
+			cmd.NetClass = "infiniband"
+			cmd.Logger = log
+			cmd.hostlist = tc.hostlist
+			for _, host := range tc.hosts {
+				path := filepath.Join(dir, host+".json")
+				if !tc.missing {
+					path = writeSnapshot(t, dir, host)
+				}
+				cmd.Snapshots = append(cmd.Snapshots, path)
+			}
+
+			_, gotErr := cmd.confGen(test.Context(t))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if len(gotReq.HostList) != 0 {
+				t.Fatalf("unexpected host list %v", gotReq.HostList)
+			}
+			var gotHosts []string
+			for _, snap := range gotReq.Snapshots {
+				gotHosts = append(gotHosts, snap.Hostname)
+			}
+			if diff := cmp.Diff(tc.expHosts, gotHosts); diff != "" {
+				t.Fatalf("unexpected snapshot hosts (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 // The Control API calls made in ConfigGenCmd.confGen() are already well tested so just do some
 // sanity checking here to prevent regressions.
 func TestAuto_confGen(t *testing.T) {
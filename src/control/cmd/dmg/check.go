@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022-2023 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -140,6 +140,7 @@ func (f *setRepPolFlag) Complete(match string) []flags.Completion {
 
 type checkStartCmd struct {
 	checkPoolCmdBase
+	progressCmd
 
 	DryRun      bool           `short:"n" long:"dry-run" description:"Scan only; do not initiate repairs."`
 	Reset       bool           `short:"r" long:"reset" description:"Reset the system check state."`
@@ -147,6 +148,7 @@ type checkStartCmd struct {
 	Auto        ui.EnabledFlag `short:"a" long:"auto" description:"Attempt to automatically repair problems." choice:"on" choice:"off"`
 	FindOrphans bool           `short:"O" long:"find-orphans" description:"Find orphaned pools."`
 	Policies    setRepPolFlag  `short:"p" long:"policies" description:"Set repair policies."`
+	Wait        bool           `short:"w" long:"wait" description:"Wait for the checker to stop running."`
 }
 
 func (cmd *checkStartCmd) Execute(_ []string) error {
@@ -186,6 +188,23 @@ func (cmd *checkStartCmd) Execute(_ []string) error {
 
 	cmd.Info("system checker started")
 
+	if !cmd.Wait {
+		return nil
+	}
+
+	waitReq := &control.SystemCheckWaitReq{Uuids: req.Uuids}
+	if pp := cmd.newProgressPrinter("system check"); pp != nil {
+		waitReq.SetProgressFn(pp.Update)
+		defer pp.Finish()
+	}
+
+	resp, err := control.SystemCheckWait(ctx, cmd.ctlInvoker, waitReq)
+	if err != nil {
+		return err
+	}
+
+	cmd.Infof("system checker %s", strings.ToLower(resp.Status.String()))
+
 	return nil
 }
 
@@ -0,0 +1,623 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	defaultClusterInitJoinTimeout = 5 * time.Minute
+	defaultClusterInitConfigFile  = "daos_server.yml"
+	defaultClusterInitPoolLabel   = "pool1"
+	defaultClusterInitPoolSize    = "100%"
+)
+
+// clusterInitJoinPollInterval is the interval at which the system is queried
+// while waiting for ranks to join.
+var clusterInitJoinPollInterval = 2 * time.Second
+
+// clusterCmd is the struct representing the top-level cluster subcommand.
+type clusterCmd struct {
+	Init clusterInitCmd `command:"init" description:"Bring up a new DAOS system step by step, or non-interactively from an answers file"`
+}
+
+type (
+	// clusterInitConfigAnswers contains the parameters used to generate the
+	// server config file, as for dmg config generate.
+	clusterInitConfigAnswers struct {
+		Output          string `yaml:"output"`
+		AccessPoints    string `yaml:"access_points"`
+		NrEngines       int    `yaml:"num_engines,omitempty"`
+		SCMOnly         bool   `yaml:"scm_only,omitempty"`
+		NetClass        string `yaml:"net_class,omitempty"`
+		NetProvider     string `yaml:"net_provider,omitempty"`
+		UseTmpfsSCM     bool   `yaml:"use_tmpfs_scm,omitempty"`
+		ExtMetadataPath string `yaml:"control_metadata_path,omitempty"`
+		FabricPorts     string `yaml:"fabric_ports,omitempty"`
+	}
+
+	// clusterInitPoolAnswers contains the parameters of the initial pool.
+	clusterInitPoolAnswers struct {
+		Label string `yaml:"label"`
+		Size  string `yaml:"size"`
+	}
+
+	// clusterInitAnswers contains the answers to the questions asked by
+	// dmg cluster init. Optional steps are only run if their section is
+	// present.
+	clusterInitAnswers struct {
+		GenerateConfig *clusterInitConfigAnswers `yaml:"generate_config,omitempty"`
+		SkipFormat     bool                      `yaml:"skip_format,omitempty"`
+		JoinTimeout    time.Duration             `yaml:"join_timeout,omitempty"`
+		Pool           *clusterInitPoolAnswers   `yaml:"pool,omitempty"`
+	}
+)
+
+// validate checks the answers and sets defaults for unset values.
+func (a *clusterInitAnswers) validate() error {
+	if a.JoinTimeout < 0 {
+		return errors.New("join_timeout must be non-negative")
+	}
+	if a.JoinTimeout == 0 {
+		a.JoinTimeout = defaultClusterInitJoinTimeout
+	}
+
+	if gc := a.GenerateConfig; gc != nil {
+		if gc.AccessPoints == "" {
+			return errors.New("generate_config: access_points must be set")
+		}
+		if gc.Output == "" {
+			gc.Output = defaultClusterInitConfigFile
+		}
+		if gc.NetClass == "" {
+			gc.NetClass = "infiniband"
+		}
+	}
+
+	if p := a.Pool; p != nil {
+		if p.Label == "" {
+			return errors.New("pool: label must be set")
+		}
+		if p.Size == "" {
+			p.Size = defaultClusterInitPoolSize
+		}
+		var size poolSizeFlag
+		if err := size.UnmarshalFlag(p.Size); err != nil {
+			return errors.Wrap(err, "pool")
+		}
+	}
+
+	return nil
+}
+
+// readClusterInitAnswers reads and validates an answers file.
+func readClusterInitAnswers(path string) (*clusterInitAnswers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading answers file")
+	}
+
+	answers := new(clusterInitAnswers)
+	if err := yaml.UnmarshalStrict(data, answers); err != nil {
+		return nil, errors.Wrapf(err, "parsing answers file %s", path)
+	}
+	if err := answers.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid answers file %s", path)
+	}
+
+	return answers, nil
+}
+
+// clusterInitStatus is the outcome of a bring-up step.
+type clusterInitStatus string
+
+const (
+	clusterInitOK      clusterInitStatus = "ok"
+	clusterInitWarning clusterInitStatus = "warning"
+	clusterInitFailed  clusterInitStatus = "failed"
+	clusterInitSkipped clusterInitStatus = "skipped"
+)
+
+type (
+	// clusterInitStep reports the outcome of a bring-up step.
+	clusterInitStep struct {
+		Name     string            `json:"name"`
+		Status   clusterInitStatus `json:"status"`
+		Duration time.Duration     `json:"duration"`
+		Detail   string            `json:"detail"`
+	}
+
+	// clusterInitOutcome is returned by a step function. If stop is set,
+	// the remaining steps are not run.
+	clusterInitOutcome struct {
+		status clusterInitStatus
+		detail string
+		stop   bool
+	}
+
+	clusterInitStepFn func(context.Context) *clusterInitOutcome
+)
+
+func clusterInitOKf(format string, args ...interface{}) *clusterInitOutcome {
+	return &clusterInitOutcome{status: clusterInitOK, detail: fmt.Sprintf(format, args...)}
+}
+
+func clusterInitFailure(err error) *clusterInitOutcome {
+	return &clusterInitOutcome{status: clusterInitFailed, detail: err.Error(), stop: true}
+}
+
+// hostErrorsDetail describes the host errors in a response, one line for each
+// set of hosts that reported the same error.
+func hostErrorsDetail(her control.HostErrorsResp) string {
+	byHosts := her.HostErrorsByHostSet()
+	hostSets := make([]string, 0, len(byHosts))
+	for hosts := range byHosts {
+		hostSets = append(hostSets, hosts)
+	}
+	sort.Strings(hostSets)
+
+	var lines []string
+	for _, hosts := range hostSets {
+		for _, err := range byHosts[hosts] {
+			lines = append(lines, fmt.Sprintf("%s: %s", hosts, err))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clusterInitCmd walks through the bring-up of a new system: it verifies that
+// the servers can be reached with the configured certificates, runs preflight
+// checks on the host storage, optionally generates a server config file,
+// formats storage, waits for the ranks to join and optionally creates an
+// initial pool. A report of each step is printed on completion.
+type clusterInitCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+
+	Answers string `short:"a" long:"answers" description:"Run non-interactively with the answers in the given YAML file"`
+
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// prompt asks a question on the terminal and returns the answer, or the
+// default if the answer is empty.
+func (cmd *clusterInitCmd) prompt(question, def string) (string, error) {
+	if def != "" {
+		question = fmt.Sprintf("%s [%s]", question, def)
+	}
+	fmt.Fprintf(cmd.out, "%s: ", question)
+
+	answer, err := cmd.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", errors.Wrap(err, "reading answer")
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// confirm asks a yes/no question on the terminal.
+func (cmd *clusterInitCmd) confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	for {
+		answer, err := cmd.prompt(fmt.Sprintf("%s (%s)", question, choices), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// askAnswers completes the answers for the optional steps interactively.
+func (cmd *clusterInitCmd) askAnswers(answers *clusterInitAnswers, hosts []string) error {
+	gen, err := cmd.confirm("Generate a server config file from the host hardware?", false)
+	if err != nil {
+		return err
+	}
+	if gen {
+		gc := new(clusterInitConfigAnswers)
+		if gc.AccessPoints, err = cmd.prompt("Access points (comma separated)", hosts[0]); err != nil {
+			return err
+		}
+		if gc.Output, err = cmd.prompt("Write config file to", defaultClusterInitConfigFile); err != nil {
+			return err
+		}
+		answers.GenerateConfig = gc
+	}
+
+	format, err := cmd.confirm("Format storage on all hosts?", true)
+	if err != nil {
+		return err
+	}
+	answers.SkipFormat = !format
+
+	create, err := cmd.confirm("Create an initial pool?", true)
+	if err != nil {
+		return err
+	}
+	if create {
+		p := new(clusterInitPoolAnswers)
+		if p.Label, err = cmd.prompt("Pool label", defaultClusterInitPoolLabel); err != nil {
+			return err
+		}
+		if p.Size, err = cmd.prompt("Pool size (bytes or percentage of free space)", defaultClusterInitPoolSize); err != nil {
+			return err
+		}
+		answers.Pool = p
+	}
+
+	return answers.validate()
+}
+
+// checkConnectivity verifies that the servers on all hosts can be reached and
+// that the TLS handshake succeeds with the configured certificates.
+func (cmd *clusterInitCmd) checkConnectivity(ctx context.Context, hosts []string) *clusterInitOutcome {
+	resp, err := control.NetworkScan(ctx, cmd.ctlInvoker, &control.NetworkScanReq{})
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+	if len(resp.HostErrors) > 0 {
+		return clusterInitFailure(errors.New(hostErrorsDetail(resp.HostErrorsResp)))
+	}
+
+	return clusterInitOKf("%d host(s) reachable", len(hosts))
+}
+
+// checkPreflight verifies that storage can be scanned on all hosts, and warns
+// if any host has no NVMe SSDs or if the hosts differ in their storage.
+func (cmd *clusterInitCmd) checkPreflight(ctx context.Context) *clusterInitOutcome {
+	resp, err := control.StorageScan(ctx, cmd.ctlInvoker, &control.StorageScanReq{})
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+	if len(resp.HostErrors) > 0 {
+		return clusterInitFailure(errors.New(hostErrorsDetail(resp.HostErrorsResp)))
+	}
+
+	var warnings []string
+	noNvme := new(hostlist.HostSet)
+	for _, key := range resp.HostStorage.Keys() {
+		hss := resp.HostStorage[key]
+		if len(hss.HostStorage.NvmeDevices) == 0 {
+			if err := noNvme.Merge(hss.HostSet); err != nil {
+				return clusterInitFailure(err)
+			}
+		}
+	}
+	if noNvme.Count() > 0 {
+		warnings = append(warnings, fmt.Sprintf("no NVMe SSDs found on %s", noNvme))
+	}
+	if len(resp.HostStorage) > 1 {
+		warnings = append(warnings, fmt.Sprintf("hosts have %d different storage configurations",
+			len(resp.HostStorage)))
+	}
+
+	if len(warnings) > 0 {
+		return &clusterInitOutcome{status: clusterInitWarning, detail: strings.Join(warnings, "\n")}
+	}
+	return clusterInitOKf("storage scanned on %d host(s)", resp.HostStorage.HostCount())
+}
+
+// generateConfig generates a server config file from the host hardware and
+// writes it to the output file. The servers must be restarted with the file
+// before the bring-up can continue, so in non-interactive mode the remaining
+// steps are not run.
+func (cmd *clusterInitCmd) generateConfig(ctx context.Context, gc *clusterInitConfigAnswers, hosts []string) *clusterInitOutcome {
+	req := control.ConfGenerateRemoteReq{
+		HostList: hosts,
+		Client:   cmd.ctlInvoker,
+	}
+	cgc := &cmdutil.ConfGenCmd{
+		AccessPoints:    gc.AccessPoints,
+		NrEngines:       gc.NrEngines,
+		SCMOnly:         gc.SCMOnly,
+		NetClass:        gc.NetClass,
+		NetProvider:     gc.NetProvider,
+		UseTmpfsSCM:     gc.UseTmpfsSCM,
+		ExtMetadataPath: gc.ExtMetadataPath,
+		FabricPorts:     gc.FabricPorts,
+	}
+	if err := convert.Types(cgc, &req.ConfGenerateReq); err != nil {
+		return clusterInitFailure(err)
+	}
+	req.Log = cmd.Logger
+
+	resp, err := confGenRemoteCall(ctx, req)
+	if err != nil {
+		if cge, ok := errors.Cause(err).(*control.ConfGenerateError); ok {
+			return clusterInitFailure(errors.New(hostErrorsDetail(cge.HostErrorsResp)))
+		}
+		return clusterInitFailure(err)
+	}
+
+	data, err := yaml.Marshal(&resp.Server)
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+	if err := os.WriteFile(gc.Output, data, 0644); err != nil {
+		return clusterInitFailure(errors.Wrap(err, "writing config file"))
+	}
+
+	outcome := clusterInitOKf("%d engine(s) per host written to %s", len(resp.Server.Engines), gc.Output)
+	if cmd.Answers == "" {
+		fmt.Fprintf(cmd.out, "Install %s as the daos_server config file on each host and restart daos_server.\n",
+			gc.Output)
+		cont, err := cmd.confirm("Continue once daos_server has been restarted on all hosts?", true)
+		if err != nil {
+			return clusterInitFailure(err)
+		}
+		outcome.stop = !cont
+		return outcome
+	}
+
+	outcome.detail += "; install it on each host, restart daos_server and rerun without generate_config"
+	outcome.stop = true
+	return outcome
+}
+
+// formatStorage formats the storage on all hosts.
+func (cmd *clusterInitCmd) formatStorage(ctx context.Context) *clusterInitOutcome {
+	resp, err := control.StorageFormat(ctx, cmd.ctlInvoker, &control.StorageFormatReq{})
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+	if len(resp.HostErrors) > 0 {
+		return clusterInitFailure(errors.New(hostErrorsDetail(resp.HostErrorsResp)))
+	}
+
+	return clusterInitOKf("storage formatted on %d host(s)", resp.HostStorage.HostCount())
+}
+
+// waitForJoin polls the system until the ranks on all hosts have joined or
+// the timeout expires.
+func (cmd *clusterInitCmd) waitForJoin(ctx context.Context, hosts []string, timeout time.Duration) *clusterInitOutcome {
+	hostSet, err := hostlist.CreateSet(strings.Join(hosts, ","))
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := "system not yet available"
+	for {
+		req := new(control.SystemQueryReq)
+		req.SetHosts(hostSet)
+		resp, err := control.SystemQuery(ctx, cmd.ctlInvoker, req)
+		switch {
+		case err != nil:
+			status = err.Error()
+		case resp.AbsentHosts.Count() > 0:
+			status = fmt.Sprintf("no ranks from %s", &resp.AbsentHosts)
+		default:
+			var joined int
+			for _, m := range resp.Members {
+				if m.State == system.MemberStateJoined {
+					joined++
+				}
+			}
+			if joined > 0 && joined == len(resp.Members) {
+				return clusterInitOKf("%d rank(s) joined", joined)
+			}
+			status = fmt.Sprintf("%d of %d rank(s) joined", joined, len(resp.Members))
+		}
+
+		select {
+		case <-ctx.Done():
+			return clusterInitFailure(errors.Errorf("timed out after %s waiting for ranks to join: %s",
+				timeout, status))
+		case <-time.After(clusterInitJoinPollInterval):
+		}
+	}
+}
+
+// createPool creates the initial pool.
+func (cmd *clusterInitCmd) createPool(ctx context.Context, p *clusterInitPoolAnswers) *clusterInitOutcome {
+	var size poolSizeFlag
+	if err := size.UnmarshalFlag(p.Size); err != nil {
+		return clusterInitFailure(err)
+	}
+	var props PoolSetPropsFlag
+	if err := props.UnmarshalFlag("label:" + p.Label); err != nil {
+		return clusterInitFailure(err)
+	}
+
+	req := &control.PoolCreateReq{
+		Properties: props.ToSet,
+	}
+	if size.IsRatio() {
+		availFrac := float64(size.availRatio) / 100.0
+		req.TierRatio = []float64{availFrac, availFrac}
+	} else {
+		req.TotalBytes = size.bytes
+		req.TierRatio = tierRatioFlag{}.Ratios()
+	}
+
+	resp, err := control.PoolCreate(ctx, cmd.ctlInvoker, req)
+	if err != nil {
+		return clusterInitFailure(err)
+	}
+
+	return clusterInitOKf("created pool %s (%s) on %d rank(s)", p.Label, resp.UUID, len(resp.TgtRanks))
+}
+
+// run runs each step in turn. Once a step has failed or has requested a stop,
+// the remaining steps are reported as skipped.
+func (cmd *clusterInitCmd) run(ctx context.Context, answers *clusterInitAnswers, hosts []string) []*clusterInitStep {
+	type stepDef struct {
+		name string
+		fn   clusterInitStepFn
+	}
+	skip := func(why string) clusterInitStepFn {
+		return func(context.Context) *clusterInitOutcome {
+			return &clusterInitOutcome{status: clusterInitSkipped, detail: why}
+		}
+	}
+
+	steps := []stepDef{
+		{"connectivity", func(ctx context.Context) *clusterInitOutcome {
+			return cmd.checkConnectivity(ctx, hosts)
+		}},
+		{"preflight", cmd.checkPreflight},
+		{"config", skip("not requested")},
+		{"format", skip("not requested")},
+		{"join", func(ctx context.Context) *clusterInitOutcome {
+			return cmd.waitForJoin(ctx, hosts, answers.JoinTimeout)
+		}},
+		{"pool", skip("not requested")},
+	}
+	if answers.GenerateConfig != nil {
+		steps[2].fn = func(ctx context.Context) *clusterInitOutcome {
+			return cmd.generateConfig(ctx, answers.GenerateConfig, hosts)
+		}
+	}
+	if !answers.SkipFormat {
+		steps[3].fn = cmd.formatStorage
+	}
+	if answers.Pool != nil {
+		steps[5].fn = func(ctx context.Context) *clusterInitOutcome {
+			return cmd.createPool(ctx, answers.Pool)
+		}
+	}
+
+	var report []*clusterInitStep
+	var stoppedAt string
+	for _, sd := range steps {
+		step := &clusterInitStep{Name: sd.name}
+		report = append(report, step)
+
+		if stoppedAt != "" {
+			step.Status = clusterInitSkipped
+			step.Detail = fmt.Sprintf("stopped after %s step", stoppedAt)
+			continue
+		}
+
+		cmd.Infof("Running %s step...", sd.name)
+		start := time.Now()
+		outcome := sd.fn(ctx)
+		step.Duration = time.Since(start).Truncate(time.Millisecond)
+		step.Status = outcome.status
+		step.Detail = outcome.detail
+		if outcome.stop {
+			stoppedAt = sd.name
+		}
+	}
+
+	return report
+}
+
+// printClusterInitReport prints a table of the outcome of each step.
+func printClusterInitReport(out io.Writer, report []*clusterInitStep) {
+	stepTitle := "Step"
+	statusTitle := "Status"
+	durationTitle := "Duration"
+	detailTitle := "Detail"
+
+	table := []txtfmt.TableRow{}
+	for _, step := range report {
+		// Multi-line details are printed on continuation rows.
+		for i, line := range strings.Split(step.Detail, "\n") {
+			row := txtfmt.TableRow{detailTitle: line}
+			if i == 0 {
+				row[stepTitle] = step.Name
+				row[statusTitle] = string(step.Status)
+				if step.Status != clusterInitSkipped {
+					row[durationTitle] = step.Duration.String()
+				}
+			}
+			table = append(table, row)
+		}
+	}
+
+	tf := txtfmt.NewTableFormatter(stepTitle, statusTitle, durationTitle, detailTitle)
+	tf.InitWriter(out)
+	tf.Format(table)
+}
+
+// Execute is run when clusterInitCmd activates.
+func (cmd *clusterInitCmd) Execute(_ []string) error {
+	if cmd.config == nil || len(cmd.config.HostList) == 0 {
+		return errors.New("no hosts specified; set --host-list or hostlist in the dmg config file")
+	}
+	hosts := cmd.config.HostList
+
+	var answers *clusterInitAnswers
+	if cmd.Answers != "" {
+		var err error
+		if answers, err = readClusterInitAnswers(cmd.Answers); err != nil {
+			return err
+		}
+	} else {
+		if cmd.JSONOutputEnabled() {
+			return errors.New("--json may only be used with --answers")
+		}
+		if cmd.in == nil {
+			cmd.in = bufio.NewReader(os.Stdin)
+		}
+		if cmd.out == nil {
+			cmd.out = os.Stdout
+		}
+		answers = new(clusterInitAnswers)
+		if err := cmd.askAnswers(answers, hosts); err != nil {
+			return err
+		}
+	}
+
+	report := cmd.run(cmd.MustLogCtx(), answers, hosts)
+
+	var err error
+	for _, step := range report {
+		if step.Status == clusterInitFailed {
+			err = errors.Errorf("cluster init failed at %s step", step.Name)
+			break
+		}
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(report, err)
+	}
+
+	var bld strings.Builder
+	printClusterInitReport(&bld, report)
+	cmd.Infof("%s", bld.String())
+
+	return err
+}
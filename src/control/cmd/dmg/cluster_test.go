@@ -0,0 +1,229 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestDmg_readClusterInitAnswers(t *testing.T) {
+	for name, tc := range map[string]struct {
+		answers    string
+		expAnswers *clusterInitAnswers
+		expErr     error
+	}{
+		"empty": {
+			expAnswers: &clusterInitAnswers{
+				JoinTimeout: defaultClusterInitJoinTimeout,
+			},
+		},
+		"all steps": {
+			answers: `
+generate_config:
+  access_points: host1
+  num_engines: 2
+join_timeout: 10m
+pool:
+  label: tank
+`,
+			expAnswers: &clusterInitAnswers{
+				GenerateConfig: &clusterInitConfigAnswers{
+					Output:       defaultClusterInitConfigFile,
+					AccessPoints: "host1",
+					NrEngines:    2,
+					NetClass:     "infiniband",
+				},
+				JoinTimeout: 10 * time.Minute,
+				Pool: &clusterInitPoolAnswers{
+					Label: "tank",
+					Size:  defaultClusterInitPoolSize,
+				},
+			},
+		},
+		"unknown key": {
+			answers: "skip_join: true\n",
+			expErr:  errors.New("field skip_join not found"),
+		},
+		"missing access points": {
+			answers: "generate_config:\n  output: /tmp/x.yml\n",
+			expErr:  errors.New("access_points must be set"),
+		},
+		"missing pool label": {
+			answers: "pool:\n  size: 10%\n",
+			expErr:  errors.New("label must be set"),
+		},
+		"bad pool size": {
+			answers: "pool:\n  label: tank\n  size: 200%\n",
+			expErr:  errors.New("invalid full size ratio"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "answers.yml")
+			if err := os.WriteFile(path, []byte(tc.answers), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			gotAnswers, gotErr := readClusterInitAnswers(path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expAnswers, gotAnswers); diff != "" {
+				t.Fatalf("unexpected answers (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDmg_clusterInitCmd_askAnswers(t *testing.T) {
+	cmd := &clusterInitCmd{
+		in:  bufio.NewReader(strings.NewReader("y\n\n/tmp/srv.yml\nn\n\ntank\n50%\n")),
+		out: new(strings.Builder),
+	}
+
+	answers := new(clusterInitAnswers)
+	if err := cmd.askAnswers(answers, []string{"host1", "host2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expAnswers := &clusterInitAnswers{
+		GenerateConfig: &clusterInitConfigAnswers{
+			Output:       "/tmp/srv.yml",
+			AccessPoints: "host1",
+			NetClass:     "infiniband",
+		},
+		SkipFormat:  true,
+		JoinTimeout: defaultClusterInitJoinTimeout,
+		Pool: &clusterInitPoolAnswers{
+			Label: "tank",
+			Size:  "50%",
+		},
+	}
+	if diff := cmp.Diff(expAnswers, answers); diff != "" {
+		t.Fatalf("unexpected answers (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestDmg_clusterInitCmd_run(t *testing.T) {
+	hosts := []string{"host1", "host2"}
+	netScan := &control.UnaryResponse{
+		Responses: control.MockHostResponses(t, 2, "host%d", &ctl.NetworkScanResp{}),
+	}
+	storScan := func(withNvme bool) *control.UnaryResponse {
+		ssr := control.MockServerScanResp(t, "standard")
+		if !withNvme {
+			ssr.Nvme.Ctrlrs = nil
+		}
+		return &control.UnaryResponse{
+			Responses: control.MockHostResponses(t, 2, "host%d", ssr),
+		}
+	}
+	sysQuery := func(states ...system.MemberState) *control.UnaryResponse {
+		resp := new(mgmtpb.SystemQueryResp)
+		for i, state := range states {
+			resp.Members = append(resp.Members, &mgmtpb.SystemMember{
+				Rank:  uint32(i),
+				Uuid:  test.MockUUID(int32(i)),
+				State: state.String(),
+				Addr:  "10.0.0.1:10001",
+			})
+		}
+		return control.MockMSResponse("host1", nil, resp)
+	}
+
+	for name, tc := range map[string]struct {
+		answers   *clusterInitAnswers
+		responses []*control.UnaryResponse
+		expStatus []clusterInitStatus
+	}{
+		"unreachable host": {
+			answers: &clusterInitAnswers{},
+			responses: []*control.UnaryResponse{
+				{
+					Responses: []*control.HostResponse{
+						{Addr: "host0", Message: &ctl.NetworkScanResp{}},
+						{Addr: "host1", Error: errors.New("connection refused")},
+					},
+				},
+			},
+			expStatus: []clusterInitStatus{
+				clusterInitFailed, clusterInitSkipped, clusterInitSkipped,
+				clusterInitSkipped, clusterInitSkipped, clusterInitSkipped,
+			},
+		},
+		"no nvme; wait for join": {
+			answers: &clusterInitAnswers{SkipFormat: true},
+			responses: []*control.UnaryResponse{
+				netScan,
+				storScan(false),
+				sysQuery(system.MemberStateJoined, system.MemberStateStarting),
+				sysQuery(system.MemberStateJoined, system.MemberStateJoined),
+			},
+			expStatus: []clusterInitStatus{
+				clusterInitOK, clusterInitWarning, clusterInitSkipped,
+				clusterInitSkipped, clusterInitOK, clusterInitSkipped,
+			},
+		},
+		"join timeout": {
+			answers: &clusterInitAnswers{SkipFormat: true, JoinTimeout: 10 * time.Millisecond},
+			responses: []*control.UnaryResponse{
+				netScan,
+				storScan(true),
+				sysQuery(system.MemberStateStarting),
+			},
+			expStatus: []clusterInitStatus{
+				clusterInitOK, clusterInitOK, clusterInitSkipped,
+				clusterInitSkipped, clusterInitFailed, clusterInitSkipped,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			prevInterval := clusterInitJoinPollInterval
+			clusterInitJoinPollInterval = time.Millisecond
+			defer func() { clusterInitJoinPollInterval = prevInterval }()
+
+			if tc.answers.JoinTimeout == 0 {
+				tc.answers.JoinTimeout = time.Second
+			}
+
+			cmd := &clusterInitCmd{Answers: "answers.yml"}
+			cmd.SetLog(log)
+			cmd.setInvoker(control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponseSet: tc.responses,
+			}))
+
+			report := cmd.run(test.Context(t), tc.answers, hosts)
+
+			gotStatus := make([]clusterInitStatus, 0, len(report))
+			for _, step := range report {
+				gotStatus = append(gotStatus, step.Status)
+			}
+			if diff := cmp.Diff(tc.expStatus, gotStatus); diff != "" {
+				t.Fatalf("unexpected step statuses (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
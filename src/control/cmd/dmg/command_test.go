@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,11 +10,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
@@ -130,10 +133,14 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemStopResp{})
 	case *control.SystemEraseReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemEraseResp{})
+	case *control.SystemArmReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemArmResp{Token: "token"})
 	case *control.SystemStartReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemStartResp{})
 	case *control.SystemExcludeReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemExcludeResp{})
+	case *control.SystemQuarantineReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemQuarantineResp{})
 	case *control.SystemQueryReq:
 		if req.FailOnUnavailable {
 			resp = control.MockMSResponse("", system.ErrRaftUnavail, nil)
@@ -189,6 +196,40 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.DaosResp{})
 	case *control.SystemGetPropReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemGetPropResp{})
+	case *control.SystemHistoryReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+			Snapshots: []*mgmtpb.SystemHealthSnapshot{
+				{
+					Pools: []*mgmtpb.SystemHealthSnapshot_PoolSummary{
+						{
+							Uuid: "12345678-1234-1234-1234-1234567890ab",
+							Tiers: []*mgmtpb.SystemHealthSnapshot_TierUsage{
+								{MediaType: "scm", TotalBytes: 2, FreeBytes: 1},
+							},
+						},
+					},
+				},
+			},
+		})
+	case *control.SystemEventsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemEventsResp{})
+	case *control.PoolActivityReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.PoolActivityResp{
+			PoolUuid: "12345678-1234-1234-1234-1234567890ab",
+		})
+	case *control.SystemEventAckReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.DaosResp{})
+	case *control.SystemTokenCreateReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemTokenCreateResp{})
+	case *control.SystemDBVerifyReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemDBVerifyResp{
+			Index: 1,
+			Replicas: []*mgmtpb.SystemDBReplicaHash{
+				{Replica: "host1:10001", Leader: true, Index: 1, Hash: "abcd"},
+			},
+		})
+	case *control.JobStatsQueryReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.JobStatsQueryResp{})
 	case *control.NetworkScanReq:
 		resp = &control.UnaryResponse{
 			Responses: []*control.HostResponse{
@@ -293,3 +334,54 @@ func TestNoCommand(t *testing.T) {
 	err := parseOpts([]string{}, &opts, nil, log)
 	testExpectedError(t, fmt.Errorf("Please specify one command"), err)
 }
+
+func TestOfflineCommand(t *testing.T) {
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	statePath := filepath.Join(tmpDir, "state.json")
+	state := &control.MSState{
+		Index: 1,
+		Members: system.Members{
+			system.MockMember(t, 0, system.MemberStateJoined),
+		},
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(statePath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		args   []string
+		expErr error
+	}{
+		"missing state file": {
+			args:   []string{"--offline", filepath.Join(tmpDir, "missing"), "system", "query"},
+			expErr: errors.New("reading MS state"),
+		},
+		"unsupported command": {
+			args:   []string{"--offline", statePath, "system", "stop"},
+			expErr: errors.New("cannot be run with --offline"),
+		},
+		"system query": {
+			args: []string{"--offline", statePath, "system", "query"},
+		},
+		"pool list": {
+			args: []string{"--offline", statePath, "pool", "list"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var opts cliOptions
+			ctlClient := control.DefaultMockInvoker(log)
+			gotErr := parseOpts(tc.args, &opts, ctlClient, log)
+			test.CmpErr(t, tc.expErr, gotErr)
+			test.AssertEqual(t, 0, ctlClient.GetInvokeCount(), "unexpected RPCs invoked")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// configApplyCmd is the struct representing the command to validate a
+// candidate server config file on a set of servers and, if it is valid on all
+// of them, optionally replace their config files with it.
+type configApplyCmd struct {
+	baseCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+	File   string `short:"f" long:"file" required:"1" description:"Candidate server config file"`
+	Commit bool   `long:"commit" description:"Replace the config file of each server with the candidate if it is valid on all of the hosts, to be used when the servers are next restarted"`
+}
+
+// Execute is run when configApplyCmd activates.
+func (cmd *configApplyCmd) Execute(_ []string) error {
+	data, err := ioutil.ReadFile(cmd.File)
+	if err != nil {
+		return errors.Wrap(err, "reading candidate config")
+	}
+
+	// catch syntax errors before contacting the servers
+	candidate := config.DefaultServer()
+	candidate.Path = cmd.File
+	if err := candidate.Parse(data); err != nil {
+		return err
+	}
+
+	req := &control.ServerConfigApplyReq{
+		Config: data,
+		Commit: cmd.Commit,
+	}
+	req.SetHostList(cmd.getHostList())
+
+	resp, err := control.ApplyServerConfig(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintServerConfigApplyResp(resp, cmd.Commit, &out, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if out.Len() > 0 {
+		cmd.Info(out.String())
+	}
+
+	return resp.Errors()
+}
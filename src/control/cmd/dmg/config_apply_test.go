@@ -0,0 +1,58 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestDmg_ConfigApplyCommands(t *testing.T) {
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+
+	validFile := test.CreateTestFile(t, tmpDir, "name: daos_server\nport: 10001\n")
+	badFile := test.CreateTestFile(t, tmpDir, "bad_key: 1\n")
+
+	runCmdTests(t, []cmdTest{
+		{
+			"Apply config without file",
+			"config apply",
+			"",
+			errMissingFlag,
+		},
+		{
+			"Apply config with missing file",
+			"config apply -f " + filepath.Join(tmpDir, "missing.yml"),
+			"",
+			errors.New("reading candidate config"),
+		},
+		{
+			"Apply config with invalid syntax",
+			"config apply -f " + badFile,
+			"",
+			errors.New("bad_key"),
+		},
+		{
+			"Validate config",
+			"config apply -f " + validFile,
+			printRequest(t, &control.ServerConfigApplyReq{}),
+			nil,
+		},
+		{
+			"Validate and commit config",
+			"config apply --commit -f " + validFile,
+			printRequest(t, &control.ServerConfigApplyReq{Commit: true}),
+			nil,
+		},
+	})
+}
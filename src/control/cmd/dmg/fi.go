@@ -31,6 +31,12 @@ import (
 	"github.com/daos-stack/daos/src/control/system/checker"
 )
 
+func init() {
+	for _, cmdPath := range []string{"faults add-checker-report", "faults mgmt-svc pool", "faults pool-svc"} {
+		jsonOutputs[cmdPath] = []interface{}{&mgmtpb.DaosResp{}}
+	}
+}
+
 type faultsCmdRoot struct {
 	Faults faultCmd `command:"faults" description:"Inject system fault"`
 }
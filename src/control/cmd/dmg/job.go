@@ -0,0 +1,51 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// jobCmd is the struct representing the top-level job subcommand.
+type jobCmd struct {
+	List jobListCmd `command:"list" alias:"ls" description:"List the pool operations queued or running under the MS pool operation scheduler"`
+}
+
+// jobListCmd represents the command to list the rebuild-generating pool
+// operations held by the MS pool operation scheduler.
+type jobListCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+}
+
+// Execute is run when jobListCmd subcommand is activated.
+func (cmd *jobListCmd) Execute(_ []string) error {
+	resp, err := control.PoolOpJobs(cmd.MustLogCtx(), cmd.ctlInvoker, &control.PoolOpJobsReq{})
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "job list failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintPoolOpJobs(&bld, resp); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
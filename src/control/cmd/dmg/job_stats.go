@@ -0,0 +1,59 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// jobStatsCmd is the struct representing the top-level job-stats subcommand.
+type jobStatsCmd struct {
+	Query jobStatsQueryCmd `command:"query" description:"Query the system-wide I/O totals for a job"`
+}
+
+// jobStatsQueryCmd represents the command to retrieve the per-job I/O totals
+// aggregated by the MS leader.
+type jobStatsQueryCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+
+	Args struct {
+		JobID string `positional-arg-name:"[job ID]" description:"Job to display totals for (default: all jobs)"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when jobStatsQueryCmd subcommand is activated.
+func (cmd *jobStatsQueryCmd) Execute(_ []string) error {
+	req := &control.JobStatsQueryReq{
+		JobID: cmd.Args.JobID,
+	}
+
+	resp, err := control.JobStatsQuery(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "job stats query failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintJobStatsQueryResponse(&bld, resp); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
@@ -0,0 +1,45 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestDmg_JobStatsCommands(t *testing.T) {
+	runCmdTests(t, []cmdTest{
+		{
+			"query all jobs",
+			"job-stats query",
+			strings.Join([]string{
+				printRequest(t, &control.JobStatsQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"query single job",
+			"job-stats query job1",
+			strings.Join([]string{
+				printRequest(t, &control.JobStatsQueryReq{
+					JobID: "job1",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"query too many jobs",
+			"job-stats query job1 job2",
+			"",
+			errors.New("unexpected arguments"),
+		},
+	})
+}
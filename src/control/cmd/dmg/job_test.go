@@ -0,0 +1,43 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestDmg_JobCommands(t *testing.T) {
+	runCmdTests(t, []cmdTest{
+		{
+			"list jobs",
+			"job list",
+			strings.Join([]string{
+				printRequest(t, &control.PoolOpJobsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"list jobs with alias",
+			"job ls",
+			strings.Join([]string{
+				printRequest(t, &control.PoolOpJobsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"list jobs with arguments",
+			"job list foo",
+			"",
+			errors.New("unexpected arguments"),
+		},
+	})
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -67,7 +67,7 @@ func TestDmg_JsonOutput(t *testing.T) {
 			testArgs := append([]string{"-i", "--json"}, args...)
 			switch strings.Join(args, " ") {
 			case "version", "telemetry config", "telemetry run", "config generate",
-				"manpage", "system set-prop", "support collect-log", "check repair":
+				"manpage", "json-schemas", "system set-prop", "support collect-log", "check repair":
 				return
 			case "storage nvme-rebind":
 				testArgs = append(testArgs, "-l", "foo.com", "-a",
@@ -96,6 +96,8 @@ func TestDmg_JsonOutput(t *testing.T) {
 				testArgs = append(testArgs, test.MockUUID(), "label:foo")
 			case "pool get-prop":
 				testArgs = append(testArgs, test.MockUUID(), "label")
+			case "pool rename":
+				testArgs = append(testArgs, test.MockUUID(), "foo")
 			case "pool extend":
 				testArgs = append(testArgs, test.MockUUID(), "--ranks", "0")
 			case "pool exclude", "pool drain", "pool reintegrate":
@@ -107,6 +109,8 @@ func TestDmg_JsonOutput(t *testing.T) {
 					"--cont", test.MockUUID())
 			case "telemetry metrics list", "telemetry metrics query":
 				return // These commands query via http directly
+			case "pool export-config":
+				return // Unset property values in the get-prop request can't be printed by the mock
 			case "system cleanup":
 				testArgs = append(testArgs, "hostname")
 			case "check set-policy":
@@ -115,10 +119,20 @@ func TestDmg_JsonOutput(t *testing.T) {
 				testArgs = append(testArgs, "foo:bar")
 			case "system del-attr":
 				testArgs = append(testArgs, "foo")
+			case "system events ack":
+				testArgs = append(testArgs, "1")
 			case "system exclude":
 				testArgs = append(testArgs, "--ranks", "0")
 			case "system clear-exclude":
 				testArgs = append(testArgs, "--ranks", "0")
+			case "server set-control-log-levels":
+				testArgs = append(testArgs, "--level", "debug")
+			case "server set-profiling":
+				testArgs = append(testArgs, "--enable")
+			case "util nodeset fold", "util nodeset expand", "util nodeset count",
+				"util nodeset union", "util nodeset intersect", "util nodeset difference",
+				"util nodeset xor":
+				testArgs = append(testArgs, "host[1-4]", "host[3-6]")
 			}
 
 			// replace os.Stdout so that we can verify the generated output
@@ -156,6 +170,15 @@ func TestDmg_JsonOutput(t *testing.T) {
 			if !json.Valid(result.Bytes()) {
 				t.Fatalf("invalid JSON in response: %s", result.String())
 			}
+
+			// The output must conform to the command's published schema.
+			schema, err := jsonOutputSchema(strings.Join(args, " "))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := schema.Validate(result.Bytes()); err != nil {
+				t.Fatalf("JSON output does not conform to schema: %s\n%s", err, result.String())
+			}
 		})
 	}
 }
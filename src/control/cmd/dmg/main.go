@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2023 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,8 +7,10 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/cmdutil"
 	"github.com/daos-stack/daos/src/control/fault"
@@ -36,7 +39,7 @@ type (
 	}
 
 	hostListCmd struct {
-		HostList ui.HostSetFlag `short:"l" long:"host-list" description:"A comma separated list of addresses <ipv4addr/hostname> to connect to"`
+		HostList hostListFlag `short:"l" long:"host-list" description:"A comma separated list of addresses <ipv4addr/hostname> or host groups <@provider:group> to connect to"`
 		hostlist []string
 	}
 
@@ -52,12 +55,58 @@ type (
 	ctlInvokerCmd struct {
 		ctlInvoker control.Invoker
 	}
+
+	offlineSetter interface {
+		setOffline()
+	}
+
+	// offlineCmd is an embeddable struct for read-only commands that can
+	// be run against exported MS state with --offline.
+	offlineCmd struct {
+		offline bool
+	}
+
+	progressSetter interface {
+		setProgressOutput(io.Writer, bool)
+	}
+
+	// progressCmd is an embeddable struct for commands that display the
+	// progress of long-running operations.
+	progressCmd struct {
+		NoProgress  bool `long:"no-progress" description:"Do not display operation progress"`
+		progressOut io.Writer
+		progressBar bool
+	}
 )
 
 func (cmd *ctlInvokerCmd) setInvoker(c control.Invoker) {
 	cmd.ctlInvoker = c
 }
 
+func (cmd *offlineCmd) setOffline() {
+	cmd.offline = true
+}
+
+func (cmd *progressCmd) setProgressOutput(out io.Writer, bar bool) {
+	cmd.progressOut = out
+	cmd.progressBar = bar
+}
+
+// newProgressPrinter returns a printer for the progress of the labelled
+// operation, or nil if progress is not to be displayed.
+func (cmd *progressCmd) newProgressPrinter(label string) *pretty.ProgressPrinter {
+	if cmd.NoProgress || cmd.progressOut == nil {
+		return nil
+	}
+	return pretty.NewProgressPrinter(cmd.progressOut, label, cmd.progressBar)
+}
+
+// isTerminal returns true if the file is a character device, e.g. a TTY.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 func (cmd *hostListCmd) getHostList() []string {
 	if cmd.hostlist == nil && !cmd.HostList.Empty() {
 		cmd.hostlist = cmd.HostList.Slice()
@@ -116,10 +165,15 @@ type cliOptions struct {
 	LogFile        string         `long:"log-file" description:"Log command output to the specified file"`
 	JSON           bool           `short:"j" long:"json" description:"Enable JSON output"`
 	JSONLogs       bool           `short:"J" long:"json-logging" description:"Enable JSON-formatted log output"`
+	Schema         bool           `long:"schema" description:"Print the JSON schema of the command's --json output and exit"`
 	ConfigPath     string         `short:"o" long:"config-path" description:"Client config file path"`
+	NoDefaults     bool           `long:"no-defaults" description:"Ignore the aliases and default flags in the client config file"`
+	Trace          bool           `long:"trace" description:"Trace the command across servers and engines and print the trace ID (spans are exported to $OTEL_EXPORTER_OTLP_ENDPOINT if set)"`
+	Offline        string         `long:"offline" description:"Run a read-only command against exported MS state (from 'daos_server ms inspect --json' or 'dmg system query --save') instead of a live system"`
 	Server         serverCmd      `command:"server" alias:"srv" description:"Perform tasks related to remote servers"`
 	Storage        storageCmd     `command:"storage" alias:"sto" description:"Perform tasks related to storage attached to remote servers"`
 	Config         configCmd      `command:"config" alias:"cfg" description:"Perform tasks related to configuration of hardware on remote servers"`
+	Cluster        clusterCmd     `command:"cluster" description:"Perform tasks related to bringing up a DAOS system"`
 	System         SystemCmd      `command:"system" alias:"sys" description:"Perform distributed tasks related to DAOS system"`
 	Network        NetCmd         `command:"network" alias:"net" description:"Perform tasks related to network devices attached to remote servers"`
 	Support        supportCmd     `command:"support" alias:"supp" description:"Perform debug tasks to help support team"`
@@ -128,7 +182,11 @@ type cliOptions struct {
 	Version        versionCmd     `command:"version" description:"Print dmg version"`
 	Telemetry      telemCmd       `command:"telemetry" alias:"telem" description:"Perform telemetry operations"`
 	Check          checkCmdRoot   `command:"check" description:"Check system health"`
+	JobStats       jobStatsCmd    `command:"job-stats" description:"Perform tasks related to per-job I/O statistics"`
+	Job            jobCmd         `command:"job" description:"Perform tasks related to scheduled pool operations"`
+	Util           utilCmd        `command:"util" description:"Perform local utility tasks that do not contact the servers"`
 	ManPage        cmdutil.ManCmd `command:"manpage" hidden:"true"`
+	JSONSchemas    jsonSchemasCmd `command:"json-schemas" hidden:"true"`
 	faultsCmdRoot                 // compiled out for release builds
 	firmwareOption                // build with tag "firmware" to enable
 }
@@ -157,7 +215,7 @@ func exitWithError(log logging.Logger, err error) {
 	if fault.HasResolution(err) {
 		log.Errorf("%s: %s", cmdName, fault.ShowResolutionFor(err))
 	}
-	os.Exit(1)
+	os.Exit(cmdutil.ExitCode(err))
 }
 
 func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *logging.LeveledLogger) error {
@@ -225,12 +283,37 @@ and access control settings, along with system wide operations.`
 			logCmd.SetLog(log)
 		}
 
+		// Progress bars are only drawn on a terminal. Otherwise, plain
+		// progress lines go to stderr so as not to interfere with any
+		// parsing of the command output.
+		if pCmd, ok := cmd.(progressSetter); ok {
+			if !opts.JSON && isTerminal(os.Stdout) {
+				pCmd.setProgressOutput(os.Stdout, true)
+			} else {
+				pCmd.setProgressOutput(os.Stderr, false)
+			}
+		}
+
 		switch cmd.(type) {
-		case *versionCmd:
-			// this command don't need the rest of the setup
+		case *versionCmd, *jsonSchemasCmd:
+			// these commands don't need the rest of the setup
 			return cmd.Execute(args)
 		}
 
+		if opts.Offline != "" {
+			offCmd, ok := cmd.(offlineSetter)
+			if !ok {
+				return errors.Errorf("%q cannot be run with --offline", activeCommandName(p))
+			}
+			state, err := control.LoadMSState(opts.Offline)
+			if err != nil {
+				return err
+			}
+			log.Debugf("running offline against MS state from %s", opts.Offline)
+			invoker = control.NewOfflineInvoker(log, state)
+			offCmd.setOffline()
+		}
+
 		ctlCfg, err := control.LoadConfig(opts.ConfigPath)
 		if err != nil {
 			if errors.Cause(err) != control.ErrNoConfigFile {
@@ -243,7 +326,9 @@ and access control settings, along with system wide operations.`
 			log.Debugf("control config loaded from %s", ctlCfg.Path)
 		}
 
-		if opts.Insecure {
+		// No connections are made in offline mode, so certificates
+		// are not required.
+		if opts.Insecure || opts.Offline != "" {
 			ctlCfg.TransportConfig.AllowInsecure = true
 		}
 		if err := ctlCfg.TransportConfig.PreLoadCertData(); err != nil {
@@ -284,14 +369,42 @@ and access control settings, along with system wide operations.`
 			}
 		}
 
-		if err := cmd.Execute(args); err != nil {
-			return err
+		var finishTrace func(error)
+		if ctxCmd, ok := cmd.(cmdutil.ContextSetter); ok && opts.Trace {
+			var ctx context.Context
+			ctx, finishTrace = startTrace(log, activeCommandName(p), os.Stderr)
+			ctxCmd.SetContext(ctx)
 		}
 
-		return nil
+		err = cmd.Execute(args)
+		if finishTrace != nil {
+			finishTrace(err)
+		}
+
+		return err
+	}
+
+	// Aliases and default flags must be applied before the command line is
+	// parsed. A config file that fails to load is reported when the command
+	// is run.
+	var defaultFlags []string
+	cfgPath, noDefaults := scanConfigArgs(p, args)
+	if !noDefaults {
+		if ctlCfg, err := control.LoadConfig(cfgPath); err == nil {
+			args, defaultFlags, err = applyCommandDefaults(p, ctlCfg, args)
+			if err != nil {
+				return err
+			}
+			cfgPath = ctlCfg.Path
+		}
+	}
+
+	if cmdPath, schema := scanSchemaArgs(p, args); schema {
+		return printJSONOutputSchema(os.Stdout, cmdPath)
 	}
 
 	_, err := p.ParseArgs(args)
+	err = defaultsError(err, cfgPath, defaultFlags)
 	if opts.JSON && wroteJSON.IsFalse() {
 		return cmdutil.OutputJSON(os.Stdout, nil, err)
 	}
@@ -0,0 +1,267 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+// utilCmd is the struct representing the top-level util subcommand.
+type utilCmd struct {
+	Nodeset nodesetCmd `command:"nodeset" description:"Compose host and rank sets for use in --host-list and --ranks arguments"`
+}
+
+// nodesetCmd is the struct representing the nodeset subcommands.
+type nodesetCmd struct {
+	Fold      nodesetFoldCmd      `command:"fold" description:"Print the union of the sets in folded (ranged) form"`
+	Expand    nodesetExpandCmd    `command:"expand" description:"Print each member of the union of the sets"`
+	Count     nodesetCountCmd     `command:"count" description:"Print the number of members in the union of the sets"`
+	Union     nodesetUnionCmd     `command:"union" description:"Print the members found in any of the sets"`
+	Intersect nodesetIntersectCmd `command:"intersect" description:"Print the members found in all of the sets"`
+	Diff      nodesetDiffCmd      `command:"difference" alias:"diff" description:"Print the members of the first set that are not in any of the others"`
+	Xor       nodesetXorCmd       `command:"xor" description:"Print the members found in an odd number of the sets"`
+}
+
+type nodesetOp int
+
+const (
+	nodesetOpUnion nodesetOp = iota
+	nodesetOpIntersection
+	nodesetOpDifference
+	nodesetOpXor
+)
+
+// nodeset is the result of a nodeset operation.
+type nodeset struct {
+	Set     string   `json:"set"`
+	Count   int      `json:"count"`
+	Members []string `json:"members"`
+}
+
+// nodesetBaseCmd is the base struct for the nodeset subcommands. Host sets
+// may include "@<provider>:<spec>" entries, which are expanded before the
+// operation is applied.
+type nodesetBaseCmd struct {
+	cmdutil.LogCmd
+	cmdutil.JSONOutputCmd
+	Ranks bool `short:"r" long:"ranks" description:"Treat the sets as rank sets rather than host sets"`
+	Args  struct {
+		Sets []string `positional-arg-name:"<set>" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func hostSetFromArg(arg string) (*hostlist.HostSet, error) {
+	hosts, err := control.ExpandHostList([]string{arg})
+	if err != nil {
+		return nil, err
+	}
+	return hostlist.CreateSet(strings.Join(hosts, ","))
+}
+
+func applyHostSetOp(op nodesetOp, args []string) (*nodeset, error) {
+	sets := make([]*hostlist.HostSet, 0, len(args))
+	for _, arg := range args {
+		hs, err := hostSetFromArg(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid host set %q", arg)
+		}
+		sets = append(sets, hs)
+	}
+
+	var result *hostlist.HostSet
+	var err error
+	switch op {
+	case nodesetOpUnion:
+		result, err = sets[0].Union(sets[1:]...)
+	case nodesetOpIntersection:
+		result, err = sets[0].Intersection(sets[1:]...)
+	case nodesetOpDifference:
+		result, err = sets[0].Difference(sets[1:]...)
+	case nodesetOpXor:
+		result, err = sets[0].SymmetricDifference(sets[1:]...)
+	default:
+		return nil, errors.Errorf("unknown nodeset operation %d", op)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &nodeset{Members: []string{}}
+	if result.Count() > 0 {
+		ns.Set = result.String()
+		ns.Count = result.Count()
+		ns.Members = result.Slice()
+	}
+	return ns, nil
+}
+
+func applyRankSetOp(op nodesetOp, args []string) (*nodeset, error) {
+	sets := make([]*ranklist.RankSet, 0, len(args))
+	for _, arg := range args {
+		rs, err := ranklist.CreateRankSet(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rank set %q", arg)
+		}
+		sets = append(sets, rs)
+	}
+
+	var result *ranklist.RankSet
+	switch op {
+	case nodesetOpUnion:
+		result = sets[0].Union(sets[1:]...)
+	case nodesetOpIntersection:
+		result = sets[0].Intersection(sets[1:]...)
+	case nodesetOpDifference:
+		result = sets[0].Difference(sets[1:]...)
+	case nodesetOpXor:
+		result = sets[0].SymmetricDifference(sets[1:]...)
+	default:
+		return nil, errors.Errorf("unknown nodeset operation %d", op)
+	}
+
+	ns := &nodeset{
+		Set:     result.String(),
+		Count:   result.Count(),
+		Members: []string{},
+	}
+	for _, r := range result.Ranks() {
+		ns.Members = append(ns.Members, r.String())
+	}
+	return ns, nil
+}
+
+// apply returns the result of applying the operation to the sets supplied
+// as arguments.
+func (cmd *nodesetBaseCmd) apply(op nodesetOp) (*nodeset, error) {
+	if len(cmd.Args.Sets) == 0 {
+		return nil, errors.New("at least one set must be supplied")
+	}
+
+	if cmd.Ranks {
+		return applyRankSetOp(op, cmd.Args.Sets)
+	}
+	return applyHostSetOp(op, cmd.Args.Sets)
+}
+
+// run applies the operation and prints the resulting set in folded form.
+func (cmd *nodesetBaseCmd) run(op nodesetOp) error {
+	ns, err := cmd.apply(op)
+	if err != nil {
+		return err
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(ns, nil)
+	}
+	cmd.Info(ns.Set)
+
+	return nil
+}
+
+// nodesetFoldCmd is the struct representing the command to fold sets.
+type nodesetFoldCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetFoldCmd activates.
+func (cmd *nodesetFoldCmd) Execute(_ []string) error {
+	return cmd.run(nodesetOpUnion)
+}
+
+// nodesetExpandCmd is the struct representing the command to expand sets.
+type nodesetExpandCmd struct {
+	nodesetBaseCmd
+	Separator string `short:"S" long:"separator" default:"," description:"Separator to print between members"`
+}
+
+// Execute is run when nodesetExpandCmd activates.
+func (cmd *nodesetExpandCmd) Execute(_ []string) error {
+	ns, err := cmd.apply(nodesetOpUnion)
+	if err != nil {
+		return err
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(ns, nil)
+	}
+	cmd.Info(strings.Join(ns.Members, cmd.Separator))
+
+	return nil
+}
+
+// nodesetCountCmd is the struct representing the command to count the
+// members of sets.
+type nodesetCountCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetCountCmd activates.
+func (cmd *nodesetCountCmd) Execute(_ []string) error {
+	ns, err := cmd.apply(nodesetOpUnion)
+	if err != nil {
+		return err
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(ns, nil)
+	}
+	cmd.Info(fmt.Sprintf("%d", ns.Count))
+
+	return nil
+}
+
+// nodesetUnionCmd is the struct representing the command to print the union
+// of sets.
+type nodesetUnionCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetUnionCmd activates.
+func (cmd *nodesetUnionCmd) Execute(_ []string) error {
+	return cmd.run(nodesetOpUnion)
+}
+
+// nodesetIntersectCmd is the struct representing the command to print the
+// intersection of sets.
+type nodesetIntersectCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetIntersectCmd activates.
+func (cmd *nodesetIntersectCmd) Execute(_ []string) error {
+	return cmd.run(nodesetOpIntersection)
+}
+
+// nodesetDiffCmd is the struct representing the command to print the
+// difference between the first set and the others.
+type nodesetDiffCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetDiffCmd activates.
+func (cmd *nodesetDiffCmd) Execute(_ []string) error {
+	return cmd.run(nodesetOpDifference)
+}
+
+// nodesetXorCmd is the struct representing the command to print the
+// symmetric difference of sets.
+type nodesetXorCmd struct {
+	nodesetBaseCmd
+}
+
+// Execute is run when nodesetXorCmd activates.
+func (cmd *nodesetXorCmd) Execute(_ []string) error {
+	return cmd.run(nodesetOpXor)
+}
@@ -0,0 +1,106 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestDmg_nodesetBaseCmd_apply(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ranks  bool
+		sets   []string
+		op     nodesetOp
+		expSet *nodeset
+		expErr error
+	}{
+		"no sets": {
+			expErr: errors.New("at least one set"),
+		},
+		"invalid host set": {
+			sets:   []string{"host[1-"},
+			expErr: errors.New("invalid host set"),
+		},
+		"invalid rank set": {
+			ranks:  true,
+			sets:   []string{"0-x"},
+			expErr: errors.New("invalid rank set"),
+		},
+		"fold hosts": {
+			sets: []string{"host1,host2,host3", "host4"},
+			op:   nodesetOpUnion,
+			expSet: &nodeset{
+				Set:     "host[1-4]",
+				Count:   4,
+				Members: []string{"host1", "host2", "host3", "host4"},
+			},
+		},
+		"intersect hosts": {
+			sets: []string{"host[1-4]", "host[3-6]"},
+			op:   nodesetOpIntersection,
+			expSet: &nodeset{
+				Set:     "host[3-4]",
+				Count:   2,
+				Members: []string{"host3", "host4"},
+			},
+		},
+		"empty host difference": {
+			sets:   []string{"host[1-2]", "host[1-4]"},
+			op:     nodesetOpDifference,
+			expSet: &nodeset{Members: []string{}},
+		},
+		"xor hosts": {
+			sets: []string{"host[1-4]", "host[3-6]"},
+			op:   nodesetOpXor,
+			expSet: &nodeset{
+				Set:     "host[1-2,5-6]",
+				Count:   4,
+				Members: []string{"host1", "host2", "host5", "host6"},
+			},
+		},
+		"difference ranks": {
+			ranks: true,
+			sets:  []string{"0-7", "2,4-5", "7"},
+			op:    nodesetOpDifference,
+			expSet: &nodeset{
+				Set:     "0-1,3,6",
+				Count:   4,
+				Members: []string{"0", "1", "3", "6"},
+			},
+		},
+		"union ranks": {
+			ranks: true,
+			sets:  []string{"[0-2]", "5"},
+			op:    nodesetOpUnion,
+			expSet: &nodeset{
+				Set:     "0-2,5",
+				Count:   4,
+				Members: []string{"0", "1", "2", "5"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := &nodesetBaseCmd{Ranks: tc.ranks}
+			cmd.Args.Sets = tc.sets
+
+			gotSet, gotErr := cmd.apply(tc.op)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expSet, gotSet); diff != "" {
+				t.Fatalf("unexpected result (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
@@ -45,7 +46,9 @@ type PoolCmd struct {
 	UpdateACL    PoolUpdateACLCmd    `command:"update-acl" description:"Update entries in a DAOS pool's Access Control List"`
 	DeleteACL    PoolDeleteACLCmd    `command:"delete-acl" description:"Delete an entry from a DAOS pool's Access Control List"`
 	SetProp      PoolSetPropCmd      `command:"set-prop" description:"Set pool property"`
+	Rename       PoolRenameCmd       `command:"rename" description:"Rename a DAOS pool"`
 	GetProp      PoolGetPropCmd      `command:"get-prop" description:"Get pool properties"`
+	ExportConfig PoolExportConfigCmd `command:"export-config" description:"Export a DAOS pool's configuration for use with pool create --from-config"`
 	Upgrade      PoolUpgradeCmd      `command:"upgrade" description:"Upgrade pool to latest format"`
 }
 
@@ -190,6 +193,7 @@ type PoolCreateCmd struct {
 	baseCmd
 	cfgCmd
 	ctlInvokerCmd
+	progressCmd
 	cmdutil.JSONOutputCmd
 	GroupName  ui.ACLPrincipalFlag `short:"g" long:"group" description:"DAOS pool to be owned by given group, format name@domain"`
 	UserName   ui.ACLPrincipalFlag `short:"u" long:"user" description:"DAOS pool to be owned by given user, format name@domain"`
@@ -203,6 +207,7 @@ type PoolCreateCmd struct {
 	NVMeSize   sizeFlag            `short:"n" long:"nvme-size" description:"Per-engine NVMe allocation for DAOS pool (manual)"`
 	MetaSize   sizeFlag            `long:"meta-size" description:"In MD-on-SSD mode specify meta blob size to be used in DAOS pool (manual)"`
 	RankList   ui.RankSetFlag      `short:"r" long:"ranks" description:"Storage engine unique identifiers (ranks) for DAOS pool"`
+	FromConfig string              `long:"from-config" description:"Pool configuration file written by pool export-config; other options override its settings"`
 
 	Args struct {
 		PoolLabel string `positional-arg-name:"<pool label>" required:"1"`
@@ -309,8 +314,50 @@ func (cmd *PoolCreateCmd) storageManual(req *control.PoolCreateReq) error {
 	return nil
 }
 
+// applyPoolConfig fills in any storage options that have not been set on the
+// command line from the supplied pool configuration.
+func (cmd *PoolCreateCmd) applyPoolConfig(cfg *control.PoolConfig) {
+	if cmd.Size.IsSet() || cmd.ScmSize.IsSet() {
+		if !cmd.Size.IsRatio() && !cmd.ScmSize.IsSet() && !cmd.TierRatio.IsSet() {
+			cmd.TierRatio.ratios = cfg.TierRatio
+		}
+		return
+	}
+
+	cmd.Size.bytes = cfg.TotalBytes
+	if !cmd.TierRatio.IsSet() {
+		cmd.TierRatio.ratios = cfg.TierRatio
+	}
+}
+
+// mergePoolProps returns the base properties with any properties in the
+// overrides replacing those of the same name.
+func mergePoolProps(base, overrides []*daos.PoolProperty) []*daos.PoolProperty {
+	overridden := make(map[string]struct{})
+	for _, prop := range overrides {
+		overridden[prop.Name] = struct{}{}
+	}
+
+	var merged []*daos.PoolProperty
+	for _, prop := range base {
+		if _, found := overridden[prop.Name]; !found {
+			merged = append(merged, prop)
+		}
+	}
+	return append(merged, overrides...)
+}
+
 // Execute is run when PoolCreateCmd subcommand is activated
 func (cmd *PoolCreateCmd) Execute(args []string) error {
+	var poolCfg *control.PoolConfig
+	if cmd.FromConfig != "" {
+		var err error
+		if poolCfg, err = control.ReadPoolConfigFile(cmd.FromConfig); err != nil {
+			return err
+		}
+		cmd.applyPoolConfig(poolCfg)
+	}
+
 	if err := cmd.checkSizeArgs(); err != nil {
 		return err
 	}
@@ -335,6 +382,24 @@ func (cmd *PoolCreateCmd) Execute(args []string) error {
 		Ranks:      cmd.RankList.Ranks(),
 	}
 
+	if poolCfg != nil {
+		if req.User == "" {
+			req.User = poolCfg.User
+		}
+		if req.UserGroup == "" {
+			req.UserGroup = poolCfg.UserGroup
+		}
+		if len(poolCfg.ACL) > 0 {
+			req.ACL = &control.AccessControlList{Entries: poolCfg.ACL}
+		}
+
+		cfgProps, err := poolCfg.PoolProperties()
+		if err != nil {
+			return err
+		}
+		req.Properties = mergePoolProps(cfgProps, req.Properties)
+	}
+
 	if cmd.ACLFile != "" {
 		var err error
 		req.ACL, err = control.ReadACLFile(cmd.ACLFile)
@@ -365,6 +430,11 @@ func (cmd *PoolCreateCmd) Execute(args []string) error {
 		}
 	}
 
+	if pp := cmd.newProgressPrinter("pool create"); pp != nil {
+		req.SetProgressFn(pp.Update)
+		defer pp.Finish()
+	}
+
 	resp, err := control.PoolCreate(ctx, cmd.ctlInvoker, req)
 
 	if cmd.JSONOutputEnabled() {
@@ -389,10 +459,14 @@ type PoolListCmd struct {
 	baseCmd
 	cfgCmd
 	ctlInvokerCmd
+	offlineCmd
 	cmdutil.JSONOutputCmd
-	Verbose     bool `short:"v" long:"verbose" description:"Add pool UUIDs and service replica lists to display"`
-	NoQuery     bool `short:"n" long:"no-query" description:"Disable query of listed pools"`
-	RebuildOnly bool `short:"r" long:"rebuild-only" description:"List only pools which rebuild stats is not idle"`
+	Verbose       bool        `short:"v" long:"verbose" description:"Add pool UUIDs and service replica lists to display"`
+	NoQuery       bool        `short:"n" long:"no-query" description:"Disable query of listed pools"`
+	RebuildOnly   bool        `short:"r" long:"rebuild-only" description:"List only pools which rebuild stats is not idle"`
+	Owner         string      `long:"owner" description:"List only pools owned by the specified user"`
+	CreatedBefore ui.TimeFlag `long:"created-before" description:"List only pools created before the specified time (YYYY-MM-DD or RFC3339)"`
+	CreatedAfter  ui.TimeFlag `long:"created-after" description:"List only pools created after the specified time (YYYY-MM-DD or RFC3339)"`
 }
 
 // Execute is run when PoolListCmd activates
@@ -405,8 +479,14 @@ func (cmd *PoolListCmd) Execute(_ []string) (errOut error) {
 		return errors.New("no configuration loaded")
 	}
 
+	// Pool usage is not recorded in exported MS state.
+	noQuery := cmd.NoQuery || cmd.offline
+
 	req := &control.ListPoolsReq{
-		NoQuery: cmd.NoQuery,
+		NoQuery:       noQuery,
+		Owner:         cmd.Owner,
+		CreatedBefore: cmd.CreatedBefore.Time,
+		CreatedAfter:  cmd.CreatedAfter.Time,
 	}
 
 	resp, err := control.ListPools(cmd.MustLogCtx(), cmd.ctlInvoker, req)
@@ -431,7 +511,7 @@ func (cmd *PoolListCmd) Execute(_ []string) (errOut error) {
 	}
 
 	var out, outErr strings.Builder
-	if err := pretty.PrintListPoolsResponse(&out, &outErr, resp, cmd.Verbose, cmd.NoQuery); err != nil {
+	if err := pretty.PrintListPoolsResponse(&out, &outErr, resp, cmd.Verbose, noQuery); err != nil {
 		return err
 	}
 	if outErr.String() != "" {
@@ -598,8 +678,10 @@ func (cmd *PoolExtendCmd) Execute(args []string) error {
 // PoolReintegrateCmd is the struct representing the command to Add a DAOS target.
 type PoolReintegrateCmd struct {
 	poolCmd
+	progressCmd
 	Rank      uint32 `long:"rank" required:"1" description:"Engine rank of the targets to be reintegrated"`
 	Targetidx string `long:"target-idx" description:"Comma-separated list of target idx(s) to be reintegrated into the rank"`
+	Wait      bool   `short:"w" long:"wait" description:"Wait for the resulting rebuild to complete"`
 }
 
 // Execute is run when PoolReintegrateCmd subcommand is activated
@@ -618,14 +700,25 @@ func (cmd *PoolReintegrateCmd) Execute(args []string) error {
 		Targetidx: idxlist,
 	}
 
-	err := control.PoolReintegrate(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	ctx := cmd.MustLogCtx()
+	err := control.PoolReintegrate(ctx, cmd.ctlInvoker, req)
 	if err != nil {
 		msg = errors.WithMessage(err, "failed").Error()
 	}
 
 	cmd.Infof("Reintegration command %s\n", msg)
 
-	return err
+	if err != nil || !cmd.Wait {
+		return err
+	}
+
+	waitReq := &control.PoolRebuildWaitReq{ID: req.ID}
+	if pp := cmd.newProgressPrinter("pool rebuild"); pp != nil {
+		waitReq.SetProgressFn(pp.Update)
+		defer pp.Finish()
+	}
+
+	return control.PoolRebuildWait(ctx, cmd.ctlInvoker, waitReq)
 }
 
 // PoolQueryCmd is the struct representing the command to query a DAOS pool.
@@ -634,10 +727,71 @@ type PoolQueryCmd struct {
 	ShowEnabledRanks  bool `short:"e" long:"show-enabled" description:"Show engine unique identifiers (ranks) which are enabled"`
 	ShowDisabledRanks bool `short:"b" long:"show-disabled" description:"Show engine unique identifiers (ranks) which are disabled"`
 	HealthOnly        bool `short:"t" long:"health-only" description:"Only perform pool health related queries"`
+	History           bool `long:"history" description:"Show space usage history and trends from the snapshots recorded by the MS"`
+	Activity          bool `long:"activity" description:"Show the log of pool lifecycle activity recorded by the MS"`
+}
+
+func (cmd *PoolQueryCmd) queryHistory() error {
+	if cmd.ShowEnabledRanks || cmd.ShowDisabledRanks || cmd.HealthOnly || cmd.Activity {
+		return errIncompatFlags("history", "show-enabled", "show-disabled", "health-only", "activity")
+	}
+
+	req := &control.PoolUsageHistoryReq{
+		ID: cmd.PoolID().String(),
+	}
+	resp, err := control.PoolUsageHistory(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "pool usage history query failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintPoolUsageHistory(resp, &bld); err != nil {
+		return err
+	}
+	cmd.Info(bld.String())
+	return nil
+}
+
+func (cmd *PoolQueryCmd) queryActivity() error {
+	if cmd.ShowEnabledRanks || cmd.ShowDisabledRanks || cmd.HealthOnly {
+		return errIncompatFlags("activity", "show-enabled", "show-disabled", "health-only")
+	}
+
+	req := &control.PoolActivityReq{
+		ID: cmd.PoolID().String(),
+	}
+	resp, err := control.PoolActivity(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "pool activity query failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintPoolActivity(resp, &bld); err != nil {
+		return err
+	}
+	cmd.Info(bld.String())
+	return nil
 }
 
 // Execute is run when PoolQueryCmd subcommand is activated
 func (cmd *PoolQueryCmd) Execute(args []string) error {
+	if cmd.History {
+		return cmd.queryHistory()
+	}
+	if cmd.Activity {
+		return cmd.queryActivity()
+	}
+
 	req := &control.PoolQueryReq{
 		ID:        cmd.PoolID().String(),
 		QueryMask: daos.DefaultPoolQueryMask,
@@ -781,6 +935,35 @@ func (cmd *PoolSetPropCmd) Execute(_ []string) error {
 	return nil
 }
 
+// PoolRenameCmd represents the command to change the label of a pool.
+type PoolRenameCmd struct {
+	poolCmd
+
+	Args struct {
+		NewLabel string `positional-arg-name:"<new label>" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when PoolRenameCmd subcommand is activated.
+func (cmd *PoolRenameCmd) Execute(_ []string) error {
+	req := &control.PoolRenameReq{
+		ID:       cmd.PoolID().String(),
+		NewLabel: cmd.Args.NewLabel,
+	}
+
+	err := control.PoolRename(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(nil, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "pool rename failed")
+	}
+	cmd.Infof("pool %s renamed to %s", cmd.PoolID(), cmd.Args.NewLabel)
+
+	return nil
+}
+
 // PoolGetPropCmd represents the command to set a property on a pool.
 type PoolGetPropCmd struct {
 	poolCmd
@@ -812,6 +995,50 @@ func (cmd *PoolGetPropCmd) Execute(_ []string) error {
 	return nil
 }
 
+// PoolExportConfigCmd represents the command to export the configuration of a
+// DAOS pool so that identical pools can be created from it.
+type PoolExportConfigCmd struct {
+	poolCmd
+	File  string `short:"o" long:"outfile" required:"0" description:"Write pool configuration to file"`
+	Force bool   `short:"f" long:"force" required:"0" description:"Allow to clobber output file"`
+}
+
+// Execute is run when the PoolExportConfigCmd subcommand is activated.
+func (cmd *PoolExportConfigCmd) Execute(_ []string) error {
+	req := &control.PoolExportConfigReq{ID: cmd.PoolID().String()}
+
+	resp, err := control.PoolExportConfig(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "pool export-config failed")
+	}
+
+	out, err := yaml.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	if cmd.File == "" {
+		cmd.Info(string(out))
+		return nil
+	}
+
+	if !cmd.Force {
+		if _, err := os.Stat(cmd.File); err == nil {
+			return errors.Errorf("file already exists: %s", cmd.File)
+		}
+	}
+	if err := os.WriteFile(cmd.File, out, 0644); err != nil {
+		return err
+	}
+	cmd.Infof("Wrote pool configuration to output file: %s", cmd.File)
+
+	return nil
+}
+
 // PoolGetACLCmd represents the command to fetch an Access Control List of a
 // DAOS pool.
 type PoolGetACLCmd struct {
@@ -917,10 +1144,22 @@ type PoolUpdateACLCmd struct {
 	poolCmd
 	ACLFile string `short:"a" long:"acl-file" required:"0" description:"Path for new Access Control List file"`
 	Entry   string `short:"e" long:"entry" required:"0" description:"Single Access Control Entry to add or update"`
+	File    string `long:"file" required:"0" description:"Path for a complete Access Control List file to replace the current ACL with"`
+	Diff    bool   `long:"diff" required:"0" description:"Show the changes that --file would make without applying them"`
 }
 
 // Execute is run when the PoolUpdateACLCmd subcommand is activated
 func (cmd *PoolUpdateACLCmd) Execute(args []string) error {
+	if cmd.File != "" {
+		if cmd.ACLFile != "" || cmd.Entry != "" {
+			return errors.New("--file may not be used with --acl-file or --entry")
+		}
+		return cmd.applyACLFile()
+	}
+	if cmd.Diff {
+		return errors.New("--diff may only be used with --file")
+	}
+
 	if (cmd.ACLFile == "" && cmd.Entry == "") || (cmd.ACLFile != "" && cmd.Entry != "") {
 		return errors.New("either ACL file or entry parameter is required")
 	}
@@ -959,6 +1198,60 @@ func (cmd *PoolUpdateACLCmd) Execute(args []string) error {
 	return nil
 }
 
+// applyACLFile replaces the pool ACL with the complete ACL in the file, after
+// showing the entries that will be added, changed or removed. In diff mode the
+// changes are only displayed.
+func (cmd *PoolUpdateACLCmd) applyACLFile() error {
+	acl, err := control.ReadACLFile(cmd.File)
+	if err != nil {
+		return err
+	}
+	if err := acl.Validate(); err != nil {
+		return errors.Wrapf(err, "ACL file %q", cmd.File)
+	}
+
+	getResp, err := control.PoolGetACL(cmd.MustLogCtx(), cmd.ctlInvoker, &control.PoolGetACLReq{
+		ID: cmd.PoolID().String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "Pool-update-ACL command failed")
+	}
+
+	diff, err := control.DiffACL(getResp.ACL, acl)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Diff {
+		if cmd.JSONOutputEnabled() {
+			return cmd.OutputJSON(diff, nil)
+		}
+		cmd.Info(control.FormatACLDiff(diff))
+		return nil
+	}
+
+	req := &control.PoolOverwriteACLReq{
+		ID:  cmd.PoolID().String(),
+		ACL: acl,
+	}
+
+	resp, err := control.PoolOverwriteACL(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "Pool-update-ACL command failed")
+	}
+
+	cmd.Infof("Pool-update-ACL command succeeded, ID: %s\n", cmd.PoolID())
+
+	cmd.Info(control.FormatACLDiff(diff))
+	cmd.Info(control.FormatACLDefault(resp.ACL))
+
+	return nil
+}
+
 // PoolDeleteACLCmd represents the command to delete an entry from the Access
 // Control List of a DAOS pool.
 type PoolDeleteACLCmd struct {
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -158,6 +159,11 @@ func TestPoolCommands(t *testing.T) {
 	}
 	testACLFile := createACLFile(t, tmpDir, testACL)
 
+	// An ACL file with entries that conflict with each other
+	testConflictACLFile := createACLFile(t, tmpDir, &control.AccessControlList{
+		Entries: []string{"A::OWNER@:rw", "A::OWNER@:r"},
+	})
+
 	// An existing file with contents for tests that need to verify overwrite
 	testExistingFile := createACLFile(t, tmpDir, testACL)
 
@@ -187,6 +193,17 @@ func TestPoolCommands(t *testing.T) {
 		return prop
 	}
 
+	testPoolConfigFile := test.CreateTestFile(t, tmpDir, `user: owner@
+group: group@
+acl:
+- A::OWNER@:rw
+size: 1073741824
+tier_ratio: [0.1, 0.9]
+properties:
+  reclaim: lazy
+  svc_rf: "2"
+`)
+
 	setQueryMask := func(xfrm func(qm *daos.PoolQueryMask)) daos.PoolQueryMask {
 		qm := daos.DefaultPoolQueryMask
 		xfrm(&qm)
@@ -229,6 +246,78 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Create pool from config",
+			fmt.Sprintf("pool create --from-config %s foo", testPoolConfigFile),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes: 1073741824,
+					TierRatio:  []float64{0.1, 0.9},
+					User:       "owner@",
+					UserGroup:  "group@",
+					ACL: &control.AccessControlList{
+						Entries: []string{"A::OWNER@:rw"},
+					},
+					Ranks: []ranklist.Rank{},
+					Properties: []*daos.PoolProperty{
+						propWithVal("reclaim", "lazy"),
+						propWithVal("svc_rf", "2"),
+						propWithVal("label", "foo"),
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool from config with overrides",
+			fmt.Sprintf("pool create --from-config %s --size %s --user bob@ --properties reclaim:disabled foo",
+				testPoolConfigFile, testSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes: uint64(testSize),
+					TierRatio:  []float64{0.1, 0.9},
+					User:       "bob@",
+					UserGroup:  "group@",
+					ACL: &control.AccessControlList{
+						Entries: []string{"A::OWNER@:rw"},
+					},
+					Ranks: []ranklist.Rank{},
+					Properties: []*daos.PoolProperty{
+						propWithVal("svc_rf", "2"),
+						propWithVal("reclaim", "disabled"),
+						propWithVal("label", "foo"),
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool from config with manual sizes",
+			fmt.Sprintf("pool create --from-config %s --scm-size %s foo", testPoolConfigFile, testSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TierBytes: []uint64{uint64(testSize), 0},
+					User:      "owner@",
+					UserGroup: "group@",
+					ACL: &control.AccessControlList{
+						Entries: []string{"A::OWNER@:rw"},
+					},
+					Ranks: []ranklist.Rank{},
+					Properties: []*daos.PoolProperty{
+						propWithVal("reclaim", "lazy"),
+						propWithVal("svc_rf", "2"),
+						propWithVal("label", "foo"),
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool from missing config",
+			"pool create --from-config /not/a/real/file foo",
+			"",
+			errors.New("reading pool configuration file"),
+		},
 		{
 			"Create pool with missing size",
 			"pool create label",
@@ -712,6 +801,24 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"List pools with owner and creation time filters",
+			"pool list --owner alice --created-after 2024-01-01 --created-before 2024-02-01T12:00:00Z",
+			strings.Join([]string{
+				printRequest(t, &control.ListPoolsReq{
+					Owner:         "alice",
+					CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					CreatedBefore: time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"List pools with bad creation time filter",
+			"pool list --created-before tomorrow",
+			"",
+			errors.New("invalid time value"),
+		},
 		{
 			"Set pool properties",
 			"pool set-prop 031bcaf8-f0f5-42ef-b3c5-ee048676dceb label:foo,space_rb:42",
@@ -740,6 +847,31 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Rename pool",
+			"pool rename 031bcaf8-f0f5-42ef-b3c5-ee048676dceb new",
+			strings.Join([]string{
+				printRequest(t, &control.PoolSetPropReq{
+					ID: "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+					Properties: []*daos.PoolProperty{
+						propWithVal("label", "new"),
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Rename pool missing new label",
+			"pool rename 031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+			"",
+			errors.New("required argument"),
+		},
+		{
+			"Rename pool invalid label",
+			"pool rename foo 031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+			"",
+			errors.New("invalid label"),
+		},
 		{
 			"Set pool property invalid property",
 			"pool set-prop 031bcaf8-f0f5-42ef-b3c5-ee048676dceb whoops:foo",
@@ -811,6 +943,12 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Export pool config without pool",
+			"pool export-config",
+			"",
+			errors.New("required argument"),
+		},
 		{
 			"Get pool ACL with verbose flag",
 			"pool get-acl 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --verbose",
@@ -940,6 +1078,48 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Update pool ACL with file and entry",
+			fmt.Sprintf("pool update-acl 12345678-1234-1234-1234-1234567890ab --file %s --entry A::user@:rw", testACLFile),
+			"",
+			dmgTestErr("--file may not be used with --acl-file or --entry"),
+		},
+		{
+			"Update pool ACL diff without file",
+			"pool update-acl 12345678-1234-1234-1234-1234567890ab --entry A::user@:rw --diff",
+			"",
+			dmgTestErr("--diff may only be used with --file"),
+		},
+		{
+			"Update pool ACL with conflicting file",
+			fmt.Sprintf("pool update-acl 12345678-1234-1234-1234-1234567890ab --file %s", testConflictACLFile),
+			"",
+			dmgTestErr("conflicting ACEs"),
+		},
+		{
+			"Update pool ACL diff with file",
+			fmt.Sprintf("pool update-acl 12345678-1234-1234-1234-1234567890ab --file %s --diff", testACLFile),
+			strings.Join([]string{
+				printRequest(t, &control.PoolGetACLReq{
+					ID: "12345678-1234-1234-1234-1234567890ab",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Update pool ACL with file",
+			fmt.Sprintf("pool update-acl 12345678-1234-1234-1234-1234567890ab --file %s", testACLFile),
+			strings.Join([]string{
+				printRequest(t, &control.PoolGetACLReq{
+					ID: "12345678-1234-1234-1234-1234567890ab",
+				}),
+				printRequest(t, &control.PoolOverwriteACLReq{
+					ID:  "12345678-1234-1234-1234-1234567890ab",
+					ACL: testACL,
+				}),
+			}, " "),
+			nil,
+		},
 		{
 			"Delete pool ACL without principal flag",
 			"pool delete-acl 12345678-1234-1234-1234-1234567890ab",
@@ -968,6 +1148,36 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Query pool usage history",
+			"pool query --history 12345678-1234-1234-1234-1234567890ab",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"Query pool usage history with health-only",
+			"pool query --history --health-only 12345678-1234-1234-1234-1234567890ab",
+			"",
+			errors.New("may not be mixed"),
+		},
+		{
+			"Query pool activity",
+			"pool query --activity 12345678-1234-1234-1234-1234567890ab",
+			strings.Join([]string{
+				printRequest(t, &control.PoolActivityReq{
+					ID: "12345678-1234-1234-1234-1234567890ab",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Query pool activity with show-enabled",
+			"pool query --activity --show-enabled 12345678-1234-1234-1234-1234567890ab",
+			"",
+			errors.New("may not be mixed"),
+		},
 		{
 			"Query pool with UUID and enabled ranks",
 			"pool query --show-enabled 12345678-1234-1234-1234-1234567890ab",
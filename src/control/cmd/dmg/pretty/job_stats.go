@@ -0,0 +1,73 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// formatJobMetric returns a human-readable representation of a per-job
+// metric total. Byte counts are displayed with units.
+func formatJobMetric(name string, val float64) string {
+	if strings.HasSuffix(name, "bytes") && val >= 0 {
+		return humanize.Bytes(uint64(val))
+	}
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+// PrintJobStatsQueryResponse generates a human-readable representation of the
+// supplied JobStatsQueryResp struct and writes it to the supplied io.Writer.
+func PrintJobStatsQueryResponse(out io.Writer, resp *control.JobStatsQueryResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Jobs) == 0 {
+		fmt.Fprintln(out, "No job statistics available")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Job statistics last updated at %s\n\n", common.FormatTime(resp.UpdatedAt))
+
+	metricTitle := "Metric"
+	totalTitle := "Total"
+
+	for _, js := range resp.Jobs {
+		fmt.Fprintf(out, "Job %s (%d %s)\n", js.JobID, js.NumRanks,
+			english.PluralWord(int(js.NumRanks), "rank", "ranks"))
+
+		names := make([]string, 0, len(js.Metrics))
+		for name := range js.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		formatter := txtfmt.NewTableFormatter(metricTitle, totalTitle)
+		var table []txtfmt.TableRow
+		for _, name := range names {
+			table = append(table, txtfmt.TableRow{
+				metricTitle: name,
+				totalTitle:  formatJobMetric(name, js.Metrics[name]),
+			})
+		}
+		fmt.Fprintln(out, formatter.Format(table))
+	}
+
+	return nil
+}
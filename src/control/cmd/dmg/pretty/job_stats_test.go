@@ -0,0 +1,83 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintJobStatsQueryResponse(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.JobStatsQueryResp
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.JobStatsQueryResp{},
+			expPrintStr: `
+No job statistics available
+`,
+		},
+		"normal response": {
+			resp: &control.JobStatsQueryResp{
+				Jobs: []*control.JobStats{
+					{
+						JobID:    "job1",
+						NumRanks: 4,
+						Metrics: map[string]float64{
+							"io_update_bytes": 4000000,
+							"io_fetch_bytes":  1000,
+							"io_update_ops":   12,
+						},
+					},
+					{
+						JobID:    "job2",
+						NumRanks: 1,
+						Metrics: map[string]float64{
+							"io_update_ops": 3,
+						},
+					},
+				},
+				UpdatedAt: ts,
+			},
+			expPrintStr: `
+Job statistics last updated at 2024-03-01T12:00:00.000+00:00
+
+Job job1 (4 ranks)
+Metric          Total  
+------          -----  
+io_fetch_bytes  1.0 kB 
+io_update_bytes 4.0 MB 
+io_update_ops   12     
+
+Job job2 (1 rank)
+Metric        Total 
+------        ----- 
+io_update_ops 3     
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintJobStatsQueryResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -9,11 +9,14 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
 	"github.com/pkg/errors"
 
 	pretty "github.com/daos-stack/daos/src/control/cmd/daos/pretty"
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
@@ -315,3 +318,123 @@ func PrintPoolProperties(poolID string, out io.Writer, properties ...*daos.PoolP
 	tf.InitWriter(out)
 	tf.Format(table)
 }
+
+func formatTierGrowth(trend *control.PoolTierTrend) string {
+	if trend.GrowthPerDay < 0 {
+		return fmt.Sprintf("-%s/day", humanize.IBytes(uint64(-trend.GrowthPerDay)))
+	}
+	return fmt.Sprintf("%s/day", humanize.IBytes(uint64(trend.GrowthPerDay)))
+}
+
+// PrintPoolUsageHistory generates a human-readable representation of the
+// supplied PoolUsageHistoryResp and writes it to the supplied io.Writer.
+func PrintPoolUsageHistory(resp *control.PoolUsageHistoryResp, out io.Writer) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+	if len(resp.Samples) == 0 {
+		fmt.Fprintln(out, "No usage history recorded")
+		return nil
+	}
+
+	poolID := resp.PoolUUID.String()
+	if resp.PoolLabel != "" {
+		poolID = fmt.Sprintf("%s (%s)", resp.PoolLabel, poolID)
+	}
+	first, last := resp.Samples[0], resp.Samples[len(resp.Samples)-1]
+	fmt.Fprintf(out, "Pool %s usage history: %s from %s to %s\n", poolID,
+		english.Plural(len(resp.Samples), "sample", ""),
+		common.FormatTime(first.Timestamp), common.FormatTime(last.Timestamp))
+	fmt.Fprintln(out)
+
+	tierTitle := "Tier"
+	usedTitle := "Used"
+	totalTitle := "Total"
+	growthTitle := "Growth"
+	fullTitle := "Projected Full"
+
+	tf := txtfmt.NewTableFormatter(tierTitle, usedTitle, totalTitle, growthTitle, fullTitle)
+	tf.InitWriter(out)
+
+	var table []txtfmt.TableRow
+	for _, trend := range resp.Trends {
+		full := "N/A"
+		if trend.ProjectedFull != nil {
+			full = common.FormatTime(*trend.ProjectedFull)
+		}
+		table = append(table, txtfmt.TableRow{
+			tierTitle:   strings.ToUpper(trend.MediaType),
+			usedTitle:   humanize.IBytes(trend.UsedBytes),
+			totalTitle:  humanize.IBytes(trend.TotalBytes),
+			growthTitle: formatTierGrowth(trend),
+			fullTitle:   full,
+		})
+	}
+	tf.Format(table)
+	fmt.Fprintln(out)
+
+	timeTitle := "Timestamp"
+	titles := []string{timeTitle}
+	for _, trend := range resp.Trends {
+		titles = append(titles, strings.ToUpper(trend.MediaType)+" Used")
+	}
+
+	tf = txtfmt.NewTableFormatter(titles...)
+	tf.InitWriter(out)
+
+	table = nil
+	for _, sample := range resp.Samples {
+		row := txtfmt.TableRow{timeTitle: common.FormatTime(sample.Timestamp)}
+		for _, tier := range sample.Tiers {
+			used := uint64(0)
+			if tier.TotalBytes > tier.FreeBytes {
+				used = tier.TotalBytes - tier.FreeBytes
+			}
+			row[strings.ToUpper(tier.MediaType)+" Used"] = humanize.IBytes(used)
+		}
+		table = append(table, row)
+	}
+	tf.Format(table)
+
+	return nil
+}
+
+// PrintPoolActivity generates a human-readable representation of the
+// supplied PoolActivityResp and writes it to the supplied io.Writer.
+func PrintPoolActivity(resp *control.PoolActivityResp, out io.Writer) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	poolID := resp.PoolUUID.String()
+	if resp.PoolLabel != "" {
+		poolID = fmt.Sprintf("%s (%s)", resp.PoolLabel, poolID)
+	}
+	if len(resp.Records) == 0 {
+		fmt.Fprintf(out, "No activity recorded for pool %s\n", poolID)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Pool %s activity: %s\n", poolID,
+		english.Plural(len(resp.Records), "record", ""))
+	fmt.Fprintln(out)
+
+	timeTitle := "Timestamp"
+	kindTitle := "Activity"
+	msgTitle := "Details"
+
+	tf := txtfmt.NewTableFormatter(timeTitle, kindTitle, msgTitle)
+	tf.InitWriter(out)
+
+	var table []txtfmt.TableRow
+	for _, rec := range resp.Records {
+		table = append(table, txtfmt.TableRow{
+			timeTitle: common.FormatTime(rec.Timestamp),
+			kindTitle: string(rec.Kind),
+			msgTitle:  rec.Msg,
+		})
+	}
+	tf.Format(table)
+
+	return nil
+}
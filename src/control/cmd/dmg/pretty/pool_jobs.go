@@ -0,0 +1,91 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// formatPoolOpLimit returns a human-readable representation of a pool
+// operation scheduler limit.
+func formatPoolOpLimit(limit uint32) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return strconv.FormatUint(uint64(limit), 10)
+}
+
+// PrintPoolOpJobs generates a human-readable representation of the supplied
+// PoolOpJobsResp struct and writes it to the supplied io.Writer.
+func PrintPoolOpJobs(out io.Writer, resp *control.PoolOpJobsResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if !resp.Enabled {
+		fmt.Fprintln(out, "Pool operation scheduler not enabled")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Limits: %s concurrent, %s per rank\n\n",
+		formatPoolOpLimit(resp.MaxConcurrent), formatPoolOpLimit(resp.MaxPerRank))
+
+	if len(resp.Jobs) == 0 {
+		fmt.Fprintln(out, "No pool operations scheduled")
+		return nil
+	}
+
+	idTitle := "ID"
+	opTitle := "Operation"
+	poolTitle := "Pool"
+	ranksTitle := "Ranks"
+	prioTitle := "Priority"
+	stateTitle := "State"
+	submittedTitle := "Submitted"
+	startedTitle := "Started"
+
+	formatter := txtfmt.NewTableFormatter(idTitle, opTitle, poolTitle, ranksTitle,
+		prioTitle, stateTitle, submittedTitle, startedTitle)
+	var table []txtfmt.TableRow
+	for _, job := range resp.Jobs {
+		pool := job.PoolLabel
+		if pool == "" {
+			pool = job.PoolUUID
+		}
+		ranks := "-"
+		if len(job.Ranks) > 0 {
+			ranks = ranklist.RankSetFromRanks(job.Ranks).String()
+		}
+		started := "-"
+		if !job.Started.IsZero() {
+			started = common.FormatTime(job.Started)
+		}
+
+		table = append(table, txtfmt.TableRow{
+			idTitle:        strconv.FormatUint(job.ID, 10),
+			opTitle:        job.Kind,
+			poolTitle:      pool,
+			ranksTitle:     ranks,
+			prioTitle:      strconv.Itoa(job.Priority),
+			stateTitle:     job.State,
+			submittedTitle: common.FormatTime(job.Submitted),
+			startedTitle:   started,
+		})
+	}
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
@@ -0,0 +1,101 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+func TestPretty_PrintPoolOpJobs(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.PoolOpJobsResp
+		expPrintStr string
+	}{
+		"disabled": {
+			resp: &control.PoolOpJobsResp{},
+			expPrintStr: `
+Pool operation scheduler not enabled
+`,
+		},
+		"no jobs": {
+			resp: &control.PoolOpJobsResp{Enabled: true},
+			expPrintStr: `
+Limits: unlimited concurrent, unlimited per rank
+
+No pool operations scheduled
+`,
+		},
+		"queued jobs": {
+			resp: &control.PoolOpJobsResp{
+				Enabled:       true,
+				MaxConcurrent: 1,
+				MaxPerRank:    1,
+				Jobs: []*control.PoolOpJob{
+					{
+						ID:        1,
+						Kind:      "rebuild",
+						PoolUUID:  test.MockUUID(1),
+						PoolLabel: "pool1",
+						Priority:  3,
+						State:     "rebuilding",
+						Submitted: ts,
+						Started:   ts,
+					},
+					{
+						ID:        2,
+						Kind:      "reintegrate",
+						PoolUUID:  test.MockUUID(2),
+						PoolLabel: "pool2",
+						Ranks:     []ranklist.Rank{2},
+						Priority:  2,
+						State:     "queued",
+						Submitted: ts.Add(time.Minute),
+					},
+					{
+						ID:        3,
+						Kind:      "extend",
+						PoolUUID:  test.MockUUID(3),
+						Ranks:     []ranklist.Rank{4, 5},
+						State:     "queued",
+						Submitted: ts.Add(2 * time.Minute),
+					},
+				},
+			},
+			expPrintStr: `
+Limits: 1 concurrent, 1 per rank
+
+ID Operation   Pool                                 Ranks Priority State      Submitted                     Started                       
+-- ---------   ----                                 ----- -------- -----      ---------                     -------                       
+1  rebuild     pool1                                -     3        rebuilding 2024-03-01T12:00:00.000+00:00 2024-03-01T12:00:00.000+00:00 
+2  reintegrate pool2                                2     2        queued     2024-03-01T12:01:00.000+00:00 -                             
+3  extend      00000003-0003-0003-0003-000000000003 4-5   0        queued     2024-03-01T12:02:00.000+00:00 -                             
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintPoolOpJobs(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
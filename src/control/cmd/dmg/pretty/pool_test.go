@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -20,6 +21,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 func TestPretty_PrintPoolQueryTargetResp(t *testing.T) {
@@ -914,3 +916,143 @@ one   00000001-0001-0001-0001-000000000001 Degraded [0-2]   100 GB   80 GB    8%
 		})
 	}
 }
+
+func TestPretty_PrintPoolUsageHistory(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	full := ts.Add(9 * 24 * time.Hour)
+
+	for name, tc := range map[string]struct {
+		resp        *control.PoolUsageHistoryResp
+		expErr      error
+		expPrintStr string
+	}{
+		"nil response": {
+			expErr: errors.New("nil"),
+		},
+		"no samples": {
+			resp:        &control.PoolUsageHistoryResp{PoolUUID: test.MockPoolUUID(1)},
+			expPrintStr: "No usage history recorded\n",
+		},
+		"two samples": {
+			resp: &control.PoolUsageHistoryResp{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "tank",
+				Samples: []*control.PoolUsageSample{
+					{
+						Timestamp: ts,
+						Tiers: []*system.PoolTierUsage{
+							{MediaType: "scm", TotalBytes: 1 * humanize.GiByte, FreeBytes: 900 * humanize.MiByte},
+							{MediaType: "nvme", TotalBytes: 10 * humanize.GiByte, FreeBytes: 9 * humanize.GiByte},
+						},
+					},
+					{
+						Timestamp: ts.Add(2 * 24 * time.Hour),
+						Tiers: []*system.PoolTierUsage{
+							{MediaType: "scm", TotalBytes: 1 * humanize.GiByte, FreeBytes: 700 * humanize.MiByte},
+							{MediaType: "nvme", TotalBytes: 10 * humanize.GiByte, FreeBytes: 10 * humanize.GiByte},
+						},
+					},
+				},
+				Trends: []*control.PoolTierTrend{
+					{
+						MediaType:     "scm",
+						TotalBytes:    1 * humanize.GiByte,
+						UsedBytes:     324 * humanize.MiByte,
+						GrowthPerDay:  100 * humanize.MiByte,
+						ProjectedFull: &full,
+					},
+					{
+						MediaType:    "nvme",
+						TotalBytes:   10 * humanize.GiByte,
+						GrowthPerDay: -512 * humanize.MiByte,
+					},
+				},
+			},
+			expPrintStr: `
+Pool tank (00000001-0001-0001-0001-000000000001) usage history: 2 samples from 2024-03-01T12:00:00.000+00:00 to 2024-03-03T12:00:00.000+00:00
+
+Tier Used    Total   Growth       Projected Full                
+---- ----    -----   ------       --------------                
+SCM  324 MiB 1.0 GiB 100 MiB/day  2024-03-10T12:00:00.000+00:00 
+NVME 0 B     10 GiB  -512 MiB/day N/A                           
+
+Timestamp                     SCM Used NVME Used 
+---------                     -------- --------- 
+2024-03-01T12:00:00.000+00:00 124 MiB  1.0 GiB   
+2024-03-03T12:00:00.000+00:00 324 MiB  0 B       
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			gotErr := PrintPoolUsageHistory(tc.resp, &bld)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintPoolActivity(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.PoolActivityResp
+		expErr      error
+		expPrintStr string
+	}{
+		"nil response": {
+			expErr: errors.New("nil"),
+		},
+		"no records": {
+			resp: &control.PoolActivityResp{PoolUUID: test.MockPoolUUID(1)},
+			expPrintStr: `
+No activity recorded for pool 00000001-0001-0001-0001-000000000001
+`,
+		},
+		"two records": {
+			resp: &control.PoolActivityResp{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "tank",
+				Records: []*system.PoolActivity{
+					{
+						Timestamp: ts,
+						Kind:      system.PoolActivityCreate,
+						Msg:       `created with label "tank" on ranks 0-3`,
+					},
+					{
+						Timestamp: ts.Add(time.Hour),
+						Kind:      system.PoolActivityExclude,
+						Msg:       "excluded rank 2 (all targets)",
+					},
+				},
+			},
+			expPrintStr: `
+Pool tank (00000001-0001-0001-0001-000000000001) activity: 2 records
+
+Timestamp                     Activity Details                                
+---------                     -------- -------                                
+2024-03-01T12:00:00.000+00:00 create   created with label "tank" on ranks 0-3 
+2024-03-01T13:00:00.000+00:00 exclude  excluded rank 2 (all targets)          
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			gotErr := PrintPoolActivity(tc.resp, &bld)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+const (
+	progressBarWidth = 30
+	// progressLineInterval limits how often a plain progress line is printed
+	// when an update does not change the amount of work completed.
+	progressLineInterval = 10 * time.Second
+)
+
+// ProgressPrinter renders the progress updates of a long-running operation.
+// When drawing to a terminal, a single progress bar line is redrawn in place.
+// Otherwise, a plain line is printed for each update that completes more work.
+type ProgressPrinter struct {
+	sync.Mutex
+	out       io.Writer
+	label     string
+	bar       bool
+	lineLen   int // length of the open progress bar line, if any
+	printed   bool
+	completed uint64
+	lastLine  time.Time
+	now       func() time.Time
+}
+
+// NewProgressPrinter returns a ProgressPrinter that writes updates for the
+// labelled operation to the writer, as a progress bar if bar is true.
+func NewProgressPrinter(out io.Writer, label string, bar bool) *ProgressPrinter {
+	return &ProgressPrinter{
+		out:   out,
+		label: label,
+		bar:   bar,
+		now:   time.Now,
+	}
+}
+
+func fmtProgressDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+func fmtProgressBar(completed, total uint64) string {
+	filled := progressBarWidth
+	if completed < total {
+		filled = int(completed * progressBarWidth / total)
+	}
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	return "[" + bar + "]"
+}
+
+func fmtProgress(pu *control.ProgressUpdate, withBar bool) string {
+	var parts []string
+
+	unit := ""
+	if pu.Unit != "" {
+		unit = " " + pu.Unit
+	}
+
+	switch {
+	case pu.Total > 0:
+		count := fmt.Sprintf("%d/%d%s (%d%%)", pu.Completed, pu.Total, unit,
+			pu.Completed*100/pu.Total)
+		if withBar {
+			count = fmtProgressBar(pu.Completed, pu.Total) + " " + count
+		}
+		parts = append(parts, count)
+	case pu.Completed > 0:
+		parts = append(parts, fmt.Sprintf("%d%s", pu.Completed, unit))
+	}
+
+	parts = append(parts, "elapsed "+fmtProgressDuration(pu.Elapsed))
+	if pu.Remaining > 0 && !pu.Done {
+		parts = append(parts, "ETA "+fmtProgressDuration(pu.Remaining))
+	}
+
+	out := strings.Join(parts, ", ")
+	if pu.Message != "" {
+		out += " - " + pu.Message
+	}
+	return out
+}
+
+// Update renders a progress update.
+func (pp *ProgressPrinter) Update(pu *control.ProgressUpdate) {
+	if pp == nil || pu == nil {
+		return
+	}
+
+	pp.Lock()
+	defer pp.Unlock()
+
+	line := pp.label + ": " + fmtProgress(pu, pp.bar)
+
+	if pp.bar {
+		// Pad with spaces to overwrite any longer line drawn previously.
+		pad := ""
+		if len(line) < pp.lineLen {
+			pad = strings.Repeat(" ", pp.lineLen-len(line))
+		}
+		fmt.Fprintf(pp.out, "\r%s%s", line, pad)
+		pp.lineLen = len(line)
+		if pu.Done {
+			fmt.Fprintln(pp.out)
+			pp.lineLen = 0
+		}
+		return
+	}
+
+	now := pp.now()
+	if pp.printed && !pu.Done && pu.Completed == pp.completed && now.Sub(pp.lastLine) < progressLineInterval {
+		return
+	}
+	fmt.Fprintln(pp.out, line)
+	pp.printed = true
+	pp.completed = pu.Completed
+	pp.lastLine = now
+}
+
+// Finish ends any progress bar line that was left open by an operation that
+// did not complete.
+func (pp *ProgressPrinter) Finish() {
+	if pp == nil {
+		return
+	}
+
+	pp.Lock()
+	defer pp.Unlock()
+
+	if pp.lineLen > 0 {
+		fmt.Fprintln(pp.out)
+		pp.lineLen = 0
+	}
+}
@@ -0,0 +1,84 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_ProgressPrinter(t *testing.T) {
+	updates := []*control.ProgressUpdate{
+		{Unit: "hosts", Completed: 1, Total: 4, Elapsed: time.Second, Remaining: 3 * time.Second, Message: "host1"},
+		{Unit: "hosts", Completed: 1, Total: 4, Elapsed: 2 * time.Second, Remaining: 6 * time.Second},
+		{Unit: "hosts", Completed: 2, Total: 4, Elapsed: 3 * time.Second, Remaining: 3 * time.Second},
+		{Unit: "hosts", Completed: 4, Total: 4, Elapsed: 4 * time.Second, Done: true},
+	}
+
+	for name, tc := range map[string]struct {
+		bar       bool
+		updates   []*control.ProgressUpdate
+		finish    bool
+		expOutput string
+	}{
+		"plain lines; unchanged progress skipped": {
+			updates: updates,
+			expOutput: `
+format: 1/4 hosts (25%), elapsed 1s, ETA 3s - host1
+format: 2/4 hosts (50%), elapsed 3s, ETA 3s
+format: 4/4 hosts (100%), elapsed 4s
+`,
+		},
+		"unknown total": {
+			updates: []*control.ProgressUpdate{
+				{Elapsed: time.Second, Message: "creating pool"},
+				{Elapsed: 2 * time.Second, Done: true, Message: "pool created"},
+			},
+			expOutput: `
+format: elapsed 1s - creating pool
+format: elapsed 2s - pool created
+`,
+		},
+		"progress bar": {
+			bar:     true,
+			updates: []*control.ProgressUpdate{updates[0], updates[3]},
+			expOutput: "\n" +
+				"\rformat: [=======>                      ] 1/4 hosts (25%), elapsed 1s, ETA 3s - host1" +
+				"\rformat: [==============================] 4/4 hosts (100%), elapsed 4s               \n",
+		},
+		"progress bar left open": {
+			bar:     true,
+			updates: updates[:1],
+			finish:  true,
+			expOutput: "\n" +
+				"\rformat: [=======>                      ] 1/4 hosts (25%), elapsed 1s, ETA 3s - host1\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			pp := NewProgressPrinter(&bld, "format", tc.bar)
+			now := time.Now()
+			pp.now = func() time.Time { return now }
+
+			for _, pu := range tc.updates {
+				pp.Update(pu)
+			}
+			if tc.finish {
+				pp.Finish()
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expOutput, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
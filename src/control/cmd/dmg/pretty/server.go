@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2023 Intel Corporation.
+// (C) Copyright 2023-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,9 +7,17 @@
 package pretty
 
 import (
+	"fmt"
 	"io"
+	"sort"
+	"strings"
 
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
 )
 
 // PrintSetEngineLogMasksResp generates a human-readable representation of the supplied response.
@@ -20,3 +28,232 @@ func PrintSetEngineLogMasksResp(resp *control.SetEngineLogMasksResp, out, outErr
 
 	return PrintHostStorageSuccesses("Engine log-masks updated", resp.HostStorage, out)
 }
+
+func formatSubsystemLevels(levels map[string]string) string {
+	if len(levels) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	strs := make([]string, len(names))
+	for i, name := range names {
+		strs[i] = fmt.Sprintf("%s=%s", name, levels[name])
+	}
+	return strings.Join(strs, ",")
+}
+
+// printHostGroups prints a table with a row for each distinct set of values in hostValues,
+// listing all hosts reporting those values in the first column.
+func printHostGroups(out io.Writer, titles []string, hostValues map[string][]string) error {
+	groups := make(map[string]*hostlist.HostSet)
+	values := make(map[string][]string)
+	for addr, vals := range hostValues {
+		key := strings.Join(vals, "\x00")
+		if _, found := groups[key]; !found {
+			hs, err := hostlist.CreateSet("")
+			if err != nil {
+				return err
+			}
+			groups[key] = hs
+			values[key] = vals
+		}
+		if _, err := groups[key].Insert(addr); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	formatter := txtfmt.NewTableFormatter(titles...)
+	var table []txtfmt.TableRow
+	for _, key := range keys {
+		row := txtfmt.TableRow{titles[0]: groups[key].String()}
+		for i, val := range values[key] {
+			row[titles[i+1]] = val
+		}
+		table = append(table, row)
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+	return nil
+}
+
+// PrintSetControlLogLevelsResp generates a human-readable representation of the supplied
+// response, grouping hosts with the same resulting log levels.
+func PrintSetControlLogLevelsResp(resp *control.SetControlLogLevelsResp, out, outErr io.Writer) error {
+	if err := PrintResponseErrors(resp, outErr); err != nil {
+		return err
+	}
+	if len(resp.HostLevels) == 0 {
+		return nil
+	}
+
+	hostValues := make(map[string][]string)
+	for addr, levels := range resp.HostLevels {
+		hostValues[addr] = []string{levels.Level, formatSubsystemLevels(levels.Subsystems)}
+	}
+
+	fmt.Fprintln(out, "Control log levels updated")
+	return printHostGroups(out, []string{"Hosts", "Level", "Subsystems"}, hostValues)
+}
+
+// PrintSetProfilingResp generates a human-readable representation of the supplied
+// response, grouping hosts with the same resulting profiling endpoint state.
+func PrintSetProfilingResp(resp *control.SetProfilingResp, out, outErr io.Writer) error {
+	if err := PrintResponseErrors(resp, outErr); err != nil {
+		return err
+	}
+	if len(resp.HostStatus) == 0 {
+		return nil
+	}
+
+	hostValues := make(map[string][]string)
+	for addr, status := range resp.HostStatus {
+		if status.Enabled {
+			hostValues[addr] = []string{"enabled", status.Address}
+			continue
+		}
+		hostValues[addr] = []string{"disabled", "-"}
+	}
+
+	return printHostGroups(out, []string{"Hosts", "Profiling", "Address"}, hostValues)
+}
+
+func formatOptionalIndex(idx *uint32) string {
+	if idx == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *idx)
+}
+
+func formatMiB(sizeMiB uint32) string {
+	if sizeMiB == 0 {
+		return "-"
+	}
+	return humanize.IBytes(uint64(sizeMiB) * humanize.MiByte)
+}
+
+// PrintGetEngineTunablesResp generates a human-readable representation of the
+// supplied response. If verbose is set, the command line arguments and
+// environment of each engine are also displayed.
+func PrintGetEngineTunablesResp(resp *control.GetEngineTunablesResp, out, outErr io.Writer, verbose bool) error {
+	if err := PrintResponseErrors(resp, outErr); err != nil {
+		return err
+	}
+	if len(resp.Engines) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	idxTitle := "Engine"
+	rankTitle := "Rank"
+	stateTitle := "State"
+	tgtTitle := "Targets"
+	helperTitle := "Helpers"
+	memTitle := "Mem Size"
+	hpTitle := "Hugepage Size"
+	numaTitle := "NUMA"
+	coreTitle := "First Core"
+	stackTitle := "ULT Stack"
+	deepTitle := "Deep ULT Stack"
+	mmapTitle := "Mmap Stacks"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, idxTitle, rankTitle, stateTitle, tgtTitle,
+		helperTitle, memTitle, hpTitle, numaTitle, coreTitle, stackTitle, deepTitle, mmapTitle)
+	var table []txtfmt.TableRow
+	var failed []*control.EngineTunables
+
+	for _, et := range resp.Engines {
+		if et.Error != "" {
+			failed = append(failed, et)
+			continue
+		}
+
+		state := "stopped"
+		if et.Running {
+			state = "running"
+		}
+		table = append(table, txtfmt.TableRow{
+			hostTitle:   et.Host,
+			idxTitle:    fmt.Sprintf("%d", et.Index),
+			rankTitle:   et.Rank.String(),
+			stateTitle:  state,
+			tgtTitle:    fmt.Sprintf("%d", et.Targets),
+			helperTitle: fmt.Sprintf("%d", et.HelperStreams),
+			memTitle:    formatMiB(et.MemSizeMiB),
+			hpTitle:     formatMiB(et.HugepageSizeMiB),
+			numaTitle:   formatOptionalIndex(et.PinnedNumaNode),
+			coreTitle:   formatOptionalIndex(et.FirstCore),
+			stackTitle:  humanize.IBytes(et.ULTStackSize),
+			deepTitle:   humanize.IBytes(et.DeepULTStackSize),
+			mmapTitle:   fmt.Sprintf("%t", et.MmapULTStacks),
+		})
+	}
+
+	for _, et := range failed {
+		fmt.Fprintf(outErr, "%s engine %d: %s\n", et.Host, et.Index, et.Error)
+	}
+	if len(table) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "Engine Tunables")
+	fmt.Fprintln(out, formatter.Format(table))
+
+	if !verbose {
+		return nil
+	}
+
+	for _, et := range resp.Engines {
+		if et.Error != "" {
+			continue
+		}
+		fmt.Fprintf(out, "%s engine %d:\n", et.Host, et.Index)
+		args := "-"
+		if len(et.Args) > 0 {
+			args = strings.Join(et.Args, " ")
+		}
+		fmt.Fprintf(out, "  Arguments: %s\n", args)
+		fmt.Fprintln(out, "  Environment:")
+		for _, ev := range et.EnvVars {
+			fmt.Fprintf(out, "    %s\n", ev)
+		}
+	}
+
+	return nil
+}
+
+// PrintServerConfigApplyResp generates a human-readable representation of the
+// supplied response.
+func PrintServerConfigApplyResp(resp *control.ServerConfigApplyResp, commit bool, out, outErr io.Writer) error {
+	if err := PrintResponseErrors(resp, outErr); err != nil {
+		return err
+	}
+
+	if resp.Validated != nil && resp.Validated.Count() > 0 {
+		fmt.Fprintf(out, "Candidate config is valid on the following %s: %s\n",
+			common.Pluralise("host", resp.Validated.Count()), resp.Validated)
+	}
+	if !commit {
+		return nil
+	}
+
+	if resp.Committed != nil && resp.Committed.Count() > 0 {
+		fmt.Fprintf(out, "Config file replaced on the following %s: %s\n",
+			common.Pluralise("host", resp.Committed.Count()), resp.Committed)
+		fmt.Fprintln(out, "Restart the servers for the new config to take effect")
+		return nil
+	}
+	fmt.Fprintln(out, "Config file not replaced on any host")
+
+	return nil
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2023 Intel Corporation.
+// (C) Copyright 2023-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -130,3 +130,315 @@ Errors:
 		})
 	}
 }
+
+func TestPretty_PrintSetControlLogLevelsResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp      *control.SetControlLogLevelsResp
+		expStdout string
+		expStderr string
+	}{
+		"empty response": {
+			resp: new(control.SetControlLogLevelsResp),
+		},
+		"one pass; one fail": {
+			resp: &control.SetControlLogLevelsResp{
+				HostErrorsResp: control.MockHostErrorsResp(t,
+					&control.MockHostError{
+						Hosts: "host1",
+						Error: "failed",
+					}),
+				HostLevels: map[string]*control.ControlLogLevels{
+					"host2": {
+						Level: "INFO",
+					},
+				},
+			},
+			expStdout: `
+Control log levels updated
+Hosts Level Subsystems 
+----- ----- ---------- 
+host2 INFO  -          
+
+`,
+			expStderr: `
+Errors:
+  Hosts Error  
+  ----- -----  
+  host1 failed 
+
+`,
+		},
+		"hosts grouped by levels": {
+			resp: &control.SetControlLogLevelsResp{
+				HostLevels: map[string]*control.ControlLogLevels{
+					"host1": {
+						Level:      "INFO",
+						Subsystems: map[string]string{"raft": "DEBUG", "drpc": "ERROR"},
+					},
+					"host2": {
+						Level:      "INFO",
+						Subsystems: map[string]string{"drpc": "ERROR", "raft": "DEBUG"},
+					},
+					"host3": {
+						Level: "NOTICE",
+					},
+				},
+			},
+			expStdout: `
+Control log levels updated
+Hosts     Level  Subsystems            
+-----     -----  ----------            
+host[1-2] INFO   drpc=ERROR,raft=DEBUG 
+host3     NOTICE -                     
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+
+			if err := PrintSetControlLogLevelsResp(tc.resp, &out, &outErr); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStdout, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStderr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSetProfilingResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp      *control.SetProfilingResp
+		expStdout string
+		expStderr string
+	}{
+		"empty response": {
+			resp: new(control.SetProfilingResp),
+		},
+		"one pass; one fail": {
+			resp: &control.SetProfilingResp{
+				HostErrorsResp: control.MockHostErrorsResp(t,
+					&control.MockHostError{
+						Hosts: "host1",
+						Error: "failed",
+					}),
+				HostStatus: map[string]*control.ProfilingStatus{
+					"host2": {},
+				},
+			},
+			expStdout: `
+Hosts Profiling Address 
+----- --------- ------- 
+host2 disabled  -       
+
+`,
+			expStderr: `
+Errors:
+  Hosts Error  
+  ----- -----  
+  host1 failed 
+
+`,
+		},
+		"hosts grouped by state": {
+			resp: &control.SetProfilingResp{
+				HostStatus: map[string]*control.ProfilingStatus{
+					"host1": {
+						Enabled: true,
+						Address: "127.0.0.1:6060",
+					},
+					"host2": {
+						Enabled: true,
+						Address: "127.0.0.1:6060",
+					},
+					"host3": {},
+				},
+			},
+			expStdout: `
+Hosts     Profiling Address        
+-----     --------- -------        
+host3     disabled  -              
+host[1-2] enabled   127.0.0.1:6060 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+
+			if err := PrintSetProfilingResp(tc.resp, &out, &outErr); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStdout, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStderr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintGetEngineTunablesResp(t *testing.T) {
+	numaNode := uint32(1)
+	firstCore := uint32(0)
+
+	for name, tc := range map[string]struct {
+		resp      *control.GetEngineTunablesResp
+		verbose   bool
+		expStdout string
+		expStderr string
+	}{
+		"empty response": {
+			resp: &control.GetEngineTunablesResp{},
+		},
+		"engine error": {
+			resp: &control.GetEngineTunablesResp{
+				Engines: []*control.EngineTunables{
+					{
+						Host:  "host1",
+						Index: 1,
+						Error: "bad config",
+					},
+				},
+			},
+			expStderr: `
+host1 engine 1: bad config
+`,
+		},
+		"verbose": {
+			resp: &control.GetEngineTunablesResp{
+				Engines: []*control.EngineTunables{
+					{
+						Host:             "host1",
+						Rank:             2,
+						Running:          true,
+						Args:             []string{"-t", "8"},
+						EnvVars:          []string{"ABT_THREAD_STACKSIZE=16384", "CRT_TIMEOUT=30"},
+						ULTStackSize:     16384,
+						DeepULTStackSize: 65536,
+						Targets:          8,
+						HelperStreams:    2,
+						MemSizeMiB:       8192,
+						HugepageSizeMiB:  2,
+						PinnedNumaNode:   &numaNode,
+					},
+					{
+						Host:             "host1",
+						Index:            1,
+						Rank:             3,
+						EnvVars:          []string{"DAOS_ULT_MMAP_STACK=1"},
+						ULTStackSize:     16384,
+						DeepULTStackSize: 65536,
+						MmapULTStacks:    true,
+						Targets:          16,
+						FirstCore:        &firstCore,
+					},
+				},
+			},
+			verbose: true,
+			expStdout: `
+Engine Tunables
+Host  Engine Rank State   Targets Helpers Mem Size Hugepage Size NUMA First Core ULT Stack Deep ULT Stack Mmap Stacks 
+----  ------ ---- -----   ------- ------- -------- ------------- ---- ---------- --------- -------------- ----------- 
+host1 0      2    running 8       2       8.0 GiB  2.0 MiB       1    -          16 KiB    64 KiB         false       
+host1 1      3    stopped 16      0       -        -             -    0          16 KiB    64 KiB         true        
+
+host1 engine 0:
+  Arguments: -t 8
+  Environment:
+    ABT_THREAD_STACKSIZE=16384
+    CRT_TIMEOUT=30
+host1 engine 1:
+  Arguments: -
+  Environment:
+    DAOS_ULT_MMAP_STACK=1
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+
+			if err := PrintGetEngineTunablesResp(tc.resp, &out, &outErr, tc.verbose); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStdout, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStderr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintServerConfigApplyResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp      *control.ServerConfigApplyResp
+		commit    bool
+		expStdout string
+		expStderr string
+	}{
+		"validated": {
+			resp: &control.ServerConfigApplyResp{
+				Validated: control.MockHostSet(t, "host[1-2]"),
+			},
+			expStdout: `
+Candidate config is valid on the following hosts: host[1-2]
+`,
+		},
+		"invalid on one host": {
+			resp: &control.ServerConfigApplyResp{
+				HostErrorsResp: control.MockHostErrorsResp(t,
+					&control.MockHostError{Hosts: "host2", Error: "interface not found"}),
+				Validated: control.MockHostSet(t, "host1"),
+			},
+			commit: true,
+			expStdout: `
+Candidate config is valid on the following host: host1
+Config file not replaced on any host
+`,
+			expStderr: `
+Errors:
+  Hosts Error               
+  ----- -----               
+  host2 interface not found 
+
+`,
+		},
+		"committed": {
+			resp: &control.ServerConfigApplyResp{
+				Validated: control.MockHostSet(t, "host[1-2]"),
+				Committed: control.MockHostSet(t, "host[1-2]"),
+			},
+			commit: true,
+			expStdout: `
+Candidate config is valid on the following hosts: host[1-2]
+Config file replaced on the following hosts: host[1-2]
+Restart the servers for the new config to take effect
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+
+			if err := PrintServerConfigApplyResp(tc.resp, tc.commit, &out, &outErr); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStdout, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStderr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected stderr (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -221,6 +221,23 @@ func printSmdDevice(dev *storage.SmdDevice, iw io.Writer, opts ...PrintConfigOpt
 	return nil
 }
 
+func printBdevIOStats(stats *storage.BdevIOStats, out io.Writer) error {
+	w := txtfmt.NewErrWriter(out)
+
+	fmt.Fprintf(w, "I/O Stats (%s):\n", stats.Period())
+
+	iw := txtfmt.NewIndentWriter(w)
+	fmt.Fprintf(iw, "Read: %.0f IOPS %s/s Latency p50/p99/p99.9: %d/%d/%dus\n",
+		stats.ReadIOPS, humanize.IBytes(uint64(stats.ReadBW)),
+		stats.ReadLatP50, stats.ReadLatP99, stats.ReadLatP999)
+	fmt.Fprintf(iw, "Write: %.0f IOPS %s/s Latency p50/p99/p99.9: %d/%d/%dus\n",
+		stats.WriteIOPS, humanize.IBytes(uint64(stats.WriteBW)),
+		stats.WriteLatP50, stats.WriteLatP99, stats.WriteLatP999)
+	fmt.Fprintf(iw, "Queue Depth: %d\n", stats.QueueDepth)
+
+	return w.Err
+}
+
 func printSmdPool(pool *control.SmdPool, out io.Writer, opts ...PrintConfigOption) error {
 	ew := txtfmt.NewErrWriter(out)
 	fmt.Fprintf(ew, "Rank:%d Targets:%+v", pool.Rank, pool.TargetIDs)
@@ -258,6 +275,12 @@ func PrintSmdInfoMap(omitDevs, omitPools bool, hsm control.HostStorageMap, out i
 					if err := printSmdDevice(device, iw1, opts...); err != nil {
 						return err
 					}
+					if device.IOStats != nil {
+						if err := printBdevIOStats(device.IOStats,
+							txtfmt.NewIndentWriter(iw1)); err != nil {
+							return err
+						}
+					}
 					if device.Ctrlr.HealthStats == nil {
 						continue
 					}
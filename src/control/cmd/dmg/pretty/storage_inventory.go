@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+func inventoryFieldValue(dev *control.StorageInventoryDevice, field string) string {
+	switch field {
+	case "model":
+		return dev.Model
+	case "firmware":
+		return dev.Firmware
+	case "serial":
+		return dev.Serial
+	case "capacity":
+		return humanize.IBytes(dev.Capacity)
+	case "numa_node":
+		return fmt.Sprintf("%d", dev.NumaNode)
+	default:
+		return "?"
+	}
+}
+
+func formatInventoryDiff(diff *control.StorageInventoryDiff) string {
+	switch diff.Change {
+	case control.StorageInventoryAdded:
+		return fmt.Sprintf("serial %s", diff.Current.Serial)
+	case control.StorageInventoryRemoved:
+		return fmt.Sprintf("serial %s", diff.Previous.Serial)
+	}
+
+	details := make([]string, 0, len(diff.Fields))
+	for _, field := range diff.Fields {
+		details = append(details, fmt.Sprintf("%s: %s -> %s", field,
+			inventoryFieldValue(diff.Previous, field),
+			inventoryFieldValue(diff.Current, field)))
+	}
+	return strings.Join(details, ", ")
+}
+
+func printStorageInventoryChanges(changes []*control.StorageInventoryDiff, out io.Writer, opts ...PrintConfigOption) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No changes since previous inventory")
+		return
+	}
+
+	hostTitle := "Host"
+	classTitle := "Class"
+	locTitle := "Location"
+	changeTitle := "Change"
+	detailTitle := "Details"
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, classTitle, locTitle, changeTitle, detailTitle)
+	var table []txtfmt.TableRow
+
+	for _, diff := range changes {
+		table = append(table, txtfmt.TableRow{
+			hostTitle:   getPrintHosts(diff.Host, opts...),
+			classTitle:  diff.Class,
+			locTitle:    diff.Location,
+			changeTitle: string(diff.Change),
+			detailTitle: formatInventoryDiff(diff),
+		})
+	}
+
+	fmt.Fprintln(out, "Changes since previous inventory")
+	fmt.Fprintln(out, formatter.Format(table))
+}
+
+// PrintStorageInventoryResp generates a human-readable representation of the
+// supplied response. If compared is set, the changes against a previous
+// inventory are also displayed.
+func PrintStorageInventoryResp(resp *control.StorageInventoryResp, compared bool, out, outErr io.Writer, opts ...PrintConfigOption) error {
+	if err := PrintResponseErrors(resp, outErr, opts...); err != nil {
+		return err
+	}
+
+	if len(resp.Devices) > 0 {
+		hostTitle := "Host"
+		classTitle := "Class"
+		locTitle := "Location"
+		modelTitle := "Model"
+		fwTitle := "Firmware"
+		serialTitle := "Serial"
+		capTitle := "Capacity"
+		numaTitle := "NUMA"
+
+		formatter := txtfmt.NewTableFormatter(hostTitle, classTitle, locTitle, modelTitle,
+			fwTitle, serialTitle, capTitle, numaTitle)
+		var table []txtfmt.TableRow
+
+		for _, dev := range resp.Devices {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   getPrintHosts(dev.Host, opts...),
+				classTitle:  dev.Class,
+				locTitle:    dev.Location,
+				modelTitle:  dev.Model,
+				fwTitle:     dev.Firmware,
+				serialTitle: dev.Serial,
+				capTitle:    humanize.IBytes(dev.Capacity),
+				numaTitle:   fmt.Sprintf("%d", dev.NumaNode),
+			})
+		}
+
+		fmt.Fprintln(out, formatter.Format(table))
+	}
+
+	if compared {
+		printStorageInventoryChanges(resp.Changes, out, opts...)
+	}
+
+	return nil
+}
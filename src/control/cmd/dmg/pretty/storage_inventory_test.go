@@ -0,0 +1,153 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintStorageInventoryResp(t *testing.T) {
+	nvme := func(host, serial string) *control.StorageInventoryDevice {
+		return &control.StorageInventoryDevice{
+			Host:     host,
+			Class:    control.StorageClassNvme,
+			Location: "0000:80:00.0",
+			Model:    "model",
+			Firmware: "fw1",
+			Serial:   serial,
+			Capacity: 2 * humanize.TiByte,
+			NumaNode: 1,
+		}
+	}
+	scm := &control.StorageInventoryDevice{
+		Host:     "host2:10001",
+		Class:    control.StorageClassScm,
+		Location: "0/0/0/0",
+		Model:    "part1",
+		Firmware: "fw2",
+		Serial:   "uid1",
+		Capacity: 128 * humanize.GiByte,
+	}
+	swapped := nvme("host1:10001", "serialX")
+	swapped.Firmware = "fw2"
+
+	for name, tc := range map[string]struct {
+		resp      *control.StorageInventoryResp
+		compared  bool
+		expStdout string
+		expStderr string
+	}{
+		"host error": {
+			resp: &control.StorageInventoryResp{
+				HostErrorsResp: control.MockHostErrorsResp(t, &control.MockHostError{
+					Hosts: "host1", Error: "failed",
+				}),
+			},
+			expStderr: `
+Errors:
+  Hosts Error  
+  ----- -----  
+  host1 failed 
+
+`,
+		},
+		"devices": {
+			resp: &control.StorageInventoryResp{
+				Devices: []*control.StorageInventoryDevice{
+					nvme("host1:10001", "serial1"), nvme("host2:10001", "serial2"), scm,
+				},
+			},
+			expStdout: `
+Host  Class Location     Model Firmware Serial  Capacity NUMA 
+----  ----- --------     ----- -------- ------  -------- ---- 
+host1 nvme  0000:80:00.0 model fw1      serial1 2.0 TiB  1    
+host2 nvme  0000:80:00.0 model fw1      serial2 2.0 TiB  1    
+host2 scm   0/0/0/0      part1 fw2      uid1    128 GiB  0    
+
+`,
+		},
+		"compared; no changes": {
+			resp: &control.StorageInventoryResp{
+				Devices: []*control.StorageInventoryDevice{scm},
+			},
+			compared: true,
+			expStdout: `
+Host  Class Location Model Firmware Serial Capacity NUMA 
+----  ----- -------- ----- -------- ------ -------- ---- 
+host2 scm   0/0/0/0  part1 fw2      uid1   128 GiB  0    
+
+No changes since previous inventory
+`,
+		},
+		"compared; changes": {
+			resp: &control.StorageInventoryResp{
+				Devices: []*control.StorageInventoryDevice{swapped, scm},
+				Changes: []*control.StorageInventoryDiff{
+					{
+						Host:     "host1:10001",
+						Class:    control.StorageClassNvme,
+						Location: "0000:80:00.0",
+						Change:   control.StorageInventoryModified,
+						Fields:   []string{"firmware", "serial"},
+						Previous: nvme("host1:10001", "serial1"),
+						Current:  swapped,
+					},
+					{
+						Host:     "host2:10001",
+						Class:    control.StorageClassNvme,
+						Location: "0000:80:00.0",
+						Change:   control.StorageInventoryRemoved,
+						Previous: nvme("host2:10001", "serial2"),
+					},
+					{
+						Host:     "host2:10001",
+						Class:    control.StorageClassScm,
+						Location: "0/0/0/0",
+						Change:   control.StorageInventoryAdded,
+						Current:  scm,
+					},
+				},
+			},
+			compared: true,
+			expStdout: `
+Host  Class Location     Model Firmware Serial  Capacity NUMA 
+----  ----- --------     ----- -------- ------  -------- ---- 
+host1 nvme  0000:80:00.0 model fw2      serialX 2.0 TiB  1    
+host2 scm   0/0/0/0      part1 fw2      uid1    128 GiB  0    
+
+Changes since previous inventory
+Host  Class Location     Change   Details                                          
+----  ----- --------     ------   -------                                          
+host1 nvme  0000:80:00.0 modified firmware: fw1 -> fw2, serial: serial1 -> serialX 
+host2 nvme  0000:80:00.0 removed  serial serial2                                   
+host2 scm   0/0/0/0      added    serial uid1                                      
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+
+			if err := PrintStorageInventoryResp(tc.resp, tc.compared, &out, &outErr); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStdout, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStderr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
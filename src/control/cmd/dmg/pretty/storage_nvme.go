@@ -25,8 +25,14 @@ import (
 func printNvmeControllerSummary(nvme *storage.NvmeController, out io.Writer, opts ...PrintConfigOption) error {
 	w := txtfmt.NewErrWriter(out)
 
-	if _, err := fmt.Fprintf(out, "PCI:%s Model:%s FW:%s Socket:%d Capacity:%s\n",
-		nvme.PciAddr, nvme.Model, nvme.FwRev, nvme.SocketID, humanize.Bytes(nvme.Capacity())); err != nil {
+	var nandType string
+	if nt := nvme.NandType(); nt != storage.NandTypeUnknown {
+		nandType = " NAND:" + nt.String()
+	}
+
+	if _, err := fmt.Fprintf(out, "PCI:%s Model:%s FW:%s Socket:%d Capacity:%s%s\n",
+		nvme.PciAddr, nvme.Model, nvme.FwRev, nvme.SocketID, humanize.Bytes(nvme.Capacity()),
+		nandType); err != nil {
 		return err
 	}
 
@@ -155,6 +161,9 @@ func printNvmeHealth(stat *storage.NvmeHealth, out io.Writer, opts ...PrintConfi
 			uint64(stat.NandBytesWritten))
 		fmt.Fprintf(iw, "Host Bytes Written:%d\n",
 			uint64(stat.HostBytesWritten))
+		if stat.HostBytesWritten > 0 {
+			fmt.Fprintf(iw, "Write Amplification:%.2f\n", stat.WriteAmplification())
+		}
 	}
 
 	return w.Err
@@ -195,6 +195,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
     PLL Lock Loss Count:%d
     NAND Bytes Written:%d
     Host Bytes Written:%d
+    Write Amplification:%.2f
 
 PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
   Health Stats:
@@ -236,6 +237,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
     PLL Lock Loss Count:%d
     NAND Bytes Written:%d
     Host Bytes Written:%d
+    Write Amplification:%.2f
 
 `,
 				controllerA.PciAddr, controllerA.Model, controllerA.FwRev,
@@ -257,6 +259,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
 				controllerA.HealthStats.RetryBufferOverflowCnt,
 				controllerA.HealthStats.PllLockLossCnt,
 				controllerA.HealthStats.NandBytesWritten, controllerA.HealthStats.HostBytesWritten,
+				controllerA.HealthStats.WriteAmplification(),
 
 				controllerB.PciAddr, controllerB.Model, controllerB.FwRev, controllerB.SocketID,
 				humanize.Bytes(controllerB.Capacity()),
@@ -277,6 +280,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
 				controllerB.HealthStats.RetryBufferOverflowCnt,
 				controllerB.HealthStats.PllLockLossCnt,
 				controllerB.HealthStats.NandBytesWritten, controllerB.HealthStats.HostBytesWritten,
+				controllerB.HealthStats.WriteAmplification(),
 			),
 		},
 		"1 host; 1 device, fetched over drpc": {
@@ -339,6 +343,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
     PLL Lock Loss Count:%d
     NAND Bytes Written:%d
     Host Bytes Written:%d
+    Write Amplification:%.2f
 
 `,
 				controllerAwTS.PciAddr, controllerAwTS.Model, controllerAwTS.FwRev,
@@ -362,6 +367,7 @@ PCI:%s Model:%s FW:%s Socket:%d Capacity:%s
 				controllerAwTS.HealthStats.RetryBufferOverflowCnt,
 				controllerAwTS.HealthStats.PllLockLossCnt,
 				controllerAwTS.HealthStats.NandBytesWritten, controllerAwTS.HealthStats.HostBytesWritten,
+				controllerAwTS.HealthStats.WriteAmplification(),
 			),
 		},
 	} {
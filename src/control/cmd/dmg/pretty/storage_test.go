@@ -1566,6 +1566,61 @@ host1
       Roles:wal SysXS Targets:[0 1 2] Rank:1 State:UNKNOWN LED:NA
     UUID:00000003-0003-0003-0003-000000000003 [TrAddr:0000:db:00.0]
       Roles:data,meta Targets:[3 4 5] Rank:1 State:NORMAL LED:QUICK_BLINK
+`,
+		},
+		"device-stats": {
+			noPools: true,
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{
+					"host1",
+					&control.HostStorage{
+						SmdInfo: &control.SmdInfo{
+							Devices: []*storage.SmdDevice{
+								{
+									UUID:             test.MockUUID(0),
+									TargetIDs:        []int32{0, 1, 2},
+									Roles:            storage.BdevRoles{storage.BdevRoleWAL},
+									Ctrlr:            identCtrlr,
+									CtrlrNamespaceID: 1,
+									IOStats: &storage.BdevIOStats{
+										PeriodMs:     1000,
+										ReadIOPS:     1500,
+										WriteIOPS:    250.4,
+										ReadBW:       6 << 20,
+										WriteBW:      1 << 20,
+										QueueDepth:   4,
+										ReadLatP50:   80,
+										ReadLatP99:   210,
+										ReadLatP999:  950,
+										WriteLatP50:  20,
+										WriteLatP99:  55,
+										WriteLatP999: 310,
+									},
+								},
+								{
+									UUID:      test.MockUUID(1),
+									TargetIDs: []int32{3, 4, 5},
+									Roles:     storage.BdevRoles{storage.BdevRoleMeta | storage.BdevRoleData},
+									Ctrlr:     newCtrlr,
+								},
+							},
+						},
+					},
+				},
+			),
+			expPrintStr: `
+-----
+host1
+-----
+  Devices
+    UUID:00000000-0000-0000-0000-000000000000 [TrAddr:0000:db:00.0 NSID:1]
+      Roles:wal Targets:[0 1 2] Rank:0 State:NORMAL LED:QUICK_BLINK
+      I/O Stats (1s):
+        Read: 1500 IOPS 6.0 MiB/s Latency p50/p99/p99.9: 80/210/950us
+        Write: 250 IOPS 1.0 MiB/s Latency p50/p99/p99.9: 20/55/310us
+        Queue Depth: 4
+    UUID:00000001-0001-0001-0001-000000000001 [TrAddr:0000:8a:00.0]
+      Roles:data,meta Targets:[3 4 5] Rank:0 State:NEW LED:OFF
 `,
 		},
 		"list-devices (none found)": {
@@ -1652,6 +1707,7 @@ host1
         PLL Lock Loss Count:%d
         NAND Bytes Written:%d
         Host Bytes Written:%d
+        Write Amplification:%.2f
 
 `,
 				mockController.HealthStats.TempK(), mockController.HealthStats.TempC(),
@@ -1671,6 +1727,7 @@ host1
 				mockController.HealthStats.RetryBufferOverflowCnt,
 				mockController.HealthStats.PllLockLossCnt,
 				mockController.HealthStats.NandBytesWritten, mockController.HealthStats.HostBytesWritten,
+				mockController.HealthStats.WriteAmplification(),
 			),
 		},
 		"identify led": {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,11 +9,15 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/dustin/go-humanize/english"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
@@ -94,10 +98,15 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 	faultDomainTitle := "Fault Domain"
 	stateTitle := "State"
 	reasonTitle := "Reason"
+	startsTitle := "Starts"
+	uptimeTitle := "Uptime"
+	lastStopTitle := "Last Stop"
 
-	formatter := txtfmt.NewTableFormatter(rankTitle, uuidTitle, addrTitle, faultDomainTitle, stateTitle, reasonTitle)
+	formatter := txtfmt.NewTableFormatter(rankTitle, uuidTitle, addrTitle, faultDomainTitle, stateTitle, reasonTitle,
+		startsTitle, uptimeTitle, lastStopTitle)
 	var table []txtfmt.TableRow
 
+	now := time.Now()
 	for _, m := range members {
 		row := txtfmt.TableRow{rankTitle: fmt.Sprintf("%d", m.Rank)}
 		row[uuidTitle] = m.UUID.String()
@@ -105,6 +114,15 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 		row[faultDomainTitle] = m.FaultDomain.String()
 		row[stateTitle] = m.State.String()
 		row[reasonTitle] = m.Info
+		row[startsTitle] = fmt.Sprintf("%d", m.Starts)
+		row[uptimeTitle] = "-"
+		if uptime := m.Uptime(now); uptime > 0 {
+			row[uptimeTitle] = uptime.Truncate(time.Second).String()
+		}
+		row[lastStopTitle] = "-"
+		if m.LastStopReason != "" {
+			row[lastStopTitle] = m.LastStopReason
+		}
 
 		table = append(table, row)
 	}
@@ -112,6 +130,48 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 	fmt.Fprintln(out, formatter.Format(table))
 }
 
+func printEngineUsage(out io.Writer, usage []*control.EngineUsage) {
+	rankTitle := "Rank"
+	hostTitle := "Host"
+	pidTitle := "PID"
+	rssTitle := "RSS"
+	hpTitle := "Hugepages"
+	fdTitle := "Open FDs"
+	cpuTitle := "CPU Time"
+
+	formatter := txtfmt.NewTableFormatter(rankTitle, hostTitle, pidTitle, rssTitle, hpTitle,
+		fdTitle, cpuTitle)
+	var table []txtfmt.TableRow
+
+	sorted := append([]*control.EngineUsage{}, usage...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Rank < sorted[j].Rank })
+
+	for _, eu := range sorted {
+		row := txtfmt.TableRow{
+			rankTitle: eu.Rank.String(),
+			hostTitle: eu.Host,
+		}
+		if eu.Error != "" {
+			row[pidTitle] = "-"
+			row[rssTitle] = "-"
+			row[hpTitle] = "-"
+			row[fdTitle] = "-"
+			row[cpuTitle] = "-"
+		} else {
+			row[pidTitle] = fmt.Sprintf("%d", eu.PID)
+			row[rssTitle] = humanize.IBytes(eu.RSSBytes)
+			row[hpTitle] = humanize.IBytes(eu.HugepageBytes)
+			row[fdTitle] = fmt.Sprintf("%d", eu.OpenFDs)
+			row[cpuTitle] = fmt.Sprintf("%.2fs", eu.TotalCPUSeconds())
+		}
+
+		table = append(table, row)
+	}
+
+	fmt.Fprintln(out, "Engine Resource Usage")
+	fmt.Fprintln(out, formatter.Format(table))
+}
+
 // PrintSystemQueryResponse generates a human-readable representation of the supplied
 // SystemQueryResp struct and writes it to the supplied io.Writer.
 func PrintSystemQueryResponse(out, outErr io.Writer, resp *control.SystemQueryResp, opts ...PrintConfigOption) error {
@@ -124,6 +184,9 @@ func PrintSystemQueryResponse(out, outErr io.Writer, resp *control.SystemQueryRe
 		fmt.Fprintln(out, "Query matches no ranks in system")
 	case getPrintConfig(opts...).Verbose:
 		printSystemQueryVerbose(out, resp.Members)
+		if len(resp.EngineUsage) > 0 {
+			printEngineUsage(out, resp.EngineUsage)
+		}
 	default:
 		if err := printSystemQuery(out, resp.Members, &resp.AbsentRanks); err != nil {
 			return err
@@ -229,3 +292,601 @@ func PrintSystemCleanupResponse(out, outErr io.Writer, resp *control.SystemClean
 	fmt.Fprintln(out, "System Cleanup Success")
 	return nil
 }
+
+// poolIsDegraded returns true if the pool summary indicates that the pool
+// was not fully healthy at the time of the snapshot.
+func poolIsDegraded(ps *system.PoolHealthSummary) bool {
+	return ps.QueryError != "" || ps.DisabledTargets > 0 ||
+		ps.State != system.PoolServiceStateReady.String()
+}
+
+// formatOtherMembers returns a compact representation of the ranks in any
+// state other than Joined, e.g. "Stopped: 2-3; Excluded: 5".
+func formatOtherMembers(hs *system.HealthSnapshot) string {
+	states := make([]string, 0, len(hs.MemberStates))
+	for state := range hs.MemberStates {
+		if state == system.MemberStateJoined.String() {
+			continue
+		}
+		states = append(states, state)
+	}
+	if len(states) == 0 {
+		return "None"
+	}
+	sort.Strings(states)
+
+	groups := make([]string, 0, len(states))
+	for _, state := range states {
+		groups = append(groups, fmt.Sprintf("%s: %s", state, hs.MemberStates[state]))
+	}
+	return strings.Join(groups, "; ")
+}
+
+func printHealthSnapshotPools(out io.Writer, hs *system.HealthSnapshot) {
+	if len(hs.Pools) == 0 {
+		fmt.Fprintln(out, "  No pools in system")
+		return
+	}
+
+	poolTitle := "Pool"
+	stateTitle := "State"
+	disabledTitle := "Disabled Targets"
+	rebuildTitle := "Rebuild State"
+	usedTitle := "Used"
+	totalTitle := "Total"
+
+	formatter := txtfmt.NewTableFormatter(poolTitle, stateTitle, disabledTitle, rebuildTitle, usedTitle, totalTitle)
+	var table []txtfmt.TableRow
+	for _, ps := range hs.Pools {
+		name := ps.PoolLabel
+		if name == "" {
+			name = ps.PoolUUID.String()
+		}
+		row := txtfmt.TableRow{poolTitle: name}
+		row[stateTitle] = ps.State
+		if ps.QueryError != "" {
+			row[stateTitle] = fmt.Sprintf("%s (%s)", ps.State, ps.QueryError)
+		}
+		row[disabledTitle] = fmt.Sprintf("%d/%d", ps.DisabledTargets, ps.TotalTargets)
+		row[rebuildTitle] = ps.RebuildState
+		row[usedTitle] = humanize.Bytes(ps.TotalBytes - ps.FreeBytes)
+		row[totalTitle] = humanize.Bytes(ps.TotalBytes)
+
+		table = append(table, row)
+	}
+
+	formatter.InitWriter(txtfmt.NewIndentWriter(out))
+	formatter.Format(table)
+}
+
+// PrintSystemHistoryResponse generates a human-readable representation of the
+// supplied SystemHistoryResp struct and writes it to the supplied io.Writer.
+func PrintSystemHistoryResponse(out io.Writer, resp *control.SystemHistoryResp, verbose bool) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Snapshots) == 0 {
+		fmt.Fprintln(out, "No system health snapshots recorded")
+		return nil
+	}
+
+	timeTitle := "Time"
+	joinedTitle := "Joined"
+	otherTitle := "Other Members"
+	poolsTitle := "Pools"
+	degradedTitle := "Degraded Pools"
+	usedTitle := "Used"
+	totalTitle := "Total"
+
+	formatter := txtfmt.NewTableFormatter(timeTitle, joinedTitle, otherTitle, poolsTitle,
+		degradedTitle, usedTitle, totalTitle)
+	var table []txtfmt.TableRow
+	for _, hs := range resp.Snapshots {
+		var degraded int
+		for _, ps := range hs.Pools {
+			if poolIsDegraded(ps) {
+				degraded++
+			}
+		}
+
+		row := txtfmt.TableRow{timeTitle: common.FormatTime(hs.Timestamp)}
+		row[joinedTitle] = fmt.Sprintf("%d", hs.MemberCount(system.MemberStateJoined))
+		row[otherTitle] = formatOtherMembers(hs)
+		row[poolsTitle] = fmt.Sprintf("%d", len(hs.Pools))
+		row[degradedTitle] = fmt.Sprintf("%d", degraded)
+		row[usedTitle] = humanize.Bytes(hs.TotalBytes() - hs.FreeBytes())
+		row[totalTitle] = humanize.Bytes(hs.TotalBytes())
+
+		table = append(table, row)
+	}
+	fmt.Fprintln(out, formatter.Format(table))
+
+	if !verbose {
+		return nil
+	}
+
+	for _, hs := range resp.Snapshots {
+		fmt.Fprintf(out, "%s:\n", common.FormatTime(hs.Timestamp))
+		printHealthSnapshotPools(out, hs)
+		fmt.Fprintln(out)
+	}
+
+	return nil
+}
+
+func printPersistentEvents(out io.Writer, evts []*system.PersistentEvent, showAck bool) {
+	idTitle := "ID"
+	lastTitle := "Last Seen"
+	eventTitle := "Event"
+	hostTitle := "Host"
+	rankTitle := "Rank"
+	countTitle := "Count"
+	ackTitle := "Acknowledged"
+	msgTitle := "Message"
+
+	titles := []string{idTitle, lastTitle, eventTitle, hostTitle, rankTitle, countTitle}
+	if showAck {
+		titles = append(titles, ackTitle)
+	}
+	titles = append(titles, msgTitle)
+
+	formatter := txtfmt.NewTableFormatter(titles...)
+	var table []txtfmt.TableRow
+	for _, pe := range evts {
+		row := txtfmt.TableRow{idTitle: fmt.Sprintf("%d", pe.ID)}
+		row[lastTitle] = common.FormatTime(pe.LastSeen)
+		row[eventTitle] = pe.RASID
+		row[hostTitle] = pe.Hostname
+		row[rankTitle] = fmt.Sprintf("%d", pe.Rank)
+		row[countTitle] = fmt.Sprintf("%d", pe.Count)
+		if showAck {
+			row[ackTitle] = "No"
+			if pe.Acknowledged {
+				row[ackTitle] = common.FormatTime(pe.AckTime)
+			}
+		}
+		row[msgTitle] = pe.Msg
+
+		table = append(table, row)
+	}
+
+	fmt.Fprint(out, formatter.Format(table))
+}
+
+// PrintSystemEventsResponse generates a human-readable representation of the
+// supplied SystemEventsResp struct and writes it to the supplied io.Writer.
+func PrintSystemEventsResponse(out io.Writer, resp *control.SystemEventsResp, activeOnly bool) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Events) == 0 {
+		if activeOnly {
+			fmt.Fprintln(out, "No active events")
+		} else {
+			fmt.Fprintln(out, "No events recorded")
+		}
+		return nil
+	}
+
+	printPersistentEvents(out, resp.Events, !activeOnly)
+	return nil
+}
+
+func printMSBackupStatus(out io.Writer, bs *system.MSBackupStatus) {
+	if !bs.Failed() {
+		fmt.Fprintf(out, "Last MS backup: %s (index %d)\n", common.FormatTime(bs.Time), bs.Index)
+		fmt.Fprintf(out, "  Location: %s\n", bs.Path)
+		return
+	}
+
+	lastSuccess := "never"
+	if !bs.LastSuccess.IsZero() {
+		lastSuccess = common.FormatTime(bs.LastSuccess)
+	}
+	fmt.Fprintf(out, "Last MS backup: %s FAILED: %s\n", common.FormatTime(bs.Time), bs.Error)
+	fmt.Fprintf(out, "  Last Successful Backup: %s\n", lastSuccess)
+}
+
+// PrintSystemHealth generates a human-readable summary of the latest system
+// health snapshot, if any, followed by the active persistent events.
+func PrintSystemHealth(out io.Writer, hist *control.SystemHistoryResp, evts *control.SystemEventsResp) error {
+	if hist == nil {
+		return errors.Errorf("nil %T", hist)
+	}
+	if evts == nil {
+		return errors.Errorf("nil %T", evts)
+	}
+
+	if len(hist.Snapshots) == 0 {
+		fmt.Fprintln(out, "No system health snapshots recorded")
+	} else {
+		hs := hist.Snapshots[len(hist.Snapshots)-1]
+		var degraded int
+		for _, ps := range hs.Pools {
+			if poolIsDegraded(ps) {
+				degraded++
+			}
+		}
+		fmt.Fprintf(out, "Last health snapshot: %s\n", common.FormatTime(hs.Timestamp))
+		fmt.Fprintf(out, "  Joined Members: %d\n", hs.MemberCount(system.MemberStateJoined))
+		fmt.Fprintf(out, "  Other Members: %s\n", formatOtherMembers(hs))
+		fmt.Fprintf(out, "  Degraded Pools: %d/%d\n", degraded, len(hs.Pools))
+	}
+	if hist.MSBackup != nil {
+		printMSBackupStatus(out, hist.MSBackup)
+	}
+	fmt.Fprintln(out)
+
+	if len(evts.Events) == 0 {
+		fmt.Fprintln(out, "No active critical events")
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s (acknowledge with \"dmg system events ack <id>\"):\n",
+		english.Plural(len(evts.Events), "active critical event", ""))
+	printPersistentEvents(txtfmt.NewIndentWriter(out), evts.Events, false)
+
+	return nil
+}
+
+func systemDiffPoolName(uuid fmt.Stringer, label string) string {
+	if label == "" {
+		return uuid.String()
+	}
+	return label
+}
+
+// PrintSystemDiff generates a human-readable representation of the supplied
+// SystemDiff struct and writes it to the supplied io.Writer.
+func PrintSystemDiff(out io.Writer, diff *control.SystemDiff) error {
+	if diff == nil {
+		return errors.Errorf("nil %T", diff)
+	}
+
+	fmt.Fprintf(out, "Changes from %s to %s:\n", common.FormatTime(diff.From),
+		common.FormatTime(diff.To))
+	if diff.IsEmpty() {
+		fmt.Fprintln(out, "  No changes")
+		return nil
+	}
+
+	if len(diff.AddedMembers) > 0 || len(diff.RemovedMembers) > 0 || len(diff.MemberChanges) > 0 {
+		rankTitle := "Rank"
+		addrTitle := "Address"
+		changeTitle := "Change"
+		causeTitle := "Reason"
+
+		formatter := txtfmt.NewTableFormatter(rankTitle, addrTitle, changeTitle, causeTitle)
+		var table []txtfmt.TableRow
+		for _, m := range diff.AddedMembers {
+			table = append(table, txtfmt.TableRow{
+				rankTitle:   m.Rank.String(),
+				addrTitle:   m.Addr.String(),
+				changeTitle: fmt.Sprintf("added (%s)", m.State),
+				causeTitle:  m.StateCause,
+			})
+		}
+		for _, m := range diff.RemovedMembers {
+			table = append(table, txtfmt.TableRow{
+				rankTitle:   m.Rank.String(),
+				addrTitle:   m.Addr.String(),
+				changeTitle: "removed",
+			})
+		}
+		for _, mc := range diff.MemberChanges {
+			change := fmt.Sprintf("%s -> %s", mc.FromState, mc.ToState)
+			if mc.Restarted {
+				change += " (restarted)"
+			}
+			table = append(table, txtfmt.TableRow{
+				rankTitle:   mc.Rank.String(),
+				addrTitle:   mc.Addr,
+				changeTitle: change,
+				causeTitle:  mc.Cause,
+			})
+		}
+
+		fmt.Fprintln(out, "Members:")
+		formatter.InitWriter(txtfmt.NewIndentWriter(out))
+		formatter.Format(table)
+	}
+
+	if len(diff.AddedPools) > 0 || len(diff.RemovedPools) > 0 || len(diff.PoolChanges) > 0 {
+		fmt.Fprintln(out, "Pools:")
+		for _, p := range diff.AddedPools {
+			fmt.Fprintf(out, "  %s: added\n", systemDiffPoolName(p.UUID, p.Label))
+		}
+		for _, p := range diff.RemovedPools {
+			fmt.Fprintf(out, "  %s: removed\n", systemDiffPoolName(p.UUID, p.Label))
+		}
+		for _, pc := range diff.PoolChanges {
+			fmt.Fprintf(out, "  %s: %s\n", systemDiffPoolName(pc.UUID, pc.Label),
+				strings.Join(pc.Changes, ", "))
+		}
+	}
+
+	return nil
+}
+
+// PrintSystemMap generates a human-readable representation of the supplied
+// SystemMapResp struct and writes it to the supplied io.Writer.
+func PrintSystemMap(out io.Writer, resp *control.SystemMapResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Ranks) == 0 {
+		fmt.Fprintln(out, "No system members")
+		return nil
+	}
+
+	rankTitle := "Rank"
+	hostTitle := "Host"
+	addrTitle := "Address"
+	domainTitle := "Fault Domain"
+	numaTitle := "NUMA Node"
+	stateTitle := "State"
+
+	formatter := txtfmt.NewTableFormatter(rankTitle, hostTitle, addrTitle, domainTitle,
+		numaTitle, stateTitle)
+	var table []txtfmt.TableRow
+	for _, loc := range resp.Ranks {
+		row := txtfmt.TableRow{
+			rankTitle:   loc.Rank.String(),
+			hostTitle:   loc.Host,
+			addrTitle:   loc.Addr,
+			domainTitle: loc.FaultDomain,
+			numaTitle:   "-",
+			stateTitle:  loc.State,
+		}
+		if loc.NUMANode != nil {
+			row[numaTitle] = fmt.Sprintf("%d", *loc.NUMANode)
+		}
+		table = append(table, row)
+	}
+
+	fmt.Fprint(out, formatter.Format(table))
+	return nil
+}
+
+// slurmRootSwitch is the name of the Slurm switch representing the root of
+// the fault domain tree.
+const slurmRootSwitch = "daos"
+
+func slurmSwitchName(fd *system.FaultDomain) string {
+	if fd.Empty() {
+		return slurmRootSwitch
+	}
+	return strings.Join(fd.Domains, "-")
+}
+
+// PrintSlurmTopology writes the supplied SystemMapResp in the format of the
+// Slurm topology.conf file used by the topology/tree plugin. Each fault domain
+// above the host level is represented by a switch, so that jobs can be placed
+// close to the DAOS servers they use.
+func PrintSlurmTopology(out io.Writer, resp *control.SystemMapResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	nodes := make(map[string]*hostlist.HostSet)
+	switches := make(map[string]common.StringSet)
+	depths := make(map[string]int)
+	for _, loc := range resp.Ranks {
+		fd, err := system.NewFaultDomainFromString(loc.FaultDomain)
+		if err != nil {
+			return errors.Wrapf(err, "rank %d", loc.Rank)
+		}
+		// The bottom level of the fault domain is the host itself.
+		parent := system.MustCreateFaultDomain()
+		if fd.NumLevels() > 1 {
+			parent = system.MustCreateFaultDomain(fd.Domains[:fd.NumLevels()-1]...)
+		}
+
+		name := slurmSwitchName(parent)
+		if _, found := nodes[name]; !found {
+			nodes[name] = new(hostlist.HostSet)
+		}
+		if _, err := nodes[name].Insert(loc.Host); err != nil {
+			return errors.Wrapf(err, "rank %d", loc.Rank)
+		}
+
+		for d := parent; ; {
+			name := slurmSwitchName(d)
+			depths[name] = d.NumLevels()
+			if d.Empty() {
+				break
+			}
+			d = system.MustCreateFaultDomain(d.Domains[:d.NumLevels()-1]...)
+			parentName := slurmSwitchName(d)
+			if _, found := switches[parentName]; !found {
+				switches[parentName] = common.NewStringSet()
+			}
+			switches[parentName].Add(name)
+		}
+	}
+
+	names := make([]string, 0, len(depths))
+	for name := range depths {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if depths[names[i]] == depths[names[j]] {
+			return names[i] < names[j]
+		}
+		return depths[names[i]] > depths[names[j]]
+	})
+
+	fmt.Fprintln(out, "# DAOS server topology generated by dmg system map")
+	for _, name := range names {
+		line := fmt.Sprintf("SwitchName=%s", name)
+		if children, found := switches[name]; found {
+			line += fmt.Sprintf(" Switches=%s", strings.Join(children.ToSlice(), ","))
+		}
+		if hosts, found := nodes[name]; found {
+			line += fmt.Sprintf(" Nodes=%s", hosts.RangedString())
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	return nil
+}
+
+// dbHashDisplayLen is the number of characters of a database state hash that
+// are displayed, which is sufficient to tell hashes apart.
+const dbHashDisplayLen = 16
+
+// PrintSystemDBVerifyResponse generates a human-readable representation of
+// the supplied SystemDBVerifyResp struct and writes it to the supplied
+// io.Writer.
+func PrintSystemDBVerifyResponse(out io.Writer, resp *control.SystemDBVerifyResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	replicaTitle := "Replica"
+	roleTitle := "Role"
+	indexTitle := "Index"
+	hashTitle := "Hash"
+	statusTitle := "Status"
+
+	formatter := txtfmt.NewTableFormatter(replicaTitle, roleTitle, indexTitle, hashTitle, statusTitle)
+	var table []txtfmt.TableRow
+	for _, rh := range resp.Replicas {
+		row := txtfmt.TableRow{replicaTitle: rh.Replica}
+		row[roleTitle] = "follower"
+		if rh.Leader {
+			row[roleTitle] = "leader"
+		}
+		row[indexTitle] = "-"
+		if rh.Index != 0 {
+			row[indexTitle] = fmt.Sprintf("%d", rh.Index)
+		}
+		row[hashTitle] = "-"
+		if rh.Hash != "" {
+			row[hashTitle] = rh.Hash
+			if len(rh.Hash) > dbHashDisplayLen {
+				row[hashTitle] = rh.Hash[:dbHashDisplayLen]
+			}
+		}
+		switch {
+		case rh.Error != "":
+			row[statusTitle] = "error: " + rh.Error
+		case resp.Matches(rh):
+			row[statusTitle] = "match"
+		default:
+			row[statusTitle] = "DIVERGED"
+		}
+
+		table = append(table, row)
+	}
+
+	fmt.Fprintf(out, "Replica state compared at index %d\n\n", resp.Index)
+	fmt.Fprint(out, formatter.Format(table))
+
+	diverged := resp.Diverged()
+	fmt.Fprintln(out)
+	switch {
+	case len(diverged) > 0:
+		fmt.Fprintf(out, "%s diverged from the MS leader: %s\n",
+			english.Plural(len(diverged), "replica", ""), strings.Join(diverged, ", "))
+	case resp.Errors() != nil:
+		fmt.Fprintln(out, "No divergence detected among the replicas that could be verified")
+	default:
+		fmt.Fprintf(out, "All %s match\n", english.Plural(len(resp.Replicas), "replica", ""))
+	}
+
+	return nil
+}
+
+// swimStateChar returns the character representing a SWIM state in the
+// disagreement matrix, or "-" if the view doesn't include the rank.
+func swimStateChar(state string) string {
+	if state == "" {
+		return "-"
+	}
+	return strings.ToUpper(state[:1])
+}
+
+// PrintSwimViews writes the local SWIM views of the system ranks held by each
+// member. If the views disagree, a matrix is printed with a row for each view
+// and a column for each rank that the views disagree about, otherwise the
+// ranks are summarized by their agreed state.
+func PrintSwimViews(out, outErr io.Writer, resp *control.SwimViewQueryResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if err := PrintResponseErrors(resp, outErr); err != nil {
+		return err
+	}
+
+	var views []*control.SwimView
+	for _, sv := range resp.Views {
+		if sv.Error != "" {
+			fmt.Fprintf(outErr, "engine %d on %s: %s\n", sv.Index, sv.Host, sv.Error)
+			continue
+		}
+		views = append(views, sv)
+	}
+	if len(views) == 0 {
+		fmt.Fprintln(out, "No SWIM views available")
+		return nil
+	}
+
+	rankTitle := "Rank"
+	disputed := resp.Disagreements()
+	if len(disputed) == 0 {
+		byState := make(map[string]*ranklist.RankSet)
+		var states []string
+		for _, rank := range resp.Subjects() {
+			var state string
+			for _, sv := range views {
+				if state = sv.State(rank); state != "" {
+					break
+				}
+			}
+			if _, found := byState[state]; !found {
+				byState[state] = ranklist.NewRankSet()
+				states = append(states, state)
+			}
+			byState[state].Add(rank)
+		}
+		sort.Strings(states)
+
+		fmt.Fprintf(out, "All %s agree\n", english.Plural(len(views), "SWIM view", ""))
+		stateTitle := "State"
+		formatter := txtfmt.NewTableFormatter(stateTitle, rankTitle)
+		var table []txtfmt.TableRow
+		for _, state := range states {
+			table = append(table, txtfmt.TableRow{
+				stateTitle: state,
+				rankTitle:  byState[state].String(),
+			})
+		}
+		fmt.Fprint(out, formatter.Format(table))
+
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s disagree about %s (A=alive S=suspect D=dead I=inactive -=unknown)\n",
+		english.Plural(len(views), "SWIM view", ""), english.Plural(len(disputed), "rank", ""))
+	titles := []string{rankTitle}
+	for _, rank := range disputed {
+		titles = append(titles, rank.String())
+	}
+	formatter := txtfmt.NewTableFormatter(titles...)
+	var table []txtfmt.TableRow
+	for _, sv := range views {
+		row := txtfmt.TableRow{rankTitle: sv.Rank.String()}
+		for _, rank := range disputed {
+			row[rank.String()] = swimStateChar(sv.State(rank))
+		}
+		table = append(table, row)
+	}
+	fmt.Fprint(out, formatter.Format(table))
+
+	return nil
+}
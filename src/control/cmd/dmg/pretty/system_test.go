@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,11 +11,14 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	. "github.com/daos-stack/daos/src/control/lib/ranklist"
@@ -171,9 +174,50 @@ Unknown 3 hosts: foo[7-9]
 			},
 			verbose: true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State  Reason 
----- ----                                 --------------- ------------ -----  ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        
+Rank UUID                                 Control Address Fault Domain State  Reason Starts Uptime Last Stop 
+---- ----                                 --------------- ------------ -----  ------ ------ ------ --------- 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        0      -      -         
+
+`,
+		},
+		"verbose with engine usage": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					MockMember(t, 0, MemberStateJoined),
+					MockMember(t, 1, MemberStateStopped),
+				},
+				EngineUsage: []*control.EngineUsage{
+					{
+						Host:  "127.0.0.1:10001",
+						Rank:  1,
+						Error: "instance 0: not started",
+					},
+					{
+						Host:          "127.0.0.0:10001",
+						Rank:          0,
+						PID:           1234,
+						RSSBytes:      humanize.GiByte,
+						HugepageBytes: 2 * humanize.GiByte,
+						OpenFDs:       42,
+						CPUSeconds: map[string]float64{
+							"daos_io_0": 10.5,
+							"daos_io_1": 2.25,
+						},
+					},
+				},
+			},
+			verbose: true,
+			expPrintStr: `
+Rank UUID                                 Control Address Fault Domain State   Reason Starts Uptime Last Stop 
+---- ----                                 --------------- ------------ -----   ------ ------ ------ --------- 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined         0      -      -         
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Stopped        0      -      -         
+
+Engine Resource Usage
+Rank Host            PID  RSS     Hugepages Open FDs CPU Time 
+---- ----            ---  ---     --------- -------- -------- 
+0    127.0.0.0:10001 1234 1.0 GiB 2.0 GiB   42       12.75s   
+1    127.0.0.1:10001 -    -       -         -        -        
 
 `,
 		},
@@ -187,9 +231,9 @@ Rank UUID                                 Control Address Fault Domain State  Re
 			absentRanks: "7-9",
 			verbose:     true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State  Reason 
----- ----                                 --------------- ------------ -----  ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        
+Rank UUID                                 Control Address Fault Domain State  Reason Starts Uptime Last Stop 
+---- ----                                 --------------- ------------ -----  ------ ------ ------ --------- 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined        0      -      -         
 
 Unknown 3 hosts: foo[7-9]
 Unknown 3 ranks: 7-9
@@ -301,15 +345,45 @@ Unknown 3 hosts: foo[7-9]
 			},
 			verbose: true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State    Reason 
----- ----                                 --------------- ------------ -----    ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          
-1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          
-2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         
-3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        
-4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         
-5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          
-6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          
+Rank UUID                                 Control Address Fault Domain State    Reason Starts Uptime Last Stop 
+---- ----                                 --------------- ------------ -----    ------ ------ ------ --------- 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          0      -      -         
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          0      -      -         
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         0      -      -         
+3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        0      -      -         
+4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         0      -      -         
+5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          0      -      -         
+6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          0      -      -         
+
+`,
+		},
+		"verbose with start and stop history": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					func() *Member {
+						m := MockMember(t, 0, MemberStateJoined)
+						m.Starts = 3
+						m.LastStart = time.Now().Add(-90 * time.Minute)
+						m.Deaths = 2
+						m.LastStopReason = "marked dead"
+						return m
+					}(),
+					func() *Member {
+						m := MockMember(t, 1, MemberStateStopped)
+						m.Starts = 1
+						m.LastStart = time.Now().Add(-time.Hour)
+						m.Stops = 1
+						m.LastStopReason = "stopped"
+						return m
+					}(),
+				},
+			},
+			verbose: true,
+			expPrintStr: `
+Rank UUID                                 Control Address Fault Domain State   Reason Starts Uptime  Last Stop   
+---- ----                                 --------------- ------------ -----   ------ ------ ------  ---------   
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined         3      1h30m0s marked dead 
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Stopped        1      -       stopped     
 
 `,
 		},
@@ -329,15 +403,15 @@ Rank UUID                                 Control Address Fault Domain State
 			absentRanks: "7-9",
 			verbose:     true,
 			expPrintStr: `
-Rank UUID                                 Control Address Fault Domain State    Reason 
----- ----                                 --------------- ------------ -----    ------ 
-0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          
-1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          
-2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         
-3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        
-4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         
-5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          
-6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          
+Rank UUID                                 Control Address Fault Domain State    Reason Starts Uptime Last Stop 
+---- ----                                 --------------- ------------ -----    ------ ------ ------ --------- 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined          0      -      -         
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined          0      -      -         
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped         0      -      -         
+3    00000003-0003-0003-0003-000000000003 127.0.0.3:10001 /            Excluded        0      -      -         
+4    00000004-0004-0004-0004-000000000004 127.0.0.4:10001 /            Stopped         0      -      -         
+5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined          0      -      -         
+6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined          0      -      -         
 
 Unknown 3 hosts: foo[7-9]
 Unknown 3 ranks: 7-9
@@ -611,3 +685,666 @@ Unknown 3 hosts: foo[7-9]
 		})
 	}
 }
+
+func TestPretty_PrintSystemHistoryResp(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	snaps := []*HealthSnapshot{
+		{
+			Timestamp: ts,
+			MemberStates: map[string]string{
+				"Joined": "0-3",
+			},
+			Pools: []*PoolHealthSummary{
+				{
+					PoolUUID:     test.MockPoolUUID(1),
+					PoolLabel:    "pool1",
+					State:        "Ready",
+					TotalTargets: 32,
+					RebuildState: "idle",
+					TotalBytes:   100 * humanize.GByte,
+					FreeBytes:    60 * humanize.GByte,
+				},
+			},
+		},
+		{
+			Timestamp: ts.Add(time.Hour),
+			MemberStates: map[string]string{
+				"Joined":   "0-1",
+				"Stopped":  "3",
+				"Unstable": "2",
+			},
+			Pools: []*PoolHealthSummary{
+				{
+					PoolUUID:        test.MockPoolUUID(1),
+					PoolLabel:       "pool1",
+					State:           "Degraded",
+					TotalTargets:    32,
+					DisabledTargets: 16,
+					RebuildState:    "busy",
+					TotalBytes:      100 * humanize.GByte,
+					FreeBytes:       50 * humanize.GByte,
+				},
+				{
+					PoolUUID:   test.MockPoolUUID(2),
+					State:      "Ready",
+					QueryError: "timed out",
+				},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemHistoryResp
+		verbose     bool
+		expPrintStr string
+	}{
+		"empty response": {
+			resp: &control.SystemHistoryResp{},
+			expPrintStr: `
+No system health snapshots recorded
+`,
+		},
+		"normal response": {
+			resp: &control.SystemHistoryResp{
+				Snapshots: snaps,
+			},
+			expPrintStr: `
+Time                          Joined Other Members           Pools Degraded Pools Used  Total  
+----                          ------ -------------           ----- -------------- ----  -----  
+2024-03-01T12:00:00.000+00:00 4      None                    1     0              40 GB 100 GB 
+2024-03-01T13:00:00.000+00:00 2      Stopped: 3; Unstable: 2 2     2              50 GB 100 GB 
+
+`,
+		},
+		"verbose response": {
+			resp: &control.SystemHistoryResp{
+				Snapshots: snaps[1:],
+			},
+			verbose: true,
+			expPrintStr: `
+Time                          Joined Other Members           Pools Degraded Pools Used  Total  
+----                          ------ -------------           ----- -------------- ----  -----  
+2024-03-01T13:00:00.000+00:00 2      Stopped: 3; Unstable: 2 2     2              50 GB 100 GB 
+
+2024-03-01T13:00:00.000+00:00:
+  Pool                                 State             Disabled Targets Rebuild State Used  Total  
+  ----                                 -----             ---------------- ------------- ----  -----  
+  pool1                                Degraded          16/32            busy          50 GB 100 GB 
+  00000002-0002-0002-0002-000000000002 Ready (timed out) 0/0                            0 B   0 B    
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemHistoryResponse(&bld, tc.resp, tc.verbose); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemHealth(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	hist := &control.SystemHistoryResp{
+		Snapshots: []*HealthSnapshot{
+			{
+				Timestamp: ts,
+				MemberStates: map[string]string{
+					"Joined":   "0-1",
+					"Excluded": "2",
+				},
+				Pools: []*PoolHealthSummary{
+					{
+						PoolUUID:        test.MockPoolUUID(1),
+						State:           "Degraded",
+						TotalTargets:    32,
+						DisabledTargets: 8,
+					},
+				},
+			},
+		},
+	}
+	evts := []*PersistentEvent{
+		{
+			ID:        4,
+			RASID:     "engine_died",
+			Msg:       "DAOS engine 0 exited unexpectedly",
+			Hostname:  "foo",
+			Rank:      2,
+			FirstSeen: ts,
+			LastSeen:  ts.Add(time.Minute),
+			Count:     2,
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		hist        *control.SystemHistoryResp
+		evts        *control.SystemEventsResp
+		expPrintStr string
+	}{
+		"no snapshots or events": {
+			hist: &control.SystemHistoryResp{},
+			evts: &control.SystemEventsResp{},
+			expPrintStr: `
+No system health snapshots recorded
+
+No active critical events
+`,
+		},
+		"active events": {
+			hist: hist,
+			evts: &control.SystemEventsResp{Events: evts},
+			expPrintStr: `
+Last health snapshot: 2024-03-01T12:00:00.000+00:00
+  Joined Members: 2
+  Other Members: Excluded: 2
+  Degraded Pools: 1/1
+
+1 active critical event (acknowledge with "dmg system events ack <id>"):
+  ID Last Seen                     Event       Host Rank Count Message                           
+  -- ---------                     -----       ---- ---- ----- -------                           
+  4  2024-03-01T12:01:00.000+00:00 engine_died foo  2    2     DAOS engine 0 exited unexpectedly 
+`,
+		},
+		"ms backup": {
+			hist: &control.SystemHistoryResp{
+				MSBackup: &MSBackupStatus{
+					Time:        ts,
+					Path:        "/var/lib/daos/ms-backups/msdb-daos_server-20240301T120000Z",
+					Index:       42,
+					LastSuccess: ts,
+				},
+			},
+			evts: &control.SystemEventsResp{},
+			expPrintStr: `
+No system health snapshots recorded
+Last MS backup: 2024-03-01T12:00:00.000+00:00 (index 42)
+  Location: /var/lib/daos/ms-backups/msdb-daos_server-20240301T120000Z
+
+No active critical events
+`,
+		},
+		"failed ms backup": {
+			hist: &control.SystemHistoryResp{
+				MSBackup: &MSBackupStatus{
+					Time:        ts,
+					Error:       "no space left on device",
+					LastSuccess: ts.Add(-24 * time.Hour),
+				},
+			},
+			evts: &control.SystemEventsResp{},
+			expPrintStr: `
+No system health snapshots recorded
+Last MS backup: 2024-03-01T12:00:00.000+00:00 FAILED: no space left on device
+  Last Successful Backup: 2024-02-29T12:00:00.000+00:00
+
+No active critical events
+`,
+		},
+		"ms backup never succeeded": {
+			hist: &control.SystemHistoryResp{
+				MSBackup: &MSBackupStatus{
+					Time:  ts,
+					Error: "not leader",
+				},
+			},
+			evts: &control.SystemEventsResp{},
+			expPrintStr: `
+No system health snapshots recorded
+Last MS backup: 2024-03-01T12:00:00.000+00:00 FAILED: not leader
+  Last Successful Backup: never
+
+No active critical events
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemHealth(&bld, tc.hist, tc.evts); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemEventsResp(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	evts := []*PersistentEvent{
+		{
+			ID:           1,
+			RASID:        "engine_died",
+			Msg:          "engine died",
+			Hostname:     "foo",
+			LastSeen:     ts,
+			Count:        1,
+			Acknowledged: true,
+			AckTime:      ts.Add(time.Hour),
+		},
+		{
+			ID:       2,
+			RASID:    "nvme_link_speed_changed",
+			Msg:      "link speed changed",
+			Hostname: "bar",
+			Rank:     3,
+			LastSeen: ts,
+			Count:    5,
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemEventsResp
+		activeOnly  bool
+		expPrintStr string
+	}{
+		"no active events": {
+			resp:       &control.SystemEventsResp{},
+			activeOnly: true,
+			expPrintStr: `
+No active events
+`,
+		},
+		"no events": {
+			resp: &control.SystemEventsResp{},
+			expPrintStr: `
+No events recorded
+`,
+		},
+		"all events": {
+			resp: &control.SystemEventsResp{Events: evts},
+			expPrintStr: `
+ID Last Seen                     Event                   Host Rank Count Acknowledged                  Message            
+-- ---------                     -----                   ---- ---- ----- ------------                  -------            
+1  2024-03-01T12:00:00.000+00:00 engine_died             foo  0    1     2024-03-01T13:00:00.000+00:00 engine died        
+2  2024-03-01T12:00:00.000+00:00 nvme_link_speed_changed bar  3    5     No                            link speed changed 
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemEventsResponse(&bld, tc.resp, tc.activeOnly); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemDiff(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	for name, tc := range map[string]struct {
+		diff        *control.SystemDiff
+		expPrintStr string
+		expErr      error
+	}{
+		"nil diff": {
+			expErr: errors.New("nil"),
+		},
+		"no changes": {
+			diff: &control.SystemDiff{From: from, To: to},
+			expPrintStr: `
+Changes from 2024-03-01T12:00:00.000+00:00 to 2024-03-01T13:00:00.000+00:00:
+  No changes
+`,
+		},
+		"member and pool changes": {
+			diff: &control.SystemDiff{
+				From: from,
+				To:   to,
+				MemberChanges: []*control.MemberChange{
+					{
+						Rank:      1,
+						Addr:      "10.0.0.1:10001",
+						FromState: "Joined",
+						ToState:   "Excluded",
+						Cause:     "marked dead",
+					},
+					{
+						Rank:      2,
+						Addr:      "10.0.0.2:10001",
+						FromState: "Joined",
+						ToState:   "Joined",
+						Restarted: true,
+					},
+				},
+				AddedPools: []*daos.PoolInfo{
+					{UUID: test.MockPoolUUID(3), Label: "pool3"},
+				},
+				RemovedPools: []*daos.PoolInfo{
+					{UUID: test.MockPoolUUID(2)},
+				},
+				PoolChanges: []*control.PoolChange{
+					{
+						UUID:  test.MockPoolUUID(1),
+						Label: "pool1",
+						Changes: []string{
+							"state: Ready -> Degraded",
+							"disabled targets: 0/16 -> 4/16",
+						},
+					},
+				},
+			},
+			expPrintStr: `
+Changes from 2024-03-01T12:00:00.000+00:00 to 2024-03-01T13:00:00.000+00:00:
+Members:
+  Rank Address        Change                       Reason      
+  ---- -------        ------                       ------      
+  1    10.0.0.1:10001 Joined -> Excluded           marked dead 
+  2    10.0.0.2:10001 Joined -> Joined (restarted)             
+Pools:
+  pool3: added
+  00000002-0002-0002-0002-000000000002: removed
+  pool1: state: Ready -> Degraded, disabled targets: 0/16 -> 4/16
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			err := PrintSystemDiff(&bld, tc.diff)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemMap(t *testing.T) {
+	numaNode := uint32(1)
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemMapResp
+		slurm       bool
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil"),
+		},
+		"no members": {
+			resp: &control.SystemMapResp{},
+			expPrintStr: `
+No system members
+`,
+		},
+		"table": {
+			resp: &control.SystemMapResp{
+				Ranks: []*control.RankLocation{
+					{
+						Rank:        0,
+						Host:        "node1",
+						Addr:        "10.0.0.1:10001",
+						FaultDomain: "/rack0/node1",
+						NUMANode:    &numaNode,
+						State:       "Joined",
+					},
+					{
+						Rank:        1,
+						Host:        "node2",
+						Addr:        "10.0.0.2:10001",
+						FaultDomain: "/rack0/node2",
+						State:       "Excluded",
+					},
+				},
+			},
+			expPrintStr: `
+Rank Host  Address        Fault Domain NUMA Node State    
+---- ----  -------        ------------ --------- -----    
+0    node1 10.0.0.1:10001 /rack0/node1 1         Joined   
+1    node2 10.0.0.2:10001 /rack0/node2 -         Excluded 
+`,
+		},
+		"slurm; hosts below root": {
+			resp: &control.SystemMapResp{
+				Ranks: []*control.RankLocation{
+					{Rank: 0, Host: "node1", FaultDomain: "/node1"},
+					{Rank: 1, Host: "node1", FaultDomain: "/node1"},
+					{Rank: 2, Host: "node2", FaultDomain: "/node2"},
+				},
+			},
+			slurm: true,
+			expPrintStr: `
+# DAOS server topology generated by dmg system map
+SwitchName=daos Nodes=node[1-2]
+`,
+		},
+		"slurm; multi-level": {
+			resp: &control.SystemMapResp{
+				Ranks: []*control.RankLocation{
+					{Rank: 0, Host: "node1", FaultDomain: "/dc0/rack0/node1"},
+					{Rank: 1, Host: "node2", FaultDomain: "/dc0/rack0/node2"},
+					{Rank: 2, Host: "node3", FaultDomain: "/dc0/rack1/node3"},
+					{Rank: 3, Host: "node4", FaultDomain: "/dc1/rack0/node4"},
+				},
+			},
+			slurm: true,
+			expPrintStr: `
+# DAOS server topology generated by dmg system map
+SwitchName=dc0-rack0 Nodes=node[1-2]
+SwitchName=dc0-rack1 Nodes=node3
+SwitchName=dc1-rack0 Nodes=node4
+SwitchName=dc0 Switches=dc0-rack0,dc0-rack1
+SwitchName=dc1 Switches=dc1-rack0
+SwitchName=daos Switches=dc0,dc1
+`,
+		},
+		"slurm; bad fault domain": {
+			resp: &control.SystemMapResp{
+				Ranks: []*control.RankLocation{
+					{Rank: 0, Host: "node1", FaultDomain: "node1"},
+				},
+			},
+			slurm:  true,
+			expErr: errors.New("rank 0"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			var err error
+			if tc.slurm {
+				err = PrintSlurmTopology(&bld, tc.resp)
+			} else {
+				err = PrintSystemMap(&bld, tc.resp)
+			}
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemDBVerifyResp(t *testing.T) {
+	ldr := &control.SystemDBReplicaHash{
+		Replica: "10.0.0.1:10001",
+		Leader:  true,
+		Index:   42,
+		Hash:    "0123456789abcdef0123",
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemDBVerifyResp
+		expPrintStr string
+	}{
+		"replicas match": {
+			resp: &control.SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*control.SystemDBReplicaHash{
+					ldr,
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "0123456789abcdef0123"},
+				},
+			},
+			expPrintStr: `
+Replica state compared at index 42
+
+Replica        Role     Index Hash             Status 
+-------        ----     ----- ----             ------ 
+10.0.0.1:10001 leader   42    0123456789abcdef match  
+10.0.0.2:10001 follower 42    0123456789abcdef match  
+
+All 2 replicas match
+`,
+		},
+		"replica diverged": {
+			resp: &control.SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*control.SystemDBReplicaHash{
+					ldr,
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "fedcba9876543210fedc"},
+					{Replica: "10.0.0.3:10001", Error: "connection refused"},
+				},
+			},
+			expPrintStr: `
+Replica state compared at index 42
+
+Replica        Role     Index Hash             Status                    
+-------        ----     ----- ----             ------                    
+10.0.0.1:10001 leader   42    0123456789abcdef match                     
+10.0.0.2:10001 follower 42    fedcba9876543210 DIVERGED                  
+10.0.0.3:10001 follower -     -                error: connection refused 
+
+1 replica diverged from the MS leader: 10.0.0.2:10001
+`,
+		},
+		"replica not verified": {
+			resp: &control.SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*control.SystemDBReplicaHash{
+					ldr,
+					{Replica: "10.0.0.3:10001", Error: "connection refused"},
+				},
+			},
+			expPrintStr: `
+Replica state compared at index 42
+
+Replica        Role     Index Hash             Status                    
+-------        ----     ----- ----             ------                    
+10.0.0.1:10001 leader   42    0123456789abcdef match                     
+10.0.0.3:10001 follower -     -                error: connection refused 
+
+No divergence detected among the replicas that could be verified
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemDBVerifyResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSwimViews(t *testing.T) {
+	members := func(states ...string) []*control.SwimMember {
+		var out []*control.SwimMember
+		for i, state := range states {
+			if state == "" {
+				continue
+			}
+			out = append(out, &control.SwimMember{Rank: ranklist.Rank(i), State: state})
+		}
+		return out
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SwimViewQueryResp
+		expPrintStr string
+		expErrStr   string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil"),
+		},
+		"no views": {
+			resp: &control.SwimViewQueryResp{
+				Views: []*control.SwimView{
+					{Host: "host1", Index: 1, Rank: 2, Error: "engine not ready"},
+				},
+			},
+			expPrintStr: `
+No SWIM views available
+`,
+			expErrStr: `
+engine 1 on host1: engine not ready
+`,
+		},
+		"agreement": {
+			resp: &control.SwimViewQueryResp{
+				Views: []*control.SwimView{
+					{Host: "host1", Rank: 0, Members: members("alive", "alive", "dead", "alive")},
+					{Host: "host1", Index: 1, Rank: 1, Members: members("alive", "alive", "dead")},
+				},
+			},
+			expPrintStr: `
+All 2 SWIM views agree
+State Rank  
+----- ----  
+alive 0-1,3 
+dead  2     
+`,
+		},
+		"disagreement": {
+			resp: &control.SwimViewQueryResp{
+				Views: []*control.SwimView{
+					{Host: "host1", Rank: 0, Members: members("alive", "alive", "suspect", "alive")},
+					{Host: "host1", Index: 1, Rank: 1, Members: members("alive", "alive", "dead")},
+					{Host: "host2", Rank: 3, Members: members("alive", "alive", "dead", "suspect")},
+					{Host: "host2", Index: 1, Rank: 4, Error: "engine not ready"},
+				},
+			},
+			expPrintStr: `
+3 SWIM views disagree about 2 ranks (A=alive S=suspect D=dead I=inactive -=unknown)
+Rank 2 3 
+---- - - 
+0    S A 
+1    D - 
+3    D S 
+`,
+			expErrStr: `
+engine 1 on host2: engine not ready
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+			gotErr := PrintSwimViews(&out, &outErr, tc.resp)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expErrStr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected stderr (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
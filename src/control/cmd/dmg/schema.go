@@ -0,0 +1,231 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/jsonschema"
+)
+
+const schemaFlag = "--schema"
+
+// jsonOutputs maps each command to the types of response it may output with
+// --json. Commands with no response types only ever output a null response
+// along with any error. Every command must be listed so that its schema can
+// be published; this is enforced by the tests.
+var jsonOutputs = map[string][]interface{}{
+	"server set-logmasks":           {control.SetEngineLogMasksResp{}},
+	"server set-control-log-levels": {control.SetControlLogLevelsResp{}},
+	"server set-profiling":          {control.SetProfilingResp{}},
+	"server get-tunables":           {control.GetEngineTunablesResp{}},
+
+	"storage scan":                {control.StorageScanResp{}},
+	"storage format":              {control.StorageFormatResp{}},
+	"storage query device-health": {control.SmdResp{}},
+	"storage query device-stats":  {control.SmdResp{}},
+	"storage query list-pools":    {control.SmdResp{}},
+	"storage query list-devices":  {control.SmdResp{}},
+	"storage query usage":         {control.StorageScanResp{}},
+	"storage nvme-rebind":         {control.NvmeRebindResp{}},
+	"storage nvme-add-device":     {control.NvmeAddDeviceResp{}},
+	"storage set nvme-faulty":     {control.SmdResp{}},
+	"storage replace nvme":        {control.SmdResp{}},
+	"storage led check":           {control.SmdResp{}},
+	"storage led identify":        {control.SmdResp{}},
+	"storage inventory":           {control.StorageInventoryResp{}},
+
+	"config generate": {control.ConfGenerateRemoteResp{}},
+	"config apply":    {control.ServerConfigApplyResp{}},
+
+	"cluster init": {[]*clusterInitStep{}},
+
+	"system leader-query":       {control.LeaderQueryResp{}},
+	"system query":              {control.SystemQueryResp{}, control.SwimViewQueryResp{}},
+	"system diff":               {control.SystemDiff{}},
+	"system stop":               {control.SystemStopResp{}},
+	"system start":              {control.SystemStartResp{}},
+	"system exclude":            {control.SystemExcludeResp{}},
+	"system clear-exclude":      {control.SystemExcludeResp{}},
+	"system quarantine":         {control.SystemQuarantineResp{}},
+	"system erase":              {control.SystemArmResp{}, control.SystemEraseResp{}},
+	"system list-pools":         {control.ListPoolsResp{}},
+	"system cleanup":            {control.SystemCleanupResp{}},
+	"system set-attr":           nil,
+	"system get-attr":           {control.SystemGetAttrResp{}},
+	"system del-attr":           nil,
+	"system set-prop":           nil,
+	"system get-prop":           {[]*daos.SystemProperty{}},
+	"system history":            {control.SystemHistoryResp{}},
+	"system health":             {systemHealth{}},
+	"system events list":        {control.SystemEventsResp{}},
+	"system events ack":         nil,
+	"system map":                {control.SystemMapResp{}},
+	"system db verify-replicas": {control.SystemDBVerifyResp{}},
+	"system token create":       {control.SystemTokenCreateResp{}},
+
+	"network scan": {control.NetworkScanResp{}},
+
+	"support collect-log": nil,
+
+	"pool create":        {control.PoolCreateResp{}},
+	"pool destroy":       nil,
+	"pool evict":         nil,
+	"pool list":          {control.ListPoolsResp{}},
+	"pool extend":        nil,
+	"pool exclude":       nil,
+	"pool drain":         nil,
+	"pool reintegrate":   nil,
+	"pool query":         {control.PoolQueryResp{}, control.PoolUsageHistoryResp{}, control.PoolActivityResp{}},
+	"pool query-targets": {control.PoolQueryTargetResp{}},
+	"pool get-acl":       {control.PoolGetACLResp{}},
+	"pool overwrite-acl": {control.PoolOverwriteACLResp{}},
+	"pool update-acl":    {control.PoolUpdateACLResp{}, control.PoolOverwriteACLResp{}, control.ACLDiff{}},
+	"pool delete-acl":    {control.PoolDeleteACLResp{}},
+	"pool set-prop":      nil,
+	"pool rename":        nil,
+	"pool get-prop":      {[]*daos.PoolProperty{}},
+	"pool export-config": {control.PoolConfig{}},
+	"pool upgrade":       nil,
+
+	"container set-owner": nil,
+
+	"version": {build.BinaryInfo{}},
+
+	"telemetry config":        nil,
+	"telemetry metrics list":  {control.MetricsListResp{}},
+	"telemetry metrics query": {control.MetricsQueryResp{}},
+
+	"check enable":     nil,
+	"check disable":    nil,
+	"check start":      nil,
+	"check stop":       nil,
+	"check query":      {control.SystemCheckQueryResp{}},
+	"check set-policy": nil,
+	"check get-policy": {control.SystemCheckGetPolicyResp{}},
+	"check repair":     nil,
+
+	"job-stats query": {control.JobStatsQueryResp{}},
+
+	"job list": {control.PoolOpJobsResp{}},
+
+	"util nodeset fold":       {nodeset{}},
+	"util nodeset expand":     {nodeset{}},
+	"util nodeset count":      {nodeset{}},
+	"util nodeset union":      {nodeset{}},
+	"util nodeset intersect":  {nodeset{}},
+	"util nodeset difference": {nodeset{}},
+	"util nodeset xor":        {nodeset{}},
+
+	"firmware query":  {control.FirmwareQueryResp{}},
+	"firmware update": {control.FirmwareUpdateResp{}},
+}
+
+// jsonOutputSchema returns the schema of the JSON output of the command with
+// the given canonical path, e.g. "pool query".
+func jsonOutputSchema(cmdPath string) (*jsonschema.Schema, error) {
+	responses, found := jsonOutputs[cmdPath]
+	if !found {
+		return nil, errors.Errorf("no JSON output schema for %q", "dmg "+cmdPath)
+	}
+
+	schema := cmdutil.JSONOutputSchema(responses...)
+	schema.Title = fmt.Sprintf("dmg %s --json", cmdPath)
+	return schema, nil
+}
+
+// scanSchemaArgs returns the canonical path of the command on the command line
+// and whether the schema of its JSON output was requested. The command line
+// is scanned rather than parsed so that the schema can be requested without
+// supplying the command's required arguments.
+func scanSchemaArgs(p *flags.Parser, args []string) (string, bool) {
+	var path []string
+	var schema bool
+
+	cmd := p.Command
+	for i := 0; i < len(args) && args[i] != "--"; {
+		if args[i] == schemaFlag {
+			schema = true
+		}
+		if isOption(args[i]) {
+			i = skipOption(cmd, args, i)
+			continue
+		}
+		if sub := cmd.Find(args[i]); sub != nil {
+			cmd = sub
+			path = append(path, sub.Name)
+		}
+		i++
+	}
+
+	return strings.Join(path, " "), schema
+}
+
+// printJSONOutputSchema writes the schema of the JSON output of the command.
+func printJSONOutputSchema(out io.Writer, cmdPath string) error {
+	if cmdPath == "" {
+		return errors.Errorf("%s requires a command", schemaFlag)
+	}
+
+	schema, err := jsonOutputSchema(cmdPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(out, schema.String())
+	return err
+}
+
+// jsonSchemasCmd writes the JSON output schemas of all commands to a
+// directory, so that they can be installed alongside dmg.
+type jsonSchemasCmd struct {
+	Output string `long:"output" short:"o" required:"1" description:"output directory"`
+}
+
+// jsonSchemaFileName returns the name of the file holding the schema of the
+// JSON output of the command, e.g. dmg-pool-query.schema.json.
+func jsonSchemaFileName(cmdPath string) string {
+	return "dmg-" + strings.ReplaceAll(cmdPath, " ", "-") + ".schema.json"
+}
+
+func (cmd *jsonSchemasCmd) Execute(_ []string) error {
+	if err := os.MkdirAll(cmd.Output, 0755); err != nil {
+		return err
+	}
+
+	cmdPaths := make([]string, 0, len(jsonOutputs))
+	for cmdPath := range jsonOutputs {
+		cmdPaths = append(cmdPaths, cmdPath)
+	}
+	sort.Strings(cmdPaths)
+
+	for _, cmdPath := range cmdPaths {
+		schema, err := jsonOutputSchema(cmdPath)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(cmd.Output, jsonSchemaFileName(cmdPath))
+		if err := os.WriteFile(path, []byte(schema.String()), 0644); err != nil {
+			return errors.Wrapf(err, "failed to write schema for %q", cmdPath)
+		}
+	}
+
+	return nil
+}
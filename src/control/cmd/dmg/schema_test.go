@@ -0,0 +1,194 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/jsonschema"
+)
+
+func TestDmg_jsonOutputs(t *testing.T) {
+	// Every command must have its JSON output registered.
+	walkStruct(reflect.ValueOf(cliOptions{}), nil, func(cmd []string) {
+		cmdPath := strings.Join(cmd, " ")
+		switch cmdPath {
+		case "manpage", "json-schemas":
+			return
+		}
+		if _, found := jsonOutputs[cmdPath]; !found {
+			t.Errorf("JSON output of %q is not registered", cmdPath)
+		}
+	})
+
+	// Every registered command must exist.
+	p := flags.NewParser(&cliOptions{}, flags.Default)
+	for cmdPath := range jsonOutputs {
+		names, err := resolveCommand(p, cmdPath)
+		if err != nil {
+			t.Errorf("JSON output registered for unknown command: %s", err)
+			continue
+		}
+		if strings.Join(names, " ") != cmdPath {
+			t.Errorf("JSON output registered for %q rather than %q", cmdPath, strings.Join(names, " "))
+		}
+	}
+}
+
+func TestDmg_jsonOutputSchema(t *testing.T) {
+	for cmdPath := range jsonOutputs {
+		t.Run(cmdPath, func(t *testing.T) {
+			schema, err := jsonOutputSchema(cmdPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The schema must be valid JSON that round-trips.
+			var decoded jsonschema.Schema
+			if err := json.Unmarshal([]byte(schema.String()), &decoded); err != nil {
+				t.Fatalf("invalid schema: %s", err)
+			}
+
+			// Failed commands output a null response.
+			var out strings.Builder
+			_ = cmdutil.OutputJSON(&out, nil, errors.New("failed"))
+			if err := schema.Validate([]byte(out.String())); err != nil {
+				t.Fatalf("error output does not conform to schema: %s", err)
+			}
+		})
+	}
+}
+
+func TestDmg_scanSchemaArgs(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args      string
+		expPath   string
+		expSchema bool
+	}{
+		"no schema": {
+			args:    "system query",
+			expPath: "system query",
+		},
+		"schema": {
+			args:      "pool query --schema",
+			expPath:   "pool query",
+			expSchema: true,
+		},
+		"global schema flag": {
+			args:      "--schema pool query",
+			expPath:   "pool query",
+			expSchema: true,
+		},
+		"command aliases": {
+			args:      "sys query --schema",
+			expPath:   "system query",
+			expSchema: true,
+		},
+		"required args not needed": {
+			args:      "pool create --schema",
+			expPath:   "pool create",
+			expSchema: true,
+		},
+		"options and args": {
+			args:      "-o /tmp/dmg.yml pool query --health-only tank --schema",
+			expPath:   "pool query",
+			expSchema: true,
+		},
+		"positional arg matching command name": {
+			args:      "pool query query --schema",
+			expPath:   "pool query",
+			expSchema: true,
+		},
+		"no command": {
+			args:      "--schema",
+			expSchema: true,
+		},
+		"after terminator": {
+			args:    "pool create -- --schema",
+			expPath: "pool create",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := flags.NewParser(&cliOptions{}, flags.Default)
+
+			gotPath, gotSchema := scanSchemaArgs(p, strings.Fields(tc.args))
+			test.AssertEqual(t, tc.expPath, gotPath, "unexpected command path")
+			test.AssertEqual(t, tc.expSchema, gotSchema, "unexpected schema request")
+		})
+	}
+}
+
+func TestDmg_printJSONOutputSchema(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cmdPath  string
+		expTitle string
+		expErr   error
+	}{
+		"no command": {
+			expErr: errors.New("requires a command"),
+		},
+		"not a leaf command": {
+			cmdPath: "pool",
+			expErr:  errors.New(`no JSON output schema for "dmg pool"`),
+		},
+		"pool query": {
+			cmdPath:  "pool query",
+			expTitle: "dmg pool query --json",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := printJSONOutputSchema(&out, tc.cmdPath)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var schema jsonschema.Schema
+			if err := json.Unmarshal([]byte(out.String()), &schema); err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expTitle, schema.Title, "unexpected title")
+			test.AssertEqual(t, jsonschema.Draft, schema.Schema, "unexpected dialect")
+		})
+	}
+}
+
+func TestDmg_jsonSchemasCmd(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "schemas")
+
+	cmd := &jsonSchemasCmd{Output: outDir}
+	if err := cmd.Execute(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, len(jsonOutputs), len(entries), "unexpected number of schema files")
+
+	data, err := os.ReadFile(filepath.Join(outDir, "dmg-system-query.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, "dmg system query --json", schema.Title, "unexpected title")
+}
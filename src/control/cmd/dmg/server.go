@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -18,7 +18,10 @@ import (
 
 // serverCmd is the struct representing the top-level server subcommand.
 type serverCmd struct {
-	SetLogMasks serverSetLogMasksCmd `command:"set-logmasks" alias:"slm" description:"Set log masks for a set of facilities to a given level and optionally specify debug streams to enable. Setting will be applied to all running DAOS I/O Engines present in the configured dmg hostlist."`
+	SetLogMasks         serverSetLogMasksCmd         `command:"set-logmasks" alias:"slm" description:"Set log masks for a set of facilities to a given level and optionally specify debug streams to enable. Setting will be applied to all running DAOS I/O Engines present in the configured dmg hostlist."`
+	SetControlLogLevels serverSetControlLogLevelsCmd `command:"set-control-log-levels" alias:"scll" description:"Set the log level of the control plane server and optionally of individual control plane subsystems. Setting will be applied to all daos_server processes present in the configured dmg hostlist."`
+	SetProfiling        serverSetProfilingCmd        `command:"set-profiling" alias:"sp" description:"Enable or disable the profiling endpoint of the control plane server, which serves runtime profiles and execution traces for use with go tool pprof and go tool trace. Setting will be applied to all daos_server processes present in the configured dmg hostlist."`
+	GetTunables         serverGetTunablesCmd         `command:"get-tunables" alias:"gt" description:"Display the environment, command line arguments and derived tuning parameters (e.g. ULT stack sizes) with which each DAOS I/O Engine is or would be started by the daos_server processes present in the configured dmg hostlist."`
 }
 
 // serverSetLogMasksCmd is the struct representing the command to set engine log
@@ -72,3 +75,180 @@ func (cmd *serverSetLogMasksCmd) Execute(_ []string) (errOut error) {
 
 	return resp.Errors()
 }
+
+// parseSubsystemLevels parses a string of the form SUBSYS1=LEVEL1,SUBSYS2=LEVEL2
+// into a map of subsystem names to levels.
+func parseSubsystemLevels(in string) (map[string]string, error) {
+	levels := make(map[string]string)
+	for _, pair := range strings.Split(in, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, errors.Errorf("invalid subsystem level %q (expected SUBSYSTEM=LEVEL)", pair)
+		}
+		levels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return levels, nil
+}
+
+// serverSetControlLogLevelsCmd is the struct representing the command to set
+// control plane log levels at runtime.
+type serverSetControlLogLevelsCmd struct {
+	baseCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+	Level      string `long:"level" description:"Set the log level of the control plane server. Supported levels are ERROR, NOTICE, INFO, DEBUG, TRACE"`
+	Subsystems string `short:"s" long:"subsystems" description:"Set the log levels of individual control plane subsystems, overriding the server log level. The input string should look like SUBSYS1=LEVEL1,SUBSYS2=LEVEL2,... Supported subsystems are raft, drpc, storage, events"`
+	Reset      bool   `long:"reset" description:"Reset the server and subsystem log levels to those set in the server config file before applying any other levels specified"`
+}
+
+// Execute is run when serverSetControlLogLevelsCmd activates.
+func (cmd *serverSetControlLogLevelsCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "set control log levels failed")
+	}()
+
+	req := &control.SetControlLogLevelsReq{
+		Level: cmd.Level,
+		Reset: cmd.Reset,
+	}
+	if cmd.Subsystems != "" {
+		subsystems, err := parseSubsystemLevels(cmd.Subsystems)
+		if err != nil {
+			return err
+		}
+		req.Subsystems = subsystems
+	}
+	req.SetHostList(cmd.getHostList())
+
+	cmd.Tracef("set control log levels request: %+v", req)
+
+	resp, err := control.SetControlLogLevels(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	cmd.Tracef("set control log levels response: %+v", resp)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSetControlLogLevelsResp(resp, &out, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if out.Len() > 0 {
+		cmd.Info(out.String())
+	}
+
+	return resp.Errors()
+}
+
+// serverSetProfilingCmd is the struct representing the command to enable or
+// disable the control plane profiling endpoint at runtime.
+type serverSetProfilingCmd struct {
+	baseCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+	Enable  bool   `long:"enable" description:"Enable the profiling endpoint"`
+	Disable bool   `long:"disable" description:"Disable the profiling endpoint"`
+	Address string `short:"a" long:"address" description:"Address (host:port) for the profiling endpoint to listen on. If not set, the address in the server config file or localhost:6060 is used. Addresses other than loopback are rejected unless allow_remote is set in the control_profiling section of the server config file"`
+}
+
+// Execute is run when serverSetProfilingCmd activates.
+func (cmd *serverSetProfilingCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "set profiling failed")
+	}()
+
+	if cmd.Enable == cmd.Disable {
+		return errors.New("exactly one of --enable or --disable must be specified")
+	}
+
+	req := &control.SetProfilingReq{
+		Enable:  cmd.Enable,
+		Address: cmd.Address,
+	}
+	req.SetHostList(cmd.getHostList())
+
+	cmd.Tracef("set profiling request: %+v", req)
+
+	resp, err := control.SetProfiling(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	cmd.Tracef("set profiling response: %+v", resp)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSetProfilingResp(resp, &out, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if out.Len() > 0 {
+		cmd.Info(out.String())
+	}
+
+	return resp.Errors()
+}
+
+// serverGetTunablesCmd is the struct representing the command to display the
+// effective tuning parameters of engines.
+type serverGetTunablesCmd struct {
+	baseCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+	Verbose bool `short:"v" long:"verbose" description:"Display engine command line arguments and environment"`
+}
+
+// Execute is run when serverGetTunablesCmd activates.
+func (cmd *serverGetTunablesCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "get engine tunables failed")
+	}()
+
+	req := new(control.GetEngineTunablesReq)
+	req.SetHostList(cmd.getHostList())
+
+	cmd.Tracef("get engine tunables request: %+v", req)
+
+	resp, err := control.GetEngineTunables(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	cmd.Tracef("get engine tunables response: %+v", resp)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintGetEngineTunablesResp(resp, &out, &outErr, cmd.Verbose); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if out.Len() > 0 {
+		cmd.Info(out.String())
+	}
+
+	return resp.Errors()
+}
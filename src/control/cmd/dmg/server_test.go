@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -56,5 +56,88 @@ func TestDmg_ServerCommands(t *testing.T) {
 			}),
 			nil,
 		},
+		{
+			"Set control log levels with nothing to set",
+			"server set-control-log-levels",
+			"",
+			errors.New("no log levels"),
+		},
+		{
+			"Set control log level",
+			"server set-control-log-levels --level debug",
+			printRequest(t, &control.SetControlLogLevelsReq{Level: "debug"}),
+			nil,
+		},
+		{
+			"Set control log subsystem levels",
+			"server set-control-log-levels -s raft=debug,drpc=error",
+			printRequest(t, &control.SetControlLogLevelsReq{
+				Subsystems: map[string]string{
+					"raft": "debug",
+					"drpc": "error",
+				},
+			}),
+			nil,
+		},
+		{
+			"Set control log levels after reset",
+			"server set-control-log-levels --reset -s storage=trace",
+			printRequest(t, &control.SetControlLogLevelsReq{
+				Reset:      true,
+				Subsystems: map[string]string{"storage": "trace"},
+			}),
+			nil,
+		},
+		{
+			"Set control log levels with malformed subsystems",
+			"server set-control-log-levels -s raft",
+			"",
+			errors.New("expected SUBSYSTEM=LEVEL"),
+		},
+		{
+			"Set profiling with neither enable nor disable",
+			"server set-profiling",
+			"",
+			errors.New("exactly one of"),
+		},
+		{
+			"Set profiling with both enable and disable",
+			"server set-profiling --enable --disable",
+			"",
+			errors.New("exactly one of"),
+		},
+		{
+			"Enable profiling",
+			"server set-profiling --enable",
+			printRequest(t, &control.SetProfilingReq{Enable: true}),
+			nil,
+		},
+		{
+			"Enable profiling with address",
+			"server set-profiling --enable -a localhost:6070",
+			printRequest(t, &control.SetProfilingReq{
+				Enable:  true,
+				Address: "localhost:6070",
+			}),
+			nil,
+		},
+		{
+			"Disable profiling",
+			"server set-profiling --disable",
+			printRequest(t, &control.SetProfilingReq{}),
+			nil,
+		},
+		{
+			"Get engine tunables",
+			"server get-tunables",
+			printRequest(t, &control.GetEngineTunablesReq{}),
+			nil,
+		},
+		{
+			"Get engine tunables; verbose",
+			"server get-tunables -v",
+			printRequest(t, &control.GetEngineTunablesReq{}),
+			nil,
+		},
 	})
 }
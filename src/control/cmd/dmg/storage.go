@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,25 +7,32 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common/cmdutil"
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
 
 // storageCmd is the struct representing the top-level storage subcommand.
 type storageCmd struct {
-	Scan          storageScanCmd    `command:"scan" description:"Scan SCM and NVMe storage attached to remote servers."`
-	Format        storageFormatCmd  `command:"format" description:"Format SCM and NVMe storage attached to remote servers."`
-	Query         storageQueryCmd   `command:"query" description:"Query storage commands, including raw NVMe SSD device health stats and internal blobstore health info."`
-	NvmeRebind    nvmeRebindCmd     `command:"nvme-rebind" description:"Detach NVMe SSD from kernel driver and rebind to userspace driver for use with DAOS."`
-	NvmeAddDevice nvmeAddDeviceCmd  `command:"nvme-add-device" description:"Add a hot-inserted NVMe SSD to a specific engine configuration to enable the new device to be used."`
-	Set           setFaultyCmd      `command:"set" description:"Manually set the device state."`
-	Replace       storageReplaceCmd `command:"replace" description:"Replace a storage device that has been hot-removed with a new device."`
-	LedManage     ledManageCmd      `command:"led" description:"Manage LED status for supported drives."`
+	Scan          storageScanCmd      `command:"scan" description:"Scan SCM and NVMe storage attached to remote servers."`
+	Format        storageFormatCmd    `command:"format" description:"Format SCM and NVMe storage attached to remote servers."`
+	Query         storageQueryCmd     `command:"query" description:"Query storage commands, including raw NVMe SSD device health stats and internal blobstore health info."`
+	NvmeRebind    nvmeRebindCmd       `command:"nvme-rebind" description:"Detach NVMe SSD from kernel driver and rebind to userspace driver for use with DAOS."`
+	NvmeAddDevice nvmeAddDeviceCmd    `command:"nvme-add-device" description:"Add a hot-inserted NVMe SSD to a specific engine configuration to enable the new device to be used."`
+	Set           setFaultyCmd        `command:"set" description:"Manually set the device state."`
+	Replace       storageReplaceCmd   `command:"replace" description:"Replace a storage device that has been hot-removed with a new device."`
+	LedManage     ledManageCmd        `command:"led" description:"Manage LED status for supported drives."`
+	Inventory     storageInventoryCmd `command:"inventory" description:"Report the model, firmware, serial number, capacity and NUMA placement of each NVMe SSD and SCM module attached to remote servers, optionally compared against a previous inventory."`
 }
 
 // storageScanCmd is the struct representing the scan storage subcommand.
@@ -36,6 +43,66 @@ type storageScanCmd struct {
 	cmdutil.JSONOutputCmd
 	Verbose    bool `short:"v" long:"verbose" description:"List SCM & NVMe device details"`
 	NvmeHealth bool `short:"n" long:"nvme-health" description:"Display NVMe device health statistics"`
+	Stream     bool `long:"stream" description:"Display the results of each host as they are received rather than waiting for all hosts"`
+}
+
+// printScanResp prints the errors and storage details of a storage scan
+// response. If hostsOnly is set, nothing is printed for the storage of a
+// response without any hosts.
+func (cmd *storageScanCmd) printScanResp(resp *control.StorageScanResp, hostsOnly bool) error {
+	var outErr strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if hostsOnly && len(resp.HostStorage) == 0 {
+		return nil
+	}
+
+	var out strings.Builder
+	if cmd.NvmeHealth {
+		if err := pretty.PrintNvmeHealthMap(resp.HostStorage, &out); err != nil {
+			return err
+		}
+	} else {
+		verbose := pretty.PrintWithVerboseOutput(cmd.Verbose)
+		if err := pretty.PrintHostStorageMap(resp.HostStorage, &out, verbose); err != nil {
+			return err
+		}
+	}
+	cmd.Info(out.String())
+
+	return nil
+}
+
+// streamScan prints the results of each host as they are received, returning
+// the aggregated response once all hosts have responded.
+func (cmd *storageScanCmd) streamScan(req *control.StorageScanReq) (*control.StorageScanResp, error) {
+	stream, err := control.StreamStorageScan(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(control.StorageScanResp)
+	for result := range stream.Results() {
+		hostResp := new(control.StorageScanResp)
+		if err := hostResp.AddHostResult(result); err != nil {
+			return nil, err
+		}
+		if err := cmd.printScanResp(hostResp, true); err != nil {
+			return nil, err
+		}
+		if err := resp.AddHostResult(result); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
 // Execute is run when storageScanCmd activates.
@@ -45,6 +112,9 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 	if cmd.Verbose && cmd.NvmeHealth {
 		return errors.New("cannot use --verbose with --nvme-health")
 	}
+	if cmd.Stream && cmd.JSONOutputEnabled() {
+		return errors.New("cannot use --stream with --json")
+	}
 
 	req := &control.StorageScanReq{
 		NvmeHealth: cmd.NvmeHealth,
@@ -55,6 +125,14 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 
 	cmd.Debugf("storage scan request: %+v", req)
 
+	if cmd.Stream {
+		resp, err := cmd.streamScan(req)
+		if err != nil {
+			return err
+		}
+		return resp.Errors()
+	}
+
 	resp, err := control.StorageScan(cmd.MustLogCtx(), cmd.ctlInvoker, req)
 	if err != nil {
 		return err
@@ -66,26 +144,86 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 		return cmd.OutputJSON(resp, resp.Errors())
 	}
 
-	var outErr strings.Builder
-	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+	if err := cmd.printScanResp(resp, false); err != nil {
 		return err
 	}
-	if outErr.Len() > 0 {
-		cmd.Error(outErr.String())
+
+	return resp.Errors()
+}
+
+// storageInventoryCmd is the struct representing the storage inventory subcommand.
+type storageInventoryCmd struct {
+	baseCmd
+	ctlInvokerCmd
+	hostListCmd
+	cmdutil.JSONOutputCmd
+	Compare string `short:"c" long:"compare" description:"Path to a previous inventory saved from the JSON output of this command, against which devices that have been added, removed or modified (e.g. a different serial number at the same location) are reported"`
+}
+
+// readStorageInventory reads the devices from a previously saved inventory,
+// which may either be the full JSON output of the inventory command or just
+// its response.
+func readStorageInventory(path string) ([]*control.StorageInventoryDevice, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading previous inventory")
 	}
 
-	var out strings.Builder
-	if cmd.NvmeHealth {
-		if err := pretty.PrintNvmeHealthMap(resp.HostStorage, &out); err != nil {
-			return err
-		}
-	} else {
-		verbose := pretty.PrintWithVerboseOutput(cmd.Verbose)
-		if err := pretty.PrintHostStorageMap(resp.HostStorage, &out, verbose); err != nil {
+	type savedDevices struct {
+		Devices []*control.StorageInventoryDevice `json:"devices"`
+	}
+	var saved struct {
+		Response *savedDevices `json:"response"`
+		savedDevices
+	}
+	if err := json.Unmarshal(buf, &saved); err != nil {
+		return nil, errors.Wrapf(err, "parsing previous inventory %q", path)
+	}
+	if saved.Response != nil {
+		return saved.Response.Devices, nil
+	}
+	return saved.Devices, nil
+}
+
+// Execute is run when storageInventoryCmd activates.
+func (cmd *storageInventoryCmd) Execute(_ []string) error {
+	var previous []*control.StorageInventoryDevice
+	if cmd.Compare != "" {
+		var err error
+		if previous, err = readStorageInventory(cmd.Compare); err != nil {
 			return err
 		}
 	}
-	cmd.Info(out.String())
+
+	req := new(control.StorageInventoryReq)
+	req.SetHostList(cmd.getHostList())
+
+	cmd.Debugf("storage inventory request: %+v", req)
+
+	resp, err := control.StorageInventory(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err
+	}
+	if cmd.Compare != "" {
+		resp.Compare(previous)
+	}
+
+	cmd.Debugf("storage inventory response: %+v", resp)
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintStorageInventoryResp(resp, cmd.Compare != "", &out, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.Error(outErr.String())
+	}
+	if out.Len() > 0 {
+		cmd.Info(out.String())
+	}
 
 	return resp.Errors()
 }
@@ -95,21 +233,53 @@ type storageFormatCmd struct {
 	baseCmd
 	ctlInvokerCmd
 	hostListCmd
+	progressCmd
 	cmdutil.JSONOutputCmd
-	Verbose bool `short:"v" long:"verbose" description:"Show results of each SCM & NVMe device format operation"`
-	Force   bool `long:"force" description:"Force storage format on a host, stopping any running engines (CAUTION: destructive operation)"`
+	Verbose bool   `short:"v" long:"verbose" description:"Show results of each SCM & NVMe device format operation"`
+	Force   bool   `long:"force" description:"Force storage format on a host, stopping any running engines (CAUTION: destructive operation)"`
+	Confirm string `long:"confirm" description:"Perform a forced format of hosts in a running system using the token returned when it was armed"`
+}
+
+// armReformat arms a forced format on the MS, returning the token that must be
+// supplied with --confirm to perform it before the token expires.
+func (cmd *storageFormatCmd) armReformat(ctx context.Context) error {
+	resp, err := control.SystemArm(ctx, cmd.ctlInvoker, &control.SystemArmReq{
+		Operation: control.SystemOpFormat,
+	})
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+	if err != nil {
+		return errors.Wrap(err, "arming storage reformat")
+	}
+
+	cmd.Infof("Storage reformat armed. To reformat the storage, run the command again with "+
+		"--confirm=%s before %s", resp.Token, resp.Expires.Format(time.RFC3339))
+	return nil
 }
 
 // Execute is run when storageFormatCmd activates.
 //
-// Run NVMe and SCM storage format on all connected servers.
+// Run NVMe and SCM storage format on all connected servers. A forced format
+// of hosts in a running system must first be armed on the MS.
 func (cmd *storageFormatCmd) Execute(args []string) (err error) {
 	ctx := cmd.MustLogCtx()
 
-	req := &control.StorageFormatReq{Reformat: cmd.Force}
+	if cmd.Confirm != "" && !cmd.Force {
+		return errors.New("--confirm may only be used with --force")
+	}
+
+	req := &control.StorageFormatReq{Reformat: cmd.Force, Token: cmd.Confirm}
 	req.SetHostList(cmd.getHostList())
+	if pp := cmd.newProgressPrinter("storage format"); pp != nil {
+		req.SetProgressFn(pp.Update)
+		defer pp.Finish()
+	}
 
 	resp, err := control.StorageFormat(ctx, cmd.ctlInvoker, req)
+	if cmd.Confirm == "" && fault.IsFaultCode(err, code.ClientFormatNotArmed) {
+		return cmd.armReformat(ctx)
+	}
 	if err != nil {
 		return err
 	}
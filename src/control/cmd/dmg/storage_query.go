@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,7 @@ package main
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -71,6 +72,7 @@ func (cmd *smdQueryCmd) makeRequest(ctx context.Context, req *control.SmdQueryRe
 // storageQueryCmd is the struct representing the storage query subcommand
 type storageQueryCmd struct {
 	DeviceHealth devHealthQueryCmd   `command:"device-health" description:"Query the device health"`
+	DeviceStats  devStatsQueryCmd    `command:"device-stats" description:"Query device I/O statistics"`
 	ListPools    listPoolsQueryCmd   `command:"list-pools" description:"List pools with NVMe on the server"`
 	ListDevices  listDevicesQueryCmd `command:"list-devices" description:"List storage devices on the server"`
 	Usage        usageQueryCmd       `command:"usage" description:"Show SCM & NVMe storage space utilization per storage server"`
@@ -92,6 +94,33 @@ func (cmd *devHealthQueryCmd) Execute(_ []string) error {
 	return cmd.makeRequest(ctx, req)
 }
 
+type devStatsQueryCmd struct {
+	smdQueryCmd
+	rankCmd
+	UUID   string `short:"u" long:"uuid" description:"Device UUID. All devices queried if arg not set"`
+	Period string `short:"p" long:"period" default:"1s" description:"Period over which I/O rates are sampled"`
+}
+
+func (cmd *devStatsQueryCmd) Execute(_ []string) error {
+	period, err := time.ParseDuration(cmd.Period)
+	if err != nil {
+		return errors.Wrapf(err, "invalid period %q", cmd.Period)
+	}
+	if period < time.Millisecond {
+		return errors.Errorf("period %q is too short", cmd.Period)
+	}
+
+	ctx := cmd.MustLogCtx()
+	req := &control.SmdQueryReq{
+		OmitPools:      true,
+		IncludeIOStats: true,
+		IOStatsPeriod:  period,
+		Rank:           cmd.GetRank(),
+		UUID:           cmd.UUID,
+	}
+	return cmd.makeRequest(ctx, req)
+}
+
 type listDevicesQueryCmd struct {
 	smdQueryCmd
 	rankCmd
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,7 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -39,6 +40,35 @@ func TestStorageQueryCommands(t *testing.T) {
 			}),
 			nil,
 		},
+		{
+			"per-server metadata device stats query",
+			"storage query device-stats",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:           ranklist.NilRank,
+				OmitPools:      true,
+				IncludeIOStats: true,
+				IOStatsPeriod:  time.Second,
+			}),
+			nil,
+		},
+		{
+			"per-server metadata device stats query (rank, uuid and period)",
+			"storage query device-stats --rank 1 --uuid 842c739b-86b5-462f-a7ba-b4a91b674f3d --period 5s",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:           ranklist.Rank(1),
+				OmitPools:      true,
+				IncludeIOStats: true,
+				IOStatsPeriod:  5 * time.Second,
+				UUID:           "842c739b-86b5-462f-a7ba-b4a91b674f3d",
+			}),
+			nil,
+		},
+		{
+			"per-server metadata device stats query (bad period)",
+			"storage query device-stats --period 5",
+			"",
+			errors.New("invalid period"),
+		},
 		{
 			"per-server metadata query pools",
 			"storage query list-pools",
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,11 +7,15 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
 
@@ -49,11 +53,28 @@ func TestStorageCommands(t *testing.T) {
 			"Format with force",
 			"storage format --force",
 			strings.Join([]string{
+				printRequest(t, systemQueryReq),
 				printRequest(t, systemQueryReq),
 				printRequest(t, &control.StorageFormatReq{Reformat: true}),
 			}, " "),
 			nil,
 		},
+		{
+			"Format with force and confirm",
+			"storage format --force --confirm=token",
+			strings.Join([]string{
+				printRequest(t, systemQueryReq),
+				printRequest(t, systemQueryReq),
+				printRequest(t, &control.StorageFormatReq{Reformat: true, Token: "token"}),
+			}, " "),
+			nil,
+		},
+		{
+			"Format with confirm and no force",
+			"storage format --confirm=token",
+			"",
+			errors.New("only be used with --force"),
+		},
 		{
 			"Scan summary",
 			"storage scan",
@@ -88,6 +109,24 @@ func TestStorageCommands(t *testing.T) {
 			"",
 			errors.New("cannot use --verbose"),
 		},
+		{
+			"Scan stream with JSON output",
+			"--json storage scan --stream",
+			"",
+			errors.New("cannot use --stream"),
+		},
+		{
+			"Inventory",
+			"storage inventory",
+			printRequest(t, &control.StorageInventoryReq{}),
+			nil,
+		},
+		{
+			"Inventory; missing previous inventory",
+			"storage inventory --compare /nonexistent/inventory.json",
+			"",
+			errors.New("reading previous inventory"),
+		},
 		{
 			"Rebind NVMe; no PCI address",
 			"storage nvme-rebind",
@@ -162,3 +201,53 @@ func TestStorageCommands(t *testing.T) {
 		},
 	})
 }
+
+func TestStorageCommands_readStorageInventory(t *testing.T) {
+	dev := &control.StorageInventoryDevice{
+		Host:     "host1",
+		Class:    control.StorageClassNvme,
+		Location: "0000:80:00.0",
+		Serial:   "serial1",
+	}
+
+	for name, tc := range map[string]struct {
+		contents   string
+		expDevices []*control.StorageInventoryDevice
+		expErr     error
+	}{
+		"bad json": {
+			contents: "{",
+			expErr:   errors.New("parsing previous inventory"),
+		},
+		"dmg json output": {
+			contents: `{"response":{"host_errors":{"failed":{"hosts":"host2"}},` +
+				`"devices":[{"host":"host1","class":"nvme","location":"0000:80:00.0","serial":"serial1"}]},` +
+				`"error":null,"status":0}`,
+			expDevices: []*control.StorageInventoryDevice{dev},
+		},
+		"response only": {
+			contents:   `{"devices":[{"host":"host1","class":"nvme","location":"0000:80:00.0","serial":"serial1"}]}`,
+			expDevices: []*control.StorageInventoryDevice{dev},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			path := filepath.Join(tmpDir, "inventory.json")
+			if err := os.WriteFile(path, []byte(tc.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			gotDevices, gotErr := readStorageInventory(path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expDevices, gotDevices); diff != "" {
+				t.Fatalf("unexpected devices (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
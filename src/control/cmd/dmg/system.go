@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,10 +7,14 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/jessevdk/go-flags"
 	"github.com/pkg/errors"
 
@@ -21,16 +25,19 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/lib/txtfmt"
 	"github.com/daos-stack/daos/src/control/lib/ui"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 // SystemCmd is the struct representing the top-level system subcommand.
 type SystemCmd struct {
 	LeaderQuery  leaderQueryCmd        `command:"leader-query" description:"Query for current Management Service leader"`
 	Query        systemQueryCmd        `command:"query" description:"Query DAOS system status"`
+	Diff         systemDiffCmd         `command:"diff" description:"Compare two system snapshots saved with 'dmg system query --save'"`
 	Stop         systemStopCmd         `command:"stop" description:"Perform controlled shutdown of DAOS system"`
 	Start        systemStartCmd        `command:"start" description:"Perform start of stopped DAOS system"`
 	Exclude      systemExcludeCmd      `command:"exclude" description:"Exclude ranks from DAOS system"`
 	ClearExclude systemClearExcludeCmd `command:"clear-exclude" description:"Clear excluded state for ranks"`
+	Quarantine   systemQuarantineCmd   `command:"quarantine" description:"Quarantine ranks, excluding them from new pool placements"`
 	Erase        systemEraseCmd        `command:"erase" description:"Erase system metadata prior to reformat"`
 	ListPools    PoolListCmd           `command:"list-pools" description:"List all pools in the DAOS system"`
 	Cleanup      systemCleanupCmd      `command:"cleanup" description:"Clean up all resources associated with the specified machine"`
@@ -39,6 +46,12 @@ type SystemCmd struct {
 	DelAttr      systemDelAttrCmd      `command:"del-attr" description:"Delete system attributes"`
 	SetProp      systemSetPropCmd      `command:"set-prop" description:"Set system properties"`
 	GetProp      systemGetPropCmd      `command:"get-prop" description:"Get system properties"`
+	History      systemHistoryCmd      `command:"history" description:"Display the history of system health snapshots"`
+	Health       systemHealthCmd       `command:"health" description:"Display system health and active critical events"`
+	Events       systemEventsCmd       `command:"events" description:"List or acknowledge persistent events"`
+	Map          systemMapCmd          `command:"map" description:"Map system ranks to hosts, fault domains and NUMA nodes"`
+	DB           systemDBCmd           `command:"db" description:"Inspect the Management Service database"`
+	Token        systemTokenCmd        `command:"token" description:"Manage provisioning tokens for servers joining the system"`
 }
 
 type leaderQueryCmd struct {
@@ -103,11 +116,57 @@ type systemQueryCmd struct {
 	baseCmd
 	cfgCmd
 	ctlInvokerCmd
+	offlineCmd
 	cmdutil.JSONOutputCmd
 	rankListCmd
 	Verbose      bool                  `long:"verbose" short:"v" description:"Display more member details"`
 	NotOK        bool                  `long:"not-ok" description:"Display components in need of administrative investigation"`
 	WantedStates ui.MemberStateSetFlag `long:"with-states" description:"Only show engines in one of a set of comma-separated states"`
+	Save         string                `long:"save" description:"Save the system membership and pools to a file for comparison with 'dmg system diff'"`
+	Swim         bool                  `long:"swim" description:"Collect the local SWIM view of each member and display the ranks that they disagree about"`
+}
+
+// querySwim collects and displays the local SWIM views of the members.
+func (cmd *systemQueryCmd) querySwim() error {
+	resp, err := control.SystemSwimQuery(cmd.MustLogCtx(), cmd.ctlInvoker)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSwimViews(&out, &outErr, resp); err != nil {
+		return err
+	}
+	cmd.Info(out.String())
+	if outErr.String() != "" {
+		cmd.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
+
+// saveSnapshot lists the pools in the system and saves them along with the
+// query results to the requested file.
+func (cmd *systemQueryCmd) saveSnapshot(ctx context.Context, resp *control.SystemQueryResp) error {
+	poolsResp, err := control.ListPools(ctx, cmd.ctlInvoker, new(control.ListPoolsReq))
+	if err != nil {
+		return errors.Wrap(err, "listing pools for snapshot")
+	}
+
+	snap, err := control.NewSystemSnapshot(resp, poolsResp)
+	if err != nil {
+		return err
+	}
+	if err := control.WriteSystemSnapshot(cmd.Save, snap); err != nil {
+		return err
+	}
+	cmd.Debugf("saved system snapshot to %s", cmd.Save)
+
+	return nil
 }
 
 // Execute is run when systemQueryCmd activates.
@@ -119,20 +178,39 @@ func (cmd *systemQueryCmd) Execute(_ []string) (errOut error) {
 	if cmd.NotOK && !cmd.WantedStates.Empty() {
 		return errors.New("--not-ok and --with-states options cannot be set together")
 	}
+	if cmd.Save != "" && (cmd.NotOK || !cmd.WantedStates.Empty() ||
+		cmd.Hosts.Count() > 0 || cmd.Ranks.Count() > 0) {
+		return errors.New("--save cannot be used with options that filter the members queried")
+	}
+	if cmd.Swim && (cmd.Save != "" || cmd.Verbose || cmd.NotOK || !cmd.WantedStates.Empty() ||
+		cmd.Hosts.Count() > 0 || cmd.Ranks.Count() > 0) {
+		return errors.New("--swim cannot be used with other query options")
+	}
 	if err := cmd.validateHostsRanks(); err != nil {
 		return err
 	}
+	if cmd.Swim {
+		return cmd.querySwim()
+	}
 	req := new(control.SystemQueryReq)
 	req.Hosts.Replace(&cmd.Hosts.HostSet)
 	req.Ranks.Replace(&cmd.Ranks.RankSet)
 	req.NotOK = cmd.NotOK
 	req.WantedStates = cmd.WantedStates.States
+	req.EngineUsage = cmd.Verbose
 
-	resp, err := control.SystemQuery(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	ctx := cmd.MustLogCtx()
+	resp, err := control.SystemQuery(ctx, cmd.ctlInvoker, req)
 	if err != nil {
 		return err // control api returned an error, disregard response
 	}
 
+	if cmd.Save != "" {
+		if err := cmd.saveSnapshot(ctx, resp); err != nil {
+			return err
+		}
+	}
+
 	if cmd.JSONOutputEnabled() {
 		return cmd.OutputJSON(resp, resp.Errors())
 	}
@@ -150,13 +228,80 @@ func (cmd *systemQueryCmd) Execute(_ []string) (errOut error) {
 	return resp.Errors()
 }
 
+// systemDiffCmd is the struct representing the command to compare two saved
+// system query snapshots.
+type systemDiffCmd struct {
+	baseCmd
+	cmdutil.JSONOutputCmd
+	Args struct {
+		Before string `positional-arg-name:"before" required:"1"`
+		After  string `positional-arg-name:"after" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when systemDiffCmd activates.
+func (cmd *systemDiffCmd) Execute(_ []string) error {
+	before, err := control.ReadSystemSnapshot(cmd.Args.Before)
+	if err != nil {
+		return err
+	}
+	after, err := control.ReadSystemSnapshot(cmd.Args.After)
+	if err != nil {
+		return err
+	}
+
+	diff, err := control.DiffSystemSnapshots(before, after)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(diff, err)
+	}
+	if err != nil {
+		return errors.Wrap(err, "system diff failed")
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemDiff(&out, diff); err != nil {
+		return err
+	}
+	cmd.Info(out.String())
+
+	return nil
+}
+
+// systemEraseCmd erases the system in two steps. Without a token, the erase
+// is armed on the MS and a token is returned that must be supplied with
+// --confirm to perform the erase before the token expires.
 type systemEraseCmd struct {
 	baseCmd
 	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+	Confirm string `long:"confirm" description:"Perform the erase using the token returned when it was armed"`
 }
 
 func (cmd *systemEraseCmd) Execute(_ []string) error {
-	resp, err := control.SystemErase(cmd.MustLogCtx(), cmd.ctlInvoker, new(control.SystemEraseReq))
+	ctx := cmd.MustLogCtx()
+
+	if cmd.Confirm == "" {
+		resp, err := control.SystemArm(ctx, cmd.ctlInvoker, &control.SystemArmReq{
+			Operation: control.SystemOpErase,
+		})
+		if cmd.JSONOutputEnabled() {
+			return cmd.OutputJSON(resp, err)
+		}
+		if err != nil {
+			return errors.Wrap(err, "arming system erase")
+		}
+
+		cmd.Infof("System erase armed. To erase all system metadata, run the following before %s:\n"+
+			"  dmg system erase --confirm=%s", resp.Expires.Format(time.RFC3339), resp.Token)
+		return nil
+	}
+
+	resp, err := control.SystemErase(ctx, cmd.ctlInvoker, &control.SystemEraseReq{
+		Token: cmd.Confirm,
+	})
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -171,7 +316,8 @@ type systemStopCmd struct {
 	ctlInvokerCmd
 	cmdutil.JSONOutputCmd
 	rankListCmd
-	Force bool `long:"force" description:"Force stop DAOS system members"`
+	Force   bool `long:"force" description:"Force stop DAOS system members"`
+	Orderly bool `long:"orderly" description:"Stop all ranks with MS replicas last and record a clean shutdown"`
 }
 
 // Execute is run when systemStopCmd activates.
@@ -185,7 +331,10 @@ func (cmd *systemStopCmd) Execute(_ []string) (errOut error) {
 	if err := cmd.validateHostsRanks(); err != nil {
 		return err
 	}
-	req := &control.SystemStopReq{Force: cmd.Force}
+	if cmd.Orderly && (cmd.Force || cmd.Hosts.Count() > 0 || cmd.Ranks.Count() > 0) {
+		return errors.New("--orderly may not be used with --force, --ranks or --rank-hosts")
+	}
+	req := &control.SystemStopReq{Force: cmd.Force, Orderly: cmd.Orderly}
 	req.Hosts.Replace(&cmd.Hosts.HostSet)
 	req.Ranks.Replace(&cmd.Ranks.RankSet)
 
@@ -268,6 +417,58 @@ func (cmd *systemClearExcludeCmd) Execute(_ []string) error {
 	return cmd.execute(true)
 }
 
+// systemQuarantineCmd is the struct representing the command to quarantine
+// ranks or to clear their quarantined state.
+type systemQuarantineCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+	rankListCmd
+	Clear  bool   `long:"clear" description:"Clear quarantined state for ranks"`
+	Reason string `long:"reason" description:"Reason for the quarantine, reported in the rank info"`
+}
+
+// Execute is run when systemQuarantineCmd activates.
+func (cmd *systemQuarantineCmd) Execute(_ []string) error {
+	if err := cmd.validateHostsRanks(); err != nil {
+		return err
+	}
+	if cmd.Ranks.Count() == 0 && cmd.Hosts.Count() == 0 {
+		return errors.New("no ranks or hosts specified")
+	}
+	if cmd.Clear && cmd.Reason != "" {
+		return errors.New("--reason may not be used with --clear")
+	}
+
+	req := &control.SystemQuarantineReq{Clear: cmd.Clear, Reason: cmd.Reason}
+	req.Hosts.Replace(&cmd.Hosts.HostSet)
+	req.Ranks.Replace(&cmd.Ranks.RankSet)
+
+	resp, err := control.SystemQuarantine(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	updated := ranklist.NewRankSet()
+	for _, result := range resp.Results {
+		if !result.Errored {
+			updated.Add(result.Rank)
+		}
+	}
+
+	if resp.Errors() != nil {
+		cmd.Errorf("Errors: %s", resp.Errors())
+	}
+	cmd.Infof("updated ranks: %s", updated)
+
+	return resp.Errors()
+}
+
 // systemStartCmd is the struct representing the command to start system.
 type systemStartCmd struct {
 	baseCmd
@@ -275,6 +476,7 @@ type systemStartCmd struct {
 	ctlInvokerCmd
 	cmdutil.JSONOutputCmd
 	rankListCmd
+	Orderly bool `long:"orderly" description:"Start all ranks with MS replicas first after a clean shutdown"`
 }
 
 // Execute is run when systemStartCmd activates.
@@ -286,7 +488,10 @@ func (cmd *systemStartCmd) Execute(_ []string) (errOut error) {
 	if err := cmd.validateHostsRanks(); err != nil {
 		return err
 	}
-	req := new(control.SystemStartReq)
+	if cmd.Orderly && (cmd.Hosts.Count() > 0 || cmd.Ranks.Count() > 0) {
+		return errors.New("--orderly may not be used with --ranks or --rank-hosts")
+	}
+	req := &control.SystemStartReq{Orderly: cmd.Orderly}
 	req.Hosts.Replace(&cmd.Hosts.HostSet)
 	req.Ranks.Replace(&cmd.Ranks.RankSet)
 
@@ -649,3 +854,298 @@ func (cmd *systemGetPropCmd) Execute(_ []string) error {
 
 	return nil
 }
+
+// systemHistoryCmd represents the command to display the history of system
+// health snapshots recorded by the MS leader.
+type systemHistoryCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+
+	Count   uint32 `long:"count" short:"n" description:"Display only the most recent snapshots (default: all)"`
+	Verbose bool   `long:"verbose" short:"v" description:"Display per-pool details for each snapshot"`
+}
+
+// Execute is run when systemHistoryCmd subcommand is activated.
+func (cmd *systemHistoryCmd) Execute(_ []string) error {
+	req := &control.SystemHistoryReq{
+		MaxEntries: cmd.Count,
+	}
+
+	resp, err := control.SystemHistory(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system history failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintSystemHistoryResponse(&bld, resp, cmd.Verbose); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
+
+// systemHealth is the JSON output of the system health command.
+type systemHealth struct {
+	Snapshot *system.HealthSnapshot    `json:"snapshot"`
+	MSBackup *system.MSBackupStatus    `json:"ms_backup"`
+	Events   []*system.PersistentEvent `json:"active_events"`
+}
+
+// systemHealthCmd represents the command to display a summary of the latest
+// system health snapshot along with any active critical events.
+type systemHealthCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+}
+
+// Execute is run when systemHealthCmd subcommand is activated.
+func (cmd *systemHealthCmd) Execute(_ []string) error {
+	ctx := cmd.MustLogCtx()
+
+	hist, err := control.SystemHistory(ctx, cmd.ctlInvoker, &control.SystemHistoryReq{
+		MaxEntries: 1,
+	})
+	if err != nil {
+		if cmd.JSONOutputEnabled() {
+			return cmd.OutputJSON(nil, err)
+		}
+		return errors.Wrap(err, "system health failed")
+	}
+
+	evts, err := control.SystemEvents(ctx, cmd.ctlInvoker, &control.SystemEventsReq{
+		ActiveOnly: true,
+	})
+	if cmd.JSONOutputEnabled() {
+		var out systemHealth
+		if len(hist.Snapshots) > 0 {
+			out.Snapshot = hist.Snapshots[len(hist.Snapshots)-1]
+		}
+		out.MSBackup = hist.MSBackup
+		if evts != nil {
+			out.Events = evts.Events
+		}
+		return cmd.OutputJSON(out, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system health failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintSystemHealth(&bld, hist, evts); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
+
+// systemEventsCmd is the struct representing the system events subcommands.
+type systemEventsCmd struct {
+	List systemEventsListCmd `command:"list" description:"List persistent events recorded by the MS"`
+	Ack  systemEventsAckCmd  `command:"ack" description:"Acknowledge active persistent events"`
+}
+
+// systemEventsListCmd represents the command to list the persistent events
+// recorded by the MS leader.
+type systemEventsListCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+
+	All bool `long:"all" short:"a" description:"Include acknowledged events"`
+}
+
+// Execute is run when systemEventsListCmd subcommand is activated.
+func (cmd *systemEventsListCmd) Execute(_ []string) error {
+	req := &control.SystemEventsReq{
+		ActiveOnly: !cmd.All,
+	}
+
+	resp, err := control.SystemEvents(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system events list failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintSystemEventsResponse(&bld, resp, req.ActiveOnly); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	return nil
+}
+
+// systemEventsAckCmd represents the command to acknowledge persistent events
+// so that they are no longer reported as active.
+type systemEventsAckCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+
+	Args struct {
+		IDs []uint64 `positional-arg-name:"<event id>" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when systemEventsAckCmd subcommand is activated.
+func (cmd *systemEventsAckCmd) Execute(_ []string) error {
+	req := &control.SystemEventAckReq{
+		IDs: cmd.Args.IDs,
+	}
+
+	err := control.SystemEventAck(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(nil, err)
+	}
+
+	if err != nil {
+		return err
+	}
+	cmd.Infof("acknowledged %s", english.Plural(len(req.IDs), "event", ""))
+
+	return nil
+}
+
+// systemTokenCmd is the struct representing the system token subcommands.
+type systemTokenCmd struct {
+	Create systemTokenCreateCmd `command:"create" description:"Create a provisioning token for servers joining the system for the first time"`
+}
+
+// systemTokenCreateCmd represents the command to create a signed provisioning
+// token.
+type systemTokenCreateCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+
+	Lifetime time.Duration `long:"lifetime" short:"l" default:"24h" description:"Length of time for which the token is valid"`
+}
+
+// Execute is run when systemTokenCreateCmd subcommand is activated.
+func (cmd *systemTokenCreateCmd) Execute(_ []string) error {
+	req := &control.SystemTokenCreateReq{
+		Lifetime: cmd.Lifetime,
+	}
+
+	resp, err := control.SystemTokenCreate(cmd.MustLogCtx(), cmd.ctlInvoker, req)
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+	cmd.Infof("Token ID: %s", resp.ID)
+	cmd.Infof("Expires:  %s", resp.Expires.Format(time.RFC3339))
+	cmd.Infof("Token:    %s", resp.Token)
+
+	return nil
+}
+
+// systemMapCmd represents the command to export the location of each system
+// rank for use by the topology plugins of external job schedulers.
+type systemMapCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	offlineCmd
+	cmdutil.JSONOutputCmd
+
+	Format string `long:"format" short:"f" choice:"table" choice:"json" choice:"slurm-topology" default:"table" description:"Output format"`
+}
+
+// Execute is run when systemMapCmd subcommand is activated.
+func (cmd *systemMapCmd) Execute(_ []string) error {
+	resp, err := control.SystemMap(cmd.MustLogCtx(), cmd.ctlInvoker)
+	if cmd.JSONOutputEnabled() {
+		if err != nil {
+			return cmd.OutputJSON(nil, err)
+		}
+		return cmd.OutputJSON(resp, resp.Errors())
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system map failed")
+	}
+	if hostErrs := resp.Errors(); hostErrs != nil {
+		cmd.Errorf("NUMA nodes unknown for ranks on some hosts: %s", hostErrs)
+	}
+
+	var bld strings.Builder
+	switch cmd.Format {
+	case "json":
+		// Write the map without the dmg response wrapper so that it can be
+		// consumed directly by scheduler tooling.
+		data, err := json.MarshalIndent(resp.Ranks, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "encoding system map")
+		}
+		bld.Write(data)
+	case "slurm-topology":
+		err = pretty.PrintSlurmTopology(&bld, resp)
+	default:
+		err = pretty.PrintSystemMap(&bld, resp)
+	}
+	if err != nil {
+		return err
+	}
+	cmd.Info(bld.String())
+
+	return nil
+}
+
+// systemDBCmd is the struct representing the system database subcommands.
+type systemDBCmd struct {
+	VerifyReplicas systemDBVerifyCmd `command:"verify-replicas" description:"Compare the database state of the MS replicas"`
+}
+
+// systemDBVerifyCmd represents the command to compare the content hashes of
+// the database state on each MS replica, in order to detect replicas whose
+// state has silently diverged from that of the leader.
+type systemDBVerifyCmd struct {
+	baseCmd
+	cfgCmd
+	ctlInvokerCmd
+	cmdutil.JSONOutputCmd
+}
+
+// Execute is run when systemDBVerifyCmd subcommand is activated.
+func (cmd *systemDBVerifyCmd) Execute(_ []string) error {
+	resp, err := control.SystemDBVerify(cmd.MustLogCtx(), cmd.ctlInvoker, new(control.SystemDBVerifyReq))
+	if cmd.JSONOutputEnabled() {
+		return cmd.OutputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "system db verify-replicas failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintSystemDBVerifyResponse(&bld, resp); err != nil {
+		return err
+	}
+	cmd.Infof("%s", bld.String())
+
+	if diverged := resp.Diverged(); len(diverged) > 0 {
+		return errors.Errorf("MS database state diverged on %s",
+			english.Plural(len(diverged), "replica", ""))
+	}
+	return resp.Errors()
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -132,11 +133,73 @@ func TestDmg_SystemCommands(t *testing.T) {
 			"",
 			errors.New("--not-ok and --with-states options cannot be set together"),
 		},
+		{
+			"system query with save and ranks specified",
+			"system query --save /tmp/snap.json --ranks 0",
+			"",
+			errors.New("--save cannot be used with options that filter"),
+		},
+		{
+			"system query with save and not-ok specified",
+			"system query --save /tmp/snap.json --not-ok",
+			"",
+			errors.New("--save cannot be used with options that filter"),
+		},
+		{
+			"system query swim",
+			"system query --swim",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system query with swim and ranks specified",
+			"system query --swim --ranks 0",
+			"",
+			errors.New("--swim cannot be used with other query options"),
+		},
+		{
+			"system query with swim and verbose specified",
+			"system query --swim --verbose",
+			"",
+			errors.New("--swim cannot be used with other query options"),
+		},
+		{
+			"system diff with missing snapshot",
+			"system diff /does/not/exist.json /does/not/exist2.json",
+			"",
+			errors.New("reading system snapshot"),
+		},
+		{
+			"system diff with one snapshot",
+			"system diff /does/not/exist.json",
+			"",
+			errors.New("required argument"),
+		},
 		{
 			"system query verbose",
 			"system query --verbose",
 			strings.Join([]string{
-				printRequest(t, &control.SystemQueryReq{}),
+				printRequest(t, &control.SystemQueryReq{EngineUsage: true}),
+			}, " "),
+			nil,
+		},
+		{
+			"system erase arms erase",
+			"system erase",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{FailOnUnavailable: true}),
+				printRequest(t, &control.SystemArmReq{Operation: control.SystemOpErase}),
+			}, " "),
+			nil,
+		},
+		{
+			"system erase with token",
+			"system erase --confirm=token",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{FailOnUnavailable: true}),
+				printRequest(t, &control.SystemEraseReq{Token: "token"}),
 			}, " "),
 			nil,
 		},
@@ -156,6 +219,54 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system stop orderly",
+			"system stop --orderly",
+			strings.Join([]string{
+				printRequest(t, &control.SystemStopReq{Orderly: true}),
+			}, " "),
+			nil,
+		},
+		{
+			"system stop orderly with force",
+			"system stop --orderly --force",
+			"",
+			errors.New("--orderly may not be used"),
+		},
+		{
+			"system quarantine with ranks and reason",
+			"system quarantine --ranks 0-1 --reason suspect",
+			strings.Join([]string{
+				printRequest(t, withRanks(&control.SystemQuarantineReq{Reason: "suspect"}, 0, 1)),
+			}, " "),
+			nil,
+		},
+		{
+			"system quarantine clear with hosts",
+			"system quarantine --rank-hosts foo-[0-1] --clear",
+			strings.Join([]string{
+				printRequest(t, withHosts(&control.SystemQuarantineReq{Clear: true}, "foo-[0-1]")),
+			}, " "),
+			nil,
+		},
+		{
+			"system quarantine with no ranks or hosts",
+			"system quarantine",
+			"",
+			errors.New("no ranks or hosts specified"),
+		},
+		{
+			"system quarantine clear with reason",
+			"system quarantine --ranks 0 --clear --reason suspect",
+			"",
+			errors.New("--reason may not be used"),
+		},
+		{
+			"system stop orderly with ranks",
+			"system stop --orderly --ranks 0",
+			"",
+			errors.New("--orderly may not be used"),
+		},
 		{
 			"system stop with single rank",
 			"system stop --ranks 0",
@@ -200,6 +311,20 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system start orderly",
+			"system start --orderly",
+			strings.Join([]string{
+				printRequest(t, &control.SystemStartReq{Orderly: true}),
+			}, " "),
+			nil,
+		},
+		{
+			"system start orderly with hosts",
+			"system start --orderly --rank-hosts foo-1",
+			"",
+			errors.New("--orderly may not be used"),
+		},
 		{
 			"system start with single rank",
 			"system start --ranks 0",
@@ -302,6 +427,133 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system history",
+			"system history",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system history with count",
+			"system history -n 24 --verbose",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{
+					MaxEntries: 24,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system health",
+			"system health",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{
+					MaxEntries: 1,
+				}),
+				printRequest(t, &control.SystemEventsReq{
+					ActiveOnly: true,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system events list",
+			"system events list",
+			strings.Join([]string{
+				printRequest(t, &control.SystemEventsReq{
+					ActiveOnly: true,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system events list all",
+			"system events list --all",
+			strings.Join([]string{
+				printRequest(t, &control.SystemEventsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system events ack",
+			"system events ack 3 5",
+			strings.Join([]string{
+				printRequest(t, &control.SystemEventAckReq{
+					IDs: []uint64{3, 5},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system events ack without ids",
+			"system events ack",
+			"",
+			errors.New("required argument"),
+		},
+		{
+			"system events ack bad id",
+			"system events ack foo",
+			"",
+			errors.New("invalid syntax"),
+		},
+		{
+			"system token create",
+			"system token create",
+			strings.Join([]string{
+				printRequest(t, &control.SystemTokenCreateReq{
+					Lifetime: 24 * time.Hour,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system token create with lifetime",
+			"system token create --lifetime 2h",
+			strings.Join([]string{
+				printRequest(t, &control.SystemTokenCreateReq{
+					Lifetime: 2 * time.Hour,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system token create bad lifetime",
+			"system token create --lifetime forever",
+			"",
+			errors.New("invalid duration"),
+		},
+		{
+			"system db verify-replicas",
+			"system db verify-replicas",
+			strings.Join([]string{
+				printRequest(t, &control.SystemDBVerifyReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system map",
+			"system map",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system map slurm topology",
+			"system map --format slurm-topology",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system map bad format",
+			"system map --format yaml",
+			"",
+			errors.New("Invalid value `yaml'"),
+		},
 		{
 			"Non-existent subcommand",
 			"system quack",
@@ -0,0 +1,65 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const traceFlushTimeout = 5 * time.Second
+
+// activeCommandName returns the full name of the command selected by the parser,
+// e.g. "dmg pool create".
+func activeCommandName(p *flags.Parser) string {
+	names := []string{p.Name}
+	for cmd := p.Active; cmd != nil; cmd = cmd.Active {
+		names = append(names, cmd.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// startTrace begins a trace covering the execution of the named command. The
+// returned function ends the trace, prints the trace ID and flushes spans to the
+// OpenTelemetry collector set in the environment, if any.
+func startTrace(log logging.Logger, name string, out io.Writer) (context.Context, func(error)) {
+	var exp *tracing.OTLPExporter
+	if endpoint := tracing.EndpointFromEnv(); endpoint != "" {
+		var err error
+		if exp, err = tracing.NewOTLPExporter(log, endpoint, build.AdminUtilName); err != nil {
+			log.Errorf("tracing: %s", err)
+		} else {
+			tracing.SetExporter(exp)
+		}
+	}
+
+	ctx, span := tracing.StartTrace(context.Background(), name, tracing.SpanKindClient)
+	return ctx, func(cmdErr error) {
+		span.SetError(cmdErr)
+		span.Finish()
+		fmt.Fprintf(out, "trace ID: %s\n", span.SpanContext().TraceID)
+
+		if exp == nil {
+			return
+		}
+		tracing.SetExporter(nil)
+		flushCtx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		if err := exp.Shutdown(flushCtx); err != nil {
+			log.Errorf("tracing: flushing spans: %s", err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestDmg_startTrace(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	t.Setenv(tracing.OTLPEndpointEnv, "")
+
+	var out strings.Builder
+	ctx, finish := startTrace(log, "dmg pool create", &out)
+
+	span := tracing.SpanFromContext(ctx)
+	if span == nil {
+		t.Fatal("expected root span in context")
+	}
+	test.AssertEqual(t, "dmg pool create", span.Name, "unexpected span name")
+
+	finish(errors.New("failed"))
+
+	test.CmpErr(t, errors.New("failed"), span.Err)
+	test.AssertEqual(t, "trace ID: "+span.SpanContext().TraceID.String()+"\n", out.String(),
+		"unexpected output")
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2022 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -13,6 +13,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/ui"
 )
@@ -31,6 +32,21 @@ func (shf *singleHostFlag) UnmarshalFlag(value string) error {
 	return nil
 }
 
+// hostListFlag extends ui.HostSetFlag to accept host groups sourced from a
+// control.HostListProvider, e.g. "@slurm:partition1".
+type hostListFlag struct {
+	ui.HostSetFlag
+}
+
+func (hlf *hostListFlag) UnmarshalFlag(value string) error {
+	hosts, err := control.ExpandHostList([]string{value})
+	if err != nil {
+		return err
+	}
+
+	return hlf.HostSetFlag.UnmarshalFlag(strings.Join(hosts, ","))
+}
+
 // formatHostGroups adds group title header per group results.
 func formatHostGroups(buf *bytes.Buffer, groups hostlist.HostGroups) string {
 	for _, res := range groups.Keys() {
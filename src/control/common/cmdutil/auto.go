@@ -9,4 +9,5 @@ type ConfGenCmd struct {
 	UseTmpfsSCM     bool   `short:"t" long:"use-tmpfs-scm" description:"Use tmpfs for scm rather than PMem"`
 	ExtMetadataPath string `short:"m" long:"control-metadata-path" description:"External storage path to store control metadata. Set this to a persistent location and specify --use-tmpfs-scm to create an MD-on-SSD config"`
 	FabricPorts     string `short:"f" long:"fabric-ports" description:"Allow custom fabric interface ports to be specified for each engine config section. Comma separated port numbers, one per engine"`
+	BdevNUMA        string `long:"bdev-numa" description:"Override the detected NUMA node of NVMe SSDs so that they are assigned to the engine on the given node. Comma separated <pci-address>=<numa-node> pairs"`
 }
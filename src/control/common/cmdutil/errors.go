@@ -0,0 +1,140 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package cmdutil
+
+import (
+	"reflect"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// Process exit codes returned by command-line tools. Faults are mapped to a
+// distinct exit code per block of fault codes (see fault/code), starting at
+// ExitFaultBase.
+const (
+	// ExitSuccess indicates that the command completed successfully.
+	ExitSuccess = 0
+	// ExitFailure indicates a failure that could not be classified.
+	ExitFailure = 1
+	// ExitUsage indicates that the command line was invalid.
+	ExitUsage = 2
+	// ExitDaosStatus indicates that a DAOS operation returned an error status.
+	ExitDaosStatus = 3
+	// ExitFaultBase is added to the fault code block number to
+	// produce the exit code for a fault.
+	ExitFaultBase = 10
+)
+
+// Error classes for errors that are not faults.
+const (
+	ErrorClassUnknown = "unknown"
+	ErrorClassUsage   = "usage"
+	ErrorClassDaos    = "daos"
+)
+
+// ErrorInfo provides a machine-readable description of an error.
+type ErrorInfo struct {
+	Class      string `json:"class"`
+	Code       int    `json:"code"`
+	Error      string `json:"error"`
+	Domain     string `json:"domain,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+}
+
+func isUsageError(err error) bool {
+	fe, ok := errors.Cause(err).(*flags.Error)
+	return ok && fe.Type != flags.ErrHelp
+}
+
+// GetErrorInfo returns a machine-readable description of the given error.
+// For faults, the code is the fault code and the class is derived from the
+// block of fault codes containing it. For DAOS errors, the code is the DAOS
+// status.
+func GetErrorInfo(err error) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+
+	info := &ErrorInfo{
+		Class: ErrorClassUnknown,
+		Error: err.Error(),
+	}
+
+	switch cause := errors.Cause(err).(type) {
+	case *fault.Fault:
+		info.Class = cause.Code.Class()
+		info.Code = int(cause.Code)
+		info.Domain = cause.Domain
+		info.Reason = cause.Reason
+		info.Resolution = cause.Resolution
+	case daos.Status:
+		info.Class = ErrorClassDaos
+		info.Code = int(cause)
+	default:
+		if isUsageError(err) {
+			info.Class = ErrorClassUsage
+		}
+	}
+
+	return info
+}
+
+// ExitCode returns the process exit code to be used for the given error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	switch cause := errors.Cause(err).(type) {
+	case *fault.Fault:
+		return ExitFaultBase + cause.Code.Block()
+	case daos.Status:
+		return ExitDaosStatus
+	default:
+		if isUsageError(err) {
+			return ExitUsage
+		}
+		return ExitFailure
+	}
+}
+
+// hostErrorsGetter is implemented by responses that contain errors reported
+// by individual hosts.
+type hostErrorsGetter interface {
+	HostErrorsByHostSet() map[string][]error
+}
+
+// getHostErrorInfo returns a machine-readable description of each of the
+// host errors in the response, keyed by the set of hosts reporting them.
+func getHostErrorInfo(in interface{}) map[string][]*ErrorInfo {
+	heg, ok := in.(hostErrorsGetter)
+	if !ok {
+		return nil
+	}
+	if rv := reflect.ValueOf(in); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	hostErrs := heg.HostErrorsByHostSet()
+	if len(hostErrs) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]*ErrorInfo)
+	for hosts, errs := range hostErrs {
+		for _, err := range errs {
+			out[hosts] = append(out[hosts], GetErrorInfo(err))
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,159 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+func TestCmdutil_ErrorInfo(t *testing.T) {
+	testFault := &fault.Fault{
+		Domain:      "scm",
+		Code:        code.ScmNoPMem,
+		Description: "no pmem",
+		Reason:      "no pmem modules",
+		Resolution:  "install pmem",
+	}
+
+	for name, tc := range map[string]struct {
+		err     error
+		expInfo *ErrorInfo
+		expExit int
+	}{
+		"nil": {
+			expExit: ExitSuccess,
+		},
+		"generic": {
+			err: errors.New("whoops"),
+			expInfo: &ErrorInfo{
+				Class: ErrorClassUnknown,
+				Error: "whoops",
+			},
+			expExit: ExitFailure,
+		},
+		"usage": {
+			err: &flags.Error{Type: flags.ErrUnknownFlag, Message: "bad flag"},
+			expInfo: &ErrorInfo{
+				Class: ErrorClassUsage,
+				Error: "bad flag",
+			},
+			expExit: ExitUsage,
+		},
+		"help": {
+			err: &flags.Error{Type: flags.ErrHelp, Message: "help"},
+			expInfo: &ErrorInfo{
+				Class: ErrorClassUnknown,
+				Error: "help",
+			},
+			expExit: ExitFailure,
+		},
+		"daos status": {
+			err: errors.Wrap(daos.Nonexistent, "pool query"),
+			expInfo: &ErrorInfo{
+				Class: ErrorClassDaos,
+				Code:  int(daos.Nonexistent),
+				Error: errors.Wrap(daos.Nonexistent, "pool query").Error(),
+			},
+			expExit: ExitDaosStatus,
+		},
+		"wrapped fault": {
+			err: errors.Wrap(testFault, "scan"),
+			expInfo: &ErrorInfo{
+				Class:      "scm",
+				Code:       int(code.ScmNoPMem),
+				Error:      errors.Wrap(testFault, "scan").Error(),
+				Domain:     "scm",
+				Reason:     "no pmem modules",
+				Resolution: "install pmem",
+			},
+			expExit: ExitFaultBase + 2,
+		},
+		"unknown fault class": {
+			err: &fault.Fault{Code: 9999},
+			expInfo: &ErrorInfo{
+				Class: "unknown",
+				Code:  9999,
+				Error: (&fault.Fault{Code: 9999}).Error(),
+			},
+			expExit: ExitFaultBase + 99,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expInfo, GetErrorInfo(tc.err)); diff != "" {
+				t.Fatalf("unexpected error info (-want, +got):\n%s\n", diff)
+			}
+			if gotExit := ExitCode(tc.err); gotExit != tc.expExit {
+				t.Fatalf("expected exit code %d, got %d", tc.expExit, gotExit)
+			}
+		})
+	}
+}
+
+type testHostErrorsResp struct {
+	hostErrs map[string][]error
+}
+
+func (r *testHostErrorsResp) HostErrorsByHostSet() map[string][]error {
+	return r.hostErrs
+}
+
+func TestCmdutil_OutputJSON_HostErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in            interface{}
+		expHostErrors map[string][]*ErrorInfo
+	}{
+		"nil response": {
+			in: (*testHostErrorsResp)(nil),
+		},
+		"no host errors": {
+			in: &testHostErrorsResp{},
+		},
+		"host errors": {
+			in: &testHostErrorsResp{
+				hostErrs: map[string][]error{
+					"host[1-2]": {daos.Busy},
+					"host3":     {errors.New("whoops")},
+				},
+			},
+			expHostErrors: map[string][]*ErrorInfo{
+				"host[1-2]": {
+					{Class: ErrorClassDaos, Code: int(daos.Busy), Error: daos.Busy.Error()},
+				},
+				"host3": {
+					{Class: ErrorClassUnknown, Error: "whoops"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := OutputJSON(&buf, tc.in, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			var out struct {
+				HostErrors map[string][]*ErrorInfo `json:"host_errors"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expHostErrors, out.HostErrors); diff != "" {
+				t.Fatalf("unexpected host errors (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2023 Intel Corporation.
+// (C) Copyright 2023-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -14,6 +14,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/lib/atm"
 	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/jsonschema"
 )
 
 var _ JSONOutputter = (*JSONOutputCmd)(nil)
@@ -27,6 +28,40 @@ type (
 	}
 )
 
+// jsonOutput is the envelope of JSON command output.
+type jsonOutput struct {
+	Response   interface{}             `json:"response"`
+	Error      *string                 `json:"error"`
+	Status     int                     `json:"status"`
+	ErrorInfo  *ErrorInfo              `json:"error_info,omitempty"`
+	HostErrors map[string][]*ErrorInfo `json:"host_errors,omitempty"`
+}
+
+// JSONOutputSchema returns the schema of the JSON output of a command whose
+// response is one of the given types. The response is always allowed to be
+// null, as it is on error; with no types, it is only ever null.
+func JSONOutputSchema(responses ...interface{}) *jsonschema.Schema {
+	r := jsonschema.NewReflector()
+
+	respSchemas := make([]*jsonschema.Schema, 0, len(responses)+1)
+	for _, resp := range responses {
+		respSchemas = append(respSchemas, r.Reflect(resp))
+	}
+	respSchemas = append(respSchemas, &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeNull}})
+
+	// Describe the envelope inline rather than as a definition.
+	r.Reflect(jsonOutput{})
+	defs := r.Defs()
+	envelope := defs["cmdutil.jsonOutput"]
+	delete(defs, "cmdutil.jsonOutput")
+
+	envelope.Schema = jsonschema.Draft
+	envelope.Properties["response"] = &jsonschema.Schema{AnyOf: respSchemas}
+	envelope.Defs = defs
+
+	return envelope
+}
+
 // OutputJSON writes the given data or error to the given writer as JSON.
 func OutputJSON(writer io.Writer, in interface{}, inErr error) error {
 	status := 0
@@ -40,11 +75,13 @@ func OutputJSON(writer io.Writer, in interface{}, inErr error) error {
 		}
 	}
 
-	data, err := json.MarshalIndent(struct {
-		Response interface{} `json:"response"`
-		Error    *string     `json:"error"`
-		Status   int         `json:"status"`
-	}{in, errStr, status}, "", "  ")
+	data, err := json.MarshalIndent(&jsonOutput{
+		Response:   in,
+		Error:      errStr,
+		Status:     status,
+		ErrorInfo:  GetErrorInfo(inErr),
+		HostErrors: getHostErrorInfo(in),
+	}, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -16,7 +16,10 @@ import (
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
-var _ LogSetter = (*LogCmd)(nil)
+var (
+	_ LogSetter     = (*LogCmd)(nil)
+	_ ContextSetter = (*LogCmd)(nil)
+)
 
 type (
 	// LogSetter defines an interface to be implemented by types
@@ -25,17 +28,26 @@ type (
 		SetLog(log logging.Logger)
 	}
 
+	// ContextSetter defines an interface to be implemented by types
+	// that can set the parent of the contexts used by the command.
+	ContextSetter interface {
+		SetContext(ctx context.Context)
+	}
+
 	// LogCmd is an embeddable type that extends a command with
 	// logging capabilities.
 	LogCmd struct {
 		logging.Logger
+		parent context.Context
 	}
 
 	// LogConfig contains parameters used to configure the logger.
 	LogConfig struct {
-		LogFile  string
-		LogLevel common.ControlLogLevel
-		JSON     bool
+		LogFile         string
+		LogLevel        common.ControlLogLevel
+		JSON            bool
+		Syslog          bool
+		SubsystemLevels map[string]common.ControlLogLevel
 	}
 )
 
@@ -44,9 +56,19 @@ func (cmd *LogCmd) SetLog(log logging.Logger) {
 	cmd.Logger = log
 }
 
+// SetContext sets the parent of the contexts returned by LogCtx, e.g. to
+// carry trace context. The background context is used if none is set.
+func (cmd *LogCmd) SetContext(ctx context.Context) {
+	cmd.parent = ctx
+}
+
 // LogCtx returns a context with the command's logger set.
 func (cmd *LogCmd) LogCtx() (context.Context, error) {
-	return logging.ToContext(context.Background(), cmd.Logger)
+	parent := cmd.parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	return logging.ToContext(parent, cmd.Logger)
 }
 
 // MustLogCtx returns a context with the command's logger set.
@@ -88,8 +110,19 @@ func ConfigureLogger(logIn logging.Logger, cfg LogConfig) error {
 			log = log.WithJSONOutput()
 		}
 
-		log.Debugf("configured logging: level=%s, file=%s, json=%v",
-			cfg.LogLevel, cfg.LogFile, cfg.JSON)
+		if cfg.Syslog {
+			log = log.WithSyslogSink(cfg.JSON)
+		}
+
+		for name, level := range cfg.SubsystemLevels {
+			if err := log.SetSubsystemLevel(name, logging.LogLevel(level)); err != nil {
+				return err
+			}
+			log.Debugf("Switching %s log level to %s", name, level)
+		}
+
+		log.Debugf("configured logging: level=%s, file=%s, json=%v, syslog=%v",
+			cfg.LogLevel, cfg.LogFile, cfg.JSON, cfg.Syslog)
 
 		return nil
 	}
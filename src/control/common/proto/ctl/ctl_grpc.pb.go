@@ -42,6 +42,10 @@ type CtlSvcClient interface {
 	SmdManage(ctx context.Context, in *SmdManageReq, opts ...grpc.CallOption) (*SmdManageResp, error)
 	// Set log level for DAOS I/O Engines on a host.
 	SetEngineLogMasks(ctx context.Context, in *SetLogMasksReq, opts ...grpc.CallOption) (*SetLogMasksResp, error)
+	// Set log levels for the DAOS control plane server on a host.
+	SetControlLogLevels(ctx context.Context, in *SetControlLogLevelsReq, opts ...grpc.CallOption) (*SetControlLogLevelsResp, error)
+	// Enable or disable the profiling endpoint of the DAOS control plane server on a host.
+	SetProfiling(ctx context.Context, in *SetProfilingReq, opts ...grpc.CallOption) (*SetProfilingResp, error)
 	// Prepare DAOS I/O Engines on a host for controlled shutdown. (gRPC fanout)
 	PrepShutdownRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
 	// Stop DAOS I/O Engines on a host. (gRPC fanout)
@@ -52,6 +56,15 @@ type CtlSvcClient interface {
 	StartRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
 	// Perform a Log collection on Servers for support/debug purpose
 	CollectLog(ctx context.Context, in *CollectLogReq, opts ...grpc.CallOption) (*CollectLogResp, error)
+	// Retrieve resource usage details for DAOS I/O Engines on a host.
+	EngineUsageQuery(ctx context.Context, in *EngineUsageReq, opts ...grpc.CallOption) (*EngineUsageResp, error)
+	// Retrieve the effective tuning parameters of DAOS I/O Engines on a host.
+	GetEngineTunables(ctx context.Context, in *EngineTunablesReq, opts ...grpc.CallOption) (*EngineTunablesResp, error)
+	// Retrieve the local SWIM view of the DAOS I/O Engines on a host.
+	SwimViewQuery(ctx context.Context, in *SwimViewReq, opts ...grpc.CallOption) (*SwimViewQueryResp, error)
+	// Validate a candidate server config against the hardware of a host and
+	// optionally save it to be used on the next restart.
+	ApplyServerConfig(ctx context.Context, in *ServerConfigApplyReq, opts ...grpc.CallOption) (*ServerConfigApplyResp, error)
 }
 
 type ctlSvcClient struct {
@@ -152,6 +165,24 @@ func (c *ctlSvcClient) SetEngineLogMasks(ctx context.Context, in *SetLogMasksReq
 	return out, nil
 }
 
+func (c *ctlSvcClient) SetControlLogLevels(ctx context.Context, in *SetControlLogLevelsReq, opts ...grpc.CallOption) (*SetControlLogLevelsResp, error) {
+	out := new(SetControlLogLevelsResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/SetControlLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) SetProfiling(ctx context.Context, in *SetProfilingReq, opts ...grpc.CallOption) (*SetProfilingResp, error) {
+	out := new(SetProfilingResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/SetProfiling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ctlSvcClient) PrepShutdownRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error) {
 	out := new(RanksResp)
 	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/PrepShutdownRanks", in, out, opts...)
@@ -197,6 +228,42 @@ func (c *ctlSvcClient) CollectLog(ctx context.Context, in *CollectLogReq, opts .
 	return out, nil
 }
 
+func (c *ctlSvcClient) EngineUsageQuery(ctx context.Context, in *EngineUsageReq, opts ...grpc.CallOption) (*EngineUsageResp, error) {
+	out := new(EngineUsageResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/EngineUsageQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) GetEngineTunables(ctx context.Context, in *EngineTunablesReq, opts ...grpc.CallOption) (*EngineTunablesResp, error) {
+	out := new(EngineTunablesResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/GetEngineTunables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) SwimViewQuery(ctx context.Context, in *SwimViewReq, opts ...grpc.CallOption) (*SwimViewQueryResp, error) {
+	out := new(SwimViewQueryResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/SwimViewQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) ApplyServerConfig(ctx context.Context, in *ServerConfigApplyReq, opts ...grpc.CallOption) (*ServerConfigApplyResp, error) {
+	out := new(ServerConfigApplyResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/ApplyServerConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CtlSvcServer is the server API for CtlSvc service.
 // All implementations must embed UnimplementedCtlSvcServer
 // for forward compatibility
@@ -221,6 +288,10 @@ type CtlSvcServer interface {
 	SmdManage(context.Context, *SmdManageReq) (*SmdManageResp, error)
 	// Set log level for DAOS I/O Engines on a host.
 	SetEngineLogMasks(context.Context, *SetLogMasksReq) (*SetLogMasksResp, error)
+	// Set log levels for the DAOS control plane server on a host.
+	SetControlLogLevels(context.Context, *SetControlLogLevelsReq) (*SetControlLogLevelsResp, error)
+	// Enable or disable the profiling endpoint of the DAOS control plane server on a host.
+	SetProfiling(context.Context, *SetProfilingReq) (*SetProfilingResp, error)
 	// Prepare DAOS I/O Engines on a host for controlled shutdown. (gRPC fanout)
 	PrepShutdownRanks(context.Context, *RanksReq) (*RanksResp, error)
 	// Stop DAOS I/O Engines on a host. (gRPC fanout)
@@ -231,6 +302,15 @@ type CtlSvcServer interface {
 	StartRanks(context.Context, *RanksReq) (*RanksResp, error)
 	// Perform a Log collection on Servers for support/debug purpose
 	CollectLog(context.Context, *CollectLogReq) (*CollectLogResp, error)
+	// Retrieve resource usage details for DAOS I/O Engines on a host.
+	EngineUsageQuery(context.Context, *EngineUsageReq) (*EngineUsageResp, error)
+	// Retrieve the effective tuning parameters of DAOS I/O Engines on a host.
+	GetEngineTunables(context.Context, *EngineTunablesReq) (*EngineTunablesResp, error)
+	// Retrieve the local SWIM view of the DAOS I/O Engines on a host.
+	SwimViewQuery(context.Context, *SwimViewReq) (*SwimViewQueryResp, error)
+	// Validate a candidate server config against the hardware of a host and
+	// optionally save it to be used on the next restart.
+	ApplyServerConfig(context.Context, *ServerConfigApplyReq) (*ServerConfigApplyResp, error)
 	mustEmbedUnimplementedCtlSvcServer()
 }
 
@@ -268,6 +348,12 @@ func (UnimplementedCtlSvcServer) SmdManage(context.Context, *SmdManageReq) (*Smd
 func (UnimplementedCtlSvcServer) SetEngineLogMasks(context.Context, *SetLogMasksReq) (*SetLogMasksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetEngineLogMasks not implemented")
 }
+func (UnimplementedCtlSvcServer) SetControlLogLevels(context.Context, *SetControlLogLevelsReq) (*SetControlLogLevelsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetControlLogLevels not implemented")
+}
+func (UnimplementedCtlSvcServer) SetProfiling(context.Context, *SetProfilingReq) (*SetProfilingResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProfiling not implemented")
+}
 func (UnimplementedCtlSvcServer) PrepShutdownRanks(context.Context, *RanksReq) (*RanksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrepShutdownRanks not implemented")
 }
@@ -283,6 +369,18 @@ func (UnimplementedCtlSvcServer) StartRanks(context.Context, *RanksReq) (*RanksR
 func (UnimplementedCtlSvcServer) CollectLog(context.Context, *CollectLogReq) (*CollectLogResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CollectLog not implemented")
 }
+func (UnimplementedCtlSvcServer) EngineUsageQuery(context.Context, *EngineUsageReq) (*EngineUsageResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EngineUsageQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) GetEngineTunables(context.Context, *EngineTunablesReq) (*EngineTunablesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngineTunables not implemented")
+}
+func (UnimplementedCtlSvcServer) SwimViewQuery(context.Context, *SwimViewReq) (*SwimViewQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwimViewQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) ApplyServerConfig(context.Context, *ServerConfigApplyReq) (*ServerConfigApplyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyServerConfig not implemented")
+}
 func (UnimplementedCtlSvcServer) mustEmbedUnimplementedCtlSvcServer() {}
 
 // UnsafeCtlSvcServer may be embedded to opt out of forward compatibility for this service.
@@ -476,6 +574,42 @@ func _CtlSvc_SetEngineLogMasks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CtlSvc_SetControlLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetControlLogLevelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).SetControlLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/SetControlLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).SetControlLogLevels(ctx, req.(*SetControlLogLevelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_SetProfiling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfilingReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).SetProfiling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/SetProfiling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).SetProfiling(ctx, req.(*SetProfilingReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CtlSvc_PrepShutdownRanks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RanksReq)
 	if err := dec(in); err != nil {
@@ -566,6 +700,78 @@ func _CtlSvc_CollectLog_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CtlSvc_EngineUsageQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EngineUsageReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).EngineUsageQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/EngineUsageQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).EngineUsageQuery(ctx, req.(*EngineUsageReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_GetEngineTunables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EngineTunablesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).GetEngineTunables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/GetEngineTunables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).GetEngineTunables(ctx, req.(*EngineTunablesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_SwimViewQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwimViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).SwimViewQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/SwimViewQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).SwimViewQuery(ctx, req.(*SwimViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_ApplyServerConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerConfigApplyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).ApplyServerConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/ApplyServerConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).ApplyServerConfig(ctx, req.(*ServerConfigApplyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CtlSvc_ServiceDesc is the grpc.ServiceDesc for CtlSvc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -613,6 +819,14 @@ var CtlSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetEngineLogMasks",
 			Handler:    _CtlSvc_SetEngineLogMasks_Handler,
 		},
+		{
+			MethodName: "SetControlLogLevels",
+			Handler:    _CtlSvc_SetControlLogLevels_Handler,
+		},
+		{
+			MethodName: "SetProfiling",
+			Handler:    _CtlSvc_SetProfiling_Handler,
+		},
 		{
 			MethodName: "PrepShutdownRanks",
 			Handler:    _CtlSvc_PrepShutdownRanks_Handler,
@@ -633,6 +847,22 @@ var CtlSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CollectLog",
 			Handler:    _CtlSvc_CollectLog_Handler,
 		},
+		{
+			MethodName: "EngineUsageQuery",
+			Handler:    _CtlSvc_EngineUsageQuery_Handler,
+		},
+		{
+			MethodName: "GetEngineTunables",
+			Handler:    _CtlSvc_GetEngineTunables_Handler,
+		},
+		{
+			MethodName: "SwimViewQuery",
+			Handler:    _CtlSvc_SwimViewQuery_Handler,
+		},
+		{
+			MethodName: "ApplyServerConfig",
+			Handler:    _CtlSvc_ApplyServerConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ctl/ctl.proto",
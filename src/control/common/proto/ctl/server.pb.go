@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -26,6 +26,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SwimState mirrors the SWIM member status of the engine's group membership.
+type SwimState int32
+
+const (
+	SwimState_SWIM_ALIVE    SwimState = 0
+	SwimState_SWIM_SUSPECT  SwimState = 1
+	SwimState_SWIM_DEAD     SwimState = 2
+	SwimState_SWIM_INACTIVE SwimState = 3
+)
+
+// Enum value maps for SwimState.
+var (
+	SwimState_name = map[int32]string{
+		0: "SWIM_ALIVE",
+		1: "SWIM_SUSPECT",
+		2: "SWIM_DEAD",
+		3: "SWIM_INACTIVE",
+	}
+	SwimState_value = map[string]int32{
+		"SWIM_ALIVE":    0,
+		"SWIM_SUSPECT":  1,
+		"SWIM_DEAD":     2,
+		"SWIM_INACTIVE": 3,
+	}
+)
+
+func (x SwimState) Enum() *SwimState {
+	p := new(SwimState)
+	*p = x
+	return p
+}
+
+func (x SwimState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwimState) Descriptor() protoreflect.EnumDescriptor {
+	return file_ctl_server_proto_enumTypes[0].Descriptor()
+}
+
+func (SwimState) Type() protoreflect.EnumType {
+	return &file_ctl_server_proto_enumTypes[0]
+}
+
+func (x SwimState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwimState.Descriptor instead.
+func (SwimState) EnumDescriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{0}
+}
+
 // SetLogMasksReq provides parameters to set system-wide log masks.
 type SetLogMasksReq struct {
 	state         protoimpl.MessageState
@@ -178,81 +231,1533 @@ func (x *SetLogMasksResp) GetErrors() []string {
 	return nil
 }
 
-var File_ctl_server_proto protoreflect.FileDescriptor
+// SetControlLogLevelsReq sets daos_server log levels at runtime.
+type SetControlLogLevelsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_ctl_server_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0xe3, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4c,
-	0x6f, 0x67, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x6d, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x73,
-	0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
-	0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
-	0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x23, 0x0a,
-	0x0d, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x75, 0x62, 0x73,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65,
-	0x73, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x41, 0x0a,
-	0x0f, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
-	0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
-	0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73,
-	0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	Sys         string            `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`                                                                                                       // DAOS system name
+	Level       string            `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`                                                                                                   // set control plane log level, leave unchanged if empty
+	Subsystems  map[string]string `protobuf:"bytes,3,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // set log levels for individual subsystems
+	ResetLevels bool              `protobuf:"varint,4,opt,name=reset_levels,json=resetLevels,proto3" json:"reset_levels,omitempty"`                                                                   // reset log levels to the values in config before applying changes
 }
 
-var (
-	file_ctl_server_proto_rawDescOnce sync.Once
-	file_ctl_server_proto_rawDescData = file_ctl_server_proto_rawDesc
-)
+func (x *SetControlLogLevelsReq) Reset() {
+	*x = SetControlLogLevelsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_ctl_server_proto_rawDescGZIP() []byte {
-	file_ctl_server_proto_rawDescOnce.Do(func() {
-		file_ctl_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_server_proto_rawDescData)
-	})
-	return file_ctl_server_proto_rawDescData
+func (x *SetControlLogLevelsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_ctl_server_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_ctl_server_proto_goTypes = []interface{}{
-	(*SetLogMasksReq)(nil),  // 0: ctl.SetLogMasksReq
-	(*SetLogMasksResp)(nil), // 1: ctl.SetLogMasksResp
+func (*SetControlLogLevelsReq) ProtoMessage() {}
+
+func (x *SetControlLogLevelsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_ctl_server_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use SetControlLogLevelsReq.ProtoReflect.Descriptor instead.
+func (*SetControlLogLevelsReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{2}
 }
 
-func init() { file_ctl_server_proto_init() }
-func file_ctl_server_proto_init() {
-	if File_ctl_server_proto != nil {
-		return
+func (x *SetControlLogLevelsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_ctl_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLogMasksReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *SetControlLogLevelsReq) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetControlLogLevelsReq) GetSubsystems() map[string]string {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+func (x *SetControlLogLevelsReq) GetResetLevels() bool {
+	if x != nil {
+		return x.ResetLevels
+	}
+	return false
+}
+
+// SetControlLogLevelsResp returns the daos_server log levels after the change.
+type SetControlLogLevelsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level      string            `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                                                   // control plane log level
+	Subsystems map[string]string `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // subsystem log levels set independently of the control plane level
+}
+
+func (x *SetControlLogLevelsResp) Reset() {
+	*x = SetControlLogLevelsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetControlLogLevelsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetControlLogLevelsResp) ProtoMessage() {}
+
+func (x *SetControlLogLevelsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_ctl_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetLogMasksResp); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetControlLogLevelsResp.ProtoReflect.Descriptor instead.
+func (*SetControlLogLevelsResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetControlLogLevelsResp) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetControlLogLevelsResp) GetSubsystems() map[string]string {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+// SetProfilingReq enables or disables the daos_server profiling endpoint at runtime.
+type SetProfilingReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys     string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`         // DAOS system name
+	Enable  bool   `protobuf:"varint,2,opt,name=enable,proto3" json:"enable,omitempty"`  // enable the profiling endpoint, disable if false
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // listen address, use configured or default address if empty
+}
+
+func (x *SetProfilingReq) Reset() {
+	*x = SetProfilingReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetProfilingReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProfilingReq) ProtoMessage() {}
+
+func (x *SetProfilingReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProfilingReq.ProtoReflect.Descriptor instead.
+func (*SetProfilingReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetProfilingReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SetProfilingReq) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *SetProfilingReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// SetProfilingResp returns the state of the daos_server profiling endpoint after the change.
+type SetProfilingResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // profiling endpoint is running
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`  // address the profiling endpoint is listening on
+}
+
+func (x *SetProfilingResp) Reset() {
+	*x = SetProfilingResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetProfilingResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProfilingResp) ProtoMessage() {}
+
+func (x *SetProfilingResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProfilingResp.ProtoReflect.Descriptor instead.
+func (*SetProfilingResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetProfilingResp) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetProfilingResp) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// EngineUsageReq requests resource usage details for DAOS I/O Engines on a host.
+type EngineUsageReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EngineUsageReq) Reset() {
+	*x = EngineUsageReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineUsageReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineUsageReq) ProtoMessage() {}
+
+func (x *EngineUsageReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineUsageReq.ProtoReflect.Descriptor instead.
+func (*EngineUsageReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{6}
+}
+
+// EngineUsage contains resource usage details for a DAOS I/O Engine process.
+type EngineUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index         uint32             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                                                                                                      // engine instance index
+	Rank          uint32             `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`                                                                                                                        // engine rank
+	Pid           int32              `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                                                                                          // engine process ID
+	RssBytes      uint64             `protobuf:"varint,4,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`                                                                                                // resident set size in bytes
+	HugepageBytes uint64             `protobuf:"varint,5,opt,name=hugepage_bytes,json=hugepageBytes,proto3" json:"hugepage_bytes,omitempty"`                                                                                 // hugetlb memory in use by the process, in bytes
+	OpenFds       uint32             `protobuf:"varint,6,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`                                                                                                   // number of open file descriptors
+	CpuSeconds    map[string]float64 `protobuf:"bytes,7,rep,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"` // cumulative CPU time by thread (xstream) name
+	Error         string             `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                                                                                                                       // error encountered while collecting usage, if any
+}
+
+func (x *EngineUsage) Reset() {
+	*x = EngineUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineUsage) ProtoMessage() {}
+
+func (x *EngineUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineUsage.ProtoReflect.Descriptor instead.
+func (*EngineUsage) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EngineUsage) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetHugepageBytes() uint64 {
+	if x != nil {
+		return x.HugepageBytes
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetOpenFds() uint32 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *EngineUsage) GetCpuSeconds() map[string]float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return nil
+}
+
+func (x *EngineUsage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// EngineUsageResp returns resource usage details for DAOS I/O Engines on a host.
+type EngineUsageResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engines []*EngineUsage `protobuf:"bytes,1,rep,name=engines,proto3" json:"engines,omitempty"`
+}
+
+func (x *EngineUsageResp) Reset() {
+	*x = EngineUsageResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineUsageResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineUsageResp) ProtoMessage() {}
+
+func (x *EngineUsageResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineUsageResp.ProtoReflect.Descriptor instead.
+func (*EngineUsageResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EngineUsageResp) GetEngines() []*EngineUsage {
+	if x != nil {
+		return x.Engines
+	}
+	return nil
+}
+
+// EngineTunablesReq requests the tuning parameters of DAOS I/O Engines on a host.
+type EngineTunablesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EngineTunablesReq) Reset() {
+	*x = EngineTunablesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineTunablesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineTunablesReq) ProtoMessage() {}
+
+func (x *EngineTunablesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineTunablesReq.ProtoReflect.Descriptor instead.
+func (*EngineTunablesReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{9}
+}
+
+// EngineTunables contains the effective tuning parameters of a DAOS I/O Engine process.
+type EngineTunables struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index            uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                                   // engine instance index
+	Rank             uint32   `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`                                                     // engine rank
+	Running          bool     `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`                                               // engine process is running
+	Args             []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`                                                      // engine command line arguments
+	EnvVars          []string `protobuf:"bytes,5,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty"`                                 // engine environment, sensitive values redacted
+	UltStackSize     uint64   `protobuf:"varint,6,opt,name=ult_stack_size,json=ultStackSize,proto3" json:"ult_stack_size,omitempty"`               // default ULT stack size in bytes
+	DeepUltStackSize uint64   `protobuf:"varint,7,opt,name=deep_ult_stack_size,json=deepUltStackSize,proto3" json:"deep_ult_stack_size,omitempty"` // deep ULT stack size in bytes
+	MmapUltStacks    bool     `protobuf:"varint,8,opt,name=mmap_ult_stacks,json=mmapUltStacks,proto3" json:"mmap_ult_stacks,omitempty"`            // ULT stacks are allocated with mmap()
+	Targets          uint32   `protobuf:"varint,9,opt,name=targets,proto3" json:"targets,omitempty"`                                               // number of VOS targets
+	HelperStreams    uint32   `protobuf:"varint,10,opt,name=helper_streams,json=helperStreams,proto3" json:"helper_streams,omitempty"`             // number of helper xstreams
+	MemSizeMib       uint32   `protobuf:"varint,11,opt,name=mem_size_mib,json=memSizeMib,proto3" json:"mem_size_mib,omitempty"`                    // memory allocated for the engine in MiB
+	HugepageSizeMib  uint32   `protobuf:"varint,12,opt,name=hugepage_size_mib,json=hugepageSizeMib,proto3" json:"hugepage_size_mib,omitempty"`     // hugepage size in MiB
+	PinnedNumaNode   int32    `protobuf:"varint,13,opt,name=pinned_numa_node,json=pinnedNumaNode,proto3" json:"pinned_numa_node,omitempty"`        // NUMA node the engine is pinned to, -1 if not set
+	FirstCore        int32    `protobuf:"varint,14,opt,name=first_core,json=firstCore,proto3" json:"first_core,omitempty"`                         // first core used by the engine, -1 if not set
+	Error            string   `protobuf:"bytes,15,opt,name=error,proto3" json:"error,omitempty"`                                                   // error encountered while collecting tunables, if any
+}
+
+func (x *EngineTunables) Reset() {
+	*x = EngineTunables{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineTunables) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineTunables) ProtoMessage() {}
+
+func (x *EngineTunables) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineTunables.ProtoReflect.Descriptor instead.
+func (*EngineTunables) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EngineTunables) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *EngineTunables) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *EngineTunables) GetEnvVars() []string {
+	if x != nil {
+		return x.EnvVars
+	}
+	return nil
+}
+
+func (x *EngineTunables) GetUltStackSize() uint64 {
+	if x != nil {
+		return x.UltStackSize
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetDeepUltStackSize() uint64 {
+	if x != nil {
+		return x.DeepUltStackSize
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetMmapUltStacks() bool {
+	if x != nil {
+		return x.MmapUltStacks
+	}
+	return false
+}
+
+func (x *EngineTunables) GetTargets() uint32 {
+	if x != nil {
+		return x.Targets
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetHelperStreams() uint32 {
+	if x != nil {
+		return x.HelperStreams
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetMemSizeMib() uint32 {
+	if x != nil {
+		return x.MemSizeMib
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetHugepageSizeMib() uint32 {
+	if x != nil {
+		return x.HugepageSizeMib
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetPinnedNumaNode() int32 {
+	if x != nil {
+		return x.PinnedNumaNode
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetFirstCore() int32 {
+	if x != nil {
+		return x.FirstCore
+	}
+	return 0
+}
+
+func (x *EngineTunables) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// EngineTunablesResp returns the tuning parameters of DAOS I/O Engines on a host.
+type EngineTunablesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engines []*EngineTunables `protobuf:"bytes,1,rep,name=engines,proto3" json:"engines,omitempty"`
+}
+
+func (x *EngineTunablesResp) Reset() {
+	*x = EngineTunablesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineTunablesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineTunablesResp) ProtoMessage() {}
+
+func (x *EngineTunablesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineTunablesResp.ProtoReflect.Descriptor instead.
+func (*EngineTunablesResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EngineTunablesResp) GetEngines() []*EngineTunables {
+	if x != nil {
+		return x.Engines
+	}
+	return nil
+}
+
+// ServerConfigApplyReq supplies a candidate server config file to be validated
+// against the hardware of a host and optionally saved for the next restart.
+type ServerConfigApplyReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`  // contents of the candidate config file
+	Commit bool   `protobuf:"varint,2,opt,name=commit,proto3" json:"commit,omitempty"` // replace the config file of the server if valid
+}
+
+func (x *ServerConfigApplyReq) Reset() {
+	*x = ServerConfigApplyReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerConfigApplyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerConfigApplyReq) ProtoMessage() {}
+
+func (x *ServerConfigApplyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerConfigApplyReq.ProtoReflect.Descriptor instead.
+func (*ServerConfigApplyReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServerConfigApplyReq) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ServerConfigApplyReq) GetCommit() bool {
+	if x != nil {
+		return x.Commit
+	}
+	return false
+}
+
+// ServerConfigApplyResp returns the result of applying a candidate server config.
+type ServerConfigApplyResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`            // path of the config file of the server
+	Committed bool   `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"` // config file has been replaced
+}
+
+func (x *ServerConfigApplyResp) Reset() {
+	*x = ServerConfigApplyResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerConfigApplyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerConfigApplyResp) ProtoMessage() {}
+
+func (x *ServerConfigApplyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerConfigApplyResp.ProtoReflect.Descriptor instead.
+func (*ServerConfigApplyResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ServerConfigApplyResp) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ServerConfigApplyResp) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// SwimMember is the state of a rank as seen by an engine's SWIM protocol.
+type SwimMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank        uint32    `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	State       SwimState `protobuf:"varint,2,opt,name=state,proto3,enum=ctl.SwimState" json:"state,omitempty"`
+	Incarnation uint64    `protobuf:"varint,3,opt,name=incarnation,proto3" json:"incarnation,omitempty"`
+}
+
+func (x *SwimMember) Reset() {
+	*x = SwimMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwimMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwimMember) ProtoMessage() {}
+
+func (x *SwimMember) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwimMember.ProtoReflect.Descriptor instead.
+func (*SwimMember) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SwimMember) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SwimMember) GetState() SwimState {
+	if x != nil {
+		return x.State
+	}
+	return SwimState_SWIM_ALIVE
+}
+
+func (x *SwimMember) GetIncarnation() uint64 {
+	if x != nil {
+		return x.Incarnation
+	}
+	return 0
+}
+
+// Request for the local SWIM view of engines, used both for the gRPC fanout
+// to hosts and for the dRPC call to each engine.
+type SwimViewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SwimViewReq) Reset() {
+	*x = SwimViewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwimViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwimViewReq) ProtoMessage() {}
+
+func (x *SwimViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwimViewReq.ProtoReflect.Descriptor instead.
+func (*SwimViewReq) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{15}
+}
+
+// Response from an engine describing its local SWIM view.
+type SwimViewResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  int32         `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // DAOS error code
+	Members []*SwimMember `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *SwimViewResp) Reset() {
+	*x = SwimViewResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwimViewResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwimViewResp) ProtoMessage() {}
+
+func (x *SwimViewResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwimViewResp.ProtoReflect.Descriptor instead.
+func (*SwimViewResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SwimViewResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *SwimViewResp) GetMembers() []*SwimMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// EngineSwimView is the local SWIM view of one of the engines on a host.
+type EngineSwimView struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index   uint32        `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // engine instance index
+	Rank    uint32        `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`   // rank of the engine holding the view
+	Members []*SwimMember `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	Error   string        `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // set if the view could not be retrieved
+}
+
+func (x *EngineSwimView) Reset() {
+	*x = EngineSwimView{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineSwimView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineSwimView) ProtoMessage() {}
+
+func (x *EngineSwimView) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineSwimView.ProtoReflect.Descriptor instead.
+func (*EngineSwimView) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EngineSwimView) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *EngineSwimView) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *EngineSwimView) GetMembers() []*SwimMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *EngineSwimView) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Response containing the local SWIM views of the engines on a host.
+type SwimViewQueryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Engines []*EngineSwimView `protobuf:"bytes,1,rep,name=engines,proto3" json:"engines,omitempty"`
+}
+
+func (x *SwimViewQueryResp) Reset() {
+	*x = SwimViewQueryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_server_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwimViewQueryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwimViewQueryResp) ProtoMessage() {}
+
+func (x *SwimViewQueryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_server_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwimViewQueryResp.ProtoReflect.Descriptor instead.
+func (*SwimViewQueryResp) Descriptor() ([]byte, []int) {
+	return file_ctl_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SwimViewQueryResp) GetEngines() []*EngineSwimView {
+	if x != nil {
+		return x.Engines
+	}
+	return nil
+}
+
+var File_ctl_server_proto protoreflect.FileDescriptor
+
+var file_ctl_server_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0xe3, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4c,
+	0x6f, 0x67, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6d, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x73,
+	0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x75, 0x62, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x41, 0x0a,
+	0x0f, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4d, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x22, 0xef, 0x01, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4c,
+	0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x4b, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xbc, 0x01, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x4c, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x55, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x46, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0x10, 0x0a, 0x0e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x22, 0xc0, 0x02, 0x0a, 0x0b, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x72, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x68,
+	0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x66, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x64, 0x73, 0x12, 0x41, 0x0a,
+	0x0b, 0x63, 0x70, 0x75, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x3d, 0x0a, 0x0f, 0x43, 0x70, 0x75, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3d, 0x0a, 0x0f, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x54, 0x75,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x22, 0xee, 0x03, 0x0a, 0x0e, 0x45, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x54, 0x75, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x76, 0x61, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x61, 0x63,
+	0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x64, 0x65, 0x65, 0x70, 0x5f, 0x75, 0x6c,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x10, 0x64, 0x65, 0x65, 0x70, 0x55, 0x6c, 0x74, 0x53, 0x74, 0x61, 0x63, 0x6b,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x6d, 0x61, 0x70, 0x5f, 0x75, 0x6c, 0x74,
+	0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6d,
+	0x6d, 0x61, 0x70, 0x55, 0x6c, 0x74, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x20, 0x0a,
+	0x0c, 0x6d, 0x65, 0x6d, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x69, 0x62, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x65, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x69, 0x62, 0x12,
+	0x2a, 0x0a, 0x11, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x6d, 0x69, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x68, 0x75, 0x67, 0x65,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x69, 0x62, 0x12, 0x28, 0x0a, 0x10, 0x70,
+	0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x61, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4e, 0x75, 0x6d,
+	0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x43, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x43, 0x0a, 0x12, 0x45, 0x6e,
+	0x67, 0x69, 0x6e, 0x65, 0x54, 0x75, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x54, 0x75,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x07, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x73, 0x22,
+	0x46, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x49, 0x0a, 0x15, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74,
+	0x65, 0x64, 0x22, 0x68, 0x0a, 0x0a, 0x53, 0x77, 0x69, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x77, 0x69, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e,
+	0x63, 0x61, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x69, 0x6e, 0x63, 0x61, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x0d, 0x0a, 0x0b,
+	0x53, 0x77, 0x69, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x22, 0x51, 0x0a, 0x0c, 0x53,
+	0x77, 0x69, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x77, 0x69, 0x6d, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x7b,
+	0x0a, 0x0e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x77, 0x69, 0x6d, 0x56, 0x69, 0x65, 0x77,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x53, 0x77, 0x69, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x42, 0x0a, 0x11, 0x53,
+	0x77, 0x69, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x77,
+	0x69, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x52, 0x07, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x73, 0x2a,
+	0x4f, 0x0a, 0x09, 0x53, 0x77, 0x69, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a,
+	0x53, 0x57, 0x49, 0x4d, 0x5f, 0x41, 0x4c, 0x49, 0x56, 0x45, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c,
+	0x53, 0x57, 0x49, 0x4d, 0x5f, 0x53, 0x55, 0x53, 0x50, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x53, 0x57, 0x49, 0x4d, 0x5f, 0x44, 0x45, 0x41, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a,
+	0x0d, 0x53, 0x57, 0x49, 0x4d, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03,
+	0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
+	0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73,
+	0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ctl_server_proto_rawDescOnce sync.Once
+	file_ctl_server_proto_rawDescData = file_ctl_server_proto_rawDesc
+)
+
+func file_ctl_server_proto_rawDescGZIP() []byte {
+	file_ctl_server_proto_rawDescOnce.Do(func() {
+		file_ctl_server_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_server_proto_rawDescData)
+	})
+	return file_ctl_server_proto_rawDescData
+}
+
+var file_ctl_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ctl_server_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_ctl_server_proto_goTypes = []interface{}{
+	(SwimState)(0),                  // 0: ctl.SwimState
+	(*SetLogMasksReq)(nil),          // 1: ctl.SetLogMasksReq
+	(*SetLogMasksResp)(nil),         // 2: ctl.SetLogMasksResp
+	(*SetControlLogLevelsReq)(nil),  // 3: ctl.SetControlLogLevelsReq
+	(*SetControlLogLevelsResp)(nil), // 4: ctl.SetControlLogLevelsResp
+	(*SetProfilingReq)(nil),         // 5: ctl.SetProfilingReq
+	(*SetProfilingResp)(nil),        // 6: ctl.SetProfilingResp
+	(*EngineUsageReq)(nil),          // 7: ctl.EngineUsageReq
+	(*EngineUsage)(nil),             // 8: ctl.EngineUsage
+	(*EngineUsageResp)(nil),         // 9: ctl.EngineUsageResp
+	(*EngineTunablesReq)(nil),       // 10: ctl.EngineTunablesReq
+	(*EngineTunables)(nil),          // 11: ctl.EngineTunables
+	(*EngineTunablesResp)(nil),      // 12: ctl.EngineTunablesResp
+	(*ServerConfigApplyReq)(nil),    // 13: ctl.ServerConfigApplyReq
+	(*ServerConfigApplyResp)(nil),   // 14: ctl.ServerConfigApplyResp
+	(*SwimMember)(nil),              // 15: ctl.SwimMember
+	(*SwimViewReq)(nil),             // 16: ctl.SwimViewReq
+	(*SwimViewResp)(nil),            // 17: ctl.SwimViewResp
+	(*EngineSwimView)(nil),          // 18: ctl.EngineSwimView
+	(*SwimViewQueryResp)(nil),       // 19: ctl.SwimViewQueryResp
+	nil,                             // 20: ctl.SetControlLogLevelsReq.SubsystemsEntry
+	nil,                             // 21: ctl.SetControlLogLevelsResp.SubsystemsEntry
+	nil,                             // 22: ctl.EngineUsage.CpuSecondsEntry
+}
+var file_ctl_server_proto_depIdxs = []int32{
+	20, // 0: ctl.SetControlLogLevelsReq.subsystems:type_name -> ctl.SetControlLogLevelsReq.SubsystemsEntry
+	21, // 1: ctl.SetControlLogLevelsResp.subsystems:type_name -> ctl.SetControlLogLevelsResp.SubsystemsEntry
+	22, // 2: ctl.EngineUsage.cpu_seconds:type_name -> ctl.EngineUsage.CpuSecondsEntry
+	8,  // 3: ctl.EngineUsageResp.engines:type_name -> ctl.EngineUsage
+	11, // 4: ctl.EngineTunablesResp.engines:type_name -> ctl.EngineTunables
+	0,  // 5: ctl.SwimMember.state:type_name -> ctl.SwimState
+	15, // 6: ctl.SwimViewResp.members:type_name -> ctl.SwimMember
+	15, // 7: ctl.EngineSwimView.members:type_name -> ctl.SwimMember
+	18, // 8: ctl.SwimViewQueryResp.engines:type_name -> ctl.EngineSwimView
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_ctl_server_proto_init() }
+func file_ctl_server_proto_init() {
+	if File_ctl_server_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_server_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogMasksReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogMasksResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetControlLogLevelsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetControlLogLevelsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetProfilingReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetProfilingResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineUsageReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineUsageResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineTunablesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineTunables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineTunablesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerConfigApplyReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerConfigApplyResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwimMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwimViewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwimViewResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineSwimView); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwimViewQueryResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -269,13 +1774,14 @@ func file_ctl_server_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ctl_server_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_ctl_server_proto_goTypes,
 		DependencyIndexes: file_ctl_server_proto_depIdxs,
+		EnumInfos:         file_ctl_server_proto_enumTypes,
 		MessageInfos:      file_ctl_server_proto_msgTypes,
 	}.Build()
 	File_ctl_server_proto = out.File
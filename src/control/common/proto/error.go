@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -37,6 +38,9 @@ const (
 	// AnnotatedSystemErrPoolNotFound defines an identifier for ErrPoolNotFound errors
 	// serialized as gRPC status metadata.
 	AnnotatedSystemErrPoolNotFound = "proto.system.ErrPoolNotFound"
+	// AnnotatedSystemErrBusy defines an identifier for ErrBusy errors
+	// serialized as gRPC status metadata.
+	AnnotatedSystemErrBusy = "proto.system.ErrBusy"
 )
 
 // ErrFromMeta converts a map of metadata into an error.
@@ -56,6 +60,9 @@ func ErrFromMeta(meta map[string]string, errType error) error {
 		err = json.Unmarshal([]byte(meta["Replicas"]), &et.Replicas)
 	case *system.ErrPoolNotFound:
 		err = json.Unmarshal([]byte(meta["PoolInfo"]), et)
+	case *system.ErrBusy:
+		et.Operation = meta["Operation"]
+		et.RetryAfter, err = time.ParseDuration(meta["RetryAfter"])
 	default:
 		err = errors.Errorf("unable to convert %+v into error", meta)
 	}
@@ -139,6 +146,15 @@ func AnnotateError(in error) error {
 				"PoolInfo": string(data),
 			},
 		}
+	case *system.ErrBusy:
+		details = &errdetails.ErrorInfo{
+			Reason: AnnotatedSystemErrBusy,
+			Domain: "DAOS",
+			Metadata: map[string]string{
+				"Operation":  et.Operation,
+				"RetryAfter": et.RetryAfter.String(),
+			},
+		}
 	}
 
 	if details == nil {
@@ -177,6 +193,8 @@ func UnwrapError(st *status.Status) error {
 				return ErrFromMeta(t.Metadata, new(system.ErrNotLeader))
 			case AnnotatedSystemErrPoolNotFound:
 				return ErrFromMeta(t.Metadata, new(system.ErrPoolNotFound))
+			case AnnotatedSystemErrBusy:
+				return ErrFromMeta(t.Metadata, new(system.ErrBusy))
 			}
 		}
 	}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc/codes"
@@ -79,6 +80,10 @@ func TestProto_AnnotateError(t *testing.T) {
 		Replicas:   []string{"a", "b", "c"},
 	}
 	testPoolNotFound := system.ErrPoolLabelNotFound("foo")
+	testBusy := &system.ErrBusy{
+		Operation:  "join",
+		RetryAfter: 3 * time.Second,
+	}
 
 	for name, tc := range map[string]struct {
 		err      error
@@ -115,6 +120,10 @@ func TestProto_AnnotateError(t *testing.T) {
 			err:    testPoolNotFound,
 			expErr: testPoolNotFound,
 		},
+		"wrap/unwrap ErrBusy": {
+			err:    testBusy,
+			expErr: testBusy,
+		},
 		"non-fault err": {
 			err:    errors.New("not a fault"),
 			expErr: status.New(codes.Unknown, "not a fault").Err(),
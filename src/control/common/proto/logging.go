@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022-2023 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -43,7 +43,10 @@ func ShouldDebug(msg proto.Message, ldrChk func() bool) bool {
 				*mgmtpb.ListPoolsReq, *mgmtpb.GetACLReq,
 				*mgmtpb.PoolQueryTargetReq, *mgmtpb.ListContReq,
 				*mgmtpb.SystemEraseReq, *mgmtpb.SystemGetPropReq,
-				*mgmtpb.SystemGetAttrReq:
+				*mgmtpb.SystemGetAttrReq, *mgmtpb.SystemHistoryReq,
+				*mgmtpb.SystemEventsReq, *mgmtpb.PoolActivityReq,
+				*mgmtpb.JobStatsQueryReq, *mgmtpb.PortProbeReq,
+				*mgmtpb.SystemDBHashReq:
 				return true
 			default:
 				return false
@@ -135,6 +138,14 @@ func Debug(msg proto.Message) string {
 		for _, p := range m.Pools {
 			fmt.Fprintf(&bld, " %s:%s", p.Label, p.State)
 		}
+	case *mgmtpb.JoinReq:
+		if m.ProvisioningToken != "" {
+			m = proto.Clone(m).(*mgmtpb.JoinReq)
+			m.ProvisioningToken = "<redacted>"
+		}
+		fmt.Fprintf(&bld, "%T (%+v)", m, m)
+	case *mgmtpb.SystemTokenCreateResp:
+		fmt.Fprintf(&bld, "%T id:%s expires:%d", m, m.Id, m.Expires)
 	case *mgmtpb.JoinResp:
 		fmt.Fprintf(&bld, "%T rank:%d (state:%s, local:%t) map:%d", m, m.Rank, m.State, m.LocalJoin, m.MapVersion)
 	case *mgmtpb.GetAttachInfoResp:
@@ -167,7 +178,8 @@ func Debug(msg proto.Message) string {
 		default:
 			fmt.Fprintf(&bld, "(%+v)", m.Event)
 		}
-	case *ctlpb.RanksResp, *mgmtpb.SystemStartResp, *mgmtpb.SystemStopResp, *mgmtpb.SystemExcludeResp, *mgmtpb.SystemEraseResp:
+	case *ctlpb.RanksResp, *mgmtpb.SystemStartResp, *mgmtpb.SystemStopResp, *mgmtpb.SystemExcludeResp, *mgmtpb.SystemQuarantineResp,
+		*mgmtpb.SystemEraseResp:
 		fmt.Fprintf(&bld, "%T", m)
 		if rg, ok := m.(interface{ GetResults() []*sharedpb.RankResult }); ok {
 			resMap := make(map[string]*ranklist.RankSet)
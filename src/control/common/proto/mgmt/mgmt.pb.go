@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -41,7 +41,7 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x11, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x0d, 0x63, 0x68, 0x6b, 0x2f, 0x63, 0x68, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x10, 0x63, 0x68, 0x6b, 0x2f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x32, 0x98, 0x15, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12,
+	0x6f, 0x74, 0x6f, 0x32, 0xf9, 0x1b, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12,
 	0x27, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x0d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a,
 	0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f,
 	0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x43, 0x6c, 0x75, 0x73,
@@ -139,83 +139,137 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d,
 	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52,
 	0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a,
-	0x0b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x12, 0x14, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52,
-	0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x12, 0x16, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75,
-	0x70, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x3b, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d,
-	0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x12,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x61, 0x62,
-	0x6c, 0x65, 0x12, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44,
-	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
-	0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x10, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0f,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x70, 0x12,
-	0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x70,
-	0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x10, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13,
-	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x14, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x74, 0x50, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x12, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x53, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4b,
-	0x0a, 0x14, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x47, 0x65, 0x74,
-	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x1a,
-	0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x47, 0x65, 0x74, 0x50,
-	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x11, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72,
-	0x12, 0x11, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x63, 0x74,
-	0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x41, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x50, 0x6f, 0x6f,
-	0x6c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
-	0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x15,
-	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x53, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x52, 0x65, 0x71,
-	0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x41,
-	0x74, 0x74, 0x72, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67,
-	0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x1a,
+	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4b, 0x0a,
+	0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e,
+	0x65, 0x12, 0x19, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x51,
+	0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e,
+	0x74, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x1a,
+	0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x09, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x41, 0x72, 0x6d, 0x12, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x41, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75,
+	0x70, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43,
+	0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a,
 	0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22,
-	0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x50, 0x72,
-	0x6f, 0x70, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d,
-	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x52,
-	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x11, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e,
-	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x2e, 0x63, 0x68, 0x6b,
-	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x0e, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34,
-	0x0a, 0x14, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6f,
-	0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61, 0x75,
-	0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x18, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a,
-	0x65, 0x63, 0x74, 0x4d, 0x67, 0x6d, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74,
-	0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x3a,
-	0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f,
-	0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63,
-	0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x00, 0x12, 0x3d, 0x0a, 0x12, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x3f, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x3c, 0x0a, 0x0f, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x53, 0x74, 0x6f, 0x70, 0x12, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x3f, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x41, 0x0a, 0x14, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65,
+	0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x14, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x17, 0x2e, 0x6d, 0x67,
+	0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x3c, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x61, 0x69, 0x72, 0x12, 0x11, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x41, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c,
+	0x0a, 0x0b, 0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x14, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x16, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x41, 0x74,
+	0x74, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72, 0x52, 0x65, 0x71,
+	0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65,
+	0x74, 0x41, 0x74, 0x74, 0x72, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0d, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x16, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x70, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x1a,
+	0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x2e, 0x6d, 0x67,
+	0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x15,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x3b, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x63,
+	0x6b, 0x12, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d,
+	0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x36, 0x0a, 0x09, 0x50, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x12, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0c, 0x50, 0x6f, 0x6f, 0x6c,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x1a,
+	0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0c, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x44, 0x42, 0x48, 0x61, 0x73, 0x68, 0x12, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x42, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x42,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0e, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x44, 0x42, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x17, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x42, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x44, 0x42, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x39, 0x0a, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x4f, 0x70, 0x4a, 0x6f, 0x62, 0x73, 0x12,
+	0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x4f, 0x70, 0x4a, 0x6f, 0x62,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c,
+	0x4f, 0x70, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x11,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x6b, 0x55, 0x55, 0x49, 0x44, 0x73, 0x12,
+	0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x61, 0x6e,
+	0x6b, 0x55, 0x55, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x6b, 0x55, 0x55, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x11, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49,
+	0x6e, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x2e, 0x63, 0x68,
+	0x6b, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x0e, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x34, 0x0a, 0x14, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f,
+	0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x18, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e,
+	0x6a, 0x65, 0x63, 0x74, 0x4d, 0x67, 0x6d, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c,
+	0x74, 0x12, 0x0a, 0x2e, 0x63, 0x68, 0x6b, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x0e, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x44, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42,
+	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61,
+	0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var file_mgmt_mgmt_proto_goTypes = []interface{}{
@@ -244,152 +298,203 @@ var file_mgmt_mgmt_proto_goTypes = []interface{}{
 	(*SystemStopReq)(nil),           // 22: mgmt.SystemStopReq
 	(*SystemStartReq)(nil),          // 23: mgmt.SystemStartReq
 	(*SystemExcludeReq)(nil),        // 24: mgmt.SystemExcludeReq
-	(*SystemEraseReq)(nil),          // 25: mgmt.SystemEraseReq
-	(*SystemCleanupReq)(nil),        // 26: mgmt.SystemCleanupReq
-	(*CheckEnableReq)(nil),          // 27: mgmt.CheckEnableReq
-	(*CheckDisableReq)(nil),         // 28: mgmt.CheckDisableReq
-	(*CheckStartReq)(nil),           // 29: mgmt.CheckStartReq
-	(*CheckStopReq)(nil),            // 30: mgmt.CheckStopReq
-	(*CheckQueryReq)(nil),           // 31: mgmt.CheckQueryReq
-	(*CheckSetPolicyReq)(nil),       // 32: mgmt.CheckSetPolicyReq
-	(*CheckGetPolicyReq)(nil),       // 33: mgmt.CheckGetPolicyReq
-	(*CheckActReq)(nil),             // 34: mgmt.CheckActReq
-	(*PoolUpgradeReq)(nil),          // 35: mgmt.PoolUpgradeReq
-	(*SystemSetAttrReq)(nil),        // 36: mgmt.SystemSetAttrReq
-	(*SystemGetAttrReq)(nil),        // 37: mgmt.SystemGetAttrReq
-	(*SystemSetPropReq)(nil),        // 38: mgmt.SystemSetPropReq
-	(*SystemGetPropReq)(nil),        // 39: mgmt.SystemGetPropReq
-	(*chk.CheckReport)(nil),         // 40: chk.CheckReport
-	(*chk.Fault)(nil),               // 41: chk.Fault
-	(*JoinResp)(nil),                // 42: mgmt.JoinResp
-	(*shared.ClusterEventResp)(nil), // 43: shared.ClusterEventResp
-	(*LeaderQueryResp)(nil),         // 44: mgmt.LeaderQueryResp
-	(*PoolCreateResp)(nil),          // 45: mgmt.PoolCreateResp
-	(*PoolDestroyResp)(nil),         // 46: mgmt.PoolDestroyResp
-	(*PoolEvictResp)(nil),           // 47: mgmt.PoolEvictResp
-	(*PoolExcludeResp)(nil),         // 48: mgmt.PoolExcludeResp
-	(*PoolDrainResp)(nil),           // 49: mgmt.PoolDrainResp
-	(*PoolExtendResp)(nil),          // 50: mgmt.PoolExtendResp
-	(*PoolReintegrateResp)(nil),     // 51: mgmt.PoolReintegrateResp
-	(*PoolQueryResp)(nil),           // 52: mgmt.PoolQueryResp
-	(*PoolQueryTargetResp)(nil),     // 53: mgmt.PoolQueryTargetResp
-	(*PoolSetPropResp)(nil),         // 54: mgmt.PoolSetPropResp
-	(*PoolGetPropResp)(nil),         // 55: mgmt.PoolGetPropResp
-	(*ACLResp)(nil),                 // 56: mgmt.ACLResp
-	(*GetAttachInfoResp)(nil),       // 57: mgmt.GetAttachInfoResp
-	(*ListPoolsResp)(nil),           // 58: mgmt.ListPoolsResp
-	(*ListContResp)(nil),            // 59: mgmt.ListContResp
-	(*ContSetOwnerResp)(nil),        // 60: mgmt.ContSetOwnerResp
-	(*SystemQueryResp)(nil),         // 61: mgmt.SystemQueryResp
-	(*SystemStopResp)(nil),          // 62: mgmt.SystemStopResp
-	(*SystemStartResp)(nil),         // 63: mgmt.SystemStartResp
-	(*SystemExcludeResp)(nil),       // 64: mgmt.SystemExcludeResp
-	(*SystemEraseResp)(nil),         // 65: mgmt.SystemEraseResp
-	(*SystemCleanupResp)(nil),       // 66: mgmt.SystemCleanupResp
-	(*DaosResp)(nil),                // 67: mgmt.DaosResp
-	(*CheckStartResp)(nil),          // 68: mgmt.CheckStartResp
-	(*CheckStopResp)(nil),           // 69: mgmt.CheckStopResp
-	(*CheckQueryResp)(nil),          // 70: mgmt.CheckQueryResp
-	(*CheckGetPolicyResp)(nil),      // 71: mgmt.CheckGetPolicyResp
-	(*CheckActResp)(nil),            // 72: mgmt.CheckActResp
-	(*PoolUpgradeResp)(nil),         // 73: mgmt.PoolUpgradeResp
-	(*SystemGetAttrResp)(nil),       // 74: mgmt.SystemGetAttrResp
-	(*SystemGetPropResp)(nil),       // 75: mgmt.SystemGetPropResp
+	(*SystemQuarantineReq)(nil),     // 25: mgmt.SystemQuarantineReq
+	(*SystemEraseReq)(nil),          // 26: mgmt.SystemEraseReq
+	(*SystemArmReq)(nil),            // 27: mgmt.SystemArmReq
+	(*SystemCleanupReq)(nil),        // 28: mgmt.SystemCleanupReq
+	(*CheckEnableReq)(nil),          // 29: mgmt.CheckEnableReq
+	(*CheckDisableReq)(nil),         // 30: mgmt.CheckDisableReq
+	(*CheckStartReq)(nil),           // 31: mgmt.CheckStartReq
+	(*CheckStopReq)(nil),            // 32: mgmt.CheckStopReq
+	(*CheckQueryReq)(nil),           // 33: mgmt.CheckQueryReq
+	(*CheckSetPolicyReq)(nil),       // 34: mgmt.CheckSetPolicyReq
+	(*CheckGetPolicyReq)(nil),       // 35: mgmt.CheckGetPolicyReq
+	(*CheckActReq)(nil),             // 36: mgmt.CheckActReq
+	(*PoolUpgradeReq)(nil),          // 37: mgmt.PoolUpgradeReq
+	(*SystemSetAttrReq)(nil),        // 38: mgmt.SystemSetAttrReq
+	(*SystemGetAttrReq)(nil),        // 39: mgmt.SystemGetAttrReq
+	(*SystemSetPropReq)(nil),        // 40: mgmt.SystemSetPropReq
+	(*SystemGetPropReq)(nil),        // 41: mgmt.SystemGetPropReq
+	(*SystemHistoryReq)(nil),        // 42: mgmt.SystemHistoryReq
+	(*SystemEventsReq)(nil),         // 43: mgmt.SystemEventsReq
+	(*SystemEventAckReq)(nil),       // 44: mgmt.SystemEventAckReq
+	(*JobStatsQueryReq)(nil),        // 45: mgmt.JobStatsQueryReq
+	(*PortProbeReq)(nil),            // 46: mgmt.PortProbeReq
+	(*PoolActivityReq)(nil),         // 47: mgmt.PoolActivityReq
+	(*SystemDBHashReq)(nil),         // 48: mgmt.SystemDBHashReq
+	(*SystemDBVerifyReq)(nil),       // 49: mgmt.SystemDBVerifyReq
+	(*PoolOpJobsReq)(nil),           // 50: mgmt.PoolOpJobsReq
+	(*SystemTokenCreateReq)(nil),    // 51: mgmt.SystemTokenCreateReq
+	(*SystemRankUUIDsReq)(nil),      // 52: mgmt.SystemRankUUIDsReq
+	(*chk.CheckReport)(nil),         // 53: chk.CheckReport
+	(*chk.Fault)(nil),               // 54: chk.Fault
+	(*JoinResp)(nil),                // 55: mgmt.JoinResp
+	(*shared.ClusterEventResp)(nil), // 56: shared.ClusterEventResp
+	(*LeaderQueryResp)(nil),         // 57: mgmt.LeaderQueryResp
+	(*PoolCreateResp)(nil),          // 58: mgmt.PoolCreateResp
+	(*PoolDestroyResp)(nil),         // 59: mgmt.PoolDestroyResp
+	(*PoolEvictResp)(nil),           // 60: mgmt.PoolEvictResp
+	(*PoolExcludeResp)(nil),         // 61: mgmt.PoolExcludeResp
+	(*PoolDrainResp)(nil),           // 62: mgmt.PoolDrainResp
+	(*PoolExtendResp)(nil),          // 63: mgmt.PoolExtendResp
+	(*PoolReintegrateResp)(nil),     // 64: mgmt.PoolReintegrateResp
+	(*PoolQueryResp)(nil),           // 65: mgmt.PoolQueryResp
+	(*PoolQueryTargetResp)(nil),     // 66: mgmt.PoolQueryTargetResp
+	(*PoolSetPropResp)(nil),         // 67: mgmt.PoolSetPropResp
+	(*PoolGetPropResp)(nil),         // 68: mgmt.PoolGetPropResp
+	(*ACLResp)(nil),                 // 69: mgmt.ACLResp
+	(*GetAttachInfoResp)(nil),       // 70: mgmt.GetAttachInfoResp
+	(*ListPoolsResp)(nil),           // 71: mgmt.ListPoolsResp
+	(*ListContResp)(nil),            // 72: mgmt.ListContResp
+	(*ContSetOwnerResp)(nil),        // 73: mgmt.ContSetOwnerResp
+	(*SystemQueryResp)(nil),         // 74: mgmt.SystemQueryResp
+	(*SystemStopResp)(nil),          // 75: mgmt.SystemStopResp
+	(*SystemStartResp)(nil),         // 76: mgmt.SystemStartResp
+	(*SystemExcludeResp)(nil),       // 77: mgmt.SystemExcludeResp
+	(*SystemQuarantineResp)(nil),    // 78: mgmt.SystemQuarantineResp
+	(*SystemEraseResp)(nil),         // 79: mgmt.SystemEraseResp
+	(*SystemArmResp)(nil),           // 80: mgmt.SystemArmResp
+	(*SystemCleanupResp)(nil),       // 81: mgmt.SystemCleanupResp
+	(*DaosResp)(nil),                // 82: mgmt.DaosResp
+	(*CheckStartResp)(nil),          // 83: mgmt.CheckStartResp
+	(*CheckStopResp)(nil),           // 84: mgmt.CheckStopResp
+	(*CheckQueryResp)(nil),          // 85: mgmt.CheckQueryResp
+	(*CheckGetPolicyResp)(nil),      // 86: mgmt.CheckGetPolicyResp
+	(*CheckActResp)(nil),            // 87: mgmt.CheckActResp
+	(*PoolUpgradeResp)(nil),         // 88: mgmt.PoolUpgradeResp
+	(*SystemGetAttrResp)(nil),       // 89: mgmt.SystemGetAttrResp
+	(*SystemGetPropResp)(nil),       // 90: mgmt.SystemGetPropResp
+	(*SystemHistoryResp)(nil),       // 91: mgmt.SystemHistoryResp
+	(*SystemEventsResp)(nil),        // 92: mgmt.SystemEventsResp
+	(*JobStatsQueryResp)(nil),       // 93: mgmt.JobStatsQueryResp
+	(*PortProbeResp)(nil),           // 94: mgmt.PortProbeResp
+	(*PoolActivityResp)(nil),        // 95: mgmt.PoolActivityResp
+	(*SystemDBHashResp)(nil),        // 96: mgmt.SystemDBHashResp
+	(*SystemDBVerifyResp)(nil),      // 97: mgmt.SystemDBVerifyResp
+	(*PoolOpJobsResp)(nil),          // 98: mgmt.PoolOpJobsResp
+	(*SystemTokenCreateResp)(nil),   // 99: mgmt.SystemTokenCreateResp
+	(*SystemRankUUIDsResp)(nil),     // 100: mgmt.SystemRankUUIDsResp
 }
 var file_mgmt_mgmt_proto_depIdxs = []int32{
-	0,  // 0: mgmt.MgmtSvc.Join:input_type -> mgmt.JoinReq
-	1,  // 1: mgmt.MgmtSvc.ClusterEvent:input_type -> shared.ClusterEventReq
-	2,  // 2: mgmt.MgmtSvc.LeaderQuery:input_type -> mgmt.LeaderQueryReq
-	3,  // 3: mgmt.MgmtSvc.PoolCreate:input_type -> mgmt.PoolCreateReq
-	4,  // 4: mgmt.MgmtSvc.PoolDestroy:input_type -> mgmt.PoolDestroyReq
-	5,  // 5: mgmt.MgmtSvc.PoolEvict:input_type -> mgmt.PoolEvictReq
-	6,  // 6: mgmt.MgmtSvc.PoolExclude:input_type -> mgmt.PoolExcludeReq
-	7,  // 7: mgmt.MgmtSvc.PoolDrain:input_type -> mgmt.PoolDrainReq
-	8,  // 8: mgmt.MgmtSvc.PoolExtend:input_type -> mgmt.PoolExtendReq
-	9,  // 9: mgmt.MgmtSvc.PoolReintegrate:input_type -> mgmt.PoolReintegrateReq
-	10, // 10: mgmt.MgmtSvc.PoolQuery:input_type -> mgmt.PoolQueryReq
-	11, // 11: mgmt.MgmtSvc.PoolQueryTarget:input_type -> mgmt.PoolQueryTargetReq
-	12, // 12: mgmt.MgmtSvc.PoolSetProp:input_type -> mgmt.PoolSetPropReq
-	13, // 13: mgmt.MgmtSvc.PoolGetProp:input_type -> mgmt.PoolGetPropReq
-	14, // 14: mgmt.MgmtSvc.PoolGetACL:input_type -> mgmt.GetACLReq
-	15, // 15: mgmt.MgmtSvc.PoolOverwriteACL:input_type -> mgmt.ModifyACLReq
-	15, // 16: mgmt.MgmtSvc.PoolUpdateACL:input_type -> mgmt.ModifyACLReq
-	16, // 17: mgmt.MgmtSvc.PoolDeleteACL:input_type -> mgmt.DeleteACLReq
-	17, // 18: mgmt.MgmtSvc.GetAttachInfo:input_type -> mgmt.GetAttachInfoReq
-	18, // 19: mgmt.MgmtSvc.ListPools:input_type -> mgmt.ListPoolsReq
-	19, // 20: mgmt.MgmtSvc.ListContainers:input_type -> mgmt.ListContReq
-	20, // 21: mgmt.MgmtSvc.ContSetOwner:input_type -> mgmt.ContSetOwnerReq
-	21, // 22: mgmt.MgmtSvc.SystemQuery:input_type -> mgmt.SystemQueryReq
-	22, // 23: mgmt.MgmtSvc.SystemStop:input_type -> mgmt.SystemStopReq
-	23, // 24: mgmt.MgmtSvc.SystemStart:input_type -> mgmt.SystemStartReq
-	24, // 25: mgmt.MgmtSvc.SystemExclude:input_type -> mgmt.SystemExcludeReq
-	25, // 26: mgmt.MgmtSvc.SystemErase:input_type -> mgmt.SystemEraseReq
-	26, // 27: mgmt.MgmtSvc.SystemCleanup:input_type -> mgmt.SystemCleanupReq
-	27, // 28: mgmt.MgmtSvc.SystemCheckEnable:input_type -> mgmt.CheckEnableReq
-	28, // 29: mgmt.MgmtSvc.SystemCheckDisable:input_type -> mgmt.CheckDisableReq
-	29, // 30: mgmt.MgmtSvc.SystemCheckStart:input_type -> mgmt.CheckStartReq
-	30, // 31: mgmt.MgmtSvc.SystemCheckStop:input_type -> mgmt.CheckStopReq
-	31, // 32: mgmt.MgmtSvc.SystemCheckQuery:input_type -> mgmt.CheckQueryReq
-	32, // 33: mgmt.MgmtSvc.SystemCheckSetPolicy:input_type -> mgmt.CheckSetPolicyReq
-	33, // 34: mgmt.MgmtSvc.SystemCheckGetPolicy:input_type -> mgmt.CheckGetPolicyReq
-	34, // 35: mgmt.MgmtSvc.SystemCheckRepair:input_type -> mgmt.CheckActReq
-	35, // 36: mgmt.MgmtSvc.PoolUpgrade:input_type -> mgmt.PoolUpgradeReq
-	36, // 37: mgmt.MgmtSvc.SystemSetAttr:input_type -> mgmt.SystemSetAttrReq
-	37, // 38: mgmt.MgmtSvc.SystemGetAttr:input_type -> mgmt.SystemGetAttrReq
-	38, // 39: mgmt.MgmtSvc.SystemSetProp:input_type -> mgmt.SystemSetPropReq
-	39, // 40: mgmt.MgmtSvc.SystemGetProp:input_type -> mgmt.SystemGetPropReq
-	40, // 41: mgmt.MgmtSvc.FaultInjectReport:input_type -> chk.CheckReport
-	41, // 42: mgmt.MgmtSvc.FaultInjectPoolFault:input_type -> chk.Fault
-	41, // 43: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:input_type -> chk.Fault
-	42, // 44: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
-	43, // 45: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
-	44, // 46: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
-	45, // 47: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
-	46, // 48: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
-	47, // 49: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
-	48, // 50: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
-	49, // 51: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
-	50, // 52: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
-	51, // 53: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintegrateResp
-	52, // 54: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
-	53, // 55: mgmt.MgmtSvc.PoolQueryTarget:output_type -> mgmt.PoolQueryTargetResp
-	54, // 56: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
-	55, // 57: mgmt.MgmtSvc.PoolGetProp:output_type -> mgmt.PoolGetPropResp
-	56, // 58: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
-	56, // 59: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
-	56, // 60: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
-	56, // 61: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
-	57, // 62: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
-	58, // 63: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
-	59, // 64: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
-	60, // 65: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.ContSetOwnerResp
-	61, // 66: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
-	62, // 67: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
-	63, // 68: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
-	64, // 69: mgmt.MgmtSvc.SystemExclude:output_type -> mgmt.SystemExcludeResp
-	65, // 70: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
-	66, // 71: mgmt.MgmtSvc.SystemCleanup:output_type -> mgmt.SystemCleanupResp
-	67, // 72: mgmt.MgmtSvc.SystemCheckEnable:output_type -> mgmt.DaosResp
-	67, // 73: mgmt.MgmtSvc.SystemCheckDisable:output_type -> mgmt.DaosResp
-	68, // 74: mgmt.MgmtSvc.SystemCheckStart:output_type -> mgmt.CheckStartResp
-	69, // 75: mgmt.MgmtSvc.SystemCheckStop:output_type -> mgmt.CheckStopResp
-	70, // 76: mgmt.MgmtSvc.SystemCheckQuery:output_type -> mgmt.CheckQueryResp
-	67, // 77: mgmt.MgmtSvc.SystemCheckSetPolicy:output_type -> mgmt.DaosResp
-	71, // 78: mgmt.MgmtSvc.SystemCheckGetPolicy:output_type -> mgmt.CheckGetPolicyResp
-	72, // 79: mgmt.MgmtSvc.SystemCheckRepair:output_type -> mgmt.CheckActResp
-	73, // 80: mgmt.MgmtSvc.PoolUpgrade:output_type -> mgmt.PoolUpgradeResp
-	67, // 81: mgmt.MgmtSvc.SystemSetAttr:output_type -> mgmt.DaosResp
-	74, // 82: mgmt.MgmtSvc.SystemGetAttr:output_type -> mgmt.SystemGetAttrResp
-	67, // 83: mgmt.MgmtSvc.SystemSetProp:output_type -> mgmt.DaosResp
-	75, // 84: mgmt.MgmtSvc.SystemGetProp:output_type -> mgmt.SystemGetPropResp
-	67, // 85: mgmt.MgmtSvc.FaultInjectReport:output_type -> mgmt.DaosResp
-	67, // 86: mgmt.MgmtSvc.FaultInjectPoolFault:output_type -> mgmt.DaosResp
-	67, // 87: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:output_type -> mgmt.DaosResp
-	44, // [44:88] is the sub-list for method output_type
-	0,  // [0:44] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	0,   // 0: mgmt.MgmtSvc.Join:input_type -> mgmt.JoinReq
+	1,   // 1: mgmt.MgmtSvc.ClusterEvent:input_type -> shared.ClusterEventReq
+	2,   // 2: mgmt.MgmtSvc.LeaderQuery:input_type -> mgmt.LeaderQueryReq
+	3,   // 3: mgmt.MgmtSvc.PoolCreate:input_type -> mgmt.PoolCreateReq
+	4,   // 4: mgmt.MgmtSvc.PoolDestroy:input_type -> mgmt.PoolDestroyReq
+	5,   // 5: mgmt.MgmtSvc.PoolEvict:input_type -> mgmt.PoolEvictReq
+	6,   // 6: mgmt.MgmtSvc.PoolExclude:input_type -> mgmt.PoolExcludeReq
+	7,   // 7: mgmt.MgmtSvc.PoolDrain:input_type -> mgmt.PoolDrainReq
+	8,   // 8: mgmt.MgmtSvc.PoolExtend:input_type -> mgmt.PoolExtendReq
+	9,   // 9: mgmt.MgmtSvc.PoolReintegrate:input_type -> mgmt.PoolReintegrateReq
+	10,  // 10: mgmt.MgmtSvc.PoolQuery:input_type -> mgmt.PoolQueryReq
+	11,  // 11: mgmt.MgmtSvc.PoolQueryTarget:input_type -> mgmt.PoolQueryTargetReq
+	12,  // 12: mgmt.MgmtSvc.PoolSetProp:input_type -> mgmt.PoolSetPropReq
+	13,  // 13: mgmt.MgmtSvc.PoolGetProp:input_type -> mgmt.PoolGetPropReq
+	14,  // 14: mgmt.MgmtSvc.PoolGetACL:input_type -> mgmt.GetACLReq
+	15,  // 15: mgmt.MgmtSvc.PoolOverwriteACL:input_type -> mgmt.ModifyACLReq
+	15,  // 16: mgmt.MgmtSvc.PoolUpdateACL:input_type -> mgmt.ModifyACLReq
+	16,  // 17: mgmt.MgmtSvc.PoolDeleteACL:input_type -> mgmt.DeleteACLReq
+	17,  // 18: mgmt.MgmtSvc.GetAttachInfo:input_type -> mgmt.GetAttachInfoReq
+	18,  // 19: mgmt.MgmtSvc.ListPools:input_type -> mgmt.ListPoolsReq
+	19,  // 20: mgmt.MgmtSvc.ListContainers:input_type -> mgmt.ListContReq
+	20,  // 21: mgmt.MgmtSvc.ContSetOwner:input_type -> mgmt.ContSetOwnerReq
+	21,  // 22: mgmt.MgmtSvc.SystemQuery:input_type -> mgmt.SystemQueryReq
+	22,  // 23: mgmt.MgmtSvc.SystemStop:input_type -> mgmt.SystemStopReq
+	23,  // 24: mgmt.MgmtSvc.SystemStart:input_type -> mgmt.SystemStartReq
+	24,  // 25: mgmt.MgmtSvc.SystemExclude:input_type -> mgmt.SystemExcludeReq
+	25,  // 26: mgmt.MgmtSvc.SystemQuarantine:input_type -> mgmt.SystemQuarantineReq
+	26,  // 27: mgmt.MgmtSvc.SystemErase:input_type -> mgmt.SystemEraseReq
+	27,  // 28: mgmt.MgmtSvc.SystemArm:input_type -> mgmt.SystemArmReq
+	28,  // 29: mgmt.MgmtSvc.SystemCleanup:input_type -> mgmt.SystemCleanupReq
+	29,  // 30: mgmt.MgmtSvc.SystemCheckEnable:input_type -> mgmt.CheckEnableReq
+	30,  // 31: mgmt.MgmtSvc.SystemCheckDisable:input_type -> mgmt.CheckDisableReq
+	31,  // 32: mgmt.MgmtSvc.SystemCheckStart:input_type -> mgmt.CheckStartReq
+	32,  // 33: mgmt.MgmtSvc.SystemCheckStop:input_type -> mgmt.CheckStopReq
+	33,  // 34: mgmt.MgmtSvc.SystemCheckQuery:input_type -> mgmt.CheckQueryReq
+	34,  // 35: mgmt.MgmtSvc.SystemCheckSetPolicy:input_type -> mgmt.CheckSetPolicyReq
+	35,  // 36: mgmt.MgmtSvc.SystemCheckGetPolicy:input_type -> mgmt.CheckGetPolicyReq
+	36,  // 37: mgmt.MgmtSvc.SystemCheckRepair:input_type -> mgmt.CheckActReq
+	37,  // 38: mgmt.MgmtSvc.PoolUpgrade:input_type -> mgmt.PoolUpgradeReq
+	38,  // 39: mgmt.MgmtSvc.SystemSetAttr:input_type -> mgmt.SystemSetAttrReq
+	39,  // 40: mgmt.MgmtSvc.SystemGetAttr:input_type -> mgmt.SystemGetAttrReq
+	40,  // 41: mgmt.MgmtSvc.SystemSetProp:input_type -> mgmt.SystemSetPropReq
+	41,  // 42: mgmt.MgmtSvc.SystemGetProp:input_type -> mgmt.SystemGetPropReq
+	42,  // 43: mgmt.MgmtSvc.SystemHistory:input_type -> mgmt.SystemHistoryReq
+	43,  // 44: mgmt.MgmtSvc.SystemEvents:input_type -> mgmt.SystemEventsReq
+	44,  // 45: mgmt.MgmtSvc.SystemEventAck:input_type -> mgmt.SystemEventAckReq
+	45,  // 46: mgmt.MgmtSvc.JobStatsQuery:input_type -> mgmt.JobStatsQueryReq
+	46,  // 47: mgmt.MgmtSvc.PortProbe:input_type -> mgmt.PortProbeReq
+	47,  // 48: mgmt.MgmtSvc.PoolActivity:input_type -> mgmt.PoolActivityReq
+	48,  // 49: mgmt.MgmtSvc.SystemDBHash:input_type -> mgmt.SystemDBHashReq
+	49,  // 50: mgmt.MgmtSvc.SystemDBVerify:input_type -> mgmt.SystemDBVerifyReq
+	50,  // 51: mgmt.MgmtSvc.PoolOpJobs:input_type -> mgmt.PoolOpJobsReq
+	51,  // 52: mgmt.MgmtSvc.SystemTokenCreate:input_type -> mgmt.SystemTokenCreateReq
+	52,  // 53: mgmt.MgmtSvc.SystemRankUUIDs:input_type -> mgmt.SystemRankUUIDsReq
+	53,  // 54: mgmt.MgmtSvc.FaultInjectReport:input_type -> chk.CheckReport
+	54,  // 55: mgmt.MgmtSvc.FaultInjectPoolFault:input_type -> chk.Fault
+	54,  // 56: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:input_type -> chk.Fault
+	55,  // 57: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
+	56,  // 58: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
+	57,  // 59: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
+	58,  // 60: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
+	59,  // 61: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
+	60,  // 62: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
+	61,  // 63: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
+	62,  // 64: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
+	63,  // 65: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
+	64,  // 66: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintegrateResp
+	65,  // 67: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
+	66,  // 68: mgmt.MgmtSvc.PoolQueryTarget:output_type -> mgmt.PoolQueryTargetResp
+	67,  // 69: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
+	68,  // 70: mgmt.MgmtSvc.PoolGetProp:output_type -> mgmt.PoolGetPropResp
+	69,  // 71: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
+	69,  // 72: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
+	69,  // 73: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
+	69,  // 74: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
+	70,  // 75: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
+	71,  // 76: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
+	72,  // 77: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
+	73,  // 78: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.ContSetOwnerResp
+	74,  // 79: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
+	75,  // 80: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
+	76,  // 81: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
+	77,  // 82: mgmt.MgmtSvc.SystemExclude:output_type -> mgmt.SystemExcludeResp
+	78,  // 83: mgmt.MgmtSvc.SystemQuarantine:output_type -> mgmt.SystemQuarantineResp
+	79,  // 84: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
+	80,  // 85: mgmt.MgmtSvc.SystemArm:output_type -> mgmt.SystemArmResp
+	81,  // 86: mgmt.MgmtSvc.SystemCleanup:output_type -> mgmt.SystemCleanupResp
+	82,  // 87: mgmt.MgmtSvc.SystemCheckEnable:output_type -> mgmt.DaosResp
+	82,  // 88: mgmt.MgmtSvc.SystemCheckDisable:output_type -> mgmt.DaosResp
+	83,  // 89: mgmt.MgmtSvc.SystemCheckStart:output_type -> mgmt.CheckStartResp
+	84,  // 90: mgmt.MgmtSvc.SystemCheckStop:output_type -> mgmt.CheckStopResp
+	85,  // 91: mgmt.MgmtSvc.SystemCheckQuery:output_type -> mgmt.CheckQueryResp
+	82,  // 92: mgmt.MgmtSvc.SystemCheckSetPolicy:output_type -> mgmt.DaosResp
+	86,  // 93: mgmt.MgmtSvc.SystemCheckGetPolicy:output_type -> mgmt.CheckGetPolicyResp
+	87,  // 94: mgmt.MgmtSvc.SystemCheckRepair:output_type -> mgmt.CheckActResp
+	88,  // 95: mgmt.MgmtSvc.PoolUpgrade:output_type -> mgmt.PoolUpgradeResp
+	82,  // 96: mgmt.MgmtSvc.SystemSetAttr:output_type -> mgmt.DaosResp
+	89,  // 97: mgmt.MgmtSvc.SystemGetAttr:output_type -> mgmt.SystemGetAttrResp
+	82,  // 98: mgmt.MgmtSvc.SystemSetProp:output_type -> mgmt.DaosResp
+	90,  // 99: mgmt.MgmtSvc.SystemGetProp:output_type -> mgmt.SystemGetPropResp
+	91,  // 100: mgmt.MgmtSvc.SystemHistory:output_type -> mgmt.SystemHistoryResp
+	92,  // 101: mgmt.MgmtSvc.SystemEvents:output_type -> mgmt.SystemEventsResp
+	82,  // 102: mgmt.MgmtSvc.SystemEventAck:output_type -> mgmt.DaosResp
+	93,  // 103: mgmt.MgmtSvc.JobStatsQuery:output_type -> mgmt.JobStatsQueryResp
+	94,  // 104: mgmt.MgmtSvc.PortProbe:output_type -> mgmt.PortProbeResp
+	95,  // 105: mgmt.MgmtSvc.PoolActivity:output_type -> mgmt.PoolActivityResp
+	96,  // 106: mgmt.MgmtSvc.SystemDBHash:output_type -> mgmt.SystemDBHashResp
+	97,  // 107: mgmt.MgmtSvc.SystemDBVerify:output_type -> mgmt.SystemDBVerifyResp
+	98,  // 108: mgmt.MgmtSvc.PoolOpJobs:output_type -> mgmt.PoolOpJobsResp
+	99,  // 109: mgmt.MgmtSvc.SystemTokenCreate:output_type -> mgmt.SystemTokenCreateResp
+	100, // 110: mgmt.MgmtSvc.SystemRankUUIDs:output_type -> mgmt.SystemRankUUIDsResp
+	82,  // 111: mgmt.MgmtSvc.FaultInjectReport:output_type -> mgmt.DaosResp
+	82,  // 112: mgmt.MgmtSvc.FaultInjectPoolFault:output_type -> mgmt.DaosResp
+	82,  // 113: mgmt.MgmtSvc.FaultInjectMgmtPoolFault:output_type -> mgmt.DaosResp
+	57,  // [57:114] is the sub-list for method output_type
+	0,   // [0:57] is the sub-list for method input_type
+	0,   // [0:0] is the sub-list for extension type_name
+	0,   // [0:0] is the sub-list for extension extendee
+	0,   // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_mgmt_mgmt_proto_init() }
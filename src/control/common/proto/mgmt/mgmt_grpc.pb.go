@@ -53,7 +53,9 @@ const (
 	MgmtSvc_SystemStop_FullMethodName               = "/mgmt.MgmtSvc/SystemStop"
 	MgmtSvc_SystemStart_FullMethodName              = "/mgmt.MgmtSvc/SystemStart"
 	MgmtSvc_SystemExclude_FullMethodName            = "/mgmt.MgmtSvc/SystemExclude"
+	MgmtSvc_SystemQuarantine_FullMethodName         = "/mgmt.MgmtSvc/SystemQuarantine"
 	MgmtSvc_SystemErase_FullMethodName              = "/mgmt.MgmtSvc/SystemErase"
+	MgmtSvc_SystemArm_FullMethodName                = "/mgmt.MgmtSvc/SystemArm"
 	MgmtSvc_SystemCleanup_FullMethodName            = "/mgmt.MgmtSvc/SystemCleanup"
 	MgmtSvc_SystemCheckEnable_FullMethodName        = "/mgmt.MgmtSvc/SystemCheckEnable"
 	MgmtSvc_SystemCheckDisable_FullMethodName       = "/mgmt.MgmtSvc/SystemCheckDisable"
@@ -68,6 +70,17 @@ const (
 	MgmtSvc_SystemGetAttr_FullMethodName            = "/mgmt.MgmtSvc/SystemGetAttr"
 	MgmtSvc_SystemSetProp_FullMethodName            = "/mgmt.MgmtSvc/SystemSetProp"
 	MgmtSvc_SystemGetProp_FullMethodName            = "/mgmt.MgmtSvc/SystemGetProp"
+	MgmtSvc_SystemHistory_FullMethodName            = "/mgmt.MgmtSvc/SystemHistory"
+	MgmtSvc_SystemEvents_FullMethodName             = "/mgmt.MgmtSvc/SystemEvents"
+	MgmtSvc_SystemEventAck_FullMethodName           = "/mgmt.MgmtSvc/SystemEventAck"
+	MgmtSvc_JobStatsQuery_FullMethodName            = "/mgmt.MgmtSvc/JobStatsQuery"
+	MgmtSvc_PortProbe_FullMethodName                = "/mgmt.MgmtSvc/PortProbe"
+	MgmtSvc_PoolActivity_FullMethodName             = "/mgmt.MgmtSvc/PoolActivity"
+	MgmtSvc_SystemDBHash_FullMethodName             = "/mgmt.MgmtSvc/SystemDBHash"
+	MgmtSvc_SystemDBVerify_FullMethodName           = "/mgmt.MgmtSvc/SystemDBVerify"
+	MgmtSvc_PoolOpJobs_FullMethodName               = "/mgmt.MgmtSvc/PoolOpJobs"
+	MgmtSvc_SystemTokenCreate_FullMethodName        = "/mgmt.MgmtSvc/SystemTokenCreate"
+	MgmtSvc_SystemRankUUIDs_FullMethodName          = "/mgmt.MgmtSvc/SystemRankUUIDs"
 	MgmtSvc_FaultInjectReport_FullMethodName        = "/mgmt.MgmtSvc/FaultInjectReport"
 	MgmtSvc_FaultInjectPoolFault_FullMethodName     = "/mgmt.MgmtSvc/FaultInjectPoolFault"
 	MgmtSvc_FaultInjectMgmtPoolFault_FullMethodName = "/mgmt.MgmtSvc/FaultInjectMgmtPoolFault"
@@ -130,8 +143,12 @@ type MgmtSvcClient interface {
 	SystemStart(ctx context.Context, in *SystemStartReq, opts ...grpc.CallOption) (*SystemStartResp, error)
 	// Exclude DAOS ranks
 	SystemExclude(ctx context.Context, in *SystemExcludeReq, opts ...grpc.CallOption) (*SystemExcludeResp, error)
+	// Quarantine DAOS ranks, excluding them from new pool placements
+	SystemQuarantine(ctx context.Context, in *SystemQuarantineReq, opts ...grpc.CallOption) (*SystemQuarantineResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(ctx context.Context, in *SystemEraseReq, opts ...grpc.CallOption) (*SystemEraseResp, error)
+	// Arm a destructive system operation
+	SystemArm(ctx context.Context, in *SystemArmReq, opts ...grpc.CallOption) (*SystemArmResp, error)
 	// Clean up leaked resources for a given node
 	SystemCleanup(ctx context.Context, in *SystemCleanupReq, opts ...grpc.CallOption) (*SystemCleanupResp, error)
 	// Enable system check mode
@@ -160,6 +177,28 @@ type MgmtSvcClient interface {
 	SystemSetProp(ctx context.Context, in *SystemSetPropReq, opts ...grpc.CallOption) (*DaosResp, error)
 	// Get a system property or properties.
 	SystemGetProp(ctx context.Context, in *SystemGetPropReq, opts ...grpc.CallOption) (*SystemGetPropResp, error)
+	// Retrieve the history of system health snapshots.
+	SystemHistory(ctx context.Context, in *SystemHistoryReq, opts ...grpc.CallOption) (*SystemHistoryResp, error)
+	// Retrieve the persistent events recorded by the MS.
+	SystemEvents(ctx context.Context, in *SystemEventsReq, opts ...grpc.CallOption) (*SystemEventsResp, error)
+	// Acknowledge persistent events.
+	SystemEventAck(ctx context.Context, in *SystemEventAckReq, opts ...grpc.CallOption) (*DaosResp, error)
+	// Retrieve the system-wide I/O totals for a job or jobs.
+	JobStatsQuery(ctx context.Context, in *JobStatsQueryReq, opts ...grpc.CallOption) (*JobStatsQueryResp, error)
+	// Check that the ports of a starting server are reachable.
+	PortProbe(ctx context.Context, in *PortProbeReq, opts ...grpc.CallOption) (*PortProbeResp, error)
+	// Retrieve the activity log of a pool.
+	PoolActivity(ctx context.Context, in *PoolActivityReq, opts ...grpc.CallOption) (*PoolActivityResp, error)
+	// Retrieve the content hash of a MS replica's database state.
+	SystemDBHash(ctx context.Context, in *SystemDBHashReq, opts ...grpc.CallOption) (*SystemDBHashResp, error)
+	// Compare the database state of all MS replicas.
+	SystemDBVerify(ctx context.Context, in *SystemDBVerifyReq, opts ...grpc.CallOption) (*SystemDBVerifyResp, error)
+	// Retrieve the pool operations queued or running under the scheduler.
+	PoolOpJobs(ctx context.Context, in *PoolOpJobsReq, opts ...grpc.CallOption) (*PoolOpJobsResp, error)
+	// Create a token that allows a new server to join the system.
+	SystemTokenCreate(ctx context.Context, in *SystemTokenCreateReq, opts ...grpc.CallOption) (*SystemTokenCreateResp, error)
+	// Retrieve the member UUIDs recorded for a server's ranks.
+	SystemRankUUIDs(ctx context.Context, in *SystemRankUUIDsReq, opts ...grpc.CallOption) (*SystemRankUUIDsResp, error)
 	// Fault injection handlers are only implemented in non-release builds.
 	// FaultInjectReport injects a checker report.
 	FaultInjectReport(ctx context.Context, in *chk.CheckReport, opts ...grpc.CallOption) (*DaosResp, error)
@@ -410,6 +449,15 @@ func (c *mgmtSvcClient) SystemExclude(ctx context.Context, in *SystemExcludeReq,
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SystemQuarantine(ctx context.Context, in *SystemQuarantineReq, opts ...grpc.CallOption) (*SystemQuarantineResp, error) {
+	out := new(SystemQuarantineResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemQuarantine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mgmtSvcClient) SystemErase(ctx context.Context, in *SystemEraseReq, opts ...grpc.CallOption) (*SystemEraseResp, error) {
 	out := new(SystemEraseResp)
 	err := c.cc.Invoke(ctx, MgmtSvc_SystemErase_FullMethodName, in, out, opts...)
@@ -419,6 +467,15 @@ func (c *mgmtSvcClient) SystemErase(ctx context.Context, in *SystemEraseReq, opt
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SystemArm(ctx context.Context, in *SystemArmReq, opts ...grpc.CallOption) (*SystemArmResp, error) {
+	out := new(SystemArmResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemArm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mgmtSvcClient) SystemCleanup(ctx context.Context, in *SystemCleanupReq, opts ...grpc.CallOption) (*SystemCleanupResp, error) {
 	out := new(SystemCleanupResp)
 	err := c.cc.Invoke(ctx, MgmtSvc_SystemCleanup_FullMethodName, in, out, opts...)
@@ -545,6 +602,105 @@ func (c *mgmtSvcClient) SystemGetProp(ctx context.Context, in *SystemGetPropReq,
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SystemHistory(ctx context.Context, in *SystemHistoryReq, opts ...grpc.CallOption) (*SystemHistoryResp, error) {
+	out := new(SystemHistoryResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemEvents(ctx context.Context, in *SystemEventsReq, opts ...grpc.CallOption) (*SystemEventsResp, error) {
+	out := new(SystemEventsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemEventAck(ctx context.Context, in *SystemEventAckReq, opts ...grpc.CallOption) (*DaosResp, error) {
+	out := new(DaosResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemEventAck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) JobStatsQuery(ctx context.Context, in *JobStatsQueryReq, opts ...grpc.CallOption) (*JobStatsQueryResp, error) {
+	out := new(JobStatsQueryResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_JobStatsQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) PortProbe(ctx context.Context, in *PortProbeReq, opts ...grpc.CallOption) (*PortProbeResp, error) {
+	out := new(PortProbeResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_PortProbe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) PoolActivity(ctx context.Context, in *PoolActivityReq, opts ...grpc.CallOption) (*PoolActivityResp, error) {
+	out := new(PoolActivityResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_PoolActivity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemDBHash(ctx context.Context, in *SystemDBHashReq, opts ...grpc.CallOption) (*SystemDBHashResp, error) {
+	out := new(SystemDBHashResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemDBHash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemDBVerify(ctx context.Context, in *SystemDBVerifyReq, opts ...grpc.CallOption) (*SystemDBVerifyResp, error) {
+	out := new(SystemDBVerifyResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemDBVerify_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) PoolOpJobs(ctx context.Context, in *PoolOpJobsReq, opts ...grpc.CallOption) (*PoolOpJobsResp, error) {
+	out := new(PoolOpJobsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_PoolOpJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemTokenCreate(ctx context.Context, in *SystemTokenCreateReq, opts ...grpc.CallOption) (*SystemTokenCreateResp, error) {
+	out := new(SystemTokenCreateResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemTokenCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemRankUUIDs(ctx context.Context, in *SystemRankUUIDsReq, opts ...grpc.CallOption) (*SystemRankUUIDsResp, error) {
+	out := new(SystemRankUUIDsResp)
+	err := c.cc.Invoke(ctx, MgmtSvc_SystemRankUUIDs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mgmtSvcClient) FaultInjectReport(ctx context.Context, in *chk.CheckReport, opts ...grpc.CallOption) (*DaosResp, error) {
 	out := new(DaosResp)
 	err := c.cc.Invoke(ctx, MgmtSvc_FaultInjectReport_FullMethodName, in, out, opts...)
@@ -629,8 +785,12 @@ type MgmtSvcServer interface {
 	SystemStart(context.Context, *SystemStartReq) (*SystemStartResp, error)
 	// Exclude DAOS ranks
 	SystemExclude(context.Context, *SystemExcludeReq) (*SystemExcludeResp, error)
+	// Quarantine DAOS ranks, excluding them from new pool placements
+	SystemQuarantine(context.Context, *SystemQuarantineReq) (*SystemQuarantineResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error)
+	// Arm a destructive system operation
+	SystemArm(context.Context, *SystemArmReq) (*SystemArmResp, error)
 	// Clean up leaked resources for a given node
 	SystemCleanup(context.Context, *SystemCleanupReq) (*SystemCleanupResp, error)
 	// Enable system check mode
@@ -659,6 +819,28 @@ type MgmtSvcServer interface {
 	SystemSetProp(context.Context, *SystemSetPropReq) (*DaosResp, error)
 	// Get a system property or properties.
 	SystemGetProp(context.Context, *SystemGetPropReq) (*SystemGetPropResp, error)
+	// Retrieve the history of system health snapshots.
+	SystemHistory(context.Context, *SystemHistoryReq) (*SystemHistoryResp, error)
+	// Retrieve the persistent events recorded by the MS.
+	SystemEvents(context.Context, *SystemEventsReq) (*SystemEventsResp, error)
+	// Acknowledge persistent events.
+	SystemEventAck(context.Context, *SystemEventAckReq) (*DaosResp, error)
+	// Retrieve the system-wide I/O totals for a job or jobs.
+	JobStatsQuery(context.Context, *JobStatsQueryReq) (*JobStatsQueryResp, error)
+	// Check that the ports of a starting server are reachable.
+	PortProbe(context.Context, *PortProbeReq) (*PortProbeResp, error)
+	// Retrieve the activity log of a pool.
+	PoolActivity(context.Context, *PoolActivityReq) (*PoolActivityResp, error)
+	// Retrieve the content hash of a MS replica's database state.
+	SystemDBHash(context.Context, *SystemDBHashReq) (*SystemDBHashResp, error)
+	// Compare the database state of all MS replicas.
+	SystemDBVerify(context.Context, *SystemDBVerifyReq) (*SystemDBVerifyResp, error)
+	// Retrieve the pool operations queued or running under the scheduler.
+	PoolOpJobs(context.Context, *PoolOpJobsReq) (*PoolOpJobsResp, error)
+	// Create a token that allows a new server to join the system.
+	SystemTokenCreate(context.Context, *SystemTokenCreateReq) (*SystemTokenCreateResp, error)
+	// Retrieve the member UUIDs recorded for a server's ranks.
+	SystemRankUUIDs(context.Context, *SystemRankUUIDsReq) (*SystemRankUUIDsResp, error)
 	// Fault injection handlers are only implemented in non-release builds.
 	// FaultInjectReport injects a checker report.
 	FaultInjectReport(context.Context, *chk.CheckReport) (*DaosResp, error)
@@ -750,9 +932,15 @@ func (UnimplementedMgmtSvcServer) SystemStart(context.Context, *SystemStartReq)
 func (UnimplementedMgmtSvcServer) SystemExclude(context.Context, *SystemExcludeReq) (*SystemExcludeResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemExclude not implemented")
 }
+func (UnimplementedMgmtSvcServer) SystemQuarantine(context.Context, *SystemQuarantineReq) (*SystemQuarantineResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemQuarantine not implemented")
+}
 func (UnimplementedMgmtSvcServer) SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemErase not implemented")
 }
+func (UnimplementedMgmtSvcServer) SystemArm(context.Context, *SystemArmReq) (*SystemArmResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemArm not implemented")
+}
 func (UnimplementedMgmtSvcServer) SystemCleanup(context.Context, *SystemCleanupReq) (*SystemCleanupResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemCleanup not implemented")
 }
@@ -795,6 +983,39 @@ func (UnimplementedMgmtSvcServer) SystemSetProp(context.Context, *SystemSetPropR
 func (UnimplementedMgmtSvcServer) SystemGetProp(context.Context, *SystemGetPropReq) (*SystemGetPropResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemGetProp not implemented")
 }
+func (UnimplementedMgmtSvcServer) SystemHistory(context.Context, *SystemHistoryReq) (*SystemHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemHistory not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemEvents(context.Context, *SystemEventsReq) (*SystemEventsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemEvents not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemEventAck(context.Context, *SystemEventAckReq) (*DaosResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemEventAck not implemented")
+}
+func (UnimplementedMgmtSvcServer) JobStatsQuery(context.Context, *JobStatsQueryReq) (*JobStatsQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobStatsQuery not implemented")
+}
+func (UnimplementedMgmtSvcServer) PortProbe(context.Context, *PortProbeReq) (*PortProbeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PortProbe not implemented")
+}
+func (UnimplementedMgmtSvcServer) PoolActivity(context.Context, *PoolActivityReq) (*PoolActivityResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PoolActivity not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemDBHash(context.Context, *SystemDBHashReq) (*SystemDBHashResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemDBHash not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemDBVerify(context.Context, *SystemDBVerifyReq) (*SystemDBVerifyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemDBVerify not implemented")
+}
+func (UnimplementedMgmtSvcServer) PoolOpJobs(context.Context, *PoolOpJobsReq) (*PoolOpJobsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PoolOpJobs not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemTokenCreate(context.Context, *SystemTokenCreateReq) (*SystemTokenCreateResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemTokenCreate not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemRankUUIDs(context.Context, *SystemRankUUIDsReq) (*SystemRankUUIDsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemRankUUIDs not implemented")
+}
 func (UnimplementedMgmtSvcServer) FaultInjectReport(context.Context, *chk.CheckReport) (*DaosResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FaultInjectReport not implemented")
 }
@@ -1285,6 +1506,24 @@ func _MgmtSvc_SystemExclude_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SystemQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemQuarantineReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemQuarantine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemQuarantine(ctx, req.(*SystemQuarantineReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MgmtSvc_SystemErase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SystemEraseReq)
 	if err := dec(in); err != nil {
@@ -1303,6 +1542,24 @@ func _MgmtSvc_SystemErase_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SystemArm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemArmReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemArm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemArm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemArm(ctx, req.(*SystemArmReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MgmtSvc_SystemCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SystemCleanupReq)
 	if err := dec(in); err != nil {
@@ -1555,6 +1812,204 @@ func _MgmtSvc_SystemGetProp_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SystemHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemHistory(ctx, req.(*SystemHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemEvents(ctx, req.(*SystemEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemEventAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemEventAckReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemEventAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemEventAck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemEventAck(ctx, req.(*SystemEventAckReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_JobStatsQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatsQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).JobStatsQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_JobStatsQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).JobStatsQuery(ctx, req.(*JobStatsQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_PortProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortProbeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).PortProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_PortProbe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).PortProbe(ctx, req.(*PortProbeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_PoolActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).PoolActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_PoolActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).PoolActivity(ctx, req.(*PoolActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemDBHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemDBHashReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemDBHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemDBHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemDBHash(ctx, req.(*SystemDBHashReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemDBVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemDBVerifyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemDBVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemDBVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemDBVerify(ctx, req.(*SystemDBVerifyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_PoolOpJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolOpJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).PoolOpJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_PoolOpJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).PoolOpJobs(ctx, req.(*PoolOpJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemTokenCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemTokenCreateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemTokenCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemTokenCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemTokenCreate(ctx, req.(*SystemTokenCreateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemRankUUIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemRankUUIDsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemRankUUIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MgmtSvc_SystemRankUUIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemRankUUIDs(ctx, req.(*SystemRankUUIDsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MgmtSvc_FaultInjectReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(chk.CheckReport)
 	if err := dec(in); err != nil {
@@ -1720,10 +2175,18 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SystemExclude",
 			Handler:    _MgmtSvc_SystemExclude_Handler,
 		},
+		{
+			MethodName: "SystemQuarantine",
+			Handler:    _MgmtSvc_SystemQuarantine_Handler,
+		},
 		{
 			MethodName: "SystemErase",
 			Handler:    _MgmtSvc_SystemErase_Handler,
 		},
+		{
+			MethodName: "SystemArm",
+			Handler:    _MgmtSvc_SystemArm_Handler,
+		},
 		{
 			MethodName: "SystemCleanup",
 			Handler:    _MgmtSvc_SystemCleanup_Handler,
@@ -1780,6 +2243,50 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SystemGetProp",
 			Handler:    _MgmtSvc_SystemGetProp_Handler,
 		},
+		{
+			MethodName: "SystemHistory",
+			Handler:    _MgmtSvc_SystemHistory_Handler,
+		},
+		{
+			MethodName: "SystemEvents",
+			Handler:    _MgmtSvc_SystemEvents_Handler,
+		},
+		{
+			MethodName: "SystemEventAck",
+			Handler:    _MgmtSvc_SystemEventAck_Handler,
+		},
+		{
+			MethodName: "JobStatsQuery",
+			Handler:    _MgmtSvc_JobStatsQuery_Handler,
+		},
+		{
+			MethodName: "PortProbe",
+			Handler:    _MgmtSvc_PortProbe_Handler,
+		},
+		{
+			MethodName: "PoolActivity",
+			Handler:    _MgmtSvc_PoolActivity_Handler,
+		},
+		{
+			MethodName: "SystemDBHash",
+			Handler:    _MgmtSvc_SystemDBHash_Handler,
+		},
+		{
+			MethodName: "SystemDBVerify",
+			Handler:    _MgmtSvc_SystemDBVerify_Handler,
+		},
+		{
+			MethodName: "PoolOpJobs",
+			Handler:    _MgmtSvc_PoolOpJobs_Handler,
+		},
+		{
+			MethodName: "SystemTokenCreate",
+			Handler:    _MgmtSvc_SystemTokenCreate_Handler,
+		},
+		{
+			MethodName: "SystemRankUUIDs",
+			Handler:    _MgmtSvc_SystemRankUUIDs_Handler,
+		},
 		{
 			MethodName: "FaultInjectReport",
 			Handler:    _MgmtSvc_FaultInjectReport_Handler,
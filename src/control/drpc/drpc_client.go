@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,6 +8,7 @@ package drpc
 
 import (
 	"context"
+	"math"
 	"net"
 	"sync"
 
@@ -103,6 +104,9 @@ func (c *ClientConnection) sendCall(ctx context.Context, msg *Call) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal dRPC request")
 	}
+	if err := checkMsgSize(len(callBytes)); err != nil {
+		return errors.Wrap(err, "invalid dRPC request")
+	}
 
 	callWrite := make(chan struct{})
 	defer close(callWrite)
@@ -138,7 +142,7 @@ func (c *ClientConnection) recvResponse(ctx context.Context) (*Response, error)
 		}
 	}(c.conn.Close)
 
-	respBytes := make([]byte, MaxMsgSize)
+	respBytes := make([]byte, MaxMsgSize+1)
 	numBytes, err := c.conn.Read(respBytes)
 	if err != nil {
 		if ctx.Err() != nil {
@@ -150,8 +154,7 @@ func (c *ClientConnection) recvResponse(ctx context.Context) (*Response, error)
 		return nil, errors.Wrap(err, "dRPC recv")
 	}
 
-	resp := &Response{}
-	err = proto.Unmarshal(respBytes[:numBytes], resp)
+	resp, err := unmarshalResponse(respBytes[:numBytes])
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal dRPC response")
 	}
@@ -180,6 +183,87 @@ func (c *ClientConnection) SendMsg(ctx context.Context, msg *Call) (*Response, e
 	return c.recvResponse(ctx)
 }
 
+// SendBatch sends the calls to the connected dRPC server packed into a single
+// message, saving a round trip per call, and returns their responses in the
+// same order. The calls are not modified, and their sequence numbers are only
+// used to check that each response matches its call. The calls are sent one
+// at a time instead if the server does not support batches or if the batch
+// does not fit into a single message. A response that would not fit into the
+// batch response is returned with a FAILED_MARSHAL status.
+func SendBatch(ctx context.Context, client DomainSocketClient, calls []*Call) ([]*Response, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	for i, call := range calls {
+		if call == nil {
+			return nil, errors.Errorf("invalid dRPC call %d in batch", i)
+		}
+	}
+	if len(calls) == 1 {
+		return sendEach(ctx, client, calls)
+	}
+
+	body, err := proto.Marshal(&CallBatch{Calls: calls})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal dRPC call batch")
+	}
+	batchCall := &Call{
+		Module:   ModuleBatch.ID(),
+		Method:   MethodBatchCalls.ID(),
+		Sequence: math.MaxInt64, // allow for the largest sequence number
+		Body:     body,
+	}
+	if proto.Size(batchCall) > MaxMsgSize {
+		return sendEach(ctx, client, calls)
+	}
+
+	resp, err := client.SendMsg(ctx, batchCall)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.Status {
+	case Status_SUCCESS:
+	case Status_UNKNOWN_MODULE:
+		// None of the calls have been handled.
+		return sendEach(ctx, client, calls)
+	default:
+		return nil, errors.Errorf("dRPC call batch failed: %s", resp.Status)
+	}
+
+	batchResp := new(ResponseBatch)
+	if err := proto.Unmarshal(resp.Body, batchResp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal dRPC response batch")
+	}
+	if len(batchResp.Responses) != len(calls) {
+		return nil, errors.Errorf("dRPC response batch has %d responses for %d calls",
+			len(batchResp.Responses), len(calls))
+	}
+	for i, r := range batchResp.Responses {
+		if r.Sequence != calls[i].Sequence {
+			return nil, errors.Errorf("dRPC response batch: response %d has sequence %d, want %d",
+				i, r.Sequence, calls[i].Sequence)
+		}
+	}
+
+	return batchResp.Responses, nil
+}
+
+// sendEach sends copies of the calls to the connected dRPC server one at a
+// time, as the client sets the sequence number of each call it sends.
+func sendEach(ctx context.Context, client DomainSocketClient, calls []*Call) ([]*Response, error) {
+	resps := make([]*Response, 0, len(calls))
+	for _, call := range calls {
+		resp, err := client.SendMsg(ctx, proto.Clone(call).(*Call))
+		if err != nil {
+			return nil, err
+		}
+		resps = append(resps, resp)
+	}
+
+	return resps, nil
+}
+
 // GetSocketPath returns client dRPC socket file path.
 func (c *ClientConnection) GetSocketPath() string {
 	return c.socketPath
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 )
@@ -347,3 +348,130 @@ func TestClient_SendMsg_UnmarshalResponseFailure(t *testing.T) {
 	test.AssertTrue(t, response == nil, "Expected no response")
 	test.CmpErr(t, expectedErr, err)
 }
+
+// batchTestClient is a DomainSocketClient that passes messages directly to a
+// ModuleService, optionally as a server that does not support batches.
+type batchTestClient struct {
+	ClientConnection
+	t         *testing.T
+	service   *ModuleService
+	noBatches bool
+	sent      int
+}
+
+func (c *batchTestClient) SendMsg(ctx context.Context, msg *Call) (*Response, error) {
+	c.sent++
+	if c.noBatches && msg.Module == ModuleBatch.ID() {
+		return &Response{Sequence: msg.Sequence, Status: Status_UNKNOWN_MODULE}, nil
+	}
+
+	callBytes, err := proto.Marshal(msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	respBytes, err := c.service.ProcessMessage(ctx, &Session{}, callBytes)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return unmarshalResponse(respBytes)
+}
+
+func TestClient_SendBatch(t *testing.T) {
+	for name, tc := range map[string]struct {
+		numCalls    int
+		nilCall     bool
+		noBatches   bool
+		bodySize    int
+		respSize    int
+		expSent     int
+		expStatuses []Status
+		expErr      error
+	}{
+		"no calls": {},
+		"nil call": {
+			numCalls: 2,
+			nilCall:  true,
+			expErr:   errors.New("invalid dRPC call 1"),
+		},
+		"single call": {
+			numCalls: 1,
+			expSent:  1,
+		},
+		"batched": {
+			numCalls: 3,
+			expSent:  1,
+		},
+		"responses too large": {
+			numCalls:    3,
+			respSize:    MaxMsgSize / 2,
+			expSent:     1,
+			expStatuses: []Status{Status_SUCCESS, Status_FAILED_MARSHAL, Status_FAILED_MARSHAL},
+		},
+		"server without batches": {
+			numCalls:  3,
+			noBatches: true,
+			expSent:   4,
+		},
+		"batch too large": {
+			numCalls: 3,
+			bodySize: MaxMsgSize / 2,
+			expSent:  3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mod := newTestModule(ModuleMgmt)
+			mod.HandleCallResponse = []byte("done")
+			if tc.respSize > 0 {
+				mod.HandleCallResponse = make([]byte, tc.respSize)
+			}
+			service := NewModuleService(log)
+			service.RegisterModule(mod)
+
+			client := &batchTestClient{
+				t:         t,
+				service:   service,
+				noBatches: tc.noBatches,
+			}
+
+			var calls []*Call
+			for i := 0; i < tc.numCalls; i++ {
+				calls = append(calls, &Call{
+					Module:   ModuleMgmt.ID(),
+					Method:   MethodPoolCreate.ID(),
+					Sequence: int64(i + 10),
+					Body:     make([]byte, tc.bodySize),
+				})
+			}
+			if tc.nilCall {
+				calls[len(calls)-1] = nil
+			}
+
+			resps, err := SendBatch(test.Context(t), client, calls)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.numCalls, len(resps), "unexpected number of responses")
+			for i, resp := range resps {
+				expStatus := Status_SUCCESS
+				if tc.expStatuses != nil {
+					expStatus = tc.expStatuses[i]
+				}
+				test.AssertEqual(t, expStatus, resp.Status, fmt.Sprintf("response %d status", i))
+				if tc.respSize == 0 {
+					test.AssertEqual(t, "done", string(resp.Body), fmt.Sprintf("response %d body", i))
+				}
+			}
+			test.AssertEqual(t, tc.expSent, client.sent, "unexpected number of messages sent")
+			test.AssertEqual(t, tc.numCalls, mod.HandleCallCalls, "calls not handled exactly once")
+			for i, call := range calls {
+				test.AssertEqual(t, int64(i+10), call.Sequence, fmt.Sprintf("call %d sequence modified", i))
+			}
+		})
+	}
+}
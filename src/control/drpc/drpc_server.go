@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2023 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -12,6 +12,7 @@ import (
 	"os"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -32,10 +33,12 @@ type DomainSocketServer struct {
 	log           logging.Logger
 	sockFile      string
 	sockFileMode  os.FileMode
+	sockGroup     int // -1 to keep the group of the process
 	listener      net.Listener
 	service       *ModuleService
 	sessions      map[net.Conn]*Session
 	sessionsMutex sync.Mutex
+	lastActive    time.Time // when the last session closed
 }
 
 // closeSession cleans up the session and removes it from the list of active
@@ -44,6 +47,7 @@ func (d *DomainSocketServer) closeSession(s *Session) {
 	d.sessionsMutex.Lock()
 	s.Close()
 	delete(d.sessions, s.Conn)
+	d.lastActive = time.Now()
 	d.sessionsMutex.Unlock()
 }
 
@@ -91,6 +95,17 @@ func (d *DomainSocketServer) Start(ctx context.Context) error {
 		return errors.New("DomainSocketServer is nil")
 	}
 
+	d.sessionsMutex.Lock()
+	d.lastActive = time.Now()
+	d.sessionsMutex.Unlock()
+
+	if d.listener != nil {
+		// The socket was created by the process that passed the
+		// listener, which retains ownership of the socket file.
+		go d.Listen(ctx)
+		return nil
+	}
+
 	addr := &net.UnixAddr{Name: d.sockFile, Net: "unixpacket"}
 	if err := d.checkExistingSocket(ctx, addr); err != nil {
 		return err
@@ -105,6 +120,11 @@ func (d *DomainSocketServer) Start(ctx context.Context) error {
 	if err := os.Chmod(d.sockFile, d.sockFileMode); err != nil {
 		return errors.Wrapf(err, "unable to set permissions on %s", d.sockFile)
 	}
+	if d.sockGroup >= 0 {
+		if err := os.Chown(d.sockFile, -1, d.sockGroup); err != nil {
+			return errors.Wrapf(err, "unable to set group ownership of %s", d.sockFile)
+		}
+	}
 
 	go d.Listen(ctx)
 	return nil
@@ -132,6 +152,30 @@ func (d *DomainSocketServer) checkExistingSocket(ctx context.Context, addr *net.
 	return err
 }
 
+// SetListener sets a listener for the socket that has already been created,
+// e.g. one inherited through socket activation, to be used instead of creating
+// the socket when the server is started.
+func (d *DomainSocketServer) SetListener(lis net.Listener) {
+	d.listener = lis
+}
+
+// IdleTime returns the time for which the server has had no open sessions, or
+// zero if a session is open.
+func (d *DomainSocketServer) IdleTime() time.Duration {
+	d.sessionsMutex.Lock()
+	defer d.sessionsMutex.Unlock()
+
+	if len(d.sessions) > 0 || d.lastActive.IsZero() {
+		return 0
+	}
+	return time.Since(d.lastActive)
+}
+
+// SocketPath returns the path of the unix domain socket.
+func (d *DomainSocketServer) SocketPath() string {
+	return d.sockFile
+}
+
 // RegisterRPCModule takes a Module and associates it with the given
 // DomainSocketServer so it can be used to process incoming dRPC calls.
 func (d *DomainSocketServer) RegisterRPCModule(mod Module) {
@@ -153,6 +197,7 @@ func NewDomainSocketServer(log logging.Logger, sock string, sockMode os.FileMode
 		log:          log,
 		sockFile:     sock,
 		sockFileMode: sockMode,
+		sockGroup:    -1,
 		service:      service,
 		sessions:     sessions}, nil
 }
@@ -166,7 +211,9 @@ type Session struct {
 // ProcessIncomingMessage listens for an incoming message on the session,
 // calls its handler, and sends the response.
 func (s *Session) ProcessIncomingMessage(ctx context.Context) error {
-	buffer := make([]byte, MaxMsgSize)
+	// Allow for one extra byte so that an oversized message, which would
+	// otherwise be silently truncated, can be detected and rejected.
+	buffer := make([]byte, MaxMsgSize+1)
 
 	bytesRead, err := s.Conn.Read(buffer)
 	if err != nil {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,9 +8,11 @@ package drpc
 
 import (
 	"context"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -107,6 +109,38 @@ func TestSession_ProcessIncomingMessage_Success(t *testing.T) {
 	}
 }
 
+func TestSession_ProcessIncomingMessage_Oversized(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	socket := newMockConn()
+	socket.ReadOutputBytes = make([]byte, MaxMsgSize+1)
+	socket.ReadOutputNumBytes = MaxMsgSize + 1
+
+	svc := NewModuleService(log)
+	svc.RegisterModule(newTestModule(ModuleMgmt))
+
+	s := NewSession(socket, svc)
+
+	if err := s.ProcessIncomingMessage(test.Context(t)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	resp := &Response{}
+	if err := proto.Unmarshal(socket.WriteInputBytes, resp); err != nil {
+		t.Fatalf("bytes written to socket weren't a Response: %v", err)
+	}
+
+	expectedResp := &Response{
+		Sequence: -1,
+		Status:   Status_FAILED_UNMARSHAL_CALL,
+	}
+	cmpOpts := test.DefaultCmpOpts()
+	if diff := cmp.Diff(expectedResp, resp, cmpOpts...); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
 func TestNewDomainSocketServer_NoSockFile(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -405,3 +439,55 @@ func TestServer_IntegrationNoMethod(t *testing.T) {
 		t.Fatalf("(-want, +got)\n%s", diff)
 	}
 }
+
+// TestServer_InheritedListener verifies that the server accepts connections on
+// a listener created elsewhere and tracks the time for which it has been idle.
+func TestServer_InheritedListener(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, tmpCleanup := test.CreateTestDir(t)
+	defer tmpCleanup()
+	path := filepath.Join(tmpDir, "test.sock")
+
+	lis, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: path, Net: "unixpacket"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dss, err := NewDomainSocketServer(log, path, testFileMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dss.RegisterRPCModule(newTestModule(0))
+	dss.SetListener(lis)
+
+	ctx, shutdown := context.WithCancel(test.Context(t))
+	defer shutdown()
+
+	// The existing socket is in use, so would be rejected if the server
+	// tried to create it.
+	if err := dss.Start(ctx); err != nil {
+		t.Fatalf("Couldn't start dRPC server: %v", err)
+	}
+
+	client := NewClientConnection(path)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	if _, err := client.SendMsg(test.Context(t), &Call{}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	test.AssertEqual(t, time.Duration(0), dss.IdleTime(), "expected server to be active")
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for dss.IdleTime() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not become idle after session closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
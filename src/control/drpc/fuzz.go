@@ -0,0 +1,89 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//go:build gofuzz
+// +build gofuzz
+
+package drpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// fuzzModule echoes the body of any call it receives.
+type fuzzModule struct{}
+
+func (m *fuzzModule) HandleCall(_ context.Context, _ *Session, _ Method, body []byte) ([]byte, error) {
+	return body, nil
+}
+
+func (m *fuzzModule) ID() ModuleID {
+	return ModuleMgmt
+}
+
+var fuzzService = func() *ModuleService {
+	svc := NewModuleService(logging.NewCombinedLogger("fuzz", io.Discard))
+	svc.RegisterModule(&fuzzModule{})
+	return svc
+}()
+
+// Fuzz subjects the server-side message framing and validation to randomized
+// inputs, as received from a local client over the dRPC socket. Any input must
+// produce a well-formed response no larger than MaxMsgSize, and any input that
+// passes validation must unmarshal to a Call with the same routing fields.
+//
+// This function is only built by go-fuzz, using go-fuzz-build. See
+// https://github.com/dvyukov/go-fuzz for details on installing and
+// running the fuzzer.
+func Fuzz(data []byte) int {
+	respBytes, err := fuzzService.ProcessMessage(context.Background(), &Session{}, data)
+	if err != nil {
+		panic(err)
+	}
+	if len(respBytes) > MaxMsgSize {
+		panic(fmt.Sprintf("response size %d exceeds maximum", len(respBytes)))
+	}
+	if _, err := unmarshalResponse(respBytes); err != nil {
+		panic(err)
+	}
+
+	hdr, err := parseCallHeader(data)
+	if err != nil {
+		return 0
+	}
+
+	call := new(Call)
+	if err := proto.Unmarshal(data, call); err != nil {
+		panic(fmt.Sprintf("validated call failed to unmarshal: %s", err))
+	}
+	if call.Module != hdr.module || call.Method != hdr.method || call.Sequence != hdr.sequence {
+		panic(fmt.Sprintf("call header %+v does not match call %+v", hdr, call))
+	}
+
+	return 1
+}
+
+// FuzzResponse subjects the client-side response validation to randomized
+// inputs, as received from a dRPC server.
+//
+// This function is only built by go-fuzz, using go-fuzz-build with
+// -func FuzzResponse.
+func FuzzResponse(data []byte) int {
+	resp, err := unmarshalResponse(data)
+	if err != nil {
+		return 0
+	}
+
+	if _, ok := Status_name[int32(resp.Status)]; !ok {
+		panic(fmt.Sprintf("unknown status %d accepted", resp.Status))
+	}
+
+	return 1
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -20,10 +20,14 @@ import (
 type mockModule struct {
 	HandleCallResponse []byte
 	HandleCallErr      error
+	HandleCallCtx      context.Context
+	HandleCallCalls    int
 	IDValue            ModuleID
 }
 
-func (m *mockModule) HandleCall(_ context.Context, session *Session, method Method, input []byte) ([]byte, error) {
+func (m *mockModule) HandleCall(ctx context.Context, session *Session, method Method, input []byte) ([]byte, error) {
+	m.HandleCallCtx = ctx
+	m.HandleCallCalls++
 	return m.HandleCallResponse, m.HandleCallErr
 }
 
@@ -115,9 +119,9 @@ func (m *mockConn) SetReadOutputBytesToResponse(t *testing.T, resp *Response) {
 	rawRespBytes := marshallResponseToBytes(t, resp)
 	m.ReadOutputNumBytes = len(rawRespBytes)
 
-	// The result from Read() will be MaxMsgSize since we have no way to
+	// The result from Read() will be MaxMsgSize+1 since we have no way to
 	// know the size of a read before we read it
-	m.ReadOutputBytes = make([]byte, MaxMsgSize)
+	m.ReadOutputBytes = make([]byte, MaxMsgSize+1)
 	copy(m.ReadOutputBytes, rawRespBytes)
 }
 
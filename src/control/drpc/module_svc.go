@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2022 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,8 +10,10 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
@@ -88,33 +90,139 @@ func marshalResponse(sequence int64, status Status, body []byte) ([]byte, error)
 	if mErr != nil {
 		return nil, errors.Wrap(mErr, "Failed to marshal response")
 	}
+	if len(responseBytes) > MaxMsgSize && status == Status_SUCCESS {
+		return marshalResponse(sequence, Status_FAILED_MARSHAL, nil)
+	}
 	return responseBytes, nil
 }
 
 // ProcessMessage is the main entry point into the ModuleService. It accepts a
 // marshaled drpc.Call instance, processes it, calls the handler in the
 // appropriate Module, and marshals the result into the body of a drpc.Response.
+//
+// The message is validated, and its module and method are checked against the
+// registered modules, before the full Call is unmarshaled.
 func (r *ModuleService) ProcessMessage(ctx context.Context, session *Session, msgBytes []byte) ([]byte, error) {
-	msg := &Call{}
-
-	err := proto.Unmarshal(msgBytes, msg)
+	hdr, err := parseCallHeader(msgBytes)
 	if err != nil {
+		r.log.Errorf("Rejected invalid dRPC call: %s", err)
 		return marshalResponse(-1, Status_FAILED_UNMARSHAL_CALL, nil)
 	}
-	module, ok := r.GetModule(ModuleID(msg.GetModule()))
+	if ModuleID(hdr.module) == ModuleBatch {
+		return r.processBatch(ctx, session, hdr, msgBytes)
+	}
+
+	module, method, status := r.lookupMethod(hdr.module, hdr.method)
+	if status != Status_SUCCESS {
+		return marshalResponse(hdr.sequence, status, nil)
+	}
+
+	msg := &Call{}
+	if err := proto.Unmarshal(msgBytes, msg); err != nil {
+		return marshalResponse(hdr.sequence, Status_FAILED_UNMARSHAL_CALL, nil)
+	}
+	status, respBody := r.handleCall(callContext(ctx, msg), session, module, method, msg.GetBody())
+
+	return marshalResponse(msg.GetSequence(), status, respBody)
+}
+
+// callContext returns the context in which to handle the call, carrying the
+// caller's trace context if the call is traced.
+func callContext(ctx context.Context, call *Call) context.Context {
+	if sc, err := tracing.ParseTraceparent(call.GetTraceparent()); err == nil {
+		return tracing.ContextWithRemoteParent(ctx, sc)
+	}
+	return ctx
+}
+
+// lookupMethod returns the registered module and method for the given IDs, or
+// the status to be returned if either is unknown.
+func (r *ModuleService) lookupMethod(moduleID, methodID int32) (Module, Method, Status) {
+	module, ok := r.GetModule(ModuleID(moduleID))
 	if !ok {
-		r.log.Errorf("Attempted to call unregistered module %d", msg.GetModule())
-		return marshalResponse(msg.GetSequence(), Status_UNKNOWN_MODULE, nil)
+		r.log.Errorf("Attempted to call unregistered module %d", moduleID)
+		return nil, nil, Status_UNKNOWN_MODULE
 	}
-	var method Method
-	method, err = module.ID().GetMethod(msg.GetMethod())
+	method, err := module.ID().GetMethod(methodID)
 	if err != nil {
-		return marshalResponse(msg.GetSequence(), Status_UNKNOWN_METHOD, nil)
+		return nil, nil, Status_UNKNOWN_METHOD
 	}
-	respBody, err := module.HandleCall(ctx, session, method, msg.GetBody())
+
+	return module, method, Status_SUCCESS
+}
+
+// handleCall calls the module's handler for the method, returning the status
+// and body of the response.
+func (r *ModuleService) handleCall(ctx context.Context, session *Session, module Module, method Method, body []byte) (Status, []byte) {
+	respBody, err := module.HandleCall(ctx, session, method, body)
 	if err != nil {
 		r.log.Errorf("HandleCall for %s:%s failed: %s\n", module.ID().String(), method.String(), err)
-		return marshalResponse(msg.GetSequence(), ErrorToStatus(err), nil)
+		return ErrorToStatus(err), nil
+	}
+
+	return Status_SUCCESS, respBody
+}
+
+// batchResponseOverhead returns the size of a Response to a batch Call,
+// excluding the batched responses in its body.
+func batchResponseOverhead(sequence int64) int {
+	return protowire.SizeTag(1) + protowire.SizeVarint(uint64(sequence)) +
+		protowire.SizeTag(3) + protowire.SizeVarint(MaxMsgSize)
+}
+
+// batchEntrySize returns the size that the Response adds to a ResponseBatch.
+func batchEntrySize(resp *Response) int {
+	return protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(resp))
+}
+
+// processBatch processes each of the Calls packed into a batch Call in turn,
+// and marshals their Responses into the body of a single Response. Batches
+// may not be nested, as the batch module is not a registered module.
+func (r *ModuleService) processBatch(ctx context.Context, session *Session, hdr *callHeader, msgBytes []byte) ([]byte, error) {
+	if hdr.method != MethodBatchCalls.ID() {
+		return marshalResponse(hdr.sequence, Status_UNKNOWN_METHOD, nil)
+	}
+
+	msg := &Call{}
+	if err := proto.Unmarshal(msgBytes, msg); err != nil {
+		return marshalResponse(hdr.sequence, Status_FAILED_UNMARSHAL_CALL, nil)
+	}
+	batch := &CallBatch{}
+	if err := proto.Unmarshal(msg.GetBody(), batch); err != nil {
+		r.log.Errorf("Rejected invalid dRPC call batch: %s", err)
+		return marshalResponse(msg.GetSequence(), Status_FAILED_UNMARSHAL_PAYLOAD, nil)
+	}
+
+	// Leave room for the Response carrying the batch.
+	avail := MaxMsgSize - batchResponseOverhead(msg.GetSequence())
+
+	resps := &ResponseBatch{
+		Responses: make([]*Response, 0, len(batch.Calls)),
+	}
+	for _, call := range batch.Calls {
+		resp := &Response{Sequence: call.GetSequence()}
+		module, method, status := r.lookupMethod(call.GetModule(), call.GetMethod())
+		if status == Status_SUCCESS {
+			status, resp.Body = r.handleCall(callContext(ctx, call), session, module, method, call.GetBody())
+		}
+		resp.Status = status
+
+		// The call has been handled, so a response that doesn't fit
+		// is failed on its own rather than failing the whole batch,
+		// which the client might then resend.
+		size := batchEntrySize(resp)
+		if size > avail && resp.Status == Status_SUCCESS {
+			r.log.Errorf("dRPC response to batched call %d:%d is too large", call.GetModule(), call.GetMethod())
+			resp.Status, resp.Body = Status_FAILED_MARSHAL, nil
+			size = batchEntrySize(resp)
+		}
+		avail -= size
+		resps.Responses = append(resps.Responses, resp)
+	}
+
+	respBody, err := proto.Marshal(resps)
+	if err != nil {
+		return marshalResponse(msg.GetSequence(), Status_FAILED_MARSHAL, nil)
 	}
 
 	return marshalResponse(msg.GetSequence(), Status_SUCCESS, respBody)
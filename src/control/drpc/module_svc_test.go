@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
@@ -148,17 +149,47 @@ func getResponse(sequence int64, status Status, body []byte) *Response {
 
 func TestService_ProcessMessage(t *testing.T) {
 	const testSequenceNum int64 = 13
+	const testTraceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	tracedCall, err := proto.Marshal(&Call{
+		Sequence:    testSequenceNum,
+		Module:      int32(defaultTestModID),
+		Method:      MethodPoolCreate.ID(),
+		Traceparent: testTraceparent,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	for name, tc := range map[string]struct {
 		callBytes      []byte
 		handleCallErr  error
 		handleCallResp []byte
 		expectedResp   *Response
+		expTrace       string
 	}{
 		"garbage input bytes": {
 			callBytes:    getGarbageBytes(),
 			expectedResp: getResponse(-1, Status_FAILED_UNMARSHAL_CALL, nil),
 		},
+		"empty input": {
+			callBytes:    []byte{},
+			expectedResp: getResponse(-1, Status_FAILED_UNMARSHAL_CALL, nil),
+		},
+		"oversized input": {
+			callBytes:    make([]byte, MaxMsgSize+1),
+			expectedResp: getResponse(-1, Status_FAILED_UNMARSHAL_CALL, nil),
+		},
+		"unknown field": {
+			callBytes: append(getCallBytes(t, testSequenceNum, int32(defaultTestModID),
+				MethodPoolCreate), 0x30, 0x01),
+			expectedResp: getResponse(-1, Status_FAILED_UNMARSHAL_CALL, nil),
+		},
+		"method doesn't exist": {
+			callBytes: getCallBytes(t, testSequenceNum, int32(defaultTestModID),
+				MethodRequestCredentials),
+			expectedResp: getResponse(testSequenceNum, Status_UNKNOWN_METHOD, nil),
+		},
 		"module doesn't exist": {
 			callBytes:    getCallBytes(t, testSequenceNum, 256, MethodPoolCreate),
 			expectedResp: getResponse(testSequenceNum, Status_UNKNOWN_MODULE, nil),
@@ -181,6 +212,18 @@ func TestService_ProcessMessage(t *testing.T) {
 			handleCallResp: []byte("succeeded"),
 			expectedResp:   getResponse(testSequenceNum, Status_SUCCESS, []byte("succeeded")),
 		},
+		"traced call": {
+			callBytes:      tracedCall,
+			handleCallResp: []byte("succeeded"),
+			expectedResp:   getResponse(testSequenceNum, Status_SUCCESS, []byte("succeeded")),
+			expTrace:       testTraceparent,
+		},
+		"HandleCall response too large": {
+			callBytes: getCallBytes(t, testSequenceNum, int32(defaultTestModID),
+				MethodPoolCreate),
+			handleCallResp: make([]byte, MaxMsgSize),
+			expectedResp:   getResponse(testSequenceNum, Status_FAILED_MARSHAL, nil),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -209,6 +252,148 @@ func TestService_ProcessMessage(t *testing.T) {
 			if diff := cmp.Diff(tc.expectedResp, resp, cmpOpts...); diff != "" {
 				t.Fatalf("(-want, +got)\n%s", diff)
 			}
+
+			if tc.expTrace != "" {
+				gotTrace := tracing.SpanContextFromContext(mockMod.HandleCallCtx).Traceparent()
+				test.AssertEqual(t, tc.expTrace, gotTrace, "trace context not passed to handler")
+			}
+		})
+	}
+}
+
+func getBatchCallBytes(t *testing.T, sequence int64, method int32, body []byte) []byte {
+	t.Helper()
+
+	callBytes, err := proto.Marshal(&Call{
+		Sequence: sequence,
+		Module:   ModuleBatch.ID(),
+		Method:   method,
+		Body:     body,
+	})
+	if err != nil {
+		t.Fatalf("Got error marshalling test call: %v", err)
+	}
+
+	return callBytes
+}
+
+func TestService_ProcessMessage_Batch(t *testing.T) {
+	const testSequenceNum int64 = 13
+
+	batchBody, err := proto.Marshal(&CallBatch{
+		Calls: []*Call{
+			{Sequence: 1, Module: defaultTestModID.ID(), Method: MethodPoolCreate.ID()},
+			{Sequence: 2, Module: 256, Method: MethodPoolCreate.ID()},
+			{Sequence: 3, Module: defaultTestModID.ID(), Method: MethodRequestCredentials.ID()},
+			{Sequence: 4, Module: ModuleBatch.ID(), Method: MethodBatchCalls.ID()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bigBatchBody, err := proto.Marshal(&CallBatch{
+		Calls: []*Call{
+			{Sequence: 1, Module: defaultTestModID.ID(), Method: MethodPoolCreate.ID()},
+			{Sequence: 2, Module: defaultTestModID.ID(), Method: MethodPoolCreate.ID()},
+			{Sequence: 3, Module: 256, Method: MethodPoolCreate.ID()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		callBytes    []byte
+		respBody     []byte
+		expectedResp *Response
+		expBatch     *ResponseBatch
+		expHandled   int
+	}{
+		"unknown method": {
+			callBytes:    getBatchCallBytes(t, testSequenceNum, 502, batchBody),
+			expectedResp: getResponse(testSequenceNum, Status_UNKNOWN_METHOD, nil),
+		},
+		"garbage batch": {
+			callBytes: getBatchCallBytes(t, testSequenceNum, MethodBatchCalls.ID(),
+				getGarbageBytes()),
+			expectedResp: getResponse(testSequenceNum, Status_FAILED_UNMARSHAL_PAYLOAD, nil),
+		},
+		"empty batch": {
+			callBytes: getBatchCallBytes(t, testSequenceNum, MethodBatchCalls.ID(), nil),
+			expBatch:  &ResponseBatch{},
+		},
+		"batch processed": {
+			callBytes: getBatchCallBytes(t, testSequenceNum, MethodBatchCalls.ID(),
+				batchBody),
+			expBatch: &ResponseBatch{
+				Responses: []*Response{
+					getResponse(1, Status_SUCCESS, []byte("succeeded")),
+					getResponse(2, Status_UNKNOWN_MODULE, nil),
+					getResponse(3, Status_UNKNOWN_METHOD, nil),
+					// Batches may not be nested.
+					getResponse(4, Status_UNKNOWN_MODULE, nil),
+				},
+			},
+			expHandled: 1,
+		},
+		"responses too large": {
+			callBytes: getBatchCallBytes(t, testSequenceNum, MethodBatchCalls.ID(),
+				bigBatchBody),
+			respBody: make([]byte, MaxMsgSize/2+1),
+			expBatch: &ResponseBatch{
+				Responses: []*Response{
+					getResponse(1, Status_SUCCESS, make([]byte, MaxMsgSize/2+1)),
+					// Handled, but doesn't fit.
+					getResponse(2, Status_FAILED_MARSHAL, nil),
+					getResponse(3, Status_UNKNOWN_MODULE, nil),
+				},
+			},
+			expHandled: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mockMod := newTestModule(defaultTestModID)
+			mockMod.HandleCallResponse = []byte("succeeded")
+			if tc.respBody != nil {
+				mockMod.HandleCallResponse = tc.respBody
+			}
+
+			service := NewModuleService(log)
+			service.RegisterModule(mockMod)
+
+			respBytes, err := service.ProcessMessage(test.Context(t), &Session{}, tc.callBytes)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %v", err)
+			}
+			test.AssertTrue(t, len(respBytes) <= MaxMsgSize, "response exceeds max message size")
+			test.AssertEqual(t, tc.expHandled, mockMod.HandleCallCalls, "unexpected number of calls handled")
+
+			resp := &Response{}
+			if err := proto.Unmarshal(respBytes, resp); err != nil {
+				t.Fatalf("couldn't unmarshal response bytes: %v", err)
+			}
+
+			cmpOpts := test.DefaultCmpOpts()
+			if tc.expBatch == nil {
+				if diff := cmp.Diff(tc.expectedResp, resp, cmpOpts...); diff != "" {
+					t.Fatalf("(-want, +got)\n%s", diff)
+				}
+				return
+			}
+
+			test.AssertEqual(t, testSequenceNum, resp.Sequence, "unexpected sequence")
+			test.AssertEqual(t, Status_SUCCESS, resp.Status, "unexpected status")
+			gotBatch := &ResponseBatch{}
+			if err := proto.Unmarshal(resp.Body, gotBatch); err != nil {
+				t.Fatalf("couldn't unmarshal response batch: %v", err)
+			}
+			if diff := cmp.Diff(tc.expBatch, gotBatch, cmpOpts...); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
 		})
 	}
 }
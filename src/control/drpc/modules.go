@@ -29,6 +29,7 @@ func (id ModuleID) String() string {
 		ModuleMgmt:          "Management",
 		ModuleSrv:           "Server",
 		ModuleSecurity:      "Security",
+		ModuleBatch:         "Batch",
 	}[id]; ok {
 		return name
 	}
@@ -42,6 +43,7 @@ func (id ModuleID) GetMethod(methodID int32) (Method, error) {
 		ModuleMgmt:          MgmtMethod(methodID),
 		ModuleSrv:           srvMethod(methodID),
 		ModuleSecurity:      securityMethod(methodID),
+		ModuleBatch:         batchMethod(methodID),
 	}[id]; ok {
 		if !m.IsValid() {
 			return nil, errors.Errorf("invalid method %d for module %s",
@@ -66,6 +68,8 @@ const (
 	ModuleSrv ModuleID = C.DRPC_MODULE_SRV
 	// ModuleSecurity is the dRPC module for security tasks in DAOS server
 	ModuleSecurity ModuleID = C.DRPC_MODULE_SEC
+	// ModuleBatch is the dRPC module for calls packed into a single message
+	ModuleBatch ModuleID = C.DRPC_MODULE_BATCH
 )
 
 type Method interface {
@@ -87,7 +91,9 @@ func (m securityAgentMethod) ID() int32 {
 
 func (m securityAgentMethod) String() string {
 	if s, ok := map[securityAgentMethod]string{
-		MethodRequestCredentials: "request agent credentials",
+		MethodRequestCredentials:     "request agent credentials",
+		MethodRequestDelegationToken: "request delegation token",
+		MethodRedeemDelegationToken:  "redeem delegation token",
 	}[m]; ok {
 		return s
 	}
@@ -109,6 +115,10 @@ func (m securityAgentMethod) IsValid() bool {
 const (
 	// MethodRequestCredentials is a ModuleSecurityAgent method
 	MethodRequestCredentials securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REQUEST_CREDS
+	// MethodRequestDelegationToken is a ModuleSecurityAgent method
+	MethodRequestDelegationToken securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REQUEST_DELEGATION_TOKEN
+	// MethodRedeemDelegationToken is a ModuleSecurityAgent method
+	MethodRedeemDelegationToken securityAgentMethod = C.DRPC_METHOD_SEC_AGENT_REDEEM_DELEGATION_TOKEN
 )
 
 type MgmtMethod int32
@@ -158,6 +168,14 @@ func (m MgmtMethod) String() string {
 		MethodPoolUpgrade:          "PoolUpgrade",
 		MethodLedManage:            "LedManage",
 		MethodSetupClientTelemetry: "SetupClientTelemetry",
+		MethodBioTrim:              "BioTrim",
+		MethodJobProlog:            "JobProlog",
+		MethodJobEpilog:            "JobEpilog",
+		MethodBioIOStats:           "BioIOStats",
+		MethodPodPrepare:           "PodPrepare",
+		MethodPodRelease:           "PodRelease",
+		MethodSwimView:             "SwimView",
+		MethodAgentStatus:          "AgentStatus",
 	}[m]; ok {
 		return s
 	}
@@ -257,6 +275,30 @@ const (
 	MethodLedManage MgmtMethod = C.DRPC_METHOD_MGMT_LED_MANAGE
 	// MethodSetupClientTelemetry defines a method to setup client telemetry
 	MethodSetupClientTelemetry MgmtMethod = C.DRPC_METHOD_MGMT_SETUP_CLIENT_TELEM
+	// MethodBioTrim is a ctl-initiated method requesting deallocation of free
+	// blocks on the engine's bdevs
+	MethodBioTrim MgmtMethod = C.DRPC_METHOD_MGMT_BIO_TRIM
+	// MethodJobProlog is a ModuleMgmt method handled by the agent to prepare for
+	// a job starting on the node
+	MethodJobProlog MgmtMethod = C.DRPC_METHOD_MGMT_JOB_PROLOG
+	// MethodJobEpilog is a ModuleMgmt method handled by the agent to clean up
+	// after a job has finished on the node
+	MethodJobEpilog MgmtMethod = C.DRPC_METHOD_MGMT_JOB_EPILOG
+	// MethodBioIOStats is a ctl-initiated method requesting I/O statistics for
+	// one of the engine's bdevs
+	MethodBioIOStats MgmtMethod = C.DRPC_METHOD_MGMT_BIO_IO_STATS
+	// MethodPodPrepare is a ModuleMgmt method handled by the agent to prepare
+	// for a container pod starting on the node
+	MethodPodPrepare MgmtMethod = C.DRPC_METHOD_MGMT_POD_PREPARE
+	// MethodPodRelease is a ModuleMgmt method handled by the agent to clean up
+	// after a container pod has finished on the node
+	MethodPodRelease MgmtMethod = C.DRPC_METHOD_MGMT_POD_RELEASE
+	// MethodSwimView is a ctl-initiated method requesting the engine's local
+	// SWIM view of the system ranks
+	MethodSwimView MgmtMethod = C.DRPC_METHOD_MGMT_SWIM_VIEW
+	// MethodAgentStatus is a ModuleMgmt method handled by the agent to report
+	// its status
+	MethodAgentStatus MgmtMethod = C.DRPC_METHOD_MGMT_AGENT_STATUS
 )
 
 type srvMethod int32
@@ -346,6 +388,42 @@ const (
 	MethodValidateCredentials securityMethod = C.DRPC_METHOD_SEC_VALIDATE_CREDS
 )
 
+type batchMethod int32
+
+func (m batchMethod) Module() ModuleID {
+	return ModuleBatch
+}
+
+func (m batchMethod) ID() int32 {
+	return int32(m)
+}
+
+func (m batchMethod) String() string {
+	if s, ok := map[batchMethod]string{
+		MethodBatchCalls: "batch calls",
+	}[m]; ok {
+		return s
+	}
+
+	return fmt.Sprintf("%s:%d", m.Module(), m.ID())
+}
+
+// IsValid sanity checks the Method ID is within expected bounds.
+func (m batchMethod) IsValid() bool {
+	startMethodID := int32(m.Module()) * moduleMethodOffset
+
+	if m.ID() <= startMethodID || m.ID() >= int32(C.NUM_DRPC_BATCH_METHODS) {
+		return false
+	}
+
+	return true
+}
+
+const (
+	// MethodBatchCalls is a ModuleBatch method
+	MethodBatchCalls batchMethod = C.DRPC_METHOD_BATCH_CALLS
+)
+
 // Marshal is a utility function that can be used by dRPC method handlers to
 // marshal their method-specific response to be passed back to the ModuleService.
 func Marshal(message proto.Message) ([]byte, error) {
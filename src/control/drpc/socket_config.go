@@ -0,0 +1,180 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// moduleNames maps the names by which modules are referred to in socket
+// configurations to their IDs.
+var moduleNames = map[string]ModuleID{
+	"security_agent": ModuleSecurityAgent,
+	"mgmt":           ModuleMgmt,
+	"srv":            ModuleSrv,
+	"security":       ModuleSecurity,
+}
+
+// ModuleIDFromName returns the ID of the module with the given socket
+// configuration name.
+func ModuleIDFromName(name string) (ModuleID, error) {
+	id, found := moduleNames[name]
+	if !found {
+		return 0, errors.Errorf("unknown dRPC module %q", name)
+	}
+	return id, nil
+}
+
+// SocketConfig describes a unix domain socket on which a dRPC server exposes
+// some or all of its modules, with its own permissions and group ownership.
+type SocketConfig struct {
+	Path    string   `yaml:"path"`
+	Modules []string `yaml:"modules,omitempty"` // all modules if empty
+	Mode    string   `yaml:"mode,omitempty"`    // octal, e.g. "0660"
+	Group   string   `yaml:"group,omitempty"`   // name or GID
+}
+
+// Validate returns an error if the socket configuration is invalid. Modules
+// must be among those supplied, if any.
+func (sc *SocketConfig) Validate(available ...ModuleID) error {
+	if sc == nil {
+		return errors.New("nil socket config")
+	}
+	if sc.Path == "" || !filepath.IsAbs(sc.Path) {
+		return errors.Errorf("socket path %q must be absolute", sc.Path)
+	}
+	for _, name := range sc.Modules {
+		id, err := ModuleIDFromName(name)
+		if err != nil {
+			return err
+		}
+		if len(available) > 0 && !hasModule(available, id) {
+			return errors.Errorf("dRPC module %q is not available", name)
+		}
+	}
+	if _, err := sc.FileMode(0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func hasModule(ids []ModuleID, id ModuleID) bool {
+	for _, cur := range ids {
+		if cur == id {
+			return true
+		}
+	}
+	return false
+}
+
+// FileMode returns the permissions of the socket file, or the supplied
+// default if none were configured.
+func (sc *SocketConfig) FileMode(def os.FileMode) (os.FileMode, error) {
+	if sc.Mode == "" {
+		return def, nil
+	}
+
+	mode, err := strconv.ParseUint(sc.Mode, 8, 32)
+	if err != nil || mode == 0 || mode&^0777 != 0 {
+		return 0, errors.Errorf("invalid socket mode %q (expected octal permissions, e.g. 0660)", sc.Mode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// GroupID returns the ID of the group that the socket file should belong to,
+// or -1 if no group was configured.
+func (sc *SocketConfig) GroupID() (int, error) {
+	if sc.Group == "" {
+		return -1, nil
+	}
+	if gid, err := strconv.Atoi(sc.Group); err == nil {
+		return gid, nil
+	}
+
+	grp, err := user.LookupGroup(sc.Group)
+	if err != nil {
+		return -1, errors.Wrapf(err, "socket group %q", sc.Group)
+	}
+	return strconv.Atoi(grp.Gid)
+}
+
+// exposes returns true if the socket should expose the module.
+func (sc *SocketConfig) exposes(id ModuleID) bool {
+	if len(sc.Modules) == 0 {
+		return true
+	}
+	for _, name := range sc.Modules {
+		if moduleNames[name] == id {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDomainSocketServers returns unstarted servers for the default socket
+// path and for each of the configured sockets. The default socket exposes all
+// of the modules with the default permissions unless a configuration with the
+// same path overrides them. Other sockets expose the configured modules.
+func NewDomainSocketServers(log logging.Logger, defPath string, defMode os.FileMode, cfgs []*SocketConfig, mods ...Module) ([]*DomainSocketServer, error) {
+	ids := make([]ModuleID, 0, len(mods))
+	for _, mod := range mods {
+		ids = append(ids, mod.ID())
+	}
+
+	defCfg := &SocketConfig{Path: defPath}
+	extra := make([]*SocketConfig, 0, len(cfgs))
+	seen := make(map[string]bool)
+	for _, sc := range cfgs {
+		if err := sc.Validate(ids...); err != nil {
+			return nil, err
+		}
+		if seen[sc.Path] {
+			return nil, errors.Errorf("duplicate socket path %q", sc.Path)
+		}
+		seen[sc.Path] = true
+
+		if sc.Path == defPath {
+			defCfg = sc
+			continue
+		}
+		extra = append(extra, sc)
+	}
+
+	servers := make([]*DomainSocketServer, 0, len(extra)+1)
+	for _, sc := range append([]*SocketConfig{defCfg}, extra...) {
+		mode, err := sc.FileMode(defMode)
+		if err != nil {
+			return nil, err
+		}
+		gid, err := sc.GroupID()
+		if err != nil {
+			return nil, err
+		}
+
+		srv, err := NewDomainSocketServer(log, sc.Path, mode)
+		if err != nil {
+			return nil, err
+		}
+		srv.sockGroup = gid
+		for _, mod := range mods {
+			if sc.exposes(mod.ID()) {
+				srv.RegisterRPCModule(mod)
+			}
+		}
+		servers = append(servers, srv)
+	}
+
+	return servers, nil
+}
@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestDrpc_SocketConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg       *SocketConfig
+		available []ModuleID
+		expErr    error
+	}{
+		"nil": {
+			expErr: errors.New("nil"),
+		},
+		"relative path": {
+			cfg:    &SocketConfig{Path: "agent.sock"},
+			expErr: errors.New("must be absolute"),
+		},
+		"unknown module": {
+			cfg: &SocketConfig{
+				Path:    "/run/daos_agent/cred.sock",
+				Modules: []string{"bogus"},
+			},
+			expErr: errors.New("unknown dRPC module"),
+		},
+		"module not available": {
+			cfg: &SocketConfig{
+				Path:    "/run/daos_agent/cred.sock",
+				Modules: []string{"srv"},
+			},
+			available: []ModuleID{ModuleSecurityAgent, ModuleMgmt},
+			expErr:    errors.New("not available"),
+		},
+		"bad mode": {
+			cfg: &SocketConfig{
+				Path: "/run/daos_agent/cred.sock",
+				Mode: "0999",
+			},
+			expErr: errors.New("invalid socket mode"),
+		},
+		"mode out of range": {
+			cfg: &SocketConfig{
+				Path: "/run/daos_agent/cred.sock",
+				Mode: "4777",
+			},
+			expErr: errors.New("invalid socket mode"),
+		},
+		"valid": {
+			cfg: &SocketConfig{
+				Path:    "/run/daos_agent/cred.sock",
+				Modules: []string{"security_agent"},
+				Mode:    "0660",
+				Group:   "daos_users",
+			},
+			available: []ModuleID{ModuleSecurityAgent, ModuleMgmt},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.cfg.Validate(tc.available...))
+		})
+	}
+}
+
+func TestDrpc_SocketConfig_GroupID(t *testing.T) {
+	for name, tc := range map[string]struct {
+		group  string
+		expGID int
+		expErr error
+	}{
+		"unset": {
+			expGID: -1,
+		},
+		"numeric": {
+			group:  "1234",
+			expGID: 1234,
+		},
+		"by name": {
+			group:  "root",
+			expGID: 0,
+		},
+		"unknown name": {
+			group:  "no_such_group_for_drpc_test",
+			expGID: -1,
+			expErr: errors.New("no_such_group_for_drpc_test"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gid, err := (&SocketConfig{Group: tc.group}).GroupID()
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expGID, gid, "unexpected gid")
+		})
+	}
+}
+
+func TestDrpc_NewDomainSocketServers(t *testing.T) {
+	defPath := "/run/daos_agent/daos_agent.sock"
+	mods := []Module{newTestModule(ModuleSecurityAgent), newTestModule(ModuleMgmt)}
+
+	type expServer struct {
+		path    string
+		mode    os.FileMode
+		gid     int
+		modules []ModuleID
+	}
+
+	for name, tc := range map[string]struct {
+		cfgs       []*SocketConfig
+		expServers []expServer
+		expErr     error
+	}{
+		"default only": {
+			expServers: []expServer{
+				{defPath, 0666, -1, []ModuleID{ModuleSecurityAgent, ModuleMgmt}},
+			},
+		},
+		"restricted module socket": {
+			cfgs: []*SocketConfig{
+				{Path: defPath, Modules: []string{"mgmt"}},
+				{Path: "/run/daos_agent/cred.sock", Modules: []string{"security_agent"}, Mode: "0660", Group: "0"},
+			},
+			expServers: []expServer{
+				{defPath, 0666, -1, []ModuleID{ModuleMgmt}},
+				{"/run/daos_agent/cred.sock", 0660, 0, []ModuleID{ModuleSecurityAgent}},
+			},
+		},
+		"duplicate path": {
+			cfgs: []*SocketConfig{
+				{Path: "/run/daos_agent/cred.sock"},
+				{Path: "/run/daos_agent/cred.sock"},
+			},
+			expErr: errors.New("duplicate socket path"),
+		},
+		"invalid config": {
+			cfgs: []*SocketConfig{
+				{Path: "/run/daos_agent/srv.sock", Modules: []string{"srv"}},
+			},
+			expErr: errors.New("not available"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			servers, err := NewDomainSocketServers(log, defPath, 0666, tc.cfgs, mods...)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			var got []expServer
+			for _, srv := range servers {
+				es := expServer{srv.sockFile, srv.sockFileMode, srv.sockGroup, nil}
+				for _, mod := range mods {
+					if _, found := srv.service.GetModule(mod.ID()); found {
+						es.modules = append(es.modules, mod.ID())
+					}
+				}
+				got = append(got, es)
+			}
+			if diff := cmp.Diff(tc.expServers, got, cmp.AllowUnexported(expServer{})); diff != "" {
+				t.Fatalf("unexpected servers (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
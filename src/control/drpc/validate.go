@@ -0,0 +1,136 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Field numbers of the dRPC Call message, as defined in drpc.proto.
+const (
+	callFieldModule   protowire.Number = 1
+	callFieldMethod   protowire.Number = 2
+	callFieldSequence protowire.Number = 3
+	callFieldBody     protowire.Number = 4
+	callFieldTrace    protowire.Number = 5
+)
+
+// callHeader contains the routing fields of a marshaled dRPC Call.
+type callHeader struct {
+	module   int32
+	method   int32
+	sequence int64
+}
+
+// checkMsgSize verifies that a dRPC message of the given size may be sent or
+// received.
+func checkMsgSize(size int) error {
+	switch {
+	case size <= 0:
+		return errors.New("empty dRPC message")
+	case size > MaxMsgSize:
+		return errors.Errorf("dRPC message size %d exceeds maximum %d", size, MaxMsgSize)
+	}
+	return nil
+}
+
+// consumeInt32 decodes a varint field value that must fit in an int32.
+func consumeInt32(b []byte) (int32, int, error) {
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, 0, protowire.ParseError(n)
+	}
+	if i := int64(v); i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, 0, errors.Errorf("value %d out of range for int32", i)
+	}
+	return int32(v), n, nil
+}
+
+// parseCallHeader decodes the routing fields of a marshaled dRPC Call without
+// unmarshaling the body. It is stricter than proto.Unmarshal: unknown fields,
+// repeated fields and mismatched wire types are all rejected.
+func parseCallHeader(b []byte) (*callHeader, error) {
+	if err := checkMsgSize(len(b)); err != nil {
+		return nil, err
+	}
+
+	hdr := new(callHeader)
+	seen := make(map[protowire.Number]bool)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "invalid field tag")
+		}
+		b = b[n:]
+
+		if seen[num] {
+			return nil, errors.Errorf("field %d repeated", num)
+		}
+		seen[num] = true
+
+		var err error
+		switch num {
+		case callFieldModule, callFieldMethod, callFieldSequence:
+			if typ != protowire.VarintType {
+				return nil, errors.Errorf("field %d: unexpected wire type %d", num, typ)
+			}
+			switch num {
+			case callFieldModule:
+				hdr.module, n, err = consumeInt32(b)
+			case callFieldMethod:
+				hdr.method, n, err = consumeInt32(b)
+			default:
+				var v uint64
+				if v, n = protowire.ConsumeVarint(b); n < 0 {
+					err = protowire.ParseError(n)
+				}
+				hdr.sequence = int64(v)
+			}
+		case callFieldBody, callFieldTrace:
+			if typ != protowire.BytesType {
+				return nil, errors.Errorf("field %d: unexpected wire type %d", num, typ)
+			}
+			var v []byte
+			if v, n = protowire.ConsumeBytes(b); n < 0 {
+				err = protowire.ParseError(n)
+			} else if num == callFieldTrace && !utf8.Valid(v) {
+				err = errors.New("invalid UTF-8")
+			}
+		default:
+			return nil, errors.Errorf("unknown field %d", num)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %d", num)
+		}
+		b = b[n:]
+	}
+
+	return hdr, nil
+}
+
+// unmarshalResponse validates and unmarshals a dRPC Response received from a
+// server. Unlike a Call, an empty Response is valid.
+func unmarshalResponse(b []byte) (*Response, error) {
+	if len(b) > MaxMsgSize {
+		return nil, errors.Errorf("dRPC response size %d exceeds maximum %d", len(b), MaxMsgSize)
+	}
+
+	resp := new(Response)
+	if err := proto.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	if _, ok := Status_name[int32(resp.Status)]; !ok {
+		return nil, errors.Errorf("unknown dRPC response status %d", resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,170 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package drpc
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestDrpc_parseCallHeader(t *testing.T) {
+	marshal := func(t *testing.T, call *Call) []byte {
+		t.Helper()
+		b, err := proto.Marshal(call)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	varintField := func(b []byte, num protowire.Number, v uint64) []byte {
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, v)
+	}
+
+	for name, tc := range map[string]struct {
+		msg    []byte
+		expHdr *callHeader
+		expErr error
+	}{
+		"empty": {
+			msg:    []byte{},
+			expErr: errors.New("empty"),
+		},
+		"too large": {
+			msg:    make([]byte, MaxMsgSize+1),
+			expErr: errors.New("exceeds maximum"),
+		},
+		"garbage": {
+			msg:    getGarbageBytes(),
+			expErr: errors.New("field"),
+		},
+		"valid": {
+			msg: marshal(t, &Call{
+				Module:   int32(ModuleMgmt),
+				Method:   MethodPoolCreate.ID(),
+				Sequence: 42,
+				Body:     []byte("body"),
+			}),
+			expHdr: &callHeader{
+				module:   int32(ModuleMgmt),
+				method:   MethodPoolCreate.ID(),
+				sequence: 42,
+			},
+		},
+		"traced": {
+			msg: marshal(t, &Call{
+				Module:      int32(ModuleMgmt),
+				Method:      MethodPoolCreate.ID(),
+				Sequence:    7,
+				Body:        []byte("body"),
+				Traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			}),
+			expHdr: &callHeader{
+				module:   int32(ModuleMgmt),
+				method:   MethodPoolCreate.ID(),
+				sequence: 7,
+			},
+		},
+		"traceparent wrong wire type": {
+			msg:    varintField(marshal(t, &Call{Module: int32(ModuleMgmt)}), callFieldTrace, 1),
+			expErr: errors.New("field 5: unexpected wire type"),
+		},
+		"traceparent invalid UTF-8": {
+			msg:    protowire.AppendBytes(protowire.AppendTag(nil, callFieldTrace, protowire.BytesType), []byte{0xff}),
+			expErr: errors.New("field 5: invalid UTF-8"),
+		},
+		"negative sequence": {
+			msg: marshal(t, &Call{
+				Module:   int32(ModuleMgmt),
+				Sequence: -1,
+			}),
+			expHdr: &callHeader{
+				module:   int32(ModuleMgmt),
+				sequence: -1,
+			},
+		},
+		"unknown field": {
+			msg:    varintField(marshal(t, &Call{Module: int32(ModuleMgmt)}), 6, 1),
+			expErr: errors.New("unknown field 6"),
+		},
+		"repeated field": {
+			msg:    varintField(marshal(t, &Call{Module: int32(ModuleMgmt)}), callFieldModule, 3),
+			expErr: errors.New("field 1 repeated"),
+		},
+		"wrong wire type": {
+			msg:    protowire.AppendBytes(protowire.AppendTag(nil, callFieldMethod, protowire.BytesType), []byte("x")),
+			expErr: errors.New("unexpected wire type"),
+		},
+		"module out of range": {
+			msg:    varintField(nil, callFieldModule, 1<<40),
+			expErr: errors.New("out of range"),
+		},
+		"truncated body": {
+			msg:    protowire.AppendVarint(protowire.AppendTag(nil, callFieldBody, protowire.BytesType), 10),
+			expErr: errors.New("field 4"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hdr, err := parseCallHeader(tc.msg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expHdr, hdr, cmp.AllowUnexported(callHeader{})); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDrpc_unmarshalResponse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		msg     []byte
+		expResp *Response
+		expErr  error
+	}{
+		"empty": {
+			msg:     []byte{},
+			expResp: &Response{},
+		},
+		"too large": {
+			msg:    make([]byte, MaxMsgSize+1),
+			expErr: errors.New("exceeds maximum"),
+		},
+		"garbage": {
+			msg:    getGarbageBytes(),
+			expErr: errors.New("cannot parse"),
+		},
+		"unknown status": {
+			msg:    protowire.AppendVarint(protowire.AppendTag(nil, 2, protowire.VarintType), 100),
+			expErr: errors.New("unknown dRPC response status"),
+		},
+		"valid": {
+			msg:     marshallResponseToBytes(t, newTestResponse(3)),
+			expResp: newTestResponse(3),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := unmarshalResponse(tc.msg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, resp, test.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
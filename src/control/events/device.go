@@ -0,0 +1,65 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+// NewScmHealthWarningEvent creates a DeviceScmHealthWarning event indicating
+// that a PMem module's health sensors have crossed a warning threshold.
+func NewScmHealthWarningEvent(hostname, uid, details string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("PMem module %s health warning", uid),
+		ID:           RASDeviceScmHealthWarning,
+		Hostname:     hostname,
+		HWID:         uid,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityWarning,
+		ExtendedInfo: NewStrInfo(details),
+	})
+}
+
+// NewAgentFabricFailoverEvent creates an AgentFabricFailover event indicating
+// that a fabric interface used by the agent for client processes has stopped
+// working. The event is raised with error severity if there are no remaining
+// usable interfaces.
+func NewAgentFabricFailoverEvent(hostname, iface string, remaining int) *RASEvent {
+	msg := fmt.Sprintf("fabric interface %s failed; %d interface(s) remaining", iface, remaining)
+	sev := RASSeverityWarning
+	if remaining == 0 {
+		msg = fmt.Sprintf("fabric interface %s failed; no usable interfaces remaining", iface)
+		sev = RASSeverityError
+	}
+
+	return fill(&RASEvent{
+		Msg:      msg,
+		ID:       RASAgentFabricFailover,
+		Hostname: hostname,
+		HWID:     iface,
+		Type:     RASTypeInfoOnly,
+		Severity: sev,
+	})
+}
+
+// NewAgentFabricIfaceAddedEvent creates an AgentFabricIfaceAdded event
+// indicating that a fabric interface which appeared at runtime has been
+// detected by the agent and may now be selected for client processes.
+func NewAgentFabricIfaceAddedEvent(hostname, iface string, providers []string) *RASEvent {
+	return fill(&RASEvent{
+		Msg: fmt.Sprintf("fabric interface %s added with %s %s", iface,
+			common.Pluralise("provider", len(providers)), strings.Join(providers, ", ")),
+		ID:       RASAgentFabricIfaceAdded,
+		Hostname: hostname,
+		HWID:     iface,
+		Type:     RASTypeInfoOnly,
+		Severity: RASSeverityNotice,
+	})
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,6 +8,7 @@ package events
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/daos-stack/daos/src/control/common"
 	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
@@ -100,3 +101,46 @@ func NewEngineJoinFailedEvent(hostname string, instanceIdx uint32, rank uint32,
 		ExtendedInfo: NewStrInfo(reason),
 	})
 }
+
+// NewEngineMemoryPressureEvent creates an EngineMemoryPressure event from the given inputs.
+func NewEngineMemoryPressureEvent(hostname string, instanceIdx uint32, rank uint32, sev RASSeverityID, details string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("DAOS engine %d (rank %d) is at risk of running out of memory", instanceIdx, rank),
+		ID:           RASEngineMemoryPressure,
+		Hostname:     hostname,
+		Rank:         rank,
+		Type:         RASTypeInfoOnly,
+		Severity:     sev,
+		ExtendedInfo: NewStrInfo(details),
+	})
+}
+
+// NewEngineSuperblockMismatchEvent creates an EngineSuperblockMismatch event
+// indicating that the superblock on the storage of an engine doesn't match
+// the identity expected for it.
+func NewEngineSuperblockMismatchEvent(hostname string, instanceIdx uint32, rank uint32, details string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("DAOS engine %d (rank %d) superblock mismatch: %s", instanceIdx, rank, details),
+		ID:           RASEngineSuperblockMismatch,
+		Hostname:     hostname,
+		Rank:         rank,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityError,
+		ExtendedInfo: NewStrInfo("check that the engine storage was not restored from the wrong backup or cloned from another host"),
+	})
+}
+
+// NewEngineStartupTimeoutEvent creates an EngineStartupTimeout event
+// indicating that an engine did not become ready within its startup deadline.
+// The details describe where the diagnostics captured at the deadline were
+// saved.
+func NewEngineStartupTimeoutEvent(hostname string, instanceIdx uint32, timeout time.Duration, details string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("DAOS engine %d not ready after %s", instanceIdx, timeout),
+		ID:           RASEngineStartupTimeout,
+		Hostname:     hostname,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityError,
+		ExtendedInfo: NewStrInfo(details),
+	})
+}
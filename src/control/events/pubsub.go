@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,6 +8,9 @@ package events
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -19,6 +22,8 @@ const (
 	submitTimeout = 1 * time.Second
 	// periodically check for debounced events that can be cleaned
 	defaultDebounceCleanInterval = 1 * time.Hour
+	// periodically check for event summaries whose window has elapsed
+	defaultSummaryFlushInterval = 1 * time.Second
 )
 
 // Handler defines an interface to be implemented by event receivers.
@@ -44,8 +49,9 @@ type Publisher interface {
 }
 
 type subscriber struct {
-	topic   RASTypeID
-	handler Handler
+	topic      RASTypeID
+	handler    Handler
+	summarized bool
 }
 
 // filterUpdate enables or disables publishing of given event ids.
@@ -81,6 +87,61 @@ func (de dbncEvts) updateLastSeen(id RASID, key string) {
 	de[id][key] = time.Now()
 }
 
+// eventSummary tracks duplicates of an event that have been suppressed
+// during a summarization window.
+type eventSummary struct {
+	start time.Time
+	count int
+	last  *RASEvent
+}
+
+// summaryKey returns a key identifying events as identical for the purposes of
+// summarization. Fields that vary between otherwise identical reports of the
+// same condition, such as the timestamp, are excluded.
+func summaryKey(evt *RASEvent) string {
+	var extInfo string
+	if !common.InterfaceIsNil(evt.ExtendedInfo) {
+		buf, err := json.Marshal(evt.ExtendedInfo)
+		if err != nil {
+			// Never treat events with unknown details as identical.
+			buf = []byte(fmt.Sprintf("%p", evt))
+		}
+		extInfo = string(buf)
+	}
+
+	return strings.Join([]string{
+		evt.ID.String(), evt.Type.String(), evt.Severity.String(), evt.Msg, evt.Hostname,
+		fmt.Sprintf("%d:%x", evt.Rank, evt.Incarnation), evt.HWID, evt.JobID,
+		evt.PoolUUID, evt.ContUUID, evt.ObjID, evt.CtlOp, extInfo,
+	}, "|")
+}
+
+// summarize returns a copy of the last suppressed event with a message
+// indicating how many times it was repeated.
+func (es *eventSummary) summarize(window time.Duration) *RASEvent {
+	evt := &RASEvent{
+		ID:           es.last.ID,
+		Timestamp:    es.last.Timestamp,
+		Type:         es.last.Type,
+		Severity:     es.last.Severity,
+		Msg:          fmt.Sprintf("%s (repeated %d times in %s)", es.last.Msg, es.count, window),
+		Hostname:     es.last.Hostname,
+		Rank:         es.last.Rank,
+		Incarnation:  es.last.Incarnation,
+		HWID:         es.last.HWID,
+		ProcID:       es.last.ProcID,
+		ThreadID:     es.last.ThreadID,
+		JobID:        es.last.JobID,
+		PoolUUID:     es.last.PoolUUID,
+		ContUUID:     es.last.ContUUID,
+		ObjID:        es.last.ObjID,
+		CtlOp:        es.last.CtlOp,
+		ExtendedInfo: es.last.ExtendedInfo,
+	}
+	evt.forwarded.Store(es.last.IsForwarded())
+	return evt
+}
+
 // PubSub stores subscriptions to event topics and handlers to be called on
 // receipt of events pertaining to a particular topic.
 type PubSub struct {
@@ -88,11 +149,16 @@ type PubSub struct {
 	events            chan *RASEvent
 	subscribers       chan *subscriber
 	handlers          map[RASTypeID][]Handler
+	smryHandlers      map[RASTypeID][]Handler
 	filterUpdates     chan *filterUpdate
 	dbncCtrl          dbncCtrl
 	dbncCtrlMsgs      chan *dbncCtrlMsg
 	dbncEvts          dbncEvts
 	dbncCleanInterval time.Duration
+	smryWindow        time.Duration
+	smryWindowMsgs    chan time.Duration
+	smryEvts          map[string]*eventSummary
+	smryFlushInterval time.Duration
 	disabledIDs       map[RASID]struct{}
 	reset             chan struct{}
 	shutdown          context.CancelFunc
@@ -105,11 +171,15 @@ func NewPubSub(parent context.Context, log logging.Logger) *PubSub {
 		events:            make(chan *RASEvent),
 		subscribers:       make(chan *subscriber),
 		handlers:          make(map[RASTypeID][]Handler),
+		smryHandlers:      make(map[RASTypeID][]Handler),
 		filterUpdates:     make(chan *filterUpdate),
 		dbncCtrl:          make(dbncCtrl),
 		dbncCtrlMsgs:      make(chan *dbncCtrlMsg),
 		dbncEvts:          make(dbncEvts),
 		dbncCleanInterval: defaultDebounceCleanInterval,
+		smryWindowMsgs:    make(chan time.Duration),
+		smryEvts:          make(map[string]*eventSummary),
+		smryFlushInterval: defaultSummaryFlushInterval,
 		disabledIDs:       make(map[RASID]struct{}),
 		reset:             make(chan struct{}),
 	}
@@ -170,6 +240,22 @@ func (ps *PubSub) Subscribe(topic RASTypeID, handler Handler) {
 	}
 }
 
+// SubscribeSummarized adds a handler to the list of handlers subscribed to a
+// given topic (event type) that receive summaries in place of duplicate events
+// when summarization is enabled. Handlers that act on every event, e.g. to
+// update system state, should use Subscribe instead.
+func (ps *PubSub) SubscribeSummarized(topic RASTypeID, handler Handler) {
+	select {
+	case <-time.After(submitTimeout):
+		ps.log.Errorf("failed to submit subscription within %s", submitTimeout)
+	case ps.subscribers <- &subscriber{
+		topic:      topic,
+		handler:    handler,
+		summarized: true,
+	}:
+	}
+}
+
 // Debounce accepts an event ID and a key function to be used to determine
 // if an event matches a previously-seen event with that ID. This mechanism
 // provides control over publication of duplicate events. The cooldown parameter
@@ -184,6 +270,60 @@ func (ps *PubSub) Debounce(id RASID, cooldown time.Duration, keyFn DebounceKeyFn
 	}
 }
 
+// Summarize enables collapsing of identical events that repeat within the given
+// window for handlers added with SubscribeSummarized. The first occurrence of
+// an event is published immediately and any duplicates received within the
+// window are suppressed. When the window elapses, a single summary event
+// reporting the number of suppressed duplicates is published. Handlers added
+// with Subscribe receive every event and no summaries. A zero window disables
+// summarization.
+func (ps *PubSub) Summarize(window time.Duration) {
+	select {
+	case <-time.After(submitTimeout):
+		ps.log.Errorf("failed to submit summary update within %s", submitTimeout)
+	case ps.smryWindowMsgs <- window:
+	}
+}
+
+// summarizeEvent returns true if the event is a duplicate that should be
+// suppressed and counted toward a summary.
+func (ps *PubSub) summarizeEvent(ctx context.Context, event *RASEvent) bool {
+	if ps.smryWindow == 0 {
+		return false
+	}
+
+	key := summaryKey(event)
+	if smry, found := ps.smryEvts[key]; found {
+		if time.Since(smry.start) < ps.smryWindow {
+			smry.count++
+			smry.last = event
+			return true
+		}
+		ps.flushSummary(ctx, key, smry)
+	}
+
+	ps.smryEvts[key] = &eventSummary{start: time.Now()}
+	return false
+}
+
+func (ps *PubSub) flushSummary(ctx context.Context, key string, smry *eventSummary) {
+	delete(ps.smryEvts, key)
+	if smry.count == 0 {
+		return
+	}
+
+	ps.log.Debugf("summarizing %d suppressed %s events", smry.count, smry.last.ID)
+	ps.dispatch(ctx, ps.smryHandlers, smry.summarize(ps.smryWindow))
+}
+
+func (ps *PubSub) flushSummaries(ctx context.Context) {
+	for key, smry := range ps.smryEvts {
+		if time.Since(smry.start) >= ps.smryWindow {
+			ps.flushSummary(ctx, key, smry)
+		}
+	}
+}
+
 func (ps *PubSub) debounceEvent(event *RASEvent) bool {
 	ctrl, isControlled := ps.dbncCtrl[event.ID]
 	if !isControlled {
@@ -227,10 +367,18 @@ func (ps *PubSub) publish(ctx context.Context, event *RASEvent) {
 		return
 	}
 
-	for _, hdlr := range ps.handlers[RASTypeAny] {
+	ps.dispatch(ctx, ps.handlers, event)
+	if ps.summarizeEvent(ctx, event) {
+		return
+	}
+	ps.dispatch(ctx, ps.smryHandlers, event)
+}
+
+func (ps *PubSub) dispatch(ctx context.Context, handlers map[RASTypeID][]Handler, event *RASEvent) {
+	for _, hdlr := range handlers[RASTypeAny] {
 		go hdlr.OnEvent(ctx, event)
 	}
-	for _, hdlr := range ps.handlers[event.Type] {
+	for _, hdlr := range handlers[event.Type] {
 		go hdlr.OnEvent(ctx, event)
 	}
 }
@@ -252,20 +400,28 @@ func (ps *PubSub) updateFilter(fu *filterUpdate) {
 // event.
 func (ps *PubSub) eventLoop(ctx context.Context) {
 	cleanDebounceTicker := time.NewTicker(ps.dbncCleanInterval)
+	flushSummaryTicker := time.NewTicker(ps.smryFlushInterval)
 
 	for {
 		select {
 		case <-ctx.Done():
 			ps.log.Debug("stopping event loop")
 			cleanDebounceTicker.Stop()
+			flushSummaryTicker.Stop()
 			return
 		case <-ps.reset:
 			ps.handlers = make(map[RASTypeID][]Handler)
+			ps.smryHandlers = make(map[RASTypeID][]Handler)
 			ps.dbncCtrl = make(dbncCtrl)
 			ps.dbncEvts = make(dbncEvts)
+			ps.smryWindow = 0
+			ps.smryEvts = make(map[string]*eventSummary)
 		case newSub := <-ps.subscribers:
-			ps.handlers[newSub.topic] = append(ps.handlers[newSub.topic],
-				newSub.handler)
+			handlers := ps.handlers
+			if newSub.summarized {
+				handlers = ps.smryHandlers
+			}
+			handlers[newSub.topic] = append(handlers[newSub.topic], newSub.handler)
 		case event := <-ps.events:
 			ps.publish(ctx, event)
 		case fu := <-ps.filterUpdates:
@@ -274,6 +430,11 @@ func (ps *PubSub) eventLoop(ctx context.Context) {
 			ps.dbncCtrl[msg.id] = msg
 		case <-cleanDebounceTicker.C:
 			ps.cleanDebouncedEvents()
+		case window := <-ps.smryWindowMsgs:
+			ps.smryWindow = window
+			ps.smryEvts = make(map[string]*eventSummary)
+		case <-flushSummaryTicker.C:
+			ps.flushSummaries(ctx)
 		}
 	}
 }
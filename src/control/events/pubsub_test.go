@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -13,6 +13,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/logging"
 )
@@ -296,3 +298,100 @@ func TestEvents_PubSub_Debounce_Cooldown(t *testing.T) {
 		}
 	}
 }
+
+func TestEvents_PubSub_Summarize(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ps := NewPubSub(test.Context(t), log)
+	defer ps.Close()
+
+	window := 50 * time.Millisecond
+	evt1 := mockEvtDied(t)
+	evt2 := mockSwimRankDeadEvt(2, 1)
+	tally := newTally(3)
+	allTally := newTally(11)
+
+	ps.SubscribeSummarized(RASTypeStateChange, tally)
+	ps.Subscribe(RASTypeAny, allTally)
+	ps.Summarize(window)
+
+	for i := 0; i < 10; i++ {
+		ps.Publish(evt1)
+	}
+	ps.Publish(evt2)
+
+	// Wait for the summary of the suppressed duplicates, which is published
+	// once the window has elapsed.
+	select {
+	case <-tally.finished:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for summary event, got %v", tally.getRx())
+	}
+
+	smry := *evt1
+	smry.Msg = fmt.Sprintf("%s (repeated 9 times in %s)", evt1.Msg, window)
+	test.AssertStringsEqual(t, []string{evt1.String(), evt2.String(), smry.String()},
+		tally.getRx(), "unexpected slice of received events")
+
+	// Handlers that are not summarized receive every event and no summary.
+	select {
+	case <-allTally.finished:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for events, got %v", allTally.getRx())
+	}
+	counts := make(map[string]int)
+	for _, rx := range allTally.getRx() {
+		counts[rx]++
+	}
+	if diff := cmp.Diff(map[string]int{evt1.String(): 10, evt2.String(): 1}, counts); diff != "" {
+		t.Fatalf("unexpected events received by unsummarized handler (-want, +got):\n%s", diff)
+	}
+}
+
+func TestEvents_summaryKey(t *testing.T) {
+	evt := mockEvtDied(t)
+	sameInfo := mockEvtDied(t)
+	sameInfo.Timestamp = "later"
+	otherInfo := mockEvtDied(t)
+	otherInfo.ExtendedInfo = NewStrInfo("other details")
+
+	test.AssertEqual(t, summaryKey(evt), summaryKey(sameInfo), "expected identical events to match")
+	test.AssertTrue(t, summaryKey(evt) != summaryKey(otherInfo),
+		"expected events with different extended info not to match")
+}
+
+func TestEvents_PubSub_summarizeEvent(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ps := &PubSub{
+		log:        log,
+		handlers:   make(map[RASTypeID][]Handler),
+		dbncCtrl:   make(dbncCtrl),
+		smryEvts:   make(map[string]*eventSummary),
+		smryWindow: time.Hour,
+	}
+
+	evt := mockSwimRankDeadEvt(1, 1)
+	otherInc := mockSwimRankDeadEvt(1, 2)
+
+	test.AssertFalse(t, ps.summarizeEvent(test.Context(t), evt), "first event should be published")
+	test.AssertTrue(t, ps.summarizeEvent(test.Context(t), evt), "duplicate should be suppressed")
+	test.AssertFalse(t, ps.summarizeEvent(test.Context(t), otherInc), "different event should be published")
+
+	// Nothing is flushed before the window elapses.
+	ps.flushSummaries(test.Context(t))
+	test.AssertEqual(t, 2, len(ps.smryEvts), "unexpected number of summaries")
+	test.AssertEqual(t, 1, ps.smryEvts[summaryKey(evt)].count, "unexpected suppressed count")
+
+	// A duplicate seen after the window has elapsed is published and starts
+	// a new window.
+	ps.smryEvts[summaryKey(evt)].start = time.Now().Add(-2 * time.Hour)
+	test.AssertFalse(t, ps.summarizeEvent(test.Context(t), evt), "event after window should be published")
+	test.AssertEqual(t, 0, ps.smryEvts[summaryKey(evt)].count, "expected new summary window")
+
+	// Disabled summarization publishes everything.
+	ps.smryWindow = 0
+	test.AssertFalse(t, ps.summarizeEvent(test.Context(t), evt), "event should be published when disabled")
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -45,16 +45,34 @@ type RASID uint32
 // RASID constant definitions matching those used when creating events either in
 // the control or data (engine) planes.
 const (
-	RASUnknownEvent            RASID = C.RAS_UNKNOWN_EVENT
-	RASEngineFormatRequired    RASID = C.RAS_ENGINE_FORMAT_REQUIRED     // notice
-	RASEngineDied              RASID = C.RAS_ENGINE_DIED                // error
-	RASPoolRepsUpdate          RASID = C.RAS_POOL_REPS_UPDATE           // info
-	RASSwimRankAlive           RASID = C.RAS_SWIM_RANK_ALIVE            // info
-	RASSwimRankDead            RASID = C.RAS_SWIM_RANK_DEAD             // info
-	RASSystemStartFailed       RASID = C.RAS_SYSTEM_START_FAILED        // error
-	RASSystemStopFailed        RASID = C.RAS_SYSTEM_STOP_FAILED         // error
-	RASEngineJoinFailed        RASID = C.RAS_ENGINE_JOIN_FAILED         // error
-	RASSystemFabricProvChanged RASID = C.RAS_SYSTEM_FABRIC_PROV_CHANGED // info
+	RASUnknownEvent             RASID = C.RAS_UNKNOWN_EVENT
+	RASEngineFormatRequired     RASID = C.RAS_ENGINE_FORMAT_REQUIRED     // notice
+	RASEngineDied               RASID = C.RAS_ENGINE_DIED                // error
+	RASPoolRebuildStart         RASID = C.RAS_POOL_REBUILD_START         // notice
+	RASPoolRebuildEnd           RASID = C.RAS_POOL_REBUILD_END           // notice
+	RASPoolRebuildFailed        RASID = C.RAS_POOL_REBUILD_FAILED        // error
+	RASPoolRepsUpdate           RASID = C.RAS_POOL_REPS_UPDATE           // info
+	RASSwimRankAlive            RASID = C.RAS_SWIM_RANK_ALIVE            // info
+	RASSwimRankDead             RASID = C.RAS_SWIM_RANK_DEAD             // info
+	RASSystemStartFailed        RASID = C.RAS_SYSTEM_START_FAILED        // error
+	RASSystemStopFailed         RASID = C.RAS_SYSTEM_STOP_FAILED         // error
+	RASEngineJoinFailed         RASID = C.RAS_ENGINE_JOIN_FAILED         // error
+	RASSystemFabricProvChanged  RASID = C.RAS_SYSTEM_FABRIC_PROV_CHANGED // info
+	RASSystemMemberUnstable     RASID = C.RAS_SYSTEM_MEMBER_UNSTABLE     // warning
+	RASEngineMemoryPressure     RASID = C.RAS_ENGINE_MEMORY_PRESSURE     // warning
+	RASDeviceScmHealthWarning   RASID = C.RAS_DEVICE_SCM_HEALTH_WARNING  // warning
+	RASSystemDestructiveOp      RASID = C.RAS_SYSTEM_DESTRUCTIVE_OP      // notice
+	RASPoolAutoReintegrate      RASID = C.RAS_POOL_AUTO_REINTEGRATE      // notice
+	RASAgentFabricFailover      RASID = C.RAS_AGENT_FABRIC_FAILOVER      // warning or error
+	RASSystemClockSkew          RASID = C.RAS_SYSTEM_CLOCK_SKEW          // warning or error
+	RASPoolCorruptionDetected   RASID = C.RAS_POOL_CORRUPTION_DETECTED   // error
+	RASDeviceSetFaulty          RASID = C.RAS_DEVICE_SET_FAULTY          // error
+	RASDeviceMediaError         RASID = C.RAS_DEVICE_MEDIA_ERROR         // error
+	RASEngineSuperblockMismatch RASID = C.RAS_ENGINE_SUPERBLOCK_MISMATCH // error
+	RASEngineStartupTimeout     RASID = C.RAS_ENGINE_STARTUP_TIMEOUT     // error
+	RASAgentFabricIfaceAdded    RASID = C.RAS_AGENT_FABRIC_IFACE_ADDED   // notice
+	RASPoolSpacePressure        RASID = C.RAS_POOL_SPACE_PRESSURE        // warning
+	RASPoolSpaceForecast        RASID = C.RAS_POOL_SPACE_FORECAST        // warning
 )
 
 func (id RASID) String() string {
@@ -0,0 +1,112 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewMemberUnstableEvent creates a MemberUnstable event indicating that a rank
+// has been held out of the system after repeatedly being marked dead.
+func NewMemberUnstableEvent(rank uint32, incarnation uint64, deaths int, window time.Duration) *RASEvent {
+	msg := fmt.Sprintf("rank %d marked dead %d times within %s; held in unstable state",
+		rank, deaths, window)
+
+	return fill(&RASEvent{
+		Msg:          msg,
+		ID:           RASSystemMemberUnstable,
+		Rank:         rank,
+		Incarnation:  incarnation,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityWarning,
+		ExtendedInfo: NewStrInfo(fmt.Sprintf("clear-exclude rank %d to allow it to rejoin", rank)),
+	})
+}
+
+// NewSystemDestructiveOpEvent creates a SystemDestructiveOp event recording
+// that a destructive system operation has been armed or confirmed.
+func NewSystemDestructiveOpEvent(operation, action, requester string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:      fmt.Sprintf("system %s %s by %s", operation, action, requester),
+		ID:       RASSystemDestructiveOp,
+		Type:     RASTypeInfoOnly,
+		Severity: RASSeverityNotice,
+	})
+}
+
+// NewPoolAutoReintegrateEvent creates a PoolAutoReintegrate event recording
+// that the targets of a rank which rejoined the system after being excluded
+// have been reintegrated into a pool.
+func NewPoolAutoReintegrateEvent(rank uint32, incarnation uint64, poolID string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:         fmt.Sprintf("rank %d automatically reintegrated into pool %s", rank, poolID),
+		ID:          RASPoolAutoReintegrate,
+		Rank:        rank,
+		Incarnation: incarnation,
+		Type:        RASTypeInfoOnly,
+		Severity:    RASSeverityNotice,
+	})
+}
+
+// NewPoolAutoReintegrateFailedEvent creates a PoolAutoReintegrate event
+// indicating that the automatic reintegration of a rank into a pool failed
+// and must be performed manually.
+func NewPoolAutoReintegrateFailedEvent(rank uint32, incarnation uint64, poolID string, cause error) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("automatic reintegration of rank %d into pool %s failed: %s", rank, poolID, cause),
+		ID:           RASPoolAutoReintegrate,
+		Rank:         rank,
+		Incarnation:  incarnation,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityError,
+		ExtendedInfo: NewStrInfo(fmt.Sprintf("dmg pool reintegrate %s --rank=%d", poolID, rank)),
+	})
+}
+
+// NewClockSkewEvent creates a ClockSkew event indicating that the clock of a
+// peer server differs from the local clock by more than a threshold. Skew
+// breaks certificate validity windows and the ordering of event timestamps.
+func NewClockSkewEvent(hostname, peer string, skew time.Duration, sev RASSeverityID) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("clock of %s differs from local clock by %s", peer, skew),
+		ID:           RASSystemClockSkew,
+		Hostname:     hostname,
+		Type:         RASTypeInfoOnly,
+		Severity:     sev,
+		ExtendedInfo: NewStrInfo("check time synchronization (e.g. NTP) on both hosts"),
+	})
+}
+
+// NewPoolSpacePressureEvent creates a PoolSpacePressure event indicating that
+// the space used in a pool has exceeded the configured warning threshold.
+func NewPoolSpacePressureEvent(poolUUID, poolName string, usedPercent float64, warnPercent int) *RASEvent {
+	return fill(&RASEvent{
+		Msg: fmt.Sprintf("pool %s is %.1f%% full, above the %d%% warning threshold",
+			poolName, usedPercent, warnPercent),
+		ID:           RASPoolSpacePressure,
+		PoolUUID:     poolUUID,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityWarning,
+		ExtendedInfo: NewStrInfo(fmt.Sprintf("free space in pool %s or extend it with dmg pool extend", poolName)),
+	})
+}
+
+// NewPoolSpaceForecastEvent creates a PoolSpaceForecast event indicating that
+// the recent growth in the space used in a pool projects it to be full within
+// the configured horizon.
+func NewPoolSpaceForecastEvent(poolUUID, poolName string, usedPercent float64, fullIn time.Duration) *RASEvent {
+	return fill(&RASEvent{
+		Msg: fmt.Sprintf("pool %s projected full in %.1f days (%.1f%% used)",
+			poolName, fullIn.Hours()/24, usedPercent),
+		ID:           RASPoolSpaceForecast,
+		PoolUUID:     poolUUID,
+		Type:         RASTypeInfoOnly,
+		Severity:     RASSeverityWarning,
+		ExtendedInfo: NewStrInfo(fmt.Sprintf("free space in pool %s or extend it with dmg pool extend", poolName)),
+	})
+}
@@ -42,6 +42,37 @@ func (c *Code) UnmarshalJSON(data []byte) (err error) {
 	return
 }
 
+// codeClasses maps each block of fault codes to a stable class name. The
+// index into the slice is the block number (code / 100).
+var codeClasses = []string{
+	"general",
+	"storage",
+	"scm",
+	"bdev",
+	"system",
+	"client",
+	"server",
+	"server_config",
+	"spdk",
+	"security",
+	"control_metadata",
+	"system_checker",
+}
+
+// Block returns the number of the block of fault codes containing the code.
+func (c Code) Block() int {
+	return int(c) / 100
+}
+
+// Class returns the name of the class of faults to which the code belongs,
+// based on the block of fault codes containing it.
+func (c Code) Class() string {
+	if c < 0 || c.Block() >= len(codeClasses) {
+		return "unknown"
+	}
+	return codeClasses[c.Block()]
+}
+
 // general fault codes
 const (
 	Unknown Code = iota
@@ -114,6 +145,8 @@ const (
 	SystemUnknown Code = iota + 400
 	SystemBadFaultDomainDepth
 	SystemPoolLocked
+	SystemDatabaseLogStoreCorrupt
+	SystemDatabaseSnapshotsCorrupt
 )
 
 // client fault codes
@@ -130,6 +163,9 @@ const (
 	ClientFormatRunningSystem
 	ClientRpcTimeout
 	ClientConfigVMDImbalance
+	ClientIncompatibleComponents
+	ClientFeatureNotSupported
+	ClientFormatNotArmed
 )
 
 // server fault codes
@@ -154,6 +190,9 @@ const (
 	ServerNoCompatibilityInsecure
 	ServerPoolHasContainers
 	ServerHugepagesDisabled
+	ServerInstanceSuperblockMismatch
+	ServerJoinMissingFeatures
+	ServerPoolInvalidACL
 )
 
 // server config fault codes
@@ -193,6 +232,33 @@ const (
 	ServerConfigScmDiffClass
 	ServerConfigEngineBdevRolesMismatch
 	ServerConfigSysRsvdZero
+	ServerConfigBadFlapDamping
+	ServerConfigBadMemoryWatchdog
+	ServerConfigBadEventDedupe
+	ServerConfigBadJoinAdmission
+	ServerConfigBadMSElectionTier
+	ServerConfigBadControlLogSubsystem
+	ServerConfigBadControlProfiling
+	ServerConfigEncryptionNoKey
+	ServerConfigBadEncryptionKey
+	ServerConfigBadBdevTrim
+	ServerConfigBadJoinRateLimit
+	ServerConfigBadAutoReintegrate
+	ServerConfigBdevCrossNUMA
+	ServerConfigBadTracing
+	ServerConfigControlMetadataBadMirror
+	ServerConfigBadTelemetryRemoteWrite
+	ServerConfigTelemetryRemoteWriteNoPort
+	ServerConfigBadFabricIfaceFilter
+	ServerConfigBadHealthReport
+	ServerConfigBadEngineLogBump
+	ServerConfigBadEventArchive
+	ServerConfigBadDrpcSocket
+	ServerConfigBadPoolOpScheduler
+	ServerConfigBadProvisioning
+	ServerConfigBadMSBackup
+	ServerConfigBadPoolSpaceForecast
+	ServerConfigBadQuarantinePolicy
 )
 
 // SPDK library bindings codes
@@ -209,6 +275,8 @@ const (
 	SecurityMissingCertFile
 	SecurityUnreadableCertFile
 	SecurityInvalidCert
+	SecurityFIPSNonCompliant
+	SecurityInvalidProvisioningToken
 )
 
 const (
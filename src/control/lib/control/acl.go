@@ -109,6 +109,243 @@ func ParseACL(reader io.Reader) (*AccessControlList, error) {
 	return &AccessControlList{Entries: aceList}, nil
 }
 
+// Special principals and limits used by Access Control Entries.
+const (
+	PrincipalOwner      = "OWNER@"
+	PrincipalOwnerGroup = "GROUP@"
+	PrincipalEveryone   = "EVERYONE@"
+
+	// MaxPrincipalLen is the maximum length of an ACE principal name.
+	MaxPrincipalLen = 255
+
+	aceTypeChars  = "AUL"
+	aceFlagChars  = "GSFP"
+	acePermChars  = "rwcdtTaAo"
+	aceGroupFlag  = "G"
+	aceAlarmFlags = "SF"
+	aceAlarmTypes = "UL"
+)
+
+// AccessControlEntry is a parsed Access Control Entry in short string format,
+// i.e. TYPES:FLAGS:PRINCIPAL:PERMISSIONS.
+type AccessControlEntry struct {
+	Types       string `json:"types"`
+	Flags       string `json:"flags"`
+	Principal   string `json:"principal"`
+	Permissions string `json:"permissions"`
+}
+
+// IsGroup indicates whether the entry applies to a group principal.
+func (ace *AccessControlEntry) IsGroup() bool {
+	return strings.Contains(ace.Flags, aceGroupFlag)
+}
+
+// IsSpecial indicates whether the entry applies to one of the special
+// OWNER@, GROUP@ or EVERYONE@ principals.
+func (ace *AccessControlEntry) IsSpecial() bool {
+	switch ace.Principal {
+	case PrincipalOwner, PrincipalOwnerGroup, PrincipalEveryone:
+		return true
+	}
+	return false
+}
+
+// Key uniquely identifies the principal that the entry applies to. An ACL may
+// contain only one entry per key.
+func (ace *AccessControlEntry) Key() string {
+	if ace.IsGroup() && !ace.IsSpecial() {
+		return "g:" + ace.Principal
+	}
+	return "u:" + ace.Principal
+}
+
+func (ace *AccessControlEntry) String() string {
+	return strings.Join([]string{ace.Types, ace.Flags, ace.Principal, ace.Permissions}, ":")
+}
+
+func checkACEChars(field, name, valid string) error {
+	for _, c := range field {
+		if !strings.ContainsRune(valid, c) {
+			return errors.Errorf("invalid %s %q", name, c)
+		}
+	}
+	return nil
+}
+
+func checkACEPrincipal(principal string) error {
+	if principal == "" || len(principal) > MaxPrincipalLen {
+		return errors.Errorf("principal must be between 1 and %d characters", MaxPrincipalLen)
+	}
+	at := strings.Index(principal, "@")
+	if at < 1 || strings.Contains(principal[at+1:], "@") {
+		return errors.Errorf("invalid principal %q, expected name@[domain]", principal)
+	}
+	return nil
+}
+
+// ParseACE parses and validates an Access Control Entry in short string format.
+func ParseACE(str string) (*AccessControlEntry, error) {
+	fields := strings.Split(str, ":")
+	if len(fields) != 4 {
+		return nil, errors.Errorf("invalid ACE %q: expected TYPES:FLAGS:PRINCIPAL:PERMISSIONS", str)
+	}
+
+	ace := &AccessControlEntry{
+		Types:       fields[0],
+		Flags:       fields[1],
+		Principal:   fields[2],
+		Permissions: fields[3],
+	}
+
+	wrap := func(err error) error {
+		return errors.Wrapf(err, "invalid ACE %q", str)
+	}
+	if ace.Types == "" {
+		return nil, wrap(errors.New("no access type"))
+	}
+	if err := checkACEChars(ace.Types, "access type", aceTypeChars); err != nil {
+		return nil, wrap(err)
+	}
+	if err := checkACEChars(ace.Flags, "flag", aceFlagChars); err != nil {
+		return nil, wrap(err)
+	}
+	if err := checkACEChars(ace.Permissions, "permission", acePermChars); err != nil {
+		return nil, wrap(err)
+	}
+	if err := checkACEPrincipal(ace.Principal); err != nil {
+		return nil, wrap(err)
+	}
+
+	switch ace.Principal {
+	case PrincipalOwnerGroup:
+		if !ace.IsGroup() {
+			return nil, wrap(errors.Errorf("%s requires the %s flag", PrincipalOwnerGroup, aceGroupFlag))
+		}
+	case PrincipalOwner, PrincipalEveryone:
+		if ace.IsGroup() {
+			return nil, wrap(errors.Errorf("%s may not have the %s flag", ace.Principal, aceGroupFlag))
+		}
+	}
+
+	isAlarm := strings.ContainsAny(ace.Types, aceAlarmTypes)
+	hasAlarmFlags := strings.ContainsAny(ace.Flags, aceAlarmFlags)
+	if isAlarm != hasAlarmFlags {
+		return nil, wrap(errors.New("audit and alarm types require the S or F flag, which are not valid for other types"))
+	}
+
+	return ace, nil
+}
+
+// Validate checks the syntax of each entry in the AccessControlList and that
+// no two entries apply to the same principal.
+func (acl *AccessControlList) Validate() error {
+	if acl.Empty() {
+		return errors.New("ACL contains no entries")
+	}
+
+	seen := make(map[string]string)
+	for _, str := range acl.Entries {
+		ace, err := ParseACE(str)
+		if err != nil {
+			return err
+		}
+		if prev, found := seen[ace.Key()]; found {
+			return errors.Errorf("conflicting ACEs %q and %q for the same principal", prev, str)
+		}
+		seen[ace.Key()] = str
+	}
+
+	return nil
+}
+
+// ACEChange describes an entry whose permissions differ between two ACLs.
+type ACEChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ACLDiff describes the entries that would change if one ACL were replaced
+// with another.
+type ACLDiff struct {
+	Added   []string    `json:"added"`
+	Removed []string    `json:"removed"`
+	Changed []ACEChange `json:"changed"`
+}
+
+// Empty indicates whether the ACLs compared were equivalent.
+func (d *ACLDiff) Empty() bool {
+	return d == nil || len(d.Added)+len(d.Removed)+len(d.Changed) == 0
+}
+
+// DiffACL compares the current ACL with the desired ACL, matching entries by
+// principal. The desired ACL must be valid.
+func DiffACL(current, desired *AccessControlList) (*ACLDiff, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+
+	keyed := func(acl *AccessControlList) ([]string, map[string]string) {
+		var keys []string
+		entries := make(map[string]string)
+		if acl.Empty() {
+			return keys, entries
+		}
+		for _, str := range acl.Entries {
+			key := str
+			if ace, err := ParseACE(str); err == nil {
+				key = ace.Key()
+			}
+			keys = append(keys, key)
+			entries[key] = str
+		}
+		return keys, entries
+	}
+	curKeys, curEntries := keyed(current)
+	desKeys, desEntries := keyed(desired)
+
+	diff := &ACLDiff{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []ACEChange{},
+	}
+	for _, key := range desKeys {
+		old, found := curEntries[key]
+		switch {
+		case !found:
+			diff.Added = append(diff.Added, desEntries[key])
+		case old != desEntries[key]:
+			diff.Changed = append(diff.Changed, ACEChange{Old: old, New: desEntries[key]})
+		}
+	}
+	for _, key := range curKeys {
+		if _, found := desEntries[key]; !found {
+			diff.Removed = append(diff.Removed, curEntries[key])
+		}
+	}
+
+	return diff, nil
+}
+
+// FormatACLDiff converts the ACLDiff to a human-readable string.
+func FormatACLDiff(diff *ACLDiff) string {
+	if diff.Empty() {
+		return "# No changes\n"
+	}
+
+	var builder strings.Builder
+	for _, ace := range diff.Added {
+		fmt.Fprintf(&builder, "+ %s\n", ace)
+	}
+	for _, chg := range diff.Changed {
+		fmt.Fprintf(&builder, "- %s\n+ %s\n", chg.Old, chg.New)
+	}
+	for _, ace := range diff.Removed {
+		fmt.Fprintf(&builder, "- %s\n", ace)
+	}
+
+	return builder.String()
+}
+
 // FormatACL converts the AccessControlList to a human-readable string.
 func FormatACL(acl *AccessControlList, verbose bool) string {
 	var builder strings.Builder
@@ -437,3 +437,253 @@ func TestControl_GetVerboseACE(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_ParseACE(t *testing.T) {
+	for name, tc := range map[string]struct {
+		str    string
+		expACE *AccessControlEntry
+		expErr error
+	}{
+		"user": {
+			str: "A::someone@:rw",
+			expACE: &AccessControlEntry{
+				Types:       "A",
+				Principal:   "someone@",
+				Permissions: "rw",
+			},
+		},
+		"group with domain": {
+			str: "A:G:admins@example.com:rwcdtTaAo",
+			expACE: &AccessControlEntry{
+				Types:       "A",
+				Flags:       "G",
+				Principal:   "admins@example.com",
+				Permissions: "rwcdtTaAo",
+			},
+		},
+		"owner group": {
+			str: "A:G:GROUP@:r",
+			expACE: &AccessControlEntry{
+				Types:       "A",
+				Flags:       "G",
+				Principal:   "GROUP@",
+				Permissions: "r",
+			},
+		},
+		"audit on failure": {
+			str: "AU:F:EVERYONE@:w",
+			expACE: &AccessControlEntry{
+				Types:       "AU",
+				Flags:       "F",
+				Principal:   "EVERYONE@",
+				Permissions: "w",
+			},
+		},
+		"no perms": {
+			str: "A::someone@:",
+			expACE: &AccessControlEntry{
+				Types:     "A",
+				Principal: "someone@",
+			},
+		},
+		"not all fields": {
+			str:    "A::someone@",
+			expErr: errors.New("expected TYPES:FLAGS:PRINCIPAL:PERMISSIONS"),
+		},
+		"no access type": {
+			str:    ":G:admins@:rw",
+			expErr: errors.New("no access type"),
+		},
+		"bad access type": {
+			str:    "X::someone@:rw",
+			expErr: errors.New("invalid access type 'X'"),
+		},
+		"bad flag": {
+			str:    "A:g:admins@:rw",
+			expErr: errors.New("invalid flag 'g'"),
+		},
+		"bad perm": {
+			str:    "A::someone@:rx",
+			expErr: errors.New("invalid permission 'x'"),
+		},
+		"no principal": {
+			str:    "A:::r",
+			expErr: errors.New("principal must be"),
+		},
+		"principal without @": {
+			str:    "A::someone:r",
+			expErr: errors.New("expected name@[domain]"),
+		},
+		"principal with two @": {
+			str:    "A::some@one@:r",
+			expErr: errors.New("expected name@[domain]"),
+		},
+		"principal too long": {
+			str:    fmt.Sprintf("A::%s@:r", strings.Repeat("x", MaxPrincipalLen)),
+			expErr: errors.New("principal must be"),
+		},
+		"owner group without group flag": {
+			str:    "A::GROUP@:r",
+			expErr: errors.New("requires the G flag"),
+		},
+		"owner with group flag": {
+			str:    "A:G:OWNER@:r",
+			expErr: errors.New("may not have the G flag"),
+		},
+		"audit without flags": {
+			str:    "U::someone@:r",
+			expErr: errors.New("require the S or F flag"),
+		},
+		"allow with success flag": {
+			str:    "A:S:someone@:r",
+			expErr: errors.New("require the S or F flag"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ace, err := ParseACE(tc.str)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expACE, ace); diff != "" {
+				t.Fatalf("unexpected ACE (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, tc.str, ace.String(), "unexpected string")
+		})
+	}
+}
+
+func TestControl_AccessControlList_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		acl    *AccessControlList
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("no entries"),
+		},
+		"valid": {
+			acl: &AccessControlList{
+				Entries: []string{
+					"A::OWNER@:rw",
+					"A:G:GROUP@:r",
+					"A::someone@:r",
+					"A:G:someone@:rw",
+					"A::EVERYONE@:",
+				},
+			},
+		},
+		"invalid entry": {
+			acl: &AccessControlList{
+				Entries: []string{"A::OWNER@:rw", "A::someone@:rx"},
+			},
+			expErr: errors.New("invalid permission"),
+		},
+		"conflicting entries": {
+			acl: &AccessControlList{
+				Entries: []string{"A::someone@:rw", "AU:F:someone@:r"},
+			},
+			expErr: errors.New("conflicting ACEs \"A::someone@:rw\" and \"AU:F:someone@:r\""),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.acl.Validate())
+		})
+	}
+}
+
+func TestControl_DiffACL(t *testing.T) {
+	current := &AccessControlList{
+		Entries: []string{
+			"A::OWNER@:rw",
+			"A:G:GROUP@:r",
+			"A::someone@:r",
+			"A:G:admins@:rw",
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		current *AccessControlList
+		desired *AccessControlList
+		expDiff *ACLDiff
+		expErr  error
+	}{
+		"invalid desired": {
+			current: current,
+			desired: &AccessControlList{Entries: []string{"A::OWNER@:rwz"}},
+			expErr:  errors.New("invalid permission"),
+		},
+		"no changes": {
+			current: current,
+			desired: current,
+			expDiff: &ACLDiff{
+				Added:   []string{},
+				Removed: []string{},
+				Changed: []ACEChange{},
+			},
+		},
+		"no current ACL": {
+			desired: &AccessControlList{Entries: []string{"A::OWNER@:rw"}},
+			expDiff: &ACLDiff{
+				Added:   []string{"A::OWNER@:rw"},
+				Removed: []string{},
+				Changed: []ACEChange{},
+			},
+		},
+		"add, change and remove": {
+			current: current,
+			desired: &AccessControlList{
+				Entries: []string{
+					"A::OWNER@:rwcdtTaAo",
+					"A:G:GROUP@:r",
+					"A:G:someone@:r",
+					"A::admins@:r",
+				},
+			},
+			expDiff: &ACLDiff{
+				Added:   []string{"A:G:someone@:r", "A::admins@:r"},
+				Removed: []string{"A::someone@:r", "A:G:admins@:rw"},
+				Changed: []ACEChange{
+					{Old: "A::OWNER@:rw", New: "A::OWNER@:rwcdtTaAo"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff, err := DiffACL(tc.current, tc.desired)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if d := cmp.Diff(tc.expDiff, diff); d != "" {
+				t.Fatalf("unexpected diff (-want, +got):\n%s\n", d)
+			}
+		})
+	}
+}
+
+func TestControl_FormatACLDiff(t *testing.T) {
+	for name, tc := range map[string]struct {
+		diff   *ACLDiff
+		expStr string
+	}{
+		"nil": {
+			expStr: "# No changes\n",
+		},
+		"changes": {
+			diff: &ACLDiff{
+				Added:   []string{"A::someone@:r"},
+				Removed: []string{"A:G:admins@:rw"},
+				Changed: []ACEChange{
+					{Old: "A::OWNER@:rw", New: "A::OWNER@:rwo"},
+				},
+			},
+			expStr: "+ A::someone@:r\n- A::OWNER@:rw\n+ A::OWNER@:rwo\n- A:G:admins@:rw\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expStr, FormatACLDiff(tc.diff), "unexpected output")
+		})
+	}
+}
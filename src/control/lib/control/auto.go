@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -69,8 +70,10 @@ type (
 		// Generate config with a tmpfs RAM-disk SCM.
 		UseTmpfsSCM bool `json:"UseTmpfsSCM"`
 		// Location to persist control-plane metadata, will generate MD-on-SSD config.
-		ExtMetadataPath string         `json:"ExtMetadataPath"`
-		Log             logging.Logger `json:"-"`
+		ExtMetadataPath string `json:"ExtMetadataPath"`
+		// NUMA node overrides for NVMe SSDs, keyed by PCI address.
+		BdevNUMAOverrides map[string]uint `json:"-"`
+		Log               logging.Logger  `json:"-"`
 	}
 
 	// ConfGenerateResp contains the generated server config.
@@ -83,6 +86,8 @@ type (
 		ConfGenerateReq
 		HostList []string
 		Client   UnaryInvoker
+		// Generate from recorded topology snapshots rather than scanning hosts.
+		Snapshots []*TopologySnapshot
 	}
 
 	// ConfGenerateRemoteResp wraps the ConfGenerateResp.
@@ -104,6 +109,7 @@ func (cgr *ConfGenerateReq) UnmarshalJSON(data []byte) error {
 		AccessPoints string
 		FabricPorts  string
 		NetClass     string
+		BdevNUMA     string
 		*Alias
 	}{
 		Alias: (*Alias)(cgr),
@@ -126,6 +132,28 @@ func (cgr *ConfGenerateReq) UnmarshalJSON(data []byte) error {
 		cgr.FabricPorts = append(cgr.FabricPorts, n)
 	}
 
+	for _, s := range strings.Split(aux.BdevNUMA, ",") {
+		if s == "" {
+			continue
+		}
+		kv := strings.Split(s, "=")
+		if len(kv) != 2 {
+			return errors.Errorf("bdev numa override %q: want <pci-address>=<numa-node>", s)
+		}
+		addr, err := hardware.NewPCIAddress(kv[0])
+		if err != nil {
+			return errors.Wrapf(err, "bdev numa override %q", s)
+		}
+		node, err := strconv.ParseUint(kv[1], 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "bdev numa override %q", s)
+		}
+		if cgr.BdevNUMAOverrides == nil {
+			cgr.BdevNUMAOverrides = make(map[string]uint)
+		}
+		cgr.BdevNUMAOverrides[addr.String()] = uint(node)
+	}
+
 	switch aux.NetClass {
 	case "ethernet":
 		cgr.NetClass = hardware.Ether
@@ -199,6 +227,12 @@ func ConfGenerate(req ConfGenerateReq, newEngineCfg newEngineCfgFn, hf *HostFabr
 		return nil, err
 	}
 
+	// SSDs explicitly assigned to an engine on another NUMA node would otherwise be refused
+	// at start-up.
+	if sd.CrossNUMABdevs {
+		sc.WithAllowCrossNUMABdevs(true)
+	}
+
 	resp := ConfGenerateResp{Server: *sc}
 	return &resp, nil
 }
@@ -208,6 +242,17 @@ func ConfGenerate(req ConfGenerateReq, newEngineCfg newEngineCfgFn, hf *HostFabr
 func ConfGenerateRemote(ctx context.Context, req ConfGenerateRemoteReq) (*ConfGenerateRemoteResp, error) {
 	req.Log.Debugf("ConfGenerateRemote called with request %+v", req)
 
+	if len(req.Snapshots) > 0 {
+		if len(req.HostList) > 0 {
+			return nil, errors.New("hosts may not be specified with topology snapshots")
+		}
+		hosts, err := topologySnapshotHosts(req.Snapshots)
+		if err != nil {
+			return nil, err
+		}
+		req.HostList = hosts
+	}
+
 	if len(req.HostList) == 0 {
 		return nil, errors.New("no hosts specified")
 	}
@@ -236,10 +281,16 @@ func ConfGenerateRemote(ctx context.Context, req ConfGenerateRemoteReq) (*ConfGe
 	return &remResp, nil
 }
 
-// getNetworkSet retrieves the result of network scan over host list and verifies that there is
-// only a single network set in response which indicates that network hardware setup is homogeneous
-// across all hosts.  Return host errors, network scan results for the host set or error.
-func getNetworkSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostFabricSet, error) {
+// getHostFabricMap retrieves the result of network scan over host list, or the fabric details
+// recorded in topology snapshots if supplied.
+func getHostFabricMap(ctx context.Context, req ConfGenerateRemoteReq) (HostFabricMap, error) {
+	if len(req.Snapshots) > 0 {
+		req.Log.Debugf("using host fabric info from topology snapshots for hosts %v",
+			req.HostList)
+
+		return snapshotFabricMap(req.Snapshots)
+	}
+
 	req.Log.Debugf("fetching host fabric info on hosts %v", req.HostList)
 
 	scanReq := &NetworkScanReq{
@@ -256,8 +307,20 @@ func getNetworkSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostFabricS
 		return nil, &ConfGenerateError{HostErrorsResp: scanResp.HostErrorsResp}
 	}
 
+	return scanResp.HostFabrics, nil
+}
+
+// getNetworkSet retrieves host fabric details over host list and verifies that there is only a
+// single network set which indicates that network hardware setup is homogeneous across all hosts.
+// Return host errors, network scan results for the host set or error.
+func getNetworkSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostFabricSet, error) {
+	hostFabrics, err := getHostFabricMap(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// verify homogeneous network
-	switch len(scanResp.HostFabrics) {
+	switch len(hostFabrics) {
 	case 0:
 		return nil, errors.New("no host responses")
 	case 1:
@@ -267,14 +330,14 @@ func getNetworkSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostFabricS
 		req.Log.Info("Heterogeneous network hardware configurations detected, " +
 			"cannot proceed. The following sets of hosts have different " +
 			"network hardware:")
-		for _, hns := range scanResp.HostFabrics {
+		for _, hns := range hostFabrics {
 			req.Log.Info(hns.HostSet.String())
 		}
 
 		return nil, errors.New("network hardware not consistent across hosts")
 	}
 
-	networkSet := scanResp.HostFabrics[scanResp.HostFabrics.Keys()[0]]
+	networkSet := hostFabrics[hostFabrics.Keys()[0]]
 
 	req.Log.Debugf("Network hardware is consistent for hosts %s:\n\t%v",
 		networkSet.HostSet, networkSet.HostFabric.Interfaces)
@@ -390,21 +453,13 @@ func getNetworkDetails(req ConfGenerateReq, hf *HostFabric) (*networkDetails, er
 // different combinations of SSD models.  Return host errors, storage scan results for the host set
 // or error.
 func getStorageSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostStorageSet, error) {
-	req.Log.Debugf("fetching host storage info on hosts %v", req.HostList)
-
-	scanReq := &StorageScanReq{NvmeBasic: true}
-	scanReq.SetHostList(req.HostList)
-
-	scanResp, err := StorageScan(ctx, req.Client, scanReq)
+	hostStorage, err := getHostStorageMap(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	if len(scanResp.GetHostErrors()) > 0 {
-		return nil, &ConfGenerateError{HostErrorsResp: scanResp.HostErrorsResp}
-	}
 
 	// verify homogeneous storage
-	switch len(scanResp.HostStorage) {
+	switch len(hostStorage) {
 	case 0:
 		return nil, errors.New("no host responses")
 	case 1:
@@ -414,23 +469,49 @@ func getStorageSet(ctx context.Context, req ConfGenerateRemoteReq) (*HostStorage
 		req.Log.Info("Heterogeneous storage hardware configurations detected, " +
 			"cannot proceed. The following sets of hosts have different " +
 			"storage hardware:")
-		for _, hss := range scanResp.HostStorage {
+		for _, hss := range hostStorage {
 			req.Log.Info(hss.HostSet.String())
 		}
 
 		return nil, errors.New("storage hardware not consistent across hosts")
 	}
 
-	storageSet := scanResp.HostStorage[scanResp.HostStorage.Keys()[0]]
-	hostStorage := storageSet.HostStorage
+	storageSet := hostStorage[hostStorage.Keys()[0]]
+	hs := storageSet.HostStorage
 
 	req.Log.Debugf("Storage hardware is consistent for hosts %s:\n\t%s\n\t%s\n\t%s",
-		storageSet.HostSet.String(), hostStorage.ScmNamespaces.Summary(),
-		hostStorage.NvmeDevices.Summary(), hostStorage.MemInfo.Summary())
+		storageSet.HostSet.String(), hs.ScmNamespaces.Summary(),
+		hs.NvmeDevices.Summary(), hs.MemInfo.Summary())
 
 	return storageSet, nil
 }
 
+// getHostStorageMap retrieves the result of storage scan over host list, or the storage details
+// recorded in topology snapshots if supplied.
+func getHostStorageMap(ctx context.Context, req ConfGenerateRemoteReq) (HostStorageMap, error) {
+	if len(req.Snapshots) > 0 {
+		req.Log.Debugf("using host storage info from topology snapshots for hosts %v",
+			req.HostList)
+
+		return snapshotStorageMap(req.Snapshots)
+	}
+
+	req.Log.Debugf("fetching host storage info on hosts %v", req.HostList)
+
+	scanReq := &StorageScanReq{NvmeBasic: true}
+	scanReq.SetHostList(req.HostList)
+
+	scanResp, err := StorageScan(ctx, req.Client, scanReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(scanResp.GetHostErrors()) > 0 {
+		return nil, &ConfGenerateError{HostErrorsResp: scanResp.HostErrorsResp}
+	}
+
+	return scanResp.HostStorage, nil
+}
+
 // numaSCMsMap is an alias for a map of NUMA node ID to slice of string sorted PMem block device
 // paths.
 type numaSCMsMap map[int]sort.StringSlice
@@ -501,10 +582,69 @@ func (nsm numaSSDsMap) fromNVMe(ssds storage.NvmeControllers) error {
 }
 
 type storageDetails struct {
-	NumaSCMs numaSCMsMap
-	NumaSSDs numaSSDsMap
-	MemInfo  *common.MemInfo
-	scmCls   storage.Class
+	NumaSCMs       numaSCMsMap
+	NumaSSDs       numaSSDsMap
+	SSDCapacities  map[string]uint64           // SSD capacity in bytes keyed by PCI address
+	SSDNandTypes   map[string]storage.NandType // SSD NAND media type keyed by PCI address
+	CrossNUMABdevs bool                        // true if any SSD was moved to another NUMA node
+	MemInfo        *common.MemInfo
+	scmCls         storage.Class
+}
+
+// applyBdevNUMAOverrides returns a copy of the SSD list with the NUMA node of each SSD replaced
+// by any override supplied for it. SSDs behind a VMD may be overridden using the address of either
+// the backing device or the VMD domain. Returns true if any SSD has been moved to a NUMA node other
+// than the one it is attached to.
+func applyBdevNUMAOverrides(log logging.Logger, overrides map[string]uint, ssds storage.NvmeControllers) (storage.NvmeControllers, bool, error) {
+	if len(overrides) == 0 {
+		return ssds, false, nil
+	}
+
+	unused := make(map[string]bool)
+	for addr := range overrides {
+		unused[addr] = true
+	}
+
+	var moved bool
+	out := make(storage.NvmeControllers, 0, len(ssds))
+	for _, ssd := range ssds {
+		addr, err := hardware.NewPCIAddress(ssd.PciAddr)
+		if err != nil {
+			return nil, false, err
+		}
+
+		key := addr.String()
+		node, found := overrides[key]
+		if !found && addr.IsVMDBackingAddress() {
+			key = addr.VMDAddr.String()
+			node, found = overrides[key]
+		}
+		if !found {
+			out = append(out, ssd)
+			continue
+		}
+		delete(unused, key)
+
+		if int32(node) != ssd.SocketID {
+			log.Noticef("ssd %s is attached to NUMA-%d but will be assigned to the engine on "+
+				"NUMA-%d as requested", ssd.PciAddr, ssd.SocketID, node)
+			moved = true
+		}
+		ovr := *ssd
+		ovr.SocketID = int32(node)
+		out = append(out, &ovr)
+	}
+
+	if len(unused) > 0 {
+		var addrs []string
+		for addr := range unused {
+			addrs = append(addrs, addr)
+		}
+		sort.Strings(addrs)
+		return nil, false, errors.Errorf("bdev numa overrides specified for unknown ssds %v", addrs)
+	}
+
+	return out, moved, nil
 }
 
 // getStorageDetails retrieves mappings of NUMA node to PMem and NVMe SSD devices.  Returns storage
@@ -530,10 +670,25 @@ func getStorageDetails(req ConfGenerateReq, numaCount int, hs *HostStorage) (*st
 		return nil, errors.New("requires nonzero HugepageSizeKiB")
 	}
 
-	if err := sd.NumaSSDs.fromNVMe(hs.NvmeDevices); err != nil {
+	ssds, moved, err := applyBdevNUMAOverrides(req.Log, req.BdevNUMAOverrides, hs.NvmeDevices)
+	if err != nil {
+		return nil, err
+	}
+	sd.CrossNUMABdevs = moved
+
+	if err := sd.NumaSSDs.fromNVMe(ssds); err != nil {
 		return nil, errors.Wrap(err, "mapping ssd addresses to numa node")
 	}
 
+	sd.SSDCapacities = make(map[string]uint64)
+	sd.SSDNandTypes = make(map[string]storage.NandType)
+	for _, ssd := range ssds {
+		if addr, err := hardware.NewPCIAddress(ssd.PciAddr); err == nil {
+			sd.SSDCapacities[addr.String()] = ssd.Capacity()
+			sd.SSDNandTypes[addr.String()] = ssd.NandType()
+		}
+	}
+
 	// if tmpfs scm mode is requested, init scm map to init entry for each numa node
 	if req.UseTmpfsSCM {
 		if numaCount <= 0 {
@@ -924,6 +1079,17 @@ func correctSSDCounts(log logging.Logger, sd *storageDetails) error {
 	}
 	log.Debugf("selecting %d ssds per engine (lowest value across engines)", minSSDsInCfg)
 
+	// balance capacity against the smallest capacity across engines with the lowest ssd count
+	var targetCap uint64 = math.MaxUint64
+	for _, ssds := range sd.NumaSSDs {
+		if ssds.Len() != minSSDsInCfg {
+			continue
+		}
+		if total := ssdsCapacity(ssds.Strings(), sd.SSDCapacities); total < targetCap {
+			targetCap = total
+		}
+	}
+
 	// second pass to apply corrections
 	for numaID := range sd.NumaSSDs {
 		ssds := sd.NumaSSDs[numaID]
@@ -942,14 +1108,87 @@ func correctSSDCounts(log logging.Logger, sd *storageDetails) error {
 			log.Debugf("only using %d SSDs from NUMA-%d from an available %d",
 				minSSDsInCfg, numaID, ssds.Len())
 
-			ssdAddrs := ssds.Strings()[:minSSDsInCfg]
+			ssdAddrs := selectBalancedSSDs(ssds.Strings(), sd.SSDCapacities, minSSDsInCfg,
+				targetCap)
 			sd.NumaSSDs[numaID] = hardware.MustNewPCIAddressSet(ssdAddrs...)
 		}
 	}
 
+	checkSSDCapacityBalance(log, sd)
+
 	return nil
 }
 
+func ssdsCapacity(addrs []string, caps map[string]uint64) (total uint64) {
+	for _, addr := range addrs {
+		total += caps[addr]
+	}
+	return
+}
+
+// selectBalancedSSDs picks nr SSDs from the supplied addresses so that their total capacity is as
+// close as possible to the target. On each pick, the SSD closest in capacity to the mean capacity
+// still required is chosen, with ties resolved in address order. If capacities are unknown, the
+// first nr addresses are selected.
+func selectBalancedSSDs(addrs []string, caps map[string]uint64, nr int, target uint64) []string {
+	pool := append([]string{}, addrs...)
+	selected := make([]string, 0, nr)
+
+	for len(selected) < nr && len(pool) > 0 {
+		want := target / uint64(nr-len(selected))
+
+		best := 0
+		var bestDiff uint64 = math.MaxUint64
+		for i, addr := range pool {
+			diff := caps[addr] - want
+			if caps[addr] < want {
+				diff = want - caps[addr]
+			}
+			if diff < bestDiff {
+				best, bestDiff = i, diff
+			}
+		}
+
+		selected = append(selected, pool[best])
+		if caps[pool[best]] < target {
+			target -= caps[pool[best]]
+		} else {
+			target = 0
+		}
+		pool = append(pool[:best], pool[best+1:]...)
+	}
+
+	sort.Strings(selected)
+	return selected
+}
+
+// ssdCapacityTolerance is the fraction by which the total SSD capacity of an engine may differ from
+// the lowest across engines before the generated config is reported as unbalanced.
+const ssdCapacityTolerance = 0.1
+
+// checkSSDCapacityBalance logs a notice if the total SSD capacity assigned to engines differs
+// significantly, as the smallest engine will limit the usable capacity of pools.
+func checkSSDCapacityBalance(log logging.Logger, sd *storageDetails) {
+	var minCap, maxCap uint64 = math.MaxUint64, 0
+	for _, ssds := range sd.NumaSSDs {
+		total := ssdsCapacity(ssds.Strings(), sd.SSDCapacities)
+		if total < minCap {
+			minCap = total
+		}
+		if total > maxCap {
+			maxCap = total
+		}
+	}
+
+	if maxCap == 0 || float64(maxCap-minCap) <= float64(minCap)*ssdCapacityTolerance {
+		return
+	}
+
+	log.Noticef("total ssd capacity per engine is not balanced (lowest %s, highest %s), usable "+
+		"pool capacity will be limited by the engine with the lowest capacity",
+		humanize.IBytes(minCap), humanize.IBytes(maxCap))
+}
+
 func getSCMTier(log logging.Logger, numaID, nrNumaNodes int, sd *storageDetails) (*storage.TierConfig, error) {
 	scmTier := storage.NewTierConfig().WithStorageClass(sd.scmCls.String()).
 		WithScmMountPoint(fmt.Sprintf("%s%d", scmMountPrefix, numaID))
@@ -966,7 +1205,7 @@ func getSCMTier(log logging.Logger, numaID, nrNumaNodes int, sd *storageDetails)
 	return scmTier, nil
 }
 
-func getBdevTiers(log logging.Logger, mdOnSSD bool, ssds *hardware.PCIAddressSet) (storage.TierConfigs, error) {
+func getBdevTiers(log logging.Logger, mdOnSSD bool, ssds *hardware.PCIAddressSet, nandTypes map[string]storage.NandType) (storage.TierConfigs, error) {
 	nrSSDs := ssds.Len()
 	addrs := ssds.Strings()
 
@@ -1001,6 +1240,8 @@ func getBdevTiers(log logging.Logger, mdOnSSD bool, ssds *hardware.PCIAddressSet
 	// 6+ SSDs: tiers 2:N-2
 	//
 	// Bdev tier device roles are assigned later based on tier structure applied here.
+	// The first tier takes the WAL role so SSDs with the best write endurance, e.g. TLC
+	// rather than QLC, are placed first.
 	//
 	// Note: Currently VMD backing devices cannot be split across tiers so only one
 	//       VMD domain per bdev tier.
@@ -1018,6 +1259,10 @@ func getBdevTiers(log logging.Logger, mdOnSSD bool, ssds *hardware.PCIAddressSet
 		ts = []int{2, nrSSDs - 2}
 	}
 
+	if !ssds.HasVMD() {
+		storage.SortByEndurance(addrs, nandTypes)
+	}
+
 	log.Debugf("md-on-ssd: nr ssds per bdev tier %v", ts)
 
 	var tiers storage.TierConfigs
@@ -1080,7 +1325,7 @@ func genEngineConfigs(req ConfGenerateReq, newEngineCfg newEngineCfgFn, nodeSet
 		}
 		tiers := storage.TierConfigs{scmTier}
 
-		bdevTiers, err := getBdevTiers(req.Log, mdOnSSD, ssds)
+		bdevTiers, err := getBdevTiers(req.Log, mdOnSSD, ssds, sd.SSDNandTypes)
 		if err != nil {
 			return nil, errors.Wrapf(err, "calculating bdev tiers")
 		}
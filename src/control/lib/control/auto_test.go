@@ -1107,6 +1107,42 @@ func TestControl_AutoConfig_correctSSDCounts(t *testing.T) {
 				},
 			},
 		},
+		"balance ssd capacity across engines": {
+			sd: storageDetails{
+				NumaSCMs: numaSCMsMap{
+					0: []string{"/dev/pmem0"},
+					1: []string{"/dev/pmem1"},
+				},
+				NumaSSDs: numaSSDsMap{
+					0: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(0, 1, 2)...),
+					1: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(3, 4)...),
+				},
+				SSDCapacities: map[string]uint64{
+					test.MockPCIAddr(0): 4 * humanize.TByte,
+					test.MockPCIAddr(1): 2 * humanize.TByte,
+					test.MockPCIAddr(2): 2 * humanize.TByte,
+					test.MockPCIAddr(3): 2 * humanize.TByte,
+					test.MockPCIAddr(4): 2 * humanize.TByte,
+				},
+			},
+			expSD: storageDetails{
+				NumaSCMs: numaSCMsMap{
+					0: []string{"/dev/pmem0"},
+					1: []string{"/dev/pmem1"},
+				},
+				NumaSSDs: numaSSDsMap{
+					0: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(1, 2)...),
+					1: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(3, 4)...),
+				},
+				SSDCapacities: map[string]uint64{
+					test.MockPCIAddr(0): 4 * humanize.TByte,
+					test.MockPCIAddr(1): 2 * humanize.TByte,
+					test.MockPCIAddr(2): 2 * humanize.TByte,
+					test.MockPCIAddr(3): 2 * humanize.TByte,
+					test.MockPCIAddr(4): 2 * humanize.TByte,
+				},
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -1125,6 +1161,115 @@ func TestControl_AutoConfig_correctSSDCounts(t *testing.T) {
 	}
 }
 
+func TestControl_AutoConfig_selectBalancedSSDs(t *testing.T) {
+	addrs := test.MockPCIAddrs(0, 1, 2, 3)
+
+	for name, tc := range map[string]struct {
+		caps   map[string]uint64
+		nr     int
+		target uint64
+		exp    []string
+	}{
+		"unknown capacities": {
+			nr:  2,
+			exp: test.MockPCIAddrs(0, 1),
+		},
+		"match target": {
+			caps: map[string]uint64{
+				addrs[0]: 8 * humanize.TByte,
+				addrs[1]: 4 * humanize.TByte,
+				addrs[2]: 2 * humanize.TByte,
+				addrs[3]: 2 * humanize.TByte,
+			},
+			nr:     2,
+			target: 6 * humanize.TByte,
+			exp:    test.MockPCIAddrs(1, 2),
+		},
+		"target unreachable": {
+			caps: map[string]uint64{
+				addrs[0]: 8 * humanize.TByte,
+				addrs[1]: 8 * humanize.TByte,
+				addrs[2]: 4 * humanize.TByte,
+				addrs[3]: 8 * humanize.TByte,
+			},
+			nr:     2,
+			target: 2 * humanize.TByte,
+			exp:    test.MockPCIAddrs(0, 2),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := selectBalancedSSDs(addrs, tc.caps, tc.nr, tc.target)
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Fatalf("unexpected ssds selected (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_applyBdevNUMAOverrides(t *testing.T) {
+	ctrlr := func(addr string, node int32) *storage.NvmeController {
+		return &storage.NvmeController{PciAddr: addr, SocketID: node}
+	}
+	ssds := storage.NvmeControllers{
+		ctrlr(test.MockPCIAddr(1), 0),
+		ctrlr(test.MockPCIAddr(2), 1),
+		ctrlr("5d0505:01:00.0", 1),
+	}
+
+	for name, tc := range map[string]struct {
+		overrides map[string]uint
+		expSSDs   storage.NvmeControllers
+		expMoved  bool
+		expErr    error
+	}{
+		"no overrides": {
+			expSSDs: ssds,
+		},
+		"override matches detected node": {
+			overrides: map[string]uint{test.MockPCIAddr(2): 1},
+			expSSDs:   ssds,
+		},
+		"ssd moved": {
+			overrides: map[string]uint{test.MockPCIAddr(2): 0},
+			expSSDs: storage.NvmeControllers{
+				ctrlr(test.MockPCIAddr(1), 0),
+				ctrlr(test.MockPCIAddr(2), 0),
+				ctrlr("5d0505:01:00.0", 1),
+			},
+			expMoved: true,
+		},
+		"vmd domain moved": {
+			overrides: map[string]uint{"0000:5d:05.5": 0},
+			expSSDs: storage.NvmeControllers{
+				ctrlr(test.MockPCIAddr(1), 0),
+				ctrlr(test.MockPCIAddr(2), 1),
+				ctrlr("5d0505:01:00.0", 0),
+			},
+			expMoved: true,
+		},
+		"unknown ssd": {
+			overrides: map[string]uint{test.MockPCIAddr(9): 0},
+			expErr:    errors.New("unknown ssds"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			gotSSDs, gotMoved, gotErr := applyBdevNUMAOverrides(log, tc.overrides, ssds)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expSSDs, gotSSDs); diff != "" {
+				t.Fatalf("unexpected ssds (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, tc.expMoved, gotMoved, "unexpected moved state")
+		})
+	}
+}
+
 func testEngineCfg(idx int) *engine.Config {
 	return engine.MockConfig().
 		WithTargetCount(defaultTargetCount).
@@ -1136,13 +1281,14 @@ func TestControl_AutoConfig_genEngineConfigs(t *testing.T) {
 		scmCls          storage.Class
 		scmOnly         bool
 		extMetadataPath string
-		memTotal        int              // available system memory for ramdisks in units of bytes
-		numaSet         []int            // set of numa nodes (by-ID) to be used for engine configs
-		numaPMems       numaSCMsMap      // numa to pmem mappings
-		numaSSDs        numaSSDsMap      // numa to ssds mappings
-		numaIfaces      numaNetIfaceMap  // numa to network interface mappings
-		fabricPorts     []int            // custom fabric port numbers
-		expCfgs         []*engine.Config // expected generated engine configs
+		memTotal        int                         // available system memory for ramdisks in units of bytes
+		numaSet         []int                       // set of numa nodes (by-ID) to be used for engine configs
+		numaPMems       numaSCMsMap                 // numa to pmem mappings
+		numaSSDs        numaSSDsMap                 // numa to ssds mappings
+		ssdNandTypes    map[string]storage.NandType // ssd nand media types keyed by pci address
+		numaIfaces      numaNetIfaceMap             // numa to network interface mappings
+		fabricPorts     []int                       // custom fabric port numbers
+		expCfgs         []*engine.Config            // expected generated engine configs
 		expErr          error
 	}{
 		"missing scm": {
@@ -1375,6 +1521,30 @@ func TestControl_AutoConfig_genEngineConfigs(t *testing.T) {
 				MockEngineCfgTmpfs(1, 0, MockBdevTier(1, 6, 7), MockBdevTier(1, 8, 9, 10, 11)),
 			},
 		},
+		"dual tmpfs; six ssds per numa; md-on-ssd; high endurance ssds first": {
+			scmCls:          storage.ClassRam,
+			extMetadataPath: "/var/daos_md",
+			memTotal:        humanize.GiByte * 25,
+			numaSet:         []int{0, 1},
+			numaPMems:       numaSCMsMap{0: []string{""}, 1: []string{""}},
+			numaIfaces:      numaNetIfaceMap{0: ib0, 1: ib1},
+			numaSSDs: numaSSDsMap{
+				0: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(0, 1, 2, 3, 4, 5)...),
+				1: hardware.MustNewPCIAddressSet(test.MockPCIAddrs(6, 7, 8, 9, 10, 11)...),
+			},
+			ssdNandTypes: map[string]storage.NandType{
+				test.MockPCIAddr(0):  storage.NandTypeQLC,
+				test.MockPCIAddr(1):  storage.NandTypeQLC,
+				test.MockPCIAddr(3):  storage.NandTypeTLC,
+				test.MockPCIAddr(5):  storage.NandTypeTLC,
+				test.MockPCIAddr(6):  storage.NandTypeQLC,
+				test.MockPCIAddr(11): storage.NandTypeSLC,
+			},
+			expCfgs: []*engine.Config{
+				MockEngineCfgTmpfs(0, 0, MockBdevTier(0, 2, 3), MockBdevTier(0, 4, 5, 0, 1)),
+				MockEngineCfgTmpfs(1, 0, MockBdevTier(1, 11, 7), MockBdevTier(1, 8, 9, 10, 6)),
+			},
+		},
 		"dual tmpfs; insufficient fabric port numbers": {
 			scmCls:     storage.ClassRam,
 			memTotal:   humanize.GiByte * 25,
@@ -1461,9 +1631,10 @@ func TestControl_AutoConfig_genEngineConfigs(t *testing.T) {
 					HugepageSizeKiB: 2048,
 					MemTotalKiB:     tc.memTotal / humanize.KiByte,
 				},
-				NumaSCMs: tc.numaPMems,
-				NumaSSDs: tc.numaSSDs,
-				scmCls:   storage.ClassDcpm,
+				NumaSCMs:     tc.numaPMems,
+				NumaSSDs:     tc.numaSSDs,
+				SSDNandTypes: tc.ssdNandTypes,
+				scmCls:       storage.ClassDcpm,
 			}
 			if tc.scmCls.String() != "" {
 				sd.scmCls = tc.scmCls
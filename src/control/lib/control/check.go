@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022-2023 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -588,6 +588,58 @@ func SystemCheckQuery(ctx context.Context, rpcClient UnaryInvoker, req *SystemCh
 	return resp, nil
 }
 
+// SystemCheckWaitReq contains the parameters for a wait on the system checker.
+type SystemCheckWaitReq struct {
+	progressRequest
+	Uuids        []string
+	PollInterval time.Duration
+}
+
+// SystemCheckWait polls the system checker until it is no longer running,
+// and returns the final query response. Progress is reported in terms of
+// the scan phases completed, with the time remaining taken from the slowest
+// pool being checked.
+func SystemCheckWait(ctx context.Context, rpcClient UnaryInvoker, req *SystemCheckWaitReq) (*SystemCheckQueryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T", req)
+	}
+
+	interval := req.PollInterval
+	if interval == 0 {
+		interval = DefaultProgressPollInterval
+	}
+	pt := newProgressTracker(req, "phases", uint64(SystemCheckScanPhaseDone))
+
+	for {
+		queryReq := new(SystemCheckQueryReq)
+		queryReq.Uuids = req.Uuids
+		resp, err := SystemCheckQuery(ctx, rpcClient, queryReq)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.Status {
+		case SystemCheckStatusInit, SystemCheckStatusRunning:
+			var remaining time.Duration
+			for _, pool := range resp.Pools {
+				if pool.Remaining > remaining {
+					remaining = pool.Remaining
+				}
+			}
+			pt.update(uint64(resp.ScanPhase), remaining, resp.ScanPhase.Description())
+		default:
+			pt.finish(uint64(resp.ScanPhase), fmt.Sprintf("checker %s", strings.ToLower(resp.Status.String())))
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 type SystemCheckGetPolicyReq struct {
 	unaryRequest
 	msRequest
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2023 Intel Corporation.
+// (C) Copyright 2023-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,10 +8,15 @@ package control
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	chkpb "github.com/daos-stack/daos/src/control/common/proto/chk"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
 )
 
 func TestControl_SystemCheckReport_RepairChoices(t *testing.T) {
@@ -101,3 +106,85 @@ func TestControl_SystemCheckReport_RepairChoices(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_SystemCheckWait(t *testing.T) {
+	queryResp := func(status chkpb.CheckInstStatus, phase chkpb.CheckScanPhase) *UnaryResponse {
+		return MockMSResponse("host1", nil, &mgmtpb.CheckQueryResp{
+			InsStatus: status,
+			InsPhase:  phase,
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		mic        *MockInvokerConfig
+		expErr     error
+		expStatus  SystemCheckStatus
+		expPhase   SystemCheckScanPhase
+		expUpdates int
+	}{
+		"query fails": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("remote failed"),
+		},
+		"already completed": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: queryResp(chkpb.CheckInstStatus_CIS_COMPLETED, chkpb.CheckScanPhase_CSP_DONE),
+			},
+			expStatus:  SystemCheckStatusCompleted,
+			expPhase:   SystemCheckScanPhaseDone,
+			expUpdates: 1,
+		},
+		"runs to completion": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					queryResp(chkpb.CheckInstStatus_CIS_INIT, chkpb.CheckScanPhase_CSP_PREPARE),
+					queryResp(chkpb.CheckInstStatus_CIS_RUNNING, chkpb.CheckScanPhase_CSP_POOL_MBS),
+					queryResp(chkpb.CheckInstStatus_CIS_RUNNING, chkpb.CheckScanPhase_CSP_CONT_LIST),
+					queryResp(chkpb.CheckInstStatus_CIS_COMPLETED, chkpb.CheckScanPhase_CSP_DONE),
+				},
+			},
+			expStatus:  SystemCheckStatusCompleted,
+			expPhase:   SystemCheckScanPhaseDone,
+			expUpdates: 4,
+		},
+		"stops on failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					queryResp(chkpb.CheckInstStatus_CIS_RUNNING, chkpb.CheckScanPhase_CSP_POOL_LIST),
+					queryResp(chkpb.CheckInstStatus_CIS_FAILED, chkpb.CheckScanPhase_CSP_POOL_LIST),
+				},
+			},
+			expStatus:  SystemCheckStatusFailed,
+			expPhase:   SystemCheckScanPhasePoolList,
+			expUpdates: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var updates []*ProgressUpdate
+			req := &SystemCheckWaitReq{PollInterval: time.Millisecond}
+			req.SetProgressFn(func(pu *ProgressUpdate) {
+				updates = append(updates, pu)
+			})
+
+			resp, gotErr := SystemCheckWait(test.Context(t), NewMockInvoker(log, tc.mic), req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expStatus, resp.Status, "unexpected final status")
+			test.AssertEqual(t, tc.expUpdates, len(updates), "unexpected number of updates")
+			for i := 1; i < len(updates); i++ {
+				test.AssertTrue(t, updates[i].Completed >= updates[i-1].Completed, "progress went backwards")
+			}
+			final := updates[len(updates)-1]
+			test.AssertTrue(t, final.Done, "final update not marked done")
+			test.AssertEqual(t, uint64(tc.expPhase), final.Completed, "unexpected final phase")
+		})
+	}
+}
@@ -0,0 +1,86 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServerTimeHeader is the response header in which servers report the time
+// at which they handled a request, in nanoseconds since the epoch.
+const ServerTimeHeader = "daos-server-time"
+
+type (
+	// ClockSample is an estimate of the offset of the clock of a remote
+	// server from the local clock, derived from the server time reported
+	// in the response to a request.
+	ClockSample struct {
+		Addr   string
+		Offset time.Duration // remote clock minus local clock
+		RTT    time.Duration // round-trip time of the request
+	}
+
+	// ClockSampleFn is called with the clock sample derived from each
+	// successful request to a server that reports its time.
+	ClockSampleFn func(*ClockSample)
+)
+
+// WithClockSampleCallback sets a callback to be called with a clock sample
+// for each successful request.
+func WithClockSampleCallback(fn ClockSampleFn) ClientOption {
+	return func(c *Client) {
+		c.clockSampleCb = fn
+	}
+}
+
+// newClockSample derives a clock sample from the server time in the response
+// headers. The server is assumed to have handled the request midway through
+// the round trip, so the error in the offset is bounded by half the RTT.
+func newClockSample(addr string, sent, received time.Time, header metadata.MD) (*ClockSample, error) {
+	vals := header.Get(ServerTimeHeader)
+	if len(vals) == 0 {
+		return nil, errors.Errorf("no %s header", ServerTimeHeader)
+	}
+	nsec, err := strconv.ParseInt(vals[0], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s header %q", ServerTimeHeader, vals[0])
+	}
+
+	rtt := received.Sub(sent)
+	local := sent.Add(rtt / 2)
+
+	return &ClockSample{
+		Addr:   addr,
+		Offset: time.Unix(0, nsec).Sub(local),
+		RTT:    rtt,
+	}, nil
+}
+
+// unaryClockInterceptor passes a clock sample to the callback for each
+// successful request to a server that reports its time in the response
+// headers.
+func unaryClockInterceptor(fn ClockSampleFn) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header metadata.MD
+		sent := time.Now()
+		if err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...); err != nil {
+			return err
+		}
+
+		// Older servers don't report their time.
+		if cs, err := newClockSample(cc.Target(), sent, time.Now(), header); err == nil {
+			fn(cs)
+		}
+		return nil
+	}
+}
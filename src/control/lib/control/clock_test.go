@@ -0,0 +1,77 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestControl_newClockSample(t *testing.T) {
+	sent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	received := sent.Add(100 * time.Millisecond)
+	serverTime := func(d time.Duration) metadata.MD {
+		return metadata.Pairs(ServerTimeHeader, strconv.FormatInt(sent.Add(d).UnixNano(), 10))
+	}
+
+	for name, tc := range map[string]struct {
+		header    metadata.MD
+		expSample *ClockSample
+		expErr    error
+	}{
+		"no header": {
+			header: metadata.MD{},
+			expErr: errors.New("no daos-server-time header"),
+		},
+		"bad header": {
+			header: metadata.Pairs(ServerTimeHeader, "yesterday"),
+			expErr: errors.New("invalid daos-server-time header"),
+		},
+		"in sync": {
+			header: serverTime(50 * time.Millisecond),
+			expSample: &ClockSample{
+				Addr: "host1:10001",
+				RTT:  100 * time.Millisecond,
+			},
+		},
+		"server ahead": {
+			header: serverTime(5 * time.Second),
+			expSample: &ClockSample{
+				Addr:   "host1:10001",
+				Offset: 4950 * time.Millisecond,
+				RTT:    100 * time.Millisecond,
+			},
+		},
+		"server behind": {
+			header: serverTime(-time.Minute),
+			expSample: &ClockSample{
+				Addr:   "host1:10001",
+				Offset: -time.Minute - 50*time.Millisecond,
+				RTT:    100 * time.Millisecond,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotSample, gotErr := newClockSample("host1:10001", sent, received, tc.header)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expSample, gotSample); diff != "" {
+				t.Fatalf("unexpected sample (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2021 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,7 +11,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
+	"time"
 
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // register gzip compressor
 	"gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/build"
@@ -21,15 +25,125 @@ import (
 
 const (
 	defaultConfigFile = "daos_control.yml"
+
+	defaultKeepaliveTime     = 30 * time.Second
+	defaultKeepaliveTimeout  = 10 * time.Second
+	defaultBackoffMaxDelay   = 10 * time.Second
+	defaultMinConnectTimeout = 5 * time.Second
+
+	// MinKeepaliveTime is the shortest client keepalive ping interval
+	// permitted by the server before it closes the connection.
+	MinKeepaliveTime = 10 * time.Second
 )
 
+// ConnectionConfig defines the parameters used to manage the gRPC
+// connections that are maintained to each control API server.
+type ConnectionConfig struct {
+	KeepaliveTime     time.Duration `yaml:"keepalive_time,omitempty"`
+	KeepaliveTimeout  time.Duration `yaml:"keepalive_timeout,omitempty"`
+	BackoffMaxDelay   time.Duration `yaml:"backoff_max_delay,omitempty"`
+	MinConnectTimeout time.Duration `yaml:"min_connect_timeout,omitempty"`
+	Compression       string        `yaml:"compression,omitempty"`
+}
+
+// WithDefaults returns a copy of the connection config with unset
+// parameters replaced by default values.
+func (cc *ConnectionConfig) WithDefaults() *ConnectionConfig {
+	out := new(ConnectionConfig)
+	if cc != nil {
+		*out = *cc
+	}
+
+	if out.KeepaliveTime == 0 {
+		out.KeepaliveTime = defaultKeepaliveTime
+	}
+	if out.KeepaliveTimeout == 0 {
+		out.KeepaliveTimeout = defaultKeepaliveTimeout
+	}
+	if out.BackoffMaxDelay == 0 {
+		out.BackoffMaxDelay = defaultBackoffMaxDelay
+	}
+	if out.MinConnectTimeout == 0 {
+		out.MinConnectTimeout = defaultMinConnectTimeout
+	}
+
+	return out
+}
+
+// Validate returns an error if the connection config is invalid.
+func (cc *ConnectionConfig) Validate() error {
+	if cc == nil {
+		return nil
+	}
+
+	for _, param := range []struct {
+		name string
+		val  time.Duration
+	}{
+		{"keepalive_time", cc.KeepaliveTime},
+		{"keepalive_timeout", cc.KeepaliveTimeout},
+		{"backoff_max_delay", cc.BackoffMaxDelay},
+		{"min_connect_timeout", cc.MinConnectTimeout},
+	} {
+		if param.val < 0 {
+			return fmt.Errorf("invalid connection %s: %s", param.name, param.val)
+		}
+	}
+
+	if cc.KeepaliveTime != 0 && cc.KeepaliveTime < MinKeepaliveTime {
+		return fmt.Errorf("connection keepalive_time %s is less than minimum %s",
+			cc.KeepaliveTime, MinKeepaliveTime)
+	}
+
+	if cc.Compression != "" && encoding.GetCompressor(cc.Compression) == nil {
+		return fmt.Errorf("unsupported connection compression %q", cc.Compression)
+	}
+
+	return nil
+}
+
+// AllCommands is the DefaultFlags key for flags applied to every command.
+const AllCommands = "*"
+
 // Config defines the parameters used to connect to a control API server.
 type Config struct {
 	SystemName      string                    `yaml:"name"`
 	ControlPort     int                       `yaml:"port"`
 	HostList        []string                  `yaml:"hostlist"`
 	TransportConfig *security.TransportConfig `yaml:"transport_config"`
-	Path            string                    `yaml:"-"`
+	Connection      *ConnectionConfig         `yaml:"connection,omitempty"`
+	// Aliases maps a name to the command line that it stands for.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	// DefaultFlags maps a command, e.g. "system query", to the flags that
+	// are added whenever it or one of its subcommands is run.
+	DefaultFlags map[string][]string `yaml:"default_flags,omitempty"`
+	Path         string              `yaml:"-"`
+}
+
+// validateCommandDefaults returns an error if an alias or set of default
+// flags in the config is invalid.
+func (cfg *Config) validateCommandDefaults() error {
+	for name, cmdLine := range cfg.Aliases {
+		if name == "" || strings.ContainsAny(name, " \t") || strings.HasPrefix(name, "-") {
+			return fmt.Errorf("invalid alias name %q", name)
+		}
+		if len(strings.Fields(cmdLine)) == 0 {
+			return fmt.Errorf("alias %q has an empty command", name)
+		}
+	}
+
+	for cmd, cmdFlags := range cfg.DefaultFlags {
+		if len(strings.Fields(cmd)) == 0 {
+			return fmt.Errorf("default flags must be set for a command or %q", AllCommands)
+		}
+		for _, flag := range cmdFlags {
+			if !strings.HasPrefix(flag, "-") {
+				return fmt.Errorf("invalid default flag %q for %q: flags must begin with \"-\"", flag, cmd)
+			}
+		}
+	}
+
+	return nil
 }
 
 // DefaultConfig returns a Config populated with default values. Only
@@ -98,5 +212,13 @@ func LoadConfig(cfgPath string) (*Config, error) {
 		return nil, fmt.Errorf("invalid system name: %q", cfg.SystemName)
 	}
 
+	if err := cfg.Connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateCommandDefaults(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
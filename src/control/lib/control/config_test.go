@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -146,6 +147,34 @@ func TestControl_LoadConfig_BadInputs(t *testing.T) {
 			input:  `hostlist: ['nvm0612-ib0:10001','nvm0611-ib0:10001,'nvm0610-ib0:10001']`,
 			expErr: errors.New("did not find expected"),
 		},
+		"negative connection timeout": {
+			input:  "connection:\n  keepalive_timeout: -1s",
+			expErr: errors.New("invalid connection keepalive_timeout"),
+		},
+		"keepalive time too short": {
+			input:  "connection:\n  keepalive_time: 1s",
+			expErr: errors.New("less than minimum"),
+		},
+		"unsupported compression": {
+			input:  "connection:\n  compression: lz4",
+			expErr: errors.New("unsupported connection compression"),
+		},
+		"alias with spaces": {
+			input:  "aliases:\n  \"my health\": system query",
+			expErr: errors.New("invalid alias name"),
+		},
+		"empty alias": {
+			input:  "aliases:\n  health: \"\"",
+			expErr: errors.New("empty command"),
+		},
+		"default flags without command": {
+			input:  "default_flags:\n  \"\": [--json]",
+			expErr: errors.New("must be set for a command"),
+		},
+		"default flag without dash": {
+			input:  "default_flags:\n  system query: [verbose]",
+			expErr: errors.New("invalid default flag"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			tmpDir, cleanup := test.CreateTestDir(t)
@@ -169,3 +198,64 @@ func TestControl_LoadConfig_BadInputs(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_LoadConfig_Connection(t *testing.T) {
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+	tmpPath := path.Join(tmpDir, defaultConfigFile)
+	input := "connection:\n  keepalive_time: 1m\n  backoff_max_delay: 2s\n  compression: gzip\n"
+	if err := ioutil.WriteFile(tmpPath, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expConnCfg := &ConnectionConfig{
+		KeepaliveTime:     time.Minute,
+		KeepaliveTimeout:  defaultKeepaliveTimeout,
+		BackoffMaxDelay:   2 * time.Second,
+		MinConnectTimeout: defaultMinConnectTimeout,
+		Compression:       "gzip",
+	}
+	if diff := cmp.Diff(expConnCfg, cfg.Connection.WithDefaults()); diff != "" {
+		t.Fatalf("unexpected connection config (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestControl_LoadConfig_CommandDefaults(t *testing.T) {
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+	tmpPath := path.Join(tmpDir, defaultConfigFile)
+	input := `
+aliases:
+  health: system query --verbose
+default_flags:
+  "*": [--json]
+  storage scan: [--host-list=storage[1-8]]
+`
+	if err := ioutil.WriteFile(tmpPath, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expAliases := map[string]string{
+		"health": "system query --verbose",
+	}
+	if diff := cmp.Diff(expAliases, cfg.Aliases); diff != "" {
+		t.Fatalf("unexpected aliases (-want, +got):\n%s\n", diff)
+	}
+	expDefaults := map[string][]string{
+		AllCommands:    {"--json"},
+		"storage scan": {"--host-list=storage[1-8]"},
+	}
+	if diff := cmp.Diff(expDefaults, cfg.DefaultFlags); diff != "" {
+		t.Fatalf("unexpected default flags (-want, +got):\n%s\n", diff)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,6 +11,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
 )
@@ -36,6 +37,11 @@ var (
 		"storage format invoked on a running system",
 		"stop and erase the system, then retry the format operation",
 	)
+	FaultFormatNotArmed = clientFault(
+		code.ClientFormatNotArmed,
+		"storage reformat must be armed while the system is running",
+		"arm the reformat on the management service and retry with the returned token",
+	)
 	FaultConfigVMDImbalance = clientFault(
 		code.ClientConfigVMDImbalance,
 		"different number of backing devices behind each engine's VMD addresses",
@@ -132,6 +138,34 @@ func FaultRpcTimeout(req deadliner) *fault.Fault {
 	)
 }
 
+func FaultIncompatibleComponents(self, other *build.VersionedComponent) *fault.Fault {
+	return clientFault(
+		code.ClientIncompatibleComponents,
+		fmt.Sprintf("components %s and %s are not compatible", self, other),
+		"upgrade or downgrade the components to versions within the supported compatibility window",
+	)
+}
+
+// FaultFeatureNotSupported indicates that the server does not implement the
+// requested method. The server version is empty if the server did not report
+// it, which is the case for versions that predate feature negotiation.
+func FaultFeatureNotSupported(method string, srvVersion string) *fault.Fault {
+	what := fmt.Sprintf("method %s", method)
+	if f, found := build.MethodFeature(method); found {
+		what = fmt.Sprintf("feature %q", f)
+	}
+	by := "server version " + srvVersion
+	if srvVersion == "" {
+		by = "server (version predates feature negotiation)"
+	}
+
+	return clientFault(
+		code.ClientFeatureNotSupported,
+		fmt.Sprintf("%s is not supported by %s", what, by),
+		"upgrade the servers, or use a client version that matches the servers",
+	)
+}
+
 func clientFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "client",
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2021 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,7 +10,8 @@ import "github.com/daos-stack/daos/src/control/common"
 
 // getRequestHosts returns a list of control plane addresses for
 // the request. If the request does not supply its own hostlist,
-// create one from the configuration's hostlist.
+// create one from the configuration's hostlist. Entries sourced from a
+// HostListProvider are expanded before the list is parsed.
 func getRequestHosts(cfg *Config, req targetChooser) (hosts []string, err error) {
 	if len(req.getHostList()) == 0 && len(cfg.HostList) == 0 {
 		return nil, FaultConfigEmptyHostList
@@ -19,17 +20,15 @@ func getRequestHosts(cfg *Config, req targetChooser) (hosts []string, err error)
 		return nil, FaultConfigBadControlPort
 	}
 
-	hosts, err = common.ParseHostList(req.getHostList(), cfg.ControlPort)
-	if err != nil {
-		return nil, err
+	hostList := req.getHostList()
+	if len(hostList) == 0 {
+		hostList = cfg.HostList
 	}
 
-	if len(hosts) == 0 {
-		hosts, err = common.ParseHostList(cfg.HostList, cfg.ControlPort)
-		if err != nil {
-			return nil, err
-		}
+	hostList, err = ExpandHostList(hostList)
+	if err != nil {
+		return nil, err
 	}
 
-	return hosts, nil
+	return common.ParseHostList(hostList, cfg.ControlPort)
 }
@@ -0,0 +1,356 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+)
+
+const (
+	// HostListProviderPrefix marks a hostlist entry that should be expanded
+	// by a HostListProvider, e.g. "@slurm:partition1".
+	HostListProviderPrefix = "@"
+
+	defaultGendersFile = "/etc/genders"
+)
+
+type (
+	// HostListProvider expands a provider-specific host group specification
+	// into a list of hosts.
+	HostListProvider interface {
+		// Name returns the name used to select the provider in a
+		// hostlist entry.
+		Name() string
+		// Expand returns the hosts in the supplied group. The returned
+		// entries may themselves be ranged hostlist strings.
+		Expand(spec string) ([]string, error)
+	}
+
+	hostListProviderRegistry struct {
+		sync.RWMutex
+		providers map[string]HostListProvider
+	}
+
+	cmdRunnerFn func(name string, args ...string) ([]byte, error)
+)
+
+var hostListProviders = &hostListProviderRegistry{
+	providers: make(map[string]HostListProvider),
+}
+
+func init() {
+	for _, p := range []HostListProvider{
+		newSlurmHostListProvider(),
+		newGendersHostListProvider(defaultGendersFile),
+		newAnsibleHostListProvider(),
+	} {
+		if err := RegisterHostListProvider(p); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterHostListProvider makes a HostListProvider available for expanding
+// hostlist entries of the form "@<name>:<spec>".
+func RegisterHostListProvider(p HostListProvider) error {
+	if p == nil {
+		return errors.New("nil HostListProvider")
+	}
+	name := p.Name()
+	if name == "" || strings.ContainsAny(name, HostListProviderPrefix+":,") {
+		return errors.Errorf("invalid host list provider name %q", name)
+	}
+
+	hostListProviders.Lock()
+	defer hostListProviders.Unlock()
+
+	if _, exists := hostListProviders.providers[name]; exists {
+		return errors.Errorf("host list provider %q already registered", name)
+	}
+	hostListProviders.providers[name] = p
+
+	return nil
+}
+
+// HostListProviders returns the names of the registered host list providers.
+func HostListProviders() []string {
+	hostListProviders.RLock()
+	defer hostListProviders.RUnlock()
+
+	return hostListProviders.names()
+}
+
+// names must be called with the registry lock held.
+func (r *hostListProviderRegistry) names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func getHostListProvider(name string) (HostListProvider, error) {
+	hostListProviders.RLock()
+	defer hostListProviders.RUnlock()
+
+	p, found := hostListProviders.providers[name]
+	if !found {
+		return nil, errors.Errorf("unknown host list provider %q (available: %s)",
+			name, strings.Join(hostListProviders.names(), ", "))
+	}
+	return p, nil
+}
+
+// splitHostList splits a comma-separated hostlist string into its entries,
+// leaving commas inside of bracketed ranges intact.
+func splitHostList(in string) []string {
+	var entries []string
+	var depth, start int
+	for i, c := range in {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				entries = append(entries, in[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(entries, in[start:])
+}
+
+// ExpandHostList replaces any hostlist entries of the form "@<provider>:<spec>"
+// with the hosts returned by the named HostListProvider. Other entries are
+// returned unchanged.
+func ExpandHostList(in []string) ([]string, error) {
+	var out []string
+	for _, hl := range in {
+		for _, entry := range splitHostList(hl) {
+			entry = strings.TrimSpace(entry)
+			if !strings.HasPrefix(entry, HostListProviderPrefix) {
+				if entry != "" {
+					out = append(out, entry)
+				}
+				continue
+			}
+
+			fields := strings.SplitN(strings.TrimPrefix(entry, HostListProviderPrefix), ":", 2)
+			if len(fields) != 2 || fields[1] == "" {
+				return nil, errors.Errorf("invalid host list provider entry %q (expected %s<provider>:<spec>)",
+					entry, HostListProviderPrefix)
+			}
+
+			p, err := getHostListProvider(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			hosts, err := p.Expand(fields[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "expanding %q", entry)
+			}
+			if len(hosts) == 0 {
+				return nil, errors.Errorf("%q did not resolve to any hosts", entry)
+			}
+			out = append(out, hosts...)
+		}
+	}
+
+	return out, nil
+}
+
+func runCmd(name string, args ...string) ([]byte, error) {
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, errors.Wrapf(err, "%s: %s", name, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, errors.Wrap(err, name)
+	}
+	return out, nil
+}
+
+func nonEmptyLines(out []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// slurmHostListProvider expands "@slurm:<partition>" to the nodes in the
+// given Slurm partition.
+type slurmHostListProvider struct {
+	run cmdRunnerFn
+}
+
+func newSlurmHostListProvider() *slurmHostListProvider {
+	return &slurmHostListProvider{run: runCmd}
+}
+
+func (p *slurmHostListProvider) Name() string {
+	return "slurm"
+}
+
+func (p *slurmHostListProvider) Expand(partition string) ([]string, error) {
+	// sinfo reports Slurm nodesets in a hostlist format we can consume
+	// directly, so there's no need to expand them here.
+	out, err := p.run("sinfo", "--noheader", "--partition="+partition, "--format=%N")
+	if err != nil {
+		return nil, err
+	}
+	return nonEmptyLines(out), nil
+}
+
+// gendersHostListProvider expands "@genders:<attr>[=<value>]" to the nodes
+// in a genders file that have the given attribute.
+type gendersHostListProvider struct {
+	path string
+}
+
+func newGendersHostListProvider(path string) *gendersHostListProvider {
+	return &gendersHostListProvider{path: path}
+}
+
+func (p *gendersHostListProvider) Name() string {
+	return "genders"
+}
+
+func (p *gendersHostListProvider) Expand(attr string) ([]string, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseGenders(f, attr)
+}
+
+// parseGenders returns the nodes in the genders data that have the given
+// attribute. Each line of the data contains a hostlist followed by a
+// comma-separated list of attributes, optionally with values.
+func parseGenders(r io.Reader, attr string) ([]string, error) {
+	var nodes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		for _, a := range strings.Split(fields[1], ",") {
+			if a == attr || (!strings.Contains(attr, "=") && strings.HasPrefix(a, attr+"=")) {
+				hs, err := hostlist.CreateSet(fields[0])
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid genders nodes %q", fields[0])
+				}
+				nodes = append(nodes, hs.String())
+				break
+			}
+		}
+	}
+
+	return nodes, scanner.Err()
+}
+
+// ansibleHostListProvider expands "@ansible:<group>" to the hosts in the
+// given group of the default Ansible inventory, including the hosts of any
+// child groups.
+type ansibleHostListProvider struct {
+	run cmdRunnerFn
+}
+
+func newAnsibleHostListProvider() *ansibleHostListProvider {
+	return &ansibleHostListProvider{run: runCmd}
+}
+
+func (p *ansibleHostListProvider) Name() string {
+	return "ansible"
+}
+
+func (p *ansibleHostListProvider) Expand(group string) ([]string, error) {
+	out, err := p.run("ansible-inventory", "--list")
+	if err != nil {
+		return nil, err
+	}
+	return parseAnsibleInventory(out, group)
+}
+
+type ansibleGroup struct {
+	Hosts    []string `json:"hosts"`
+	Children []string `json:"children"`
+}
+
+// parseAnsibleInventory returns the hosts in the group from the JSON output
+// of `ansible-inventory --list`.
+func parseAnsibleInventory(data []byte, group string) ([]string, error) {
+	var inventory map[string]json.RawMessage
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		return nil, errors.Wrap(err, "parsing ansible inventory")
+	}
+
+	groups := make(map[string]*ansibleGroup)
+	for name, raw := range inventory {
+		if name == "_meta" {
+			continue
+		}
+		g := new(ansibleGroup)
+		if err := json.Unmarshal(raw, g); err != nil {
+			return nil, errors.Wrapf(err, "parsing ansible inventory group %q", name)
+		}
+		groups[name] = g
+	}
+
+	if _, found := groups[group]; !found {
+		return nil, errors.Errorf("ansible inventory group %q not found", group)
+	}
+
+	var hosts []string
+	seen := make(map[string]struct{})
+	var walk func(string)
+	walk = func(name string) {
+		if _, visited := seen[name]; visited {
+			return
+		}
+		seen[name] = struct{}{}
+
+		g, found := groups[name]
+		if !found {
+			return
+		}
+		hosts = append(hosts, g.Hosts...)
+		for _, child := range g.Children {
+			walk(child)
+		}
+	}
+	walk(group)
+
+	return hosts, nil
+}
@@ -0,0 +1,293 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+type mockHostListProvider struct {
+	name   string
+	groups map[string][]string
+	err    error
+}
+
+func (p *mockHostListProvider) Name() string {
+	return p.name
+}
+
+func (p *mockHostListProvider) Expand(spec string) ([]string, error) {
+	return p.groups[spec], p.err
+}
+
+func withMockHostListProvider(t *testing.T, p HostListProvider) {
+	t.Helper()
+
+	if err := RegisterHostListProvider(p); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		hostListProviders.Lock()
+		delete(hostListProviders.providers, p.Name())
+		hostListProviders.Unlock()
+	})
+}
+
+func TestControl_RegisterHostListProvider(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider HostListProvider
+		expErr   error
+	}{
+		"nil provider": {
+			expErr: errors.New("nil"),
+		},
+		"empty name": {
+			provider: &mockHostListProvider{},
+			expErr:   errors.New("invalid host list provider name"),
+		},
+		"name with separator": {
+			provider: &mockHostListProvider{name: "bad:name"},
+			expErr:   errors.New("invalid host list provider name"),
+		},
+		"duplicate name": {
+			provider: &mockHostListProvider{name: "slurm"},
+			expErr:   errors.New("already registered"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, RegisterHostListProvider(tc.provider))
+		})
+	}
+
+	if diff := cmp.Diff([]string{"ansible", "genders", "slurm"}, HostListProviders()); diff != "" {
+		t.Fatalf("unexpected providers (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestControl_ExpandHostList(t *testing.T) {
+	withMockHostListProvider(t, &mockHostListProvider{
+		name: "mock",
+		groups: map[string][]string{
+			"group1": {"node[1-2]"},
+			"group2": {"node3", "node4"},
+		},
+	})
+	withMockHostListProvider(t, &mockHostListProvider{
+		name: "failing",
+		err:  errors.New("mock failure"),
+	})
+
+	for name, tc := range map[string]struct {
+		in     []string
+		expOut []string
+		expErr error
+	}{
+		"nil list": {},
+		"no provider entries": {
+			in:     []string{"host[1,3],host5", "host7"},
+			expOut: []string{"host[1,3]", "host5", "host7"},
+		},
+		"provider entry": {
+			in:     []string{"@mock:group1"},
+			expOut: []string{"node[1-2]"},
+		},
+		"mixed entries": {
+			in:     []string{"host1,@mock:group2", "@mock:group1"},
+			expOut: []string{"host1", "node3", "node4", "node[1-2]"},
+		},
+		"missing spec": {
+			in:     []string{"@mock"},
+			expErr: errors.New("invalid host list provider entry"),
+		},
+		"unknown provider": {
+			in:     []string{"@unknown:group1"},
+			expErr: errors.New("unknown host list provider \"unknown\""),
+		},
+		"empty group": {
+			in:     []string{"@mock:group3"},
+			expErr: errors.New("did not resolve to any hosts"),
+		},
+		"provider fails": {
+			in:     []string{"@failing:group1"},
+			expErr: errors.New("mock failure"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotOut, gotErr := ExpandHostList(tc.in)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expOut, gotOut); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_slurmHostListProvider(t *testing.T) {
+	for name, tc := range map[string]struct {
+		out    string
+		err    error
+		expOut []string
+		expErr error
+	}{
+		"command fails": {
+			err:    errors.New("sinfo: not found"),
+			expErr: errors.New("not found"),
+		},
+		"multiple nodesets": {
+			out:    "node[01-04]\ngpu[1-2]\n\n",
+			expOut: []string{"node[01-04]", "gpu[1-2]"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := newSlurmHostListProvider()
+			p.run = func(name string, args ...string) ([]byte, error) {
+				test.AssertEqual(t, "sinfo", name, "unexpected command")
+				test.AssertEqual(t, "--partition=partition1", args[1], "unexpected partition arg")
+				return []byte(tc.out), tc.err
+			}
+
+			gotOut, gotErr := p.Expand("partition1")
+			test.CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expOut, gotOut); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_gendersHostListProvider(t *testing.T) {
+	genders := `# cluster genders file
+node[1-4] daos_server,rack=1
+node[5-8]   daos_server,rack=2 # second rack
+login1 login
+node9 rack=2
+`
+	path := filepath.Join(t.TempDir(), "genders")
+	if err := os.WriteFile(path, []byte(genders), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		path   string
+		attr   string
+		expOut []string
+		expErr error
+	}{
+		"missing file": {
+			path:   filepath.Join(t.TempDir(), "missing"),
+			attr:   "daos_server",
+			expErr: errors.New("no such file"),
+		},
+		"attribute": {
+			attr:   "daos_server",
+			expOut: []string{"node[1-4]", "node[5-8]"},
+		},
+		"attribute with any value": {
+			attr:   "rack",
+			expOut: []string{"node[1-4]", "node[5-8]", "node9"},
+		},
+		"attribute with value": {
+			attr:   "rack=2",
+			expOut: []string{"node[5-8]", "node9"},
+		},
+		"no matches": {
+			attr: "compute",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.path == "" {
+				tc.path = path
+			}
+
+			gotOut, gotErr := newGendersHostListProvider(tc.path).Expand(tc.attr)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expOut, gotOut); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_ansibleHostListProvider(t *testing.T) {
+	inventory := `{
+	"_meta": {"hostvars": {}},
+	"all": {"children": ["daos", "ungrouped"]},
+	"daos": {"children": ["daos_servers", "daos_clients"]},
+	"daos_servers": {"hosts": ["server1", "server2"]},
+	"daos_clients": {"hosts": ["client1"], "children": ["daos"]},
+	"ungrouped": {}
+}`
+
+	for name, tc := range map[string]struct {
+		out    string
+		err    error
+		group  string
+		expOut []string
+		expErr error
+	}{
+		"command fails": {
+			err:    errors.New("ansible-inventory: not found"),
+			group:  "daos",
+			expErr: errors.New("not found"),
+		},
+		"bad inventory": {
+			out:    "not json",
+			group:  "daos",
+			expErr: errors.New("parsing ansible inventory"),
+		},
+		"unknown group": {
+			out:    inventory,
+			group:  "missing",
+			expErr: errors.New("group \"missing\" not found"),
+		},
+		"hosts only": {
+			out:    inventory,
+			group:  "daos_servers",
+			expOut: []string{"server1", "server2"},
+		},
+		"nested children": {
+			out:    inventory,
+			group:  "all",
+			expOut: []string{"server1", "server2", "client1"},
+		},
+		"empty group": {
+			out:   inventory,
+			group: "ungrouped",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := newAnsibleHostListProvider()
+			p.run = func(name string, args ...string) ([]byte, error) {
+				test.AssertEqual(t, "ansible-inventory", name, "unexpected command")
+				return []byte(tc.out), tc.err
+			}
+
+			gotOut, gotErr := p.Expand(tc.group)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expOut, gotOut); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_splitHostList(t *testing.T) {
+	got := splitHostList("host[1,3-4],@slurm:p1,host5")
+	if diff := cmp.Diff([]string{"host[1,3-4]", "@slurm:p1", "host5"}, got); diff != "" {
+		t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -38,6 +38,10 @@ func mockHostList(hosts ...string) []string {
 
 func TestControl_getRequestHosts(t *testing.T) {
 	defaultCfg := DefaultConfig()
+	withMockHostListProvider(t, &mockHostListProvider{
+		name:   "mock",
+		groups: map[string][]string{"group1": {"node[1-2]"}},
+	})
 
 	for name, tc := range map[string]struct {
 		cfg    *Config
@@ -72,6 +76,28 @@ func TestControl_getRequestHosts(t *testing.T) {
 			req:    &testTgtChooser{},
 			expErr: FaultConfigEmptyHostList,
 		},
+		"provider entry in request": {
+			cfg: defaultCfg,
+			req: &testTgtChooser{
+				hostList: mockHostList("@mock:group1"),
+			},
+			expOut: mockHostList("node1:10001", "node2:10001"),
+		},
+		"provider entry in config": {
+			cfg: &Config{
+				ControlPort: 42,
+				HostList:    mockHostList("host1,@mock:group1"),
+			},
+			req:    &testTgtChooser{},
+			expOut: mockHostList("host1:42", "node1:42", "node2:42"),
+		},
+		"unknown provider in request": {
+			cfg: defaultCfg,
+			req: &testTgtChooser{
+				hostList: mockHostList("@unknown:group1"),
+			},
+			expErr: errors.New("unknown host list provider"),
+		},
 		"default config; empty req list": {
 			cfg:    defaultCfg,
 			req:    &testTgtChooser{},
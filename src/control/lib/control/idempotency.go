@@ -0,0 +1,60 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotencyKeyHeader is the request header in which clients supply the key
+// identifying a mutating request, so that the MS leader can return the result
+// of the original request to retries rather than performing it again.
+const IdempotencyKeyHeader = "daos-idempotency-key"
+
+type (
+	// idempotencyKeyer defines an interface to be implemented by
+	// requests that may be safely retried by supplying a key that
+	// is unique to the request.
+	idempotencyKeyer interface {
+		getIdempotencyKey() string
+	}
+
+	// idempotentRequest is an embeddable struct to be used by mutating
+	// MS requests whose retries must not be applied more than once.
+	idempotentRequest struct {
+		idempotencyKey string
+	}
+)
+
+// SetIdempotencyKey sets the key sent with the request. Callers that retry a
+// request after a timeout, e.g. from a new process, should supply the same key
+// for each attempt. If unset, a key is generated on first use and is reused
+// for all retries of the request.
+func (r *idempotentRequest) SetIdempotencyKey(key string) {
+	r.idempotencyKey = key
+}
+
+func (r *idempotentRequest) getIdempotencyKey() string {
+	if r.idempotencyKey == "" {
+		r.idempotencyKey = uuid.New().String()
+	}
+	return r.idempotencyKey
+}
+
+// withIdempotencyKey adds the idempotency key for the request, if it has one,
+// to the outgoing metadata of the context.
+func withIdempotencyKey(ctx context.Context, req interface{}) context.Context {
+	ik, ok := req.(idempotencyKeyer)
+	if !ok {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, IdempotencyKeyHeader, ik.getIdempotencyKey())
+}
@@ -0,0 +1,45 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestControl_withIdempotencyKey(t *testing.T) {
+	getKeys := func(t *testing.T, req interface{}) []string {
+		t.Helper()
+
+		ctx := withIdempotencyKey(test.Context(t), req)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return md.Get(IdempotencyKeyHeader)
+	}
+
+	t.Run("not idempotent", func(t *testing.T) {
+		test.AssertEqual(t, 0, len(getKeys(t, new(PoolQueryReq))), "unexpected key")
+	})
+
+	t.Run("generated key reused", func(t *testing.T) {
+		req := new(PoolDestroyReq)
+		first := getKeys(t, req)
+		test.AssertEqual(t, 1, len(first), "expected one key")
+		if first[0] == "" {
+			t.Fatal("expected generated key")
+		}
+		test.AssertEqual(t, first, getKeys(t, req), "expected same key on retry")
+	})
+
+	t.Run("supplied key", func(t *testing.T) {
+		req := new(SystemExcludeReq)
+		req.SetIdempotencyKey("my-key")
+		test.AssertEqual(t, []string{"my-key"}, getKeys(t, req), "unexpected key")
+	})
+}
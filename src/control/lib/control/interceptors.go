@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -12,10 +12,13 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common/proto"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/security"
 )
 
@@ -91,6 +94,52 @@ func unaryVersionedComponentInterceptor(comp build.Component) grpc.UnaryClientIn
 		if err != nil {
 			return err
 		}
+		// Servers advertise their features so that the MS can refuse
+		// joins from servers that lack features required of members.
+		if comp == build.ComponentServer {
+			ctx = build.FeaturesToContext(ctx, build.ServerFeatures())
+		}
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// unaryFeatureInterceptor converts the error returned when the server does
+// not implement the called method into a fault that names the missing feature
+// and the server version, as advertised in the response headers.
+func unaryFeatureInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+
+		var srvVersion string
+		if comp, vcErr := build.FromMetadata(header); vcErr == nil {
+			srvVersion = comp.Version.String()
+		}
+		return FaultFeatureNotSupported(method, srvVersion)
+	}
+}
+
+// unaryTraceInterceptor records a span for each request made as part of a trace
+// and passes the trace context to the server in the request metadata.
+func unaryTraceInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := tracing.StartSpan(ctx, method, tracing.SpanKindClient)
+		if span == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if cc != nil {
+			span.SetAttribute("net.peer.name", cc.Target())
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, tracing.TraceparentHeader,
+			span.SpanContext().Traceparent())
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		span.SetError(err)
+		span.Finish()
+
+		return err
+	}
+}
@@ -0,0 +1,113 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
+)
+
+func TestControl_unaryFeatureInterceptor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method    string
+		invokeErr error
+		header    metadata.MD
+		expErr    error
+	}{
+		"success": {
+			method: "/mgmt.MgmtSvc/JobStatsQuery",
+		},
+		"other error passed through": {
+			method:    "/mgmt.MgmtSvc/JobStatsQuery",
+			invokeErr: status.Error(codes.Unavailable, "unavailable"),
+			expErr:    status.Error(codes.Unavailable, "unavailable"),
+		},
+		"feature not supported by server version": {
+			method:    "/mgmt.MgmtSvc/JobStatsQuery",
+			invokeErr: status.Error(codes.Unimplemented, "unknown method"),
+			header:    build.ComponentMetadata(build.ComponentServer, build.MustNewVersion("2.6.0"), nil),
+			expErr:    FaultFeatureNotSupported("/mgmt.MgmtSvc/JobStatsQuery", "2.6.0"),
+		},
+		"method not supported by server predating negotiation": {
+			method:    "/mgmt.MgmtSvc/FutureMethod",
+			invokeErr: status.Error(codes.Unimplemented, "unknown method"),
+			expErr:    errors.New("method /mgmt.MgmtSvc/FutureMethod is not supported by server (version predates"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invoker := func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+				for _, opt := range opts {
+					if ho, ok := opt.(grpc.HeaderCallOption); ok && tc.header != nil {
+						*ho.HeaderAddr = tc.header
+					}
+				}
+				return tc.invokeErr
+			}
+
+			gotErr := unaryFeatureInterceptor()(test.Context(t), tc.method, nil, nil, nil, invoker)
+			test.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestControl_unaryTraceInterceptor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		traced    bool
+		invokeErr error
+	}{
+		"not traced": {},
+		"traced": {
+			traced: true,
+		},
+		"traced; invoke error": {
+			traced:    true,
+			invokeErr: errors.New("whoops"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := test.Context(t)
+			var root *tracing.Span
+			if tc.traced {
+				ctx, root = tracing.StartTrace(ctx, "test", tracing.SpanKindInternal)
+			}
+
+			var gotMD metadata.MD
+			invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				gotMD, _ = metadata.FromOutgoingContext(ctx)
+				return tc.invokeErr
+			}
+
+			gotErr := unaryTraceInterceptor()(ctx, "/mgmt.MgmtSvc/PoolCreate", nil, nil, nil, invoker)
+			test.CmpErr(t, tc.invokeErr, gotErr)
+
+			vals := gotMD.Get(tracing.TraceparentHeader)
+			if !tc.traced {
+				test.AssertEqual(t, 0, len(vals), "expected no traceparent")
+				return
+			}
+			test.AssertEqual(t, 1, len(vals), "expected traceparent")
+
+			sc, err := tracing.ParseTraceparent(vals[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, root.SpanContext().TraceID, sc.TraceID, "unexpected trace id")
+			test.AssertTrue(t, sc.SpanID != root.SpanContext().SpanID,
+				"expected request span to be a child of the root span")
+		})
+	}
+}
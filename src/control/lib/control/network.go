@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2022 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
@@ -233,9 +234,45 @@ type (
 		MSRanks                 []uint32              `json:"ms_ranks"`
 		ClientNetHint           ClientNetworkHint     `json:"client_net_hint"`
 		AlternateClientNetHints []ClientNetworkHint   `json:"secondary_client_net_hints"`
+		MSVersion               string                `json:"ms_version"`
+		Features                []string              `json:"features"`
 	}
 )
 
+// HasFeature indicates whether the MS advertised support for the given
+// optional feature.
+func (gair *GetAttachInfoResp) HasFeature(feature build.Feature) bool {
+	for _, f := range gair.Features {
+		if f == feature.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCompatibility verifies that the version of the MS that returned the
+// response is compatible with the given component. MS versions that predate
+// version reporting are assumed to enforce compatibility themselves.
+func (gair *GetAttachInfoResp) CheckCompatibility(self *build.VersionedComponent) error {
+	if self == nil {
+		return errors.New("nil component")
+	}
+	// If either version is unknown, there's no checking to be done.
+	if gair.MSVersion == "" || self.Version.IsZero() {
+		return nil
+	}
+
+	ms, err := build.NewVersionedComponent(build.ComponentServer, gair.MSVersion)
+	if err != nil {
+		return errors.Wrapf(err, "invalid MS version %q", gair.MSVersion)
+	}
+
+	if err := build.CheckCompatibility(self, ms); err != nil {
+		return errors.Wrap(FaultIncompatibleComponents(self, ms), err.Error())
+	}
+	return nil
+}
+
 func (gair *GetAttachInfoResp) String() string {
 	// gair.ServiceRanks may contain thousands of elements. Print a few
 	// (just one!) at most to avoid flooding logs.
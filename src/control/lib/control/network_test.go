@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -12,9 +12,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/build"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -355,3 +358,74 @@ func TestControl_GetAttachInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_GetAttachInfoResp_CheckCompatibility(t *testing.T) {
+	agent := func(version string) *build.VersionedComponent {
+		vc, err := build.NewVersionedComponent(build.ComponentAgent, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vc
+	}
+
+	for name, tc := range map[string]struct {
+		self        *build.VersionedComponent
+		msVersion   string
+		expErr      error
+		expIncompat bool
+	}{
+		"nil self": {
+			msVersion: "2.6.0",
+			expErr:    errors.New("nil"),
+		},
+		"MS version not reported": {
+			self: agent("2.6.0"),
+		},
+		"self version unknown": {
+			self:      &build.VersionedComponent{Component: build.ComponentAgent},
+			msVersion: "2.6.0",
+		},
+		"invalid MS version": {
+			self:      agent("2.6.0"),
+			msVersion: "bad",
+			expErr:    errors.New("invalid MS version"),
+		},
+		"same version": {
+			self:      agent("2.6.0"),
+			msVersion: "2.6.0",
+		},
+		"older MS within window": {
+			self:      agent("2.6.0"),
+			msVersion: "2.4.0",
+		},
+		"newer MS within window": {
+			self:      agent("2.4.0"),
+			msVersion: "2.6.0",
+		},
+		"MS outside window": {
+			self:        agent("2.8.0"),
+			msVersion:   "2.4.0",
+			expErr:      errors.New("agent:2.8.0 and server:2.4.0 are not compatible"),
+			expIncompat: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &GetAttachInfoResp{MSVersion: tc.msVersion}
+
+			gotErr := resp.CheckCompatibility(tc.self)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expIncompat {
+				test.AssertTrue(t, fault.IsFaultCode(gotErr, code.ClientIncompatibleComponents),
+					"expected incompatible components fault")
+			}
+		})
+	}
+}
+
+func TestControl_GetAttachInfoResp_HasFeature(t *testing.T) {
+	resp := &GetAttachInfoResp{Features: []string{build.FeatureJobStats.String()}}
+
+	test.AssertTrue(t, resp.HasFeature(build.FeatureJobStats), "expected feature")
+	test.AssertFalse(t, resp.HasFeature(build.FeatureSystemCleanup), "unexpected feature")
+	test.AssertFalse(t, new(GetAttachInfoResp).HasFeature(build.FeatureJobStats), "unexpected feature")
+}
@@ -0,0 +1,307 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// ErrOfflineUnavailable indicates that a request cannot be served from the
+// exported MS state because it requires a live system.
+var ErrOfflineUnavailable = errors.New("not available in offline mode")
+
+type (
+	// MSState is the system membership and pool state exported from a
+	// system, from which a subset of read-only requests can be served
+	// without contacting the system.
+	MSState struct {
+		Path    string                `json:"path"`
+		Index   uint64                `json:"index"`
+		Term    uint64                `json:"term"`
+		Time    time.Time             `json:"time"`
+		Members system.Members        `json:"members,omitempty"`
+		Pools   []*system.PoolService `json:"pools,omitempty"`
+	}
+
+	// OfflineInvoker implements the Invoker interface by serving requests
+	// from exported MS state rather than by invoking RPCs on a live system.
+	// Only the MS requests for system query and pool list are supported.
+	// Requests to other hosts fail with ErrOfflineUnavailable for each
+	// host.
+	OfflineInvoker struct {
+		log   debugLogger
+		cfg   *Config
+		state *MSState
+	}
+)
+
+// msStateFromSnapshot returns the MS state recorded in a system snapshot saved
+// by "dmg system query --save". The snapshot does not record the owners or
+// creation times of the pools.
+func msStateFromSnapshot(path string, ss *SystemSnapshot) *MSState {
+	state := &MSState{
+		Path:    path,
+		Time:    ss.Created,
+		Members: ss.Members,
+	}
+	for _, pi := range ss.Pools {
+		state.Pools = append(state.Pools, &system.PoolService{
+			PoolUUID:  pi.UUID,
+			PoolLabel: pi.Label,
+			State:     system.PoolServiceState(pi.State),
+			Replicas:  pi.ServiceReplicas,
+		})
+	}
+
+	return state
+}
+
+// LoadMSState reads the exported MS state from the file at the given path.
+// The file may contain the output of "daos_server ms inspect --json" or a
+// system snapshot saved by "dmg system query --save".
+func LoadMSState(path string) (*MSState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading MS state")
+	}
+
+	var header struct {
+		Response json.RawMessage `json:"response"`
+		Error    *string         `json:"error"`
+		Version  int             `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, errors.Wrapf(err, "decoding MS state %q", path)
+	}
+
+	if header.Version != 0 {
+		ss, err := ReadSystemSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		return msStateFromSnapshot(path, ss), nil
+	}
+
+	if header.Error != nil {
+		return nil, errors.Errorf("MS state %q records a failed export: %s", path, *header.Error)
+	}
+	if len(header.Response) > 0 {
+		data = header.Response
+	}
+
+	state := new(MSState)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "decoding MS state %q", path)
+	}
+	if state.Index == 0 && len(state.Members) == 0 && len(state.Pools) == 0 {
+		return nil, errors.Errorf("no MS state found in %q", path)
+	}
+
+	return state, nil
+}
+
+// NewOfflineInvoker returns an OfflineInvoker that serves requests from the
+// supplied MS state.
+func NewOfflineInvoker(log debugLogger, state *MSState) *OfflineInvoker {
+	return &OfflineInvoker{
+		log:   log,
+		cfg:   DefaultConfig(),
+		state: state,
+	}
+}
+
+// Debug implements part of the UnaryInvoker interface.
+func (oi *OfflineInvoker) Debug(msg string) {
+	oi.log.Debug(msg)
+}
+
+// Debugf implements part of the UnaryInvoker interface.
+func (oi *OfflineInvoker) Debugf(fmtStr string, args ...interface{}) {
+	oi.log.Debugf(fmtStr, args...)
+}
+
+// GetSystem returns the name of the configured system.
+func (oi *OfflineInvoker) GetSystem() string {
+	return oi.cfg.SystemName
+}
+
+// GetComponent returns the component of the invoker.
+func (oi *OfflineInvoker) GetComponent() build.Component {
+	return build.ComponentAdmin
+}
+
+// SetConfig sets the client configuration.
+func (oi *OfflineInvoker) SetConfig(cfg *Config) {
+	oi.cfg = cfg
+}
+
+// InvokeUnaryRPC serves the request from the MS state.
+func (oi *OfflineInvoker) InvokeUnaryRPC(ctx context.Context, req UnaryRequest) (*UnaryResponse, error) {
+	if !req.isMSRequest() {
+		ur := new(UnaryResponse)
+		for _, host := range req.getHostList() {
+			ur.Responses = append(ur.Responses, &HostResponse{
+				Addr:  host,
+				Error: ErrOfflineUnavailable,
+			})
+		}
+		return ur, nil
+	}
+
+	var msg proto.Message
+	var err error
+	switch req := req.(type) {
+	case *SystemQueryReq:
+		msg, err = oi.systemQuery(req)
+	case *ListPoolsReq:
+		msg, err = oi.listPools(req)
+	default:
+		err = errors.Wrapf(ErrOfflineUnavailable, "%T", req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnaryResponse{
+		fromMS: true,
+		log:    oi.log,
+		Responses: []*HostResponse{
+			{
+				Addr:    oi.state.Path,
+				Message: msg,
+			},
+		},
+	}, nil
+}
+
+// InvokeUnaryRPCAsync serves the request from the MS state and returns the
+// responses on a closed channel.
+func (oi *OfflineInvoker) InvokeUnaryRPCAsync(ctx context.Context, req UnaryRequest) (HostResponseChan, error) {
+	ur, err := oi.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(HostResponseChan, len(ur.Responses))
+	for _, hr := range ur.Responses {
+		responses <- hr
+	}
+	close(responses)
+
+	return responses, nil
+}
+
+// memberMatchesHost returns true if the host, with or without a port, is the
+// name or address of the host the member runs on.
+func memberMatchesHost(m *system.Member, host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == memberHostname(m) {
+		return true
+	}
+	return m.Addr != nil && host == m.Addr.IP.String()
+}
+
+func (oi *OfflineInvoker) systemQuery(req *SystemQueryReq) (*mgmtpb.SystemQueryResp, error) {
+	mask, err := req.getStateMask()
+	if err != nil {
+		return nil, err
+	}
+
+	wantRanks := make(map[ranklist.Rank]bool)
+	for _, rank := range req.Ranks.Ranks() {
+		wantRanks[rank] = true
+	}
+	foundRanks := make(map[ranklist.Rank]bool)
+	foundHosts := make(map[string]bool)
+
+	var members system.Members
+	for _, m := range oi.state.Members {
+		selected := len(wantRanks) == 0 && req.Hosts.Count() == 0
+		if wantRanks[m.Rank] {
+			selected = true
+			foundRanks[m.Rank] = true
+		}
+		for _, host := range req.Hosts.Slice() {
+			if memberMatchesHost(m, host) {
+				selected = true
+				foundHosts[host] = true
+			}
+		}
+		if selected && m.State&mask != 0 {
+			members = append(members, m)
+		}
+	}
+
+	absentRanks := ranklist.NewRankSet()
+	for rank := range wantRanks {
+		if !foundRanks[rank] {
+			absentRanks.Add(rank)
+		}
+	}
+	var absentHosts []string
+	for _, host := range req.Hosts.Slice() {
+		if !foundHosts[host] {
+			absentHosts = append(absentHosts, host)
+		}
+	}
+
+	resp := &mgmtpb.SystemQueryResp{
+		Absentranks: absentRanks.String(),
+		Absenthosts: strings.Join(absentHosts, ","),
+		DataVersion: oi.state.Index,
+	}
+	if err := convert.Types(members, &resp.Members); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (oi *OfflineInvoker) listPools(req *ListPoolsReq) (*mgmtpb.ListPoolsResp, error) {
+	owner := req.Owner
+	if owner != "" && !strings.Contains(owner, "@") {
+		owner += "@"
+	}
+
+	resp := &mgmtpb.ListPoolsResp{
+		DataVersion: oi.state.Index,
+	}
+	for _, ps := range oi.state.Pools {
+		switch {
+		case owner != "" && ps.Owner != owner:
+			continue
+		case !req.CreatedAfter.IsZero() && !ps.CreatedAt.After(req.CreatedAfter):
+			continue
+		case !req.CreatedBefore.IsZero() && !ps.CreatedAt.Before(req.CreatedBefore):
+			continue
+		}
+		resp.Pools = append(resp.Pools, &mgmtpb.ListPoolsResp_Pool{
+			Uuid:    ps.PoolUUID.String(),
+			Label:   ps.PoolLabel,
+			SvcReps: ranklist.RanksToUint32(ps.Replicas),
+			State:   ps.State.String(),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,242 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func mockMSState(t *testing.T) *MSState {
+	return &MSState{
+		Path:  "/tmp/state.json",
+		Index: 42,
+		Term:  2,
+		Time:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Members: system.Members{
+			system.MockMember(t, 1, system.MemberStateJoined),
+			system.MockMember(t, 2, system.MemberStateStopped),
+		},
+		Pools: []*system.PoolService{
+			{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "pool1",
+				Owner:     "alice@",
+				State:     system.PoolServiceStateReady,
+				Replicas:  []ranklist.Rank{1},
+			},
+			{
+				PoolUUID:  test.MockPoolUUID(2),
+				PoolLabel: "pool2",
+				Owner:     "bob@",
+				State:     system.PoolServiceStateReady,
+				Replicas:  []ranklist.Rank{2},
+			},
+		},
+	}
+}
+
+func TestControl_LoadMSState(t *testing.T) {
+	state := mockMSState(t)
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrappedJSON, err := json.Marshal(map[string]interface{}{
+		"response": state,
+		"error":    nil,
+		"status":   0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapJSON, err := json.Marshal(&SystemSnapshot{
+		Version: SystemSnapshotVersion,
+		Created: state.Time,
+		Members: state.Members,
+		Pools: []*daos.PoolInfo{
+			mockSnapshotPool(1, daos.PoolServiceStateReady, 0, 1),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		content    string
+		expMembers int
+		expPools   []string
+		expErr     error
+	}{
+		"bad json": {
+			content: "{",
+			expErr:  errors.New("decoding MS state"),
+		},
+		"empty": {
+			content: "{}",
+			expErr:  errors.New("no MS state found"),
+		},
+		"failed export": {
+			content: `{"response":null,"error":"snapshot not found","status":-1025}`,
+			expErr:  errors.New("failed export: snapshot not found"),
+		},
+		"bare state": {
+			content:    string(stateJSON),
+			expMembers: 2,
+			expPools:   []string{"pool1", "pool2"},
+		},
+		"ms inspect output": {
+			content:    string(wrappedJSON),
+			expMembers: 2,
+			expPools:   []string{"pool1", "pool2"},
+		},
+		"system snapshot": {
+			content:    string(snapJSON),
+			expMembers: 2,
+			expPools:   []string{"pool1"},
+		},
+		"unsupported system snapshot version": {
+			content: `{"version":99}`,
+			expErr:  errors.New("unsupported system snapshot version"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			path := filepath.Join(tmpDir, "state.json")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, gotErr := LoadMSState(path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expMembers, len(got.Members), "unexpected number of members")
+			var gotPools []string
+			for _, ps := range got.Pools {
+				gotPools = append(gotPools, ps.PoolLabel)
+			}
+			if diff := cmp.Diff(tc.expPools, gotPools); diff != "" {
+				t.Fatalf("unexpected pools (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_OfflineInvoker_SystemQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ranks          string
+		hosts          string
+		notOK          bool
+		expRanks       []ranklist.Rank
+		expAbsentRanks string
+		expAbsentHosts string
+	}{
+		"all members": {
+			expRanks: []ranklist.Rank{1, 2},
+		},
+		"not ok": {
+			notOK:    true,
+			expRanks: []ranklist.Rank{2},
+		},
+		"rank filter": {
+			ranks:          "2-3",
+			expRanks:       []ranklist.Rank{2},
+			expAbsentRanks: "3",
+		},
+		"host filter": {
+			hosts:          "127.0.0.1:10001,127.0.0.9",
+			expRanks:       []ranklist.Rank{1},
+			expAbsentHosts: "127.0.0.9",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := &SystemQueryReq{NotOK: tc.notOK}
+			req.Ranks.Replace(ranklist.MustCreateRankSet(tc.ranks))
+			if tc.hosts != "" {
+				if _, err := req.Hosts.Insert(tc.hosts); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			oi := NewOfflineInvoker(log, mockMSState(t))
+			resp, err := SystemQuery(test.Context(t), oi, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotRanks []ranklist.Rank
+			for _, m := range resp.Members {
+				gotRanks = append(gotRanks, m.Rank)
+			}
+			if diff := cmp.Diff(tc.expRanks, gotRanks); diff != "" {
+				t.Fatalf("unexpected members (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, tc.expAbsentRanks, resp.AbsentRanks.String(), "unexpected absent ranks")
+			test.AssertEqual(t, tc.expAbsentHosts, resp.AbsentHosts.String(), "unexpected absent hosts")
+		})
+	}
+}
+
+func TestControl_OfflineInvoker_ListPools(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	oi := NewOfflineInvoker(log, mockMSState(t))
+
+	resp, err := ListPools(test.Context(t), oi, &ListPoolsReq{NoQuery: true, Owner: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, len(resp.Pools), "unexpected number of pools")
+	test.AssertEqual(t, "pool2", resp.Pools[0].Label, "unexpected pool")
+
+	// Pools cannot be queried offline, so each query fails.
+	resp, err = ListPools(test.Context(t), oi, new(ListPoolsReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 2, len(resp.QueryErrors), "unexpected number of query errors")
+	for _, qe := range resp.QueryErrors {
+		test.CmpErr(t, ErrOfflineUnavailable, qe.Error)
+	}
+}
+
+func TestControl_OfflineInvoker_SystemMap(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	oi := NewOfflineInvoker(log, mockMSState(t))
+
+	// The member hosts cannot be contacted for NUMA node details, but
+	// the map is still built from the membership.
+	resp, err := SystemMap(test.Context(t), oi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 2, len(resp.Ranks), "unexpected number of ranks")
+	test.CmpErr(t, ErrOfflineUnavailable, resp.Errors())
+}
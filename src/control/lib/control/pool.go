@@ -202,6 +202,8 @@ type (
 	// PoolCreateReq contains the parameters for a pool create request.
 	PoolCreateReq struct {
 		poolRequest
+		progressRequest
+		idempotentRequest
 		userExt    auth.UserExt
 		User       string
 		UserGroup  string
@@ -330,8 +332,15 @@ func PoolCreate(ctx context.Context, rpcClient UnaryInvoker, req *PoolCreateReq)
 		return mgmtpb.NewMgmtSvcClient(conn).PoolCreate(ctx, pbReq)
 	})
 
+	// The engines don't report the progress of a pool create, so the
+	// best that can be done is to show that it is still running.
+	pt := newProgressTracker(req, "", 0)
+	tickCtx, stopTick := context.WithCancel(ctx)
+	pt.tick(tickCtx, "creating pool")
+
 	rpcClient.Debugf("Create DAOS pool request: %+v\n", pbUtil.Debug(pbReq))
 	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	stopTick()
 	if err != nil {
 		return nil, err
 	}
@@ -343,6 +352,7 @@ func PoolCreate(ctx context.Context, rpcClient UnaryInvoker, req *PoolCreateReq)
 	if pcr.UUID == "" {
 		pcr.UUID = pbReq.Uuid
 	}
+	pt.finish(0, "pool created")
 
 	return pcr, nil
 }
@@ -350,6 +360,7 @@ func PoolCreate(ctx context.Context, rpcClient UnaryInvoker, req *PoolCreateReq)
 // PoolDestroyReq contains the parameters for a pool destroy request.
 type PoolDestroyReq struct {
 	poolRequest
+	idempotentRequest
 	ID        string
 	Recursive bool // Remove pool and any child containers.
 	Force     bool
@@ -710,6 +721,37 @@ func PoolSetProp(ctx context.Context, rpcClient UnaryInvoker, req *PoolSetPropRe
 	return errors.Wrap(ur.getMSError(), "pool set-prop failed")
 }
 
+// PoolRenameReq contains pool rename parameters.
+type PoolRenameReq struct {
+	poolRequest
+	// ID identifies the pool to be renamed.
+	ID string
+	// NewLabel is the label to be applied to the pool.
+	NewLabel string
+}
+
+// PoolRename sends a request to the MS to atomically change the label of
+// the pool. The request fails if the new label is already in use by another
+// pool.
+func PoolRename(ctx context.Context, rpcClient UnaryInvoker, req *PoolRenameReq) error {
+	if req == nil {
+		return errors.Errorf("nil %T in PoolRename()", req)
+	}
+	labelProp, err := daos.PoolProperties().GetProperty("label")
+	if err != nil {
+		return err
+	}
+	if err := labelProp.SetValue(req.NewLabel); err != nil {
+		return err
+	}
+
+	return PoolSetProp(ctx, rpcClient, &PoolSetPropReq{
+		poolRequest: req.poolRequest,
+		ID:          req.ID,
+		Properties:  []*daos.PoolProperty{labelProp},
+	})
+}
+
 // PoolGetPropReq contains pool get-prop parameters.
 type PoolGetPropReq struct {
 	poolRequest
@@ -934,11 +976,81 @@ func PoolReintegrate(ctx context.Context, rpcClient UnaryInvoker, req *PoolReint
 	return errors.Wrap(ur.getMSError(), "pool reintegrate failed")
 }
 
+// rebuildStartPolls is the number of times that a pool is polled for a
+// rebuild to start before the wait is abandoned.
+const rebuildStartPolls = 3
+
+// PoolRebuildWaitReq contains the parameters for a wait on pool rebuild.
+type PoolRebuildWaitReq struct {
+	progressRequest
+	ID           string
+	PollInterval time.Duration
+}
+
+// PoolRebuildWait polls the pool until a rebuild that is running, or that
+// starts within the first few polls, has completed. This allows callers to
+// follow the rebuild triggered by a reintegrate or drain request. The number
+// of objects to be rebuilt is not known in advance, so progress is reported
+// without a total.
+func PoolRebuildWait(ctx context.Context, rpcClient UnaryInvoker, req *PoolRebuildWaitReq) error {
+	if req == nil {
+		return errors.Errorf("nil %T", req)
+	}
+
+	interval := req.PollInterval
+	if interval == 0 {
+		interval = DefaultProgressPollInterval
+	}
+	pt := newProgressTracker(req, "objects", 0)
+
+	var rebuilding bool
+	var objects uint64
+	for polls := 1; ; polls++ {
+		resp, err := PoolQuery(ctx, rpcClient, &PoolQueryReq{
+			ID:        req.ID,
+			QueryMask: daos.HealthOnlyPoolQueryMask,
+		})
+		if err != nil {
+			return err
+		}
+
+		rs := resp.Rebuild
+		if rs == nil {
+			return errors.Errorf("pool %s query returned no rebuild status", req.ID)
+		}
+		if rs.Status != 0 {
+			return errors.Wrapf(daos.Status(rs.Status), "pool %s rebuild failed", req.ID)
+		}
+
+		switch {
+		case rs.State == daos.PoolRebuildStateBusy:
+			rebuilding = true
+			objects = rs.Objects
+			pt.update(objects, 0, fmt.Sprintf("%d records rebuilt", rs.Records))
+		case rebuilding:
+			pt.finish(objects, "rebuild done")
+			return nil
+		case polls >= rebuildStartPolls:
+			pt.finish(objects, "no rebuild running")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 // ListPoolsReq contains the inputs for the list pools command.
 type ListPoolsReq struct {
 	unaryRequest
 	msRequest
-	NoQuery bool
+	NoQuery       bool
+	Owner         string
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
 }
 
 // PoolQueryErr contains the error if a pool query failed.
@@ -1028,6 +1140,18 @@ func newListPoolsResp() *ListPoolsResp {
 // system.
 func ListPools(ctx context.Context, rpcClient UnaryInvoker, req *ListPoolsReq) (*ListPoolsResp, error) {
 	pbReq := &mgmtpb.ListPoolsReq{Sys: req.getSystem(rpcClient)}
+	if req.Owner != "" {
+		pbReq.Owner = req.Owner
+		if !strings.Contains(pbReq.Owner, "@") {
+			pbReq.Owner += "@"
+		}
+	}
+	if !req.CreatedBefore.IsZero() {
+		pbReq.CreatedBefore = req.CreatedBefore.Unix()
+	}
+	if !req.CreatedAfter.IsZero() {
+		pbReq.CreatedAfter = req.CreatedAfter.Unix()
+	}
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).ListPools(ctx, pbReq)
 	})
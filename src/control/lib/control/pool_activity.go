@@ -0,0 +1,94 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	pbUtil "github.com/daos-stack/daos/src/control/common/proto"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type (
+	// PoolActivityReq contains the inputs for the pool activity request.
+	PoolActivityReq struct {
+		unaryRequest
+		msRequest
+
+		// ID is the UUID or label of the pool.
+		ID string
+	}
+
+	// PoolActivityResp contains the activity log of a pool recorded by
+	// the MS, ordered from oldest to newest.
+	PoolActivityResp struct {
+		PoolUUID  uuid.UUID              `json:"uuid"`
+		PoolLabel string                 `json:"label"`
+		Records   []*system.PoolActivity `json:"records"`
+	}
+)
+
+// PoolActivity retrieves the activity log of a pool from the MS.
+func PoolActivity(ctx context.Context, rpcClient UnaryInvoker, req *PoolActivityReq) (*PoolActivityResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.ID == "" {
+		return nil, errors.New("pool ID must be supplied")
+	}
+
+	pbReq := &mgmtpb.PoolActivityReq{
+		Sys: req.getSystem(rpcClient),
+		Id:  req.ID,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).PoolActivity(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS PoolActivity request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "pool activity failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.PoolActivityResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	poolUUID, err := uuid.Parse(pbResp.PoolUuid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pool UUID %q in response", pbResp.PoolUuid)
+	}
+
+	resp := &PoolActivityResp{
+		PoolUUID:  poolUUID,
+		PoolLabel: pbResp.PoolLabel,
+		Records:   make([]*system.PoolActivity, 0, len(pbResp.Records)),
+	}
+	for _, rec := range pbResp.Records {
+		resp.Records = append(resp.Records, &system.PoolActivity{
+			Timestamp: time.Unix(0, rec.Timestamp),
+			Kind:      system.PoolActivityKind(rec.Kind),
+			Msg:       rec.Msg,
+		})
+	}
+
+	return resp, nil
+}
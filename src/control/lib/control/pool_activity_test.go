@@ -0,0 +1,112 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_PoolActivity(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *PoolActivityReq
+		mic     *MockInvokerConfig
+		expResp *PoolActivityResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"missing ID": {
+			req:    &PoolActivityReq{},
+			expErr: errors.New("pool ID must be supplied"),
+		},
+		"req fails": {
+			req: &PoolActivityReq{ID: "pool1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"bad pool UUID": {
+			req: &PoolActivityReq{ID: "pool1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.PoolActivityResp{PoolUuid: "bad"}),
+				},
+			},
+			expErr: errors.New("invalid pool UUID"),
+		},
+		"success": {
+			req: &PoolActivityReq{ID: "pool1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.PoolActivityResp{
+						PoolUuid:  test.MockUUID(1),
+						PoolLabel: "pool1",
+						Records: []*mgmtpb.PoolActivityRecord{
+							{
+								Timestamp: ts.UnixNano(),
+								Kind:      "create",
+								Msg:       "created",
+							},
+							{
+								Timestamp: ts.Add(time.Hour).UnixNano(),
+								Kind:      "exclude",
+								Msg:       "excluded rank 1 (all targets)",
+							},
+						},
+					}),
+				},
+			},
+			expResp: &PoolActivityResp{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "pool1",
+				Records: []*system.PoolActivity{
+					{
+						Timestamp: time.Unix(0, ts.UnixNano()),
+						Kind:      system.PoolActivityCreate,
+						Msg:       "created",
+					},
+					{
+						Timestamp: time.Unix(0, ts.Add(time.Hour).UnixNano()),
+						Kind:      system.PoolActivityExclude,
+						Msg:       "excluded rank 1 (all targets)",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := PoolActivity(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
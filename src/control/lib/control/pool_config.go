@@ -0,0 +1,212 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+// poolConfigExcludedProps are the pool properties that describe the state of
+// a particular pool rather than how it was configured, and are therefore left
+// out of an exported pool configuration.
+var poolConfigExcludedProps = map[string]struct{}{
+	"label":          {},
+	"svc_list":       {},
+	"upgrade_status": {},
+	"global_version": {},
+}
+
+type (
+	// PoolConfig is a portable description of a pool's configuration which
+	// can be used to create new pools with identical settings. The label,
+	// UUID and storage placement of the source pool are not included.
+	PoolConfig struct {
+		User       string            `json:"user,omitempty" yaml:"user,omitempty"`
+		UserGroup  string            `json:"group,omitempty" yaml:"group,omitempty"`
+		ACL        []string          `json:"acl,omitempty" yaml:"acl,omitempty"`
+		TotalBytes uint64            `json:"size,omitempty" yaml:"size,omitempty"`
+		TierRatio  []float64         `json:"tier_ratio,omitempty" yaml:"tier_ratio,omitempty"`
+		Properties map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
+	}
+
+	// PoolExportConfigReq contains the parameters for a pool export-config
+	// request.
+	PoolExportConfigReq struct {
+		poolRequest
+		ID string
+	}
+)
+
+// PoolConfigProperties returns the names of the pool properties that are
+// included in an exported pool configuration.
+func PoolConfigProperties() []string {
+	var names []string
+	for _, name := range daos.PoolProperties().Keys() {
+		if _, excluded := poolConfigExcludedProps[name]; !excluded {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// exportPropValue returns a string representation of the property value that
+// will be parsed back into the same value when the configuration is imported.
+// The human-readable form is preferred, but some values (e.g. sizes) may be
+// rounded for display, in which case the raw number is used.
+func exportPropValue(prop *daos.PoolProperty) string {
+	strVal := prop.StringValue()
+	num, err := prop.Value.GetNumber()
+	if err != nil {
+		return strVal
+	}
+
+	check := daos.PoolProperties()[prop.Name].GetProperty(prop.Name)
+	if err := check.SetValue(strVal); err == nil {
+		if n, err := check.Value.GetNumber(); err == nil && n == num {
+			return strVal
+		}
+	}
+	return strconv.FormatUint(num, 10)
+}
+
+// PoolExportConfig retrieves the properties, ACL and storage configuration of
+// a pool and returns them as a PoolConfig.
+func PoolExportConfig(ctx context.Context, rpcClient UnaryInvoker, req *PoolExportConfigReq) (*PoolConfig, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	queryReq := &PoolQueryReq{
+		ID:        req.ID,
+		QueryMask: daos.DefaultPoolQueryMask,
+	}
+	queryReq.SetSystem(req.Sys)
+	queryResp, err := PoolQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "pool query failed")
+	}
+	if queryResp.Status != 0 {
+		return nil, errors.Wrap(daos.Status(queryResp.Status), "pool query failed")
+	}
+
+	propsReq := &PoolGetPropReq{ID: req.ID}
+	propsReq.SetSystem(req.Sys)
+	for _, name := range PoolConfigProperties() {
+		propsReq.Properties = append(propsReq.Properties,
+			daos.PoolProperties()[name].GetProperty(name))
+	}
+	props, err := PoolGetProp(ctx, rpcClient, propsReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "pool get-prop failed")
+	}
+
+	aclReq := &PoolGetACLReq{ID: req.ID}
+	aclReq.SetSystem(req.Sys)
+	aclResp, err := PoolGetACL(ctx, rpcClient, aclReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "pool get-acl failed")
+	}
+
+	cfg := &PoolConfig{
+		User:      aclResp.ACL.Owner,
+		UserGroup: aclResp.ACL.OwnerGroup,
+		ACL:       aclResp.ACL.Entries,
+	}
+
+	for _, prop := range props {
+		if !prop.Value.IsSet() {
+			continue
+		}
+		if cfg.Properties == nil {
+			cfg.Properties = make(map[string]string)
+		}
+		cfg.Properties[prop.Name] = exportPropValue(prop)
+	}
+
+	for _, tier := range queryResp.TierStats {
+		cfg.TotalBytes += tier.Total
+	}
+	if cfg.TotalBytes > 0 {
+		for _, tier := range queryResp.TierStats {
+			ratio := float64(tier.Total) / float64(cfg.TotalBytes)
+			cfg.TierRatio = append(cfg.TierRatio, math.Round(ratio*10000)/10000)
+		}
+	}
+
+	return cfg, nil
+}
+
+// PoolProperties returns the configured pool properties in a form suitable
+// for use in a PoolCreateReq.
+func (pc *PoolConfig) PoolProperties() ([]*daos.PoolProperty, error) {
+	if pc == nil {
+		return nil, errors.New("nil PoolConfig")
+	}
+
+	propHdlrs := daos.PoolProperties()
+	var props []*daos.PoolProperty
+	for _, name := range propHdlrs.Keys() {
+		val, found := pc.Properties[name]
+		if !found {
+			continue
+		}
+		if _, excluded := poolConfigExcludedProps[name]; excluded {
+			return nil, errors.Errorf("pool property %q may not be set from a pool configuration", name)
+		}
+
+		prop := propHdlrs[name].GetProperty(name)
+		if err := prop.SetValue(val); err != nil {
+			return nil, err
+		}
+		props = append(props, prop)
+	}
+
+	for name := range pc.Properties {
+		if _, found := propHdlrs[name]; !found {
+			return nil, errors.Errorf("unknown pool property %q in pool configuration", name)
+		}
+	}
+
+	return props, nil
+}
+
+// ReadPoolConfigFile reads a pool configuration written by `dmg pool
+// export-config`. Both the YAML and JSON forms of the output are accepted.
+func ReadPoolConfigFile(path string) (*PoolConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pool configuration file")
+	}
+
+	cfg := new(PoolConfig)
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errors.Wrapf(err, "parsing pool configuration file %q", path)
+	}
+
+	if len(cfg.TierRatio) > 0 {
+		var total float64
+		for _, ratio := range cfg.TierRatio {
+			if ratio < 0 || ratio > 1 {
+				return nil, errors.Errorf("invalid tier ratio %f in pool configuration (valid: 0-1)", ratio)
+			}
+			total += ratio
+		}
+		if math.Abs(total-1) > 0.01 {
+			return nil, errors.Errorf("tier ratios in pool configuration must add up to 1 (got %f)", total)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,265 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_PoolExportConfig(t *testing.T) {
+	queryResp := &mgmtpb.PoolQueryResp{
+		Uuid:         test.MockUUID(),
+		TotalTargets: 8,
+		TierStats: []*mgmtpb.StorageUsageStats{
+			{Total: 6000000000},
+			{Total: 94000000000},
+		},
+	}
+	propResp := &mgmtpb.PoolGetPropResp{
+		Properties: []*mgmtpb.PoolProperty{
+			{
+				Number: propWithVal("reclaim", "").Number,
+				Value:  &mgmtpb.PoolProperty_Numval{Numval: daos.PoolSpaceReclaimLazy},
+			},
+			{
+				Number: propWithVal("space_rb", "").Number,
+				Value:  &mgmtpb.PoolProperty_Numval{Numval: 10},
+			},
+			{
+				Number: propWithVal("ec_cell_sz", "").Number,
+				Value:  &mgmtpb.PoolProperty_Numval{Numval: 1048576},
+			},
+			{
+				Number: propWithVal("data_thresh", "").Number,
+				Value:  &mgmtpb.PoolProperty_Numval{Numval: 4100},
+			},
+			{
+				Number: propWithVal("svc_rf", "").Number,
+				Value:  &mgmtpb.PoolProperty_Numval{Numval: 2},
+			},
+		},
+	}
+	aclResp := &mgmtpb.ACLResp{
+		Acl: &mgmtpb.AccessControlList{
+			OwnerUser:  MockACL.Owner,
+			OwnerGroup: MockACL.OwnerGroup,
+			Entries:    MockACL.Entries,
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *PoolExportConfigReq
+		expResp *PoolConfig
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"query fails": {
+			req: &PoolExportConfigReq{ID: test.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("pool query failed: remote failed"),
+		},
+		"get-acl fails": {
+			req: &PoolExportConfigReq{ID: test.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("host1", nil, queryResp),
+					MockMSResponse("host1", nil, propResp),
+					MockMSResponse("host1", errors.New("remote failed"), nil),
+				},
+			},
+			expErr: errors.New("pool get-acl failed: remote failed"),
+		},
+		"success": {
+			req: &PoolExportConfigReq{ID: test.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("host1", nil, queryResp),
+					MockMSResponse("host1", nil, propResp),
+					MockMSResponse("host1", nil, aclResp),
+				},
+			},
+			expResp: &PoolConfig{
+				User:       MockACL.Owner,
+				UserGroup:  MockACL.OwnerGroup,
+				ACL:        MockACL.Entries,
+				TotalBytes: 100000000000,
+				TierRatio:  []float64{0.06, 0.94},
+				Properties: map[string]string{
+					"reclaim":     "lazy",
+					"space_rb":    "10%",
+					"ec_cell_sz":  "1.0 MiB",
+					"data_thresh": "4100",
+					"svc_rf":      "2",
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			gotResp, gotErr := PoolExportConfig(test.Context(t), NewMockInvoker(log, mic), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected pool config (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_PoolConfig_PoolProperties(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg      *PoolConfig
+		expProps []*daos.PoolProperty
+		expErr   error
+	}{
+		"nil config": {
+			expErr: errors.New("nil"),
+		},
+		"no properties": {
+			cfg: &PoolConfig{},
+		},
+		"unknown property": {
+			cfg: &PoolConfig{
+				Properties: map[string]string{"foo": "bar"},
+			},
+			expErr: errors.New("unknown pool property \"foo\""),
+		},
+		"excluded property": {
+			cfg: &PoolConfig{
+				Properties: map[string]string{"label": "foo"},
+			},
+			expErr: errors.New("may not be set"),
+		},
+		"invalid value": {
+			cfg: &PoolConfig{
+				Properties: map[string]string{"reclaim": "sometimes"},
+			},
+			expErr: errors.New("invalid value"),
+		},
+		"properties": {
+			cfg: &PoolConfig{
+				Properties: map[string]string{
+					"space_rb": "10%",
+					"reclaim":  "lazy",
+				},
+			},
+			expProps: []*daos.PoolProperty{
+				propWithVal("reclaim", "lazy"),
+				propWithVal("space_rb", "10"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotProps, gotErr := tc.cfg.PoolProperties()
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(tc.expProps), len(gotProps), "unexpected number of properties")
+			for i, exp := range tc.expProps {
+				test.AssertEqual(t, exp.String(), gotProps[i].String(), "unexpected property")
+			}
+		})
+	}
+}
+
+func TestControl_ReadPoolConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	expCfg := &PoolConfig{
+		User:       "owner@",
+		UserGroup:  "group@",
+		ACL:        []string{"A::OWNER@:rw"},
+		TotalBytes: 1 << 30,
+		TierRatio:  []float64{0.06, 0.94},
+		Properties: map[string]string{"reclaim": "lazy"},
+	}
+
+	for name, tc := range map[string]struct {
+		path   string
+		expCfg *PoolConfig
+		expErr error
+	}{
+		"missing file": {
+			path:   filepath.Join(dir, "missing"),
+			expErr: errors.New("no such file"),
+		},
+		"unknown field": {
+			path:   writeFile("unknown.yml", "nsvc: 3\n"),
+			expErr: errors.New("field nsvc not found"),
+		},
+		"bad tier ratio": {
+			path:   writeFile("bad_ratio.yml", "tier_ratio: [0.5, 0.6]\n"),
+			expErr: errors.New("must add up to 1"),
+		},
+		"yaml": {
+			path: writeFile("pool.yml", `user: owner@
+group: group@
+acl:
+- A::OWNER@:rw
+size: 1073741824
+tier_ratio:
+- 0.06
+- 0.94
+properties:
+  reclaim: lazy
+`),
+			expCfg: expCfg,
+		},
+		"json": {
+			path: writeFile("pool.json", `{"user":"owner@","group":"group@",`+
+				`"acl":["A::OWNER@:rw"],"size":1073741824,"tier_ratio":[0.06,0.94],`+
+				`"properties":{"reclaim":"lazy"}}`),
+			expCfg: expCfg,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotCfg, gotErr := ReadPoolConfigFile(tc.path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCfg, gotCfg); diff != "" {
+				t.Fatalf("unexpected pool config (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
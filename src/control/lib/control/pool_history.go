@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// maxPoolFullProjection is the furthest into the future that a pool tier
+// may be projected to become full.
+const maxPoolFullProjection = 10 * 365 * 24 * time.Hour
+
+type (
+	// PoolUsageHistoryReq contains the inputs for the pool usage history request.
+	PoolUsageHistoryReq struct {
+		// ID is the UUID or label of the pool.
+		ID string
+		// MaxEntries limits the history to the most recent health
+		// snapshots. If zero, the full history is used.
+		MaxEntries uint32
+	}
+
+	// PoolUsageSample is the capacity of each tier of a pool at the time of
+	// a health snapshot.
+	PoolUsageSample struct {
+		Timestamp time.Time               `json:"timestamp"`
+		Tiers     []*system.PoolTierUsage `json:"tiers"`
+	}
+
+	// PoolTierTrend describes the growth in the used capacity of a pool
+	// tier over the sampled history.
+	PoolTierTrend struct {
+		MediaType  string `json:"media_type"`
+		TotalBytes uint64 `json:"total_bytes"`
+		UsedBytes  uint64 `json:"used_bytes"`
+		// GrowthPerDay is the rate of change of used capacity, in bytes
+		// per day. It is negative if usage is shrinking.
+		GrowthPerDay float64 `json:"growth_per_day"`
+		// ProjectedFull is the time at which the tier is projected to
+		// be full. It is not set if usage is not growing.
+		ProjectedFull *time.Time `json:"projected_full,omitempty"`
+	}
+
+	// PoolUsageHistoryResp contains the sampled usage history of a pool,
+	// ordered from oldest to newest, and the trend for each tier.
+	PoolUsageHistoryResp struct {
+		PoolUUID  uuid.UUID          `json:"uuid"`
+		PoolLabel string             `json:"label"`
+		Samples   []*PoolUsageSample `json:"samples"`
+		Trends    []*PoolTierTrend   `json:"trends"`
+	}
+)
+
+// PoolUsageHistory retrieves the space usage of a pool from the health
+// snapshots periodically recorded by the MS leader, and calculates the
+// growth trend and projected full time of each tier.
+func PoolUsageHistory(ctx context.Context, rpcClient UnaryInvoker, req *PoolUsageHistoryReq) (*PoolUsageHistoryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.ID == "" {
+		return nil, errors.New("pool ID must be supplied")
+	}
+
+	histResp, err := SystemHistory(ctx, rpcClient, &SystemHistoryReq{MaxEntries: req.MaxEntries})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPoolUsageHistory(req.ID, histResp.Snapshots)
+}
+
+// newPoolUsageHistory extracts the usage history of the identified pool from
+// the supplied health snapshots. The label is only used to find the pool's
+// UUID in the most recent snapshot, so that a label change doesn't truncate
+// the history.
+func newPoolUsageHistory(id string, snaps []*system.HealthSnapshot) (*PoolUsageHistoryResp, error) {
+	var resp *PoolUsageHistoryResp
+	for i := len(snaps) - 1; i >= 0 && resp == nil; i-- {
+		if ps := snaps[i].FindPool(id); ps != nil {
+			resp = &PoolUsageHistoryResp{
+				PoolUUID:  ps.PoolUUID,
+				PoolLabel: ps.PoolLabel,
+			}
+		}
+	}
+	if resp == nil {
+		return nil, errors.Errorf("no usage history recorded for pool %s", id)
+	}
+
+	for _, hs := range snaps {
+		ps := hs.FindPool(resp.PoolUUID.String())
+		if ps == nil || ps.QueryError != "" || len(ps.Tiers) == 0 {
+			continue
+		}
+		resp.Samples = append(resp.Samples, &PoolUsageSample{
+			Timestamp: hs.Timestamp,
+			Tiers:     ps.Tiers,
+		})
+	}
+	if len(resp.Samples) == 0 {
+		return nil, errors.Errorf("no usage history recorded for pool %s", id)
+	}
+
+	latest := resp.Samples[len(resp.Samples)-1]
+	for _, tier := range latest.Tiers {
+		resp.Trends = append(resp.Trends, calcTierTrend(tier.MediaType, resp.Samples))
+	}
+
+	return resp, nil
+}
+
+// calcTierTrend calculates the trend for the given tier using a least-squares
+// fit of the used capacity over time.
+func calcTierTrend(mediaType string, samples []*PoolUsageSample) *PoolTierTrend {
+	trend := new(PoolTierTrend)
+	trend.MediaType = mediaType
+
+	var xs, ys []float64
+	var lastTime time.Time
+	var lastFree uint64
+	for _, s := range samples {
+		for _, tier := range s.Tiers {
+			if tier.MediaType != mediaType {
+				continue
+			}
+			used := tier.TotalBytes - tier.FreeBytes
+			if tier.FreeBytes > tier.TotalBytes {
+				used = 0
+			}
+			xs = append(xs, s.Timestamp.Sub(samples[0].Timestamp).Seconds())
+			ys = append(ys, float64(used))
+
+			trend.TotalBytes = tier.TotalBytes
+			trend.UsedBytes = used
+			lastTime = s.Timestamp
+			lastFree = tier.FreeBytes
+		}
+	}
+	if len(xs) < 2 {
+		return trend
+	}
+
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return trend
+	}
+	perSec := (n*sumXY - sumX*sumY) / denom
+	trend.GrowthPerDay = perSec * (24 * time.Hour).Seconds()
+
+	if perSec <= 0 {
+		return trend
+	}
+	// Projections too far into the future are meaningless, and could
+	// overflow a time.Duration.
+	remaining := float64(lastFree) / perSec
+	if remaining > maxPoolFullProjection.Seconds() {
+		return trend
+	}
+	full := lastTime.Add(time.Duration(remaining * float64(time.Second)))
+	trend.ProjectedFull = &full
+
+	return trend
+}
@@ -0,0 +1,198 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_PoolUsageHistory(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tiers := func(scmFree, nvmeFree uint64) []*mgmtpb.SystemHealthSnapshot_TierUsage {
+		return []*mgmtpb.SystemHealthSnapshot_TierUsage{
+			{MediaType: "scm", TotalBytes: 1000, FreeBytes: scmFree},
+			{MediaType: "nvme", TotalBytes: 10000, FreeBytes: nvmeFree},
+		}
+	}
+	snapshot := func(at time.Time, pools ...*mgmtpb.SystemHealthSnapshot_PoolSummary) *mgmtpb.SystemHealthSnapshot {
+		return &mgmtpb.SystemHealthSnapshot{
+			Timestamp: at.UnixNano(),
+			Pools:     pools,
+		}
+	}
+	projected := func(at time.Time) *time.Time {
+		return &at
+	}
+
+	for name, tc := range map[string]struct {
+		req     *PoolUsageHistoryReq
+		mic     *MockInvokerConfig
+		expResp *PoolUsageHistoryResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"missing ID": {
+			req:    &PoolUsageHistoryReq{},
+			expErr: errors.New("pool ID must be supplied"),
+		},
+		"req fails": {
+			req: &PoolUsageHistoryReq{ID: "pool1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"unknown pool": {
+			req: &PoolUsageHistoryReq{ID: "pool2"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+						Snapshots: []*mgmtpb.SystemHealthSnapshot{
+							snapshot(ts, &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:  test.MockUUID(1),
+								Label: "pool1",
+								Tiers: tiers(1000, 10000),
+							}),
+						},
+					}),
+				},
+			},
+			expErr: errors.New("no usage history recorded for pool pool2"),
+		},
+		"single sample": {
+			req: &PoolUsageHistoryReq{ID: "pool1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+						Snapshots: []*mgmtpb.SystemHealthSnapshot{
+							snapshot(ts, &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:  test.MockUUID(1),
+								Label: "pool1",
+								Tiers: tiers(900, 9000),
+							}),
+						},
+					}),
+				},
+			},
+			expResp: &PoolUsageHistoryResp{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "pool1",
+				Samples: []*PoolUsageSample{
+					{
+						Timestamp: ts,
+						Tiers: []*system.PoolTierUsage{
+							{MediaType: "scm", TotalBytes: 1000, FreeBytes: 900},
+							{MediaType: "nvme", TotalBytes: 10000, FreeBytes: 9000},
+						},
+					},
+				},
+				Trends: []*PoolTierTrend{
+					{MediaType: "scm", TotalBytes: 1000, UsedBytes: 100},
+					{MediaType: "nvme", TotalBytes: 10000, UsedBytes: 1000},
+				},
+			},
+		},
+		"growth; relabeled pool and failed query skipped": {
+			req: &PoolUsageHistoryReq{ID: "newlabel"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+						Snapshots: []*mgmtpb.SystemHealthSnapshot{
+							snapshot(ts, &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:  test.MockUUID(1),
+								Label: "oldlabel",
+								Tiers: tiers(900, 9000),
+							}, &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:  test.MockUUID(2),
+								Label: "other",
+								Tiers: tiers(0, 0),
+							}),
+							snapshot(ts.Add(day), &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:       test.MockUUID(1),
+								Label:      "oldlabel",
+								QueryError: "timed out",
+							}),
+							snapshot(ts.Add(2*day), &mgmtpb.SystemHealthSnapshot_PoolSummary{
+								Uuid:  test.MockUUID(1),
+								Label: "newlabel",
+								Tiers: tiers(700, 9500),
+							}),
+						},
+					}),
+				},
+			},
+			expResp: &PoolUsageHistoryResp{
+				PoolUUID:  test.MockPoolUUID(1),
+				PoolLabel: "newlabel",
+				Samples: []*PoolUsageSample{
+					{
+						Timestamp: ts,
+						Tiers: []*system.PoolTierUsage{
+							{MediaType: "scm", TotalBytes: 1000, FreeBytes: 900},
+							{MediaType: "nvme", TotalBytes: 10000, FreeBytes: 9000},
+						},
+					},
+					{
+						Timestamp: ts.Add(2 * day),
+						Tiers: []*system.PoolTierUsage{
+							{MediaType: "scm", TotalBytes: 1000, FreeBytes: 700},
+							{MediaType: "nvme", TotalBytes: 10000, FreeBytes: 9500},
+						},
+					},
+				},
+				Trends: []*PoolTierTrend{
+					{
+						MediaType:     "scm",
+						TotalBytes:    1000,
+						UsedBytes:     300,
+						GrowthPerDay:  100,
+						ProjectedFull: projected(ts.Add(9 * day)),
+					},
+					{
+						MediaType:    "nvme",
+						TotalBytes:   10000,
+						UsedBytes:    500,
+						GrowthPerDay: -250,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := PoolUsageHistory(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{
+				cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) }),
+			}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
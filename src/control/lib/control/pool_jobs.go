@@ -0,0 +1,109 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	pbUtil "github.com/daos-stack/daos/src/control/common/proto"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+type (
+	// PoolOpJobsReq contains the inputs for the pool operation jobs request.
+	PoolOpJobsReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// PoolOpJob describes a rebuild-generating pool operation that is
+	// queued or running under the control of the MS pool operation
+	// scheduler.
+	PoolOpJob struct {
+		ID        uint64          `json:"id"`
+		Kind      string          `json:"kind"`
+		PoolUUID  string          `json:"pool_uuid"`
+		PoolLabel string          `json:"pool_label"`
+		Ranks     []ranklist.Rank `json:"ranks"`
+		Priority  int             `json:"priority"`
+		State     string          `json:"state"`
+		Submitted time.Time       `json:"submitted"`
+		Started   time.Time       `json:"started"`
+	}
+
+	// PoolOpJobsResp contains the limits of the pool operation scheduler
+	// and the operations that it holds, running operations first followed
+	// by queued operations in the order that they will be started.
+	PoolOpJobsResp struct {
+		Enabled       bool         `json:"enabled"`
+		MaxConcurrent uint32       `json:"max_concurrent"`
+		MaxPerRank    uint32       `json:"max_per_rank"`
+		Jobs          []*PoolOpJob `json:"jobs"`
+	}
+)
+
+// PoolOpJobs retrieves the pool operations queued or running under the
+// control of the MS pool operation scheduler.
+func PoolOpJobs(ctx context.Context, rpcClient UnaryInvoker, req *PoolOpJobsReq) (*PoolOpJobsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.PoolOpJobsReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).PoolOpJobs(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS PoolOpJobs request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "pool operation jobs query failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.PoolOpJobsResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	resp := &PoolOpJobsResp{
+		Enabled:       pbResp.Enabled,
+		MaxConcurrent: pbResp.MaxConcurrent,
+		MaxPerRank:    pbResp.MaxPerRank,
+		Jobs:          make([]*PoolOpJob, 0, len(pbResp.Jobs)),
+	}
+	for _, pbJob := range pbResp.Jobs {
+		job := &PoolOpJob{
+			ID:        pbJob.Id,
+			Kind:      pbJob.Kind,
+			PoolUUID:  pbJob.PoolUuid,
+			PoolLabel: pbJob.PoolLabel,
+			Ranks:     ranklist.RanksFromUint32(pbJob.Ranks),
+			Priority:  int(pbJob.Priority),
+			State:     pbJob.State,
+			Submitted: time.Unix(0, pbJob.Submitted),
+		}
+		if pbJob.Started != 0 {
+			job.Started = time.Unix(0, pbJob.Started)
+		}
+		resp.Jobs = append(resp.Jobs, job)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_PoolOpJobs(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *PoolOpJobsReq
+		mic     *MockInvokerConfig
+		expResp *PoolOpJobsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &PoolOpJobsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"scheduler disabled": {
+			req: &PoolOpJobsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.PoolOpJobsResp{}),
+				},
+			},
+			expResp: &PoolOpJobsResp{Jobs: []*PoolOpJob{}},
+		},
+		"success": {
+			req: &PoolOpJobsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.PoolOpJobsResp{
+						Enabled:       true,
+						MaxConcurrent: 1,
+						MaxPerRank:    1,
+						Jobs: []*mgmtpb.PoolOpJob{
+							{
+								Id:        1,
+								Kind:      "reintegrate",
+								PoolUuid:  test.MockUUID(1),
+								PoolLabel: "pool1",
+								Ranks:     []uint32{2},
+								Priority:  2,
+								State:     "rebuilding",
+								Submitted: ts.UnixNano(),
+								Started:   ts.Add(time.Second).UnixNano(),
+							},
+							{
+								Id:        2,
+								Kind:      "extend",
+								PoolUuid:  test.MockUUID(2),
+								PoolLabel: "pool2",
+								Ranks:     []uint32{4, 5},
+								State:     "queued",
+								Submitted: ts.Add(time.Minute).UnixNano(),
+							},
+						},
+					}),
+				},
+			},
+			expResp: &PoolOpJobsResp{
+				Enabled:       true,
+				MaxConcurrent: 1,
+				MaxPerRank:    1,
+				Jobs: []*PoolOpJob{
+					{
+						ID:        1,
+						Kind:      "reintegrate",
+						PoolUUID:  test.MockUUID(1),
+						PoolLabel: "pool1",
+						Ranks:     []ranklist.Rank{2},
+						Priority:  2,
+						State:     "rebuilding",
+						Submitted: time.Unix(0, ts.UnixNano()),
+						Started:   time.Unix(0, ts.Add(time.Second).UnixNano()),
+					},
+					{
+						ID:        2,
+						Kind:      "extend",
+						PoolUUID:  test.MockUUID(2),
+						PoolLabel: "pool2",
+						Ranks:     []ranklist.Rank{4, 5},
+						State:     "queued",
+						Submitted: time.Unix(0, ts.Add(time.Minute).UnixNano()),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := PoolOpJobs(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
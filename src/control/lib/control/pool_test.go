@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -1202,6 +1203,95 @@ func propWithVal(key, val string) *daos.PoolProperty {
 	return prop
 }
 
+func TestControl_PoolRebuildWait(t *testing.T) {
+	rebuildResp := func(state mgmtpb.PoolRebuildStatus_State, status int32, objs uint64) *UnaryResponse {
+		return MockMSResponse("host1", nil, &mgmtpb.PoolQueryResp{
+			Uuid: test.MockUUID(),
+			Rebuild: &mgmtpb.PoolRebuildStatus{
+				State:   state,
+				Status:  status,
+				Objects: objs,
+			},
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		mic         *MockInvokerConfig
+		expErr      error
+		expUpdates  int
+		expObjects  uint64
+		expFinalMsg string
+	}{
+		"query fails": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("remote failed"),
+		},
+		"no rebuild status": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, &mgmtpb.PoolQueryResp{}),
+			},
+			expErr: errors.New("no rebuild status"),
+		},
+		"rebuild failed": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					rebuildResp(mgmtpb.PoolRebuildStatus_BUSY, 0, 1),
+					rebuildResp(mgmtpb.PoolRebuildStatus_DONE, int32(daos.NoSpace), 1),
+				},
+			},
+			expErr: daos.NoSpace,
+		},
+		"rebuild never starts": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: rebuildResp(mgmtpb.PoolRebuildStatus_IDLE, 0, 0),
+			},
+			expUpdates:  1,
+			expFinalMsg: "no rebuild running",
+		},
+		"rebuild completes": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					rebuildResp(mgmtpb.PoolRebuildStatus_IDLE, 0, 0),
+					rebuildResp(mgmtpb.PoolRebuildStatus_BUSY, 0, 10),
+					rebuildResp(mgmtpb.PoolRebuildStatus_BUSY, 0, 20),
+					rebuildResp(mgmtpb.PoolRebuildStatus_DONE, 0, 30),
+				},
+			},
+			expUpdates:  3,
+			expObjects:  20,
+			expFinalMsg: "rebuild done",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var updates []*ProgressUpdate
+			req := &PoolRebuildWaitReq{
+				ID:           test.MockUUID(),
+				PollInterval: time.Millisecond,
+			}
+			req.SetProgressFn(func(pu *ProgressUpdate) {
+				updates = append(updates, pu)
+			})
+
+			gotErr := PoolRebuildWait(test.Context(t), NewMockInvoker(log, tc.mic), req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expUpdates, len(updates), "unexpected number of updates")
+			final := updates[len(updates)-1]
+			test.AssertTrue(t, final.Done, "final update not marked done")
+			test.AssertEqual(t, tc.expObjects, final.Completed, "unexpected objects rebuilt")
+			test.AssertEqual(t, tc.expFinalMsg, final.Message, "unexpected final message")
+		})
+	}
+}
+
 func TestControl_PoolSetProp(t *testing.T) {
 	defaultReq := &PoolSetPropReq{
 		ID:         test.MockUUID(),
@@ -1294,6 +1384,57 @@ func propWithNoVal(s string) *daos.PoolProperty {
 	return p
 }
 
+func TestControl_PoolRename(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic    *MockInvokerConfig
+		req    *PoolRenameReq
+		expErr error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.PoolRenameReq"),
+		},
+		"invalid label": {
+			req: &PoolRenameReq{
+				ID:       test.MockUUID(),
+				NewLabel: "bad label",
+			},
+			expErr: errors.New("invalid label"),
+		},
+		"remote failure": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			req: &PoolRenameReq{
+				ID:       test.MockUUID(),
+				NewLabel: "new",
+			},
+			expErr: errors.New("remote failed"),
+		},
+		"success": {
+			req: &PoolRenameReq{
+				ID:       test.MockUUID(),
+				NewLabel: "new",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := test.Context(t)
+			mi := NewMockInvoker(log, mic)
+
+			gotErr := PoolRename(ctx, mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
 func TestControl_PoolGetProp(t *testing.T) {
 	defaultReq := &PoolGetPropReq{
 		ID: test.MockUUID(),
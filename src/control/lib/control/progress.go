@@ -0,0 +1,189 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// progressTickInterval is the period at which updates are reported
+	// for operations whose progress can't be measured.
+	progressTickInterval = time.Second
+	// DefaultProgressPollInterval is the default period at which the
+	// state of an operation running in the background is polled.
+	DefaultProgressPollInterval = 2 * time.Second
+)
+
+type (
+	// ProgressUpdate describes the progress of a long-running operation.
+	ProgressUpdate struct {
+		Unit      string        `json:"unit,omitempty"`
+		Completed uint64        `json:"completed"`
+		Total     uint64        `json:"total"` // zero if the amount of work is unknown
+		Elapsed   time.Duration `json:"elapsed"`
+		Remaining time.Duration `json:"remaining"` // zero if no estimate is available
+		Message   string        `json:"message,omitempty"`
+		Done      bool          `json:"done"`
+	}
+
+	// ProgressFn is called with each progress update for an operation.
+	ProgressFn func(*ProgressUpdate)
+
+	// progressReporter defines an interface to be implemented by
+	// requests that can report the progress of their operation.
+	progressReporter interface {
+		getProgressFn() ProgressFn
+	}
+
+	// progressRequest is an embeddable struct to be used by requests
+	// for long-running operations that report their progress.
+	progressRequest struct {
+		progressFn ProgressFn
+	}
+
+	// progressTracker generates progress updates for an operation,
+	// deriving elapsed time and remaining time estimates.
+	progressTracker struct {
+		sync.Mutex
+		fn        ProgressFn
+		unit      string
+		total     uint64
+		completed uint64
+		start     time.Time
+		done      bool
+	}
+)
+
+// SetProgressFn sets a callback to be invoked with progress updates while the
+// request is being processed.
+func (r *progressRequest) SetProgressFn(fn ProgressFn) {
+	r.progressFn = fn
+}
+
+func (r *progressRequest) getProgressFn() ProgressFn {
+	return r.progressFn
+}
+
+// newProgressTracker returns a progressTracker for the operation, or nil if
+// the request has no interest in progress updates.
+func newProgressTracker(req interface{}, unit string, total uint64) *progressTracker {
+	pr, ok := req.(progressReporter)
+	if !ok || pr.getProgressFn() == nil {
+		return nil
+	}
+
+	return &progressTracker{
+		fn:    pr.getProgressFn(),
+		unit:  unit,
+		total: total,
+		start: time.Now(),
+	}
+}
+
+// estimateRemaining projects the time needed to complete the remaining work
+// from the rate at which work has been completed so far.
+func estimateRemaining(elapsed time.Duration, completed, total uint64) time.Duration {
+	if total == 0 || completed == 0 || completed >= total {
+		return 0
+	}
+	return time.Duration(float64(elapsed) * float64(total-completed) / float64(completed))
+}
+
+func (pt *progressTracker) send(remaining time.Duration, msg string) {
+	elapsed := time.Since(pt.start)
+	if remaining == 0 && !pt.done {
+		remaining = estimateRemaining(elapsed, pt.completed, pt.total)
+	}
+
+	pt.fn(&ProgressUpdate{
+		Unit:      pt.unit,
+		Completed: pt.completed,
+		Total:     pt.total,
+		Elapsed:   elapsed,
+		Remaining: remaining,
+		Message:   msg,
+		Done:      pt.done,
+	})
+}
+
+// update reports the amount of work completed. If remaining is zero, an
+// estimate is derived from the rate of progress.
+func (pt *progressTracker) update(completed uint64, remaining time.Duration, msg string) {
+	if pt == nil {
+		return
+	}
+
+	pt.Lock()
+	defer pt.Unlock()
+	if pt.done {
+		return
+	}
+	pt.completed = completed
+	pt.send(remaining, msg)
+}
+
+// increment reports the completion of another unit of work.
+func (pt *progressTracker) increment(msg string) {
+	if pt == nil {
+		return
+	}
+
+	pt.Lock()
+	defer pt.Unlock()
+	if pt.done {
+		return
+	}
+	pt.completed++
+	pt.send(0, msg)
+}
+
+// finish reports the end of the operation with the amount of work that was
+// completed, which may fall short of the total if the operation failed.
+// Subsequent updates are ignored.
+func (pt *progressTracker) finish(completed uint64, msg string) {
+	if pt == nil {
+		return
+	}
+
+	pt.Lock()
+	defer pt.Unlock()
+	if pt.done {
+		return
+	}
+	pt.done = true
+	pt.completed = completed
+	pt.send(0, msg)
+}
+
+// tick reports elapsed time periodically until the context is canceled, for
+// use while waiting on operations that do not report their progress.
+func (pt *progressTracker) tick(ctx context.Context, msg string) {
+	if pt == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(progressTickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pt.Lock()
+				if !pt.done {
+					pt.send(0, msg)
+				}
+				pt.Unlock()
+			}
+		}
+	}()
+}
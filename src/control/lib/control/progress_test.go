@@ -0,0 +1,109 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_estimateRemaining(t *testing.T) {
+	for name, tc := range map[string]struct {
+		elapsed   time.Duration
+		completed uint64
+		total     uint64
+		exp       time.Duration
+	}{
+		"unknown total": {
+			elapsed:   time.Minute,
+			completed: 5,
+		},
+		"nothing completed": {
+			elapsed: time.Minute,
+			total:   10,
+		},
+		"all completed": {
+			elapsed:   time.Minute,
+			completed: 10,
+			total:     10,
+		},
+		"quarter completed": {
+			elapsed:   time.Minute,
+			completed: 1,
+			total:     4,
+			exp:       3 * time.Minute,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.exp, estimateRemaining(tc.elapsed, tc.completed, tc.total), "")
+		})
+	}
+}
+
+func TestControl_progressTracker(t *testing.T) {
+	// No tracker is created for a request without a progress callback,
+	// and a nil tracker ignores updates.
+	pt := newProgressTracker(new(StorageFormatReq), "hosts", 3)
+	if pt != nil {
+		t.Fatal("expected nil tracker")
+	}
+	pt.increment("")
+	pt.finish(0, "")
+
+	var updates []*ProgressUpdate
+	req := new(StorageFormatReq)
+	req.SetProgressFn(func(pu *ProgressUpdate) {
+		updates = append(updates, pu)
+	})
+
+	pt = newProgressTracker(req, "hosts", 3)
+	pt.increment("host1")
+	pt.update(2, time.Hour, "host2")
+	pt.finish(3, "done")
+	pt.increment("ignored")
+
+	test.AssertEqual(t, 3, len(updates), "unexpected number of updates")
+	test.AssertEqual(t, uint64(1), updates[0].Completed, "unexpected first update")
+	test.AssertEqual(t, "hosts", updates[0].Unit, "unexpected unit")
+	test.AssertEqual(t, time.Hour, updates[1].Remaining, "supplied estimate not used")
+	test.AssertEqual(t, uint64(3), updates[2].Completed, "unexpected completed when done")
+	test.AssertTrue(t, updates[2].Done, "final update not marked done")
+	test.AssertEqual(t, time.Duration(0), updates[2].Remaining, "unexpected remaining time when done")
+}
+
+func TestControl_invokeUnaryRPC_progress(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	mi := NewMockInvoker(log, &MockInvokerConfig{
+		UnaryResponse: &UnaryResponse{
+			Responses: MockHostResponses(t, 3, "host%d", &ctlpb.StorageFormatResp{}),
+		},
+	})
+
+	var updates []*ProgressUpdate
+	req := new(StorageFormatReq)
+	req.SetHostList([]string{"host[0-2]"})
+	req.SetProgressFn(func(pu *ProgressUpdate) {
+		updates = append(updates, pu)
+	})
+
+	if _, err := mi.InvokeUnaryRPC(test.Context(t), req); err != nil {
+		t.Fatal(err)
+	}
+
+	test.AssertEqual(t, 4, len(updates), "expected one update per host and a final update")
+	for i, pu := range updates[:3] {
+		test.AssertEqual(t, uint64(i+1), pu.Completed, "unexpected hosts completed")
+		test.AssertEqual(t, uint64(3), pu.Total, "unexpected host total")
+	}
+	test.AssertTrue(t, updates[3].Done, "final update not marked done")
+}
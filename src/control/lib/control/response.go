@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -60,6 +60,18 @@ func (her *HostErrorsResp) GetHostErrors() HostErrorsMap {
 	return her.HostErrors
 }
 
+// HostErrorsByHostSet returns the errors in the response keyed by the ranged
+// string of the set of hosts that reported them.
+func (her *HostErrorsResp) HostErrorsByHostSet() map[string][]error {
+	out := make(map[string][]error)
+	for _, errStr := range her.HostErrors.Keys() {
+		hes := her.HostErrors[errStr]
+		hosts := hes.HostSet.RangedString()
+		out[hosts] = append(out[hosts], hes.HostError)
+	}
+	return out
+}
+
 // Errors returns an error containing brief description of errors in map.
 func (her *HostErrorsResp) Errors() error {
 	if len(her.HostErrors) > 0 {
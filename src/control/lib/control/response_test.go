@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -106,6 +106,37 @@ func TestControl_HostErrorsMap(t *testing.T) {
 	}
 }
 
+func TestControl_HostErrorsResp_HostErrorsByHostSet(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+
+	her := new(HostErrorsResp)
+	test.AssertEqual(t, 0, len(her.HostErrorsByHostSet()), "expected no host errors")
+
+	for _, he := range []struct {
+		host string
+		err  error
+	}{
+		{"host1", errA},
+		{"host2", errA},
+		{"host3", errC},
+		{"host3", errB},
+	} {
+		if err := her.addHostError(he.host, he.err); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expHostErrs := map[string][]error{
+		"host[1-2]": {errA},
+		"host3":     {errB, errC},
+	}
+	if diff := cmp.Diff(expHostErrs, her.HostErrorsByHostSet(), cmp.Comparer(test.CmpErrBool)); diff != "" {
+		t.Fatalf("unexpected host errors (-want, +got):\n%s\n", diff)
+	}
+}
+
 func TestControl_getMSResponse(t *testing.T) {
 	for name, tc := range map[string]struct {
 		resp    *UnaryResponse
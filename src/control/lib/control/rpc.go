@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -15,7 +15,10 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
@@ -122,11 +125,15 @@ func (r *unaryRequest) setRPC(rpc unaryRPC) {
 
 type (
 	// Client implements the Invoker interface and should be provided to
-	// API methods to invoke RPCs.
+	// API methods to invoke RPCs. A single gRPC connection is maintained
+	// to each host and reused across requests.
 	Client struct {
-		config    *Config
-		log       debugLogger
-		component build.Component
+		config        *Config
+		log           debugLogger
+		component     build.Component
+		clockSampleCb ClockSampleFn
+		connMu        sync.Mutex
+		conns         map[string]*grpc.ClientConn
 	}
 
 	// ClientOption defines the signature for functional Client options.
@@ -159,6 +166,7 @@ func WithConfig(cfg *Config) ClientOption {
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
 		config: DefaultConfig(),
+		conns:  make(map[string]*grpc.ClientConn),
 	}
 
 	for _, opt := range opts {
@@ -187,11 +195,26 @@ func (c *Client) GetComponent() build.Component {
 }
 
 // SetConfig sets the client configuration for an
-// existing Client.
+// existing Client. Any cached connections are closed so that
+// subsequent requests use the new configuration.
 func (c *Client) SetConfig(cfg *Config) {
+	c.Close()
 	c.config = cfg
 }
 
+// Close closes all cached connections held by the client.
+func (c *Client) Close() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	for addr, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			c.Debugf("failed to close connection to %s: %s", addr, err)
+		}
+		delete(c.conns, addr)
+	}
+}
+
 // GetConfig retrieves the system name from the client configuration and
 // implements the sysGetter interface.
 func (c *Client) GetSystem() string {
@@ -209,13 +232,39 @@ func (c *Client) Debugf(fmtStr string, args ...interface{}) {
 // dialOptions is a helper method to return a set of gRPC
 // client dialer options.
 func (c *Client) dialOptions() ([]grpc.DialOption, error) {
+	connCfg := c.config.Connection.WithDefaults()
+	backoffCfg := backoff.DefaultConfig
+	backoffCfg.MaxDelay = connCfg.BackoffMaxDelay
+
+	unaryInterceptors := []grpc.UnaryClientInterceptor{
+		unaryTraceInterceptor(),
+		unaryFeatureInterceptor(),
+		unaryErrorInterceptor(),
+		unaryVersionedComponentInterceptor(c.GetComponent()),
+	}
+	if c.clockSampleCb != nil {
+		unaryInterceptors = append(unaryInterceptors, unaryClockInterceptor(c.clockSampleCb))
+	}
+
 	opts := []grpc.DialOption{
 		streamErrorInterceptor(),
-		grpc.WithChainUnaryInterceptor(
-			unaryErrorInterceptor(),
-			unaryVersionedComponentInterceptor(c.GetComponent()),
-		),
+		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
 		grpc.FailOnNonTempDialError(true),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                connCfg.KeepaliveTime,
+			Timeout:             connCfg.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoffCfg,
+			MinConnectTimeout: connCfg.MinConnectTimeout,
+		}),
+	}
+
+	// Requests are compressed with the configured compressor, and servers
+	// compress their responses to match.
+	if connCfg.Compression != "" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(connCfg.Compression)))
 	}
 
 	creds, err := security.DialOptionForTransportConfig(c.config.TransportConfig)
@@ -227,6 +276,42 @@ func (c *Client) dialOptions() ([]grpc.DialOption, error) {
 	return opts, nil
 }
 
+// getConn returns the cached connection to the given host, creating a new
+// one if necessary. A connection that has been shut down is replaced, and
+// one that is waiting to reconnect after a failure is prompted to retry
+// immediately rather than failing the request.
+func (c *Client) getConn(hostAddr string) (*grpc.ClientConn, error) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if conn, found := c.conns[hostAddr]; found {
+		switch conn.GetState() {
+		case connectivity.Shutdown:
+			delete(c.conns, hostAddr)
+		case connectivity.TransientFailure:
+			conn.ResetConnectBackoff()
+			return conn, nil
+		default:
+			return conn, nil
+		}
+	}
+
+	opts, err := c.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	// The connection outlives any single request, so the dial is not
+	// bound to a request context.
+	conn, err := grpc.Dial(hostAddr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[hostAddr] = conn
+
+	return conn, nil
+}
+
 // setDeadlineIfUnset sets a deadline on the context unless there is already
 // one set. If the request does not define a specific deadline, then the
 // default timeout is used.
@@ -290,14 +375,9 @@ func (c *Client) InvokeUnaryRPCAsync(parent context.Context, req UnaryRequest) (
 			wg.Add(1)
 			go func(hostAddr string) {
 				var msg proto.Message
-				opts, err := c.dialOptions()
+				conn, err := c.getConn(hostAddr)
 				if err == nil {
-					var conn *grpc.ClientConn
-					conn, err = grpc.DialContext(ctx, hostAddr, opts...)
-					if err == nil {
-						msg, err = req.getRPC()(ctx, conn)
-						conn.Close()
-					}
+					msg, err = req.getRPC()(ctx, conn)
 				}
 
 				select {
@@ -319,16 +399,18 @@ func (c *Client) InvokeUnaryRPCAsync(parent context.Context, req UnaryRequest) (
 // real Client as well as the MockInvoker. This allows us to ensure that
 // the retry logic here gets adequate test coverage.
 func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker, req UnaryRequest, defaultHosts []string) (*UnaryResponse, error) {
-	gatherResponses := func(ctx context.Context, respChan chan *HostResponse, ur *UnaryResponse) error {
+	gatherResponses := func(ctx context.Context, respChan chan *HostResponse, ur *UnaryResponse, pt *progressTracker) error {
 		for {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case hr := <-respChan:
 				if hr == nil {
+					pt.finish(uint64(len(ur.Responses)), "")
 					return nil
 				}
 				ur.Responses = append(ur.Responses, hr)
+				pt.increment(hr.Addr)
 			}
 		}
 	}
@@ -336,6 +418,7 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 	// Set a deadline for the request across all retries.
 	reqCtx, cancel := setDeadlineIfUnset(parentCtx, req)
 	defer cancel()
+	reqCtx = withIdempotencyKey(reqCtx, req)
 
 	// For non-MS requests, just keep things simple. Fan-out, fan-in,
 	// no retries possible.
@@ -345,8 +428,22 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 			return nil, err
 		}
 
+		// Fan-out requests that report progress do so by counting
+		// the hosts that have responded.
+		hostList := req.getHostList()
+		if len(hostList) == 0 {
+			hostList = defaultHosts
+		}
+		var nrHosts int
+		if hosts, err := ExpandHostList(hostList); err == nil {
+			if hosts, err = common.ParseHostList(hosts, build.DefaultControlPort); err == nil {
+				nrHosts = len(hosts)
+			}
+		}
+		pt := newProgressTracker(req, "hosts", uint64(nrHosts))
+
 		ur := &UnaryResponse{log: log}
-		if err := gatherResponses(reqCtx, respChan, ur); err != nil {
+		if err := gatherResponses(reqCtx, respChan, ur, pt); err != nil {
 			return nil, wrapReqTimeout(req, err)
 		}
 		return ur, nil
@@ -434,7 +531,7 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 		}
 
 		ur := &UnaryResponse{log: log, fromMS: true, retryCount: try}
-		err = gatherResponses(tryCtx, respChan, ur)
+		err = gatherResponses(tryCtx, respChan, ur, nil)
 		if isHardFailure(err, reqCtx) {
 			return nil, wrapReqTimeout(req, err)
 		}
@@ -454,7 +551,19 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 			}
 		}
 
+		var minBackoff time.Duration
 		switch e := err.(type) {
+		case *system.ErrBusy:
+			// The leader is shedding load, so wait at least as long as it
+			// asked before retrying. Spread the retries out so that the
+			// rejected requests don't all return at once.
+			if !req.canRetry(err, try) {
+				return ur, nil
+			}
+			minBackoff = e.RetryAfter
+			if e.RetryAfter > 0 {
+				minBackoff += time.Duration(rand.Int63n(int64(e.RetryAfter)))
+			}
 		case *system.ErrNotLeader:
 			// If we sent the request to a non-leader MS replica,
 			// then the error should give us a hint for where to
@@ -510,6 +619,9 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 		}
 
 		backoff := common.ExpBackoff(req.retryAfter(baseMSBackoff), uint64(try), maxMSBackoffFactor)
+		if backoff < minBackoff {
+			backoff = minBackoff
+		}
 		log.Debugf("retrying MS request after %s", backoff)
 		select {
 		case <-reqCtx.Done():
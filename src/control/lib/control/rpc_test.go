@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -21,6 +21,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
@@ -217,6 +218,9 @@ func TestControl_InvokeUnaryRPCAsync(t *testing.T) {
 
 			// Explicitly clean up before checking for stragglers.
 			cancel()
+			for range respChan {
+			}
+			client.Close()
 
 			// Give things a little bit of time to settle down before checking for
 			// any lingering goroutines.
@@ -236,6 +240,51 @@ func TestControl_InvokeUnaryRPCAsync(t *testing.T) {
 	}
 }
 
+func TestControl_Client_getConn(t *testing.T) {
+	clientCfg := DefaultConfig()
+	clientCfg.TransportConfig.AllowInsecure = true
+
+	client := NewClient(WithConfig(clientCfg))
+	defer client.Close()
+
+	conn1, err := client.getConn("127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn2, err := client.getConn("127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn1 != conn2 {
+		t.Fatal("expected connection to be reused for the same host")
+	}
+
+	conn3, err := client.getConn("127.0.0.1:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn3 == conn1 {
+		t.Fatal("expected a separate connection for a different host")
+	}
+
+	// Connections that have been shut down must be replaced.
+	if err := conn1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	conn4, err := client.getConn("127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn4 == conn1 {
+		t.Fatal("expected closed connection to be replaced")
+	}
+
+	// Updating the config drops all cached connections.
+	client.SetConfig(clientCfg)
+	test.AssertEqual(t, 0, len(client.conns), "expected cached connections to be closed")
+	test.AssertEqual(t, connectivity.Shutdown, conn3.GetState(), "unexpected connection state")
+}
+
 func TestControl_InvokeUnaryRPC(t *testing.T) {
 	// make the rand deterministic for testing
 	msCandidateRandSource = newSafeRandSource(1)
@@ -278,6 +327,7 @@ func TestControl_InvokeUnaryRPC(t *testing.T) {
 		Replicas: replicaHosts,
 	}
 	errUnimplemented := status.Newf(codes.Unimplemented, "unimplemented").Err()
+	errBusy := &system.ErrBusy{Operation: "test"}
 
 	genRpcFn := func(inner func(*int) (proto.Message, error)) func(_ context.Context, _ *grpc.ClientConn) (proto.Message, error) {
 		callCount := 0
@@ -422,6 +472,52 @@ func TestControl_InvokeUnaryRPC(t *testing.T) {
 				},
 			},
 		},
+		"request to busy leader retries after hint": {
+			req: &testRequest{
+				HostList: []string{leaderHost},
+				toMS:     true,
+				rpcFn: genRpcFn(func(callCount *int) (proto.Message, error) {
+					*callCount++
+					if *callCount == 1 {
+						return nil, &system.ErrBusy{
+							Operation:  "test",
+							RetryAfter: time.Millisecond,
+						}
+					}
+					return defaultMessage, nil
+				}),
+			},
+			expResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr:    leaderHost,
+						Message: defaultMessage,
+					},
+				},
+			},
+		},
+		"request to busy leader not retryable": {
+			req: &testRequest{
+				HostList: []string{leaderHost},
+				toMS:     true,
+				rpcFn: func(_ context.Context, _ *grpc.ClientConn) (proto.Message, error) {
+					return nil, errBusy
+				},
+				retryableRequest: retryableRequest{
+					retryTestFn: func(_ error, _ uint) bool {
+						return false
+					},
+				},
+			},
+			expResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr:  leaderHost,
+						Error: errBusy,
+					},
+				},
+			},
+		},
 		"request to non-leader replicas discovers leader": {
 			req: &testRequest{
 				HostList: nonLeaderReplicas,
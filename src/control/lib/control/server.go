@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,7 @@ package control
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -16,6 +17,9 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/engine"
 )
 
@@ -155,3 +159,467 @@ func SetEngineLogMasks(ctx context.Context, rpcClient UnaryInvoker, req *SetEngi
 	rpcClient.Debugf("DAOS set engine log masks response: %+v", resp)
 	return resp, nil
 }
+
+type (
+	// SetControlLogLevelsReq contains the inputs for the set control log levels request.
+	SetControlLogLevelsReq struct {
+		unaryRequest
+		Level      string            `json:"level,omitempty"`
+		Subsystems map[string]string `json:"subsystems,omitempty"`
+		Reset      bool              `json:"reset,omitempty"`
+	}
+
+	// ControlLogLevels describes the log levels of a control plane server.
+	ControlLogLevels struct {
+		Level      string            `json:"level"`
+		Subsystems map[string]string `json:"subsystems"`
+	}
+
+	// SetControlLogLevelsResp contains the results of a set control log levels request.
+	SetControlLogLevelsResp struct {
+		HostErrorsResp
+		HostLevels map[string]*ControlLogLevels `json:"host_levels"`
+	}
+)
+
+func validateControlLogLevel(in string) error {
+	var level logging.LogLevel
+	return level.SetString(in)
+}
+
+// SetControlLogLevels will send RPC to hostlist to request changes to the log levels of the
+// control plane server, and of its subsystems, on each host in list.
+func SetControlLogLevels(ctx context.Context, rpcClient UnaryInvoker, req *SetControlLogLevelsReq) (*SetControlLogLevelsResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if req.Level == "" && len(req.Subsystems) == 0 && !req.Reset {
+		return nil, errors.New("no log levels to set or reset")
+	}
+
+	if req.Level != "" {
+		if err := validateControlLogLevel(req.Level); err != nil {
+			return nil, err
+		}
+	}
+	for name, level := range req.Subsystems {
+		if err := logging.ValidateSubsystem(name); err != nil {
+			return nil, err
+		}
+		if err := validateControlLogLevel(level); err != nil {
+			return nil, errors.Wrapf(err, "subsystem %s", name)
+		}
+	}
+
+	pbReq := &ctlpb.SetControlLogLevelsReq{
+		Sys:         req.getSystem(rpcClient),
+		Level:       req.Level,
+		Subsystems:  req.Subsystems,
+		ResetLevels: req.Reset,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).SetControlLogLevels(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS set control log levels request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SetControlLogLevelsResp{
+		HostLevels: make(map[string]*ControlLogLevels),
+	}
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.SetControlLogLevelsResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		resp.HostLevels[hr.Addr] = &ControlLogLevels{
+			Level:      pbResp.Level,
+			Subsystems: pbResp.Subsystems,
+		}
+	}
+
+	rpcClient.Debugf("DAOS set control log levels response: %+v", resp)
+	return resp, nil
+}
+
+type (
+	// SetProfilingReq contains the inputs for the set profiling request.
+	SetProfilingReq struct {
+		unaryRequest
+		Enable  bool   `json:"enable"`
+		Address string `json:"address,omitempty"`
+	}
+
+	// ProfilingStatus describes the state of the profiling endpoint of a
+	// control plane server.
+	ProfilingStatus struct {
+		Enabled bool   `json:"enabled"`
+		Address string `json:"address,omitempty"`
+	}
+
+	// SetProfilingResp contains the results of a set profiling request.
+	SetProfilingResp struct {
+		HostErrorsResp
+		HostStatus map[string]*ProfilingStatus `json:"host_status"`
+	}
+)
+
+// SetProfiling will send RPC to hostlist to request that the profiling endpoint of the
+// control plane server on each host in list is enabled or disabled.
+func SetProfiling(ctx context.Context, rpcClient UnaryInvoker, req *SetProfilingReq) (*SetProfilingResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if !req.Enable && req.Address != "" {
+		return nil, errors.New("address may only be supplied when enabling profiling")
+	}
+
+	pbReq := &ctlpb.SetProfilingReq{
+		Sys:     req.getSystem(rpcClient),
+		Enable:  req.Enable,
+		Address: req.Address,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).SetProfiling(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS set profiling request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SetProfilingResp{
+		HostStatus: make(map[string]*ProfilingStatus),
+	}
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.SetProfilingResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		resp.HostStatus[hr.Addr] = &ProfilingStatus{
+			Enabled: pbResp.Enabled,
+			Address: pbResp.Address,
+		}
+	}
+
+	rpcClient.Debugf("DAOS set profiling response: %+v", resp)
+	return resp, nil
+}
+
+type (
+	// EngineUsageQueryReq contains the inputs for the engine usage query request.
+	EngineUsageQueryReq struct {
+		unaryRequest
+	}
+
+	// EngineUsage contains resource usage details for an engine process.
+	EngineUsage struct {
+		Host          string             `json:"host"`
+		Index         uint32             `json:"index"`
+		Rank          ranklist.Rank      `json:"rank"`
+		PID           int32              `json:"pid"`
+		RSSBytes      uint64             `json:"rss_bytes"`
+		HugepageBytes uint64             `json:"hugepage_bytes"`
+		OpenFDs       uint32             `json:"open_fds"`
+		CPUSeconds    map[string]float64 `json:"cpu_seconds"`
+		Error         string             `json:"error,omitempty"`
+	}
+
+	// EngineUsageQueryResp contains the results of an engine usage query request.
+	EngineUsageQueryResp struct {
+		HostErrorsResp
+		Engines []*EngineUsage `json:"engines"`
+	}
+)
+
+// TotalCPUSeconds returns the cumulative CPU time consumed by all threads
+// in the engine process.
+func (eu *EngineUsage) TotalCPUSeconds() (total float64) {
+	for _, secs := range eu.CPUSeconds {
+		total += secs
+	}
+	return
+}
+
+// EngineUsageQuery will send RPC to hostlist to request resource usage
+// details for all DAOS engines on each host in list.
+func EngineUsageQuery(ctx context.Context, rpcClient UnaryInvoker, req *EngineUsageQueryReq) (*EngineUsageQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).EngineUsageQuery(ctx, &ctlpb.EngineUsageReq{})
+	})
+	rpcClient.Debugf("DAOS engine usage query request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(EngineUsageQueryResp)
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.EngineUsageResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		for _, pbEU := range pbResp.GetEngines() {
+			resp.Engines = append(resp.Engines, &EngineUsage{
+				Host:          hr.Addr,
+				Index:         pbEU.Index,
+				Rank:          ranklist.Rank(pbEU.Rank),
+				PID:           pbEU.Pid,
+				RSSBytes:      pbEU.RssBytes,
+				HugepageBytes: pbEU.HugepageBytes,
+				OpenFDs:       pbEU.OpenFds,
+				CPUSeconds:    pbEU.CpuSeconds,
+				Error:         pbEU.Error,
+			})
+		}
+	}
+
+	sort.Slice(resp.Engines, func(i, j int) bool {
+		if resp.Engines[i].Host == resp.Engines[j].Host {
+			return resp.Engines[i].Index < resp.Engines[j].Index
+		}
+		return resp.Engines[i].Host < resp.Engines[j].Host
+	})
+
+	rpcClient.Debugf("DAOS engine usage query response: %+v", resp)
+	return resp, nil
+}
+
+type (
+	// GetEngineTunablesReq contains the inputs for the get engine tunables request.
+	GetEngineTunablesReq struct {
+		unaryRequest
+	}
+
+	// EngineTunables contains the effective tuning parameters of an engine process.
+	EngineTunables struct {
+		Host             string        `json:"host"`
+		Index            uint32        `json:"index"`
+		Rank             ranklist.Rank `json:"rank"`
+		Running          bool          `json:"running"`
+		Args             []string      `json:"args"`
+		EnvVars          []string      `json:"env_vars"`
+		ULTStackSize     uint64        `json:"ult_stack_size"`
+		DeepULTStackSize uint64        `json:"deep_ult_stack_size"`
+		MmapULTStacks    bool          `json:"mmap_ult_stacks"`
+		Targets          uint32        `json:"targets"`
+		HelperStreams    uint32        `json:"helper_streams"`
+		MemSizeMiB       uint32        `json:"mem_size_mib"`
+		HugepageSizeMiB  uint32        `json:"hugepage_size_mib"`
+		PinnedNumaNode   *uint32       `json:"pinned_numa_node,omitempty"`
+		FirstCore        *uint32       `json:"first_core,omitempty"`
+		Error            string        `json:"error,omitempty"`
+	}
+
+	// GetEngineTunablesResp contains the results of a get engine tunables request.
+	GetEngineTunablesResp struct {
+		HostErrorsResp
+		Engines []*EngineTunables `json:"engines"`
+	}
+)
+
+// optionalIndex converts a protobuf index value where -1 indicates that it is
+// unset.
+func optionalIndex(in int32) *uint32 {
+	if in < 0 {
+		return nil
+	}
+	out := uint32(in)
+	return &out
+}
+
+// GetEngineTunables will send RPC to hostlist to request the effective tuning
+// parameters of all DAOS engines on each host in list.
+func GetEngineTunables(ctx context.Context, rpcClient UnaryInvoker, req *GetEngineTunablesReq) (*GetEngineTunablesResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).GetEngineTunables(ctx, &ctlpb.EngineTunablesReq{})
+	})
+	rpcClient.Debugf("DAOS get engine tunables request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(GetEngineTunablesResp)
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.EngineTunablesResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		for _, pbET := range pbResp.GetEngines() {
+			et := &EngineTunables{
+				Host:    hr.Addr,
+				Index:   pbET.Index,
+				Rank:    ranklist.Rank(pbET.Rank),
+				Running: pbET.Running,
+				Error:   pbET.Error,
+			}
+			if pbET.Error == "" {
+				et.Args = pbET.Args
+				et.EnvVars = pbET.EnvVars
+				et.ULTStackSize = pbET.UltStackSize
+				et.DeepULTStackSize = pbET.DeepUltStackSize
+				et.MmapULTStacks = pbET.MmapUltStacks
+				et.Targets = pbET.Targets
+				et.HelperStreams = pbET.HelperStreams
+				et.MemSizeMiB = pbET.MemSizeMib
+				et.HugepageSizeMiB = pbET.HugepageSizeMib
+				et.PinnedNumaNode = optionalIndex(pbET.PinnedNumaNode)
+				et.FirstCore = optionalIndex(pbET.FirstCore)
+			}
+			resp.Engines = append(resp.Engines, et)
+		}
+	}
+
+	sort.Slice(resp.Engines, func(i, j int) bool {
+		if resp.Engines[i].Host == resp.Engines[j].Host {
+			return resp.Engines[i].Index < resp.Engines[j].Index
+		}
+		return resp.Engines[i].Host < resp.Engines[j].Host
+	})
+
+	rpcClient.Debugf("DAOS get engine tunables response: %+v", resp)
+	return resp, nil
+}
+
+type (
+	// ServerConfigApplyReq contains the inputs for the apply server config
+	// request.
+	ServerConfigApplyReq struct {
+		unaryRequest
+		Config []byte `json:"-"`
+		Commit bool   `json:"commit"`
+	}
+
+	// ServerConfigApplyResp contains the results of an apply server config
+	// request.
+	ServerConfigApplyResp struct {
+		HostErrorsResp
+		Validated *hostlist.HostSet `json:"validated"`
+		Committed *hostlist.HostSet `json:"committed"`
+	}
+)
+
+// sendServerConfig sends the candidate server config to the hosts in the
+// request and returns the set of hosts that accepted it. Host errors are added
+// to the response.
+func sendServerConfig(ctx context.Context, rpcClient UnaryInvoker, req *ServerConfigApplyReq, commit bool, resp *ServerConfigApplyResp) (*hostlist.HostSet, error) {
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).ApplyServerConfig(ctx, &ctlpb.ServerConfigApplyReq{
+			Config: req.Config,
+			Commit: commit,
+		})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := hostlist.MustCreateSet("")
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.ServerConfigApplyResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		if commit && !pbResp.Committed {
+			if err := resp.addHostError(hr.Addr, errors.Errorf("%s was not replaced", pbResp.Path)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := accepted.Insert(hr.Addr); err != nil {
+			return nil, err
+		}
+	}
+
+	return accepted, nil
+}
+
+// ApplyServerConfig sends a candidate server config to each host in the list
+// to be validated against the hardware of the host, without applying it. Only
+// if the config is valid on all of the hosts, and the request is to commit
+// it, is the config file of each server then replaced with the candidate, to
+// be used when the server is next restarted. This prevents a config that
+// isn't valid across the cluster from being partially rolled out.
+func ApplyServerConfig(ctx context.Context, rpcClient UnaryInvoker, req *ServerConfigApplyReq) (*ServerConfigApplyResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if len(req.Config) == 0 {
+		return nil, errors.New("empty server config")
+	}
+
+	resp := &ServerConfigApplyResp{
+		Committed: hostlist.MustCreateSet(""),
+	}
+
+	rpcClient.Debugf("DAOS apply server config request (validate): %+v", req)
+	validated, err := sendServerConfig(ctx, rpcClient, req, false, resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Validated = validated
+	if !req.Commit || resp.Errors() != nil || validated.Count() == 0 {
+		return resp, nil
+	}
+
+	rpcClient.Debugf("DAOS apply server config request (commit): %+v", req)
+	committed, err := sendServerConfig(ctx, rpcClient, req, true, resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Committed = committed
+
+	rpcClient.Debugf("DAOS apply server config response: %+v", resp)
+	return resp, nil
+}
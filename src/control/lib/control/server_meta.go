@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -72,6 +73,8 @@ type (
 		UUID             string        `json:"uuid"`
 		Rank             ranklist.Rank `json:"rank"`
 		FaultyDevsOnly   bool          `json:"-"` // only show faulty devices
+		IncludeIOStats   bool          `json:"include_io_stats"`
+		IOStatsPeriod    time.Duration `json:"io_stats_period"` // period over which I/O rates are sampled
 	}
 
 	// SmdManageReq contains the request parameters for a SMD query operation.
@@ -185,6 +188,9 @@ func SmdQuery(ctx context.Context, rpcClient UnaryInvoker, req *SmdQueryReq) (*S
 	if err := convert.Types(req, pbReq); err != nil {
 		return nil, errors.Wrap(err, "unable to convert request to protobuf")
 	}
+	if req.IncludeIOStats {
+		pbReq.IoStatsPeriodMs = uint32(req.IOStatsPeriod.Milliseconds())
+	}
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return ctlpb.NewCtlSvcClient(conn).SmdQuery(ctx, pbReq)
 	})
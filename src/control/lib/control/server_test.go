@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2023 Intel Corporation.
+// (C) Copyright 2023-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -436,3 +436,618 @@ func Test_SetEngineLogMasks(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_EngineUsageQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *EngineUsageQueryReq
+		mic         *MockInvokerConfig
+		expResponse *EngineUsageQueryResp
+		expErr      error
+	}{
+		"nil request": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("nil request"),
+		},
+		"invoke fails": {
+			req: &EngineUsageQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"unexpected message": {
+			req: &EngineUsageQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: new(ctlpb.SetLogMasksResp),
+						},
+					},
+				},
+			},
+			expErr: errors.New("unable to unpack message"),
+		},
+		"multiple hosts; one host fails": {
+			req: &EngineUsageQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host3",
+							Error: errors.New("failed"),
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.EngineUsageResp{
+								Engines: []*ctlpb.EngineUsage{
+									{
+										Index: 1,
+										Rank:  3,
+										Error: "not started",
+									},
+									{
+										Index:      0,
+										Rank:       2,
+										Pid:        42,
+										RssBytes:   1024,
+										OpenFds:    8,
+										CpuSeconds: map[string]float64{"daos_io_0": 1},
+									},
+								},
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.EngineUsageResp{
+								Engines: []*ctlpb.EngineUsage{
+									{
+										Index:         0,
+										Rank:          0,
+										Pid:           1234,
+										RssBytes:      4096,
+										HugepageBytes: 8192,
+										OpenFds:       16,
+										CpuSeconds:    map[string]float64{"daos_io_0": 2.5},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResponse: &EngineUsageQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "host3",
+					Error: "failed",
+				}),
+				Engines: []*EngineUsage{
+					{
+						Host:          "host1",
+						Rank:          0,
+						PID:           1234,
+						RSSBytes:      4096,
+						HugepageBytes: 8192,
+						OpenFDs:       16,
+						CPUSeconds:    map[string]float64{"daos_io_0": 2.5},
+					},
+					{
+						Host:       "host2",
+						Rank:       2,
+						PID:        42,
+						RSSBytes:   1024,
+						OpenFDs:    8,
+						CPUSeconds: map[string]float64{"daos_io_0": 1},
+					},
+					{
+						Host:  "host2",
+						Index: 1,
+						Rank:  3,
+						Error: "not started",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResponse, gotErr := EngineUsageQuery(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResponse, gotResponse, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_GetEngineTunables(t *testing.T) {
+	numaNode := uint32(1)
+	firstCore := uint32(0)
+
+	for name, tc := range map[string]struct {
+		req         *GetEngineTunablesReq
+		mic         *MockInvokerConfig
+		expResponse *GetEngineTunablesResp
+		expErr      error
+	}{
+		"nil request": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("nil request"),
+		},
+		"invoke fails": {
+			req: &GetEngineTunablesReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"unexpected message": {
+			req: &GetEngineTunablesReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: new(ctlpb.SetLogMasksResp),
+						},
+					},
+				},
+			},
+			expErr: errors.New("unable to unpack message"),
+		},
+		"multiple hosts; one host fails": {
+			req: &GetEngineTunablesReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host3",
+							Error: errors.New("failed"),
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.EngineTunablesResp{
+								Engines: []*ctlpb.EngineTunables{
+									{
+										Index: 1,
+										Rank:  3,
+										Error: "bad config",
+									},
+									{
+										Index:            0,
+										Rank:             2,
+										Running:          true,
+										Args:             []string{"-t", "8"},
+										EnvVars:          []string{"ABT_THREAD_STACKSIZE=16384"},
+										UltStackSize:     16384,
+										DeepUltStackSize: 65536,
+										Targets:          8,
+										HelperStreams:    2,
+										PinnedNumaNode:   1,
+										FirstCore:        -1,
+									},
+								},
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.EngineTunablesResp{
+								Engines: []*ctlpb.EngineTunables{
+									{
+										Index:            0,
+										Rank:             0,
+										Args:             []string{"-t", "16"},
+										EnvVars:          []string{"DAOS_ULT_MMAP_STACK=1"},
+										UltStackSize:     16384,
+										DeepUltStackSize: 65536,
+										MmapUltStacks:    true,
+										Targets:          16,
+										MemSizeMib:       8192,
+										HugepageSizeMib:  2,
+										PinnedNumaNode:   -1,
+										FirstCore:        0,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResponse: &GetEngineTunablesResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "host3",
+					Error: "failed",
+				}),
+				Engines: []*EngineTunables{
+					{
+						Host:             "host1",
+						Args:             []string{"-t", "16"},
+						EnvVars:          []string{"DAOS_ULT_MMAP_STACK=1"},
+						ULTStackSize:     16384,
+						DeepULTStackSize: 65536,
+						MmapULTStacks:    true,
+						Targets:          16,
+						MemSizeMiB:       8192,
+						HugepageSizeMiB:  2,
+						FirstCore:        &firstCore,
+					},
+					{
+						Host:             "host2",
+						Rank:             2,
+						Running:          true,
+						Args:             []string{"-t", "8"},
+						EnvVars:          []string{"ABT_THREAD_STACKSIZE=16384"},
+						ULTStackSize:     16384,
+						DeepULTStackSize: 65536,
+						Targets:          8,
+						HelperStreams:    2,
+						PinnedNumaNode:   &numaNode,
+					},
+					{
+						Host:  "host2",
+						Index: 1,
+						Rank:  3,
+						Error: "bad config",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResponse, gotErr := GetEngineTunables(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResponse, gotResponse, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SetControlLogLevels(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *SetControlLogLevelsReq
+		mic         *MockInvokerConfig
+		expResponse *SetControlLogLevelsResp
+		expErr      error
+	}{
+		"nil request": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("nil request"),
+		},
+		"nothing to set": {
+			req:    &SetControlLogLevelsReq{},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("no log levels"),
+		},
+		"bad level": {
+			req:    &SetControlLogLevelsReq{Level: "loud"},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("not a valid log level"),
+		},
+		"unknown subsystem": {
+			req: &SetControlLogLevelsReq{
+				Subsystems: map[string]string{"bogus": "debug"},
+			},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("unknown log subsystem"),
+		},
+		"bad subsystem level": {
+			req: &SetControlLogLevelsReq{
+				Subsystems: map[string]string{"raft": "loud"},
+			},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("subsystem raft"),
+		},
+		"invoke fails": {
+			req: &SetControlLogLevelsReq{Reset: true},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"unexpected message": {
+			req: &SetControlLogLevelsReq{Reset: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: new(ctlpb.SetLogMasksResp),
+						},
+					},
+				},
+			},
+			expErr: errors.New("unable to unpack message"),
+		},
+		"multiple hosts; one host fails": {
+			req: &SetControlLogLevelsReq{
+				Level:      "info",
+				Subsystems: map[string]string{"raft": "debug"},
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host2",
+							Error: errors.New("failed"),
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.SetControlLogLevelsResp{
+								Level:      "INFO",
+								Subsystems: map[string]string{"raft": "DEBUG"},
+							},
+						},
+					},
+				},
+			},
+			expResponse: &SetControlLogLevelsResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "host2",
+					Error: "failed",
+				}),
+				HostLevels: map[string]*ControlLogLevels{
+					"host1": {
+						Level:      "INFO",
+						Subsystems: map[string]string{"raft": "DEBUG"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResponse, gotErr := SetControlLogLevels(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResponse, gotResponse, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SetProfiling(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *SetProfilingReq
+		mic         *MockInvokerConfig
+		expResponse *SetProfilingResp
+		expErr      error
+	}{
+		"nil request": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("nil request"),
+		},
+		"address when disabling": {
+			req:    &SetProfilingReq{Address: "localhost:6060"},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("only be supplied when enabling"),
+		},
+		"invoke fails": {
+			req: &SetProfilingReq{Enable: true},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"unexpected message": {
+			req: &SetProfilingReq{Enable: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: new(ctlpb.SetLogMasksResp),
+						},
+					},
+				},
+			},
+			expErr: errors.New("unable to unpack message"),
+		},
+		"multiple hosts; one host fails": {
+			req: &SetProfilingReq{Enable: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host2",
+							Error: errors.New("failed"),
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.SetProfilingResp{
+								Enabled: true,
+								Address: "127.0.0.1:6060",
+							},
+						},
+					},
+				},
+			},
+			expResponse: &SetProfilingResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "host2",
+					Error: "failed",
+				}),
+				HostStatus: map[string]*ProfilingStatus{
+					"host1": {
+						Enabled: true,
+						Address: "127.0.0.1:6060",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResponse, gotErr := SetProfiling(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResponse, gotResponse, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_ApplyServerConfig(t *testing.T) {
+	validResp := func(hosts ...string) *UnaryResponse {
+		ur := new(UnaryResponse)
+		for _, host := range hosts {
+			ur.Responses = append(ur.Responses, &HostResponse{
+				Addr:    host,
+				Message: &ctlpb.ServerConfigApplyResp{Path: "/etc/daos/daos_server.yml"},
+			})
+		}
+		return ur
+	}
+	committedResp := func(hosts ...string) *UnaryResponse {
+		ur := new(UnaryResponse)
+		for _, host := range hosts {
+			ur.Responses = append(ur.Responses, &HostResponse{
+				Addr: host,
+				Message: &ctlpb.ServerConfigApplyResp{
+					Path:      "/etc/daos/daos_server.yml",
+					Committed: true,
+				},
+			})
+		}
+		return ur
+	}
+
+	for name, tc := range map[string]struct {
+		req          *ServerConfigApplyReq
+		mic          *MockInvokerConfig
+		expValidated string
+		expCommitted string
+		expHostErr   error
+		expInvokes   int
+		expErr       error
+	}{
+		"nil request": {
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("nil request"),
+		},
+		"empty config": {
+			req:    &ServerConfigApplyReq{},
+			mic:    &MockInvokerConfig{},
+			expErr: errors.New("empty server config"),
+		},
+		"invoke fails": {
+			req: &ServerConfigApplyReq{Config: []byte("name: daos_server")},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"validate only": {
+			req: &ServerConfigApplyReq{Config: []byte("name: daos_server")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{validResp("host1", "host2")},
+			},
+			expValidated: "host[1-2]",
+			expInvokes:   1,
+		},
+		"invalid on one host; not committed": {
+			req: &ServerConfigApplyReq{Config: []byte("name: daos_server"), Commit: true},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					{
+						Responses: []*HostResponse{
+							validResp("host1").Responses[0],
+							{
+								Addr:  "host2",
+								Error: errors.New("interface not found"),
+							},
+						},
+					},
+				},
+			},
+			expValidated: "host1",
+			expHostErr:   errors.New("1 host had errors"),
+			expInvokes:   1,
+		},
+		"valid on all hosts; committed": {
+			req: &ServerConfigApplyReq{Config: []byte("name: daos_server"), Commit: true},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					validResp("host1", "host2"),
+					committedResp("host1", "host2"),
+				},
+			},
+			expValidated: "host[1-2]",
+			expCommitted: "host[1-2]",
+			expInvokes:   2,
+		},
+		"commit fails on one host": {
+			req: &ServerConfigApplyReq{Config: []byte("name: daos_server"), Commit: true},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					validResp("host1", "host2"),
+					{
+						Responses: []*HostResponse{
+							committedResp("host1").Responses[0],
+							{
+								Addr:  "host2",
+								Error: errors.New("read-only file system"),
+							},
+						},
+					},
+				},
+			},
+			expValidated: "host[1-2]",
+			expCommitted: "host1",
+			expHostErr:   errors.New("1 host had errors"),
+			expInvokes:   2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := ApplyServerConfig(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expInvokes, mi.GetInvokeCount(), "unexpected number of requests")
+			test.AssertEqual(t, tc.expValidated, gotResp.Validated.RangedString(), "unexpected validated hosts")
+			test.AssertEqual(t, tc.expCommitted, gotResp.Committed.RangedString(), "unexpected committed hosts")
+			test.CmpErr(t, tc.expHostErr, gotResp.Errors())
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -167,87 +167,134 @@ type (
 		HostErrorsResp
 		HostStorage HostStorageMap
 	}
+
+	// StorageScanHostResp contains the results of the storage scan of a
+	// single host. Errors holds the failure to contact the host, in which
+	// case HostStorage is nil, or the failures of its NVMe or SCM scans.
+	StorageScanHostResp struct {
+		Addr        string
+		HostStorage *HostStorage
+		Errors      []error
+	}
+
+	// StorageScanStream delivers the results of a storage scan from each
+	// host as they are received.
+	StorageScanStream struct {
+		results chan *StorageScanHostResp
+		err     error
+	}
 )
 
-// addHostResponse is responsible for validating the given HostResponse
-// and adding it to the StorageScanResp.
-//
-// TODO: pass info field that is embedded in message to response receiver.
-func (ssp *StorageScanResp) addHostResponse(hr *HostResponse) error {
+// scanStateError returns an error describing a failed NVMe or SCM scan, or nil
+// if the scan succeeded.
+func scanStateError(state *ctlpb.ResponseState) error {
+	if state.GetStatus() == ctlpb.ResponseStatus_CTL_SUCCESS {
+		return nil
+	}
+
+	pbErrMsg := state.GetError()
+	if pbErrMsg == "" {
+		pbErrMsg = "unknown error"
+	}
+	if state.GetInfo() != "" {
+		pbErrMsg += fmt.Sprintf(" (%s)", state.GetInfo())
+	}
+	return errors.New(pbErrMsg)
+}
+
+// unpackStorageScanResp converts the storage scan response message from a host
+// into a HostStorage. Failures of the NVMe or SCM scans on the host are returned
+// separately from any error encountered while unpacking the message.
+func unpackStorageScanResp(hr *HostResponse) (hs *HostStorage, scanErrs []error, err error) {
 	pbResp, ok := hr.Message.(*ctlpb.StorageScanResp)
 	if !ok {
-		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+		return nil, nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
 	}
 
-	hs := new(HostStorage)
+	hs = new(HostStorage)
 
 	nvmeResp := pbResp.GetNvme()
-	nvmeState := nvmeResp.GetState()
-	switch nvmeState.GetStatus() {
-	case ctlpb.ResponseStatus_CTL_SUCCESS:
-		if err := convert.Types(nvmeResp.GetCtrlrs(), &hs.NvmeDevices); err != nil {
-			return err
-		}
-	default:
-		pbErrMsg := nvmeState.GetError()
-		if pbErrMsg == "" {
-			pbErrMsg = "unknown error"
-		}
-		if nvmeState.GetInfo() != "" {
-			pbErrMsg += fmt.Sprintf(" (%s)", nvmeState.GetInfo())
-		}
-		if err := ssp.addHostError(hr.Addr, errors.New(pbErrMsg)); err != nil {
-			return err
-		}
+	if scanErr := scanStateError(nvmeResp.GetState()); scanErr != nil {
+		scanErrs = append(scanErrs, scanErr)
+	} else if err := convert.Types(nvmeResp.GetCtrlrs(), &hs.NvmeDevices); err != nil {
+		return nil, nil, err
 	}
 
 	scmResp := pbResp.GetScm()
-	scmState := scmResp.GetState()
-	switch scmState.GetStatus() {
-	case ctlpb.ResponseStatus_CTL_SUCCESS:
+	if scanErr := scanStateError(scmResp.GetState()); scanErr != nil {
+		scanErrs = append(scanErrs, scanErr)
+	} else {
 		if err := convert.Types(scmResp.GetModules(), &hs.ScmModules); err != nil {
-			return err
+			return nil, nil, err
 		}
 		if err := convert.Types(scmResp.GetNamespaces(), &hs.ScmNamespaces); err != nil {
-			return err
-		}
-	default:
-		pbErrMsg := scmState.GetError()
-		if pbErrMsg == "" {
-			pbErrMsg = "unknown error"
-		}
-		if scmState.GetInfo() != "" {
-			pbErrMsg += fmt.Sprintf(" (%s)", scmState.GetInfo())
-		}
-		if err := ssp.addHostError(hr.Addr, errors.New(pbErrMsg)); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
 	if err := convert.Types(pbResp.GetMemInfo(), &hs.MemInfo); err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	return hs, scanErrs, nil
+}
+
+// AddHostResult adds the results of the storage scan of a single host to the
+// StorageScanResp, allowing results received from a StorageScanStream to be
+// aggregated.
+func (ssp *StorageScanResp) AddHostResult(result *StorageScanHostResp) error {
+	if result == nil {
+		return errors.Errorf("nil %T", result)
+	}
+
+	for _, scanErr := range result.Errors {
+		if err := ssp.addHostError(result.Addr, scanErr); err != nil {
+			return err
+		}
+	}
+	if result.HostStorage == nil {
+		return nil
 	}
 
 	if ssp.HostStorage == nil {
 		ssp.HostStorage = make(HostStorageMap)
 	}
-	if err := ssp.HostStorage.Add(hr.Addr, hs); err != nil {
-		return err
+	return ssp.HostStorage.Add(result.Addr, result.HostStorage)
+}
+
+// Results returns the channel on which the result of the scan of each host is
+// delivered. The channel is closed once all hosts have responded or the scan
+// has been aborted.
+func (sss *StorageScanStream) Results() <-chan *StorageScanHostResp {
+	return sss.results
+}
+
+// Err returns the error that aborted the scan, if any. It must only be called
+// after the results channel has been closed.
+func (sss *StorageScanStream) Err() error {
+	return sss.err
+}
+
+// newStorageScanHostResp converts the response from a single host into a
+// StorageScanHostResp.
+func newStorageScanHostResp(hr *HostResponse) (*StorageScanHostResp, error) {
+	result := &StorageScanHostResp{Addr: hr.Addr}
+	if hr.Error != nil {
+		result.Errors = []error{hr.Error}
+		return result, nil
 	}
 
-	return nil
+	hs, scanErrs, err := unpackStorageScanResp(hr)
+	if err != nil {
+		return nil, err
+	}
+	result.HostStorage = hs
+	result.Errors = scanErrs
+
+	return result, nil
 }
 
-// StorageScan concurrently performs storage scans across all hosts
-// supplied in the request's hostlist, or all configured hosts if not
-// explicitly specified. The function blocks until all results (successful
-// or otherwise) are received, and returns a single response structure
-// containing results for all host scan operations.
-//
-// NumaHealth option requests SSD health statistics.
-// NumaMeta option requests DAOS server meta data stored on SSDs.
-// NumaBasic option strips SSD details down to only the most basic.
-func StorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanReq) (*StorageScanResp, error) {
+func (req *StorageScanReq) setScanRPC() {
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return ctlpb.NewCtlSvcClient(conn).StorageScan(ctx, &ctlpb.StorageScanReq{
 			Scm: &ctlpb.ScanScmReq{
@@ -261,6 +308,19 @@ func StorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanRe
 			},
 		})
 	})
+}
+
+// StorageScan concurrently performs storage scans across all hosts
+// supplied in the request's hostlist, or all configured hosts if not
+// explicitly specified. The function blocks until all results (successful
+// or otherwise) are received, and returns a single response structure
+// containing results for all host scan operations.
+//
+// NumaHealth option requests SSD health statistics.
+// NumaMeta option requests DAOS server meta data stored on SSDs.
+// NumaBasic option strips SSD details down to only the most basic.
+func StorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanReq) (*StorageScanResp, error) {
+	req.setScanRPC()
 
 	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
 	if err != nil {
@@ -269,14 +329,11 @@ func StorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanRe
 
 	ssr := new(StorageScanResp)
 	for _, hostResp := range ur.Responses {
-		if hostResp.Error != nil {
-			if err := ssr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
-				return nil, err
-			}
-			continue
+		result, err := newStorageScanHostResp(hostResp)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := ssr.addHostResponse(hostResp); err != nil {
+		if err := ssr.AddHostResult(result); err != nil {
 			return nil, err
 		}
 	}
@@ -284,11 +341,72 @@ func StorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanRe
 	return ssr, nil
 }
 
+// StreamStorageScan performs the same scans as StorageScan but, rather than
+// waiting for the slowest host, delivers the result of each host scan on the
+// returned StorageScanStream as soon as it is received. The scan is aborted if
+// the context is canceled, the request deadline expires or a host response
+// can't be unpacked.
+func StreamStorageScan(ctx context.Context, rpcClient UnaryInvoker, req *StorageScanReq) (*StorageScanStream, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	req.setScanRPC()
+
+	reqCtx, cancel := setDeadlineIfUnset(ctx, req)
+	hostResps, err := rpcClient.InvokeUnaryRPCAsync(reqCtx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream := &StorageScanStream{
+		results: make(chan *StorageScanHostResp),
+	}
+	go func() {
+		// NB: The error is set before the channel is closed, so it is
+		// safe for the receiver to read it once the channel is closed.
+		defer close(stream.results)
+		defer cancel()
+
+		for {
+			var hr *HostResponse
+			select {
+			case <-reqCtx.Done():
+				stream.err = wrapReqTimeout(req, reqCtx.Err())
+				return
+			case hr = <-hostResps:
+			}
+			if hr == nil {
+				return
+			}
+
+			result, err := newStorageScanHostResp(hr)
+			if err != nil {
+				stream.err = err
+				return
+			}
+
+			select {
+			case <-reqCtx.Done():
+				stream.err = wrapReqTimeout(req, reqCtx.Err())
+				return
+			case stream.results <- result:
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
 type (
 	// StorageFormatReq contains the parameters for a storage format request.
+	// A reformat while the system is running requires the token returned
+	// when it was armed with SystemArm.
 	StorageFormatReq struct {
 		unaryRequest
+		progressRequest
 		Reformat bool
+		Token    string
 	}
 
 	// StorageFormatResp contains the response from a storage format request.
@@ -421,6 +539,31 @@ func checkFormatReq(ctx context.Context, rpcClient UnaryInvoker, req *StorageFor
 	return nil
 }
 
+// confirmReformat consumes the token with which a reformat was armed if the
+// MS is running, so that hosts participating in a running system can't be
+// reformatted by a single mistyped command. If the MS is not running, then
+// the system has either been erased or was never formatted, and there is no
+// token to confirm.
+func confirmReformat(ctx context.Context, rpcClient UnaryInvoker, req *StorageFormatReq) error {
+	_, err := SystemQuery(ctx, rpcClient, &SystemQueryReq{FailOnUnavailable: true})
+	if err != nil {
+		if system.IsUnavailable(err) || system.IsUninitialized(err) || err == errMSConnectionFailure {
+			return nil
+		}
+		return err
+	}
+
+	if req.Token == "" {
+		return FaultFormatNotArmed
+	}
+	_, err = SystemArm(ctx, rpcClient, &SystemArmReq{
+		Operation: SystemOpFormat,
+		Confirm:   req.Token,
+	})
+
+	return err
+}
+
 // StorageFormat concurrently performs storage preparation steps across
 // all hosts supplied in the request's hostlist, or all configured hosts
 // if not explicitly specified. The function blocks until all results
@@ -430,6 +573,11 @@ func StorageFormat(ctx context.Context, rpcClient UnaryInvoker, req *StorageForm
 	if err := checkFormatReq(ctx, rpcClient, req); err != nil {
 		return nil, err
 	}
+	if req.Reformat {
+		if err := confirmReformat(ctx, rpcClient, req); err != nil {
+			return nil, err
+		}
+	}
 
 	pbReq := new(ctlpb.StorageFormatReq)
 	if err := convert.Types(req, pbReq); err != nil {
@@ -0,0 +1,279 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+const (
+	// StorageClassNvme identifies NVMe SSDs in a storage inventory.
+	StorageClassNvme = "nvme"
+	// StorageClassScm identifies SCM modules (PMem DIMMs) in a storage
+	// inventory.
+	StorageClassScm = "scm"
+)
+
+// StorageInventoryChange describes how a device differs between two storage
+// inventories.
+type StorageInventoryChange string
+
+const (
+	// StorageInventoryAdded indicates a device which is not in the
+	// previous inventory.
+	StorageInventoryAdded StorageInventoryChange = "added"
+	// StorageInventoryRemoved indicates a device which is missing from
+	// the current inventory.
+	StorageInventoryRemoved StorageInventoryChange = "removed"
+	// StorageInventoryModified indicates a device at the same location
+	// whose details (e.g. serial number) differ between inventories.
+	StorageInventoryModified StorageInventoryChange = "modified"
+)
+
+type (
+	// StorageInventoryReq contains the parameters for a storage inventory
+	// request.
+	StorageInventoryReq struct {
+		unaryRequest
+	}
+
+	// StorageInventoryDevice describes a single storage device on a host.
+	// NVMe SSDs are located by PCI address and SCM modules by
+	// socket/controller/channel/position.
+	StorageInventoryDevice struct {
+		Host     string `json:"host"`
+		Class    string `json:"class"`
+		Location string `json:"location"`
+		Model    string `json:"model"`
+		Firmware string `json:"firmware"`
+		Serial   string `json:"serial"`
+		Capacity uint64 `json:"capacity"`
+		NumaNode uint32 `json:"numa_node"`
+	}
+
+	// StorageInventoryDiff describes a difference between two storage
+	// inventories for a single device location.
+	StorageInventoryDiff struct {
+		Host     string                  `json:"host"`
+		Class    string                  `json:"class"`
+		Location string                  `json:"location"`
+		Change   StorageInventoryChange  `json:"change"`
+		Fields   []string                `json:"fields,omitempty"`
+		Previous *StorageInventoryDevice `json:"previous,omitempty"`
+		Current  *StorageInventoryDevice `json:"current,omitempty"`
+	}
+
+	// StorageInventoryResp contains the results of a storage inventory
+	// request.
+	StorageInventoryResp struct {
+		HostErrorsResp
+		Devices []*StorageInventoryDevice `json:"devices"`
+		Changes []*StorageInventoryDiff   `json:"changes,omitempty"`
+	}
+)
+
+func (sid *StorageInventoryDevice) key() string {
+	return strings.Join([]string{sid.Host, sid.Class, sid.Location}, "/")
+}
+
+// changedFields returns the names of the fields which differ between the
+// devices.
+func (sid *StorageInventoryDevice) changedFields(other *StorageInventoryDevice) []string {
+	var fields []string
+	for _, f := range []struct {
+		name    string
+		changed bool
+	}{
+		{"model", sid.Model != other.Model},
+		{"firmware", sid.Firmware != other.Firmware},
+		{"serial", sid.Serial != other.Serial},
+		{"capacity", sid.Capacity != other.Capacity},
+		{"numa_node", sid.NumaNode != other.NumaNode},
+	} {
+		if f.changed {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+func sortStorageInventory(devices []*StorageInventoryDevice) {
+	sort.Slice(devices, func(i, j int) bool {
+		a, b := devices[i], devices[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Class != b.Class {
+			return a.Class < b.Class
+		}
+		return a.Location < b.Location
+	})
+}
+
+func (sir *StorageInventoryResp) addHostDevices(hr *HostResponse) error {
+	hs, scanErrs, err := unpackStorageScanResp(hr)
+	if err != nil {
+		return err
+	}
+	for _, scanErr := range scanErrs {
+		if err := sir.addHostError(hr.Addr, scanErr); err != nil {
+			return err
+		}
+	}
+
+	for _, nc := range hs.NvmeDevices {
+		sir.Devices = append(sir.Devices, &StorageInventoryDevice{
+			Host:     hr.Addr,
+			Class:    StorageClassNvme,
+			Location: nc.PciAddr,
+			Model:    nc.Model,
+			Firmware: nc.FwRev,
+			Serial:   nc.Serial,
+			Capacity: nc.Capacity(),
+			NumaNode: uint32(nc.SocketID),
+		})
+	}
+	for _, sm := range hs.ScmModules {
+		sir.Devices = append(sir.Devices, &StorageInventoryDevice{
+			Host:  hr.Addr,
+			Class: StorageClassScm,
+			Location: fmt.Sprintf("%d/%d/%d/%d", sm.SocketID, sm.ControllerID,
+				sm.ChannelID, sm.ChannelPosition),
+			Model:    sm.PartNumber,
+			Firmware: sm.FirmwareRevision,
+			Serial:   sm.UID,
+			Capacity: sm.Capacity,
+			NumaNode: sm.SocketID,
+		})
+	}
+
+	return nil
+}
+
+// erroredHosts returns the set of hosts which reported errors.
+func (sir *StorageInventoryResp) erroredHosts() map[string]struct{} {
+	hosts := make(map[string]struct{})
+	for _, hes := range sir.HostErrors {
+		for _, host := range strings.Split(hes.HostSet.DerangedString(), ",") {
+			hosts[host] = struct{}{}
+		}
+	}
+	return hosts
+}
+
+// Compare sets the changes between the supplied previous inventory and the
+// devices in the response. Previously inventoried devices on hosts which
+// reported errors are not considered to have been removed.
+func (sir *StorageInventoryResp) Compare(previous []*StorageInventoryDevice) {
+	sir.Changes = nil
+
+	erroredHosts := sir.erroredHosts()
+	prevByKey := make(map[string]*StorageInventoryDevice)
+	for _, dev := range previous {
+		prevByKey[dev.key()] = dev
+	}
+
+	for _, dev := range sir.Devices {
+		prev, found := prevByKey[dev.key()]
+		if !found {
+			sir.Changes = append(sir.Changes, &StorageInventoryDiff{
+				Host:     dev.Host,
+				Class:    dev.Class,
+				Location: dev.Location,
+				Change:   StorageInventoryAdded,
+				Current:  dev,
+			})
+			continue
+		}
+		delete(prevByKey, dev.key())
+
+		if fields := prev.changedFields(dev); len(fields) > 0 {
+			sir.Changes = append(sir.Changes, &StorageInventoryDiff{
+				Host:     dev.Host,
+				Class:    dev.Class,
+				Location: dev.Location,
+				Change:   StorageInventoryModified,
+				Fields:   fields,
+				Previous: prev,
+				Current:  dev,
+			})
+		}
+	}
+
+	for _, prev := range prevByKey {
+		if _, errored := erroredHosts[prev.Host]; errored {
+			continue
+		}
+		sir.Changes = append(sir.Changes, &StorageInventoryDiff{
+			Host:     prev.Host,
+			Class:    prev.Class,
+			Location: prev.Location,
+			Change:   StorageInventoryRemoved,
+			Previous: prev,
+		})
+	}
+
+	sort.Slice(sir.Changes, func(i, j int) bool {
+		a, b := sir.Changes[i], sir.Changes[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Class != b.Class {
+			return a.Class < b.Class
+		}
+		return a.Location < b.Location
+	})
+}
+
+// StorageInventory concurrently performs storage scans across all hosts
+// supplied in the request's hostlist, or all configured hosts if not
+// explicitly specified, and returns the details of each NVMe SSD and SCM
+// module found. Unlike StorageScan, results are not coalesced across hosts
+// so that per-device serial numbers are retained.
+func StorageInventory(ctx context.Context, rpcClient UnaryInvoker, req *StorageInventoryReq) (*StorageInventoryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).StorageScan(ctx, &ctlpb.StorageScanReq{
+			Scm:  &ctlpb.ScanScmReq{},
+			Nvme: &ctlpb.ScanNvmeReq{},
+		})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(StorageInventoryResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := resp.addHostDevices(hostResp); err != nil {
+			return nil, err
+		}
+	}
+	sortStorageInventory(resp.Devices)
+
+	return resp, nil
+}
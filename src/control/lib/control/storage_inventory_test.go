@@ -0,0 +1,273 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func mockInventoryScanResp(t *testing.T, serials ...string) *ctlpb.StorageScanResp {
+	t.Helper()
+
+	pbResp := &ctlpb.StorageScanResp{
+		Nvme: &ctlpb.ScanNvmeResp{},
+		Scm:  &ctlpb.ScanScmResp{},
+	}
+
+	var ncs storage.NvmeControllers
+	for i, serial := range serials {
+		ncs = append(ncs, &storage.NvmeController{
+			Model:      "model",
+			Serial:     serial,
+			PciAddr:    test.MockPCIAddr(int32(i)),
+			FwRev:      "fwRev",
+			SocketID:   int32(i % 2),
+			Namespaces: []*storage.NvmeNamespace{{ID: 1, Size: humanize.TByte}},
+		})
+	}
+	if err := convert.Types(ncs, &pbResp.Nvme.Ctrlrs); err != nil {
+		t.Fatal(err)
+	}
+	if err := convert.Types(storage.ScmModules{storage.MockScmModule(1)}, &pbResp.Scm.Modules); err != nil {
+		t.Fatal(err)
+	}
+
+	return pbResp
+}
+
+func mockInventoryNvme(host string, idx int32, serial string) *StorageInventoryDevice {
+	return &StorageInventoryDevice{
+		Host:     host,
+		Class:    StorageClassNvme,
+		Location: test.MockPCIAddr(idx),
+		Model:    "model",
+		Firmware: "fwRev",
+		Serial:   serial,
+		Capacity: humanize.TByte,
+		NumaNode: uint32(idx % 2),
+	}
+}
+
+func mockInventoryScm(host string) *StorageInventoryDevice {
+	return &StorageInventoryDevice{
+		Host:     host,
+		Class:    StorageClassScm,
+		Location: "1/1/1/1",
+		Model:    "PartNumber1",
+		Firmware: "FWRev1",
+		Serial:   "Device1",
+		Capacity: humanize.GByte,
+		NumaNode: 1,
+	}
+}
+
+func TestControl_StorageInventory(t *testing.T) {
+	scmFailedResp := mockInventoryScanResp(t, "serialA")
+	scmFailedResp.Scm = &ctlpb.ScanScmResp{
+		State: &ctlpb.ResponseState{
+			Status: ctlpb.ResponseStatus_CTL_ERR_SCM,
+			Error:  "scm scan failed",
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		req     *StorageInventoryReq
+		mic     *MockInvokerConfig
+		expResp *StorageInventoryResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"invoke fails": {
+			req: &StorageInventoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			},
+			expErr: errors.New("failed"),
+		},
+		"unexpected message": {
+			req: &StorageInventoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: new(ctlpb.SetLogMasksResp),
+						},
+					},
+				},
+			},
+			expErr: errors.New("unable to unpack message"),
+		},
+		"serials retained per host": {
+			req: &StorageInventoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host2",
+							Message: mockInventoryScanResp(t, "serialC", "serialD"),
+						},
+						{
+							Addr:    "host1",
+							Message: mockInventoryScanResp(t, "serialA", "serialB"),
+						},
+					},
+				},
+			},
+			expResp: &StorageInventoryResp{
+				Devices: []*StorageInventoryDevice{
+					mockInventoryNvme("host1", 0, "serialA"),
+					mockInventoryNvme("host1", 1, "serialB"),
+					mockInventoryScm("host1"),
+					mockInventoryNvme("host2", 0, "serialC"),
+					mockInventoryNvme("host2", 1, "serialD"),
+					mockInventoryScm("host2"),
+				},
+			},
+		},
+		"host and scan failures": {
+			req: &StorageInventoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("connection refused"),
+						},
+						{
+							Addr:    "host2",
+							Message: scmFailedResp,
+						},
+					},
+				},
+			},
+			expResp: &StorageInventoryResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{Hosts: "host1", Error: "connection refused"},
+					&MockHostError{Hosts: "host2", Error: "scm scan failed"},
+				),
+				Devices: []*StorageInventoryDevice{
+					mockInventoryNvme("host2", 0, "serialA"),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, tc.mic)
+
+			gotResp, gotErr := StorageInventory(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_StorageInventoryResp_Compare(t *testing.T) {
+	swapped := mockInventoryNvme("host1", 1, "serialX")
+	swapped.Firmware = "fwRev2"
+
+	for name, tc := range map[string]struct {
+		hostErrors []*MockHostError
+		previous   []*StorageInventoryDevice
+		current    []*StorageInventoryDevice
+		expChanges []*StorageInventoryDiff
+	}{
+		"no changes": {
+			previous: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+				mockInventoryScm("host1"),
+			},
+			current: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+				mockInventoryScm("host1"),
+			},
+		},
+		"added, removed and swapped": {
+			previous: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+				mockInventoryNvme("host1", 1, "serialB"),
+				mockInventoryNvme("host2", 0, "serialC"),
+			},
+			current: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+				swapped,
+				mockInventoryScm("host2"),
+			},
+			expChanges: []*StorageInventoryDiff{
+				{
+					Host:     "host1",
+					Class:    StorageClassNvme,
+					Location: test.MockPCIAddr(1),
+					Change:   StorageInventoryModified,
+					Fields:   []string{"firmware", "serial"},
+					Previous: mockInventoryNvme("host1", 1, "serialB"),
+					Current:  swapped,
+				},
+				{
+					Host:     "host2",
+					Class:    StorageClassNvme,
+					Location: test.MockPCIAddr(0),
+					Change:   StorageInventoryRemoved,
+					Previous: mockInventoryNvme("host2", 0, "serialC"),
+				},
+				{
+					Host:     "host2",
+					Class:    StorageClassScm,
+					Location: "1/1/1/1",
+					Change:   StorageInventoryAdded,
+					Current:  mockInventoryScm("host2"),
+				},
+			},
+		},
+		"errored host not reported as removed": {
+			hostErrors: []*MockHostError{
+				{Hosts: "host2", Error: "connection refused"},
+			},
+			previous: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+				mockInventoryNvme("host2", 0, "serialC"),
+			},
+			current: []*StorageInventoryDevice{
+				mockInventoryNvme("host1", 0, "serialA"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &StorageInventoryResp{
+				HostErrorsResp: MockHostErrorsResp(t, tc.hostErrors...),
+				Devices:        tc.current,
+			}
+
+			resp.Compare(tc.previous)
+
+			if diff := cmp.Diff(tc.expChanges, resp.Changes); diff != "" {
+				t.Fatalf("unexpected changes (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
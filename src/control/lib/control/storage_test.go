@@ -7,6 +7,7 @@
 package control
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -426,6 +427,118 @@ func TestControl_StorageScan(t *testing.T) {
 	}
 }
 
+func TestControl_StreamStorageScan(t *testing.T) {
+	standard := MockServerScanResp(t, "standard")
+	nvmeFailed := MockServerScanResp(t, "nvmeFailed")
+
+	for name, tc := range map[string]struct {
+		hostResps  []*HostResponse
+		invokeErr  error
+		cancel     bool
+		expResults []*StorageScanHostResp
+		expErr     error
+	}{
+		"invoke fails": {
+			invokeErr: errors.New("failed"),
+			expErr:    errors.New("failed"),
+		},
+		"results in order received": {
+			hostResps: []*HostResponse{
+				{Addr: "host2", Message: standard},
+				{Addr: "host1", Error: errors.New("failed")},
+				{Addr: "host3", Message: nvmeFailed},
+			},
+			expResults: []*StorageScanHostResp{
+				{
+					Addr:        "host2",
+					HostStorage: mockHostStorageSet(t, "host2", standard).HostStorage,
+				},
+				{
+					Addr:   "host1",
+					Errors: []error{errors.New("failed")},
+				},
+				{
+					Addr:        "host3",
+					HostStorage: mockHostStorageSet(t, "host3", nvmeFailed).HostStorage,
+					Errors:      []error{errors.New("nvme scan failed")},
+				},
+			},
+		},
+		"nil message aborts stream": {
+			hostResps: []*HostResponse{
+				{Addr: "host1", Error: errors.New("failed")},
+				{Addr: "host2"},
+			},
+			expResults: []*StorageScanHostResp{
+				{
+					Addr:   "host1",
+					Errors: []error{errors.New("failed")},
+				},
+			},
+			expErr: errors.New("unpack"),
+		},
+		"context canceled": {
+			cancel: true,
+			expErr: context.Canceled,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ctx, cancel := context.WithCancel(test.Context(t))
+			defer cancel()
+
+			// NB: The channel is left open when the stream should
+			// be terminated by context cancellation.
+			hostResps := make(HostResponseChan, len(tc.hostResps))
+			for _, hr := range tc.hostResps {
+				hostResps <- hr
+			}
+			if !tc.cancel {
+				close(hostResps)
+			}
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				HostResponses: hostResps,
+				UnaryError:    tc.invokeErr,
+			})
+
+			stream, gotErr := StreamStorageScan(ctx, mi, &StorageScanReq{})
+			if tc.invokeErr != nil {
+				test.CmpErr(t, tc.expErr, gotErr)
+				return
+			}
+			if gotErr != nil {
+				t.Fatal(gotErr)
+			}
+			if tc.cancel {
+				cancel()
+			}
+
+			var gotResults []*StorageScanHostResp
+			for result := range stream.Results() {
+				gotResults = append(gotResults, result)
+			}
+			test.CmpErr(t, tc.expErr, stream.Err())
+
+			cmpOpts := append(defResCmpOpts(),
+				cmp.Comparer(func(x, y error) bool { return test.CmpErrBool(x, y) }))
+			if diff := cmp.Diff(tc.expResults, gotResults, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+
+			// Results must be able to be aggregated into a full response.
+			ssr := new(StorageScanResp)
+			for _, result := range gotResults {
+				if err := ssr.AddHostResult(result); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestControl_StorageFormat(t *testing.T) {
 	for name, tc := range map[string]struct {
 		mic         *MockInvokerConfig
@@ -807,6 +920,67 @@ func TestControl_checkFormatReq(t *testing.T) {
 	}
 }
 
+func TestControl_confirmReformat(t *testing.T) {
+	localServer := DefaultConfig().HostList[0]
+
+	for name, tc := range map[string]struct {
+		token     string
+		responses []*UnaryResponse
+		expErr    error
+	}{
+		"system not running": {
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, system.ErrRaftUnavail, nil),
+			},
+		},
+		"system unformatted": {
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, system.ErrUninitialized, nil),
+			},
+		},
+		"system query fails": {
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, errors.New("oops"), nil),
+			},
+			expErr: errors.New("oops"),
+		},
+		"system running; not armed": {
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, nil, &mgmtpb.SystemQueryResp{}),
+			},
+			expErr: FaultFormatNotArmed,
+		},
+		"system running; bad token": {
+			token: "bad",
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, nil, &mgmtpb.SystemQueryResp{}),
+				MockMSResponse(localServer, errors.New("token is invalid or has expired"), nil),
+			},
+			expErr: errors.New("invalid or has expired"),
+		},
+		"system running; confirmed": {
+			token: "good",
+			responses: []*UnaryResponse{
+				MockMSResponse(localServer, nil, &mgmtpb.SystemQueryResp{}),
+				MockMSResponse(localServer, nil, &mgmtpb.SystemArmResp{}),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.responses,
+			})
+
+			req := &StorageFormatReq{Reformat: true, Token: tc.token}
+			err := confirmReformat(test.Context(t), mi, req)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
 func TestControl_StorageNvmeRebind(t *testing.T) {
 	for name, tc := range map[string]struct {
 		mic         *MockInvokerConfig
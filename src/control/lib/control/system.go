@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/dustin/go-humanize/english"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
@@ -120,6 +121,8 @@ type SystemJoinReq struct {
 	InstanceIdx          uint32              `json:"idx"`
 	Incarnation          uint64              `json:"incarnation"`
 	CheckMode            bool                `json:"check_mode"`
+	ProvisioningToken    string              `json:"provisioning_token"`
+	HostID               string              `json:"host_id"`
 }
 
 // MarshalJSON packs SystemJoinResp struct into a JSON message.
@@ -184,12 +187,12 @@ func SystemJoin(ctx context.Context, rpcClient UnaryInvoker, req *SystemJoinReq)
 	req.retryTimeout = SystemJoinRetryTimeout
 	req.retryTestFn = func(err error, _ uint) bool {
 		switch {
-		case IsRetryableConnErr(err), system.IsNotReady(err):
+		case IsRetryableConnErr(err), system.IsNotReady(err), system.IsBusy(err):
 			return true
 		}
 		return err == errNoMsResponse
 	}
-	rpcClient.Debugf("DAOS system join request: %+v", pbReq)
+	rpcClient.Debugf("DAOS system join request: %s", pbUtil.Debug(pbReq))
 
 	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
 	if err != nil {
@@ -215,6 +218,7 @@ type SystemQueryReq struct {
 	FailOnUnavailable bool               // Fail without retrying if the MS is unavailable
 	NotOK             bool               // Only show engines not in a joined state
 	WantedStates      system.MemberState // Bitmask of desired states
+	EngineUsage       bool               // Include engine resource usage from member hosts
 }
 
 func (req *SystemQueryReq) getStateMask() (system.MemberState, error) {
@@ -234,8 +238,9 @@ func (req *SystemQueryReq) getStateMask() (system.MemberState, error) {
 // SystemQueryResp contains the request response.
 type SystemQueryResp struct {
 	sysResponse
-	Members   system.Members `json:"members"`
-	Providers []string       `json:"providers"`
+	Members     system.Members `json:"members"`
+	Providers   []string       `json:"providers"`
+	EngineUsage []*EngineUsage `json:"engine_usage,omitempty"`
 }
 
 // UnmarshalJSON unpacks JSON message into SystemQueryResp struct.
@@ -311,7 +316,53 @@ func SystemQuery(ctx context.Context, rpcClient UnaryInvoker, req *SystemQueryRe
 	}
 
 	resp := new(SystemQueryResp)
-	return resp, convertMSResponse(ur, resp)
+	if err := convertMSResponse(ur, resp); err != nil {
+		return resp, err
+	}
+
+	if req.EngineUsage {
+		addEngineUsage(ctx, rpcClient, resp)
+	}
+
+	return resp, nil
+}
+
+// addEngineUsage queries the hosts of the members in the response for engine
+// resource usage details. Failures to contact hosts are not considered fatal,
+// as the membership details are still valid.
+func addEngineUsage(ctx context.Context, rpcClient UnaryInvoker, resp *SystemQueryResp) {
+	hostSet := make(map[string]struct{})
+	var hosts []string
+	for _, m := range resp.Members {
+		if m.Addr == nil {
+			continue
+		}
+		if _, found := hostSet[m.Addr.String()]; !found {
+			hostSet[m.Addr.String()] = struct{}{}
+			hosts = append(hosts, m.Addr.String())
+		}
+	}
+	if len(hosts) == 0 {
+		return
+	}
+
+	euReq := new(EngineUsageQueryReq)
+	euReq.SetHostList(hosts)
+	euResp, err := EngineUsageQuery(ctx, rpcClient, euReq)
+	if err != nil {
+		rpcClient.Debugf("failed to query engine usage: %s", err)
+		return
+	}
+	if euErr := euResp.Errors(); euErr != nil {
+		rpcClient.Debugf("engine usage query: %s", euErr)
+	}
+
+	for _, eu := range euResp.Engines {
+		if eu.Rank.Equals(ranklist.NilRank) {
+			continue
+		}
+		resp.EngineUsage = append(resp.EngineUsage, eu)
+	}
 }
 
 func concatSysErrs(errSys, errRes error) error {
@@ -336,6 +387,9 @@ type SystemStartReq struct {
 	unaryRequest
 	msRequest
 	sysRequest
+	// Orderly requests a sequenced startup of the whole system following
+	// an orderly shutdown.
+	Orderly bool
 }
 
 // SystemStartResp contains the request response.
@@ -382,9 +436,10 @@ func SystemStart(ctx context.Context, rpcClient UnaryInvoker, req *SystemStartRe
 	}
 
 	pbReq := &mgmtpb.SystemStartReq{
-		Hosts: req.Hosts.String(),
-		Ranks: req.Ranks.String(),
-		Sys:   req.getSystem(rpcClient),
+		Hosts:   req.Hosts.String(),
+		Ranks:   req.Ranks.String(),
+		Sys:     req.getSystem(rpcClient),
+		Orderly: req.Orderly,
 	}
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).SystemStart(ctx, pbReq)
@@ -406,6 +461,9 @@ type SystemStopReq struct {
 	msRequest
 	sysRequest
 	Force bool
+	// Orderly requests a sequenced shutdown of the whole system that is
+	// recorded so that it can be followed by an orderly startup.
+	Orderly bool
 }
 
 // SystemStopResp contains the request response.
@@ -456,6 +514,7 @@ func SystemStop(ctx context.Context, rpcClient UnaryInvoker, req *SystemStopReq)
 	pbReq.Hosts = req.Hosts.String()
 	pbReq.Ranks = req.Ranks.String()
 	pbReq.Force = req.Force
+	pbReq.Orderly = req.Orderly
 	pbReq.Sys = req.getSystem(rpcClient)
 
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
@@ -505,6 +564,7 @@ type SystemExcludeReq struct {
 	unaryRequest
 	msRequest
 	sysRequest
+	idempotentRequest
 	Clear bool
 }
 
@@ -546,11 +606,63 @@ func SystemExclude(ctx context.Context, rpcClient UnaryInvoker, req *SystemExclu
 	return resp, convertMSResponse(ur, resp)
 }
 
+// SystemQuarantineReq contains the inputs for the system quarantine request.
+type SystemQuarantineReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	idempotentRequest
+	Clear  bool
+	Reason string
+}
+
+// SystemQuarantineResp contains the request response.
+type SystemQuarantineResp struct {
+	sysResponse
+	Results system.MemberResults
+}
+
+// Errors returns a single error combining all error messages associated with a
+// system quarantine response.
+func (resp *SystemQuarantineResp) Errors() error {
+	return concatSysErrs(resp.getAbsentHostsRanksErrors(), resp.Results.Errors())
+}
+
+// SystemQuarantine will mark the specified ranks as quarantined, excluding them
+// from new pool placements while they remain in the system for existing I/O.
+func SystemQuarantine(ctx context.Context, rpcClient UnaryInvoker, req *SystemQuarantineReq) (*SystemQuarantineResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemQuarantineReq{
+		Hosts:  req.Hosts.String(),
+		Ranks:  req.Ranks.String(),
+		Sys:    req.getSystem(rpcClient),
+		Clear:  req.Clear,
+		Reason: req.Reason,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemQuarantine(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS system quarantine request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemQuarantineResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
 // SystemEraseReq contains the inputs for a system erase request.
 type SystemEraseReq struct {
 	msRequest
 	unaryRequest
 	retryableRequest
+	// Token is the token returned by SystemArm for the erase operation.
+	Token string
 }
 
 // SystemEraseResp contains the results of a system erase request.
@@ -612,6 +724,7 @@ func SystemErase(ctx context.Context, rpcClient UnaryInvoker, req *SystemEraseRe
 
 	pbReq := new(mgmtpb.SystemEraseReq)
 	pbReq.Sys = req.getSystem(rpcClient)
+	pbReq.Token = req.Token
 
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).SystemErase(ctx, pbReq)
@@ -661,6 +774,149 @@ func SystemErase(ctx context.Context, rpcClient UnaryInvoker, req *SystemEraseRe
 	return resp, nil
 }
 
+const (
+	// SystemOpErase identifies the system erase operation when arming it.
+	SystemOpErase = "erase"
+	// SystemOpFormat identifies the storage reformat operation when arming
+	// it.
+	SystemOpFormat = "format"
+)
+
+type (
+	// SystemArmReq contains the inputs for a request to arm a destructive
+	// system operation. If Confirm is set, the token is consumed instead,
+	// which is only supported for operations not performed by the MS.
+	SystemArmReq struct {
+		unaryRequest
+		msRequest
+		Operation string
+		Confirm   string
+	}
+
+	// SystemArmResp contains the token required to perform the armed
+	// operation before it expires.
+	SystemArmResp struct {
+		Token   string    `json:"token"`
+		Expires time.Time `json:"expires"`
+	}
+)
+
+// SystemArm arms a destructive system operation. The returned token must be
+// supplied with the request to perform the operation before the token expires.
+func SystemArm(ctx context.Context, rpcClient UnaryInvoker, req *SystemArmReq) (*SystemArmResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	if req.Operation == SystemOpErase {
+		// Fail early rather than after the operation has been armed.
+		if err := checkSystemErase(ctx, rpcClient); err != nil {
+			return nil, err
+		}
+	}
+
+	pbReq := &mgmtpb.SystemArmReq{
+		Sys:       req.getSystem(rpcClient),
+		Operation: req.Operation,
+		Confirm:   req.Confirm,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemArm(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemArm request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SystemArmResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	return &SystemArmResp{
+		Token:   pbResp.Token,
+		Expires: time.Unix(pbResp.Expires, 0),
+	}, nil
+}
+
+type (
+	// PortProbeReq contains the addresses that each MS replica in the
+	// request hostlist should attempt to connect to.
+	PortProbeReq struct {
+		unaryRequest
+		Addrs []string
+	}
+
+	// PortProbeResult contains the outcome of a connection attempt made
+	// by a MS replica.
+	PortProbeResult struct {
+		Addr  string `json:"addr"`
+		Error string `json:"error,omitempty"`
+	}
+
+	// PortProbeResp contains the connection attempt results for each MS
+	// replica that handled the request, keyed by replica address.
+	PortProbeResp struct {
+		HostErrorsResp
+		Results map[string][]*PortProbeResult `json:"results"`
+	}
+)
+
+// PortProbe asks each MS replica in the request hostlist to attempt a TCP
+// connection to each of the supplied addresses. It is used by a starting
+// server to check that its ports are reachable from the MS replicas.
+func PortProbe(ctx context.Context, rpcClient UnaryInvoker, req *PortProbeReq) (*PortProbeResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.PortProbeReq{
+		Sys:   req.getSystem(rpcClient),
+		Addrs: req.Addrs,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).PortProbe(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS port probe request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PortProbeResp{
+		Results: make(map[string][]*PortProbeResult),
+	}
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*mgmtpb.PortProbeResp)
+		if !ok {
+			return nil, errors.Errorf("unexpected response type: %T", hr.Message)
+		}
+		for _, pbResult := range pbResp.Results {
+			resp.Results[hr.Addr] = append(resp.Results[hr.Addr], &PortProbeResult{
+				Addr:  pbResult.Addr,
+				Error: pbResult.Error,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
 // LeaderQueryReq contains the inputs for the leader query request.
 type LeaderQueryReq struct {
 	unaryRequest
@@ -1120,3 +1376,420 @@ func SystemGetProp(ctx context.Context, rpcClient UnaryInvoker, req *SystemGetPr
 
 	return resp, nil
 }
+
+type (
+	// SystemHistoryReq contains the inputs for the system history request.
+	SystemHistoryReq struct {
+		unaryRequest
+		msRequest
+
+		// MaxEntries limits the response to the most recent snapshots.
+		// If zero, the full history is returned.
+		MaxEntries uint32
+	}
+
+	// SystemHistoryResp contains the system health snapshots recorded
+	// by the MS leader, ordered from oldest to newest.
+	SystemHistoryResp struct {
+		Snapshots []*system.HealthSnapshot `json:"snapshots"`
+		MSBackup  *system.MSBackupStatus   `json:"ms_backup,omitempty"`
+	}
+)
+
+// SystemHistory retrieves the history of system health snapshots.
+func SystemHistory(ctx context.Context, rpcClient UnaryInvoker, req *SystemHistoryReq) (*SystemHistoryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemHistoryReq{
+		Sys:        req.getSystem(rpcClient),
+		MaxEntries: req.MaxEntries,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemHistory(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemHistory request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SystemHistoryResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	resp := &SystemHistoryResp{
+		Snapshots: make([]*system.HealthSnapshot, 0, len(pbResp.Snapshots)),
+	}
+	for _, pbSnap := range pbResp.Snapshots {
+		hs := &system.HealthSnapshot{
+			Timestamp:    time.Unix(0, pbSnap.Timestamp),
+			MemberStates: pbSnap.MemberStates,
+		}
+		if hs.MemberStates == nil {
+			hs.MemberStates = make(map[string]string)
+		}
+		for _, pbPool := range pbSnap.Pools {
+			poolUUID, err := uuid.Parse(pbPool.Uuid)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid pool UUID %q in health snapshot", pbPool.Uuid)
+			}
+			ps := &system.PoolHealthSummary{
+				PoolUUID:        poolUUID,
+				PoolLabel:       pbPool.Label,
+				State:           pbPool.State,
+				TotalTargets:    pbPool.TotalTargets,
+				DisabledTargets: pbPool.DisabledTargets,
+				RebuildState:    pbPool.RebuildState,
+				TotalBytes:      pbPool.TotalBytes,
+				FreeBytes:       pbPool.FreeBytes,
+				QueryError:      pbPool.QueryError,
+			}
+			for _, pbTier := range pbPool.Tiers {
+				ps.Tiers = append(ps.Tiers, &system.PoolTierUsage{
+					MediaType:  pbTier.MediaType,
+					TotalBytes: pbTier.TotalBytes,
+					FreeBytes:  pbTier.FreeBytes,
+				})
+			}
+			hs.Pools = append(hs.Pools, ps)
+		}
+		resp.Snapshots = append(resp.Snapshots, hs)
+	}
+
+	if pbBackup := pbResp.MsBackup; pbBackup != nil {
+		resp.MSBackup = &system.MSBackupStatus{
+			Time:  time.Unix(0, pbBackup.Time),
+			Path:  pbBackup.Path,
+			Index: pbBackup.Index,
+			Error: pbBackup.Error,
+		}
+		if pbBackup.LastSuccess != 0 {
+			resp.MSBackup.LastSuccess = time.Unix(0, pbBackup.LastSuccess)
+		}
+	}
+
+	return resp, nil
+}
+
+type (
+	// SystemEventsReq contains the inputs for the system events request.
+	SystemEventsReq struct {
+		unaryRequest
+		msRequest
+
+		// ActiveOnly excludes acknowledged events from the response.
+		ActiveOnly bool
+	}
+
+	// SystemEventsResp contains the persistent events recorded by the
+	// MS leader, ordered by ID.
+	SystemEventsResp struct {
+		Events []*system.PersistentEvent `json:"events"`
+	}
+)
+
+// SystemEvents retrieves the persistent events recorded by the MS.
+func SystemEvents(ctx context.Context, rpcClient UnaryInvoker, req *SystemEventsReq) (*SystemEventsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemEventsReq{
+		Sys:        req.getSystem(rpcClient),
+		ActiveOnly: req.ActiveOnly,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemEvents(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemEvents request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SystemEventsResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	resp := &SystemEventsResp{
+		Events: make([]*system.PersistentEvent, 0, len(pbResp.Events)),
+	}
+	for _, pbEvt := range pbResp.Events {
+		pe := &system.PersistentEvent{
+			ID:           pbEvt.Id,
+			RASID:        pbEvt.RasId,
+			Severity:     pbEvt.Severity,
+			Msg:          pbEvt.Msg,
+			Hostname:     pbEvt.Hostname,
+			Rank:         pbEvt.Rank,
+			PoolUUID:     pbEvt.PoolUuid,
+			FirstSeen:    time.Unix(0, pbEvt.FirstSeen),
+			LastSeen:     time.Unix(0, pbEvt.LastSeen),
+			Count:        pbEvt.Count,
+			Acknowledged: pbEvt.Acknowledged,
+		}
+		if pbEvt.Acknowledged {
+			pe.AckTime = time.Unix(0, pbEvt.AckTime)
+		}
+		resp.Events = append(resp.Events, pe)
+	}
+
+	return resp, nil
+}
+
+// SystemEventAckReq contains the inputs for the system event ack request.
+type SystemEventAckReq struct {
+	unaryRequest
+	msRequest
+
+	IDs []uint64
+}
+
+// SystemEventAck acknowledges persistent events so that they are no longer
+// reported as active.
+func SystemEventAck(ctx context.Context, rpcClient UnaryInvoker, req *SystemEventAckReq) error {
+	if req == nil {
+		return errors.Errorf("nil %T request", req)
+	}
+	if len(req.IDs) == 0 {
+		return errors.New("event IDs cannot be empty")
+	}
+
+	pbReq := &mgmtpb.SystemEventAckReq{
+		Sys: req.getSystem(rpcClient),
+		Ids: req.IDs,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemEventAck(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemEventAck request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(ur.getMSError(), "system event ack failed")
+}
+
+type (
+	// SystemTokenCreateReq contains the inputs for the system token create
+	// request.
+	SystemTokenCreateReq struct {
+		unaryRequest
+		msRequest
+
+		// Lifetime is how long the token remains valid. If zero, the
+		// server default is used.
+		Lifetime time.Duration
+	}
+
+	// SystemTokenCreateResp contains a signed provisioning token.
+	SystemTokenCreateResp struct {
+		Token   string    `json:"token"`
+		ID      string    `json:"id"`
+		Expires time.Time `json:"expires"`
+	}
+)
+
+// SystemTokenCreate requests a signed provisioning token that allows a server
+// to join the system for the first time.
+func SystemTokenCreate(ctx context.Context, rpcClient UnaryInvoker, req *SystemTokenCreateReq) (*SystemTokenCreateResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.Lifetime < 0 {
+		return nil, errors.New("token lifetime cannot be negative")
+	}
+
+	pbReq := &mgmtpb.SystemTokenCreateReq{
+		Sys:      req.getSystem(rpcClient),
+		Lifetime: uint64(req.Lifetime / time.Second),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemTokenCreate(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemTokenCreate request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "system token create failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SystemTokenCreateResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	return &SystemTokenCreateResp{
+		Token:   pbResp.Token,
+		ID:      pbResp.Id,
+		Expires: time.Unix(pbResp.Expires, 0),
+	}, nil
+}
+
+type (
+	// SystemRankUUIDsReq contains the inputs for the system rank UUIDs
+	// request.
+	SystemRankUUIDsReq struct {
+		unaryRequest
+		msRequest
+
+		// Ranks selects the ranks to retrieve the member UUIDs for.
+		Ranks *ranklist.RankSet
+	}
+
+	// SystemRankUUIDsResp contains the member UUIDs recorded in the MS
+	// database for the requested ranks.
+	SystemRankUUIDsResp struct {
+		UUIDs map[ranklist.Rank]uuid.UUID `json:"uuids"`
+	}
+)
+
+// SystemRankUUIDs requests the member UUIDs recorded in the MS database for
+// the given ranks. Ranks that are not system members are omitted from the
+// response.
+func SystemRankUUIDs(ctx context.Context, rpcClient UnaryInvoker, req *SystemRankUUIDsReq) (*SystemRankUUIDsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.Ranks == nil || req.Ranks.Count() == 0 {
+		return nil, errors.New("no ranks specified")
+	}
+
+	pbReq := &mgmtpb.SystemRankUUIDsReq{
+		Sys:   req.getSystem(rpcClient),
+		Ranks: ranklist.RanksToUint32(req.Ranks.Ranks()),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemRankUUIDs(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemRankUUIDs request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, errors.Wrap(err, "system rank uuids failed")
+	}
+
+	pbResp, ok := msg.(*mgmtpb.SystemRankUUIDsResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	resp := &SystemRankUUIDsResp{
+		UUIDs: make(map[ranklist.Rank]uuid.UUID),
+	}
+	for rank, id := range pbResp.Uuids {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid uuid %q for rank %d", id, rank)
+		}
+		resp.UUIDs[ranklist.Rank(rank)] = u
+	}
+
+	return resp, nil
+}
+
+type (
+	// JobStatsQueryReq contains the inputs for the job stats query request.
+	JobStatsQueryReq struct {
+		unaryRequest
+		msRequest
+
+		// JobID selects the job to retrieve totals for. If empty, the
+		// totals for all jobs are returned.
+		JobID string
+	}
+
+	// JobStats contains the system-wide totals of the per-job metrics
+	// reported by the engines for a single job.
+	JobStats struct {
+		JobID    string             `json:"job_id"`
+		NumRanks uint32             `json:"num_ranks"`
+		Metrics  map[string]float64 `json:"metrics"`
+	}
+
+	// JobStatsQueryResp contains the per-job totals aggregated by the MS
+	// leader, ordered by job ID.
+	JobStatsQueryResp struct {
+		Jobs      []*JobStats `json:"jobs"`
+		UpdatedAt time.Time   `json:"updated_at"`
+	}
+)
+
+// JobStatsQuery retrieves the system-wide I/O totals for a job or jobs.
+func JobStatsQuery(ctx context.Context, rpcClient UnaryInvoker, req *JobStatsQueryReq) (*JobStatsQueryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.JobStatsQueryReq{
+		Sys:   req.getSystem(rpcClient),
+		Jobid: req.JobID,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).JobStatsQuery(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS JobStatsQuery request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ur.getMSResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	pbResp, ok := msg.(*mgmtpb.JobStatsQueryResp)
+	if !ok {
+		return nil, errors.Errorf("unexpected response type: %T", msg)
+	}
+
+	resp := &JobStatsQueryResp{
+		Jobs: make([]*JobStats, 0, len(pbResp.Jobs)),
+	}
+	if pbResp.UpdatedAt != 0 {
+		resp.UpdatedAt = time.Unix(0, pbResp.UpdatedAt)
+	}
+	for _, pbJob := range pbResp.Jobs {
+		js := &JobStats{
+			JobID:    pbJob.Jobid,
+			NumRanks: pbJob.NumRanks,
+			Metrics:  pbJob.Metrics,
+		}
+		if js.Metrics == nil {
+			js.Metrics = make(map[string]float64)
+		}
+		resp.Jobs = append(resp.Jobs, js)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	pbUtil "github.com/daos-stack/daos/src/control/common/proto"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+type (
+	// SystemDBHashReq requests the content hash of the MS database state
+	// on each MS replica in the request hostlist.
+	SystemDBHashReq struct {
+		unaryRequest
+		Index uint64
+	}
+
+	// DBStateHash is the content hash of a replica's MS database state.
+	// An empty hash indicates that the replica's state had already
+	// advanced past the requested index, which is then the replica's
+	// current index.
+	DBStateHash struct {
+		Index uint64 `json:"index"`
+		Hash  string `json:"hash"`
+	}
+
+	// SystemDBHashResp contains the database state hashes of each MS
+	// replica that handled the request, keyed by replica address.
+	SystemDBHashResp struct {
+		HostErrorsResp
+		Hashes map[string]*DBStateHash `json:"hashes"`
+	}
+)
+
+// SystemDBHash asks each MS replica in the request hostlist for the content
+// hash of its database state as of the requested index. It is used by the MS
+// leader to compare the state of its replicas.
+func SystemDBHash(ctx context.Context, rpcClient UnaryInvoker, req *SystemDBHashReq) (*SystemDBHashResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemDBHashReq{
+		Sys:   req.getSystem(rpcClient),
+		Index: req.Index,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemDBHash(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemDBHash request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SystemDBHashResp{
+		Hashes: make(map[string]*DBStateHash),
+	}
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*mgmtpb.SystemDBHashResp)
+		if !ok {
+			return nil, errors.Errorf("unexpected response type: %T", hr.Message)
+		}
+		resp.Hashes[hr.Addr] = &DBStateHash{
+			Index: pbResp.Index,
+			Hash:  pbResp.Hash,
+		}
+	}
+
+	return resp, nil
+}
+
+type (
+	// SystemDBVerifyReq contains the inputs for the system database
+	// verification request.
+	SystemDBVerifyReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// SystemDBReplicaHash contains the database state hash of a MS
+	// replica, or the reason that it could not be retrieved.
+	SystemDBReplicaHash struct {
+		Replica string `json:"replica"`
+		Leader  bool   `json:"leader"`
+		Index   uint64 `json:"index"`
+		Hash    string `json:"hash"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	// SystemDBVerifyResp contains the database state hashes of the MS
+	// replicas as of the same index.
+	SystemDBVerifyResp struct {
+		Index    uint64                 `json:"index"`
+		Replicas []*SystemDBReplicaHash `json:"replicas"`
+	}
+)
+
+// leaderHash returns the hash of the MS leader's database state.
+func (resp *SystemDBVerifyResp) leaderHash() string {
+	for _, rh := range resp.Replicas {
+		if rh.Leader {
+			return rh.Hash
+		}
+	}
+	return ""
+}
+
+// Matches indicates whether the replica's database state is identical to
+// that of the MS leader.
+func (resp *SystemDBVerifyResp) Matches(rh *SystemDBReplicaHash) bool {
+	if resp == nil || rh == nil || rh.Error != "" {
+		return false
+	}
+	return rh.Hash != "" && rh.Hash == resp.leaderHash()
+}
+
+// Diverged returns the addresses of the replicas whose database state differs
+// from that of the MS leader. Replicas that could not be compared are not
+// included.
+func (resp *SystemDBVerifyResp) Diverged() []string {
+	if resp == nil {
+		return nil
+	}
+
+	var diverged []string
+	for _, rh := range resp.Replicas {
+		if rh.Error != "" || resp.Matches(rh) {
+			continue
+		}
+		diverged = append(diverged, rh.Replica)
+	}
+	return diverged
+}
+
+// Errors returns an error summarizing the replicas that could not be
+// compared, if any.
+func (resp *SystemDBVerifyResp) Errors() error {
+	if resp == nil {
+		return nil
+	}
+
+	var failed int
+	for _, rh := range resp.Replicas {
+		if rh.Error != "" {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return errors.Errorf("%d %s could not be verified", failed,
+		common.Pluralise("replica", failed))
+}
+
+// SystemDBVerify requests that the MS leader compares the content hashes of
+// the database state on each MS replica as of the same index, in order to
+// detect replicas whose state has silently diverged.
+func SystemDBVerify(ctx context.Context, rpcClient UnaryInvoker, req *SystemDBVerifyReq) (*SystemDBVerifyResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemDBVerifyReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemDBVerify(ctx, pbReq)
+	})
+
+	rpcClient.Debugf("DAOS SystemDBVerify request: %s", pbUtil.Debug(pbReq))
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemDBVerifyResp)
+	return resp, convertMSResponse(ur, resp)
+}
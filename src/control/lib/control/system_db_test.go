@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_SystemDBHash(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req        *SystemDBHashReq
+		uResp      *UnaryResponse
+		expHashes  map[string]*DBStateHash
+		expErr     error
+		expHostErr error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemDBHashReq request"),
+		},
+		"replica unavailable": {
+			req: &SystemDBHashReq{Index: 42},
+			uResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr:    "host1",
+						Message: &mgmtpb.SystemDBHashResp{Index: 42, Hash: "abcd"},
+					},
+					{
+						Addr:  "host2",
+						Error: errors.New("connection refused"),
+					},
+				},
+			},
+			expHashes: map[string]*DBStateHash{
+				"host1": {Index: 42, Hash: "abcd"},
+			},
+			expHostErr: errors.New("1 host had errors"),
+		},
+		"replica passed index": {
+			req: &SystemDBHashReq{Index: 42},
+			uResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr:    "host1",
+						Message: &mgmtpb.SystemDBHashResp{Index: 42, Hash: "abcd"},
+					},
+					{
+						Addr:    "host2",
+						Message: &mgmtpb.SystemDBHashResp{Index: 43},
+					},
+				},
+			},
+			expHashes: map[string]*DBStateHash{
+				"host1": {Index: 42, Hash: "abcd"},
+				"host2": {Index: 43},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemDBHash(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.CmpErr(t, tc.expHostErr, gotResp.Errors())
+			if diff := cmp.Diff(tc.expHashes, gotResp.Hashes); diff != "" {
+				t.Fatalf("unexpected hashes (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemDBVerify(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *SystemDBVerifyReq
+		uResp       *UnaryResponse
+		expResp     *SystemDBVerifyResp
+		expDiverged []string
+		expErr      error
+		expRepErr   error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemDBVerifyReq request"),
+		},
+		"rpc error": {
+			req:    new(SystemDBVerifyReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"replicas match": {
+			req: new(SystemDBVerifyReq),
+			uResp: MockMSResponse("host1", nil, &mgmtpb.SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*mgmtpb.SystemDBReplicaHash{
+					{Replica: "10.0.0.1:10001", Leader: true, Index: 42, Hash: "abcd"},
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "abcd"},
+				},
+			}),
+			expResp: &SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*SystemDBReplicaHash{
+					{Replica: "10.0.0.1:10001", Leader: true, Index: 42, Hash: "abcd"},
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "abcd"},
+				},
+			},
+		},
+		"replica diverged": {
+			req: new(SystemDBVerifyReq),
+			uResp: MockMSResponse("host1", nil, &mgmtpb.SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*mgmtpb.SystemDBReplicaHash{
+					{Replica: "10.0.0.1:10001", Leader: true, Index: 42, Hash: "abcd"},
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "ef01"},
+					{Replica: "10.0.0.3:10001", Error: "connection refused"},
+				},
+			}),
+			expResp: &SystemDBVerifyResp{
+				Index: 42,
+				Replicas: []*SystemDBReplicaHash{
+					{Replica: "10.0.0.1:10001", Leader: true, Index: 42, Hash: "abcd"},
+					{Replica: "10.0.0.2:10001", Index: 42, Hash: "ef01"},
+					{Replica: "10.0.0.3:10001", Error: "connection refused"},
+				},
+			},
+			expDiverged: []string{"10.0.0.2:10001"},
+			expRepErr:   errors.New("1 replica could not be verified"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemDBVerify(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expDiverged, gotResp.Diverged()); diff != "" {
+				t.Fatalf("unexpected diverged replicas (-want, +got):\n%s\n", diff)
+			}
+			test.CmpErr(t, tc.expRepErr, gotResp.Errors())
+		})
+	}
+}
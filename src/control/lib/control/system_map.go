@@ -0,0 +1,123 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// RankLocation describes where a system rank runs, in a form that can be
+// consumed by the topology plugins of external job schedulers.
+type RankLocation struct {
+	Rank        ranklist.Rank `json:"rank"`
+	Host        string        `json:"host"`
+	Addr        string        `json:"addr"`
+	FaultDomain string        `json:"fault_domain"`
+	NUMANode    *uint32       `json:"numa_node,omitempty"`
+	State       string        `json:"state"`
+}
+
+// SystemMapResp maps the ranks of the system to the hosts, fault domains and
+// NUMA nodes that they run on. Host errors indicate hosts whose ranks have no
+// NUMA node information.
+type SystemMapResp struct {
+	HostErrorsResp
+	Ranks []*RankLocation `json:"ranks"`
+}
+
+// memberHostname returns the name of the host the member runs on, which is
+// the bottom level of its fault domain unless a custom fault domain without
+// one has been configured, in which case its address is used instead.
+func memberHostname(m *system.Member) string {
+	if host := m.FaultDomain.BottomLevel(); host != "" {
+		return host
+	}
+	if m.Addr == nil {
+		return ""
+	}
+	return m.Addr.IP.String()
+}
+
+func newSystemMapResp(members system.Members, tunables []*EngineTunables) *SystemMapResp {
+	numaByRank := make(map[ranklist.Rank]*uint32)
+	for _, et := range tunables {
+		if et.Error != "" || et.PinnedNumaNode == nil {
+			continue
+		}
+		numaByRank[et.Rank] = et.PinnedNumaNode
+	}
+
+	resp := new(SystemMapResp)
+	for _, m := range members {
+		loc := &RankLocation{
+			Rank:        m.Rank,
+			Host:        memberHostname(m),
+			FaultDomain: m.FaultDomain.String(),
+			NUMANode:    numaByRank[m.Rank],
+			State:       m.State.String(),
+		}
+		if m.Addr != nil {
+			loc.Addr = m.Addr.String()
+		}
+		resp.Ranks = append(resp.Ranks, loc)
+	}
+	sort.Slice(resp.Ranks, func(i, j int) bool {
+		return resp.Ranks[i].Rank < resp.Ranks[j].Rank
+	})
+
+	return resp
+}
+
+// memberHostAddrs returns the unique control addresses of the hosts that the
+// members run on, in membership order.
+func memberHostAddrs(members system.Members) []string {
+	hostSet := make(map[string]struct{})
+	var hosts []string
+	for _, m := range members {
+		if m.Addr == nil {
+			continue
+		}
+		if _, found := hostSet[m.Addr.String()]; !found {
+			hostSet[m.Addr.String()] = struct{}{}
+			hosts = append(hosts, m.Addr.String())
+		}
+	}
+	return hosts
+}
+
+// SystemMap queries the system membership and the engines on the member hosts
+// to build a map of the location of each rank. Failures to contact member
+// hosts are not fatal, as the membership details are still valid.
+func SystemMap(ctx context.Context, rpcClient UnaryInvoker) (*SystemMapResp, error) {
+	queryResp, err := SystemQuery(ctx, rpcClient, new(SystemQueryReq))
+	if err != nil {
+		return nil, errors.Wrap(err, "querying system members")
+	}
+
+	hosts := memberHostAddrs(queryResp.Members)
+	if len(hosts) == 0 {
+		return newSystemMapResp(queryResp.Members, nil), nil
+	}
+
+	etReq := new(GetEngineTunablesReq)
+	etReq.SetHostList(hosts)
+	etResp, err := GetEngineTunables(ctx, rpcClient, etReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying member engines")
+	}
+
+	resp := newSystemMapResp(queryResp.Members, etResp.Engines)
+	resp.HostErrorsResp = etResp.HostErrorsResp
+
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_SystemMap(t *testing.T) {
+	numaNode := uint32(1)
+	queryResp := MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemQueryResp{
+		Members: []*mgmtpb.SystemMember{
+			{
+				Rank:        2,
+				State:       system.MemberStateExcluded.String(),
+				Addr:        "10.0.0.2:10001",
+				FaultDomain: "/rack0/node2",
+			},
+			{
+				Rank:        0,
+				State:       system.MemberStateJoined.String(),
+				Addr:        "10.0.0.1:10001",
+				FaultDomain: "/rack0/node1",
+			},
+			{
+				Rank:        1,
+				State:       system.MemberStateJoined.String(),
+				Addr:        "10.0.0.1:10001",
+				FaultDomain: "/",
+			},
+		},
+	})
+
+	for name, tc := range map[string]struct {
+		uResps  []*UnaryResponse
+		expResp *SystemMapResp
+		expErr  error
+	}{
+		"system query fails": {
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", errors.New("failed"), nil),
+			},
+			expErr: errors.New("querying system members"),
+		},
+		"no members": {
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemQueryResp{}),
+			},
+			expResp: &SystemMapResp{},
+		},
+		"one host fails": {
+			uResps: []*UnaryResponse{
+				queryResp,
+				{
+					Responses: []*HostResponse{
+						{
+							Addr: "10.0.0.1:10001",
+							Message: &ctlpb.EngineTunablesResp{
+								Engines: []*ctlpb.EngineTunables{
+									{Index: 0, Rank: 0, PinnedNumaNode: 1},
+									{Index: 1, Rank: 1, PinnedNumaNode: -1},
+								},
+							},
+						},
+						{
+							Addr:  "10.0.0.2:10001",
+							Error: errors.New("failed"),
+						},
+					},
+				},
+			},
+			expResp: &SystemMapResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "10.0.0.2:10001",
+					Error: "failed",
+				}),
+				Ranks: []*RankLocation{
+					{
+						Rank:        0,
+						Host:        "node1",
+						Addr:        "10.0.0.1:10001",
+						FaultDomain: "/rack0/node1",
+						NUMANode:    &numaNode,
+						State:       "Joined",
+					},
+					{
+						Rank:        1,
+						Host:        "10.0.0.1",
+						Addr:        "10.0.0.1:10001",
+						FaultDomain: "/",
+						State:       "Joined",
+					},
+					{
+						Rank:        2,
+						Host:        "node2",
+						Addr:        "10.0.0.2:10001",
+						FaultDomain: "/rack0/node2",
+						State:       "Excluded",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+			})
+
+			gotResp, gotErr := SystemMap(test.Context(t), mi)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// SystemSnapshotVersion is the version of the system snapshot format written by this release.
+const SystemSnapshotVersion = 1
+
+// SystemSnapshot is a point-in-time record of the system membership and pools, saved so that
+// it can later be compared with another snapshot.
+type SystemSnapshot struct {
+	Version int              `json:"version"`
+	Created time.Time        `json:"created"`
+	Members system.Members   `json:"members"`
+	Pools   []*daos.PoolInfo `json:"pools"`
+}
+
+// NewSystemSnapshot creates a snapshot from the results of a system query and pool list.
+func NewSystemSnapshot(queryResp *SystemQueryResp, poolsResp *ListPoolsResp) (*SystemSnapshot, error) {
+	if queryResp == nil {
+		return nil, errors.Errorf("nil %T", queryResp)
+	}
+	if poolsResp == nil {
+		return nil, errors.Errorf("nil %T", poolsResp)
+	}
+
+	return &SystemSnapshot{
+		Version: SystemSnapshotVersion,
+		Created: time.Now(),
+		Members: queryResp.Members,
+		Pools:   poolsResp.Pools,
+	}, nil
+}
+
+// Validate checks that the snapshot is in a format that can be consumed.
+func (ss *SystemSnapshot) Validate() error {
+	if ss == nil {
+		return errors.Errorf("nil %T", ss)
+	}
+
+	if ss.Version != SystemSnapshotVersion {
+		return errors.Errorf("unsupported system snapshot version %d (want %d)",
+			ss.Version, SystemSnapshotVersion)
+	}
+	if ss.Created.IsZero() {
+		return errors.New("system snapshot has no creation time")
+	}
+
+	return nil
+}
+
+// WriteSystemSnapshot writes the snapshot to the given file.
+func WriteSystemSnapshot(path string, ss *SystemSnapshot) error {
+	if err := ss.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(ss, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding system snapshot")
+	}
+
+	return errors.Wrapf(common.WriteFileAtomic(path, data, 0644),
+		"writing system snapshot %q", path)
+}
+
+// ReadSystemSnapshot reads and validates a system snapshot from the given file.
+func ReadSystemSnapshot(path string) (*SystemSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading system snapshot")
+	}
+
+	ss := new(SystemSnapshot)
+	if err := json.Unmarshal(data, ss); err != nil {
+		return nil, errors.Wrapf(err, "decoding system snapshot %q", path)
+	}
+
+	if err := ss.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "system snapshot %q", path)
+	}
+
+	return ss, nil
+}
+
+type (
+	// MemberChange describes the difference in a system member between two snapshots.
+	MemberChange struct {
+		Rank      ranklist.Rank `json:"rank"`
+		Addr      string        `json:"addr"`
+		FromState string        `json:"from_state"`
+		ToState   string        `json:"to_state"`
+		Restarted bool          `json:"restarted"`
+		Cause     string        `json:"cause,omitempty"`
+	}
+
+	// PoolChange describes the difference in a pool between two snapshots.
+	PoolChange struct {
+		UUID    uuid.UUID `json:"uuid"`
+		Label   string    `json:"label,omitempty"`
+		Changes []string  `json:"changes"`
+	}
+
+	// SystemDiff describes the changes in the system between two snapshots.
+	SystemDiff struct {
+		From           time.Time        `json:"from"`
+		To             time.Time        `json:"to"`
+		AddedMembers   system.Members   `json:"added_members"`
+		RemovedMembers system.Members   `json:"removed_members"`
+		MemberChanges  []*MemberChange  `json:"member_changes"`
+		AddedPools     []*daos.PoolInfo `json:"added_pools"`
+		RemovedPools   []*daos.PoolInfo `json:"removed_pools"`
+		PoolChanges    []*PoolChange    `json:"pool_changes"`
+	}
+)
+
+// IsEmpty returns true if no changes were found between the snapshots.
+func (sd *SystemDiff) IsEmpty() bool {
+	return len(sd.AddedMembers) == 0 && len(sd.RemovedMembers) == 0 &&
+		len(sd.MemberChanges) == 0 && len(sd.AddedPools) == 0 &&
+		len(sd.RemovedPools) == 0 && len(sd.PoolChanges) == 0
+}
+
+func memberAddr(m *system.Member) string {
+	if m.Addr == nil {
+		return ""
+	}
+	return m.Addr.String()
+}
+
+func diffMember(before, after *system.Member) *MemberChange {
+	restarted := after.Incarnation != before.Incarnation
+	if !restarted && after.State == before.State {
+		return nil
+	}
+
+	return &MemberChange{
+		Rank:      after.Rank,
+		Addr:      memberAddr(after),
+		FromState: before.State.String(),
+		ToState:   after.State.String(),
+		Restarted: restarted,
+		Cause:     after.StateCause,
+	}
+}
+
+func rankSetString(ranks []ranklist.Rank) string {
+	return ranklist.RankSetFromRanks(ranks).String()
+}
+
+func rebuildStateString(rs *daos.PoolRebuildStatus) string {
+	if rs == nil {
+		return "unknown"
+	}
+	return rs.State.String()
+}
+
+func diffPool(before, after *daos.PoolInfo) *PoolChange {
+	var changes []string
+	addChange := func(what, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", what, from, to))
+		}
+	}
+
+	addChange("label", before.Label, after.Label)
+	addChange("state", before.State.String(), after.State.String())
+	addChange("disabled targets", fmt.Sprintf("%d/%d", before.DisabledTargets, before.TotalTargets),
+		fmt.Sprintf("%d/%d", after.DisabledTargets, after.TotalTargets))
+	addChange("rebuild state", rebuildStateString(before.Rebuild), rebuildStateString(after.Rebuild))
+	addChange("service leader", fmt.Sprintf("%d", before.ServiceLeader),
+		fmt.Sprintf("%d", after.ServiceLeader))
+	addChange("service replicas", rankSetString(before.ServiceReplicas),
+		rankSetString(after.ServiceReplicas))
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return &PoolChange{
+		UUID:    after.UUID,
+		Label:   after.Label,
+		Changes: changes,
+	}
+}
+
+// DiffSystemSnapshots reports the member and pool changes between the before and after
+// snapshots.
+func DiffSystemSnapshots(before, after *SystemSnapshot) (*SystemDiff, error) {
+	if err := before.Validate(); err != nil {
+		return nil, err
+	}
+	if err := after.Validate(); err != nil {
+		return nil, err
+	}
+
+	sd := &SystemDiff{
+		From: before.Created,
+		To:   after.Created,
+	}
+
+	beforeMembers := make(map[ranklist.Rank]*system.Member)
+	for _, m := range before.Members {
+		beforeMembers[m.Rank] = m
+	}
+	afterMembers := make(map[ranklist.Rank]*system.Member)
+	for _, m := range after.Members {
+		afterMembers[m.Rank] = m
+
+		prev, found := beforeMembers[m.Rank]
+		if !found {
+			sd.AddedMembers = append(sd.AddedMembers, m)
+			continue
+		}
+		if mc := diffMember(prev, m); mc != nil {
+			sd.MemberChanges = append(sd.MemberChanges, mc)
+		}
+	}
+	for _, m := range before.Members {
+		if _, found := afterMembers[m.Rank]; !found {
+			sd.RemovedMembers = append(sd.RemovedMembers, m)
+		}
+	}
+
+	beforePools := make(map[uuid.UUID]*daos.PoolInfo)
+	for _, p := range before.Pools {
+		beforePools[p.UUID] = p
+	}
+	afterPools := make(map[uuid.UUID]*daos.PoolInfo)
+	for _, p := range after.Pools {
+		afterPools[p.UUID] = p
+
+		prev, found := beforePools[p.UUID]
+		if !found {
+			sd.AddedPools = append(sd.AddedPools, p)
+			continue
+		}
+		if pc := diffPool(prev, p); pc != nil {
+			sd.PoolChanges = append(sd.PoolChanges, pc)
+		}
+	}
+	for _, p := range before.Pools {
+		if _, found := afterPools[p.UUID]; !found {
+			sd.RemovedPools = append(sd.RemovedPools, p)
+		}
+	}
+
+	sort.Slice(sd.AddedMembers, func(i, j int) bool { return sd.AddedMembers[i].Rank < sd.AddedMembers[j].Rank })
+	sort.Slice(sd.RemovedMembers, func(i, j int) bool { return sd.RemovedMembers[i].Rank < sd.RemovedMembers[j].Rank })
+	sort.Slice(sd.MemberChanges, func(i, j int) bool { return sd.MemberChanges[i].Rank < sd.MemberChanges[j].Rank })
+
+	return sd, nil
+}
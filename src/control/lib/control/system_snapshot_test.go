@@ -0,0 +1,222 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func mockSnapshotPool(idx int32, state daos.PoolServiceState, disabled uint32, svcReps ...ranklist.Rank) *daos.PoolInfo {
+	return &daos.PoolInfo{
+		UUID:            test.MockPoolUUID(idx),
+		Label:           fmt.Sprintf("pool%d", idx),
+		State:           state,
+		TotalTargets:    16,
+		DisabledTargets: disabled,
+		ServiceReplicas: svcReps,
+		Rebuild:         &daos.PoolRebuildStatus{State: daos.PoolRebuildStateIdle},
+		TierStats: []*daos.StorageUsageStats{
+			{Total: 100, Free: 50, MediaType: daos.StorageMediaTypeNvme},
+		},
+	}
+}
+
+func TestControl_SystemSnapshot_ReadWrite(t *testing.T) {
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	snap, err := NewSystemSnapshot(&SystemQueryResp{
+		Members: system.Members{
+			system.MockMember(t, 0, system.MemberStateJoined),
+			system.MockMember(t, 1, system.MemberStateStopped),
+		},
+	}, &ListPoolsResp{
+		Pools: []*daos.PoolInfo{
+			mockSnapshotPool(1, daos.PoolServiceStateReady, 0, 0, 1),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(tmpDir, "snap.json")
+	if err := WriteSystemSnapshot(path, snap); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadSystemSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, snap.Created.Equal(got.Created), "unexpected creation time")
+
+	diff, err := DiffSystemSnapshots(snap, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, diff.IsEmpty(), "expected no changes after round trip")
+
+	for name, tc := range map[string]struct {
+		content string
+		expErr  error
+	}{
+		"bad json": {
+			content: "{",
+			expErr:  errors.New("decoding system snapshot"),
+		},
+		"bad version": {
+			content: `{"version":99,"created":"2024-01-01T00:00:00Z"}`,
+			expErr:  errors.New("unsupported system snapshot version 99"),
+		},
+		"no creation time": {
+			content: `{"version":1}`,
+			expErr:  errors.New("no creation time"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, name+".json")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := ReadSystemSnapshot(path)
+			test.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestControl_DiffSystemSnapshots(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	after := time.Now()
+
+	restarted := system.MockMember(t, 2, system.MemberStateJoined)
+	restarted.Incarnation = 2
+	excluded := system.MockMember(t, 1, system.MemberStateExcluded)
+	excluded.StateCause = "marked dead"
+
+	for name, tc := range map[string]struct {
+		before  *SystemSnapshot
+		after   *SystemSnapshot
+		expDiff *SystemDiff
+		expErr  error
+	}{
+		"bad version": {
+			before: &SystemSnapshot{Version: 2, Created: before},
+			after:  &SystemSnapshot{Version: SystemSnapshotVersion, Created: after},
+			expErr: errors.New("unsupported system snapshot version"),
+		},
+		"no changes": {
+			before: &SystemSnapshot{
+				Version: SystemSnapshotVersion,
+				Created: before,
+				Members: system.Members{system.MockMember(t, 0, system.MemberStateJoined)},
+				Pools:   []*daos.PoolInfo{mockSnapshotPool(1, daos.PoolServiceStateReady, 0, 0)},
+			},
+			after: &SystemSnapshot{
+				Version: SystemSnapshotVersion,
+				Created: after,
+				Members: system.Members{system.MockMember(t, 0, system.MemberStateJoined)},
+				Pools:   []*daos.PoolInfo{mockSnapshotPool(1, daos.PoolServiceStateReady, 0, 0)},
+			},
+			expDiff: &SystemDiff{From: before, To: after},
+		},
+		"member and pool changes": {
+			before: &SystemSnapshot{
+				Version: SystemSnapshotVersion,
+				Created: before,
+				Members: system.Members{
+					system.MockMember(t, 0, system.MemberStateJoined),
+					system.MockMember(t, 1, system.MemberStateJoined),
+					system.MockMember(t, 2, system.MemberStateJoined),
+					system.MockMember(t, 3, system.MemberStateJoined),
+				},
+				Pools: []*daos.PoolInfo{
+					mockSnapshotPool(1, daos.PoolServiceStateReady, 0, 0, 1, 2),
+					mockSnapshotPool(2, daos.PoolServiceStateReady, 0, 0),
+				},
+			},
+			after: &SystemSnapshot{
+				Version: SystemSnapshotVersion,
+				Created: after,
+				Members: system.Members{
+					system.MockMember(t, 4, system.MemberStateJoined),
+					restarted,
+					excluded,
+					system.MockMember(t, 0, system.MemberStateJoined),
+				},
+				Pools: []*daos.PoolInfo{
+					mockSnapshotPool(1, daos.PoolServiceStateDegraded, 4, 0, 2, 3),
+					mockSnapshotPool(3, daos.PoolServiceStateReady, 0, 0),
+				},
+			},
+			expDiff: &SystemDiff{
+				From:           before,
+				To:             after,
+				AddedMembers:   system.Members{system.MockMember(t, 4, system.MemberStateJoined)},
+				RemovedMembers: system.Members{system.MockMember(t, 3, system.MemberStateJoined)},
+				MemberChanges: []*MemberChange{
+					{
+						Rank:      1,
+						Addr:      excluded.Addr.String(),
+						FromState: "Joined",
+						ToState:   "Excluded",
+						Cause:     "marked dead",
+					},
+					{
+						Rank:      2,
+						Addr:      restarted.Addr.String(),
+						FromState: "Joined",
+						ToState:   "Joined",
+						Restarted: true,
+					},
+				},
+				AddedPools:   []*daos.PoolInfo{mockSnapshotPool(3, daos.PoolServiceStateReady, 0, 0)},
+				RemovedPools: []*daos.PoolInfo{mockSnapshotPool(2, daos.PoolServiceStateReady, 0, 0)},
+				PoolChanges: []*PoolChange{
+					{
+						UUID:  test.MockPoolUUID(1),
+						Label: "pool1",
+						Changes: []string{
+							"state: Ready -> Degraded",
+							"disabled targets: 0/16 -> 4/16",
+							"service replicas: 0-2 -> 0,2-3",
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotDiff, gotErr := DiffSystemSnapshots(tc.before, tc.after)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{
+				cmp.Comparer(func(x, y *system.Member) bool {
+					return x.Rank == y.Rank && x.State == y.State
+				}),
+			}
+			if diff := cmp.Diff(tc.expDiff, gotDiff, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected diff (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
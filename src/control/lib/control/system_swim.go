@@ -0,0 +1,205 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+type (
+	// SwimViewQueryReq contains the inputs for the SWIM view query request.
+	SwimViewQueryReq struct {
+		unaryRequest
+	}
+
+	// SwimMember is the state of a rank as seen by another rank's SWIM
+	// protocol.
+	SwimMember struct {
+		Rank        ranklist.Rank `json:"rank"`
+		State       string        `json:"state"`
+		Incarnation uint64        `json:"incarnation"`
+	}
+
+	// SwimView is the local SWIM view of the system ranks held by a rank.
+	SwimView struct {
+		Host    string        `json:"host"`
+		Index   uint32        `json:"index"`
+		Rank    ranklist.Rank `json:"rank"`
+		Members []*SwimMember `json:"members"`
+		Error   string        `json:"error,omitempty"`
+	}
+
+	// SwimViewQueryResp contains the results of a SWIM view query request.
+	SwimViewQueryResp struct {
+		HostErrorsResp
+		Views []*SwimView `json:"views"`
+	}
+)
+
+// swimStateString converts a protobuf SWIM state into the lower-case form
+// used in responses, e.g. "alive".
+func swimStateString(state ctlpb.SwimState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "SWIM_"))
+}
+
+// State returns the state of the rank in the view, or an empty string if the
+// view doesn't include it.
+func (sv *SwimView) State(rank ranklist.Rank) string {
+	for _, sm := range sv.Members {
+		if sm.Rank == rank {
+			return sm.State
+		}
+	}
+	return ""
+}
+
+// Subjects returns the ranks that appear in any of the views.
+func (resp *SwimViewQueryResp) Subjects() []ranklist.Rank {
+	rs := ranklist.NewRankSet()
+	for _, sv := range resp.Views {
+		for _, sm := range sv.Members {
+			rs.Add(sm.Rank)
+		}
+	}
+	return rs.Ranks()
+}
+
+// Disagreements returns the ranks whose state differs between the views that
+// include them, e.g. ranks that are alive to some members and dead or suspect
+// to others.
+func (resp *SwimViewQueryResp) Disagreements() []ranklist.Rank {
+	states := make(map[ranklist.Rank]map[string]struct{})
+	for _, sv := range resp.Views {
+		if sv.Error != "" {
+			continue
+		}
+		for _, sm := range sv.Members {
+			if _, found := states[sm.Rank]; !found {
+				states[sm.Rank] = make(map[string]struct{})
+			}
+			states[sm.Rank][sm.State] = struct{}{}
+		}
+	}
+
+	var out []ranklist.Rank
+	for rank, seen := range states {
+		if len(seen) > 1 {
+			out = append(out, rank)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+
+	return out
+}
+
+// MarshalJSON packs SwimViewQueryResp struct into a JSON message, including
+// the ranks that the views disagree about.
+func (resp *SwimViewQueryResp) MarshalJSON() ([]byte, error) {
+	// use a type alias to leverage the default marshal for
+	// most fields
+	type toJSON SwimViewQueryResp
+	disagreements := resp.Disagreements()
+	if disagreements == nil {
+		disagreements = []ranklist.Rank{}
+	}
+	return json.Marshal(&struct {
+		*toJSON
+		Disagreements []ranklist.Rank `json:"disagreements"`
+	}{
+		toJSON:        (*toJSON)(resp),
+		Disagreements: disagreements,
+	})
+}
+
+// SwimViewQuery will send RPC to hostlist to request the local SWIM view of
+// all DAOS engines on each host in list.
+func SwimViewQuery(ctx context.Context, rpcClient UnaryInvoker, req *SwimViewQueryReq) (*SwimViewQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).SwimViewQuery(ctx, &ctlpb.SwimViewReq{})
+	})
+	rpcClient.Debugf("DAOS SWIM view query request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SwimViewQueryResp)
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hr.Message.(*ctlpb.SwimViewQueryResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hr.Message)
+		}
+		for _, pbView := range pbResp.GetEngines() {
+			sv := &SwimView{
+				Host:    hr.Addr,
+				Index:   pbView.Index,
+				Rank:    ranklist.Rank(pbView.Rank),
+				Members: []*SwimMember{},
+				Error:   pbView.Error,
+			}
+			for _, pbMember := range pbView.GetMembers() {
+				sv.Members = append(sv.Members, &SwimMember{
+					Rank:        ranklist.Rank(pbMember.Rank),
+					State:       swimStateString(pbMember.State),
+					Incarnation: pbMember.Incarnation,
+				})
+			}
+			sort.Slice(sv.Members, func(i, j int) bool {
+				return sv.Members[i].Rank < sv.Members[j].Rank
+			})
+			resp.Views = append(resp.Views, sv)
+		}
+	}
+
+	sort.Slice(resp.Views, func(i, j int) bool {
+		return resp.Views[i].Rank < resp.Views[j].Rank
+	})
+
+	rpcClient.Debugf("DAOS SWIM view query response: %+v", resp)
+	return resp, nil
+}
+
+// SystemSwimQuery queries the system membership and collects the local SWIM
+// view of each member from the engines on the member hosts, so that ranks
+// which are seen differently by different members can be identified.
+func SystemSwimQuery(ctx context.Context, rpcClient UnaryInvoker) (*SwimViewQueryResp, error) {
+	queryResp, err := SystemQuery(ctx, rpcClient, new(SystemQueryReq))
+	if err != nil {
+		return nil, errors.Wrap(err, "querying system members")
+	}
+
+	hosts := memberHostAddrs(queryResp.Members)
+	if len(hosts) == 0 {
+		return &SwimViewQueryResp{Views: []*SwimView{}}, nil
+	}
+
+	req := new(SwimViewQueryReq)
+	req.SetHostList(hosts)
+	return SwimViewQuery(ctx, rpcClient, req)
+}
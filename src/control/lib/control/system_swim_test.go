@@ -0,0 +1,215 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_SystemSwimQuery(t *testing.T) {
+	queryResp := MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemQueryResp{
+		Members: []*mgmtpb.SystemMember{
+			{Rank: 0, State: system.MemberStateJoined.String(), Addr: "10.0.0.1:10001"},
+			{Rank: 1, State: system.MemberStateJoined.String(), Addr: "10.0.0.1:10001"},
+			{Rank: 2, State: system.MemberStateJoined.String(), Addr: "10.0.0.2:10001"},
+		},
+	})
+	pbMembers := func(states ...ctlpb.SwimState) []*ctlpb.SwimMember {
+		var out []*ctlpb.SwimMember
+		for i, state := range states {
+			out = append(out, &ctlpb.SwimMember{Rank: uint32(i), State: state, Incarnation: 1})
+		}
+		return out
+	}
+	members := func(states ...string) []*SwimMember {
+		out := []*SwimMember{}
+		for i, state := range states {
+			out = append(out, &SwimMember{Rank: ranklist.Rank(i), State: state, Incarnation: 1})
+		}
+		return out
+	}
+
+	for name, tc := range map[string]struct {
+		uResps           []*UnaryResponse
+		expResp          *SwimViewQueryResp
+		expDisagreements []ranklist.Rank
+		expErr           error
+	}{
+		"system query fails": {
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", errors.New("failed"), nil),
+			},
+			expErr: errors.New("querying system members"),
+		},
+		"no members": {
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemQueryResp{}),
+			},
+			expResp: &SwimViewQueryResp{Views: []*SwimView{}},
+		},
+		"partitioned": {
+			uResps: []*UnaryResponse{
+				queryResp,
+				{
+					Responses: []*HostResponse{
+						{
+							Addr: "10.0.0.1:10001",
+							Message: &ctlpb.SwimViewQueryResp{
+								Engines: []*ctlpb.EngineSwimView{
+									{
+										Index: 1,
+										Rank:  1,
+										Members: pbMembers(ctlpb.SwimState_SWIM_ALIVE,
+											ctlpb.SwimState_SWIM_ALIVE, ctlpb.SwimState_SWIM_DEAD),
+									},
+									{
+										Index: 0,
+										Rank:  0,
+										Members: pbMembers(ctlpb.SwimState_SWIM_ALIVE,
+											ctlpb.SwimState_SWIM_ALIVE, ctlpb.SwimState_SWIM_SUSPECT),
+									},
+								},
+							},
+						},
+						{
+							Addr: "10.0.0.2:10001",
+							Message: &ctlpb.SwimViewQueryResp{
+								Engines: []*ctlpb.EngineSwimView{
+									{Index: 0, Rank: 2, Error: "engine not ready"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &SwimViewQueryResp{
+				Views: []*SwimView{
+					{
+						Host:    "10.0.0.1:10001",
+						Index:   0,
+						Rank:    0,
+						Members: members("alive", "alive", "suspect"),
+					},
+					{
+						Host:    "10.0.0.1:10001",
+						Index:   1,
+						Rank:    1,
+						Members: members("alive", "alive", "dead"),
+					},
+					{
+						Host:    "10.0.0.2:10001",
+						Rank:    2,
+						Members: []*SwimMember{},
+						Error:   "engine not ready",
+					},
+				},
+			},
+			expDisagreements: []ranklist.Rank{2},
+		},
+		"one host fails": {
+			uResps: []*UnaryResponse{
+				queryResp,
+				{
+					Responses: []*HostResponse{
+						{
+							Addr: "10.0.0.1:10001",
+							Message: &ctlpb.SwimViewQueryResp{
+								Engines: []*ctlpb.EngineSwimView{
+									{
+										Rank: 0,
+										Members: pbMembers(ctlpb.SwimState_SWIM_ALIVE,
+											ctlpb.SwimState_SWIM_ALIVE, ctlpb.SwimState_SWIM_ALIVE),
+									},
+								},
+							},
+						},
+						{
+							Addr:  "10.0.0.2:10001",
+							Error: errors.New("failed"),
+						},
+					},
+				},
+			},
+			expResp: &SwimViewQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{
+					Hosts: "10.0.0.2:10001",
+					Error: "failed",
+				}),
+				Views: []*SwimView{
+					{
+						Host:    "10.0.0.1:10001",
+						Rank:    0,
+						Members: members("alive", "alive", "alive"),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+			})
+
+			gotResp, gotErr := SystemSwimQuery(test.Context(t), mi)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expDisagreements, gotResp.Disagreements()); diff != "" {
+				t.Fatalf("unexpected disagreements (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SwimViewQueryResp_MarshalJSON(t *testing.T) {
+	resp := &SwimViewQueryResp{
+		Views: []*SwimView{
+			{
+				Host:    "host1",
+				Rank:    0,
+				Members: []*SwimMember{{Rank: 1, State: "alive"}},
+			},
+			{
+				Host:    "host2",
+				Rank:    2,
+				Members: []*SwimMember{{Rank: 1, State: "dead"}},
+			},
+		},
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{"host_errors":{},"views":[` +
+		`{"host":"host1","index":0,"rank":0,"members":[{"rank":1,"state":"alive","incarnation":0}]},` +
+		`{"host":"host2","index":0,"rank":2,"members":[{"rank":1,"state":"dead","incarnation":0}]}],` +
+		`"disagreements":[1]}`
+	if diff := cmp.Diff(exp, string(data)); diff != "" {
+		t.Fatalf("unexpected JSON (-want, +got):\n%s\n", diff)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -13,8 +13,10 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
 	"github.com/daos-stack/daos/src/control/common/test"
@@ -509,12 +511,38 @@ func TestControl_SystemQuery(t *testing.T) {
 		fds[i] = system.MustCreateFaultDomainFromString(fdStrs[i])
 	}
 
+	queryMembers := []*mgmtpb.SystemMember{
+		{
+			Rank:        1,
+			Uuid:        test.MockUUID(1),
+			State:       system.MemberStateReady.String(),
+			Addr:        "10.0.0.1:10001",
+			FaultDomain: fdStrs[1],
+		},
+		{
+			Rank:        0,
+			Uuid:        test.MockUUID(0),
+			State:       system.MemberStateStopped.String(),
+			Addr:        "10.0.0.2:10001",
+			FaultDomain: fdStrs[0],
+		},
+	}
+	expQueryMembers := system.Members{
+		system.MockMemberFullSpec(t, 1, test.MockUUID(1), "",
+			test.MockHostAddr(1), system.MemberStateReady).
+			WithFaultDomain(fds[1]),
+		system.MockMemberFullSpec(t, 0, test.MockUUID(0), "",
+			test.MockHostAddr(2), system.MemberStateStopped).
+			WithFaultDomain(fds[0]),
+	}
+
 	for name, tc := range map[string]struct {
-		req     *SystemQueryReq
-		uErr    error
-		uResp   *UnaryResponse
-		expResp *SystemQueryResp
-		expErr  error
+		req      *SystemQueryReq
+		uErr     error
+		uResp    *UnaryResponse
+		uRespSet []*UnaryResponse
+		expResp  *SystemQueryResp
+		expErr   error
 	}{
 		"nil req": {
 			req:    nil,
@@ -599,14 +627,66 @@ func TestControl_SystemQuery(t *testing.T) {
 				},
 			},
 		},
+		"engine usage": {
+			req: &SystemQueryReq{EngineUsage: true},
+			uRespSet: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", nil,
+					&mgmtpb.SystemQueryResp{Members: queryMembers}),
+				{
+					Responses: []*HostResponse{
+						{
+							Addr: "10.0.0.1:10001",
+							Message: &ctlpb.EngineUsageResp{
+								Engines: []*ctlpb.EngineUsage{
+									{Rank: 1, Pid: 42, RssBytes: 1024},
+								},
+							},
+						},
+						{
+							Addr: "10.0.0.2:10001",
+							Message: &ctlpb.EngineUsageResp{
+								Engines: []*ctlpb.EngineUsage{
+									{Rank: uint32(ranklist.NilRank), Error: "not started"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &SystemQueryResp{
+				Members: expQueryMembers,
+				EngineUsage: []*EngineUsage{
+					{Host: "10.0.0.1:10001", Rank: 1, PID: 42, RSSBytes: 1024},
+				},
+			},
+		},
+		"engine usage; query fails": {
+			req: &SystemQueryReq{EngineUsage: true},
+			uRespSet: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", nil,
+					&mgmtpb.SystemQueryResp{Members: queryMembers}),
+				{
+					Responses: []*HostResponse{
+						{
+							Addr:  "10.0.0.1:10001",
+							Error: errors.New("failed"),
+						},
+					},
+				},
+			},
+			expResp: &SystemQueryResp{
+				Members: expQueryMembers,
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
 			defer test.ShowBufferOnFailure(t, buf)
 
 			mi := NewMockInvoker(log, &MockInvokerConfig{
-				UnaryError:    tc.uErr,
-				UnaryResponse: tc.uResp,
+				UnaryError:       tc.uErr,
+				UnaryResponse:    tc.uResp,
+				UnaryResponseSet: tc.uRespSet,
 			})
 
 			gotResp, gotErr := SystemQuery(test.Context(t), mi, tc.req)
@@ -1086,6 +1166,82 @@ func TestControl_SystemExclude(t *testing.T) {
 	}
 }
 
+func TestControl_SystemQuarantine(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemQuarantineReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemQuarantineResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemQuarantineReq request"),
+		},
+		"local failure": {
+			req:    new(SystemQuarantineReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemQuarantineReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"one quarantined one failed": {
+			req: &SystemQuarantineReq{Reason: "suspect"},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemQuarantineResp{
+					Results: []*sharedpb.RankResult{
+						{
+							Rank:  1,
+							State: system.MemberStateQuarantined.String(),
+						},
+						{
+							Rank:    2,
+							Errored: true,
+							Msg:     "illegal member state update",
+							State:   system.MemberStateStopped.String(),
+						},
+					},
+				},
+			),
+			expResp: &SystemQuarantineResp{
+				Results: system.MemberResults{
+					system.NewMemberResult(1, nil, system.MemberStateQuarantined),
+					{
+						Rank:    2,
+						Errored: true,
+						Msg:     "illegal member state update",
+						State:   system.MemberStateStopped,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemQuarantine(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{cmpopts.IgnoreUnexported(SystemQuarantineResp{}, system.MemberResult{})}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestDmg_System_checkSystemErase(t *testing.T) {
 	for name, tc := range map[string]struct {
 		uErr, expErr error
@@ -1345,12 +1501,159 @@ func TestControl_SystemErase(t *testing.T) {
 	}
 }
 
+func TestControl_SystemArm(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		req     *SystemArmReq
+		uResps  []*UnaryResponse
+		expResp *SystemArmResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemArmReq request"),
+		},
+		"erase with running ranks": {
+			req: &SystemArmReq{Operation: SystemOpErase},
+			uResps: []*UnaryResponse{
+				MockMSResponse("host1", nil, &mgmtpb.SystemQueryResp{
+					Members: []*mgmtpb.SystemMember{
+						{Rank: 0, State: system.MemberStateJoined.String()},
+					},
+				}),
+			},
+			expErr: errors.New("requires the following 1 rank to be stopped"),
+		},
+		"remote failure": {
+			req: &SystemArmReq{Operation: SystemOpErase},
+			uResps: []*UnaryResponse{
+				MockMSResponse("host1", nil, &mgmtpb.SystemQueryResp{}),
+				MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("remote failed"),
+		},
+		"success": {
+			req: &SystemArmReq{Operation: SystemOpErase},
+			uResps: []*UnaryResponse{
+				MockMSResponse("host1", nil, &mgmtpb.SystemQueryResp{}),
+				MockMSResponse("host1", nil, &mgmtpb.SystemArmResp{
+					Token:   "token",
+					Expires: expires.Unix(),
+				}),
+			},
+			expResp: &SystemArmResp{
+				Token:   "token",
+				Expires: expires,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+			})
+
+			gotResp, gotErr := SystemArm(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_PortProbe(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req        *PortProbeReq
+		uResp      *UnaryResponse
+		expResults map[string][]*PortProbeResult
+		expErr     error
+		expHostErr error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.PortProbeReq request"),
+		},
+		"replica unavailable": {
+			req: &PortProbeReq{Addrs: []string{"10.0.0.1:10001"}},
+			uResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr: "host1",
+						Message: &mgmtpb.PortProbeResp{
+							Results: []*mgmtpb.PortProbeResult{
+								{Addr: "10.0.0.1:10001"},
+							},
+						},
+					},
+					{
+						Addr:  "host2",
+						Error: errors.New("connection refused"),
+					},
+				},
+			},
+			expResults: map[string][]*PortProbeResult{
+				"host1": {{Addr: "10.0.0.1:10001"}},
+			},
+			expHostErr: errors.New("1 host had errors"),
+		},
+		"probe failures": {
+			req: &PortProbeReq{Addrs: []string{"10.0.0.1:10001", "10.0.0.1:31416"}},
+			uResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{
+						Addr: "host1",
+						Message: &mgmtpb.PortProbeResp{
+							Results: []*mgmtpb.PortProbeResult{
+								{Addr: "10.0.0.1:10001"},
+								{Addr: "10.0.0.1:31416", Error: "i/o timeout"},
+							},
+						},
+					},
+				},
+			},
+			expResults: map[string][]*PortProbeResult{
+				"host1": {
+					{Addr: "10.0.0.1:10001"},
+					{Addr: "10.0.0.1:31416", Error: "i/o timeout"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := PortProbe(test.Context(t), mi, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.CmpErr(t, tc.expHostErr, gotResp.Errors())
+			if diff := cmp.Diff(tc.expResults, gotResp.Results); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_SystemJoin_RetryableErrors(t *testing.T) {
 	for name, testErr := range map[string]error{
 		"system not formatted": system.ErrUninitialized,
 		"system unavailable":   system.ErrRaftUnavail,
 		"connection closed":    FaultConnectionClosed(""),
 		"connection refused":   FaultConnectionRefused(""),
+		"MS busy":              &system.ErrBusy{Operation: "join", RetryAfter: time.Millisecond},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(name)
@@ -1585,3 +1888,507 @@ func TestControl_SystemGetAttr(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_SystemHistory(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *SystemHistoryReq
+		mic     *MockInvokerConfig
+		expResp *SystemHistoryResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &SystemHistoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"bad pool uuid": {
+			req: &SystemHistoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+						Snapshots: []*mgmtpb.SystemHealthSnapshot{
+							{
+								Timestamp: ts.UnixNano(),
+								Pools: []*mgmtpb.SystemHealthSnapshot_PoolSummary{
+									{Uuid: "bad"},
+								},
+							},
+						},
+					}),
+				},
+			},
+			expErr: errors.New("invalid pool UUID"),
+		},
+		"no snapshots": {
+			req: &SystemHistoryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{}),
+				},
+			},
+			expResp: &SystemHistoryResp{
+				Snapshots: []*system.HealthSnapshot{},
+			},
+		},
+		"success": {
+			req: &SystemHistoryReq{MaxEntries: 1},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{
+						Snapshots: []*mgmtpb.SystemHealthSnapshot{
+							{
+								Timestamp: ts.UnixNano(),
+								MemberStates: map[string]string{
+									"Joined":  "0-2",
+									"Stopped": "3",
+								},
+								Pools: []*mgmtpb.SystemHealthSnapshot_PoolSummary{
+									{
+										Uuid:            test.MockUUID(1),
+										Label:           "pool1",
+										State:           "Degraded",
+										TotalTargets:    32,
+										DisabledTargets: 8,
+										RebuildState:    "busy",
+										TotalBytes:      100,
+										FreeBytes:       40,
+										Tiers: []*mgmtpb.SystemHealthSnapshot_TierUsage{
+											{MediaType: "scm", TotalBytes: 10, FreeBytes: 4},
+											{MediaType: "nvme", TotalBytes: 90, FreeBytes: 36},
+										},
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+			expResp: &SystemHistoryResp{
+				Snapshots: []*system.HealthSnapshot{
+					{
+						Timestamp: time.Unix(0, ts.UnixNano()),
+						MemberStates: map[string]string{
+							"Joined":  "0-2",
+							"Stopped": "3",
+						},
+						Pools: []*system.PoolHealthSummary{
+							{
+								PoolUUID:        test.MockPoolUUID(1),
+								PoolLabel:       "pool1",
+								State:           "Degraded",
+								TotalTargets:    32,
+								DisabledTargets: 8,
+								RebuildState:    "busy",
+								TotalBytes:      100,
+								FreeBytes:       40,
+								Tiers: []*system.PoolTierUsage{
+									{MediaType: "scm", TotalBytes: 10, FreeBytes: 4},
+									{MediaType: "nvme", TotalBytes: 90, FreeBytes: 36},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemHistory(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_JobStatsQuery(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *JobStatsQueryReq
+		mic     *MockInvokerConfig
+		expResp *JobStatsQueryResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &JobStatsQueryReq{JobID: "job1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"no jobs": {
+			req: &JobStatsQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.JobStatsQueryResp{}),
+				},
+			},
+			expResp: &JobStatsQueryResp{
+				Jobs: []*JobStats{},
+			},
+		},
+		"success": {
+			req: &JobStatsQueryReq{JobID: "job1"},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.JobStatsQueryResp{
+						Jobs: []*mgmtpb.JobStats{
+							{
+								Jobid:    "job1",
+								NumRanks: 4,
+								Metrics: map[string]float64{
+									"io_update_bytes": 4096,
+									"io_fetch_bytes":  1024,
+								},
+							},
+							{
+								Jobid: "job2",
+							},
+						},
+						UpdatedAt: ts.UnixNano(),
+					}),
+				},
+			},
+			expResp: &JobStatsQueryResp{
+				Jobs: []*JobStats{
+					{
+						JobID:    "job1",
+						NumRanks: 4,
+						Metrics: map[string]float64{
+							"io_update_bytes": 4096,
+							"io_fetch_bytes":  1024,
+						},
+					},
+					{
+						JobID:   "job2",
+						Metrics: map[string]float64{},
+					},
+				},
+				UpdatedAt: time.Unix(0, ts.UnixNano()),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := JobStatsQuery(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemEvents(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *SystemEventsReq
+		mic     *MockInvokerConfig
+		expResp *SystemEventsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"req fails": {
+			req: &SystemEventsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("error"), nil),
+				},
+			},
+			expErr: errors.New("error"),
+		},
+		"no events": {
+			req: &SystemEventsReq{ActiveOnly: true},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemEventsResp{}),
+				},
+			},
+			expResp: &SystemEventsResp{
+				Events: []*system.PersistentEvent{},
+			},
+		},
+		"success": {
+			req: &SystemEventsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemEventsResp{
+						Events: []*mgmtpb.SystemEvent{
+							{
+								Id:        1,
+								RasId:     "engine_died",
+								Severity:  "ERROR",
+								Msg:       "engine died",
+								Hostname:  "foo",
+								Rank:      2,
+								FirstSeen: ts.UnixNano(),
+								LastSeen:  ts.UnixNano(),
+								Count:     3,
+							},
+							{
+								Id:           2,
+								RasId:        "engine_died",
+								Hostname:     "bar",
+								FirstSeen:    ts.UnixNano(),
+								LastSeen:     ts.UnixNano(),
+								Count:        1,
+								Acknowledged: true,
+								AckTime:      ts.UnixNano(),
+							},
+						},
+					}),
+				},
+			},
+			expResp: &SystemEventsResp{
+				Events: []*system.PersistentEvent{
+					{
+						ID:        1,
+						RASID:     "engine_died",
+						Severity:  "ERROR",
+						Msg:       "engine died",
+						Hostname:  "foo",
+						Rank:      2,
+						FirstSeen: time.Unix(0, ts.UnixNano()),
+						LastSeen:  time.Unix(0, ts.UnixNano()),
+						Count:     3,
+					},
+					{
+						ID:           2,
+						RASID:        "engine_died",
+						Hostname:     "bar",
+						FirstSeen:    time.Unix(0, ts.UnixNano()),
+						LastSeen:     time.Unix(0, ts.UnixNano()),
+						Count:        1,
+						Acknowledged: true,
+						AckTime:      time.Unix(0, ts.UnixNano()),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemEvents(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemEventAck(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req    *SystemEventAckReq
+		mic    *MockInvokerConfig
+		expErr error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"no ids": {
+			req:    &SystemEventAckReq{},
+			expErr: errors.New("cannot be empty"),
+		},
+		"req fails": {
+			req: &SystemEventAckReq{IDs: []uint64{1}},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("unable to find event"), nil),
+				},
+			},
+			expErr: errors.New("unable to find event"),
+		},
+		"success": {
+			req: &SystemEventAckReq{IDs: []uint64{1, 2}},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.DaosResp{}),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotErr := SystemEventAck(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestControl_SystemTokenCreate(t *testing.T) {
+	expires := time.Unix(1700000000, 0)
+
+	for name, tc := range map[string]struct {
+		req     *SystemTokenCreateReq
+		mic     *MockInvokerConfig
+		expResp *SystemTokenCreateResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"negative lifetime": {
+			req:    &SystemTokenCreateReq{Lifetime: -time.Hour},
+			expErr: errors.New("cannot be negative"),
+		},
+		"req fails": {
+			req: &SystemTokenCreateReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("exceeds maximum"), nil),
+				},
+			},
+			expErr: errors.New("exceeds maximum"),
+		},
+		"success": {
+			req: &SystemTokenCreateReq{Lifetime: time.Hour},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemTokenCreateResp{
+						Token:   "daospt1.abc.def",
+						Id:      "0123456789abcdef",
+						Expires: expires.Unix(),
+					}),
+				},
+			},
+			expResp: &SystemTokenCreateResp{
+				Token:   "daospt1.abc.def",
+				ID:      "0123456789abcdef",
+				Expires: expires,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemTokenCreate(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemRankUUIDs(t *testing.T) {
+	uuid0 := test.MockUUID(0)
+	uuid1 := test.MockUUID(1)
+
+	for name, tc := range map[string]struct {
+		req     *SystemRankUUIDsReq
+		mic     *MockInvokerConfig
+		expResp *SystemRankUUIDsResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil"),
+		},
+		"no ranks": {
+			req:    &SystemRankUUIDsReq{},
+			expErr: errors.New("no ranks"),
+		},
+		"req fails": {
+			req: &SystemRankUUIDsReq{Ranks: ranklist.MustCreateRankSet("0-1")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", errors.New("not leader"), nil),
+				},
+			},
+			expErr: errors.New("not leader"),
+		},
+		"bad uuid": {
+			req: &SystemRankUUIDsReq{Ranks: ranklist.MustCreateRankSet("0")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemRankUUIDsResp{
+						Uuids: map[uint32]string{0: "bad"},
+					}),
+				},
+			},
+			expErr: errors.New("invalid uuid"),
+		},
+		"success": {
+			req: &SystemRankUUIDsReq{Ranks: ranklist.MustCreateRankSet("0-2")},
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("", nil, &mgmtpb.SystemRankUUIDsResp{
+						Uuids: map[uint32]string{0: uuid0, 1: uuid1},
+					}),
+				},
+			},
+			expResp: &SystemRankUUIDsResp{
+				UUIDs: map[ranklist.Rank]uuid.UUID{
+					0: uuid.MustParse(uuid0),
+					1: uuid.MustParse(uuid1),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			client := NewMockInvoker(log, tc.mic)
+			gotResp, gotErr := SystemRankUUIDs(test.Context(t), client, tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
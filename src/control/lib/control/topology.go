@@ -0,0 +1,155 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// TopologySnapshotVersion is the version of the topology snapshot format written by this
+// release.
+const TopologySnapshotVersion = 1
+
+// TopologySnapshot is a point-in-time record of the NUMA, CPU, fabric and storage hardware
+// detected on a server. Snapshots allow configs to be generated and checked for a server
+// without access to it.
+type TopologySnapshot struct {
+	Version  int                `json:"version"`
+	Hostname string             `json:"hostname"`
+	Created  time.Time          `json:"created"`
+	Topology *hardware.Topology `json:"topology"`
+	Fabric   *HostFabric        `json:"fabric"`
+	Storage  *HostStorage       `json:"storage"`
+}
+
+// Validate checks that the snapshot is complete and in a format that can be consumed.
+func (ts *TopologySnapshot) Validate() error {
+	if ts == nil {
+		return errors.Errorf("nil %T", ts)
+	}
+
+	if ts.Version != TopologySnapshotVersion {
+		return errors.Errorf("unsupported topology snapshot version %d (want %d)",
+			ts.Version, TopologySnapshotVersion)
+	}
+	if ts.Hostname == "" {
+		return errors.New("topology snapshot has no hostname")
+	}
+	if ts.Topology == nil || ts.Topology.NumNUMANodes() == 0 {
+		return errors.Errorf("topology snapshot for %s has no NUMA nodes", ts.Hostname)
+	}
+	if ts.Fabric == nil {
+		return errors.Errorf("topology snapshot for %s has no fabric details", ts.Hostname)
+	}
+	if ts.Storage == nil {
+		return errors.Errorf("topology snapshot for %s has no storage details", ts.Hostname)
+	}
+
+	return nil
+}
+
+// ReadTopologySnapshot reads and validates a topology snapshot from the given file.
+func ReadTopologySnapshot(path string) (*TopologySnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading topology snapshot")
+	}
+
+	ts := new(TopologySnapshot)
+	if err := json.Unmarshal(data, ts); err != nil {
+		return nil, errors.Wrapf(err, "decoding topology snapshot %q", path)
+	}
+
+	if err := ts.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "topology snapshot %q", path)
+	}
+
+	return ts, nil
+}
+
+// hostFabric returns the fabric details recorded in the snapshot, taking NUMA details from the
+// recorded topology if they are missing.
+func (ts *TopologySnapshot) hostFabric() *HostFabric {
+	hf := *ts.Fabric
+	if hf.NumaCount == 0 {
+		hf.NumaCount = uint32(ts.Topology.NumNUMANodes())
+	}
+	if hf.CoresPerNuma == 0 {
+		hf.CoresPerNuma = uint32(ts.Topology.NumCoresPerNUMA())
+	}
+
+	return &hf
+}
+
+// hostStorage returns the storage details recorded in the snapshot, trimmed in the same way as
+// a basic storage scan so that hosts with different models of SSD are considered homogeneous.
+func (ts *TopologySnapshot) hostStorage() *HostStorage {
+	hs := *ts.Storage
+	hs.SmdInfo = nil
+	hs.NvmeDevices = make(storage.NvmeControllers, 0, len(ts.Storage.NvmeDevices))
+	for _, nc := range ts.Storage.NvmeDevices {
+		basic := *nc
+		basic.HealthStats = nil
+		basic.SmdDevices = nil
+		basic.Serial = ""
+		basic.Model = ""
+		basic.FwRev = ""
+		hs.NvmeDevices = append(hs.NvmeDevices, &basic)
+	}
+
+	return &hs
+}
+
+// topologySnapshotHosts returns the hostnames of the given snapshots, which must be unique.
+func topologySnapshotHosts(snaps []*TopologySnapshot) ([]string, error) {
+	seen := common.NewStringSet()
+	hosts := make([]string, 0, len(snaps))
+	for _, ts := range snaps {
+		if err := ts.Validate(); err != nil {
+			return nil, err
+		}
+		if seen.Has(ts.Hostname) {
+			return nil, errors.Errorf("multiple topology snapshots for host %s", ts.Hostname)
+		}
+		seen.Add(ts.Hostname)
+		hosts = append(hosts, ts.Hostname)
+	}
+
+	return hosts, nil
+}
+
+// snapshotFabricMap groups the fabric details recorded in the given snapshots by host.
+func snapshotFabricMap(snaps []*TopologySnapshot) (HostFabricMap, error) {
+	hfm := make(HostFabricMap)
+	for _, ts := range snaps {
+		if err := hfm.Add(ts.Hostname, ts.hostFabric()); err != nil {
+			return nil, err
+		}
+	}
+
+	return hfm, nil
+}
+
+// snapshotStorageMap groups the storage details recorded in the given snapshots by host.
+func snapshotStorageMap(snaps []*TopologySnapshot) (HostStorageMap, error) {
+	hsm := make(HostStorageMap)
+	for _, ts := range snaps {
+		if err := hsm.Add(ts.Hostname, ts.hostStorage()); err != nil {
+			return nil, err
+		}
+	}
+
+	return hsm, nil
+}
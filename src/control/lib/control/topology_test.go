@@ -0,0 +1,218 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func mockTopologySnapshot(hostname string) *TopologySnapshot {
+	return &TopologySnapshot{
+		Version:  TopologySnapshotVersion,
+		Hostname: hostname,
+		Topology: &hardware.Topology{
+			NUMANodes: hardware.NodeMap{
+				0: hardware.MockNUMANode(0, 8),
+				1: hardware.MockNUMANode(1, 8, 8),
+			},
+		},
+		Fabric: &HostFabric{
+			Interfaces: []*HostFabricInterface{ib0, ib1},
+			Providers:  []string{"ofi+psm2"},
+		},
+		Storage: &HostStorage{
+			NvmeDevices:   storage.MockNvmeControllers(2),
+			ScmNamespaces: storage.ScmNamespaces{storage.MockScmNamespace(0)},
+			MemInfo:       MockMemInfo(),
+		},
+	}
+}
+
+func TestControl_ReadTopologySnapshot(t *testing.T) {
+	for name, tc := range map[string]struct {
+		snap     *TopologySnapshot
+		contents string
+		expErr   error
+	}{
+		"missing file": {
+			expErr: errors.New("no such file"),
+		},
+		"bad JSON": {
+			contents: "{",
+			expErr:   errors.New("decoding topology snapshot"),
+		},
+		"unsupported version": {
+			snap: func() *TopologySnapshot {
+				ts := mockTopologySnapshot("host1")
+				ts.Version = TopologySnapshotVersion + 1
+				return ts
+			}(),
+			expErr: errors.New("unsupported topology snapshot version"),
+		},
+		"no hostname": {
+			snap:   mockTopologySnapshot(""),
+			expErr: errors.New("no hostname"),
+		},
+		"no NUMA nodes": {
+			snap: func() *TopologySnapshot {
+				ts := mockTopologySnapshot("host1")
+				ts.Topology = &hardware.Topology{}
+				return ts
+			}(),
+			expErr: errors.New("no NUMA nodes"),
+		},
+		"no storage": {
+			snap: func() *TopologySnapshot {
+				ts := mockTopologySnapshot("host1")
+				ts.Storage = nil
+				return ts
+			}(),
+			expErr: errors.New("no storage details"),
+		},
+		"success": {
+			snap: mockTopologySnapshot("host1"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "topology.json")
+			contents := []byte(tc.contents)
+			if tc.snap != nil {
+				var err error
+				if contents, err = json.Marshal(tc.snap); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(contents) > 0 {
+				if err := os.WriteFile(path, contents, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotSnap, gotErr := ReadTopologySnapshot(path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{
+				test.CmpOptIgnoreFieldAnyType("NUMANode"),
+				cmpopts.EquateEmpty(),
+			}
+			if diff := cmp.Diff(tc.snap, gotSnap, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected snapshot (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_snapshots(t *testing.T) {
+	otherSSDs := mockTopologySnapshot("host2")
+	for _, nc := range otherSSDs.Storage.NvmeDevices {
+		nc.Model = "other-model"
+		nc.Serial = "other-serial"
+		nc.FwRev = "other-fw"
+	}
+	otherFabric := mockTopologySnapshot("host2")
+	otherFabric.Fabric = &HostFabric{
+		Interfaces: []*HostFabricInterface{eth0},
+		Providers:  []string{"ofi+tcp"},
+	}
+	fewerSSDs := mockTopologySnapshot("host2")
+	fewerSSDs.Storage.NvmeDevices = fewerSSDs.Storage.NvmeDevices[:1]
+
+	for name, tc := range map[string]struct {
+		hostList   []string
+		snaps      []*TopologySnapshot
+		expErr     error
+		expHostSet *hostlist.HostSet
+	}{
+		"hosts and snapshots": {
+			hostList: []string{"host1"},
+			snaps:    []*TopologySnapshot{mockTopologySnapshot("host1")},
+			expErr:   errors.New("may not be specified with topology snapshots"),
+		},
+		"invalid snapshot": {
+			snaps:  []*TopologySnapshot{mockTopologySnapshot("")},
+			expErr: errors.New("no hostname"),
+		},
+		"duplicate hosts": {
+			snaps: []*TopologySnapshot{
+				mockTopologySnapshot("host1"),
+				mockTopologySnapshot("host1"),
+			},
+			expErr: errors.New("multiple topology snapshots for host host1"),
+		},
+		"network mismatch": {
+			snaps:  []*TopologySnapshot{mockTopologySnapshot("host1"), otherFabric},
+			expErr: errors.New("network hardware not consistent across hosts"),
+		},
+		"storage mismatch": {
+			snaps:  []*TopologySnapshot{mockTopologySnapshot("host1"), fewerSSDs},
+			expErr: errors.New("storage hardware not consistent across hosts"),
+		},
+		"different SSD models": {
+			snaps:      []*TopologySnapshot{mockTopologySnapshot("host1"), otherSSDs},
+			expHostSet: hostlist.MustCreateSet("host[1-2]"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			req := ConfGenerateRemoteReq{
+				HostList:  tc.hostList,
+				Snapshots: tc.snaps,
+				Client: NewMockInvoker(log, &MockInvokerConfig{
+					UnaryError: errors.New("unexpected scan"),
+				}),
+			}
+			req.AccessPoints = []string{"host1"}
+			req.Log = log
+
+			if tc.expErr != nil {
+				_, gotErr := ConfGenerateRemote(test.Context(t), req)
+				test.CmpErr(t, tc.expErr, gotErr)
+				return
+			}
+
+			req.HostList = []string{"host1", "host2"}
+			netSet, err := getNetworkSet(test.Context(t), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expHostSet.String(), netSet.HostSet.String(),
+				"unexpected network host set")
+			test.AssertEqual(t, uint32(2), netSet.HostFabric.NumaCount,
+				"expected NUMA count from topology")
+			test.AssertEqual(t, uint32(8), netSet.HostFabric.CoresPerNuma,
+				"expected cores per NUMA from topology")
+
+			storageSet, err := getStorageSet(test.Context(t), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expHostSet.String(), storageSet.HostSet.String(),
+				"unexpected storage host set")
+			for _, nc := range storageSet.HostStorage.NvmeDevices {
+				test.AssertEqual(t, "", nc.Model, "expected trimmed NVMe details")
+			}
+		})
+	}
+}
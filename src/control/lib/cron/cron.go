@@ -0,0 +1,232 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package cron parses standard 5-field cron schedule specifications and
+// computes their activation times.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxSearchYears limits the search for the next activation time, so that
+// schedules that can never activate (e.g. "0 0 30 2 *") are detected.
+const maxSearchYears = 5
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// fieldSpec describes the values allowed in a schedule field.
+type fieldSpec struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var fieldSpecs = []fieldSpec{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// Both 0 and 7 are Sunday.
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// Schedule is a parsed cron schedule. Each field is a bitmask of the values
+// at which the schedule activates.
+type Schedule struct {
+	spec    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a schedule of the form "minute hour day-of-month month
+// day-of-week". Each field is "*" or a comma-separated list of values or
+// ranges ("a-b"), optionally followed by a step ("*/15", "1-23/2"). Months
+// and days of the week may be given by their abbreviated names, e.g. "jan"
+// or "mon". The descriptors @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if strings.HasPrefix(expanded, "@") {
+		var found bool
+		if expanded, found = descriptors[strings.ToLower(expanded)]; !found {
+			return nil, errors.Errorf("unknown schedule descriptor %q", spec)
+		}
+	}
+
+	fields := strings.Fields(expanded)
+	if len(fields) != len(fieldSpecs) {
+		return nil, errors.Errorf("schedule %q has %d fields (expected %d)", spec, len(fields),
+			len(fieldSpecs))
+	}
+
+	masks := make([]uint64, len(fields))
+	for i, f := range fields {
+		var err error
+		if masks[i], err = parseField(f, fieldSpecs[i]); err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule %q", spec)
+		}
+	}
+
+	s := &Schedule{
+		spec:    strings.TrimSpace(spec),
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+
+	return s, nil
+}
+
+func parseValue(s string, fs fieldSpec) (int, error) {
+	if v, found := fs.names[strings.ToLower(s)]; found {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < fs.min || v > fs.max {
+		return 0, errors.Errorf("invalid %s %q (expected %d-%d)", fs.name, s, fs.min, fs.max)
+	}
+	return v, nil
+}
+
+// parseField returns the bitmask of the values matched by the field.
+func parseField(f string, fs fieldSpec) (uint64, error) {
+	var mask uint64
+
+	for _, term := range strings.Split(f, ",") {
+		rng, step := term, 1
+		if i := strings.Index(term, "/"); i >= 0 {
+			var err error
+			rng = term[:i]
+			if step, err = strconv.Atoi(term[i+1:]); err != nil || step < 1 {
+				return 0, errors.Errorf("invalid step in %s %q", fs.name, term)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*":
+			lo, hi = fs.min, fs.max
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], fs); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(bounds[1], fs); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, errors.Errorf("invalid %s range %q", fs.name, rng)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rng, fs); err != nil {
+				return 0, err
+			}
+			hi = lo
+			// A single value with a step runs to the end of the range.
+			if rng != term {
+				hi = fs.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+
+	return mask, nil
+}
+
+func has(mask uint64, v int) bool {
+	return mask&(1<<uint(v)) != 0
+}
+
+// String returns the specification from which the schedule was parsed.
+func (s *Schedule) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.spec
+}
+
+// dayMatches returns true if the schedule activates on the day of the given
+// time. As in cron, if both the day of the month and the day of the week are
+// restricted, a day matching either of them matches.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first activation time of the schedule after the given
+// time, in its location. The zero time is returned if the schedule never
+// activates.
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,166 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestCron_Parse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec   string
+		expErr error
+	}{
+		"every minute": {
+			spec: "* * * * *",
+		},
+		"lists ranges and steps": {
+			spec: "0,30 1-5/2 */10 jan-jun mon-fri",
+		},
+		"sunday as 7": {
+			spec: "0 0 * * 7",
+		},
+		"descriptor": {
+			spec: "@Daily",
+		},
+		"empty": {
+			expErr: errors.New("has 0 fields"),
+		},
+		"too many fields": {
+			spec:   "0 0 * * * *",
+			expErr: errors.New("has 6 fields"),
+		},
+		"unknown descriptor": {
+			spec:   "@fortnightly",
+			expErr: errors.New("unknown schedule descriptor"),
+		},
+		"minute out of range": {
+			spec:   "60 * * * *",
+			expErr: errors.New("invalid minute \"60\""),
+		},
+		"day of month out of range": {
+			spec:   "0 0 0 * *",
+			expErr: errors.New("invalid day of month"),
+		},
+		"unknown month name": {
+			spec:   "0 0 1 foo *",
+			expErr: errors.New("invalid month \"foo\""),
+		},
+		"reversed range": {
+			spec:   "0 5-1 * * *",
+			expErr: errors.New("invalid hour range"),
+		},
+		"bad step": {
+			spec:   "*/0 * * * *",
+			expErr: errors.New("invalid step"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, gotErr := Parse(tc.spec)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, tc.spec, s.String(), "unexpected spec")
+		})
+	}
+}
+
+func TestCron_Schedule_Next(t *testing.T) {
+	// Wednesday
+	start := time.Date(2024, time.May, 15, 10, 17, 42, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		spec    string
+		after   time.Time
+		expNext time.Time
+	}{
+		"every minute": {
+			spec:    "* * * * *",
+			expNext: time.Date(2024, time.May, 15, 10, 18, 0, 0, time.UTC),
+		},
+		"every 15 minutes": {
+			spec:    "*/15 * * * *",
+			expNext: time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC),
+		},
+		"on the minute is not included": {
+			spec:    "*/15 * * * *",
+			after:   time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC),
+			expNext: time.Date(2024, time.May, 15, 10, 45, 0, 0, time.UTC),
+		},
+		"daily": {
+			spec:    "@daily",
+			expNext: time.Date(2024, time.May, 16, 0, 0, 0, 0, time.UTC),
+		},
+		"later today": {
+			spec:    "30 2,22 * * *",
+			expNext: time.Date(2024, time.May, 15, 22, 30, 0, 0, time.UTC),
+		},
+		"weekly on sunday as 7": {
+			spec:    "0 3 * * 7",
+			expNext: time.Date(2024, time.May, 19, 3, 0, 0, 0, time.UTC),
+		},
+		"weekdays by name": {
+			spec:    "0 1 * * sat,mon",
+			expNext: time.Date(2024, time.May, 18, 1, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			spec:    "0 0 20 * fri",
+			expNext: time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month and any day of week": {
+			spec:    "0 0 20 * *",
+			expNext: time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			spec:    "0 0 1 jan *",
+			expNext: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap day": {
+			spec:    "0 0 29 2 *",
+			expNext: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec: "0 0 30 feb *",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			after := tc.after
+			if after.IsZero() {
+				after = start
+			}
+			got := s.Next(after)
+			if !got.Equal(tc.expNext) {
+				t.Fatalf("expected next activation %s, got %s", tc.expNext, got)
+			}
+		})
+	}
+}
+
+func TestCron_Schedule_Next_Location(t *testing.T) {
+	loc := time.FixedZone("UTC+5", 5*60*60)
+	s, err := Parse("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.Next(time.Date(2024, time.May, 15, 1, 0, 0, 0, loc))
+	exp := time.Date(2024, time.May, 15, 2, 0, 0, 0, loc)
+	if !got.Equal(exp) {
+		t.Fatalf("expected next activation %s, got %s", exp, got)
+	}
+}
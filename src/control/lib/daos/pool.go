@@ -348,6 +348,18 @@ func (smt StorageMediaType) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + smt.String() + `"`), nil
 }
 
+func (smt *StorageMediaType) UnmarshalJSON(data []byte) error {
+	typeStr := strings.ToUpper(strings.Trim(string(data), "\""))
+
+	mediaType, err := unmarshalStrVal(typeStr, mgmtpb.StorageMediaType_value, mgmtpb.StorageMediaType_name)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal StorageMediaType")
+	}
+	*smt = StorageMediaType(mediaType)
+
+	return nil
+}
+
 // PoolRebuildState indicates the current state of the pool rebuild process.
 type PoolRebuildState int32
 
@@ -373,7 +385,7 @@ func (prs PoolRebuildState) MarshalJSON() ([]byte, error) {
 }
 
 func (prs *PoolRebuildState) UnmarshalJSON(data []byte) error {
-	stateStr := strings.ToUpper(string(data))
+	stateStr := strings.ToUpper(strings.Trim(string(data), "\""))
 
 	state, err := unmarshalStrVal(stateStr, mgmtpb.PoolRebuildStatus_State_value, mgmtpb.PoolRebuildStatus_State_name)
 	if err != nil {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022-2023 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -303,6 +303,7 @@ func (sp SystemPropertyKey) String() string {
 		SystemPropertyDaosSystem:      "daos_system",
 		SystemPropertyPoolScrubMode:   "pool_scrub_mode",
 		SystemPropertyPoolScrubThresh: "pool_scrub_thresh",
+		SystemPropertyAutoReintegrate: "auto_reintegrate",
 	}[sp]; found {
 		return str
 	}
@@ -342,6 +343,9 @@ const (
 	SystemPropertyPoolScrubMode
 	// SystemPropertyPoolScrubThresh sets or retrieves the scrubbing error threshold for each pool in the system.
 	SystemPropertyPoolScrubThresh
+	// SystemPropertyAutoReintegrate sets or retrieves whether ranks that rejoin after
+	// being excluded are automatically reintegrated, if enabled in the server config.
+	SystemPropertyAutoReintegrate
 	// NB: This must be the last entry.
 	systemPropertyMax
 )
@@ -504,5 +508,10 @@ func SystemProperties() SystemPropertyMap {
 		},
 		SystemPropertyPoolScrubThresh: pph2sp(SystemPropertyPoolScrubThresh, poolProps["scrub_thresh"], "0"),
 		SystemPropertyPoolScrubMode:   pph2sp(SystemPropertyPoolScrubMode, poolProps["scrub"], "off"),
+		SystemPropertyAutoReintegrate: SystemProperty{
+			Key:         SystemPropertyAutoReintegrate,
+			Value:       NewBoolPropVal(true),
+			Description: "Automatically reintegrate rejoining excluded ranks",
+		},
 	}
 }
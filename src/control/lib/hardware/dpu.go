@@ -0,0 +1,78 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"regexp"
+)
+
+// DPUFunction indicates the role of a PCI function that belongs to a DPU (SmartNIC).
+type DPUFunction uint
+
+const (
+	// DPUFunctionNone indicates a device that is not part of a DPU.
+	DPUFunctionNone DPUFunction = iota
+	// DPUFunctionNetwork indicates a DPU function that carries traffic to and from the
+	// network, i.e. a host-side data path or a DPU-side uplink port.
+	DPUFunctionNetwork
+	// DPUFunctionHost indicates a DPU function that only faces the host, i.e. a management
+	// interface or a DPU-side representor of a host function.
+	DPUFunctionHost
+)
+
+func (f DPUFunction) String() string {
+	switch f {
+	case DPUFunctionNetwork:
+		return "network-facing"
+	case DPUFunctionHost:
+		return "host-facing"
+	}
+
+	return "none"
+}
+
+const (
+	pciVendorMellanox = 0x15b3
+	pciVendorPensando = 0x1dd8
+)
+
+type pciID struct {
+	vendor uint16
+	device uint16
+}
+
+// dpuPCIIDs maps the PCI IDs of known DPU functions to their role.
+var dpuPCIIDs = map[pciID]DPUFunction{
+	{pciVendorMellanox, 0xa2d2}: DPUFunctionNetwork, // BlueField integrated ConnectX-5
+	{pciVendorMellanox, 0xa2d6}: DPUFunctionNetwork, // BlueField-2 integrated ConnectX-6 Dx
+	{pciVendorMellanox, 0xa2dc}: DPUFunctionNetwork, // BlueField-3 integrated ConnectX-7
+	{pciVendorMellanox, 0xc2d2}: DPUFunctionHost,    // BlueField SoC management interface
+	{pciVendorMellanox, 0xc2d3}: DPUFunctionHost,    // BlueField-2 SoC management interface
+	{pciVendorMellanox, 0xc2d5}: DPUFunctionHost,    // BlueField-3 SoC management interface
+	{pciVendorPensando, 0x1002}: DPUFunctionNetwork, // DSC Ethernet controller
+	{pciVendorPensando, 0x1004}: DPUFunctionHost,    // DSC management controller
+}
+
+// uplinkPortName matches the physical port name of an eswitch uplink representor (e.g. "p0").
+// Other representors (e.g. "pf0", "pf0vf1", "c1pf0") stand in for host functions.
+var uplinkPortName = regexp.MustCompile(`^p[0-9]+$`)
+
+// GetDPUFunction determines the role of a PCI function from its vendor and device IDs. When
+// run on the DPU itself, the eswitch physical port name (if any) distinguishes the uplinks from
+// the representors of host functions.
+func GetDPUFunction(vendorID, deviceID uint16, physPortName string) DPUFunction {
+	fn, found := dpuPCIIDs[pciID{vendor: vendorID, device: deviceID}]
+	if !found {
+		return DPUFunctionNone
+	}
+
+	if fn == DPUFunctionNetwork && physPortName != "" && !uplinkPortName.MatchString(physPortName) {
+		return DPUFunctionHost
+	}
+
+	return fn
+}
@@ -0,0 +1,71 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestHardware_GetDPUFunction(t *testing.T) {
+	for name, tc := range map[string]struct {
+		vendor   uint16
+		device   uint16
+		portName string
+		expFn    DPUFunction
+	}{
+		"ConnectX-6": {
+			vendor: 0x15b3,
+			device: 0x101b,
+			expFn:  DPUFunctionNone,
+		},
+		"ConnectX-6 uplink representor": {
+			vendor:   0x15b3,
+			device:   0x101b,
+			portName: "p0",
+			expFn:    DPUFunctionNone,
+		},
+		"BlueField-2 host PF": {
+			vendor: 0x15b3,
+			device: 0xa2d6,
+			expFn:  DPUFunctionNetwork,
+		},
+		"BlueField-2 uplink": {
+			vendor:   0x15b3,
+			device:   0xa2d6,
+			portName: "p1",
+			expFn:    DPUFunctionNetwork,
+		},
+		"BlueField-2 host PF representor": {
+			vendor:   0x15b3,
+			device:   0xa2d6,
+			portName: "pf0",
+			expFn:    DPUFunctionHost,
+		},
+		"BlueField-3 host VF representor": {
+			vendor:   0x15b3,
+			device:   0xa2dc,
+			portName: "pf0vf3",
+			expFn:    DPUFunctionHost,
+		},
+		"BlueField-3 management": {
+			vendor: 0x15b3,
+			device: 0xc2d5,
+			expFn:  DPUFunctionHost,
+		},
+		"Pensando DSC": {
+			vendor: 0x1dd8,
+			device: 0x1002,
+			expFn:  DPUFunctionNetwork,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expFn, GetDPUFunction(tc.vendor, tc.device, tc.portName), "")
+		})
+	}
+}
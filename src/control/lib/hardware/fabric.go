@@ -199,6 +199,8 @@ type FabricInterface struct {
 	DeviceClass NetDevClass `json:"device_class"`
 	// NUMANode is the NUMA affinity of the network interface.
 	NUMANode uint `json:"numa_node"`
+	// DPUFunction is the role of the DPU function backing the interface, if any.
+	DPUFunction DPUFunction `json:"dpu_function,omitempty"`
 }
 
 func (fi *FabricInterface) String() string {
@@ -226,7 +228,20 @@ func (fi *FabricInterface) String() string {
 		providers = fi.Providers.String()
 	}
 
-	return fmt.Sprintf("%s%s%s (providers: %s)", name, osName, netIF, providers)
+	var dpu string
+	if fi.IsDPUBacked() {
+		dpu = fmt.Sprintf(" (DPU: %s)", fi.DPUFunction)
+	}
+
+	return fmt.Sprintf("%s%s%s (providers: %s)%s", name, osName, netIF, providers, dpu)
+}
+
+// IsDPUBacked reports whether the FabricInterface is backed by a DPU function.
+func (fi *FabricInterface) IsDPUBacked() bool {
+	if fi == nil {
+		return false
+	}
+	return fi.DPUFunction != DPUFunctionNone
 }
 
 // SupportsProvider reports whether the FabricInterface supports the given provider string. If the
@@ -295,6 +310,9 @@ func (s fabricInterfaceMap) update(name string, fi *FabricInterface) {
 		if cur.DeviceClass == NetDevClass(0) {
 			cur.DeviceClass = fi.DeviceClass
 		}
+		if cur.DPUFunction == DPUFunctionNone {
+			cur.DPUFunction = fi.DPUFunction
+		}
 
 		// always possible to add to providers or net interfaces
 		if fi.Providers != nil {
@@ -800,6 +818,73 @@ func newNUMAAffinityBuilder(log logging.Logger, topo *Topology) *NUMAAffinityBui
 	}
 }
 
+// DPUBuilder is a builder that updates FabricInterfaces with the role of the DPU function backing
+// them, if any.
+type DPUBuilder struct {
+	log  logging.Logger
+	topo *Topology
+}
+
+// BuildPart updates existing FabricInterface structures in the set to indicate whether they are
+// backed by a DPU. Interfaces backed by host-facing DPU functions can't reach the fabric and are
+// removed from the set.
+func (d *DPUBuilder) BuildPart(ctx context.Context, fis *FabricInterfaceSet) error {
+	if d == nil {
+		return errors.New("DPUBuilder is nil")
+	}
+
+	if fis == nil {
+		return errors.New("FabricInterfaceSet is nil")
+	}
+
+	if d.topo == nil {
+		return errors.New("DPUBuilder is uninitialized")
+	}
+
+	devsByName := d.topo.AllDevices()
+
+	for _, name := range fis.Names() {
+		fi, err := fis.GetInterface(name)
+		if err != nil {
+			d.log.Errorf("can't update interface %s: %s", name, err.Error())
+			continue
+		}
+
+		topoName, err := fi.TopologyName()
+		if err != nil {
+			d.log.Errorf("can't get topology name for %q: %s", name, err.Error())
+			continue
+		}
+
+		dev, exists := devsByName[topoName]
+		if !exists {
+			continue
+		}
+
+		pciDev := dev.PCIDevice()
+		if pciDev == nil || pciDev.DPUFunction == DPUFunctionNone {
+			continue
+		}
+
+		if pciDev.DPUFunction == DPUFunctionHost {
+			d.log.Debugf("ignoring fabric interface %q backed by host-facing DPU function %s",
+				name, &pciDev.PCIAddr)
+			fis.Remove(name)
+			continue
+		}
+
+		fi.DPUFunction = pciDev.DPUFunction
+	}
+	return nil
+}
+
+func newDPUBuilder(log logging.Logger, topo *Topology) *DPUBuilder {
+	return &DPUBuilder{
+		log:  log,
+		topo: topo,
+	}
+}
+
 // NetDevClassBuilder is a builder updates FabricInterfaces with a NetDevClass.
 type NetDevClassBuilder struct {
 	log      logging.Logger
@@ -855,6 +940,7 @@ type FabricInterfaceSetBuilderConfig struct {
 	Providers                []string
 	FabricInterfaceProviders []FabricInterfaceProvider
 	NetDevClassProvider      NetDevClassProvider
+	InterfaceFilter          *FabricInterfaceFilter
 }
 
 func defaultFabricInterfaceSetBuilders(log logging.Logger, config *FabricInterfaceSetBuilderConfig) []FabricInterfaceSetBuilder {
@@ -863,6 +949,8 @@ func defaultFabricInterfaceSetBuilders(log logging.Logger, config *FabricInterfa
 		newNetworkDeviceBuilder(log, config.Topology),
 		newNetDevClassBuilder(log, config.NetDevClassProvider),
 		newNUMAAffinityBuilder(log, config.Topology),
+		newDPUBuilder(log, config.Topology),
+		newInterfaceFilterBuilder(log, config.Topology, config.InterfaceFilter),
 	}
 }
 
@@ -871,6 +959,7 @@ type FabricScannerConfig struct {
 	TopologyProvider         TopologyProvider
 	FabricInterfaceProviders []FabricInterfaceProvider
 	NetDevClassProvider      NetDevClassProvider
+	InterfaceFilter          *FabricInterfaceFilter
 }
 
 // Validate checks if the FabricScannerConfig is valid.
@@ -891,7 +980,7 @@ func (c *FabricScannerConfig) Validate() error {
 		return errors.New("NetDevClassProvider is required")
 	}
 
-	return nil
+	return c.InterfaceFilter.Validate()
 }
 
 // FabricScanner is a type that scans the system for fabric interfaces.
@@ -937,6 +1026,7 @@ func (s *FabricScanner) init(ctx context.Context, providers []string) error {
 			Providers:                providers,
 			FabricInterfaceProviders: s.config.FabricInterfaceProviders,
 			NetDevClassProvider:      s.config.NetDevClassProvider,
+			InterfaceFilter:          s.config.InterfaceFilter,
 		})
 	return nil
 }
@@ -1015,10 +1105,29 @@ func (s *FabricScanner) CacheTopology(t *Topology) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	if s.topo != t {
+		// Builders hold the topology they were created with.
+		s.builders = nil
+	}
 	s.topo = t
 	return nil
 }
 
+// Reset discards the cached topology and builders so that the next scan
+// rediscovers the hardware, e.g. after a fabric interface has been added.
+func (s *FabricScanner) Reset() error {
+	if s == nil {
+		return errors.New("FabricScanner is nil")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.topo = nil
+	s.builders = nil
+	return nil
+}
+
 // NetDevState describes the state of a network device.
 type NetDevState int
 
@@ -0,0 +1,180 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// mgmtNICMaxLinkSpeed is the PCIe link bandwidth (GB/s) below which an
+// Ethernet device is assumed to be an onboard management NIC, such as one
+// shared with the BMC. Such NICs are 1GbE devices on x1 to x4 PCIe Gen2
+// links, whereas fabric-capable NICs have at least x8 links.
+const mgmtNICMaxLinkSpeed = 2.5
+
+// DefaultMgmtIfacePatterns are the patterns matching the names of interfaces
+// that are excluded from fabric scans by default, as they are virtual bridges
+// and tunnels used by container, VM and overlay networks.
+var DefaultMgmtIfacePatterns = []string{
+	"docker*", "virbr*", "br-*", "veth*", "cni*", "flannel*", "cali*",
+	"vxlan*", "tun*", "tap*", "podman*", "lxcbr*",
+}
+
+// FabricInterfaceFilter contains patterns used to exclude interfaces that
+// should not be used for the fabric (e.g. management or provisioning
+// networks) from the results of fabric scans.
+type FabricInterfaceFilter struct {
+	// Exclude patterns are matched in addition to the defaults.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Include patterns override any exclusion, including the heuristics.
+	Include []string `yaml:"include,omitempty"`
+}
+
+// Validate checks that the filter patterns are well-formed.
+func (f *FabricInterfaceFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	for _, patterns := range [][]string{f.Exclude, f.Include} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Errorf("invalid fabric interface pattern %q", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WithInclude returns a copy of the filter including the named interfaces.
+func (f *FabricInterfaceFilter) WithInclude(names ...string) *FabricInterfaceFilter {
+	out := new(FabricInterfaceFilter)
+	if f != nil {
+		out.Exclude = append(out.Exclude, f.Exclude...)
+		out.Include = append(out.Include, f.Include...)
+	}
+	for _, name := range names {
+		if name != "" {
+			out.Include = append(out.Include, name)
+		}
+	}
+
+	return out
+}
+
+func ifaceNames(fi *FabricInterface) []string {
+	names := []string{fi.Name}
+	if fi.OSName != "" {
+		names = append(names, fi.OSName)
+	}
+	return append(names, fi.NetInterfaces.ToSlice()...)
+}
+
+func matchIface(patterns []string, fi *FabricInterface) string {
+	for _, name := range ifaceNames(fi) {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return pattern
+			}
+		}
+	}
+	return ""
+}
+
+// InterfaceFilterBuilder is a builder that removes management, provisioning and
+// virtual interfaces from the FabricInterfaceSet.
+type InterfaceFilterBuilder struct {
+	log    logging.Logger
+	topo   *Topology
+	filter *FabricInterfaceFilter
+}
+
+// BuildPart removes interfaces matching the default or configured exclusion
+// patterns from the set, and any Ethernet interfaces that appear to be
+// onboard management NICs, unless they match an include pattern. The latter
+// are only removed if other interfaces remain, so that hosts with nothing
+// but a management network can still be used.
+func (b *InterfaceFilterBuilder) BuildPart(ctx context.Context, fis *FabricInterfaceSet) error {
+	if b == nil {
+		return errors.New("InterfaceFilterBuilder is nil")
+	}
+
+	if fis == nil {
+		return errors.New("FabricInterfaceSet is nil")
+	}
+
+	if b.topo == nil {
+		return errors.New("InterfaceFilterBuilder is uninitialized")
+	}
+
+	filter := b.filter
+	if filter == nil {
+		filter = new(FabricInterfaceFilter)
+	}
+	exclude := append(append([]string{}, DefaultMgmtIfacePatterns...), filter.Exclude...)
+	devsByName := b.topo.AllDevices()
+
+	var mgmtNICs []string
+	for _, name := range fis.Names() {
+		fi, err := fis.GetInterface(name)
+		if err != nil {
+			b.log.Errorf("can't filter interface %s: %s", name, err.Error())
+			continue
+		}
+
+		if pattern := matchIface(filter.Include, fi); pattern != "" {
+			b.log.Tracef("keeping fabric interface %q matching include pattern %q", name, pattern)
+			continue
+		}
+
+		if pattern := matchIface(exclude, fi); pattern != "" {
+			b.log.Debugf("ignoring fabric interface %q matching exclude pattern %q", name, pattern)
+			fis.Remove(name)
+			continue
+		}
+
+		if fi.DeviceClass != Ether {
+			continue
+		}
+		topoName, err := fi.TopologyName()
+		if err != nil {
+			continue
+		}
+		dev, exists := devsByName[topoName]
+		if !exists {
+			continue
+		}
+		if pciDev := dev.PCIDevice(); pciDev != nil && pciDev.LinkSpeed > 0 &&
+			pciDev.LinkSpeed < mgmtNICMaxLinkSpeed {
+			mgmtNICs = append(mgmtNICs, name)
+		}
+	}
+
+	if len(mgmtNICs) == fis.NumFabricInterfaces() {
+		return nil
+	}
+	for _, name := range mgmtNICs {
+		b.log.Debugf("ignoring fabric interface %q on a low-bandwidth PCIe link (likely a management NIC)", name)
+		fis.Remove(name)
+	}
+
+	return nil
+}
+
+func newInterfaceFilterBuilder(log logging.Logger, topo *Topology, filter *FabricInterfaceFilter) *InterfaceFilterBuilder {
+	return &InterfaceFilterBuilder{
+		log:    log,
+		topo:   topo,
+		filter: filter,
+	}
+}
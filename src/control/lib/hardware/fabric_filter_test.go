@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestHardware_FabricInterfaceFilter_WithInclude(t *testing.T) {
+	var nilFilter *FabricInterfaceFilter
+	test.AssertEqual(t, &FabricInterfaceFilter{Include: []string{"ib0"}},
+		nilFilter.WithInclude("ib0", ""), "unexpected filter")
+
+	filter := &FabricInterfaceFilter{
+		Exclude: []string{"eth0.*"},
+		Include: []string{"eth1"},
+	}
+	out := filter.WithInclude("ib0")
+	test.AssertEqual(t, &FabricInterfaceFilter{
+		Exclude: []string{"eth0.*"},
+		Include: []string{"eth1", "ib0"},
+	}, out, "unexpected filter")
+	test.AssertEqual(t, []string{"eth1"}, filter.Include, "original filter modified")
+}
+
+func TestHardware_InterfaceFilterBuilder_BuildPart(t *testing.T) {
+	testTopo := &Topology{
+		NUMANodes: map[uint]*NUMANode{
+			0: MockNUMANode(0, 8).WithDevices([]*PCIDevice{
+				mockPCIDevice("eno", 1).withType(DeviceTypeNetInterface).withLinkSpeed(0.5),
+				mockPCIDevice("ens", 2).withType(DeviceTypeNetInterface).withLinkSpeed(7.88),
+				mockPCIDevice("ib", 3).withType(DeviceTypeNetInterface).withLinkSpeed(1),
+			}),
+		},
+	}
+	newSet := func(names ...string) *FabricInterfaceSet {
+		set := NewFabricInterfaceSet()
+		for _, name := range names {
+			class := Ether
+			if name == "ib03" {
+				class = Infiniband
+			}
+			set.Update(&FabricInterface{
+				Name:          name,
+				NetInterfaces: common.NewStringSet(name),
+				DeviceClass:   class,
+			})
+		}
+		return set
+	}
+
+	for name, tc := range map[string]struct {
+		builder   *InterfaceFilterBuilder
+		set       *FabricInterfaceSet
+		expResult *FabricInterfaceSet
+		expErr    error
+	}{
+		"nil builder": {
+			set:       NewFabricInterfaceSet(),
+			expErr:    errors.New("InterfaceFilterBuilder is nil"),
+			expResult: NewFabricInterfaceSet(),
+		},
+		"uninit": {
+			builder:   &InterfaceFilterBuilder{},
+			set:       NewFabricInterfaceSet(),
+			expErr:    errors.New("uninitialized"),
+			expResult: NewFabricInterfaceSet(),
+		},
+		"nil set": {
+			builder: newInterfaceFilterBuilder(nil, &Topology{}, nil),
+			expErr:  errors.New("FabricInterfaceSet is nil"),
+		},
+		"defaults": {
+			builder:   newInterfaceFilterBuilder(nil, testTopo, nil),
+			set:       newSet("docker0", "virbr0", "eno01", "ens02", "ib03"),
+			expResult: newSet("ens02", "ib03"),
+		},
+		"only management NIC kept": {
+			builder:   newInterfaceFilterBuilder(nil, testTopo, nil),
+			set:       newSet("docker0", "eno01"),
+			expResult: newSet("eno01"),
+		},
+		"configured exclude": {
+			builder: newInterfaceFilterBuilder(nil, testTopo, &FabricInterfaceFilter{
+				Exclude: []string{"ens*"},
+			}),
+			set:       newSet("ens02", "ens02.100", "ib03"),
+			expResult: newSet("ib03"),
+		},
+		"include overrides": {
+			builder: newInterfaceFilterBuilder(nil, testTopo, &FabricInterfaceFilter{
+				Exclude: []string{"ens*"},
+				Include: []string{"eno01", "ens02", "docker*"},
+			}),
+			set:       newSet("docker0", "eno01", "ens02", "ens02.100", "ib03"),
+			expResult: newSet("docker0", "eno01", "ens02", "ib03"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			if tc.builder != nil {
+				tc.builder.log = log
+			}
+
+			err := tc.builder.BuildPart(test.Context(t), tc.set)
+
+			test.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expResult, tc.set, fabricCmpOpts()...); diff != "" {
+				t.Fatalf("(-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -1292,6 +1292,19 @@ func TestHardware_FabricScannerConfig_IsValid(t *testing.T) {
 			},
 			expErr: errors.New("FabricInterfaceProvider is required"),
 		},
+		"bad interface filter": {
+			config: &FabricScannerConfig{
+				TopologyProvider: &MockTopologyProvider{},
+				FabricInterfaceProviders: []FabricInterfaceProvider{
+					&MockFabricInterfaceProvider{},
+				},
+				NetDevClassProvider: &MockNetDevClassProvider{},
+				InterfaceFilter: &FabricInterfaceFilter{
+					Exclude: []string{"eth[0"},
+				},
+			},
+			expErr: errors.New("invalid fabric interface pattern"),
+		},
 		"multiple FabricInterfaceProviders": {
 			config: &FabricScannerConfig{
 				TopologyProvider: &MockTopologyProvider{},
@@ -1740,10 +1753,13 @@ func TestHardware_FabricScanner_Scan(t *testing.T) {
 }
 
 func TestHardware_FabricScanner_CacheTopology(t *testing.T) {
+	sameTopo := &Topology{}
+
 	for name, tc := range map[string]struct {
-		fs     *FabricScanner
-		topo   *Topology
-		expErr error
+		fs          *FabricScanner
+		topo        *Topology
+		expBuilders int
+		expErr      error
 	}{
 		"nil": {
 			topo:   &Topology{},
@@ -1765,9 +1781,18 @@ func TestHardware_FabricScanner_CacheTopology(t *testing.T) {
 						1: MockNUMANode(1, 8),
 					},
 				},
+				builders: []FabricInterfaceSetBuilder{&MockFabricInterfaceSetBuilder{}},
 			},
 			topo: &Topology{},
 		},
+		"same topology": {
+			fs: &FabricScanner{
+				topo:     sameTopo,
+				builders: []FabricInterfaceSetBuilder{&MockFabricInterfaceSetBuilder{}},
+			},
+			topo:        sameTopo,
+			expBuilders: 1,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -1789,6 +1814,45 @@ func TestHardware_FabricScanner_CacheTopology(t *testing.T) {
 			if diff := cmp.Diff(tc.topo, tc.fs.topo); diff != "" {
 				t.Fatalf("(-want, +got)\n%s\n", diff)
 			}
+
+			// Builders using an old topology must be discarded
+			test.AssertEqual(t, tc.expBuilders, len(tc.fs.builders), "")
+		})
+	}
+}
+
+func TestHardware_FabricScanner_Reset(t *testing.T) {
+	for name, tc := range map[string]struct {
+		fs     *FabricScanner
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil"),
+		},
+		"not initialized": {
+			fs: &FabricScanner{},
+		},
+		"initialized": {
+			fs: &FabricScanner{
+				topo:      &Topology{},
+				providers: common.NewStringSet("ofi+tcp"),
+				builders:  []FabricInterfaceSetBuilder{&MockFabricInterfaceSetBuilder{}},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.fs.Reset()
+
+			test.CmpErr(t, tc.expErr, err)
+
+			if tc.fs == nil {
+				return
+			}
+
+			if tc.fs.topo != nil {
+				t.Fatal("expected cached topology to be discarded")
+			}
+			test.AssertFalse(t, tc.fs.isInitialized(tc.fs.providers.ToSlice()), "expected scanner to need initialization")
 		})
 	}
 }
@@ -1811,6 +1875,8 @@ func TestHardware_defaultFabricInterfaceSetBuilders(t *testing.T) {
 		newNetworkDeviceBuilder(nil, &Topology{}),
 		newNetDevClassBuilder(nil, &MockNetDevClassProvider{}),
 		newNUMAAffinityBuilder(nil, &Topology{}),
+		newDPUBuilder(nil, &Topology{}),
+		newInterfaceFilterBuilder(nil, &Topology{}, nil),
 	}
 
 	log, buf := logging.NewTestLogger(t.Name())
@@ -1821,6 +1887,8 @@ func TestHardware_defaultFabricInterfaceSetBuilders(t *testing.T) {
 	if diff := cmp.Diff(expResult, result,
 		cmp.AllowUnexported(FabricInterfaceBuilder{}),
 		cmp.AllowUnexported(NUMAAffinityBuilder{}),
+		cmp.AllowUnexported(DPUBuilder{}),
+		cmp.AllowUnexported(InterfaceFilterBuilder{}),
 		cmp.AllowUnexported(NetDevClassBuilder{}),
 		cmp.AllowUnexported(NetworkDeviceBuilder{}),
 		cmp.AllowUnexported(MockFabricInterfaceProvider{}),
@@ -2380,6 +2448,114 @@ func TestHardware_NUMAAffinityBuilder_BuildPart(t *testing.T) {
 	}
 }
 
+func TestHardware_DPUBuilder_BuildPart(t *testing.T) {
+	testTopo := &Topology{
+		NUMANodes: map[uint]*NUMANode{
+			0: MockNUMANode(0, 8).WithDevices([]*PCIDevice{
+				{
+					Name:    "eth0",
+					Type:    DeviceTypeNetInterface,
+					PCIAddr: *MustNewPCIAddress("0000:00:00.1"),
+				},
+				{
+					Name:        "eth1",
+					Type:        DeviceTypeNetInterface,
+					PCIAddr:     *MustNewPCIAddress("0000:03:00.0"),
+					DPUFunction: DPUFunctionNetwork,
+				},
+				{
+					Name:        "mlx5_1",
+					Type:        DeviceTypeOFIDomain,
+					PCIAddr:     *MustNewPCIAddress("0000:03:00.0"),
+					DPUFunction: DPUFunctionNetwork,
+				},
+				{
+					Name:        "eth2",
+					Type:        DeviceTypeNetInterface,
+					PCIAddr:     *MustNewPCIAddress("0000:03:00.2"),
+					DPUFunction: DPUFunctionHost,
+				},
+			}),
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		builder   *DPUBuilder
+		set       *FabricInterfaceSet
+		expResult *FabricInterfaceSet
+		expErr    error
+	}{
+		"nil builder": {
+			set:       NewFabricInterfaceSet(),
+			expErr:    errors.New("DPUBuilder is nil"),
+			expResult: NewFabricInterfaceSet(),
+		},
+		"uninit": {
+			builder:   &DPUBuilder{},
+			set:       NewFabricInterfaceSet(),
+			expErr:    errors.New("uninitialized"),
+			expResult: NewFabricInterfaceSet(),
+		},
+		"nil set": {
+			builder: newDPUBuilder(nil, &Topology{}),
+			expErr:  errors.New("FabricInterfaceSet is nil"),
+		},
+		"success": {
+			builder: newDPUBuilder(nil, testTopo),
+			set: NewFabricInterfaceSet(
+				&FabricInterface{
+					Name: "notfound",
+				},
+				&FabricInterface{
+					Name: "eth0",
+				},
+				&FabricInterface{
+					Name: "eth1",
+				},
+				&FabricInterface{
+					Name:   "mlx5_1",
+					OSName: "eth1",
+				},
+				&FabricInterface{
+					Name: "eth2",
+				},
+			),
+			expResult: NewFabricInterfaceSet(
+				&FabricInterface{
+					Name: "notfound",
+				},
+				&FabricInterface{
+					Name: "eth0",
+				},
+				&FabricInterface{
+					Name:        "eth1",
+					DPUFunction: DPUFunctionNetwork,
+				},
+				&FabricInterface{
+					Name:        "mlx5_1",
+					OSName:      "eth1",
+					DPUFunction: DPUFunctionNetwork,
+				},
+			),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			if tc.builder != nil {
+				tc.builder.log = log
+			}
+
+			err := tc.builder.BuildPart(test.Context(t), tc.set)
+
+			test.CmpErr(t, tc.expErr, err)
+			if diff := cmp.Diff(tc.expResult, tc.set, fabricCmpOpts()...); diff != "" {
+				t.Fatalf("(-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
 func TestHardware_NetDevClassBuilder_BuildPart(t *testing.T) {
 	for name, tc := range map[string]struct {
 		builder   *NetDevClassBuilder
@@ -65,6 +65,19 @@ func DefaultFabricScanner(log logging.Logger) *hardware.FabricScanner {
 	return fs
 }
 
+// DefaultFabricScannerWithFilter gets a FabricScanner using the default
+// configuration, with the supplied interface filter.
+func DefaultFabricScannerWithFilter(log logging.Logger, filter *hardware.FabricInterfaceFilter) (*hardware.FabricScanner, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultFabricScannerConfig(log)
+	cfg.InterfaceFilter = filter
+
+	return hardware.NewFabricScanner(log, cfg)
+}
+
 // DefaultNetDevStateProvider gets the default provider for getting the fabric interface state.
 func DefaultNetDevStateProvider(log logging.Logger) hardware.NetDevStateProvider {
 	return sysfs.NewProvider(log)
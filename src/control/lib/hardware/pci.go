@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -443,6 +443,7 @@ type (
 		PCIAddr     PCIAddress   `json:"pci_address"`
 		LinkSpeed   float64      `json:"link_speed,omitempty"`
 		BlockDevice *BlockDevice `json:"-"`
+		DPUFunction DPUFunction  `json:"dpu_function,omitempty"`
 	}
 
 	// PCIBus represents the root of a PCI bus hierarchy.
@@ -521,7 +522,11 @@ func (d *PCIDevice) String() string {
 	if d.BlockDevice != nil {
 		sizeStr = fmt.Sprintf("%s ", humanize.Bytes(d.BlockDevice.Size))
 	}
-	return fmt.Sprintf("%s %s (%s%s)%s", &d.PCIAddr, d.Name, sizeStr, d.Type, speedStr)
+	var dpuStr string
+	if d.DPUFunction != DPUFunctionNone {
+		dpuStr = fmt.Sprintf(" [DPU %s]", d.DPUFunction)
+	}
+	return fmt.Sprintf("%s %s (%s%s)%s%s", &d.PCIAddr, d.Name, sizeStr, d.Type, speedStr, dpuStr)
 }
 
 // DeviceName returns the system name of the PCI device.
@@ -553,6 +558,25 @@ func (d PCIDevices) MarshalJSON() ([]byte, error) {
 	return json.Marshal(strMap)
 }
 
+// UnmarshalJSON decodes PCIDevices encoded by MarshalJSON, keying each device by its own PCI
+// address.
+func (d *PCIDevices) UnmarshalJSON(data []byte) error {
+	var strMap map[string][]*PCIDevice
+	if err := json.Unmarshal(data, &strMap); err != nil {
+		return err
+	}
+
+	*d = make(PCIDevices)
+	for _, devs := range strMap {
+		for _, dev := range devs {
+			if err := d.Add(dev); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Add adds a device to the PCIDevices.
 func (d PCIDevices) Add(dev *PCIDevice) error {
 	if d == nil {
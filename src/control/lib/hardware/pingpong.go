@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PingPongCommand is the libfabric fabtests utility used to measure
+	// point-to-point fabric performance.
+	PingPongCommand = "fi_pingpong"
+	// PingPongDefaultPort is the default port used by fi_pingpong for
+	// exchanging addresses out of band.
+	PingPongDefaultPort = 47592
+)
+
+// PingPongResult contains the performance measured by fi_pingpong for a
+// single transfer size.
+type PingPongResult struct {
+	Bytes       uint64  `json:"bytes"`
+	Transfers   uint64  `json:"transfers"`
+	MBPerSec    float64 `json:"mb_per_sec"`
+	UsecPerXfer float64 `json:"usec_per_xfer"`
+}
+
+// PingPongProvider converts a DAOS fabric provider string (e.g.
+// "ofi+tcp;ofi_rxm") into the libfabric provider name accepted by
+// fi_pingpong (e.g. "tcp;ofi_rxm").
+func PingPongProvider(provider string) (string, error) {
+	if !strings.HasPrefix(provider, "ofi+") {
+		return "", errors.Errorf("provider %q is not a libfabric provider", provider)
+	}
+	return strings.TrimPrefix(provider, "ofi+"), nil
+}
+
+// parsePingPongSize parses a transfer size as printed by fi_pingpong, which
+// uses binary k/m/g suffixes.
+func parsePingPongSize(str string) (uint64, error) {
+	mult := uint64(1)
+	switch {
+	case strings.HasSuffix(str, "k"):
+		mult = 1 << 10
+	case strings.HasSuffix(str, "m"):
+		mult = 1 << 20
+	case strings.HasSuffix(str, "g"):
+		mult = 1 << 30
+	}
+	if mult != 1 {
+		str = str[:len(str)-1]
+	}
+
+	val, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return val * mult, nil
+}
+
+// ParsePingPongOutput parses the performance table printed by fi_pingpong.
+//
+// bytes   #sent   #ack     total       time     MB/sec    usec/xfer   Mxfers/sec
+// 64      1k      =1k      125k        0.01s     10.38       6.17       0.16
+func ParsePingPongOutput(r io.Reader) ([]*PingPongResult, error) {
+	var results []*PingPongResult
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 8 || !strings.HasPrefix(fields[2], "=") {
+			continue
+		}
+
+		bytes, err := parsePingPongSize(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing transfer size %q", fields[0])
+		}
+		xfers, err := parsePingPongSize(fields[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing transfer count %q", fields[1])
+		}
+		mbps, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing bandwidth %q", fields[5])
+		}
+		usec, err := strconv.ParseFloat(fields[6], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing latency %q", fields[6])
+		}
+
+		results = append(results, &PingPongResult{
+			Bytes:       bytes,
+			Transfers:   xfers,
+			MBPerSec:    mbps,
+			UsecPerXfer: usec,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, errors.Errorf("no results found in %s output", PingPongCommand)
+	}
+	return results, nil
+}
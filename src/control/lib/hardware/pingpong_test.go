@@ -0,0 +1,92 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package hardware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestHardware_PingPongProvider(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider string
+		expProv  string
+		expErr   error
+	}{
+		"tcp": {
+			provider: "ofi+tcp",
+			expProv:  "tcp",
+		},
+		"verbs with rxm": {
+			provider: "ofi+verbs;ofi_rxm",
+			expProv:  "verbs;ofi_rxm",
+		},
+		"ucx": {
+			provider: "ucx+dc_x",
+			expErr:   errors.New("not a libfabric provider"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotProv, gotErr := PingPongProvider(tc.provider)
+			test.CmpErr(t, tc.expErr, gotErr)
+			test.AssertEqual(t, tc.expProv, gotProv, "unexpected provider")
+		})
+	}
+}
+
+func TestHardware_ParsePingPongOutput(t *testing.T) {
+	for name, tc := range map[string]struct {
+		output     string
+		expResults []*PingPongResult
+		expErr     error
+	}{
+		"empty": {
+			expErr: errors.New("no results"),
+		},
+		"bad size": {
+			output: "64x     1k      =1k      125k        0.01s     10.38       6.17       0.16\n",
+			expErr: errors.New("parsing transfer size \"64x\""),
+		},
+		"results": {
+			output: `bytes   #sent   #ack     total       time     MB/sec    usec/xfer   Mxfers/sec
+64      1k      =1k      125k        0.01s     10.38       6.17       0.16
+1m      100     =100     200m        0.03s   7037.41     149.00       0.01
+`,
+			expResults: []*PingPongResult{
+				{
+					Bytes:       64,
+					Transfers:   1024,
+					MBPerSec:    10.38,
+					UsecPerXfer: 6.17,
+				},
+				{
+					Bytes:       1 << 20,
+					Transfers:   100,
+					MBPerSec:    7037.41,
+					UsecPerXfer: 149,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotResults, gotErr := ParsePingPongOutput(strings.NewReader(tc.output))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResults, gotResults); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
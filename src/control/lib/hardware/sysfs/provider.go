@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -182,6 +182,7 @@ func (s *Provider) addPCIDevice(topo *hardware.Topology, subsystem string, path
 		return nil
 	}
 	dev.PCIAddr = *pciAddr
+	dev.DPUFunction = s.getDPUFunction(path)
 
 	s.log.Tracef("adding device found at %q (type %s, NUMA node %d)", path, dev.Type, numaID)
 	return topo.AddDevice(uint(numaID), dev)
@@ -229,6 +230,41 @@ func (s *Provider) getNUMANode(path string) (uint, error) {
 	return uint(numaID), nil
 }
 
+func readHexID(path string) (uint16, error) {
+	idBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(string(idBytes)), "0x"), 16, 16)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing ID in %q", path)
+	}
+	return uint16(id), nil
+}
+
+func (s *Provider) getDPUFunction(path string) hardware.DPUFunction {
+	vendor, err := readHexID(filepath.Join(path, "device", "vendor"))
+	if err != nil {
+		s.log.Tracef("unable to get PCI vendor ID for %q: %s", path, err.Error())
+		return hardware.DPUFunctionNone
+	}
+
+	device, err := readHexID(filepath.Join(path, "device", "device"))
+	if err != nil {
+		s.log.Tracef("unable to get PCI device ID for %q: %s", path, err.Error())
+		return hardware.DPUFunctionNone
+	}
+
+	// Only eswitch representors have a physical port name, and not all drivers support it.
+	var portName string
+	if portBytes, err := ioutil.ReadFile(filepath.Join(path, "phys_port_name")); err == nil {
+		portName = strings.TrimSpace(string(portBytes))
+	}
+
+	return hardware.GetDPUFunction(vendor, device, portName)
+}
+
 func (s *Provider) getPCIAddress(path string) (*hardware.PCIAddress, error) {
 	pciPath, err := filepath.EvalSymlinks(filepath.Join(path, "device"))
 	if err != nil {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2023 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -391,6 +391,54 @@ func TestProvider_GetTopology(t *testing.T) {
 			p:         &Provider{},
 			expResult: testTopo,
 		},
+		"DPU functions": {
+			setup: func(t *testing.T, root string) {
+				for _, dev := range []struct {
+					pciAddr  string
+					deviceID string
+					name     string
+					portName string
+				}{
+					{pciAddr: "0000:03:00.0", deviceID: "0xa2d6", name: "p0", portName: "p0"},
+					{pciAddr: "0000:03:00.1", deviceID: "0xa2d6", name: "pf0hpf", portName: "pf0"},
+					{pciAddr: "0000:04:00.0", deviceID: "0x101b", name: "net0"},
+				} {
+					path := setupPCIDev(t, root, dev.pciAddr, "net", dev.name)
+					setupClassLink(t, root, "net", path)
+					setupNUMANode(t, path, "0\n")
+					writeTestFile(t, filepath.Join(path, "device", "vendor"), "0x15b3\n")
+					writeTestFile(t, filepath.Join(path, "device", "device"), dev.deviceID+"\n")
+					if dev.portName != "" {
+						writeTestFile(t, filepath.Join(path, "phys_port_name"), dev.portName+"\n")
+					}
+				}
+			},
+			p: &Provider{},
+			expResult: &hardware.Topology{
+				NUMANodes: hardware.NodeMap{
+					0: hardware.MockNUMANode(0, 0).
+						WithDevices([]*hardware.PCIDevice{
+							{
+								Name:        "p0",
+								Type:        hardware.DeviceTypeNetInterface,
+								PCIAddr:     *hardware.MustNewPCIAddress("0000:03:00.0"),
+								DPUFunction: hardware.DPUFunctionNetwork,
+							},
+							{
+								Name:        "pf0hpf",
+								Type:        hardware.DeviceTypeNetInterface,
+								PCIAddr:     *hardware.MustNewPCIAddress("0000:03:00.1"),
+								DPUFunction: hardware.DPUFunctionHost,
+							},
+							{
+								Name:    "net0",
+								Type:    hardware.DeviceTypeNetInterface,
+								PCIAddr: *hardware.MustNewPCIAddress("0000:04:00.0"),
+							},
+						}),
+				},
+			},
+		},
 		"virtual devices": {
 			setup: func(t *testing.T, root string) {
 				for _, dev := range []struct {
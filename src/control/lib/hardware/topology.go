@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,6 +8,7 @@ package hardware
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -167,6 +168,76 @@ type Topology struct {
 	VirtualDevices []*VirtualDevice `json:"virtual_devices"`
 }
 
+// UnmarshalJSON decodes a Topology and restores the references between NUMA nodes, buses and
+// devices that are omitted from the JSON encoding.
+func (t *Topology) UnmarshalJSON(data []byte) error {
+	type fromJSON Topology
+	var raw fromJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	t.NUMANodes = nil
+	t.VirtualDevices = nil
+	for id, rawNode := range raw.NUMANodes {
+		if t.NUMANodes == nil {
+			t.NUMANodes = make(NodeMap)
+		}
+		node := &NUMANode{
+			ID:         id,
+			Cores:      []CPUCore{},
+			PCIDevices: PCIDevices{},
+		}
+		t.NUMANodes[id] = node
+
+		for _, core := range rawNode.Cores {
+			if err := node.AddCore(core); err != nil {
+				return err
+			}
+		}
+		for _, bus := range rawNode.PCIBuses {
+			if err := node.AddPCIBus(&PCIBus{
+				LowAddress:  bus.LowAddress,
+				HighAddress: bus.HighAddress,
+			}); err != nil {
+				return err
+			}
+		}
+		for _, addr := range rawNode.PCIDevices.Keys() {
+			for _, dev := range rawNode.PCIDevices.Get(addr) {
+				if err := node.AddDevice(dev); err != nil {
+					return err
+				}
+			}
+		}
+		for _, blockDev := range rawNode.BlockDevices {
+			if blockDev.BackingDevice != nil {
+				for _, dev := range node.PCIDevices.Get(&blockDev.BackingDevice.PCIAddr) {
+					if dev.Name == blockDev.BackingDevice.Name {
+						blockDev.BackingDevice = dev
+						dev.BlockDevice = blockDev
+					}
+				}
+			}
+			if err := node.AddBlockDevice(blockDev); err != nil {
+				return err
+			}
+		}
+	}
+
+	allDevices := t.AllDevices()
+	for _, virt := range raw.VirtualDevices {
+		if err := mergeBackingDev(allDevices, virt, virt); err != nil {
+			return err
+		}
+		if err := t.AddVirtualDevice(virt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AllDevices returns a map of all system Devices sorted by their name.
 func (t *Topology) AllDevices() map[string]Device {
 	devsByName := make(map[string]Device)
@@ -356,6 +427,10 @@ func (t *Topology) Merge(newTopo *Topology) error {
 						if oldDev.LinkSpeed == 0 {
 							oldDev.LinkSpeed = newDev.LinkSpeed
 						}
+
+						if oldDev.DPUFunction == DPUFunctionNone {
+							oldDev.DPUFunction = newDev.DPUFunction
+						}
 					}
 				}
 				if !devExists {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,6 +7,7 @@
 package hardware
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -953,6 +954,115 @@ func TestHardware_Topology_Merge(t *testing.T) {
 	}
 }
 
+func TestHardware_Topology_UnmarshalJSON(t *testing.T) {
+	newTopo := func() *Topology {
+		nvme := mockPCIDevice("nvme", 1, 1, 0).withType(DeviceTypeBlock)
+		ib := mockPCIDevice("ib", 1, 2, 1).withType(DeviceTypeNetInterface).withLinkSpeed(100)
+		topo := &Topology{
+			NUMANodes: NodeMap{
+				0: MockNUMANode(0, 4).
+					WithPCIBuses([]*PCIBus{mockPCIBus(1, 0, 3)}).
+					WithDevices([]*PCIDevice{nvme, ib}).
+					WithBlockDevices([]*BlockDevice{
+						{
+							Type:          "disk",
+							Name:          "nvme0n1",
+							Size:          1 << 40,
+							BackingDevice: nvme,
+						},
+					}),
+				1: MockNUMANode(1, 4, 4),
+			},
+		}
+		nvme.BlockDevice = topo.NUMANodes[0].BlockDevices[0]
+		if err := topo.AddVirtualDevice(&VirtualDevice{
+			Name:          "bond0",
+			Type:          DeviceTypeNetInterface,
+			BackingDevice: ib,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return topo
+	}
+
+	for name, tc := range map[string]struct {
+		data      []byte
+		expResult *Topology
+		expErr    error
+	}{
+		"bad JSON": {
+			data:   []byte(`{"numa_nodes": [}`),
+			expErr: errors.New("invalid character"),
+		},
+		"empty": {
+			data:      []byte(`{}`),
+			expResult: &Topology{},
+		},
+		"missing backing device": {
+			data:   []byte(`{"virtual_devices":[{"name":"bond0","backing_device":{"name":"ib01"}}]}`),
+			expErr: errors.New("does not exist"),
+		},
+		"round trip": {
+			expResult: newTopo(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := tc.data
+			if data == nil {
+				var err error
+				if data, err = json.Marshal(tc.expResult); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result := new(Topology)
+			err := json.Unmarshal(data, result)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{
+				test.CmpOptIgnoreFieldAnyType("NUMANode"),
+				cmpopts.IgnoreFields(PCIDevice{}, "Bus", "BlockDevice"),
+				cmpopts.EquateEmpty(),
+			}
+			if diff := cmp.Diff(tc.expResult, result, cmpOpts...); diff != "" {
+				t.Fatalf("(-want, +got)\n%s\n", diff)
+			}
+
+			// Verify that references omitted from the encoding have been restored.
+			for _, node := range result.NUMANodes {
+				for _, core := range node.Cores {
+					if core.NUMANode != node {
+						t.Fatalf("core %d not linked to NUMA node %d", core.ID, node.ID)
+					}
+				}
+				for _, bus := range node.PCIBuses {
+					for _, devs := range bus.PCIDevices {
+						for _, dev := range devs {
+							if dev.Bus != bus || dev.NUMANode != node {
+								t.Fatalf("device %s not linked to bus or NUMA node", dev.Name)
+							}
+						}
+					}
+				}
+				for _, blockDev := range node.BlockDevices {
+					if blockDev.BackingDevice != nil && blockDev.BackingDevice.BlockDevice != blockDev {
+						t.Fatalf("block device %s not linked to backing device", blockDev.Name)
+					}
+				}
+			}
+			allDevs := result.AllDevices()
+			for _, virt := range result.VirtualDevices {
+				if virt.BackingDevice != allDevs[virt.BackingDevice.Name] {
+					t.Fatalf("virtual device %s not linked to backing device", virt.Name)
+				}
+			}
+		})
+	}
+}
+
 func TestHardware_DeviceType_String(t *testing.T) {
 	for name, tc := range map[string]struct {
 		devType   DeviceType
@@ -211,3 +211,95 @@ func (hs *HostSet) Count() int {
 	hs.initList()
 	return hs.list.Count()
 }
+
+// setFromHosts creates a new HostSet containing the supplied hostnames.
+func setFromHosts(hosts []string) (*HostSet, error) {
+	hl, err := Create("")
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if err := hl.PushHost(host); err != nil {
+			return nil, err
+		}
+	}
+	hl.Uniq()
+
+	return &HostSet{list: hl}, nil
+}
+
+// hosts returns the hostnames of every host in the HostSet, or nil if the
+// HostSet is nil or empty.
+func (hs *HostSet) hosts() []string {
+	if hs == nil || hs.Count() == 0 {
+		return nil
+	}
+	return hs.Slice()
+}
+
+// hostCounts returns the number of the supplied HostSets that each host
+// is a member of.
+func hostCounts(sets ...*HostSet) map[string]int {
+	counts := make(map[string]int)
+	for _, set := range sets {
+		for _, host := range set.hosts() {
+			counts[host]++
+		}
+	}
+	return counts
+}
+
+// Union returns a new HostSet containing the hosts which are in this
+// HostSet or in any of the supplied HostSets.
+func (hs *HostSet) Union(others ...*HostSet) (*HostSet, error) {
+	counts := hostCounts(append([]*HostSet{hs}, others...)...)
+
+	hosts := make([]string, 0, len(counts))
+	for host := range counts {
+		hosts = append(hosts, host)
+	}
+	return setFromHosts(hosts)
+}
+
+// Intersection returns a new HostSet containing the hosts which are in
+// this HostSet and in all of the supplied HostSets.
+func (hs *HostSet) Intersection(others ...*HostSet) (*HostSet, error) {
+	counts := hostCounts(others...)
+
+	var hosts []string
+	for _, host := range hs.hosts() {
+		if counts[host] == len(others) {
+			hosts = append(hosts, host)
+		}
+	}
+	return setFromHosts(hosts)
+}
+
+// Difference returns a new HostSet containing the hosts which are in this
+// HostSet but in none of the supplied HostSets.
+func (hs *HostSet) Difference(others ...*HostSet) (*HostSet, error) {
+	counts := hostCounts(others...)
+
+	var hosts []string
+	for _, host := range hs.hosts() {
+		if counts[host] == 0 {
+			hosts = append(hosts, host)
+		}
+	}
+	return setFromHosts(hosts)
+}
+
+// SymmetricDifference returns a new HostSet containing the hosts which are
+// in an odd number of this and the supplied HostSets, i.e. for two sets,
+// the hosts which are in either set but not in both.
+func (hs *HostSet) SymmetricDifference(others ...*HostSet) (*HostSet, error) {
+	counts := hostCounts(append([]*HostSet{hs}, others...)...)
+
+	var hosts []string
+	for host, count := range counts {
+		if count%2 == 1 {
+			hosts = append(hosts, host)
+		}
+	}
+	return setFromHosts(hosts)
+}
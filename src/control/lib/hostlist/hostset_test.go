@@ -154,6 +154,85 @@ func TestHostSet_Intersects(t *testing.T) {
 	}
 }
 
+func TestHostSet_SetOperations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		set       string
+		others    []string
+		expUnion  string
+		expInter  string
+		expDiff   string
+		expSymDif string
+	}{
+		"no others": {
+			set:       "node[1-4]",
+			expUnion:  "node[1-4]",
+			expInter:  "node[1-4]",
+			expDiff:   "node[1-4]",
+			expSymDif: "node[1-4]",
+		},
+		"overlapping ranges": {
+			set:       "node[1-8]",
+			others:    []string{"node[5-12]"},
+			expUnion:  "node[1-12]",
+			expInter:  "node[5-8]",
+			expDiff:   "node[1-4]",
+			expSymDif: "node[1-4,9-12]",
+		},
+		"disjoint prefixes": {
+			set:       "node[1-2]",
+			others:    []string{"rack[1-2]"},
+			expUnion:  "node[1-2],rack[1-2]",
+			expDiff:   "node[1-2]",
+			expSymDif: "node[1-2],rack[1-2]",
+		},
+		"multiple others": {
+			set:       "node[1-10]",
+			others:    []string{"node[1-6]", "node[4-12]"},
+			expUnion:  "node[1-12]",
+			expInter:  "node[4-6]",
+			expDiff:   "",
+			expSymDif: "node[4-6,11-12]",
+		},
+		"empty set": {
+			set:       "",
+			others:    []string{"node[1-2]"},
+			expUnion:  "node[1-2]",
+			expSymDif: "node[1-2]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hs, err := hostlist.CreateSet(tc.set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var others []*hostlist.HostSet
+			for _, o := range tc.others {
+				others = append(others, hostlist.MustCreateSet(o))
+			}
+
+			for opName, op := range map[string]struct {
+				fn  func(...*hostlist.HostSet) (*hostlist.HostSet, error)
+				exp string
+			}{
+				"union":                {hs.Union, tc.expUnion},
+				"intersection":         {hs.Intersection, tc.expInter},
+				"difference":           {hs.Difference, tc.expDiff},
+				"symmetric difference": {hs.SymmetricDifference, tc.expSymDif},
+			} {
+				got, err := op.fn(others...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.String() != op.exp {
+					t.Fatalf("%s: expected %q, got %q", opName, op.exp, got.String())
+				}
+			}
+
+			cmpOut(t, hostlist.MustCreateSet(tc.set).String(), hs.String())
+		})
+	}
+}
+
 func TestHostSet_ZeroValue(t *testing.T) {
 	zVal := &hostlist.HostSet{}
 
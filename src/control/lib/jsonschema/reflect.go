@@ -0,0 +1,375 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schemaer is implemented by types that describe their own JSON encoding.
+type Schemaer interface {
+	JSONSchema() *Schema
+}
+
+var (
+	schemaerType       = reflect.TypeOf((*Schemaer)(nil)).Elem()
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType           = reflect.TypeOf(time.Time{})
+	customEncodingDesc = "custom JSON encoding"
+)
+
+// Reflector builds the schemas of several types sharing a common set of
+// definitions.
+type Reflector struct {
+	names map[reflect.Type]string
+	defs  map[string]*Schema
+}
+
+// NewReflector returns an initialized Reflector.
+func NewReflector() *Reflector {
+	return &Reflector{
+		names: make(map[reflect.Type]string),
+		defs:  make(map[string]*Schema),
+	}
+}
+
+// Defs returns the definitions of the named struct types referenced by the
+// schemas built so far.
+func (r *Reflector) Defs() map[string]*Schema {
+	return r.defs
+}
+
+// Reflect returns a schema describing the JSON encoding of the given value's
+// type by encoding/json. Named struct types are added to the definitions and
+// referenced where they are used.
+//
+// Types implementing json.Marshaler are described by encoding their zero
+// value, so the schema of such types is looser than that of plain types.
+// Types may instead implement Schemaer to provide an exact schema.
+func (r *Reflector) Reflect(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{Type: Types{TypeNull}}
+	}
+	return r.reflect(reflect.TypeOf(v))
+}
+
+// Reflect returns a standalone schema describing the JSON encoding of the
+// given value's type. See Reflector.Reflect.
+func Reflect(v interface{}) *Schema {
+	r := NewReflector()
+	root := *r.Reflect(v)
+	root.Schema = Draft
+	if len(r.defs) > 0 {
+		root.Defs = r.defs
+	}
+	return &root
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(iface))
+}
+
+// zeroIface returns the zero value of the type as the given interface, using
+// a pointer to it if the methods have pointer receivers.
+func zeroIface(t, iface reflect.Type) interface{} {
+	if t.Implements(iface) {
+		return reflect.Zero(t).Interface()
+	}
+	return reflect.New(t).Interface()
+}
+
+func (r *Reflector) reflect(t reflect.Type) *Schema {
+	switch {
+	case implements(t, schemaerType):
+		return zeroIface(t, schemaerType).(Schemaer).JSONSchema()
+	case t.Kind() == reflect.Ptr:
+		return Nullable(r.reflect(t.Elem()))
+	case t == timeType:
+		return &Schema{Type: Types{TypeString}, Format: "date-time"}
+	case implements(t, jsonMarshalerType):
+		if t.Kind() == reflect.Struct && t.Name() != "" {
+			return r.define(t, r.customSchema)
+		}
+		return r.customSchema(t)
+	case implements(t, textMarshalerType):
+		return &Schema{Type: Types{TypeString}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Types{TypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{TypeNumber}}
+	case reflect.String:
+		return &Schema{Type: Types{TypeString}}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) &&
+			!implements(t.Elem(), textMarshalerType) {
+			// Byte slices are encoded as base64 strings.
+			return &Schema{Type: Types{TypeString, TypeNull}}
+		}
+		return &Schema{Type: Types{TypeArray, TypeNull}, Items: r.reflect(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: Types{TypeArray}, Items: r.reflect(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{TypeObject, TypeNull}, AdditionalProperties: r.reflect(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return r.define(t, r.structSchema)
+	}
+
+	// Interfaces may hold any value.
+	return &Schema{}
+}
+
+// define adds the schema of the named type to the definitions, returning a
+// reference to it. The definition is registered before it is built so that
+// recursive types refer to themselves.
+func (r *Reflector) define(t reflect.Type, build func(reflect.Type) *Schema) *Schema {
+	if name, found := r.names[t]; found {
+		return &Schema{Ref: defRef(name)}
+	}
+
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	if t.PkgPath() == "" {
+		name = t.Name()
+	}
+	for i := 2; r.defs[name] != nil; i++ {
+		name = fmt.Sprintf("%s.%s%d", path.Base(t.PkgPath()), t.Name(), i)
+	}
+
+	def := &Schema{}
+	r.names[t] = name
+	r.defs[name] = def
+	*def = *build(t)
+
+	return &Schema{Ref: defRef(name)}
+}
+
+type jsonTag struct {
+	name      string
+	omitEmpty bool
+	asString  bool
+}
+
+func parseTag(tag string) jsonTag {
+	parts := strings.Split(tag, ",")
+	jt := jsonTag{name: parts[0]}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			jt.omitEmpty = true
+		case "string":
+			jt.asString = true
+		}
+	}
+	return jt
+}
+
+func (r *Reflector) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       Types{TypeObject},
+		Properties: make(map[string]*Schema),
+	}
+	r.addFields(s, t, false)
+
+	if len(s.Required) == 0 {
+		s.Required = nil
+	}
+	return s
+}
+
+type embeddedStruct struct {
+	typ      reflect.Type
+	optional bool
+}
+
+// addFields adds the fields of the struct to the schema following the rules of
+// encoding/json. Fields of embedded structs are added after the struct's own
+// fields so that the shallower fields take precedence. The fields of structs
+// embedded by pointer are omitted when the pointer is nil, so are optional.
+func (r *Reflector) addFields(s *Schema, t reflect.Type, optional bool) {
+	var embedded []embeddedStruct
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		jt := parseTag(tag)
+
+		if f.Anonymous && jt.name == "" {
+			es := embeddedStruct{typ: f.Type, optional: optional}
+			if es.typ.Kind() == reflect.Ptr {
+				es.typ = es.typ.Elem()
+				es.optional = true
+			}
+			if es.typ.Kind() == reflect.Struct {
+				embedded = append(embedded, es)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := jt.name
+		if name == "" {
+			name = f.Name
+		}
+		if _, found := s.Properties[name]; found {
+			continue
+		}
+
+		fs := r.reflect(f.Type)
+		if jt.asString && isScalar(f.Type) {
+			fs = &Schema{Type: Types{TypeString}}
+		}
+		s.Properties[name] = fs
+		if !jt.omitEmpty && !optional {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	for _, es := range embedded {
+		r.addFields(s, es.typ, es.optional)
+	}
+}
+
+func isScalar(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// sampleJSON returns the encoding of the zero value of a type with a custom
+// JSON encoding, or false if it can't be encoded.
+func sampleJSON(t reflect.Type) (data []byte, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	data, err := zeroIface(t, jsonMarshalerType).(json.Marshaler).MarshalJSON()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// kindOf returns the JSON type of the encoded value.
+func kindOf(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return ""
+	}
+	return valueType(v)
+}
+
+func valueType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case float64, json.Number:
+		return TypeNumber
+	case string:
+		return TypeString
+	case []interface{}:
+		return TypeArray
+	case map[string]interface{}:
+		return TypeObject
+	}
+	return ""
+}
+
+func allows(s *Schema, typ string) bool {
+	if len(s.Type) == 0 {
+		return s.Ref == "" && len(s.AnyOf) == 0
+	}
+	return s.Type.Has(typ) || (typ == TypeNumber && s.Type.Has(TypeInteger))
+}
+
+// customSchema describes a type implementing json.Marshaler from the encoding
+// of its zero value. Structs encoded as objects are described by their fields,
+// updated with the properties of the sample, but no properties are required.
+// Properties that are null in the sample may be encoded differently to the
+// field, so they accept any value.
+func (r *Reflector) customSchema(t reflect.Type) *Schema {
+	data, ok := sampleJSON(t)
+	if !ok {
+		return &Schema{Description: customEncodingDesc}
+	}
+
+	switch typ := kindOf(data); typ {
+	case TypeBoolean, TypeNumber, TypeString:
+		return &Schema{Type: Types{typ}}
+	case TypeArray:
+		return &Schema{Type: Types{TypeArray, TypeNull}}
+	case TypeObject:
+		if t.Kind() != reflect.Struct {
+			return &Schema{Type: Types{TypeObject, TypeNull}}
+		}
+	default:
+		return &Schema{Description: customEncodingDesc}
+	}
+
+	s := &Schema{
+		Type:       Types{TypeObject},
+		Properties: make(map[string]*Schema),
+	}
+	r.addFields(s, t, false)
+	s.Required = nil
+
+	var sample map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sample); err != nil {
+		return &Schema{Description: customEncodingDesc}
+	}
+	for name, raw := range sample {
+		typ := kindOf(raw)
+		if prop, found := s.Properties[name]; found {
+			switch {
+			case typ == TypeNull:
+			case allows(prop, typ):
+				continue
+			case (typ == TypeObject || typ == TypeArray) && (prop.Ref != "" || len(prop.AnyOf) > 0):
+				continue
+			}
+		}
+
+		switch typ {
+		case TypeBoolean, TypeNumber, TypeString:
+			s.Properties[name] = &Schema{Type: Types{typ}}
+		default:
+			s.Properties[name] = &Schema{}
+		}
+	}
+
+	return s
+}
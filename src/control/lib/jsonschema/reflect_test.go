@@ -0,0 +1,351 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+type testState int
+
+func (ts testState) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{"ready", "busy"}[ts])
+}
+
+type testInner struct {
+	Size uint64 `json:"size"`
+}
+
+type testEmbedded struct {
+	Name  string `json:"name"`
+	Extra string `json:"extra,omitempty"`
+}
+
+type testOuter struct {
+	testEmbedded
+	Name     int               `json:"name"`
+	ID       uuid.UUID         `json:"id"`
+	Inner    *testInner        `json:"inner"`
+	Inners   []testInner       `json:"inners,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Data     []byte            `json:"data"`
+	When     time.Time         `json:"when"`
+	State    testState         `json:"state"`
+	Count    int32             `json:"count,string"`
+	Ignored  bool              `json:"-"`
+	Untagged float64
+	private  bool
+}
+
+type testPtrEmbedded struct {
+	*testInner
+	Name string `json:"name"`
+}
+
+type testNode struct {
+	Children []*testNode `json:"children"`
+}
+
+type testAddr struct {
+	IP string
+}
+
+type testCustom struct {
+	Addr  *testAddr `json:"-"`
+	Label string    `json:"label"`
+}
+
+func (tc *testCustom) MarshalJSON() ([]byte, error) {
+	type toJSON testCustom
+	return json.Marshal(&struct {
+		Addr string `json:"addr"`
+		*toJSON
+	}{
+		Addr:   tc.Addr.IP,
+		toJSON: (*toJSON)(tc),
+	})
+}
+
+type testSchemaer struct{}
+
+func (testSchemaer) JSONSchema() *Schema {
+	return &Schema{Type: Types{TypeString}, Format: "hostname"}
+}
+
+func TestJSONSchema_Reflect(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in        interface{}
+		expSchema *Schema
+	}{
+		"nil": {
+			expSchema: &Schema{Schema: Draft, Type: Types{TypeNull}},
+		},
+		"scalar": {
+			in:        uint32(0),
+			expSchema: &Schema{Schema: Draft, Type: Types{TypeInteger}},
+		},
+		"slice of pointers": {
+			in: []*string{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Type:   Types{TypeArray, TypeNull},
+				Items:  &Schema{Type: Types{TypeString, TypeNull}},
+			},
+		},
+		"anonymous struct": {
+			in: struct {
+				Rank uint32 `json:"rank"`
+				Msg  string `json:"msg,omitempty"`
+			}{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Type:   Types{TypeObject},
+				Properties: map[string]*Schema{
+					"rank": {Type: Types{TypeInteger}},
+					"msg":  {Type: Types{TypeString}},
+				},
+				Required: []string{"rank"},
+			},
+		},
+		"named struct": {
+			in: &testOuter{},
+			expSchema: &Schema{
+				Schema: Draft,
+				AnyOf: []*Schema{
+					{Ref: "#/$defs/jsonschema.testOuter"},
+					{Type: Types{TypeNull}},
+				},
+				Defs: map[string]*Schema{
+					"jsonschema.testOuter": {
+						Type: Types{TypeObject},
+						Properties: map[string]*Schema{
+							"name":  {Type: Types{TypeInteger}},
+							"extra": {Type: Types{TypeString}},
+							"id":    {Type: Types{TypeString}},
+							"inner": {AnyOf: []*Schema{
+								{Ref: "#/$defs/jsonschema.testInner"},
+								{Type: Types{TypeNull}},
+							}},
+							"inners": {
+								Type:  Types{TypeArray, TypeNull},
+								Items: &Schema{Ref: "#/$defs/jsonschema.testInner"},
+							},
+							"labels": {
+								Type:                 Types{TypeObject, TypeNull},
+								AdditionalProperties: &Schema{Type: Types{TypeString}},
+							},
+							"data":     {Type: Types{TypeString, TypeNull}},
+							"when":     {Type: Types{TypeString}, Format: "date-time"},
+							"state":    {Type: Types{TypeString}},
+							"count":    {Type: Types{TypeString}},
+							"Untagged": {Type: Types{TypeNumber}},
+						},
+						Required: []string{"name", "id", "inner", "labels", "data", "when", "state", "count", "Untagged"},
+					},
+					"jsonschema.testInner": {
+						Type: Types{TypeObject},
+						Properties: map[string]*Schema{
+							"size": {Type: Types{TypeInteger}},
+						},
+						Required: []string{"size"},
+					},
+				},
+			},
+		},
+		"embedded pointer": {
+			in: testPtrEmbedded{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Ref:    "#/$defs/jsonschema.testPtrEmbedded",
+				Defs: map[string]*Schema{
+					"jsonschema.testPtrEmbedded": {
+						Type: Types{TypeObject},
+						Properties: map[string]*Schema{
+							"name": {Type: Types{TypeString}},
+							"size": {Type: Types{TypeInteger}},
+						},
+						Required: []string{"name"},
+					},
+				},
+			},
+		},
+		"recursive struct": {
+			in: testNode{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Ref:    "#/$defs/jsonschema.testNode",
+				Defs: map[string]*Schema{
+					"jsonschema.testNode": {
+						Type: Types{TypeObject},
+						Properties: map[string]*Schema{
+							"children": {
+								Type: Types{TypeArray, TypeNull},
+								Items: &Schema{AnyOf: []*Schema{
+									{Ref: "#/$defs/jsonschema.testNode"},
+									{Type: Types{TypeNull}},
+								}},
+							},
+						},
+						Required: []string{"children"},
+					},
+				},
+			},
+		},
+		"custom struct encoding": {
+			in: testCustom{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Ref:    "#/$defs/jsonschema.testCustom",
+				Defs: map[string]*Schema{
+					"jsonschema.testCustom": {
+						Description: customEncodingDesc,
+					},
+				},
+			},
+		},
+		"schemaer": {
+			in: []testSchemaer{},
+			expSchema: &Schema{
+				Schema: Draft,
+				Type:   Types{TypeArray, TypeNull},
+				Items:  &Schema{Type: Types{TypeString}, Format: "hostname"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expSchema, Reflect(tc.in)); diff != "" {
+				t.Fatalf("unexpected schema (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+type testSampledEncoding struct {
+	Hosts *string `json:"hosts"`
+	Count uint32  `json:"count"`
+}
+
+func (tse testSampledEncoding) MarshalJSON() ([]byte, error) {
+	type toJSON testSampledEncoding
+	return json.Marshal(struct {
+		toJSON
+		Count string `json:"count"`
+		State string `json:"state"`
+	}{
+		toJSON: toJSON(tse),
+		Count:  "none",
+		State:  "unknown",
+	})
+}
+
+func TestJSONSchema_Reflect_SampledEncoding(t *testing.T) {
+	expDef := &Schema{
+		Type: Types{TypeObject},
+		Properties: map[string]*Schema{
+			"hosts": {},
+			"count": {Type: Types{TypeString}},
+			"state": {Type: Types{TypeString}},
+		},
+	}
+
+	s := Reflect(testSampledEncoding{})
+	if diff := cmp.Diff(expDef, s.Defs["jsonschema.testSampledEncoding"]); diff != "" {
+		t.Fatalf("unexpected schema (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestJSONSchema_Reflect_Conformance(t *testing.T) {
+	hosts := "host[1-2]"
+
+	for name, val := range map[string]interface{}{
+		"zero value": &testOuter{},
+		"populated": &testOuter{
+			testEmbedded: testEmbedded{Extra: "extra"},
+			Name:         1,
+			ID:           uuid.New(),
+			Inner:        &testInner{Size: 1 << 63},
+			Inners:       []testInner{{Size: 1}},
+			Labels:       map[string]string{"a": "b"},
+			Data:         []byte("data"),
+			When:         time.Now(),
+			State:        testState(1),
+			Count:        42,
+		},
+		"recursive": &testNode{
+			Children: []*testNode{{}, nil, {Children: []*testNode{{}}}},
+		},
+		"nil embedded pointer": &testPtrEmbedded{},
+		"sampled":              []testSampledEncoding{{Hosts: &hosts, Count: 2}, {}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(val)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Reflect(val).Validate(data); err != nil {
+				t.Fatalf("%s does not conform: %s", data, err)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Types_JSON(t *testing.T) {
+	for name, tc := range map[string]struct {
+		types   Types
+		expJSON string
+	}{
+		"single": {
+			types:   Types{TypeString},
+			expJSON: `"string"`,
+		},
+		"multiple": {
+			types:   Types{TypeString, TypeNull},
+			expJSON: `["string","null"]`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(tc.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.expJSON {
+				t.Fatalf("expected %s, got %s", tc.expJSON, data)
+			}
+
+			var got Types
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.types, got); diff != "" {
+				t.Fatalf("unexpected types (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Schema_RoundTrip(t *testing.T) {
+	s := Reflect(&testOuter{})
+
+	got := new(Schema)
+	if err := json.Unmarshal([]byte(s.String()), got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(s, got); diff != "" {
+		t.Fatalf("unexpected schema (-want, +got):\n%s\n", diff)
+	}
+
+	if !strings.Contains(s.String(), `"$ref": "#/$defs/jsonschema.testOuter"`) {
+		t.Fatalf("unexpected encoding: %s", s)
+	}
+}
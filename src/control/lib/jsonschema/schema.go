@@ -0,0 +1,118 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package jsonschema generates JSON Schema documents describing the JSON
+// encoding of Go types, and validates JSON documents against them. Only the
+// subset of the specification needed to describe encoding/json output is
+// supported.
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON types used in schemas.
+const (
+	TypeNull    = "null"
+	TypeBoolean = "boolean"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeString  = "string"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+// Types is the set of JSON types allowed by a schema. A single type is
+// encoded as a string.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*t = multi
+	return nil
+}
+
+// Has returns true if the type is allowed.
+func (t Types) Has(typ string) bool {
+	for _, allowed := range t {
+		if allowed == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema is a JSON Schema document. A schema without any constraints
+// accepts any value.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+const defsRefPrefix = "#/$defs/"
+
+func defRef(name string) string {
+	return defsRefPrefix + name
+}
+
+// Nullable returns a schema that also accepts null.
+func Nullable(s *Schema) *Schema {
+	switch {
+	case s == nil:
+		return &Schema{}
+	case s.Ref != "" || len(s.AnyOf) > 0:
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{TypeNull}}}}
+	case len(s.Type) == 0 || s.Type.Has(TypeNull):
+		return s
+	}
+
+	ns := *s
+	ns.Type = append(append(Types{}, s.Type...), TypeNull)
+	return &ns
+}
+
+// String returns the indented JSON encoding of the schema.
+func (s *Schema) String() string {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return err.Error()
+	}
+	return sb.String()
+}
@@ -0,0 +1,153 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ValidationError describes where a JSON document does not conform to a
+// schema.
+type ValidationError struct {
+	Path   string
+	Reason string
+}
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ve.Path, ve.Reason)
+}
+
+// Validate checks that the JSON document conforms to the schema. Formats are
+// not checked.
+func (s *Schema) Validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return errors.Wrap(err, "invalid JSON document")
+	}
+
+	return s.validate(s, doc, "$")
+}
+
+func (s *Schema) resolve(ref string) (*Schema, error) {
+	if !strings.HasPrefix(ref, defsRefPrefix) {
+		return nil, errors.Errorf("unsupported reference %q", ref)
+	}
+	def, found := s.Defs[strings.TrimPrefix(ref, defsRefPrefix)]
+	if !found {
+		return nil, errors.Errorf("unresolved reference %q", ref)
+	}
+	return def, nil
+}
+
+func isInteger(v interface{}) bool {
+	num, ok := v.(json.Number)
+	if !ok {
+		return false
+	}
+	if _, err := num.Int64(); err == nil {
+		return true
+	}
+	// Large unsigned values don't fit in an int64.
+	return !strings.ContainsAny(num.String(), ".eE")
+}
+
+func (s *Schema) validate(root *Schema, v interface{}, path string) error {
+	if s.Ref != "" {
+		def, err := root.resolve(s.Ref)
+		if err != nil {
+			return err
+		}
+		if err := def.validate(root, v, path); err != nil {
+			return err
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		var reasons []string
+		for _, sub := range s.AnyOf {
+			err := sub.validate(root, v, path)
+			if err == nil {
+				reasons = nil
+				break
+			}
+			reasons = append(reasons, err.Error())
+		}
+		if len(reasons) > 0 {
+			return &ValidationError{
+				Path:   path,
+				Reason: fmt.Sprintf("does not match any schema (%s)", strings.Join(reasons, "; ")),
+			}
+		}
+	}
+
+	typ := valueType(v)
+	if len(s.Type) > 0 {
+		if !s.Type.Has(typ) && !(typ == TypeNumber && s.Type.Has(TypeInteger) && isInteger(v)) {
+			return &ValidationError{
+				Path:   path,
+				Reason: fmt.Sprintf("%s is not of type %s", typ, strings.Join(s.Type, " or ")),
+			}
+		}
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return s.validateObject(root, val, path)
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range val {
+			if err := s.Items.validate(root, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(root *Schema, obj map[string]interface{}, path string) error {
+	for _, name := range s.Required {
+		if _, found := obj[name]; !found {
+			return &ValidationError{
+				Path:   path,
+				Reason: fmt.Sprintf("missing required property %q", name),
+			}
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, found := s.Properties[name]
+		if !found {
+			prop = s.AdditionalProperties
+		}
+		if prop == nil {
+			continue
+		}
+		if err := prop.validate(root, obj[name], path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestJSONSchema_Validate(t *testing.T) {
+	schema := &Schema{
+		Type: Types{TypeObject},
+		Properties: map[string]*Schema{
+			"rank":  {Type: Types{TypeInteger}},
+			"ratio": {Type: Types{TypeNumber}},
+			"hosts": {
+				Type:  Types{TypeArray, TypeNull},
+				Items: &Schema{Ref: "#/$defs/host"},
+			},
+			"state": {AnyOf: []*Schema{
+				{Type: Types{TypeString}},
+				{Type: Types{TypeBoolean}},
+			}},
+			"anything": {},
+		},
+		Required:             []string{"rank"},
+		AdditionalProperties: &Schema{Type: Types{TypeString}},
+		Defs: map[string]*Schema{
+			"host": {
+				Type: Types{TypeObject},
+				Properties: map[string]*Schema{
+					"addr": {Type: Types{TypeString}},
+				},
+				Required: []string{"addr"},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		schema *Schema
+		doc    string
+		expErr error
+	}{
+		"invalid JSON": {
+			doc:    `{"rank":`,
+			expErr: errors.New("invalid JSON"),
+		},
+		"minimal": {
+			doc: `{"rank": 1}`,
+		},
+		"full": {
+			doc: `{"rank": 18446744073709551615, "ratio": 0.5, "hosts": [{"addr": "a"}, {"addr": "b"}],
+				"state": true, "anything": [null], "other": "x"}`,
+		},
+		"null array": {
+			doc: `{"rank": 1, "hosts": null}`,
+		},
+		"wrong type": {
+			doc:    `[]`,
+			expErr: errors.New("$: array is not of type object"),
+		},
+		"missing required": {
+			doc:    `{"ratio": 1}`,
+			expErr: errors.New(`$: missing required property "rank"`),
+		},
+		"not an integer": {
+			doc:    `{"rank": 1.5}`,
+			expErr: errors.New("$.rank: number is not of type integer"),
+		},
+		"bad item": {
+			doc:    `{"rank": 1, "hosts": [{"addr": "a"}, {}]}`,
+			expErr: errors.New(`$.hosts[1]: missing required property "addr"`),
+		},
+		"no matching schema": {
+			doc:    `{"rank": 1, "state": 1}`,
+			expErr: errors.New("$.state: does not match any schema"),
+		},
+		"bad additional property": {
+			doc:    `{"rank": 1, "other": 1}`,
+			expErr: errors.New("$.other: number is not of type string"),
+		},
+		"unresolved reference": {
+			schema: &Schema{Ref: "#/$defs/missing"},
+			doc:    `{}`,
+			expErr: errors.New("unresolved reference"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := tc.schema
+			if s == nil {
+				s = schema
+			}
+			test.CmpErr(t, tc.expErr, s.Validate([]byte(tc.doc)))
+		})
+	}
+}
+
+func TestJSONSchema_Nullable(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in     *Schema
+		expOut *Schema
+	}{
+		"nil": {
+			expOut: &Schema{},
+		},
+		"any": {
+			in:     &Schema{},
+			expOut: &Schema{},
+		},
+		"typed": {
+			in:     &Schema{Type: Types{TypeString}},
+			expOut: &Schema{Type: Types{TypeString, TypeNull}},
+		},
+		"already nullable": {
+			in:     &Schema{Type: Types{TypeString, TypeNull}},
+			expOut: &Schema{Type: Types{TypeString, TypeNull}},
+		},
+		"reference": {
+			in: &Schema{Ref: "#/$defs/x"},
+			expOut: &Schema{AnyOf: []*Schema{
+				{Ref: "#/$defs/x"},
+				{Type: Types{TypeNull}},
+			}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expOut.String(), Nullable(tc.in).String(), "unexpected schema")
+		})
+	}
+}
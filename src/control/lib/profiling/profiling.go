@@ -0,0 +1,286 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package profiling provides an HTTP server exposing runtime profiles and
+// execution traces of a control plane process, compatible with "go tool pprof"
+// and "go tool trace".
+//
+// The handlers are registered on a private mux rather than via net/http/pprof,
+// whose init() registers them on http.DefaultServeMux and would therefore
+// expose them on any other HTTP service in the process (e.g. the telemetry
+// exporter) regardless of whether profiling has been enabled.
+package profiling
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// DefaultServerAddress is the default listen address of the
+	// profiling server in daos_server.
+	DefaultServerAddress = "localhost:6060"
+	// DefaultAgentAddress is the default listen address of the
+	// profiling server in daos_agent.
+	DefaultAgentAddress = "localhost:6061"
+
+	// PathPrefix is the URL path under which profiles are served.
+	PathPrefix = "/debug/pprof/"
+
+	defaultDuration = 30 * time.Second
+	maxDuration     = 5 * time.Minute
+	shutdownTimeout = time.Second
+)
+
+// Config defines the configuration of the profiling server.
+type Config struct {
+	Enabled     bool   `yaml:"enabled,omitempty"`
+	Address     string `yaml:"address,omitempty"`
+	AllowRemote bool   `yaml:"allow_remote,omitempty"`
+}
+
+// ValidateAddress returns an error if the supplied address is not a valid
+// host:port listen address, or if it would accept connections from other
+// hosts and allowRemote is false.
+func ValidateAddress(addr string, allowRemote bool) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid profiling address %q", addr)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return errors.Errorf("invalid profiling address %q: bad port", addr)
+	}
+	if allowRemote || host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return errors.Errorf("profiling address %q is not a loopback address and remote access is not allowed", addr)
+}
+
+// Validate returns an error if the configuration is invalid.
+func (cfg *Config) Validate() error {
+	if cfg == nil || cfg.Address == "" {
+		return nil
+	}
+	return ValidateAddress(cfg.Address, cfg.AllowRemote)
+}
+
+// Server manages the lifecycle of a profiling HTTP server.
+type Server struct {
+	sync.Mutex
+	log         logging.Logger
+	defaultAddr string
+	srv         *http.Server
+	addr        string
+}
+
+// NewServer returns an initialized Server which will listen on defaultAddr
+// if it is started without an address.
+func NewServer(log logging.Logger, defaultAddr string) *Server {
+	return &Server{
+		log:         log,
+		defaultAddr: defaultAddr,
+	}
+}
+
+// Start starts the profiling server on the supplied address, or the default
+// address if empty. If the server is already running on a different address
+// it is restarted.
+func (s *Server) Start(addr string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if addr == "" {
+		addr = s.defaultAddr
+	}
+	if s.srv != nil {
+		if addr == s.addr {
+			return nil
+		}
+		s.stop()
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, "unable to start profiling server")
+	}
+
+	s.srv = &http.Server{Handler: NewHandler()}
+	s.addr = lis.Addr().String()
+	go func(srv *http.Server) {
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			s.log.Errorf("profiling server exited: %s", err)
+		}
+	}(s.srv)
+
+	s.log.Noticef("profiling server started on %s", s.addr)
+	return nil
+}
+
+// Stop stops the profiling server if it is running.
+func (s *Server) Stop() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.stop()
+}
+
+func (s *Server) stop() {
+	if s.srv == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.log.Debugf("profiling server didn't shut down within timeout: %s", err)
+		_ = s.srv.Close()
+	}
+
+	s.log.Noticef("profiling server on %s stopped", s.addr)
+	s.srv = nil
+	s.addr = ""
+}
+
+// Status returns whether the profiling server is running and the address
+// on which it is listening.
+func (s *Server) Status() (bool, string) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.srv != nil, s.addr
+}
+
+// NewHandler returns an http.Handler serving the runtime profiles under
+// PathPrefix.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPrefix, serveIndex)
+	mux.HandleFunc(PathPrefix+"profile", serveCPUProfile)
+	mux.HandleFunc(PathPrefix+"trace", serveTrace)
+	return mux
+}
+
+func serveError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Del("Content-Disposition")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, msg)
+}
+
+func getDuration(r *http.Request) (time.Duration, error) {
+	val := r.FormValue("seconds")
+	if val == "" {
+		return defaultDuration, nil
+	}
+
+	secs, err := strconv.ParseUint(val, 10, 32)
+	if err != nil || secs == 0 {
+		return 0, errors.Errorf("invalid seconds value %q", val)
+	}
+	dur := time.Duration(secs) * time.Second
+	if dur > maxDuration {
+		return 0, errors.Errorf("duration %s exceeds maximum of %s", dur, maxDuration)
+	}
+	return dur, nil
+}
+
+func wait(ctx context.Context, dur time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(dur):
+	}
+}
+
+func serveIndex(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	if name != "" {
+		serveProfile(w, r, name)
+		return
+	}
+
+	names := []string{"profile", "trace"}
+	for _, p := range pprof.Profiles() {
+		names = append(names, p.Name())
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s%s\n", PathPrefix, name)
+	}
+}
+
+func serveProfile(w http.ResponseWriter, r *http.Request, name string) {
+	p := pprof.Lookup(name)
+	if p == nil {
+		serveError(w, http.StatusNotFound, fmt.Sprintf("unknown profile %q", name))
+		return
+	}
+
+	debug, _ := strconv.Atoi(r.FormValue("debug"))
+	if name == "heap" && r.FormValue("gc") != "" {
+		runtime.GC()
+	}
+
+	if debug != 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	}
+	_ = p.WriteTo(w, debug)
+}
+
+func serveCPUProfile(w http.ResponseWriter, r *http.Request) {
+	dur, err := getDuration(r)
+	if err != nil {
+		serveError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		serveError(w, http.StatusConflict, fmt.Sprintf("unable to start CPU profile: %s", err))
+		return
+	}
+	wait(r.Context(), dur)
+	pprof.StopCPUProfile()
+}
+
+func serveTrace(w http.ResponseWriter, r *http.Request) {
+	dur, err := getDuration(r)
+	if err != nil {
+		serveError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="trace"`)
+	if err := trace.Start(w); err != nil {
+		serveError(w, http.StatusConflict, fmt.Sprintf("unable to start execution trace: %s", err))
+		return
+	}
+	wait(r.Context(), dur)
+	trace.Stop()
+}
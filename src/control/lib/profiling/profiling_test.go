@@ -0,0 +1,166 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package profiling
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestProfiling_ValidateAddress(t *testing.T) {
+	for name, tc := range map[string]struct {
+		addr        string
+		allowRemote bool
+		expErr      error
+	}{
+		"localhost": {
+			addr: "localhost:6060",
+		},
+		"ipv4 loopback": {
+			addr: "127.0.0.1:6060",
+		},
+		"ipv6 loopback": {
+			addr: "[::1]:6060",
+		},
+		"missing port": {
+			addr:   "localhost",
+			expErr: errors.New("invalid profiling address"),
+		},
+		"bad port": {
+			addr:   "localhost:99999",
+			expErr: errors.New("bad port"),
+		},
+		"remote not allowed": {
+			addr:   "0.0.0.0:6060",
+			expErr: errors.New("remote access is not allowed"),
+		},
+		"hostname not allowed": {
+			addr:   "foo.example.com:6060",
+			expErr: errors.New("remote access is not allowed"),
+		},
+		"remote allowed": {
+			addr:        "0.0.0.0:6060",
+			allowRemote: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, ValidateAddress(tc.addr, tc.allowRemote))
+		})
+	}
+}
+
+func TestProfiling_Handler(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path      string
+		expStatus int
+		expBody   string
+	}{
+		"index": {
+			path:      PathPrefix,
+			expStatus: http.StatusOK,
+			expBody:   PathPrefix + "goroutine\n",
+		},
+		"goroutine text": {
+			path:      PathPrefix + "goroutine?debug=1",
+			expStatus: http.StatusOK,
+			expBody:   "goroutine profile:",
+		},
+		"unknown profile": {
+			path:      PathPrefix + "bogus",
+			expStatus: http.StatusNotFound,
+			expBody:   "unknown profile",
+		},
+		"cpu profile bad duration": {
+			path:      PathPrefix + "profile?seconds=foo",
+			expStatus: http.StatusBadRequest,
+			expBody:   "invalid seconds",
+		},
+		"cpu profile duration too long": {
+			path:      PathPrefix + "profile?seconds=3600",
+			expStatus: http.StatusBadRequest,
+			expBody:   "exceeds maximum",
+		},
+		"cpu profile": {
+			path:      PathPrefix + "profile?seconds=1",
+			expStatus: http.StatusOK,
+		},
+		"trace": {
+			path:      PathPrefix + "trace?seconds=1",
+			expStatus: http.StatusOK,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			test.AssertEqual(t, tc.expStatus, rec.Code, "unexpected status")
+			if !strings.Contains(rec.Body.String(), tc.expBody) {
+				t.Fatalf("expected body to contain %q, got:\n%s", tc.expBody, rec.Body.String())
+			}
+			if tc.expStatus == http.StatusOK && rec.Body.Len() == 0 {
+				t.Fatal("expected non-empty body")
+			}
+		})
+	}
+}
+
+func TestProfiling_Server(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	srv := NewServer(log, "127.0.0.1:0")
+	defer srv.Stop()
+
+	if running, _ := srv.Status(); running {
+		t.Fatal("server running before start")
+	}
+
+	if err := srv.Start(""); err != nil {
+		t.Fatal(err)
+	}
+	running, addr := srv.Status()
+	if !running || addr == "" {
+		t.Fatalf("expected running server, got running=%t addr=%q", running, addr)
+	}
+
+	// Starting again on the same address is a no-op.
+	if err := srv.Start(addr); err != nil {
+		t.Fatal(err)
+	}
+	if _, newAddr := srv.Status(); newAddr != addr {
+		t.Fatalf("expected address %q, got %q", addr, newAddr)
+	}
+
+	resp, err := http.Get("http://" + addr + PathPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "heap") {
+		t.Fatalf("unexpected index:\n%s", body)
+	}
+
+	srv.Stop()
+	if running, _ := srv.Status(); running {
+		t.Fatal("server running after stop")
+	}
+	if _, err := http.Get("http://" + addr + PathPrefix); err == nil {
+		t.Fatal("expected error after stop")
+	}
+}
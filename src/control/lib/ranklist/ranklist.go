@@ -191,3 +191,75 @@ func ParseRanks(stringRanks string) ([]Rank, error) {
 
 	return rs.Ranks(), nil
 }
+
+// rankCounts returns the number of the supplied RankSets that each rank
+// is a member of.
+func rankCounts(sets ...*RankSet) map[Rank]int {
+	counts := make(map[Rank]int)
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		for _, r := range set.Ranks() {
+			counts[r]++
+		}
+	}
+	return counts
+}
+
+// Union returns a new RankSet containing the ranks which are in this
+// RankSet or in any of the supplied RankSets.
+func (rs *RankSet) Union(others ...*RankSet) *RankSet {
+	out := NewRankSet()
+	for r := range rankCounts(append([]*RankSet{rs}, others...)...) {
+		out.Add(r)
+	}
+	return out
+}
+
+// Intersection returns a new RankSet containing the ranks which are in
+// this RankSet and in all of the supplied RankSets.
+func (rs *RankSet) Intersection(others ...*RankSet) *RankSet {
+	counts := rankCounts(others...)
+
+	out := NewRankSet()
+	if rs == nil {
+		return out
+	}
+	for _, r := range rs.Ranks() {
+		if counts[r] == len(others) {
+			out.Add(r)
+		}
+	}
+	return out
+}
+
+// Difference returns a new RankSet containing the ranks which are in this
+// RankSet but in none of the supplied RankSets.
+func (rs *RankSet) Difference(others ...*RankSet) *RankSet {
+	counts := rankCounts(others...)
+
+	out := NewRankSet()
+	if rs == nil {
+		return out
+	}
+	for _, r := range rs.Ranks() {
+		if counts[r] == 0 {
+			out.Add(r)
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns a new RankSet containing the ranks which are
+// in an odd number of this and the supplied RankSets, i.e. for two sets,
+// the ranks which are in either set but not in both.
+func (rs *RankSet) SymmetricDifference(others ...*RankSet) *RankSet {
+	out := NewRankSet()
+	for r, count := range rankCounts(append([]*RankSet{rs}, others...)...) {
+		if count%2 == 1 {
+			out.Add(r)
+		}
+	}
+	return out
+}
@@ -96,3 +96,56 @@ func TestRankList_RankSet(t *testing.T) {
 		})
 	}
 }
+
+func TestRankList_RankSet_SetOperations(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ranks     string
+		others    []string
+		expUnion  string
+		expInter  string
+		expDiff   string
+		expSymDif string
+	}{
+		"no others": {
+			ranks:     "0-3",
+			expUnion:  "0-3",
+			expInter:  "0-3",
+			expDiff:   "0-3",
+			expSymDif: "0-3",
+		},
+		"overlapping ranges": {
+			ranks:     "0-7",
+			others:    []string{"4-11"},
+			expUnion:  "0-11",
+			expInter:  "4-7",
+			expDiff:   "0-3",
+			expSymDif: "0-3,8-11",
+		},
+		"multiple others": {
+			ranks:     "0-9",
+			others:    []string{"0-5", "3-11"},
+			expUnion:  "0-11",
+			expInter:  "3-5",
+			expSymDif: "3-5,10-11",
+		},
+		"empty set": {
+			others:    []string{"1,3"},
+			expUnion:  "1,3",
+			expSymDif: "1,3",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rs := MustCreateRankSet(tc.ranks)
+			var others []*RankSet
+			for _, o := range tc.others {
+				others = append(others, MustCreateRankSet(o))
+			}
+
+			test.AssertEqual(t, tc.expUnion, rs.Union(others...).String(), "unexpected union")
+			test.AssertEqual(t, tc.expInter, rs.Intersection(others...).String(), "unexpected intersection")
+			test.AssertEqual(t, tc.expDiff, rs.Difference(others...).String(), "unexpected difference")
+			test.AssertEqual(t, tc.expSymDif, rs.SymmetricDifference(others...).String(), "unexpected symmetric difference")
+			test.AssertEqual(t, MustCreateRankSet(tc.ranks).String(), rs.String(), "receiver modified")
+		})
+	}
+}
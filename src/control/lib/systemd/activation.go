@@ -0,0 +1,75 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package systemd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// listenFDsStart is the first file descriptor passed by socket activation.
+const listenFDsStart = 3
+
+// ListenFiles returns the files of the sockets passed to the process by
+// systemd socket activation, in the order in which they are listed in the
+// socket unit. Each file is named with its FileDescriptorName= if one is set.
+// The activation environment variables are unset so that they are not
+// inherited by child processes. No files are returned if the process was not
+// socket-activated.
+func ListenFiles() ([]*os.File, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	return listenFiles(listenFDsStart, os.Getpid(),
+		os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_FDNAMES"))
+}
+
+func listenFiles(start, pid int, listenPID, listenFDs, fdNames string) ([]*os.File, error) {
+	if listenPID == "" {
+		return nil, nil
+	}
+	lpid, err := strconv.Atoi(listenPID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid LISTEN_PID %q", listenPID)
+	}
+	if lpid != pid {
+		// The sockets were passed to a parent process.
+		return nil, nil
+	}
+
+	numFDs, err := strconv.Atoi(listenFDs)
+	if err != nil || numFDs < 0 {
+		return nil, errors.Errorf("invalid LISTEN_FDS %q", listenFDs)
+	}
+
+	var names []string
+	if fdNames != "" {
+		names = strings.Split(fdNames, ":")
+	}
+
+	files := make([]*os.File, 0, numFDs)
+	for i := 0; i < numFDs; i++ {
+		fd := start + i
+		syscall.CloseOnExec(fd)
+
+		name := fmt.Sprintf("LISTEN_FD_%d", fd)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		files = append(files, os.NewFile(uintptr(fd), name))
+	}
+
+	return files, nil
+}
@@ -0,0 +1,117 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package systemd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func Test_Systemd_listenFiles(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	for name, tc := range map[string]struct {
+		listenPID string
+		listenFDs string
+		fdNames   string
+		expNames  []string
+		expErr    error
+	}{
+		"not activated": {},
+		"activated parent process": {
+			listenPID: "1",
+			listenFDs: "1",
+		},
+		"invalid pid": {
+			listenPID: "pid",
+			expErr:    errors.New("invalid LISTEN_PID"),
+		},
+		"invalid fd count": {
+			listenPID: "100",
+			listenFDs: "-1",
+			expErr:    errors.New("invalid LISTEN_FDS"),
+		},
+		"no fds": {
+			listenPID: "100",
+			listenFDs: "0",
+		},
+		"unnamed fd": {
+			listenPID: "100",
+			listenFDs: "1",
+			expNames:  []string{"LISTEN_FD_%d"},
+		},
+		"named fd": {
+			listenPID: "100",
+			listenFDs: "1",
+			fdNames:   "agent",
+			expNames:  []string{"agent"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fd, err := syscall.Dup(int(r.Fd()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files, gotErr := listenFiles(fd, 100, tc.listenPID, tc.listenFDs, tc.fdNames)
+			defer func() {
+				if len(files) > 0 {
+					files[0].Close()
+					return
+				}
+				syscall.Close(fd)
+			}()
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotNames []string
+			for _, f := range files {
+				test.AssertEqual(t, uintptr(fd), f.Fd(), "unexpected fd")
+				gotNames = append(gotNames, f.Name())
+
+				flags, err := unixFcntl(fd, syscall.F_GETFD)
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertTrue(t, flags&syscall.FD_CLOEXEC != 0, "expected close-on-exec to be set")
+			}
+
+			var expNames []string
+			for _, name := range tc.expNames {
+				if name == "LISTEN_FD_%d" {
+					name = fmt.Sprintf(name, fd)
+				}
+				expNames = append(expNames, name)
+			}
+			if diff := cmp.Diff(expNames, gotNames); diff != "" {
+				t.Fatalf("unexpected files (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func unixFcntl(fd, cmd int) (int, error) {
+	val, _, errno := syscall.Syscall(syscall.SYS_FCNTL, uintptr(fd), uintptr(cmd), 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(val), nil
+}
@@ -91,6 +91,9 @@ func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
 					}
 				}
 			}
+			if hm, ok := sm.metric.(telemetry.HistogramMetric); ok && sm.hist != nil && err == nil {
+				err = sm.hist.set(hm, sm.labels)
+			}
 		case telemetry.MetricTypeCounter:
 			err = sm.cvm.set(sm.baseName, sm.metric.FloatValue(), sm.labels)
 		default:
@@ -38,6 +38,10 @@ type (
 		MetricSource
 		Index uint32
 		Rank  uint32
+		// TargetCount is the number of targets configured for the
+		// engine, used to label the scheduler metrics of each xstream
+		// with the target it serves.
+		TargetCount int
 	}
 )
 
@@ -184,6 +188,11 @@ func extractLabels(log logging.Logger, in string) (labels labelMap, name string)
 		compsIdx++
 		labels["device"] = comps[compsIdx]
 		compsIdx++
+	case "jobs":
+		name = "job"
+		compsIdx++
+		labels["job"] = comps[compsIdx]
+		compsIdx++
 	}
 
 	for {
@@ -212,10 +221,51 @@ func newRankMetric(log logging.Logger, rank uint32, m telemetry.Metric) *sourceM
 	labels, name := extractLabels(log, m.FullPath())
 	baseName := "engine_" + name
 	labels["rank"] = fmt.Sprintf("%d", rank)
+	if _, found := labels["xstream"]; found && strings.HasPrefix(baseName, schedMetricPrefix) {
+		// Set for each collection once all xstreams are known.
+		labels["target"] = ""
+		labels["xs_type"] = ""
+	}
 
 	return newSourceMetric(log, m, baseName, labels)
 }
 
+// Collect collects the metrics for the engine. The scheduler metrics are held
+// back until all xstreams have been seen so that they can be labeled with the
+// target each xstream serves.
+func (es *EngineSource) Collect(log logging.Logger, ch chan<- *sourceMetric) {
+	if es == nil || ch == nil || !es.IsEnabled() {
+		es.getMetricSource().Collect(log, ch)
+		return
+	}
+
+	metrics := make(chan *sourceMetric)
+	go func() {
+		es.MetricSource.Collect(log, metrics)
+		close(metrics)
+	}()
+
+	var sched []*sourceMetric
+	for sm := range metrics {
+		if isSchedMetric(sm) {
+			sched = append(sched, sm)
+			continue
+		}
+		ch <- sm
+	}
+
+	for _, sm := range labelSchedMetrics(log, es.Rank, es.TargetCount, sched) {
+		ch <- sm
+	}
+}
+
+func (es *EngineSource) getMetricSource() *MetricSource {
+	if es == nil {
+		return nil
+	}
+	return &es.MetricSource
+}
+
 // AddSource adds an EngineSource to the Collector.
 func (c *EngineCollector) AddSource(es *EngineSource, cleanup func()) {
 	if es == nil {
@@ -622,6 +622,14 @@ func TestPromExp_extractEngineLabels(t *testing.T) {
 				"device": "d70505:05:00.0",
 			},
 		},
+		"job_io_update_bytes": {
+			input:   "ID: 0/jobs/testJob/io/update_bytes/tgt_3",
+			expName: "job_io_update_bytes",
+			expLabels: labelMap{
+				"job":    "testJob",
+				"target": "3",
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
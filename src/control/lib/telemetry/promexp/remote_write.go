@@ -0,0 +1,424 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package promexp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// DefaultRemoteWriteInterval is the default period between pushes of
+	// gathered metrics to a remote-write receiver.
+	DefaultRemoteWriteInterval = 30 * time.Second
+	// DefaultRemoteWriteMaxPending is the default number of unsent pushes
+	// held for retry while the receiver is unavailable.
+	DefaultRemoteWriteMaxPending = 10
+
+	remoteWriteTimeout = 10 * time.Second
+	remoteWriteVersion = "0.1.0"
+)
+
+type (
+	// RemoteWriteConfig defines the configuration for pushing metrics to a
+	// Prometheus remote-write receiver such as Mimir or Thanos.
+	RemoteWriteConfig struct {
+		URL            string
+		Interval       time.Duration
+		MaxPending     int
+		BearerToken    string
+		ExternalLabels map[string]string
+		Gatherer       prometheus.Gatherer
+	}
+
+	rwLabel struct {
+		name  string
+		value string
+	}
+
+	rwSeries struct {
+		labels []rwLabel
+		value  float64
+		tsMs   int64
+	}
+
+	// RemoteWriter periodically gathers metrics and pushes them to a
+	// remote-write receiver. Pushes that fail with a retryable error are
+	// queued and resent in order on the following intervals.
+	RemoteWriter struct {
+		log      logging.Logger
+		cfg      RemoteWriteConfig
+		client   *http.Client
+		mu       sync.Mutex
+		pending  [][]byte
+		dropped  int
+		done     chan struct{}
+		stopped  chan struct{}
+		stopOnce sync.Once
+	}
+
+	// remoteWriteError is returned for pushes rejected by the receiver.
+	remoteWriteError struct {
+		status    string
+		retryable bool
+	}
+)
+
+func (e *remoteWriteError) Error() string {
+	return fmt.Sprintf("remote-write receiver returned %s", e.status)
+}
+
+func (cfg *RemoteWriteConfig) validate() error {
+	if cfg == nil {
+		return errors.New("invalid remote-write config: nil config")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid remote-write config: bad url %q", cfg.URL)
+	}
+	if cfg.Interval < 0 || cfg.MaxPending < 0 {
+		return errors.New("invalid remote-write config: negative interval or max pending")
+	}
+	if cfg.Gatherer == nil {
+		return errors.New("invalid remote-write config: nil gatherer")
+	}
+
+	return nil
+}
+
+// NewRemoteWriter returns a RemoteWriter for the given config. Unset
+// interval and max pending values are replaced with defaults.
+func NewRemoteWriter(log logging.Logger, cfg *RemoteWriteConfig) (*RemoteWriter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	rw := &RemoteWriter{
+		log:     log,
+		cfg:     *cfg,
+		client:  &http.Client{Timeout: remoteWriteTimeout},
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if rw.cfg.Interval == 0 {
+		rw.cfg.Interval = DefaultRemoteWriteInterval
+	}
+	if rw.cfg.MaxPending == 0 {
+		rw.cfg.MaxPending = DefaultRemoteWriteMaxPending
+	}
+
+	return rw, nil
+}
+
+// StartRemoteWriter starts pushing metrics to a remote-write receiver and
+// returns a function that flushes pending pushes and stops the writer.
+func StartRemoteWriter(ctx context.Context, log logging.Logger, cfg *RemoteWriteConfig) (func(), error) {
+	rw, err := NewRemoteWriter(log, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Pushing metrics to %s every %s", rw.cfg.URL, rw.cfg.Interval)
+	go rw.run(ctx)
+
+	return func() {
+		log.Debug("Shutting down Prometheus remote-write")
+
+		// When this cleanup function is called, the original context
+		// will probably have already been canceled.
+		timedCtx, cancel := context.WithTimeout(context.Background(), remoteWriteTimeout)
+		defer cancel()
+		if err := rw.Stop(timedCtx); err != nil {
+			log.Noticef("remote-write didn't shut down within timeout: %s", err.Error())
+		}
+	}, nil
+}
+
+// Stop pushes a final set of metrics and stops the writer.
+func (rw *RemoteWriter) Stop(ctx context.Context) error {
+	rw.stopOnce.Do(func() {
+		close(rw.done)
+	})
+
+	select {
+	case <-rw.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rw *RemoteWriter) run(ctx context.Context) {
+	defer close(rw.stopped)
+
+	ticker := time.NewTicker(rw.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-rw.done:
+			rw.push(context.Background())
+			return
+		case <-ticker.C:
+			rw.push(ctx)
+		}
+	}
+}
+
+// push gathers the current metrics, queues them and sends everything that
+// is queued.
+func (rw *RemoteWriter) push(ctx context.Context) {
+	mfs, err := rw.cfg.Gatherer.Gather()
+	if err != nil {
+		// Gather returns whatever it could collect along with the error.
+		rw.log.Debugf("remote-write: gathering metrics: %s", err)
+	}
+
+	series := seriesFromFamilies(mfs, rw.cfg.ExternalLabels, time.Now())
+	if len(series) > 0 {
+		rw.enqueue(snappyEncode(encodeWriteRequest(series)))
+	}
+
+	if err := rw.flush(ctx); err != nil {
+		rw.log.Debugf("remote-write: %s", err)
+	}
+}
+
+func (rw *RemoteWriter) enqueue(req []byte) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	rw.pending = append(rw.pending, req)
+	if over := len(rw.pending) - rw.cfg.MaxPending; over > 0 {
+		rw.pending = rw.pending[over:]
+		rw.dropped += over
+	}
+}
+
+// flush sends queued pushes oldest first. Sending stops at the first
+// retryable failure so that the receiver sees samples in order; pushes
+// that the receiver rejects outright are dropped.
+func (rw *RemoteWriter) flush(ctx context.Context) error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.dropped > 0 {
+		rw.log.Noticef("remote-write: queue full; dropped %d pushes", rw.dropped)
+		rw.dropped = 0
+	}
+
+	for len(rw.pending) > 0 {
+		err := rw.send(ctx, rw.pending[0])
+		if err != nil {
+			if rwe, ok := err.(*remoteWriteError); !ok || rwe.retryable {
+				return errors.Wrapf(err, "%d pushes pending", len(rw.pending))
+			}
+			rw.log.Errorf("remote-write: dropping rejected push: %s", err)
+		}
+		rw.pending = rw.pending[1:]
+	}
+
+	return nil
+}
+
+func (rw *RemoteWriter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rw.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "daos_server")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+	if rw.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+rw.cfg.BearerToken)
+	}
+
+	resp, err := rw.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	return &remoteWriteError{
+		status: resp.Status,
+		// Per the remote-write spec, only server errors and throttling
+		// should be retried.
+		retryable: resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests,
+	}
+}
+
+// seriesFromFamilies flattens gathered metric families into remote-write
+// series in the same way that a Prometheus scrape would, e.g. histograms are
+// expanded into _bucket, _sum and _count series.
+func seriesFromFamilies(mfs []*dto.MetricFamily, extLabels map[string]string, now time.Time) []*rwSeries {
+	var out []*rwSeries
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+
+	for _, mf := range mfs {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			ts := nowMs
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			add := func(suffix string, value float64, extra ...rwLabel) {
+				out = append(out, &rwSeries{
+					labels: seriesLabels(name+suffix, m.GetLabel(), extLabels, extra...),
+					value:  value,
+					tsMs:   ts,
+				})
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add("", m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add("", q.GetValue(), rwLabel{"quantile", formatFloat(q.GetQuantile())})
+				}
+				add("_sum", s.GetSampleSum())
+				add("_count", float64(s.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					add("_bucket", float64(b.GetCumulativeCount()),
+						rwLabel{"le", formatFloat(b.GetUpperBound())})
+				}
+				add("_bucket", float64(h.GetSampleCount()), rwLabel{"le", "+Inf"})
+				add("_sum", h.GetSampleSum())
+				add("_count", float64(h.GetSampleCount()))
+			}
+		}
+	}
+
+	return out
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// seriesLabels returns the sorted label set for a series. Labels on the
+// metric take precedence over external labels with the same name.
+func seriesLabels(name string, pairs []*dto.LabelPair, extLabels map[string]string, extra ...rwLabel) []rwLabel {
+	set := make(map[string]string, len(extLabels)+len(pairs)+len(extra)+1)
+	for k, v := range extLabels {
+		set[k] = v
+	}
+	for _, lp := range pairs {
+		set[lp.GetName()] = lp.GetValue()
+	}
+	for _, l := range extra {
+		set[l.name] = l.value
+	}
+	set["__name__"] = name
+
+	labels := make([]rwLabel, 0, len(set))
+	for k, v := range set {
+		labels = append(labels, rwLabel{k, v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+
+	return labels
+}
+
+// encodeWriteRequest encodes the series as a prometheus.WriteRequest
+// protobuf message.
+func encodeWriteRequest(series []*rwSeries) []byte {
+	var req []byte
+	for _, s := range series {
+		var ts []byte
+		for _, l := range s.labels {
+			var lb []byte
+			lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.name)
+			lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+			lb = protowire.AppendString(lb, l.value)
+
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, lb)
+		}
+
+		var sb []byte
+		sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+		sb = protowire.AppendFixed64(sb, math.Float64bits(s.value))
+		sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+		sb = protowire.AppendVarint(sb, uint64(s.tsMs))
+
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sb)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+
+	return req
+}
+
+// snappyEncode returns src in the snappy block format required by
+// remote-write receivers. The data is stored as literals only, which is
+// valid snappy and avoids a compression dependency; pushes are sent
+// infrequently so the extra bandwidth is not significant.
+func snappyEncode(src []byte) []byte {
+	const maxLiteral = 1 << 16
+
+	dst := protowire.AppendVarint(nil, uint64(len(src)))
+	for len(src) > 0 {
+		n := len(src)
+		if n > maxLiteral {
+			n = maxLiteral
+		}
+
+		switch l := n - 1; {
+		case l < 60:
+			dst = append(dst, byte(l<<2))
+		case l < 1<<8:
+			dst = append(dst, 60<<2, byte(l))
+		default:
+			dst = append(dst, 61<<2, byte(l), byte(l>>8))
+		}
+		dst = append(dst, src[:n]...)
+		src = src[n:]
+	}
+
+	return dst
+}
@@ -0,0 +1,286 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package promexp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// snappyDecodeLiterals decodes snappy block data containing only literals.
+func snappyDecodeLiterals(t *testing.T, src []byte) []byte {
+	t.Helper()
+
+	dLen, n := protowire.ConsumeVarint(src)
+	if n < 0 {
+		t.Fatal("bad snappy length")
+	}
+	src = src[n:]
+
+	var dst []byte
+	for len(src) > 0 {
+		tag := src[0]
+		if tag&0x3 != 0 {
+			t.Fatalf("unexpected snappy tag %x", tag)
+		}
+		l := int(tag >> 2)
+		src = src[1:]
+		switch l {
+		case 60:
+			l = int(src[0])
+			src = src[1:]
+		case 61:
+			l = int(src[0]) | int(src[1])<<8
+			src = src[2:]
+		}
+		dst = append(dst, src[:l+1]...)
+		src = src[l+1:]
+	}
+
+	if uint64(len(dst)) != dLen {
+		t.Fatalf("decoded %d bytes, header says %d", len(dst), dLen)
+	}
+	return dst
+}
+
+func TestPromExp_snappyEncode(t *testing.T) {
+	for name, tc := range map[string]struct {
+		size int
+	}{
+		"empty":          {},
+		"short literal":  {size: 10},
+		"1-byte length":  {size: 200},
+		"2-byte length":  {size: 5000},
+		"multiple chunk": {size: 70000},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := make([]byte, tc.size)
+			for i := range src {
+				src[i] = byte(i)
+			}
+
+			got := snappyDecodeLiterals(t, snappyEncode(src))
+			test.AssertTrue(t, bytes.Equal(src, got), "round trip mismatch")
+		})
+	}
+}
+
+func TestPromExp_seriesFromFamilies(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	ctr := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "engine_ops",
+		Help: "ops",
+	}, []string{"rank"})
+	ctr.WithLabelValues("1").Add(5)
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "engine_lat",
+		Help:    "latency",
+		Buckets: []float64{1},
+	})
+	hist.Observe(0.5)
+	hist.Observe(2)
+	reg.MustRegister(ctr, hist)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1000, 0)
+	ext := map[string]string{"cluster": "daos1", "rank": "none"}
+	got := seriesFromFamilies(mfs, ext, now)
+
+	lbls := func(kv ...string) []rwLabel {
+		var out []rwLabel
+		for i := 0; i < len(kv); i += 2 {
+			out = append(out, rwLabel{kv[i], kv[i+1]})
+		}
+		return out
+	}
+	expTS := int64(1000000)
+	exp := []*rwSeries{
+		{labels: lbls("__name__", "engine_lat_bucket", "cluster", "daos1", "le", "1", "rank", "none"), value: 1, tsMs: expTS},
+		{labels: lbls("__name__", "engine_lat_bucket", "cluster", "daos1", "le", "+Inf", "rank", "none"), value: 2, tsMs: expTS},
+		{labels: lbls("__name__", "engine_lat_sum", "cluster", "daos1", "rank", "none"), value: 2.5, tsMs: expTS},
+		{labels: lbls("__name__", "engine_lat_count", "cluster", "daos1", "rank", "none"), value: 2, tsMs: expTS},
+		{labels: lbls("__name__", "engine_ops", "cluster", "daos1", "rank", "1"), value: 5, tsMs: expTS},
+	}
+
+	if diff := cmp.Diff(exp, got, cmp.AllowUnexported(rwSeries{}, rwLabel{})); diff != "" {
+		t.Fatalf("unexpected series (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestPromExp_encodeWriteRequest(t *testing.T) {
+	req := encodeWriteRequest([]*rwSeries{
+		{labels: []rwLabel{{"__name__", "m"}}, value: 1.5, tsMs: 42},
+	})
+
+	// WriteRequest.timeseries
+	num, typ, n := protowire.ConsumeTag(req)
+	test.AssertEqual(t, protowire.Number(1), num, "timeseries field")
+	test.AssertEqual(t, protowire.BytesType, typ, "timeseries type")
+	ts, m := protowire.ConsumeBytes(req[n:])
+	test.AssertEqual(t, len(req), n+m, "trailing data")
+
+	// TimeSeries.labels
+	num, _, n = protowire.ConsumeTag(ts)
+	test.AssertEqual(t, protowire.Number(1), num, "labels field")
+	lbl, m := protowire.ConsumeBytes(ts[n:])
+	ts = ts[n+m:]
+	_, _, n = protowire.ConsumeTag(lbl)
+	name, m := protowire.ConsumeString(lbl[n:])
+	lbl = lbl[n+m:]
+	_, _, n = protowire.ConsumeTag(lbl)
+	value, _ := protowire.ConsumeString(lbl[n:])
+	test.AssertEqual(t, "__name__", name, "label name")
+	test.AssertEqual(t, "m", value, "label value")
+
+	// TimeSeries.samples
+	num, _, n = protowire.ConsumeTag(ts)
+	test.AssertEqual(t, protowire.Number(2), num, "samples field")
+	sample, _ := protowire.ConsumeBytes(ts[n:])
+	_, typ, n = protowire.ConsumeTag(sample)
+	test.AssertEqual(t, protowire.Fixed64Type, typ, "value type")
+	_, m = protowire.ConsumeFixed64(sample[n:])
+	sample = sample[n+m:]
+	num, _, n = protowire.ConsumeTag(sample)
+	test.AssertEqual(t, protowire.Number(2), num, "timestamp field")
+	tsMs, _ := protowire.ConsumeVarint(sample[n:])
+	test.AssertEqual(t, uint64(42), tsMs, "timestamp")
+}
+
+func TestPromExp_RemoteWriter_push(t *testing.T) {
+	for name, tc := range map[string]struct {
+		statuses   []int
+		maxPending int
+		expPending int
+		expSent    int
+	}{
+		"success": {
+			statuses: []int{http.StatusOK, http.StatusOK},
+			expSent:  2,
+		},
+		"server error retried": {
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusNoContent},
+			expSent:    3,
+			expPending: 0,
+		},
+		"throttled; still pending": {
+			statuses:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expSent:    2,
+			expPending: 2,
+		},
+		"rejected push dropped": {
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			expSent:  2,
+		},
+		"queue bounded": {
+			statuses:   []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			maxPending: 2,
+			expSent:    3,
+			expPending: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var status, sent int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent++
+				if r.Header.Get("Content-Encoding") != "snappy" ||
+					r.Header.Get("Authorization") != "Bearer secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				snappyDecodeLiterals(t, body)
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+
+			reg := prometheus.NewRegistry()
+			reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "g", Help: "g"}))
+
+			rw, err := NewRemoteWriter(log, &RemoteWriteConfig{
+				URL:         srv.URL,
+				MaxPending:  tc.maxPending,
+				BearerToken: "secret",
+				Gatherer:    reg,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, status = range tc.statuses {
+				rw.push(test.Context(t))
+			}
+
+			test.AssertEqual(t, tc.expSent, sent, "unexpected number of requests")
+			test.AssertEqual(t, tc.expPending, len(rw.pending), "unexpected pending pushes")
+		})
+	}
+}
+
+func TestPromExp_NewRemoteWriter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *RemoteWriteConfig
+		expErr error
+	}{
+		"nil cfg": {
+			expErr: errors.New("nil config"),
+		},
+		"bad url": {
+			cfg:    &RemoteWriteConfig{URL: "mimir:9009", Gatherer: prometheus.DefaultGatherer},
+			expErr: errors.New("bad url"),
+		},
+		"negative interval": {
+			cfg: &RemoteWriteConfig{
+				URL:      "http://mimir:9009/api/v1/push",
+				Interval: -time.Second,
+				Gatherer: prometheus.DefaultGatherer,
+			},
+			expErr: errors.New("negative"),
+		},
+		"nil gatherer": {
+			cfg:    &RemoteWriteConfig{URL: "http://mimir:9009/api/v1/push"},
+			expErr: errors.New("nil gatherer"),
+		},
+		"defaults": {
+			cfg: &RemoteWriteConfig{
+				URL:      "http://mimir:9009/api/v1/push",
+				Gatherer: prometheus.DefaultGatherer,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			rw, err := NewRemoteWriter(log, tc.cfg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			test.AssertEqual(t, DefaultRemoteWriteInterval, rw.cfg.Interval, "interval")
+			test.AssertEqual(t, DefaultRemoteWriteMaxPending, rw.cfg.MaxPending, "max pending")
+		})
+	}
+}
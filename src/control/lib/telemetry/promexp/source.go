@@ -90,6 +90,7 @@ type sourceMetric struct {
 	labels   labelMap
 	gvm      gvMap
 	cvm      cvMap
+	hist     *histogram
 }
 
 // collect sends the metrics vectors in the sourceMetric struct to the provided channel.
@@ -100,6 +101,9 @@ func (bm *sourceMetric) collect(ch chan<- prometheus.Metric) {
 	for _, cv := range bm.cvm {
 		cv.Collect(ch)
 	}
+	if bm.hist != nil && bm.hist.metric != nil {
+		ch <- bm.hist.metric
+	}
 }
 
 // resetVecs resets all the metrics vectors in the sourceMetric struct.
@@ -110,6 +114,9 @@ func (bm *sourceMetric) resetVecs() {
 	for _, cv := range bm.cvm {
 		cv.Reset()
 	}
+	if bm.hist != nil {
+		bm.hist.metric = nil
+	}
 }
 
 // newSourceMetric initializes a new sourceMetric struct.
@@ -137,6 +144,9 @@ func newSourceMetric(log logging.Logger, m telemetry.Metric, baseName string, la
 				sm.gvm.add(ms.name, ms.desc, sm.labels)
 			}
 		}
+		if _, ok := getHistogram(sm.metric); ok {
+			sm.hist = newHistogram(sm.baseName+"_hist", desc+" (histogram)", sm.labels)
+		}
 	case telemetry.MetricTypeCounter:
 		sm.cvm.add(sm.baseName, desc, sm.labels)
 	default:
@@ -110,6 +110,62 @@ func (m cvMap) set(name string, value float64, labels labelMap) error {
 	return nil
 }
 
+// histogram exports the buckets of a telemetry histogram as a native
+// Prometheus histogram. Unlike the gauge and counter vectors, the bucket
+// counts are read from the source rather than observed, so a constant
+// metric is generated for each collection.
+type histogram struct {
+	desc   *prometheus.Desc
+	metric prometheus.Metric
+}
+
+func newHistogram(name, help string, labels labelMap) *histogram {
+	return &histogram{
+		desc: prometheus.NewDesc(name, help, labels.keys(), nil),
+	}
+}
+
+func (h *histogram) set(hm telemetry.HistogramMetric, labels labelMap) error {
+	buckets := hm.Buckets()
+	if len(buckets) == 0 {
+		return errors.Errorf("no histogram buckets for %s", hm.FullPath())
+	}
+
+	// Prometheus buckets are cumulative and keyed by their upper bound. The
+	// last telemetry bucket is unbounded, and is implied by the total count.
+	var count uint64
+	upperBounds := make(map[float64]uint64, len(buckets)-1)
+	for i, bucket := range buckets {
+		count += bucket.Count
+		if i < len(buckets)-1 {
+			upperBounds[float64(bucket.Max)] = count
+		}
+	}
+
+	labelVals := make([]string, 0, len(labels))
+	for _, key := range labels.keys() {
+		labelVals = append(labelVals, labels[key])
+	}
+
+	metric, err := prometheus.NewConstHistogram(h.desc, count, float64(hm.Sum()), upperBounds, labelVals...)
+	if err != nil {
+		return err
+	}
+	h.metric = metric
+
+	return nil
+}
+
+// getHistogram returns the metric as a HistogramMetric if it has
+// histogram buckets.
+func getHistogram(m telemetry.Metric) (telemetry.HistogramMetric, bool) {
+	hm, ok := m.(telemetry.HistogramMetric)
+	if !ok || len(hm.Buckets()) == 0 {
+		return nil, false
+	}
+	return hm, true
+}
+
 type metricStat struct {
 	name      string
 	desc      string
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/lib/telemetry"
@@ -133,3 +134,54 @@ func TestPromExp_getMetricStats(t *testing.T) {
 		})
 	}
 }
+
+func TestPromExp_histogram_set(t *testing.T) {
+	segID := telemetry.NextTestID(telemetry.PromexpIDBase)
+	telemetry.InitTestMetricsProducer(t, segID, 4096)
+	defer telemetry.CleanupTestMetricsProducer(t)
+
+	ctx, err := telemetry.Init(test.Context(t), uint32(segID))
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	tm := &telemetry.TestMetric{
+		Name:           "hist_gauge",
+		Type:           telemetry.MetricTypeStatsGauge,
+		Values:         []uint64{1, 2, 3, 4, 5},
+		HistBuckets:    3,
+		HistWidth:      2,
+		HistMultiplier: 1,
+	}
+	telemetry.AddTestMetric(t, tm)
+
+	m, err := tm.GetMetric(ctx)
+	if err != nil {
+		t.Fatalf("GetMetric: %v", err)
+	}
+	hm, ok := getHistogram(m)
+	if !ok {
+		t.Fatal("expected metric to have a histogram")
+	}
+
+	labels := labelMap{"rank": "0"}
+	hist := newHistogram("hist_gauge_hist", "test", labels)
+	if err := hist.set(hm, labels); err != nil {
+		t.Fatal(err)
+	}
+
+	var got dto.Metric
+	if err := hist.metric.Write(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	// Buckets are [0..1], [2..3] and [4..max].
+	test.AssertEqual(t, uint64(5), got.GetHistogram().GetSampleCount(), "unexpected sample count")
+	test.AssertEqual(t, float64(15), got.GetHistogram().GetSampleSum(), "unexpected sample sum")
+	gotBuckets := make(map[float64]uint64)
+	for _, b := range got.GetHistogram().GetBucket() {
+		gotBuckets[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	test.AssertEqual(t, map[float64]uint64{1: 1, 3: 3}, gotBuckets, "unexpected buckets")
+	test.AssertEqual(t, "0", got.GetLabel()[0].GetValue(), "unexpected label value")
+}
@@ -0,0 +1,175 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package promexp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/daos-stack/daos/src/control/lib/telemetry"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// engineSysXstreams is the number of system xstreams started by each
+	// engine ahead of the target xstreams (system, SWIM and dRPC).
+	engineSysXstreams = 3
+
+	schedMetricPrefix    = "engine_sched_"
+	schedTotalTimeMetric = schedMetricPrefix + "total_time"
+	schedRelaxTimeMetric = schedMetricPrefix + "relax_time"
+	schedBusyTimeMetric  = schedMetricPrefix + "busy_time"
+
+	xsTypeSystem = "system"
+	xsTypeMain   = "main"
+	xsTypeHelper = "helper"
+)
+
+// isSchedMetric indicates whether the metric is one of the per-xstream
+// scheduler metrics.
+func isSchedMetric(sm *sourceMetric) bool {
+	if sm == nil || !strings.HasPrefix(sm.baseName, schedMetricPrefix) {
+		return false
+	}
+	_, found := sm.labels["xstream"]
+	return found
+}
+
+// xstreamRole returns the VOS target served by an xstream and whether it is a
+// system, main or helper xstream, following the engine's xstream layout. The
+// number of helper xstreams is derived from the total number of xstreams as
+// the engine may run fewer than configured. A target of -1 indicates that the
+// xstream doesn't serve a single target.
+func xstreamRole(xsID, numTargets, numXstreams int) (int, string) {
+	if xsID < engineSysXstreams {
+		return -1, xsTypeSystem
+	}
+	if numTargets <= 0 {
+		return -1, ""
+	}
+
+	off := xsID - engineSysXstreams
+	numHelpers := numXstreams - engineSysXstreams - numTargets
+	if numHelpers < 0 {
+		numHelpers = 0
+	}
+
+	// Helpers are shared by all targets when they can't be evenly
+	// assigned, and are started after all of the main xstreams.
+	if numHelpers%numTargets != 0 {
+		if off < numTargets {
+			return off, xsTypeMain
+		}
+		return -1, xsTypeHelper
+	}
+
+	perTarget := numHelpers/numTargets + 1
+	if off%perTarget == 0 {
+		return off / perTarget, xsTypeMain
+	}
+	return off / perTarget, xsTypeHelper
+}
+
+// derivedMetric is a counter computed from other engine metrics.
+type derivedMetric struct {
+	path  string
+	name  string
+	desc  string
+	units string
+	value float64
+}
+
+func (dm *derivedMetric) Path() string               { return dm.path }
+func (dm *derivedMetric) Name() string               { return dm.name }
+func (dm *derivedMetric) FullPath() string           { return dm.path + "/" + dm.name }
+func (dm *derivedMetric) Type() telemetry.MetricType { return telemetry.MetricTypeCounter }
+func (dm *derivedMetric) Desc() string               { return dm.desc }
+func (dm *derivedMetric) Units() string              { return dm.units }
+func (dm *derivedMetric) FloatValue() float64        { return dm.value }
+func (dm *derivedMetric) String() string             { return strconv.FormatFloat(dm.value, 'f', -1, 64) }
+
+// labelSchedMetrics sets the target and xstream type labels on the collected
+// scheduler metrics and returns a busy time counter for each xstream, derived
+// from its running and relaxing times.
+func labelSchedMetrics(log logging.Logger, rank uint32, numTargets int, metrics []*sourceMetric) []*sourceMetric {
+	numXstreams := 0
+	for _, sm := range metrics {
+		xsID, err := strconv.Atoi(sm.labels["xstream"])
+		if err != nil {
+			continue
+		}
+		if xsID+1 > numXstreams {
+			numXstreams = xsID + 1
+		}
+	}
+
+	type xsTimes struct {
+		total, relax *sourceMetric
+	}
+	times := make(map[string]*xsTimes)
+	var xsOrder []string
+
+	for _, sm := range metrics {
+		xs := sm.labels["xstream"]
+		target := ""
+		xsType := ""
+		if xsID, err := strconv.Atoi(xs); err == nil {
+			var tgt int
+			tgt, xsType = xstreamRole(xsID, numTargets, numXstreams)
+			if tgt >= 0 {
+				target = strconv.Itoa(tgt)
+			}
+		}
+		sm.labels["target"] = target
+		sm.labels["xs_type"] = xsType
+
+		switch sm.baseName {
+		case schedTotalTimeMetric, schedRelaxTimeMetric:
+			t, found := times[xs]
+			if !found {
+				t = new(xsTimes)
+				times[xs] = t
+				xsOrder = append(xsOrder, xs)
+			}
+			if sm.baseName == schedTotalTimeMetric {
+				t.total = sm
+			} else {
+				t.relax = sm
+			}
+		}
+	}
+
+	out := metrics
+	for _, xs := range xsOrder {
+		t := times[xs]
+		if t.total == nil || t.relax == nil {
+			continue
+		}
+
+		busy := t.total.metric.FloatValue() - t.relax.metric.FloatValue()
+		if busy < 0 {
+			busy = 0
+		}
+		dm := &derivedMetric{
+			path:  "sched/busy_time",
+			name:  "xs_" + xs,
+			desc:  "Total busy (running but not relaxing) time",
+			units: t.total.metric.Units(),
+			value: busy,
+		}
+		labels := labelMap{"rank": strconv.FormatUint(uint64(rank), 10)}
+		for _, key := range []string{"xstream", "target", "xs_type"} {
+			labels[key] = t.total.labels[key]
+		}
+		out = append(out, newSourceMetric(log, dm, schedBusyTimeMetric, labels))
+	}
+
+	return out
+}
@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package promexp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestPromExp_xstreamRole(t *testing.T) {
+	type role struct {
+		Target int
+		Type   string
+	}
+
+	for name, tc := range map[string]struct {
+		numTargets  int
+		numXstreams int
+		expRoles    []role
+	}{
+		"unknown targets": {
+			numXstreams: 5,
+			expRoles: []role{
+				{-1, "system"}, {-1, "system"}, {-1, "system"},
+				{-1, ""}, {-1, ""},
+			},
+		},
+		"no helpers": {
+			numTargets:  2,
+			numXstreams: 5,
+			expRoles: []role{
+				{-1, "system"}, {-1, "system"}, {-1, "system"},
+				{0, "main"}, {1, "main"},
+			},
+		},
+		"one helper per target": {
+			numTargets:  2,
+			numXstreams: 7,
+			expRoles: []role{
+				{-1, "system"}, {-1, "system"}, {-1, "system"},
+				{0, "main"}, {0, "helper"}, {1, "main"}, {1, "helper"},
+			},
+		},
+		"shared helpers": {
+			numTargets:  4,
+			numXstreams: 9,
+			expRoles: []role{
+				{-1, "system"}, {-1, "system"}, {-1, "system"},
+				{0, "main"}, {1, "main"}, {2, "main"}, {3, "main"},
+				{-1, "helper"}, {-1, "helper"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var gotRoles []role
+			for xs := 0; xs < tc.numXstreams; xs++ {
+				tgt, typ := xstreamRole(xs, tc.numTargets, tc.numXstreams)
+				gotRoles = append(gotRoles, role{tgt, typ})
+			}
+
+			if diff := cmp.Diff(tc.expRoles, gotRoles); diff != "" {
+				t.Fatalf("unexpected roles (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPromExp_labelSchedMetrics(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var metrics []*sourceMetric
+	addMetric := func(name string, xs int, value float64) {
+		m := &derivedMetric{
+			path:  "ID: 0/sched/" + name,
+			name:  fmt.Sprintf("xs_%d", xs),
+			units: "ms",
+			value: value,
+		}
+		metrics = append(metrics, newRankMetric(log, 1, m))
+	}
+	for xs := 0; xs < 5; xs++ {
+		addMetric("total_time", xs, 1000)
+		addMetric("relax_time", xs, float64(xs*100))
+		addMetric("wait_queue", xs, float64(xs))
+	}
+
+	out := labelSchedMetrics(log, 1, 1, metrics)
+	test.AssertEqual(t, len(metrics)+5, len(out), "expected a busy time metric per xstream")
+
+	expLabels := map[string]labelMap{
+		"0": {"rank": "1", "xstream": "0", "target": "", "xs_type": "system"},
+		"3": {"rank": "1", "xstream": "3", "target": "0", "xs_type": "main"},
+		"4": {"rank": "1", "xstream": "4", "target": "0", "xs_type": "helper"},
+	}
+	busy := make(map[string]float64)
+	for _, sm := range out {
+		xs := sm.labels["xstream"]
+		if exp, found := expLabels[xs]; found {
+			if diff := cmp.Diff(exp, sm.labels); diff != "" {
+				t.Fatalf("%s: unexpected labels (-want, +got):\n%s\n", sm.baseName, diff)
+			}
+		}
+		if sm.baseName == schedBusyTimeMetric {
+			busy[xs] = sm.metric.FloatValue()
+		}
+
+		// The labels must match those the metric vectors were created with.
+		if err := sm.cvm.set(sm.baseName, sm.metric.FloatValue(), sm.labels); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expBusy := map[string]float64{"0": 1000, "1": 900, "2": 800, "3": 700, "4": 600}
+	if diff := cmp.Diff(expBusy, busy); diff != "" {
+		t.Fatalf("unexpected busy times (-want, +got):\n%s\n", diff)
+	}
+}
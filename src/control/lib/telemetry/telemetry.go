@@ -108,6 +108,21 @@ type (
 		SumSquares() float64
 		SampleSize() uint64
 	}
+
+	// HistogramBucket contains the number of samples within a range of
+	// values of a metric.
+	HistogramBucket struct {
+		Min   uint64
+		Max   uint64
+		Count uint64
+	}
+
+	// HistogramMetric is a metric with statistics that may also track the
+	// distribution of its samples in a histogram.
+	HistogramMetric interface {
+		StatsMetric
+		Buckets() []HistogramBucket
+	}
 )
 
 type (
@@ -332,6 +347,40 @@ func (sm *statsMetric) SampleSize() uint64 {
 	return uint64(sm.stats.sample_size)
 }
 
+// Buckets returns the histogram buckets for the metric, ordered by range.
+// If the metric does not have a histogram, nil is returned.
+func (sm *statsMetric) Buckets() []HistogramBucket {
+	if sm.handle == nil || sm.node == nil {
+		return nil
+	}
+
+	var hist C.struct_d_tm_histogram_t
+	if C.d_tm_get_num_buckets(sm.handle.ctx, &hist, sm.node) != C.DER_SUCCESS {
+		return nil
+	}
+
+	buckets := make([]HistogramBucket, 0, int(hist.dth_num_buckets))
+	for i := 0; i < int(hist.dth_num_buckets); i++ {
+		var bucket C.struct_d_tm_bucket_t
+		if C.d_tm_get_bucket_range(sm.handle.ctx, &bucket, C.int(i), sm.node) != C.DER_SUCCESS {
+			return nil
+		}
+
+		var val C.uint64_t
+		if C.d_tm_get_counter(sm.handle.ctx, &val, bucket.dtb_bucket) != C.DER_SUCCESS {
+			return nil
+		}
+
+		buckets = append(buckets, HistogramBucket{
+			Min:   uint64(bucket.dtb_min),
+			Max:   uint64(bucket.dtb_max),
+			Count: uint64(val),
+		})
+	}
+
+	return buckets
+}
+
 func collectGarbageLoop(ctx context.Context, ticker *time.Ticker) {
 	defer ticker.Stop()
 	for {
@@ -98,6 +98,11 @@ type (
 		sumsqs float64
 		str    string // string of regex to compare String() against
 		node   *C.struct_d_tm_node_t
+
+		// Optional histogram parameters for stats gauges.
+		HistBuckets    int
+		HistWidth      int
+		HistMultiplier int
 	}
 	TestMetricsMap map[MetricType]*TestMetric
 )
@@ -156,6 +161,14 @@ func AddTestMetric(t *testing.T, tm *TestMetric) {
 			t.Fatalf("failed to add %s: %s", tm.Name, daos.Status(rc))
 		}
 
+		if tm.HistBuckets > 0 {
+			rc = C.d_tm_init_histogram(tm.node, C.CString(fullName), C.int(tm.HistBuckets),
+				C.int(tm.HistWidth), C.int(tm.HistMultiplier))
+			if rc != 0 {
+				t.Fatalf("failed to init histogram for %s: %s", tm.Name, daos.Status(rc))
+			}
+		}
+
 		vals := make([]uint64, len(tm.Values))
 		if len(tm.Values) > 0 {
 			copy(vals, tm.Values)
@@ -0,0 +1,296 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	// OTLPEndpointEnv is the standard OpenTelemetry environment variable used to
+	// specify the collector endpoint.
+	OTLPEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	otlpTracesPath    = "/v1/traces"
+	otlpBatchSize     = 128
+	otlpMaxQueue      = 4096
+	otlpFlushInterval = 5 * time.Second
+	otlpTimeout       = 10 * time.Second
+
+	otlpStatusOk    = 1
+	otlpStatusError = 2
+)
+
+type (
+	otlpValue struct {
+		StringValue string `json:"stringValue"`
+	}
+
+	otlpKeyValue struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              SpanKind       `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+
+	otlpTracesRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+)
+
+func kv(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpValue{StringValue: value}}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func toOTLPSpan(s *Span) otlpSpan {
+	s.Lock()
+	defer s.Unlock()
+
+	out := otlpSpan{
+		TraceID:           s.Context.TraceID.String(),
+		SpanID:            s.Context.SpanID.String(),
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: unixNano(s.Start),
+		EndTimeUnixNano:   unixNano(s.End),
+		Status:            otlpStatus{Code: otlpStatusOk},
+	}
+	if s.ParentID.IsValid() {
+		out.ParentSpanID = s.ParentID.String()
+	}
+	for _, attr := range s.Attrs {
+		out.Attributes = append(out.Attributes, kv(attr.Key, attr.Value))
+	}
+	if s.Err != nil {
+		out.Status = otlpStatus{Code: otlpStatusError, Message: s.Err.Error()}
+	}
+
+	return out
+}
+
+// OTLPExporter batches finished spans and sends them to an OpenTelemetry
+// collector using the OTLP/HTTP JSON encoding.
+type OTLPExporter struct {
+	log      logging.Logger
+	url      string
+	resource otlpResource
+	client   *http.Client
+	mu       sync.Mutex
+	queue    []*Span
+	dropped  int
+	kick     chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// EndpointFromEnv returns the collector endpoint set in the environment, if any.
+func EndpointFromEnv() string {
+	return os.Getenv(OTLPEndpointEnv)
+}
+
+func tracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid OTLP endpoint %q", endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.Errorf("invalid OTLP endpoint %q: scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return "", errors.Errorf("invalid OTLP endpoint %q: no host", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, otlpTracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + otlpTracesPath
+	}
+
+	return u.String(), nil
+}
+
+// NewOTLPExporter returns an exporter that sends spans to the collector at the
+// given endpoint, e.g. "http://collector:4318". Spans are reported as coming
+// from the named service on this host. Shutdown must be called to flush pending spans.
+func NewOTLPExporter(log logging.Logger, endpoint, service string) (*OTLPExporter, error) {
+	u, err := tracesURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := &OTLPExporter{
+		log: log,
+		url: u,
+		resource: otlpResource{
+			Attributes: []otlpKeyValue{kv("service.name", service)},
+		},
+		client:  &http.Client{Timeout: otlpTimeout},
+		kick:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if host, err := os.Hostname(); err == nil {
+		exp.resource.Attributes = append(exp.resource.Attributes, kv("host.name", host))
+	}
+	go exp.run()
+
+	return exp, nil
+}
+
+// Export queues a finished span for delivery. Spans are dropped if the queue is full.
+func (exp *OTLPExporter) Export(s *Span) {
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+
+	if len(exp.queue) >= otlpMaxQueue {
+		exp.dropped++
+		return
+	}
+	exp.queue = append(exp.queue, s)
+
+	if len(exp.queue) >= otlpBatchSize {
+		select {
+		case exp.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Shutdown flushes any queued spans and stops the exporter.
+func (exp *OTLPExporter) Shutdown(ctx context.Context) error {
+	exp.stopOnce.Do(func() {
+		close(exp.done)
+	})
+
+	select {
+	case <-exp.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (exp *OTLPExporter) run() {
+	defer close(exp.stopped)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exp.done:
+			exp.flush()
+			return
+		case <-ticker.C:
+		case <-exp.kick:
+		}
+		exp.flush()
+	}
+}
+
+func (exp *OTLPExporter) flush() {
+	exp.mu.Lock()
+	spans := exp.queue
+	dropped := exp.dropped
+	exp.queue = nil
+	exp.dropped = 0
+	exp.mu.Unlock()
+
+	if dropped > 0 {
+		exp.log.Debugf("OTLP export queue full; dropped %d spans", dropped)
+	}
+	if len(spans) == 0 {
+		return
+	}
+
+	if err := exp.send(spans); err != nil {
+		exp.log.Debugf("failed to export %d spans: %s", len(spans), err)
+	}
+}
+
+func (exp *OTLPExporter) send(spans []*Span) error {
+	scope := otlpScopeSpans{
+		Scope: otlpScope{Name: "daos"},
+		Spans: make([]otlpSpan, 0, len(spans)),
+	}
+	for _, s := range spans {
+		scope.Spans = append(scope.Spans, toOTLPSpan(s))
+	}
+	req := otlpTracesRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource:   exp.resource,
+				ScopeSpans: []otlpScopeSpans{scope},
+			},
+		},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := exp.client.Post(exp.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("collector returned %s", resp.Status)
+	}
+
+	return nil
+}
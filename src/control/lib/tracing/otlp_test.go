@@ -0,0 +1,149 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestTracing_tracesURL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		endpoint string
+		expURL   string
+		expErr   error
+	}{
+		"no scheme": {
+			endpoint: "collector:4318",
+			expErr:   errors.New("scheme must be http or https"),
+		},
+		"grpc scheme": {
+			endpoint: "grpc://collector:4317",
+			expErr:   errors.New("scheme must be http or https"),
+		},
+		"no host": {
+			endpoint: "http:///v1/traces",
+			expErr:   errors.New("no host"),
+		},
+		"base endpoint": {
+			endpoint: "http://collector:4318",
+			expURL:   "http://collector:4318/v1/traces",
+		},
+		"trailing slash": {
+			endpoint: "https://collector:4318/otlp/",
+			expURL:   "https://collector:4318/otlp/v1/traces",
+		},
+		"full path": {
+			endpoint: "http://collector:4318/v1/traces",
+			expURL:   "http://collector:4318/v1/traces",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotURL, gotErr := tracesURL(tc.endpoint)
+			test.CmpErr(t, tc.expErr, gotErr)
+			test.AssertEqual(t, tc.expURL, gotURL, "unexpected URL")
+		})
+	}
+}
+
+func TestTracing_OTLPExporter(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	reqs := make(chan *otlpTracesRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		req := new(otlpTracesRequest)
+		if err := json.Unmarshal(data, req); err != nil {
+			t.Error(err)
+		}
+		reqs <- req
+	}))
+	defer srv.Close()
+
+	exp, err := NewOTLPExporter(log, srv.URL, "daos_server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp.resource.Attributes = exp.resource.Attributes[:1]
+
+	parent, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(1700000000, 0)
+	exp.Export(&Span{
+		Name:     "/mgmt.MgmtSvc/PoolCreate",
+		Kind:     SpanKindServer,
+		Context:  SpanContext{TraceID: parent.TraceID, SpanID: SpanID{1}, Flags: flagSampled},
+		ParentID: parent.SpanID,
+		Start:    start,
+		End:      start.Add(time.Second),
+		Attrs:    []Attribute{{Key: "rank", Value: "1"}},
+		Err:      errors.New("failed"),
+	})
+
+	if err := exp.Shutdown(test.Context(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	expReq := &otlpTracesRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{kv("service.name", "daos_server")},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "daos"},
+						Spans: []otlpSpan{
+							{
+								TraceID:           "4bf92f3577b34da6a3ce929d0e0e4736",
+								SpanID:            "0100000000000000",
+								ParentSpanID:      "00f067aa0ba902b7",
+								Name:              "/mgmt.MgmtSvc/PoolCreate",
+								Kind:              SpanKindServer,
+								StartTimeUnixNano: "1700000000000000000",
+								EndTimeUnixNano:   "1700000001000000000",
+								Attributes:        []otlpKeyValue{kv("rank", "1")},
+								Status:            otlpStatus{Code: otlpStatusError, Message: "failed"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	select {
+	case gotReq := <-reqs:
+		if diff := cmp.Diff(expReq, gotReq); diff != "" {
+			t.Fatalf("unexpected request (-want, +got):\n%s\n", diff)
+		}
+	default:
+		t.Fatal("expected spans to be flushed on shutdown")
+	}
+}
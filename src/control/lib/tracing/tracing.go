@@ -0,0 +1,324 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package tracing provides lightweight distributed tracing for the control plane.
+//
+// Trace context is propagated between processes in the W3C Trace Context
+// "traceparent" format (https://www.w3.org/TR/trace-context/) so that a single
+// request can be followed from dmg through the management service and servers
+// to the engines. Completed spans may be exported to an OpenTelemetry collector
+// via OTLP.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TraceparentHeader is the name of the header or metadata key used to carry trace context.
+const TraceparentHeader = "traceparent"
+
+const (
+	traceparentVersion = "00"
+	flagSampled        = 0x01
+)
+
+type (
+	// TraceID uniquely identifies a trace.
+	TraceID [16]byte
+
+	// SpanID uniquely identifies a span within a trace.
+	SpanID [8]byte
+)
+
+// IsValid returns true if the TraceID is non-zero.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns true if the SpanID is non-zero.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func newTraceID() (id TraceID) {
+	for !id.IsValid() {
+		if _, err := rand.Read(id[:]); err != nil {
+			panic(err)
+		}
+	}
+	return
+}
+
+func newSpanID() (id SpanID) {
+	for !id.IsValid() {
+		if _, err := rand.Read(id[:]); err != nil {
+			panic(err)
+		}
+	}
+	return
+}
+
+// SpanContext holds the trace state that is propagated across process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   byte
+}
+
+// IsValid returns true if both trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// IsSampled returns true if spans in the trace should be recorded.
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&flagSampled != 0
+}
+
+// Traceparent returns the SpanContext encoded as a W3C traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("%s-%s-%s-%02x", traceparentVersion, sc.TraceID, sc.SpanID, sc.Flags)
+}
+
+func decodeHex(dst []byte, src string) error {
+	if len(src) != hex.EncodedLen(len(dst)) || strings.ToLower(src) != src {
+		return errors.Errorf("expected %d lowercase hex characters", hex.EncodedLen(len(dst)))
+	}
+	_, err := hex.Decode(dst, []byte(src))
+	return err
+}
+
+// ParseTraceparent decodes a W3C traceparent header value.
+func ParseTraceparent(in string) (SpanContext, error) {
+	var sc SpanContext
+
+	fields := strings.Split(strings.TrimSpace(in), "-")
+	if len(fields) < 4 {
+		return sc, errors.Errorf("invalid traceparent %q: expected 4 fields", in)
+	}
+
+	var version [1]byte
+	if err := decodeHex(version[:], fields[0]); err != nil {
+		return sc, errors.Wrapf(err, "invalid traceparent %q version", in)
+	}
+	// Later versions may append fields but must keep the leading ones compatible.
+	if version[0] == 0xff || (fields[0] == traceparentVersion && len(fields) != 4) {
+		return sc, errors.Errorf("invalid traceparent %q", in)
+	}
+
+	if err := decodeHex(sc.TraceID[:], fields[1]); err != nil {
+		return sc, errors.Wrapf(err, "invalid traceparent %q trace-id", in)
+	}
+	if err := decodeHex(sc.SpanID[:], fields[2]); err != nil {
+		return sc, errors.Wrapf(err, "invalid traceparent %q parent-id", in)
+	}
+	var flags [1]byte
+	if err := decodeHex(flags[:], fields[3]); err != nil {
+		return sc, errors.Wrapf(err, "invalid traceparent %q flags", in)
+	}
+	sc.Flags = flags[0]
+
+	if !sc.IsValid() {
+		return SpanContext{}, errors.Errorf("invalid traceparent %q: zero trace or parent id", in)
+	}
+
+	return sc, nil
+}
+
+// SpanKind describes the relationship of a span to the remote side of a request.
+type SpanKind int
+
+// SpanKind values match the OTLP enumeration.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a key/value annotation on a span.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Span records the timing of a single operation within a trace. A nil *Span is
+// valid and records nothing, so callers need not check whether tracing is active.
+type Span struct {
+	sync.Mutex
+	Name     string
+	Kind     SpanKind
+	Context  SpanContext
+	ParentID SpanID
+	Start    time.Time
+	End      time.Time
+	Attrs    []Attribute
+	Err      error
+
+	ended bool
+}
+
+// SetAttribute annotates the span with the given key and value.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	s.Attrs = append(s.Attrs, Attribute{Key: key, Value: fmt.Sprint(value)})
+}
+
+// SetError marks the span as failed if err is non-nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	s.Err = err
+}
+
+// SpanContext returns the propagation context of the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.Context
+}
+
+// Finish records the end time of the span and hands it to the exporter. Calls
+// after the first have no effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	if s.ended {
+		s.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.Unlock()
+
+	if exp := getExporter(); exp != nil {
+		exp.Export(s)
+	}
+}
+
+// Exporter is implemented by types that deliver finished spans to a backend.
+type Exporter interface {
+	Export(*Span)
+}
+
+var (
+	exporterMu sync.RWMutex
+	exporter   Exporter
+)
+
+// SetExporter sets the Exporter that receives all spans finished by this
+// process. A nil Exporter discards spans.
+func SetExporter(exp Exporter) {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+
+	exporter = exp
+}
+
+func getExporter() Exporter {
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+
+	return exporter
+}
+
+type ctxKey int
+
+const (
+	spanKey ctxKey = iota
+	remoteKey
+)
+
+// ContextWithRemoteParent returns a Context that will parent new spans to the
+// given SpanContext received from another process.
+func ContextWithRemoteParent(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey, sc)
+}
+
+// SpanFromContext returns the current span, or nil if there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+// SpanContextFromContext returns the context of the current span, falling back
+// to a remote parent if there is no local span.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.Context
+	}
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteKey).(SpanContext)
+	return sc
+}
+
+func startSpan(ctx context.Context, name string, kind SpanKind, traceID TraceID, parentID SpanID) (context.Context, *Span) {
+	span := &Span{
+		Name: name,
+		Kind: kind,
+		Context: SpanContext{
+			TraceID: traceID,
+			SpanID:  newSpanID(),
+			Flags:   flagSampled,
+		},
+		ParentID: parentID,
+		Start:    time.Now(),
+	}
+
+	return context.WithValue(ctx, spanKey, span), span
+}
+
+// StartTrace begins a new sampled trace with a root span of the given name.
+func StartTrace(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	return startSpan(ctx, name, kind, newTraceID(), SpanID{})
+}
+
+// StartSpan begins a span as a child of the current span or remote parent in the
+// Context. If there is no sampled parent, no span is created and a nil *Span is
+// returned.
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	if !parent.IsValid() || !parent.IsSampled() {
+		return ctx, nil
+	}
+
+	return startSpan(ctx, name, kind, parent.TraceID, parent.SpanID)
+}
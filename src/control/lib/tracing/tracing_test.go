@@ -0,0 +1,192 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestTracing_ParseTraceparent(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in     string
+		expSC  SpanContext
+		expErr error
+	}{
+		"empty": {
+			expErr: errors.New("expected 4 fields"),
+		},
+		"too few fields": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			expErr: errors.New("expected 4 fields"),
+		},
+		"extra fields in version 00": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xx",
+			expErr: errors.New("invalid traceparent"),
+		},
+		"invalid version": {
+			in:     "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expErr: errors.New("invalid traceparent"),
+		},
+		"short trace-id": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+			expErr: errors.New("trace-id"),
+		},
+		"uppercase trace-id": {
+			in:     "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			expErr: errors.New("trace-id"),
+		},
+		"non-hex parent-id": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01",
+			expErr: errors.New("parent-id"),
+		},
+		"bad flags": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+			expErr: errors.New("flags"),
+		},
+		"zero trace-id": {
+			in:     "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			expErr: errors.New("zero trace or parent id"),
+		},
+		"zero parent-id": {
+			in:     "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			expErr: errors.New("zero trace or parent id"),
+		},
+		"sampled": {
+			in: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expSC: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6,
+					0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID: SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Flags:  0x01,
+			},
+		},
+		"future version with extra fields": {
+			in: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra",
+			expSC: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6,
+					0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID: SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotSC, gotErr := ParseTraceparent(tc.in)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expSC, gotSC); diff != "" {
+				t.Fatalf("unexpected span context (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestTracing_SpanContext_Traceparent(t *testing.T) {
+	in := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, in, sc.Traceparent(), "unexpected traceparent")
+	test.AssertTrue(t, sc.IsSampled(), "expected sampled flag")
+}
+
+type mockExporter struct {
+	sync.Mutex
+	spans []*Span
+}
+
+func (me *mockExporter) Export(s *Span) {
+	me.Lock()
+	defer me.Unlock()
+	me.spans = append(me.spans, s)
+}
+
+func TestTracing_StartSpan(t *testing.T) {
+	exp := &mockExporter{}
+	SetExporter(exp)
+	defer SetExporter(nil)
+
+	remote, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsampled := remote
+	unsampled.Flags = 0
+
+	for name, tc := range map[string]struct {
+		ctx       context.Context
+		expSpan   bool
+		expTrace  TraceID
+		expParent SpanID
+	}{
+		"no parent": {
+			ctx: test.Context(t),
+		},
+		"unsampled remote parent": {
+			ctx: ContextWithRemoteParent(test.Context(t), unsampled),
+		},
+		"invalid remote parent": {
+			ctx: ContextWithRemoteParent(test.Context(t), SpanContext{}),
+		},
+		"remote parent": {
+			ctx:       ContextWithRemoteParent(test.Context(t), remote),
+			expSpan:   true,
+			expTrace:  remote.TraceID,
+			expParent: remote.SpanID,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			exp.spans = nil
+
+			ctx, span := StartSpan(tc.ctx, "test", SpanKindServer)
+			span.SetAttribute("key", 42)
+			span.SetError(errors.New("failed"))
+			span.Finish()
+
+			if !tc.expSpan {
+				if span != nil {
+					t.Fatal("expected no span to be created")
+				}
+				test.AssertEqual(t, 0, len(exp.spans), "expected nothing exported")
+				return
+			}
+
+			test.AssertEqual(t, span, SpanFromContext(ctx), "expected span in context")
+			test.AssertEqual(t, tc.expTrace, span.Context.TraceID, "unexpected trace id")
+			test.AssertEqual(t, tc.expParent, span.ParentID, "unexpected parent id")
+			test.AssertEqual(t, []Attribute{{Key: "key", Value: "42"}}, span.Attrs, "unexpected attributes")
+			test.AssertTrue(t, !span.End.Before(span.Start), "unexpected end time")
+
+			// A child of the local span stays in the same trace.
+			_, child := StartSpan(ctx, "child", SpanKindClient)
+			test.AssertEqual(t, tc.expTrace, child.Context.TraceID, "unexpected child trace id")
+			test.AssertEqual(t, span.Context.SpanID, child.ParentID, "unexpected child parent id")
+
+			// Spans are only exported once.
+			span.Finish()
+			test.AssertEqual(t, 1, len(exp.spans), "unexpected number of exported spans")
+		})
+	}
+}
+
+func TestTracing_StartTrace(t *testing.T) {
+	ctx, root := StartTrace(test.Context(t), "root", SpanKindClient)
+	test.AssertTrue(t, root.Context.IsValid(), "expected valid root span context")
+	test.AssertTrue(t, root.Context.IsSampled(), "expected root span to be sampled")
+	test.AssertTrue(t, !root.ParentID.IsValid(), "expected no parent for root span")
+	test.AssertEqual(t, root.Context, SpanContextFromContext(ctx), "unexpected span context")
+}
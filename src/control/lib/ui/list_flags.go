@@ -92,7 +92,7 @@ func (f *MemberStateSetFlag) Empty() bool {
 }
 
 // States are specified as a comma separated list of AwaitFormat Starting Ready Joined Stopping
-// Stopped Excluded AdminExcluded Errored Unresponsive
+// Stopped Excluded AdminExcluded Errored Unresponsive CheckerStarted Unstable Quarantined
 func memberStateMaskFromStrings(statesStr string) (system.MemberState, error) {
 	var mask system.MemberState
 
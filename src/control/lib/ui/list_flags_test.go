@@ -169,7 +169,7 @@ func TestUI_MemberStateSetFlag(t *testing.T) {
 			},
 		},
 		"full list": {
-			arg: "Joined,Excluded,Stopped,Stopping,Ready,Starting,AwaitFormat,AdminExcluded,Errored,Unresponsive,CheckerStarted",
+			arg: "Joined,Excluded,Stopped,Stopping,Ready,Starting,AwaitFormat,AdminExcluded,Errored,Unresponsive,CheckerStarted,Unstable,Quarantined",
 			expFlag: &ui.MemberStateSetFlag{
 				States: system.MemberState(int(system.MemberStateMax) - 1),
 			},
@@ -206,8 +206,8 @@ func TestUI_MemberStateSetFlag_Complete(t *testing.T) {
 		"empty string; suggest all": {
 			expComplStrs: []string{
 				"AdminExcluded", "AwaitFormat", "CheckerStarted", "Errored", "Excluded",
-				"Joined", "Ready", "Starting", "Stopped", "Stopping",
-				"Unresponsive",
+				"Joined", "Quarantined", "Ready", "Starting", "Stopped", "Stopping",
+				"Unresponsive", "Unstable",
 			},
 		},
 		"single suggestion": {
@@ -228,8 +228,8 @@ func TestUI_MemberStateSetFlag_Complete(t *testing.T) {
 				"Starting,AdminExcluded", "Starting,AwaitFormat",
 				"Starting,CheckerStarted",
 				"Starting,Errored", "Starting,Excluded", "Starting,Joined",
-				"Starting,Ready", "Starting,Stopped", "Starting,Stopping",
-				"Starting,Unresponsive",
+				"Starting,Quarantined", "Starting,Ready", "Starting,Stopped", "Starting,Stopping",
+				"Starting,Unresponsive", "Starting,Unstable",
 			},
 		},
 		"suggestions after prefix; partial match": {
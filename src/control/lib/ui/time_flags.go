@@ -0,0 +1,47 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package ui
+
+import (
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+var _ flags.Unmarshaler = &TimeFlag{}
+
+// timeFlagDateFmt is accepted as a shorthand for midnight UTC on the given date.
+const timeFlagDateFmt = "2006-01-02"
+
+// TimeFlag is a go-flags compatible flag type for handling
+// timestamp inputs in RFC3339/ISO8601 or YYYY-MM-DD format.
+type TimeFlag struct {
+	time.Time
+}
+
+// UnmarshalFlag implements the flags.Unmarshaler interface.
+func (f *TimeFlag) UnmarshalFlag(fv string) error {
+	if fv == "" {
+		return errors.New("empty time value")
+	}
+
+	if t, err := time.Parse(timeFlagDateFmt, fv); err == nil {
+		f.Time = t
+		return nil
+	}
+
+	t, err := common.ParseTime(fv)
+	if err != nil {
+		return errors.Errorf("invalid time value %q (expected YYYY-MM-DD or RFC3339)", fv)
+	}
+	f.Time = t
+
+	return nil
+}
@@ -0,0 +1,57 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestUI_TimeFlag(t *testing.T) {
+	for name, tc := range map[string]struct {
+		arg     string
+		expTime time.Time
+		expErr  error
+	}{
+		"empty": {
+			expErr: errors.New("empty"),
+		},
+		"garbage": {
+			arg:    "yesterday",
+			expErr: errors.New("invalid time"),
+		},
+		"date only": {
+			arg:     "2024-03-01",
+			expTime: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"rfc3339": {
+			arg:     "2024-03-01T12:30:00Z",
+			expTime: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		},
+		"iso8601 offset": {
+			arg:     "2024-03-01T12:30:00-0500",
+			expTime: time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var f TimeFlag
+			gotErr := f.UnmarshalFlag(tc.arg)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if !f.Time.Equal(tc.expTime) {
+				t.Fatalf("expected %s, got %s", tc.expTime, f.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package yamlcheck validates YAML configuration files against the Go types
+// that they are decoded into, reporting unknown keys, type errors and invalid
+// combinations of settings at their location in the file.
+package yamlcheck
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule checks a document for invalid combinations of settings.
+type Rule func(root *Node) Issues
+
+var (
+	unmarshalerType     = reflect.TypeOf((*yamlv2.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+
+	syntaxErrRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+// Check validates the YAML document in data against the schema described by
+// the type of target, which is the value that the document will be decoded
+// into, and then applies the supplied rules. The keys accepted for structs are
+// those accepted by the decoder, i.e. yaml tags or lower-cased field names.
+// Values of types that implement their own unmarshaling are left for the
+// decoder to validate. Returns nil if no problems are found, otherwise
+// the returned error is of type Issues.
+func Check(file string, data []byte, target interface{}, rules ...Rule) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return syntaxIssue(file, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+
+	c := &checker{file: file}
+	c.check(doc.Content[0], reflect.TypeOf(target), "")
+
+	if root := newNode(file, "", doc.Content[0]); root != nil {
+		for _, rule := range rules {
+			c.issues = append(c.issues, rule(root)...)
+		}
+	}
+
+	if len(c.issues) == 0 {
+		return nil
+	}
+	c.issues.sort()
+	return c.issues
+}
+
+// syntaxIssue converts a YAML syntax error into an Issue.
+func syntaxIssue(file string, err error) error {
+	msg := strings.TrimSpace(err.Error())
+	if m := syntaxErrRe.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return Issues{{File: file, Line: line, Message: m[2]}}
+	}
+	return Issues{{File: file, Message: strings.TrimPrefix(msg, "yaml: ")}}
+}
+
+type checker struct {
+	file   string
+	issues Issues
+}
+
+func (c *checker) add(yn *yaml.Node, path, fix, format string, args ...interface{}) {
+	c.issues = append(c.issues, &Issue{
+		File:    c.file,
+		Line:    yn.Line,
+		Column:  yn.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+		Fix:     fix,
+	})
+}
+
+// describe returns a short description of the node's type and value.
+func describe(yn *yaml.Node) string {
+	switch yn.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+
+	val := yn.Value
+	if len(val) > 32 {
+		val = val[:29] + "..."
+	}
+	switch yn.ShortTag() {
+	case "!!int":
+		return "integer " + val
+	case "!!float":
+		return "number " + val
+	case "!!bool":
+		return "boolean " + val
+	default:
+		return fmt.Sprintf("string %q", val)
+	}
+}
+
+func (c *checker) mismatch(yn *yaml.Node, path, want string) {
+	c.add(yn, path, "", "expected %s, got %s", want, describe(yn))
+}
+
+func (c *checker) check(yn *yaml.Node, t reflect.Type, path string) {
+	for yn.Kind == yaml.AliasNode {
+		yn = yn.Alias
+	}
+	if yn.ShortTag() == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		// Custom unmarshalers define their own format, but those that decode
+		// a list of structs can still have the list entries checked.
+		if t.Kind() == reflect.Slice && yn.Kind == yaml.SequenceNode {
+			c.checkSequence(yn, t, path)
+		}
+		return
+	}
+	if t == durationType {
+		c.checkDuration(yn, path)
+		return
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		if yn.Kind != yaml.ScalarNode {
+			c.mismatch(yn, path, "a single value")
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		c.checkStruct(yn, t, path)
+	case reflect.Map:
+		c.checkMap(yn, t, path)
+	case reflect.Slice, reflect.Array:
+		c.checkSequence(yn, t, path)
+	case reflect.Bool:
+		c.checkBool(yn, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.checkInt(yn, t, path)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c.checkUint(yn, t, path)
+	case reflect.Float32, reflect.Float64:
+		c.checkFloat(yn, path)
+	case reflect.String:
+		if yn.Kind != yaml.ScalarNode {
+			c.mismatch(yn, path, "a string")
+		}
+	}
+}
+
+// structField describes a key accepted for a struct.
+type structField struct {
+	name string
+	typ  reflect.Type
+}
+
+// structFields returns the keys accepted for a struct type, following the
+// same rules as the decoder for tags, inlined structs and untagged fields.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue // unexported
+		}
+
+		tag := f.Tag.Get("yaml")
+		if tag == "" && strings.Index(string(f.Tag), ":") < 0 {
+			tag = string(f.Tag)
+		}
+		if tag == "-" {
+			continue
+		}
+
+		opts := strings.Split(tag, ",")
+		name := opts[0]
+		inline := false
+		for _, opt := range opts[1:] {
+			if opt == "inline" {
+				inline = true
+			}
+		}
+
+		if inline {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported embedded type
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, structField{name: name, typ: f.Type})
+	}
+	return fields
+}
+
+func (c *checker) checkStruct(yn *yaml.Node, t reflect.Type, path string) {
+	if yn.Kind != yaml.MappingNode {
+		c.mismatch(yn, path, "a mapping of settings")
+		return
+	}
+
+	fields := structFields(t)
+	names := make([]string, 0, len(fields))
+	byName := make(map[string]reflect.Type, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+		byName[f.name] = f.typ
+	}
+
+	seen := make(map[string]int)
+	for i := 0; i+1 < len(yn.Content); i += 2 {
+		key, val := yn.Content[i], yn.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			c.checkMerge(val, t, path)
+			continue
+		}
+
+		kp := childPath(path, key.Value)
+		ft, found := byName[key.Value]
+		if !found {
+			c.add(key, kp, didYouMean(key.Value, names), "unknown key %q", key.Value)
+			continue
+		}
+		if line, dup := seen[key.Value]; dup {
+			c.add(key, kp, fmt.Sprintf("remove one of the settings (first set on line %d)", line),
+				"duplicate key %q", key.Value)
+			continue
+		}
+		seen[key.Value] = key.Line
+
+		c.check(val, ft, kp)
+	}
+}
+
+// checkMerge checks mappings merged into a struct with the << key.
+func (c *checker) checkMerge(yn *yaml.Node, t reflect.Type, path string) {
+	for yn.Kind == yaml.AliasNode {
+		yn = yn.Alias
+	}
+	if yn.Kind == yaml.SequenceNode {
+		for _, item := range yn.Content {
+			c.checkMerge(item, t, path)
+		}
+		return
+	}
+	c.checkStruct(yn, t, path)
+}
+
+func (c *checker) checkMap(yn *yaml.Node, t reflect.Type, path string) {
+	if yn.Kind != yaml.MappingNode {
+		c.mismatch(yn, path, "a mapping")
+		return
+	}
+
+	for i := 0; i+1 < len(yn.Content); i += 2 {
+		key, val := yn.Content[i], yn.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			continue
+		}
+		c.check(val, t.Elem(), childPath(path, key.Value))
+	}
+}
+
+func (c *checker) checkSequence(yn *yaml.Node, t reflect.Type, path string) {
+	if yn.Kind != yaml.SequenceNode {
+		if t.Elem().Kind() == reflect.Uint8 {
+			return // []byte may be given as a string
+		}
+		c.mismatch(yn, path, "a list")
+		return
+	}
+
+	for i, item := range yn.Content {
+		c.check(item, t.Elem(), indexPath(path, i))
+	}
+}
+
+func (c *checker) checkBool(yn *yaml.Node, path string) {
+	if yn.Kind == yaml.ScalarNode {
+		if yn.ShortTag() == "!!bool" {
+			return
+		}
+		// YAML 1.1 boolean values accepted by the decoder.
+		switch strings.ToLower(yn.Value) {
+		case "y", "yes", "n", "no", "on", "off":
+			return
+		}
+	}
+	c.mismatch(yn, path, "true or false")
+}
+
+// intValue returns the integral value of an integer or whole number scalar.
+func (c *checker) intValue(yn *yaml.Node, path, want string) (string, bool) {
+	if yn.Kind == yaml.ScalarNode {
+		switch yn.ShortTag() {
+		case "!!int":
+			return strings.Replace(yn.Value, "_", "", -1), true
+		case "!!float":
+			if f, err := strconv.ParseFloat(yn.Value, 64); err == nil && f == math.Trunc(f) {
+				return strconv.FormatFloat(f, 'f', -1, 64), true
+			}
+		}
+	}
+	c.mismatch(yn, path, want)
+	return "", false
+}
+
+func (c *checker) checkInt(yn *yaml.Node, t reflect.Type, path string) {
+	val, ok := c.intValue(yn, path, "an integer")
+	if !ok {
+		return
+	}
+	if _, err := strconv.ParseInt(val, 0, t.Bits()); err != nil {
+		c.add(yn, path, "", "integer %s out of range for %d-bit value", yn.Value, t.Bits())
+	}
+}
+
+func (c *checker) checkUint(yn *yaml.Node, t reflect.Type, path string) {
+	val, ok := c.intValue(yn, path, "a non-negative integer")
+	if !ok {
+		return
+	}
+	if strings.HasPrefix(val, "-") {
+		c.add(yn, path, "", "expected a non-negative integer, got %s", yn.Value)
+		return
+	}
+	if _, err := strconv.ParseUint(strings.TrimPrefix(val, "+"), 0, t.Bits()); err != nil {
+		c.add(yn, path, "", "integer %s out of range for unsigned %d-bit value", yn.Value, t.Bits())
+	}
+}
+
+func (c *checker) checkFloat(yn *yaml.Node, path string) {
+	if yn.Kind == yaml.ScalarNode {
+		switch yn.ShortTag() {
+		case "!!int", "!!float":
+			return
+		}
+	}
+	c.mismatch(yn, path, "a number")
+}
+
+func (c *checker) checkDuration(yn *yaml.Node, path string) {
+	if yn.Kind == yaml.ScalarNode {
+		if yn.ShortTag() == "!!int" {
+			return
+		}
+		if _, err := time.ParseDuration(yn.Value); err == nil {
+			return
+		}
+	}
+	c.add(yn, path, "use a number followed by a unit, e.g. 30s, 5m or 1h",
+		"expected a duration, got %s", describe(yn))
+}
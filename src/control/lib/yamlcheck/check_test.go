@@ -0,0 +1,257 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package yamlcheck
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+type testLevel int
+
+func (l *testLevel) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	return unmarshal(&s)
+}
+
+type testTier struct {
+	Class string   `yaml:"class"`
+	Size  uint8    `yaml:"size,omitempty"`
+	List  []string `yaml:"list,omitempty"`
+}
+
+type testTiers []*testTier
+
+func (tt *testTiers) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp []*testTier
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+	*tt = tmp
+	return nil
+}
+
+type testFabric struct {
+	Provider string `yaml:"provider"`
+	Port     int    `yaml:"port,omitempty"`
+}
+
+type testEngine struct {
+	Targets int        `yaml:"targets"`
+	Tiers   testTiers  `yaml:"storage"`
+	Fabric  testFabric `yaml:",inline"`
+	Index   int        `yaml:"-"`
+}
+
+type testConfig struct {
+	Name     string            `yaml:"name"`
+	Enabled  bool              `yaml:"enabled"`
+	Ratio    float64           `yaml:"ratio"`
+	Timeout  time.Duration     `yaml:"timeout"`
+	Level    testLevel         `yaml:"level"`
+	Labels   map[string]string `yaml:"labels"`
+	Engines  []*testEngine     `yaml:"engines"`
+	Untagged string
+}
+
+func TestYamlcheck_Check(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input  string
+		rules  []Rule
+		expErr error
+	}{
+		"empty": {},
+		"valid": {
+			input: `
+name: test
+enabled: yes
+ratio: 1
+timeout: 30s
+level: anything
+untagged: value
+labels:
+  a: b
+engines:
+- targets: 0x10
+  provider: ofi+tcp
+  storage:
+  - class: ram
+    size: 16
+  - class: nvme
+    list: [a, b]
+`,
+		},
+		"syntax error": {
+			input: `
+name: test
+  enabled: true
+`,
+			expErr: errors.New("test.yml:3: mapping values are not allowed in this context"),
+		},
+		"root not a mapping": {
+			input:  "One ring to rule them all\n",
+			expErr: errors.New("test.yml:1:1: expected a mapping of settings, got string \"One ring to rule them all\""),
+		},
+		"unknown key with suggestion": {
+			input: `
+name: test
+engines:
+- targets: 1
+  provder: ofi+tcp
+`,
+			expErr: errors.New(`test.yml:5:3: engines[0].provder: unknown key "provder"; did you mean "provider"?`),
+		},
+		"unknown key without suggestion": {
+			input: `
+name: test
+frodo: baggins
+`,
+			expErr: errors.New(`test.yml:3:1: frodo: unknown key "frodo"`),
+		},
+		"ignored field not accepted": {
+			input: `
+engines:
+- index: 1
+`,
+			expErr: errors.New(`engines[0].index: unknown key "index"`),
+		},
+		"duplicate key": {
+			input: `
+name: a
+name: b
+`,
+			expErr: errors.New(`test.yml:3:1: name: duplicate key "name"; remove one of the settings (first set on line 2)`),
+		},
+		"type errors": {
+			input: `
+enabled: maybe
+ratio: high
+timeout: 10 minutes
+engines:
+- targets: "8"
+  storage:
+  - class: ram
+    size: 256
+  - class: [nvme]
+    list: a
+`,
+			expErr: errors.New(strings.Join([]string{
+				"7 problems found in config:",
+				"  test.yml:2:10: enabled: expected true or false, got string \"maybe\"",
+				"  test.yml:3:8: ratio: expected a number, got string \"high\"",
+				"  test.yml:4:10: timeout: expected a duration, got string \"10 minutes\"; use a number followed by a unit, e.g. 30s, 5m or 1h",
+				"  test.yml:6:12: engines[0].targets: expected an integer, got string \"8\"",
+				"  test.yml:9:11: engines[0].storage[0].size: integer 256 out of range for unsigned 8-bit value",
+				"  test.yml:10:12: engines[0].storage[1].class: expected a string, got a list",
+				"  test.yml:11:11: engines[0].storage[1].list: expected a list, got string \"a\"",
+			}, "\n")),
+		},
+		"negative unsigned": {
+			input: `
+engines:
+- storage:
+  - size: -1
+`,
+			expErr: errors.New("engines[0].storage[0].size: expected a non-negative integer, got -1"),
+		},
+		"list expected": {
+			input: `
+engines:
+  targets: 1
+`,
+			expErr: errors.New("test.yml:3:3: engines: expected a list, got a mapping"),
+		},
+		"rule": {
+			input: `
+name: test
+enabled: true
+engines:
+- storage:
+  - class: ram
+    list: [a]
+`,
+			rules: []Rule{
+				func(root *Node) Issues {
+					var issues Issues
+					for _, eng := range root.Key("engines").Items() {
+						for _, tier := range eng.Key("storage").Items() {
+							if tier.Key("class").Value() == "ram" && tier.Key("list") != nil {
+								issues = append(issues, tier.Key("list").Issue(
+									"list may not be set when class is ram", "remove list"))
+							}
+						}
+					}
+					if root.Key("missing").Key("nested") != nil {
+						t.Fatal("unexpected node")
+					}
+					return issues
+				},
+			},
+			expErr: errors.New("test.yml:7:11: engines[0].storage[0].list: list may not be set when class is ram; remove list"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := new(testConfig)
+			gotErr := Check("test.yml", []byte(tc.input), cfg, tc.rules...)
+			if tc.expErr == nil {
+				if gotErr != nil {
+					t.Fatalf("unexpected error: %s", gotErr)
+				}
+				return
+			}
+			if gotErr == nil {
+				t.Fatalf("expected error %q, got nil", tc.expErr)
+			}
+			if _, ok := gotErr.(Issues); !ok {
+				t.Fatalf("expected Issues, got %T", gotErr)
+			}
+			if !strings.Contains(gotErr.Error(), tc.expErr.Error()) {
+				t.Fatalf("unexpected error (-want, +got):\n%s\n", cmp.Diff(tc.expErr.Error(), gotErr.Error()))
+			}
+		})
+	}
+}
+
+func TestYamlcheck_Closest(t *testing.T) {
+	candidates := []string{"fabric_iface", "fabric_iface_port", "provider", "targets", "nr_xs_helpers", "nvme"}
+
+	for name, tc := range map[string]struct {
+		input string
+		exp   string
+	}{
+		"empty": {},
+		"typo": {
+			input: "tragets",
+			exp:   "targets",
+		},
+		"transposition": {
+			input: "nvem",
+			exp:   "nvme",
+		},
+		"case": {
+			input: "Provider",
+			exp:   "provider",
+		},
+		"missing prefix": {
+			input: "iface",
+			exp:   "fabric_iface",
+		},
+		"unrelated": {
+			input: "scm_mount",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := Closest(tc.input, candidates); got != tc.exp {
+				t.Fatalf("expected %q, got %q", tc.exp, got)
+			}
+		})
+	}
+}
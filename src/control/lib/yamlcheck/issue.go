@@ -0,0 +1,145 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package yamlcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Issue describes a problem found at a specific location in a YAML file.
+type Issue struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string
+	Message string
+	Fix     string
+}
+
+// Location returns the position of the issue in file:line:column form.
+func (i *Issue) Location() string {
+	loc := fmt.Sprintf("%d", i.Line)
+	if i.Column > 0 {
+		loc += fmt.Sprintf(":%d", i.Column)
+	}
+	if i.File != "" {
+		loc = i.File + ":" + loc
+	}
+	return loc
+}
+
+func (i *Issue) Error() string {
+	msg := i.Location() + ": "
+	if i.Path != "" {
+		msg += i.Path + ": "
+	}
+	msg += i.Message
+	if i.Fix != "" {
+		msg += "; " + i.Fix
+	}
+	return msg
+}
+
+// Issues is a list of problems found in a YAML file.
+type Issues []*Issue
+
+func (is Issues) Error() string {
+	if len(is) == 1 {
+		return is[0].Error()
+	}
+
+	lines := make([]string, 0, len(is)+1)
+	lines = append(lines, fmt.Sprintf("%d problems found in config:", len(is)))
+	for _, i := range is {
+		lines = append(lines, "  "+i.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sort orders the issues by their position in the file.
+func (is Issues) sort() {
+	sort.SliceStable(is, func(a, b int) bool {
+		if is[a].Line == is[b].Line {
+			return is[a].Column < is[b].Column
+		}
+		return is[a].Line < is[b].Line
+	})
+}
+
+// editDistance returns the number of insertions, deletions, substitutions
+// and transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = d[i-1][j-1] + cost
+			if d[i-1][j]+1 < d[i][j] {
+				d[i][j] = d[i-1][j] + 1
+			}
+			if d[i][j-1]+1 < d[i][j] {
+				d[i][j] = d[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+// Closest returns the candidate most similar to the supplied string, or an
+// empty string if none of the candidates is a plausible match.
+func Closest(s string, candidates []string) string {
+	s = strings.ToLower(s)
+	if s == "" {
+		return ""
+	}
+
+	// Allow roughly one edit for every three characters.
+	maxDist := len(s) / 3
+	if maxDist < 1 {
+		maxDist = 1
+	}
+
+	best := ""
+	bestDist := maxDist + 1
+	for _, c := range candidates {
+		lc := strings.ToLower(c)
+		d := editDistance(s, lc)
+		if d > 1 && len(s) >= 4 && (strings.Contains(lc, s) || strings.Contains(s, lc)) {
+			// Treat a missing or extra prefix or suffix as a near miss.
+			d = 1
+		}
+		if d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// didYouMean returns a fix suggesting the closest candidate, if any.
+func didYouMean(s string, candidates []string) string {
+	if c := Closest(s, candidates); c != "" {
+		return fmt.Sprintf("did you mean %q?", c)
+	}
+	return ""
+}
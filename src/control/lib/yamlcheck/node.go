@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package yamlcheck
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Node provides read access to a parsed YAML value along with its location,
+// for use by rules that check combinations of settings. All methods may be
+// called on a nil Node, which represents a setting that is not present.
+type Node struct {
+	file string
+	path string
+	yn   *yaml.Node
+}
+
+func newNode(file, path string, yn *yaml.Node) *Node {
+	for yn != nil && yn.Kind == yaml.AliasNode {
+		yn = yn.Alias
+	}
+	if yn == nil || yn.Tag == "!!null" {
+		return nil
+	}
+	return &Node{file: file, path: path, yn: yn}
+}
+
+func childPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func indexPath(parent string, idx int) string {
+	return fmt.Sprintf("%s[%d]", parent, idx)
+}
+
+// Path returns the dotted path of the node from the document root.
+func (n *Node) Path() string {
+	if n == nil {
+		return ""
+	}
+	return n.path
+}
+
+// Line returns the line on which the node starts.
+func (n *Node) Line() int {
+	if n == nil {
+		return 0
+	}
+	return n.yn.Line
+}
+
+// Value returns the value of a scalar node, or an empty string for any other
+// type of node.
+func (n *Node) Value() string {
+	if n == nil || n.yn.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return n.yn.Value
+}
+
+// Keys returns the keys of a mapping node in document order.
+func (n *Node) Keys() []string {
+	if n == nil || n.yn.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(n.yn.Content)/2)
+	for i := 0; i+1 < len(n.yn.Content); i += 2 {
+		keys = append(keys, n.yn.Content[i].Value)
+	}
+	return keys
+}
+
+// Key returns the value of the given key in a mapping node, or nil if the key
+// is not present.
+func (n *Node) Key(name string) *Node {
+	if n == nil || n.yn.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(n.yn.Content); i += 2 {
+		if n.yn.Content[i].Value == name {
+			return newNode(n.file, childPath(n.path, name), n.yn.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// Items returns the entries of a sequence node.
+func (n *Node) Items() []*Node {
+	if n == nil || n.yn.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	items := make([]*Node, 0, len(n.yn.Content))
+	for i, yn := range n.yn.Content {
+		if item := newNode(n.file, indexPath(n.path, i), yn); item != nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Issue returns an Issue located at the node.
+func (n *Node) Issue(message, fix string) *Issue {
+	if n == nil {
+		return &Issue{Message: message, Fix: fix}
+	}
+	return &Issue{
+		File:    n.file,
+		Line:    n.yn.Line,
+		Column:  n.yn.Column,
+		Path:    n.path,
+		Message: message,
+		Fix:     fix,
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -76,6 +76,7 @@ type (
 		infoLoggers   []InfoLogger
 		noticeLoggers []NoticeLogger
 		errorLoggers  []ErrorLogger
+		subsystems    map[string]*SubsystemLogger
 	}
 
 	baseLogger struct {
@@ -0,0 +1,283 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package logging
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// SubsystemRaft identifies log messages from the MS raft
+	// implementation and the system database.
+	SubsystemRaft = "raft"
+	// SubsystemDrpc identifies log messages from the dRPC
+	// client and server.
+	SubsystemDrpc = "drpc"
+	// SubsystemStorage identifies log messages from the SCM
+	// and NVMe storage providers.
+	SubsystemStorage = "storage"
+	// SubsystemEvents identifies log messages from the RAS
+	// event framework.
+	SubsystemEvents = "events"
+
+	// levelInherit indicates that a subsystem logger uses the
+	// level of its parent logger.
+	levelInherit LogLevel = -1
+)
+
+var knownSubsystems = map[string]struct{}{
+	SubsystemRaft:    {},
+	SubsystemDrpc:    {},
+	SubsystemStorage: {},
+	SubsystemEvents:  {},
+}
+
+// Subsystems returns the sorted list of subsystems that may
+// have their log levels set independently.
+func Subsystems() []string {
+	names := make([]string, 0, len(knownSubsystems))
+	for name := range knownSubsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateSubsystem returns an error if the supplied name is not
+// a known subsystem.
+func ValidateSubsystem(name string) error {
+	if _, found := knownSubsystems[name]; !found {
+		return fmt.Errorf("unknown log subsystem %q (valid subsystems: %v)", name, Subsystems())
+	}
+	return nil
+}
+
+type (
+	// SubsystemLeveler is implemented by loggers which allow
+	// the level of a subsystem to be set independently of the
+	// logger's own level.
+	SubsystemLeveler interface {
+		SetSubsystemLevel(name string, level LogLevel) error
+		ResetSubsystemLevels()
+		SubsystemLevels() map[string]LogLevel
+	}
+
+	// SubsystemLogger emits messages for a single subsystem to
+	// the outputs of its parent LeveledLogger. Unless a level has
+	// been set for the subsystem, the parent's level is used.
+	SubsystemLogger struct {
+		parent *LeveledLogger
+		name   string
+		level  LogLevel
+	}
+)
+
+// Subsystem returns a logger for the named subsystem which shares
+// the outputs of this logger.
+func (ll *LeveledLogger) Subsystem(name string) Logger {
+	return ll.subsystem(name)
+}
+
+func (ll *LeveledLogger) subsystem(name string) *SubsystemLogger {
+	ll.Lock()
+	defer ll.Unlock()
+
+	if ll.subsystems == nil {
+		ll.subsystems = make(map[string]*SubsystemLogger)
+	}
+	if sl, found := ll.subsystems[name]; found {
+		return sl
+	}
+
+	sl := &SubsystemLogger{
+		parent: ll,
+		name:   name,
+		level:  levelInherit,
+	}
+	ll.subsystems[name] = sl
+	return sl
+}
+
+// SetSubsystemLevel sets the level at or above which messages for
+// the named subsystem will be emitted, regardless of the level of
+// this logger.
+func (ll *LeveledLogger) SetSubsystemLevel(name string, level LogLevel) error {
+	if err := ValidateSubsystem(name); err != nil {
+		return err
+	}
+	if level < LogLevelDisabled || level > LogLevelTrace {
+		return fmt.Errorf("invalid log level %d", level)
+	}
+
+	ll.subsystem(name).level.Set(level)
+	return nil
+}
+
+// ResetSubsystemLevels reverts all subsystems to the level of this
+// logger.
+func (ll *LeveledLogger) ResetSubsystemLevels() {
+	ll.RLock()
+	defer ll.RUnlock()
+
+	for _, sl := range ll.subsystems {
+		sl.level.Set(levelInherit)
+	}
+}
+
+// SubsystemLevels returns the levels that have been set for
+// subsystems.
+func (ll *LeveledLogger) SubsystemLevels() map[string]LogLevel {
+	ll.RLock()
+	defer ll.RUnlock()
+
+	levels := make(map[string]LogLevel)
+	for name, sl := range ll.subsystems {
+		if level := sl.level.Get(); level != levelInherit {
+			levels[name] = level
+		}
+	}
+	return levels
+}
+
+// ForSubsystem returns a logger for the named subsystem if the
+// supplied logger supports them, or the supplied logger otherwise.
+func ForSubsystem(log Logger, name string) Logger {
+	if ssl, ok := log.(interface{ Subsystem(string) Logger }); ok {
+		return ssl.Subsystem(name)
+	}
+	return log
+}
+
+// Name returns the name of the subsystem.
+func (sl *SubsystemLogger) Name() string {
+	return sl.name
+}
+
+// Level returns the effective LogLevel of the subsystem.
+func (sl *SubsystemLogger) Level() LogLevel {
+	if level := sl.level.Get(); level != levelInherit {
+		return level
+	}
+	return sl.parent.Level()
+}
+
+// EnabledFor returns true if the subsystem is enabled for the
+// specified LogLevel.
+func (sl *SubsystemLogger) EnabledFor(level LogLevel) bool {
+	return sl.Level() >= level
+}
+
+// Trace emits an unformatted message at Trace level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Trace(msg string) {
+	sl.Tracef("%s", msg)
+}
+
+// Tracef emits a formatted message at Trace level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Tracef(format string, args ...interface{}) {
+	if sl.Level() < LogLevelTrace {
+		return
+	}
+
+	sl.parent.RLock()
+	loggers := sl.parent.traceLoggers
+	sl.parent.RUnlock()
+
+	for _, l := range loggers {
+		l.Tracef(format, args...)
+	}
+}
+
+// Debug emits an unformatted message at Debug level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Debug(msg string) {
+	sl.Debugf("%s", msg)
+}
+
+// Debugf emits a formatted message at Debug level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Debugf(format string, args ...interface{}) {
+	if sl.Level() < LogLevelDebug {
+		return
+	}
+
+	sl.parent.RLock()
+	loggers := sl.parent.debugLoggers
+	sl.parent.RUnlock()
+
+	for _, l := range loggers {
+		l.Debugf(format, args...)
+	}
+}
+
+// Info emits an unformatted message at Info level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Info(msg string) {
+	sl.Infof("%s", msg)
+}
+
+// Infof emits a formatted message at Info level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Infof(format string, args ...interface{}) {
+	if sl.Level() < LogLevelInfo {
+		return
+	}
+
+	sl.parent.RLock()
+	loggers := sl.parent.infoLoggers
+	sl.parent.RUnlock()
+
+	for _, l := range loggers {
+		l.Infof(format, args...)
+	}
+}
+
+// Notice emits an unformatted message at Notice level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Notice(msg string) {
+	sl.Noticef("%s", msg)
+}
+
+// Noticef emits a formatted message at Notice level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Noticef(format string, args ...interface{}) {
+	if sl.Level() < LogLevelNotice {
+		return
+	}
+
+	sl.parent.RLock()
+	loggers := sl.parent.noticeLoggers
+	sl.parent.RUnlock()
+
+	for _, l := range loggers {
+		l.Noticef(format, args...)
+	}
+}
+
+// Error emits an unformatted message at Error level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Error(msg string) {
+	sl.Errorf("%s", msg)
+}
+
+// Errorf emits a formatted message at Error level, if
+// the subsystem is configured to do so.
+func (sl *SubsystemLogger) Errorf(format string, args ...interface{}) {
+	if sl.Level() < LogLevelError {
+		return
+	}
+
+	sl.parent.RLock()
+	loggers := sl.parent.errorLoggers
+	sl.parent.RUnlock()
+
+	for _, l := range loggers {
+		l.Errorf(format, args...)
+	}
+}
@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package logging_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestLogging_SubsystemLevels(t *testing.T) {
+	for name, tc := range map[string]struct {
+		parentLevel logging.LogLevel
+		subsysLevel map[string]logging.LogLevel
+		reset       bool
+		expRaft     bool
+		expDrpc     bool
+		expParent   bool
+		expErr      bool
+	}{
+		"inherits parent level": {
+			parentLevel: logging.LogLevelInfo,
+		},
+		"inherits parent debug": {
+			parentLevel: logging.LogLevelDebug,
+			expRaft:     true,
+			expDrpc:     true,
+			expParent:   true,
+		},
+		"raft debug only": {
+			parentLevel: logging.LogLevelInfo,
+			subsysLevel: map[string]logging.LogLevel{
+				logging.SubsystemRaft: logging.LogLevelDebug,
+			},
+			expRaft: true,
+		},
+		"quiet drpc": {
+			parentLevel: logging.LogLevelDebug,
+			subsysLevel: map[string]logging.LogLevel{
+				logging.SubsystemDrpc: logging.LogLevelError,
+			},
+			expRaft:   true,
+			expParent: true,
+		},
+		"reset": {
+			parentLevel: logging.LogLevelInfo,
+			subsysLevel: map[string]logging.LogLevel{
+				logging.SubsystemRaft: logging.LogLevelDebug,
+			},
+			reset: true,
+		},
+		"unknown subsystem": {
+			parentLevel: logging.LogLevelInfo,
+			subsysLevel: map[string]logging.LogLevel{
+				"bogus": logging.LogLevelDebug,
+			},
+			expErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			log.SetLevel(tc.parentLevel)
+
+			raftLog := logging.ForSubsystem(log, logging.SubsystemRaft)
+			drpcLog := logging.ForSubsystem(log, logging.SubsystemDrpc)
+
+			for name, level := range tc.subsysLevel {
+				err := log.SetSubsystemLevel(name, level)
+				if (err != nil) != tc.expErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if tc.reset {
+				log.ResetSubsystemLevels()
+			}
+
+			raftLog.Debug("raft message")
+			drpcLog.Debugf("drpc %s", "message")
+			log.Debug("parent message")
+
+			for msg, exp := range map[string]bool{
+				"raft message":   tc.expRaft,
+				"drpc message":   tc.expDrpc,
+				"parent message": tc.expParent,
+			} {
+				if strings.Contains(buf.String(), msg) != exp {
+					t.Errorf("expected %q logged: %t\n%s", msg, exp, buf.String())
+				}
+			}
+
+			expLevels := map[string]logging.LogLevel{}
+			if !tc.reset && !tc.expErr && tc.subsysLevel != nil {
+				expLevels = tc.subsysLevel
+			}
+			if diff := cmp.Diff(expLevels, log.SubsystemLevels()); diff != "" {
+				t.Errorf("unexpected subsystem levels (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLogging_ForSubsystem(t *testing.T) {
+	log, _ := logging.NewTestLogger(t.Name())
+
+	raftLog := logging.ForSubsystem(log, logging.SubsystemRaft)
+	if raftLog != logging.ForSubsystem(log, logging.SubsystemRaft) {
+		t.Fatal("expected same logger for repeated lookups")
+	}
+	if sl, ok := raftLog.(*logging.SubsystemLogger); !ok || sl.Name() != logging.SubsystemRaft {
+		t.Fatalf("unexpected subsystem logger %+v", raftLog)
+	}
+
+	// Loggers without subsystem support are returned unchanged.
+	if logging.ForSubsystem(raftLog, logging.SubsystemDrpc) != raftLog {
+		t.Fatal("expected original logger")
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //go:build linux
@@ -22,6 +22,16 @@ func MustCreateSyslogger(prio syslog.Priority, flags int) *log.Logger {
 	return logger
 }
 
+// MustCreateSyslogWriter attempts to create a *syslog.Writer for output
+// to the system log daemon. If it fails, it will panic.
+func MustCreateSyslogWriter(prio syslog.Priority) *syslog.Writer {
+	w, err := syslog.New(prio, "")
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
 type (
 	syslogDebug interface {
 		WithSyslogOutput() DebugLogger
@@ -127,3 +137,37 @@ func (l *DefaultDebugLogger) WithSyslogOutput() DebugLogger {
 		},
 	}
 }
+
+// WithSyslogSink adds loggers which send Info, Notice and Error messages
+// to the system logging service, optionally formatted as JSON. Debug and
+// Trace messages are not sent to syslog.
+func (ll *LeveledLogger) WithSyslogSink(jsonOutput bool) *LeveledLogger {
+	if !jsonOutput {
+		return ll.
+			WithInfoLogger((&DefaultInfoLogger{}).WithSyslogOutput()).
+			WithNoticeLogger((&DefaultNoticeLogger{}).WithSyslogOutput()).
+			WithErrorLogger((&DefaultErrorLogger{}).WithSyslogOutput())
+	}
+
+	infoDest := MustCreateSyslogWriter(syslog.LOG_INFO)
+	errDest := MustCreateSyslogWriter(syslog.LOG_ERR)
+	return ll.
+		WithInfoLogger(&DefaultInfoLogger{
+			baseLogger{
+				dest: infoDest,
+				log:  NewJSONFormatter(infoDest, "INFO", "", emptyLogFlags),
+			},
+		}).
+		WithNoticeLogger(&DefaultNoticeLogger{
+			baseLogger{
+				dest: infoDest,
+				log:  NewJSONFormatter(infoDest, "NOTICE", "", emptyLogFlags),
+			},
+		}).
+		WithErrorLogger(&DefaultErrorLogger{
+			baseLogger{
+				dest: errDest,
+				log:  NewJSONFormatter(errDest, "ERROR", "", emptyLogFlags),
+			},
+		})
+}
@@ -98,6 +98,8 @@ func (e *External) Current() (User, error) {
 	return &UserInfo{Info: info}, nil
 }
 
+var errUnsignedFIPS = errors.New("unsigned credentials are not permitted in FIPS mode")
+
 // VerifierFromToken will return a SHA512 hash of the token data. If a signing key
 // is passed in it will additionally sign the hash of the token.
 func VerifierFromToken(key crypto.PublicKey, token *Token) ([]byte, error) {
@@ -110,6 +112,9 @@ func VerifierFromToken(key crypto.PublicKey, token *Token) ([]byte, error) {
 	signer := security.DefaultTokenSigner()
 
 	if key == nil {
+		if security.FIPSModeEnabled() {
+			return nil, errUnsignedFIPS
+		}
 		return signer.Hash(tokenBytes)
 	}
 	sig, err = signer.Sign(key, tokenBytes)
@@ -128,6 +133,9 @@ func VerifyToken(key crypto.PublicKey, token *Token, sig []byte) error {
 	signer := security.DefaultTokenSigner()
 
 	if key == nil {
+		if security.FIPSModeEnabled() {
+			return errUnsignedFIPS
+		}
 		digest, err := signer.Hash(tokenBytes)
 		if err != nil {
 			return err
@@ -160,6 +168,50 @@ func GetMachineName() (string, error) {
 // during the dRPC request and creates an AuthSys security request to obtain
 // a handle from the management service.
 func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing crypto.PrivateKey) (*Credential, error) {
+	sys, err := authSysFromCreds(ext, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentialFromAuthSys(sys, signing)
+}
+
+// MappedIdentity is a DAOS identity assigned to a client process in place of
+// the one derived from its local account.
+type MappedIdentity struct {
+	User   string
+	Group  string
+	Groups []string
+}
+
+// AuthSysRequestFromIdentity creates a signed AuthSys credential for the
+// client process using the mapped identity rather than looking up the domain
+// info credentials in the local account database.
+func AuthSysRequestFromIdentity(id *MappedIdentity, creds *security.DomainInfo, signing crypto.PrivateKey) (*Credential, error) {
+	if creds == nil {
+		return nil, errors.New("No credentials supplied")
+	}
+	if id == nil || id.User == "" || id.Group == "" {
+		return nil, errors.New("mapped identity requires a user and group")
+	}
+
+	groupList := []string{}
+	for _, grp := range id.Groups {
+		groupList = append(groupList, sysNameToPrincipalName(grp))
+	}
+
+	return credentialFromAuthSys(&Sys{
+		Stamp:       0,
+		Machinename: localMachineName(),
+		User:        sysNameToPrincipalName(id.User),
+		Group:       sysNameToPrincipalName(id.Group),
+		Groups:      groupList,
+		Secctx:      creds.Ctx()}, signing)
+}
+
+// authSysFromCreds looks up the user and group information for the domain
+// info credentials and uses it to build an AuthSys token.
+func authSysFromCreds(ext UserExt, creds *security.DomainInfo) (*Sys, error) {
 	if creds == nil {
 		return nil, errors.New("No credentials supplied")
 	}
@@ -182,11 +234,6 @@ func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing cr
 			userInfo.Username())
 	}
 
-	host, err := GetMachineName()
-	if err != nil {
-		host = "unavailable"
-	}
-
 	var groupList = []string{}
 
 	// Convert groups to gids
@@ -200,24 +247,27 @@ func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing cr
 	}
 
 	// Craft AuthToken
-	sys := Sys{
+	return &Sys{
 		Stamp:       0,
-		Machinename: host,
+		Machinename: localMachineName(),
 		User:        sysNameToPrincipalName(userInfo.Username()),
 		Group:       sysNameToPrincipalName(groupInfo.Name),
 		Groups:      groupList,
-		Secctx:      creds.Ctx()}
+		Secctx:      creds.Ctx()}, nil
+}
 
-	// Marshal our AuthSys token into a byte array
-	tokenBytes, err := proto.Marshal(&sys)
+func localMachineName() string {
+	host, err := GetMachineName()
 	if err != nil {
-		return nil, errors.Wrap(err, "Unable to marshal AuthSys token")
+		return "unavailable"
 	}
-	token := Token{
-		Flavor: Flavor_AUTH_SYS,
-		Data:   tokenBytes}
+	return host
+}
 
-	verifier, err := VerifierFromToken(signing, &token)
+// signedCredential wraps the token in a Credential with a verifier
+// generated using the signing key.
+func signedCredential(token *Token, signing crypto.PrivateKey) (*Credential, error) {
+	verifier, err := VerifierFromToken(signing, token)
 	if err != nil {
 		return nil, errors.WithMessage(err, "Unable to generate verifier")
 	}
@@ -226,12 +276,23 @@ func AuthSysRequestFromCreds(ext UserExt, creds *security.DomainInfo, signing cr
 		Flavor: Flavor_AUTH_SYS,
 		Data:   verifier}
 
-	credential := Credential{
-		Token:    &token,
+	return &Credential{
+		Token:    token,
 		Verifier: &verifierToken,
-		Origin:   "agent"}
+		Origin:   "agent"}, nil
+}
 
-	return &credential, nil
+// credentialFromAuthSys creates a signed Credential from an AuthSys token.
+func credentialFromAuthSys(sys *Sys, signing crypto.PrivateKey) (*Credential, error) {
+	// Marshal our AuthSys token into a byte array
+	tokenBytes, err := proto.Marshal(sys)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to marshal AuthSys token")
+	}
+
+	return signedCredential(&Token{
+		Flavor: Flavor_AUTH_SYS,
+		Data:   tokenBytes}, signing)
 }
 
 // AuthSysFromAuthToken takes an opaque AuthToken and turns it into a
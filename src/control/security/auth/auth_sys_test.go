@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2022 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -271,3 +271,65 @@ func TestAuthSysRequestFromCreds_GroupIDListFails(t *testing.T) {
 		t.Errorf("Expected error '%v', got '%v'", expectedErr, err)
 	}
 }
+
+func TestAuthSysRequestFromIdentity(t *testing.T) {
+	for name, tc := range map[string]struct {
+		id        *MappedIdentity
+		creds     *security.DomainInfo
+		expSys    *Sys
+		expErrStr string
+	}{
+		"nil creds": {
+			id:        &MappedIdentity{User: "user", Group: "group"},
+			expErrStr: "No credentials supplied",
+		},
+		"no user": {
+			id:        &MappedIdentity{Group: "group"},
+			creds:     getTestCreds(100000, 100000),
+			expErrStr: "mapped identity requires a user and group",
+		},
+		"no group": {
+			id:        &MappedIdentity{User: "user"},
+			creds:     getTestCreds(100000, 100000),
+			expErrStr: "mapped identity requires a user and group",
+		},
+		"success": {
+			id: &MappedIdentity{
+				User:   "user",
+				Group:  "group",
+				Groups: []string{"extra1", "extra2"},
+			},
+			creds: getTestCreds(100000, 100000),
+			expSys: &Sys{
+				User:   "user@",
+				Group:  "group@",
+				Groups: []string{"extra1@", "extra2@"},
+				Secctx: "test",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := AuthSysRequestFromIdentity(tc.id, tc.creds, nil)
+			if tc.expErrStr != "" {
+				ExpectError(t, err, tc.expErrStr, name)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			authsys, err := AuthSysFromAuthToken(result.GetToken())
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.expSys.Machinename = authsys.Machinename
+			if !proto.Equal(tc.expSys, authsys) {
+				t.Fatalf("unexpected AuthSys: want %+v, got %+v", tc.expSys, authsys)
+			}
+
+			if err := VerifyToken(nil, result.GetToken(), result.GetVerifier().GetData()); err != nil {
+				t.Fatalf("credential failed to verify: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"encoding/base64"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/security"
+)
+
+const (
+	// DefaultDelegationTokenLifetime is the lifetime given to a delegation
+	// token when the request does not specify one.
+	DefaultDelegationTokenLifetime = time.Hour
+	// MaxDelegationTokenLifetime is the longest lifetime that may be
+	// requested for a delegation token.
+	MaxDelegationTokenLifetime = 24 * time.Hour
+)
+
+var (
+	// ErrDelegationTokenExpired indicates that a delegation token was
+	// redeemed after its expiration time.
+	ErrDelegationTokenExpired = errors.New("delegation token has expired")
+	// ErrDelegationTokenMismatch indicates that a delegation token was
+	// redeemed by a process that it was not issued for.
+	ErrDelegationTokenMismatch = errors.New("delegation token was not issued for this process")
+)
+
+// CreateDelegationToken generates a signed, encoded delegation token for the
+// user identified by the domain info credentials. The token may be redeemed
+// by processes running as the same user on any node whose agent can verify
+// the signature.
+func CreateDelegationToken(ext UserExt, creds *security.DomainInfo, jobID string, lifetime time.Duration, signing crypto.PrivateKey) (string, time.Time, error) {
+	if lifetime == 0 {
+		lifetime = DefaultDelegationTokenLifetime
+	}
+	if lifetime < 0 || lifetime > MaxDelegationTokenLifetime {
+		return "", time.Time{}, errors.Errorf("invalid delegation token lifetime %s (max %s)", lifetime, MaxDelegationTokenLifetime)
+	}
+
+	sys, err := authSysFromCreds(ext, creds)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	sysBytes, err := proto.Marshal(sys)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "unable to marshal AuthSys token")
+	}
+
+	expires := time.Now().Add(lifetime)
+	dt := &DelegationToken{
+		Token: &Token{
+			Flavor: Flavor_AUTH_SYS,
+			Data:   sysBytes,
+		},
+		Uid:     creds.Uid(),
+		Jobid:   jobID,
+		Expires: uint64(expires.Unix()),
+		Issuer:  sys.Machinename,
+	}
+
+	dtBytes, err := proto.Marshal(dt)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "unable to marshal delegation token")
+	}
+
+	// The delegation token has its own flavor, which is covered by the
+	// signature, so that it can't be presented as an AUTH_SYS credential.
+	cred, err := signedCredential(&Token{
+		Flavor: Flavor_AUTH_DELEGATION,
+		Data:   dtBytes,
+	}, signing)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	credBytes, err := proto.Marshal(cred)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "unable to marshal delegation credential")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(credBytes), time.Unix(int64(dt.Expires), 0), nil
+}
+
+// DecodeDelegationToken decodes an encoded delegation token and verifies its
+// signature with the supplied key.
+func DecodeDelegationToken(encoded string, verify crypto.PublicKey) (*DelegationToken, error) {
+	credBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode delegation token")
+	}
+
+	cred := new(Credential)
+	if err := proto.Unmarshal(credBytes, cred); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal delegation credential")
+	}
+	if cred.GetToken() == nil || cred.GetVerifier() == nil {
+		return nil, errors.New("malformed delegation token")
+	}
+
+	if cred.GetToken().GetFlavor() != Flavor_AUTH_DELEGATION {
+		return nil, errors.Errorf("invalid delegation token flavor %s", cred.GetToken().GetFlavor())
+	}
+	if err := VerifyToken(verify, cred.GetToken(), cred.GetVerifier().GetData()); err != nil {
+		return nil, errors.Wrap(err, "delegation token failed to verify")
+	}
+
+	dt := new(DelegationToken)
+	if err := proto.Unmarshal(cred.GetToken().GetData(), dt); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal delegation token")
+	}
+	if dt.GetToken() == nil {
+		return nil, errors.New("delegation token has no AuthSys token")
+	}
+
+	return dt, nil
+}
+
+// RedeemDelegationToken verifies an encoded delegation token and exchanges it
+// for a credential on behalf of the process identified by the domain info
+// credentials. The user and group information is taken from the token rather
+// than being looked up locally.
+func RedeemDelegationToken(encoded string, creds *security.DomainInfo, jobID string, verify crypto.PublicKey, signing crypto.PrivateKey) (*Credential, error) {
+	if creds == nil {
+		return nil, errors.New("No credentials supplied")
+	}
+
+	dt, err := DecodeDelegationToken(encoded, verify)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(time.Unix(int64(dt.GetExpires()), 0)) {
+		return nil, ErrDelegationTokenExpired
+	}
+	if dt.GetUid() != creds.Uid() {
+		return nil, errors.Wrapf(ErrDelegationTokenMismatch, "uid %d", creds.Uid())
+	}
+	if dt.GetJobid() != "" && dt.GetJobid() != jobID {
+		return nil, errors.Wrapf(ErrDelegationTokenMismatch, "job %q", jobID)
+	}
+
+	sys, err := AuthSysFromAuthToken(dt.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	sys.Machinename = localMachineName()
+	sys.Secctx = creds.Ctx()
+
+	return credentialFromAuthSys(sys, signing)
+}
@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestAuth_DelegationToken(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		signing      crypto.PrivateKey
+		verify       crypto.PublicKey
+		lifetime     time.Duration
+		tokenJobID   string
+		redeemUid    uint32
+		redeemJobID  string
+		corrupt      bool
+		expCreateErr error
+		expRedeemErr error
+	}{
+		"insecure": {
+			redeemUid: 15,
+		},
+		"signed": {
+			signing:   signingKey,
+			verify:    &signingKey.PublicKey,
+			redeemUid: 15,
+		},
+		"signed with other key": {
+			signing:      signingKey,
+			verify:       &otherKey.PublicKey,
+			redeemUid:    15,
+			expRedeemErr: errors.New("failed to verify"),
+		},
+		"corrupted token": {
+			redeemUid:    15,
+			corrupt:      true,
+			expRedeemErr: errors.New("decode"),
+		},
+		"lifetime too long": {
+			lifetime:     MaxDelegationTokenLifetime + time.Second,
+			expCreateErr: errors.New("invalid delegation token lifetime"),
+		},
+		"negative lifetime": {
+			lifetime:     -time.Hour,
+			expCreateErr: errors.New("invalid delegation token lifetime"),
+		},
+		"wrong uid": {
+			redeemUid:    16,
+			expRedeemErr: ErrDelegationTokenMismatch,
+		},
+		"matching job": {
+			tokenJobID:  "job1",
+			redeemUid:   15,
+			redeemJobID: "job1",
+		},
+		"wrong job": {
+			tokenJobID:   "job1",
+			redeemUid:    15,
+			redeemJobID:  "job2",
+			expRedeemErr: ErrDelegationTokenMismatch,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ext := NewMockExtWithUser("myuser", 15, 2001)
+
+			encoded, expires, err := CreateDelegationToken(ext, getTestCreds(15, 2001), tc.tokenJobID, tc.lifetime, tc.signing)
+			test.CmpErr(t, tc.expCreateErr, err)
+			if tc.expCreateErr != nil {
+				return
+			}
+
+			expLifetime := tc.lifetime
+			if expLifetime == 0 {
+				expLifetime = DefaultDelegationTokenLifetime
+			}
+			if time.Until(expires) > expLifetime || time.Until(expires) < expLifetime-time.Minute {
+				t.Fatalf("unexpected expiration time %s", expires)
+			}
+
+			if tc.corrupt {
+				encoded = "!" + encoded
+			}
+
+			cred, err := RedeemDelegationToken(encoded, getTestCreds(tc.redeemUid, 2001), tc.redeemJobID, tc.verify, tc.signing)
+			test.CmpErr(t, tc.expRedeemErr, err)
+			if tc.expRedeemErr != nil {
+				return
+			}
+
+			if err := VerifyToken(tc.verify, cred.GetToken(), cred.GetVerifier().GetData()); err != nil {
+				t.Fatalf("redeemed credential failed to verify: %s", err)
+			}
+
+			sys, err := AuthSysFromAuthToken(cred.GetToken())
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, "myuser@", sys.GetUser(), "unexpected user")
+			test.AssertEqual(t, "test", sys.GetSecctx(), "unexpected security context")
+			test.AssertEqual(t, localMachineName(), sys.GetMachinename(), "unexpected machine name")
+		})
+	}
+}
+
+func TestAuth_RedeemDelegationToken_Expired(t *testing.T) {
+	ext := NewMockExtWithUser("myuser", 15, 2001)
+	encoded, _, err := CreateDelegationToken(ext, getTestCreds(15, 2001), "", time.Nanosecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Expiration has a resolution of one second.
+	time.Sleep(1100 * time.Millisecond)
+
+	_, err = RedeemDelegationToken(encoded, getTestCreds(15, 2001), "", nil, nil)
+	test.CmpErr(t, ErrDelegationTokenExpired, err)
+}
+
+func TestAuth_DelegationToken_NotCredential(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := NewMockExtWithUser("myuser", 15, 2001)
+
+	encoded, _, err := CreateDelegationToken(ext, getTestCreds(15, 2001), "", 0, signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := new(Credential)
+	if err := proto.Unmarshal(credBytes, cred); err != nil {
+		t.Fatal(err)
+	}
+
+	// The token is signed by the agent key, but must not be accepted as an
+	// AUTH_SYS credential.
+	if err := VerifyToken(&signingKey.PublicKey, cred.GetToken(), cred.GetVerifier().GetData()); err != nil {
+		t.Fatal(err)
+	}
+	_, err = AuthSysFromAuthToken(cred.GetToken())
+	test.CmpErr(t, errors.New("invalid AuthSys Token"), err)
+
+	// Nor may an AUTH_SYS credential be redeemed as a delegation token.
+	sysCred, err := AuthSysRequestFromCreds(ext, getTestCreds(15, 2001), signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysBytes, err := proto.Marshal(sysCred)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = DecodeDelegationToken(base64.RawURLEncoding.EncodeToString(sysBytes), &signingKey.PublicKey)
+	test.CmpErr(t, errors.New("invalid delegation token flavor"), err)
+}
@@ -36,6 +36,7 @@ const (
 // certificates and their location if their use is specified.
 type TransportConfig struct {
 	AllowInsecure     bool `yaml:"allow_insecure"`
+	FIPSMode          bool `yaml:"fips_mode,omitempty"`
 	CertificateConfig `yaml:",inline"`
 }
 
@@ -121,6 +122,9 @@ func (tc *TransportConfig) PreLoadCertData() error {
 	if tc == nil {
 		return errors.New("nil TransportConfig")
 	}
+	if err := tc.ValidateFIPS(); err != nil {
+		return err
+	}
 	if tc.tlsKeypair != nil && tc.caPool != nil || tc.AllowInsecure {
 		// In this case the data is already preloaded.
 		// In order to reload data use ReloadCertData
@@ -147,16 +151,23 @@ func (tc *TransportConfig) PreLoadCertData() error {
 		return err
 	}
 
-	if _, err = tc.tlsKeypair.Leaf.Verify(x509.VerifyOptions{
+	chains, err := tc.tlsKeypair.Leaf.Verify(x509.VerifyOptions{
 		CurrentTime: tc.CertificateConfig.verifyTime, // for testing - by default this is 0, which is treated as current time
 		Roots:       certPool,
 		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
-	}); isInvalidCert(err) {
+	})
+	if isInvalidCert(err) {
 		return FaultInvalidCertFile(tc.CertificatePath, err)
 	} else if err != nil {
 		return err
 	}
 
+	if tc.fipsEnabled() {
+		if err := checkFIPSChains(chains); err != nil {
+			return FaultFIPSNonCompliantCert(tc.CertificatePath, err)
+		}
+	}
+
 	return nil
 }
 
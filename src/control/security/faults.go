@@ -65,6 +65,40 @@ func FaultInvalidCert(err error) *fault.Fault {
 	return f
 }
 
+// FaultFIPSInsecure indicates that FIPS mode was requested together with
+// insecure (unauthenticated, unencrypted) transport.
+func FaultFIPSInsecure() *fault.Fault {
+	return securityFault(
+		code.SecurityFIPSNonCompliant,
+		"FIPS mode is enabled but the transport config allows insecure communication",
+		"set allow_insecure to false and configure certificates, or disable fips_mode",
+	)
+}
+
+// FaultFIPSNonCompliantCert indicates that a certificate does not meet the
+// requirements of FIPS mode.
+func FaultFIPSNonCompliantCert(filePath string, err error) *fault.Fault {
+	f := securityFault(
+		code.SecurityFIPSNonCompliant,
+		fmt.Sprintf("certificate chain for %q is not FIPS compliant", filePath),
+		"regenerate the certificates with RSA (2048 bits or larger) or ECDSA (P-256, P-384 or P-521) keys and SHA-2 signatures",
+	)
+	if err != nil {
+		f.Reason = err.Error()
+	}
+	return f
+}
+
+// FaultInvalidProvisioningToken indicates that a server joining the system for
+// the first time did not present a valid provisioning token.
+func FaultInvalidProvisioningToken(reason string) *fault.Fault {
+	return securityFault(
+		code.SecurityInvalidProvisioningToken,
+		fmt.Sprintf("provisioning token rejected: %s", reason),
+		"create a token with \"dmg system token create\" and set provisioning_token_file in the joining server's config file",
+	)
+}
+
 func securityFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "security",
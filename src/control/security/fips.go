@@ -0,0 +1,172 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// FIPS mode restricts the cryptographic choices made by the control plane to
+// those approved by FIPS 140. It may be enabled at build time with the "fips"
+// build tag, or at runtime by setting fips_mode in the transport config.
+// Once enabled at runtime it applies to the whole process.
+
+// minFIPSRSABits is the smallest RSA modulus accepted in FIPS mode.
+const minFIPSRSABits = 2048
+
+var (
+	fipsRuntime int32
+
+	// fipsCipherSuites lists the TLS 1.2 ciphersuites permitted in FIPS
+	// mode. ECDHE key exchange is preferred, with the RSA key transport
+	// suite used in non-FIPS mode last to remain interoperable with it.
+	fipsCipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	}
+
+	fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+	fipsSignatureAlgorithms = map[x509.SignatureAlgorithm]struct{}{
+		x509.SHA256WithRSA:    {},
+		x509.SHA384WithRSA:    {},
+		x509.SHA512WithRSA:    {},
+		x509.SHA256WithRSAPSS: {},
+		x509.SHA384WithRSAPSS: {},
+		x509.SHA512WithRSAPSS: {},
+		x509.ECDSAWithSHA256:  {},
+		x509.ECDSAWithSHA384:  {},
+		x509.ECDSAWithSHA512:  {},
+	}
+)
+
+// EnableFIPSMode enables FIPS mode for the remainder of the process lifetime.
+func EnableFIPSMode() {
+	atomic.StoreInt32(&fipsRuntime, 1)
+}
+
+// FIPSModeEnabled indicates whether FIPS mode is enabled, either at build time
+// or at runtime.
+func FIPSModeEnabled() bool {
+	return fipsBuild || atomic.LoadInt32(&fipsRuntime) == 1
+}
+
+func (tc *TransportConfig) fipsEnabled() bool {
+	return FIPSModeEnabled() || (tc != nil && tc.FIPSMode)
+}
+
+// ValidateFIPS checks that the transport config is usable in FIPS mode and
+// enables FIPS mode for the process if the config requests it. Certificates
+// are checked when they are loaded.
+func (tc *TransportConfig) ValidateFIPS() error {
+	if tc == nil {
+		return errors.New("nil TransportConfig")
+	}
+	if !tc.fipsEnabled() {
+		return nil
+	}
+	EnableFIPSMode()
+
+	if tc.AllowInsecure {
+		return FaultFIPSInsecure()
+	}
+
+	return nil
+}
+
+// checkFIPSPublicKey returns an error if the key type or size is not approved.
+func checkFIPSPublicKey(key crypto.PublicKey) error {
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minFIPSRSABits {
+			return errors.Errorf("%d-bit RSA key is smaller than the %d-bit minimum",
+				pub.N.BitLen(), minFIPSRSABits)
+		}
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return errors.Errorf("ECDSA curve %s is not approved", pub.Curve.Params().Name)
+		}
+	default:
+		return errors.Errorf("%T keys are not approved", key)
+	}
+
+	return nil
+}
+
+// checkFIPSCertificate returns an error if the certificate is signed with an
+// unapproved algorithm or contains an unapproved public key.
+func checkFIPSCertificate(cert *x509.Certificate) error {
+	if _, ok := fipsSignatureAlgorithms[cert.SignatureAlgorithm]; !ok {
+		return errors.Errorf("certificate %q: signature algorithm %s is not approved",
+			cert.Subject.CommonName, cert.SignatureAlgorithm)
+	}
+	if err := checkFIPSPublicKey(cert.PublicKey); err != nil {
+		return errors.Wrapf(err, "certificate %q", cert.Subject.CommonName)
+	}
+
+	return nil
+}
+
+func checkFIPSChains(chains [][]*x509.Certificate) error {
+	for _, chain := range chains {
+		for _, cert := range chain {
+			if err := checkFIPSCertificate(cert); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyFIPSPeerCertificates is used as the tls.Config VerifyPeerCertificate
+// callback in FIPS mode. It runs after the normal chain verification and
+// rejects peers presenting non-compliant certificates.
+func verifyFIPSPeerCertificates(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(verifiedChains) > 0 {
+		return checkFIPSChains(verifiedChains)
+	}
+
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		if err := checkFIPSCertificate(cert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyFIPSTLSConfig restricts the TLS config to FIPS-approved choices if FIPS
+// mode is enabled.
+func applyFIPSTLSConfig(tc *TransportConfig, cfg *tls.Config) *tls.Config {
+	if !tc.fipsEnabled() {
+		return cfg
+	}
+
+	cfg.CipherSuites = fipsCipherSuites
+	cfg.CurvePreferences = fipsCurves
+	cfg.VerifyPeerCertificate = verifyFIPSPeerCertificates
+
+	return cfg
+}
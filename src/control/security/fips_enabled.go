@@ -0,0 +1,14 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+//go:build fips
+// +build fips
+
+package security
+
+// fipsBuild indicates that FIPS mode was enabled at build time and cannot be
+// disabled by configuration.
+const fipsBuild = true
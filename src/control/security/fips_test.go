@@ -0,0 +1,294 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func resetFIPSMode(t *testing.T) {
+	t.Helper()
+
+	if fipsBuild {
+		t.Skip("FIPS mode enabled at build time")
+	}
+	atomic.StoreInt32(&fipsRuntime, 0)
+	t.Cleanup(func() {
+		atomic.StoreInt32(&fipsRuntime, 0)
+	})
+}
+
+// writeTestCertChain generates a CA and a leaf certificate signed by it with
+// keys of the given RSA size, and returns a TransportConfig using them.
+func writeTestCertChain(t *testing.T, dir string, bits int) *TransportConfig {
+	t.Helper()
+
+	writePEM := func(name, typ string, der []byte, perm os.FileMode) string {
+		path := filepath.Join(dir, name)
+		data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+		if err := os.WriteFile(path, data, perm); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	caKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caTmpl, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &TransportConfig{
+		CertificateConfig: CertificateConfig{
+			CARootPath:      writePEM("ca.crt", "CERTIFICATE", caDER, MaxCertPerm),
+			CertificatePath: writePEM("server.crt", "CERTIFICATE", der, MaxCertPerm),
+			PrivateKeyPath: writePEM("server.key", "RSA PRIVATE KEY",
+				x509.MarshalPKCS1PrivateKey(key), MaxUserOnlyKeyPerm),
+			maxKeyPerms: MaxUserOnlyKeyPerm,
+		},
+	}
+}
+
+func TestSecurity_TransportConfig_ValidateFIPS(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        *TransportConfig
+		expErr     error
+		expEnabled bool
+	}{
+		"nil": {
+			expErr: errors.New("nil"),
+		},
+		"fips disabled; insecure": {
+			cfg: InsecureTC(),
+		},
+		"fips enabled; insecure": {
+			cfg: &TransportConfig{
+				AllowInsecure: true,
+				FIPSMode:      true,
+			},
+			expErr:     FaultFIPSInsecure(),
+			expEnabled: true,
+		},
+		"fips enabled; secure": {
+			cfg: &TransportConfig{
+				FIPSMode: true,
+			},
+			expEnabled: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resetFIPSMode(t)
+
+			err := tc.cfg.ValidateFIPS()
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expEnabled, FIPSModeEnabled(), "unexpected FIPS mode")
+		})
+	}
+}
+
+func TestSecurity_checkFIPSPublicKey(t *testing.T) {
+	genRSA := func(bits int) crypto.PublicKey {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &key.PublicKey
+	}
+	genECDSA := func(curve elliptic.Curve) crypto.PublicKey {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &key.PublicKey
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key    crypto.PublicKey
+		expErr error
+	}{
+		"rsa 1024": {
+			key:    genRSA(1024),
+			expErr: errors.New("1024-bit RSA key is smaller"),
+		},
+		"rsa 2048": {
+			key: genRSA(2048),
+		},
+		"ecdsa p224": {
+			key:    genECDSA(elliptic.P224()),
+			expErr: errors.New("P-224 is not approved"),
+		},
+		"ecdsa p384": {
+			key: genECDSA(elliptic.P384()),
+		},
+		"ed25519": {
+			key:    edKey,
+			expErr: errors.New("ed25519.PublicKey keys are not approved"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, checkFIPSPublicKey(tc.key))
+		})
+	}
+}
+
+func TestSecurity_PreLoadCertData_FIPS(t *testing.T) {
+	for name, tc := range map[string]struct {
+		getCfg func(t *testing.T) *TransportConfig
+		expErr error
+	}{
+		"compliant certs": {
+			getCfg: func(t *testing.T) *TransportConfig {
+				cfg := ServerTC()
+				setValidVerifyTime(t, cfg)
+				SetupTCFilePerms(t, cfg)
+				return cfg
+			},
+		},
+		"small keys": {
+			getCfg: func(t *testing.T) *TransportConfig {
+				tmpDir, cleanup := test.CreateTestDir(t)
+				t.Cleanup(cleanup)
+				return writeTestCertChain(t, tmpDir, 1024)
+			},
+			expErr: errors.New("not FIPS compliant"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resetFIPSMode(t)
+
+			cfg := tc.getCfg(t)
+			if err := cfg.PreLoadCertData(); err != nil {
+				t.Fatalf("unexpected error without FIPS mode: %s", err)
+			}
+
+			cfg.FIPSMode = true
+			test.CmpErr(t, tc.expErr, cfg.ReloadCertData())
+		})
+	}
+}
+
+func TestSecurity_applyFIPSTLSConfig(t *testing.T) {
+	resetFIPSMode(t)
+
+	defSuites := []uint16{tls.TLS_RSA_WITH_AES_256_GCM_SHA384}
+
+	cfg := applyFIPSTLSConfig(&TransportConfig{}, &tls.Config{CipherSuites: defSuites})
+	if diff := cmp.Diff(defSuites, cfg.CipherSuites); diff != "" {
+		t.Fatalf("unexpected ciphersuites (-want, +got):\n%s\n", diff)
+	}
+	if cfg.VerifyPeerCertificate != nil {
+		t.Fatal("unexpected peer certificate verifier")
+	}
+
+	cfg = applyFIPSTLSConfig(&TransportConfig{FIPSMode: true}, &tls.Config{CipherSuites: defSuites})
+	if diff := cmp.Diff(fipsCipherSuites, cfg.CipherSuites); diff != "" {
+		t.Fatalf("unexpected ciphersuites (-want, +got):\n%s\n", diff)
+	}
+	if diff := cmp.Diff(fipsCurves, cfg.CurvePreferences); diff != "" {
+		t.Fatalf("unexpected curves (-want, +got):\n%s\n", diff)
+	}
+	if cfg.VerifyPeerCertificate == nil {
+		t.Fatal("expected peer certificate verifier")
+	}
+}
+
+func TestSecurity_verifyFIPSPeerCertificates(t *testing.T) {
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	rawCert := func(path string) []byte {
+		return getCert(t, path).Raw
+	}
+
+	weak := writeTestCertChain(t, tmpDir, 1024)
+	err := verifyFIPSPeerCertificates([][]byte{rawCert(weak.CertificatePath)}, nil)
+	test.CmpErr(t, errors.New("smaller than"), err)
+
+	err = verifyFIPSPeerCertificates([][]byte{rawCert("testdata/certs/server.crt")}, nil)
+	test.CmpErr(t, nil, err)
+
+	err = verifyFIPSPeerCertificates(nil, [][]*x509.Certificate{
+		{getCert(t, "testdata/certs/server.crt"), getCert(t, weak.CARootPath)},
+	})
+	test.CmpErr(t, errors.New("Test CA"), err)
+}
+
+func TestSecurity_TokenSigner_FIPS(t *testing.T) {
+	resetFIPSMode(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("token")
+
+	sig, err := DefaultTokenSigner().Sign(key, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	EnableFIPSMode()
+
+	_, err = DefaultTokenSigner().Sign(key, data)
+	test.CmpErr(t, errors.New("FIPS mode"), err)
+
+	err = DefaultTokenSigner().Verify(&key.PublicKey, data, sig)
+	test.CmpErr(t, errors.New("FIPS mode"), err)
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -39,6 +39,12 @@ var methodAuthorizations = map[string][]Component{
 	"/ctl.CtlSvc/SmdQuery":                   {ComponentAdmin},
 	"/ctl.CtlSvc/SmdManage":                  {ComponentAdmin},
 	"/ctl.CtlSvc/SetEngineLogMasks":          {ComponentAdmin},
+	"/ctl.CtlSvc/SetControlLogLevels":        {ComponentAdmin},
+	"/ctl.CtlSvc/SetProfiling":               {ComponentAdmin},
+	"/ctl.CtlSvc/EngineUsageQuery":           {ComponentAdmin},
+	"/ctl.CtlSvc/GetEngineTunables":          {ComponentAdmin},
+	"/ctl.CtlSvc/SwimViewQuery":              {ComponentAdmin},
+	"/ctl.CtlSvc/ApplyServerConfig":          {ComponentAdmin},
 	"/ctl.CtlSvc/PrepShutdownRanks":          {ComponentServer},
 	"/ctl.CtlSvc/StopRanks":                  {ComponentServer},
 	"/ctl.CtlSvc/ResetFormatRanks":           {ComponentServer},
@@ -48,9 +54,11 @@ var methodAuthorizations = map[string][]Component{
 	"/mgmt.MgmtSvc/LeaderQuery":              {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemQuery":              {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemErase":              {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemArm":                {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemStart":              {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemStop":               {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemExclude":            {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemQuarantine":         {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolCreate":               {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolDestroy":              {ComponentAdmin},
 	"/mgmt.MgmtSvc/PoolQuery":                {ComponentAdmin},
@@ -87,11 +95,27 @@ var methodAuthorizations = map[string][]Component{
 	"/mgmt.MgmtSvc/SystemGetAttr":            {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemSetProp":            {ComponentAdmin},
 	"/mgmt.MgmtSvc/SystemGetProp":            {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemHistory":            {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemEvents":             {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemEventAck":           {ComponentAdmin},
+	"/mgmt.MgmtSvc/JobStatsQuery":            {ComponentAdmin},
+	"/mgmt.MgmtSvc/PortProbe":                {ComponentServer},
+	"/mgmt.MgmtSvc/PoolActivity":             {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemDBHash":             {ComponentServer},
+	"/mgmt.MgmtSvc/SystemDBVerify":           {ComponentAdmin},
+	"/mgmt.MgmtSvc/PoolOpJobs":               {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemTokenCreate":        {ComponentAdmin},
+	"/mgmt.MgmtSvc/SystemRankUUIDs":          {ComponentServer},
 	"/RaftTransport/AppendEntries":           {ComponentServer},
 	"/RaftTransport/AppendEntriesPipeline":   {ComponentServer},
 	"/RaftTransport/RequestVote":             {ComponentServer},
 	"/RaftTransport/TimeoutNow":              {ComponentServer},
 	"/RaftTransport/InstallSnapshot":         {ComponentServer},
+
+	// Standard gRPC health checking and reflection services.
+	"/grpc.health.v1.Health/Check":                                   {ComponentAdmin},
+	"/grpc.health.v1.Health/Watch":                                   {ComponentAdmin},
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": {ComponentAdmin},
 }
 
 func methodToComponent(method string, methodAuthorizations map[string][]Component) (build.Component, error) {
@@ -29,7 +29,7 @@ import (
 // validate the certificate chain.
 
 func serverTLSConfig(cfg *TransportConfig) *tls.Config {
-	return &tls.Config{
+	return applyFIPSTLSConfig(cfg, &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
 		ClientCAs:                cfg.caPool,
@@ -51,13 +51,13 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 			_, err := cs.PeerCertificates[0].Verify(opts)
 			return err
 		},
-	}
+	})
 }
 
 const ServerCommonName = "server"
 
 func clientTLSConfig(cfg *TransportConfig) *tls.Config {
-	return &tls.Config{
+	return applyFIPSTLSConfig(cfg, &tls.Config{
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
 		RootCAs:                  cfg.caPool,
 		MinVersion:               tls.VersionTLS12,
@@ -95,5 +95,5 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 			}
 			return nil
 		},
-	}
+	})
 }
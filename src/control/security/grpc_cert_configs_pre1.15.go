@@ -12,7 +12,7 @@ package security
 import "crypto/tls"
 
 func serverTLSConfig(cfg *TransportConfig) *tls.Config {
-	return &tls.Config{
+	return applyFIPSTLSConfig(cfg, &tls.Config{
 		ClientAuth:               tls.RequireAndVerifyClientCert,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
 		ClientCAs:                cfg.caPool,
@@ -22,11 +22,11 @@ func serverTLSConfig(cfg *TransportConfig) *tls.Config {
 		CipherSuites: []uint16{
 			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
 		},
-	}
+	})
 }
 
 func clientTLSConfig(cfg *TransportConfig) *tls.Config {
-	return &tls.Config{
+	return applyFIPSTLSConfig(cfg, &tls.Config{
 		ServerName:               cfg.ServerName,
 		Certificates:             []tls.Certificate{*cfg.tlsKeypair},
 		RootCAs:                  cfg.caPool,
@@ -36,5 +36,5 @@ func clientTLSConfig(cfg *TransportConfig) *tls.Config {
 		CipherSuites: []uint16{
 			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
 		},
-	}
+	})
 }
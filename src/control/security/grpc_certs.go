@@ -50,6 +50,10 @@ func ServerOptionForTransportConfig(cfg *TransportConfig) (grpc.ServerOption, er
 		return nil, errors.New("nil TransportConfig")
 	}
 
+	if err := cfg.ValidateFIPS(); err != nil {
+		return nil, err
+	}
+
 	if cfg.AllowInsecure {
 		return grpc.Creds(nil), nil
 	}
@@ -67,6 +71,10 @@ func DialOptionForTransportConfig(cfg *TransportConfig) (grpc.DialOption, error)
 		return nil, errors.New("nil TransportConfig")
 	}
 
+	if err := cfg.ValidateFIPS(); err != nil {
+		return nil, err
+	}
+
 	if cfg.AllowInsecure {
 		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
 	}
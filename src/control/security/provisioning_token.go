@@ -0,0 +1,164 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProvisioningKeyLen is the length in bytes of the key used to sign
+	// provisioning tokens.
+	ProvisioningKeyLen = 32
+
+	provisioningTokenPrefix = "daospt1"
+	provisioningTokenIDLen  = 8
+)
+
+// ProvisioningToken permits a server to join a DAOS system for the first time.
+// Tokens are signed with a key held by the management service and expire after
+// a fixed lifetime.
+type ProvisioningToken struct {
+	ID      string
+	System  string
+	Issued  time.Time
+	Expires time.Time
+}
+
+// provisioningTokenPayload is the signed content of an encoded token.
+type provisioningTokenPayload struct {
+	ID      string `json:"id"`
+	System  string `json:"sys"`
+	Issued  int64  `json:"iat"`
+	Expires int64  `json:"exp"`
+}
+
+// NewProvisioningKey generates a random key for signing provisioning tokens.
+func NewProvisioningKey() ([]byte, error) {
+	key := make([]byte, ProvisioningKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "generating provisioning key")
+	}
+	return key, nil
+}
+
+// NewProvisioningToken returns a token for the system that is valid for the
+// given lifetime, starting at the supplied time.
+func NewProvisioningToken(system string, issued time.Time, lifetime time.Duration) (*ProvisioningToken, error) {
+	if system == "" {
+		return nil, errors.New("system name is required")
+	}
+	if lifetime <= 0 {
+		return nil, errors.New("token lifetime must be greater than zero")
+	}
+
+	id := make([]byte, provisioningTokenIDLen)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "generating token ID")
+	}
+
+	issued = issued.Truncate(time.Second)
+	return &ProvisioningToken{
+		ID:      hex.EncodeToString(id),
+		System:  system,
+		Issued:  issued,
+		Expires: issued.Add(lifetime).Truncate(time.Second),
+	}, nil
+}
+
+func provisioningMAC(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(provisioningTokenPrefix + "." + payload))
+	return mac.Sum(nil)
+}
+
+// Sign encodes the token and signs it with the supplied key.
+func (t *ProvisioningToken) Sign(key []byte) (string, error) {
+	if t == nil {
+		return "", errors.New("nil ProvisioningToken")
+	}
+	if len(key) == 0 {
+		return "", errors.New("provisioning key is empty")
+	}
+
+	data, err := json.Marshal(&provisioningTokenPayload{
+		ID:      t.ID,
+		System:  t.System,
+		Issued:  t.Issued.Unix(),
+		Expires: t.Expires.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	payload := enc.EncodeToString(data)
+	return strings.Join([]string{
+		provisioningTokenPrefix,
+		payload,
+		enc.EncodeToString(provisioningMAC(key, payload)),
+	}, "."), nil
+}
+
+// ParseProvisioningToken verifies the signature of an encoded token with the
+// supplied key and returns the decoded token. The token's system and expiry
+// are checked separately with Validate.
+func ParseProvisioningToken(key []byte, encoded string) (*ProvisioningToken, error) {
+	parts := strings.Split(strings.TrimSpace(encoded), ".")
+	if len(parts) != 3 || parts[0] != provisioningTokenPrefix {
+		return nil, errors.New("malformed token")
+	}
+
+	enc := base64.RawURLEncoding
+	sig, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+	if !hmac.Equal(sig, provisioningMAC(key, parts[1])) {
+		return nil, errors.New("token signature does not match")
+	}
+
+	data, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token payload")
+	}
+	var payload provisioningTokenPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, errors.Wrap(err, "malformed token payload")
+	}
+
+	return &ProvisioningToken{
+		ID:      payload.ID,
+		System:  payload.System,
+		Issued:  time.Unix(payload.Issued, 0),
+		Expires: time.Unix(payload.Expires, 0),
+	}, nil
+}
+
+// Validate checks that the token was issued for the named system and has not
+// expired at the supplied time.
+func (t *ProvisioningToken) Validate(system string, now time.Time) error {
+	if t == nil {
+		return errors.New("nil ProvisioningToken")
+	}
+	if t.System != system {
+		return errors.Errorf("token %s was issued for system %q", t.ID, t.System)
+	}
+	if !now.Before(t.Expires) {
+		return errors.Errorf("token %s expired at %s", t.ID, t.Expires.Format(time.RFC3339))
+	}
+	return nil
+}
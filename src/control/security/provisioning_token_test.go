@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestSecurity_NewProvisioningToken(t *testing.T) {
+	issued := time.Date(2024, 6, 1, 12, 0, 0, 500, time.UTC)
+
+	for name, tc := range map[string]struct {
+		system     string
+		lifetime   time.Duration
+		expExpires time.Time
+		expErr     error
+	}{
+		"no system": {
+			lifetime: time.Hour,
+			expErr:   errors.New("system name"),
+		},
+		"zero lifetime": {
+			system: "daos_server",
+			expErr: errors.New("greater than zero"),
+		},
+		"success": {
+			system:     "daos_server",
+			lifetime:   24 * time.Hour,
+			expExpires: time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tok, err := NewProvisioningToken(tc.system, issued, tc.lifetime)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, provisioningTokenIDLen*2, len(tok.ID), "unexpected ID length")
+			test.AssertEqual(t, tc.system, tok.System, "")
+			test.AssertTrue(t, tok.Issued.Equal(issued.Truncate(time.Second)), "unexpected issue time")
+			test.AssertTrue(t, tok.Expires.Equal(tc.expExpires), "unexpected expiry time")
+		})
+	}
+}
+
+func TestSecurity_ProvisioningToken_SignParse(t *testing.T) {
+	key, err := NewProvisioningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := NewProvisioningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := NewProvisioningToken("daos_server", time.Unix(1717243200, 0), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := tok.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(encoded, ".")
+	forged, err := (&ProvisioningToken{
+		ID:      tok.ID,
+		System:  tok.System,
+		Issued:  tok.Issued,
+		Expires: tok.Expires.Add(24 * time.Hour),
+	}).Sign(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedParts := strings.Split(forged, ".")
+
+	for name, tc := range map[string]struct {
+		key      []byte
+		encoded  string
+		expToken *ProvisioningToken
+		expErr   error
+	}{
+		"empty": {
+			key:    key,
+			expErr: errors.New("malformed"),
+		},
+		"wrong prefix": {
+			key:     key,
+			encoded: "daospt0." + parts[1] + "." + parts[2],
+			expErr:  errors.New("malformed"),
+		},
+		"bad signature encoding": {
+			key:     key,
+			encoded: parts[0] + "." + parts[1] + ".!!",
+			expErr:  errors.New("malformed token signature"),
+		},
+		"wrong key": {
+			key:     otherKey,
+			encoded: encoded,
+			expErr:  errors.New("signature does not match"),
+		},
+		"modified payload": {
+			key:     key,
+			encoded: parts[0] + "." + forgedParts[1] + "." + parts[2],
+			expErr:  errors.New("signature does not match"),
+		},
+		"success": {
+			key:      key,
+			encoded:  encoded,
+			expToken: tok,
+		},
+		"surrounding whitespace": {
+			key:      key,
+			encoded:  encoded + "\n",
+			expToken: tok,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotToken, gotErr := ParseProvisioningToken(tc.key, tc.encoded)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expToken, gotToken); diff != "" {
+				t.Fatalf("unexpected token (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSecurity_ProvisioningToken_Validate(t *testing.T) {
+	tok := &ProvisioningToken{
+		ID:      "0123456789abcdef",
+		System:  "daos_server",
+		Issued:  time.Unix(1717243200, 0),
+		Expires: time.Unix(1717246800, 0),
+	}
+
+	for name, tc := range map[string]struct {
+		tok    *ProvisioningToken
+		system string
+		now    time.Time
+		expErr error
+	}{
+		"nil": {
+			expErr: errors.New("nil"),
+		},
+		"wrong system": {
+			tok:    tok,
+			system: "other",
+			now:    tok.Issued,
+			expErr: errors.New(`issued for system "daos_server"`),
+		},
+		"expired": {
+			tok:    tok,
+			system: "daos_server",
+			now:    tok.Expires,
+			expErr: errors.New("expired"),
+		},
+		"valid": {
+			tok:    tok,
+			system: "daos_server",
+			now:    tok.Expires.Add(-time.Second),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.tok.Validate(tc.system, tc.now))
+		})
+	}
+}
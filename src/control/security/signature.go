@@ -61,6 +61,11 @@ func (s *TokenSigner) Sign(key crypto.PrivateKey, data []byte) ([]byte, error) {
 	switch signingKey := key.(type) {
 	// TODO: Support key types other than RSA
 	case *rsa.PrivateKey:
+		if FIPSModeEnabled() {
+			if err := checkFIPSPublicKey(&signingKey.PublicKey); err != nil {
+				return nil, errors.Wrap(err, "FIPS mode")
+			}
+		}
 		return rsa.SignPSS(s.randPool, signingKey, crypto.SHA512, digest, nil)
 	default:
 		return nil, &UnsupportedKeyError{}
@@ -78,6 +83,11 @@ func (s *TokenSigner) Verify(key crypto.PublicKey, data []byte, sig []byte) erro
 	switch signingKey := key.(type) {
 	// TODO: Support key types other than RSA
 	case *rsa.PublicKey:
+		if FIPSModeEnabled() {
+			if err := checkFIPSPublicKey(signingKey); err != nil {
+				return errors.Wrap(err, "FIPS mode")
+			}
+		}
 		return rsa.VerifyPSS(signingKey, crypto.SHA512, digest, sig, nil)
 	default:
 		return &UnsupportedKeyError{}
@@ -0,0 +1,404 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	// bdevIOStatsInterval is the period at which device I/O stats are
+	// sampled for telemetry.
+	bdevIOStatsInterval = 15 * time.Second
+	// defaultIOStatsPeriod is the period over which I/O rates are sampled
+	// for a query if none is specified.
+	defaultIOStatsPeriod = time.Second
+	// maxIOStatsPeriod limits the period over which I/O rates are sampled
+	// for a query.
+	maxIOStatsPeriod = time.Minute
+
+	sysfsBlockRoot   = "/sys/block"
+	sysfsSectorBytes = 512
+)
+
+var errBdevIOStatsUnsupported = errors.New("engine does not support device I/O stats")
+
+// deriveBdevIOStats calculates I/O rates from two samples of a device's
+// cumulative I/O counters. Latencies and queue depth are taken from the later
+// sample.
+func deriveBdevIOStats(prev, cur *ctl.BioIOStatsResp) *ctl.DeviceIOStats {
+	stats := &ctl.DeviceIOStats{
+		QueueDepth:   cur.QueueDepth,
+		ReadLatP50:   cur.ReadLatP50,
+		ReadLatP99:   cur.ReadLatP99,
+		ReadLatP999:  cur.ReadLatP999,
+		WriteLatP50:  cur.WriteLatP50,
+		WriteLatP99:  cur.WriteLatP99,
+		WriteLatP999: cur.WriteLatP999,
+	}
+	if prev == nil || cur.Timestamp <= prev.Timestamp {
+		return stats
+	}
+
+	elapsed := time.Duration(cur.Timestamp - prev.Timestamp)
+	stats.PeriodMs = uint64(elapsed.Milliseconds())
+
+	rate := func(p, c uint64) float64 {
+		if c < p {
+			return 0 // counters reset
+		}
+		return float64(c-p) / elapsed.Seconds()
+	}
+	stats.ReadIops = rate(prev.ReadOps, cur.ReadOps)
+	stats.WriteIops = rate(prev.WriteOps, cur.WriteOps)
+	stats.ReadBw = rate(prev.ReadBytes, cur.ReadBytes)
+	stats.WriteBw = rate(prev.WriteBytes, cur.WriteBytes)
+
+	return stats
+}
+
+// sampleBdevIOStats fetches the I/O counters of each device that is able to
+// supply them, with a single batch of dRPC calls to the engine.
+func sampleBdevIOStats(ctx context.Context, engine Engine, devs []*ctl.SmdDevice) (map[string]*ctl.BioIOStatsResp, error) {
+	samples := make(map[string]*ctl.BioIOStatsResp)
+
+	var reqs []*ctl.BioIOStatsReq
+	for _, dev := range devs {
+		if dev.Ctrlr != nil && !dev.Ctrlr.CanSupplyHealthStats() {
+			continue
+		}
+		reqs = append(reqs, &ctl.BioIOStatsReq{DevUuid: dev.Uuid})
+	}
+	if len(reqs) == 0 {
+		return samples, nil
+	}
+
+	resps, err := getBioIOStats(ctx, engine, reqs...)
+	if isUnknownMethod(err) {
+		return nil, errBdevIOStatsUnsupported
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve device I/O stats")
+	}
+	for i, resp := range resps {
+		samples[reqs[i].DevUuid] = resp
+	}
+
+	return samples, nil
+}
+
+// populateBdevIOStats samples the I/O counters of the devices twice, period
+// apart, and attaches the derived I/O stats to each device.
+func populateBdevIOStats(ctx context.Context, engine Engine, devs []*ctl.SmdDevice, period time.Duration) error {
+	if len(devs) == 0 {
+		return nil
+	}
+	if period <= 0 {
+		period = defaultIOStatsPeriod
+	}
+	if period > maxIOStatsPeriod {
+		return errors.Errorf("I/O stats period %s exceeds maximum of %s", period,
+			maxIOStatsPeriod)
+	}
+
+	first, err := sampleBdevIOStats(ctx, engine, devs)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(period):
+	}
+
+	second, err := sampleBdevIOStats(ctx, engine, devs)
+	if err != nil {
+		return err
+	}
+
+	for _, dev := range devs {
+		cur, found := second[dev.Uuid]
+		if !found {
+			continue
+		}
+		dev.IoStats = deriveBdevIOStats(first[dev.Uuid], cur)
+	}
+
+	return nil
+}
+
+// readSysfsBlockStat reads the I/O counters of a kernel block device from
+// sysfs. The counters are returned in the same form as those supplied by the
+// engine so that the same derivation can be used. Latency percentiles are
+// not available from sysfs.
+func readSysfsBlockStat(root, devPath string, now time.Time) (*ctl.BioIOStatsResp, error) {
+	name := filepath.Base(devPath)
+	data, err := os.ReadFile(filepath.Join(root, name, "stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	// See Documentation/block/stat.rst in the kernel tree for field
+	// descriptions.
+	fields := strings.Fields(string(data))
+	if len(fields) < 11 {
+		return nil, errors.Errorf("unexpected format of %s stat: %q", name, string(data))
+	}
+	vals := make([]uint64, len(fields))
+	for i, f := range fields {
+		if vals[i], err = strconv.ParseUint(f, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "parse %s stat field %d", name, i)
+		}
+	}
+
+	resp := &ctl.BioIOStatsResp{
+		DevUuid:    devPath,
+		Timestamp:  uint64(now.UnixNano()),
+		ReadOps:    vals[0],
+		ReadBytes:  vals[2] * sysfsSectorBytes,
+		WriteOps:   vals[4],
+		WriteBytes: vals[6] * sysfsSectorBytes,
+		QueueDepth: uint32(vals[8]),
+	}
+	if len(vals) >= 12 {
+		resp.UnmapOps = vals[11]
+	}
+
+	return resp, nil
+}
+
+// kdevPaths returns the kernel block devices configured for an engine's bdev
+// tiers.
+func kdevPaths(engine Engine) []string {
+	sp := engine.GetStorage()
+	if sp == nil {
+		return nil
+	}
+
+	var paths []string
+	for _, tc := range sp.GetBdevConfigs() {
+		if tc.Class != storage.ClassKdev || tc.Bdev.DeviceList == nil {
+			continue
+		}
+		paths = append(paths, tc.Bdev.DeviceList.Devices()...)
+	}
+
+	return paths
+}
+
+// bdevIOStatsCollector periodically samples the I/O counters of engine bdevs,
+// from the engine for SPDK-managed devices and from sysfs for kernel block
+// devices, and exports the derived I/O rates and latencies as prometheus
+// metrics.
+type bdevIOStatsCollector struct {
+	sync.RWMutex
+	log         logging.Logger
+	engines     []Engine
+	sysfsRoot   string
+	now         func() time.Time
+	samples     map[uint32]map[string]*ctl.BioIOStatsResp
+	stats       map[uint32]map[string]*ctl.DeviceIOStats
+	unsupported map[uint32]bool
+
+	readIOPS   *prometheus.Desc
+	writeIOPS  *prometheus.Desc
+	readBW     *prometheus.Desc
+	writeBW    *prometheus.Desc
+	queueDepth *prometheus.Desc
+	readLat    *prometheus.Desc
+	writeLat   *prometheus.Desc
+}
+
+func newBdevIOStatsCollector(log logging.Logger, engines []Engine) *bdevIOStatsCollector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("engine", "nvme_io", name), help,
+			append([]string{"engine", "device"}, labels...), nil)
+	}
+
+	return &bdevIOStatsCollector{
+		log:         log,
+		engines:     engines,
+		sysfsRoot:   sysfsBlockRoot,
+		now:         time.Now,
+		samples:     make(map[uint32]map[string]*ctl.BioIOStatsResp),
+		stats:       make(map[uint32]map[string]*ctl.DeviceIOStats),
+		unsupported: make(map[uint32]bool),
+		readIOPS:    desc("read_iops", "Device read operations per second"),
+		writeIOPS:   desc("write_iops", "Device write operations per second"),
+		readBW:      desc("read_bytes_per_second", "Device bytes read per second"),
+		writeBW:     desc("write_bytes_per_second", "Device bytes written per second"),
+		queueDepth:  desc("queue_depth", "Device I/O operations in flight"),
+		readLat:     desc("read_latency_us", "Device read latency percentiles in microseconds", "quantile"),
+		writeLat:    desc("write_latency_us", "Device write latency percentiles in microseconds", "quantile"),
+	}
+}
+
+// startBdevIOStatsCollector starts sampling device I/O stats if telemetry is
+// enabled.
+func startBdevIOStatsCollector(ctx context.Context, srv *server) {
+	if srv.cfg.TelemetryPort == 0 {
+		return
+	}
+
+	bsc := newBdevIOStatsCollector(srv.log, srv.harness.Instances())
+	prometheus.MustRegister(bsc)
+
+	go bsc.run(ctx)
+}
+
+// run samples device I/O stats until the context is canceled.
+func (bsc *bdevIOStatsCollector) run(ctx context.Context) {
+	ticker := time.NewTicker(bdevIOStatsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			bsc.sample(ctx)
+		}
+	}
+}
+
+func (bsc *bdevIOStatsCollector) sample(ctx context.Context) {
+	for _, e := range bsc.engines {
+		if !e.IsStarted() || !e.IsReady() {
+			continue
+		}
+
+		samples, err := bsc.sampleEngine(ctx, e)
+		if err != nil {
+			bsc.log.Errorf("engine %d: sampling device I/O stats: %s", e.Index(), err)
+			continue
+		}
+		bsc.update(e.Index(), samples)
+	}
+}
+
+func (bsc *bdevIOStatsCollector) sampleEngine(ctx context.Context, e Engine) (map[string]*ctl.BioIOStatsResp, error) {
+	samples := make(map[string]*ctl.BioIOStatsResp)
+
+	bsc.RLock()
+	unsupported := bsc.unsupported[e.Index()]
+	bsc.RUnlock()
+
+	if !unsupported {
+		resp, err := listSmdDevices(ctx, e, new(ctl.SmdDevReq))
+		if err != nil {
+			return nil, err
+		}
+		engSamples, err := sampleBdevIOStats(ctx, e, resp.Devices)
+		switch {
+		case err == errBdevIOStatsUnsupported:
+			bsc.log.Noticef("engine %d does not support device I/O stats, only kernel block devices will be sampled",
+				e.Index())
+			bsc.Lock()
+			bsc.unsupported[e.Index()] = true
+			bsc.Unlock()
+		case err != nil:
+			return nil, err
+		default:
+			samples = engSamples
+		}
+	}
+
+	now := bsc.now()
+	for _, path := range kdevPaths(e) {
+		resp, err := readSysfsBlockStat(bsc.sysfsRoot, path, now)
+		if err != nil {
+			bsc.log.Debugf("engine %d: reading sysfs stats of %s: %s", e.Index(), path, err)
+			continue
+		}
+		samples[path] = resp
+	}
+
+	return samples, nil
+}
+
+func (bsc *bdevIOStatsCollector) update(idx uint32, samples map[string]*ctl.BioIOStatsResp) {
+	bsc.Lock()
+	defer bsc.Unlock()
+
+	prev := bsc.samples[idx]
+	stats := make(map[string]*ctl.DeviceIOStats)
+	for dev, cur := range samples {
+		if p, found := prev[dev]; found {
+			stats[dev] = deriveBdevIOStats(p, cur)
+		}
+	}
+	bsc.samples[idx] = samples
+	bsc.stats[idx] = stats
+}
+
+func (bsc *bdevIOStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{bsc.readIOPS, bsc.writeIOPS, bsc.readBW, bsc.writeBW,
+		bsc.queueDepth, bsc.readLat, bsc.writeLat} {
+		ch <- d
+	}
+}
+
+func (bsc *bdevIOStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	bsc.RLock()
+	defer bsc.RUnlock()
+
+	idxs := make([]int, 0, len(bsc.stats))
+	for idx := range bsc.stats {
+		idxs = append(idxs, int(idx))
+	}
+	sort.Ints(idxs)
+
+	gauge := func(d *prometheus.Desc, val float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, val, labels...)
+	}
+
+	for _, idx := range idxs {
+		engStats := bsc.stats[uint32(idx)]
+		engIdx := fmt.Sprintf("%d", idx)
+
+		devs := make([]string, 0, len(engStats))
+		for dev := range engStats {
+			devs = append(devs, dev)
+		}
+		sort.Strings(devs)
+
+		for _, dev := range devs {
+			s := engStats[dev]
+			gauge(bsc.readIOPS, s.ReadIops, engIdx, dev)
+			gauge(bsc.writeIOPS, s.WriteIops, engIdx, dev)
+			gauge(bsc.readBW, s.ReadBw, engIdx, dev)
+			gauge(bsc.writeBW, s.WriteBw, engIdx, dev)
+			gauge(bsc.queueDepth, float64(s.QueueDepth), engIdx, dev)
+
+			if s.ReadLatP50 == 0 && s.ReadLatP99 == 0 && s.WriteLatP50 == 0 && s.WriteLatP99 == 0 {
+				continue // no latencies from sysfs
+			}
+			for q, v := range map[string]uint64{"0.5": s.ReadLatP50, "0.99": s.ReadLatP99, "0.999": s.ReadLatP999} {
+				gauge(bsc.readLat, float64(v), engIdx, dev, q)
+			}
+			for q, v := range map[string]uint64{"0.5": s.WriteLatP50, "0.99": s.WriteLatP99, "0.999": s.WriteLatP999} {
+				gauge(bsc.writeLat, float64(v), engIdx, dev, q)
+			}
+		}
+	}
+}
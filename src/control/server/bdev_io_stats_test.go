@@ -0,0 +1,331 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestServer_deriveBdevIOStats(t *testing.T) {
+	start := time.Date(2024, time.January, 6, 2, 0, 0, 0, time.UTC)
+	sample := func(offset time.Duration, readOps, writeOps, readBytes, writeBytes uint64) *ctl.BioIOStatsResp {
+		return &ctl.BioIOStatsResp{
+			Timestamp:    uint64(start.Add(offset).UnixNano()),
+			ReadOps:      readOps,
+			WriteOps:     writeOps,
+			ReadBytes:    readBytes,
+			WriteBytes:   writeBytes,
+			QueueDepth:   8,
+			ReadLatP50:   80,
+			ReadLatP99:   200,
+			ReadLatP999:  900,
+			WriteLatP50:  20,
+			WriteLatP99:  50,
+			WriteLatP999: 300,
+		}
+	}
+	lats := func(stats *ctl.DeviceIOStats) *ctl.DeviceIOStats {
+		stats.QueueDepth = 8
+		stats.ReadLatP50 = 80
+		stats.ReadLatP99 = 200
+		stats.ReadLatP999 = 900
+		stats.WriteLatP50 = 20
+		stats.WriteLatP99 = 50
+		stats.WriteLatP999 = 300
+		return stats
+	}
+
+	for name, tc := range map[string]struct {
+		prev     *ctl.BioIOStatsResp
+		cur      *ctl.BioIOStatsResp
+		expStats *ctl.DeviceIOStats
+	}{
+		"no previous sample": {
+			cur:      sample(0, 100, 100, 4096, 4096),
+			expStats: lats(&ctl.DeviceIOStats{}),
+		},
+		"same timestamp": {
+			prev:     sample(0, 100, 100, 4096, 4096),
+			cur:      sample(0, 200, 200, 8192, 8192),
+			expStats: lats(&ctl.DeviceIOStats{}),
+		},
+		"rates over two seconds": {
+			prev: sample(0, 100, 50, 1<<20, 1<<10),
+			cur:  sample(2*time.Second, 300, 150, 3<<20, 3<<10),
+			expStats: lats(&ctl.DeviceIOStats{
+				PeriodMs:  2000,
+				ReadIops:  100,
+				WriteIops: 50,
+				ReadBw:    1 << 20,
+				WriteBw:   1 << 10,
+			}),
+		},
+		"counters reset": {
+			prev: sample(0, 300, 150, 3<<20, 3<<10),
+			cur:  sample(time.Second, 100, 50, 1<<20, 1<<10),
+			expStats: lats(&ctl.DeviceIOStats{
+				PeriodMs: 1000,
+			}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotStats := deriveBdevIOStats(tc.prev, tc.cur)
+
+			if diff := cmp.Diff(tc.expStats, gotStats, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected stats (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_populateBdevIOStats(t *testing.T) {
+	statsResp := func(t *testing.T, status drpc.Status, resp *ctl.BioIOStatsResp) *drpc.Response {
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &drpc.Response{Status: status, Body: body}
+	}
+	normalDev := func(idx int32) *ctl.SmdDevice {
+		return &ctl.SmdDevice{
+			Uuid:  test.MockUUID(idx),
+			Ctrlr: &ctl.NvmeController{DevState: ctl.NvmeDevState_NORMAL},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		devs        []*ctl.SmdDevice
+		period      time.Duration
+		drpcStatus  drpc.Status
+		drpcResp    *ctl.BioIOStatsResp
+		drpcErr     error
+		expErr      error
+		expWithStat int
+	}{
+		"no devices": {
+			drpcErr: errors.New("unexpected call"),
+		},
+		"period too long": {
+			devs:   []*ctl.SmdDevice{normalDev(1)},
+			period: 2 * maxIOStatsPeriod,
+			expErr: errors.New("exceeds maximum"),
+		},
+		"method not supported": {
+			devs:    []*ctl.SmdDevice{normalDev(1)},
+			period:  time.Millisecond,
+			drpcErr: errors.Errorf("bad dRPC response status: %s", drpc.Status_UNKNOWN_METHOD),
+			expErr:  errBdevIOStatsUnsupported,
+		},
+		"method not supported in batch": {
+			devs:       []*ctl.SmdDevice{normalDev(1), normalDev(2)},
+			period:     time.Millisecond,
+			drpcStatus: drpc.Status_UNKNOWN_METHOD,
+			drpcResp:   &ctl.BioIOStatsResp{},
+			expErr:     errBdevIOStatsUnsupported,
+		},
+		"device query fails": {
+			devs:     []*ctl.SmdDevice{normalDev(1), normalDev(2)},
+			period:   time.Millisecond,
+			drpcResp: &ctl.BioIOStatsResp{Status: int32(daos.Nonexistent)},
+			expErr:   daos.Nonexistent,
+		},
+		"dRPC fails": {
+			devs:    []*ctl.SmdDevice{normalDev(1)},
+			period:  time.Millisecond,
+			drpcErr: errors.New("dRPC failed"),
+			expErr:  errors.New("dRPC failed"),
+		},
+		"new device skipped": {
+			devs: []*ctl.SmdDevice{
+				normalDev(1),
+				{
+					Uuid:  test.MockUUID(2),
+					Ctrlr: &ctl.NvmeController{DevState: ctl.NvmeDevState_NEW},
+				},
+			},
+			period:      time.Millisecond,
+			drpcResp:    &ctl.BioIOStatsResp{QueueDepth: 4},
+			expWithStat: 1,
+		},
+		"several devices": {
+			devs:        []*ctl.SmdDevice{normalDev(1), normalDev(2), normalDev(3)},
+			period:      time.Millisecond,
+			drpcResp:    &ctl.BioIOStatsResp{QueueDepth: 4},
+			expWithStat: 3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mic := &MockInstanceConfig{CallDrpcErr: tc.drpcErr}
+			if tc.drpcResp != nil {
+				mic.CallDrpcResp = statsResp(t, tc.drpcStatus, tc.drpcResp)
+			}
+
+			gotErr := populateBdevIOStats(test.Context(t), NewMockInstance(mic), tc.devs,
+				tc.period)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var withStat int
+			for _, dev := range tc.devs {
+				if dev.IoStats != nil {
+					test.AssertEqual(t, uint32(4), dev.IoStats.QueueDepth, "unexpected queue depth")
+					withStat++
+				}
+			}
+			test.AssertEqual(t, tc.expWithStat, withStat, "unexpected number of devices with stats")
+		})
+	}
+}
+
+func TestServer_readSysfsBlockStat(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		contents string
+		expResp  *ctl.BioIOStatsResp
+		expErr   error
+	}{
+		"missing": {
+			expErr: errors.New("no such file"),
+		},
+		"too few fields": {
+			contents: "1 2 3\n",
+			expErr:   errors.New("unexpected format"),
+		},
+		"bad field": {
+			contents: "1 2 3 4 5 6 7 8 9 10 eleven\n",
+			expErr:   errors.New("field 10"),
+		},
+		"without discard fields": {
+			contents: "    100 0 800 10 50 0 400 5 2 20 15\n",
+			expResp: &ctl.BioIOStatsResp{
+				DevUuid:    "/dev/sdb",
+				Timestamp:  uint64(now.UnixNano()),
+				ReadOps:    100,
+				ReadBytes:  800 * 512,
+				WriteOps:   50,
+				WriteBytes: 400 * 512,
+				QueueDepth: 2,
+			},
+		},
+		"with discard fields": {
+			contents: "100 0 800 10 50 0 400 5 2 20 15 7 0 56 3 0 0\n",
+			expResp: &ctl.BioIOStatsResp{
+				DevUuid:    "/dev/sdb",
+				Timestamp:  uint64(now.UnixNano()),
+				ReadOps:    100,
+				ReadBytes:  800 * 512,
+				WriteOps:   50,
+				WriteBytes: 400 * 512,
+				QueueDepth: 2,
+				UnmapOps:   7,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			if tc.contents != "" {
+				if err := os.MkdirAll(filepath.Join(root, "sdb"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(root, "sdb", "stat"), []byte(tc.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotResp, gotErr := readSysfsBlockStat(root, "/dev/sdb", now)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_bdevIOStatsCollector_sample(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "sdb"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeStat := func(contents string) {
+		if err := os.WriteFile(filepath.Join(root, "sdb", "stat"), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engCfg := &storage.Config{
+		Tiers: storage.TierConfigs{
+			storage.NewTierConfig().
+				WithStorageClass(storage.ClassKdev.String()).
+				WithBdevDeviceList("/dev/sdb"),
+		},
+	}
+	// Engine has no SPDK-managed devices.
+	body, err := proto.Marshal(&ctl.SmdDevResp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mic := &MockInstanceConfig{
+		CallDrpcResp:    &drpc.Response{Body: body},
+		StorageProvider: storage.MockProvider(log, 0, engCfg, nil, nil, nil, nil),
+	}
+	mic.Started.SetTrue()
+	mic.Ready.SetTrue()
+
+	bsc := newBdevIOStatsCollector(log, []Engine{NewMockInstance(mic)})
+	bsc.sysfsRoot = root
+	now := time.Now()
+	bsc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	writeStat("100 0 800 10 50 0 400 5 2 20 15\n")
+	bsc.sample(ctx)
+	test.AssertEqual(t, 0, len(bsc.stats[0]), "expected no stats after first sample")
+
+	now = now.Add(2 * time.Second)
+	writeStat("300 0 2800 10 150 0 1400 5 3 20 15\n")
+	bsc.sample(ctx)
+
+	expStats := map[string]*ctl.DeviceIOStats{
+		"/dev/sdb": {
+			PeriodMs:   2000,
+			ReadIops:   100,
+			WriteIops:  50,
+			ReadBw:     512000,
+			WriteBw:    256000,
+			QueueDepth: 3,
+		},
+	}
+	if diff := cmp.Diff(expStats, bsc.stats[0], protocmp.Transform()); diff != "" {
+		t.Fatalf("unexpected stats (-want, +got):\n%s\n", diff)
+	}
+}
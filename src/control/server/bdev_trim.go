@@ -0,0 +1,279 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// bdevTrimCheckInterval is the period at which the scheduler checks whether
+// a TRIM pass is due.
+const bdevTrimCheckInterval = time.Minute
+
+type bdevTrimDevice struct {
+	trimmedBlocks uint64
+	trimmedBytes  uint64
+}
+
+type bdevTrimEngine struct {
+	lastPass    time.Time
+	passes      uint64
+	unsupported bool
+	devices     map[string]*bdevTrimDevice
+}
+
+// bdevTrimScheduler periodically asks engines to issue TRIM/deallocate
+// commands for free capacity on their NVMe bdevs during the configured
+// maintenance windows, keeping count of the blocks reclaimed and exporting
+// them as prometheus metrics.
+type bdevTrimScheduler struct {
+	sync.RWMutex
+	log     logging.Logger
+	cfg     *config.BdevTrim
+	windows []*config.TrimWindow
+	engines []Engine
+	now     func() time.Time
+	state   map[uint32]*bdevTrimEngine
+
+	trimmedBlocks *prometheus.Desc
+	trimmedBytes  *prometheus.Desc
+	passes        *prometheus.Desc
+	lastPass      *prometheus.Desc
+}
+
+func newBdevTrimScheduler(log logging.Logger, cfg *config.BdevTrim, engines []Engine) (*bdevTrimScheduler, error) {
+	windows, err := cfg.ParseWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	devDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("engine", "nvme_trim", name), help,
+			[]string{"engine", "device"}, nil)
+	}
+	engDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("engine", "nvme_trim", name), help,
+			[]string{"engine"}, nil)
+	}
+
+	return &bdevTrimScheduler{
+		log:           log,
+		cfg:           cfg.WithDefaults(),
+		windows:       windows,
+		engines:       engines,
+		now:           time.Now,
+		state:         make(map[uint32]*bdevTrimEngine),
+		trimmedBlocks: devDesc("reclaimed_blocks_total", "NVMe blocks deallocated by scheduled TRIM passes"),
+		trimmedBytes:  devDesc("reclaimed_bytes_total", "NVMe bytes deallocated by scheduled TRIM passes"),
+		passes:        engDesc("passes_total", "Scheduled NVMe TRIM passes completed"),
+		lastPass:      engDesc("last_pass_timestamp_seconds", "Time of the last completed NVMe TRIM pass"),
+	}, nil
+}
+
+// startBdevTrimScheduler starts scheduling TRIM passes if windows have been
+// configured.
+func startBdevTrimScheduler(ctx context.Context, srv *server) {
+	if !srv.cfg.BdevTrim.Enabled() {
+		return
+	}
+
+	sched, err := newBdevTrimScheduler(srv.log, srv.cfg.BdevTrim, srv.harness.Instances())
+	if err != nil {
+		srv.log.Errorf("bdev trim scheduler: %s", err)
+		return
+	}
+	if srv.cfg.TelemetryPort != 0 {
+		prometheus.MustRegister(sched)
+	}
+
+	go sched.run(ctx)
+}
+
+// run checks whether TRIM passes are due until the context is canceled.
+func (bts *bdevTrimScheduler) run(ctx context.Context) {
+	bts.log.Debugf("bdev trim scheduled in windows %v at most every %s", bts.cfg.Windows,
+		bts.cfg.Interval)
+
+	ticker := time.NewTicker(bdevTrimCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			bts.check(ctx)
+		}
+	}
+}
+
+func (bts *bdevTrimScheduler) inWindow(t time.Time) bool {
+	for _, tw := range bts.windows {
+		if tw.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bts *bdevTrimScheduler) engineState(idx uint32) *bdevTrimEngine {
+	es, found := bts.state[idx]
+	if !found {
+		es = &bdevTrimEngine{devices: make(map[string]*bdevTrimDevice)}
+		bts.state[idx] = es
+	}
+	return es
+}
+
+// dueEngines returns the running engines with NVMe bdevs that support TRIM
+// passes and haven't completed one within the configured interval.
+func (bts *bdevTrimScheduler) dueEngines(now time.Time) []Engine {
+	bts.Lock()
+	defer bts.Unlock()
+
+	var due []Engine
+	for _, e := range bts.engines {
+		if !e.IsStarted() || !e.IsReady() {
+			continue
+		}
+		if sp := e.GetStorage(); sp == nil || !sp.HasBlockDevices() {
+			continue
+		}
+
+		es := bts.engineState(e.Index())
+		if es.unsupported || (!es.lastPass.IsZero() && now.Sub(es.lastPass) < bts.cfg.Interval) {
+			continue
+		}
+		due = append(due, e)
+	}
+
+	return due
+}
+
+// check runs a TRIM pass on each engine that is due one, if within a window.
+func (bts *bdevTrimScheduler) check(ctx context.Context) {
+	now := bts.now()
+	if !bts.inWindow(now) {
+		return
+	}
+
+	for _, e := range bts.dueEngines(now) {
+		if err := bts.trimEngine(ctx, e, now); err != nil {
+			bts.log.Errorf("engine %d: bdev trim pass failed: %s", e.Index(), err)
+		}
+	}
+}
+
+func isUnknownMethod(err error) bool {
+	return err != nil && strings.Contains(err.Error(), drpc.Status_UNKNOWN_METHOD.String())
+}
+
+// trimEngine requests a TRIM pass from the engine and records the result.
+// Engines which don't implement the method are not asked again.
+func (bts *bdevTrimScheduler) trimEngine(ctx context.Context, e Engine, now time.Time) error {
+	dresp, err := e.CallDrpc(ctx, drpc.MethodBioTrim, &ctl.BioTrimReq{MaxBlocks: bts.cfg.MaxBlocks})
+	if isUnknownMethod(err) {
+		bts.log.Noticef("engine %d does not support bdev trim, disabling scheduled passes",
+			e.Index())
+		bts.Lock()
+		bts.engineState(e.Index()).unsupported = true
+		bts.Unlock()
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "BioTrim dRPC call")
+	}
+
+	resp := new(ctl.BioTrimResp)
+	if err := proto.Unmarshal(dresp.Body, resp); err != nil {
+		return errors.Wrap(err, "unmarshal BioTrim response")
+	}
+	if resp.Status != 0 {
+		return errors.Wrap(daos.Status(resp.Status), "BioTrim response status")
+	}
+
+	bts.Lock()
+	defer bts.Unlock()
+
+	es := bts.engineState(e.Index())
+	es.lastPass = now
+	es.passes++
+
+	var total uint64
+	for _, dev := range resp.Devices {
+		ds, found := es.devices[dev.Uuid]
+		if !found {
+			ds = new(bdevTrimDevice)
+			es.devices[dev.Uuid] = ds
+		}
+		ds.trimmedBlocks += dev.TrimmedBlocks
+		ds.trimmedBytes += dev.TrimmedBlocks * uint64(dev.BlockSize)
+		total += dev.TrimmedBlocks
+	}
+	bts.log.Debugf("engine %d: bdev trim pass reclaimed %d blocks on %d device(s)", e.Index(),
+		total, len(resp.Devices))
+
+	return nil
+}
+
+func (bts *bdevTrimScheduler) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{bts.trimmedBlocks, bts.trimmedBytes, bts.passes, bts.lastPass} {
+		ch <- d
+	}
+}
+
+func (bts *bdevTrimScheduler) Collect(ch chan<- prometheus.Metric) {
+	bts.RLock()
+	defer bts.RUnlock()
+
+	idxs := make([]int, 0, len(bts.state))
+	for idx := range bts.state {
+		idxs = append(idxs, int(idx))
+	}
+	sort.Ints(idxs)
+
+	for _, idx := range idxs {
+		es := bts.state[uint32(idx)]
+		if es.passes == 0 {
+			continue
+		}
+
+		engIdx := fmt.Sprintf("%d", idx)
+		ch <- prometheus.MustNewConstMetric(bts.passes, prometheus.CounterValue,
+			float64(es.passes), engIdx)
+		ch <- prometheus.MustNewConstMetric(bts.lastPass, prometheus.GaugeValue,
+			float64(es.lastPass.Unix()), engIdx)
+
+		uuids := make([]string, 0, len(es.devices))
+		for uuid := range es.devices {
+			uuids = append(uuids, uuid)
+		}
+		sort.Strings(uuids)
+		for _, uuid := range uuids {
+			ds := es.devices[uuid]
+			ch <- prometheus.MustNewConstMetric(bts.trimmedBlocks, prometheus.CounterValue,
+				float64(ds.trimmedBlocks), engIdx, uuid)
+			ch <- prometheus.MustNewConstMetric(bts.trimmedBytes, prometheus.CounterValue,
+				float64(ds.trimmedBytes), engIdx, uuid)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestServer_bdevTrimScheduler_check(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	inWindow := time.Date(2024, time.January, 6, 2, 0, 0, 0, time.Local)
+	outsideWindow := time.Date(2024, time.January, 6, 12, 0, 0, 0, time.Local)
+
+	trimResp := func(t *testing.T, resp *ctl.BioTrimResp) *drpc.Response {
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &drpc.Response{Body: body}
+	}
+	devResp := &ctl.BioTrimResp{
+		Devices: []*ctl.BioTrimResp_Device{
+			{Uuid: test.MockUUID(1), TrimmedBlocks: 100, BlockSize: 4096},
+			{Uuid: test.MockUUID(2), TrimmedBlocks: 50, BlockSize: 512},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		now         time.Time
+		noBdevs     bool
+		notReady    bool
+		lastPass    time.Duration // before now
+		unsupported bool
+		drpcResp    *ctl.BioTrimResp
+		drpcErr     error
+		expPasses   uint64
+		expUnsupp   bool
+		expDevices  map[string]*bdevTrimDevice
+	}{
+		"outside window": {
+			now:      outsideWindow,
+			drpcResp: devResp,
+		},
+		"engine not ready": {
+			now:      inWindow,
+			notReady: true,
+			drpcResp: devResp,
+		},
+		"engine without bdevs": {
+			now:      inWindow,
+			noBdevs:  true,
+			drpcResp: devResp,
+		},
+		"pass within interval": {
+			now:      inWindow,
+			lastPass: time.Hour,
+			drpcResp: devResp,
+		},
+		"previously unsupported": {
+			now:         inWindow,
+			unsupported: true,
+			drpcResp:    devResp,
+			expUnsupp:   true,
+		},
+		"dRPC fails": {
+			now:     inWindow,
+			drpcErr: errors.New("dRPC failed"),
+		},
+		"engine returns error status": {
+			now:      inWindow,
+			drpcResp: &ctl.BioTrimResp{Status: int32(daos.IOError)},
+		},
+		"method not supported": {
+			now:       inWindow,
+			drpcErr:   errors.Errorf("bad dRPC response status: %s", drpc.Status_UNKNOWN_METHOD),
+			expUnsupp: true,
+		},
+		"pass after interval": {
+			now:       inWindow,
+			lastPass:  48 * time.Hour,
+			drpcResp:  devResp,
+			expPasses: 1,
+			expDevices: map[string]*bdevTrimDevice{
+				test.MockUUID(1): {trimmedBlocks: 100, trimmedBytes: 409600},
+				test.MockUUID(2): {trimmedBlocks: 50, trimmedBytes: 25600},
+			},
+		},
+		"first pass": {
+			now:       inWindow,
+			drpcResp:  devResp,
+			expPasses: 1,
+			expDevices: map[string]*bdevTrimDevice{
+				test.MockUUID(1): {trimmedBlocks: 100, trimmedBytes: 409600},
+				test.MockUUID(2): {trimmedBlocks: 50, trimmedBytes: 25600},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tier := storage.NewTierConfig().
+				WithStorageClass(storage.ClassNvme.String()).
+				WithBdevDeviceList(test.MockPCIAddr(1))
+			if tc.noBdevs {
+				tier = storage.NewTierConfig().
+					WithStorageClass(storage.ClassDcpm.String()).
+					WithScmDeviceList("/dev/pmem0")
+			}
+			engCfg := &storage.Config{Tiers: storage.TierConfigs{tier}}
+
+			mic := &MockInstanceConfig{
+				CallDrpcErr:     tc.drpcErr,
+				StorageProvider: storage.MockProvider(log, 0, engCfg, nil, nil, nil, nil),
+			}
+			if tc.drpcResp != nil {
+				mic.CallDrpcResp = trimResp(t, tc.drpcResp)
+			}
+			mic.Started.SetTrue()
+			if !tc.notReady {
+				mic.Ready.SetTrue()
+			}
+
+			bts, err := newBdevTrimScheduler(log, &config.BdevTrim{
+				Windows: []string{"Sat,Sun 01:00-05:00"},
+			}, []Engine{NewMockInstance(mic)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			bts.now = func() time.Time { return tc.now }
+
+			es := bts.engineState(0)
+			es.unsupported = tc.unsupported
+			var expLastPass time.Time
+			if tc.lastPass != 0 {
+				es.lastPass = tc.now.Add(-tc.lastPass)
+				expLastPass = es.lastPass
+			}
+			if tc.expPasses > 0 {
+				expLastPass = tc.now
+			}
+			if tc.expDevices == nil {
+				tc.expDevices = make(map[string]*bdevTrimDevice)
+			}
+
+			bts.check(context.Background())
+
+			test.AssertEqual(t, tc.expPasses, es.passes, "unexpected pass count")
+			test.AssertEqual(t, tc.expUnsupp, es.unsupported, "unexpected unsupported state")
+			test.AssertEqual(t, expLastPass, es.lastPass, "unexpected last pass time")
+			if diff := cmp.Diff(tc.expDevices, es.devices, cmp.AllowUnexported(bdevTrimDevice{})); diff != "" {
+				t.Fatalf("unexpected device stats (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	clockSkewCheckInterval = 5 * time.Minute
+	clockSkewCheckTimeout  = 10 * time.Second
+	// clockSkewMaxRTT is the round-trip time above which samples are
+	// discarded, as the error in the estimated skew is up to half the RTT.
+	clockSkewMaxRTT = time.Second
+	// clockSkewEventInterval is the interval at which events are raised
+	// again for a peer whose skew remains above a threshold.
+	clockSkewEventInterval = time.Hour
+
+	// Thresholds at which clock skew events are raised. Skew of a second
+	// reorders event timestamps across hosts, and skew of a minute is
+	// enough to break the validity windows of freshly issued certificates.
+	clockSkewWarnThreshold  = time.Second
+	clockSkewErrorThreshold = time.Minute
+)
+
+type (
+	clockSkewLevel int
+
+	// clockPeer contains the latest clock sample for a peer server.
+	clockPeer struct {
+		offset  time.Duration
+		rtt     time.Duration
+		updated time.Time
+		level   clockSkewLevel
+		raised  time.Time
+	}
+
+	// clockSkewMonitor compares the local clock with those of the peer
+	// servers, using the times reported in the responses to control
+	// RPCs. The latest skew for each peer is reported as prometheus
+	// metrics, and RAS events are raised when it exceeds thresholds.
+	clockSkewMonitor struct {
+		sync.RWMutex
+		log      logging.Logger
+		hostname string
+		publish  func(*events.RASEvent)
+		now      func() time.Time
+		peers    map[string]*clockPeer
+
+		skew *prometheus.Desc
+		rtt  *prometheus.Desc
+	}
+)
+
+const (
+	clockSkewNone clockSkewLevel = iota
+	clockSkewWarn
+	clockSkewError
+)
+
+func getClockSkewLevel(skew time.Duration) clockSkewLevel {
+	if skew < 0 {
+		skew = -skew
+	}
+
+	switch {
+	case skew >= clockSkewErrorThreshold:
+		return clockSkewError
+	case skew >= clockSkewWarnThreshold:
+		return clockSkewWarn
+	default:
+		return clockSkewNone
+	}
+}
+
+func newClockSkewMonitor(log logging.Logger, hostname string, publish func(*events.RASEvent)) *clockSkewMonitor {
+	return &clockSkewMonitor{
+		log:      log,
+		hostname: hostname,
+		publish:  publish,
+		now:      time.Now,
+		peers:    make(map[string]*clockPeer),
+		skew: prometheus.NewDesc("server_clock_skew_seconds",
+			"Estimated offset of the clock of the peer server from the local clock",
+			[]string{"peer"}, nil),
+		rtt: prometheus.NewDesc("server_clock_skew_rtt_seconds",
+			"Round-trip time of the request used to estimate the clock skew of the peer server",
+			[]string{"peer"}, nil),
+	}
+}
+
+// observe records a clock sample for a peer, raising an event if the skew
+// has crossed a higher threshold or has remained above a threshold for the
+// event interval.
+func (csm *clockSkewMonitor) observe(cs *control.ClockSample) {
+	if cs == nil || cs.RTT > clockSkewMaxRTT {
+		return
+	}
+
+	csm.Lock()
+	defer csm.Unlock()
+
+	now := csm.now()
+	peer, found := csm.peers[cs.Addr]
+	if !found {
+		peer = new(clockPeer)
+		csm.peers[cs.Addr] = peer
+	}
+	peer.offset = cs.Offset
+	peer.rtt = cs.RTT
+	peer.updated = now
+
+	level := getClockSkewLevel(cs.Offset)
+	if level == clockSkewNone {
+		if peer.level != clockSkewNone {
+			csm.log.Noticef("clock of %s is back within %s of local clock", cs.Addr, clockSkewWarnThreshold)
+		}
+		peer.level = clockSkewNone
+		return
+	}
+	if level <= peer.level && now.Sub(peer.raised) < clockSkewEventInterval {
+		peer.level = level
+		return
+	}
+	peer.level = level
+	peer.raised = now
+
+	sev := events.RASSeverityWarning
+	if level == clockSkewError {
+		sev = events.RASSeverityError
+	}
+	csm.publish(events.NewClockSkewEvent(csm.hostname, cs.Addr, cs.Offset.Round(time.Millisecond), sev))
+}
+
+// check prompts a round of control RPCs to the MS replicas via the supplied
+// function, so that the clocks of the replicas are sampled periodically even
+// when no other RPCs are being made.
+func (csm *clockSkewMonitor) check(ctx context.Context, query func(context.Context) error) {
+	qCtx, cancel := context.WithTimeout(ctx, clockSkewCheckTimeout)
+	defer cancel()
+
+	if err := query(qCtx); err != nil {
+		csm.log.Debugf("failed to query MS replicas for clock skew: %s", err)
+	}
+}
+
+// run samples the clocks of the MS replicas at a fixed interval until the
+// context is canceled.
+func (csm *clockSkewMonitor) run(ctx context.Context, query func(context.Context) error) {
+	ticker := time.NewTicker(clockSkewCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			csm.check(ctx, query)
+		}
+	}
+}
+
+func (csm *clockSkewMonitor) Describe(ch chan<- *prometheus.Desc) {
+	ch <- csm.skew
+	ch <- csm.rtt
+}
+
+func (csm *clockSkewMonitor) Collect(ch chan<- prometheus.Metric) {
+	csm.RLock()
+	defer csm.RUnlock()
+
+	addrs := make([]string, 0, len(csm.peers))
+	for addr := range csm.peers {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	for _, addr := range addrs {
+		peer := csm.peers[addr]
+		ch <- prometheus.MustNewConstMetric(csm.skew, prometheus.GaugeValue, peer.offset.Seconds(), addr)
+		ch <- prometheus.MustNewConstMetric(csm.rtt, prometheus.GaugeValue, peer.rtt.Seconds(), addr)
+	}
+}
+
+// startClockSkewMonitor starts sampling the clocks of the MS replicas by
+// periodically querying the MS leader and replicas.
+func startClockSkewMonitor(ctx context.Context, srv *server) {
+	if srv.cfg.TelemetryPort != 0 {
+		prometheus.MustRegister(srv.clockSkew)
+	}
+
+	go srv.clockSkew.run(ctx, func(ctx context.Context) error {
+		req := new(control.LeaderQueryReq)
+		req.SetHostList(srv.cfg.AccessPoints)
+		_, err := control.LeaderQuery(ctx, srv.mgmtSvc.rpcClient, req)
+		return err
+	})
+}
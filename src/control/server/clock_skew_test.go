@@ -0,0 +1,117 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_clockSkewMonitor_observe(t *testing.T) {
+	const peer = "host1:10001"
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type observation struct {
+		after  time.Duration // since start
+		offset time.Duration
+		rtt    time.Duration
+	}
+
+	for name, tc := range map[string]struct {
+		obs     []observation
+		expSevs []events.RASSeverityID
+		expSkew time.Duration
+	}{
+		"in sync": {
+			obs: []observation{
+				{offset: 10 * time.Millisecond},
+				{after: time.Minute, offset: -20 * time.Millisecond},
+			},
+			expSkew: -20 * time.Millisecond,
+		},
+		"imprecise sample discarded": {
+			obs: []observation{
+				{offset: 10 * time.Millisecond},
+				{after: time.Minute, offset: 5 * time.Second, rtt: 2 * clockSkewMaxRTT},
+			},
+			expSkew: 10 * time.Millisecond,
+		},
+		"warning raised once per interval": {
+			obs: []observation{
+				{offset: 2 * time.Second},
+				{after: time.Minute, offset: 3 * time.Second},
+				{after: clockSkewEventInterval + time.Minute, offset: -3 * time.Second},
+			},
+			expSevs: []events.RASSeverityID{
+				events.RASSeverityWarning,
+				events.RASSeverityWarning,
+			},
+			expSkew: -3 * time.Second,
+		},
+		"escalated to error": {
+			obs: []observation{
+				{offset: 2 * time.Second},
+				{after: time.Minute, offset: 2 * time.Minute},
+				{after: 2 * time.Minute, offset: 2 * time.Second},
+			},
+			expSevs: []events.RASSeverityID{
+				events.RASSeverityWarning,
+				events.RASSeverityError,
+			},
+			expSkew: 2 * time.Second,
+		},
+		"raised again after recovery": {
+			obs: []observation{
+				{offset: -2 * time.Second},
+				{after: time.Minute},
+				{after: 2 * time.Minute, offset: -2 * time.Second},
+			},
+			expSevs: []events.RASSeverityID{
+				events.RASSeverityWarning,
+				events.RASSeverityWarning,
+			},
+			expSkew: -2 * time.Second,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var gotSevs []events.RASSeverityID
+			publish := func(evt *events.RASEvent) {
+				test.AssertEqual(t, events.RASSystemClockSkew, evt.ID, "unexpected event ID")
+				test.AssertEqual(t, "foo", evt.Hostname, "unexpected event hostname")
+				gotSevs = append(gotSevs, evt.Severity)
+			}
+
+			csm := newClockSkewMonitor(log, "foo", publish)
+			for _, o := range tc.obs {
+				csm.now = func() time.Time { return start.Add(o.after) }
+				csm.observe(&control.ClockSample{
+					Addr:   peer,
+					Offset: o.offset,
+					RTT:    o.rtt,
+				})
+			}
+
+			test.AssertEqual(t, tc.expSevs, gotSevs, "unexpected event severities")
+			test.AssertEqual(t, tc.expSkew, csm.peers[peer].offset, "unexpected skew")
+
+			ch := make(chan prometheus.Metric, 10)
+			csm.Collect(ch)
+			close(ch)
+			test.AssertEqual(t, 2, len(ch), "unexpected number of metrics")
+		})
+	}
+}
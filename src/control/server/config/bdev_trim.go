@@ -0,0 +1,166 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBdevTrimInterval is the default minimum period between TRIM passes on
+// an engine's bdevs.
+const DefaultBdevTrimInterval = 24 * time.Hour
+
+// BdevTrim describes the parameters used to schedule periodic TRIM/deallocate
+// passes over free capacity on engine NVMe bdevs. Passes are only scheduled if
+// windows are configured and only start within one of them. Unset values are
+// replaced with defaults.
+type BdevTrim struct {
+	Windows   []string      `yaml:"windows,omitempty"`
+	Interval  time.Duration `yaml:"interval,omitempty"`
+	MaxBlocks uint64        `yaml:"max_blocks,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (bt *BdevTrim) WithDefaults() *BdevTrim {
+	out := new(BdevTrim)
+	if bt != nil {
+		*out = *bt
+		out.Windows = append([]string{}, bt.Windows...)
+	}
+	if out.Interval == 0 {
+		out.Interval = DefaultBdevTrimInterval
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (bt *BdevTrim) Validate() error {
+	if bt == nil {
+		return nil
+	}
+	if bt.Interval < 0 {
+		return FaultConfigBadBdevTrim(errors.New("interval must not be negative"))
+	}
+	if _, err := bt.ParseWindows(); err != nil {
+		return FaultConfigBadBdevTrim(err)
+	}
+
+	return nil
+}
+
+// Enabled returns true if TRIM passes are to be scheduled.
+func (bt *BdevTrim) Enabled() bool {
+	return bt != nil && len(bt.Windows) > 0
+}
+
+// ParseWindows returns the parsed representation of the configured windows.
+func (bt *BdevTrim) ParseWindows() ([]*TrimWindow, error) {
+	if bt == nil {
+		return nil, nil
+	}
+
+	var windows []*TrimWindow
+	for _, s := range bt.Windows {
+		tw, err := ParseTrimWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, tw)
+	}
+
+	return windows, nil
+}
+
+// TrimWindow is a daily period, optionally restricted to certain days of the
+// week, within which a TRIM pass may be started. A window whose end is before
+// its start crosses midnight and belongs to the day on which it starts.
+type TrimWindow struct {
+	Days  map[time.Weekday]bool // nil means every day
+	Start time.Duration         // offset from midnight
+	End   time.Duration         // offset from midnight
+}
+
+var trimWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.Errorf("invalid time of day %q (expected HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseTrimWindow parses a window of the form "[days ]HH:MM-HH:MM", where days
+// is an optional comma-separated list of abbreviated weekday names, e.g.
+// "Sat,Sun 01:00-05:00" or "23:00-02:00".
+func ParseTrimWindow(s string) (*TrimWindow, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, errors.Errorf("invalid trim window %q", s)
+	}
+
+	tw := new(TrimWindow)
+	if len(fields) == 2 {
+		tw.Days = make(map[time.Weekday]bool)
+		for _, name := range strings.Split(fields[0], ",") {
+			day, found := trimWeekdays[strings.ToLower(name)]
+			if !found {
+				return nil, errors.Errorf("invalid day %q in trim window %q", name, s)
+			}
+			tw.Days[day] = true
+		}
+	}
+
+	times := strings.Split(fields[len(fields)-1], "-")
+	if len(times) != 2 {
+		return nil, errors.Errorf("invalid trim window %q", s)
+	}
+	var err error
+	if tw.Start, err = parseTimeOfDay(times[0]); err != nil {
+		return nil, err
+	}
+	if tw.End, err = parseTimeOfDay(times[1]); err != nil {
+		return nil, err
+	}
+	if tw.Start == tw.End {
+		return nil, errors.Errorf("trim window %q has zero length", s)
+	}
+
+	return tw, nil
+}
+
+func (tw *TrimWindow) onDay(day time.Weekday) bool {
+	return tw.Days == nil || tw.Days[day]
+}
+
+// Contains returns true if the given time falls within the window.
+func (tw *TrimWindow) Contains(t time.Time) bool {
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+
+	if tw.Start < tw.End {
+		return tw.onDay(t.Weekday()) && offset >= tw.Start && offset < tw.End
+	}
+
+	if offset >= tw.Start {
+		return tw.onDay(t.Weekday())
+	}
+	// the early-morning portion belongs to the window started on the previous day
+	return offset < tw.End && tw.onDay((t.Weekday()+6)%7)
+}
@@ -0,0 +1,140 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestConfig_ParseTrimWindow(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in     string
+		expTW  *TrimWindow
+		expErr error
+	}{
+		"empty": {
+			expErr: errors.New("invalid trim window"),
+		},
+		"every day": {
+			in: "01:00-05:30",
+			expTW: &TrimWindow{
+				Start: time.Hour,
+				End:   5*time.Hour + 30*time.Minute,
+			},
+		},
+		"weekend": {
+			in: "Sat,sun 22:00-02:00",
+			expTW: &TrimWindow{
+				Days:  map[time.Weekday]bool{time.Saturday: true, time.Sunday: true},
+				Start: 22 * time.Hour,
+				End:   2 * time.Hour,
+			},
+		},
+		"bad day": {
+			in:     "Sat,Caturday 01:00-02:00",
+			expErr: errors.New(`invalid day "Caturday"`),
+		},
+		"missing end": {
+			in:     "01:00",
+			expErr: errors.New("invalid trim window"),
+		},
+		"bad time": {
+			in:     "01:00-25:00",
+			expErr: errors.New("invalid time of day \"25:00\""),
+		},
+		"zero length": {
+			in:     "01:00-01:00",
+			expErr: errors.New("zero length"),
+		},
+		"too many fields": {
+			in:     "Sat 01:00 - 02:00",
+			expErr: errors.New("invalid trim window"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tw, err := ParseTrimWindow(tc.in)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expTW, tw); diff != "" {
+				t.Fatalf("unexpected window (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestConfig_TrimWindow_Contains(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, time.January, day, hour, min, 0, 0, time.Local)
+	}
+
+	for name, tc := range map[string]struct {
+		window string
+		time   time.Time
+		expIn  bool
+	}{
+		"inside": {
+			window: "01:00-05:00",
+			time:   at(3, 2, 0),
+			expIn:  true,
+		},
+		"at start": {
+			window: "01:00-05:00",
+			time:   at(3, 1, 0),
+			expIn:  true,
+		},
+		"at end": {
+			window: "01:00-05:00",
+			time:   at(3, 5, 0),
+		},
+		"wrong day": {
+			window: "Sat,Sun 01:00-05:00",
+			time:   at(5, 2, 0),
+		},
+		"right day": {
+			window: "Sat,Sun 01:00-05:00",
+			time:   at(7, 2, 0),
+			expIn:  true,
+		},
+		"crosses midnight; before midnight": {
+			window: "Fri 23:00-02:00",
+			time:   at(5, 23, 30),
+			expIn:  true,
+		},
+		"crosses midnight; after midnight": {
+			window: "Fri 23:00-02:00",
+			time:   at(6, 1, 30),
+			expIn:  true,
+		},
+		"crosses midnight; after midnight on start day": {
+			window: "Fri 23:00-02:00",
+			time:   at(5, 1, 30),
+		},
+		"crosses midnight; outside": {
+			window: "23:00-02:00",
+			time:   at(5, 12, 0),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tw, err := ParseTrimWindow(tc.window)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.AssertEqual(t, tc.expIn, tw.Contains(tc.time), "unexpected result")
+		})
+	}
+}
@@ -0,0 +1,138 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daos-stack/daos/src/control/lib/yamlcheck"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// storageClasses lists the values accepted for a storage tier class.
+var storageClasses = []string{
+	storage.ClassDcpm.String(),
+	storage.ClassRam.String(),
+	storage.ClassNvme.String(),
+	storage.ClassFile.String(),
+	storage.ClassKdev.String(),
+}
+
+// serverConfigRules check for combinations of settings in the server config
+// file that are individually valid but not valid together, so that they can
+// be reported along with their location in the file.
+var serverConfigRules = []yamlcheck.Rule{
+	checkStorageTiers,
+	checkBdevRolesWithDCPM,
+}
+
+// engineTiers calls fn for each storage tier of each engine.
+func engineTiers(root *yamlcheck.Node, fn func(engine, tier *yamlcheck.Node)) {
+	for _, engine := range root.Key("engines").Items() {
+		for _, tier := range engine.Key("storage").Items() {
+			fn(engine, tier)
+		}
+	}
+}
+
+func isScmClass(class string) bool {
+	return class == storage.ClassDcpm.String() || class == storage.ClassRam.String()
+}
+
+// checkStorageTiers verifies that each storage tier has a supported class and
+// only contains settings that apply to that class.
+func checkStorageTiers(root *yamlcheck.Node) yamlcheck.Issues {
+	var issues yamlcheck.Issues
+
+	engineTiers(root, func(_, tier *yamlcheck.Node) {
+		classNode := tier.Key("class")
+		class := classNode.Value()
+		if classNode == nil {
+			issues = append(issues, tier.Issue("storage tier has no class",
+				"set class to one of "+strings.Join(storageClasses, ", ")))
+			return
+		}
+
+		valid := false
+		for _, c := range storageClasses {
+			if class == c {
+				valid = true
+			}
+		}
+		if !valid {
+			fix := "supported classes are " + strings.Join(storageClasses, ", ")
+			if c := yamlcheck.Closest(class, storageClasses); c != "" {
+				fix = fmt.Sprintf("did you mean %q?", c)
+			}
+			issues = append(issues, classNode.Issue(
+				fmt.Sprintf("unsupported storage class %q", class), fix))
+			return
+		}
+
+		wrongPrefix, tierType := "scm_", "a bdev"
+		if isScmClass(class) {
+			wrongPrefix, tierType = "bdev_", "an scm"
+		}
+		for _, key := range tier.Keys() {
+			if strings.HasPrefix(key, wrongPrefix) {
+				issues = append(issues, tier.Key(key).Issue(
+					fmt.Sprintf("%s may not be set on %s tier (class %s)", key, tierType, class),
+					fmt.Sprintf("move %s to a tier of the matching class or remove it", key)))
+			}
+		}
+
+		var invalid []string
+		switch storage.Class(class) {
+		case storage.ClassDcpm:
+			invalid = []string{"scm_size", "scm_hugepages_disabled"}
+		case storage.ClassRam:
+			invalid = []string{"scm_list"}
+		}
+		for _, key := range invalid {
+			if n := tier.Key(key); n != nil {
+				issues = append(issues, n.Issue(
+					fmt.Sprintf("%s may not be set when class is %s", key, class),
+					"remove "+key+" or change the tier class"))
+			}
+		}
+	})
+
+	return issues
+}
+
+// checkBdevRolesWithDCPM verifies that MD-on-SSD settings are not combined
+// with an scm tier of class dcpm.
+func checkBdevRolesWithDCPM(root *yamlcheck.Node) yamlcheck.Issues {
+	var issues yamlcheck.Issues
+
+	dcpmEngines := make(map[string]bool)
+	engineTiers(root, func(engine, tier *yamlcheck.Node) {
+		if tier.Key("class").Value() == storage.ClassDcpm.String() {
+			dcpmEngines[engine.Path()] = true
+		}
+	})
+	if len(dcpmEngines) == 0 {
+		return nil
+	}
+
+	engineTiers(root, func(engine, tier *yamlcheck.Node) {
+		if roles := tier.Key("bdev_roles"); roles != nil && dcpmEngines[engine.Path()] {
+			issues = append(issues, roles.Issue(
+				storage.FaultBdevConfigRolesWithDCPM.Description,
+				storage.FaultBdevConfigRolesWithDCPM.Resolution))
+		}
+	})
+
+	if md := root.Key("control_metadata"); md != nil {
+		issues = append(issues, md.Issue(
+			"control_metadata may not be set when an engine has an scm tier of class dcpm",
+			"change the scm tiers to class ram or remove control_metadata"))
+	}
+
+	return issues
+}
@@ -0,0 +1,93 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestServerConfig_Parse_Checks(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input  string
+		expErr error
+	}{
+		"unknown engine key": {
+			input: `
+engines:
+- targts: 16
+`,
+			expErr: errors.New(`test.yml:3:3: engines[0].targts: unknown key "targts"; did you mean "targets"?`),
+		},
+		"unknown storage key": {
+			input: `
+engines:
+- storage:
+  - class: ram
+    scm_mnt: /mnt/daos
+`,
+			expErr: errors.New(`test.yml:5:5: engines[0].storage[0].scm_mnt: unknown key "scm_mnt"; did you mean "scm_mount"?`),
+		},
+		"type error": {
+			input: `
+port: ten
+`,
+			expErr: errors.New(`test.yml:2:7: port: expected an integer, got string "ten"`),
+		},
+		"unsupported storage class": {
+			input: `
+engines:
+- storage:
+  - class: nvem
+`,
+			expErr: errors.New(`test.yml:4:12: engines[0].storage[0].class: unsupported storage class "nvem"; did you mean "nvme"?`),
+		},
+		"bdev setting on scm tier": {
+			input: `
+engines:
+- storage:
+  - class: ram
+    scm_mount: /mnt/daos
+    bdev_list: ["0000:81:00.0"]
+`,
+			expErr: errors.New("test.yml:6:16: engines[0].storage[0].bdev_list: bdev_list may not be set on an scm tier (class ram)"),
+		},
+		"scm_list with ram": {
+			input: `
+engines:
+- storage:
+  - class: ram
+    scm_mount: /mnt/daos
+    scm_list: [/dev/pmem0]
+`,
+			expErr: errors.New("test.yml:6:15: engines[0].storage[0].scm_list: scm_list may not be set when class is ram"),
+		},
+		"bdev roles with dcpm": {
+			input: `
+engines:
+- storage:
+  - class: dcpm
+    scm_mount: /mnt/daos
+    scm_list: [/dev/pmem0]
+  - class: nvme
+    bdev_list: ["0000:81:00.0"]
+    bdev_roles: [wal, meta, data]
+`,
+			expErr: errors.New("test.yml:9:17: engines[0].storage[1].bdev_roles: MD-on-SSD roles has been specified in config with scm class set to dcpm"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultServer()
+			cfg.Path = "test.yml"
+
+			test.CmpErr(t, tc.expErr, cfg.Parse([]byte(tc.input)))
+		})
+	}
+}
@@ -8,10 +8,12 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/dustin/go-humanize"
 )
 
@@ -96,11 +98,21 @@ var (
 		"hugepages cannot be disabled if bdevs have been specified in config",
 		"either set false (or remove) disable_hugepages parameter or remove nvme storage assignment in config and restart the control server",
 	)
+	FaultConfigEncryptionNoKey = serverConfigFault(
+		code.ServerConfigEncryptionNoKey,
+		"storage tiers with scm_encryption or bdev_encryption require an encryption key",
+		"add an `encryption_key` section to the config or remove the encryption settings from the engine storage tiers",
+	)
 	FaultConfigControlMetadataNoPath = serverConfigFault(
 		code.ServerConfigControlMetadataNoPath,
 		"using a control_metadata device requires a path to use as the mount point",
 		"add a valid 'path' to the 'control_metadata' section of the config",
 	)
+	FaultConfigControlMetadataBadMirror = serverConfigFault(
+		code.ServerConfigControlMetadataBadMirror,
+		"control_metadata mirror_path requires a path and must not be within it",
+		"set 'mirror_path' in the 'control_metadata' section of the config to a directory on a different device than 'path'",
+	)
 	FaultConfigEngineBdevRolesMismatch = serverConfigFault(
 		code.ServerConfigEngineBdevRolesMismatch,
 		"md-on-ssd bdev roles have been set in some but not all engine configs",
@@ -111,8 +123,159 @@ var (
 		"`system_ram_reserved` is set to zero in server config",
 		"set `system_ram_reserved` to a positive integer value in config",
 	)
+	FaultConfigBadFlapDamping = serverConfigFault(
+		code.ServerConfigBadFlapDamping,
+		"invalid `flap_damping` parameters in server config",
+		"set `flap_damping` threshold to a positive integer and window to a positive duration (e.g. 30m) in config",
+	)
+	FaultConfigBadMemoryWatchdog = serverConfigFault(
+		code.ServerConfigBadMemoryWatchdog,
+		"invalid `memory_watchdog` parameters in server config",
+		"set `memory_watchdog` percentages between 0 and 100 with mem_avail_stop_percent below mem_avail_warn_percent, and interval to a positive duration (e.g. 30s) in config",
+	)
+	FaultConfigBadEventDedupe = serverConfigFault(
+		code.ServerConfigBadEventDedupe,
+		"invalid `event_dedupe` parameters in server config",
+		"set `event_dedupe` window to a positive duration (e.g. 60s) in config",
+	)
+	FaultConfigBadTracing = serverConfigFault(
+		code.ServerConfigBadTracing,
+		"invalid `tracing` parameters in server config",
+		"set `tracing` otlp_endpoint to the http or https URL of an OpenTelemetry collector (e.g. http://collector:4318) in config",
+	)
+	FaultConfigBadTelemetryRemoteWrite = serverConfigFault(
+		code.ServerConfigBadTelemetryRemoteWrite,
+		"invalid `telemetry_remote_write` parameters in server config",
+		"set `telemetry_remote_write` endpoint to the http or https URL of a Prometheus remote-write receiver, push_interval and max_pending to non-negative values and bearer_token_file to an absolute path in config",
+	)
+	FaultConfigTelemetryRemoteWriteNoPort = serverConfigFault(
+		code.ServerConfigTelemetryRemoteWriteNoPort,
+		"`telemetry_remote_write` requires `telemetry_port` in server config",
+		"set `telemetry_port` (e.g. 9191) in config to enable engine metrics collection",
+	)
+	FaultConfigBadJoinAdmission = serverConfigFault(
+		code.ServerConfigBadJoinAdmission,
+		"invalid `join_admission` parameters in server config",
+		"set exactly one of `join_admission` url (http or https) or script (absolute path), and timeout to a positive duration (e.g. 10s) in config",
+	)
+	FaultConfigBadJoinRateLimit = serverConfigFault(
+		code.ServerConfigBadJoinRateLimit,
+		"invalid `join_rate_limit` parameters in server config",
+		"set `join_rate_limit` max_per_batch and max_queued to positive integers with max_queued no less than max_per_batch, and retry_after to a positive duration (e.g. 2s) in config",
+	)
+	FaultConfigBadAutoReintegrate = serverConfigFault(
+		code.ServerConfigBadAutoReintegrate,
+		"invalid `auto_reintegrate` parameters in server config",
+		"set `auto_reintegrate` stability_window to a positive duration (e.g. 5m) in config",
+	)
+	FaultConfigBadHealthReport = serverConfigFault(
+		code.ServerConfigBadHealthReport,
+		"invalid `health_report` parameters in server config",
+		"set `health_report` interval to at least 1h, format to text or html, and an absolute output_dir and/or an smtp section with a host:port server, from address and to addresses in config",
+	)
+	FaultConfigBadEngineLogBump = serverConfigFault(
+		code.ServerConfigBadEngineLogBump,
+		"invalid `engine_log_bump` parameters in server config",
+		"set `engine_log_bump` duration to a positive value (e.g. 5m) and log_mask and streams to valid engine log settings in config",
+	)
+	FaultConfigBadEventArchive = serverConfigFault(
+		code.ServerConfigBadEventArchive,
+		"invalid `event_archive` parameters in server config",
+		"set `event_archive` archive_dir to an absolute path, interval to at least 1h and archive_after to a positive duration in config",
+	)
+	FaultConfigBadDrpcSocket = serverConfigFault(
+		code.ServerConfigBadDrpcSocket,
+		"invalid `drpc_sockets` entry in server config",
+		"set the path of each `drpc_sockets` entry to a unique absolute path, its modules to any of security, mgmt and srv, and its mode to octal permissions (e.g. 0660) in config",
+	)
+	FaultConfigBadPoolOpScheduler = serverConfigFault(
+		code.ServerConfigBadPoolOpScheduler,
+		"invalid `pool_op_scheduler` parameters in server config",
+		"set `pool_op_scheduler` max_concurrent and max_per_rank to zero or positive values and poll_interval to at least 1s in config",
+	)
+	FaultConfigBadPoolSpaceForecast = serverConfigFault(
+		code.ServerConfigBadPoolSpaceForecast,
+		"invalid `pool_space_forecast` parameters in server config",
+		"set `pool_space_forecast` warn_percent to a percentage between 1 and 100, horizon to a positive duration (e.g. 168h) and trend_window to at least 2h in config",
+	)
+	FaultConfigBadQuarantinePolicy = serverConfigFault(
+		code.ServerConfigBadQuarantinePolicy,
+		"invalid `quarantine_policy` parameters in server config",
+		"set `quarantine_policy` threshold to a positive count, window to a positive duration (e.g. 1h) and events to RAS event names in config",
+	)
+	FaultConfigBadProvisioning = serverConfigFault(
+		code.ServerConfigBadProvisioning,
+		"invalid `provisioning` parameters in server config",
+		"set `provisioning` token_file to an absolute path in config",
+	)
+	FaultConfigBadMSElectionTier = serverConfigFault(
+		code.ServerConfigBadMSElectionTier,
+		fmt.Sprintf("invalid `ms_election_tier` in server config (max %d)", MaxMSElectionTier),
+		fmt.Sprintf("set `ms_election_tier` to a value between 0 and %d in config", MaxMSElectionTier),
+	)
 )
 
+// FaultConfigBadControlLogSubsystem creates a fault for an unknown subsystem in the
+// control_log_subsystems section of the server config.
+func FaultConfigBadControlLogSubsystem(name string) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadControlLogSubsystem,
+		fmt.Sprintf("unknown subsystem %q in `control_log_subsystems` in server config", name),
+		fmt.Sprintf("use only the subsystems %s in `control_log_subsystems` in config",
+			strings.Join(logging.Subsystems(), ", ")),
+	)
+}
+
+// FaultConfigBadControlProfiling creates a fault for an invalid control_profiling
+// section of the server config.
+func FaultConfigBadControlProfiling(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadControlProfiling,
+		fmt.Sprintf("invalid `control_profiling` parameters in server config: %s", err),
+		"set `control_profiling` address to a loopback host:port, or set allow_remote, in config",
+	)
+}
+
+// FaultConfigBadEncryptionKey creates a fault for an invalid encryption_key
+// section of the server config.
+func FaultConfigBadEncryptionKey(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadEncryptionKey,
+		fmt.Sprintf("invalid `encryption_key` parameters in server config: %s", err),
+		"set exactly one of `file` or `command` to an absolute path in the `encryption_key` section of config",
+	)
+}
+
+// FaultConfigBadFabricIfaceFilter creates a fault for an invalid
+// fabric_iface_filter section of the server config.
+func FaultConfigBadFabricIfaceFilter(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadFabricIfaceFilter,
+		fmt.Sprintf("invalid `fabric_iface_filter` parameters in server config: %s", err),
+		"use shell-style patterns (e.g. \"eth0.*\") in the `fabric_iface_filter` section of config",
+	)
+}
+
+// FaultConfigBadBdevTrim creates a fault for an invalid bdev_trim section of
+// the server config.
+func FaultConfigBadBdevTrim(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadBdevTrim,
+		fmt.Sprintf("invalid `bdev_trim` parameters in server config: %s", err),
+		"set one or more `bdev_trim` windows (e.g. \"Sat,Sun 01:00-05:00\") and a positive interval in config",
+	)
+}
+
+// FaultConfigBadMSBackup creates a fault for an invalid ms_backup section of
+// the server config.
+func FaultConfigBadMSBackup(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadMSBackup,
+		fmt.Sprintf("invalid `ms_backup` parameters in server config: %s", err),
+		"set `ms_backup` schedule to a cron schedule (e.g. \"0 2 * * *\") and backup_dir and any upload_script to absolute paths in config",
+	)
+}
+
 func FaultConfigDuplicateFabric(curIdx, seenIdx int) *fault.Fault {
 	return serverConfigFault(
 		code.ServerConfigDuplicateFabric,
@@ -11,9 +11,12 @@ import (
 	"io/ioutil"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -21,12 +24,18 @@ import (
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/lib/cron"
 	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
+	"github.com/daos-stack/daos/src/control/lib/yamlcheck"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 const (
@@ -36,29 +45,789 @@ const (
 	relConfExamplesPath = "../utils/config/examples/"
 )
 
+// Default memory watchdog parameters.
+const (
+	DefaultMemWatchdogInterval = 30 * time.Second
+	DefaultRamdiskWarnPercent  = 90
+	DefaultMemAvailWarnPercent = 10
+)
+
+// MemoryWatchdog describes the parameters used to monitor memory pressure on
+// hosts running engines with ram-class SCM. Unset values are replaced with
+// defaults. Engines are only stopped if MemAvailStopPercent is set.
+type MemoryWatchdog struct {
+	Disabled            bool          `yaml:"disabled,omitempty"`
+	Interval            time.Duration `yaml:"interval,omitempty"`
+	RamdiskWarnPercent  int           `yaml:"ramdisk_warn_percent,omitempty"`
+	MemAvailWarnPercent int           `yaml:"mem_avail_warn_percent,omitempty"`
+	MemAvailStopPercent int           `yaml:"mem_avail_stop_percent,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (mw *MemoryWatchdog) WithDefaults() *MemoryWatchdog {
+	out := new(MemoryWatchdog)
+	if mw != nil {
+		*out = *mw
+	}
+	if out.Interval == 0 {
+		out.Interval = DefaultMemWatchdogInterval
+	}
+	if out.RamdiskWarnPercent == 0 {
+		out.RamdiskWarnPercent = DefaultRamdiskWarnPercent
+	}
+	if out.MemAvailWarnPercent == 0 {
+		out.MemAvailWarnPercent = DefaultMemAvailWarnPercent
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are out of range.
+func (mw *MemoryWatchdog) Validate() error {
+	if mw == nil {
+		return nil
+	}
+	if mw.Interval < 0 {
+		return FaultConfigBadMemoryWatchdog
+	}
+	for _, pct := range []int{mw.RamdiskWarnPercent, mw.MemAvailWarnPercent, mw.MemAvailStopPercent} {
+		if pct < 0 || pct > 100 {
+			return FaultConfigBadMemoryWatchdog
+		}
+	}
+	if mw.MemAvailStopPercent >= mw.WithDefaults().MemAvailWarnPercent {
+		return FaultConfigBadMemoryWatchdog
+	}
+
+	return nil
+}
+
+// DefaultEventDedupeWindow is the default period over which identical events
+// are collapsed into a single summary event on the MS leader.
+const DefaultEventDedupeWindow = time.Minute
+
+// EventDedupe describes the parameters used by the MS leader to collapse
+// identical events that repeat within a window in its logs, e.g. during mass
+// failures.
+// Unset values are replaced with defaults.
+type EventDedupe struct {
+	Disabled bool          `yaml:"disabled,omitempty"`
+	Window   time.Duration `yaml:"window,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (ed *EventDedupe) WithDefaults() *EventDedupe {
+	out := new(EventDedupe)
+	if ed != nil {
+		*out = *ed
+	}
+	if out.Window == 0 {
+		out.Window = DefaultEventDedupeWindow
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are out of range.
+func (ed *EventDedupe) Validate() error {
+	if ed != nil && ed.Window < 0 {
+		return FaultConfigBadEventDedupe
+	}
+	return nil
+}
+
+// Default engine log bump parameters.
+const (
+	DefaultLogBumpMask     = "DEBUG"
+	DefaultLogBumpDuration = 5 * time.Minute
+)
+
+// DefaultLogBumpEvents lists the RAS events that trigger an engine log bump
+// when none are specified.
+var DefaultLogBumpEvents = []string{
+	"corruption_detected",
+	"device_set_faulty",
+	"device_media_error",
+}
+
+// EngineLogBump describes the policy used to temporarily raise the log mask of
+// an engine when it raises one of the listed RAS events, so that verbose logs
+// are captured around a fault without permanent debug logging. Unset values are
+// replaced with defaults.
+type EngineLogBump struct {
+	Enabled  bool          `yaml:"enabled,omitempty"`
+	Events   []string      `yaml:"events,omitempty"`
+	LogMask  string        `yaml:"log_mask,omitempty"`
+	Streams  string        `yaml:"streams,omitempty"`
+	Duration time.Duration `yaml:"duration,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (lb *EngineLogBump) WithDefaults() *EngineLogBump {
+	out := new(EngineLogBump)
+	if lb != nil {
+		*out = *lb
+	}
+	if len(out.Events) == 0 {
+		out.Events = DefaultLogBumpEvents
+	}
+	if out.LogMask == "" {
+		out.LogMask = DefaultLogBumpMask
+	}
+	if out.Duration == 0 {
+		out.Duration = DefaultLogBumpDuration
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (lb *EngineLogBump) Validate() error {
+	if lb == nil {
+		return nil
+	}
+	if lb.Duration < 0 {
+		return FaultConfigBadEngineLogBump
+	}
+	if engine.ValidateLogMasks(lb.LogMask) != nil || engine.ValidateLogStreams(lb.Streams) != nil {
+		return FaultConfigBadEngineLogBump
+	}
+
+	return nil
+}
+
+// Tracing describes where spans recorded for traced control plane requests are
+// exported. Trace context is propagated whether or not an endpoint is set.
+type Tracing struct {
+	OTLPEndpoint string `yaml:"otlp_endpoint,omitempty"`
+}
+
+// Validate returns an error if the parameters are invalid.
+func (tr *Tracing) Validate() error {
+	if tr == nil || tr.OTLPEndpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(tr.OTLPEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return FaultConfigBadTracing
+	}
+
+	return nil
+}
+
+// TelemetryRemoteWrite describes a Prometheus remote-write receiver (e.g. Mimir
+// or Thanos) that metrics are pushed to, for sites where scraping every server
+// is impractical. Unset values are replaced with defaults.
+type TelemetryRemoteWrite struct {
+	Endpoint        string            `yaml:"endpoint"`
+	PushInterval    time.Duration     `yaml:"push_interval,omitempty"`
+	MaxPending      int               `yaml:"max_pending,omitempty"`
+	BearerTokenFile string            `yaml:"bearer_token_file,omitempty"`
+	ExternalLabels  map[string]string `yaml:"external_labels,omitempty"`
+}
+
+// Validate returns an error if the parameters are invalid.
+func (rw *TelemetryRemoteWrite) Validate() error {
+	if rw == nil {
+		return nil
+	}
+	u, err := url.Parse(rw.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return FaultConfigBadTelemetryRemoteWrite
+	}
+	if rw.PushInterval < 0 || rw.MaxPending < 0 {
+		return FaultConfigBadTelemetryRemoteWrite
+	}
+	if rw.BearerTokenFile != "" && !filepath.IsAbs(rw.BearerTokenFile) {
+		return FaultConfigBadTelemetryRemoteWrite
+	}
+
+	return nil
+}
+
+// DefaultJoinAdmissionTimeout is the default period the MS leader waits for a
+// join admission hook to reach a decision.
+const DefaultJoinAdmissionTimeout = 10 * time.Second
+
+// JoinAdmission describes an external hook consulted by the MS leader before
+// a rank is admitted to the system. Exactly one of URL or Script must be set.
+// Unset values are replaced with defaults.
+type JoinAdmission struct {
+	URL          string        `yaml:"url,omitempty"`
+	Script       string        `yaml:"script,omitempty"`
+	Timeout      time.Duration `yaml:"timeout,omitempty"`
+	AllowOnError bool          `yaml:"allow_on_error,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (ja *JoinAdmission) WithDefaults() *JoinAdmission {
+	out := new(JoinAdmission)
+	if ja != nil {
+		*out = *ja
+	}
+	if out.Timeout == 0 {
+		out.Timeout = DefaultJoinAdmissionTimeout
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (ja *JoinAdmission) Validate() error {
+	if ja == nil {
+		return nil
+	}
+	if (ja.URL == "") == (ja.Script == "") || ja.Timeout < 0 {
+		return FaultConfigBadJoinAdmission
+	}
+	if ja.URL != "" {
+		u, err := url.Parse(ja.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return FaultConfigBadJoinAdmission
+		}
+	}
+	if ja.Script != "" && !filepath.IsAbs(ja.Script) {
+		return FaultConfigBadJoinAdmission
+	}
+
+	return nil
+}
+
+const (
+	// DefaultJoinMaxPerBatch is the default number of join requests the MS
+	// leader processes in each batch.
+	DefaultJoinMaxPerBatch = 256
+	// DefaultJoinMaxQueued is the default number of join requests the MS
+	// leader holds before asking new requests to be retried later.
+	DefaultJoinMaxQueued = 4096
+	// DefaultJoinRetryAfter is the default minimum period that engines are
+	// asked to wait before retrying a rejected join request.
+	DefaultJoinRetryAfter = 2 * time.Second
+)
+
+// JoinRateLimit describes the parameters used by the MS leader to limit the
+// rate at which join requests are processed, so that a burst of joins after a
+// mass restart does not swamp the raft log and trigger a flood of group map
+// updates. Unset values are replaced with defaults.
+type JoinRateLimit struct {
+	Disabled    bool          `yaml:"disabled,omitempty"`
+	MaxPerBatch int           `yaml:"max_per_batch,omitempty"`
+	MaxQueued   int           `yaml:"max_queued,omitempty"`
+	RetryAfter  time.Duration `yaml:"retry_after,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (jl *JoinRateLimit) WithDefaults() *JoinRateLimit {
+	out := new(JoinRateLimit)
+	if jl != nil {
+		*out = *jl
+	}
+	if out.MaxPerBatch == 0 {
+		out.MaxPerBatch = DefaultJoinMaxPerBatch
+	}
+	if out.MaxQueued == 0 {
+		out.MaxQueued = DefaultJoinMaxQueued
+	}
+	if out.RetryAfter == 0 {
+		out.RetryAfter = DefaultJoinRetryAfter
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are out of range.
+func (jl *JoinRateLimit) Validate() error {
+	if jl == nil {
+		return nil
+	}
+	if jl.MaxPerBatch < 0 || jl.MaxQueued < 0 || jl.RetryAfter < 0 {
+		return FaultConfigBadJoinRateLimit
+	}
+	if wd := jl.WithDefaults(); wd.MaxQueued < wd.MaxPerBatch {
+		return FaultConfigBadJoinRateLimit
+	}
+	return nil
+}
+
+// DefaultAutoReintegrateWindow is the default period that a rank which
+// rejoined after being excluded must remain joined before it is reintegrated.
+const DefaultAutoReintegrateWindow = 5 * time.Minute
+
+// AutoReintegrate describes the policy used by the MS leader to reintegrate
+// the targets of a rank that was excluded from the system and has since
+// rejoined, once the rank has remained joined for the stability window.
+type AutoReintegrate struct {
+	Enabled         bool          `yaml:"enabled,omitempty"`
+	StabilityWindow time.Duration `yaml:"stability_window,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (ar *AutoReintegrate) WithDefaults() *AutoReintegrate {
+	out := new(AutoReintegrate)
+	if ar != nil {
+		*out = *ar
+	}
+	if out.StabilityWindow == 0 {
+		out.StabilityWindow = DefaultAutoReintegrateWindow
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are out of range.
+func (ar *AutoReintegrate) Validate() error {
+	if ar == nil {
+		return nil
+	}
+	if ar.StabilityWindow < 0 {
+		return FaultConfigBadAutoReintegrate
+	}
+	return nil
+}
+
+const (
+	// DefaultHealthReportInterval is the default period covered by each
+	// health report generated by the MS leader.
+	DefaultHealthReportInterval = 24 * time.Hour
+	// MinHealthReportInterval is the shortest period that may be covered by
+	// a health report, as health snapshots are only recorded hourly.
+	MinHealthReportInterval = time.Hour
+
+	// HealthReportFormatText selects plain text health reports.
+	HealthReportFormatText = "text"
+	// HealthReportFormatHTML selects HTML health reports.
+	HealthReportFormatHTML = "html"
+)
+
+// HealthReportSMTP describes the SMTP relay and recipients that health reports
+// are mailed to. The relay must accept unauthenticated mail from the servers.
+type HealthReportSMTP struct {
+	Server string   `yaml:"server"`
+	From   string   `yaml:"from"`
+	To     []string `yaml:"to"`
+}
+
+// HealthReport describes the periodic reports summarizing events, capacity
+// changes and health status that are generated by the MS leader. Reports are
+// written to the output directory, mailed, or both. Unset values are replaced
+// with defaults.
+type HealthReport struct {
+	Interval  time.Duration     `yaml:"interval,omitempty"`
+	Format    string            `yaml:"format,omitempty"`
+	OutputDir string            `yaml:"output_dir,omitempty"`
+	SMTP      *HealthReportSMTP `yaml:"smtp,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (hr *HealthReport) WithDefaults() *HealthReport {
+	out := new(HealthReport)
+	if hr != nil {
+		*out = *hr
+	}
+	if out.Interval == 0 {
+		out.Interval = DefaultHealthReportInterval
+	}
+	if out.Format == "" {
+		out.Format = HealthReportFormatText
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (hr *HealthReport) Validate() error {
+	if hr == nil {
+		return nil
+	}
+	if hr.Interval != 0 && hr.Interval < MinHealthReportInterval {
+		return FaultConfigBadHealthReport
+	}
+	switch hr.Format {
+	case "", HealthReportFormatText, HealthReportFormatHTML:
+	default:
+		return FaultConfigBadHealthReport
+	}
+	if hr.OutputDir == "" && hr.SMTP == nil {
+		return FaultConfigBadHealthReport
+	}
+	if hr.OutputDir != "" && !filepath.IsAbs(hr.OutputDir) {
+		return FaultConfigBadHealthReport
+	}
+	if hr.SMTP != nil {
+		if _, _, err := net.SplitHostPort(hr.SMTP.Server); err != nil {
+			return FaultConfigBadHealthReport
+		}
+		if hr.SMTP.From == "" || len(hr.SMTP.To) == 0 {
+			return FaultConfigBadHealthReport
+		}
+	}
+
+	return nil
+}
+
+const (
+	// DefaultEventArchiveInterval is the default period between passes of
+	// the event archiver.
+	DefaultEventArchiveInterval = 24 * time.Hour
+	// MinEventArchiveInterval is the shortest period between passes of the
+	// event archiver.
+	MinEventArchiveInterval = time.Hour
+	// DefaultEventArchiveAfter is the default time after acknowledgment at
+	// which persistent events are moved from the MS database to the archive.
+	DefaultEventArchiveAfter = 30 * 24 * time.Hour
+)
+
+// EventArchive describes the periodic export by the MS leader of aged-out
+// persistent events to CSV files, after which the events are removed from the
+// MS database. Unset values are replaced with defaults.
+type EventArchive struct {
+	ArchiveDir   string        `yaml:"archive_dir"`
+	Interval     time.Duration `yaml:"interval,omitempty"`
+	ArchiveAfter time.Duration `yaml:"archive_after,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (ea *EventArchive) WithDefaults() *EventArchive {
+	out := new(EventArchive)
+	if ea != nil {
+		*out = *ea
+	}
+	if out.Interval == 0 {
+		out.Interval = DefaultEventArchiveInterval
+	}
+	if out.ArchiveAfter == 0 {
+		out.ArchiveAfter = DefaultEventArchiveAfter
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (ea *EventArchive) Validate() error {
+	if ea == nil {
+		return nil
+	}
+	if ea.ArchiveDir == "" || !filepath.IsAbs(ea.ArchiveDir) {
+		return FaultConfigBadEventArchive
+	}
+	if ea.Interval != 0 && ea.Interval < MinEventArchiveInterval {
+		return FaultConfigBadEventArchive
+	}
+	if ea.ArchiveAfter < 0 {
+		return FaultConfigBadEventArchive
+	}
+
+	return nil
+}
+
+const (
+	// DefaultMSBackupRetain is the default number of MS database backups
+	// kept in the backup directory.
+	DefaultMSBackupRetain = 7
+	// DefaultMSBackupUploadTimeout is the default period the MS leader waits
+	// for the upload script to copy a backup to a remote target.
+	DefaultMSBackupUploadTimeout = 10 * time.Minute
+)
+
+// MSBackup describes the backups of the MS database taken by the MS leader
+// on a cron schedule. Each backup is written to a new directory under the
+// backup directory, from which the database can be restored with
+// "daos_server ms restore", and is then optionally passed to an upload script
+// for copying to a remote target. Only the most recent backups are retained.
+// Unset values are replaced with defaults.
+type MSBackup struct {
+	Schedule      string        `yaml:"schedule"`
+	BackupDir     string        `yaml:"backup_dir"`
+	Retain        int           `yaml:"retain,omitempty"`
+	UploadScript  string        `yaml:"upload_script,omitempty"`
+	UploadTimeout time.Duration `yaml:"upload_timeout,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (mb *MSBackup) WithDefaults() *MSBackup {
+	out := new(MSBackup)
+	if mb != nil {
+		*out = *mb
+	}
+	if out.Retain == 0 {
+		out.Retain = DefaultMSBackupRetain
+	}
+	if out.UploadTimeout == 0 {
+		out.UploadTimeout = DefaultMSBackupUploadTimeout
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (mb *MSBackup) Validate() error {
+	if mb == nil {
+		return nil
+	}
+	if _, err := cron.Parse(mb.Schedule); err != nil {
+		return FaultConfigBadMSBackup(err)
+	}
+	if mb.BackupDir == "" || !filepath.IsAbs(mb.BackupDir) {
+		return FaultConfigBadMSBackup(errors.New("backup_dir must be an absolute path"))
+	}
+	if mb.Retain < 0 {
+		return FaultConfigBadMSBackup(errors.New("retain must not be negative"))
+	}
+	if mb.UploadScript != "" && !filepath.IsAbs(mb.UploadScript) {
+		return FaultConfigBadMSBackup(errors.New("upload_script must be an absolute path"))
+	}
+	if mb.UploadTimeout < 0 {
+		return FaultConfigBadMSBackup(errors.New("upload_timeout must not be negative"))
+	}
+
+	return nil
+}
+
+const (
+	// DefaultPoolSpaceWarnPercent is the default percentage of pool space
+	// used above which the MS leader reports a pool as under space pressure.
+	DefaultPoolSpaceWarnPercent = 90
+	// DefaultPoolSpaceHorizon is the default period within which a pool must
+	// be projected to fill for the MS leader to report it.
+	DefaultPoolSpaceHorizon = 7 * 24 * time.Hour
+	// DefaultPoolSpaceTrendWindow is the default period of pool usage history
+	// from which the MS leader projects the growth of each pool.
+	DefaultPoolSpaceTrendWindow = 24 * time.Hour
+	// MinPoolSpaceTrendWindow is the shortest trend window, as pool usage is
+	// only sampled hourly in health snapshots.
+	MinPoolSpaceTrendWindow = 2 * time.Hour
+)
+
+// PoolSpaceForecast describes the evaluation of pool space usage by the MS
+// leader. A pool whose usage exceeds the warning threshold is reported as
+// under space pressure, and a pool whose usage trend over the trend window
+// projects it to be full within the horizon is reported before it reaches the
+// threshold. Unset values are replaced with defaults.
+type PoolSpaceForecast struct {
+	Enabled     bool          `yaml:"enabled,omitempty"`
+	WarnPercent int           `yaml:"warn_percent,omitempty"`
+	Horizon     time.Duration `yaml:"horizon,omitempty"`
+	TrendWindow time.Duration `yaml:"trend_window,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (psf *PoolSpaceForecast) WithDefaults() *PoolSpaceForecast {
+	out := new(PoolSpaceForecast)
+	if psf != nil {
+		*out = *psf
+	}
+	if out.WarnPercent == 0 {
+		out.WarnPercent = DefaultPoolSpaceWarnPercent
+	}
+	if out.Horizon == 0 {
+		out.Horizon = DefaultPoolSpaceHorizon
+	}
+	if out.TrendWindow == 0 {
+		out.TrendWindow = DefaultPoolSpaceTrendWindow
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are out of range.
+func (psf *PoolSpaceForecast) Validate() error {
+	if psf == nil {
+		return nil
+	}
+	if psf.WarnPercent < 0 || psf.WarnPercent > 100 || psf.Horizon < 0 {
+		return FaultConfigBadPoolSpaceForecast
+	}
+	if psf.TrendWindow != 0 && psf.TrendWindow < MinPoolSpaceTrendWindow {
+		return FaultConfigBadPoolSpaceForecast
+	}
+	return nil
+}
+
+// Default rank quarantine policy parameters.
+const (
+	DefaultQuarantineThreshold = 3
+	DefaultQuarantineWindow    = time.Hour
+)
+
+// DefaultQuarantineEvents lists the RAS events counted against a rank by the
+// quarantine policy when none are specified.
+var DefaultQuarantineEvents = []string{
+	"corruption_detected",
+	"device_set_faulty",
+	"device_media_error",
+}
+
+// QuarantinePolicy describes the policy used by the MS leader to quarantine a
+// rank that raises the listed RAS events at least threshold times within the
+// window, so that a rank that keeps reporting faults is excluded from new pool
+// placements without administrator action. Unset values are replaced with
+// defaults.
+type QuarantinePolicy struct {
+	Enabled   bool          `yaml:"enabled,omitempty"`
+	Events    []string      `yaml:"events,omitempty"`
+	Threshold int           `yaml:"threshold,omitempty"`
+	Window    time.Duration `yaml:"window,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (qp *QuarantinePolicy) WithDefaults() *QuarantinePolicy {
+	out := new(QuarantinePolicy)
+	if qp != nil {
+		*out = *qp
+	}
+	if len(out.Events) == 0 {
+		out.Events = DefaultQuarantineEvents
+	}
+	if out.Threshold == 0 {
+		out.Threshold = DefaultQuarantineThreshold
+	}
+	if out.Window == 0 {
+		out.Window = DefaultQuarantineWindow
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (qp *QuarantinePolicy) Validate() error {
+	if qp == nil {
+		return nil
+	}
+	if qp.Threshold < 0 || qp.Window < 0 {
+		return FaultConfigBadQuarantinePolicy
+	}
+	for _, evt := range qp.Events {
+		if evt == "" {
+			return FaultConfigBadQuarantinePolicy
+		}
+	}
+	return nil
+}
+
+const (
+	// DefaultPoolOpPollInterval is the default period at which the MS leader
+	// checks whether the rebuilds of scheduled pool operations have completed.
+	DefaultPoolOpPollInterval = 30 * time.Second
+	// MinPoolOpPollInterval is the shortest period at which the MS leader
+	// checks for completed rebuilds.
+	MinPoolOpPollInterval = time.Second
+)
+
+// PoolOpScheduler describes the limits applied by the MS leader to the number
+// of rebuild-generating pool operations (reintegrate, extend and drain) that
+// run at the same time, both system-wide and for each rank. A zero limit is
+// unlimited. Unset values are replaced with defaults.
+type PoolOpScheduler struct {
+	MaxConcurrent int           `yaml:"max_concurrent,omitempty"`
+	MaxPerRank    int           `yaml:"max_per_rank,omitempty"`
+	PollInterval  time.Duration `yaml:"poll_interval,omitempty"`
+}
+
+// WithDefaults returns a copy of the parameters with unset values replaced
+// by defaults.
+func (pos *PoolOpScheduler) WithDefaults() *PoolOpScheduler {
+	out := new(PoolOpScheduler)
+	if pos != nil {
+		*out = *pos
+	}
+	if out.PollInterval == 0 {
+		out.PollInterval = DefaultPoolOpPollInterval
+	}
+	return out
+}
+
+// Validate returns an error if the parameters are invalid.
+func (pos *PoolOpScheduler) Validate() error {
+	if pos == nil {
+		return nil
+	}
+	if pos.MaxConcurrent < 0 || pos.MaxPerRank < 0 {
+		return FaultConfigBadPoolOpScheduler
+	}
+	if pos.PollInterval != 0 && pos.PollInterval < MinPoolOpPollInterval {
+		return FaultConfigBadPoolOpScheduler
+	}
+
+	return nil
+}
+
+// Provisioning describes the use of provisioning tokens, which servers must
+// present to the MS when joining the system for the first time.
+type Provisioning struct {
+	RequireToken bool   `yaml:"require_token,omitempty"`
+	TokenFile    string `yaml:"token_file,omitempty"`
+}
+
+// Validate returns an error if the parameters are invalid.
+func (p *Provisioning) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.TokenFile != "" && !filepath.IsAbs(p.TokenFile) {
+		return FaultConfigBadProvisioning
+	}
+
+	return nil
+}
+
+// ControlLogSubsystems maps daos_server subsystems to the log levels that
+// override control_log_mask for their messages.
+type ControlLogSubsystems map[string]common.ControlLogLevel
+
+// MaxMSElectionTier is the highest campaign delay tier that may be assigned
+// to an MS replica.
+const MaxMSElectionTier = 2
+
 // Server describes configuration options for DAOS control plane.
 // See utils/config/daos_server.yml for parameter descriptions.
 type Server struct {
 	// control-specific
-	ControlPort       int                       `yaml:"port"`
-	TransportConfig   *security.TransportConfig `yaml:"transport_config"`
-	Engines           []*engine.Config          `yaml:"engines"`
-	BdevExclude       []string                  `yaml:"bdev_exclude,omitempty"`
-	DisableVFIO       bool                      `yaml:"disable_vfio"`
-	DisableVMD        *bool                     `yaml:"disable_vmd"`
-	EnableHotplug     bool                      `yaml:"enable_hotplug"`
-	NrHugepages       int                       `yaml:"nr_hugepages"`        // total for all engines
-	SystemRamReserved int                       `yaml:"system_ram_reserved"` // total for all engines
-	DisableHugepages  bool                      `yaml:"disable_hugepages"`
-	ControlLogMask    common.ControlLogLevel    `yaml:"control_log_mask"`
-	ControlLogFile    string                    `yaml:"control_log_file,omitempty"`
-	ControlLogJSON    bool                      `yaml:"control_log_json,omitempty"`
-	HelperLogFile     string                    `yaml:"helper_log_file,omitempty"`
-	FWHelperLogFile   string                    `yaml:"firmware_helper_log_file,omitempty"`
-	FaultPath         string                    `yaml:"fault_path,omitempty"`
-	TelemetryPort     int                       `yaml:"telemetry_port,omitempty"`
-	CoreDumpFilter    uint8                     `yaml:"core_dump_filter,omitempty"`
-	ClientEnvVars     []string                  `yaml:"client_env_vars,omitempty"`
+	ControlPort         int                       `yaml:"port"`
+	TransportConfig     *security.TransportConfig `yaml:"transport_config"`
+	Engines             []*engine.Config          `yaml:"engines"`
+	BdevExclude         []string                  `yaml:"bdev_exclude,omitempty"`
+	DisableVFIO         bool                      `yaml:"disable_vfio"`
+	DisableVMD          *bool                     `yaml:"disable_vmd"`
+	EnableHotplug       bool                      `yaml:"enable_hotplug"`
+	EngineMountNs       bool                      `yaml:"engine_mount_namespaces,omitempty"`
+	AllowCrossNUMABdevs bool                      `yaml:"allow_cross_numa_bdevs,omitempty"`
+	NrHugepages         int                       `yaml:"nr_hugepages"`        // total for all engines
+	SystemRamReserved   int                       `yaml:"system_ram_reserved"` // total for all engines
+	DisableHugepages    bool                      `yaml:"disable_hugepages"`
+	ControlLogMask      common.ControlLogLevel    `yaml:"control_log_mask"`
+	ControlLogFile      string                    `yaml:"control_log_file,omitempty"`
+	ControlLogJSON      bool                      `yaml:"control_log_json,omitempty"`
+	ControlLogSyslog    bool                      `yaml:"control_log_syslog,omitempty"`
+	ControlLogSubsys    ControlLogSubsystems      `yaml:"control_log_subsystems,omitempty"`
+	HelperLogFile       string                    `yaml:"helper_log_file,omitempty"`
+	FWHelperLogFile     string                    `yaml:"firmware_helper_log_file,omitempty"`
+	FaultPath           string                    `yaml:"fault_path,omitempty"`
+	TelemetryPort       int                       `yaml:"telemetry_port,omitempty"`
+	RemoteWrite         *TelemetryRemoteWrite     `yaml:"telemetry_remote_write,omitempty"`
+	DisablePortCheck    bool                      `yaml:"disable_port_check,omitempty"`
+	GrpcReflection      bool                      `yaml:"enable_grpc_reflection,omitempty"`
+	CoreDumpFilter      uint8                     `yaml:"core_dump_filter,omitempty"`
+	ClientEnvVars       []string                  `yaml:"client_env_vars,omitempty"`
+	FlapDamping         *system.FlapDamping       `yaml:"flap_damping,omitempty"`
+	MemoryWatchdog      *MemoryWatchdog           `yaml:"memory_watchdog,omitempty"`
+	EventDedupe         *EventDedupe              `yaml:"event_dedupe,omitempty"`
+	JoinAdmission       *JoinAdmission            `yaml:"join_admission,omitempty"`
+	JoinRateLimit       *JoinRateLimit            `yaml:"join_rate_limit,omitempty"`
+	AutoReintegrate     *AutoReintegrate          `yaml:"auto_reintegrate,omitempty"`
+	BdevTrim            *BdevTrim                 `yaml:"bdev_trim,omitempty"`
+	MSElectionTier      uint                      `yaml:"ms_election_tier,omitempty"`
+	ControlProfiling    *profiling.Config         `yaml:"control_profiling,omitempty"`
+	Tracing             *Tracing                  `yaml:"tracing,omitempty"`
+	HealthReport        *HealthReport             `yaml:"health_report,omitempty"`
+	EngineLogBump       *EngineLogBump            `yaml:"engine_log_bump,omitempty"`
+	EventArchive        *EventArchive             `yaml:"event_archive,omitempty"`
+	MSBackup            *MSBackup                 `yaml:"ms_backup,omitempty"`
+	PoolSpaceForecast   *PoolSpaceForecast        `yaml:"pool_space_forecast,omitempty"`
+	QuarantinePolicy    *QuarantinePolicy         `yaml:"quarantine_policy,omitempty"`
+	DrpcSockets         []*drpc.SocketConfig      `yaml:"drpc_sockets,omitempty"`
+	PoolOpScheduler     *PoolOpScheduler          `yaml:"pool_op_scheduler,omitempty"`
+	ACLPrincipalCheck   bool                      `yaml:"acl_principal_check,omitempty"`
+	Provisioning        *Provisioning             `yaml:"provisioning,omitempty"`
 
 	// duplicated in engine.Config
 	SystemName string              `yaml:"name"`
@@ -70,6 +839,10 @@ type Server struct {
 
 	Metadata storage.ControlMetadata `yaml:"control_metadata,omitempty"`
 
+	EncryptionKey *storage.EncryptionKeyConfig `yaml:"encryption_key,omitempty"`
+
+	FabricIfaceFilter *hardware.FabricInterfaceFilter `yaml:"fabric_iface_filter,omitempty"`
+
 	// unused (?)
 	FaultCb      string `yaml:"fault_cb"`
 	Hyperthreads bool   `yaml:"hyperthreads"`
@@ -77,7 +850,8 @@ type Server struct {
 	Path string `yaml:"-"` // path to config file
 
 	// Behavior flags
-	AutoFormat bool `yaml:"-"`
+	AutoFormat      bool `yaml:"-"`
+	SimulateEngines int  `yaml:"-"` // number of simulated engines, 0 to run real engines
 }
 
 // WithCoreDumpFilter sets the core dump filter written to /proc/self/coredump_filter.
@@ -163,6 +937,12 @@ func (cfg *Server) WithControlMetadata(md storage.ControlMetadata) *Server {
 	return cfg
 }
 
+// WithEncryptionKey sets the source of the key used to unlock encrypted storage.
+func (cfg *Server) WithEncryptionKey(ekc *storage.EncryptionKeyConfig) *Server {
+	cfg.EncryptionKey = ekc
+	return cfg
+}
+
 // NB: In order to ease maintenance, the set of chained config functions
 // which modify nested engine configurations should be kept above this
 // one as a reference for which things should be set/updated in the next
@@ -183,6 +963,7 @@ func (cfg *Server) updateServerConfig(cfgPtr **engine.Config) {
 	engineCfg.SocketDir = cfg.SocketDir
 	engineCfg.Modules = cfg.Modules
 	engineCfg.Storage.EnableHotplug = cfg.EnableHotplug
+	engineCfg.PrivateMountNamespace = cfg.EngineMountNs
 }
 
 // WithEngines sets the list of engine configurations.
@@ -251,6 +1032,23 @@ func (cfg *Server) WithEnableHotplug(enabled bool) *Server {
 	return cfg
 }
 
+// WithEngineMountNamespaces sets whether each engine runs in its own mount
+// namespace.
+func (cfg *Server) WithEngineMountNamespaces(enabled bool) *Server {
+	cfg.EngineMountNs = enabled
+	for _, engine := range cfg.Engines {
+		engine.WithPrivateMountNamespace(enabled)
+	}
+	return cfg
+}
+
+// WithAllowCrossNUMABdevs can be used to permit engines to use NVMe SSDs
+// that are attached to a different NUMA node.
+func (cfg *Server) WithAllowCrossNUMABdevs(allowed bool) *Server {
+	cfg.AllowCrossNUMABdevs = allowed
+	return cfg
+}
+
 // WithHyperthreads enables or disables hyperthread support.
 func (cfg *Server) WithHyperthreads(enabled bool) *Server {
 	cfg.Hyperthreads = enabled
@@ -276,6 +1074,166 @@ func (cfg *Server) WithSystemRamReserved(nr int) *Server {
 	return cfg
 }
 
+// WithFlapDamping sets the parameters used to hold repeatedly failing ranks
+// in the Unstable state.
+func (cfg *Server) WithFlapDamping(threshold int, window time.Duration) *Server {
+	cfg.FlapDamping = &system.FlapDamping{
+		Threshold: threshold,
+		Window:    window,
+	}
+	return cfg
+}
+
+// WithMemoryWatchdog sets the parameters used to monitor memory pressure on
+// hosts running engines with ram-class SCM.
+func (cfg *Server) WithMemoryWatchdog(mw *MemoryWatchdog) *Server {
+	cfg.MemoryWatchdog = mw
+	return cfg
+}
+
+// WithEventDedupe sets the parameters used to collapse repeated identical
+// events on the MS leader.
+func (cfg *Server) WithEventDedupe(ed *EventDedupe) *Server {
+	cfg.EventDedupe = ed
+	return cfg
+}
+
+// WithJoinAdmission sets the parameters of the hook consulted by the MS
+// leader before admitting a rank to the system.
+func (cfg *Server) WithJoinAdmission(ja *JoinAdmission) *Server {
+	cfg.JoinAdmission = ja
+	return cfg
+}
+
+// WithJoinRateLimit sets the parameters used by the MS leader to limit the
+// rate at which join requests are processed.
+func (cfg *Server) WithJoinRateLimit(jl *JoinRateLimit) *Server {
+	cfg.JoinRateLimit = jl
+	return cfg
+}
+
+// WithAutoReintegrate sets the policy used by the MS leader to reintegrate
+// ranks that rejoin the system after being excluded.
+func (cfg *Server) WithAutoReintegrate(ar *AutoReintegrate) *Server {
+	cfg.AutoReintegrate = ar
+	return cfg
+}
+
+// WithBdevTrim sets the parameters used to schedule TRIM passes on engine
+// NVMe bdevs.
+func (cfg *Server) WithBdevTrim(bt *BdevTrim) *Server {
+	cfg.BdevTrim = bt
+	return cfg
+}
+
+// WithControlProfiling sets the configuration of the control plane profiling server.
+func (cfg *Server) WithControlProfiling(pc *profiling.Config) *Server {
+	cfg.ControlProfiling = pc
+	return cfg
+}
+
+// WithTracing sets the parameters used to export spans for traced requests.
+func (cfg *Server) WithTracing(tr *Tracing) *Server {
+	cfg.Tracing = tr
+	return cfg
+}
+
+// WithHealthReport sets the parameters used by the MS leader to generate
+// periodic health reports.
+func (cfg *Server) WithHealthReport(hr *HealthReport) *Server {
+	cfg.HealthReport = hr
+	return cfg
+}
+
+// WithEngineLogBump sets the policy used to temporarily raise engine log
+// masks when faults are detected.
+func (cfg *Server) WithEngineLogBump(lb *EngineLogBump) *Server {
+	cfg.EngineLogBump = lb
+	return cfg
+}
+
+// WithEventArchive sets the parameters used by the MS leader to archive
+// aged-out persistent events.
+func (cfg *Server) WithEventArchive(ea *EventArchive) *Server {
+	cfg.EventArchive = ea
+	return cfg
+}
+
+// WithMSBackup sets the parameters used by the MS leader to take scheduled
+// backups of the MS database.
+func (cfg *Server) WithMSBackup(mb *MSBackup) *Server {
+	cfg.MSBackup = mb
+	return cfg
+}
+
+// WithPoolSpaceForecast sets the parameters used by the MS leader to report
+// pools that are under space pressure or projected to fill.
+func (cfg *Server) WithPoolSpaceForecast(psf *PoolSpaceForecast) *Server {
+	cfg.PoolSpaceForecast = psf
+	return cfg
+}
+
+// WithQuarantinePolicy sets the policy used by the MS leader to quarantine
+// ranks that repeatedly raise fault events.
+func (cfg *Server) WithQuarantinePolicy(qp *QuarantinePolicy) *Server {
+	cfg.QuarantinePolicy = qp
+	return cfg
+}
+
+// WithDrpcSockets sets the sockets on which the dRPC server exposes its
+// modules in addition to, or instead of, the default socket.
+func (cfg *Server) WithDrpcSockets(sockets ...*drpc.SocketConfig) *Server {
+	cfg.DrpcSockets = sockets
+	return cfg
+}
+
+// WithPoolOpScheduler sets the limits applied by the MS leader to concurrent
+// rebuild-generating pool operations.
+func (cfg *Server) WithPoolOpScheduler(pos *PoolOpScheduler) *Server {
+	cfg.PoolOpScheduler = pos
+	return cfg
+}
+
+// WithACLPrincipalCheck sets whether user and group principals in pool ACL
+// entries are looked up in the local directory before the ACL is modified.
+func (cfg *Server) WithACLPrincipalCheck(enabled bool) *Server {
+	cfg.ACLPrincipalCheck = enabled
+	return cfg
+}
+
+// WithProvisioning sets the parameters for provisioning tokens presented by
+// servers joining the system for the first time.
+func (cfg *Server) WithProvisioning(p *Provisioning) *Server {
+	cfg.Provisioning = p
+	return cfg
+}
+
+// WithFabricIfaceFilter sets the patterns used to exclude interfaces from
+// fabric scans.
+func (cfg *Server) WithFabricIfaceFilter(filter *hardware.FabricInterfaceFilter) *Server {
+	cfg.FabricIfaceFilter = filter
+	return cfg
+}
+
+// FabricScanFilter returns the filter to be applied to fabric scans, which
+// always includes the interfaces assigned to engines in the config.
+func (cfg *Server) FabricScanFilter() *hardware.FabricInterfaceFilter {
+	var ifaces []string
+	for _, ec := range cfg.Engines {
+		if names, err := ec.Fabric.GetInterfaces(); err == nil {
+			ifaces = append(ifaces, names...)
+		}
+	}
+
+	return cfg.FabricIfaceFilter.WithInclude(ifaces...)
+}
+
+// WithMSElectionTier sets the campaign delay tier of the local MS replica.
+func (cfg *Server) WithMSElectionTier(tier uint) *Server {
+	cfg.MSElectionTier = tier
+	return cfg
+}
+
 // WithControlLogMask sets the daos_server log level.
 func (cfg *Server) WithControlLogMask(lvl common.ControlLogLevel) *Server {
 	cfg.ControlLogMask = lvl
@@ -294,6 +1252,21 @@ func (cfg *Server) WithControlLogJSON(enabled bool) *Server {
 	return cfg
 }
 
+// WithControlLogSyslog enables or disables output to syslog.
+func (cfg *Server) WithControlLogSyslog(enabled bool) *Server {
+	cfg.ControlLogSyslog = enabled
+	return cfg
+}
+
+// WithControlLogSubsystem sets the log level for a daos_server subsystem.
+func (cfg *Server) WithControlLogSubsystem(name string, lvl common.ControlLogLevel) *Server {
+	if cfg.ControlLogSubsys == nil {
+		cfg.ControlLogSubsys = make(ControlLogSubsystems)
+	}
+	cfg.ControlLogSubsys[name] = lvl
+	return cfg
+}
+
 // WithHelperLogFile sets the path to the daos_server_helper logfile.
 func (cfg *Server) WithHelperLogFile(filePath string) *Server {
 	cfg.HelperLogFile = filePath
@@ -306,12 +1279,30 @@ func (cfg *Server) WithFirmwareHelperLogFile(filePath string) *Server {
 	return cfg
 }
 
+// WithRemoteWrite sets the remote-write receiver that metrics are pushed to.
+func (cfg *Server) WithRemoteWrite(rw *TelemetryRemoteWrite) *Server {
+	cfg.RemoteWrite = rw
+	return cfg
+}
+
 // WithTelemetryPort sets the port for the telemetry exporter.
 func (cfg *Server) WithTelemetryPort(port int) *Server {
 	cfg.TelemetryPort = port
 	return cfg
 }
 
+// WithDisablePortCheck disables the startup check of port reachability.
+func (cfg *Server) WithDisablePortCheck(disabled bool) *Server {
+	cfg.DisablePortCheck = disabled
+	return cfg
+}
+
+// WithGrpcReflection enables the gRPC server reflection service.
+func (cfg *Server) WithGrpcReflection(enabled bool) *Server {
+	cfg.GrpcReflection = enabled
+	return cfg
+}
+
 // DefaultServer creates a new instance of configuration struct
 // populated with defaults.
 func DefaultServer() *Server {
@@ -342,7 +1333,18 @@ func (cfg *Server) Load() error {
 		return errors.WithMessage(err, "reading file")
 	}
 
-	if err = yaml.UnmarshalStrict(bytes, cfg); err != nil {
+	return cfg.Parse(bytes)
+}
+
+// Parse reads the serialized configuration and validates its syntax.
+func (cfg *Server) Parse(bytes []byte) error {
+	// Report unknown keys, type errors and invalid combinations of settings
+	// with their location in the file before attempting to decode.
+	if err := yamlcheck.Check(cfg.Path, bytes, cfg, serverConfigRules...); err != nil {
+		return err
+	}
+
+	if err := yaml.UnmarshalStrict(bytes, cfg); err != nil {
 		return errors.WithMessagef(err, "parse of %q failed; config contains invalid "+
 			"parameters and may be out of date, see server config examples",
 			cfg.Path)
@@ -663,11 +1665,122 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 	if cfg.Metadata.DevicePath != "" && cfg.Metadata.Path == "" {
 		return FaultConfigControlMetadataNoPath
 	}
+	if cfg.Metadata.MirrorPath != "" {
+		if !cfg.Metadata.HasPath() || isSubPath(cfg.Metadata.Path, cfg.Metadata.MirrorPath) ||
+			isSubPath(cfg.Metadata.MirrorPath, cfg.Metadata.Path) {
+			return FaultConfigControlMetadataBadMirror
+		}
+	}
+
+	if cfg.EncryptionKey != nil {
+		if err := cfg.EncryptionKey.Validate(); err != nil {
+			return FaultConfigBadEncryptionKey(err)
+		}
+	}
+
+	if err := cfg.FabricIfaceFilter.Validate(); err != nil {
+		return FaultConfigBadFabricIfaceFilter(err)
+	}
 
 	if cfg.SystemRamReserved <= 0 {
 		return FaultConfigSysRsvdZero
 	}
 
+	if fd := cfg.FlapDamping; fd != nil && (fd.Threshold < 0 || fd.Window < 0 ||
+		(fd.Threshold > 0 && fd.Window == 0)) {
+		return FaultConfigBadFlapDamping
+	}
+
+	if err := cfg.MemoryWatchdog.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.EventDedupe.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.JoinAdmission.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.RemoteWrite.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.JoinRateLimit.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.AutoReintegrate.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.HealthReport.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.EngineLogBump.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.EventArchive.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.MSBackup.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.PoolSpaceForecast.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.QuarantinePolicy.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.PoolOpScheduler.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Provisioning.Validate(); err != nil {
+		return err
+	}
+
+	drpcPaths := make(map[string]bool)
+	for _, sc := range cfg.DrpcSockets {
+		if err := sc.Validate(drpc.ModuleSecurity, drpc.ModuleMgmt, drpc.ModuleSrv); err != nil {
+			log.Errorf("drpc_sockets: %s", err)
+			return FaultConfigBadDrpcSocket
+		}
+		if drpcPaths[sc.Path] {
+			return FaultConfigBadDrpcSocket
+		}
+		drpcPaths[sc.Path] = true
+	}
+
+	if err := cfg.BdevTrim.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.MSElectionTier > MaxMSElectionTier {
+		return FaultConfigBadMSElectionTier
+	}
+
+	for name := range cfg.ControlLogSubsys {
+		if err := logging.ValidateSubsystem(name); err != nil {
+			return FaultConfigBadControlLogSubsystem(name)
+		}
+	}
+
+	if err := cfg.ControlProfiling.Validate(); err != nil {
+		return FaultConfigBadControlProfiling(err)
+	}
+
 	// A config without engines is valid when initially discovering hardware prior to adding
 	// per-engine sections with device allocations.
 	if len(cfg.Engines) == 0 {
@@ -694,10 +1807,16 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 		return FaultConfigBadControlPort
 	case cfg.TelemetryPort < 0:
 		return FaultConfigBadTelemetryPort
+	case cfg.RemoteWrite != nil && cfg.TelemetryPort == 0:
+		return FaultConfigTelemetryRemoteWriteNoPort
 	}
 
 	for idx, ec := range cfg.Engines {
 		ec.Storage.ControlMetadata = cfg.Metadata
+		ec.Storage.EncryptionKey = cfg.EncryptionKey
+		if ec.Storage.Tiers.HaveEncryption() && cfg.EncryptionKey == nil {
+			return FaultConfigEncryptionNoKey
+		}
 		ec.Storage.EngineIdx = uint(idx)
 		ec.Fabric.Update(cfg.Fabric)
 
@@ -910,3 +2029,9 @@ func (cfg *Server) SetEngineAffinities(log logging.Logger, affSources ...EngineA
 
 	return nil
 }
+
+// isSubPath returns true if path is the same as or lexically within base.
+func isSubPath(base, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(base), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -25,6 +26,9 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/engine"
@@ -237,16 +241,33 @@ func TestServerConfig_Constructed(t *testing.T) {
 		WithControlMetadata(storage.ControlMetadata{
 			Path:       "/home/daos_server/control_meta",
 			DevicePath: "/dev/sdb1",
+			MirrorPath: "/var/daos/control_meta_mirror",
+		}).
+		WithEncryptionKey(&storage.EncryptionKeyConfig{
+			File: "/etc/daos/daos_storage.key",
 		}).
 		WithBdevExclude("0000:81:00.1").
 		WithDisableVFIO(true).   // vfio enabled by default
 		WithDisableVMD(true).    // vmd enabled by default
 		WithEnableHotplug(true). // hotplug disabled by default
+		WithEngineMountNamespaces(true).
+		WithAllowCrossNUMABdevs(true).
 		WithControlLogMask(common.ControlLogLevelError).
 		WithControlLogFile("/tmp/daos_server.log").
+		WithControlLogSubsystem(logging.SubsystemRaft, common.ControlLogLevelDebug).
+		WithControlLogSyslog(true).
 		WithHelperLogFile("/tmp/daos_server_helper.log").
 		WithFirmwareHelperLogFile("/tmp/daos_firmware_helper.log").
 		WithTelemetryPort(9191).
+		WithRemoteWrite(&TelemetryRemoteWrite{
+			Endpoint:        "https://mimir.example.com/api/v1/push",
+			PushInterval:    30 * time.Second,
+			MaxPending:      10,
+			BearerTokenFile: "/etc/daos/remote_write.token",
+			ExternalLabels:  map[string]string{"cluster": "daos1"},
+		}).
+		WithDisablePortCheck(true). // port check enabled by default
+		WithGrpcReflection(true).
 		WithSystemName("daos_server").
 		WithSocketDir("./.daos/daos_server").
 		WithFabricProvider("ofi+verbs;ofi_rxm").
@@ -257,7 +278,87 @@ func TestServerConfig_Constructed(t *testing.T) {
 		WithClientEnvVars([]string{"foo=bar"}).
 		WithFabricAuthKey("foo:bar").
 		WithHyperthreads(true). // hyper-threads disabled by default
-		WithSystemRamReserved(5)
+		WithSystemRamReserved(5).
+		WithFlapDamping(3, 30*time.Minute).
+		WithMemoryWatchdog(&MemoryWatchdog{
+			Interval:            time.Minute,
+			RamdiskWarnPercent:  85,
+			MemAvailWarnPercent: 15,
+			MemAvailStopPercent: 5,
+		}).
+		WithEventDedupe(&EventDedupe{}). // window is a duplicate key, skipped when uncommenting
+		WithJoinAdmission(&JoinAdmission{
+			URL:     "https://admission.example.com/daos/join",
+			Timeout: 5 * time.Second,
+		}).
+		WithJoinRateLimit(&JoinRateLimit{
+			MaxPerBatch: 128,
+			MaxQueued:   2048,
+			RetryAfter:  5 * time.Second,
+		}).
+		WithAutoReintegrate(&AutoReintegrate{ // enabled is a duplicate key, skipped when uncommenting
+			StabilityWindow: 10 * time.Minute,
+		}).
+		WithBdevTrim(&BdevTrim{ // interval is a duplicate key, skipped when uncommenting
+			Windows:   []string{"Sat,Sun 01:00-05:00", "23:30-00:30"},
+			MaxBlocks: 1048576,
+		}).
+		WithMSElectionTier(1).
+		WithControlProfiling(&profiling.Config{
+			Enabled: true,
+			Address: "localhost:6060",
+		}).
+		WithTracing(&Tracing{OTLPEndpoint: "http://collector:4318"}).
+		WithHealthReport(&HealthReport{ // interval is a duplicate key, skipped when uncommenting
+			Format:    "html",
+			OutputDir: "/var/log/daos/reports",
+			SMTP: &HealthReportSMTP{
+				Server: "smtp.example.com:25",
+				From:   "daos@example.com",
+				To:     []string{"storage-admins@example.com"},
+			},
+		}).
+		WithEngineLogBump(&EngineLogBump{ // enabled is a duplicate key, skipped when uncommenting
+			Events:   []string{"corruption_detected", "device_set_faulty"},
+			LogMask:  "DEBUG",
+			Streams:  "csum,io",
+			Duration: 10 * time.Minute,
+		}).
+		WithEventArchive(&EventArchive{ // interval is a duplicate key, skipped when uncommenting
+			ArchiveDir:   "/var/log/daos/events",
+			ArchiveAfter: 2160 * time.Hour,
+		}).
+		WithMSBackup(&MSBackup{
+			Schedule:      "0 2 * * *",
+			BackupDir:     "/var/lib/daos/ms-backups",
+			Retain:        14,
+			UploadScript:  "/usr/local/bin/daos-ms-backup-upload",
+			UploadTimeout: 30 * time.Minute,
+		}).
+		WithPoolSpaceForecast(&PoolSpaceForecast{ // enabled is a duplicate key, skipped when uncommenting
+			WarnPercent: 85,
+			Horizon:     14 * 24 * time.Hour,
+			TrendWindow: 48 * time.Hour,
+		}).
+		WithQuarantinePolicy(&QuarantinePolicy{ // enabled is a duplicate key, skipped when uncommenting
+			Events:    []string{"device_set_faulty", "device_media_error"},
+			Threshold: 2,
+			Window:    30 * time.Minute,
+		}).
+		WithPoolOpScheduler(&PoolOpScheduler{
+			MaxConcurrent: 2,
+			MaxPerRank:    1,
+			PollInterval:  30 * time.Second,
+		}).
+		WithACLPrincipalCheck(true).
+		WithProvisioning(&Provisioning{
+			RequireToken: true,
+			TokenFile:    "/etc/daos/provisioning.token",
+		}).
+		WithFabricIfaceFilter(&hardware.FabricInterfaceFilter{
+			Exclude: []string{"eth0.*", "enp*s0f3"},
+			Include: []string{"eno1"},
+		})
 
 	// add engines explicitly to test functionality applied in WithEngines()
 	constructed.Engines = []*engine.Config{
@@ -287,9 +388,12 @@ func TestServerConfig_Constructed(t *testing.T) {
 			WithBypassHealthChk(&bypass).
 			WithEnvVars("CRT_TIMEOUT=30").
 			WithLogFile("/tmp/daos_engine.0.log").
+			WithStartupTimeout(10*time.Minute).
+			WithStartupRestarts(1).
 			WithLogMask("INFO").
 			WithStorageEnableHotplug(true).
-			WithStorageAutoFaultyCriteria(true, 100, 200),
+			WithStorageAutoFaultyCriteria(true, 100, 200).
+			WithPrivateMountNamespace(true),
 		engine.MockConfig().
 			WithSystemName("daos_server").
 			WithSocketDir("./.daos/daos_server").
@@ -314,9 +418,12 @@ func TestServerConfig_Constructed(t *testing.T) {
 			WithBypassHealthChk(&bypass).
 			WithEnvVars("CRT_TIMEOUT=100").
 			WithLogFile("/tmp/daos_engine.1.log").
+			WithStartupTimeout(10*time.Minute).
+			WithStartupRestarts(1).
 			WithLogMask("INFO").
 			WithStorageEnableHotplug(true).
-			WithStorageAutoFaultyCriteria(false, 0, 0),
+			WithStorageAutoFaultyCriteria(false, 0, 0).
+			WithPrivateMountNamespace(true),
 	}
 	constructed.Path = testFile // just to avoid failing the cmp
 
@@ -349,6 +456,7 @@ func TestServerConfig_updateServerConfig(t *testing.T) {
 				SocketDir:     "socketdir",
 				Modules:       "modules",
 				EnableHotplug: true,
+				EngineMountNs: true,
 				Fabric: engine.FabricConfig{
 					Provider:              "provider",
 					Interface:             "iface",
@@ -363,6 +471,7 @@ func TestServerConfig_updateServerConfig(t *testing.T) {
 				Storage: storage.Config{
 					EnableHotplug: true,
 				},
+				PrivateMountNamespace: true,
 				Fabric: engine.FabricConfig{
 					Provider:              "provider",
 					Interface:             "iface",
@@ -476,6 +585,9 @@ func TestServerConfig_Validation(t *testing.T) {
 	uncommentServerConfig(t, testFile)
 
 	testMetadataDir := filepath.Join(testDir, "control_md")
+	exampleEncryptionKey := &storage.EncryptionKeyConfig{
+		File: "/etc/daos/daos_storage.key",
+	}
 
 	noopExtra := func(c *Server) *Server { return c }
 
@@ -608,7 +720,7 @@ func TestServerConfig_Validation(t *testing.T) {
 		},
 		"good telemetry port (zero)": {
 			extraConfig: func(c *Server) *Server {
-				return c.WithTelemetryPort(0)
+				return c.WithTelemetryPort(0).WithRemoteWrite(nil)
 			},
 		},
 		"bad telemetry port (negative)": {
@@ -668,6 +780,516 @@ func TestServerConfig_Validation(t *testing.T) {
 			},
 			expErr: FaultConfigSysRsvdZero,
 		},
+		"good flap damping": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFlapDamping(3, time.Hour)
+			},
+		},
+		"flap damping threshold with no window": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFlapDamping(3, 0)
+			},
+			expErr: FaultConfigBadFlapDamping,
+		},
+		"flap damping negative threshold": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFlapDamping(-1, time.Hour)
+			},
+			expErr: FaultConfigBadFlapDamping,
+		},
+		"good memory watchdog": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMemoryWatchdog(&MemoryWatchdog{MemAvailStopPercent: 5})
+			},
+		},
+		"memory watchdog percentage out of range": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMemoryWatchdog(&MemoryWatchdog{RamdiskWarnPercent: 101})
+			},
+			expErr: FaultConfigBadMemoryWatchdog,
+		},
+		"memory watchdog stop above warn": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMemoryWatchdog(&MemoryWatchdog{
+					MemAvailWarnPercent: 5,
+					MemAvailStopPercent: 10,
+				})
+			},
+			expErr: FaultConfigBadMemoryWatchdog,
+		},
+		"memory watchdog negative interval": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMemoryWatchdog(&MemoryWatchdog{Interval: -time.Second})
+			},
+			expErr: FaultConfigBadMemoryWatchdog,
+		},
+		"good event dedupe": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventDedupe(&EventDedupe{Window: time.Minute})
+			},
+		},
+		"event dedupe negative window": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventDedupe(&EventDedupe{Window: -time.Second})
+			},
+			expErr: FaultConfigBadEventDedupe,
+		},
+		"good tracing endpoint": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTracing(&Tracing{OTLPEndpoint: "http://collector:4318"})
+			},
+		},
+		"tracing endpoint without scheme": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTracing(&Tracing{OTLPEndpoint: "collector:4318"})
+			},
+			expErr: FaultConfigBadTracing,
+		},
+		"good telemetry remote write": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(9191).
+					WithRemoteWrite(&TelemetryRemoteWrite{Endpoint: "http://mimir:9009/api/v1/push"})
+			},
+		},
+		"telemetry remote write without telemetry port": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(0).
+					WithRemoteWrite(&TelemetryRemoteWrite{Endpoint: "http://mimir:9009/api/v1/push"})
+			},
+			expErr: FaultConfigTelemetryRemoteWriteNoPort,
+		},
+		"telemetry remote write bad endpoint": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(9191).
+					WithRemoteWrite(&TelemetryRemoteWrite{Endpoint: "mimir:9009"})
+			},
+			expErr: FaultConfigBadTelemetryRemoteWrite,
+		},
+		"telemetry remote write relative token file": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(9191).
+					WithRemoteWrite(&TelemetryRemoteWrite{
+						Endpoint:        "http://mimir:9009/api/v1/push",
+						BearerTokenFile: "token",
+					})
+			},
+			expErr: FaultConfigBadTelemetryRemoteWrite,
+		},
+		"good join admission url": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{URL: "https://example.com/join"})
+			},
+		},
+		"good join admission script": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{Script: "/usr/local/bin/admit"})
+			},
+		},
+		"join admission without hook": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{Timeout: time.Second})
+			},
+			expErr: FaultConfigBadJoinAdmission,
+		},
+		"join admission with url and script": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{
+					URL:    "https://example.com/join",
+					Script: "/usr/local/bin/admit",
+				})
+			},
+			expErr: FaultConfigBadJoinAdmission,
+		},
+		"join admission bad url scheme": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{URL: "ftp://example.com/join"})
+			},
+			expErr: FaultConfigBadJoinAdmission,
+		},
+		"join admission relative script": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{Script: "admit.sh"})
+			},
+			expErr: FaultConfigBadJoinAdmission,
+		},
+		"good bdev trim": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithBdevTrim(&BdevTrim{
+					Windows:  []string{"Sat,Sun 01:00-05:00"},
+					Interval: time.Hour,
+				})
+			},
+		},
+		"bdev trim bad window": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithBdevTrim(&BdevTrim{Windows: []string{"Someday 01:00-05:00"}})
+			},
+			expErr: FaultConfigBadBdevTrim(errors.New(`invalid day "Someday" in trim window "Someday 01:00-05:00"`)),
+		},
+		"bdev trim negative interval": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithBdevTrim(&BdevTrim{
+					Windows:  []string{"01:00-05:00"},
+					Interval: -time.Hour,
+				})
+			},
+			expErr: FaultConfigBadBdevTrim(errors.New("interval must not be negative")),
+		},
+		"join admission negative timeout": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinAdmission(&JoinAdmission{
+					URL:     "https://example.com/join",
+					Timeout: -time.Second,
+				})
+			},
+			expErr: FaultConfigBadJoinAdmission,
+		},
+		"good join rate limit": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinRateLimit(&JoinRateLimit{MaxPerBatch: 64, MaxQueued: 512})
+			},
+		},
+		"join rate limit negative retry": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinRateLimit(&JoinRateLimit{RetryAfter: -time.Second})
+			},
+			expErr: FaultConfigBadJoinRateLimit,
+		},
+		"join rate limit queue smaller than batch": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinRateLimit(&JoinRateLimit{MaxPerBatch: 64, MaxQueued: 32})
+			},
+			expErr: FaultConfigBadJoinRateLimit,
+		},
+		"join rate limit batch above default queue": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithJoinRateLimit(&JoinRateLimit{MaxPerBatch: DefaultJoinMaxQueued + 1})
+			},
+			expErr: FaultConfigBadJoinRateLimit,
+		},
+		"good auto reintegrate": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithAutoReintegrate(&AutoReintegrate{Enabled: true})
+			},
+		},
+		"auto reintegrate negative window": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithAutoReintegrate(&AutoReintegrate{
+					Enabled:         true,
+					StabilityWindow: -time.Minute,
+				})
+			},
+			expErr: FaultConfigBadAutoReintegrate,
+		},
+		"good health report": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthReport(&HealthReport{OutputDir: "/var/log/daos"})
+			},
+		},
+		"health report without destination": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthReport(&HealthReport{Format: HealthReportFormatHTML})
+			},
+			expErr: FaultConfigBadHealthReport,
+		},
+		"health report interval too short": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthReport(&HealthReport{
+					Interval:  time.Minute,
+					OutputDir: "/var/log/daos",
+				})
+			},
+			expErr: FaultConfigBadHealthReport,
+		},
+		"health report bad format": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthReport(&HealthReport{
+					Format:    "pdf",
+					OutputDir: "/var/log/daos",
+				})
+			},
+			expErr: FaultConfigBadHealthReport,
+		},
+		"health report bad smtp server": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthReport(&HealthReport{
+					SMTP: &HealthReportSMTP{
+						Server: "smtp.example.com",
+						From:   "daos@example.com",
+						To:     []string{"admin@example.com"},
+					},
+				})
+			},
+			expErr: FaultConfigBadHealthReport,
+		},
+		"good engine log bump": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEngineLogBump(&EngineLogBump{
+					Enabled: true,
+					LogMask: "ERR,CSUM=DEBUG",
+				})
+			},
+		},
+		"engine log bump negative duration": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEngineLogBump(&EngineLogBump{
+					Enabled:  true,
+					Duration: -time.Minute,
+				})
+			},
+			expErr: FaultConfigBadEngineLogBump,
+		},
+		"engine log bump bad mask": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEngineLogBump(&EngineLogBump{
+					Enabled: true,
+					LogMask: "LOUD",
+				})
+			},
+			expErr: FaultConfigBadEngineLogBump,
+		},
+		"good event archive": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventArchive(&EventArchive{ArchiveDir: "/var/log/daos/events"})
+			},
+		},
+		"event archive without output dir": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventArchive(&EventArchive{ArchiveAfter: time.Hour})
+			},
+			expErr: FaultConfigBadEventArchive,
+		},
+		"event archive relative output dir": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventArchive(&EventArchive{ArchiveDir: "events"})
+			},
+			expErr: FaultConfigBadEventArchive,
+		},
+		"event archive interval too short": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventArchive(&EventArchive{
+					ArchiveDir: "/var/log/daos/events",
+					Interval:   time.Minute,
+				})
+			},
+			expErr: FaultConfigBadEventArchive,
+		},
+		"good ms backup": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSBackup(&MSBackup{
+					Schedule:  "@daily",
+					BackupDir: "/var/lib/daos/ms-backups",
+				})
+			},
+		},
+		"ms backup bad schedule": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSBackup(&MSBackup{
+					Schedule:  "0 25 * * *",
+					BackupDir: "/var/lib/daos/ms-backups",
+				})
+			},
+			expErr: FaultConfigBadMSBackup(errors.New(`invalid schedule "0 25 * * *": invalid hour "25" (expected 0-23)`)),
+		},
+		"ms backup relative backup dir": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSBackup(&MSBackup{
+					Schedule:  "@daily",
+					BackupDir: "ms-backups",
+				})
+			},
+			expErr: FaultConfigBadMSBackup(errors.New("backup_dir must be an absolute path")),
+		},
+		"ms backup relative upload script": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSBackup(&MSBackup{
+					Schedule:     "@daily",
+					BackupDir:    "/var/lib/daos/ms-backups",
+					UploadScript: "upload.sh",
+				})
+			},
+			expErr: FaultConfigBadMSBackup(errors.New("upload_script must be an absolute path")),
+		},
+		"good pool space forecast": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolSpaceForecast(&PoolSpaceForecast{Enabled: true})
+			},
+		},
+		"pool space forecast bad warn percent": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolSpaceForecast(&PoolSpaceForecast{
+					Enabled:     true,
+					WarnPercent: 101,
+				})
+			},
+			expErr: FaultConfigBadPoolSpaceForecast,
+		},
+		"pool space forecast short trend window": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolSpaceForecast(&PoolSpaceForecast{
+					Enabled:     true,
+					TrendWindow: time.Hour,
+				})
+			},
+			expErr: FaultConfigBadPoolSpaceForecast,
+		},
+		"good quarantine policy": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithQuarantinePolicy(&QuarantinePolicy{Enabled: true})
+			},
+		},
+		"quarantine policy negative threshold": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithQuarantinePolicy(&QuarantinePolicy{
+					Enabled:   true,
+					Threshold: -1,
+				})
+			},
+			expErr: FaultConfigBadQuarantinePolicy,
+		},
+		"quarantine policy empty event name": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithQuarantinePolicy(&QuarantinePolicy{
+					Enabled: true,
+					Events:  []string{"device_set_faulty", ""},
+				})
+			},
+			expErr: FaultConfigBadQuarantinePolicy,
+		},
+		"good pool op scheduler": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolOpScheduler(&PoolOpScheduler{MaxConcurrent: 2, MaxPerRank: 1})
+			},
+		},
+		"pool op scheduler negative limit": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolOpScheduler(&PoolOpScheduler{MaxConcurrent: -1})
+			},
+			expErr: FaultConfigBadPoolOpScheduler,
+		},
+		"pool op scheduler poll interval too short": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithPoolOpScheduler(&PoolOpScheduler{PollInterval: time.Millisecond})
+			},
+			expErr: FaultConfigBadPoolOpScheduler,
+		},
+		"good provisioning": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithProvisioning(&Provisioning{
+					RequireToken: true,
+					TokenFile:    "/etc/daos/provisioning.token",
+				})
+			},
+		},
+		"provisioning token file not absolute": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithProvisioning(&Provisioning{TokenFile: "provisioning.token"})
+			},
+			expErr: FaultConfigBadProvisioning,
+		},
+		"good drpc sockets": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDrpcSockets(&drpc.SocketConfig{
+					Path:    "/var/run/daos_server/daos_server_srv.sock",
+					Modules: []string{"srv"},
+					Mode:    "0660",
+				})
+			},
+		},
+		"drpc socket with agent module": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDrpcSockets(&drpc.SocketConfig{
+					Path:    "/var/run/daos_server/cred.sock",
+					Modules: []string{"security_agent"},
+				})
+			},
+			expErr: FaultConfigBadDrpcSocket,
+		},
+		"duplicate drpc socket path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDrpcSockets(
+					&drpc.SocketConfig{Path: "/var/run/daos_server/a.sock"},
+					&drpc.SocketConfig{Path: "/var/run/daos_server/a.sock"},
+				)
+			},
+			expErr: FaultConfigBadDrpcSocket,
+		},
+		"good control log subsystem": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlLogSubsystem(logging.SubsystemDrpc, common.ControlLogLevelTrace)
+			},
+		},
+		"unknown control log subsystem": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlLogSubsystem("bogus", common.ControlLogLevelDebug)
+			},
+			expErr: FaultConfigBadControlLogSubsystem("bogus"),
+		},
+		"good control profiling": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlProfiling(&profiling.Config{
+					Enabled: true,
+					Address: "127.0.0.1:6060",
+				})
+			},
+		},
+		"remote control profiling allowed": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlProfiling(&profiling.Config{
+					Enabled:     true,
+					Address:     "0.0.0.0:6060",
+					AllowRemote: true,
+				})
+			},
+		},
+		"remote control profiling not allowed": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlProfiling(&profiling.Config{
+					Enabled: true,
+					Address: "0.0.0.0:6060",
+				})
+			},
+			expErr: FaultConfigBadControlProfiling(profiling.ValidateAddress("0.0.0.0:6060", false)),
+		},
+		"encryption key with file and command": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEncryptionKey(&storage.EncryptionKeyConfig{
+					File:    "/etc/daos/daos_storage.key",
+					Command: "/usr/bin/kms_client",
+				})
+			},
+			expErr: FaultConfigBadEncryptionKey(errors.New("file and command may not both be set")),
+		},
+		"bad fabric interface filter pattern": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFabricIfaceFilter(&hardware.FabricInterfaceFilter{Exclude: []string{"eth[0"}})
+			},
+			expErr: FaultConfigBadFabricIfaceFilter(errors.New(`invalid fabric interface pattern "eth[0"`)),
+		},
+		"encrypted bdev tier with key": {
+			extraConfig: func(c *Server) *Server {
+				c.Engines[0].Storage.Tiers[1].WithBdevEncryption(storage.BdevEncryptionOpal)
+				return c
+			},
+		},
+		"encrypted bdev tier without key": {
+			extraConfig: func(c *Server) *Server {
+				c.Engines[0].Storage.Tiers[1].WithBdevEncryption(storage.BdevEncryptionOpal)
+				return c.WithEncryptionKey(nil)
+			},
+			expErr: FaultConfigEncryptionNoKey,
+		},
+		"good ms election tier": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSElectionTier(MaxMSElectionTier)
+			},
+		},
+		"ms election tier too high": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithMSElectionTier(MaxMSElectionTier + 1)
+			},
+			expErr: FaultConfigBadMSElectionTier,
+		},
 		"control metadata multi-engine": {
 			extraConfig: func(c *Server) *Server {
 				return c.WithControlMetadata(storage.ControlMetadata{
@@ -725,6 +1347,7 @@ func TestServerConfig_Validation(t *testing.T) {
 						).
 						WithStorageVosEnv("NVME").
 						WithStorageControlMetadataPath(testMetadataDir).
+						WithStorageEncryptionKey(exampleEncryptionKey).
 						WithStorageControlMetadataDevice("/dev/something").
 						WithStorageConfigOutputPath(filepath.Join(
 							testMetadataDir,
@@ -748,6 +1371,7 @@ func TestServerConfig_Validation(t *testing.T) {
 						).
 						WithStorageVosEnv("NVME").
 						WithStorageControlMetadataPath(testMetadataDir).
+						WithStorageEncryptionKey(exampleEncryptionKey).
 						WithStorageControlMetadataDevice("/dev/something").
 						WithStorageConfigOutputPath(filepath.Join(
 							testMetadataDir,
@@ -799,6 +1423,7 @@ func TestServerConfig_Validation(t *testing.T) {
 						).
 						WithStorageVosEnv("NVME").
 						WithStorageControlMetadataPath(testMetadataDir).
+						WithStorageEncryptionKey(exampleEncryptionKey).
 						WithStorageControlMetadataDevice("/dev/something").
 						WithStorageConfigOutputPath(filepath.Join(
 							testMetadataDir,
@@ -882,6 +1507,7 @@ func TestServerConfig_Validation(t *testing.T) {
 					).
 					WithStorageVosEnv("NVME").
 					WithStorageControlMetadataPath(testMetadataDir).
+					WithStorageEncryptionKey(exampleEncryptionKey).
 					WithStorageControlMetadataDevice("").
 					WithStorageConfigOutputPath(filepath.Join(
 						testMetadataDir,
@@ -905,6 +1531,41 @@ func TestServerConfig_Validation(t *testing.T) {
 			},
 			expErr: FaultConfigControlMetadataNoPath,
 		},
+		"control metadata has mirror only": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlMetadata(storage.ControlMetadata{
+					MirrorPath: "/mnt/mirror",
+				})
+			},
+			expErr: FaultConfigControlMetadataBadMirror,
+		},
+		"control metadata mirror same as path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlMetadata(storage.ControlMetadata{
+					Path:       testMetadataDir,
+					MirrorPath: testMetadataDir + "/",
+				})
+			},
+			expErr: FaultConfigControlMetadataBadMirror,
+		},
+		"control metadata mirror within path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlMetadata(storage.ControlMetadata{
+					Path:       testMetadataDir,
+					MirrorPath: filepath.Join(testMetadataDir, "mirror"),
+				})
+			},
+			expErr: FaultConfigControlMetadataBadMirror,
+		},
+		"control metadata path within mirror": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlMetadata(storage.ControlMetadata{
+					Path:       filepath.Join(testMetadataDir, "primary"),
+					MirrorPath: testMetadataDir,
+				})
+			},
+			expErr: FaultConfigControlMetadataBadMirror,
+		},
 		"control metadata with no roles specified": {
 			extraConfig: func(c *Server) *Server {
 				return c.
@@ -2119,3 +2780,19 @@ func TestConfig_SetEngineAffinities(t *testing.T) {
 		})
 	}
 }
+
+func TestServerConfig_FabricScanFilter(t *testing.T) {
+	cfg := DefaultServer().
+		WithFabricIfaceFilter(&hardware.FabricInterfaceFilter{
+			Exclude: []string{"eth*"},
+		}).
+		WithEngines(
+			engine.MockConfig().WithFabricInterface("eth0"),
+			engine.MockConfig().WithFabricInterface("eth1"),
+		)
+
+	test.AssertEqual(t, &hardware.FabricInterfaceFilter{
+		Exclude: []string{"eth*"},
+		Include: []string{"eth0", "eth1"},
+	}, cfg.FabricScanFilter(), "unexpected filter")
+}
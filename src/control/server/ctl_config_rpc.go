@@ -0,0 +1,95 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// candidateConfigCheckFn validates a candidate server config against the
+// hardware of the host.
+type candidateConfigCheckFn func(context.Context, *config.Server) error
+
+// checkCandidateConfig validates a candidate server config against the fabric
+// interfaces and memory of the host in the same way as on server start,
+// without applying it.
+func (svc *ControlService) checkCandidateConfig(ctx context.Context, cfg *config.Server) error {
+	if svc.fabric == nil {
+		return errors.New("fabric scanner not available")
+	}
+
+	providers, err := cfg.Fabric.GetProviders()
+	if err != nil {
+		return err
+	}
+	fis, err := svc.fabric.Scan(ctx, providers...)
+	if err != nil {
+		return errors.Wrap(err, "scan fabric")
+	}
+	mi, err := common.GetMemInfo()
+	if err != nil {
+		return errors.Wrap(err, "retrieve system memory info")
+	}
+
+	return checkConfig(svc.log, cfg, fis, mi, lookupIF, genFiAffFn(fis))
+}
+
+// ApplyServerConfig validates a candidate server config file against the
+// hardware of the host without applying it. If requested, the config file of
+// the server is then replaced with the candidate, to take effect when the
+// server is next started.
+func (svc *ControlService) ApplyServerConfig(ctx context.Context, req *ctlpb.ServerConfigApplyReq) (*ctlpb.ServerConfigApplyResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if len(req.Config) == 0 {
+		return nil, errors.New("empty server config")
+	}
+	path := svc.srvCfg.Path
+	if path == "" {
+		return nil, config.FaultConfigNoPath
+	}
+
+	cfg := config.DefaultServer()
+	cfg.Path = path
+	if err := cfg.Parse(req.Config); err != nil {
+		return nil, err
+	}
+
+	check := svc.checkConfig
+	if check == nil {
+		check = svc.checkCandidateConfig
+	}
+	if err := check(ctx, cfg); err != nil {
+		return nil, errors.Wrap(err, "candidate config is not valid for this host")
+	}
+
+	resp := &ctlpb.ServerConfigApplyResp{Path: path}
+	if !req.Commit {
+		svc.log.Debugf("candidate server config is valid for this host")
+		return resp, nil
+	}
+
+	perm := os.FileMode(0644)
+	if fi, err := os.Stat(path); err == nil {
+		perm = fi.Mode().Perm()
+	}
+	if err := common.WriteFileAtomic(path, req.Config, perm); err != nil {
+		return nil, errors.Wrapf(err, "writing %s", path)
+	}
+	resp.Committed = true
+
+	svc.log.Noticef("server config %s replaced; changes will take effect when the server is restarted", path)
+	return resp, nil
+}
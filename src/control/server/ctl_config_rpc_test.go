@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_CtlSvc_ApplyServerConfig(t *testing.T) {
+	curConfig := []byte("name: daos_server\n")
+	newConfig := []byte("name: daos_server\nport: 10002\n")
+
+	for name, tc := range map[string]struct {
+		noPath    bool
+		req       *ctlpb.ServerConfigApplyReq
+		checkErr  error
+		expPort   int
+		expConfig []byte
+		expErr    error
+	}{
+		"nil request": {
+			expErr:    errors.New("nil request"),
+			expConfig: curConfig,
+		},
+		"empty config": {
+			req:       &ctlpb.ServerConfigApplyReq{},
+			expErr:    errors.New("empty server config"),
+			expConfig: curConfig,
+		},
+		"no config path": {
+			noPath: true,
+			req:    &ctlpb.ServerConfigApplyReq{Config: newConfig},
+			expErr: config.FaultConfigNoPath,
+		},
+		"invalid syntax": {
+			req:       &ctlpb.ServerConfigApplyReq{Config: []byte("bad_key: 1\n"), Commit: true},
+			expErr:    errors.New("bad_key"),
+			expConfig: curConfig,
+		},
+		"not valid for host": {
+			req:       &ctlpb.ServerConfigApplyReq{Config: newConfig, Commit: true},
+			checkErr:  errors.New("interface not found"),
+			expErr:    errors.New("candidate config is not valid for this host: interface not found"),
+			expConfig: curConfig,
+		},
+		"validate only": {
+			req:       &ctlpb.ServerConfigApplyReq{Config: newConfig},
+			expPort:   10002,
+			expConfig: curConfig,
+		},
+		"commit": {
+			req:       &ctlpb.ServerConfigApplyReq{Config: newConfig, Commit: true},
+			expPort:   10002,
+			expConfig: newConfig,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			cfgPath := filepath.Join(tmpDir, "daos_server.yml")
+			if err := ioutil.WriteFile(cfgPath, curConfig, 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.DefaultServer()
+			cfg.Path = cfgPath
+			if tc.noPath {
+				cfg.Path = ""
+			}
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+
+			var gotPort int
+			svc.checkConfig = func(_ context.Context, candidate *config.Server) error {
+				gotPort = candidate.ControlPort
+				return tc.checkErr
+			}
+
+			gotResp, gotErr := svc.ApplyServerConfig(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expConfig != nil {
+				gotConfig, err := ioutil.ReadFile(cfgPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(string(tc.expConfig), string(gotConfig)); diff != "" {
+					t.Fatalf("unexpected config file (-want, +got):\n%s\n", diff)
+				}
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expPort, gotPort, "unexpected candidate config")
+			expResp := &ctlpb.ServerConfigApplyResp{
+				Path:      cfgPath,
+				Committed: tc.req.Commit,
+			}
+			if diff := cmp.Diff(expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+
+			fi, err := os.Stat(cfgPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, os.FileMode(0600), fi.Mode().Perm(), "config file mode not preserved")
+		})
+	}
+}
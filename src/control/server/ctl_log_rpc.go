@@ -0,0 +1,100 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// controlLogLeveler is implemented by loggers whose level, and the levels of
+// their subsystems, can be changed at runtime.
+type controlLogLeveler interface {
+	logging.SubsystemLeveler
+	Level() logging.LogLevel
+	SetLevel(logging.LogLevel)
+}
+
+func parseLogLevel(in string) (logging.LogLevel, error) {
+	var level logging.LogLevel
+	if err := level.SetString(in); err != nil {
+		return logging.LogLevelDisabled, err
+	}
+	return level, nil
+}
+
+func controlLogLevelsToPB(ll controlLogLeveler) *ctlpb.SetControlLogLevelsResp {
+	resp := &ctlpb.SetControlLogLevelsResp{
+		Level:      ll.Level().String(),
+		Subsystems: make(map[string]string),
+	}
+	for name, level := range ll.SubsystemLevels() {
+		resp.Subsystems[name] = level.String()
+	}
+	return resp
+}
+
+// SetControlLogLevels sets the log level of the control plane server and of
+// its subsystems at runtime. All requested levels are validated before any
+// are applied.
+func (svc *ControlService) SetControlLogLevels(ctx context.Context, req *ctlpb.SetControlLogLevelsReq) (*ctlpb.SetControlLogLevelsResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	ll, ok := svc.log.(controlLogLeveler)
+	if !ok {
+		return nil, errors.Errorf("control log levels can't be changed for %T", svc.log)
+	}
+
+	level := ll.Level()
+	if req.ResetLevels {
+		level = logging.LogLevel(svc.srvCfg.ControlLogMask)
+	}
+	if req.Level != "" {
+		var err error
+		if level, err = parseLogLevel(req.Level); err != nil {
+			return nil, err
+		}
+	}
+
+	subsysLevels := make(map[string]logging.LogLevel)
+	if req.ResetLevels {
+		for name, lvl := range svc.srvCfg.ControlLogSubsys {
+			subsysLevels[name] = logging.LogLevel(lvl)
+		}
+	}
+	for name, in := range req.Subsystems {
+		if err := logging.ValidateSubsystem(name); err != nil {
+			return nil, err
+		}
+		lvl, err := parseLogLevel(in)
+		if err != nil {
+			return nil, errors.Wrapf(err, "subsystem %s", name)
+		}
+		subsysLevels[name] = lvl
+	}
+
+	ll.SetLevel(level)
+	if req.ResetLevels {
+		ll.ResetSubsystemLevels()
+	}
+	for name, lvl := range subsysLevels {
+		if err := ll.SetSubsystemLevel(name, lvl); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := controlLogLevelsToPB(ll)
+	svc.log.Noticef("control log levels set: level=%s, subsystems=%v", resp.Level, resp.Subsystems)
+
+	return resp, nil
+}
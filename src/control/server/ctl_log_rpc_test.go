@@ -0,0 +1,147 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_CtlSvc_SetControlLogLevels(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *ctlpb.SetControlLogLevelsReq
+		preSubsys map[string]logging.LogLevel
+		expResp   *ctlpb.SetControlLogLevelsResp
+		expErr    error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no changes": {
+			req: &ctlpb.SetControlLogLevelsReq{},
+			expResp: &ctlpb.SetControlLogLevelsResp{
+				Level: "TRACE",
+			},
+		},
+		"set level": {
+			req: &ctlpb.SetControlLogLevelsReq{Level: "info"},
+			expResp: &ctlpb.SetControlLogLevelsResp{
+				Level: "INFO",
+			},
+		},
+		"set subsystems": {
+			req: &ctlpb.SetControlLogLevelsReq{
+				Level: "ERROR",
+				Subsystems: map[string]string{
+					logging.SubsystemRaft: "debug",
+					logging.SubsystemDrpc: "trace",
+				},
+			},
+			preSubsys: map[string]logging.LogLevel{
+				logging.SubsystemEvents: logging.LogLevelNotice,
+			},
+			expResp: &ctlpb.SetControlLogLevelsResp{
+				Level: "ERROR",
+				Subsystems: map[string]string{
+					logging.SubsystemRaft:   "DEBUG",
+					logging.SubsystemDrpc:   "TRACE",
+					logging.SubsystemEvents: "NOTICE",
+				},
+			},
+		},
+		"reset to config": {
+			req: &ctlpb.SetControlLogLevelsReq{ResetLevels: true},
+			preSubsys: map[string]logging.LogLevel{
+				logging.SubsystemEvents: logging.LogLevelNotice,
+			},
+			expResp: &ctlpb.SetControlLogLevelsResp{
+				Level: "NOTICE",
+				Subsystems: map[string]string{
+					logging.SubsystemStorage: "DEBUG",
+				},
+			},
+		},
+		"reset and set": {
+			req: &ctlpb.SetControlLogLevelsReq{
+				ResetLevels: true,
+				Subsystems: map[string]string{
+					logging.SubsystemStorage: "error",
+				},
+			},
+			expResp: &ctlpb.SetControlLogLevelsResp{
+				Level: "NOTICE",
+				Subsystems: map[string]string{
+					logging.SubsystemStorage: "ERROR",
+				},
+			},
+		},
+		"bad level": {
+			req:    &ctlpb.SetControlLogLevelsReq{Level: "loud"},
+			expErr: errors.New("not a valid log level"),
+		},
+		"unknown subsystem": {
+			req: &ctlpb.SetControlLogLevelsReq{
+				Subsystems: map[string]string{"bogus": "debug"},
+			},
+			expErr: errors.New("unknown log subsystem"),
+		},
+		"bad subsystem level; nothing applied": {
+			req: &ctlpb.SetControlLogLevelsReq{
+				Level: "ERROR",
+				Subsystems: map[string]string{
+					logging.SubsystemRaft: "debug",
+					logging.SubsystemDrpc: "loud",
+				},
+			},
+			expErr: errors.New("subsystem drpc"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			for name, level := range tc.preSubsys {
+				if err := log.SetSubsystemLevel(name, level); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := config.DefaultServer().
+				WithControlLogMask(common.ControlLogLevelNotice).
+				WithControlLogSubsystem(logging.SubsystemStorage, common.ControlLogLevelDebug)
+			svc := &ControlService{
+				StorageControlService: StorageControlService{log: log},
+				srvCfg:                cfg,
+			}
+
+			gotResp, gotErr := svc.SetControlLogLevels(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				test.AssertEqual(t, logging.LogLevelTrace, log.Level(), "level changed on error")
+				test.AssertEqual(t, len(tc.preSubsys), len(log.SubsystemLevels()),
+					"subsystem levels changed on error")
+				return
+			}
+
+			if tc.expResp.Subsystems == nil {
+				tc.expResp.Subsystems = map[string]string{}
+			}
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
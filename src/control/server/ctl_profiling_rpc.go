@@ -0,0 +1,58 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
+)
+
+// SetProfiling starts or stops the profiling endpoint of the control plane
+// server. An address supplied in the request is subject to the same
+// restrictions as one set in the server config.
+func (svc *ControlService) SetProfiling(ctx context.Context, req *ctlpb.SetProfilingReq) (*ctlpb.SetProfilingResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if svc.profiler == nil {
+		return nil, errors.New("profiling is not available")
+	}
+
+	if !req.Enable {
+		if req.Address != "" {
+			return nil, errors.New("address may only be supplied when enabling profiling")
+		}
+		svc.profiler.Stop()
+	} else {
+		addr := req.Address
+		var allowRemote bool
+		if pc := svc.srvCfg.ControlProfiling; pc != nil {
+			allowRemote = pc.AllowRemote
+			if addr == "" {
+				addr = pc.Address
+			}
+		}
+		if addr != "" {
+			if err := profiling.ValidateAddress(addr, allowRemote); err != nil {
+				return nil, err
+			}
+		}
+		if err := svc.profiler.Start(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	enabled, addr := svc.profiler.Status()
+	return &ctlpb.SetProfilingResp{
+		Enabled: enabled,
+		Address: addr,
+	}, nil
+}
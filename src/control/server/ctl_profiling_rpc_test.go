@@ -0,0 +1,92 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_CtlSvc_SetProfiling(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        *profiling.Config
+		preStart   bool
+		req        *ctlpb.SetProfilingReq
+		expEnabled bool
+		expErr     error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"enable": {
+			req:        &ctlpb.SetProfilingReq{Enable: true, Address: "127.0.0.1:0"},
+			expEnabled: true,
+		},
+		"enable with configured address": {
+			cfg:        &profiling.Config{Address: "127.0.0.1:0"},
+			req:        &ctlpb.SetProfilingReq{Enable: true},
+			expEnabled: true,
+		},
+		"enable remote; not allowed": {
+			req:    &ctlpb.SetProfilingReq{Enable: true, Address: "0.0.0.0:0"},
+			expErr: errors.New("remote access is not allowed"),
+		},
+		"enable remote; allowed in config": {
+			cfg:        &profiling.Config{AllowRemote: true},
+			req:        &ctlpb.SetProfilingReq{Enable: true, Address: "0.0.0.0:0"},
+			expEnabled: true,
+		},
+		"disable": {
+			preStart: true,
+			req:      &ctlpb.SetProfilingReq{},
+		},
+		"disable with address": {
+			req:    &ctlpb.SetProfilingReq{Address: "127.0.0.1:0"},
+			expErr: errors.New("only be supplied when enabling"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := &ControlService{
+				StorageControlService: StorageControlService{log: log},
+				srvCfg:                config.DefaultServer().WithControlProfiling(tc.cfg),
+				profiler:              profiling.NewServer(log, "127.0.0.1:0"),
+			}
+			defer svc.profiler.Stop()
+
+			if tc.preStart {
+				if err := svc.profiler.Start(""); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotResp, gotErr := svc.SetProfiling(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expEnabled, gotResp.Enabled, "unexpected enabled state")
+			running, addr := svc.profiler.Status()
+			test.AssertEqual(t, tc.expEnabled, running, "unexpected profiler state")
+			test.AssertEqual(t, addr, gotResp.Address, "unexpected address")
+			if tc.expEnabled && strings.HasSuffix(addr, ":0") {
+				t.Fatalf("expected resolved listen address, got %q", addr)
+			}
+		})
+	}
+}
@@ -138,15 +138,24 @@ func (svc *ControlService) memberStateResults(instances []Engine, tgtState syste
 			continue
 		}
 
+		// Report the UUID so that callers can verify the identity of the rank.
+		rankUUID, err := ei.GetUUID()
+		if err != nil {
+			svc.log.Debugf("Instance %d GetUUID(): %s", ei.Index(), err)
+		}
+
 		state := ei.LocalState()
 		if state != tgtState {
-			results = append(results, system.NewMemberResult(rank, errors.Errorf(failMsg),
-				system.MemberStateErrored))
+			res := system.NewMemberResult(rank, errors.Errorf(failMsg),
+				system.MemberStateErrored)
+			res.UUID = rankUUID
+			results = append(results, res)
 			continue
 		}
 
 		res := system.NewMemberResult(rank, nil, state)
 		res.Msg = okMsg
+		res.UUID = rankUUID
 		results = append(results, res)
 	}
 
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -69,7 +69,7 @@ func NewStorageControlService(log logging.Logger, ecs []*engine.Config) *Storage
 	return &StorageControlService{
 		log:             log,
 		instanceStorage: instanceStorage,
-		storage:         storage.DefaultProvider(log, 0, topCfg),
+		storage:         storage.DefaultProvider(logging.ForSubsystem(log, logging.SubsystemStorage), 0, topCfg),
 		getMemInfo:      common.GetMemInfo,
 	}
 }
@@ -755,6 +755,12 @@ func (cs *ControlService) formatMetadata(instances []Engine, reformat bool) (boo
 			return false, errors.Wrap(err, "formatting control metadata storage")
 		}
 
+		// Any engine that failed over to the mirror should use the newly formatted
+		// primary location again.
+		for _, eng := range instances {
+			eng.GetStorage().RestoreControlMetadataPrimary()
+		}
+
 		return true, nil
 	}
 
@@ -10,6 +10,7 @@ import (
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/profiling"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
 )
@@ -19,10 +20,13 @@ import (
 type ControlService struct {
 	ctlpb.UnimplementedCtlSvcServer
 	StorageControlService
-	harness *EngineHarness
-	srvCfg  *config.Server
-	events  *events.PubSub
-	fabric  *hardware.FabricScanner
+	harness  *EngineHarness
+	srvCfg   *config.Server
+	events   *events.PubSub
+	fabric   *hardware.FabricScanner
+	profiler *profiling.Server
+	// checkConfig overrides the validation of candidate server configs
+	checkConfig candidateConfigCheckFn
 }
 
 // NewControlService returns ControlService to be used as gRPC control service
@@ -42,5 +46,6 @@ func NewControlService(log logging.Logger, h *EngineHarness,
 		srvCfg:                cfg,
 		events:                e,
 		fabric:                f,
+		profiler:              profiling.NewServer(log, profiling.DefaultServerAddress),
 	}
 }
@@ -0,0 +1,62 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// errSwimViewUnsupported is reported for engines which don't implement the
+// SwimView dRPC method.
+var errSwimViewUnsupported = errors.New("engine does not support SWIM view queries")
+
+// SwimViewQuery returns the local SWIM view of the system ranks held by each
+// of the engine processes on the host. Failures to retrieve the view of an
+// engine are reported in its entry rather than failing the request.
+func (svc *ControlService) SwimViewQuery(ctx context.Context, req *ctlpb.SwimViewReq) (*ctlpb.SwimViewQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	resp := new(ctlpb.SwimViewQueryResp)
+	for _, ei := range svc.harness.Instances() {
+		view := &ctlpb.EngineSwimView{Index: ei.Index()}
+		resp.Engines = append(resp.Engines, view)
+
+		rank, err := ei.GetRank()
+		if err != nil {
+			// Ranks are only known once the engine has joined the system.
+			svc.log.Debugf("instance %d: %s", ei.Index(), err)
+			view.Rank = rank.Uint32()
+			view.Error = err.Error()
+			continue
+		}
+		view.Rank = rank.Uint32()
+
+		if !ei.IsReady() {
+			view.Error = "engine not ready"
+			continue
+		}
+
+		dresp, err := getSwimView(ctx, ei)
+		switch {
+		case isUnknownMethod(err):
+			view.Error = errSwimViewUnsupported.Error()
+		case err != nil:
+			svc.log.Debugf("instance %d: failed to get SWIM view: %s", ei.Index(), err)
+			view.Error = err.Error()
+		default:
+			view.Members = dresp.Members
+		}
+	}
+
+	return resp, nil
+}
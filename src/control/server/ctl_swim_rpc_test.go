@@ -0,0 +1,135 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_CtlSvc_SwimViewQuery(t *testing.T) {
+	swimResp := func(t *testing.T, resp *ctlpb.SwimViewResp) *drpc.Response {
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &drpc.Response{Body: body}
+	}
+	members := []*ctlpb.SwimMember{
+		{Rank: 0, State: ctlpb.SwimState_SWIM_ALIVE, Incarnation: 10},
+		{Rank: 1, State: ctlpb.SwimState_SWIM_SUSPECT, Incarnation: 11},
+	}
+
+	for name, tc := range map[string]struct {
+		req     *ctlpb.SwimViewReq
+		mic     *MockInstanceConfig
+		expResp *ctlpb.SwimViewQueryResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no rank": {
+			req: &ctlpb.SwimViewReq{},
+			mic: &MockInstanceConfig{
+				GetRankResp: ranklist.NilRank,
+				GetRankErr:  errors.New("no rank"),
+			},
+			expResp: &ctlpb.SwimViewQueryResp{
+				Engines: []*ctlpb.EngineSwimView{
+					{Rank: uint32(ranklist.NilRank), Error: "no rank"},
+				},
+			},
+		},
+		"not ready": {
+			req: &ctlpb.SwimViewReq{},
+			mic: &MockInstanceConfig{GetRankResp: 2},
+			expResp: &ctlpb.SwimViewQueryResp{
+				Engines: []*ctlpb.EngineSwimView{
+					{Rank: 2, Error: "engine not ready"},
+				},
+			},
+		},
+		"unsupported": {
+			req: &ctlpb.SwimViewReq{},
+			mic: &MockInstanceConfig{
+				GetRankResp: 2,
+				Ready:       atm.NewBool(true),
+				CallDrpcErr: errors.Errorf("bad dRPC response status: %s", drpc.Status_UNKNOWN_METHOD),
+			},
+			expResp: &ctlpb.SwimViewQueryResp{
+				Engines: []*ctlpb.EngineSwimView{
+					{Rank: 2, Error: errSwimViewUnsupported.Error()},
+				},
+			},
+		},
+		"engine error": {
+			req: &ctlpb.SwimViewReq{},
+			mic: &MockInstanceConfig{
+				GetRankResp:  2,
+				Ready:        atm.NewBool(true),
+				CallDrpcResp: swimResp(t, &ctlpb.SwimViewResp{Status: int32(daos.MiscError)}),
+			},
+			expResp: &ctlpb.SwimViewQueryResp{
+				Engines: []*ctlpb.EngineSwimView{
+					{Rank: 2, Error: "SwimView response status: " + daos.MiscError.Error()},
+				},
+			},
+		},
+		"success": {
+			req: &ctlpb.SwimViewReq{},
+			mic: &MockInstanceConfig{
+				GetRankResp:  2,
+				Ready:        atm.NewBool(true),
+				CallDrpcResp: swimResp(t, &ctlpb.SwimViewResp{Members: members}),
+			},
+			expResp: &ctlpb.SwimViewQueryResp{
+				Engines: []*ctlpb.EngineSwimView{
+					{Rank: 2, Members: members},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			h := NewEngineHarness(log)
+			if tc.mic != nil {
+				if err := h.AddInstance(NewMockInstance(tc.mic)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc := &ControlService{
+				StorageControlService: StorageControlService{log: log},
+				harness:               h,
+			}
+
+			gotResp, gotErr := svc.SwimViewQuery(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
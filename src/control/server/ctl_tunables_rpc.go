@@ -0,0 +1,73 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func engineTunablesToPB(idx uint32, et *engine.Tunables) *ctlpb.EngineTunables {
+	pb := &ctlpb.EngineTunables{
+		Index:            idx,
+		Args:             et.Args,
+		EnvVars:          et.EnvVars,
+		UltStackSize:     et.ULTStackSize,
+		DeepUltStackSize: et.DeepULTStackSize,
+		MmapUltStacks:    et.MmapULTStacks,
+		Targets:          uint32(et.TargetCount),
+		HelperStreams:    uint32(et.HelperStreams),
+		MemSizeMib:       uint32(et.MemSizeMiB),
+		HugepageSizeMib:  uint32(et.HugepageSizeMiB),
+		PinnedNumaNode:   -1,
+		FirstCore:        -1,
+	}
+	if et.PinnedNumaNode != nil {
+		pb.PinnedNumaNode = int32(*et.PinnedNumaNode)
+	}
+	if et.ServiceCore != nil {
+		pb.FirstCore = int32(*et.ServiceCore)
+	}
+	return pb
+}
+
+// GetEngineTunables returns the effective tuning parameters of each of the
+// engine processes on the host.
+func (svc *ControlService) GetEngineTunables(ctx context.Context, req *ctlpb.EngineTunablesReq) (*ctlpb.EngineTunablesResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	resp := new(ctlpb.EngineTunablesResp)
+	for _, ei := range svc.harness.Instances() {
+		var et *ctlpb.EngineTunables
+
+		tun, err := ei.GetTunables()
+		if err != nil {
+			svc.log.Debugf("instance %d: failed to get tunables: %s", ei.Index(), err)
+			et = &ctlpb.EngineTunables{Index: ei.Index(), Error: err.Error()}
+		} else {
+			et = engineTunablesToPB(ei.Index(), tun)
+		}
+
+		rank, err := ei.GetRank()
+		if err != nil {
+			// Ranks are only known once the engine has joined the system.
+			svc.log.Debugf("instance %d: %s", ei.Index(), err)
+		}
+		et.Rank = rank.Uint32()
+		et.Running = ei.IsStarted()
+
+		resp.Engines = append(resp.Engines, et)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,120 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_CtlSvc_GetEngineTunables(t *testing.T) {
+	numaNode := uint(1)
+
+	for name, tc := range map[string]struct {
+		req     *ctlpb.EngineTunablesReq
+		mics    []*MockInstanceConfig
+		expResp *ctlpb.EngineTunablesResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no engines": {
+			req:     &ctlpb.EngineTunablesReq{},
+			expResp: &ctlpb.EngineTunablesResp{},
+		},
+		"mixed results": {
+			req: &ctlpb.EngineTunablesReq{},
+			mics: []*MockInstanceConfig{
+				{
+					Index:       0,
+					Started:     atm.NewBool(true),
+					GetRankResp: 3,
+					Tunables: &engine.Tunables{
+						Args:             []string{"-t", "16"},
+						EnvVars:          []string{"CRT_TIMEOUT=30"},
+						ULTStackSize:     engine.DefaultULTStackSize,
+						DeepULTStackSize: engine.DeepULTStackSize,
+						MmapULTStacks:    true,
+						TargetCount:      16,
+						HelperStreams:    2,
+						MemSizeMiB:       8192,
+						HugepageSizeMiB:  2,
+						PinnedNumaNode:   &numaNode,
+					},
+				},
+				{
+					Index:       1,
+					GetRankResp: ranklist.NilRank,
+					GetRankErr:  errors.New("no rank"),
+					TunablesErr: errors.New("bad config"),
+				},
+			},
+			expResp: &ctlpb.EngineTunablesResp{
+				Engines: []*ctlpb.EngineTunables{
+					{
+						Index:            0,
+						Rank:             3,
+						Running:          true,
+						Args:             []string{"-t", "16"},
+						EnvVars:          []string{"CRT_TIMEOUT=30"},
+						UltStackSize:     engine.DefaultULTStackSize,
+						DeepUltStackSize: engine.DeepULTStackSize,
+						MmapUltStacks:    true,
+						Targets:          16,
+						HelperStreams:    2,
+						MemSizeMib:       8192,
+						HugepageSizeMib:  2,
+						PinnedNumaNode:   1,
+						FirstCore:        -1,
+					},
+					{
+						Index: 1,
+						Rank:  uint32(ranklist.NilRank),
+						Error: "bad config",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			h := NewEngineHarness(log)
+			for _, mic := range tc.mics {
+				if err := h.AddInstance(NewMockInstance(mic)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc := &ControlService{
+				StorageControlService: StorageControlService{log: log},
+				harness:               h,
+			}
+
+			gotResp, gotErr := svc.GetEngineTunables(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
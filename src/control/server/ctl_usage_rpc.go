@@ -0,0 +1,58 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+func engineUsageToPB(idx uint32, ru *EngineResourceUsage) *ctlpb.EngineUsage {
+	return &ctlpb.EngineUsage{
+		Index:         idx,
+		Pid:           int32(ru.PID),
+		RssBytes:      ru.RSSBytes,
+		HugepageBytes: ru.HugepageBytes,
+		OpenFds:       ru.OpenFDs,
+		CpuSeconds:    ru.CPUSeconds,
+	}
+}
+
+// EngineUsageQuery returns resource usage details for each of the running
+// engine processes on the host.
+func (svc *ControlService) EngineUsageQuery(ctx context.Context, req *ctlpb.EngineUsageReq) (*ctlpb.EngineUsageResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	resp := new(ctlpb.EngineUsageResp)
+	for _, ei := range svc.harness.Instances() {
+		var eu *ctlpb.EngineUsage
+
+		ru, err := ei.GetResourceUsage()
+		if err != nil {
+			svc.log.Debugf("instance %d: failed to get resource usage: %s", ei.Index(), err)
+			eu = &ctlpb.EngineUsage{Index: ei.Index(), Error: err.Error()}
+		} else {
+			eu = engineUsageToPB(ei.Index(), ru)
+		}
+
+		rank, err := ei.GetRank()
+		if err != nil {
+			// Ranks are only known once the engine has joined the system.
+			svc.log.Debugf("instance %d: %s", ei.Index(), err)
+		}
+		eu.Rank = rank.Uint32()
+
+		resp.Engines = append(resp.Engines, eu)
+	}
+
+	return resp, nil
+}
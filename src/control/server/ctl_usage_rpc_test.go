@@ -0,0 +1,103 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_CtlSvc_EngineUsageQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *ctlpb.EngineUsageReq
+		mics    []*MockInstanceConfig
+		expResp *ctlpb.EngineUsageResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"no engines": {
+			req:     &ctlpb.EngineUsageReq{},
+			expResp: &ctlpb.EngineUsageResp{},
+		},
+		"mixed results": {
+			req: &ctlpb.EngineUsageReq{},
+			mics: []*MockInstanceConfig{
+				{
+					Index:       0,
+					GetRankResp: 3,
+					ResourceUsage: &EngineResourceUsage{
+						PID:           1234,
+						RSSBytes:      1 << 30,
+						HugepageBytes: 1 << 31,
+						OpenFDs:       10,
+						CPUSeconds:    map[string]float64{"daos_io_0": 1.5},
+					},
+				},
+				{
+					Index:            1,
+					GetRankResp:      ranklist.NilRank,
+					GetRankErr:       errors.New("no rank"),
+					ResourceUsageErr: errors.New("instance 1: not started"),
+				},
+			},
+			expResp: &ctlpb.EngineUsageResp{
+				Engines: []*ctlpb.EngineUsage{
+					{
+						Index:         0,
+						Rank:          3,
+						Pid:           1234,
+						RssBytes:      1 << 30,
+						HugepageBytes: 1 << 31,
+						OpenFds:       10,
+						CpuSeconds:    map[string]float64{"daos_io_0": 1.5},
+					},
+					{
+						Index: 1,
+						Rank:  uint32(ranklist.NilRank),
+						Error: "instance 1: not started",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			h := NewEngineHarness(log)
+			for _, mic := range tc.mics {
+				if err := h.AddInstance(NewMockInstance(mic)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc := &ControlService{
+				StorageControlService: StorageControlService{log: log},
+				harness:               h,
+			}
+
+			gotResp, gotErr := svc.EngineUsageQuery(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -16,6 +16,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/system/raft"
@@ -71,6 +72,7 @@ type drpcServerSetupReq struct {
 	tc      *security.TransportConfig
 	sysdb   *raft.Database
 	events  *events.PubSub
+	sockets []*drpc.SocketConfig
 }
 
 // drpcServerSetup specifies socket path and starts drpc server.
@@ -82,20 +84,26 @@ func drpcServerSetup(ctx context.Context, req *drpcServerSetupReq) error {
 
 	sockPath := getDrpcServerSocketPath(req.sockDir)
 
+	// Create our modules
+	mods := []drpc.Module{
+		NewSecurityModule(req.log, req.tc),
+		newMgmtModule(),
+		newSrvModule(req.log, req.sysdb, req.sysdb, req.engines, req.events),
+	}
+
 	// Server socket file to be readable and writable by user. daos_server should receive
-	// messages from daos_engine and both processes will be run by the same user.
-	drpcServer, err := drpc.NewDomainSocketServer(req.log, sockPath, 0600)
+	// messages from daos_engine and both processes will be run by the same user. Additional
+	// sockets may expose a subset of the modules with their own permissions.
+	drpcServers, err := drpc.NewDomainSocketServers(logging.ForSubsystem(req.log, logging.SubsystemDrpc),
+		sockPath, 0600, req.sockets, mods...)
 	if err != nil {
 		return errors.Wrap(err, "unable to create socket server")
 	}
 
-	// Create and add our modules
-	drpcServer.RegisterRPCModule(NewSecurityModule(req.log, req.tc))
-	drpcServer.RegisterRPCModule(newMgmtModule())
-	drpcServer.RegisterRPCModule(newSrvModule(req.log, req.sysdb, req.sysdb, req.engines, req.events))
-
-	if err := drpcServer.Start(ctx); err != nil {
-		return errors.Wrapf(err, "unable to start socket server on %s", sockPath)
+	for _, drpcServer := range drpcServers {
+		if err := drpcServer.Start(ctx); err != nil {
+			return errors.Wrapf(err, "unable to start socket server on %s", drpcServer.SocketPath())
+		}
 	}
 
 	return nil
@@ -168,14 +176,21 @@ func makeDrpcCall(ctx context.Context, log logging.Logger, client drpc.DomainSoc
 		return nil, errors.Wrap(err, "build drpc call")
 	}
 
+	// Pass the trace context to the engine so that time spent in the engine can be
+	// attributed to the originating request.
+	ctx, span := tracing.StartSpan(ctx, "drpc "+method.String(), tracing.SpanKindClient)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+	if span != nil {
+		span.SetAttribute("drpc.module", method.Module())
+		drpcCall.Traceparent = span.SpanContext().Traceparent()
+	}
+
 	// Forward the request to the I/O Engine via dRPC
-	if err = client.Connect(ctx); err != nil {
-		if te, ok := errors.Cause(err).(interface{ Temporary() bool }); ok {
-			if !te.Temporary() {
-				return nil, FaultDataPlaneNotStarted
-			}
-		}
-		return nil, errors.Wrap(err, "connect to client")
+	if err = connectDrpcClient(ctx, client); err != nil {
+		return nil, err
 	}
 	defer func() {
 		if err := client.Close(); err != nil {
@@ -193,3 +208,64 @@ func makeDrpcCall(ctx context.Context, log logging.Logger, client drpc.DomainSoc
 
 	return drpcResp, nil
 }
+
+// makeDrpcBatchCall opens a drpc connection, sends a call to the method for
+// each of the protobuf messages, packed into a single batch message, and closes
+// the connection. The drpc responses are returned in the same order as the
+// messages, and must each be checked by the caller.
+func makeDrpcBatchCall(ctx context.Context, log logging.Logger, client drpc.DomainSocketClient, method drpc.Method, msgs []proto.Message) (drpcResps []*drpc.Response, err error) {
+	client.Lock()
+	defer client.Unlock()
+
+	ctx, span := tracing.StartSpan(ctx, "drpc batch "+method.String(), tracing.SpanKindClient)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+	if span != nil {
+		span.SetAttribute("drpc.module", method.Module())
+		span.SetAttribute("drpc.batch_size", len(msgs))
+	}
+
+	drpcCalls := make([]*drpc.Call, 0, len(msgs))
+	for _, msg := range msgs {
+		drpcCall, err := newDrpcCall(method, msg)
+		if err != nil {
+			return nil, errors.Wrap(err, "build drpc call")
+		}
+		if span != nil {
+			drpcCall.Traceparent = span.SpanContext().Traceparent()
+		}
+		drpcCalls = append(drpcCalls, drpcCall)
+	}
+
+	if err = connectDrpcClient(ctx, client); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			log.Errorf("error closing dRPC client: %s", err)
+		}
+	}()
+
+	if drpcResps, err = drpc.SendBatch(ctx, client, drpcCalls); err != nil {
+		return nil, errors.Wrapf(err, "failed to send batch of %d messages", len(drpcCalls))
+	}
+
+	return drpcResps, nil
+}
+
+// connectDrpcClient connects the client to the I/O Engine's dRPC socket.
+func connectDrpcClient(ctx context.Context, client drpc.DomainSocketClient) error {
+	err := client.Connect(ctx)
+	if err == nil {
+		return nil
+	}
+	if te, ok := errors.Cause(err).(interface{ Temporary() bool }); ok {
+		if !te.Temporary() {
+			return FaultDataPlaneNotStarted
+		}
+	}
+
+	return errors.Wrap(err, "connect to client")
+}
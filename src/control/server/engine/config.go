@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -257,6 +258,14 @@ type Config struct {
 	MemSize           int            `yaml:"-" cmdLongFlag:"--mem_size" cmdShortFlag:"-r"`
 	HugepageSz        int            `yaml:"-" cmdLongFlag:"--hugepage_size" cmdShortFlag:"-H"`
 	CheckerEnabled    bool           `yaml:"-" cmdLongFlag:"--checker" cmdShortFlag:"-C"`
+	// PrivateMountNamespace runs the engine in its own mount namespace.
+	PrivateMountNamespace bool `yaml:"-"`
+	// StartupTimeout is the time allowed for the engine to report ready
+	// after it is started before diagnostics are captured (0 disables).
+	StartupTimeout time.Duration `yaml:"startup_timeout,omitempty"`
+	// StartupRestarts is the number of times the engine is restarted after
+	// consecutive startup timeouts before it is left stopped.
+	StartupRestarts int `yaml:"startup_restarts,omitempty"`
 }
 
 // NewConfig returns an I/O Engine config.
@@ -310,6 +319,12 @@ func (c *Config) Validate() error {
 	if c.HugepageSz < 0 {
 		return errNegative("hugepage size")
 	}
+	if c.StartupTimeout < 0 {
+		return errNegative("startup timeout")
+	}
+	if c.StartupRestarts < 0 {
+		return errNegative("startup restarts")
+	}
 
 	if c.TargetCount == 0 {
 		return errors.New("target count must be nonzero")
@@ -513,6 +528,13 @@ func (c *Config) WithStorageEnableHotplug(enable bool) *Config {
 	return c
 }
 
+// WithPrivateMountNamespace sets whether the I/O Engine runs in its own mount
+// namespace.
+func (c *Config) WithPrivateMountNamespace(private bool) *Config {
+	c.PrivateMountNamespace = private
+	return c
+}
+
 // WithStorageNumaNodeIndex sets the NUMA node index to be used by this instance.
 func (c *Config) WithStorageNumaNodeIndex(nodeIndex uint) *Config {
 	c.Storage.NumaNodeIndex = nodeIndex
@@ -531,6 +553,13 @@ func (c *Config) WithStorageControlMetadataDevice(device string) *Config {
 	return c
 }
 
+// WithStorageEncryptionKey sets the source of the key used to unlock
+// encrypted storage for this instance.
+func (c *Config) WithStorageEncryptionKey(ekc *storage.EncryptionKeyConfig) *Config {
+	c.Storage.EncryptionKey = ekc
+	return c
+}
+
 // WithSocketDir sets the path to the instance's dRPC socket directory.
 func (c *Config) WithSocketDir(dir string) *Config {
 	c.SocketDir = dir
@@ -621,6 +650,19 @@ func (c *Config) WithLogFile(logPath string) *Config {
 	return c
 }
 
+// WithStartupTimeout sets the time allowed for the engine to report ready.
+func (c *Config) WithStartupTimeout(timeout time.Duration) *Config {
+	c.StartupTimeout = timeout
+	return c
+}
+
+// WithStartupRestarts sets the number of restarts after consecutive startup
+// timeouts.
+func (c *Config) WithStartupRestarts(restarts int) *Config {
+	c.StartupRestarts = restarts
+	return c
+}
+
 // WithLogMask sets the DAOS logging mask to be used by this instance.
 func (c *Config) WithLogMask(logMask string) *Config {
 	c.LogMask = logMask
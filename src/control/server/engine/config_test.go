@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -153,7 +154,9 @@ func TestConfig_Constructed(t *testing.T) {
 		WithTargetCount(12).
 		WithHelperStreamCount(1).
 		WithPinnedNumaNode(8).
-		WithBypassHealthChk(nil)
+		WithBypassHealthChk(nil).
+		WithStartupTimeout(5 * time.Minute).
+		WithStartupRestarts(1)
 
 	if *update {
 		outFile, err := os.Create(goldenPath)
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ const engineBin = "daos_engine"
 
 // Runner starts and manages an instance of a DAOS I/O Engine
 type Runner struct {
+	lastPid uint64 // accessed atomically; keep first for alignment
 	Config  *Config
 	log     logging.Logger
 	running atm.Bool
@@ -65,25 +67,23 @@ func (r *Runner) run(parent context.Context, args, env []string, exitCh RunnerEx
 	}
 	cmd.Env = env
 
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		// I/O Engine should get a SIGKILL if this process dies.
-		Pdeathsig: syscall.SIGKILL,
-		// I/O Engine should run with real uid/gid (drop egid).
-		Credential: &syscall.Credential{
-			Uid:         uint32(os.Getuid()),
-			Gid:         uint32(os.Getgid()),
-			NoSetGroups: true,
-		},
-	}
+	cmd.SysProcAttr = r.sysProcAttr()
 
 	r.log.Debugf("%s:%d args: %s", engineBin, r.Config.Index, args)
 	r.log.Debugf("%s:%d env: %s", engineBin, r.Config.Index, cmd.Env)
 	r.log.Infof("Starting I/O Engine instance %d: %s", r.Config.Index, binPath)
+	if r.Config.PrivateMountNamespace {
+		r.log.Infof("I/O Engine instance %d runs in a private mount namespace", r.Config.Index)
+	}
 
 	if err := cmd.Start(); err != nil {
+		if r.Config.PrivateMountNamespace && errors.Is(err, syscall.EPERM) {
+			err = errors.Wrap(err, "creating a mount namespace requires CAP_SYS_ADMIN")
+		}
 		return errors.Wrapf(common.GetExitStatus(err),
 			"%s (instance %d) failed to start", binPath, r.Config.Index)
 	}
+	atomic.StoreUint64(&r.lastPid, uint64(cmd.Process.Pid))
 	r.running.SetTrue()
 
 	ctx, cancel := context.WithCancel(parent)
@@ -124,6 +124,29 @@ func (r *Runner) run(parent context.Context, args, env []string, exitCh RunnerEx
 	return nil
 }
 
+func (r *Runner) sysProcAttr() *syscall.SysProcAttr {
+	attr := &syscall.SysProcAttr{
+		// I/O Engine should get a SIGKILL if this process dies.
+		Pdeathsig: syscall.SIGKILL,
+		// I/O Engine should run with real uid/gid (drop egid).
+		Credential: &syscall.Credential{
+			Uid:         uint32(os.Getuid()),
+			Gid:         uint32(os.Getgid()),
+			NoSetGroups: true,
+		},
+	}
+
+	// The engine gets a copy of the mounts set up by the control plane,
+	// with propagation made private (done by the runtime when unsharing
+	// the mount namespace), so that unmounting in the host namespace or
+	// in the namespace of another engine does not affect its mounts.
+	if r.Config.PrivateMountNamespace {
+		attr.Unshareflags = syscall.CLONE_NEWNS
+	}
+
+	return attr
+}
+
 // Try to integrate DD_SUBSYS into D_LOG_MASK then unset DD_SUBSYS in environment.
 func processLogEnvs(env []string) ([]string, error) {
 	subsys, err := common.FindKeyValue(env, envLogSubsystems)
@@ -157,19 +180,30 @@ func processLogEnvs(env []string) ([]string, error) {
 	return env, nil
 }
 
-// Start asynchronously starts the Engine instance.
-func (r *Runner) Start(ctx context.Context) (RunnerExitChan, error) {
-	args, err := r.Config.CmdLineArgs()
+// StartParams returns the command line arguments and environment with which an
+// I/O Engine process is started for this configuration.
+func (c *Config) StartParams() (args, env []string, err error) {
+	args, err = c.CmdLineArgs()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	env, err := r.Config.CmdLineEnv()
+	env, err = c.CmdLineEnv()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	env = common.MergeKeyValues(cleanEnvVars(os.Environ(), r.Config.EnvPassThrough), env)
+	env = common.MergeKeyValues(cleanEnvVars(os.Environ(), c.EnvPassThrough), env)
 
 	env, err = processLogEnvs(env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return args, env, nil
+}
+
+// Start asynchronously starts the Engine instance.
+func (r *Runner) Start(ctx context.Context) (RunnerExitChan, error) {
+	args, env, err := r.Config.StartParams()
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +227,11 @@ func (r *Runner) Signal(signal os.Signal) {
 	r.sigCh <- signal
 }
 
+// GetLastPid returns the PID of the most recently started Runner process.
+func (r *Runner) GetLastPid() uint64 {
+	return atomic.LoadUint64(&r.lastPid)
+}
+
 // GetConfig returns the runner's configuration
 func (r *Runner) GetConfig() *Config {
 	return r.Config
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -215,3 +216,27 @@ func TestRunnerNormalExit(t *testing.T) {
 		t.Fatalf("wanted %q; got %q", wantEnv, gotEnv)
 	}
 }
+
+func TestRunner_sysProcAttr(t *testing.T) {
+	for name, tc := range map[string]struct {
+		private        bool
+		expUnshareFlag uintptr
+	}{
+		"shared mount namespace": {},
+		"private mount namespace": {
+			private:        true,
+			expUnshareFlag: syscall.CLONE_NEWNS,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			runner := NewRunner(log, MockConfig().WithPrivateMountNamespace(tc.private))
+			attr := runner.sysProcAttr()
+
+			test.AssertEqual(t, syscall.SIGKILL, attr.Pdeathsig, "unexpected parent death signal")
+			test.AssertEqual(t, tc.expUnshareFlag, attr.Unshareflags, "unexpected unshare flags")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+const (
+	envULTStackSize = "ABT_THREAD_STACKSIZE"
+	envULTMmapStack = "DAOS_ULT_MMAP_STACK"
+
+	// DefaultULTStackSize is the ULT stack size in bytes used by the engine
+	// unless overridden by ABT_THREAD_STACKSIZE (see DEFAULT_STACKSIZE in
+	// src/engine/sched.c).
+	DefaultULTStackSize = 16384
+	// DeepULTStackSize is the stack size in bytes of engine ULTs created
+	// with DSS_ULT_DEEP_STACK (see DSS_DEEP_STACK_SZ in daos_engine.h).
+	DeepULTStackSize = 65536
+
+	redactedValue = "<redacted>"
+)
+
+// Tunables describes the effective tuning parameters of an I/O Engine
+// process, as derived from its configuration and environment.
+type Tunables struct {
+	Args             []string
+	EnvVars          []string
+	ULTStackSize     uint64
+	DeepULTStackSize uint64
+	MmapULTStacks    bool
+	TargetCount      int
+	HelperStreams    int
+	MemSizeMiB       int
+	HugepageSizeMiB  int
+	PinnedNumaNode   *uint
+	ServiceCore      *int
+}
+
+// isSensitiveEnv returns true if the value of the named environment variable
+// must not be reported outside of the host.
+func isSensitiveEnv(name string) bool {
+	for _, substr := range []string{"AUTH_KEY", "PASSWORD", "SECRET", "TOKEN"} {
+		if strings.Contains(name, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// envBool interprets an environment variable value as d_getenv_bool() does in
+// the engine, i.e. anything other than an integer zero is true.
+func envBool(val string) bool {
+	i, err := strconv.ParseInt(val, 10, 64)
+	return err != nil || i != 0
+}
+
+func redactEnvVars(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, pair := range in {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 && isSensitiveEnv(kv[0]) {
+			pair = kv[0] + "=" + redactedValue
+		}
+		out = append(out, pair)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Tunables returns the tuning parameters with which an I/O Engine process is
+// started for this configuration. The values of sensitive environment
+// variables, e.g. fabric authentication keys, are redacted.
+func (c *Config) Tunables() (*Tunables, error) {
+	args, env, err := c.StartParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ProcessTunables(args, env)
+}
+
+// ProcessTunables returns the tuning parameters of an I/O Engine process for
+// this configuration that was started with the supplied arguments and
+// environment, e.g. as read back from procfs for a running engine.
+func (c *Config) ProcessTunables(args, env []string) (*Tunables, error) {
+	t := &Tunables{
+		Args:             args,
+		EnvVars:          redactEnvVars(env),
+		ULTStackSize:     DefaultULTStackSize,
+		DeepULTStackSize: DeepULTStackSize,
+		MmapULTStacks:    true,
+		TargetCount:      c.TargetCount,
+		HelperStreams:    c.HelperStreamCount,
+		MemSizeMiB:       c.MemSize,
+		HugepageSizeMiB:  c.HugepageSz,
+		PinnedNumaNode:   c.PinnedNumaNode,
+		ServiceCore:      c.ServiceThreadCore,
+	}
+
+	if val, err := common.FindKeyValue(env, envULTStackSize); err == nil {
+		if t.ULTStackSize, err = strconv.ParseUint(val, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s value %q", envULTStackSize, val)
+		}
+	}
+	if val, err := common.FindKeyValue(env, envULTMmapStack); err == nil {
+		t.MmapULTStacks = envBool(val)
+	}
+
+	return t, nil
+}
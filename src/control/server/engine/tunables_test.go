@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestEngine_Config_Tunables(t *testing.T) {
+	numaNode := uint(1)
+
+	for name, tc := range map[string]struct {
+		cfg    *Config
+		expTun *Tunables
+		expErr error
+	}{
+		"defaults": {
+			cfg: MockConfig().WithTargetCount(8),
+			expTun: &Tunables{
+				Args:             []string{"-t", "8", "-x", "2", "-I", "0", "-r", "0", "-H", "0"},
+				EnvVars:          []string{"CRT_TIMEOUT=0", "FI_OFI_RXM_USE_SRX=1"},
+				ULTStackSize:     DefaultULTStackSize,
+				DeepULTStackSize: DeepULTStackSize,
+				MmapULTStacks:    true,
+				TargetCount:      8,
+				HelperStreams:    2,
+			},
+		},
+		"overridden stacks; redacted auth key": {
+			cfg: MockConfig().
+				WithTargetCount(16).
+				WithHelperStreamCount(1).
+				WithPinnedNumaNode(numaNode).
+				WithFabricAuthKey("s3cr3t").
+				WithLogMask("ERR").
+				WithEnvVars("ABT_THREAD_STACKSIZE=32768", "DAOS_ULT_MMAP_STACK=0"),
+			expTun: &Tunables{
+				EnvVars: []string{
+					"ABT_THREAD_STACKSIZE=32768",
+					"CRT_TIMEOUT=0",
+					"DAOS_ULT_MMAP_STACK=0",
+					"D_LOG_MASK=ERR",
+					"D_PROVIDER_AUTH_KEY=<redacted>",
+					"FI_OFI_RXM_USE_SRX=1",
+				},
+				ULTStackSize:     32768,
+				DeepULTStackSize: DeepULTStackSize,
+				TargetCount:      16,
+				HelperStreams:    1,
+				PinnedNumaNode:   &numaNode,
+			},
+		},
+		"bad stack size": {
+			cfg:    MockConfig().WithEnvVars("ABT_THREAD_STACKSIZE=big"),
+			expErr: errors.New("invalid ABT_THREAD_STACKSIZE"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotTun, gotErr := tc.cfg.Tunables()
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.expTun.Args == nil {
+				tc.expTun.Args = gotTun.Args
+			}
+			if diff := cmp.Diff(tc.expTun, gotTun); diff != "" {
+				t.Fatalf("unexpected tunables (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+// logBumpWindow tracks an engine whose log mask is currently raised.
+type logBumpWindow struct {
+	start   time.Time
+	trigger string
+	timer   *time.Timer
+}
+
+// engineLogBumper temporarily raises the log mask of a local engine when it
+// raises one of the configured RAS events, restoring the masks from the
+// engine config once the window expires. Repeated events extend the window.
+type engineLogBumper struct {
+	sync.Mutex
+	log        logging.Logger
+	cfg        *config.EngineLogBump
+	engines    []Engine
+	engineCfgs []*engine.Config
+	events     map[string]struct{}
+	windows    map[uint32]*logBumpWindow
+	setMasks   func(context.Context, Engine, *ctlpb.SetLogMasksReq) error
+}
+
+func newEngineLogBumper(log logging.Logger, cfg *config.EngineLogBump, engines []Engine, engineCfgs []*engine.Config) *engineLogBumper {
+	lb := &engineLogBumper{
+		log:        log,
+		cfg:        cfg.WithDefaults(),
+		engines:    engines,
+		engineCfgs: engineCfgs,
+		events:     make(map[string]struct{}),
+		windows:    make(map[uint32]*logBumpWindow),
+		setMasks:   setEngineLogMasks,
+	}
+	for _, id := range lb.cfg.Events {
+		lb.events[id] = struct{}{}
+	}
+
+	return lb
+}
+
+// setEngineLogMasks sets the log masks of a single engine over dRPC.
+func setEngineLogMasks(ctx context.Context, ei Engine, req *ctlpb.SetLogMasksReq) error {
+	dresp, err := ei.CallDrpc(ctx, drpc.MethodSetLogMasks, req)
+	if err != nil {
+		return err
+	}
+
+	resp := new(ctlpb.SetLogMasksResp)
+	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+		return errors.Wrap(err, "unmarshal SetLogMasks response")
+	}
+	if resp.Status != 0 {
+		return daos.Status(resp.Status)
+	}
+
+	return nil
+}
+
+// targets returns the started local engines that an event applies to. Events
+// that don't identify a rank apply to all of them.
+func (lb *engineLogBumper) targets(evt *events.RASEvent) []Engine {
+	var out []Engine
+	for _, ei := range lb.engines {
+		if !ei.IsStarted() {
+			continue
+		}
+		if ranklist.Rank(evt.Rank) != ranklist.NilRank {
+			rank, err := ei.GetRank()
+			if err != nil || rank.Uint32() != evt.Rank {
+				continue
+			}
+		}
+		out = append(out, ei)
+	}
+
+	return out
+}
+
+// OnEvent implements the events.Handler interface. Only events raised by
+// engines on this host are acted upon.
+func (lb *engineLogBumper) OnEvent(ctx context.Context, evt *events.RASEvent) {
+	if evt == nil || evt.IsForwarded() {
+		return
+	}
+	if _, ok := lb.events[evt.ID.String()]; !ok {
+		return
+	}
+
+	for _, ei := range lb.targets(evt) {
+		if err := lb.bump(ctx, ei, evt.ID.String()); err != nil {
+			lb.log.Errorf("instance %d: raise log mask after %s event: %s", ei.Index(),
+				evt.ID, err)
+		}
+	}
+}
+
+// bump raises the log mask of the engine, or extends the current window if the
+// mask is already raised.
+func (lb *engineLogBumper) bump(ctx context.Context, ei Engine, trigger string) error {
+	lb.Lock()
+	defer lb.Unlock()
+
+	idx := ei.Index()
+	if w, found := lb.windows[idx]; found {
+		w.timer.Reset(lb.cfg.Duration)
+		lb.log.Debugf("instance %d: %s event extended raised log mask window", idx, trigger)
+		return nil
+	}
+
+	req := &ctlpb.SetLogMasksReq{
+		Masks:   lb.cfg.LogMask,
+		Streams: lb.cfg.Streams,
+	}
+	if err := updateSetLogMasksReq(lb.engineCfgs[idx], req); err != nil {
+		return err
+	}
+	if err := lb.setMasks(ctx, ei, req); err != nil {
+		return err
+	}
+
+	lb.windows[idx] = &logBumpWindow{
+		start:   time.Now(),
+		trigger: trigger,
+		timer: time.AfterFunc(lb.cfg.Duration, func() {
+			lb.restore(context.Background(), ei)
+		}),
+	}
+	lb.log.Noticef("instance %d: log mask raised to %q for %s after %s event", idx,
+		req.Masks, lb.cfg.Duration, trigger)
+
+	return nil
+}
+
+// restore returns the engine log masks to those set in the engine config and
+// records the verbose window in the server log.
+func (lb *engineLogBumper) restore(ctx context.Context, ei Engine) {
+	lb.Lock()
+	defer lb.Unlock()
+
+	idx := ei.Index()
+	w, found := lb.windows[idx]
+	if !found {
+		return
+	}
+	w.timer.Stop()
+	delete(lb.windows, idx)
+
+	logFile := lb.engineCfgs[idx].LogFile
+	lb.log.Noticef("instance %d: verbose logging after %s event from %s to %s in %s", idx,
+		w.trigger, w.start.Format(time.RFC3339), time.Now().Format(time.RFC3339), logFile)
+
+	if !ei.IsStarted() {
+		// Masks from the engine config are applied on restart.
+		return
+	}
+
+	req := &ctlpb.SetLogMasksReq{
+		ResetMasks:      true,
+		ResetStreams:    true,
+		ResetSubsystems: true,
+	}
+	if err := updateSetLogMasksReq(lb.engineCfgs[idx], req); err != nil {
+		lb.log.Errorf("instance %d: restore log mask: %s", idx, err)
+		return
+	}
+	if err := lb.setMasks(ctx, ei, req); err != nil {
+		lb.log.Errorf("instance %d: restore log mask: %s", idx, err)
+	}
+}
@@ -0,0 +1,162 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_engineLogBumper_OnEvent(t *testing.T) {
+	rankEvt := func(id events.RASID, rank uint32) *events.RASEvent {
+		evt := events.NewGenericEvent(id, events.RASSeverityError, "fault", "")
+		evt.Rank = rank
+		return evt
+	}
+
+	for name, tc := range map[string]struct {
+		cfg       *config.EngineLogBump
+		evts      []*events.RASEvent
+		notReady  bool
+		setErr    error
+		expMasks  map[uint32]string
+		expWindow []uint32
+	}{
+		"unrelated event": {
+			evts: []*events.RASEvent{rankEvt(events.RASEngineDied, 1)},
+		},
+		"forwarded event": {
+			evts: []*events.RASEvent{
+				rankEvt(events.RASPoolCorruptionDetected, 1).WithForwarded(true),
+			},
+		},
+		"event for local rank": {
+			evts:      []*events.RASEvent{rankEvt(events.RASPoolCorruptionDetected, 2)},
+			expMasks:  map[uint32]string{1: "DBUG"},
+			expWindow: []uint32{1},
+		},
+		"event without rank": {
+			cfg: &config.EngineLogBump{
+				Events:  []string{events.RASDeviceSetFaulty.String()},
+				LogMask: "ERR,CSUM=DEBUG",
+				Streams: "csum",
+			},
+			evts: []*events.RASEvent{rankEvt(events.RASDeviceSetFaulty, uint32(ranklist.NilRank))},
+			expMasks: map[uint32]string{
+				0: "ERR,CSUM=DBUG",
+				1: "ERR,CSUM=DBUG",
+			},
+			expWindow: []uint32{0, 1},
+		},
+		"event not in configured list": {
+			cfg: &config.EngineLogBump{
+				Events: []string{events.RASDeviceSetFaulty.String()},
+			},
+			evts: []*events.RASEvent{rankEvt(events.RASPoolCorruptionDetected, 1)},
+		},
+		"repeated event extends window": {
+			evts: []*events.RASEvent{
+				rankEvt(events.RASPoolCorruptionDetected, 1),
+				rankEvt(events.RASDeviceMediaError, 1),
+			},
+			expMasks:  map[uint32]string{0: "DBUG"},
+			expWindow: []uint32{0},
+		},
+		"engines not started": {
+			notReady: true,
+			evts:     []*events.RASEvent{rankEvt(events.RASPoolCorruptionDetected, 1)},
+		},
+		"set masks fails": {
+			setErr: errors.New("dRPC failed"),
+			evts:   []*events.RASEvent{rankEvt(events.RASPoolCorruptionDetected, 1)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var engines []Engine
+			var engineCfgs []*engine.Config
+			for i := 0; i < 2; i++ {
+				mic := &MockInstanceConfig{
+					Index:       uint32(i),
+					GetRankResp: ranklist.Rank(i + 1),
+				}
+				mic.Started.SetTrue()
+				if tc.notReady {
+					mic.Started.SetFalse()
+				}
+				engines = append(engines, NewMockInstance(mic))
+				engineCfgs = append(engineCfgs, engine.MockConfig().WithLogMask("ERR"))
+			}
+
+			cfg := tc.cfg
+			if cfg == nil {
+				cfg = &config.EngineLogBump{}
+			}
+			cfg.Enabled = true
+			cfg.Duration = time.Hour
+
+			lb := newEngineLogBumper(log, cfg, engines, engineCfgs)
+			calls := 0
+			gotMasks := make(map[uint32]string)
+			lb.setMasks = func(_ context.Context, ei Engine, req *ctlpb.SetLogMasksReq) error {
+				calls++
+				if tc.setErr != nil {
+					return tc.setErr
+				}
+				gotMasks[ei.Index()] = req.Masks
+				return nil
+			}
+
+			for _, evt := range tc.evts {
+				lb.OnEvent(test.Context(t), evt)
+			}
+
+			if tc.expMasks == nil {
+				tc.expMasks = map[uint32]string{}
+			}
+			if diff := cmp.Diff(tc.expMasks, gotMasks); diff != "" {
+				t.Fatalf("unexpected masks set (-want, +got):\n%s\n", diff)
+			}
+			if tc.setErr == nil {
+				test.AssertEqual(t, len(tc.expMasks), calls, "unexpected number of dRPC calls")
+			}
+
+			var gotWindow []uint32
+			for idx := range lb.windows {
+				gotWindow = append(gotWindow, idx)
+			}
+			sort.Slice(gotWindow, func(i, j int) bool { return gotWindow[i] < gotWindow[j] })
+			if diff := cmp.Diff(tc.expWindow, gotWindow); diff != "" {
+				t.Fatalf("unexpected raised engines (-want, +got):\n%s\n", diff)
+			}
+
+			// Restoring resets the masks to those in the engine config.
+			for _, ei := range engines {
+				lb.restore(test.Context(t), ei)
+			}
+			test.AssertEqual(t, 0, len(lb.windows), "expected all windows closed")
+			for idx := range tc.expMasks {
+				test.AssertEqual(t, "ERR", gotMasks[idx], "expected config masks restored")
+			}
+		})
+	}
+}
@@ -139,6 +139,18 @@ func FaultEngineNUMAImbalance(nodeMap map[int]int) *fault.Fault {
 	)
 }
 
+// FaultBdevCrossNUMA creates a Fault for an NVMe SSD assigned to an engine on a
+// different NUMA node to the one the SSD is attached to.
+func FaultBdevCrossNUMA(engineIdx int, pciAddr string, ssdNode, engineNode uint) *fault.Fault {
+	return serverFault(
+		code.ServerConfigBdevCrossNUMA,
+		fmt.Sprintf("engine %d bdev %s is attached to NUMA node %d but the engine is bound to NUMA node %d",
+			engineIdx, pciAddr, ssdNode, engineNode),
+		"assign the SSD to an engine on the same NUMA node, or set allow_cross_numa_bdevs: true "+
+			"in the server config file to accept the reduced bandwidth",
+	)
+}
+
 func FaultScmUnmanaged(mntPoint string) *fault.Fault {
 	return serverFault(
 		code.ServerScmUnmanaged,
@@ -147,6 +159,15 @@ func FaultScmUnmanaged(mntPoint string) *fault.Fault {
 	)
 }
 
+func FaultInstanceSuperblockMismatch(idx uint32, prev, cur *Superblock) *fault.Fault {
+	return serverFault(
+		code.ServerInstanceSuperblockMismatch,
+		fmt.Sprintf("superblock found on storage for instance %d (rank %s, uuid %s) does not match previous superblock (rank %s, uuid %s)",
+			idx, cur.Rank, cur.UUID, prev.Rank, prev.UUID),
+		"check that the correct storage devices are mounted for the instance and restart daos_server",
+	)
+}
+
 func FaultWrongSystem(reqName, sysName string) *fault.Fault {
 	return serverFault(
 		code.ServerWrongSystem,
@@ -163,6 +184,28 @@ func FaultIncompatibleComponents(self, other *build.VersionedComponent) *fault.F
 	)
 }
 
+// FaultJoinMissingFeatures indicates that a server was refused membership
+// because it lacks features that every member must support.
+func FaultJoinMissingFeatures(missing []build.Feature) *fault.Fault {
+	strs := make([]string, len(missing))
+	for i, f := range missing {
+		strs[i] = f.String()
+	}
+	return serverFault(
+		code.ServerJoinMissingFeatures,
+		fmt.Sprintf("joining server does not support required features: %s", strings.Join(strs, ", ")),
+		"upgrade the joining server to the same version as the rest of the system",
+	)
+}
+
+func FaultPoolInvalidACL(err error) *fault.Fault {
+	return serverFault(
+		code.ServerPoolInvalidACL,
+		fmt.Sprintf("pool ACL is invalid: %s", err),
+		"correct the ACL entries and retry the request",
+	)
+}
+
 func FaultNoCompatibilityInsecure(self, other build.Version) *fault.Fault {
 	return serverFault(
 		code.ServerNoCompatibilityInsecure,
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -22,6 +22,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -48,8 +49,12 @@ type Engine interface {
 
 	// This is a more reasonable surface that will be easier to maintain and test.
 	CallDrpc(context.Context, drpc.Method, proto.Message) (*drpc.Response, error)
+	CallDrpcBatch(context.Context, drpc.Method, []proto.Message) ([]*drpc.Response, error)
 	GetRank() (ranklist.Rank, error)
+	GetUUID() (string, error)
+	GetResourceUsage() (*EngineResourceUsage, error)
 	GetTargetCount() int
+	GetTunables() (*engine.Tunables, error)
 	Index() uint32
 	IsStarted() bool
 	IsReady() bool
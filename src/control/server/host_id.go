@@ -0,0 +1,107 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// hostIDFile is the name of the file holding the persistent host ID, which is
+// kept alongside the control plane raft directory.
+const hostIDFile = "daos_host_id"
+
+// cfgGetHostIDPath returns the path of the persistent host ID file.
+func cfgGetHostIDPath(cfg *config.Server) string {
+	raftDir := cfgGetRaftDir(cfg)
+	if raftDir == "" {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(raftDir), hostIDFile)
+}
+
+// readHostID reads the host ID stored at the given path.
+func readHostID(path string) (uuid.UUID, error) {
+	if path == "" {
+		return uuid.Nil, errors.New("host ID path not available (missing SCM or control metadata in config?)")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return uuid.Nil, errors.Wrapf(err, "invalid host ID in %s", path)
+	}
+
+	return id, nil
+}
+
+// loadHostID reads the host ID stored at the given path, generating and
+// storing a new one if there is none. The file is written atomically so that
+// a crash cannot leave a partial ID behind.
+func loadHostID(path string) (uuid.UUID, error) {
+	id, err := readHostID(path)
+	if err == nil || !os.IsNotExist(err) {
+		return id, err
+	}
+
+	id = uuid.New()
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(id.String()+"\n"), 0644); err != nil {
+		return uuid.Nil, errors.Wrap(err, "failed to write host ID")
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return uuid.Nil, errors.Wrap(err, "failed to store host ID")
+	}
+
+	return id, nil
+}
+
+// hostIDStore provides the persistent identity of the server host, which is
+// used to recognize the server in the system membership and raft cluster
+// independently of its address. As the ID may be stored on SCM, it is loaded
+// on first use rather than at startup.
+type hostIDStore struct {
+	sync.Mutex
+	path string
+	id   uuid.UUID
+}
+
+func newHostIDStore(cfg *config.Server) *hostIDStore {
+	return &hostIDStore{
+		path: cfgGetHostIDPath(cfg),
+	}
+}
+
+// get returns the host ID, loading or creating it if necessary.
+func (his *hostIDStore) get() (uuid.UUID, error) {
+	his.Lock()
+	defer his.Unlock()
+
+	if his.id != uuid.Nil {
+		return his.id, nil
+	}
+
+	id, err := loadHostID(his.path)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	his.id = id
+
+	return id, nil
+}
@@ -0,0 +1,113 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestServer_cfgGetHostIDPath(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg     *config.Server
+		expPath string
+	}{
+		"no storage": {
+			cfg: config.DefaultServer(),
+		},
+		"control metadata": {
+			cfg: config.DefaultServer().WithControlMetadata(storage.ControlMetadata{
+				Path: "/metadata",
+			}),
+			expPath: "/metadata/daos_control/daos_host_id",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expPath, cfgGetHostIDPath(tc.cfg), "unexpected path")
+		})
+	}
+}
+
+func TestServer_loadHostID(t *testing.T) {
+	storedID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		contents *string
+		expID    uuid.UUID
+		expErr   error
+	}{
+		"new ID": {},
+		"existing ID": {
+			contents: func() *string { s := storedID.String() + "\n"; return &s }(),
+			expID:    storedID,
+		},
+		"invalid ID": {
+			contents: func() *string { s := "frodo"; return &s }(),
+			expErr:   errors.New("invalid host ID"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), hostIDFile)
+			if tc.contents != nil {
+				if err := os.WriteFile(path, []byte(*tc.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotID, gotErr := loadHostID(path)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.expID != uuid.Nil {
+				test.AssertEqual(t, tc.expID, gotID, "unexpected host ID")
+			}
+			if gotID == uuid.Nil {
+				t.Fatal("expected a host ID")
+			}
+
+			// The ID must be the same when loaded again.
+			readID, err := readHostID(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, gotID, readID, "host ID not persisted")
+		})
+	}
+}
+
+func TestServer_hostIDStore(t *testing.T) {
+	his := &hostIDStore{path: filepath.Join(t.TempDir(), hostIDFile)}
+
+	first, err := his.get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A cached ID is returned even if the file goes away.
+	if err := os.Remove(his.path); err != nil {
+		t.Fatal(err)
+	}
+	second, err := his.get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, first, second, "unexpected host ID")
+
+	if _, err := (&hostIDStore{}).get(); err == nil {
+		t.Fatal("expected error with no path")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -22,6 +23,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -32,6 +34,9 @@ type (
 	onStorageReadyFn func(context.Context) error
 	onReadyFn        func(context.Context) error
 	onInstanceExitFn func(context.Context, uint32, ranklist.Rank, error, int) error
+	// onStartupTimeoutFn receives the instance index, the startup timeout
+	// and the path of any diagnostics bundle captured.
+	onStartupTimeoutFn func(context.Context, uint32, time.Duration, string) error
 )
 
 // EngineInstance encapsulates control-plane specific configuration
@@ -42,23 +47,32 @@ type (
 // be used with EngineHarness to manage and monitor multiple instances
 // per node.
 type EngineInstance struct {
-	log             logging.Logger
-	runner          EngineRunner
-	storage         *storage.Provider
-	waitFormat      atm.Bool
-	storageReady    chan bool
-	waitDrpc        atm.Bool
-	drpcReady       chan *srvpb.NotifyReadyReq
-	ready           atm.Bool
-	startRequested  chan bool
-	fsRoot          string
-	hostFaultDomain *system.FaultDomain
-	joinSystem      systemJoinFn
-	onAwaitFormat   []onAwaitFormatFn
-	onStorageReady  []onStorageReadyFn
-	onReady         []onReadyFn
-	onInstanceExit  []onInstanceExitFn
-	getDrpcClientFn func(string) drpc.DomainSocketClient
+	log              logging.Logger
+	runner           EngineRunner
+	storage          *storage.Provider
+	waitFormat       atm.Bool
+	storageReady     chan bool
+	waitDrpc         atm.Bool
+	drpcReady        chan *srvpb.NotifyReadyReq
+	ready            atm.Bool
+	startRequested   chan bool
+	fsRoot           string
+	hostFaultDomain  *system.FaultDomain
+	joinSystem       systemJoinFn
+	onAwaitFormat    []onAwaitFormatFn
+	onStorageReady   []onStorageReadyFn
+	onReady          []onReadyFn
+	onInstanceExit   []onInstanceExitFn
+	onStartupTimeout []onStartupTimeoutFn
+	getDrpcClientFn  func(string) drpc.DomainSocketClient
+
+	// storage fault recovery parameters
+	storageRecoveryInterval time.Duration
+	storageRecoveryAttempts int
+
+	// number of consecutive restarts following startup timeouts, only
+	// accessed from the instance control loop
+	startupTimeoutRestarts int
 
 	sync.RWMutex
 	// these must be protected by a mutex in order to
@@ -80,6 +94,9 @@ func NewEngineInstance(l logging.Logger, p *storage.Provider, jf systemJoinFn, r
 		drpcReady:      make(chan *srvpb.NotifyReadyReq),
 		storageReady:   make(chan bool),
 		startRequested: make(chan bool),
+
+		storageRecoveryInterval: storageRecoveryInterval,
+		storageRecoveryAttempts: storageRecoveryAttempts,
 	}
 }
 
@@ -133,6 +150,12 @@ func (ei *EngineInstance) OnInstanceExit(fns ...onInstanceExitFn) {
 	ei.onInstanceExit = append(ei.onInstanceExit, fns...)
 }
 
+// OnStartupTimeout adds a list of callbacks to invoke when the instance
+// fails to become ready within the configured startup timeout.
+func (ei *EngineInstance) OnStartupTimeout(fns ...onStartupTimeoutFn) {
+	ei.onStartupTimeout = append(ei.onStartupTimeout, fns...)
+}
+
 // LocalState returns local perspective of the current instance state
 // (doesn't consider state info held by the global system membership).
 func (ei *EngineInstance) LocalState() system.MemberState {
@@ -164,6 +187,26 @@ func (ei *EngineInstance) SetCheckerMode(enabled bool) {
 	ei.runner.GetConfig().CheckerEnabled = enabled
 }
 
+// GetTunables returns the tuning parameters of the engine process managed by
+// the instance. For a running engine these reflect the arguments and
+// environment the process was actually started with, otherwise the values
+// with which it would be started from the current configuration.
+func (ei *EngineInstance) GetTunables() (*engine.Tunables, error) {
+	cfg := ei.runner.GetConfig()
+
+	pid := int(ei.runner.GetLastPid())
+	if !ei.IsStarted() || pid == 0 {
+		return cfg.Tunables()
+	}
+
+	args, env, err := readProcStartParams(procRoot, pid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "instance %d", ei.Index())
+	}
+
+	return cfg.ProcessTunables(args, env)
+}
+
 // removeSocket removes the socket file used for dRPC communication with
 // harness and updates relevant ready states.
 func (ei *EngineInstance) removeSocket() error {
@@ -343,6 +386,16 @@ func (ei *EngineInstance) GetRank() (ranklist.Rank, error) {
 	return *sb.Rank, nil
 }
 
+// GetUUID returns the instance UUID recorded in its superblock, or error.
+func (ei *EngineInstance) GetUUID() (string, error) {
+	sb := ei.getSuperblock()
+	if sb == nil {
+		return "", errors.New("nil superblock")
+	}
+
+	return sb.UUID, nil
+}
+
 // setMemSize updates memory size in engine config.
 func (ei *EngineInstance) setMemSize(memSizeMb int) {
 	ei.Lock()
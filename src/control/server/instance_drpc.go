@@ -21,6 +21,7 @@ import (
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -73,37 +74,74 @@ func (ei *EngineInstance) isDrpcSocketReady() bool {
 	return ei.getDrpcSocket() != ""
 }
 
+func (ei *EngineInstance) drpcRankMsg() string {
+	if sb := ei.getSuperblock(); sb != nil && sb.Rank != nil {
+		return fmt.Sprintf(" (rank %s)", sb.Rank)
+	}
+	return ""
+}
+
 func (ei *EngineInstance) callDrpc(ctx context.Context, method drpc.Method, body proto.Message) (*drpc.Response, error) {
 	dc := ei.getDrpcClient()
+	log := logging.ForSubsystem(ei.log, logging.SubsystemDrpc)
+	rankMsg := ei.drpcRankMsg()
 
-	rankMsg := ""
-	if sb := ei.getSuperblock(); sb != nil && sb.Rank != nil {
-		rankMsg = fmt.Sprintf(" (rank %s)", sb.Rank)
-	}
+	startedAt := time.Now()
+	defer func() {
+		log.Debugf("dRPC to index %d%s: %s/%dB/%s", ei.Index(), rankMsg, method, proto.Size(body), time.Since(startedAt))
+	}()
+
+	return makeDrpcCall(ctx, log, dc, method, body)
+}
+
+func (ei *EngineInstance) callDrpcBatch(ctx context.Context, method drpc.Method, bodies []proto.Message) ([]*drpc.Response, error) {
+	dc := ei.getDrpcClient()
+	log := logging.ForSubsystem(ei.log, logging.SubsystemDrpc)
+	rankMsg := ei.drpcRankMsg()
 
 	startedAt := time.Now()
 	defer func() {
-		ei.log.Debugf("dRPC to index %d%s: %s/%dB/%s", ei.Index(), rankMsg, method, proto.Size(body), time.Since(startedAt))
+		log.Debugf("dRPC batch to index %d%s: %d x %s/%s", ei.Index(), rankMsg, len(bodies), method, time.Since(startedAt))
 	}()
 
-	return makeDrpcCall(ctx, ei.log, dc, method, body)
+	return makeDrpcBatchCall(ctx, log, dc, method, bodies)
 }
 
-// CallDrpc makes the supplied dRPC call via this instance's dRPC client.
-func (ei *EngineInstance) CallDrpc(ctx context.Context, method drpc.Method, body proto.Message) (*drpc.Response, error) {
+// checkDrpcReady returns an error if this instance can't receive dRPC calls.
+func (ei *EngineInstance) checkDrpcReady() error {
 	if !ei.IsStarted() {
-		return nil, FaultDataPlaneNotStarted
+		return FaultDataPlaneNotStarted
 	}
 	if !ei.IsReady() {
-		return nil, errEngineNotReady
+		return errEngineNotReady
 	}
 	if !ei.isDrpcSocketReady() {
-		return nil, errDRPCNotReady
+		return errDRPCNotReady
+	}
+
+	return nil
+}
+
+// CallDrpc makes the supplied dRPC call via this instance's dRPC client.
+func (ei *EngineInstance) CallDrpc(ctx context.Context, method drpc.Method, body proto.Message) (*drpc.Response, error) {
+	if err := ei.checkDrpcReady(); err != nil {
+		return nil, err
 	}
 
 	return ei.callDrpc(ctx, method, body)
 }
 
+// CallDrpcBatch makes a dRPC call to the method for each of the supplied
+// bodies via this instance's dRPC client, sending them to the engine in a
+// single message. The responses are returned in the same order as the bodies.
+func (ei *EngineInstance) CallDrpcBatch(ctx context.Context, method drpc.Method, bodies []proto.Message) ([]*drpc.Response, error) {
+	if err := ei.checkDrpcReady(); err != nil {
+		return nil, err
+	}
+
+	return ei.callDrpcBatch(ctx, method, bodies)
+}
+
 // drespToMemberResult converts drpc.Response to system.MemberResult.
 //
 // MemberResult is populated with rank, state and error dependent on processing
@@ -199,6 +237,63 @@ func getBioHealth(ctx context.Context, engine Engine, req *ctlpb.BioHealthReq) (
 	return resp, nil
 }
 
+// getBioIOStats fetches the I/O stats of each of the requested devices in a
+// single dRPC round trip. The responses are returned in the same order as the
+// requests, and the first failure is returned.
+func getBioIOStats(ctx context.Context, engine Engine, reqs ...*ctlpb.BioIOStatsReq) ([]*ctlpb.BioIOStatsResp, error) {
+	bodies := make([]proto.Message, 0, len(reqs))
+	for _, req := range reqs {
+		bodies = append(bodies, req)
+	}
+
+	dresps, err := engine.CallDrpcBatch(ctx, drpc.MethodBioIOStats, bodies)
+	if err != nil {
+		return nil, errors.Wrap(err, "BioIOStats dRPC call")
+	}
+	if len(dresps) != len(reqs) {
+		return nil, errors.Errorf("BioIOStats dRPC call: %d responses for %d requests",
+			len(dresps), len(reqs))
+	}
+
+	resps := make([]*ctlpb.BioIOStatsResp, 0, len(reqs))
+	for i, dresp := range dresps {
+		if err := checkDrpcResponse(dresp); err != nil {
+			return nil, errors.Wrapf(err, "BioIOStats dRPC call for %q", reqs[i].DevUuid)
+		}
+
+		resp := new(ctlpb.BioIOStatsResp)
+		if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+			return nil, errors.Wrap(err, "unmarshal BioIOStats response")
+		}
+
+		if resp.Status != 0 {
+			return nil, errors.Wrapf(daos.Status(resp.Status), "BioIOStats response status for %q",
+				reqs[i].DevUuid)
+		}
+		resps = append(resps, resp)
+	}
+
+	return resps, nil
+}
+
+func getSwimView(ctx context.Context, engine Engine) (*ctlpb.SwimViewResp, error) {
+	dresp, err := engine.CallDrpc(ctx, drpc.MethodSwimView, new(ctlpb.SwimViewReq))
+	if err != nil {
+		return nil, errors.Wrap(err, "SwimView dRPC call")
+	}
+
+	resp := new(ctlpb.SwimViewResp)
+	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal SwimView response")
+	}
+
+	if resp.Status != 0 {
+		return nil, errors.Wrap(daos.Status(resp.Status), "SwimView response status")
+	}
+
+	return resp, nil
+}
+
 func listSmdDevices(ctx context.Context, engine Engine, req *ctlpb.SmdDevReq) (*ctlpb.SmdDevResp, error) {
 	dresp, err := engine.CallDrpc(ctx, drpc.MethodSmdDevs, req)
 	if err != nil {
@@ -215,6 +215,97 @@ func TestEngineInstance_CallDrpc_Parallel(t *testing.T) {
 	wg.Wait()
 }
 
+func TestEngineInstance_CallDrpcBatch(t *testing.T) {
+	// batchHandler replies to each call, or to each call in a batch, with a
+	// response echoing its body.
+	batchHandler := func(supported bool, sent *int) func(context.Context, *drpc.Call) (*drpc.Response, error) {
+		return func(_ context.Context, call *drpc.Call) (*drpc.Response, error) {
+			*sent++
+			if call.Module != drpc.ModuleBatch.ID() {
+				return &drpc.Response{Sequence: call.Sequence, Body: call.Body}, nil
+			}
+			if !supported {
+				return &drpc.Response{Sequence: call.Sequence, Status: drpc.Status_UNKNOWN_MODULE}, nil
+			}
+
+			batch := new(drpc.CallBatch)
+			if err := proto.Unmarshal(call.Body, batch); err != nil {
+				return nil, err
+			}
+			resps := new(drpc.ResponseBatch)
+			for _, c := range batch.Calls {
+				resps.Responses = append(resps.Responses,
+					&drpc.Response{Sequence: c.Sequence, Body: c.Body})
+			}
+			body, err := proto.Marshal(resps)
+			if err != nil {
+				return nil, err
+			}
+			return &drpc.Response{Sequence: call.Sequence, Body: body}, nil
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		notReady       bool
+		noBatchSupport bool
+		expErr         error
+		expSent        int
+	}{
+		"not ready": {
+			notReady: true,
+			expErr:   errEngineNotReady,
+		},
+		"single round trip": {
+			expSent: 1,
+		},
+		"engine without batch support": {
+			noBatchSupport: true,
+			expSent:        4,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			trc := engine.TestRunnerConfig{}
+			trc.Running.Store(true)
+			runner := engine.NewTestRunner(&trc, engine.MockConfig())
+			instance := NewEngineInstance(log, nil, nil, runner)
+			instance.ready.Store(!tc.notReady)
+			instance.setDrpcSocket("/something")
+
+			var sent int
+			instance.getDrpcClientFn = func(s string) drpc.DomainSocketClient {
+				return &sendMsgDrpcClient{
+					sendMsgFn: batchHandler(!tc.noBatchSupport, &sent),
+				}
+			}
+
+			reqs := []proto.Message{
+				&mgmtpb.PoolQueryReq{Id: "pool1"},
+				&mgmtpb.PoolQueryReq{Id: "pool2"},
+				&mgmtpb.PoolQueryReq{Id: "pool3"},
+			}
+			resps, err := instance.CallDrpcBatch(test.Context(t), drpc.MethodPoolQuery, reqs)
+			test.CmpErr(t, tc.expErr, err)
+			test.AssertEqual(t, tc.expSent, sent, "unexpected number of messages sent")
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, len(reqs), len(resps), "unexpected number of responses")
+			for i, resp := range resps {
+				got := new(mgmtpb.PoolQueryReq)
+				if err := proto.Unmarshal(resp.Body, got); err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, reqs[i].(*mgmtpb.PoolQueryReq).Id, got.Id,
+					"response out of order")
+			}
+		})
+	}
+}
+
 func TestEngineInstance_DrespToRankResult(t *testing.T) {
 	dRank := Rank(1)
 
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,22 +11,35 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
 	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/server/engine"
 )
 
+const (
+	// storageRecoveryInterval is the time to wait between attempts to
+	// recover the storage of an instance that exited due to a storage fault.
+	storageRecoveryInterval = 10 * time.Second
+	// storageRecoveryAttempts is the number of recovery attempts made before
+	// giving up and leaving the instance stopped.
+	storageRecoveryAttempts = 30
+)
+
 // EngineRunner defines an interface for starting and stopping the
 // daos_engine.
 type EngineRunner interface {
 	Start(context.Context) (engine.RunnerExitChan, error)
 	IsRunning() bool
 	Signal(os.Signal)
+	GetLastPid() uint64
 	GetConfig() *engine.Config
 }
 
@@ -45,6 +58,9 @@ func (ei *EngineInstance) format(ctx context.Context) error {
 		return errors.Errorf("instance %d: no superblock after format", idx)
 	}
 
+	// Snapshot any metadata written by the engine in previous runs.
+	ei.syncMetadataMirror()
+
 	// After we know that the instance storage is ready, fire off
 	// any callbacks that were waiting for this state.
 	for _, readyFn := range ei.onStorageReady {
@@ -70,10 +86,23 @@ func (ei *EngineInstance) start(ctx context.Context) (chan *engine.RunnerExitInf
 // waitReady awaits ready signal from I/O Engine before starting
 // management service on MS replicas immediately so other instances can join.
 // I/O Engine modules are then loaded.
+//
+// If a startup timeout is configured and the engine has not reported ready
+// before it expires, diagnostics are captured and an error is returned.
 func (ei *EngineInstance) waitReady(ctx context.Context) error {
+	var deadline <-chan time.Time
+	timeout := ei.runner.GetConfig().StartupTimeout
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
 	select {
 	case <-ctx.Done(): // propagated harness exit
 		return ctx.Err()
+	case <-deadline:
+		return ei.handleStartupTimeout(ctx, timeout)
 	case ready := <-ei.awaitDrpcReady():
 		if err := ei.finishStartup(ctx, ready); err != nil {
 			return err
@@ -92,6 +121,7 @@ func (ei *EngineInstance) finishStartup(ctx context.Context, ready *srvpb.Notify
 	}
 
 	ei.ready.SetTrue()
+	ei.startupTimeoutRestarts = 0
 
 	for _, fn := range ei.onReady {
 		if err := fn(ctx); err != nil {
@@ -181,6 +211,42 @@ func (ei *EngineInstance) startRunner(parent context.Context) (_ chan *engine.Ru
 	return runnerExitChan, ei.waitReady(ctx)
 }
 
+// restartAfterStorageFault is invoked after an engine has exited and its
+// storage is found to be unavailable. The storage is periodically remounted
+// and the superblock re-verified, and once successful a restart is requested
+// so that the engine rejoins the system in place with its existing rank.
+func (ei *EngineInstance) restartAfterStorageFault(ctx context.Context) {
+	idx := ei.Index()
+
+	for i := 1; i <= ei.storageRecoveryAttempts; i++ {
+		err := ei.verifyStorage()
+		if err == nil {
+			if ei.IsStarted() {
+				ei.log.Debugf("instance %d: already restarted", idx)
+				return
+			}
+			ei.log.Noticef("instance %d: storage recovered; restarting", idx)
+			ei.requestStart(ctx)
+			return
+		}
+		if fault.IsFaultCode(err, code.ServerInstanceSuperblockMismatch) {
+			ei.log.Errorf("instance %d: not restarting: %s", idx, err)
+			return
+		}
+		ei.log.Debugf("instance %d: storage recovery attempt %d/%d failed: %s",
+			idx, i, ei.storageRecoveryAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ei.storageRecoveryInterval):
+		}
+	}
+
+	ei.log.Errorf("instance %d: storage not recovered after %d attempts; manual restart required",
+		idx, ei.storageRecoveryAttempts)
+}
+
 // requestStart makes a request to (re-)start the engine, and blocks
 // until the request is received.
 func (ei *EngineInstance) requestStart(ctx context.Context) {
@@ -213,6 +279,19 @@ func (ei *EngineInstance) Run(ctx context.Context) {
 				}
 
 				runnerExitCh, err = ei.startRunner(ctx)
+				if errors.Cause(err) == errEngineStartupTimeout && runnerExitCh != nil {
+					// The engine is stopped as a result of the startup error and
+					// the exit is handled once the process has terminated.
+					ei.log.Errorf("instance %d: %s", ei.Index(), err)
+					restartRequested = ei.startupTimeoutRestarts < ei.runner.GetConfig().StartupRestarts
+					if restartRequested {
+						ei.startupTimeoutRestarts++
+						ei.log.Noticef("instance %d: restarting after startup timeout (%d/%d)",
+							ei.Index(), ei.startupTimeoutRestarts,
+							ei.runner.GetConfig().StartupRestarts)
+					}
+					continue
+				}
 				if err != nil {
 					ei.log.Errorf("runner exited without starting process: %s", err)
 					ei.handleExit(ctx, 0, err)
@@ -221,9 +300,14 @@ func (ei *EngineInstance) Run(ctx context.Context) {
 			case runnerExit := <-runnerExitCh:
 				ei.handleExit(ctx, runnerExit.PID, runnerExit.Error)
 				runnerExitCh = nil // next runner will reset this
-				if restartRequested {
+				switch {
+				case restartRequested:
 					go ei.requestStart(ctx)
 					restartRequested = false
+				case ei.hasSuperblock() && ei.storageFaulted():
+					ei.log.Noticef("instance %d: storage unavailable after exit; attempting recovery",
+						ei.Index())
+					go ei.restartAfterStorageFault(ctx)
 				}
 			}
 		}
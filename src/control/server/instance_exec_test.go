@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,9 +7,13 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -18,7 +22,10 @@ import (
 	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/provider/system"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
 )
 
 // TestIOEngineInstance_exit establishes that event is published on exit.
@@ -97,3 +104,210 @@ func TestIOEngineInstance_exit(t *testing.T) {
 		})
 	}
 }
+
+func TestIOEngineInstance_restartAfterStorageFault(t *testing.T) {
+	mnt := "/mnt/test"
+	dcpmCfg := engine.MockConfig().WithStorage(
+		storage.NewTierConfig().
+			WithStorageClass(storage.ClassDcpm.String()).
+			WithScmMountPoint(mnt).
+			WithScmDeviceList("/dev/foo"),
+	)
+	prevSB := &Superblock{UUID: "engine-uuid", Rank: ranklist.NewRankPtr(1), ValidRank: true}
+
+	for name, tc := range map[string]struct {
+		sbOnDisk   *Superblock
+		mountErr   error
+		readErr    error
+		isRunning  bool
+		expRestart bool
+		expSB      *Superblock
+	}{
+		"storage recovered": {
+			sbOnDisk:   prevSB,
+			expRestart: true,
+			expSB:      prevSB,
+		},
+		"storage recovered; already restarted": {
+			sbOnDisk:  prevSB,
+			isRunning: true,
+			expSB:     prevSB,
+		},
+		"remount fails": {
+			sbOnDisk: prevSB,
+			mountErr: errors.New("mount failed"),
+			expSB:    prevSB,
+		},
+		"superblock read fails": {
+			readErr: errors.New("read failed"),
+			expSB:   prevSB,
+		},
+		"superblock mismatch": {
+			sbOnDisk: &Superblock{UUID: "other-uuid", Rank: ranklist.NewRankPtr(1), ValidRank: true},
+			expSB:    prevSB,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			sbPath := filepath.Join(mnt, "superblock")
+			msc := &system.MockSysConfig{
+				MountErr:       tc.mountErr,
+				ReadFileErrors: map[string]error{sbPath: tc.readErr},
+			}
+			if tc.sbOnDisk != nil {
+				data, err := tc.sbOnDisk.Marshal()
+				if err != nil {
+					t.Fatal(err)
+				}
+				msc.ReadFileResults = map[string][]byte{sbPath: data}
+			}
+
+			trc := &engine.TestRunnerConfig{}
+			trc.Running.Store(tc.isRunning)
+			runner := engine.NewTestRunner(trc, dcpmCfg)
+			mp := storage.NewProvider(log, 0, &dcpmCfg.Storage,
+				system.NewMockSysProvider(log, msc),
+				scm.NewMockProvider(log, &scm.MockBackendConfig{}, msc),
+				nil, nil)
+			ei := NewEngineInstance(log, mp, nil, runner)
+			ei.storageRecoveryInterval = time.Millisecond
+			ei.storageRecoveryAttempts = 3
+			ei.setSuperblock(prevSB)
+
+			ctx, cancel := context.WithTimeout(test.Context(t), time.Second)
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				ei.restartAfterStorageFault(ctx)
+				close(done)
+			}()
+
+			var gotRestart bool
+			select {
+			case gotRestart = <-ei.startRequested:
+				<-done
+			case <-done:
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for storage recovery")
+			}
+
+			test.AssertEqual(t, tc.expRestart, gotRestart, "unexpected restart request")
+			if diff := cmp.Diff(tc.expSB, ei.getSuperblock()); diff != "" {
+				t.Fatalf("unexpected superblock (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestIOEngineInstance_waitReady_startupTimeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		isRunning  bool
+		expDump    bool
+		expSummary string
+	}{
+		"engine running": {
+			isRunning: true,
+			expDump:   true,
+		},
+		"engine not running": {
+			expSummary: "engine process not running; no stack dump taken",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			origWait, origDumpDir, origProcRoot := startupDiagStackWait, startupDiagDumpDir, startupDiagProcRoot
+			defer func() {
+				startupDiagStackWait, startupDiagDumpDir, startupDiagProcRoot = origWait, origDumpDir, origProcRoot
+			}()
+			startupDiagStackWait = 0
+			startupDiagDumpDir = testDir
+			startupDiagProcRoot = filepath.Join(testDir, "proc")
+
+			logFile := filepath.Join(testDir, "engine.log")
+			if err := os.WriteFile(logFile, []byte("engine log\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dumpFile := filepath.Join(testDir, "daos_dump_1234_20240101_00_00.txt")
+
+			var gotSignals []os.Signal
+			trc := &engine.TestRunnerConfig{
+				LastPid: 1234,
+				SignalCb: func(_ uint32, sig os.Signal) {
+					gotSignals = append(gotSignals, sig)
+					if err := os.WriteFile(dumpFile, []byte("stacks\n"), 0644); err != nil {
+						t.Fatal(err)
+					}
+				},
+			}
+			trc.Running.Store(tc.isRunning)
+			cfg := engine.MockConfig().
+				WithLogFile(logFile).
+				WithStartupTimeout(time.Millisecond).
+				WithStorage(
+					storage.NewTierConfig().
+						WithStorageClass(storage.ClassDcpm.String()).
+						WithScmMountPoint("/mnt/test").
+						WithScmDeviceList("/dev/foo"),
+				)
+			runner := engine.NewTestRunner(trc, cfg)
+			msc := &system.MockSysConfig{}
+			mp := storage.NewProvider(log, 0, &cfg.Storage,
+				system.NewMockSysProvider(log, msc),
+				scm.NewMockProvider(log, &scm.MockBackendConfig{}, msc),
+				nil, nil)
+			ei := NewEngineInstance(log, mp, nil, runner)
+
+			var gotBundle string
+			var rxEvts []*events.RASEvent
+			ei.OnStartupTimeout(func(_ context.Context, _ uint32, _ time.Duration, bundle string) error {
+				gotBundle = bundle
+				return nil
+			})
+			ei.OnStartupTimeout(createPublishStartupTimeoutFunc(func(evt *events.RASEvent) {
+				rxEvts = append(rxEvts, evt)
+			}, "foo"))
+
+			gotErr := ei.waitReady(test.Context(t))
+			if errors.Cause(gotErr) != errEngineStartupTimeout {
+				t.Fatalf("expected startup timeout error, got %v", gotErr)
+			}
+
+			test.AssertEqual(t, 1, len(rxEvts), "unexpected number of events published")
+			test.AssertEqual(t, events.RASEngineStartupTimeout, rxEvts[0].ID, "unexpected event ID")
+			test.AssertEqual(t, "diagnostics saved to "+gotBundle,
+				string(*rxEvts[0].GetStrInfo()), "unexpected event details")
+
+			if tc.expDump {
+				test.AssertEqual(t, 1, len(gotSignals), "unexpected number of signals")
+				if _, err := os.Stat(filepath.Join(gotBundle, filepath.Base(dumpFile))); err != nil {
+					t.Fatalf("stack dump not collected: %s", err)
+				}
+			} else {
+				test.AssertEqual(t, 0, len(gotSignals), "unexpected number of signals")
+			}
+
+			for _, name := range []string{"summary.txt", "storage.txt", "engine.log"} {
+				if _, err := os.Stat(filepath.Join(gotBundle, name)); err != nil {
+					t.Fatalf("%s not collected: %s", name, err)
+				}
+			}
+
+			if tc.expSummary != "" {
+				summary, err := os.ReadFile(filepath.Join(gotBundle, "summary.txt"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertTrue(t, strings.Contains(string(summary), tc.expSummary),
+					"summary missing expected note")
+			}
+		})
+	}
+}
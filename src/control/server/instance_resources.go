@@ -0,0 +1,201 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	procRoot = "/proc"
+
+	// userHZ is the number of clock ticks per second used by the kernel
+	// to report process CPU times in /proc/<pid>/stat.
+	userHZ = 100
+)
+
+// EngineResourceUsage contains resource usage details for an engine process,
+// as harvested from procfs.
+type EngineResourceUsage struct {
+	PID           int
+	RSSBytes      uint64
+	HugepageBytes uint64
+	OpenFDs       uint32
+	// CPUSeconds maps a thread name (e.g. an xstream) to the cumulative CPU
+	// time consumed by all threads in the process with that name.
+	CPUSeconds map[string]float64
+}
+
+// GetResourceUsage returns the resource usage details for the running engine
+// process managed by the instance.
+func (ei *EngineInstance) GetResourceUsage() (*EngineResourceUsage, error) {
+	if !ei.IsStarted() {
+		return nil, errors.Errorf("instance %d: not started", ei.Index())
+	}
+
+	pid := int(ei.runner.GetLastPid())
+	if pid == 0 {
+		return nil, errors.Errorf("instance %d: no process ID", ei.Index())
+	}
+
+	return readProcResourceUsage(procRoot, pid)
+}
+
+// readProcResourceUsage reads resource usage details for the given process
+// from the procfs mounted at the supplied root.
+func readProcResourceUsage(root string, pid int) (*EngineResourceUsage, error) {
+	pidDir := filepath.Join(root, strconv.Itoa(pid))
+
+	ru := &EngineResourceUsage{
+		PID:        pid,
+		CPUSeconds: make(map[string]float64),
+	}
+
+	if err := readProcStatus(filepath.Join(pidDir, "status"), ru); err != nil {
+		return nil, err
+	}
+
+	fds, err := os.ReadDir(filepath.Join(pidDir, "fd"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read open file descriptors")
+	}
+	ru.OpenFDs = uint32(len(fds))
+
+	tasks, err := os.ReadDir(filepath.Join(pidDir, "task"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read process tasks")
+	}
+	for _, task := range tasks {
+		name, ticks, err := readProcTaskStat(filepath.Join(pidDir, "task", task.Name(), "stat"))
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				// thread exited since the task list was read
+				continue
+			}
+			return nil, err
+		}
+		ru.CPUSeconds[name] += float64(ticks) / userHZ
+	}
+
+	return ru, nil
+}
+
+// readProcStatus populates memory usage details from /proc/<pid>/status.
+func readProcStatus(path string, ru *EngineResourceUsage) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read process status")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		kv := strings.SplitN(scanner.Text(), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, val := kv[0], kv[1]
+
+		var dest *uint64
+		switch key {
+		case "VmRSS":
+			dest = &ru.RSSBytes
+		case "HugetlbPages":
+			dest = &ru.HugepageBytes
+		default:
+			continue
+		}
+
+		fields := strings.Fields(val)
+		if len(fields) != 2 || fields[1] != "kB" {
+			return errors.Errorf("unexpected format for %s: %q", key, val)
+		}
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s", key)
+		}
+		*dest = kb * 1024
+	}
+
+	return scanner.Err()
+}
+
+// readProcTaskStat returns the thread name and the total user and system CPU
+// time in clock ticks from /proc/<pid>/task/<tid>/stat.
+func readProcTaskStat(path string) (string, uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "failed to read task stat")
+	}
+	stat := string(data)
+
+	// The thread name is enclosed in parentheses and may itself contain
+	// spaces or parentheses, so locate the fields relative to the last ')'.
+	nameStart := strings.IndexByte(stat, '(')
+	nameEnd := strings.LastIndexByte(stat, ')')
+	if nameStart < 0 || nameEnd < nameStart {
+		return "", 0, errors.Errorf("malformed task stat: %q", stat)
+	}
+	name := stat[nameStart+1 : nameEnd]
+
+	// Fields following the name start at field 3 (state); utime and
+	// stime are fields 14 and 15.
+	fields := strings.Fields(stat[nameEnd+1:])
+	if len(fields) < 13 {
+		return "", 0, errors.Errorf("malformed task stat: %q", stat)
+	}
+	var ticks uint64
+	for _, field := range fields[11:13] {
+		val, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "failed to parse task CPU time")
+		}
+		ticks += val
+	}
+
+	return name, ticks, nil
+}
+
+// splitProcStrings splits the NUL-separated contents of a procfs file such as
+// /proc/<pid>/cmdline or /proc/<pid>/environ.
+func splitProcStrings(data []byte) []string {
+	data = bytes.TrimRight(data, "\x00")
+	if len(data) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(string(data), "\x00")
+}
+
+// readProcStartParams returns the command line arguments (excluding the
+// executable) and environment of the given process from the procfs mounted at
+// the supplied root.
+func readProcStartParams(root string, pid int) (args, env []string, err error) {
+	pidDir := filepath.Join(root, strconv.Itoa(pid))
+
+	data, err := os.ReadFile(filepath.Join(pidDir, "cmdline"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read process command line")
+	}
+	args = splitProcStrings(data)
+	if len(args) > 0 {
+		args = args[1:]
+	}
+
+	data, err = os.ReadFile(filepath.Join(pidDir, "environ"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read process environment")
+	}
+
+	return args, splitProcStrings(data), nil
+}
@@ -0,0 +1,274 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func mockTaskStat(tid int, name string, utime, stime uint64) string {
+	return fmt.Sprintf("%d (%s) S 1 %d %d 0 -1 4194560 1000 0 0 0 %d %d 0 0 20 0 1 0 100 0 0\n",
+		tid, name, tid, tid, utime, stime)
+}
+
+func writeMockProc(t *testing.T, root string, pid int, status string, fds int, tasks map[int]string) {
+	t.Helper()
+
+	pidDir := filepath.Join(root, fmt.Sprintf("%d", pid))
+	for _, dir := range []string{"fd", "task"} {
+		if err := os.MkdirAll(filepath.Join(pidDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if status != "" {
+		if err := os.WriteFile(filepath.Join(pidDir, "status"), []byte(status), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < fds; i++ {
+		if err := os.WriteFile(filepath.Join(pidDir, "fd", fmt.Sprintf("%d", i)), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for tid, stat := range tasks {
+		taskDir := filepath.Join(pidDir, "task", fmt.Sprintf("%d", tid))
+		if err := os.MkdirAll(taskDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(taskDir, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestServer_readProcResourceUsage(t *testing.T) {
+	const pid = 4242
+	goodStatus := "Name:\tdaos_engine\nVmRSS:\t    2048 kB\nHugetlbPages:\t  4096 kB\nThreads:\t3\n"
+
+	for name, tc := range map[string]struct {
+		status string
+		fds    int
+		tasks  map[int]string
+		expRU  *EngineResourceUsage
+		expErr error
+	}{
+		"missing status": {
+			expErr: errors.New("failed to read process status"),
+		},
+		"malformed status": {
+			status: "VmRSS:\tlots\n",
+			expErr: errors.New("unexpected format for VmRSS"),
+		},
+		"malformed task stat": {
+			status: goodStatus,
+			tasks:  map[int]string{pid: "garbage"},
+			expErr: errors.New("malformed task stat"),
+		},
+		"success": {
+			status: goodStatus,
+			fds:    3,
+			tasks: map[int]string{
+				pid:     mockTaskStat(pid, "daos_engine", 100, 50),
+				pid + 1: mockTaskStat(pid+1, "daos_io_0", 1000, 250),
+				pid + 2: mockTaskStat(pid+2, "daos_io_0", 200, 50),
+				pid + 3: mockTaskStat(pid+3, "odd (name) x", 10, 0),
+			},
+			expRU: &EngineResourceUsage{
+				PID:           pid,
+				RSSBytes:      2048 * 1024,
+				HugepageBytes: 4096 * 1024,
+				OpenFDs:       3,
+				CPUSeconds: map[string]float64{
+					"daos_engine":  1.5,
+					"daos_io_0":    15,
+					"odd (name) x": 0.1,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			writeMockProc(t, root, pid, tc.status, tc.fds, tc.tasks)
+
+			gotRU, gotErr := readProcResourceUsage(root, pid)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expRU, gotRU); diff != "" {
+				t.Fatalf("unexpected resource usage (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestIOEngineInstance_GetResourceUsage(t *testing.T) {
+	for name, tc := range map[string]struct {
+		running bool
+		pid     uint64
+		expErr  error
+	}{
+		"not started": {
+			expErr: errors.New("not started"),
+		},
+		"no pid": {
+			running: true,
+			expErr:  errors.New("no process ID"),
+		},
+		"self": {
+			running: true,
+			pid:     uint64(os.Getpid()),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			trc := &engine.TestRunnerConfig{LastPid: tc.pid}
+			trc.Running.Store(tc.running)
+			ei := NewEngineInstance(log, nil, nil, engine.NewTestRunner(trc, engine.MockConfig()))
+
+			gotRU, gotErr := ei.GetResourceUsage()
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, int(tc.pid), gotRU.PID, "unexpected PID")
+			test.AssertTrue(t, gotRU.RSSBytes > 0, "expected nonzero RSS")
+			test.AssertTrue(t, gotRU.OpenFDs > 0, "expected open fds")
+		})
+	}
+}
+
+func TestServer_readProcStartParams(t *testing.T) {
+	const pid = 4242
+
+	for name, tc := range map[string]struct {
+		cmdline string
+		environ string
+		expArgs []string
+		expEnv  []string
+		expErr  error
+	}{
+		"missing cmdline": {
+			expErr: errors.New("failed to read process command line"),
+		},
+		"missing environ": {
+			cmdline: "daos_engine\x00-t\x008\x00",
+			expErr:  errors.New("failed to read process environment"),
+		},
+		"empty": {
+			cmdline: "\x00",
+			environ: "\x00",
+			expArgs: []string{},
+			expEnv:  []string{},
+		},
+		"success": {
+			cmdline: "daos_engine\x00-t\x008\x00-x\x002\x00",
+			environ: "ABT_THREAD_STACKSIZE=32768\x00CRT_TIMEOUT=0\x00",
+			expArgs: []string{"-t", "8", "-x", "2"},
+			expEnv:  []string{"ABT_THREAD_STACKSIZE=32768", "CRT_TIMEOUT=0"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			pidDir := filepath.Join(root, fmt.Sprintf("%d", pid))
+			if err := os.MkdirAll(pidDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for fName, contents := range map[string]string{
+				"cmdline": tc.cmdline,
+				"environ": tc.environ,
+			} {
+				if contents == "" {
+					continue
+				}
+				if err := os.WriteFile(filepath.Join(pidDir, fName), []byte(contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotArgs, gotEnv, gotErr := readProcStartParams(root, pid)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expArgs, gotArgs); diff != "" {
+				t.Fatalf("unexpected args (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expEnv, gotEnv); diff != "" {
+				t.Fatalf("unexpected env (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestIOEngineInstance_GetTunables(t *testing.T) {
+	for name, tc := range map[string]struct {
+		running bool
+		pid     uint64
+		expEnv  []string
+	}{
+		"not started; computed from config": {
+			expEnv: []string{"CRT_TIMEOUT=0", "FI_OFI_RXM_USE_SRX=1"},
+		},
+		"running; read from process": {
+			running: true,
+			pid:     uint64(os.Getpid()),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			trc := &engine.TestRunnerConfig{LastPid: tc.pid}
+			trc.Running.Store(tc.running)
+			cfg := engine.MockConfig().WithTargetCount(8)
+			ei := NewEngineInstance(log, nil, nil, engine.NewTestRunner(trc, cfg))
+
+			gotTun, gotErr := ei.GetTunables()
+			if gotErr != nil {
+				t.Fatal(gotErr)
+			}
+
+			if tc.expEnv == nil {
+				// The test process environment is unrelated to the
+				// engine config, so only check that it was used.
+				_, gotEnv, err := readProcStartParams(procRoot, int(tc.pid))
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, len(gotEnv), len(gotTun.EnvVars), "unexpected env count")
+				return
+			}
+			for _, ev := range tc.expEnv {
+				test.AssertTrue(t, common.Includes(gotTun.EnvVars, ev), "missing "+ev)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+var (
+	// errEngineStartupTimeout indicates that an engine did not report
+	// ready within the configured startup timeout.
+	errEngineStartupTimeout = errors.New("engine startup timed out")
+
+	// startupDiagStackWait is the time allowed for a signalled engine to
+	// write its stack dump before the dump is collected.
+	startupDiagStackWait = 5 * time.Second
+	// startupDiagLogTail is the maximum number of bytes copied from the
+	// end of the engine log into a diagnostics bundle.
+	startupDiagLogTail int64 = 1 << 20
+	// startupDiagDumpDir is where the engine writes stack dumps on SIGUSR1.
+	startupDiagDumpDir = os.TempDir()
+	// startupDiagProcRoot is the root of the proc filesystem.
+	startupDiagProcRoot = procRoot
+)
+
+// createPublishStartupTimeoutFunc returns onStartupTimeoutFn which will publish
+// a startup timeout event using the provided publish function.
+func createPublishStartupTimeoutFunc(publish func(*events.RASEvent), hostname string) onStartupTimeoutFn {
+	return func(_ context.Context, engineIdx uint32, timeout time.Duration, bundle string) error {
+		details := "no diagnostics captured"
+		if bundle != "" {
+			details = "diagnostics saved to " + bundle
+		}
+
+		publish(events.NewEngineStartupTimeoutEvent(hostname, engineIdx, timeout, details).
+			WithRank(uint32(ranklist.NilRank)))
+
+		return nil
+	}
+}
+
+// handleStartupTimeout is invoked when the engine has not reported ready
+// within the configured startup timeout. Diagnostics are captured from the
+// still-running engine before the startup timeout callbacks are fired.
+func (ei *EngineInstance) handleStartupTimeout(ctx context.Context, timeout time.Duration) error {
+	idx := ei.Index()
+	ei.log.Errorf("instance %d: not ready after %s; capturing diagnostics", idx, timeout)
+
+	bundle, err := ei.captureStartupDiagnostics(ctx, timeout)
+	if err != nil {
+		ei.log.Errorf("instance %d: capturing startup diagnostics: %s", idx, err)
+		bundle = ""
+	} else {
+		ei.log.Noticef("instance %d: startup diagnostics saved to %s", idx, bundle)
+	}
+
+	for _, fn := range ei.onStartupTimeout {
+		if err := fn(ctx, idx, timeout, bundle); err != nil {
+			ei.log.Errorf("onStartupTimeout: %s", err)
+		}
+	}
+
+	return errors.Wrapf(errEngineStartupTimeout, "instance %d not ready after %s", idx, timeout)
+}
+
+// startupDiagDir returns the directory in which startup diagnostics bundles
+// are created, which is the engine log directory if one is configured.
+func (ei *EngineInstance) startupDiagDir() string {
+	if logFile := ei.runner.GetConfig().LogFile; logFile != "" {
+		return filepath.Dir(logFile)
+	}
+	return os.TempDir()
+}
+
+// captureStartupDiagnostics collects the state of an engine that failed to
+// start in time into a new directory and returns its path. The engine is
+// signalled to dump its ULT stacks, and the dump is collected along with the
+// process and storage state and the tail of the engine log. Failures to
+// collect individual items are recorded in the bundle summary.
+func (ei *EngineInstance) captureStartupDiagnostics(ctx context.Context, timeout time.Duration) (string, error) {
+	idx := ei.Index()
+	pid := int(ei.runner.GetLastPid())
+	now := time.Now()
+
+	bundle := filepath.Join(ei.startupDiagDir(),
+		fmt.Sprintf("daos_engine%d_startup_%s", idx, now.UTC().Format("20060102T150405Z")))
+	if err := os.MkdirAll(bundle, 0750); err != nil {
+		return "", errors.Wrap(err, "creating diagnostics directory")
+	}
+
+	var notes []string
+	note := func(format string, args ...interface{}) {
+		notes = append(notes, fmt.Sprintf(format, args...))
+	}
+
+	if ei.IsStarted() && pid != 0 {
+		ei.runner.Signal(syscall.SIGUSR1)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(startupDiagStackWait):
+		}
+
+		if err := collectStackDumps(bundle, pid, now.Truncate(time.Second)); err != nil {
+			note("stack dump: %s", err)
+		}
+		for _, name := range []string{"status", "stack"} {
+			src := filepath.Join(startupDiagProcRoot, fmt.Sprint(pid), name)
+			if err := copyFileTail(src, filepath.Join(bundle, "proc_"+name+".txt"), 0); err != nil {
+				note("proc %s: %s", name, err)
+			}
+		}
+	} else {
+		note("engine process not running; no stack dump taken")
+	}
+
+	if err := os.WriteFile(filepath.Join(bundle, "storage.txt"), []byte(ei.storageDiagnostics()), 0640); err != nil {
+		note("storage: %s", err)
+	}
+
+	if logFile := ei.runner.GetConfig().LogFile; logFile != "" {
+		if err := copyFileTail(logFile, filepath.Join(bundle, "engine.log"), startupDiagLogTail); err != nil {
+			note("engine log: %s", err)
+		}
+	}
+
+	summary := []string{
+		fmt.Sprintf("instance: %d", idx),
+		fmt.Sprintf("pid: %d", pid),
+		fmt.Sprintf("startup timeout: %s", timeout),
+		fmt.Sprintf("captured: %s", now.Format(time.RFC3339)),
+	}
+	if len(notes) > 0 {
+		summary = append(summary, "errors:")
+		for _, n := range notes {
+			summary = append(summary, "  "+n)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "summary.txt"),
+		[]byte(strings.Join(summary, "\n")+"\n"), 0640); err != nil {
+		return "", errors.Wrap(err, "writing diagnostics summary")
+	}
+
+	return bundle, nil
+}
+
+// storageDiagnostics returns a description of the instance storage state.
+func (ei *EngineInstance) storageDiagnostics() string {
+	var sb strings.Builder
+
+	mounted, err := ei.storage.ScmIsMounted()
+	if err != nil {
+		fmt.Fprintf(&sb, "scm mounted: unknown (%s)\n", err)
+	} else {
+		fmt.Fprintf(&sb, "scm mounted: %t\n", mounted)
+	}
+
+	if mounted {
+		if mp, err := ei.storage.GetScmUsage(); err != nil {
+			fmt.Fprintf(&sb, "scm usage: unknown (%s)\n", err)
+		} else {
+			fmt.Fprintf(&sb, "scm usage: %s %d/%d bytes available\n", mp.Path,
+				mp.AvailBytes, mp.TotalBytes)
+		}
+	}
+
+	fmt.Fprintf(&sb, "superblock: %t\n", ei.hasSuperblock())
+
+	return sb.String()
+}
+
+// collectStackDumps copies the stack dumps written by the engine process
+// since the given time into the bundle directory.
+func collectStackDumps(bundle string, pid int, since time.Time) error {
+	matches, err := filepath.Glob(filepath.Join(startupDiagDumpDir, fmt.Sprintf("daos_dump_%d*.txt", pid)))
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, dump := range matches {
+		fi, err := os.Stat(dump)
+		if err != nil || fi.ModTime().Before(since) {
+			continue
+		}
+		if err := copyFileTail(dump, filepath.Join(bundle, filepath.Base(dump)), 0); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return errors.Errorf("no dump written by pid %d", pid)
+	}
+
+	return nil
+}
+
+// copyFileTail copies the last max bytes of src to dst, or the whole file if
+// max is zero.
+func copyFileTail(src, dst string, max int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if max > 0 {
+		fi, err := in.Stat()
+		if err != nil {
+			return err
+		}
+		if fi.Size() > max {
+			if _, err := in.Seek(-max, io.SeekEnd); err != nil {
+				return err
+			}
+		}
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
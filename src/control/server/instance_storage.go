@@ -69,6 +69,111 @@ func (ei *EngineInstance) MountScm() error {
 	return ei.storage.MountScm()
 }
 
+// storageFaulted indicates whether any of the instance's storage is no longer
+// mounted. If the instance has been running with a superblock, this implies
+// that an engine exit was caused by the loss of its storage.
+func (ei *EngineInstance) storageFaulted() bool {
+	msgIdx := fmt.Sprintf("instance %d", ei.Index())
+
+	if md := ei.storage.GetControlMetadata(); md.HasPath() && md.DevicePath != "" {
+		isMounted, err := ei.storage.ControlMetadataIsMounted()
+		if err != nil || !isMounted {
+			ei.log.Debugf("%s: control metadata not mounted (err: %v)", msgIdx, err)
+			return true
+		}
+	}
+
+	isMounted, err := ei.storage.ScmIsMounted()
+	if err != nil || !isMounted {
+		ei.log.Debugf("%s: scm not mounted (err: %v)", msgIdx, err)
+		return true
+	}
+
+	return false
+}
+
+// verifyStorage remounts the instance's control metadata storage and
+// re-reads the superblock, checking that it matches the superblock that the
+// instance was previously running with. If the superblock can't be read or
+// does not match, the previous superblock is retained and an error is returned.
+func (ei *EngineInstance) verifyStorage() error {
+	msgIdx := fmt.Sprintf("instance %d", ei.Index())
+
+	prev := ei.getSuperblock()
+	if prev == nil {
+		return errors.Errorf("%s: no superblock to verify", msgIdx)
+	}
+
+	if err := ei.ReadSuperblock(); err != nil {
+		ei.setSuperblock(prev)
+		return errors.Wrapf(err, "%s: verify superblock", msgIdx)
+	}
+
+	cur := ei.getSuperblock()
+	if !prev.matches(cur) {
+		ei.setSuperblock(prev)
+		return FaultInstanceSuperblockMismatch(ei.Index(), prev, cur)
+	}
+	ei.log.Debugf("%s: storage verified (rank: %s, uuid: %s)", msgIdx, cur.Rank, cur.UUID)
+
+	return nil
+}
+
+// syncMetadataMirror refreshes the mirrored copy of the instance's control metadata, if a mirror
+// is configured. Failure to update the mirror is logged but does not prevent the instance from
+// running with its primary control metadata.
+func (ei *EngineInstance) syncMetadataMirror() {
+	if err := ei.storage.SyncControlMetadataMirror(); err != nil {
+		ei.log.Errorf("instance %d: %s", ei.Index(), err)
+	}
+}
+
+// verifyPrimaryMetadata checks that the primary control metadata location is formatted and
+// holds a readable superblock.
+func (ei *EngineInstance) verifyPrimaryMetadata() error {
+	needsFormat, err := ei.storage.ControlMetadataNeedsFormat()
+	if err != nil {
+		return err
+	}
+	if needsFormat {
+		return errors.New("control metadata storage is not formatted")
+	}
+
+	if err := ei.MountMetadata(); err != nil {
+		return errors.Wrap(err, "failed to mount control metadata device")
+	}
+
+	_, err = ei.readSuperblockFrom(ei.superblockPath())
+	return err
+}
+
+// checkMetadataMirror verifies the primary control metadata location before the instance
+// storage format checks are performed. If the primary location fails verification but the
+// mirror holds a valid superblock, the instance fails over to the mirror rather than waiting
+// for its storage to be reformatted.
+func (ei *EngineInstance) checkMetadataMirror() {
+	if ei.hasSuperblock() || !ei.storage.ControlMetadataMirrorConfigured() {
+		return
+	}
+	msgIdx := fmt.Sprintf("instance %d", ei.Index())
+
+	primaryErr := ei.verifyPrimaryMetadata()
+	if primaryErr == nil {
+		return
+	}
+
+	if _, err := ei.readSuperblockFrom(ei.superblockMirrorPath()); err != nil {
+		ei.log.Debugf("%s: control metadata mirror unusable: %s", msgIdx, err)
+		return
+	}
+
+	ei.log.Noticef("%s: primary control metadata failed verification (%s); using mirror",
+		msgIdx, primaryErr)
+	if err := ei.storage.FailoverControlMetadata(); err != nil {
+		ei.log.Errorf("%s: %s", msgIdx, err)
+	}
+}
+
 // NotifyStorageReady releases any blocks on awaitStorageReady().
 func (ei *EngineInstance) NotifyStorageReady() {
 	go func() {
@@ -132,6 +237,23 @@ func (ei *EngineInstance) awaitStorageReady(ctx context.Context) error {
 
 	ei.log.Infof("Checking %s %s storage ...", build.DataPlaneName, msgIdx)
 
+	// If the instance has previously run and its storage has since gone
+	// away, try to remount it and verify that the superblock is unchanged
+	// before falling through to the format checks. If the storage comes
+	// back with a different superblock, refuse to start rather than
+	// running with a mismatched identity.
+	if ei.hasSuperblock() && ei.storageFaulted() {
+		ei.log.Noticef("%s storage unavailable; attempting to remount", msgIdx)
+		if err := ei.verifyStorage(); err != nil {
+			if fault.IsFaultCode(err, code.ServerInstanceSuperblockMismatch) {
+				return err
+			}
+			ei.log.Errorf("%s: failed to recover storage: %s", msgIdx, err)
+		}
+	}
+
+	ei.checkMetadataMirror()
+
 	needsMetaFormat, err := ei.storage.ControlMetadataNeedsFormat()
 	if err != nil {
 		ei.log.Errorf("%s: failed to check control metadata storage formatting: %s",
@@ -432,5 +432,15 @@ func smdQueryEngine(ctx context.Context, engine Engine, pbReq *ctlpb.SmdQueryReq
 		rResp.Devices = nil
 	}
 
+	if pbReq.IncludeIoStats {
+		period := time.Duration(pbReq.IoStatsPeriodMs) * time.Millisecond
+		err := populateBdevIOStats(ctx, engine, rResp.Devices, period)
+		if err == errBdevIOStatsUnsupported {
+			engine.Debugf("skip fetching device I/O stats: %s", err)
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "rank %d", engineRank)
+		}
+	}
+
 	return rResp, nil
 }
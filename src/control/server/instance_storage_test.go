@@ -376,6 +376,7 @@ func TestIOEngineInstance_awaitStorageReady(t *testing.T) {
 		fsStr           string
 		fsErr           error
 		sbSet           bool
+		sbOnDisk        *Superblock
 		engineIndex     uint32
 		isMounted       bool
 		isMountedErr    error
@@ -410,6 +411,20 @@ func TestIOEngineInstance_awaitStorageReady(t *testing.T) {
 			isMountedErr: os.ErrNotExist,
 			expErr:       storage.FaultDeviceWithFsNoMountpoint(dev, mnt),
 		},
+		"superblock set; scm unmounted; remounted with matching superblock": {
+			sbSet:     true,
+			sbOnDisk:  &Superblock{Rank: ranklist.NewRankPtr(0), ValidRank: true},
+			fsStr:     "ext4",
+			expNoWait: true,
+		},
+		"superblock set; scm unmounted; remounted with different superblock": {
+			sbSet:    true,
+			sbOnDisk: &Superblock{UUID: "new", Rank: ranklist.NewRankPtr(1), ValidRank: true},
+			fsStr:    "ext4",
+			expErr: FaultInstanceSuperblockMismatch(0,
+				&Superblock{Rank: ranklist.NewRankPtr(0)},
+				&Superblock{UUID: "new", Rank: ranklist.NewRankPtr(1)}),
+		},
 		"mount check fails": {
 			sbSet:        true,
 			fsStr:        "ext4",
@@ -510,6 +525,15 @@ func TestIOEngineInstance_awaitStorageReady(t *testing.T) {
 				t.Logf("setting readfile err %s for path %s", tc.readErr.Error(), sbPath)
 				msc.ReadFileErrors = map[string]error{sbPath: tc.readErr}
 			}
+			if tc.sbOnDisk != nil {
+				data, err := tc.sbOnDisk.Marshal()
+				if err != nil {
+					t.Fatal(err)
+				}
+				msc.ReadFileResults = map[string][]byte{
+					filepath.Join(mnt, "superblock"): data,
+				}
+			}
 			smbc := scm.MockBackendConfig{}
 			mmp := &storage.MockMetadataProvider{
 				NeedsFormatRes: tc.metaNeedsFmt,
@@ -558,3 +582,82 @@ func TestIOEngineInstance_awaitStorageReady(t *testing.T) {
 		})
 	}
 }
+
+func TestIOEngineInstance_checkMetadataMirror(t *testing.T) {
+	mirrorCfg := mockRamCfg
+	mirrorCfg.ControlMetadata.MirrorPath = "/mirror"
+	primarySB := filepath.Join(mirrorCfg.ControlMetadata.EngineDirectory(0), "superblock")
+	mirrorSB := filepath.Join(mirrorCfg.ControlMetadata.MirrorEngineDirectory(0), "superblock")
+
+	for name, tc := range map[string]struct {
+		noMirror     bool
+		sbSet        bool
+		metaNeedsFmt bool
+		mountErr     error
+		readErrs     map[string]error
+		expFailover  bool
+	}{
+		"no mirror configured": {
+			noMirror: true,
+			readErrs: map[string]error{primarySB: os.ErrNotExist},
+		},
+		"superblock already set": {
+			sbSet:        true,
+			metaNeedsFmt: true,
+		},
+		"primary verified": {},
+		"primary needs format; mirror has superblock": {
+			metaNeedsFmt: true,
+			expFailover:  true,
+		},
+		"primary mount fails; mirror has superblock": {
+			mountErr:    errors.New("bad mount"),
+			expFailover: true,
+		},
+		"primary superblock missing; mirror has superblock": {
+			readErrs:    map[string]error{primarySB: os.ErrNotExist},
+			expFailover: true,
+		},
+		"primary superblock missing; mirror superblock missing": {
+			readErrs: map[string]error{
+				primarySB: os.ErrNotExist,
+				mirrorSB:  os.ErrNotExist,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := mirrorCfg
+			if tc.noMirror {
+				cfg.ControlMetadata.MirrorPath = ""
+			}
+
+			msc := system.MockSysConfig{
+				ReadFileErrors: tc.readErrs,
+			}
+			mmp := &storage.MockMetadataProvider{
+				MountRes:       &storage.MountResponse{},
+				MountErr:       tc.mountErr,
+				NeedsFormatRes: tc.metaNeedsFmt,
+			}
+			mp := storage.NewProvider(log, 0, &cfg, system.NewMockSysProvider(log, &msc),
+				nil, nil, mmp)
+			runner := engine.NewTestRunner(nil, engine.MockConfig())
+			ei := NewEngineInstance(log, mp, nil, runner)
+
+			if tc.sbSet {
+				ei.setSuperblock(&Superblock{Rank: ranklist.NewRankPtr(0)})
+			}
+
+			ei.checkMetadataMirror()
+
+			test.AssertEqual(t, tc.expFailover, mp.ControlMetadataFailedOver(), "unexpected failover state")
+			if tc.expFailover {
+				test.AssertEqual(t, mirrorCfg.ControlMetadata.MirrorEngineDirectory(0),
+					mp.ControlMetadataEnginePath(), "expected mirror to be used for metadata")
+			}
+		})
+	}
+}
@@ -52,11 +52,34 @@ func (sb *Superblock) Unmarshal(raw []byte) error {
 	return yaml.Unmarshal(raw, sb)
 }
 
+// matches indicates whether the other Superblock describes the same
+// instance identity, i.e. it has the same UUID, system and rank.
+func (sb *Superblock) matches(other *Superblock) bool {
+	if sb == nil || other == nil {
+		return sb == other
+	}
+	if sb.UUID != other.UUID || sb.System != other.System {
+		return false
+	}
+	if sb.Rank == nil || other.Rank == nil {
+		return sb.Rank == other.Rank
+	}
+	return sb.Rank.Equals(*other.Rank)
+}
+
 func (ei *EngineInstance) superblockPath() string {
 	storagePath := ei.storage.ControlMetadataEnginePath()
 	return filepath.Join(ei.fsRoot, storagePath, "superblock")
 }
 
+func (ei *EngineInstance) superblockMirrorPath() string {
+	mirrorPath := ei.storage.ControlMetadataMirrorEnginePath()
+	if mirrorPath == "" {
+		return ""
+	}
+	return filepath.Join(ei.fsRoot, mirrorPath, "superblock")
+}
+
 func (ei *EngineInstance) setSuperblock(sb *Superblock) {
 	ei.Lock()
 	defer ei.Unlock()
@@ -160,7 +183,12 @@ func (ei *EngineInstance) createSuperblock() error {
 // to storage.
 func (ei *EngineInstance) WriteSuperblock() error {
 	ei.log.Debugf("instance %d: writing superblock at %s", ei.Index(), ei.superblockPath())
-	return WriteSuperblock(ei.superblockPath(), ei.getSuperblock())
+	if err := WriteSuperblock(ei.superblockPath(), ei.getSuperblock()); err != nil {
+		return err
+	}
+
+	ei.syncMetadataMirror()
+	return nil
 }
 
 // ReadSuperblock reads the instance's superblock from storage.
@@ -169,23 +197,31 @@ func (ei *EngineInstance) ReadSuperblock() error {
 		return errors.Wrap(err, "failed to mount control metadata device")
 	}
 
+	sb, err := ei.readSuperblockFrom(ei.superblockPath())
+	if err != nil {
+		return err
+	}
+
+	ei.setSuperblock(sb)
+
+	return nil
+}
+
+func (ei *EngineInstance) readSuperblockFrom(sbPath string) (*Superblock, error) {
 	msgIdx := fmt.Sprintf("instance %d", ei.Index())
-	sbPath := ei.superblockPath()
 	ei.log.Tracef("%s: read sb: %q", msgIdx, sbPath)
 
 	data, err := ei.storage.Sys.ReadFile(sbPath)
 	if err != nil {
-		return errors.Wrapf(err, "%s: failed to read Superblock from %s", msgIdx, sbPath)
+		return nil, errors.Wrapf(err, "%s: failed to read Superblock from %s", msgIdx, sbPath)
 	}
 
 	sb := &Superblock{}
 	if err := sb.Unmarshal(data); err != nil {
-		return err
+		return nil, err
 	}
 
-	ei.setSuperblock(sb)
-
-	return nil
+	return sb, nil
 }
 
 // RemoveSuperblock removes a superblock from storage.
@@ -173,6 +173,7 @@ type (
 		CallDrpcErr         error
 		GetRankResp         ranklist.Rank
 		GetRankErr          error
+		GetUUIDResp         string
 		TargetCount         int
 		Index               uint32
 		Started             atm.Bool
@@ -184,6 +185,11 @@ type (
 		StopErr             error
 		ScmTierConfig       *storage.TierConfig
 		ScanBdevTiersResult []storage.BdevTierScanResult
+		ResourceUsage       *EngineResourceUsage
+		ResourceUsageErr    error
+		Tunables            *engine.Tunables
+		TunablesErr         error
+		StorageProvider     *storage.Provider
 	}
 
 	MockInstance struct {
@@ -213,10 +219,34 @@ func (mi *MockInstance) CallDrpc(_ context.Context, _ drpc.Method, _ proto.Messa
 	return mi.cfg.CallDrpcResp, mi.cfg.CallDrpcErr
 }
 
+func (mi *MockInstance) CallDrpcBatch(_ context.Context, _ drpc.Method, bodies []proto.Message) ([]*drpc.Response, error) {
+	if mi.cfg.CallDrpcErr != nil {
+		return nil, mi.cfg.CallDrpcErr
+	}
+
+	resps := make([]*drpc.Response, 0, len(bodies))
+	for range bodies {
+		resps = append(resps, mi.cfg.CallDrpcResp)
+	}
+	return resps, nil
+}
+
 func (mi *MockInstance) GetRank() (ranklist.Rank, error) {
 	return mi.cfg.GetRankResp, mi.cfg.GetRankErr
 }
 
+func (mi *MockInstance) GetUUID() (string, error) {
+	return mi.cfg.GetUUIDResp, nil
+}
+
+func (mi *MockInstance) GetResourceUsage() (*EngineResourceUsage, error) {
+	return mi.cfg.ResourceUsage, mi.cfg.ResourceUsageErr
+}
+
+func (mi *MockInstance) GetTunables() (*engine.Tunables, error) {
+	return mi.cfg.Tunables, mi.cfg.TunablesErr
+}
+
 func (mi *MockInstance) GetTargetCount() int {
 	return mi.cfg.TargetCount
 }
@@ -299,7 +329,7 @@ func (mi *MockInstance) StorageFormatSCM(context.Context, bool) *ctlpb.ScmMountR
 }
 
 func (mi *MockInstance) GetStorage() *storage.Provider {
-	return nil
+	return mi.cfg.StorageProvider
 }
 
 func (mi *MockInstance) Debugf(format string, args ...interface{}) {
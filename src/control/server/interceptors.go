@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,7 +7,9 @@
 package server
 
 import (
+	"crypto/x509"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,19 +18,23 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common/proto"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
-func componentFromContext(ctx context.Context) (comp *security.Component, err error) {
+// peerCertFromContext returns the verified certificate presented by the peer.
+func peerCertFromContext(ctx context.Context) (*x509.Certificate, error) {
 	clientPeer, ok := peer.FromContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "no peer information found")
@@ -45,7 +51,14 @@ func componentFromContext(ctx context.Context) (comp *security.Component, err er
 		return nil, status.Error(codes.Unauthenticated, "unable to verify client certificates")
 	}
 
-	peerCert := certs[0][0]
+	return certs[0][0], nil
+}
+
+func componentFromContext(ctx context.Context) (comp *security.Component, err error) {
+	peerCert, err := peerCertFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	component := security.CommonNameToComponent(peerCert.Subject.CommonName)
 
 	return &component, nil
@@ -74,6 +87,12 @@ func unaryAccessInterceptor(ctx context.Context, req interface{}, info *grpc.Una
 }
 
 func streamAccessInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	// Calls to unimplemented methods have no service and are passed to
+	// unknownMethodHandler, which rejects them without side effects.
+	if srv == nil {
+		return handler(srv, ss)
+	}
+
 	if err := checkAccess(ss.Context(), info.FullMethod); err != nil {
 		return err
 	}
@@ -196,8 +215,21 @@ func checkVersion(ctx context.Context, log logging.Logger, self *build.Versioned
 	return nil
 }
 
+// selfServerMetadata returns the response headers that advertise the version
+// and features of this server to the caller.
+func selfServerMetadata() metadata.MD {
+	return build.ComponentMetadata(selfServerComponent.Component, selfServerComponent.Version,
+		build.ServerFeatures())
+}
+
 func unaryVersionInterceptor(log logging.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Advertise our version and features even if the check fails, so
+		// that the caller can report the reason.
+		if err := grpc.SetHeader(ctx, selfServerMetadata()); err != nil {
+			log.Debugf("failed to set version headers: %s", err)
+		}
+
 		if err := checkVersion(ctx, log, selfServerComponent, req); err != nil {
 			return nil, errors.Wrapf(err, "version check failed for %T", req)
 		}
@@ -206,6 +238,27 @@ func unaryVersionInterceptor(log logging.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
+// unaryClockInterceptor reports the time at which the request was handled in
+// the response headers, so that callers can detect clock skew.
+func unaryClockInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	// Best effort, as the header is only used for diagnostics.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(control.ServerTimeHeader,
+		strconv.FormatInt(time.Now().UnixNano(), 10)))
+
+	return handler(ctx, req)
+}
+
+// unknownMethodHandler rejects calls to methods that this server does not
+// implement, advertising the server's version and features so that newer
+// clients can report that the feature is not supported by this version.
+func unknownMethodHandler(_ interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	_ = stream.SetHeader(selfServerMetadata())
+
+	return status.Errorf(codes.Unimplemented, "method %s not supported by server version %s",
+		method, selfServerComponent.Version)
+}
+
 func unaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	res, err := handler(ctx, req)
 	return res, proto.AnnotateError(err)
@@ -244,6 +297,27 @@ func unaryStatusInterceptor(ctx context.Context, req interface{}, info *grpc.Una
 	return res, err
 }
 
+// unaryTraceInterceptor records a span for each traced request. The trace context
+// is taken from the traceparent metadata supplied by the client and is passed on
+// to any requests or dRPC calls made while handling the request.
+func unaryTraceInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(tracing.TraceparentHeader); len(vals) > 0 {
+			if sc, err := tracing.ParseTraceparent(vals[0]); err == nil {
+				ctx = tracing.ContextWithRemoteParent(ctx, sc)
+			}
+		}
+	}
+
+	ctx, span := tracing.StartSpan(ctx, info.FullMethod, tracing.SpanKindServer)
+
+	res, err := handler(ctx, req)
+	span.SetError(err)
+	span.Finish()
+
+	return res, err
+}
+
 // isSentinelErr indicates whether or not the error is a sentinel
 // error used to convey a specific state to the client.
 func isSentinelErr(err error) bool {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,18 +11,25 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 )
 
@@ -74,6 +81,72 @@ func TestServer_unaryStatusInterceptor(t *testing.T) {
 	}
 }
 
+func TestServer_unaryTraceInterceptor(t *testing.T) {
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	for name, tc := range map[string]struct {
+		md         metadata.MD
+		handlerErr error
+		expSpan    bool
+	}{
+		"no metadata": {},
+		"no traceparent": {
+			md: metadata.Pairs("foo", "bar"),
+		},
+		"invalid traceparent": {
+			md: metadata.Pairs(tracing.TraceparentHeader, "garbage"),
+		},
+		"unsampled traceparent": {
+			md: metadata.Pairs(tracing.TraceparentHeader,
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"),
+		},
+		"traced": {
+			md:      metadata.Pairs(tracing.TraceparentHeader, parent),
+			expSpan: true,
+		},
+		"traced; handler error": {
+			md:         metadata.Pairs(tracing.TraceparentHeader, parent),
+			handlerErr: errors.New("whoops"),
+			expSpan:    true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := test.Context(t)
+			if tc.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.md)
+			}
+
+			var gotSpan *tracing.Span
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotSpan = tracing.SpanFromContext(ctx)
+				return nil, tc.handlerErr
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: "/mgmt.MgmtSvc/PoolCreate"}
+
+			_, gotErr := unaryTraceInterceptor(ctx, nil, info, handler)
+			test.CmpErr(t, tc.handlerErr, gotErr)
+
+			if !tc.expSpan {
+				if gotSpan != nil {
+					t.Fatal("expected no span")
+				}
+				return
+			}
+			if gotSpan == nil {
+				t.Fatal("expected span in handler context")
+			}
+
+			test.AssertEqual(t, info.FullMethod, gotSpan.Name, "unexpected span name")
+			test.AssertEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736",
+				gotSpan.Context.TraceID.String(), "unexpected trace id")
+			test.AssertEqual(t, "00f067aa0ba902b7", gotSpan.ParentID.String(),
+				"unexpected parent id")
+			test.CmpErr(t, tc.handlerErr, gotSpan.Err)
+			test.AssertTrue(t, !gotSpan.End.IsZero(), "expected span to be finished")
+		})
+	}
+}
+
 // newTestAuthCtx returns a context with a fake peer.PeerInfo
 // set up to validate component access/versioning.
 func newTestAuthCtx(parent context.Context, commonName string) context.Context {
@@ -265,3 +338,68 @@ func TestServer_checkVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_unknownMethodHandler(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(
+		grpc.ChainStreamInterceptor(streamAccessInterceptor),
+		grpc.UnknownServiceHandler(unknownMethodHandler),
+	)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var header metadata.MD
+	err = conn.Invoke(test.Context(t), "/mgmt.MgmtSvc/FutureFeature", new(mgmtpb.SystemQueryReq),
+		new(mgmtpb.SystemQueryResp), grpc.Header(&header))
+	test.AssertEqual(t, codes.Unimplemented, status.Code(err), "unexpected status")
+	test.CmpErr(t, errors.New("method /mgmt.MgmtSvc/FutureFeature not supported by server version"), err)
+
+	comp, err := build.FromMetadata(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, selfServerComponent.String(), comp.String(), "unexpected advertised component")
+
+	features, advertised := build.FeaturesFromMetadata(header)
+	test.AssertTrue(t, advertised, "features not advertised")
+	if diff := cmp.Diff(build.ServerFeatures(), features); diff != "" {
+		t.Fatalf("unexpected features (-want, +got):\n%s", diff)
+	}
+}
+
+func TestServer_checkMemberFeatures(t *testing.T) {
+	required := []build.Feature{build.FeatureSystemCleanup}
+
+	for name, tc := range map[string]struct {
+		md     metadata.MD
+		expErr error
+	}{
+		"features not advertised": {
+			md: metadata.Pairs(build.DaosVersionHeader, "2.6.0"),
+		},
+		"required features supported": {
+			md: metadata.Pairs(build.DaosFeaturesHeader, "job_stats,system_cleanup"),
+		},
+		"required feature missing": {
+			md:     metadata.Pairs(build.DaosFeaturesHeader, "job_stats"),
+			expErr: FaultJoinMissingFeatures([]build.Feature{build.FeatureSystemCleanup}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(test.Context(t), tc.md)
+
+			test.CmpErr(t, tc.expErr, checkMemberFeatures(ctx, required))
+		})
+	}
+}
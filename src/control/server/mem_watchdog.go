@@ -0,0 +1,284 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// memPressureLevel indicates the degree of memory pressure an engine is under.
+type memPressureLevel int
+
+const (
+	memPressureNone memPressureLevel = iota
+	memPressureWarning
+	memPressureCritical
+)
+
+func (l memPressureLevel) String() string {
+	return map[memPressureLevel]string{
+		memPressureNone:     "none",
+		memPressureWarning:  "warning",
+		memPressureCritical: "critical",
+	}[l]
+}
+
+// memTopology records the system memory totals seen by the memory watchdog.
+// A change indicates that memory has been hot-added or removed, or that a
+// balloon driver has resized the memory of a virtual machine.
+type memTopology struct {
+	memTotalKiB    int
+	hugepagesTotal int
+}
+
+// memWatchdog periodically checks ramdisk (tmpfs) usage and available system
+// memory on behalf of engines using ram-class SCM. When an engine exceeds
+// the warning thresholds a RAS event is raised, and when available memory
+// drops below the stop threshold the engine is stopped in a controlled
+// manner so that it is not instead terminated by the OOM killer. When the
+// total system memory changes, ramdisk sizes and hugepage allocations are
+// re-evaluated against it.
+type memWatchdog struct {
+	log             logging.Logger
+	cfg             *config.MemoryWatchdog
+	hostname        string
+	engines         []Engine
+	getMemInfo      common.GetMemInfoFn
+	publish         func(*events.RASEvent)
+	levels          map[uint32]memPressureLevel
+	nrHugepages     int
+	calcRamdiskSize func(hpSizeKiB, memKiB int) (uint64, error)
+	topology        *memTopology
+}
+
+func newMemWatchdog(log logging.Logger, cfg *config.MemoryWatchdog, hostname string, engines []Engine, publish func(*events.RASEvent)) *memWatchdog {
+	return &memWatchdog{
+		log:        log,
+		cfg:        cfg.WithDefaults(),
+		hostname:   hostname,
+		engines:    engines,
+		getMemInfo: common.GetMemInfo,
+		publish:    publish,
+		levels:     make(map[uint32]memPressureLevel),
+	}
+}
+
+// ramdiskEngines returns the engines that use ram-class SCM.
+func (mw *memWatchdog) ramdiskEngines() []Engine {
+	var ramEngines []Engine
+	for _, e := range mw.engines {
+		sp := e.GetStorage()
+		if sp == nil {
+			continue
+		}
+		scmCfg, err := sp.GetScmConfig()
+		if err != nil || scmCfg.Class != storage.ClassRam {
+			continue
+		}
+		ramEngines = append(ramEngines, e)
+	}
+
+	return ramEngines
+}
+
+// run checks memory pressure at the configured interval until the context
+// is canceled. It returns immediately if the watchdog is disabled or if no
+// engines use ram-class SCM.
+func (mw *memWatchdog) run(ctx context.Context) {
+	if mw.cfg.Disabled {
+		mw.log.Debug("memory watchdog disabled")
+		return
+	}
+
+	engines := mw.ramdiskEngines()
+	if len(engines) == 0 {
+		return
+	}
+	mw.log.Debugf("memory watchdog monitoring %d engine(s) every %s", len(engines), mw.cfg.Interval)
+
+	ticker := time.NewTicker(mw.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := mw.check(engines); err != nil {
+				mw.log.Errorf("memory watchdog: %s", err)
+			}
+		}
+	}
+}
+
+// check evaluates the memory pressure for each of the supplied engines,
+// acting on any engine whose pressure level has increased since the
+// previous check.
+func (mw *memWatchdog) check(engines []Engine) error {
+	mi, err := mw.getMemInfo()
+	if err != nil {
+		return errors.Wrap(err, "retrieve system memory info")
+	}
+	if mi.MemTotalKiB <= 0 {
+		return errors.New("system memory total not reported")
+	}
+	memAvailPct := float64(mi.MemAvailableKiB) * 100 / float64(mi.MemTotalKiB)
+
+	mw.checkTopology(engines, mi)
+
+	for _, e := range engines {
+		idx := e.Index()
+
+		if !e.IsStarted() {
+			delete(mw.levels, idx)
+			continue
+		}
+
+		usage, err := e.GetStorage().GetScmUsage()
+		if err != nil {
+			mw.log.Errorf("memory watchdog: instance %d: retrieve ramdisk usage: %s", idx, err)
+			continue
+		}
+		var ramdiskPct float64
+		if usage.TotalBytes > 0 {
+			ramdiskPct = float64(usage.TotalBytes-usage.AvailBytes) * 100 / float64(usage.TotalBytes)
+		}
+
+		level := memPressureNone
+		switch {
+		case mw.cfg.MemAvailStopPercent > 0 && memAvailPct < float64(mw.cfg.MemAvailStopPercent):
+			level = memPressureCritical
+		case memAvailPct < float64(mw.cfg.MemAvailWarnPercent),
+			ramdiskPct >= float64(mw.cfg.RamdiskWarnPercent):
+			level = memPressureWarning
+		}
+
+		prev := mw.levels[idx]
+		mw.levels[idx] = level
+		if level == prev {
+			continue
+		}
+
+		details := fmt.Sprintf("ramdisk %s %.1f%% used, system memory %.1f%% available",
+			usage.Path, ramdiskPct, memAvailPct)
+
+		if level < prev {
+			mw.log.Noticef("instance %d memory pressure reduced to %s: %s", idx, level, details)
+			continue
+		}
+
+		rank, err := e.GetRank()
+		if err != nil {
+			rank = ranklist.NilRank
+		}
+
+		sev := events.RASSeverityWarning
+		if level == memPressureCritical {
+			sev = events.RASSeverityError
+			details += "; stopping engine"
+		}
+		mw.publish(events.NewEngineMemoryPressureEvent(mw.hostname, idx, rank.Uint32(), sev, details))
+
+		if level == memPressureCritical {
+			mw.log.Errorf("instance %d under critical memory pressure; stopping engine", idx)
+			if err := e.Stop(syscall.SIGINT); err != nil {
+				mw.log.Errorf("instance %d: stop engine: %s", idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkTopology re-evaluates the hugepage allocation and the size of each
+// engine's ramdisk when the total system memory has changed since the
+// previous check, raising a RAS event for each engine whose ramdisk can no
+// longer be backed by memory. Otherwise a tmpfs larger than the memory left
+// to back it only fails once the engine writes to it.
+func (mw *memWatchdog) checkTopology(engines []Engine, mi *common.MemInfo) {
+	topo := &memTopology{
+		memTotalKiB:    mi.MemTotalKiB,
+		hugepagesTotal: mi.HugepagesTotal,
+	}
+	prev := mw.topology
+	mw.topology = topo
+	if prev == nil || *prev == *topo {
+		return
+	}
+
+	mw.log.Noticef("system memory changed: total %s -> %s, hugepages %d -> %d",
+		humanize.IBytes(uint64(prev.memTotalKiB)*humanize.KiByte),
+		humanize.IBytes(uint64(topo.memTotalKiB)*humanize.KiByte),
+		prev.hugepagesTotal, topo.hugepagesTotal)
+
+	var hostIssues []string
+	if mw.nrHugepages > 0 && topo.hugepagesTotal < mw.nrHugepages {
+		hostIssues = append(hostIssues, fmt.Sprintf("%d hugepages allocated but %d required",
+			topo.hugepagesTotal, mw.nrHugepages))
+	}
+
+	var maxRamdisk uint64
+	if mw.calcRamdiskSize != nil {
+		size, err := mw.calcRamdiskSize(mi.HugepageSizeKiB, mi.MemTotalKiB)
+		if err != nil {
+			hostIssues = append(hostIssues, fmt.Sprintf("ramdisk cannot be backed: %s", err))
+		}
+		maxRamdisk = size
+	}
+
+	for _, e := range engines {
+		idx := e.Index()
+		if !e.IsStarted() {
+			continue
+		}
+
+		issues := append([]string{}, hostIssues...)
+		if maxRamdisk > 0 {
+			usage, err := e.GetStorage().GetScmUsage()
+			if err != nil {
+				mw.log.Errorf("memory watchdog: instance %d: retrieve ramdisk usage: %s", idx, err)
+				continue
+			}
+			switch {
+			case usage.TotalBytes > maxRamdisk:
+				issues = append(issues, fmt.Sprintf("ramdisk %s size %s exceeds the %s that "+
+					"system memory can back", usage.Path, humanize.IBytes(usage.TotalBytes),
+					humanize.IBytes(maxRamdisk)))
+			case topo.memTotalKiB > prev.memTotalKiB:
+				mw.log.Noticef("instance %d ramdisk %s of size %s could now be resized up to %s",
+					idx, usage.Path, humanize.IBytes(usage.TotalBytes), humanize.IBytes(maxRamdisk))
+			}
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		rank, err := e.GetRank()
+		if err != nil {
+			rank = ranklist.NilRank
+		}
+
+		details := "system memory changed: " + strings.Join(issues, "; ")
+		mw.log.Errorf("instance %d: %s", idx, details)
+		mw.publish(events.NewEngineMemoryPressureEvent(mw.hostname, idx, rank.Uint32(),
+			events.RASSeverityWarning, details))
+	}
+}
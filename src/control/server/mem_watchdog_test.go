@@ -0,0 +1,302 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/provider/system"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestServer_memWatchdog_ramdiskEngines(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	dcpmCfg := storage.Config{
+		Tiers: storage.TierConfigs{
+			storage.NewTierConfig().
+				WithStorageClass(storage.ClassDcpm.String()).
+				WithScmMountPoint(defaultStoragePath).
+				WithScmDeviceList("/dev/pmem0"),
+		},
+	}
+
+	var engines []Engine
+	engineCfgs := []storage.Config{mockRamCfg, dcpmCfg, mockRamCfg}
+	for i := range engineCfgs {
+		sp := storage.MockProvider(log, i, &engineCfgs[i], nil, nil, nil, nil)
+		engines = append(engines, NewEngineInstance(log, sp, nil,
+			engine.NewTestRunner(nil, engine.MockConfig())))
+	}
+	engines = append(engines, NewMockInstance(nil))
+
+	mw := newMemWatchdog(log, nil, "foo", engines, func(*events.RASEvent) {})
+
+	test.AssertEqual(t, 2, len(mw.ramdiskEngines()), "unexpected number of ramdisk engines")
+}
+
+func TestServer_memWatchdog_check(t *testing.T) {
+	const rank = 3
+
+	for name, tc := range map[string]struct {
+		cfg        *config.MemoryWatchdog
+		notStarted bool
+		prevLevel  memPressureLevel
+		memInfoErr error
+		memAvailKB int
+		fsTotal    uint64
+		fsAvail    uint64
+		expErr     error
+		expLevel   memPressureLevel
+		expSev     events.RASSeverityID
+		expStopped bool
+	}{
+		"meminfo fails": {
+			memInfoErr: errors.New("no meminfo"),
+			expErr:     errors.New("no meminfo"),
+		},
+		"engine not started": {
+			notStarted: true,
+			prevLevel:  memPressureWarning,
+			memAvailKB: 1,
+			expLevel:   memPressureNone,
+		},
+		"no pressure": {
+			memAvailKB: 50,
+			fsTotal:    100,
+			fsAvail:    50,
+		},
+		"ramdisk nearly full": {
+			memAvailKB: 50,
+			fsTotal:    100,
+			fsAvail:    5,
+			expLevel:   memPressureWarning,
+			expSev:     events.RASSeverityWarning,
+		},
+		"available memory low": {
+			memAvailKB: 5,
+			fsTotal:    100,
+			fsAvail:    50,
+			expLevel:   memPressureWarning,
+			expSev:     events.RASSeverityWarning,
+		},
+		"warning already raised": {
+			prevLevel:  memPressureWarning,
+			memAvailKB: 5,
+			fsTotal:    100,
+			fsAvail:    50,
+			expLevel:   memPressureWarning,
+		},
+		"pressure relieved": {
+			prevLevel:  memPressureWarning,
+			memAvailKB: 50,
+			fsTotal:    100,
+			fsAvail:    50,
+			expLevel:   memPressureNone,
+		},
+		"critical without stop threshold": {
+			memAvailKB: 1,
+			fsTotal:    100,
+			fsAvail:    50,
+			expLevel:   memPressureWarning,
+			expSev:     events.RASSeverityWarning,
+		},
+		"critical; engine stopped": {
+			cfg:        &config.MemoryWatchdog{MemAvailStopPercent: 2},
+			prevLevel:  memPressureWarning,
+			memAvailKB: 1,
+			fsTotal:    100,
+			fsAvail:    50,
+			expLevel:   memPressureCritical,
+			expSev:     events.RASSeverityError,
+			expStopped: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			sysProv := system.NewMockSysProvider(log, &system.MockSysConfig{
+				GetfsUsageResps: []system.GetfsUsageRetval{
+					{Total: tc.fsTotal, Avail: tc.fsAvail},
+				},
+			})
+			sp := storage.MockProvider(log, 0, &mockRamCfg, sysProv, nil, nil, nil)
+
+			var stopped bool
+			trc := &engine.TestRunnerConfig{
+				SignalCb: func(uint32, os.Signal) { stopped = true },
+			}
+			trc.Running.Store(!tc.notStarted)
+			ei := NewEngineInstance(log, sp, nil, engine.NewTestRunner(trc, engine.MockConfig()))
+			ei.setSuperblock(&Superblock{Rank: ranklist.NewRankPtr(rank)})
+
+			var published []*events.RASEvent
+			mw := newMemWatchdog(log, tc.cfg, "foo", []Engine{ei}, func(evt *events.RASEvent) {
+				published = append(published, evt)
+			})
+			mw.getMemInfo = func() (*common.MemInfo, error) {
+				if tc.memInfoErr != nil {
+					return nil, tc.memInfoErr
+				}
+				return &common.MemInfo{
+					MemTotalKiB:     100,
+					MemAvailableKiB: tc.memAvailKB,
+				}, nil
+			}
+			mw.levels[ei.Index()] = tc.prevLevel
+
+			gotErr := mw.check([]Engine{ei})
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.expLevel, mw.levels[ei.Index()], "unexpected pressure level")
+			test.AssertEqual(t, tc.expStopped, stopped, "unexpected engine stop")
+
+			if tc.expSev == events.RASSeverityUnknown {
+				test.AssertEqual(t, 0, len(published), "unexpected events published")
+				return
+			}
+			test.AssertEqual(t, 1, len(published), "expected one event published")
+			evt := published[0]
+			test.AssertEqual(t, events.RASEngineMemoryPressure, evt.ID, "unexpected event ID")
+			test.AssertEqual(t, tc.expSev, evt.Severity, "unexpected event severity")
+			test.AssertEqual(t, uint32(rank), evt.Rank, "unexpected event rank")
+		})
+	}
+}
+
+func TestServer_memWatchdog_checkTopology(t *testing.T) {
+	const rank = 3
+
+	for name, tc := range map[string]struct {
+		prevTopo    *memTopology
+		notStarted  bool
+		nrHugepages int
+		memTotalKB  int
+		hugepages   int
+		maxRamdisk  uint64
+		calcErr     error
+		fsTotal     uint64
+		expDetails  string
+	}{
+		"first check": {
+			memTotalKB: 100,
+			maxRamdisk: 50,
+			fsTotal:    100,
+		},
+		"unchanged": {
+			prevTopo:   &memTopology{memTotalKiB: 100},
+			memTotalKB: 100,
+			maxRamdisk: 50,
+			fsTotal:    100,
+		},
+		"memory added": {
+			prevTopo:   &memTopology{memTotalKiB: 100},
+			memTotalKB: 200,
+			maxRamdisk: 200,
+			fsTotal:    100,
+		},
+		"memory removed; ramdisk still backed": {
+			prevTopo:   &memTopology{memTotalKiB: 200},
+			memTotalKB: 100,
+			maxRamdisk: 100,
+			fsTotal:    100,
+		},
+		"memory removed; ramdisk too large": {
+			prevTopo:   &memTopology{memTotalKiB: 200},
+			memTotalKB: 100,
+			maxRamdisk: 50,
+			fsTotal:    100,
+			expDetails: "exceeds the 50 B that system memory can back",
+		},
+		"memory removed; engine not started": {
+			prevTopo:   &memTopology{memTotalKiB: 200},
+			notStarted: true,
+			memTotalKB: 100,
+			maxRamdisk: 50,
+			fsTotal:    100,
+		},
+		"memory removed; ramdisk cannot be backed": {
+			prevTopo:   &memTopology{memTotalKiB: 200},
+			memTotalKB: 100,
+			calcErr:    errors.New("insufficient ram"),
+			expDetails: "ramdisk cannot be backed: insufficient ram",
+		},
+		"hugepages lost": {
+			prevTopo:    &memTopology{memTotalKiB: 200, hugepagesTotal: 10},
+			nrHugepages: 10,
+			memTotalKB:  100,
+			hugepages:   5,
+			maxRamdisk:  100,
+			fsTotal:     100,
+			expDetails:  "5 hugepages allocated but 10 required",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			sysProv := system.NewMockSysProvider(log, &system.MockSysConfig{
+				GetfsUsageResps: []system.GetfsUsageRetval{
+					{Total: tc.fsTotal, Avail: tc.fsTotal},
+				},
+			})
+			sp := storage.MockProvider(log, 0, &mockRamCfg, sysProv, nil, nil, nil)
+
+			trc := new(engine.TestRunnerConfig)
+			trc.Running.Store(!tc.notStarted)
+			ei := NewEngineInstance(log, sp, nil, engine.NewTestRunner(trc, engine.MockConfig()))
+			ei.setSuperblock(&Superblock{Rank: ranklist.NewRankPtr(rank)})
+
+			var published []*events.RASEvent
+			mw := newMemWatchdog(log, nil, "foo", []Engine{ei}, func(evt *events.RASEvent) {
+				published = append(published, evt)
+			})
+			mw.nrHugepages = tc.nrHugepages
+			mw.calcRamdiskSize = func(int, int) (uint64, error) {
+				return tc.maxRamdisk, tc.calcErr
+			}
+			mw.topology = tc.prevTopo
+
+			mw.checkTopology([]Engine{ei}, &common.MemInfo{
+				MemTotalKiB:    tc.memTotalKB,
+				HugepagesTotal: tc.hugepages,
+			})
+
+			test.AssertEqual(t, tc.memTotalKB, mw.topology.memTotalKiB, "unexpected recorded memory total")
+
+			if tc.expDetails == "" {
+				test.AssertEqual(t, 0, len(published), "unexpected events published")
+				return
+			}
+			test.AssertEqual(t, 1, len(published), "expected one event published")
+			evt := published[0]
+			test.AssertEqual(t, events.RASEngineMemoryPressure, evt.ID, "unexpected event ID")
+			test.AssertEqual(t, events.RASSeverityWarning, evt.Severity, "unexpected event severity")
+			test.AssertEqual(t, uint32(rank), evt.Rank, "unexpected event rank")
+			details := string(*evt.GetStrInfo())
+			test.AssertTrue(t, strings.Contains(details, tc.expDetails),
+				fmt.Sprintf("event details %q do not contain %q", details, tc.expDetails))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/peer"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// armedOpTTL is the time within which an armed destructive operation must
+// be confirmed.
+const armedOpTTL = 5 * time.Minute
+
+type (
+	armedOp struct {
+		operation string
+		expires   time.Time
+	}
+
+	// destructiveOpArmer tracks the tokens issued for destructive system
+	// operations that must be armed before they are performed. Tokens are
+	// only held in memory on the MS leader, so a change of leadership
+	// requires the operation to be armed again.
+	destructiveOpArmer struct {
+		sync.Mutex
+		ttl   time.Duration
+		armed map[string]*armedOp
+	}
+)
+
+func newDestructiveOpArmer() *destructiveOpArmer {
+	return &destructiveOpArmer{
+		ttl:   armedOpTTL,
+		armed: make(map[string]*armedOp),
+	}
+}
+
+func (a *destructiveOpArmer) expire(now time.Time) {
+	for token, op := range a.armed {
+		if now.After(op.expires) {
+			delete(a.armed, token)
+		}
+	}
+}
+
+// arm issues a token that may be used once to perform the operation before
+// the token expires.
+func (a *destructiveOpArmer) arm(operation string) (string, time.Time) {
+	a.Lock()
+	defer a.Unlock()
+
+	now := time.Now()
+	a.expire(now)
+
+	token := uuid.New().String()
+	op := &armedOp{
+		operation: operation,
+		expires:   now.Add(a.ttl),
+	}
+	a.armed[token] = op
+
+	return token, op.expires
+}
+
+// confirm consumes the token issued for the operation.
+func (a *destructiveOpArmer) confirm(operation, token string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	a.expire(time.Now())
+
+	if token == "" {
+		return errors.Errorf("system %s must be armed before it can be performed", operation)
+	}
+	op, found := a.armed[token]
+	if !found {
+		return errors.Errorf("system %s token is invalid or has expired; re-arm the operation", operation)
+	}
+	if op.operation != operation {
+		return errors.Errorf("token was armed for system %s, not %s", op.operation, operation)
+	}
+	delete(a.armed, token)
+
+	return nil
+}
+
+func requesterFromContext(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown requester"
+}
+
+// confirmDestructiveOp consumes the token for a destructive operation,
+// recording the outcome as a RAS event.
+func (svc *mgmtSvc) confirmDestructiveOp(ctx context.Context, operation, token string) error {
+	requester := requesterFromContext(ctx)
+	if err := svc.armer.confirm(operation, token); err != nil {
+		svc.log.Errorf("rejected system %s requested by %s: %s", operation, requester, err)
+		return err
+	}
+
+	svc.log.Noticef("system %s confirmed by %s", operation, requester)
+	svc.events.Publish(events.NewSystemDestructiveOpEvent(operation, "confirmed", requester))
+
+	return nil
+}
+
+// SystemArm implements the method defined for the Management Service.
+//
+// Issue a token that must be supplied to perform a destructive system
+// operation. A storage format is performed by the hosts rather than the MS,
+// so its token is instead consumed by a request to confirm it before the
+// format is sent to the hosts.
+func (svc *mgmtSvc) SystemArm(ctx context.Context, req *mgmtpb.SystemArmReq) (*mgmtpb.SystemArmResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	switch req.Operation {
+	case control.SystemOpErase:
+		if req.Confirm != "" {
+			return nil, errors.Errorf("system %s is confirmed when it is performed", req.Operation)
+		}
+	case control.SystemOpFormat:
+		if req.Confirm != "" {
+			if err := svc.confirmDestructiveOp(ctx, req.Operation, req.Confirm); err != nil {
+				return nil, err
+			}
+			return new(mgmtpb.SystemArmResp), nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported destructive operation %q", req.Operation)
+	}
+
+	requester := requesterFromContext(ctx)
+	token, expires := svc.armer.arm(req.Operation)
+	svc.log.Noticef("system %s armed by %s until %s", req.Operation, requester, expires)
+	svc.events.Publish(events.NewSystemDestructiveOpEvent(req.Operation, "armed", requester))
+
+	return &mgmtpb.SystemArmResp{
+		Token:   token,
+		Expires: expires.Unix(),
+	}, nil
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_destructiveOpArmer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ttl       time.Duration
+		armOp     string
+		confirmOp string
+		badToken  bool
+		noToken   bool
+		expErr    error
+	}{
+		"no token": {
+			armOp:     "erase",
+			confirmOp: "erase",
+			noToken:   true,
+			expErr:    errors.New("must be armed"),
+		},
+		"unknown token": {
+			armOp:     "erase",
+			confirmOp: "erase",
+			badToken:  true,
+			expErr:    errors.New("invalid or has expired"),
+		},
+		"expired token": {
+			ttl:       -time.Second,
+			armOp:     "erase",
+			confirmOp: "erase",
+			expErr:    errors.New("invalid or has expired"),
+		},
+		"wrong operation": {
+			armOp:     "format",
+			confirmOp: "erase",
+			expErr:    errors.New("armed for system format, not erase"),
+		},
+		"success": {
+			armOp:     "erase",
+			confirmOp: "erase",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			armer := newDestructiveOpArmer()
+			if tc.ttl != 0 {
+				armer.ttl = tc.ttl
+			}
+
+			token, expires := armer.arm(tc.armOp)
+			if expires.IsZero() {
+				t.Fatal("expected non-zero expiry")
+			}
+			switch {
+			case tc.noToken:
+				token = ""
+			case tc.badToken:
+				token = "bad-token"
+			}
+
+			test.CmpErr(t, tc.expErr, armer.confirm(tc.confirmOp, token))
+			if tc.expErr != nil {
+				return
+			}
+
+			// Tokens may only be used once.
+			test.CmpErr(t, errors.New("invalid or has expired"),
+				armer.confirm(tc.confirmOp, token))
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemArm(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.SystemArmReq
+		armFormat bool
+		expErr    error
+	}{
+		"nil req": {
+			expErr: errors.New("nil request"),
+		},
+		"unsupported operation": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: "reboot",
+			},
+			expErr: errors.New("unsupported destructive operation"),
+		},
+		"erase": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: control.SystemOpErase,
+			},
+		},
+		"confirm erase": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: control.SystemOpErase,
+				Confirm:   "token",
+			},
+			expErr: errors.New("confirmed when it is performed"),
+		},
+		"format": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: control.SystemOpFormat,
+			},
+		},
+		"confirm format; not armed": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: control.SystemOpFormat,
+				Confirm:   "token",
+			},
+			expErr: errors.New("invalid or has expired"),
+		},
+		"confirm format": {
+			req: &mgmtpb.SystemArmReq{
+				Sys:       build.DefaultSystemName,
+				Operation: control.SystemOpFormat,
+			},
+			armFormat: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			if tc.armFormat {
+				tc.req.Confirm, _ = svc.armer.arm(control.SystemOpFormat)
+			}
+			gotResp, gotErr := svc.SystemArm(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.armFormat {
+				// The token is consumed by the confirmation.
+				test.CmpErr(t, errors.New("invalid or has expired"),
+					svc.armer.confirm(tc.req.Operation, tc.req.Confirm))
+				return
+			}
+
+			if gotResp.Expires <= time.Now().Unix() {
+				t.Fatalf("expected token expiry in the future, got %d", gotResp.Expires)
+			}
+			if err := svc.armer.confirm(tc.req.Operation, gotResp.Token); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// autoReintCheckInterval is the period at which the MS leader checks
+	// for rejoined ranks that have passed the stability window.
+	autoReintCheckInterval = 10 * time.Second
+	// autoReintPoolTimeout bounds the query and reintegration of a rank in
+	// each pool.
+	autoReintPoolTimeout = 30 * time.Second
+)
+
+type (
+	// autoReintCandidate records the join of a rank that had been excluded.
+	autoReintCandidate struct {
+		rank        ranklist.Rank
+		incarnation uint64
+		joined      time.Time
+	}
+
+	// autoReintegrator tracks ranks that have rejoined the system after
+	// being excluded, holding each one until it has remained joined for
+	// the stability window.
+	autoReintegrator struct {
+		sync.Mutex
+		window     time.Duration
+		candidates map[ranklist.Rank]*autoReintCandidate
+	}
+)
+
+// newAutoReintegrator returns an autoReintegrator for the supplied policy, or
+// nil if automatic reintegration has not been enabled.
+func newAutoReintegrator(cfg *config.AutoReintegrate) *autoReintegrator {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	return &autoReintegrator{
+		window:     cfg.WithDefaults().StabilityWindow,
+		candidates: make(map[ranklist.Rank]*autoReintCandidate),
+	}
+}
+
+// add records the join of a previously excluded rank, replacing any earlier
+// record so that the stability window restarts on each join.
+func (ar *autoReintegrator) add(rank ranklist.Rank, incarnation uint64, joined time.Time) {
+	if ar == nil {
+		return
+	}
+
+	ar.Lock()
+	defer ar.Unlock()
+
+	ar.candidates[rank] = &autoReintCandidate{
+		rank:        rank,
+		incarnation: incarnation,
+		joined:      joined,
+	}
+}
+
+// due removes and returns the candidates that joined at least the stability
+// window before the supplied time.
+func (ar *autoReintegrator) due(now time.Time) []*autoReintCandidate {
+	if ar == nil {
+		return nil
+	}
+
+	ar.Lock()
+	defer ar.Unlock()
+
+	var out []*autoReintCandidate
+	for rank, c := range ar.candidates {
+		if now.Sub(c.joined) < ar.window {
+			continue
+		}
+		out = append(out, c)
+		delete(ar.candidates, rank)
+	}
+	return out
+}
+
+// autoReintegrateEnabled returns true unless automatic reintegration has been
+// suspended by setting the system property to false.
+func (svc *mgmtSvc) autoReintegrateEnabled() bool {
+	val, err := system.GetUserProperty(svc.sysdb, svc.systemProps, daos.SystemPropertyAutoReintegrate.String())
+	if err != nil {
+		svc.log.Errorf("failed to get %s system property: %s", daos.SystemPropertyAutoReintegrate, err)
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(val)
+	if err != nil {
+		svc.log.Errorf("invalid %s system property value %q", daos.SystemPropertyAutoReintegrate, val)
+		return false
+	}
+	return enabled
+}
+
+// autoReintegrateLoop periodically reintegrates the ranks that have remained
+// joined for the stability window since rejoining after being excluded.
+func (svc *mgmtSvc) autoReintegrateLoop(parent context.Context) {
+	if svc.autoReint == nil {
+		return
+	}
+
+	checkTimer := time.NewTicker(autoReintCheckInterval)
+	defer checkTimer.Stop()
+
+	svc.log.Debug("starting autoReintegrateLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped autoReintegrateLoop")
+			return
+		case <-checkTimer.C:
+			svc.processAutoReintegrations(parent, time.Now())
+		}
+	}
+}
+
+// processAutoReintegrations reintegrates each candidate that has passed the
+// stability window, provided that it is still joined with the incarnation
+// that it rejoined with.
+func (svc *mgmtSvc) processAutoReintegrations(ctx context.Context, now time.Time) {
+	candidates := svc.autoReint.due(now)
+	if len(candidates) == 0 {
+		return
+	}
+
+	if !svc.autoReintegrateEnabled() {
+		for _, c := range candidates {
+			svc.log.Noticef("automatic reintegration suspended; rank %d must be reintegrated manually", c.rank)
+		}
+		return
+	}
+
+	for _, c := range candidates {
+		m, err := svc.membership.Get(c.rank)
+		if err != nil {
+			svc.log.Errorf("skipping automatic reintegration of rank %d: %s", c.rank, err)
+			continue
+		}
+		if m.State&(system.MemberStateJoined|system.MemberStateQuarantined) == 0 || m.Incarnation != c.incarnation {
+			svc.log.Debugf("skipping automatic reintegration of rank %d: not stable (state %s, incarnation %d)",
+				c.rank, m.State, m.Incarnation)
+			continue
+		}
+
+		svc.autoReintegrateRank(ctx, m)
+	}
+}
+
+// autoReintegrateRank reintegrates the targets of the member into each ready
+// pool that reports the member's rank as disabled.
+func (svc *mgmtSvc) autoReintegrateRank(ctx context.Context, m *system.Member) {
+	poolSvcs, err := svc.sysdb.PoolServiceList(false)
+	if err != nil {
+		svc.log.Errorf("failed to list pools for automatic reintegration of rank %d: %s", m.Rank, err)
+		return
+	}
+
+	for _, ps := range poolSvcs {
+		poolID := ps.PoolUUID.String()
+
+		excluded, err := svc.poolRankExcluded(ctx, poolID, m.Rank)
+		if err != nil {
+			svc.log.Errorf("failed to query pool %s for automatic reintegration of rank %d: %s",
+				poolID, m.Rank, err)
+			continue
+		}
+		if !excluded {
+			continue
+		}
+
+		if err := svc.poolReintegrateRank(ctx, poolID, m.Rank); err != nil {
+			svc.log.Errorf("automatic reintegration of rank %d into pool %s failed: %s", m.Rank, poolID, err)
+			svc.events.Publish(events.NewPoolAutoReintegrateFailedEvent(m.Rank.Uint32(),
+				m.Incarnation, poolID, err))
+			continue
+		}
+
+		svc.log.Noticef("rank %d automatically reintegrated into pool %s", m.Rank, poolID)
+		svc.events.Publish(events.NewPoolAutoReintegrateEvent(m.Rank.Uint32(), m.Incarnation, poolID))
+	}
+}
+
+// poolRankExcluded returns true if the pool reports the rank as disabled.
+func (svc *mgmtSvc) poolRankExcluded(ctx context.Context, poolID string, rank ranklist.Rank) (bool, error) {
+	qCtx, cancel := context.WithTimeout(ctx, autoReintPoolTimeout)
+	defer cancel()
+
+	resp, err := svc.PoolQuery(qCtx, &mgmtpb.PoolQueryReq{
+		Sys:       svc.sysdb.SystemName(),
+		Id:        poolID,
+		QueryMask: uint64(daos.HealthOnlyPoolQueryMask),
+	})
+	if err == nil && resp.Status != int32(daos.Success) {
+		err = daos.Status(resp.Status)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	disabled, err := ranklist.CreateRankSet(resp.DisabledRanks)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid disabled ranks")
+	}
+	for _, r := range disabled.Ranks() {
+		if r == rank {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// poolReintegrateRank reintegrates all of the rank's targets into the pool.
+func (svc *mgmtSvc) poolReintegrateRank(ctx context.Context, poolID string, rank ranklist.Rank) error {
+	rCtx, cancel := context.WithTimeout(ctx, autoReintPoolTimeout)
+	defer cancel()
+
+	resp, err := svc.PoolReintegrate(rCtx, &mgmtpb.PoolReintegrateReq{
+		Sys:  svc.sysdb.SystemName(),
+		Id:   poolID,
+		Rank: rank.Uint32(),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Status != int32(daos.Success) {
+		return daos.Status(resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_autoReintegrator(t *testing.T) {
+	for name, cfg := range map[string]*config.AutoReintegrate{
+		"no config":   nil,
+		"not enabled": {StabilityWindow: time.Minute},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if ar := newAutoReintegrator(cfg); ar != nil {
+				t.Fatal("expected nil autoReintegrator")
+			}
+		})
+	}
+
+	var nilAR *autoReintegrator
+	nilAR.add(1, 1, time.Now())
+	test.AssertEqual(t, 0, len(nilAR.due(time.Now())), "nil autoReintegrator returned candidates")
+
+	ar := newAutoReintegrator(&config.AutoReintegrate{Enabled: true})
+	test.AssertEqual(t, config.DefaultAutoReintegrateWindow, ar.window, "default window not applied")
+
+	start := time.Now()
+	ar.add(1, 1, start)
+	ar.add(2, 1, start)
+	// A rank that rejoins again restarts its stability window.
+	ar.add(2, 2, start.Add(time.Minute))
+
+	test.AssertEqual(t, 0, len(ar.due(start.Add(ar.window-time.Second))), "candidates due before window")
+
+	due := ar.due(start.Add(ar.window))
+	test.AssertEqual(t, 1, len(due), "unexpected number of candidates due")
+	test.AssertEqual(t, ranklist.Rank(1), due[0].rank, "unexpected candidate due")
+	test.AssertEqual(t, 0, len(ar.due(start.Add(ar.window))), "candidate returned twice")
+
+	due = ar.due(start.Add(time.Minute + ar.window))
+	test.AssertEqual(t, 1, len(due), "rejoined candidate not due")
+	test.AssertEqual(t, uint64(2), due[0].incarnation, "unexpected candidate incarnation")
+}
+
+func TestServer_MgmtSvc_processAutoReintegrations(t *testing.T) {
+	poolUUID := uuid.MustParse(mockUUID)
+
+	for name, tc := range map[string]struct {
+		memberState  system.MemberState
+		incarnation  uint64
+		propDisabled bool
+		drpcResps    []*mockDrpcResponse
+		expMethods   []drpc.Method
+	}{
+		"rank excluded from pool; reintegrated": {
+			drpcResps: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{DisabledRanks: "0-1"}},
+				{Message: &mgmtpb.PoolReintegrateResp{}},
+			},
+			expMethods: []drpc.Method{drpc.MethodPoolQuery, drpc.MethodPoolReintegrate},
+		},
+		"rank not excluded from pool": {
+			drpcResps: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{DisabledRanks: "1"}},
+			},
+			expMethods: []drpc.Method{drpc.MethodPoolQuery},
+		},
+		"pool query fails": {
+			drpcResps: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{Status: int32(daos.TimedOut)}},
+			},
+			expMethods: []drpc.Method{drpc.MethodPoolQuery},
+		},
+		"reintegration fails": {
+			drpcResps: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{DisabledRanks: "0"}},
+				{Message: &mgmtpb.PoolReintegrateResp{Status: int32(daos.MiscError)}},
+			},
+			expMethods: []drpc.Method{drpc.MethodPoolQuery, drpc.MethodPoolReintegrate},
+		},
+		"rank no longer joined": {
+			memberState: system.MemberStateExcluded,
+		},
+		"rank restarted during window": {
+			incarnation: 2,
+		},
+		"suspended by system property": {
+			propDisabled: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+
+			if tc.memberState == system.MemberStateUnknown {
+				tc.memberState = system.MemberStateJoined
+			}
+			m := system.MockMember(t, 0, tc.memberState)
+			m.Incarnation = 1
+			if err := svc.sysdb.AddMember(m); err != nil {
+				t.Fatal(err)
+			}
+			addTestPoolService(t, svc.sysdb, &system.PoolService{
+				PoolUUID:  poolUUID,
+				PoolLabel: "test",
+				State:     system.PoolServiceStateReady,
+				Replicas:  []ranklist.Rank{0},
+				Storage:   &system.PoolServiceStorage{},
+			})
+
+			if tc.propDisabled {
+				if err := system.SetUserProperty(svc.sysdb, svc.systemProps,
+					daos.SystemPropertyAutoReintegrate.String(), "false"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := new(mockDrpcClientConfig)
+			cfg.setSendMsgResponseList(t, tc.drpcResps...)
+			mdc := newMockDrpcClient(cfg)
+			setupSvcDrpcClient(svc, 0, mdc)
+
+			svc.autoReint = newAutoReintegrator(&config.AutoReintegrate{Enabled: true})
+			incarnation := tc.incarnation
+			if incarnation == 0 {
+				incarnation = m.Incarnation
+			}
+			start := time.Now()
+			svc.autoReint.add(m.Rank, incarnation, start)
+
+			svc.processAutoReintegrations(test.Context(t), start.Add(svc.autoReint.window))
+
+			if diff := cmp.Diff(tc.expMethods, mdc.CalledMethods()); diff != "" {
+				t.Fatalf("unexpected dRPC calls (-want, +got):\n%s\n", diff)
+			}
+			test.AssertEqual(t, 0, len(svc.autoReint.candidates), "candidate not removed")
+		})
+	}
+}
@@ -0,0 +1,188 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// eventArchiveColumns are the column titles of an event archive file.
+var eventArchiveColumns = []string{
+	"id", "ras_id", "severity", "msg", "hostname", "rank", "pool_uuid",
+	"first_seen", "last_seen", "count", "ack_time",
+}
+
+// eventArchiver moves aged-out persistent events from the MS database to
+// CSV files for long-term analysis.
+type eventArchiver struct {
+	cfg *config.EventArchive
+}
+
+// newEventArchiver returns an eventArchiver for the supplied parameters, or
+// nil if the event archive has not been configured.
+func newEventArchiver(cfg *config.EventArchive) *eventArchiver {
+	if cfg == nil {
+		return nil
+	}
+
+	return &eventArchiver{
+		cfg: cfg.WithDefaults(),
+	}
+}
+
+// agedOut returns the events that were acknowledged at least archive_after
+// before the supplied time.
+func (ea *eventArchiver) agedOut(evts []*system.PersistentEvent, now time.Time) []*system.PersistentEvent {
+	var out []*system.PersistentEvent
+	for _, pe := range evts {
+		if pe.IsActive() || now.Sub(pe.AckTime) < ea.cfg.ArchiveAfter {
+			continue
+		}
+		out = append(out, pe)
+	}
+	return out
+}
+
+// eventArchiveTime formats a timestamp for an event archive file.
+func eventArchiveTime(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.UTC().Format(time.RFC3339Nano)
+}
+
+// marshalEventArchive renders the events as CSV with a header row.
+func marshalEventArchive(evts []*system.PersistentEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(eventArchiveColumns); err != nil {
+		return nil, err
+	}
+	for _, pe := range evts {
+		if err := w.Write([]string{
+			strconv.FormatUint(pe.ID, 10),
+			pe.RASID,
+			pe.Severity,
+			pe.Msg,
+			pe.Hostname,
+			strconv.FormatUint(uint64(pe.Rank), 10),
+			pe.PoolUUID,
+			eventArchiveTime(pe.FirstSeen),
+			eventArchiveTime(pe.LastSeen),
+			strconv.FormatUint(uint64(pe.Count), 10),
+			eventArchiveTime(pe.AckTime),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// write writes the events to a new file in the archive directory and
+// returns its path. The file is named after the ID of the first event, so
+// that files written within the same second do not collide.
+func (ea *eventArchiver) write(sysName string, evts []*system.PersistentEvent, now time.Time) (string, error) {
+	data, err := marshalEventArchive(evts)
+	if err != nil {
+		return "", errors.Wrap(err, "formatting event archive")
+	}
+
+	if err := os.MkdirAll(ea.cfg.ArchiveDir, 0750); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("events-%s-%s-%d.csv", sysName, now.UTC().Format("20060102T150405Z"), evts[0].ID)
+	path := filepath.Join(ea.cfg.ArchiveDir, name)
+	if err := common.WriteFileAtomic(path, data, 0640); err != nil {
+		return "", errors.Wrapf(err, "writing %s", path)
+	}
+
+	return path, nil
+}
+
+// archiveEvents moves the aged-out events from the MS database to a new
+// archive file. The events are only removed from the database once the
+// file has been written.
+func (svc *mgmtSvc) archiveEvents(now time.Time) error {
+	evts, err := svc.sysdb.Events(false)
+	if err != nil {
+		return err
+	}
+
+	aged := svc.eventArchiver.agedOut(evts, now)
+	if len(aged) == 0 {
+		return nil
+	}
+
+	path, err := svc.eventArchiver.write(svc.sysdb.SystemName(), aged, now)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(aged))
+	for _, pe := range aged {
+		ids = append(ids, pe.ID)
+	}
+	if err := svc.sysdb.RemoveEvents(ids...); err != nil {
+		return errors.Wrapf(err, "removing events archived to %s", path)
+	}
+	svc.log.Debugf("archived %d events to %s", len(aged), path)
+
+	return nil
+}
+
+// archiveEvicted writes events evicted from the full MS database event log
+// to a new archive file, so that they are not lost before they age out.
+func (svc *mgmtSvc) archiveEvicted(evts []*system.PersistentEvent) {
+	path, err := svc.eventArchiver.write(svc.sysdb.SystemName(), evts, time.Now())
+	if err != nil {
+		svc.log.Errorf("failed to archive %d evicted events: %s", len(evts), err)
+		return
+	}
+	svc.log.Debugf("archived %d evicted events to %s", len(evts), path)
+}
+
+// eventArchiveLoop periodically moves aged-out events to the archive.
+func (svc *mgmtSvc) eventArchiveLoop(parent context.Context) {
+	if svc.eventArchiver == nil {
+		return
+	}
+
+	archiveTimer := time.NewTicker(svc.eventArchiver.cfg.Interval)
+	defer archiveTimer.Stop()
+
+	svc.log.Debug("starting eventArchiveLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped eventArchiveLoop")
+			return
+		case <-archiveTimer.C:
+			if err := svc.archiveEvents(time.Now()); err != nil {
+				svc.log.Errorf("failed to archive events: %s", err)
+			}
+		}
+	}
+}
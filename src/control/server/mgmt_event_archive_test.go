@@ -0,0 +1,173 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_marshalEventArchive(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	data, err := marshalEventArchive([]*system.PersistentEvent{
+		{
+			ID:           7,
+			RASID:        "engine_died",
+			Severity:     "ERROR",
+			Msg:          "engine died, \"exit 1\"",
+			Hostname:     "foo",
+			Rank:         1,
+			FirstSeen:    ts,
+			LastSeen:     ts.Add(time.Minute),
+			Count:        2,
+			Acknowledged: true,
+			AckTime:      ts.Add(time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `id,ras_id,severity,msg,hostname,rank,pool_uuid,first_seen,last_seen,count,ack_time
+7,engine_died,ERROR,"engine died, ""exit 1""",foo,1,,2024-03-01T12:00:00Z,2024-03-01T12:01:00Z,2,2024-03-01T13:00:00Z
+`
+	if diff := cmp.Diff(exp, string(data)); diff != "" {
+		t.Fatalf("unexpected archive (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_MgmtSvc_archiveEvents(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ackIDs       []uint64
+		age          time.Duration
+		expArchived  []string
+		expRemaining []uint64
+	}{
+		"nothing acknowledged": {
+			age:          time.Hour,
+			expRemaining: []uint64{1, 2, 3},
+		},
+		"acknowledged but not aged out": {
+			ackIDs:       []uint64{1, 3},
+			age:          time.Minute,
+			expRemaining: []uint64{1, 2, 3},
+		},
+		"aged out": {
+			ackIDs:       []uint64{1, 3},
+			age:          time.Hour,
+			expArchived:  []string{"1,engine_died", "3,engine_died"},
+			expRemaining: []uint64{2},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			svc := newTestMgmtSvc(t, log)
+			svc.eventArchiver = newEventArchiver(&config.EventArchive{
+				ArchiveDir:   filepath.Join(tmpDir, "events"),
+				ArchiveAfter: 30 * time.Minute,
+			})
+			for i := uint32(0); i < 3; i++ {
+				evt := events.NewEngineDiedEvent("foo", i, i, common.NormalExit, 1234+int(i))
+				if err := svc.sysdb.AddEvent(system.NewPersistentEvent(evt)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(tc.ackIDs) > 0 {
+				if err := svc.sysdb.AckEvents(tc.ackIDs...); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			now := time.Now().Add(tc.age)
+			if err := svc.archiveEvents(now); err != nil {
+				t.Fatal(err)
+			}
+
+			archive := filepath.Join(tmpDir, "events", "events-"+build.DefaultSystemName+"-"+
+				now.UTC().Format("20060102T150405Z")+"-1.csv")
+			data, err := os.ReadFile(archive)
+			if len(tc.expArchived) == 0 {
+				if !os.IsNotExist(err) {
+					t.Fatalf("expected no archive file, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+				test.AssertEqual(t, len(tc.expArchived)+1, len(lines), "unexpected number of archive lines")
+				for i, exp := range tc.expArchived {
+					if !strings.HasPrefix(lines[i+1], exp) {
+						t.Fatalf("expected line %d to start with %q, got %q", i+1, exp, lines[i+1])
+					}
+				}
+			}
+
+			remaining, err := svc.sysdb.Events(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotIDs []uint64
+			for _, pe := range remaining {
+				gotIDs = append(gotIDs, pe.ID)
+			}
+			if diff := cmp.Diff(tc.expRemaining, gotIDs); diff != "" {
+				t.Fatalf("unexpected remaining events (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_archiveEvicted(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	tmpDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	svc := newTestMgmtSvc(t, log)
+	svc.eventArchiver = newEventArchiver(&config.EventArchive{
+		ArchiveDir: filepath.Join(tmpDir, "events"),
+	})
+
+	svc.archiveEvicted([]*system.PersistentEvent{
+		{ID: 4, RASID: "engine_died"},
+		{ID: 5, RASID: "engine_died"},
+	})
+
+	archives, err := filepath.Glob(filepath.Join(tmpDir, "events", "events-*-4.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 1, len(archives), "expected one archive file")
+
+	data, err := os.ReadFile(archives[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	test.AssertEqual(t, 3, len(lines), "unexpected number of archive lines")
+}
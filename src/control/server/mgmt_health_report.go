@@ -0,0 +1,495 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// healthReportMaxEvents is the maximum number of events listed in a health
+// report. The most recently seen events are listed.
+const healthReportMaxEvents = 50
+
+type (
+	// healthReportTable is a table of values in a health report section.
+	healthReportTable struct {
+		titles []string
+		rows   [][]string
+	}
+
+	// healthReportSection is a titled section of a health report, holding
+	// a list of items and/or a table.
+	healthReportSection struct {
+		title string
+		items []string
+		table *healthReportTable
+	}
+
+	// healthReport summarizes the events, membership and pool capacity
+	// changes and the health status of the system over a period.
+	healthReport struct {
+		system  string
+		start   time.Time
+		end     time.Time
+		before  *system.HealthSnapshot // nil if no earlier snapshot
+		current *system.HealthSnapshot
+		events  []*system.PersistentEvent // seen during the period
+		active  int
+	}
+
+	// healthReporter delivers the health reports generated by the MS leader.
+	healthReporter struct {
+		cfg      *config.HealthReport
+		sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	}
+)
+
+// newHealthReport creates a report for the period ending with the current
+// snapshot. Changes are reported relative to the last snapshot recorded
+// before the start of the period or, failing that, the earliest snapshot
+// recorded during it.
+func newHealthReport(sysName string, start time.Time, current *system.HealthSnapshot, history []*system.HealthSnapshot, evts []*system.PersistentEvent) *healthReport {
+	rpt := &healthReport{
+		system:  sysName,
+		start:   start,
+		end:     current.Timestamp,
+		current: current,
+	}
+
+	for _, hs := range history {
+		if !hs.Timestamp.Before(rpt.end) {
+			break
+		}
+		if rpt.before == nil || !hs.Timestamp.After(start) {
+			rpt.before = hs
+		}
+	}
+
+	for _, pe := range evts {
+		if !pe.Acknowledged {
+			rpt.active++
+		}
+		if !pe.LastSeen.Before(start) {
+			rpt.events = append(rpt.events, pe)
+		}
+	}
+	sort.SliceStable(rpt.events, func(i, j int) bool {
+		return rpt.events[i].LastSeen.After(rpt.events[j].LastSeen)
+	})
+
+	return rpt
+}
+
+func healthReportPoolName(ps *system.PoolHealthSummary) string {
+	if ps.PoolLabel != "" {
+		return ps.PoolLabel
+	}
+	return ps.PoolUUID.String()
+}
+
+func healthReportPoolDegraded(ps *system.PoolHealthSummary) bool {
+	return ps.QueryError != "" || ps.DisabledTargets > 0 ||
+		ps.State != system.PoolServiceStateReady.String()
+}
+
+// needsAttention returns true if any member is not joined, any pool is
+// degraded or any critical event remains unacknowledged.
+func (rpt *healthReport) needsAttention() bool {
+	if rpt.active > 0 {
+		return true
+	}
+	for state := range rpt.current.MemberStates {
+		if state != system.MemberStateJoined.String() {
+			return true
+		}
+	}
+	for _, ps := range rpt.current.Pools {
+		if healthReportPoolDegraded(ps) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rpt *healthReport) subject() string {
+	status := "OK"
+	if rpt.needsAttention() {
+		status = "attention needed"
+	}
+	return fmt.Sprintf("DAOS system %s health report: %s", rpt.system, status)
+}
+
+func (rpt *healthReport) summarySection() *healthReportSection {
+	var degraded int
+	for _, ps := range rpt.current.Pools {
+		if healthReportPoolDegraded(ps) {
+			degraded++
+		}
+	}
+
+	total := rpt.current.TotalBytes()
+	used := total - rpt.current.FreeBytes()
+	capacity := fmt.Sprintf("Capacity used: %s of %s", humanize.IBytes(used), humanize.IBytes(total))
+	if rpt.before != nil {
+		prevUsed := rpt.before.TotalBytes() - rpt.before.FreeBytes()
+		if used >= prevUsed {
+			capacity += fmt.Sprintf(" (+%s)", humanize.IBytes(used-prevUsed))
+		} else {
+			capacity += fmt.Sprintf(" (-%s)", humanize.IBytes(prevUsed-used))
+		}
+	}
+
+	return &healthReportSection{
+		title: "Summary",
+		items: []string{
+			fmt.Sprintf("Joined members: %d", rpt.current.MemberCount(system.MemberStateJoined)),
+			fmt.Sprintf("Degraded pools: %d/%d", degraded, len(rpt.current.Pools)),
+			capacity,
+			fmt.Sprintf("Events during period: %d", len(rpt.events)),
+			fmt.Sprintf("Active critical events: %d", rpt.active),
+		},
+	}
+}
+
+func (rpt *healthReport) membersSection() *healthReportSection {
+	sect := &healthReportSection{title: "Membership"}
+
+	before := make(map[string]string)
+	if rpt.before != nil {
+		before = rpt.before.MemberStates
+	}
+	states := common.NewStringSet()
+	for state := range before {
+		states.Add(state)
+	}
+	for state := range rpt.current.MemberStates {
+		states.Add(state)
+	}
+
+	for _, state := range states.ToSlice() {
+		ranks := rpt.current.MemberStates[state]
+		if ranks == "" {
+			ranks = "none"
+		}
+		item := fmt.Sprintf("%s: %s", state, ranks)
+		if rpt.before != nil && before[state] != rpt.current.MemberStates[state] {
+			prev := before[state]
+			if prev == "" {
+				prev = "none"
+			}
+			item += fmt.Sprintf(" (was %s)", prev)
+		}
+		sect.items = append(sect.items, item)
+	}
+	if len(sect.items) == 0 {
+		sect.items = []string{"No system members"}
+	}
+
+	return sect
+}
+
+func (rpt *healthReport) poolsSection() *healthReportSection {
+	sect := &healthReportSection{title: "Pools"}
+
+	before := make(map[string]*system.PoolHealthSummary)
+	if rpt.before != nil {
+		for _, ps := range rpt.before.Pools {
+			before[ps.PoolUUID.String()] = ps
+		}
+	}
+
+	tbl := &healthReportTable{
+		titles: []string{"Pool", "State", "Disabled Targets", "Used", "Change"},
+	}
+	for _, ps := range rpt.current.Pools {
+		used := ps.TotalBytes - ps.FreeBytes
+		state := ps.State
+		if ps.QueryError != "" {
+			state = fmt.Sprintf("%s (query failed)", state)
+		}
+
+		change := "-"
+		if prev, found := before[ps.PoolUUID.String()]; found {
+			prevUsed := prev.TotalBytes - prev.FreeBytes
+			if used >= prevUsed {
+				change = "+" + humanize.IBytes(used-prevUsed)
+			} else {
+				change = "-" + humanize.IBytes(prevUsed-used)
+			}
+			delete(before, ps.PoolUUID.String())
+		} else if rpt.before != nil {
+			change = "created"
+		}
+
+		tbl.rows = append(tbl.rows, []string{
+			healthReportPoolName(ps),
+			state,
+			fmt.Sprintf("%d/%d", ps.DisabledTargets, ps.TotalTargets),
+			fmt.Sprintf("%s/%s", humanize.IBytes(used), humanize.IBytes(ps.TotalBytes)),
+			change,
+		})
+	}
+	destroyed := make([]string, 0, len(before))
+	for _, prev := range before {
+		destroyed = append(destroyed, healthReportPoolName(prev))
+	}
+	sort.Strings(destroyed)
+	for _, name := range destroyed {
+		tbl.rows = append(tbl.rows, []string{name, "-", "-", "-", "destroyed"})
+	}
+
+	if len(tbl.rows) == 0 {
+		sect.items = []string{"No pools in system"}
+	} else {
+		sect.table = tbl
+	}
+
+	return sect
+}
+
+func (rpt *healthReport) eventsSection() *healthReportSection {
+	sect := &healthReportSection{title: "Events"}
+
+	evts := rpt.events
+	if len(evts) == 0 {
+		sect.items = []string{"No events during period"}
+		return sect
+	}
+	if len(evts) > healthReportMaxEvents {
+		sect.items = []string{fmt.Sprintf("Showing the %d most recent of %d events",
+			healthReportMaxEvents, len(evts))}
+		evts = evts[:healthReportMaxEvents]
+	}
+
+	tbl := &healthReportTable{
+		titles: []string{"Last Seen", "Event", "Host", "Rank", "Count", "Active", "Message"},
+	}
+	for _, pe := range evts {
+		active := "Yes"
+		if pe.Acknowledged {
+			active = "No"
+		}
+		tbl.rows = append(tbl.rows, []string{
+			common.FormatTime(pe.LastSeen),
+			pe.RASID,
+			pe.Hostname,
+			fmt.Sprintf("%d", pe.Rank),
+			fmt.Sprintf("%d", pe.Count),
+			active,
+			pe.Msg,
+		})
+	}
+	sect.table = tbl
+
+	return sect
+}
+
+func (rpt *healthReport) sections() []*healthReportSection {
+	return []*healthReportSection{
+		rpt.summarySection(),
+		rpt.membersSection(),
+		rpt.poolsSection(),
+		rpt.eventsSection(),
+	}
+}
+
+func (rpt *healthReport) period() string {
+	return fmt.Sprintf("Period: %s to %s", common.FormatTime(rpt.start), common.FormatTime(rpt.end))
+}
+
+// renderText renders the report as plain text.
+func (rpt *healthReport) renderText() string {
+	var bld strings.Builder
+
+	fmt.Fprintf(&bld, "%s\n%s\n", rpt.subject(), rpt.period())
+	for _, sect := range rpt.sections() {
+		fmt.Fprintf(&bld, "\n%s\n%s\n", sect.title, strings.Repeat("-", len(sect.title)))
+		for _, item := range sect.items {
+			fmt.Fprintf(&bld, "  %s\n", item)
+		}
+		if sect.table == nil {
+			continue
+		}
+
+		formatter := txtfmt.NewTableFormatter(sect.table.titles...)
+		var table []txtfmt.TableRow
+		for _, vals := range sect.table.rows {
+			row := make(txtfmt.TableRow)
+			for i, title := range sect.table.titles {
+				row[title] = vals[i]
+			}
+			table = append(table, row)
+		}
+		formatter.InitWriter(txtfmt.NewIndentWriter(&bld))
+		formatter.Format(table)
+	}
+
+	return bld.String()
+}
+
+// renderHTML renders the report as a self-contained HTML document.
+func (rpt *healthReport) renderHTML() string {
+	var bld strings.Builder
+
+	subject := html.EscapeString(rpt.subject())
+	fmt.Fprintf(&bld, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", subject)
+	fmt.Fprintf(&bld, "<h1>%s</h1>\n<p>%s</p>\n", subject, html.EscapeString(rpt.period()))
+	for _, sect := range rpt.sections() {
+		fmt.Fprintf(&bld, "<h2>%s</h2>\n", html.EscapeString(sect.title))
+		if len(sect.items) > 0 {
+			bld.WriteString("<ul>\n")
+			for _, item := range sect.items {
+				fmt.Fprintf(&bld, "<li>%s</li>\n", html.EscapeString(item))
+			}
+			bld.WriteString("</ul>\n")
+		}
+		if sect.table == nil {
+			continue
+		}
+
+		bld.WriteString("<table border=\"1\" cellpadding=\"4\">\n<tr>")
+		for _, title := range sect.table.titles {
+			fmt.Fprintf(&bld, "<th>%s</th>", html.EscapeString(title))
+		}
+		bld.WriteString("</tr>\n")
+		for _, vals := range sect.table.rows {
+			bld.WriteString("<tr>")
+			for _, val := range vals {
+				fmt.Fprintf(&bld, "<td>%s</td>", html.EscapeString(val))
+			}
+			bld.WriteString("</tr>\n")
+		}
+		bld.WriteString("</table>\n")
+	}
+	bld.WriteString("</body>\n</html>\n")
+
+	return bld.String()
+}
+
+// newHealthReporter returns a healthReporter for the supplied parameters, or
+// nil if health reports have not been configured.
+func newHealthReporter(cfg *config.HealthReport) *healthReporter {
+	if cfg == nil {
+		return nil
+	}
+
+	return &healthReporter{
+		cfg:      cfg.WithDefaults(),
+		sendMail: smtp.SendMail,
+	}
+}
+
+// mailMessage formats the report body as an RFC 5322 message.
+func (hr *healthReporter) mailMessage(rpt *healthReport, contentType, body string) []byte {
+	var bld strings.Builder
+
+	fmt.Fprintf(&bld, "From: %s\r\n", hr.cfg.SMTP.From)
+	fmt.Fprintf(&bld, "To: %s\r\n", strings.Join(hr.cfg.SMTP.To, ", "))
+	fmt.Fprintf(&bld, "Subject: %s\r\n", rpt.subject())
+	fmt.Fprintf(&bld, "Date: %s\r\n", rpt.end.Format(time.RFC1123Z))
+	bld.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&bld, "Content-Type: %s; charset=\"utf-8\"\r\n\r\n", contentType)
+	bld.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return []byte(bld.String())
+}
+
+// deliver writes the report to the output directory and/or mails it, as
+// configured. A failure to deliver by one method does not prevent delivery by
+// the other.
+func (hr *healthReporter) deliver(rpt *healthReport) error {
+	body, ext, contentType := rpt.renderText(), "txt", "text/plain"
+	if hr.cfg.Format == config.HealthReportFormatHTML {
+		body, ext, contentType = rpt.renderHTML(), "html", "text/html"
+	}
+
+	var errs []string
+	if hr.cfg.OutputDir != "" {
+		name := fmt.Sprintf("health-report-%s-%s.%s", rpt.system,
+			rpt.end.UTC().Format("20060102T150405Z"), ext)
+		path := filepath.Join(hr.cfg.OutputDir, name)
+		if err := os.MkdirAll(hr.cfg.OutputDir, 0750); err != nil {
+			errs = append(errs, err.Error())
+		} else if err := os.WriteFile(path, []byte(body), 0640); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if hr.cfg.SMTP != nil {
+		msg := hr.mailMessage(rpt, contentType, body)
+		if err := hr.sendMail(hr.cfg.SMTP.Server, nil, hr.cfg.SMTP.From, hr.cfg.SMTP.To, msg); err != nil {
+			errs = append(errs, errors.Wrapf(err, "mailing via %s", hr.cfg.SMTP.Server).Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf("delivering health report: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// generateHealthReport generates a report for the period preceding the
+// current time and delivers it.
+func (svc *mgmtSvc) generateHealthReport(ctx context.Context) error {
+	current, err := svc.collectHealthSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	history, err := svc.sysdb.HealthSnapshots(0)
+	if err != nil {
+		return err
+	}
+
+	evts, err := svc.sysdb.Events(false)
+	if err != nil {
+		return err
+	}
+
+	start := current.Timestamp.Add(-svc.healthReporter.cfg.Interval)
+	rpt := newHealthReport(svc.sysdb.SystemName(), start, current, history, evts)
+
+	return svc.healthReporter.deliver(rpt)
+}
+
+// healthReportLoop periodically generates and delivers a health report.
+func (svc *mgmtSvc) healthReportLoop(parent context.Context) {
+	if svc.healthReporter == nil {
+		return
+	}
+
+	reportTimer := time.NewTicker(svc.healthReporter.cfg.Interval)
+	defer reportTimer.Stop()
+
+	svc.log.Debug("starting healthReportLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped healthReportLoop")
+			return
+		case <-reportTimer.C:
+			if err := svc.generateHealthReport(parent); err != nil {
+				svc.log.Errorf("failed to generate system health report: %s", err)
+			}
+		}
+	}
+}
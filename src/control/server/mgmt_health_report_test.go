@@ -0,0 +1,240 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func mockHealthReport(t *testing.T) *healthReport {
+	t.Helper()
+
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-24 * time.Hour)
+	snap := func(ts time.Time, states map[string]string, pools ...*system.PoolHealthSummary) *system.HealthSnapshot {
+		return &system.HealthSnapshot{Timestamp: ts, MemberStates: states, Pools: pools}
+	}
+	pool := func(idx int32, label string, disabled uint32, free uint64) *system.PoolHealthSummary {
+		return &system.PoolHealthSummary{
+			PoolUUID:        test.MockPoolUUID(idx),
+			PoolLabel:       label,
+			State:           system.PoolServiceStateReady.String(),
+			TotalTargets:    16,
+			DisabledTargets: disabled,
+			TotalBytes:      100 << 30,
+			FreeBytes:       free,
+		}
+	}
+
+	history := []*system.HealthSnapshot{
+		snap(start.Add(-2*time.Hour), map[string]string{"Joined": "0-1"}),
+		snap(start.Add(-time.Hour), map[string]string{"Joined": "0-3"},
+			pool(1, "pool1", 0, 60<<30), pool(2, "pool2", 0, 100<<30)),
+		snap(start.Add(time.Hour), map[string]string{"Joined": "0-3"},
+			pool(1, "pool1", 0, 50<<30)),
+	}
+	current := snap(end, map[string]string{"Joined": "0-2", "Excluded": "3"},
+		pool(1, "pool1", 4, 40<<30), pool(3, "", 0, 90<<30))
+	evts := []*system.PersistentEvent{
+		{
+			ID:           1,
+			RASID:        "engine_died",
+			Hostname:     "node1",
+			Rank:         1,
+			Msg:          "old event",
+			LastSeen:     start.Add(-time.Minute),
+			Count:        1,
+			Acknowledged: true,
+		},
+		{
+			ID:           2,
+			RASID:        "swim_rank_dead",
+			Hostname:     "node2",
+			Rank:         3,
+			Msg:          "rank <3> marked dead",
+			LastSeen:     start.Add(2 * time.Hour),
+			Count:        2,
+			Acknowledged: true,
+		},
+		{
+			ID:       3,
+			RASID:    "engine_died",
+			Hostname: "node2",
+			Rank:     3,
+			Msg:      "rank 3 exited",
+			LastSeen: start.Add(3 * time.Hour),
+			Count:    1,
+		},
+	}
+
+	return newHealthReport("daos_server", start, current, history, evts)
+}
+
+func TestServer_newHealthReport(t *testing.T) {
+	rpt := mockHealthReport(t)
+
+	test.AssertEqual(t, rpt.start.Add(-time.Hour), rpt.before.Timestamp,
+		"expected last snapshot before period")
+	test.AssertEqual(t, 2, len(rpt.events), "expected events seen during period")
+	test.AssertEqual(t, uint64(3), rpt.events[0].ID, "expected most recent event first")
+	test.AssertEqual(t, 1, rpt.active, "unexpected active event count")
+	test.AssertTrue(t, rpt.needsAttention(), "expected report to need attention")
+
+	noHistory := newHealthReport("daos_server", rpt.start, rpt.current, nil, nil)
+	if noHistory.before != nil {
+		t.Fatal("expected no earlier snapshot")
+	}
+
+	first := &system.HealthSnapshot{Timestamp: rpt.start.Add(time.Hour)}
+	inPeriod := newHealthReport("daos_server", rpt.start, rpt.current,
+		[]*system.HealthSnapshot{
+			first,
+			{Timestamp: rpt.start.Add(2 * time.Hour)},
+			{Timestamp: rpt.end},
+		}, nil)
+	if inPeriod.before != first {
+		t.Fatal("expected earliest snapshot in period")
+	}
+}
+
+func TestServer_healthReport_renderText(t *testing.T) {
+	rpt := mockHealthReport(t)
+
+	expText := `
+DAOS system daos_server health report: attention needed
+Period: 2024-03-01T00:00:00.000+00:00 to 2024-03-02T00:00:00.000+00:00
+
+Summary
+-------
+  Joined members: 3
+  Degraded pools: 1/2
+  Capacity used: 70 GiB of 200 GiB (+30 GiB)
+  Events during period: 2
+  Active critical events: 1
+
+Membership
+----------
+  Excluded: 3 (was none)
+  Joined: 0-2 (was 0-3)
+
+Pools
+-----
+  Pool                                 State Disabled Targets Used           Change    
+  ----                                 ----- ---------------- ----           ------    
+  pool1                                Ready 4/16             60 GiB/100 GiB +20 GiB   
+  00000003-0003-0003-0003-000000000003 Ready 0/16             10 GiB/100 GiB created   
+  pool2                                -     -                -              destroyed 
+
+Events
+------
+  Last Seen                     Event          Host  Rank Count Active Message              
+  ---------                     -----          ----  ---- ----- ------ -------              
+  2024-03-01T03:00:00.000+00:00 engine_died    node2 3    1     Yes    rank 3 exited        
+  2024-03-01T02:00:00.000+00:00 swim_rank_dead node2 3    2     No     rank <3> marked dead 
+`
+	if diff := cmp.Diff(strings.TrimLeft(expText, "\n"), rpt.renderText()); diff != "" {
+		t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+	}
+
+	html := rpt.renderHTML()
+	for _, exp := range []string{
+		"<title>DAOS system daos_server health report: attention needed</title>",
+		"<li>Excluded: 3 (was none)</li>",
+		"<td>rank &lt;3&gt; marked dead</td>",
+	} {
+		if !strings.Contains(html, exp) {
+			t.Fatalf("expected %q in HTML report:\n%s", exp, html)
+		}
+	}
+}
+
+func TestServer_healthReporter_deliver(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        *config.HealthReport
+		mailErr    error
+		expFile    string
+		expMail    bool
+		expErr     error
+		expContent string
+	}{
+		"text file": {
+			cfg:        &config.HealthReport{},
+			expFile:    "health-report-daos_server-20240302T000000Z.txt",
+			expContent: "Summary\n-------\n",
+		},
+		"html file and mail": {
+			cfg: &config.HealthReport{
+				Format: config.HealthReportFormatHTML,
+				SMTP: &config.HealthReportSMTP{
+					Server: "localhost:25",
+					From:   "daos@example.com",
+					To:     []string{"admin@example.com"},
+				},
+			},
+			expFile:    "health-report-daos_server-20240302T000000Z.html",
+			expMail:    true,
+			expContent: "<h2>Summary</h2>",
+		},
+		"mail fails": {
+			cfg: &config.HealthReport{
+				SMTP: &config.HealthReportSMTP{
+					Server: "localhost:25",
+					From:   "daos@example.com",
+					To:     []string{"admin@example.com"},
+				},
+			},
+			mailErr: errors.New("connection refused"),
+			expFile: "health-report-daos_server-20240302T000000Z.txt",
+			expMail: true,
+			expErr:  errors.New("mailing via localhost:25: connection refused"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			cfg := *tc.cfg
+			cfg.OutputDir = filepath.Join(tmpDir, "reports")
+			hr := newHealthReporter(&cfg)
+
+			var mailed []byte
+			hr.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+				mailed = msg
+				return tc.mailErr
+			}
+
+			err := hr.deliver(mockHealthReport(t))
+			test.CmpErr(t, tc.expErr, err)
+
+			data, err := os.ReadFile(filepath.Join(cfg.OutputDir, tc.expFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), tc.expContent) {
+				t.Fatalf("expected %q in report:\n%s", tc.expContent, data)
+			}
+
+			test.AssertEqual(t, tc.expMail, mailed != nil, "unexpected mail")
+			if mailed != nil && !strings.Contains(string(mailed),
+				"Subject: DAOS system daos_server health report: attention needed\r\n") {
+				t.Fatalf("unexpected mail message:\n%s", mailed)
+			}
+		})
+	}
+}
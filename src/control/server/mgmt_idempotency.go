@@ -0,0 +1,178 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// idempotentOpTTL is the time for which the result of a request is kept after
+// it completes. It exceeds the longest client timeout for the requests, so
+// that any retry made by the client before it gives up gets the result.
+const idempotentOpTTL = 15 * time.Minute
+
+type (
+	idempotentFn func(context.Context) (proto.Message, error)
+
+	idempotentOp struct {
+		method  string
+		done    chan struct{}
+		resp    proto.Message
+		err     error
+		expires time.Time // zero until the request completes
+	}
+
+	// idempotencyCache tracks the results of mutating requests by the
+	// idempotency key supplied by the client, so that a request retried
+	// after a timeout or leader-side delay is not applied twice. Results
+	// are only held in memory on the MS leader, so a retry made after a
+	// change of leadership is performed again.
+	idempotencyCache struct {
+		sync.Mutex
+		ttl time.Duration
+		now func() time.Time
+		ops map[string]*idempotentOp
+	}
+)
+
+// detachedContext carries the values of its parent context, e.g. the trace
+// context, without its deadline or cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (dc detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (dc detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (dc detachedContext) Err() error {
+	return nil
+}
+
+func (dc detachedContext) Value(key interface{}) interface{} {
+	return dc.parent.Value(key)
+}
+
+func newIdempotencyCache() *idempotencyCache {
+	return &idempotencyCache{
+		ttl: idempotentOpTTL,
+		now: time.Now,
+		ops: make(map[string]*idempotentOp),
+	}
+}
+
+func (c *idempotencyCache) expire(now time.Time) {
+	for key, op := range c.ops {
+		if !op.expires.IsZero() && now.After(op.expires) {
+			delete(c.ops, key)
+		}
+	}
+}
+
+// reset discards all results, e.g. on loss of leadership.
+func (c *idempotencyCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.ops = make(map[string]*idempotentOp)
+}
+
+// do runs the function for the first request with the key and returns its
+// result to any retries. A retry that arrives while the request is still
+// running waits for it to complete. Only successful results are kept, so
+// that a request that failed may be retried.
+func (c *idempotencyCache) do(ctx context.Context, key, method string, fn idempotentFn) (proto.Message, bool, error) {
+	if key == "" {
+		resp, err := fn(ctx)
+		return resp, false, err
+	}
+
+	c.Lock()
+	c.expire(c.now())
+	op, found := c.ops[key]
+	if found && op.method != method {
+		c.Unlock()
+		return nil, false, errors.Errorf("idempotency key %q was used for a %s request", key, op.method)
+	}
+	if !found {
+		op = &idempotentOp{
+			method: method,
+			done:   make(chan struct{}),
+		}
+		c.ops[key] = op
+		go c.run(ctx, key, op, fn)
+	}
+	c.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case <-op.done:
+	}
+	return op.resp, found, op.err
+}
+
+// run runs the function for the request. The function is not cancelled if the
+// client gives up waiting for the request, so that it is not aborted part way
+// through, and the entry is kept until it finishes so that retries get its
+// result rather than running it again. It is given at most the TTL to finish.
+func (c *idempotencyCache) run(ctx context.Context, key string, op *idempotentOp, fn idempotentFn) {
+	runCtx, cancel := context.WithTimeout(detachedContext{ctx}, c.ttl)
+	defer cancel()
+
+	op.resp, op.err = fn(runCtx)
+
+	failed := op.err != nil
+	if sg, ok := op.resp.(statusGetter); ok && sg.GetStatus() != 0 {
+		failed = true
+	}
+
+	c.Lock()
+	if c.ops[key] == op {
+		if failed {
+			delete(c.ops, key)
+		} else {
+			op.expires = c.now().Add(c.ttl)
+		}
+	}
+	c.Unlock()
+	close(op.done)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(control.IdempotencyKeyHeader); len(vals) > 0 {
+			return vals[0]
+		}
+	}
+	return ""
+}
+
+// idempotent runs the handler for a mutating request once per idempotency key
+// supplied by the client.
+func (svc *mgmtSvc) idempotent(ctx context.Context, req proto.Message, fn idempotentFn) (proto.Message, error) {
+	key := idempotencyKeyFromContext(ctx)
+	method := string(proto.MessageName(req))
+
+	resp, replayed, err := svc.idempotency.do(ctx, key, method, fn)
+	if replayed {
+		svc.log.Debugf("returned existing result for %s with idempotency key %s", method, key)
+	}
+	return resp, err
+}
@@ -0,0 +1,188 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+)
+
+func TestServer_idempotencyCache_do(t *testing.T) {
+	const method = "mgmt.PoolDestroyReq"
+
+	type call struct {
+		key         string
+		method      string
+		after       time.Duration // since start
+		status      daos.Status
+		err         error
+		expCalled   bool
+		expReplayed bool
+		expErr      error
+	}
+
+	for name, tc := range map[string]struct {
+		calls []call
+	}{
+		"no key": {
+			calls: []call{
+				{expCalled: true},
+				{expCalled: true},
+			},
+		},
+		"retry replayed": {
+			calls: []call{
+				{key: "a", expCalled: true},
+				{key: "a", expReplayed: true},
+				{key: "b", expCalled: true},
+			},
+		},
+		"key used for other method": {
+			calls: []call{
+				{key: "a", expCalled: true},
+				{key: "a", method: "mgmt.PoolCreateReq", expErr: errors.New("was used for a mgmt.PoolDestroyReq")},
+			},
+		},
+		"failed request not kept": {
+			calls: []call{
+				{key: "a", err: errors.New("failed"), expCalled: true, expErr: errors.New("failed")},
+				{key: "a", status: daos.Busy, expCalled: true},
+				{key: "a", expCalled: true},
+				{key: "a", expReplayed: true},
+			},
+		},
+		"result expires": {
+			calls: []call{
+				{key: "a", expCalled: true},
+				{key: "a", after: idempotentOpTTL - time.Second, expReplayed: true},
+				{key: "a", after: idempotentOpTTL + time.Second, expCalled: true},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			cache := newIdempotencyCache()
+
+			for i, c := range tc.calls {
+				if c.method == "" {
+					c.method = method
+				}
+				cache.now = func() time.Time { return start.Add(c.after) }
+
+				var called bool
+				resp, replayed, err := cache.do(test.Context(t), c.key, c.method, func(context.Context) (proto.Message, error) {
+					called = true
+					return &mgmtpb.PoolDestroyResp{Status: int32(c.status)}, c.err
+				})
+				test.CmpErr(t, c.expErr, err)
+				test.AssertEqual(t, c.expCalled, called, "unexpected handler call")
+				test.AssertEqual(t, c.expReplayed, replayed, "unexpected replay")
+				if c.expErr == nil && resp == nil {
+					t.Fatalf("call %d: nil response", i)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_idempotencyCache_do_InFlight(t *testing.T) {
+	cache := newIdempotencyCache()
+	ctx := test.Context(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	firstDone := make(chan proto.Message)
+	go func() {
+		resp, _, _ := cache.do(ctx, "a", "method", func(context.Context) (proto.Message, error) {
+			close(started)
+			<-release
+			return &mgmtpb.PoolDestroyResp{}, nil
+		})
+		firstDone <- resp
+	}()
+	<-started
+
+	// A retry made while the request is running waits for its result.
+	retryCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err := cache.do(retryCtx, "a", "method", func(context.Context) (proto.Message, error) {
+		t.Fatal("unexpected handler call")
+		return nil, nil
+	})
+	test.CmpErr(t, context.DeadlineExceeded, err)
+
+	close(release)
+	first := <-firstDone
+
+	resp, replayed, err := cache.do(ctx, "a", "method", func(context.Context) (proto.Message, error) {
+		t.Fatal("unexpected handler call")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, replayed, "expected replayed result")
+	if resp != first {
+		t.Fatal("expected result of first request")
+	}
+}
+
+func TestServer_idempotencyCache_do_ClientGivesUp(t *testing.T) {
+	cache := newIdempotencyCache()
+	ctx := test.Context(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handlerErr := make(chan error, 1)
+	firstCtx, cancelFirst := context.WithCancel(ctx)
+	firstDone := make(chan error)
+	go func() {
+		_, _, err := cache.do(firstCtx, "a", "method", func(ctx context.Context) (proto.Message, error) {
+			close(started)
+			<-release
+			handlerErr <- ctx.Err()
+			return &mgmtpb.PoolDestroyResp{}, nil
+		})
+		firstDone <- err
+	}()
+	<-started
+
+	// The client gives up, but the request continues to run.
+	cancelFirst()
+	test.CmpErr(t, context.Canceled, <-firstDone)
+
+	retryDone := make(chan proto.Message)
+	go func() {
+		resp, replayed, err := cache.do(ctx, "a", "method", func(context.Context) (proto.Message, error) {
+			t.Error("unexpected handler call")
+			return nil, nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		if !replayed {
+			t.Error("expected replayed result")
+		}
+		retryDone <- resp
+	}()
+
+	close(release)
+	if err := <-handlerErr; err != nil {
+		t.Fatalf("handler context cancelled with client: %s", err)
+	}
+	if resp := <-retryDone; resp == nil {
+		t.Fatal("expected result of first request")
+	}
+}
@@ -0,0 +1,316 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	jobStatsInterval = time.Minute
+	// jobStatsQueryTimeout bounds each scrape of a member's
+	// telemetry endpoint while aggregating job statistics.
+	jobStatsQueryTimeout = 10 * time.Second
+
+	// engineJobMetricPrefix is the prefix of the exported names of
+	// engine metrics published under the per-job telemetry tree.
+	engineJobMetricPrefix = "engine_job_"
+)
+
+type metricsQueryFn func(context.Context, *control.MetricsQueryReq) (*control.MetricsQueryResp, error)
+
+// jobStats contains the totals of the per-job metrics reported for a job.
+type jobStats struct {
+	jobID   string
+	ranks   map[string]struct{}
+	metrics map[string]float64
+}
+
+func newJobStats(jobID string) *jobStats {
+	return &jobStats{
+		jobID:   jobID,
+		ranks:   make(map[string]struct{}),
+		metrics: make(map[string]float64),
+	}
+}
+
+// add merges the given totals into the receiver.
+func (js *jobStats) add(other *jobStats) {
+	for rank := range other.ranks {
+		js.ranks[rank] = struct{}{}
+	}
+	for name, val := range other.metrics {
+		js.metrics[name] += val
+	}
+}
+
+// jobStatsAggregator periodically scrapes the telemetry endpoints of the
+// system members on behalf of the MS leader and sums the per-job engine
+// metrics into system-wide totals for each job. The totals are reported
+// as prometheus metrics and are available via the JobStatsQuery RPC.
+type jobStatsAggregator struct {
+	sync.RWMutex
+	log       logging.Logger
+	port      uint32
+	query     metricsQueryFn
+	hosts     map[string]map[string]*jobStats // host -> job ID -> totals
+	updatedAt time.Time
+}
+
+func newJobStatsAggregator(log logging.Logger, port uint32, query metricsQueryFn) *jobStatsAggregator {
+	return &jobStatsAggregator{
+		log:   log,
+		port:  port,
+		query: query,
+		hosts: make(map[string]map[string]*jobStats),
+	}
+}
+
+// parseJobMetrics extracts the per-job counters from the metrics scraped from
+// a single host, summing the values reported by each rank and target.
+func parseJobMetrics(resp *control.MetricsQueryResp) map[string]*jobStats {
+	jobs := make(map[string]*jobStats)
+
+	for _, ms := range resp.MetricSets {
+		if ms.Type != control.MetricTypeCounter || !strings.HasPrefix(ms.Name, engineJobMetricPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(ms.Name, engineJobMetricPrefix)
+
+		for _, m := range ms.Metrics {
+			sm, ok := m.(*control.SimpleMetric)
+			if !ok {
+				continue
+			}
+			jobID := sm.Labels["job"]
+			if jobID == "" {
+				continue
+			}
+
+			js, found := jobs[jobID]
+			if !found {
+				js = newJobStats(jobID)
+				jobs[jobID] = js
+			}
+			if rank, found := sm.Labels["rank"]; found {
+				js.ranks[rank] = struct{}{}
+			}
+			js.metrics[name] += sm.Value
+		}
+	}
+
+	return jobs
+}
+
+// update scrapes the telemetry endpoint of each of the given hosts and
+// replaces the cached per-job metrics for that host. If a host can't be
+// scraped, the metrics from its last successful scrape are retained so that
+// the totals aren't skewed by transient failures.
+func (jsa *jobStatsAggregator) update(ctx context.Context, hosts []string) {
+	type scrapeResult struct {
+		host string
+		jobs map[string]*jobStats
+	}
+
+	results := make(chan *scrapeResult, len(hosts))
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+
+			qCtx, cancel := context.WithTimeout(ctx, jobStatsQueryTimeout)
+			defer cancel()
+
+			resp, err := jsa.query(qCtx, &control.MetricsQueryReq{
+				Host: host,
+				Port: jsa.port,
+			})
+			if err != nil {
+				jsa.log.Debugf("failed to scrape job metrics from %s: %s", host, err)
+				return
+			}
+			results <- &scrapeResult{host: host, jobs: parseJobMetrics(resp)}
+		}(host)
+	}
+	wg.Wait()
+	close(results)
+
+	jsa.Lock()
+	defer jsa.Unlock()
+
+	current := make(map[string]struct{})
+	for _, host := range hosts {
+		current[host] = struct{}{}
+	}
+	for host := range jsa.hosts {
+		if _, found := current[host]; !found {
+			delete(jsa.hosts, host)
+		}
+	}
+	for res := range results {
+		jsa.hosts[res.host] = res.jobs
+	}
+	jsa.updatedAt = time.Now()
+}
+
+// reset discards all cached per-job metrics.
+func (jsa *jobStatsAggregator) reset() {
+	jsa.Lock()
+	defer jsa.Unlock()
+
+	jsa.hosts = make(map[string]map[string]*jobStats)
+	jsa.updatedAt = time.Time{}
+}
+
+// totals returns the system-wide totals for the given job, or for all jobs
+// if the job ID is empty, ordered by job ID.
+func (jsa *jobStatsAggregator) totals(jobID string) ([]*jobStats, time.Time) {
+	jsa.RLock()
+	defer jsa.RUnlock()
+
+	jobs := make(map[string]*jobStats)
+	for _, hostJobs := range jsa.hosts {
+		for id, js := range hostJobs {
+			if jobID != "" && id != jobID {
+				continue
+			}
+			if _, found := jobs[id]; !found {
+				jobs[id] = newJobStats(id)
+			}
+			jobs[id].add(js)
+		}
+	}
+
+	out := make([]*jobStats, 0, len(jobs))
+	for _, js := range jobs {
+		out = append(out, js)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].jobID < out[j].jobID
+	})
+
+	return out, jsa.updatedAt
+}
+
+// Describe sends no descriptors, as the set of exported metrics depends on
+// the per-job metrics published by the engines.
+func (jsa *jobStatsAggregator) Describe(chan<- *prometheus.Desc) {}
+
+func (jsa *jobStatsAggregator) Collect(ch chan<- prometheus.Metric) {
+	jobs, _ := jsa.totals("")
+
+	descs := make(map[string]*prometheus.Desc)
+	for _, js := range jobs {
+		for name, val := range js.metrics {
+			desc, found := descs[name]
+			if !found {
+				desc = prometheus.NewDesc("system_job_"+name,
+					"System-wide total of engine job metric "+name, []string{"job"}, nil)
+				descs[name] = desc
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, val, js.jobID)
+		}
+	}
+}
+
+// jobStatsHosts returns the addresses of the hosts with joined members.
+func (svc *mgmtSvc) jobStatsHosts() ([]string, error) {
+	members, err := svc.sysdb.AllMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var hosts []string
+	for _, m := range members {
+		if m.State&(system.MemberStateJoined|system.MemberStateQuarantined) == 0 || m.Addr == nil {
+			continue
+		}
+		host := m.Addr.IP.String()
+		if _, found := seen[host]; found {
+			continue
+		}
+		seen[host] = struct{}{}
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	return hosts, nil
+}
+
+// jobStatsLoop periodically aggregates the per-job metrics reported by the
+// system members. The cached totals are discarded on leadership loss.
+func (svc *mgmtSvc) jobStatsLoop(parent context.Context) {
+	if svc.jobStats == nil {
+		return
+	}
+	defer svc.jobStats.reset()
+
+	jobStatsTimer := time.NewTicker(jobStatsInterval)
+	defer jobStatsTimer.Stop()
+
+	svc.log.Debug("starting jobStatsLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped jobStatsLoop")
+			return
+		case <-jobStatsTimer.C:
+			hosts, err := svc.jobStatsHosts()
+			if err != nil {
+				svc.log.Errorf("failed to get hosts for job statistics: %s", err)
+				continue
+			}
+			svc.jobStats.update(parent, hosts)
+		}
+	}
+}
+
+// JobStatsQuery returns the system-wide I/O totals for a job or jobs, as
+// aggregated by the MS leader.
+func (svc *mgmtSvc) JobStatsQuery(ctx context.Context, req *mgmtpb.JobStatsQueryReq) (*mgmtpb.JobStatsQueryResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	if svc.jobStats == nil {
+		return nil, errors.New("job statistics are not available: telemetry is not enabled on the MS leader")
+	}
+
+	jobs, updatedAt := svc.jobStats.totals(req.GetJobid())
+	if req.GetJobid() != "" && len(jobs) == 0 {
+		return nil, errors.Wrapf(daos.Nonexistent, "no statistics for job %q", req.GetJobid())
+	}
+
+	resp := new(mgmtpb.JobStatsQueryResp)
+	if !updatedAt.IsZero() {
+		resp.UpdatedAt = updatedAt.UnixNano()
+	}
+	for _, js := range jobs {
+		resp.Jobs = append(resp.Jobs, &mgmtpb.JobStats{
+			Jobid:    js.jobID,
+			Metrics:  js.metrics,
+			NumRanks: uint32(len(js.ranks)),
+		})
+	}
+
+	return resp, nil
+}
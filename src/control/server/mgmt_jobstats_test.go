@@ -0,0 +1,282 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+func mockJobMetricsResp(rank string, jobVals map[string]float64) *control.MetricsQueryResp {
+	counter := &control.MetricSet{
+		Name: engineJobMetricPrefix + "io_update_bytes",
+		Type: control.MetricTypeCounter,
+	}
+	gauge := &control.MetricSet{
+		Name: engineJobMetricPrefix + "io_active",
+		Type: control.MetricTypeGauge,
+	}
+	for job, val := range jobVals {
+		for _, tgt := range []string{"0", "1"} {
+			labels := control.LabelMap{"job": job, "rank": rank, "target": tgt}
+			counter.Metrics = append(counter.Metrics, &control.SimpleMetric{Labels: labels, Value: val})
+			gauge.Metrics = append(gauge.Metrics, &control.SimpleMetric{Labels: labels, Value: 1})
+		}
+	}
+
+	return &control.MetricsQueryResp{
+		MetricSets: []*control.MetricSet{
+			counter,
+			gauge,
+			{
+				Name: "engine_io_update_bytes",
+				Type: control.MetricTypeCounter,
+				Metrics: []control.Metric{
+					&control.SimpleMetric{Labels: control.LabelMap{"rank": rank}, Value: 100},
+				},
+			},
+		},
+	}
+}
+
+func TestServer_jobStatsAggregator(t *testing.T) {
+	for name, tc := range map[string]struct {
+		prev      map[string]map[string]float64 // host -> job -> value of first update
+		cur       map[string]map[string]float64 // host -> job -> value of second update
+		failHosts []string
+		jobID     string
+		expTotals map[string]float64
+		expRanks  map[string]int
+	}{
+		"no jobs": {
+			cur: map[string]map[string]float64{
+				"host1": {},
+			},
+		},
+		"totals across hosts": {
+			cur: map[string]map[string]float64{
+				"host1": {"job1": 10, "job2": 5},
+				"host2": {"job1": 20},
+			},
+			expTotals: map[string]float64{"job1": 60, "job2": 10},
+			expRanks:  map[string]int{"job1": 2, "job2": 1},
+		},
+		"single job": {
+			cur: map[string]map[string]float64{
+				"host1": {"job1": 10, "job2": 5},
+				"host2": {"job1": 20},
+			},
+			jobID:     "job2",
+			expTotals: map[string]float64{"job2": 10},
+			expRanks:  map[string]int{"job2": 1},
+		},
+		"failed host retains previous values": {
+			prev: map[string]map[string]float64{
+				"host1": {"job1": 10},
+				"host2": {"job1": 20},
+			},
+			cur: map[string]map[string]float64{
+				"host1": {"job1": 15},
+				"host2": {"job1": 25},
+			},
+			failHosts: []string{"host2"},
+			expTotals: map[string]float64{"job1": 70},
+			expRanks:  map[string]int{"job1": 2},
+		},
+		"removed host dropped": {
+			prev: map[string]map[string]float64{
+				"host1": {"job1": 10},
+				"host2": {"job1": 20},
+			},
+			cur: map[string]map[string]float64{
+				"host1": {"job1": 15},
+			},
+			expTotals: map[string]float64{"job1": 30},
+			expRanks:  map[string]int{"job1": 1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var vals map[string]map[string]float64
+			var failHosts []string
+			ranks := map[string]string{"host1": "0", "host2": "1"}
+			query := func(_ context.Context, req *control.MetricsQueryReq) (*control.MetricsQueryResp, error) {
+				test.AssertEqual(t, uint32(9191), req.Port, "unexpected telemetry port")
+				for _, host := range failHosts {
+					if host == req.Host {
+						return nil, errors.New("scrape failed")
+					}
+				}
+				return mockJobMetricsResp(ranks[req.Host], vals[req.Host]), nil
+			}
+
+			hostList := func(in map[string]map[string]float64) []string {
+				var hosts []string
+				for host := range in {
+					hosts = append(hosts, host)
+				}
+				return hosts
+			}
+
+			jsa := newJobStatsAggregator(log, 9191, query)
+			if tc.prev != nil {
+				vals = tc.prev
+				jsa.update(test.Context(t), hostList(tc.prev))
+			}
+			vals = tc.cur
+			failHosts = tc.failHosts
+			jsa.update(test.Context(t), hostList(tc.cur))
+
+			jobs, updatedAt := jsa.totals(tc.jobID)
+			if updatedAt.IsZero() {
+				t.Fatal("expected update time to be set")
+			}
+
+			gotTotals := make(map[string]float64)
+			gotRanks := make(map[string]int)
+			for _, js := range jobs {
+				gotTotals[js.jobID] = js.metrics["io_update_bytes"]
+				gotRanks[js.jobID] = len(js.ranks)
+				if _, found := js.metrics["io_active"]; found {
+					t.Fatal("gauge metrics should not be aggregated")
+				}
+			}
+			if tc.expTotals == nil {
+				tc.expTotals = make(map[string]float64)
+				tc.expRanks = make(map[string]int)
+			}
+			if diff := cmp.Diff(tc.expTotals, gotTotals); diff != "" {
+				t.Fatalf("unexpected totals (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expRanks, gotRanks); diff != "" {
+				t.Fatalf("unexpected rank counts (-want, +got):\n%s\n", diff)
+			}
+
+			if tc.jobID == "" {
+				ch := make(chan prometheus.Metric, 100)
+				jsa.Collect(ch)
+				close(ch)
+				test.AssertEqual(t, len(tc.expTotals), len(ch), "unexpected number of metrics")
+			}
+
+			jsa.reset()
+			jobs, updatedAt = jsa.totals("")
+			test.AssertEqual(t, 0, len(jobs), "expected no jobs after reset")
+			test.AssertTrue(t, updatedAt.IsZero(), "expected zero update time after reset")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_JobStatsQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		disabled bool
+		jobID    string
+		expResp  *mgmtpb.JobStatsQueryResp
+		expErr   error
+	}{
+		"telemetry disabled": {
+			disabled: true,
+			expErr:   errors.New("telemetry is not enabled"),
+		},
+		"unknown job": {
+			jobID:  "job3",
+			expErr: daos.Nonexistent,
+		},
+		"single job": {
+			jobID: "job1",
+			expResp: &mgmtpb.JobStatsQueryResp{
+				Jobs: []*mgmtpb.JobStats{
+					{
+						Jobid:    "job1",
+						Metrics:  map[string]float64{"io_update_bytes": 20},
+						NumRanks: 1,
+					},
+				},
+			},
+		},
+		"all jobs": {
+			expResp: &mgmtpb.JobStatsQueryResp{
+				Jobs: []*mgmtpb.JobStats{
+					{
+						Jobid:    "job1",
+						Metrics:  map[string]float64{"io_update_bytes": 20},
+						NumRanks: 1,
+					},
+					{
+						Jobid:    "job2",
+						Metrics:  map[string]float64{"io_update_bytes": 10},
+						NumRanks: 1,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := raft.MockDatabase(t, log)
+			ms := system.MockMembership(t, log, db, mockTCPResolver)
+			for _, m := range []*system.Member{
+				system.MockMember(t, 1, system.MemberStateJoined),
+				system.MockMember(t, 2, system.MemberStateStopped),
+			} {
+				if _, err := ms.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc := newMgmtSvc(NewEngineHarness(log), ms, db, nil, nil)
+
+			if !tc.disabled {
+				query := func(_ context.Context, req *control.MetricsQueryReq) (*control.MetricsQueryResp, error) {
+					test.AssertEqual(t, "127.0.0.1", req.Host, "unexpected host scraped")
+					return mockJobMetricsResp("1", map[string]float64{"job1": 10, "job2": 5}), nil
+				}
+				svc.jobStats = newJobStatsAggregator(log, 9191, query)
+
+				hosts, err := svc.jobStatsHosts()
+				if err != nil {
+					t.Fatal(err)
+				}
+				svc.jobStats.update(test.Context(t), hosts)
+			}
+
+			resp, err := svc.JobStatsQuery(test.Context(t), &mgmtpb.JobStatsQueryReq{
+				Sys:   build.DefaultSystemName,
+				Jobid: tc.jobID,
+			})
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if resp.UpdatedAt == 0 {
+				t.Fatal("expected update time to be set")
+			}
+			tc.expResp.UpdatedAt = resp.UpdatedAt
+			if diff := cmp.Diff(tc.expResp, resp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const (
+	// maxAdmissionReasonLen limits the amount of hook output used as the
+	// reason for a rejected join.
+	maxAdmissionReasonLen = 512
+	// maxConcurrentAdmissions limits the number of admission hooks run at
+	// once for a batch of joins.
+	maxConcurrentAdmissions = 16
+	// admissionDecisionTTL is the time for which a decision reached by
+	// the admission hook is reused for joins by the same engine.
+	admissionDecisionTTL = 5 * time.Minute
+)
+
+type (
+	// joinAdmissionReq describes a joining engine to the admission hook.
+	joinAdmissionReq struct {
+		System              string   `json:"system"`
+		Rank                uint32   `json:"rank"`
+		UUID                string   `json:"uuid"`
+		ControlAddr         string   `json:"control_addr"`
+		CertSubject         string   `json:"cert_subject,omitempty"`
+		CertFingerprint     string   `json:"cert_fingerprint,omitempty"`
+		FabricURI           string   `json:"fabric_uri"`
+		SecondaryFabricURIs []string `json:"secondary_fabric_uris,omitempty"`
+		FaultDomain         string   `json:"fault_domain"`
+		Incarnation         uint64   `json:"incarnation"`
+	}
+
+	// admissionRejectedErr is returned by an admission hook that reached
+	// a decision to reject the join.
+	admissionRejectedErr struct {
+		reason string
+	}
+
+	admissionHookFn func(ctx context.Context, doc []byte) error
+
+	// admissionDecision records the outcome of an admission hook.
+	admissionDecision struct {
+		err     error
+		expires time.Time
+	}
+
+	// joinAdmitter consults an external hook before a rank is admitted
+	// to the system.
+	joinAdmitter struct {
+		sync.Mutex
+		log       logging.Logger
+		cfg       *config.JoinAdmission
+		hook      admissionHookFn
+		decisions map[string]*admissionDecision
+	}
+)
+
+func (e *admissionRejectedErr) Error() string {
+	if e.reason == "" {
+		return "rejected by admission hook"
+	}
+	return "rejected by admission hook: " + e.reason
+}
+
+func isAdmissionRejected(err error) bool {
+	_, ok := errors.Cause(err).(*admissionRejectedErr)
+	return ok
+}
+
+func admissionReason(out []byte) string {
+	reason := strings.TrimSpace(string(out))
+	if len(reason) > maxAdmissionReasonLen {
+		reason = reason[:maxAdmissionReasonLen] + "..."
+	}
+	return reason
+}
+
+// newJoinAdmitter returns a joinAdmitter for the supplied parameters, or nil
+// if no admission hook has been configured.
+func newJoinAdmitter(log logging.Logger, cfg *config.JoinAdmission) *joinAdmitter {
+	if cfg == nil {
+		return nil
+	}
+
+	ja := &joinAdmitter{
+		log: log,
+		cfg: cfg.WithDefaults(),
+	}
+	if ja.cfg.URL != "" {
+		ja.hook = httpAdmissionHook(&http.Client{}, ja.cfg.URL)
+	} else {
+		ja.hook = scriptAdmissionHook(ja.cfg.Script)
+	}
+
+	return ja
+}
+
+// httpAdmissionHook POSTs the join description to the URL. A 2xx response
+// admits the rank and a 403 response rejects it.
+func httpAdmissionHook(client *http.Client, url string) admissionHookFn {
+	return func(ctx context.Context, doc []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(doc))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAdmissionReasonLen+1))
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode == http.StatusForbidden:
+			return &admissionRejectedErr{reason: admissionReason(body)}
+		default:
+			return errors.Errorf("unexpected response from %s: %s", url, resp.Status)
+		}
+	}
+}
+
+// scriptAdmissionHook runs the script with the join description on stdin.
+// Exit status 0 admits the rank and any other exit status rejects it.
+func scriptAdmissionHook(path string) admissionHookFn {
+	return func(ctx context.Context, doc []byte) error {
+		var out bytes.Buffer
+		cmd := exec.Command(path)
+		cmd.Stdin = bytes.NewReader(doc)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		// Run the script in its own process group so that any children
+		// holding its output open are also killed on timeout.
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+		if err := cmd.Start(); err != nil {
+			return errors.Wrapf(err, "running %s", path)
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			<-done
+			return errors.Wrapf(ctx.Err(), "running %s", path)
+		}
+
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*exec.ExitError); ok {
+			return &admissionRejectedErr{reason: admissionReason(out.Bytes())}
+		}
+		return errors.Wrapf(err, "running %s", path)
+	}
+}
+
+func newJoinAdmissionReq(sys string, req *mgmtpb.JoinReq, peerAddr *net.TCPAddr, peerCert *x509.Certificate) *joinAdmissionReq {
+	ar := &joinAdmissionReq{
+		System:              sys,
+		Rank:                req.Rank,
+		UUID:                req.Uuid,
+		FabricURI:           req.Uri,
+		SecondaryFabricURIs: req.SecondaryUris,
+		FaultDomain:         req.SrvFaultDomain,
+		Incarnation:         req.Incarnation,
+	}
+	if peerAddr != nil {
+		ar.ControlAddr = peerAddr.String()
+	}
+	if peerCert != nil {
+		sum := sha256.Sum256(peerCert.Raw)
+		ar.CertSubject = peerCert.Subject.String()
+		ar.CertFingerprint = hex.EncodeToString(sum[:])
+	}
+
+	return ar
+}
+
+// decisionKey identifies the engine described by the admission request. The
+// incarnation is excluded so that a decision is reused when an engine rejoins
+// after a restart.
+func decisionKey(ar *joinAdmissionReq) (string, error) {
+	key := *ar
+	key.Incarnation = 0
+	buf, err := json.Marshal(&key)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// cachedDecision returns the unexpired decision for the key, if any.
+func (ja *joinAdmitter) cachedDecision(key string) (*admissionDecision, bool) {
+	ja.Lock()
+	defer ja.Unlock()
+
+	now := time.Now()
+	for k, d := range ja.decisions {
+		if now.After(d.expires) {
+			delete(ja.decisions, k)
+		}
+	}
+	d, found := ja.decisions[key]
+	return d, found
+}
+
+func (ja *joinAdmitter) cacheDecision(key string, err error) {
+	ja.Lock()
+	defer ja.Unlock()
+
+	if ja.decisions == nil {
+		ja.decisions = make(map[string]*admissionDecision)
+	}
+	ja.decisions[key] = &admissionDecision{
+		err:     err,
+		expires: time.Now().Add(admissionDecisionTTL),
+	}
+}
+
+// admit returns an error if the join should not be allowed to proceed. A
+// decision reached by the hook is reused for subsequent joins by the same
+// engine until it expires, but a failure to reach one is not.
+func (ja *joinAdmitter) admit(ctx context.Context, ar *joinAdmissionReq) error {
+	doc, err := json.Marshal(ar)
+	if err != nil {
+		return err
+	}
+	key, err := decisionKey(ar)
+	if err != nil {
+		return err
+	}
+	if d, found := ja.cachedDecision(key); found {
+		return d.err
+	}
+
+	hookCtx, cancel := context.WithTimeout(ctx, ja.cfg.Timeout)
+	defer cancel()
+
+	err = ja.hook(hookCtx, doc)
+	switch {
+	case err == nil:
+		ja.cacheDecision(key, nil)
+		return nil
+	case isAdmissionRejected(err):
+		ja.cacheDecision(key, err)
+		return err
+	case ja.cfg.AllowOnError:
+		ja.log.Noticef("join admission hook failed, admitting rank %d: %s", ar.Rank, err)
+		return nil
+	default:
+		return errors.Wrap(err, "admission hook failed")
+	}
+}
+
+// checkJoinAdmissions consults the admission hook, if configured, for a batch
+// of joins concurrently, so that a slow hook doesn't hold up the joins in turn.
+// The returned slice holds the error for each join that is not admitted.
+func (svc *mgmtSvc) checkJoinAdmissions(ctx context.Context, joins []*pendingJoin) []error {
+	errs := make([]error, len(joins))
+	if svc.joinAdmitter == nil {
+		return errs
+	}
+
+	sem := make(chan struct{}, maxConcurrentAdmissions)
+	var wg sync.WaitGroup
+	for i, pj := range joins {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, pj *pendingJoin) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = svc.checkJoinAdmission(ctx, pj.msg, pj.replyAddr, pj.peerCert)
+		}(i, pj)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// checkJoinAdmission consults the admission hook, if configured, and
+// publishes a join failure event for a rank that is not admitted.
+func (svc *mgmtSvc) checkJoinAdmission(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net.TCPAddr, peerCert *x509.Certificate) error {
+	if svc.joinAdmitter == nil {
+		return nil
+	}
+
+	ar := newJoinAdmissionReq(svc.sysdb.SystemName(), req, peerAddr, peerCert)
+	if err := svc.joinAdmitter.admit(ctx, ar); err != nil {
+		msg := fmt.Sprintf("rank %d join %s", req.Rank, err)
+		svc.events.Publish(events.NewEngineJoinFailedEvent(ar.ControlAddr, req.Idx, req.Rank, msg))
+		return errors.New(msg)
+	}
+
+	return nil
+}
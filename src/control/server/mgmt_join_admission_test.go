@@ -0,0 +1,281 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+var testAdmissionReq = &joinAdmissionReq{
+	System:      "daos_server",
+	Rank:        1,
+	UUID:        test.MockUUID(1),
+	ControlAddr: "10.0.0.1:10001",
+	FabricURI:   "tcp://10.0.0.1:31416",
+	FaultDomain: "/rack1/host1",
+	Incarnation: 42,
+}
+
+func TestServer_joinAdmitter_admit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		allowOnError bool
+		hookErr      error
+		expErr       error
+	}{
+		"admitted": {},
+		"rejected": {
+			hookErr: &admissionRejectedErr{reason: "unknown hardware"},
+			expErr:  errors.New("rejected by admission hook: unknown hardware"),
+		},
+		"rejected; allow on error": {
+			allowOnError: true,
+			hookErr:      &admissionRejectedErr{},
+			expErr:       errors.New("rejected by admission hook"),
+		},
+		"hook fails": {
+			hookErr: errors.New("connection refused"),
+			expErr:  errors.New("admission hook failed: connection refused"),
+		},
+		"hook fails; allow on error": {
+			allowOnError: true,
+			hookErr:      errors.New("connection refused"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var gotReq joinAdmissionReq
+			ja := &joinAdmitter{
+				log: log,
+				cfg: (&config.JoinAdmission{AllowOnError: tc.allowOnError}).WithDefaults(),
+				hook: func(_ context.Context, doc []byte) error {
+					if err := json.Unmarshal(doc, &gotReq); err != nil {
+						t.Fatal(err)
+					}
+					return tc.hookErr
+				},
+			}
+
+			test.CmpErr(t, tc.expErr, ja.admit(test.Context(t), testAdmissionReq))
+			if diff := cmp.Diff(*testAdmissionReq, gotReq); diff != "" {
+				t.Fatalf("unexpected hook request (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_joinAdmitter_decisions(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	var calls int
+	hookErr := errors.New("connection refused")
+	ja := &joinAdmitter{
+		log: log,
+		cfg: (&config.JoinAdmission{}).WithDefaults(),
+		hook: func(_ context.Context, _ []byte) error {
+			calls++
+			return hookErr
+		},
+	}
+
+	// Hook failures are not reused.
+	for i := 0; i < 2; i++ {
+		test.CmpErr(t, errors.New("connection refused"), ja.admit(test.Context(t), testAdmissionReq))
+	}
+	test.AssertEqual(t, 2, calls, "unexpected number of hook calls")
+
+	// Decisions are reused for the same engine, even after a restart.
+	hookErr = &admissionRejectedErr{reason: "unknown hardware"}
+	restarted := *testAdmissionReq
+	restarted.Incarnation++
+	for _, ar := range []*joinAdmissionReq{testAdmissionReq, &restarted} {
+		test.CmpErr(t, errors.New("unknown hardware"), ja.admit(test.Context(t), ar))
+	}
+	test.AssertEqual(t, 3, calls, "unexpected number of hook calls")
+
+	// Decisions are not reused for other engines.
+	other := *testAdmissionReq
+	other.UUID = test.MockUUID(2)
+	hookErr = nil
+	test.CmpErr(t, nil, ja.admit(test.Context(t), &other))
+	test.AssertEqual(t, 4, calls, "unexpected number of hook calls")
+
+	// Expired decisions are discarded.
+	for _, d := range ja.decisions {
+		d.expires = time.Now().Add(-time.Second)
+	}
+	test.CmpErr(t, nil, ja.admit(test.Context(t), testAdmissionReq))
+	test.AssertEqual(t, 5, calls, "unexpected number of hook calls")
+}
+
+func TestServer_MgmtSvc_checkJoinAdmissions(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	const numJoins = 3
+	svc := newTestMgmtSvc(t, log)
+
+	// Each hook waits for all of the others to have been called, so
+	// the check only completes if they are run concurrently.
+	var started sync.WaitGroup
+	started.Add(numJoins)
+	svc.joinAdmitter = &joinAdmitter{
+		log: log,
+		cfg: (&config.JoinAdmission{}).WithDefaults(),
+		hook: func(ctx context.Context, doc []byte) error {
+			started.Done()
+			started.Wait()
+
+			var ar joinAdmissionReq
+			if err := json.Unmarshal(doc, &ar); err != nil {
+				return err
+			}
+			if ar.Rank == 1 {
+				return &admissionRejectedErr{reason: "unknown hardware"}
+			}
+			return nil
+		},
+	}
+
+	var joins []*pendingJoin
+	for i := 0; i < numJoins; i++ {
+		joins = append(joins, &pendingJoin{
+			msg: &mgmtpb.JoinReq{Rank: uint32(i), Uuid: test.MockUUID(int32(i))},
+		})
+	}
+
+	done := make(chan []error)
+	go func() {
+		done <- svc.checkJoinAdmissions(test.Context(t), joins)
+	}()
+
+	select {
+	case errs := <-done:
+		test.CmpErr(t, nil, errs[0])
+		test.CmpErr(t, errors.New("rank 1 join rejected by admission hook: unknown hardware"), errs[1])
+		test.CmpErr(t, nil, errs[2])
+	case <-time.After(5 * time.Second):
+		t.Fatal("admission hooks were not run concurrently")
+	}
+}
+
+func TestServer_httpAdmissionHook(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status int
+		body   string
+		expErr error
+	}{
+		"admitted": {
+			status: http.StatusOK,
+		},
+		"no content": {
+			status: http.StatusNoContent,
+		},
+		"rejected": {
+			status: http.StatusForbidden,
+			body:   "unknown hardware\n",
+			expErr: &admissionRejectedErr{reason: "unknown hardware"},
+		},
+		"server error": {
+			status: http.StatusInternalServerError,
+			expErr: errors.New("500 Internal Server Error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				test.AssertEqual(t, http.MethodPost, r.Method, "unexpected method")
+				test.AssertEqual(t, "application/json", r.Header.Get("Content-Type"), "unexpected content type")
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, `{"rank":1}`, string(body), "unexpected request body")
+
+				w.WriteHeader(tc.status)
+				if _, err := w.Write([]byte(tc.body)); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer srv.Close()
+
+			hook := httpAdmissionHook(srv.Client(), srv.URL)
+			gotErr := hook(test.Context(t), []byte(`{"rank":1}`))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.status == http.StatusForbidden && !isAdmissionRejected(gotErr) {
+				t.Fatalf("expected rejection, got %v", gotErr)
+			}
+		})
+	}
+}
+
+func TestServer_scriptAdmissionHook(t *testing.T) {
+	dir := t.TempDir()
+	writeScript := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"+content), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for name, tc := range map[string]struct {
+		path        string
+		timeout     time.Duration
+		expErr      error
+		expRejected bool
+	}{
+		"admitted": {
+			path: writeScript("admit.sh", `grep -q '"rank":1' || exit 1`),
+		},
+		"rejected": {
+			path:        writeScript("reject.sh", "cat >/dev/null\necho 'rank not in inventory'\nexit 2\n"),
+			expErr:      errors.New("rejected by admission hook: rank not in inventory"),
+			expRejected: true,
+		},
+		"missing script": {
+			path:   filepath.Join(dir, "missing.sh"),
+			expErr: errors.New("no such file"),
+		},
+		"timed out": {
+			path:    writeScript("slow.sh", "sleep 5\n"),
+			timeout: 10 * time.Millisecond,
+			expErr:  context.DeadlineExceeded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := test.Context(t)
+			if tc.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			gotErr := scriptAdmissionHook(tc.path)(ctx, []byte(`{"rank":1}`))
+			test.CmpErr(t, tc.expErr, gotErr)
+			test.AssertEqual(t, tc.expRejected, isAdmissionRejected(gotErr), "unexpected rejection")
+		})
+	}
+}
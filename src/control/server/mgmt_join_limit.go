@@ -0,0 +1,91 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// joinLimiter bounds the join work accepted by the MS leader. Requests beyond
+// the queue limit are rejected with a busy error carrying a retry hint, and
+// each batch processes a limited number of joins so that a mass restart is
+// spread over several raft applies and group updates rather than one.
+type joinLimiter struct {
+	sync.Mutex
+	maxPerBatch int
+	maxQueued   int
+	retryAfter  time.Duration
+	interval    time.Duration
+	queued      int
+}
+
+// newJoinLimiter returns a joinLimiter for the supplied parameters, or nil if
+// join rate limiting has been disabled. The interval is the period at which
+// batched requests are processed and is used to estimate queue drain time.
+func newJoinLimiter(cfg *config.JoinRateLimit, interval time.Duration) *joinLimiter {
+	if cfg != nil && cfg.Disabled {
+		return nil
+	}
+	cfg = cfg.WithDefaults()
+
+	return &joinLimiter{
+		maxPerBatch: cfg.MaxPerBatch,
+		maxQueued:   cfg.MaxQueued,
+		retryAfter:  cfg.RetryAfter,
+		interval:    interval,
+	}
+}
+
+// acquire reserves a queue slot for a join request. If the queue is full, a
+// busy error is returned, with a hint no shorter than the time needed to
+// drain the requests that are already queued.
+func (jl *joinLimiter) acquire() error {
+	if jl == nil {
+		return nil
+	}
+
+	jl.Lock()
+	defer jl.Unlock()
+
+	if jl.queued >= jl.maxQueued {
+		hint := time.Duration(jl.queued/jl.maxPerBatch+1) * jl.interval
+		if hint < jl.retryAfter {
+			hint = jl.retryAfter
+		}
+		return &system.ErrBusy{Operation: "join", RetryAfter: hint}
+	}
+	jl.queued++
+
+	return nil
+}
+
+// release frees a queue slot reserved by acquire.
+func (jl *joinLimiter) release() {
+	if jl == nil {
+		return
+	}
+
+	jl.Lock()
+	defer jl.Unlock()
+
+	if jl.queued > 0 {
+		jl.queued--
+	}
+}
+
+// split divides a batch into the requests to process now and those to be
+// deferred to a later batch.
+func (jl *joinLimiter) split(reqs []*batchRequest) (now, later []*batchRequest) {
+	if jl == nil || len(reqs) <= jl.maxPerBatch {
+		return reqs, nil
+	}
+	return reqs[:jl.maxPerBatch], reqs[jl.maxPerBatch:]
+}
@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_newJoinLimiter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg    *config.JoinRateLimit
+		expNil bool
+		expMax int
+	}{
+		"nil config uses defaults": {
+			expMax: config.DefaultJoinMaxPerBatch,
+		},
+		"disabled": {
+			cfg:    &config.JoinRateLimit{Disabled: true},
+			expNil: true,
+		},
+		"custom": {
+			cfg:    &config.JoinRateLimit{MaxPerBatch: 8},
+			expMax: 8,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			jl := newJoinLimiter(tc.cfg, time.Second)
+			if tc.expNil {
+				if jl != nil {
+					t.Fatal("expected nil limiter")
+				}
+				return
+			}
+			test.AssertEqual(t, tc.expMax, jl.maxPerBatch, "unexpected per-batch limit")
+		})
+	}
+}
+
+func TestServer_joinLimiter_acquire(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg      *config.JoinRateLimit
+		queued   int
+		expBusy  bool
+		expRetry time.Duration
+	}{
+		"slot available": {
+			cfg:    &config.JoinRateLimit{MaxPerBatch: 2, MaxQueued: 4},
+			queued: 3,
+		},
+		"queue full; configured hint": {
+			cfg:      &config.JoinRateLimit{MaxPerBatch: 2, MaxQueued: 4, RetryAfter: time.Minute},
+			queued:   4,
+			expBusy:  true,
+			expRetry: time.Minute,
+		},
+		"queue full; drain time exceeds hint": {
+			cfg:      &config.JoinRateLimit{MaxPerBatch: 2, MaxQueued: 4, RetryAfter: time.Second},
+			queued:   4,
+			expBusy:  true,
+			expRetry: 3 * time.Second,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			jl := newJoinLimiter(tc.cfg, time.Second)
+			jl.queued = tc.queued
+
+			err := jl.acquire()
+			if !tc.expBusy {
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, tc.queued+1, jl.queued, "slot not reserved")
+				jl.release()
+				test.AssertEqual(t, tc.queued, jl.queued, "slot not released")
+				return
+			}
+
+			busy, ok := err.(*system.ErrBusy)
+			if !ok {
+				t.Fatalf("expected busy error, got %v", err)
+			}
+			test.AssertEqual(t, tc.expRetry, busy.RetryAfter, "unexpected retry hint")
+			test.AssertEqual(t, tc.queued, jl.queued, "rejected request took a slot")
+		})
+	}
+}
+
+func TestServer_joinLimiter_nil(t *testing.T) {
+	var jl *joinLimiter
+
+	if err := jl.acquire(); err != nil {
+		t.Fatal(err)
+	}
+	jl.release()
+
+	reqs := make([]*batchRequest, 3)
+	now, later := jl.split(reqs)
+	test.AssertEqual(t, 3, len(now), "nil limiter should not split batch")
+	test.AssertEqual(t, 0, len(later), "nil limiter should not defer requests")
+}
+
+func TestServer_MgmtSvc_processBatchJoins_limited(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	svc.joinLimiter = newJoinLimiter(&config.JoinRateLimit{MaxPerBatch: 2}, svc.batchInterval)
+
+	// Requests from callers that have given up are dropped without a join.
+	abandoned, cancel := context.WithCancel(test.Context(t))
+	cancel()
+
+	var reqs []*batchRequest
+	for i := 0; i < 5; i++ {
+		reqs = append(reqs, &batchRequest{
+			msg:    &mgmtpb.JoinReq{Rank: uint32(i)},
+			ctx:    abandoned,
+			respCh: make(batchRespChan, 1),
+		})
+	}
+
+	deferred := svc.processBatchJoins(test.Context(t), nil, reqs)
+	if diff := cmp.Diff(reqs[2:], deferred, cmp.Comparer(func(a, b *batchRequest) bool {
+		return a == b
+	})); diff != "" {
+		t.Fatalf("unexpected deferred requests (-want, +got):\n%s\n", diff)
+	}
+
+	for i, req := range reqs[:2] {
+		select {
+		case resp := <-req.respCh:
+			t.Fatalf("unexpected response for abandoned request %d: %+v", i, resp)
+		default:
+		}
+	}
+}
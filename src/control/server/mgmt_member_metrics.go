@@ -0,0 +1,81 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+// memberLifecycleCollector exports the engine start and stop counts and the
+// uptime of each system member as prometheus metrics. The metrics are only
+// exported while this server is MS leader, as every replica holds the same
+// membership and dashboards would otherwise count each member once per
+// replica.
+type memberLifecycleCollector struct {
+	log    logging.Logger
+	sysdb  *raft.Database
+	now    func() time.Time
+	starts *prometheus.Desc
+	stops  *prometheus.Desc
+	uptime *prometheus.Desc
+}
+
+func newMemberLifecycleCollector(log logging.Logger, sysdb *raft.Database) *memberLifecycleCollector {
+	return &memberLifecycleCollector{
+		log:   log,
+		sysdb: sysdb,
+		now:   time.Now,
+		starts: prometheus.NewDesc("system_member_starts_total",
+			"Number of engine incarnations of the rank that have joined the system",
+			[]string{"rank"}, nil),
+		stops: prometheus.NewDesc("system_member_stops_total",
+			"Number of times the engine of the rank has stopped, by reason",
+			[]string{"rank", "reason"}, nil),
+		uptime: prometheus.NewDesc("system_member_uptime_seconds",
+			"Time since the current engine incarnation of the rank joined the system",
+			[]string{"rank"}, nil),
+	}
+}
+
+func (mlc *memberLifecycleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mlc.starts
+	ch <- mlc.stops
+	ch <- mlc.uptime
+}
+
+func (mlc *memberLifecycleCollector) Collect(ch chan<- prometheus.Metric) {
+	if err := mlc.sysdb.CheckLeader(); err != nil {
+		return
+	}
+
+	members, err := mlc.sysdb.AllMembers()
+	if err != nil {
+		mlc.log.Errorf("failed to get members for lifecycle metrics: %s", err)
+		return
+	}
+
+	now := mlc.now()
+	for _, m := range members {
+		rank := m.Rank.String()
+
+		ch <- prometheus.MustNewConstMetric(mlc.starts, prometheus.CounterValue, float64(m.Starts), rank)
+		for reason, count := range map[string]uint32{
+			"exited":  m.Exits,
+			"dead":    m.Deaths,
+			"stopped": m.Stops,
+		} {
+			ch <- prometheus.MustNewConstMetric(mlc.stops, prometheus.CounterValue, float64(count),
+				rank, reason)
+		}
+		ch <- prometheus.MustNewConstMetric(mlc.uptime, prometheus.GaugeValue, m.Uptime(now).Seconds(), rank)
+	}
+}
@@ -0,0 +1,109 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+func TestServer_memberLifecycleCollector(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		notLeader  bool
+		expMetrics map[string]float64
+	}{
+		"not leader": {
+			notLeader:  true,
+			expMetrics: map[string]float64{},
+		},
+		"leader": {
+			expMetrics: map[string]float64{
+				"system_member_starts_total/0":        3,
+				"system_member_stops_total/0/exited":  1,
+				"system_member_stops_total/0/dead":    1,
+				"system_member_stops_total/0/stopped": 0,
+				"system_member_uptime_seconds/0":      600,
+				"system_member_starts_total/1":        1,
+				"system_member_stops_total/1/exited":  0,
+				"system_member_stops_total/1/dead":    0,
+				"system_member_stops_total/1/stopped": 1,
+				"system_member_uptime_seconds/1":      0,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := raft.MockDatabase(t, log)
+
+			joined := system.MockMember(t, 0, system.MemberStateJoined)
+			joined.Starts = 3
+			joined.LastStart = now.Add(-10 * time.Minute)
+			joined.Exits = 1
+			joined.Deaths = 1
+			stopped := system.MockMember(t, 1, system.MemberStateStopped)
+			stopped.Starts = 1
+			stopped.LastStart = now.Add(-time.Hour)
+			stopped.Stops = 1
+			for _, m := range []*system.Member{joined, stopped} {
+				if err := db.AddMember(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.notLeader {
+				_ = db.ShutdownRaft()
+			}
+
+			mlc := newMemberLifecycleCollector(log, db)
+			mlc.now = func() time.Time { return now }
+
+			ch := make(chan prometheus.Metric, 100)
+			mlc.Collect(ch)
+			close(ch)
+
+			names := map[*prometheus.Desc]string{
+				mlc.starts: "system_member_starts_total",
+				mlc.stops:  "system_member_stops_total",
+				mlc.uptime: "system_member_uptime_seconds",
+			}
+			gotMetrics := make(map[string]float64)
+			for metric := range ch {
+				var m dto.Metric
+				if err := metric.Write(&m); err != nil {
+					t.Fatal(err)
+				}
+
+				// key each value by metric name and label values
+				key := names[metric.Desc()]
+				for _, lp := range m.Label {
+					key += "/" + lp.GetValue()
+				}
+				if m.Counter != nil {
+					gotMetrics[key] = m.Counter.GetValue()
+				} else {
+					gotMetrics[key] = m.Gauge.GetValue()
+				}
+			}
+
+			if diff := cmp.Diff(tc.expMetrics, gotMetrics); diff != "" {
+				t.Fatalf("unexpected metrics (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
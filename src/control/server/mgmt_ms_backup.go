@@ -0,0 +1,243 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/cron"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// msBackupPrefix is the prefix of the names of the MS backup directories.
+	msBackupPrefix = "msdb-"
+	// maxUploadOutputLen limits the amount of upload script output included
+	// in the error for a failed upload.
+	maxUploadOutputLen = 512
+)
+
+type (
+	msBackupUploadFn func(ctx context.Context, path string) error
+
+	// msBackupScheduler takes backups of the MS database on a cron
+	// schedule and keeps the most recent of them.
+	msBackupScheduler struct {
+		cfg      *config.MSBackup
+		schedule *cron.Schedule
+		upload   msBackupUploadFn
+	}
+)
+
+// newMSBackupScheduler returns an msBackupScheduler for the supplied
+// parameters, or nil if MS backups have not been configured.
+func newMSBackupScheduler(cfg *config.MSBackup) (*msBackupScheduler, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	schedule, err := cron.Parse(cfg.Schedule)
+	if err != nil {
+		return nil, config.FaultConfigBadMSBackup(err)
+	}
+
+	mbs := &msBackupScheduler{
+		cfg:      cfg.WithDefaults(),
+		schedule: schedule,
+	}
+	if mbs.cfg.UploadScript != "" {
+		mbs.upload = scriptBackupUpload(mbs.cfg.UploadScript)
+	}
+
+	return mbs, nil
+}
+
+// scriptBackupUpload runs the script with the path of the backup as its only
+// argument. Any exit status other than 0 fails the upload.
+func scriptBackupUpload(path string) msBackupUploadFn {
+	return func(ctx context.Context, backupPath string) error {
+		var out bytes.Buffer
+		cmd := exec.Command(path, backupPath)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		// Run the script in its own process group so that any children
+		// holding its output open are also killed on timeout.
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+		if err := cmd.Start(); err != nil {
+			return errors.Wrapf(err, "running %s", path)
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+
+		select {
+		case err := <-done:
+			if err == nil {
+				return nil
+			}
+			output := strings.TrimSpace(out.String())
+			if len(output) > maxUploadOutputLen {
+				output = output[:maxUploadOutputLen] + "..."
+			}
+			if output == "" {
+				return errors.Wrapf(err, "running %s", path)
+			}
+			return errors.Wrapf(err, "running %s: %s", path, output)
+		case <-ctx.Done():
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			<-done
+			return errors.Wrapf(ctx.Err(), "running %s", path)
+		}
+	}
+}
+
+// backupName returns the name of the directory holding the backup taken at
+// the supplied time. Names sort in the order in which the backups were taken.
+func backupName(sysName string, ts time.Time) string {
+	return fmt.Sprintf("%s%s-%s", msBackupPrefix, sysName, ts.UTC().Format("20060102T150405Z"))
+}
+
+// prune removes the oldest backups in the backup directory so that no more
+// than the configured number remain, and returns the paths of the removed
+// backups.
+func (mbs *msBackupScheduler) prune(sysName string) ([]string, error) {
+	entries, err := os.ReadDir(mbs.cfg.BackupDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	prefix := msBackupPrefix + sysName + "-"
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, prefix) || strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	sort.Strings(backups)
+
+	var removed []string
+	for len(backups) > mbs.cfg.Retain {
+		path := filepath.Join(mbs.cfg.BackupDir, backups[0])
+		if err := os.RemoveAll(path); err != nil {
+			return removed, errors.Wrapf(err, "removing %s", path)
+		}
+		removed = append(removed, path)
+		backups = backups[1:]
+	}
+
+	return removed, nil
+}
+
+// takeMSBackup writes a verified backup of the MS database to the backup
+// directory and uploads it, if configured.
+func (svc *mgmtSvc) takeMSBackup(ctx context.Context, now time.Time, status *system.MSBackupStatus) error {
+	mbs := svc.msBackupSched
+
+	if err := os.MkdirAll(mbs.cfg.BackupDir, 0700); err != nil {
+		return err
+	}
+	details, err := svc.sysdb.Backup(mbs.cfg.BackupDir, backupName(svc.sysdb.SystemName(), now))
+	if err != nil {
+		return err
+	}
+	status.Path = details.Path
+	status.Index = details.Metadata.Index
+
+	if mbs.upload == nil {
+		return nil
+	}
+	uploadCtx, cancel := context.WithTimeout(ctx, mbs.cfg.UploadTimeout)
+	defer cancel()
+	if err := mbs.upload(uploadCtx, details.Path); err != nil {
+		return errors.Wrap(err, "uploading backup")
+	}
+
+	return nil
+}
+
+// backupMS takes a scheduled backup of the MS database, records its status in
+// the database and removes the backups that are no longer retained.
+func (svc *mgmtSvc) backupMS(ctx context.Context, now time.Time) error {
+	status := &system.MSBackupStatus{Time: now}
+	if prev, err := svc.sysdb.MSBackupStatus(); err == nil && prev != nil {
+		status.LastSuccess = prev.LastSuccess
+	}
+
+	backupErr := svc.takeMSBackup(ctx, now, status)
+	if backupErr != nil {
+		status.Error = backupErr.Error()
+	} else {
+		status.LastSuccess = now
+		svc.log.Debugf("backed up MS database (index %d) to %s", status.Index, status.Path)
+	}
+	if err := svc.sysdb.SetMSBackupStatus(status); err != nil {
+		svc.log.Errorf("failed to record MS backup status: %s", err)
+	}
+	if backupErr != nil {
+		return backupErr
+	}
+
+	removed, err := svc.msBackupSched.prune(svc.sysdb.SystemName())
+	for _, path := range removed {
+		svc.log.Debugf("removed expired MS backup %s", path)
+	}
+	if err != nil {
+		svc.log.Errorf("failed to remove expired MS backups: %s", err)
+	}
+
+	return nil
+}
+
+// msBackupLoop takes backups of the MS database on the configured schedule.
+func (svc *mgmtSvc) msBackupLoop(parent context.Context) {
+	if svc.msBackupSched == nil {
+		return
+	}
+
+	next := svc.msBackupSched.schedule.Next(time.Now())
+	if next.IsZero() {
+		svc.log.Errorf("MS backup schedule %q never activates", svc.msBackupSched.schedule)
+		return
+	}
+	backupTimer := time.NewTimer(time.Until(next))
+	defer backupTimer.Stop()
+
+	svc.log.Debugf("starting msBackupLoop (next backup at %s)", next)
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped msBackupLoop")
+			return
+		case <-backupTimer.C:
+			if err := svc.backupMS(parent, time.Now()); err != nil {
+				svc.log.Errorf("failed to back up MS database: %s", err)
+			}
+
+			next = svc.msBackupSched.schedule.Next(time.Now())
+			if next.IsZero() {
+				return
+			}
+			backupTimer.Reset(time.Until(next))
+		}
+	}
+}
@@ -0,0 +1,172 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+func TestServer_msBackupScheduler_prune(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		numBackups int
+		retain     int
+		expRemoved int
+	}{
+		"fewer than retained": {
+			numBackups: 2,
+			retain:     3,
+		},
+		"oldest removed": {
+			numBackups: 5,
+			retain:     3,
+			expRemoved: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			var names []string
+			for i := 0; i < tc.numBackups; i++ {
+				name := backupName(build.DefaultSystemName, ts.Add(time.Duration(i)*24*time.Hour))
+				names = append(names, name)
+				if err := os.Mkdir(filepath.Join(tmpDir, name), 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Neither incomplete backups nor those of other systems are
+			// counted or removed.
+			others := []string{
+				backupName(build.DefaultSystemName, ts.Add(-time.Hour)) + ".tmp",
+				backupName("other", ts.Add(-time.Hour)),
+			}
+			for _, name := range others {
+				if err := os.Mkdir(filepath.Join(tmpDir, name), 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			mbs, err := newMSBackupScheduler(&config.MSBackup{
+				Schedule:  "@daily",
+				BackupDir: tmpDir,
+				Retain:    tc.retain,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			removed, err := mbs.prune(build.DefaultSystemName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expRemoved []string
+			for _, name := range names[:tc.expRemoved] {
+				expRemoved = append(expRemoved, filepath.Join(tmpDir, name))
+			}
+			if diff := cmp.Diff(expRemoved, removed); diff != "" {
+				t.Fatalf("unexpected removed backups (-want, +got):\n%s\n", diff)
+			}
+			for _, name := range append(names[tc.expRemoved:], others...) {
+				if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+					t.Fatalf("expected %s to remain: %s", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_backupMS(t *testing.T) {
+	for name, tc := range map[string]struct {
+		uploadErr error
+		expErr    error
+	}{
+		"success": {},
+		"upload failed": {
+			uploadErr: errors.New("remote unavailable"),
+			expErr:    errors.New("uploading backup: remote unavailable"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			tmpDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+			backupDir := filepath.Join(tmpDir, "backups")
+
+			svc := newTestMgmtSvc(t, log)
+			mbs, err := newMSBackupScheduler(&config.MSBackup{
+				Schedule:  "@daily",
+				BackupDir: backupDir,
+				Retain:    1,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var uploaded []string
+			mbs.upload = func(_ context.Context, path string) error {
+				uploaded = append(uploaded, path)
+				return tc.uploadErr
+			}
+			svc.msBackupSched = mbs
+
+			first := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+			second := first.Add(24 * time.Hour)
+			if err := svc.backupMS(test.Context(t), first); err != nil && tc.expErr == nil {
+				t.Fatal(err)
+			}
+			gotErr := svc.backupMS(test.Context(t), second)
+			test.CmpErr(t, tc.expErr, gotErr)
+
+			expPath := filepath.Join(backupDir, backupName(build.DefaultSystemName, second))
+			test.AssertEqual(t, expPath, uploaded[len(uploaded)-1], "unexpected uploaded backup")
+
+			// A backup that failed to upload is kept in the backup
+			// directory, but not counted as a success.
+			if _, err := raft.ReadSnapshotInfo(expPath); err != nil {
+				t.Fatal(err)
+			}
+
+			status, err := svc.sysdb.MSBackupStatus()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, second, status.Time, "unexpected backup time")
+			test.AssertEqual(t, expPath, status.Path, "unexpected backup path")
+			if tc.expErr != nil {
+				test.AssertTrue(t, status.Failed(), "expected failed backup status")
+				test.AssertTrue(t, status.LastSuccess.IsZero(), "expected no successful backup")
+				return
+			}
+			test.AssertFalse(t, status.Failed(), "unexpected failed backup status")
+			test.AssertEqual(t, second, status.LastSuccess, "unexpected last success")
+
+			// Only the most recent backup is retained.
+			entries, err := os.ReadDir(backupDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, 1, len(entries), "unexpected number of backups")
+		})
+	}
+}
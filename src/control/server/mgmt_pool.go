@@ -7,8 +7,11 @@
 package server
 
 import (
+	"fmt"
 	"math/rand"
+	"os/user"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -21,10 +24,12 @@ import (
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/system"
+	"github.com/daos-stack/daos/src/control/system/raft"
 )
 
 const (
@@ -122,6 +127,28 @@ func (svc *mgmtSvc) getPoolService(id string) (*system.PoolService, error) {
 	return ps, nil
 }
 
+// recordPoolActivity adds a record to the activity log of the pool. Failure
+// to record the activity is logged but otherwise ignored, as it should not
+// cause the operation that it describes to fail.
+func (svc *mgmtSvc) recordPoolActivity(id string, kind system.PoolActivityKind, format string, args ...interface{}) {
+	poolUUID, err := svc.resolvePoolID(id)
+	if err == nil {
+		err = svc.sysdb.AddPoolActivity(poolUUID, system.NewPoolActivity(kind, format, args...))
+	}
+	if err != nil {
+		svc.log.Errorf("failed to record %s activity for pool %s: %s", kind, id, err)
+	}
+}
+
+// poolTargetsString returns a description of the targets on a rank affected
+// by a pool operation.
+func poolTargetsString(rank uint32, tgtIdx []uint32) string {
+	if len(tgtIdx) == 0 {
+		return fmt.Sprintf("rank %d (all targets)", rank)
+	}
+	return fmt.Sprintf("rank %d targets %v", rank, tgtIdx)
+}
+
 // getPoolServiceRanks returns a slice of ranks designated as the
 // pool service hosts.
 func (svc *mgmtSvc) getPoolServiceRanks(ps *system.PoolService) ([]uint32, error) {
@@ -250,7 +277,9 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 		return nil, err
 	}
 
-	msg, err := svc.submitSerialRequest(ctx, req)
+	msg, err := svc.idempotent(ctx, req, func(ctx context.Context) (proto.Message, error) {
+		return svc.submitSerialRequest(ctx, req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +362,8 @@ func (svc *mgmtSvc) poolCreate(parent context.Context, req *mgmtpb.PoolCreateReq
 		return nil, FaultPoolNoLabel
 	}
 
-	allRanks, err := svc.sysdb.MemberRanks(system.AvailableMemberFilter)
+	// Quarantined ranks are not selected for new pool placements.
+	allRanks, err := svc.sysdb.MemberRanks(system.PlacementMemberFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -411,6 +441,8 @@ func (svc *mgmtSvc) poolCreate(parent context.Context, req *mgmtpb.PoolCreateReq
 
 	ps = system.NewPoolService(poolUUID, req.Tierbytes, ranklist.RanksFromUint32(req.GetRanks()))
 	ps.PoolLabel = poolLabel
+	ps.Owner = req.GetUser()
+	ps.CreatedAt = time.Now()
 	if err := svc.sysdb.AddPoolService(ctx, ps); err != nil {
 		return nil, err
 	}
@@ -487,6 +519,8 @@ func (svc *mgmtSvc) poolCreate(parent context.Context, req *mgmtpb.PoolCreateReq
 	if err := svc.sysdb.UpdatePoolService(ctx, ps); err != nil {
 		return nil, err
 	}
+	svc.recordPoolActivity(ps.PoolUUID.String(), system.PoolActivityCreate,
+		"created with label %q on ranks %s", ps.PoolLabel, ps.Storage.CreationRankStr)
 
 	return resp, nil
 }
@@ -652,11 +686,22 @@ func (svc *mgmtSvc) poolEvictConnections(ctx context.Context, req *mgmtpb.PoolDe
 }
 
 // PoolDestroy implements the method defined for the Management Service.
-func (svc *mgmtSvc) PoolDestroy(parent context.Context, req *mgmtpb.PoolDestroyReq) (*mgmtpb.PoolDestroyResp, error) {
+func (svc *mgmtSvc) PoolDestroy(ctx context.Context, req *mgmtpb.PoolDestroyReq) (*mgmtpb.PoolDestroyResp, error) {
 	if err := svc.checkLeaderRequest(req); err != nil {
 		return nil, err
 	}
 
+	msg, err := svc.idempotent(ctx, req, func(ctx context.Context) (proto.Message, error) {
+		return svc.poolDestroy(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*mgmtpb.PoolDestroyResp), nil
+}
+
+// poolDestroy handles the actual pool destroy request.
+func (svc *mgmtSvc) poolDestroy(parent context.Context, req *mgmtpb.PoolDestroyReq) (*mgmtpb.PoolDestroyResp, error) {
 	poolUUID, err := svc.resolvePoolID(req.Id)
 	if err != nil {
 		return nil, err
@@ -825,6 +870,11 @@ func (svc *mgmtSvc) PoolExclude(ctx context.Context, req *mgmtpb.PoolExcludeReq)
 		return nil, errors.Wrap(err, "unmarshal PoolExclude response")
 	}
 
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(req.GetId(), system.PoolActivityExclude, "excluded %s",
+			poolTargetsString(req.GetRank(), req.GetTargetidx()))
+	}
+
 	return resp, nil
 }
 
@@ -834,14 +884,30 @@ func (svc *mgmtSvc) PoolDrain(ctx context.Context, req *mgmtpb.PoolDrainReq) (*m
 		return nil, err
 	}
 
-	dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolDrain, req)
+	poolUUID, err := svc.resolvePoolID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &mgmtpb.PoolDrainResp{}
-	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
-		return nil, errors.Wrap(err, "unmarshal PoolDrain response")
+	err = svc.schedulePoolOp(ctx, poolOpDrain, poolUUID, []ranklist.Rank{ranklist.Rank(req.GetRank())},
+		func() (daos.Status, error) {
+			dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolDrain, req)
+			if err != nil {
+				return daos.MiscError, err
+			}
+			if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+				return daos.MiscError, errors.Wrap(err, "unmarshal PoolDrain response")
+			}
+			return daos.Status(resp.GetStatus()), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(req.GetId(), system.PoolActivityDrain, "drained %s",
+			poolTargetsString(req.GetRank(), req.GetTargetidx()))
 	}
 
 	return resp, nil
@@ -853,6 +919,21 @@ func (svc *mgmtSvc) PoolExtend(ctx context.Context, req *mgmtpb.PoolExtendReq) (
 		return nil, err
 	}
 
+	// Quarantined ranks are not selected for new pool placements.
+	var quarantined []ranklist.Rank
+	for _, r := range ranklist.RanksFromUint32(req.GetRanks()) {
+		m, err := svc.membership.Get(r)
+		if err != nil {
+			return nil, err
+		}
+		if m.State == system.MemberStateQuarantined {
+			quarantined = append(quarantined, r)
+		}
+	}
+	if len(quarantined) > 0 {
+		return nil, FaultPoolInvalidRanks(quarantined)
+	}
+
 	// the IO engine needs the domain tree for placement purposes
 	fdTree, err := svc.membership.CompressedFaultDomainTree(req.Ranks...)
 	if err != nil {
@@ -870,14 +951,25 @@ func (svc *mgmtSvc) PoolExtend(ctx context.Context, req *mgmtpb.PoolExtendReq) (
 
 	svc.log.Debugf("MgmtSvc.PoolExtend forwarding modified req:%+v\n", req)
 
-	dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolExtend, req)
+	resp := &mgmtpb.PoolExtendResp{}
+	err = svc.schedulePoolOp(ctx, poolOpExtend, ps.PoolUUID, ranklist.RanksFromUint32(req.GetRanks()),
+		func() (daos.Status, error) {
+			dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolExtend, req)
+			if err != nil {
+				return daos.MiscError, err
+			}
+			if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+				return daos.MiscError, errors.Wrap(err, "unmarshal PoolExtend response")
+			}
+			return daos.Status(resp.GetStatus()), nil
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &mgmtpb.PoolExtendResp{}
-	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
-		return nil, errors.Wrap(err, "unmarshal PoolExtend response")
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(req.GetId(), system.PoolActivityExtend, "extended to ranks %s",
+			ranklist.RankSetFromRanks(ranklist.RanksFromUint32(req.GetRanks())))
 	}
 
 	return resp, nil
@@ -910,14 +1002,25 @@ func (svc *mgmtSvc) PoolReintegrate(ctx context.Context, req *mgmtpb.PoolReinteg
 
 	req.Tierbytes = ps.Storage.PerRankTierStorage
 
-	dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolReintegrate, req)
+	resp := &mgmtpb.PoolReintegrateResp{}
+	err = svc.schedulePoolOp(ctx, poolOpReintegrate, ps.PoolUUID, []ranklist.Rank{r},
+		func() (daos.Status, error) {
+			dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolReintegrate, req)
+			if err != nil {
+				return daos.MiscError, err
+			}
+			if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+				return daos.MiscError, errors.Wrap(err, "unmarshal PoolReintegrate response")
+			}
+			return daos.Status(resp.GetStatus()), nil
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &mgmtpb.PoolReintegrateResp{}
-	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
-		return nil, errors.Wrap(err, "unmarshal PoolReintegrate response")
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(req.GetId(), system.PoolActivityReintegrate, "reintegrated %s",
+			poolTargetsString(req.GetRank(), req.GetTargetidx()))
 	}
 
 	return resp, nil
@@ -984,6 +1087,10 @@ func (svc *mgmtSvc) PoolUpgrade(ctx context.Context, req *mgmtpb.PoolUpgradeReq)
 		return nil, errors.Wrap(err, "unmarshal PoolUpgrade response")
 	}
 
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(req.GetId(), system.PoolActivityUpgrade, "upgrade started")
+	}
+
 	return resp, nil
 }
 
@@ -997,23 +1104,25 @@ func (svc *mgmtSvc) updatePoolLabel(ctx context.Context, sys string, uuid uuid.U
 	if err != nil {
 		return err
 	}
+	oldLabel := ps.PoolLabel
 
-	if label != "" {
-		// If we're setting a label, first check to see
-		// if a pool has already had the label applied.
-		found, err := svc.sysdb.FindPoolServiceByLabel(label)
-		if found != nil && found.PoolUUID != ps.PoolUUID {
-			// If we find a pool with this label but the
-			// UUID differs, then we should fail the request.
+	// Reserve the new label in the MS DB first. The rename is validated
+	// and applied atomically by the raft FSM, so that concurrent requests
+	// cannot claim the same label for different pools.
+	if err := svc.sysdb.RenamePoolService(ctx, uuid, label); err != nil {
+		if system.IsPoolLabelExists(err) {
 			return FaultPoolDuplicateLabel(label)
 		}
-		if err != nil && !system.IsPoolNotFound(err) {
-			// If the query failed, then we should fail
-			// the request.
-			return err
+		return err
+	}
+
+	// If the engine fails to apply the label, restore the previous
+	// label so that the MS DB remains consistent with the pool.
+	rollback := func(cause error) error {
+		if err := svc.sysdb.RenamePoolService(ctx, uuid, oldLabel); err != nil {
+			svc.log.Errorf("pool %s: failed to restore label %q: %s", uuid, oldLabel, err)
 		}
-		// Otherwise, allow the label to be set again on the same
-		// pool for idempotency.
+		return cause
 	}
 
 	req := &mgmtpb.PoolSetPropReq{
@@ -1025,22 +1134,19 @@ func (svc *mgmtSvc) updatePoolLabel(ctx context.Context, sys string, uuid uuid.U
 	var dresp *drpc.Response
 	dresp, err = svc.makePoolServiceCall(ctx, drpc.MethodPoolSetProp, req)
 	if err != nil {
-		return err
+		return rollback(err)
 	}
 
 	resp := new(mgmtpb.PoolSetPropResp)
 	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
-		return errors.Wrap(err, "unmarshal PoolSetProp response")
+		return rollback(errors.Wrap(err, "unmarshal PoolSetProp response"))
 	}
 
 	if resp.GetStatus() != 0 {
-		return errors.Errorf("label update failed: %s", drpc.Status(resp.Status))
+		return rollback(errors.Errorf("label update failed: %s", drpc.Status(resp.Status)))
 	}
 
-	// Persist the label update in the MS DB if the
-	// dRPC call succeeded.
-	ps.PoolLabel = label
-	return svc.sysdb.UpdatePoolService(ctx, ps)
+	return nil
 }
 
 // PoolSetProp forwards a request to the I/O Engine to set pool properties.
@@ -1074,6 +1180,8 @@ func (svc *mgmtSvc) PoolSetProp(parent context.Context, req *mgmtpb.PoolSetPropR
 			if err := svc.updatePoolLabel(ctx, req.GetSys(), poolUUID, prop); err != nil {
 				return nil, err
 			}
+			svc.recordPoolActivity(poolUUID.String(), system.PoolActivitySetProp,
+				"label set to %q", prop.GetStrval())
 			continue
 		}
 
@@ -1097,6 +1205,66 @@ func (svc *mgmtSvc) PoolSetProp(parent context.Context, req *mgmtpb.PoolSetPropR
 		return nil, errors.Wrap(err, "unmarshal PoolSetProp response")
 	}
 
+	if resp.GetStatus() == 0 {
+		svc.recordPoolActivity(poolUUID.String(), system.PoolActivitySetProp,
+			"properties set: %s", poolPropNames(miscProps))
+	}
+
+	return resp, nil
+}
+
+// poolPropNames returns a comma-separated list of the names of the supplied
+// pool properties.
+func poolPropNames(props []*mgmtpb.PoolProperty) string {
+	numNames := make(map[uint32]string)
+	for name, hdlr := range daos.PoolProperties() {
+		numNames[hdlr.GetProperty(name).Number] = name
+	}
+
+	names := make([]string, 0, len(props))
+	for _, prop := range props {
+		name, found := numNames[prop.GetNumber()]
+		if !found {
+			name = fmt.Sprintf("prop-%d", prop.GetNumber())
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+// PoolActivity returns the activity log of a pool recorded in the MS database.
+func (svc *mgmtSvc) PoolActivity(ctx context.Context, req *mgmtpb.PoolActivityReq) (*mgmtpb.PoolActivityResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	poolUUID, err := svc.resolvePoolID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := svc.sysdb.FindPoolServiceByUUID(poolUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := svc.sysdb.PoolActivity(poolUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &mgmtpb.PoolActivityResp{
+		PoolUuid:  ps.PoolUUID.String(),
+		PoolLabel: ps.PoolLabel,
+	}
+	for _, pa := range records {
+		resp.Records = append(resp.Records, &mgmtpb.PoolActivityRecord{
+			Timestamp: pa.Timestamp.UnixNano(),
+			Kind:      string(pa.Kind),
+			Msg:       pa.Msg,
+		})
+	}
+
 	return resp, nil
 }
 
@@ -1149,11 +1317,60 @@ func (svc *mgmtSvc) PoolGetACL(ctx context.Context, req *mgmtpb.GetACLReq) (*mgm
 	return resp, nil
 }
 
+// lookupACLPrincipal checks that the user or group principal in the ACE exists
+// in the local directory. Special principals and principals qualified with a
+// domain are not checked.
+func lookupACLPrincipal(ace *control.AccessControlEntry) error {
+	if ace.IsSpecial() || !strings.HasSuffix(ace.Principal, "@") {
+		return nil
+	}
+
+	name := strings.TrimSuffix(ace.Principal, "@")
+	if ace.IsGroup() {
+		if _, err := user.LookupGroup(name); err != nil {
+			return errors.Wrapf(err, "ACE %q", ace)
+		}
+		return nil
+	}
+	if _, err := user.Lookup(name); err != nil {
+		return errors.Wrapf(err, "ACE %q", ace)
+	}
+
+	return nil
+}
+
+// validatePoolACL checks the syntax of the ACL entries in a modify request and
+// that they don't conflict. If enabled, the principals are also looked up.
+func (svc *mgmtSvc) validatePoolACL(entries []string) error {
+	acl := &control.AccessControlList{Entries: entries}
+	if err := acl.Validate(); err != nil {
+		return FaultPoolInvalidACL(err)
+	}
+	if !svc.aclPrincipalCheck {
+		return nil
+	}
+
+	for _, str := range entries {
+		ace, err := control.ParseACE(str)
+		if err != nil {
+			return FaultPoolInvalidACL(err)
+		}
+		if err := lookupACLPrincipal(ace); err != nil {
+			return FaultPoolInvalidACL(err)
+		}
+	}
+
+	return nil
+}
+
 // PoolOverwriteACL forwards a request to the I/O Engine to overwrite a pool's Access Control List
 func (svc *mgmtSvc) PoolOverwriteACL(ctx context.Context, req *mgmtpb.ModifyACLReq) (*mgmtpb.ACLResp, error) {
 	if err := svc.checkLeaderRequest(req); err != nil {
 		return nil, err
 	}
+	if err := svc.validatePoolACL(req.GetEntries()); err != nil {
+		return nil, err
+	}
 
 	dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolOverwriteACL, req)
 	if err != nil {
@@ -1174,6 +1391,9 @@ func (svc *mgmtSvc) PoolUpdateACL(ctx context.Context, req *mgmtpb.ModifyACLReq)
 	if err := svc.checkLeaderRequest(req); err != nil {
 		return nil, err
 	}
+	if err := svc.validatePoolACL(req.GetEntries()); err != nil {
+		return nil, err
+	}
 
 	dresp, err := svc.makeLockedPoolServiceCall(ctx, drpc.MethodPoolUpdateACL, req)
 	if err != nil {
@@ -1214,7 +1434,17 @@ func (svc *mgmtSvc) ListPools(ctx context.Context, req *mgmtpb.ListPoolsReq) (*m
 		return nil, err
 	}
 
-	psList, err := svc.sysdb.PoolServiceList(true)
+	filter := &raft.PoolServiceFilter{
+		Owner: req.GetOwner(),
+	}
+	if req.GetCreatedBefore() != 0 {
+		filter.CreatedBefore = time.Unix(req.GetCreatedBefore(), 0)
+	}
+	if req.GetCreatedAfter() != 0 {
+		filter.CreatedAfter = time.Unix(req.GetCreatedAfter(), 0)
+	}
+
+	psList, err := svc.sysdb.FilterPoolServices(true, filter)
 	if err != nil {
 		return nil, err
 	}
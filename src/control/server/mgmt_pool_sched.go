@@ -0,0 +1,474 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// poolOpQueryTimeout bounds each pool query made while checking whether the
+// rebuild of a scheduled pool operation has completed.
+const poolOpQueryTimeout = 30 * time.Second
+
+// poolOpKind identifies the type of a scheduled pool operation.
+type poolOpKind string
+
+// poolOpKind values.
+const (
+	poolOpRebuild     poolOpKind = "rebuild"
+	poolOpReintegrate poolOpKind = "reintegrate"
+	poolOpDrain       poolOpKind = "drain"
+	poolOpExtend      poolOpKind = "extend"
+)
+
+// priority returns the priority of the operation, higher priority operations
+// being started first. Reintegration restores redundancy and capacity, so it
+// is preferred over drains, which are in turn preferred over extensions.
+// Rebuilds started by the engines are never queued.
+func (k poolOpKind) priority() int {
+	switch k {
+	case poolOpRebuild:
+		return 3
+	case poolOpReintegrate:
+		return 2
+	case poolOpDrain:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// poolOpState identifies the stage of a scheduled pool operation.
+type poolOpState string
+
+// poolOpState values.
+const (
+	poolOpQueued     poolOpState = "queued"
+	poolOpRunning    poolOpState = "running"
+	poolOpRebuilding poolOpState = "rebuilding"
+)
+
+type (
+	// poolOpJob is a rebuild-generating pool operation held by the
+	// scheduler.
+	poolOpJob struct {
+		id        uint64
+		kind      poolOpKind
+		poolUUID  uuid.UUID
+		ranks     []ranklist.Rank
+		priority  int
+		state     poolOpState
+		submitted time.Time
+		started   time.Time
+		rebuildAt time.Time
+		admitted  chan struct{}
+	}
+
+	// poolOpScheduler limits the number of rebuild-generating pool
+	// operations that run at the same time, both system-wide and for each
+	// rank. Operations over the limits are queued in priority order and an
+	// operation keeps its place until the rebuild that it started has
+	// finished.
+	poolOpScheduler struct {
+		sync.Mutex
+		maxConcurrent int
+		maxPerRank    int
+		pollInterval  time.Duration
+		nextID        uint64
+		queue         []*poolOpJob
+		active        map[uint64]*poolOpJob
+	}
+)
+
+// newPoolOpScheduler returns a poolOpScheduler with the supplied limits, or
+// nil if the scheduler has not been enabled.
+func newPoolOpScheduler(cfg *config.PoolOpScheduler) *poolOpScheduler {
+	if cfg == nil {
+		return nil
+	}
+	cfg = cfg.WithDefaults()
+
+	return &poolOpScheduler{
+		maxConcurrent: cfg.MaxConcurrent,
+		maxPerRank:    cfg.MaxPerRank,
+		pollInterval:  cfg.PollInterval,
+		active:        make(map[uint64]*poolOpJob),
+	}
+}
+
+func (pos *poolOpScheduler) newJob(kind poolOpKind, poolUUID uuid.UUID, ranks []ranklist.Rank) *poolOpJob {
+	pos.nextID++
+	return &poolOpJob{
+		id:        pos.nextID,
+		kind:      kind,
+		poolUUID:  poolUUID,
+		ranks:     ranks,
+		priority:  kind.priority(),
+		state:     poolOpQueued,
+		submitted: time.Now(),
+		admitted:  make(chan struct{}),
+	}
+}
+
+// submit queues an operation and starts any queued operations that fit
+// within the limits.
+func (pos *poolOpScheduler) submit(kind poolOpKind, poolUUID uuid.UUID, ranks []ranklist.Rank) *poolOpJob {
+	pos.Lock()
+	defer pos.Unlock()
+
+	job := pos.newJob(kind, poolUUID, ranks)
+	pos.queue = append(pos.queue, job)
+	sort.SliceStable(pos.queue, func(i, j int) bool {
+		return pos.queue[i].priority > pos.queue[j].priority
+	})
+	pos.dispatch()
+
+	return job
+}
+
+// fits returns true if the operation can be started without exceeding the
+// limits given the currently active operations.
+func (pos *poolOpScheduler) fits(job *poolOpJob, rankLoad map[ranklist.Rank]int) bool {
+	if pos.maxConcurrent > 0 && len(pos.active) >= pos.maxConcurrent {
+		return false
+	}
+	if pos.maxPerRank > 0 {
+		for _, r := range job.ranks {
+			if rankLoad[r] >= pos.maxPerRank {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dispatch starts queued operations in priority order for as long as they
+// fit within the limits. An operation that is held back by the per-rank limit
+// does not block lower priority operations on other ranks. Must be called
+// with the lock held.
+func (pos *poolOpScheduler) dispatch() {
+	rankLoad := make(map[ranklist.Rank]int)
+	for _, job := range pos.active {
+		for _, r := range job.ranks {
+			rankLoad[r]++
+		}
+	}
+
+	remaining := pos.queue[:0]
+	for _, job := range pos.queue {
+		if !pos.fits(job, rankLoad) {
+			remaining = append(remaining, job)
+			continue
+		}
+
+		job.state = poolOpRunning
+		job.started = time.Now()
+		pos.active[job.id] = job
+		for _, r := range job.ranks {
+			rankLoad[r]++
+		}
+		close(job.admitted)
+	}
+	for i := len(remaining); i < len(pos.queue); i++ {
+		pos.queue[i] = nil
+	}
+	pos.queue = remaining
+}
+
+// wait blocks until the operation has been started or the context is
+// canceled, in which case the operation is removed from the scheduler.
+func (pos *poolOpScheduler) wait(ctx context.Context, job *poolOpJob) error {
+	select {
+	case <-job.admitted:
+		return nil
+	case <-ctx.Done():
+		pos.finish(job)
+		return ctx.Err()
+	}
+}
+
+// finish removes the operation from the scheduler and starts any queued
+// operations that now fit within the limits.
+func (pos *poolOpScheduler) finish(job *poolOpJob) {
+	pos.Lock()
+	defer pos.Unlock()
+
+	pos.remove(job)
+}
+
+// remove removes the operation from the scheduler. Must be called with the
+// lock held.
+func (pos *poolOpScheduler) remove(job *poolOpJob) {
+	if _, found := pos.active[job.id]; found {
+		delete(pos.active, job.id)
+		pos.dispatch()
+		return
+	}
+
+	for i, queued := range pos.queue {
+		if queued.id == job.id {
+			pos.queue = append(pos.queue[:i], pos.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// rebuilding records that the operation has been accepted by the pool and
+// that it will keep its place until the resulting rebuild has finished.
+func (pos *poolOpScheduler) rebuilding(job *poolOpJob) {
+	pos.Lock()
+	defer pos.Unlock()
+
+	job.state = poolOpRebuilding
+	job.rebuildAt = time.Now()
+}
+
+// poolActive returns true if an operation on the pool has been started.
+// Must be called with the lock held.
+func (pos *poolOpScheduler) poolActive(poolUUID uuid.UUID) bool {
+	for _, job := range pos.active {
+		if job.poolUUID == poolUUID {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildDone removes all operations on the pool that are waiting for a
+// rebuild to finish.
+func (pos *poolOpScheduler) rebuildDone(poolUUID uuid.UUID) {
+	pos.Lock()
+	defer pos.Unlock()
+
+	for _, job := range pos.active {
+		if job.poolUUID == poolUUID && job.state == poolOpRebuilding {
+			delete(pos.active, job.id)
+		}
+	}
+	pos.dispatch()
+}
+
+// OnEvent tracks pool rebuilds so that rebuilds started by the engines, e.g.
+// after a rank has been excluded, count against the system-wide limit and
+// operations are released as soon as the rebuilds that they started finish.
+func (pos *poolOpScheduler) OnEvent(_ context.Context, evt *events.RASEvent) {
+	if evt == nil {
+		return
+	}
+
+	switch evt.ID {
+	case events.RASPoolRebuildStart, events.RASPoolRebuildEnd, events.RASPoolRebuildFailed:
+	default:
+		return
+	}
+
+	poolUUID, err := uuid.Parse(evt.PoolUUID)
+	if err != nil {
+		return
+	}
+
+	if evt.ID != events.RASPoolRebuildStart {
+		pos.rebuildDone(poolUUID)
+		return
+	}
+
+	pos.Lock()
+	defer pos.Unlock()
+
+	if pos.poolActive(poolUUID) {
+		return
+	}
+	job := pos.newJob(poolOpRebuild, poolUUID, nil)
+	job.state = poolOpRebuilding
+	job.started = job.submitted
+	job.rebuildAt = job.submitted
+	pos.active[job.id] = job
+}
+
+// rebuildCandidates returns the operations that have been waiting for a
+// rebuild to finish for at least the poll interval.
+func (pos *poolOpScheduler) rebuildCandidates(now time.Time) []*poolOpJob {
+	pos.Lock()
+	defer pos.Unlock()
+
+	var out []*poolOpJob
+	for _, job := range pos.active {
+		if job.state == poolOpRebuilding && now.Sub(job.rebuildAt) >= pos.pollInterval {
+			out = append(out, job)
+		}
+	}
+	return out
+}
+
+// jobs returns copies of the operations held by the scheduler, active
+// operations in the order that they were started followed by queued
+// operations in the order that they will be considered.
+func (pos *poolOpScheduler) jobs() []*poolOpJob {
+	pos.Lock()
+	defer pos.Unlock()
+
+	out := make([]*poolOpJob, 0, len(pos.active)+len(pos.queue))
+	for _, job := range pos.active {
+		jc := *job
+		out = append(out, &jc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].started.Equal(out[j].started) {
+			return out[i].id < out[j].id
+		}
+		return out[i].started.Before(out[j].started)
+	})
+	for _, job := range pos.queue {
+		jc := *job
+		out = append(out, &jc)
+	}
+	return out
+}
+
+// schedulePoolOp runs the supplied pool operation once the scheduler, if
+// enabled, has admitted it. If the operation is accepted by the pool, it
+// keeps its place in the scheduler until the resulting rebuild has finished.
+func (svc *mgmtSvc) schedulePoolOp(ctx context.Context, kind poolOpKind, poolUUID uuid.UUID, ranks []ranklist.Rank, opFn func() (daos.Status, error)) error {
+	if svc.poolOpSched == nil {
+		_, err := opFn()
+		return err
+	}
+
+	job := svc.poolOpSched.submit(kind, poolUUID, ranks)
+	if err := svc.poolOpSched.wait(ctx, job); err != nil {
+		return errors.Wrapf(err, "%s of pool %s not started", kind, poolUUID)
+	}
+	svc.log.Debugf("pool %s: scheduled %s of ranks %v started", poolUUID, kind, ranks)
+
+	status, err := opFn()
+	if err != nil || status != daos.Success {
+		svc.poolOpSched.finish(job)
+		return err
+	}
+	svc.poolOpSched.rebuilding(job)
+
+	return nil
+}
+
+// poolRebuildBusy returns true if the pool reports that a rebuild is in
+// progress.
+func (svc *mgmtSvc) poolRebuildBusy(ctx context.Context, poolUUID uuid.UUID) (bool, error) {
+	qCtx, cancel := context.WithTimeout(ctx, poolOpQueryTimeout)
+	defer cancel()
+
+	resp, err := svc.PoolQuery(qCtx, &mgmtpb.PoolQueryReq{
+		Sys:       svc.sysdb.SystemName(),
+		Id:        poolUUID.String(),
+		QueryMask: uint64(daos.HealthOnlyPoolQueryMask),
+	})
+	if err == nil && resp.Status != int32(daos.Success) {
+		err = daos.Status(resp.Status)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetRebuild().GetState() == mgmtpb.PoolRebuildStatus_BUSY, nil
+}
+
+// checkPoolOpRebuilds releases the operations whose pools no longer report a
+// rebuild in progress, in case the rebuild end event was missed.
+func (svc *mgmtSvc) checkPoolOpRebuilds(ctx context.Context, now time.Time) {
+	checked := make(map[uuid.UUID]bool)
+	for _, job := range svc.poolOpSched.rebuildCandidates(now) {
+		if _, done := checked[job.poolUUID]; done {
+			continue
+		}
+
+		busy, err := svc.poolRebuildBusy(ctx, job.poolUUID)
+		if err != nil {
+			svc.log.Debugf("pool %s: unable to query rebuild state: %s", job.poolUUID, err)
+			if !system.IsPoolNotFound(err) {
+				continue
+			}
+		}
+		checked[job.poolUUID] = true
+
+		if !busy {
+			svc.log.Debugf("pool %s: rebuild finished; releasing scheduled operations", job.poolUUID)
+			svc.poolOpSched.rebuildDone(job.poolUUID)
+		}
+	}
+}
+
+// poolOpSchedulerLoop periodically releases scheduled pool operations whose
+// rebuilds have finished.
+func (svc *mgmtSvc) poolOpSchedulerLoop(parent context.Context) {
+	if svc.poolOpSched == nil {
+		return
+	}
+
+	pollTimer := time.NewTicker(svc.poolOpSched.pollInterval)
+	defer pollTimer.Stop()
+
+	svc.log.Debug("starting poolOpSchedulerLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped poolOpSchedulerLoop")
+			return
+		case <-pollTimer.C:
+			svc.checkPoolOpRebuilds(parent, time.Now())
+		}
+	}
+}
+
+// PoolOpJobs returns the pool operations queued or running under the control
+// of the pool operation scheduler.
+func (svc *mgmtSvc) PoolOpJobs(ctx context.Context, req *mgmtpb.PoolOpJobsReq) (*mgmtpb.PoolOpJobsResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	resp := new(mgmtpb.PoolOpJobsResp)
+	if svc.poolOpSched == nil {
+		return resp, nil
+	}
+
+	resp.Enabled = true
+	resp.MaxConcurrent = uint32(svc.poolOpSched.maxConcurrent)
+	resp.MaxPerRank = uint32(svc.poolOpSched.maxPerRank)
+
+	for _, job := range svc.poolOpSched.jobs() {
+		pbJob := &mgmtpb.PoolOpJob{
+			Id:        job.id,
+			Kind:      string(job.kind),
+			PoolUuid:  job.poolUUID.String(),
+			Ranks:     ranklist.RanksToUint32(job.ranks),
+			Priority:  int32(job.priority),
+			State:     string(job.state),
+			Submitted: job.submitted.UnixNano(),
+		}
+		if !job.started.IsZero() {
+			pbJob.Started = job.started.UnixNano()
+		}
+		if ps, err := svc.sysdb.FindPoolServiceByUUID(job.poolUUID); err == nil {
+			pbJob.PoolLabel = ps.PoolLabel
+		}
+		resp.Jobs = append(resp.Jobs, pbJob)
+	}
+
+	return resp, nil
+}
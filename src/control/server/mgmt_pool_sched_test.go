@@ -0,0 +1,237 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func isAdmitted(job *poolOpJob) bool {
+	select {
+	case <-job.admitted:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestServer_poolOpScheduler(t *testing.T) {
+	if pos := newPoolOpScheduler(nil); pos != nil {
+		t.Fatal("expected nil poolOpScheduler")
+	}
+
+	pos := newPoolOpScheduler(&config.PoolOpScheduler{MaxConcurrent: 2, MaxPerRank: 1})
+	test.AssertEqual(t, config.DefaultPoolOpPollInterval, pos.pollInterval, "default poll interval not applied")
+
+	pool1 := test.MockPoolUUID(1)
+	pool2 := test.MockPoolUUID(2)
+
+	ext := pos.submit(poolOpExtend, pool1, []ranklist.Rank{1})
+	test.AssertTrue(t, isAdmitted(ext), "first operation not started")
+
+	// Held back by the per-rank limit.
+	drain := pos.submit(poolOpDrain, pool2, []ranklist.Rank{1})
+	test.AssertFalse(t, isAdmitted(drain), "operation on busy rank started")
+
+	// Does not block lower priority operations on other ranks.
+	ext2 := pos.submit(poolOpExtend, pool2, []ranklist.Rank{2})
+	test.AssertTrue(t, isAdmitted(ext2), "operation on idle rank not started")
+
+	// Held back by the system-wide limit.
+	reint := pos.submit(poolOpReintegrate, pool2, []ranklist.Rank{3})
+	test.AssertFalse(t, isAdmitted(reint), "operation over system-wide limit started")
+
+	// Queued in priority order.
+	var gotKinds []poolOpKind
+	for _, job := range pos.jobs() {
+		gotKinds = append(gotKinds, job.kind)
+	}
+	if diff := cmp.Diff([]poolOpKind{poolOpExtend, poolOpExtend, poolOpReintegrate, poolOpDrain}, gotKinds); diff != "" {
+		t.Fatalf("unexpected jobs (-want, +got):\n%s\n", diff)
+	}
+
+	// The highest priority queued operation starts once a slot is released.
+	pos.rebuilding(ext)
+	pos.rebuildDone(pool1)
+	test.AssertTrue(t, isAdmitted(reint), "highest priority operation not started")
+	test.AssertFalse(t, isAdmitted(drain), "lower priority operation started")
+
+	// Canceled operations are removed from the queue.
+	ctx, cancel := context.WithCancel(test.Context(t))
+	cancel()
+	if err := pos.wait(ctx, drain); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	test.AssertEqual(t, 0, len(pos.queue), "canceled operation still queued")
+
+	pos.finish(ext2)
+	pos.finish(reint)
+	test.AssertEqual(t, 0, len(pos.jobs()), "operations not removed")
+}
+
+func TestServer_poolOpScheduler_OnEvent(t *testing.T) {
+	pool1 := test.MockPoolUUID(1)
+	pool2 := test.MockPoolUUID(2)
+	rebuildEvent := func(id events.RASID, poolUUID uuid.UUID) *events.RASEvent {
+		return &events.RASEvent{ID: id, Type: events.RASTypeInfoOnly, PoolUUID: poolUUID.String()}
+	}
+
+	pos := newPoolOpScheduler(&config.PoolOpScheduler{MaxConcurrent: 1})
+
+	// A rebuild started by the engines counts against the limit.
+	pos.OnEvent(test.Context(t), rebuildEvent(events.RASPoolRebuildStart, pool1))
+	reint := pos.submit(poolOpReintegrate, pool2, []ranklist.Rank{1})
+	test.AssertFalse(t, isAdmitted(reint), "operation started during rebuild")
+
+	// Events for other pools are ignored.
+	pos.OnEvent(test.Context(t), rebuildEvent(events.RASPoolRebuildEnd, pool2))
+	test.AssertFalse(t, isAdmitted(reint), "operation started during rebuild")
+
+	pos.OnEvent(test.Context(t), rebuildEvent(events.RASPoolRebuildFailed, pool1))
+	test.AssertTrue(t, isAdmitted(reint), "operation not started after rebuild")
+
+	// The rebuild started by a scheduled operation is not tracked separately.
+	pos.rebuilding(reint)
+	pos.OnEvent(test.Context(t), rebuildEvent(events.RASPoolRebuildStart, pool2))
+	test.AssertEqual(t, 1, len(pos.jobs()), "rebuild tracked separately")
+
+	pos.OnEvent(test.Context(t), rebuildEvent(events.RASPoolRebuildEnd, pool2))
+	test.AssertEqual(t, 0, len(pos.jobs()), "operation not released after rebuild")
+}
+
+func TestServer_MgmtSvc_checkPoolOpRebuilds(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rebuildState mgmtpb.PoolRebuildStatus_State
+		queryStatus  daos.Status
+		expJobs      int
+	}{
+		"rebuild busy": {
+			rebuildState: mgmtpb.PoolRebuildStatus_BUSY,
+			expJobs:      1,
+		},
+		"rebuild done": {
+			rebuildState: mgmtpb.PoolRebuildStatus_DONE,
+		},
+		"query fails": {
+			queryStatus: daos.TimedOut,
+			expJobs:     1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			poolUUID := uuid.MustParse(mockUUID)
+			addTestPoolService(t, svc.sysdb, &system.PoolService{
+				PoolUUID:  poolUUID,
+				PoolLabel: "test",
+				State:     system.PoolServiceStateReady,
+				Replicas:  []ranklist.Rank{0},
+				Storage:   &system.PoolServiceStorage{},
+			})
+
+			cfg := new(mockDrpcClientConfig)
+			cfg.setSendMsgResponseList(t, &mockDrpcResponse{
+				Message: &mgmtpb.PoolQueryResp{
+					Status:  int32(tc.queryStatus),
+					Rebuild: &mgmtpb.PoolRebuildStatus{State: tc.rebuildState},
+				},
+			})
+			setupSvcDrpcClient(svc, 0, newMockDrpcClient(cfg))
+
+			svc.poolOpSched = newPoolOpScheduler(&config.PoolOpScheduler{})
+			job := svc.poolOpSched.submit(poolOpReintegrate, poolUUID, []ranklist.Rank{1})
+			svc.poolOpSched.rebuilding(job)
+
+			// Not checked until the poll interval has passed.
+			svc.checkPoolOpRebuilds(test.Context(t), time.Now())
+			test.AssertEqual(t, 1, len(svc.poolOpSched.jobs()), "operation released early")
+
+			svc.checkPoolOpRebuilds(test.Context(t), time.Now().Add(svc.poolOpSched.pollInterval))
+			test.AssertEqual(t, tc.expJobs, len(svc.poolOpSched.jobs()), "unexpected number of operations")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_PoolOpJobs(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	poolUUID := uuid.MustParse(mockUUID)
+	addTestPoolService(t, svc.sysdb, &system.PoolService{
+		PoolUUID:  poolUUID,
+		PoolLabel: "test",
+		State:     system.PoolServiceStateReady,
+		Replicas:  []ranklist.Rank{0},
+		Storage:   &system.PoolServiceStorage{},
+	})
+	req := &mgmtpb.PoolOpJobsReq{Sys: build.DefaultSystemName}
+
+	resp, err := svc.PoolOpJobs(test.Context(t), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, resp.Enabled, "scheduler reported as enabled")
+
+	svc.poolOpSched = newPoolOpScheduler(&config.PoolOpScheduler{MaxConcurrent: 1, MaxPerRank: 1})
+	running := svc.poolOpSched.submit(poolOpReintegrate, poolUUID, []ranklist.Rank{1})
+	queued := svc.poolOpSched.submit(poolOpExtend, poolUUID, []ranklist.Rank{2, 3})
+
+	resp, err = svc.PoolOpJobs(test.Context(t), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expResp := &mgmtpb.PoolOpJobsResp{
+		Enabled:       true,
+		MaxConcurrent: 1,
+		MaxPerRank:    1,
+		Jobs: []*mgmtpb.PoolOpJob{
+			{
+				Id:        running.id,
+				Kind:      string(poolOpReintegrate),
+				PoolUuid:  mockUUID,
+				PoolLabel: "test",
+				Ranks:     []uint32{1},
+				Priority:  int32(poolOpReintegrate.priority()),
+				State:     string(poolOpRunning),
+				Submitted: running.submitted.UnixNano(),
+				Started:   running.started.UnixNano(),
+			},
+			{
+				Id:        queued.id,
+				Kind:      string(poolOpExtend),
+				PoolUuid:  mockUUID,
+				PoolLabel: "test",
+				Ranks:     []uint32{2, 3},
+				State:     string(poolOpQueued),
+				Submitted: queued.submitted.UnixNano(),
+			},
+		},
+	}
+	if diff := cmp.Diff(expResp, resp, protocmp.Transform()); diff != "" {
+		t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,186 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// minPoolSpaceSamples is the minimum number of usage samples within the trend
+// window from which the growth of a pool is projected.
+const minPoolSpaceSamples = 3
+
+type (
+	// poolSpaceState records the conditions last reported for a pool, so
+	// that each event is only raised when its condition starts.
+	poolSpaceState struct {
+		pressure bool
+		forecast bool
+	}
+
+	// poolSpaceForecaster evaluates the pool usage recorded in the health
+	// snapshots against the configured thresholds.
+	poolSpaceForecaster struct {
+		cfg    *config.PoolSpaceForecast
+		states map[uuid.UUID]*poolSpaceState
+	}
+)
+
+// newPoolSpaceForecaster returns a poolSpaceForecaster for the supplied
+// parameters, or nil if pool space forecasting has not been enabled.
+func newPoolSpaceForecaster(cfg *config.PoolSpaceForecast) *poolSpaceForecaster {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	return &poolSpaceForecaster{
+		cfg:    cfg.WithDefaults(),
+		states: make(map[uuid.UUID]*poolSpaceState),
+	}
+}
+
+func poolUsageKnown(ps *system.PoolHealthSummary) bool {
+	return ps.QueryError == "" && ps.TotalBytes > 0
+}
+
+func poolUsedPercent(ps *system.PoolHealthSummary) float64 {
+	return float64(ps.TotalBytes-ps.FreeBytes) / float64(ps.TotalBytes) * 100
+}
+
+// projectPoolFull returns the time until the pool is projected to be full.
+// The growth rate is the least squares fit of the space used in the pool over
+// the snapshots within the window ending with the latest sample. Returns false
+// if there are too few samples or the space used is not growing.
+func projectPoolFull(history []*system.HealthSnapshot, poolUUID uuid.UUID, window time.Duration) (time.Duration, bool) {
+	type sample struct {
+		ts   time.Time
+		used float64
+	}
+
+	var samples []sample
+	var latest *system.PoolHealthSummary
+	for _, hs := range history {
+		for _, ps := range hs.Pools {
+			if ps.PoolUUID != poolUUID || !poolUsageKnown(ps) {
+				continue
+			}
+			samples = append(samples, sample{hs.Timestamp, float64(ps.TotalBytes - ps.FreeBytes)})
+			latest = ps
+		}
+	}
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	start := samples[len(samples)-1].ts.Add(-window)
+	for len(samples) > 0 && samples[0].ts.Before(start) {
+		samples = samples[1:]
+	}
+	if len(samples) < minPoolSpaceSamples {
+		return 0, false
+	}
+
+	var meanX, meanY float64
+	for _, s := range samples {
+		meanX += s.ts.Sub(samples[0].ts).Seconds()
+		meanY += s.used
+	}
+	meanX /= float64(len(samples))
+	meanY /= float64(len(samples))
+
+	var covXY, varX float64
+	for _, s := range samples {
+		dx := s.ts.Sub(samples[0].ts).Seconds() - meanX
+		covXY += dx * (s.used - meanY)
+		varX += dx * dx
+	}
+	if varX == 0 {
+		return 0, false
+	}
+	bytesPerSec := covXY / varX
+	if bytesPerSec <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(latest.FreeBytes) / bytesPerSec * float64(time.Second)), true
+}
+
+// evaluate returns the events for the pools that have come under space
+// pressure or are newly projected to be full within the horizon, based on the
+// latest snapshot in the history.
+func (psf *poolSpaceForecaster) evaluate(history []*system.HealthSnapshot) []*events.RASEvent {
+	if len(history) == 0 {
+		return nil
+	}
+
+	var evts []*events.RASEvent
+	seen := make(map[uuid.UUID]bool)
+	for _, ps := range history[len(history)-1].Pools {
+		seen[ps.PoolUUID] = true
+		if !poolUsageKnown(ps) {
+			continue
+		}
+
+		st, found := psf.states[ps.PoolUUID]
+		if !found {
+			st = new(poolSpaceState)
+			psf.states[ps.PoolUUID] = st
+		}
+
+		usedPct := poolUsedPercent(ps)
+		pressure := usedPct >= float64(psf.cfg.WarnPercent)
+		if pressure && !st.pressure {
+			evts = append(evts, events.NewPoolSpacePressureEvent(ps.PoolUUID.String(),
+				healthReportPoolName(ps), usedPct, psf.cfg.WarnPercent))
+		}
+
+		fullIn, ok := projectPoolFull(history, ps.PoolUUID, psf.cfg.TrendWindow)
+		forecast := ok && fullIn <= psf.cfg.Horizon
+		if forecast && !st.forecast {
+			evts = append(evts, events.NewPoolSpaceForecastEvent(ps.PoolUUID.String(),
+				healthReportPoolName(ps), usedPct, fullIn))
+		}
+
+		st.pressure = pressure
+		st.forecast = forecast
+	}
+
+	// Forget pools that have been destroyed.
+	for id := range psf.states {
+		if !seen[id] {
+			delete(psf.states, id)
+		}
+	}
+
+	return evts
+}
+
+// forecastPoolSpace raises events for the pools that are under space pressure
+// or projected to be full, based on the recorded health snapshots.
+func (svc *mgmtSvc) forecastPoolSpace() error {
+	if svc.poolSpaceForecaster == nil {
+		return nil
+	}
+
+	history, err := svc.sysdb.HealthSnapshots(0)
+	if err != nil {
+		return err
+	}
+
+	for _, evt := range svc.poolSpaceForecaster.evaluate(history) {
+		svc.log.Notice(evt.Msg)
+		svc.events.Publish(evt)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+var (
+	testPoolSpaceStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	testPoolSpaceUUID  = uuid.MustParse(test.MockUUID(1))
+)
+
+// poolSpaceHistory returns hourly snapshots of a single pool of 1000 bytes with
+// the supplied numbers of bytes used.
+func poolSpaceHistory(used ...uint64) []*system.HealthSnapshot {
+	var history []*system.HealthSnapshot
+	for i, u := range used {
+		history = append(history, &system.HealthSnapshot{
+			Timestamp: testPoolSpaceStart.Add(time.Duration(i) * time.Hour),
+			Pools: []*system.PoolHealthSummary{
+				{
+					PoolUUID:   testPoolSpaceUUID,
+					PoolLabel:  "pool1",
+					TotalBytes: 1000,
+					FreeBytes:  1000 - u,
+				},
+			},
+		})
+	}
+	return history
+}
+
+func TestServer_projectPoolFull(t *testing.T) {
+	for name, tc := range map[string]struct {
+		history   []*system.HealthSnapshot
+		window    time.Duration
+		expFullIn time.Duration
+		expOK     bool
+	}{
+		"no history": {
+			window: 24 * time.Hour,
+		},
+		"too few samples": {
+			history: poolSpaceHistory(100, 200),
+			window:  24 * time.Hour,
+		},
+		"not growing": {
+			history: poolSpaceHistory(500, 500, 500),
+			window:  24 * time.Hour,
+		},
+		"shrinking": {
+			history: poolSpaceHistory(500, 400, 300),
+			window:  24 * time.Hour,
+		},
+		"steady growth": {
+			history:   poolSpaceHistory(100, 200, 300),
+			window:    24 * time.Hour,
+			expFullIn: 7 * time.Hour,
+			expOK:     true,
+		},
+		"older samples outside window": {
+			history:   poolSpaceHistory(600, 100, 110, 120, 130),
+			window:    3 * time.Hour,
+			expFullIn: 87 * time.Hour,
+			expOK:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fullIn, ok := projectPoolFull(tc.history, testPoolSpaceUUID, tc.window)
+
+			test.AssertEqual(t, tc.expOK, ok, "unexpected projection result")
+			test.AssertEqual(t, tc.expFullIn, fullIn.Round(time.Minute), "unexpected time until full")
+		})
+	}
+}
+
+func TestServer_poolSpaceForecaster_evaluate(t *testing.T) {
+	psf := newPoolSpaceForecaster(&config.PoolSpaceForecast{
+		Enabled:     true,
+		WarnPercent: 90,
+		Horizon:     24 * time.Hour,
+		TrendWindow: 24 * time.Hour,
+	})
+
+	evtIDs := func(evts []*events.RASEvent) []string {
+		var ids []string
+		for _, evt := range evts {
+			ids = append(ids, evt.ID.String())
+		}
+		return ids
+	}
+
+	for _, step := range []struct {
+		name    string
+		history []*system.HealthSnapshot
+		expIDs  []string
+	}{
+		{
+			name:    "slow growth",
+			history: poolSpaceHistory(100, 101, 102),
+		},
+		{
+			name:    "projected full within horizon",
+			history: poolSpaceHistory(100, 150, 200, 250),
+			expIDs:  []string{events.RASPoolSpaceForecast.String()},
+		},
+		{
+			name:    "forecast only raised once",
+			history: poolSpaceHistory(100, 150, 200, 250, 300),
+		},
+		{
+			name:    "above warning threshold",
+			history: poolSpaceHistory(750, 800, 850, 900),
+			expIDs:  []string{events.RASPoolSpacePressure.String()},
+		},
+		{
+			name:    "pool destroyed",
+			history: []*system.HealthSnapshot{{Timestamp: testPoolSpaceStart}},
+		},
+		{
+			name:    "state forgotten for destroyed pool",
+			history: poolSpaceHistory(750, 800, 850, 900),
+			expIDs: []string{
+				events.RASPoolSpacePressure.String(),
+				events.RASPoolSpaceForecast.String(),
+			},
+		},
+	} {
+		if diff := cmp.Diff(step.expIDs, evtIDs(psf.evaluate(step.history))); diff != "" {
+			t.Fatalf("%s: unexpected events (-want, +got):\n%s\n", step.name, diff)
+		}
+	}
+}
+
+func TestServer_newPoolSpaceForecaster(t *testing.T) {
+	if psf := newPoolSpaceForecaster(nil); psf != nil {
+		t.Fatal("expected nil forecaster without config")
+	}
+	if psf := newPoolSpaceForecaster(&config.PoolSpaceForecast{}); psf != nil {
+		t.Fatal("expected nil forecaster when disabled")
+	}
+
+	psf := newPoolSpaceForecaster(&config.PoolSpaceForecast{Enabled: true})
+	test.AssertEqual(t, config.DefaultPoolSpaceWarnPercent, psf.cfg.WarnPercent, "unexpected warn percent")
+	test.AssertEqual(t, config.DefaultPoolSpaceHorizon, psf.cfg.Horizon, "unexpected horizon")
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/daos-stack/daos/src/control/common/test"
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -1303,6 +1305,7 @@ func TestServer_MgmtSvc_PoolExtend(t *testing.T) {
 		mgmtSvc       *mgmtSvc
 		setupMockDrpc func(_ *mgmtSvc, _ error)
 		req           *mgmtpb.PoolExtendReq
+		quarantined   bool
 		expResp       *mgmtpb.PoolExtendResp
 		expErr        error
 	}{
@@ -1341,6 +1344,11 @@ func TestServer_MgmtSvc_PoolExtend(t *testing.T) {
 			req:    &mgmtpb.PoolExtendReq{Ranks: []uint32{1}},
 			expErr: errors.New("empty pool id"),
 		},
+		"quarantined rank": {
+			req:         &mgmtpb.PoolExtendReq{Id: mockUUID, Ranks: []uint32{1}},
+			quarantined: true,
+			expErr:      FaultPoolInvalidRanks([]ranklist.Rank{1}),
+		},
 		"successfully extended": {
 			req: &mgmtpb.PoolExtendReq{Id: mockUUID, Ranks: []uint32{1}},
 			expResp: &mgmtpb.PoolExtendResp{
@@ -1371,6 +1379,11 @@ func TestServer_MgmtSvc_PoolExtend(t *testing.T) {
 			if _, err := tc.mgmtSvc.membership.Add(system.MockMember(t, 1, system.MemberStateJoined)); err != nil {
 				t.Fatal(err)
 			}
+			if tc.quarantined {
+				if _, err := tc.mgmtSvc.membership.Quarantine(1, "suspect"); err != nil {
+					t.Fatal(err)
+				}
+			}
 
 			gotResp, gotErr := tc.mgmtSvc.PoolExtend(test.Context(t), tc.req)
 			test.CmpErr(t, tc.expErr, gotErr)
@@ -1908,6 +1921,79 @@ func TestPoolUpdateACL_Success(t *testing.T) {
 	}
 }
 
+func TestPoolUpdateACL_InvalidACL(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	addTestPools(t, svc.sysdb, mockUUID)
+	setupSvcDrpcClient(svc, 0, getMockDrpcClient(&mgmtpb.ACLResp{}, nil))
+
+	req := newTestModifyACLReq()
+	req.Entries = append(req.Entries, "A::OWNER@:r")
+
+	resp, err := svc.PoolUpdateACL(test.Context(t), req)
+
+	if resp != nil {
+		t.Errorf("Expected no response, got: %+v", resp)
+	}
+
+	test.CmpErr(t, errors.New("conflicting ACEs"), err)
+}
+
+func TestServer_MgmtSvc_validatePoolACL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		checkPrincipals bool
+		entries         []string
+		expErr          error
+	}{
+		"no entries": {
+			expErr: errors.New("no entries"),
+		},
+		"bad syntax": {
+			entries: []string{"A::OWNER@:rwx"},
+			expErr:  errors.New("invalid permission"),
+		},
+		"unknown user; not checked": {
+			entries: []string{"A::OWNER@:rw", "A::no-such-user-4a2f@:r"},
+		},
+		"unknown user": {
+			checkPrincipals: true,
+			entries:         []string{"A::OWNER@:rw", "A::no-such-user-4a2f@:r"},
+			expErr:          errors.New("no-such-user-4a2f"),
+		},
+		"unknown group": {
+			checkPrincipals: true,
+			entries:         []string{"A:G:no-such-group-4a2f@:r"},
+			expErr:          errors.New("no-such-group-4a2f"),
+		},
+		"known principals": {
+			checkPrincipals: true,
+			entries: []string{
+				"A::OWNER@:rw",
+				"A:G:GROUP@:r",
+				"A::root@:rw",
+				"A:G:root@:r",
+				"A::remote@example.com:r",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			svc.aclPrincipalCheck = tc.checkPrincipals
+
+			err := svc.validatePoolACL(tc.entries)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil && !fault.IsFaultCode(err, code.ServerPoolInvalidACL) {
+				t.Fatalf("expected invalid ACL fault, got %v", err)
+			}
+		})
+	}
+}
+
 func newTestDeleteACLReq() *mgmtpb.DeleteACLReq {
 	return &mgmtpb.DeleteACLReq{
 		Sys:       build.DefaultSystemName,
@@ -2432,3 +2518,88 @@ func TestServer_MgmtSvc_PoolUpgrade(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_MgmtSvc_PoolActivity(t *testing.T) {
+	testPoolService := &system.PoolService{
+		PoolUUID:  uuid.MustParse(mockUUID),
+		PoolLabel: "pool1",
+		State:     system.PoolServiceStateReady,
+		Replicas:  []ranklist.Rank{0},
+	}
+
+	for name, tc := range map[string]struct {
+		drainReq   *mgmtpb.PoolDrainReq
+		drainResp  *mgmtpb.PoolDrainResp
+		req        *mgmtpb.PoolActivityReq
+		expRecords []*mgmtpb.PoolActivityRecord
+		expErr     error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.PoolActivityReq{Id: mockUUID, Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"unknown pool": {
+			req:    &mgmtpb.PoolActivityReq{Id: "pool2"},
+			expErr: system.ErrPoolLabelNotFound("pool2"),
+		},
+		"no activity": {
+			req: &mgmtpb.PoolActivityReq{Id: "pool1"},
+		},
+		"drain recorded": {
+			drainReq:  &mgmtpb.PoolDrainReq{Id: mockUUID, Rank: 2, Targetidx: []uint32{1, 2}},
+			drainResp: &mgmtpb.PoolDrainResp{},
+			req:       &mgmtpb.PoolActivityReq{Id: "pool1"},
+			expRecords: []*mgmtpb.PoolActivityRecord{
+				{
+					Kind: string(system.PoolActivityDrain),
+					Msg:  "drained rank 2 targets [1 2]",
+				},
+			},
+		},
+		"failed drain not recorded": {
+			drainReq:  &mgmtpb.PoolDrainReq{Id: mockUUID, Rank: 2},
+			drainResp: &mgmtpb.PoolDrainResp{Status: int32(daos.Busy)},
+			req:       &mgmtpb.PoolActivityReq{Id: "pool1"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			addTestPoolService(t, svc.sysdb, testPoolService)
+
+			if tc.drainReq != nil {
+				setupSvcDrpcClient(svc, 0, getMockDrpcClient(tc.drainResp, nil))
+				tc.drainReq.Sys = build.DefaultSystemName
+				if _, err := svc.PoolDrain(test.Context(t), tc.drainReq); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tc.req != nil && tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.PoolActivity(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			expResp := &mgmtpb.PoolActivityResp{
+				PoolUuid:  mockUUID,
+				PoolLabel: "pool1",
+				Records:   tc.expRecords,
+			}
+			cmpOpts := append(test.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&mgmtpb.PoolActivityRecord{}, "timestamp"))
+			if diff := cmp.Diff(expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
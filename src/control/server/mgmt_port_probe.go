@@ -0,0 +1,78 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+const (
+	// maxPortProbeAddrs limits the number of connections that a single
+	// request may ask a replica to attempt.
+	maxPortProbeAddrs = 64
+	// portProbeDialTimeout bounds each connection attempt.
+	portProbeDialTimeout = 2 * time.Second
+)
+
+// PortProbe attempts a TCP connection to each of the requested addresses on
+// behalf of a starting server, so that the server can detect ports that are
+// blocked between it and the MS replicas. Results are returned in the order
+// of the requested addresses.
+func (svc *mgmtSvc) PortProbe(ctx context.Context, req *mgmtpb.PortProbeReq) (*mgmtpb.PortProbeResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+	if len(req.Addrs) > maxPortProbeAddrs {
+		return nil, errors.Errorf("too many addresses to probe (%d > %d)",
+			len(req.Addrs), maxPortProbeAddrs)
+	}
+
+	resp := &mgmtpb.PortProbeResp{
+		Results: make([]*mgmtpb.PortProbeResult, len(req.Addrs)),
+	}
+
+	var wg sync.WaitGroup
+	for i, addr := range req.Addrs {
+		result := &mgmtpb.PortProbeResult{Addr: addr}
+		resp.Results[i] = result
+
+		// A server that is not a replica doesn't know which of its
+		// addresses the replicas will see, so 0.0.0.0 is resolved to
+		// the address that the request came from.
+		tcpAddr, err := getPeerListenAddr(ctx, addr)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.Addr = tcpAddr.String()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			dialCtx, cancel := context.WithTimeout(ctx, portProbeDialTimeout)
+			defer cancel()
+
+			conn, err := new(net.Dialer).DialContext(dialCtx, "tcp", result.Addr)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			conn.Close()
+		}()
+	}
+	wg.Wait()
+
+	return resp, nil
+}
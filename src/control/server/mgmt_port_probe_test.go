@@ -0,0 +1,103 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_MgmtSvc_PortProbe(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	openPort := lis.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	for name, tc := range map[string]struct {
+		addrs      []string
+		expResults []*mgmtpb.PortProbeResult
+		expErr     error
+	}{
+		"too many addresses": {
+			addrs:  make([]string, maxPortProbeAddrs+1),
+			expErr: errors.New("too many addresses"),
+		},
+		"reachable": {
+			addrs: []string{fmt.Sprintf("127.0.0.1:%d", openPort)},
+			expResults: []*mgmtpb.PortProbeResult{
+				{Addr: fmt.Sprintf("127.0.0.1:%d", openPort)},
+			},
+		},
+		"unspecified address resolved to peer": {
+			addrs: []string{fmt.Sprintf("0.0.0.0:%d", openPort)},
+			expResults: []*mgmtpb.PortProbeResult{
+				{Addr: fmt.Sprintf("127.0.0.1:%d", openPort)},
+			},
+		},
+		"mixed results": {
+			addrs: []string{
+				"foo",
+				fmt.Sprintf("127.0.0.1:%d", closedPort),
+				fmt.Sprintf("127.0.0.1:%d", openPort),
+			},
+			expResults: []*mgmtpb.PortProbeResult{
+				{
+					Addr:  "foo",
+					Error: "get listening port: address foo: missing port in address",
+				},
+				{
+					Addr:  fmt.Sprintf("127.0.0.1:%d", closedPort),
+					Error: fmt.Sprintf("dial tcp 127.0.0.1:%d: connect: connection refused", closedPort),
+				},
+				{Addr: fmt.Sprintf("127.0.0.1:%d", openPort)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			ctx := peer.NewContext(test.Context(t), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 10001},
+			})
+
+			gotResp, gotErr := svc.PortProbe(ctx, &mgmtpb.PortProbeReq{
+				Sys:   build.DefaultSystemName,
+				Addrs: tc.addrs,
+			})
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResults, gotResp.Results, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/peer"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// provisioningKeyProp is the MS property holding the key used to sign
+	// provisioning tokens.
+	provisioningKeyProp = "provisioning_key"
+
+	// DefaultProvisioningTokenLifetime is the lifetime of a provisioning token
+	// if none is requested.
+	DefaultProvisioningTokenLifetime = 24 * time.Hour
+	// MaxProvisioningTokenLifetime is the longest lifetime that may be
+	// requested for a provisioning token.
+	MaxProvisioningTokenLifetime = 30 * 24 * time.Hour
+)
+
+// provisioningKey returns the key used to sign provisioning tokens. If no key
+// has been created yet and create is true, a new key is generated and stored
+// in the system database, otherwise nil is returned.
+func (svc *mgmtSvc) provisioningKey(create bool) ([]byte, error) {
+	svc.provisioningKeyLock.Lock()
+	defer svc.provisioningKeyLock.Unlock()
+
+	val, err := system.GetMgmtProperty(svc.sysdb, provisioningKeyProp)
+	if err != nil && !system.IsErrSystemAttrNotFound(err) {
+		return nil, err
+	}
+	if val != "" {
+		return hex.DecodeString(val)
+	}
+	if !create {
+		return nil, nil
+	}
+
+	key, err := security.NewProvisioningKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := system.SetMgmtProperty(svc.sysdb, provisioningKeyProp, hex.EncodeToString(key)); err != nil {
+		return nil, errors.Wrap(err, "storing provisioning key")
+	}
+	svc.log.Notice("created provisioning token signing key")
+
+	return key, nil
+}
+
+// SystemTokenCreate creates a signed provisioning token to be presented by
+// servers joining the system for the first time.
+func (svc *mgmtSvc) SystemTokenCreate(ctx context.Context, req *mgmtpb.SystemTokenCreateReq) (*mgmtpb.SystemTokenCreateResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	lifetime := time.Duration(req.GetLifetime()) * time.Second
+	if lifetime == 0 {
+		lifetime = DefaultProvisioningTokenLifetime
+	}
+	if lifetime > MaxProvisioningTokenLifetime {
+		return nil, errors.Errorf("token lifetime %s exceeds maximum of %s",
+			lifetime, MaxProvisioningTokenLifetime)
+	}
+
+	key, err := svc.provisioningKey(true)
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := security.NewProvisioningToken(svc.sysdb.SystemName(), time.Now(), lifetime)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := tok.Sign(key)
+	if err != nil {
+		return nil, err
+	}
+	svc.log.Noticef("created provisioning token %s (expires %s)", tok.ID, tok.Expires.Format(time.RFC3339))
+
+	return &mgmtpb.SystemTokenCreateResp{
+		Token:   encoded,
+		Id:      tok.ID,
+		Expires: tok.Expires.Unix(),
+	}, nil
+}
+
+// isTrustedJoinPeer returns true if the connection carrying a join request
+// came from this host or from another MS replica. Replicas are trusted to
+// join without a token so that the system can be bootstrapped before any
+// tokens have been created.
+func (svc *mgmtSvc) isTrustedJoinPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	tcpAddr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+
+	return common.IsLocalAddr(tcpAddr) || svc.sysdb.IsReplicaIP(tcpAddr.IP)
+}
+
+// isFirstJoin returns true if the joining engine is not a member of the
+// system or is joining from a different host than the one recorded for it.
+// The host is matched on its persistent host ID, so a server that has only
+// changed address is not joining for the first time. Members recorded
+// without a host ID, or servers joining without one, are matched on address.
+func (svc *mgmtSvc) isFirstJoin(req *mgmtpb.JoinReq, peerAddr *net.TCPAddr) (bool, error) {
+	id, err := uuid.Parse(req.Uuid)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid uuid %q", req.Uuid)
+	}
+
+	member, err := svc.sysdb.FindMemberByUUID(id)
+	switch {
+	case system.IsMemberNotFound(err):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+
+	if req.HostId != "" && member.HostID != uuid.Nil {
+		hostID, err := uuid.Parse(req.HostId)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid host ID %q", req.HostId)
+		}
+		return hostID != member.HostID, nil
+	}
+
+	return member.Addr == nil || peerAddr == nil || !member.Addr.IP.Equal(peerAddr.IP), nil
+}
+
+// checkProvisioningToken verifies the provisioning token presented by an
+// engine joining the system for the first time, if tokens are required.
+// A join failure event is published for an engine that is not allowed to
+// join.
+func (svc *mgmtSvc) checkProvisioningToken(reqCtx context.Context, req *mgmtpb.JoinReq, peerAddr *net.TCPAddr) error {
+	if !svc.requireProvisioningToken || svc.isTrustedJoinPeer(reqCtx) {
+		return nil
+	}
+
+	first, err := svc.isFirstJoin(req, peerAddr)
+	if err != nil || !first {
+		return err
+	}
+
+	reason := svc.verifyProvisioningToken(req.ProvisioningToken)
+	if reason == "" {
+		return nil
+	}
+
+	fault := security.FaultInvalidProvisioningToken(reason)
+	var host string
+	if peerAddr != nil {
+		host = peerAddr.String()
+	}
+	svc.log.Errorf("rejecting first join of engine %d from %s: %s", req.Idx, host, fault.Description)
+	svc.events.Publish(events.NewEngineJoinFailedEvent(host, req.Idx, req.Rank, fault.Description))
+
+	return fault
+}
+
+// verifyProvisioningToken returns the reason that the token is not valid, or
+// an empty string if it is.
+func (svc *mgmtSvc) verifyProvisioningToken(encoded string) string {
+	if encoded == "" {
+		return "no token presented"
+	}
+
+	key, err := svc.provisioningKey(false)
+	if err != nil {
+		return err.Error()
+	}
+	if key == nil {
+		return "no tokens have been created"
+	}
+
+	tok, err := security.ParseProvisioningToken(key, encoded)
+	if err != nil {
+		return err.Error()
+	}
+	if err := tok.Validate(svc.sysdb.SystemName(), time.Now()); err != nil {
+		return err.Error()
+	}
+	svc.log.Debugf("accepted provisioning token %s", tok.ID)
+
+	return ""
+}
@@ -0,0 +1,248 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/peer"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_SystemTokenCreate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *mgmtpb.SystemTokenCreateReq
+		expLifetime time.Duration
+		expErr      error
+	}{
+		"default lifetime": {
+			req:         &mgmtpb.SystemTokenCreateReq{},
+			expLifetime: DefaultProvisioningTokenLifetime,
+		},
+		"custom lifetime": {
+			req:         &mgmtpb.SystemTokenCreateReq{Lifetime: 3600},
+			expLifetime: time.Hour,
+		},
+		"lifetime too long": {
+			req:    &mgmtpb.SystemTokenCreateReq{Lifetime: uint64((MaxProvisioningTokenLifetime + time.Hour) / time.Second)},
+			expErr: errors.New("exceeds maximum"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			tc.req.Sys = build.DefaultSystemName
+
+			before := time.Now()
+			resp, err := svc.SystemTokenCreate(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			key, err := svc.provisioningKey(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tok, err := security.ParseProvisioningToken(key, resp.Token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, resp.Id, tok.ID, "unexpected token ID")
+			test.AssertEqual(t, resp.Expires, tok.Expires.Unix(), "unexpected expiry")
+			if err := tok.Validate(svc.sysdb.SystemName(), time.Now()); err != nil {
+				t.Fatal(err)
+			}
+			if tok.Expires.Before(before.Add(tc.expLifetime).Truncate(time.Second)) {
+				t.Fatalf("token expires too early: %s", tok.Expires)
+			}
+
+			// The signing key is created once and then reused.
+			if _, err := svc.SystemTokenCreate(test.Context(t), tc.req); err != nil {
+				t.Fatal(err)
+			}
+			key2, err := svc.provisioningKey(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, string(key), string(key2), "signing key changed")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_checkProvisioningToken(t *testing.T) {
+	remoteAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 10001}
+	memberUUID := test.MockUUID(1)
+	hostID := test.MockUUID(2)
+
+	for name, tc := range map[string]struct {
+		notRequired  bool
+		peerAddr     *net.TCPAddr
+		member       *net.TCPAddr
+		memberHostID string
+		reqHostID    string
+		token        func(t *testing.T, svc *mgmtSvc) string
+		expErr       error
+	}{
+		"tokens not required": {
+			notRequired: true,
+		},
+		"trusted local peer": {
+			peerAddr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 10001},
+		},
+		"rejoin from same address": {
+			member: remoteAddr,
+		},
+		"no token presented": {
+			expErr: errors.New("no token presented"),
+		},
+		"no tokens created": {
+			token: func(t *testing.T, _ *mgmtSvc) string {
+				return "daospt1.abc.def"
+			},
+			expErr: errors.New("no tokens have been created"),
+		},
+		"valid token": {
+			token: func(t *testing.T, svc *mgmtSvc) string {
+				resp, err := svc.SystemTokenCreate(test.Context(t), &mgmtpb.SystemTokenCreateReq{
+					Sys: build.DefaultSystemName,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return resp.Token
+			},
+		},
+		"valid token; rejoin from new address": {
+			member: &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 10001},
+			token: func(t *testing.T, svc *mgmtSvc) string {
+				resp, err := svc.SystemTokenCreate(test.Context(t), &mgmtpb.SystemTokenCreateReq{
+					Sys: build.DefaultSystemName,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return resp.Token
+			},
+		},
+		"rejoin from new address without token": {
+			member: &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 10001},
+			expErr: errors.New("no token presented"),
+		},
+		"rejoin from new address with same host ID": {
+			member:       &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 10001},
+			memberHostID: hostID,
+			reqHostID:    hostID,
+		},
+		"rejoin from same address with new host ID": {
+			member:       remoteAddr,
+			memberHostID: hostID,
+			reqHostID:    test.MockUUID(3),
+			expErr:       errors.New("no token presented"),
+		},
+		"rejoin with host ID from member without one": {
+			member:    remoteAddr,
+			reqHostID: hostID,
+		},
+		"invalid host ID": {
+			member:       remoteAddr,
+			memberHostID: hostID,
+			reqHostID:    "bad",
+			expErr:       errors.New("invalid host ID"),
+		},
+		"expired token": {
+			token: func(t *testing.T, svc *mgmtSvc) string {
+				key, err := svc.provisioningKey(true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tok, err := security.NewProvisioningToken(svc.sysdb.SystemName(), time.Now().Add(-2*time.Hour), time.Hour)
+				if err != nil {
+					t.Fatal(err)
+				}
+				encoded, err := tok.Sign(key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return encoded
+			},
+			expErr: errors.New("expired"),
+		},
+		"token signed with another key": {
+			token: func(t *testing.T, svc *mgmtSvc) string {
+				if _, err := svc.provisioningKey(true); err != nil {
+					t.Fatal(err)
+				}
+				key, err := security.NewProvisioningKey()
+				if err != nil {
+					t.Fatal(err)
+				}
+				tok, err := security.NewProvisioningToken(svc.sysdb.SystemName(), time.Now(), time.Hour)
+				if err != nil {
+					t.Fatal(err)
+				}
+				encoded, err := tok.Sign(key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return encoded
+			},
+			expErr: errors.New("signature does not match"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			svc.requireProvisioningToken = !tc.notRequired
+
+			if tc.member != nil {
+				m := system.MockMemberFullSpec(t, ranklist.Rank(1), memberUUID, "", tc.member,
+					system.MemberStateJoined)
+				if tc.memberHostID != "" {
+					m.HostID = uuid.MustParse(tc.memberHostID)
+				}
+				if err := svc.sysdb.AddMember(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := &mgmtpb.JoinReq{
+				Sys:    build.DefaultSystemName,
+				Uuid:   memberUUID,
+				Rank:   1,
+				HostId: tc.reqHostID,
+			}
+			if tc.token != nil {
+				req.ProvisioningToken = tc.token(t, svc)
+			}
+
+			if tc.peerAddr == nil {
+				tc.peerAddr = remoteAddr
+			}
+			reqCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: tc.peerAddr})
+
+			gotErr := svc.checkProvisioningToken(reqCtx, req, tc.peerAddr)
+			test.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
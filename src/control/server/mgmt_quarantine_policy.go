@@ -0,0 +1,99 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// rankQuarantiner is run on the MS leader and quarantines a rank once it has
+// raised the configured RAS events the threshold number of times within the
+// policy window. Quarantined ranks are excluded from new pool placements until
+// the quarantine is cleared by an administrator.
+type rankQuarantiner struct {
+	sync.Mutex
+	log        logging.Logger
+	cfg        *config.QuarantinePolicy
+	membership *system.Membership
+	events     map[string]struct{}
+	seen       map[ranklist.Rank][]time.Time
+	now        func() time.Time
+}
+
+func newRankQuarantiner(log logging.Logger, cfg *config.QuarantinePolicy, membership *system.Membership) *rankQuarantiner {
+	rq := &rankQuarantiner{
+		log:        log,
+		cfg:        cfg.WithDefaults(),
+		membership: membership,
+		events:     make(map[string]struct{}),
+		seen:       make(map[ranklist.Rank][]time.Time),
+		now:        time.Now,
+	}
+	for _, id := range rq.cfg.Events {
+		rq.events[id] = struct{}{}
+	}
+
+	return rq
+}
+
+// record adds an event for the rank and returns the number of events recorded
+// for the rank within the policy window.
+func (rq *rankQuarantiner) record(rank ranklist.Rank) int {
+	rq.Lock()
+	defer rq.Unlock()
+
+	now := rq.now()
+	var recent []time.Time
+	for _, ts := range rq.seen[rank] {
+		if now.Sub(ts) < rq.cfg.Window {
+			recent = append(recent, ts)
+		}
+	}
+	rq.seen[rank] = append(recent, now)
+
+	return len(rq.seen[rank])
+}
+
+// OnEvent implements the events.Handler interface. Events that don't identify
+// a rank are ignored.
+func (rq *rankQuarantiner) OnEvent(_ context.Context, evt *events.RASEvent) {
+	if evt == nil || ranklist.Rank(evt.Rank) == ranklist.NilRank {
+		return
+	}
+	if _, ok := rq.events[evt.ID.String()]; !ok {
+		return
+	}
+
+	rank := ranklist.Rank(evt.Rank)
+	count := rq.record(rank)
+	if count < rq.cfg.Threshold {
+		return
+	}
+
+	reason := fmt.Sprintf("quarantine policy: %d fault events within %s, last %s", count,
+		rq.cfg.Window, evt.ID)
+	if _, err := rq.membership.Quarantine(rank, reason); err != nil {
+		rq.log.Debugf("rank %d not quarantined after %s event: %s", rank, evt.ID, err)
+		return
+	}
+
+	rq.Lock()
+	delete(rq.seen, rank)
+	rq.Unlock()
+
+	rq.log.Noticef("rank %d quarantined after %d RAS events within %s (last %s)", rank,
+		count, rq.cfg.Window, evt.ID)
+}
@@ -0,0 +1,139 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_rankQuarantiner_OnEvent(t *testing.T) {
+	rankEvt := func(id events.RASID, rank uint32) *events.RASEvent {
+		evt := events.NewGenericEvent(id, events.RASSeverityError, "fault", "")
+		evt.Rank = rank
+		return evt
+	}
+	faultyEvts := func(rank uint32, n int) []*events.RASEvent {
+		var evts []*events.RASEvent
+		for i := 0; i < n; i++ {
+			evts = append(evts, rankEvt(events.RASDeviceSetFaulty, rank))
+		}
+		return evts
+	}
+
+	for name, tc := range map[string]struct {
+		cfg            *config.QuarantinePolicy
+		members        system.Members
+		evts           []*events.RASEvent
+		interval       time.Duration
+		expQuarantined []ranklist.Rank
+	}{
+		"below threshold": {
+			evts: faultyEvts(1, 2),
+		},
+		"threshold reached": {
+			evts:           faultyEvts(1, 3),
+			expQuarantined: []ranklist.Rank{1},
+		},
+		"mixed events counted together": {
+			evts: []*events.RASEvent{
+				rankEvt(events.RASDeviceSetFaulty, 1),
+				rankEvt(events.RASDeviceMediaError, 1),
+				rankEvt(events.RASPoolCorruptionDetected, 1),
+			},
+			expQuarantined: []ranklist.Rank{1},
+		},
+		"events counted per rank": {
+			evts: []*events.RASEvent{
+				rankEvt(events.RASDeviceSetFaulty, 0),
+				rankEvt(events.RASDeviceSetFaulty, 1),
+				rankEvt(events.RASDeviceSetFaulty, 0),
+				rankEvt(events.RASDeviceSetFaulty, 1),
+			},
+		},
+		"unrelated event": {
+			evts: []*events.RASEvent{
+				rankEvt(events.RASEngineDied, 1),
+				rankEvt(events.RASEngineDied, 1),
+				rankEvt(events.RASEngineDied, 1),
+			},
+		},
+		"event without rank": {
+			evts: faultyEvts(uint32(ranklist.NilRank), 3),
+		},
+		"configured events and threshold": {
+			cfg: &config.QuarantinePolicy{
+				Events:    []string{events.RASEngineDied.String()},
+				Threshold: 2,
+			},
+			evts: []*events.RASEvent{
+				rankEvt(events.RASDeviceSetFaulty, 1),
+				rankEvt(events.RASEngineDied, 1),
+				rankEvt(events.RASEngineDied, 1),
+			},
+			expQuarantined: []ranklist.Rank{1},
+		},
+		"events outside window": {
+			cfg: &config.QuarantinePolicy{
+				Window: time.Minute,
+			},
+			evts:     faultyEvts(1, 3),
+			interval: time.Minute,
+		},
+		"rank not in placement state": {
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
+			},
+			evts: faultyEvts(1, 3),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			if tc.members == nil {
+				tc.members = system.Members{
+					mockMember(t, 0, 1, "joined"),
+					mockMember(t, 1, 1, "joined"),
+				}
+			}
+			svc := mgmtSystemTestSetup(t, log, tc.members, nil)
+
+			rq := newRankQuarantiner(log, tc.cfg, svc.membership)
+			now := time.Now()
+			rq.now = func() time.Time { return now }
+
+			for _, evt := range tc.evts {
+				rq.OnEvent(test.Context(t), evt)
+				now = now.Add(tc.interval)
+			}
+
+			var gotQuarantined []ranklist.Rank
+			for _, m := range tc.members {
+				got, err := svc.membership.Get(m.Rank)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.Quarantined {
+					if got.State != system.MemberStateQuarantined {
+						t.Fatalf("rank %d quarantined in state %s", got.Rank, got.State)
+					}
+					gotQuarantined = append(gotQuarantined, got.Rank)
+				}
+			}
+			test.AssertEqual(t, tc.expQuarantined, gotQuarantined, "quarantined ranks")
+		})
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2023 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,8 +8,11 @@ package server
 
 import (
 	"context"
+	"crypto/x509"
+	"net"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -27,8 +30,15 @@ import (
 )
 
 const (
-	groupUpdateInterval = 500 * time.Millisecond
-	batchLoopInterval   = 250 * time.Millisecond
+	groupUpdateInterval    = 500 * time.Millisecond
+	batchLoopInterval      = 250 * time.Millisecond
+	healthSnapshotInterval = time.Hour
+	// healthSnapshotQueryTimeout bounds each pool query made
+	// while collecting a health snapshot.
+	healthSnapshotQueryTimeout = 30 * time.Second
+	// memberAddrCompactInterval is the period between removals of stale
+	// entries from the member address map.
+	memberAddrCompactInterval = 24 * time.Hour
 )
 
 type (
@@ -68,19 +78,34 @@ func (br *batchRequest) sendResponse(parent context.Context, msg proto.Message,
 // mgmtpb.MgmtSvcServer.
 type mgmtSvc struct {
 	mgmtpb.UnimplementedMgmtSvcServer
-	log               logging.Logger
-	harness           *EngineHarness
-	membership        *system.Membership // if MS leader, system membership list
-	sysdb             *raft.Database
-	rpcClient         control.UnaryInvoker
-	events            *events.PubSub
-	systemProps       daos.SystemPropertyMap
-	clientNetworkHint []*mgmtpb.ClientNetHint
-	batchInterval     time.Duration
-	batchReqs         batchReqChan
-	serialReqs        batchReqChan
-	groupUpdateReqs   chan bool
-	lastMapVer        uint32
+	log                 logging.Logger
+	harness             *EngineHarness
+	membership          *system.Membership // if MS leader, system membership list
+	sysdb               *raft.Database
+	rpcClient           control.UnaryInvoker
+	events              *events.PubSub
+	systemProps         daos.SystemPropertyMap
+	clientNetworkHint   []*mgmtpb.ClientNetHint
+	batchInterval       time.Duration
+	batchReqs           batchReqChan
+	serialReqs          batchReqChan
+	groupUpdateReqs     chan bool
+	lastMapVer          uint32
+	jobStats            *jobStatsAggregator
+	armer               *destructiveOpArmer
+	joinAdmitter        *joinAdmitter
+	joinLimiter         *joinLimiter
+	autoReint           *autoReintegrator
+	idempotency         *idempotencyCache
+	healthReporter      *healthReporter
+	eventArchiver       *eventArchiver
+	msBackupSched       *msBackupScheduler
+	poolSpaceForecaster *poolSpaceForecaster
+	poolOpSched         *poolOpScheduler
+	aclPrincipalCheck   bool
+
+	requireProvisioningToken bool
+	provisioningKeyLock      sync.Mutex
 }
 
 func newMgmtSvc(h *EngineHarness, m *system.Membership, s *raft.Database, c control.UnaryInvoker, p *events.PubSub) *mgmtSvc {
@@ -97,6 +122,8 @@ func newMgmtSvc(h *EngineHarness, m *system.Membership, s *raft.Database, c cont
 		batchReqs:         make(batchReqChan),
 		serialReqs:        make(batchReqChan),
 		groupUpdateReqs:   make(chan bool),
+		armer:             newDestructiveOpArmer(),
+		idempotency:       newIdempotencyCache(),
 	}
 }
 
@@ -156,6 +183,14 @@ func (svc *mgmtSvc) checkReplicaRequest(req proto.Message) error {
 // that will be canceled on leadership loss.
 func (svc *mgmtSvc) startLeaderLoops(ctx context.Context) {
 	go svc.leaderTaskLoop(ctx)
+	go svc.healthSnapshotLoop(ctx)
+	go svc.memberAddrCompactLoop(ctx)
+	go svc.jobStatsLoop(ctx)
+	go svc.autoReintegrateLoop(ctx)
+	go svc.healthReportLoop(ctx)
+	go svc.eventArchiveLoop(ctx)
+	go svc.msBackupLoop(ctx)
+	go svc.poolOpSchedulerLoop(ctx)
 }
 
 // startAsyncLoops kicks off the asynchronous processing loops.
@@ -239,10 +274,29 @@ func (svc *mgmtSvc) processBatchPoolEvictions(ctx context.Context, bprChan batch
 	return nil
 }
 
-// processBatchJoins processes a batch of JoinReq messages.
+// pendingJoin holds a join request that has passed the initial checks.
+type pendingJoin struct {
+	req       *batchRequest
+	msg       *mgmtpb.JoinReq
+	replyAddr *net.TCPAddr
+	peerCert  *x509.Certificate
+}
+
+// processBatchJoins processes a batch of JoinReq messages. Requests beyond
+// the per-batch limit are returned to be retried in the next batch.
 func (svc *mgmtSvc) processBatchJoins(ctx context.Context, bprChan batchProcessRespChan, reqs []*batchRequest) []*batchRequest {
-	var updateNeeded bool
+	reqs, deferred := svc.joinLimiter.split(reqs)
+	if len(deferred) > 0 {
+		svc.log.Debugf("deferring %d join requests to next batch", len(deferred))
+	}
+
+	var joins []*pendingJoin
 	for _, req := range reqs {
+		// Don't commit a join that the caller has already given up on.
+		if req.ctx.Err() != nil {
+			continue
+		}
+
 		msg, ok := req.msg.(*mgmtpb.JoinReq)
 		if !ok {
 			req.sendResponse(ctx, nil, errors.Errorf("unexpected message type %T", req.msg))
@@ -257,8 +311,36 @@ func (svc *mgmtSvc) processBatchJoins(ctx context.Context, bprChan batchProcessR
 			continue
 		}
 
-		resp, err := svc.join(ctx, msg, replyAddr)
-		req.sendResponse(ctx, resp, err)
+		if err := svc.checkProvisioningToken(req.ctx, msg, replyAddr); err != nil {
+			req.sendResponse(ctx, nil, err)
+			continue
+		}
+
+		// Insecure mode has no peer certificate to offer the admission hook.
+		peerCert, _ := peerCertFromContext(req.ctx)
+
+		joins = append(joins, &pendingJoin{
+			req:       req,
+			msg:       msg,
+			replyAddr: replyAddr,
+			peerCert:  peerCert,
+		})
+	}
+
+	admitErrs := svc.checkJoinAdmissions(ctx, joins)
+
+	var updateNeeded bool
+	for i, pj := range joins {
+		if pj.req.ctx.Err() != nil {
+			continue
+		}
+		if admitErrs[i] != nil {
+			pj.req.sendResponse(ctx, nil, admitErrs[i])
+			continue
+		}
+
+		resp, err := svc.join(ctx, pj.msg, pj.replyAddr)
+		pj.req.sendResponse(ctx, resp, err)
 		if err == nil {
 			updateNeeded = true
 		}
@@ -268,7 +350,7 @@ func (svc *mgmtSvc) processBatchJoins(ctx context.Context, bprChan batchProcessR
 		svc.reqGroupUpdate(ctx, true)
 	}
 
-	return nil
+	return deferred
 }
 
 // processBatchedMsgRequests processes a batch of requests for a given message type.
@@ -369,6 +451,57 @@ func (svc *mgmtSvc) batchReqLoop(parent context.Context) {
 	}
 }
 
+// healthSnapshotLoop periodically records a system health snapshot
+// in the MS database. It runs separately from the leaderTaskLoop so
+// that slow pool queries do not delay group map updates.
+func (svc *mgmtSvc) healthSnapshotLoop(parent context.Context) {
+	snapshotTimer := time.NewTicker(healthSnapshotInterval)
+	defer snapshotTimer.Stop()
+
+	svc.log.Debug("starting healthSnapshotLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped healthSnapshotLoop")
+			return
+		case <-snapshotTimer.C:
+			if err := svc.recordHealthSnapshot(parent); err != nil {
+				svc.log.Errorf("failed to record system health snapshot: %s", err)
+				continue
+			}
+			if err := svc.forecastPoolSpace(); err != nil {
+				svc.log.Errorf("failed to forecast pool space usage: %s", err)
+			}
+		}
+	}
+}
+
+// memberAddrCompactLoop periodically removes entries from the member address
+// map that are no longer referenced by a member, so that the map and database
+// snapshots do not grow as hosts are re-addressed over the system's life.
+func (svc *mgmtSvc) memberAddrCompactLoop(parent context.Context) {
+	compactTimer := time.NewTicker(memberAddrCompactInterval)
+	defer compactTimer.Stop()
+
+	svc.log.Debug("starting memberAddrCompactLoop")
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped memberAddrCompactLoop")
+			return
+		case <-compactTimer.C:
+			removed, err := svc.sysdb.CompactMemberAddrs()
+			if err != nil {
+				svc.log.Errorf("failed to compact member address map: %s", err)
+				continue
+			}
+			if removed > 0 {
+				svc.log.Debugf("removed %d stale entries from member address map", removed)
+			}
+		}
+	}
+}
+
 // leaderTaskLoop is the main loop for handling MS leader tasks.
 func (svc *mgmtSvc) leaderTaskLoop(parent context.Context) {
 	var groupUpdateNeeded bool
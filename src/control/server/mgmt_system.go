@@ -115,6 +115,15 @@ func (svc *mgmtSvc) GetAttachInfo(ctx context.Context, req *mgmtpb.GetAttachInfo
 
 	resp.Sys = svc.sysdb.SystemName()
 
+	// Allow the caller to negotiate compatibility and optional features
+	// with this server during rolling upgrades.
+	if !selfServerComponent.Version.IsZero() {
+		resp.MsVersion = selfServerComponent.Version.String()
+	}
+	for _, feature := range build.ServerFeatures() {
+		resp.Features = append(resp.Features, feature.String())
+	}
+
 	return resp, nil
 }
 
@@ -165,9 +174,19 @@ func getPeerListenAddr(ctx context.Context, listenAddrStr string) (*net.TCPAddr,
 		net.JoinHostPort(tcpAddr.IP.String(), portStr))
 }
 
-// join handles a request to join the system and is called from
-// the batch processing goroutine.
+// join handles a request to join the system that has been admitted and is
+// called from the batch processing goroutine.
 func (svc *mgmtSvc) join(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net.TCPAddr) (*mgmtpb.JoinResp, error) {
+	// Servers predating host IDs join without one.
+	var hostID uuid.UUID
+	if req.HostId != "" {
+		id, err := uuid.Parse(req.HostId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid host ID %q", req.HostId)
+		}
+		hostID = id
+	}
+
 	uuid, err := uuid.Parse(req.Uuid)
 	if err != nil {
 		return nil, errors.Wrapf(err, "invalid uuid %q", req.Uuid)
@@ -186,6 +205,7 @@ func (svc *mgmtSvc) join(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net
 		Rank:                    ranklist.Rank(req.Rank),
 		UUID:                    uuid,
 		ControlAddr:             peerAddr,
+		HostID:                  hostID,
 		PrimaryFabricURI:        req.Uri,
 		SecondaryFabricURIs:     req.SecondaryUris,
 		FabricContexts:          req.Nctxs,
@@ -195,6 +215,13 @@ func (svc *mgmtSvc) join(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net
 		CheckMode:               req.CheckMode,
 	})
 	if err != nil {
+		// An engine joining with an identity that differs from the one
+		// recorded in the MS database is running on storage restored
+		// from the wrong backup or cloned from another host.
+		if system.IsIdentityMismatch(err) {
+			svc.events.Publish(events.NewEngineSuperblockMismatchEvent(peerAddr.String(),
+				req.Idx, req.Rank, err.Error()))
+		}
 		return nil, errors.Wrap(err, "failed to join system")
 	}
 
@@ -205,6 +232,12 @@ func (svc *mgmtSvc) join(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net
 	} else {
 		svc.log.Debugf("updated system member: rank %d, primary uri %s, secondary uris %s, %s->%s",
 			member.Rank, member.PrimaryFabricURI, member.SecondaryFabricURIs, joinResponse.PrevState, member.State)
+
+		// Ranks excluded by an administrator are left for the administrator
+		// to reintegrate.
+		if joinResponse.PrevState == system.MemberStateExcluded {
+			svc.autoReint.add(member.Rank, member.Incarnation, time.Now())
+		}
 	}
 
 	joinState := mgmtpb.JoinResp_IN
@@ -243,6 +276,31 @@ func (svc *mgmtSvc) join(ctx context.Context, req *mgmtpb.JoinReq, peerAddr *net
 	return resp, nil
 }
 
+// SystemRankUUIDs returns the member UUIDs recorded in the MS database for the
+// requested ranks, so that servers that are not MS replicas can verify the
+// superblocks of their engines.
+func (svc *mgmtSvc) SystemRankUUIDs(ctx context.Context, req *mgmtpb.SystemRankUUIDsReq) (*mgmtpb.SystemRankUUIDsResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	resp := &mgmtpb.SystemRankUUIDsResp{
+		Uuids: make(map[uint32]string),
+	}
+	for _, r := range req.Ranks {
+		m, err := svc.membership.Get(ranklist.Rank(r))
+		if err != nil {
+			if system.IsMemberNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		resp.Uuids[r] = m.UUID.String()
+	}
+
+	return resp, nil
+}
+
 // allRanksJoined checks whether all ranks that the system knows about, and that are not admin
 // excluded, are joined.
 //
@@ -258,7 +316,8 @@ func (svc *mgmtSvc) allRanksJoined() bool {
 		return false
 	}
 
-	if joined, err = svc.sysdb.MemberCount(system.MemberStateJoined, system.MemberStateAdminExcluded); err != nil {
+	if joined, err = svc.sysdb.MemberCount(system.MemberStateJoined, system.MemberStateQuarantined,
+		system.MemberStateAdminExcluded); err != nil {
 		svc.log.Errorf("failed to get joined member count: %s", err)
 		return false
 	}
@@ -342,7 +401,7 @@ func (svc *mgmtSvc) updateFabricProviders(provList []string, publisher events.Pu
 	}
 
 	if provStr != curProv {
-		numJoined, err := svc.sysdb.MemberCount(system.MemberStateJoined)
+		numJoined, err := svc.sysdb.MemberCount(system.MemberStateJoined, system.MemberStateQuarantined)
 		if err != nil {
 			return errors.Wrapf(err, "getting number of joined members")
 		}
@@ -454,6 +513,21 @@ func (svc *mgmtSvc) doGroupUpdate(ctx context.Context, forced bool) error {
 	return nil
 }
 
+// checkMemberFeatures returns an error if the joining server advertises its
+// features but lacks one that every member must support. Servers that predate
+// feature negotiation are gated by the version check alone.
+func checkMemberFeatures(ctx context.Context, required []build.Feature) error {
+	features, advertised := build.FeaturesFromContext(ctx)
+	if !advertised {
+		return nil
+	}
+
+	if missing := build.MissingFeatures(features, required); len(missing) > 0 {
+		return FaultJoinMissingFeatures(missing)
+	}
+	return nil
+}
+
 // Join management service gRPC handler receives Join requests from
 // control-plane instances attempting to register a managed instance (will be a
 // rank once joined) to the DAOS system.
@@ -473,6 +547,17 @@ func (svc *mgmtSvc) Join(ctx context.Context, req *mgmtpb.JoinReq) (resp *mgmtpb
 		return nil, err
 	}
 
+	if err := checkMemberFeatures(ctx, build.RequiredMemberFeatures()); err != nil {
+		svc.log.Errorf("refusing join from rank %d: %s", req.Rank, err)
+		return nil, err
+	}
+
+	if err := svc.joinLimiter.acquire(); err != nil {
+		svc.log.Debugf("rejecting join from rank %d: %s", req.Rank, err)
+		return nil, err
+	}
+	defer svc.joinLimiter.release()
+
 	msg, err := svc.submitBatchRequest(ctx, req)
 	if err != nil {
 		return nil, err
@@ -806,6 +891,10 @@ func (svc *mgmtSvc) SystemStop(ctx context.Context, req *mgmtpb.SystemStopReq) (
 	}
 	svc.log.Debug("Received SystemStop RPC")
 
+	if req.Orderly {
+		return svc.orderlyStop(ctx, req)
+	}
+
 	fReq, fResp, err := svc.getFanout(req)
 	if err != nil {
 		return nil, err
@@ -917,6 +1006,21 @@ func (svc *mgmtSvc) SystemStart(ctx context.Context, req *mgmtpb.SystemStartReq)
 	}
 	svc.log.Debug("Received SystemStart RPC")
 
+	if req.Orderly {
+		return svc.orderlyStart(ctx, req)
+	}
+
+	// Ranks remain administratively excluded after an orderly shutdown
+	// and would be refused on join.
+	marker, err := svc.getCleanShutdown()
+	if err != nil {
+		return nil, err
+	}
+	if marker != nil {
+		return nil, errors.Errorf("system was shut down in order at %s, use an orderly startup",
+			common.FormatTime(marker.Timestamp))
+	}
+
 	fReq, fResp, err := svc.getFanout(req)
 	if err != nil {
 		return nil, err
@@ -943,6 +1047,17 @@ func (svc *mgmtSvc) SystemExclude(ctx context.Context, req *mgmtpb.SystemExclude
 		return nil, err
 	}
 
+	msg, err := svc.idempotent(ctx, req, func(ctx context.Context) (proto.Message, error) {
+		return svc.systemExclude(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*mgmtpb.SystemExcludeResp), nil
+}
+
+// systemExclude handles the actual system exclude request.
+func (svc *mgmtSvc) systemExclude(ctx context.Context, req *mgmtpb.SystemExcludeReq) (*mgmtpb.SystemExcludeResp, error) {
 	if req.Hosts == "" && req.Ranks == "" {
 		return nil, errors.New("no hosts or ranks specified")
 	}
@@ -966,10 +1081,11 @@ func (svc *mgmtSvc) SystemExclude(ctx context.Context, req *mgmtpb.SystemExclude
 			return nil, err
 		}
 		action := "set admin-excluded state"
-		m.State = system.MemberStateAdminExcluded
 		if req.Clear {
 			action = "clear admin-excluded state"
-			m.State = system.MemberStateExcluded // cleared on rejoin
+			m.SetState(system.MemberStateExcluded, action) // cleared on rejoin
+		} else {
+			m.SetState(system.MemberStateAdminExcluded, action)
 		}
 		if err := svc.sysdb.UpdateMember(m); err != nil {
 			return nil, err
@@ -985,6 +1101,78 @@ func (svc *mgmtSvc) SystemExclude(ctx context.Context, req *mgmtpb.SystemExclude
 	return resp, nil
 }
 
+// SystemQuarantine marks the specified ranks as quarantined, excluding them
+// from new pool placements while they remain in the system for existing I/O.
+func (svc *mgmtSvc) SystemQuarantine(ctx context.Context, req *mgmtpb.SystemQuarantineReq) (*mgmtpb.SystemQuarantineResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	msg, err := svc.idempotent(ctx, req, func(_ context.Context) (proto.Message, error) {
+		return svc.systemQuarantine(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*mgmtpb.SystemQuarantineResp), nil
+}
+
+// systemQuarantine handles the actual system quarantine request. Ranks that
+// can't be quarantined, or aren't quarantined when clearing, are reported as
+// errored results.
+func (svc *mgmtSvc) systemQuarantine(req *mgmtpb.SystemQuarantineReq) (*mgmtpb.SystemQuarantineResp, error) {
+	if req.Hosts == "" && req.Ranks == "" {
+		return nil, errors.New("no hosts or ranks specified")
+	}
+
+	fReq, fResp, err := svc.getFanout(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if fResp.AbsentHosts.Count() > 0 {
+		return nil, errors.Errorf("invalid host(s): %s", fResp.AbsentHosts.String())
+	}
+	if fResp.AbsentRanks.Count() > 0 {
+		return nil, errors.Errorf("invalid rank(s): %s", fResp.AbsentRanks.String())
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "quarantined by administrator"
+	}
+
+	resp := new(mgmtpb.SystemQuarantineResp)
+	for _, r := range fReq.Ranks.Ranks() {
+		action := "set quarantined state"
+		var m *system.Member
+		var opErr error
+		if req.Clear {
+			action = "clear quarantined state"
+			m, opErr = svc.membership.ClearQuarantine(r)
+		} else {
+			m, opErr = svc.membership.Quarantine(r, reason)
+		}
+
+		result := &sharedpb.RankResult{
+			Rank:   r.Uint32(),
+			Action: action,
+		}
+		if opErr != nil {
+			if m, err = svc.membership.Get(r); err != nil {
+				return nil, err
+			}
+			result.Errored = true
+			result.Msg = opErr.Error()
+		}
+		result.State = strings.ToLower(m.State.String())
+		result.Addr = m.Addr.String()
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
 // ClusterEvent management service gRPC handler receives ClusterEvent requests
 // from control-plane instances attempting to notify the MS of a cluster event
 // in the DAOS system (this handler should only get called on the MS leader).
@@ -1057,6 +1245,10 @@ func (svc *mgmtSvc) SystemErase(ctx context.Context, pbReq *mgmtpb.SystemEraseRe
 		if err := svc.eraseAndRestart(false); err != nil {
 			return nil, errors.Wrap(err, "erasing and restarting non-leader")
 		}
+	} else if err := svc.confirmDestructiveOp(ctx, control.SystemOpErase, pbReq.Token); err != nil {
+		// The leader only proceeds if the erase has been armed, so that
+		// a single mistyped command can't wipe out the system.
+		return nil, err
 	}
 
 	// On the leader, we should first tell all servers to prepare for
@@ -1293,3 +1485,194 @@ func (svc *mgmtSvc) SystemGetProp(ctx context.Context, req *mgmtpb.SystemGetProp
 	resp = &mgmtpb.SystemGetPropResp{Properties: props}
 	return
 }
+
+// collectPoolHealth gathers a compact health and capacity summary for the
+// given pool service. Pools that are not ready are not queried, and query
+// failures are recorded in the summary rather than failing the snapshot.
+func (svc *mgmtSvc) collectPoolHealth(ctx context.Context, ps *system.PoolService) *system.PoolHealthSummary {
+	sum := &system.PoolHealthSummary{
+		PoolUUID:  ps.PoolUUID,
+		PoolLabel: ps.PoolLabel,
+		State:     ps.State.String(),
+	}
+	if ps.State != system.PoolServiceStateReady {
+		return sum
+	}
+
+	qCtx, cancel := context.WithTimeout(ctx, healthSnapshotQueryTimeout)
+	defer cancel()
+
+	resp, err := svc.PoolQuery(qCtx, &mgmtpb.PoolQueryReq{
+		Sys: svc.sysdb.SystemName(),
+		Id:  ps.PoolUUID.String(),
+	})
+	if err == nil && resp.Status != int32(daos.Success) {
+		err = daos.Status(resp.Status)
+	}
+	if err != nil {
+		sum.QueryError = err.Error()
+		return sum
+	}
+
+	sum.State = daos.PoolServiceState(resp.State).String()
+	sum.TotalTargets = resp.TotalTargets
+	sum.DisabledTargets = resp.DisabledTargets
+	if resp.Rebuild != nil {
+		sum.RebuildState = daos.PoolRebuildState(resp.Rebuild.State).String()
+	}
+	for _, tier := range resp.TierStats {
+		sum.TotalBytes += tier.Total
+		sum.FreeBytes += tier.Free
+		sum.Tiers = append(sum.Tiers, &system.PoolTierUsage{
+			MediaType:  daos.StorageMediaType(tier.MediaType).String(),
+			TotalBytes: tier.Total,
+			FreeBytes:  tier.Free,
+		})
+	}
+
+	return sum
+}
+
+// collectHealthSnapshot collects a snapshot of the current member and pool
+// health.
+func (svc *mgmtSvc) collectHealthSnapshot(ctx context.Context) (*system.HealthSnapshot, error) {
+	members, err := svc.sysdb.AllMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	poolSvcs, err := svc.sysdb.PoolServiceList(true)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]*system.PoolHealthSummary, 0, len(poolSvcs))
+	for _, ps := range poolSvcs {
+		pools = append(pools, svc.collectPoolHealth(ctx, ps))
+	}
+
+	return system.NewHealthSnapshot(time.Now(), members, pools), nil
+}
+
+// recordHealthSnapshot collects a snapshot of the current member and pool
+// health and adds it to the bounded history stored in the MS database.
+func (svc *mgmtSvc) recordHealthSnapshot(ctx context.Context) error {
+	hs, err := svc.collectHealthSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	return svc.sysdb.AddHealthSnapshot(hs)
+}
+
+// SystemHistory returns the history of system health snapshots recorded
+// by the MS leader, ordered from oldest to newest.
+func (svc *mgmtSvc) SystemHistory(ctx context.Context, req *mgmtpb.SystemHistoryReq) (*mgmtpb.SystemHistoryResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	snaps, err := svc.sysdb.HealthSnapshots(int(req.GetMaxEntries()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(mgmtpb.SystemHistoryResp)
+	for _, hs := range snaps {
+		pbSnap := &mgmtpb.SystemHealthSnapshot{
+			Timestamp:    hs.Timestamp.UnixNano(),
+			MemberStates: hs.MemberStates,
+		}
+		for _, ps := range hs.Pools {
+			pbPool := &mgmtpb.SystemHealthSnapshot_PoolSummary{
+				Uuid:            ps.PoolUUID.String(),
+				Label:           ps.PoolLabel,
+				State:           ps.State,
+				TotalTargets:    ps.TotalTargets,
+				DisabledTargets: ps.DisabledTargets,
+				RebuildState:    ps.RebuildState,
+				TotalBytes:      ps.TotalBytes,
+				FreeBytes:       ps.FreeBytes,
+				QueryError:      ps.QueryError,
+			}
+			for _, tier := range ps.Tiers {
+				pbPool.Tiers = append(pbPool.Tiers, &mgmtpb.SystemHealthSnapshot_TierUsage{
+					MediaType:  tier.MediaType,
+					TotalBytes: tier.TotalBytes,
+					FreeBytes:  tier.FreeBytes,
+				})
+			}
+			pbSnap.Pools = append(pbSnap.Pools, pbPool)
+		}
+		resp.Snapshots = append(resp.Snapshots, pbSnap)
+	}
+
+	bs, err := svc.sysdb.MSBackupStatus()
+	if err != nil {
+		return nil, err
+	}
+	if bs != nil {
+		resp.MsBackup = &mgmtpb.MSBackupStatus{
+			Time:  bs.Time.UnixNano(),
+			Path:  bs.Path,
+			Index: bs.Index,
+			Error: bs.Error,
+		}
+		if !bs.LastSuccess.IsZero() {
+			resp.MsBackup.LastSuccess = bs.LastSuccess.UnixNano()
+		}
+	}
+
+	return resp, nil
+}
+
+// SystemEvents returns the persistent events recorded by the MS leader,
+// ordered by ID.
+func (svc *mgmtSvc) SystemEvents(ctx context.Context, req *mgmtpb.SystemEventsReq) (*mgmtpb.SystemEventsResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	evts, err := svc.sysdb.Events(req.GetActiveOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(mgmtpb.SystemEventsResp)
+	for _, pe := range evts {
+		pbEvt := &mgmtpb.SystemEvent{
+			Id:           pe.ID,
+			RasId:        pe.RASID,
+			Severity:     pe.Severity,
+			Msg:          pe.Msg,
+			Hostname:     pe.Hostname,
+			Rank:         pe.Rank,
+			PoolUuid:     pe.PoolUUID,
+			FirstSeen:    pe.FirstSeen.UnixNano(),
+			LastSeen:     pe.LastSeen.UnixNano(),
+			Count:        pe.Count,
+			Acknowledged: pe.Acknowledged,
+		}
+		if pe.Acknowledged {
+			pbEvt.AckTime = pe.AckTime.UnixNano()
+		}
+		resp.Events = append(resp.Events, pbEvt)
+	}
+
+	return resp, nil
+}
+
+// SystemEventAck marks the requested persistent events as acknowledged so
+// that they are no longer reported as active.
+func (svc *mgmtSvc) SystemEventAck(ctx context.Context, req *mgmtpb.SystemEventAckReq) (*mgmtpb.DaosResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := svc.sysdb.AckEvents(req.GetIds()...); err != nil {
+		return nil, err
+	}
+	svc.log.Noticef("acknowledged events %v", req.GetIds())
+
+	return new(mgmtpb.DaosResp), nil
+}
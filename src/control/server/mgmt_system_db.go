@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+const (
+	// dbHashWaitTimeout bounds the time that a replica waits for the
+	// requested index to be applied before hashing its state.
+	dbHashWaitTimeout = 10 * time.Second
+	// dbVerifyMaxAttempts is the number of times that the comparison is
+	// attempted when the state of a replica moves past the index being
+	// compared before it can be hashed.
+	dbVerifyMaxAttempts = 3
+)
+
+// SystemDBHash returns the content hash of this replica's system database
+// state as of the requested index, waiting for the index to be applied if
+// necessary. If the state has already advanced past the requested index, the
+// response contains the current index and no hash.
+func (svc *mgmtSvc) SystemDBHash(ctx context.Context, req *mgmtpb.SystemDBHashReq) (*mgmtpb.SystemDBHashResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, dbHashWaitTimeout)
+	defer cancel()
+
+	hash, err := svc.sysdb.StateHashAt(waitCtx, req.Index)
+	if err != nil {
+		if errors.Is(err, raft.ErrStateIndexPassed) {
+			curIndex, err := svc.sysdb.DataVersion()
+			if err != nil {
+				return nil, err
+			}
+			return &mgmtpb.SystemDBHashResp{Index: curIndex}, nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, errors.Errorf("timed out waiting for index %d to be applied", req.Index)
+		}
+		return nil, err
+	}
+
+	return &mgmtpb.SystemDBHashResp{
+		Index: hash.Index,
+		Hash:  hash.Hash,
+	}, nil
+}
+
+// SystemDBVerify compares the content hash of the system database state on
+// each MS replica with that of the leader, as of the same index, in order to
+// detect replicas whose state has silently diverged.
+func (svc *mgmtSvc) SystemDBVerify(ctx context.Context, req *mgmtpb.SystemDBVerifyReq) (*mgmtpb.SystemDBVerifyResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, passed, err := svc.verifyDBReplicas(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !passed || attempt == dbVerifyMaxAttempts {
+			return resp, nil
+		}
+		svc.log.Debugf("replica state advanced past index %d; retrying verification", resp.Index)
+	}
+}
+
+// verifyDBReplicas hashes the leader's state once all outstanding updates
+// have been applied, then requests the hash of the state at the same index
+// from the other replicas. Returns true if any of the replicas had already
+// applied later updates.
+func (svc *mgmtSvc) verifyDBReplicas(ctx context.Context) (*mgmtpb.SystemDBVerifyResp, bool, error) {
+	if err := svc.sysdb.Barrier(); err != nil {
+		return nil, false, err
+	}
+	ldrHash, err := svc.sysdb.StateHash()
+	if err != nil {
+		return nil, false, err
+	}
+	ldrAddr, err := svc.sysdb.ReplicaAddr()
+	if err != nil {
+		return nil, false, err
+	}
+	peers, err := svc.sysdb.PeerAddrs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp := &mgmtpb.SystemDBVerifyResp{
+		Index: ldrHash.Index,
+		Replicas: []*mgmtpb.SystemDBReplicaHash{
+			{
+				Replica: ldrAddr.String(),
+				Leader:  true,
+				Index:   ldrHash.Index,
+				Hash:    ldrHash.Hash,
+			},
+		},
+	}
+	if len(peers) == 0 {
+		return resp, false, nil
+	}
+
+	hostList := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		hostList = append(hostList, peer.String())
+	}
+	hashReq := &control.SystemDBHashReq{Index: ldrHash.Index}
+	hashReq.SetHostList(hostList)
+	hashResp, err := control.SystemDBHash(ctx, svc.rpcClient, hashReq)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hostErrs := make(map[string]string)
+	for _, hes := range hashResp.HostErrors {
+		for _, host := range strings.Split(hes.HostSet.DerangedString(), ",") {
+			hostErrs[host] = hes.HostError.Error()
+		}
+	}
+
+	var passed bool
+	for _, addr := range hostList {
+		rh := &mgmtpb.SystemDBReplicaHash{Replica: addr}
+		resp.Replicas = append(resp.Replicas, rh)
+
+		hash, found := hashResp.Hashes[addr]
+		switch {
+		case found && hash.Hash == "":
+			passed = true
+			rh.Index = hash.Index
+			rh.Error = errors.Errorf("state advanced to index %d before it could be hashed",
+				hash.Index).Error()
+		case found:
+			rh.Index = hash.Index
+			rh.Hash = hash.Hash
+		case hostErrs[addr] != "":
+			rh.Error = hostErrs[addr]
+		default:
+			rh.Error = "no response from replica"
+		}
+	}
+
+	return resp, passed, nil
+}
@@ -0,0 +1,276 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system/raft"
+)
+
+func TestServer_MgmtSvc_SystemDBHash(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	if err := svc.sysdb.SetSystemAttrs(map[string]string{"foo": "bar"}); err != nil {
+		t.Fatal(err)
+	}
+	curHash, err := svc.sysdb.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		req     *mgmtpb.SystemDBHashReq
+		expResp *mgmtpb.SystemDBHashResp
+		expErr  error
+	}{
+		"wrong system": {
+			req:    &mgmtpb.SystemDBHashReq{Sys: "bad"},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"current index": {
+			req: &mgmtpb.SystemDBHashReq{Index: curHash.Index},
+			expResp: &mgmtpb.SystemDBHashResp{
+				Index: curHash.Index,
+				Hash:  curHash.Hash,
+			},
+		},
+		"passed index": {
+			req:     &mgmtpb.SystemDBHashReq{Index: curHash.Index - 1},
+			expResp: &mgmtpb.SystemDBHashResp{Index: curHash.Index},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemDBHash(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemDBVerify(t *testing.T) {
+	ldrAddr := common.LocalhostCtrlAddr()
+	peer1 := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: build.DefaultControlPort}
+	peer2 := &net.TCPAddr{IP: net.ParseIP("10.0.0.3"), Port: build.DefaultControlPort}
+
+	for name, tc := range map[string]struct {
+		noPeers    bool
+		uResps     func(index uint64, hash string) []*control.UnaryResponse
+		expInvokes int
+		expResp    func(index uint64, hash string) *mgmtpb.SystemDBVerifyResp
+		expErr     error
+	}{
+		"single replica": {
+			noPeers: true,
+			expResp: func(index uint64, hash string) *mgmtpb.SystemDBVerifyResp {
+				return &mgmtpb.SystemDBVerifyResp{
+					Index: index,
+					Replicas: []*mgmtpb.SystemDBReplicaHash{
+						{Replica: ldrAddr.String(), Leader: true, Index: index, Hash: hash},
+					},
+				}
+			},
+		},
+		"rpc fails": {
+			uResps: func(uint64, string) []*control.UnaryResponse {
+				return nil
+			},
+			expErr: errors.New("failed"),
+		},
+		"replicas match": {
+			uResps: func(index uint64, hash string) []*control.UnaryResponse {
+				return []*control.UnaryResponse{
+					{
+						Responses: []*control.HostResponse{
+							{
+								Addr:    peer1.String(),
+								Message: &mgmtpb.SystemDBHashResp{Index: index, Hash: hash},
+							},
+							{
+								Addr:    peer2.String(),
+								Message: &mgmtpb.SystemDBHashResp{Index: index, Hash: hash},
+							},
+						},
+					},
+				}
+			},
+			expInvokes: 1,
+			expResp: func(index uint64, hash string) *mgmtpb.SystemDBVerifyResp {
+				return &mgmtpb.SystemDBVerifyResp{
+					Index: index,
+					Replicas: []*mgmtpb.SystemDBReplicaHash{
+						{Replica: ldrAddr.String(), Leader: true, Index: index, Hash: hash},
+						{Replica: peer1.String(), Index: index, Hash: hash},
+						{Replica: peer2.String(), Index: index, Hash: hash},
+					},
+				}
+			},
+		},
+		"replica diverged; replica unavailable": {
+			uResps: func(index uint64, hash string) []*control.UnaryResponse {
+				return []*control.UnaryResponse{
+					{
+						Responses: []*control.HostResponse{
+							{
+								Addr:    peer1.String(),
+								Message: &mgmtpb.SystemDBHashResp{Index: index, Hash: "bad"},
+							},
+							{
+								Addr:  peer2.String(),
+								Error: errors.New("connection refused"),
+							},
+						},
+					},
+				}
+			},
+			expInvokes: 1,
+			expResp: func(index uint64, hash string) *mgmtpb.SystemDBVerifyResp {
+				return &mgmtpb.SystemDBVerifyResp{
+					Index: index,
+					Replicas: []*mgmtpb.SystemDBReplicaHash{
+						{Replica: ldrAddr.String(), Leader: true, Index: index, Hash: hash},
+						{Replica: peer1.String(), Index: index, Hash: "bad"},
+						{Replica: peer2.String(), Error: "connection refused"},
+					},
+				}
+			},
+		},
+		"replica passed index; retried": {
+			uResps: func(index uint64, hash string) []*control.UnaryResponse {
+				passed := &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    peer1.String(),
+							Message: &mgmtpb.SystemDBHashResp{Index: index + 1},
+						},
+						{
+							Addr:    peer2.String(),
+							Message: &mgmtpb.SystemDBHashResp{Index: index, Hash: hash},
+						},
+					},
+				}
+				return []*control.UnaryResponse{passed, passed, passed}
+			},
+			expInvokes: dbVerifyMaxAttempts,
+			expResp: func(index uint64, hash string) *mgmtpb.SystemDBVerifyResp {
+				return &mgmtpb.SystemDBVerifyResp{
+					Index: index,
+					Replicas: []*mgmtpb.SystemDBReplicaHash{
+						{Replica: ldrAddr.String(), Leader: true, Index: index, Hash: hash},
+						{
+							Replica: peer1.String(),
+							Index:   index + 1,
+							Error:   "state advanced to index 2 before it could be hashed",
+						},
+						{Replica: peer2.String(), Index: index, Hash: hash},
+					},
+				}
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			dbCfg := &raft.DatabaseConfig{
+				Replicas:   []*net.TCPAddr{ldrAddr},
+				SystemName: build.DefaultSystemName,
+			}
+			if !tc.noPeers {
+				dbCfg.Replicas = append(dbCfg.Replicas, peer1, peer2)
+			}
+			svc.sysdb = raft.MockDatabaseWithCfg(t, log, dbCfg)
+			if err := svc.sysdb.SetSystemAttrs(map[string]string{"foo": "bar"}); err != nil {
+				t.Fatal(err)
+			}
+			ldrHash, err := svc.sysdb.StateHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mic := &control.MockInvokerConfig{
+				UnaryError: errors.New("failed"),
+			}
+			if tc.uResps != nil {
+				if resps := tc.uResps(ldrHash.Index, ldrHash.Hash); resps != nil {
+					mic.UnaryError = nil
+					mic.UnaryResponseSet = resps
+				}
+			}
+			mi := control.NewMockInvoker(log, mic)
+			svc.rpcClient = mi
+
+			gotResp, gotErr := svc.SystemDBVerify(test.Context(t), &mgmtpb.SystemDBVerifyReq{
+				Sys: build.DefaultSystemName,
+			})
+			test.AssertEqual(t, tc.expInvokes, mi.GetInvokeCount(), "unexpected number of hash requests")
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp(ldrHash.Index, ldrHash.Hash), gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemDBHash_Wait(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	curIndex, err := svc.sysdb.DataVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		if err := svc.sysdb.SetSystemAttrs(map[string]string{"foo": "bar"}); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	gotResp, err := svc.SystemDBHash(test.Context(t), &mgmtpb.SystemDBHashReq{
+		Sys:   build.DefaultSystemName,
+		Index: curIndex + 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, curIndex+1, gotResp.Index, "unexpected index")
+	if gotResp.Hash == "" {
+		t.Fatal("expected hash of state at requested index")
+	}
+}
@@ -0,0 +1,381 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const cleanShutdownProp = "clean_shutdown"
+
+type (
+	// cleanShutdownMember identifies a system member that was stopped by
+	// an orderly shutdown.
+	cleanShutdownMember struct {
+		Rank ranklist.Rank `json:"rank"`
+		UUID uuid.UUID     `json:"uuid"`
+		Addr string        `json:"addr"`
+	}
+
+	// cleanShutdownMarker is recorded in the MS database when an orderly
+	// shutdown of the whole system completes.
+	cleanShutdownMarker struct {
+		Timestamp time.Time              `json:"timestamp"`
+		Members   []*cleanShutdownMember `json:"members"`
+		// Excluded contains the ranks that were administratively
+		// excluded by the shutdown, and that will be cleared by the
+		// orderly startup. Ranks that were already excluded are not
+		// included.
+		Excluded []ranklist.Rank `json:"excluded"`
+	}
+)
+
+// getCleanShutdown returns the clean shutdown marker, or nil if the system has
+// not been shut down in order.
+func (svc *mgmtSvc) getCleanShutdown() (*cleanShutdownMarker, error) {
+	val, err := system.GetMgmtProperty(svc.sysdb, cleanShutdownProp)
+	if err != nil {
+		if system.IsErrSystemAttrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	marker := new(cleanShutdownMarker)
+	if err := json.Unmarshal([]byte(val), marker); err != nil {
+		return nil, errors.Wrap(err, "invalid clean shutdown marker")
+	}
+	return marker, nil
+}
+
+// recordCleanShutdown persists the clean shutdown marker and administratively
+// excludes all stopped members, so that no rank can rejoin the system until
+// the orderly startup has validated the membership.
+func (svc *mgmtSvc) recordCleanShutdown() error {
+	members, err := svc.sysdb.AllMembers()
+	if err != nil {
+		return err
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Rank < members[j].Rank })
+
+	marker := &cleanShutdownMarker{Timestamp: time.Now()}
+	for _, m := range members {
+		marker.Members = append(marker.Members, &cleanShutdownMember{
+			Rank: m.Rank,
+			UUID: m.UUID,
+			Addr: m.Addr.String(),
+		})
+		if m.State != system.MemberStateAdminExcluded {
+			marker.Excluded = append(marker.Excluded, m.Rank)
+		}
+	}
+
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	if err := system.SetMgmtProperty(svc.sysdb, cleanShutdownProp, string(data)); err != nil {
+		return errors.Wrap(err, "recording clean shutdown")
+	}
+
+	for _, m := range members {
+		if m.State == system.MemberStateAdminExcluded {
+			continue
+		}
+		m.SetState(system.MemberStateAdminExcluded, "orderly system stop")
+		if err := svc.sysdb.UpdateMember(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCleanShutdownMembers verifies that the current system membership is the
+// same as that recorded by the clean shutdown.
+func (svc *mgmtSvc) checkCleanShutdownMembers(marker *cleanShutdownMarker) error {
+	members, err := svc.sysdb.AllMembers()
+	if err != nil {
+		return err
+	}
+
+	current := make(map[ranklist.Rank]*system.Member)
+	for _, m := range members {
+		current[m.Rank] = m
+	}
+
+	missing := ranklist.MustCreateRankSet("")
+	changed := ranklist.MustCreateRankSet("")
+	for _, csm := range marker.Members {
+		m, found := current[csm.Rank]
+		if !found {
+			missing.Add(csm.Rank)
+			continue
+		}
+		if m.UUID != csm.UUID || m.Addr.String() != csm.Addr {
+			changed.Add(csm.Rank)
+		}
+		delete(current, csm.Rank)
+	}
+	added := ranklist.MustCreateRankSet("")
+	for r := range current {
+		added.Add(r)
+	}
+
+	var problems []string
+	if missing.Count() > 0 {
+		problems = append(problems, "missing ranks "+missing.String())
+	}
+	if changed.Count() > 0 {
+		problems = append(problems, "changed ranks "+changed.String())
+	}
+	if added.Count() > 0 {
+		problems = append(problems, "new ranks "+added.String())
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("system membership changed since orderly shutdown at %s: %s",
+			common.FormatTime(marker.Timestamp), strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// checkCleanShutdownHosts verifies that each host recorded by the clean
+// shutdown is reachable and still reports the recorded ranks with the same
+// UUIDs. The recorded ranks are stopped on their hosts to collect their
+// identities, which is a no-op for ranks that are already stopped.
+func (svc *mgmtSvc) checkCleanShutdownHosts(ctx context.Context, marker *cleanShutdownMarker) error {
+	ranks := ranklist.MustCreateRankSet("")
+	hosts := common.NewStringSet()
+	for _, csm := range marker.Members {
+		ranks.Add(csm.Rank)
+		hosts.Add(csm.Addr)
+	}
+	if ranks.Count() == 0 {
+		return nil
+	}
+
+	req := &control.RanksReq{Ranks: ranks.String()}
+	req.SetHostList(hosts.ToSlice())
+	resp, err := control.StopRanks(ctx, svc.rpcClient, req)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	if resp.Errors() != nil {
+		problems = append(problems, "unreachable hosts: "+resp.Errors().Error())
+	}
+
+	reported := make(map[ranklist.Rank]*system.MemberResult)
+	for _, res := range resp.RankResults {
+		reported[res.Rank] = res
+	}
+	missing := ranklist.MustCreateRankSet("")
+	changed := ranklist.MustCreateRankSet("")
+	for _, csm := range marker.Members {
+		res, found := reported[csm.Rank]
+		switch {
+		case !found:
+			missing.Add(csm.Rank)
+		case res.Errored:
+			problems = append(problems, fmt.Sprintf("rank %d: %s", csm.Rank, res.Msg))
+		case res.UUID != csm.UUID.String():
+			changed.Add(csm.Rank)
+		}
+	}
+	if missing.Count() > 0 {
+		problems = append(problems, "unreported ranks "+missing.String())
+	}
+	if changed.Count() > 0 {
+		problems = append(problems, "changed ranks "+changed.String())
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("system hosts changed since orderly shutdown at %s: %s",
+			common.FormatTime(marker.Timestamp), strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// splitReplicaRanks divides the supplied ranks into those hosted on MS replicas
+// and all others.
+func (svc *mgmtSvc) splitReplicaRanks(ranks *ranklist.RankSet) (replicas, others *ranklist.RankSet, err error) {
+	_, repAddrs, err := svc.sysdb.LeaderQuery()
+	if err != nil {
+		return nil, nil, err
+	}
+	repSet := common.NewStringSet(repAddrs...)
+
+	replicas = ranklist.MustCreateRankSet("")
+	others = ranklist.MustCreateRankSet("")
+	for _, r := range ranks.Ranks() {
+		m, err := svc.sysdb.FindMemberByRank(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		if m.Addr != nil && repSet.Has(m.Addr.String()) {
+			replicas.Add(r)
+			continue
+		}
+		others.Add(r)
+	}
+
+	return replicas, others, nil
+}
+
+// fanoutStages issues the request to each set of ranks in turn, stopping at the
+// first stage that returns errors. The results of all issued stages are
+// combined in the returned response.
+func (svc *mgmtSvc) fanoutStages(ctx context.Context, fReq *fanoutRequest, fResp *fanoutResponse, stages ...*ranklist.RankSet) (*fanoutResponse, error) {
+	var results system.MemberResults
+	for _, stage := range stages {
+		fReq.Ranks = stage
+		fResp.Results = nil
+
+		var err error
+		fResp, _, err = svc.rpcFanout(ctx, fReq, fResp, true)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fResp.Results...)
+		if fResp.Results.Errors() != nil {
+			break
+		}
+	}
+	fResp.Results = results
+
+	return fResp, nil
+}
+
+// orderlyStop stops all ranks in the system, with ranks hosted on MS replicas
+// stopped last, and records a clean shutdown on success.
+func (svc *mgmtSvc) orderlyStop(ctx context.Context, req *mgmtpb.SystemStopReq) (*mgmtpb.SystemStopResp, error) {
+	if req.Hosts != "" || req.Ranks != "" {
+		return nil, errors.New("orderly shutdown must include all ranks")
+	}
+	if req.Force {
+		return nil, errors.New("orderly shutdown may not be forced")
+	}
+
+	marker, err := svc.getCleanShutdown()
+	if err != nil {
+		return nil, err
+	}
+	if marker != nil {
+		return nil, errors.Errorf("orderly shutdown already recorded at %s",
+			common.FormatTime(marker.Timestamp))
+	}
+
+	fReq, fResp, err := svc.getFanout(req)
+	if err != nil {
+		return nil, err
+	}
+	replicas, others, err := svc.splitReplicaRanks(fReq.Ranks)
+	if err != nil {
+		return nil, err
+	}
+
+	fReq.Method = control.PrepShutdownRanks
+	fResp, _, err = svc.rpcFanout(ctx, fReq, fResp, true)
+	if err != nil {
+		return nil, err
+	}
+	if fResp.Results.Errors() != nil {
+		return processStopResp("prep shutdown", fResp, svc.events)
+	}
+
+	svc.log.Debugf("orderly shutdown: stopping ranks %s, then MS replica ranks %s", others, replicas)
+	fReq.Method = control.StopRanks
+	fResp, err = svc.fanoutStages(ctx, fReq, fResp, others, replicas)
+	if err != nil {
+		return nil, err
+	}
+	if fResp.Results.Errors() != nil {
+		return processStopResp("stop", fResp, svc.events)
+	}
+
+	if err := svc.recordCleanShutdown(); err != nil {
+		return nil, err
+	}
+	svc.log.Noticef("orderly shutdown of system %q complete", svc.sysdb.SystemName())
+
+	return processStopResp("stop", fResp, svc.events)
+}
+
+// orderlyStart validates that the membership is unchanged since the clean
+// shutdown and that the recorded hosts still report it, clears the administrative exclusions set by the shutdown and then
+// starts all ranks, with ranks hosted on MS replicas started first.
+func (svc *mgmtSvc) orderlyStart(ctx context.Context, req *mgmtpb.SystemStartReq) (*mgmtpb.SystemStartResp, error) {
+	if req.Hosts != "" || req.Ranks != "" {
+		return nil, errors.New("orderly startup must include all ranks")
+	}
+
+	marker, err := svc.getCleanShutdown()
+	if err != nil {
+		return nil, err
+	}
+	if marker == nil {
+		return nil, errors.New("no orderly shutdown recorded")
+	}
+	if err := svc.checkCleanShutdownMembers(marker); err != nil {
+		return nil, err
+	}
+	if err := svc.checkCleanShutdownHosts(ctx, marker); err != nil {
+		return nil, err
+	}
+
+	for _, r := range marker.Excluded {
+		m, err := svc.sysdb.FindMemberByRank(r)
+		if err != nil {
+			return nil, err
+		}
+		if m.State != system.MemberStateAdminExcluded {
+			continue
+		}
+		m.SetState(system.MemberStateExcluded, "orderly system start") // cleared on rejoin
+		if err := svc.sysdb.UpdateMember(m); err != nil {
+			return nil, err
+		}
+	}
+	if err := system.DelMgmtProperty(svc.sysdb, cleanShutdownProp); err != nil {
+		return nil, errors.Wrap(err, "clearing clean shutdown")
+	}
+
+	fReq, fResp, err := svc.getFanout(req)
+	if err != nil {
+		return nil, err
+	}
+	replicas, others, err := svc.splitReplicaRanks(fReq.Ranks)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Debugf("orderly startup: starting MS replica ranks %s, then ranks %s", replicas, others)
+	fReq.CheckMode = req.CheckMode
+	fReq.Method = control.StartRanks
+	fResp, err = svc.fanoutStages(ctx, fReq, fResp, replicas, others)
+	if err != nil {
+		return nil, err
+	}
+
+	return processStartResp(fResp, svc.events)
+}
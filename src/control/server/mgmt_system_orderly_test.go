@@ -0,0 +1,246 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_recordCleanShutdown(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	svc := mgmtSystemTestSetup(t, log, system.Members{
+		mockMember(t, 0, 1, "stopped"),
+		mockMember(t, 1, 1, "adminexcluded"),
+		mockMember(t, 2, 2, "stopped"),
+	}, []*control.HostResponse{})
+
+	marker, err := svc.getCleanShutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker != nil {
+		t.Fatal("unexpected clean shutdown marker")
+	}
+
+	if err := svc.recordCleanShutdown(); err != nil {
+		t.Fatal(err)
+	}
+
+	marker, err = svc.getCleanShutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker == nil {
+		t.Fatal("expected clean shutdown marker")
+	}
+	test.AssertEqual(t, 3, len(marker.Members), "unexpected number of members")
+	test.AssertEqual(t, []ranklist.Rank{0, 2}, marker.Excluded, "unexpected excluded ranks")
+
+	checkMembers(t, system.Members{
+		mockMember(t, 0, 1, "adminexcluded"),
+		mockMember(t, 1, 1, "adminexcluded"),
+		mockMember(t, 2, 2, "adminexcluded"),
+	}, svc.membership)
+
+	if err := svc.checkCleanShutdownMembers(marker); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.membership.Add(mockMember(t, 3, 2, "joined")); err != nil {
+		t.Fatal(err)
+	}
+	test.CmpErr(t, errors.New("new ranks 3"), svc.checkCleanShutdownMembers(marker))
+}
+
+func TestServer_MgmtSvc_checkCleanShutdownHosts(t *testing.T) {
+	stopped := func(r uint32, uuid string) *sharedpb.RankResult {
+		return &sharedpb.RankResult{
+			Rank:   r,
+			Action: "stop",
+			State:  stateString(system.MemberStateStopped),
+			Uuid:   uuid,
+		}
+	}
+	hostResp := func(n int32, results ...*sharedpb.RankResult) *control.HostResponse {
+		return &control.HostResponse{
+			Addr:    test.MockHostAddr(n).String(),
+			Message: &ctlpb.RanksResp{Results: results},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		hostResps []*control.HostResponse
+		expErr    error
+	}{
+		"same hosts and ranks": {
+			hostResps: []*control.HostResponse{
+				hostResp(1, stopped(0, test.MockUUID(0))),
+				hostResp(2, stopped(1, test.MockUUID(1))),
+			},
+		},
+		"host unreachable": {
+			hostResps: []*control.HostResponse{
+				hostResp(1, stopped(0, test.MockUUID(0))),
+				{
+					Addr:  test.MockHostAddr(2).String(),
+					Error: errors.New("connection refused"),
+				},
+			},
+			expErr: errors.New("unreachable hosts"),
+		},
+		"rank not reported": {
+			hostResps: []*control.HostResponse{
+				hostResp(1, stopped(0, test.MockUUID(0))),
+				hostResp(2),
+			},
+			expErr: errors.New("unreported ranks 1"),
+		},
+		"rank UUID changed": {
+			hostResps: []*control.HostResponse{
+				hostResp(1, stopped(0, test.MockUUID(0))),
+				hostResp(2, stopped(1, test.MockUUID(2))),
+			},
+			expErr: errors.New("changed ranks 1"),
+		},
+		"rank failed to stop": {
+			hostResps: []*control.HostResponse{
+				hostResp(1, stopped(0, test.MockUUID(0))),
+				hostResp(2, mockRankFail("stop", 1)),
+			},
+			expErr: errors.New("rank 1: stop failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, system.Members{
+				mockMember(t, 0, 1, "stopped"),
+				mockMember(t, 1, 2, "stopped"),
+			}, tc.hostResps)
+			if err := svc.recordCleanShutdown(); err != nil {
+				t.Fatal(err)
+			}
+			marker, err := svc.getCleanShutdown()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotErr := svc.checkCleanShutdownHosts(test.Context(t), marker)
+			test.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemStop_Orderly(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.SystemStopReq
+		recorded  bool
+		expAPIErr error
+	}{
+		"ranks specified": {
+			req:       &mgmtpb.SystemStopReq{Orderly: true, Ranks: "0"},
+			expAPIErr: errors.New("must include all ranks"),
+		},
+		"forced": {
+			req:       &mgmtpb.SystemStopReq{Orderly: true, Force: true},
+			expAPIErr: errors.New("may not be forced"),
+		},
+		"already recorded": {
+			req:       &mgmtpb.SystemStopReq{Orderly: true},
+			recorded:  true,
+			expAPIErr: errors.New("already recorded"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, system.Members{
+				mockMember(t, 0, 1, "stopped"),
+				mockMember(t, 1, 2, "stopped"),
+			}, []*control.HostResponse{})
+			if tc.recorded {
+				if err := svc.recordCleanShutdown(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tc.req.Sys = build.DefaultSystemName
+			_, gotAPIErr := svc.SystemStop(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expAPIErr, gotAPIErr)
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemStart_Orderly(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.SystemStartReq
+		recorded  bool
+		addMember *system.Member
+		expAPIErr error
+	}{
+		"ranks specified": {
+			req:       &mgmtpb.SystemStartReq{Orderly: true, Ranks: "0"},
+			recorded:  true,
+			expAPIErr: errors.New("must include all ranks"),
+		},
+		"no shutdown recorded": {
+			req:       &mgmtpb.SystemStartReq{Orderly: true},
+			expAPIErr: errors.New("no orderly shutdown recorded"),
+		},
+		"membership changed": {
+			req:       &mgmtpb.SystemStartReq{Orderly: true},
+			recorded:  true,
+			addMember: mockMember(t, 2, 3, "joined"),
+			expAPIErr: errors.New("new ranks 2"),
+		},
+		"regular start after orderly shutdown": {
+			req:       &mgmtpb.SystemStartReq{},
+			recorded:  true,
+			expAPIErr: errors.New("use an orderly startup"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, system.Members{
+				mockMember(t, 0, 1, "stopped"),
+				mockMember(t, 1, 2, "stopped"),
+			}, []*control.HostResponse{})
+			if tc.recorded {
+				if err := svc.recordCleanShutdown(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.addMember != nil {
+				if _, err := svc.membership.Add(tc.addMember); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tc.req.Sys = build.DefaultSystemName
+			_, gotAPIErr := svc.SystemStart(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expAPIErr, gotAPIErr)
+		})
+	}
+}
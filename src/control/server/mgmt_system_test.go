@@ -35,6 +35,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/hardware"
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 	"github.com/daos-stack/daos/src/control/system/raft"
@@ -54,6 +55,10 @@ func act2state(a string) string {
 		return stateString(system.MemberStateAdminExcluded)
 	case "clear admin-excluded state":
 		return stateString(system.MemberStateExcluded)
+	case "set quarantined state":
+		return stateString(system.MemberStateQuarantined)
+	case "clear quarantined state":
+		return stateString(system.MemberStateJoined)
 	default:
 		return ""
 	}
@@ -88,6 +93,11 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 	msReplica := system.MockMember(t, 0, system.MemberStateJoined)
 	nonReplica := system.MockMember(t, 1, system.MemberStateJoined)
 
+	var expFeatures []string
+	for _, feature := range build.ServerFeatures() {
+		expFeatures = append(expFeatures, feature.String())
+	}
+
 	for name, tc := range map[string]struct {
 		svc               *mgmtSvc
 		clientNetworkHint *mgmtpb.ClientNetHint
@@ -125,6 +135,7 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 				MsRanks:     []uint32{0},
 				DataVersion: 2,
 				Sys:         build.DefaultSystemName,
+				Features:    expFeatures,
 			},
 		},
 		"Server uses TCP sockets + Ethernet": {
@@ -158,6 +169,7 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 				MsRanks:     []uint32{0},
 				DataVersion: 2,
 				Sys:         build.DefaultSystemName,
+				Features:    expFeatures,
 			},
 		},
 		"older client (AllRanks: false)": {
@@ -185,6 +197,7 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 				MsRanks:     []uint32{0},
 				DataVersion: 2,
 				Sys:         build.DefaultSystemName,
+				Features:    expFeatures,
 			},
 		},
 	} {
@@ -458,6 +471,7 @@ func mockMember(t *testing.T, r, a int32, s string) *system.Member {
 		"unknown":       system.MemberStateUnknown,
 		"unresponsive":  system.MemberStateUnresponsive,
 		"adminexcluded": system.MemberStateAdminExcluded,
+		"quarantined":   system.MemberStateQuarantined,
 	}[s]
 
 	if state == system.MemberStateUnknown && s != "unknown" {
@@ -495,6 +509,10 @@ func checkMembers(t *testing.T, exp system.Members, ms *system.Membership) {
 		}
 		cmpOpts := append(test.DefaultCmpOpts(),
 			cmpopts.EquateApproxTime(time.Second),
+			// start and stop counts and state change causes are verified by the
+			// membership tests
+			cmpopts.IgnoreFields(system.Member{}, "Starts", "LastStart", "Exits", "Deaths",
+				"Stops", "LastStopReason", "StateCause", "LastStateChange"),
 		)
 		if diff := cmp.Diff(em, am, cmpOpts...); diff != "" {
 			t.Fatalf("unexpected members (-want, +got)\n%s\n", diff)
@@ -1805,6 +1823,200 @@ func TestServer_MgmtSvc_SystemExclude(t *testing.T) {
 
 			checkRankResults(t, tc.expResults, gotResp.Results)
 			checkMembers(t, tc.expMembers, svc.membership)
+
+			for _, rr := range gotResp.Results {
+				m, err := svc.membership.Get(ranklist.Rank(rr.Rank))
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, rr.Action, m.StateCause, "unexpected state change cause")
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemQuarantine(t *testing.T) {
+	defReason := "quarantined by administrator"
+
+	for name, tc := range map[string]struct {
+		req        *mgmtpb.SystemQuarantineReq
+		members    system.Members
+		expMembers system.Members
+		expResults []*sharedpb.RankResult
+		expAPIErr  error
+	}{
+		"nil req": {
+			req:       (*mgmtpb.SystemQuarantineReq)(nil),
+			expAPIErr: errors.New("nil request"),
+		},
+		"not system leader": {
+			req: &mgmtpb.SystemQuarantineReq{
+				Sys: "quack",
+			},
+			expAPIErr: FaultWrongSystem("quack", build.DefaultSystemName),
+		},
+		"no hosts or ranks": {
+			req: &mgmtpb.SystemQuarantineReq{},
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+			},
+			expAPIErr: errors.New("no hosts or ranks"),
+		},
+		"invalid ranks": {
+			req:       &mgmtpb.SystemQuarantineReq{Ranks: "41,42"},
+			expAPIErr: errors.New("invalid"),
+		},
+		"quarantine ranks": {
+			req: &mgmtpb.SystemQuarantineReq{Ranks: "0-1"},
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "joined"),
+				mockMember(t, 3, 2, "joined"),
+			},
+			expResults: []*sharedpb.RankResult{
+				mockRankSuccess("set quarantined state", 0, 1),
+				mockRankSuccess("set quarantined state", 1, 1),
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "quarantined").WithInfo(defReason),
+				mockMember(t, 1, 1, "quarantined").WithInfo(defReason),
+				mockMember(t, 2, 2, "joined"),
+				mockMember(t, 3, 2, "joined"),
+			},
+		},
+		"quarantine hosts with reason": {
+			req: &mgmtpb.SystemQuarantineReq{
+				Hosts:  test.MockHostAddr(2).String(),
+				Reason: "suspect nic",
+			},
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "joined"),
+				mockMember(t, 3, 2, "joined"),
+			},
+			expResults: []*sharedpb.RankResult{
+				mockRankSuccess("set quarantined state", 2, 2),
+				mockRankSuccess("set quarantined state", 3, 2),
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "quarantined").WithInfo("suspect nic"),
+				mockMember(t, 3, 2, "quarantined").WithInfo("suspect nic"),
+			},
+		},
+		"quarantine stopped rank": {
+			req: &mgmtpb.SystemQuarantineReq{Ranks: "0-1"},
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
+			},
+			expResults: []*sharedpb.RankResult{
+				mockRankSuccess("set quarantined state", 0, 1),
+				{
+					Rank:    1,
+					Action:  "set quarantined state",
+					Errored: true,
+					Msg:     "rank 1 in state Stopped can't be quarantined",
+					State:   stateString(system.MemberStateStopped),
+					Addr:    test.MockHostAddr(1).String(),
+				},
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "quarantined").WithInfo(defReason),
+				mockMember(t, 1, 1, "stopped"),
+			},
+		},
+		"clear quarantine": {
+			req: &mgmtpb.SystemQuarantineReq{Ranks: "0-1", Clear: true},
+			members: system.Members{
+				mockMember(t, 0, 1, "quarantined").WithInfo(defReason),
+				mockMember(t, 1, 1, "quarantined").WithInfo(defReason),
+				mockMember(t, 2, 2, "joined"),
+			},
+			expResults: []*sharedpb.RankResult{
+				mockRankSuccess("clear quarantined state", 0, 1),
+				mockRankSuccess("clear quarantined state", 1, 1),
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "joined"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, tc.members, nil)
+
+			ctx := test.Context(t)
+			if tc.req != nil && tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+			gotResp, gotAPIErr := svc.SystemQuarantine(ctx, tc.req)
+			test.CmpErr(t, tc.expAPIErr, gotAPIErr)
+			if tc.expAPIErr != nil {
+				return
+			}
+
+			checkRankResults(t, tc.expResults, gotResp.Results)
+			checkMembers(t, tc.expMembers, svc.membership)
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemRankUUIDs(t *testing.T) {
+	members := system.Members{
+		mockMember(t, 0, 1, "joined"),
+		mockMember(t, 1, 1, "stopped"),
+	}
+
+	for name, tc := range map[string]struct {
+		req       *mgmtpb.SystemRankUUIDsReq
+		expResp   *mgmtpb.SystemRankUUIDsResp
+		expAPIErr error
+	}{
+		"nil req": {
+			req:       (*mgmtpb.SystemRankUUIDsReq)(nil),
+			expAPIErr: errors.New("nil request"),
+		},
+		"wrong system": {
+			req:       &mgmtpb.SystemRankUUIDsReq{Sys: "quack"},
+			expAPIErr: FaultWrongSystem("quack", build.DefaultSystemName),
+		},
+		"unknown ranks omitted": {
+			req: &mgmtpb.SystemRankUUIDsReq{Ranks: []uint32{0, 1, 42}},
+			expResp: &mgmtpb.SystemRankUUIDsResp{
+				Uuids: map[uint32]string{
+					0: members[0].UUID.String(),
+					1: members[1].UUID.String(),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, members, nil)
+
+			if tc.req != nil && tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+			gotResp, gotAPIErr := svc.SystemRankUUIDs(test.Context(t), tc.req)
+			test.CmpErr(t, tc.expAPIErr, gotAPIErr)
+			if tc.expAPIErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
 		})
 	}
 }
@@ -1827,12 +2039,20 @@ func TestServer_MgmtSvc_SystemErase(t *testing.T) {
 		expResults     []*sharedpb.RankResult
 		expAbsentRanks string
 		expAbsentHosts string
+		notArmed       bool
 		expErrMsg      string
 	}{
 		"nil req": {
 			nilReq:    true,
 			expErrMsg: "nil request",
 		},
+		"not armed": {
+			members: system.Members{
+				mockMember(t, 0, 1, "stopped"),
+			},
+			notArmed:  true,
+			expErrMsg: "system erase must be armed before it can be performed",
+		},
 		"unfiltered rank results": {
 			members: system.Members{
 				mockMember(t, 0, 1, "stopped"),
@@ -1909,6 +2129,9 @@ func TestServer_MgmtSvc_SystemErase(t *testing.T) {
 			req := &mgmtpb.SystemEraseReq{
 				Sys: build.DefaultSystemName,
 			}
+			if !tc.notArmed {
+				req.Token, _ = svc.armer.arm(control.SystemOpErase)
+			}
 			if tc.nilReq {
 				req = nil
 			}
@@ -1934,9 +2157,11 @@ func TestServer_MgmtSvc_Join(t *testing.T) {
 	for name, tc := range map[string]struct {
 		req              *mgmtpb.JoinReq
 		pauseGroupUpdate bool
+		admissionHook    admissionHookFn
 		guResp           *mgmtpb.GroupUpdateResp
 		expGuReq         *mgmtpb.GroupUpdateReq
 		expResp          *mgmtpb.JoinResp
+		expEvent         events.RASID
 		expErr           error
 	}{
 		"bad sys": {
@@ -1962,7 +2187,8 @@ func TestServer_MgmtSvc_Join(t *testing.T) {
 				Rank: curMember.Rank.Uint32(),
 				Uuid: test.MockUUID(5),
 			},
-			expErr: errors.New("uuid changed"),
+			expEvent: events.RASEngineSuperblockMismatch,
+			expErr:   errors.New("uuid changed"),
 		},
 		"dupe host diff rank same uuid": {
 			req: &mgmtpb.JoinReq{
@@ -2039,6 +2265,26 @@ func TestServer_MgmtSvc_Join(t *testing.T) {
 			},
 			expErr: errors.New("does not match"),
 		},
+		"rejected by admission hook": {
+			req: &mgmtpb.JoinReq{
+				Rank:        uint32(ranklist.NilRank),
+				Incarnation: newMember.Incarnation,
+			},
+			admissionHook: func(_ context.Context, _ []byte) error {
+				return &admissionRejectedErr{reason: "unknown hardware"}
+			},
+			expErr: errors.New("rejected by admission hook: unknown hardware"),
+		},
+		"admission hook fails": {
+			req: &mgmtpb.JoinReq{
+				Rank:        uint32(ranklist.NilRank),
+				Incarnation: newMember.Incarnation,
+			},
+			admissionHook: func(_ context.Context, _ []byte) error {
+				return errors.New("connection refused")
+			},
+			expErr: errors.New("admission hook failed: connection refused"),
+		},
 		"group update resumed": {
 			pauseGroupUpdate: true,
 			req: &mgmtpb.JoinReq{
@@ -2128,6 +2374,13 @@ func TestServer_MgmtSvc_Join(t *testing.T) {
 			if tc.pauseGroupUpdate {
 				svc.pauseGroupUpdate()
 			}
+			if tc.admissionHook != nil {
+				svc.joinAdmitter = &joinAdmitter{
+					log:  log,
+					cfg:  (&config.JoinAdmission{}).WithDefaults(),
+					hook: tc.admissionHook,
+				}
+			}
 
 			if tc.req.Sys == "" {
 				tc.req.Sys = build.DefaultSystemName
@@ -2159,8 +2412,22 @@ func TestServer_MgmtSvc_Join(t *testing.T) {
 			mdc := getMockDrpcClient(tc.guResp, nil)
 			setupSvcDrpcClient(svc, 0, mdc)
 
+			evtCtx, cancel := context.WithTimeout(test.Context(t), 50*time.Millisecond)
+			defer cancel()
+			ps := events.NewPubSub(evtCtx, log)
+			defer ps.Close()
+			svc.events = ps
+			dispatched := &eventsDispatched{cancel: cancel}
+			svc.events.Subscribe(events.RASTypeInfoOnly, dispatched)
+
 			gotResp, gotErr := svc.Join(peerCtx, tc.req)
 			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expEvent != 0 {
+				<-evtCtx.Done()
+				if len(dispatched.rx) != 1 || dispatched.rx[0].ID != tc.expEvent {
+					t.Fatalf("expected %s event, got %+v", tc.expEvent, dispatched.rx)
+				}
+			}
 			if tc.expErr != nil {
 				return
 			}
@@ -2717,3 +2984,221 @@ func TestMgmtSvc_resumeGroupUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_MgmtSvc_SystemHistory(t *testing.T) {
+	poolUUID := test.MockPoolUUID(1)
+
+	for name, tc := range map[string]struct {
+		poolState   system.PoolServiceState
+		queryResp   *mgmtpb.PoolQueryResp
+		queryErr    error
+		maxEntries  uint32
+		numRecorded int
+		expPool     *mgmtpb.SystemHealthSnapshot_PoolSummary
+		expCount    int
+	}{
+		"no snapshots": {
+			poolState: system.PoolServiceStateReady,
+		},
+		"pool not ready; not queried": {
+			poolState:   system.PoolServiceStateCreating,
+			numRecorded: 1,
+			expPool: &mgmtpb.SystemHealthSnapshot_PoolSummary{
+				Uuid:  poolUUID.String(),
+				Label: "0",
+				State: "Creating",
+			},
+			expCount: 1,
+		},
+		"pool query fails": {
+			poolState:   system.PoolServiceStateReady,
+			queryErr:    errors.New("query failed"),
+			numRecorded: 1,
+			expPool: &mgmtpb.SystemHealthSnapshot_PoolSummary{
+				Uuid:       poolUUID.String(),
+				Label:      "0",
+				State:      "Ready",
+				QueryError: "query failed",
+			},
+			expCount: 1,
+		},
+		"pool query returns error status": {
+			poolState: system.PoolServiceStateReady,
+			queryResp: &mgmtpb.PoolQueryResp{
+				Status: int32(daos.TimedOut),
+			},
+			numRecorded: 1,
+			expPool: &mgmtpb.SystemHealthSnapshot_PoolSummary{
+				Uuid:       poolUUID.String(),
+				Label:      "0",
+				State:      "Ready",
+				QueryError: daos.TimedOut.Error(),
+			},
+			expCount: 1,
+		},
+		"most recent snapshots": {
+			poolState: system.PoolServiceStateReady,
+			queryResp: &mgmtpb.PoolQueryResp{
+				State:           mgmtpb.PoolServiceState_Degraded,
+				TotalTargets:    8,
+				DisabledTargets: 2,
+				Rebuild: &mgmtpb.PoolRebuildStatus{
+					State: mgmtpb.PoolRebuildStatus_BUSY,
+				},
+				TierStats: []*mgmtpb.StorageUsageStats{
+					{Total: 100, Free: 60},
+					{Total: 1000, Free: 400, MediaType: mgmtpb.StorageMediaType_NVME},
+				},
+			},
+			maxEntries:  2,
+			numRecorded: 3,
+			expPool: &mgmtpb.SystemHealthSnapshot_PoolSummary{
+				Uuid:            poolUUID.String(),
+				Label:           "0",
+				State:           "Degraded",
+				TotalTargets:    8,
+				DisabledTargets: 2,
+				RebuildState:    "busy",
+				TotalBytes:      1100,
+				FreeBytes:       460,
+				Tiers: []*mgmtpb.SystemHealthSnapshot_TierUsage{
+					{MediaType: "scm", TotalBytes: 100, FreeBytes: 60},
+					{MediaType: "nvme", TotalBytes: 1000, FreeBytes: 400},
+				},
+			},
+			expCount: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			addTestPoolService(t, svc.sysdb, &system.PoolService{
+				PoolUUID:  poolUUID,
+				PoolLabel: "0",
+				State:     tc.poolState,
+				Replicas:  []ranklist.Rank{0},
+			})
+			if tc.queryResp == nil {
+				tc.queryResp = &mgmtpb.PoolQueryResp{}
+			}
+			setupMockDrpcClient(svc, tc.queryResp, tc.queryErr)
+
+			for i := 0; i < tc.numRecorded; i++ {
+				if err := svc.recordHealthSnapshot(test.Context(t)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := svc.SystemHistory(test.Context(t), &mgmtpb.SystemHistoryReq{
+				Sys:        build.DefaultSystemName,
+				MaxEntries: tc.maxEntries,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(resp.Snapshots) != tc.expCount {
+				t.Fatalf("expected %d snapshots, got %d", tc.expCount, len(resp.Snapshots))
+			}
+			for _, snap := range resp.Snapshots {
+				// The dRPC failure is wrapped with transport details,
+				// so only verify that the cause was recorded.
+				for _, ps := range snap.Pools {
+					if tc.expPool.QueryError != "" && strings.Contains(ps.QueryError, tc.expPool.QueryError) {
+						ps.QueryError = tc.expPool.QueryError
+					}
+				}
+
+				expStates := map[string]string{"Joined": "0"}
+				if diff := cmp.Diff(expStates, snap.MemberStates); diff != "" {
+					t.Fatalf("unexpected member states (-want, +got):\n%s\n", diff)
+				}
+				if diff := cmp.Diff([]*mgmtpb.SystemHealthSnapshot_PoolSummary{tc.expPool},
+					snap.Pools, protocmp.Transform()); diff != "" {
+					t.Fatalf("unexpected pool summaries (-want, +got):\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemEvents(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ackIDs     []uint64
+		activeOnly bool
+		expAckErr  error
+		expIDs     []uint64
+	}{
+		"all events": {
+			expIDs: []uint64{1, 2},
+		},
+		"active after ack": {
+			ackIDs:     []uint64{1},
+			activeOnly: true,
+			expIDs:     []uint64{2},
+		},
+		"acknowledged events included": {
+			ackIDs: []uint64{2},
+			expIDs: []uint64{1, 2},
+		},
+		"unknown event": {
+			ackIDs:     []uint64{3},
+			activeOnly: true,
+			expAckErr:  system.ErrEventNotFound(3),
+			expIDs:     []uint64{1, 2},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			for _, evt := range []*events.RASEvent{
+				events.NewEngineDiedEvent("foo", 0, 0, common.NormalExit, 1234),
+				events.NewEngineDiedEvent("foo", 1, 1, common.NormalExit, 1235),
+			} {
+				if err := svc.sysdb.AddEvent(system.NewPersistentEvent(evt)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if len(tc.ackIDs) > 0 {
+				_, err := svc.SystemEventAck(test.Context(t), &mgmtpb.SystemEventAckReq{
+					Sys: build.DefaultSystemName,
+					Ids: tc.ackIDs,
+				})
+				test.CmpErr(t, tc.expAckErr, err)
+			}
+
+			resp, err := svc.SystemEvents(test.Context(t), &mgmtpb.SystemEventsReq{
+				Sys:        build.DefaultSystemName,
+				ActiveOnly: tc.activeOnly,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotIDs []uint64
+			for _, pbEvt := range resp.Events {
+				gotIDs = append(gotIDs, pbEvt.Id)
+				if pbEvt.Hostname != "foo" || pbEvt.Count != 1 {
+					t.Fatalf("unexpected event: %+v", pbEvt)
+				}
+				acked := false
+				for _, id := range tc.ackIDs {
+					if id == pbEvt.Id && tc.expAckErr == nil {
+						acked = true
+					}
+				}
+				if pbEvt.Acknowledged != acked || (pbEvt.AckTime != 0) != acked {
+					t.Fatalf("unexpected ack state for event %d: %+v", pbEvt.Id, pbEvt)
+				}
+			}
+			if diff := cmp.Diff(tc.expIDs, gotIDs); diff != "" {
+				t.Fatalf("unexpected event IDs (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// portCheckTimeout bounds the time that a starting server waits for the MS
+// replicas to probe its ports.
+const portCheckTimeout = 10 * time.Second
+
+type (
+	// checkedPort describes a port that the server or its engines will
+	// listen on and which must be reachable from the MS replicas.
+	checkedPort struct {
+		desc string
+		// bindAddr is the address to listen on for the duration of
+		// the check, or empty if the port is already being served.
+		bindAddr string
+		// addr is the address that the MS replicas connect to.
+		addr string
+	}
+
+	portProbeFn func(context.Context, control.UnaryInvoker, *control.PortProbeReq) (*control.PortProbeResp, error)
+
+	portCheckParams struct {
+		log       logging.Logger
+		cfg       *config.Server
+		ctlAddr   *net.TCPAddr
+		lookupIF  ifLookupFn
+		listen    netListenFn
+		rpcClient control.UnaryInvoker
+		probe     portProbeFn
+	}
+)
+
+// isIPFabricProvider returns true if the provider communicates over TCP/IP,
+// in which case its port may be blocked by a firewall.
+func isIPFabricProvider(provider string) bool {
+	return strings.Contains(provider, "tcp") || strings.Contains(provider, "sockets")
+}
+
+func getInterfaceIPv4(lookup ifLookupFn, name string) (net.IP, error) {
+	netIF, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := netIF.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+	}
+
+	return nil, errors.Errorf("no IPv4 address for interface %q", name)
+}
+
+// getCheckedPorts returns the control, telemetry and TCP/IP-based fabric ports
+// described by the server configuration.
+func getCheckedPorts(cfg *config.Server, ctlAddr *net.TCPAddr, lookup ifLookupFn) ([]*checkedPort, error) {
+	ports := []*checkedPort{
+		{
+			desc: "control port",
+			addr: ctlAddr.String(),
+		},
+	}
+
+	if cfg.TelemetryPort > 0 {
+		ports = append(ports, &checkedPort{
+			desc:     "telemetry port",
+			bindAddr: fmt.Sprintf(":%d", cfg.TelemetryPort),
+			addr:     net.JoinHostPort(ctlAddr.IP.String(), strconv.Itoa(cfg.TelemetryPort)),
+		})
+	}
+
+	for idx, ec := range cfg.Engines {
+		provider, err := ec.Fabric.GetPrimaryProvider()
+		if err != nil {
+			return nil, err
+		}
+		if !isIPFabricProvider(provider) || ec.Fabric.InterfacePort == 0 {
+			continue
+		}
+
+		iface, err := ec.Fabric.GetPrimaryInterface()
+		if err != nil {
+			return nil, err
+		}
+		ip, err := getInterfaceIPv4(lookup, iface)
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d", idx)
+		}
+
+		addr := net.JoinHostPort(ip.String(), strconv.Itoa(ec.Fabric.InterfacePort))
+		ports = append(ports, &checkedPort{
+			desc:     fmt.Sprintf("engine %d fabric port (%s on %s)", idx, provider, iface),
+			bindAddr: addr,
+			addr:     addr,
+		})
+	}
+
+	return ports, nil
+}
+
+// serveCheckedPorts listens on each port that is not already being served so
+// that the MS replicas are able to connect to it. Ports that cannot be bound
+// are reported and left out of the returned list.
+func serveCheckedPorts(log logging.Logger, ports []*checkedPort, listen netListenFn) ([]*checkedPort, func()) {
+	var listeners []net.Listener
+	var bound []*checkedPort
+
+	for _, port := range ports {
+		if port.bindAddr == "" {
+			bound = append(bound, port)
+			continue
+		}
+
+		lis, err := listen("tcp", port.bindAddr)
+		if err != nil {
+			log.Errorf("%s %s cannot be bound: %s", port.desc, port.bindAddr, err)
+			continue
+		}
+		listeners = append(listeners, lis)
+		bound = append(bound, port)
+
+		go func() {
+			for {
+				conn, err := lis.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+	}
+
+	return bound, func() {
+		for _, lis := range listeners {
+			lis.Close()
+		}
+	}
+}
+
+// checkPorts verifies that the ports used by the server and its engines can
+// be bound locally and reached from the MS replicas, so that a firewall
+// misconfiguration is reported precisely rather than as a join timeout.
+// Problems are logged but are not fatal.
+func checkPorts(ctx context.Context, params *portCheckParams) {
+	log := params.log
+
+	ports, err := getCheckedPorts(params.cfg, params.ctlAddr, params.lookupIF)
+	if err != nil {
+		log.Errorf("port check: %s", err)
+		return
+	}
+
+	bound, cleanup := serveCheckedPorts(log, ports, params.listen)
+	defer cleanup()
+
+	if len(params.cfg.AccessPoints) == 0 || len(bound) == 0 {
+		return
+	}
+
+	req := &control.PortProbeReq{}
+	req.SetHostList(params.cfg.AccessPoints)
+	req.SetSystem(params.cfg.SystemName)
+	req.SetTimeout(portCheckTimeout)
+	for _, port := range bound {
+		req.Addrs = append(req.Addrs, port.addr)
+	}
+
+	resp, err := params.probe(ctx, params.rpcClient, req)
+	if err != nil {
+		log.Noticef("port check skipped: %s", err)
+		return
+	}
+
+	for _, hes := range resp.GetHostErrors() {
+		log.Debugf("port check not performed by %s: %s", hes.HostSet, hes.HostError)
+	}
+	if len(resp.Results) == 0 {
+		log.Notice("port check skipped: no MS replica was available")
+		return
+	}
+
+	for replica, results := range resp.Results {
+		for i, result := range results {
+			if result.Error == "" || i >= len(bound) {
+				continue
+			}
+			log.Errorf("%s %s is not reachable from MS replica %s (check firewall rules): %s",
+				bound[i].desc, result.Addr, replica, result.Error)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func portCheckTestLookup(name string) (netInterface, error) {
+	switch name {
+	case "eth0":
+		return &mockInterface{
+			addrs: []net.Addr{
+				&net.IPNet{IP: net.ParseIP("fe80::1")},
+				&net.IPNet{IP: net.ParseIP("10.0.1.1")},
+			},
+		}, nil
+	case "eth1":
+		return &mockInterface{
+			addrs: []net.Addr{&net.IPNet{IP: net.ParseIP("fe80::2")}},
+		}, nil
+	}
+	return nil, errors.Errorf("unknown interface %q", name)
+}
+
+func portCheckTestConfig(provider, iface string) *config.Server {
+	return config.DefaultServer().
+		WithAccessPoints("10.0.0.1:10001").
+		WithEngines(engine.MockConfig().
+			WithFabricProvider(provider).
+			WithFabricInterface(iface).
+			WithFabricInterfacePort(31416))
+}
+
+func TestServer_getCheckedPorts(t *testing.T) {
+	ctlAddr := &net.TCPAddr{IP: net.IPv4zero, Port: 10001}
+
+	for name, tc := range map[string]struct {
+		cfg      *config.Server
+		expPorts []*checkedPort
+		expErr   error
+	}{
+		"tcp provider with telemetry": {
+			cfg: portCheckTestConfig("ofi+tcp", "eth0").WithTelemetryPort(9191),
+			expPorts: []*checkedPort{
+				{desc: "control port", addr: "0.0.0.0:10001"},
+				{desc: "telemetry port", bindAddr: ":9191", addr: "0.0.0.0:9191"},
+				{
+					desc:     "engine 0 fabric port (ofi+tcp on eth0)",
+					bindAddr: "10.0.1.1:31416",
+					addr:     "10.0.1.1:31416",
+				},
+			},
+		},
+		"verbs provider skipped": {
+			cfg: portCheckTestConfig("ofi+verbs;ofi_rxm", "ib0"),
+			expPorts: []*checkedPort{
+				{desc: "control port", addr: "0.0.0.0:10001"},
+			},
+		},
+		"interface without IPv4 address": {
+			cfg:    portCheckTestConfig("ofi+tcp", "eth1"),
+			expErr: errors.New("engine 0: no IPv4 address for interface \"eth1\""),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotPorts, gotErr := getCheckedPorts(tc.cfg, ctlAddr, portCheckTestLookup)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expPorts, gotPorts, cmp.AllowUnexported(checkedPort{})); diff != "" {
+				t.Fatalf("unexpected ports (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+type mockListener struct {
+	net.Listener
+	closed bool
+}
+
+func (ml *mockListener) Accept() (net.Conn, error) {
+	return nil, errors.New("closed")
+}
+
+func (ml *mockListener) Close() error {
+	ml.closed = true
+	return nil
+}
+
+func TestServer_checkPorts(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg       *config.Server
+		listenErr error
+		probeResp *control.PortProbeResp
+		probeErr  error
+		expAddrs  []string
+		expLogs   []string
+	}{
+		"no access points": {
+			cfg: portCheckTestConfig("ofi+tcp", "eth0").WithAccessPoints(),
+		},
+		"bind fails": {
+			cfg:       portCheckTestConfig("ofi+tcp", "eth0"),
+			listenErr: errors.New("address already in use"),
+			probeResp: &control.PortProbeResp{
+				Results: map[string][]*control.PortProbeResult{
+					"10.0.0.1:10001": {{Addr: "10.0.1.1:10001"}},
+				},
+			},
+			expAddrs: []string{"0.0.0.0:10001"},
+			expLogs: []string{
+				"engine 0 fabric port (ofi+tcp on eth0) 10.0.1.1:31416 cannot be bound: address already in use",
+			},
+		},
+		"probe fails": {
+			cfg:      portCheckTestConfig("ofi+tcp", "eth0"),
+			probeErr: errors.New("context deadline exceeded"),
+			expAddrs: []string{"0.0.0.0:10001", "10.0.1.1:31416"},
+			expLogs:  []string{"port check skipped: context deadline exceeded"},
+		},
+		"no replicas available": {
+			cfg:       portCheckTestConfig("ofi+tcp", "eth0"),
+			probeResp: &control.PortProbeResp{},
+			expAddrs:  []string{"0.0.0.0:10001", "10.0.1.1:31416"},
+			expLogs:   []string{"no MS replica was available"},
+		},
+		"fabric port unreachable": {
+			cfg: portCheckTestConfig("ofi+tcp", "eth0"),
+			probeResp: &control.PortProbeResp{
+				Results: map[string][]*control.PortProbeResult{
+					"10.0.0.1:10001": {
+						{Addr: "10.0.1.1:10001"},
+						{Addr: "10.0.1.1:31416", Error: "i/o timeout"},
+					},
+				},
+			},
+			expAddrs: []string{"0.0.0.0:10001", "10.0.1.1:31416"},
+			expLogs: []string{
+				"engine 0 fabric port (ofi+tcp on eth0) 10.0.1.1:31416 is not reachable " +
+					"from MS replica 10.0.0.1:10001 (check firewall rules): i/o timeout",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var listeners []*mockListener
+			var gotAddrs []string
+			checkPorts(test.Context(t), &portCheckParams{
+				log:      log,
+				cfg:      tc.cfg,
+				ctlAddr:  &net.TCPAddr{IP: net.IPv4zero, Port: 10001},
+				lookupIF: portCheckTestLookup,
+				listen: func(_, _ string) (net.Listener, error) {
+					if tc.listenErr != nil {
+						return nil, tc.listenErr
+					}
+					lis := new(mockListener)
+					listeners = append(listeners, lis)
+					return lis, nil
+				},
+				probe: func(_ context.Context, _ control.UnaryInvoker, req *control.PortProbeReq) (*control.PortProbeResp, error) {
+					gotAddrs = req.Addrs
+					return tc.probeResp, tc.probeErr
+				},
+			})
+
+			test.AssertEqual(t, tc.expAddrs, gotAddrs, "unexpected probed addresses")
+			for _, lis := range listeners {
+				test.AssertTrue(t, lis.closed, "listener not closed after check")
+			}
+			for _, exp := range tc.expLogs {
+				if !strings.Contains(buf.String(), exp) {
+					t.Fatalf("expected log to contain %q", exp)
+				}
+			}
+		})
+	}
+}
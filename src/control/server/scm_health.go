@@ -0,0 +1,200 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	scmHealthInterval = 5 * time.Minute
+	scmHealthyState   = "Healthy"
+
+	// Thresholds at which a PMem module health warning is raised.
+	scmMaxMediaTemperature = 80 // degrees Celsius
+	scmMinSpareCapacity    = 10 // percent remaining
+)
+
+type scmHealthQueryFn func(storage.ScmHealthQueryRequest) (*storage.ScmHealthQueryResponse, error)
+
+// scmHealthMonitor periodically polls the health sensors of the PMem modules
+// on the host, raising RAS events when readings cross warning thresholds and
+// reporting the latest readings as prometheus metrics.
+type scmHealthMonitor struct {
+	sync.RWMutex
+	log      logging.Logger
+	hostname string
+	query    scmHealthQueryFn
+	publish  func(*events.RASEvent)
+	modules  map[string]*storage.ScmModuleHealth
+
+	healthy *prometheus.Desc
+	temp    *prometheus.Desc
+	spare   *prometheus.Desc
+	dirty   *prometheus.Desc
+}
+
+func newScmHealthMonitor(log logging.Logger, hostname string, query scmHealthQueryFn, publish func(*events.RASEvent)) *scmHealthMonitor {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("scm", "module", name), help,
+			[]string{"uid", "socket"}, nil)
+	}
+
+	return &scmHealthMonitor{
+		log:      log,
+		hostname: hostname,
+		query:    query,
+		publish:  publish,
+		modules:  make(map[string]*storage.ScmModuleHealth),
+		healthy:  desc("healthy", "Whether the PMem module reports a healthy state"),
+		temp:     desc("media_temperature_celsius", "PMem module media temperature"),
+		spare:    desc("spare_capacity_percent", "PMem module spare capacity remaining"),
+		dirty:    desc("dirty_shutdowns_total", "PMem module latched dirty shutdown count"),
+	}
+}
+
+// hasDcpmEngines indicates whether any of the engines use DCPM-class SCM.
+func hasDcpmEngines(cfgs []*engine.Config) bool {
+	for _, cfg := range cfgs {
+		for _, scmCfg := range cfg.Storage.Tiers.ScmConfigs() {
+			if scmCfg.Class == storage.ClassDcpm {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// startScmHealthMonitor starts polling PMem module health if any engine on
+// the host uses DCPM-class SCM.
+func startScmHealthMonitor(ctx context.Context, srv *server) {
+	if !hasDcpmEngines(srv.cfg.Engines) {
+		return
+	}
+
+	mon := newScmHealthMonitor(srv.log, srv.hostname, srv.ctlSvc.storage.QueryScmHealth,
+		srv.pubSub.Publish)
+	if srv.cfg.TelemetryPort != 0 {
+		prometheus.MustRegister(mon)
+	}
+
+	go mon.run(ctx)
+}
+
+// run polls PMem module health at a fixed interval until the context is
+// canceled.
+func (mon *scmHealthMonitor) run(ctx context.Context) {
+	mon.check()
+
+	ticker := time.NewTicker(scmHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mon.check()
+		}
+	}
+}
+
+// healthWarnings returns a description of each warning threshold crossed by
+// the current readings that was not already crossed by the previous readings.
+func healthWarnings(prev, cur *storage.ScmModuleHealth) []string {
+	var warnings []string
+
+	if cur.HealthState != scmHealthyState && (prev == nil || prev.HealthState != cur.HealthState) {
+		warnings = append(warnings, fmt.Sprintf("health state %q", cur.HealthState))
+	}
+	if cur.MediaTemperature >= scmMaxMediaTemperature &&
+		(prev == nil || prev.MediaTemperature < scmMaxMediaTemperature) {
+		warnings = append(warnings, fmt.Sprintf("media temperature %dC", cur.MediaTemperature))
+	}
+	if cur.SpareCapacity < scmMinSpareCapacity &&
+		(prev == nil || prev.SpareCapacity >= scmMinSpareCapacity) {
+		warnings = append(warnings, fmt.Sprintf("spare capacity %d%%", cur.SpareCapacity))
+	}
+	if prev != nil && cur.DirtyShutdownCount > prev.DirtyShutdownCount {
+		warnings = append(warnings, fmt.Sprintf("dirty shutdown count increased from %d to %d",
+			prev.DirtyShutdownCount, cur.DirtyShutdownCount))
+	}
+
+	return warnings
+}
+
+// check queries PMem module health and publishes a RAS event for each module
+// that has newly crossed a warning threshold.
+func (mon *scmHealthMonitor) check() {
+	resp, err := mon.query(storage.ScmHealthQueryRequest{})
+	if err != nil {
+		mon.log.Errorf("failed to query pmem module health: %s", err)
+		return
+	}
+
+	mon.Lock()
+	defer mon.Unlock()
+
+	modules := make(map[string]*storage.ScmModuleHealth)
+	for _, cur := range resp.Modules {
+		modules[cur.UID] = cur
+
+		if warnings := healthWarnings(mon.modules[cur.UID], cur); len(warnings) > 0 {
+			details := strings.Join(warnings, ", ")
+			mon.log.Noticef("pmem module %s health warning: %s", cur.UID, details)
+			mon.publish(events.NewScmHealthWarningEvent(mon.hostname, cur.UID, details))
+		}
+	}
+	mon.modules = modules
+}
+
+func (mon *scmHealthMonitor) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{mon.healthy, mon.temp, mon.spare, mon.dirty} {
+		ch <- d
+	}
+}
+
+func (mon *scmHealthMonitor) Collect(ch chan<- prometheus.Metric) {
+	mon.RLock()
+	defer mon.RUnlock()
+
+	uids := make([]string, 0, len(mon.modules))
+	for uid := range mon.modules {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	for _, uid := range uids {
+		mh := mon.modules[uid]
+		sock := fmt.Sprintf("%d", mh.SocketID)
+
+		var healthy float64
+		if mh.HealthState == scmHealthyState {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(mon.healthy, prometheus.GaugeValue, healthy, uid, sock)
+		ch <- prometheus.MustNewConstMetric(mon.temp, prometheus.GaugeValue,
+			float64(mh.MediaTemperature), uid, sock)
+		ch <- prometheus.MustNewConstMetric(mon.spare, prometheus.GaugeValue,
+			float64(mh.SpareCapacity), uid, sock)
+		ch <- prometheus.MustNewConstMetric(mon.dirty, prometheus.CounterValue,
+			float64(mh.DirtyShutdownCount), uid, sock)
+	}
+}
@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func mockScmModuleHealth(uid string) *storage.ScmModuleHealth {
+	return &storage.ScmModuleHealth{
+		UID:                uid,
+		HealthState:        scmHealthyState,
+		MediaTemperature:   40,
+		SpareCapacity:      100,
+		DirtyShutdownCount: 2,
+	}
+}
+
+func TestServer_scmHealthMonitor_check(t *testing.T) {
+	for name, tc := range map[string]struct {
+		prev     []*storage.ScmModuleHealth
+		cur      []*storage.ScmModuleHealth
+		queryErr error
+		expEvts  map[string]string
+	}{
+		"query fails": {
+			prev:     []*storage.ScmModuleHealth{mockScmModuleHealth("a")},
+			queryErr: errors.New("fail"),
+		},
+		"all healthy": {
+			cur: []*storage.ScmModuleHealth{
+				mockScmModuleHealth("a"),
+				mockScmModuleHealth("b"),
+			},
+		},
+		"first readings over thresholds": {
+			cur: []*storage.ScmModuleHealth{
+				func() *storage.ScmModuleHealth {
+					mh := mockScmModuleHealth("a")
+					mh.HealthState = "Non-critical failure"
+					mh.MediaTemperature = 85
+					mh.SpareCapacity = 5
+					return mh
+				}(),
+				mockScmModuleHealth("b"),
+			},
+			expEvts: map[string]string{
+				"a": `health state "Non-critical failure", media temperature 85C, spare capacity 5%`,
+			},
+		},
+		"thresholds already crossed": {
+			prev: []*storage.ScmModuleHealth{
+				func() *storage.ScmModuleHealth {
+					mh := mockScmModuleHealth("a")
+					mh.MediaTemperature = 82
+					return mh
+				}(),
+			},
+			cur: []*storage.ScmModuleHealth{
+				func() *storage.ScmModuleHealth {
+					mh := mockScmModuleHealth("a")
+					mh.MediaTemperature = 85
+					return mh
+				}(),
+			},
+		},
+		"dirty shutdown count increased": {
+			prev: []*storage.ScmModuleHealth{
+				mockScmModuleHealth("a"),
+				mockScmModuleHealth("b"),
+			},
+			cur: []*storage.ScmModuleHealth{
+				mockScmModuleHealth("a"),
+				func() *storage.ScmModuleHealth {
+					mh := mockScmModuleHealth("b")
+					mh.DirtyShutdownCount = 3
+					return mh
+				}(),
+			},
+			expEvts: map[string]string{
+				"b": "dirty shutdown count increased from 2 to 3",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			query := func(storage.ScmHealthQueryRequest) (*storage.ScmHealthQueryResponse, error) {
+				if tc.queryErr != nil {
+					return nil, tc.queryErr
+				}
+				return &storage.ScmHealthQueryResponse{Modules: tc.cur}, nil
+			}
+			gotEvts := make(map[string]string)
+			publish := func(evt *events.RASEvent) {
+				test.AssertEqual(t, events.RASDeviceScmHealthWarning, evt.ID, "unexpected event ID")
+				gotEvts[evt.HWID] = string(*evt.GetStrInfo())
+			}
+
+			mon := newScmHealthMonitor(log, "foo", query, publish)
+			for _, mh := range tc.prev {
+				mon.modules[mh.UID] = mh
+			}
+
+			mon.check()
+
+			if tc.expEvts == nil {
+				tc.expEvts = make(map[string]string)
+			}
+			test.AssertEqual(t, tc.expEvts, gotEvts, "unexpected events")
+
+			expModules := tc.cur
+			if tc.queryErr != nil {
+				expModules = tc.prev
+			}
+			test.AssertEqual(t, len(expModules), len(mon.modules), "unexpected number of cached modules")
+
+			ch := make(chan prometheus.Metric, 100)
+			mon.Collect(ch)
+			close(ch)
+			test.AssertEqual(t, 4*len(expModules), len(ch), "unexpected number of metrics")
+		})
+	}
+}
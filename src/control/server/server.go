@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2018-2023 Intel Corporation.
+// (C) Copyright 2018-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -19,6 +19,9 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
@@ -29,6 +32,7 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/daos"
 	"github.com/daos-stack/daos/src/control/lib/hardware"
 	"github.com/daos-stack/daos/src/control/lib/hardware/hwprov"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/config"
@@ -62,6 +66,23 @@ func genFiAffFn(fis *hardware.FabricInterfaceSet) config.EngineAffinityFn {
 var osSetenv = os.Setenv
 
 func processConfig(log logging.Logger, cfg *config.Server, fis *hardware.FabricInterfaceSet, mi *common.MemInfo, lookupNetIF ifLookupFn, affSrcs ...config.EngineAffinityFn) error {
+	if err := checkConfig(log, cfg, fis, mi, lookupNetIF, affSrcs...); err != nil {
+		return err
+	}
+
+	cfg.SaveActiveConfig(log)
+
+	if err := setDaosHelperEnvs(cfg, osSetenv); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkConfig validates the server config against the fabric and memory of
+// the host, deriving the settings that depend on them. It has no effect
+// outside of the config.
+func checkConfig(log logging.Logger, cfg *config.Server, fis *hardware.FabricInterfaceSet, mi *common.MemInfo, lookupNetIF ifLookupFn, affSrcs ...config.EngineAffinityFn) error {
 	processFabricProvider(cfg)
 
 	if err := cfg.SetEngineAffinities(log, affSrcs...); err != nil {
@@ -97,12 +118,6 @@ func processConfig(log logging.Logger, cfg *config.Server, fis *hardware.FabricI
 		}
 	}
 
-	cfg.SaveActiveConfig(log)
-
-	if err := setDaosHelperEnvs(cfg, osSetenv); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -134,6 +149,7 @@ type server struct {
 	runningUser *user.User
 	faultDomain *system.FaultDomain
 	ctlAddr     *net.TCPAddr
+	hostID      *hostIDStore
 	netDevClass []hardware.NetDevClass
 	listener    net.Listener
 
@@ -143,9 +159,15 @@ type server struct {
 	pubSub       *events.PubSub
 	evtForwarder *control.EventForwarder
 	evtLogger    *control.EventLogger
+	logBumper    *engineLogBumper
+	quarantiner  *rankQuarantiner
+	clockSkew    *clockSkewMonitor
 	ctlSvc       *ControlService
 	mgmtSvc      *mgmtSvc
 	grpcServer   *grpc.Server
+	grpcHealth   *health.Server
+	sim          *simulation // non-nil when running simulated engines
+	getMemInfo   common.GetMemInfoFn
 
 	cbLock           sync.Mutex
 	onEnginesStarted []func(context.Context) error
@@ -171,7 +193,9 @@ func newServer(log logging.Logger, cfg *config.Server, faultDomain *system.Fault
 		hostname:    hostname,
 		runningUser: cu,
 		faultDomain: faultDomain,
+		hostID:      newHostIDStore(cfg),
 		harness:     harness,
+		getMemInfo:  common.GetMemInfo,
 	}, nil
 }
 
@@ -195,11 +219,20 @@ func CreateDatabaseConfig(cfg *config.Server) (*raft.DatabaseConfig, error) {
 		return nil, errors.New("raft directory not available (missing SCM or control metadata in config?)")
 	}
 
-	return &raft.DatabaseConfig{
-		Replicas:   dbReplicas,
-		RaftDir:    raftDir,
-		SystemName: cfg.SystemName,
-	}, nil
+	dbCfg := &raft.DatabaseConfig{
+		Replicas:         dbReplicas,
+		RaftDir:          raftDir,
+		RaftElectionTier: cfg.MSElectionTier,
+		SystemName:       cfg.SystemName,
+	}
+
+	// The host ID is only created by a running server, but is picked up
+	// here if present so that offline recovery keeps the same raft ID.
+	if id, err := readHostID(cfgGetHostIDPath(cfg)); err == nil {
+		dbCfg.HostID = id
+	}
+
+	return dbCfg, nil
 }
 
 // newManagementDatabase creates a new instance of the raft-backed management database.
@@ -211,7 +244,7 @@ func newManagementDatabase(log logging.Logger, cfg *config.Server) (*raft.Databa
 
 	// If this daos_server instance ends up being the MS leader,
 	// this will record the DAOS system membership.
-	return raft.NewDatabase(log, dbCfg)
+	return raft.NewDatabase(logging.ForSubsystem(log, logging.SubsystemRaft), dbCfg)
 }
 
 // createServices builds scaffolding for rpc and event services.
@@ -220,7 +253,14 @@ func (srv *server) createServices(ctx context.Context) (err error) {
 	if err != nil {
 		return
 	}
-	srv.membership = system.NewMembership(srv.log, srv.sysdb)
+	srv.membership = system.NewMembership(srv.log, srv.sysdb).
+		WithFlapDamping(srv.cfg.FlapDamping)
+
+	// The clocks of peers are sampled from the responses to inter-server
+	// requests. Events are published once the event primitives exist.
+	srv.clockSkew = newClockSkewMonitor(srv.log, srv.hostname, func(evt *events.RASEvent) {
+		srv.pubSub.Publish(evt)
+	})
 
 	// Create rpcClient for inter-server communication.
 	cliCfg := control.DefaultConfig()
@@ -228,17 +268,43 @@ func (srv *server) createServices(ctx context.Context) (err error) {
 	rpcClient := control.NewClient(
 		control.WithClientComponent(build.ComponentServer),
 		control.WithConfig(cliCfg),
-		control.WithClientLogger(srv.log))
+		control.WithClientLogger(srv.log),
+		control.WithClockSampleCallback(srv.clockSkew.observe))
+	srv.OnShutdown(rpcClient.Close)
 
 	// Create event distribution primitives.
-	srv.pubSub = events.NewPubSub(ctx, srv.log)
+	srv.pubSub = events.NewPubSub(ctx, logging.ForSubsystem(srv.log, logging.SubsystemEvents))
 	srv.OnShutdown(srv.pubSub.Close)
+	srv.membership.WithEventPublisher(srv.pubSub)
 	srv.evtForwarder = control.NewEventForwarder(rpcClient, srv.cfg.AccessPoints)
 	srv.evtLogger = control.NewEventLogger(srv.log)
 
-	srv.ctlSvc = NewControlService(srv.log, srv.harness, srv.cfg, srv.pubSub,
-		hwprov.DefaultFabricScanner(srv.log))
+	fabricScanner, err := hwprov.DefaultFabricScannerWithFilter(srv.log, srv.cfg.FabricScanFilter())
+	if err != nil {
+		return errors.Wrap(err, "creating fabric scanner")
+	}
+	srv.ctlSvc = NewControlService(srv.log, srv.harness, srv.cfg, srv.pubSub, fabricScanner)
+	if srv.sim != nil {
+		srv.sim.attachStorageControl(&srv.ctlSvc.StorageControlService, srv.cfg.Engines)
+	}
 	srv.mgmtSvc = newMgmtSvc(srv.harness, srv.membership, srv.sysdb, rpcClient, srv.pubSub)
+	srv.mgmtSvc.joinAdmitter = newJoinAdmitter(srv.log, srv.cfg.JoinAdmission)
+	srv.mgmtSvc.joinLimiter = newJoinLimiter(srv.cfg.JoinRateLimit, srv.mgmtSvc.batchInterval)
+	srv.mgmtSvc.autoReint = newAutoReintegrator(srv.cfg.AutoReintegrate)
+	srv.mgmtSvc.healthReporter = newHealthReporter(srv.cfg.HealthReport)
+	srv.mgmtSvc.eventArchiver = newEventArchiver(srv.cfg.EventArchive)
+	if srv.mgmtSvc.eventArchiver != nil {
+		srv.sysdb.OnEventsEvicted(srv.mgmtSvc.archiveEvicted)
+	}
+	if srv.mgmtSvc.msBackupSched, err = newMSBackupScheduler(srv.cfg.MSBackup); err != nil {
+		return err
+	}
+	srv.mgmtSvc.poolOpSched = newPoolOpScheduler(srv.cfg.PoolOpScheduler)
+	srv.mgmtSvc.poolSpaceForecaster = newPoolSpaceForecaster(srv.cfg.PoolSpaceForecast)
+	srv.mgmtSvc.aclPrincipalCheck = srv.cfg.ACLPrincipalCheck
+	if srv.cfg.Provisioning != nil {
+		srv.mgmtSvc.requireProvisioningToken = srv.cfg.Provisioning.RequireToken
+	}
 
 	if err := srv.mgmtSvc.systemProps.UpdateCompPropVal(daos.SystemPropertyDaosSystem, func() string {
 		return srv.cfg.SystemName
@@ -318,17 +384,43 @@ func (srv *server) createEngine(ctx context.Context, idx int, cfg *engine.Config
 		req.SetSystem(srv.cfg.SystemName)
 		req.ControlAddr = srv.ctlAddr
 
+		// Without a host ID the server is only known by its address, as
+		// before, so failing to load it should not prevent the join.
+		if id, err := srv.hostID.get(); err != nil {
+			srv.log.Errorf("failed to load host ID: %s", err)
+		} else {
+			req.HostID = id.String()
+		}
+
+		if srv.cfg.Provisioning != nil && srv.cfg.Provisioning.TokenFile != "" {
+			tok, err := os.ReadFile(srv.cfg.Provisioning.TokenFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "reading provisioning token")
+			}
+			req.ProvisioningToken = strings.TrimSpace(string(tok))
+		}
+
 		return control.SystemJoin(ctxIn, srv.mgmtSvc.rpcClient, req)
 	}
 
-	sp := storage.DefaultProvider(srv.log, idx, &cfg.Storage).
-		WithVMDEnabled(srv.ctlSvc.storage.IsVMDEnabled())
+	storageLog := logging.ForSubsystem(srv.log, logging.SubsystemStorage)
+	var sp *storage.Provider
+	if srv.sim != nil {
+		sp = srv.sim.storageProvider(storageLog, idx, &cfg.Storage)
+	} else {
+		sp = storage.DefaultProvider(storageLog, idx, &cfg.Storage).
+			WithVMDEnabled(srv.ctlSvc.storage.IsVMDEnabled())
+	}
 
 	engine := NewEngineInstance(srv.log, sp, joinFn, engine.NewRunner(srv.log, cfg)).
 		WithHostFaultDomain(srv.harness.faultDomain)
 
+	if srv.sim != nil {
+		srv.sim.attachEngine(engine, cfg)
+	}
+
 	if idx == 0 {
-		configureFirstEngine(ctx, engine, srv.sysdb, joinFn)
+		configureFirstEngine(ctx, engine, srv.sysdb, joinFn, srv.hostID.get)
 	}
 
 	return engine, nil
@@ -340,14 +432,21 @@ func (srv *server) addEngines(ctx context.Context) error {
 	var allStarted sync.WaitGroup
 	registerTelemetryCallbacks(ctx, srv)
 
-	iommuEnabled, err := hwprov.DefaultIOMMUDetector(srv.log).IsIOMMUEnabled()
-	if err != nil {
-		return err
-	}
+	// Simulated engines have no devices to prepare.
+	if srv.sim == nil {
+		iommuEnabled, err := hwprov.DefaultIOMMUDetector(srv.log).IsIOMMUEnabled()
+		if err != nil {
+			return err
+		}
 
-	// Allocate hugepages and rebind NVMe devices to userspace drivers.
-	if err := prepBdevStorage(srv, iommuEnabled); err != nil {
-		return err
+		// Allocate hugepages and rebind NVMe devices to userspace drivers.
+		if err := prepBdevStorage(srv, iommuEnabled); err != nil {
+			return err
+		}
+
+		if err := checkBdevNUMAAffinity(srv.log, srv.cfg, srv.ctlSvc.NvmeScan); err != nil {
+			return err
+		}
 	}
 
 	if len(srv.cfg.Engines) == 0 {
@@ -422,6 +521,19 @@ func (srv *server) setupGrpc() error {
 
 	mgmtpb.RegisterMgmtSvcServer(srv.grpcServer, srv.mgmtSvc)
 
+	// The management service only handles requests on the MS leader, so
+	// report it as serving once leadership has been gained.
+	srv.grpcHealth = health.NewServer()
+	srv.grpcHealth.SetServingStatus(ctlpb.CtlSvc_ServiceDesc.ServiceName,
+		healthpb.HealthCheckResponse_SERVING)
+	srv.grpcHealth.SetServingStatus(mgmtpb.MgmtSvc_ServiceDesc.ServiceName,
+		healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(srv.grpcServer, srv.grpcHealth)
+
+	if srv.cfg.GrpcReflection {
+		reflection.Register(srv.grpcServer)
+	}
+
 	tSec, err := security.DialOptionForTransportConfig(srv.cfg.TransportConfig)
 	if err != nil {
 		return err
@@ -434,11 +546,21 @@ func (srv *server) setupGrpc() error {
 }
 
 func (srv *server) registerEvents() {
+	if srv.cfg.EngineLogBump.WithDefaults().Enabled {
+		srv.logBumper = newEngineLogBumper(srv.log, srv.cfg.EngineLogBump,
+			srv.harness.Instances(), srv.cfg.Engines)
+	}
+	if srv.cfg.QuarantinePolicy.WithDefaults().Enabled {
+		srv.quarantiner = newRankQuarantiner(srv.log, srv.cfg.QuarantinePolicy,
+			srv.membership)
+	}
 	registerFollowerSubscriptions(srv)
 
 	srv.sysdb.OnLeadershipGained(
 		func(ctx context.Context) error {
 			srv.log.Infof("MS leader running on %s", srv.hostname)
+			srv.grpcHealth.SetServingStatus(mgmtpb.MgmtSvc_ServiceDesc.ServiceName,
+				healthpb.HealthCheckResponse_SERVING)
 
 			if err := srv.mgmtSvc.updateFabricProviders([]string{srv.cfg.Fabric.Provider}, srv.pubSub); err != nil {
 				srv.log.Errorf(err.Error())
@@ -475,7 +597,10 @@ func (srv *server) registerEvents() {
 	)
 	srv.sysdb.OnLeadershipLost(func() error {
 		srv.log.Infof("MS leader no longer running on %s", srv.hostname)
+		srv.grpcHealth.SetServingStatus(mgmtpb.MgmtSvc_ServiceDesc.ServiceName,
+			healthpb.HealthCheckResponse_NOT_SERVING)
 		registerFollowerSubscriptions(srv)
+		srv.mgmtSvc.idempotency.reset()
 		return nil
 	})
 }
@@ -487,9 +612,31 @@ func (srv *server) start(ctx context.Context) error {
 		_ = srv.grpcServer.Serve(srv.listener)
 	}()
 	defer srv.grpcServer.Stop()
+	defer srv.grpcHealth.Shutdown()
+
+	if pc := srv.cfg.ControlProfiling; pc != nil && pc.Enabled {
+		if err := srv.ctlSvc.profiler.Start(pc.Address); err != nil {
+			srv.log.Errorf("profiling: %s", err)
+		}
+	}
+	defer srv.ctlSvc.profiler.Stop()
 
-	// noop on release builds
-	control.StartPProf(srv.log)
+	if tr := srv.cfg.Tracing; tr != nil && tr.OTLPEndpoint != "" {
+		exp, err := tracing.NewOTLPExporter(srv.log, tr.OTLPEndpoint, build.ControlPlaneName)
+		if err != nil {
+			srv.log.Errorf("tracing: %s", err)
+		} else {
+			tracing.SetExporter(exp)
+			defer func() {
+				tracing.SetExporter(nil)
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := exp.Shutdown(shutdownCtx); err != nil {
+					srv.log.Errorf("tracing: flushing spans: %s", err)
+				}
+			}()
+		}
+	}
 
 	srv.log.Infof("%s v%s (pid %d) listening on %s", build.ControlPlaneName,
 		build.DaosVersion, os.Getpid(), srv.ctlAddr)
@@ -501,6 +648,7 @@ func (srv *server) start(ctx context.Context) error {
 		tc:      srv.cfg.TransportConfig,
 		sysdb:   srv.sysdb,
 		events:  srv.pubSub,
+		sockets: srv.cfg.DrpcSockets,
 	}
 	// Single daos_server dRPC server to handle all engine requests
 	if err := drpcServerSetup(ctx, drpcSetupReq); err != nil {
@@ -514,6 +662,34 @@ func (srv *server) start(ctx context.Context) error {
 
 	srv.mgmtSvc.startAsyncLoops(ctx)
 
+	memWatchdog := newMemWatchdog(srv.log, srv.cfg.MemoryWatchdog, srv.hostname,
+		srv.harness.Instances(), srv.pubSub.Publish)
+	memWatchdog.getMemInfo = srv.getMemInfo
+	memWatchdog.nrHugepages = srv.cfg.NrHugepages
+	memWatchdog.calcRamdiskSize = func(hpSizeKiB, memKiB int) (uint64, error) {
+		return srv.cfg.CalcRamdiskSize(srv.log, hpSizeKiB, memKiB)
+	}
+	go memWatchdog.run(ctx)
+	startScmHealthMonitor(ctx, srv)
+	startBdevTrimScheduler(ctx, srv)
+	startBdevIOStatsCollector(ctx, srv)
+	startClockSkewMonitor(ctx, srv)
+	startSuperblockVerifier(ctx, srv)
+
+	if !srv.cfg.DisablePortCheck {
+		// Run before the engines are started, as their fabric ports are
+		// bound for the duration of the check.
+		checkPorts(ctx, &portCheckParams{
+			log:       srv.log,
+			cfg:       srv.cfg,
+			ctlAddr:   srv.ctlAddr,
+			lookupIF:  lookupIF,
+			listen:    net.Listen,
+			rpcClient: srv.mgmtSvc.rpcClient,
+			probe:     control.PortProbe,
+		})
+	}
+
 	if srv.cfg.AutoFormat {
 		srv.log.Notice("--auto flag set on server start so formatting storage now")
 		if _, err := srv.ctlSvc.StorageFormat(ctx, &ctlpb.StorageFormatReq{}); err != nil {
@@ -560,39 +736,62 @@ func lookupIF(name string) (netInterface, error) {
 	return iface, nil
 }
 
-// Start is the entry point for a daos_server instance.
-func Start(log logging.Logger, cfg *config.Server) error {
-	if err := common.CheckDupeProcess(); err != nil {
-		return err
-	}
-
-	// Create the root context here. All contexts should inherit from this one so
-	// that they can be shut down from one place.
-	ctx, shutdown := context.WithCancel(context.Background())
-	defer shutdown()
-
+// prepHostConfig waits for the configured fabric interfaces to be ready then
+// processes the server config against the fabric and memory of the host.
+func prepHostConfig(ctx context.Context, log logging.Logger, cfg *config.Server) (*hardware.FabricInterfaceSet, error) {
 	providers, err := cfg.Fabric.GetProviders()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := waitFabricReady(ctx, log, cfg); err != nil {
-		return err
+		return nil, err
 	}
 
-	scanner := hwprov.DefaultFabricScanner(log)
+	scanner, err := hwprov.DefaultFabricScannerWithFilter(log, cfg.FabricScanFilter())
+	if err != nil {
+		return nil, errors.Wrap(err, "creating fabric scanner")
+	}
 
 	fis, err := scanner.Scan(ctx, providers...)
 	if err != nil {
-		return errors.Wrap(err, "scan fabric")
+		return nil, errors.Wrap(err, "scan fabric")
 	}
 
 	mi, err := common.GetMemInfo()
 	if err != nil {
-		return errors.Wrapf(err, "retrieve system memory info")
+		return nil, errors.Wrapf(err, "retrieve system memory info")
 	}
 
 	if err = processConfig(log, cfg, fis, mi, lookupIF, genFiAffFn(fis)); err != nil {
+		return nil, err
+	}
+
+	return fis, nil
+}
+
+// Start is the entry point for a daos_server instance.
+func Start(log logging.Logger, cfg *config.Server) error {
+	// Simulated servers don't touch host resources, so several may share a host.
+	if cfg.SimulateEngines == 0 {
+		if err := common.CheckDupeProcess(); err != nil {
+			return err
+		}
+	}
+
+	// Create the root context here. All contexts should inherit from this one so
+	// that they can be shut down from one place.
+	ctx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+
+	var fis *hardware.FabricInterfaceSet
+	var err error
+	if cfg.SimulateEngines > 0 {
+		err = prepSimulatedConfig(log, cfg)
+	} else {
+		fis, err = prepHostConfig(ctx, log, cfg)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -608,12 +807,22 @@ func Start(log logging.Logger, cfg *config.Server) error {
 	}
 	defer srv.shutdown()
 
-	if err := srv.setCoreDumpFilter(); err != nil {
-		return err
-	}
+	if cfg.SimulateEngines > 0 {
+		if srv.sim, err = newSimulation(log, cfg); err != nil {
+			return err
+		}
+		srv.getMemInfo = srv.sim.getMemInfo
+		if srv.netDevClass, err = simNetDevClass(cfg); err != nil {
+			return err
+		}
+	} else {
+		if err := srv.setCoreDumpFilter(); err != nil {
+			return err
+		}
 
-	if srv.netDevClass, err = getFabricNetDevClass(cfg, fis); err != nil {
-		return err
+		if srv.netDevClass, err = getFabricNetDevClass(cfg, fis); err != nil {
+			return err
+		}
 	}
 
 	if err := srv.createServices(ctx); err != nil {
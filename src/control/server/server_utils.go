@@ -19,8 +19,12 @@ import (
 	"sync"
 
 	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // register gzip compressor
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/events"
@@ -333,6 +337,16 @@ func prepBdevStorage(srv *server, iommuEnabled bool) error {
 		DisableVFIO:  srv.cfg.DisableVFIO,
 	}
 
+	// Opal drives are unlocked (but never provisioned) during start-up.
+	if opalDevs := bdevCfgs.OpalDevices(); len(opalDevs) > 0 {
+		key, err := srv.cfg.EncryptionKey.GetKey()
+		if err != nil {
+			return errors.Wrap(err, "unlocking opal nvme drives")
+		}
+		prepReq.OpalDevices = opalDevs
+		prepReq.EncryptionKey = key
+	}
+
 	enableVMD := true
 	if srv.cfg.DisableVMD != nil && *srv.cfg.DisableVMD {
 		enableVMD = false
@@ -402,6 +416,67 @@ func prepBdevStorage(srv *server, iommuEnabled bool) error {
 	return nil
 }
 
+// checkBdevNUMAAffinity verifies that the NVMe SSDs assigned to each engine are attached to the
+// NUMA node that the engine is bound to, as cross-NUMA I/O costs significant bandwidth. SSDs
+// behind a VMD are checked using the domain address listed in the config. Cross-NUMA assignments
+// are refused unless explicitly allowed in the config.
+func checkBdevNUMAAffinity(log logging.Logger, cfg *config.Server, scan scanBdevsFn) error {
+	bdevCfgs := getBdevCfgsFromSrvCfg(cfg)
+	if cfg.DisableHugepages || !bdevCfgs.HaveRealNVMe() {
+		return nil
+	}
+
+	resp, err := scan(storage.BdevScanRequest{DeviceList: bdevCfgs.NVMeBdevs()})
+	if err != nil {
+		// Problems with the SSDs themselves will be reported when the engines start.
+		log.Errorf("skipping bdev numa affinity check: %s", err)
+		return nil
+	}
+
+	ssdNodes := make(map[string]int32)
+	for _, ctrlr := range resp.Controllers {
+		addr, err := hardware.NewPCIAddress(ctrlr.PciAddr)
+		if err != nil {
+			log.Debugf("skipping numa affinity check for ssd %q: %s", ctrlr.PciAddr, err)
+			continue
+		}
+		if addr.IsVMDBackingAddress() {
+			if addr, err = addr.BackingToVMDAddress(); err != nil {
+				log.Debugf("skipping numa affinity check for ssd %q: %s", ctrlr.PciAddr, err)
+				continue
+			}
+		}
+		ssdNodes[addr.String()] = ctrlr.SocketID
+	}
+
+	for idx, ec := range cfg.Engines {
+		if ec.PinnedNumaNode == nil {
+			continue
+		}
+		engineNode := *ec.PinnedNumaNode
+
+		for _, tc := range ec.Storage.Tiers.BdevConfigs() {
+			if tc.Class != storage.ClassNvme {
+				continue
+			}
+			for _, dev := range tc.Bdev.DeviceList.Devices() {
+				ssdNode, found := ssdNodes[dev]
+				if !found || ssdNode < 0 || uint(ssdNode) == engineNode {
+					continue
+				}
+
+				f := FaultBdevCrossNUMA(idx, dev, uint(ssdNode), engineNode)
+				if !cfg.AllowCrossNUMABdevs {
+					return f
+				}
+				log.Noticef("%s (allowed by config)", f.Description)
+			}
+		}
+	}
+
+	return nil
+}
+
 func setDaosHelperEnvs(cfg *config.Server, setenv func(k, v string) error) error {
 	if cfg.HelperLogFile != "" {
 		if err := setenv(pbin.DaosPrivHelperLogFileEnvVar, cfg.HelperLogFile); err != nil {
@@ -546,6 +621,9 @@ func registerEngineEventCallbacks(srv *server, engine *EngineInstance, allStarte
 	// Register callback to publish engine process exit events.
 	engine.OnInstanceExit(createPublishInstanceExitFunc(srv.pubSub.Publish, srv.hostname))
 
+	// Register callback to publish engine startup timeout events.
+	engine.OnStartupTimeout(createPublishStartupTimeoutFunc(srv.pubSub.Publish, srv.hostname))
+
 	engine.OnInstanceExit(func(_ context.Context, _ uint32, _ ranklist.Rank, _ error, _ int) error {
 		if engine.storage.BdevRoleMetaConfigured() {
 			return engine.storage.UnmountTmpfs()
@@ -578,7 +656,7 @@ func registerEngineEventCallbacks(srv *server, engine *EngineInstance, allStarte
 		}
 
 		// Retrieve up-to-date meminfo to check resource availability.
-		mi, err := common.GetMemInfo()
+		mi, err := srv.getMemInfo()
 		if err != nil {
 			return err
 		}
@@ -597,7 +675,7 @@ func registerEngineEventCallbacks(srv *server, engine *EngineInstance, allStarte
 	})
 }
 
-func configureFirstEngine(ctx context.Context, engine *EngineInstance, sysdb *raft.Database, join systemJoinFn) {
+func configureFirstEngine(ctx context.Context, engine *EngineInstance, sysdb *raft.Database, join systemJoinFn, getHostID func() (uuid.UUID, error)) {
 	if !sysdb.IsReplica() {
 		return
 	}
@@ -606,6 +684,14 @@ func configureFirstEngine(ctx context.Context, engine *EngineInstance, sysdb *ra
 	var onceStorageReady sync.Once
 	engine.OnStorageReady(func(_ context.Context) (err error) {
 		onceStorageReady.Do(func() {
+			// A replica without a host ID falls back to using its
+			// address as raft server ID.
+			if id, err := getHostID(); err != nil {
+				engine.log.Errorf("failed to load host ID: %s", err)
+			} else {
+				sysdb.SetHostID(id)
+			}
+
 			// NB: We use the outer context rather than
 			// the closure context in order to avoid
 			// tying the db to the instance.
@@ -644,13 +730,34 @@ func registerTelemetryCallbacks(ctx context.Context, srv *server) {
 		return
 	}
 
+	// Per-job totals are only aggregated while this server is MS leader.
+	srv.mgmtSvc.jobStats = newJobStatsAggregator(srv.log, uint32(telemPort), control.MetricsQuery)
+	prometheus.MustRegister(srv.mgmtSvc.jobStats)
+	prometheus.MustRegister(newMemberLifecycleCollector(srv.log, srv.sysdb))
+
 	srv.OnEnginesStarted(func(ctxIn context.Context) error {
 		srv.log.Debug("starting Prometheus exporter")
-		cleanup, err := startPrometheusExporter(ctxIn, srv.log, telemPort, srv.harness.Instances())
+		// Simulated engines have no shared memory to export metrics from.
+		engines := srv.harness.Instances()
+		if srv.sim != nil {
+			engines = nil
+		}
+		cleanup, err := startPrometheusExporter(ctxIn, srv.log, telemPort, engines)
 		if err != nil {
 			return err
 		}
 		srv.OnShutdown(cleanup)
+
+		// Pushed metrics include the job totals published while this
+		// server is MS leader.
+		if srv.cfg.RemoteWrite != nil {
+			srv.log.Debug("starting Prometheus remote-write")
+			cleanup, err := startRemoteWrite(ctxIn, srv.log, telemPort, srv.cfg.RemoteWrite)
+			if err != nil {
+				return errors.Wrap(err, "starting telemetry remote-write")
+			}
+			srv.OnShutdown(cleanup)
+		}
 		return nil
 	})
 }
@@ -662,16 +769,39 @@ func registerTelemetryCallbacks(ctx context.Context, srv *server) {
 func registerFollowerSubscriptions(srv *server) {
 	srv.pubSub.Reset()
 	srv.pubSub.Subscribe(events.RASTypeAny, srv.evtLogger)
+	if srv.logBumper != nil {
+		srv.pubSub.Subscribe(events.RASTypeAny, srv.logBumper)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.evtForwarder)
+	// Critical info-only events are also forwarded so that they can be
+	// recorded as persistent events by the MS leader, as are pool events
+	// that are recorded in the pool activity log.
+	srv.pubSub.Subscribe(events.RASTypeInfoOnly,
+		events.HandlerFunc(func(ctx context.Context, evt *events.RASEvent) {
+			if system.IsCriticalEvent(evt) || system.PoolActivityFromEvent(evt) != nil {
+				srv.evtForwarder.OnEvent(ctx, evt)
+			}
+		}))
 }
 
 // registerLeaderSubscriptions stops forwarding events to MS and instead starts
 // handling received forwarded (and local) events.
 func registerLeaderSubscriptions(srv *server) {
 	srv.pubSub.Reset()
-	srv.pubSub.Subscribe(events.RASTypeAny, srv.evtLogger)
+	// Repeated events are only collapsed for logging, handlers that update
+	// system state are subscribed below to receive every event.
+	srv.pubSub.SubscribeSummarized(events.RASTypeAny, srv.evtLogger)
+	if srv.logBumper != nil {
+		srv.pubSub.SubscribeSummarized(events.RASTypeAny, srv.logBumper)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.membership)
-	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.sysdb)
+	srv.pubSub.Subscribe(events.RASTypeAny, srv.sysdb)
+	if srv.quarantiner != nil {
+		srv.pubSub.Subscribe(events.RASTypeAny, srv.quarantiner)
+	}
+	if srv.mgmtSvc.poolOpSched != nil {
+		srv.pubSub.Subscribe(events.RASTypeInfoOnly, srv.mgmtSvc.poolOpSched)
+	}
 	srv.pubSub.Subscribe(events.RASTypeStateChange,
 		events.HandlerFunc(func(ctx context.Context, evt *events.RASEvent) {
 			switch evt.ID {
@@ -703,15 +833,22 @@ func registerLeaderSubscriptions(srv *server) {
 	srv.pubSub.Debounce(events.RASSwimRankDead, 0, func(ev *events.RASEvent) string {
 		return strconv.FormatUint(uint64(ev.Rank), 10) + ":" + strconv.FormatUint(ev.Incarnation, 10)
 	})
+
+	// Collapse floods of identical events, e.g. when many engines fail at once.
+	if ed := srv.cfg.EventDedupe.WithDefaults(); !ed.Disabled {
+		srv.pubSub.Summarize(ed.Window)
+	}
 }
 
 // getGrpcOpts generates a set of gRPC options for the server based on the supplied configuration.
 func getGrpcOpts(log logging.Logger, cfgTransport *security.TransportConfig, ldrChk func() bool) ([]grpc.ServerOption, error) {
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		unaryLoggingInterceptor(log, ldrChk), // must be first in order to properly log errors
+		unaryTraceInterceptor,
 		unaryErrorInterceptor,
 		unaryStatusInterceptor,
 		unaryVersionInterceptor(log),
+		unaryClockInterceptor,
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		streamErrorInterceptor,
@@ -740,6 +877,13 @@ func getGrpcOpts(log logging.Logger, cfgTransport *security.TransportConfig, ldr
 	return append(srvOpts, []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.UnknownServiceHandler(unknownMethodHandler),
+		// Allow clients to keep idle connections alive with pings so that
+		// they can be reused across requests.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             control.MinKeepaliveTime,
+			PermitWithoutStream: true,
+		}),
 	}...), nil
 }
 
@@ -751,6 +751,110 @@ func TestServer_prepBdevStorage(t *testing.T) {
 	}
 }
 
+func TestServer_checkBdevNUMAAffinity(t *testing.T) {
+	vmdAddr := "0000:5d:05.5"
+	vmdBackingAddr := "5d0505:01:00.0"
+
+	ctrlr := func(addr string, node int32) *storage.NvmeController {
+		return &storage.NvmeController{PciAddr: addr, SocketID: node}
+	}
+
+	for name, tc := range map[string]struct {
+		allowCrossNUMA bool
+		engines        []*engine.Config
+		scanResp       *storage.BdevScanResponse
+		scanErr        error
+		expScan        bool
+		expErr         error
+		expNotice      bool
+	}{
+		"no nvme": {
+			engines: []*engine.Config{pmemOnlyEngine(0)},
+		},
+		"same numa node": {
+			engines: []*engine.Config{pmemEngine(0).WithPinnedNumaNode(0)},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr(test.MockPCIAddr(0), 0)},
+			},
+			expScan: true,
+		},
+		"cross numa": {
+			engines: []*engine.Config{
+				pmemEngine(0).WithPinnedNumaNode(0),
+				pmemEngine(1).WithPinnedNumaNode(1),
+			},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{
+					ctrlr(test.MockPCIAddr(0), 0),
+					ctrlr(test.MockPCIAddr(1), 0),
+				},
+			},
+			expScan: true,
+			expErr:  FaultBdevCrossNUMA(1, test.MockPCIAddr(1), 0, 1),
+		},
+		"cross numa; allowed": {
+			allowCrossNUMA: true,
+			engines:        []*engine.Config{pmemEngine(0).WithPinnedNumaNode(0)},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr(test.MockPCIAddr(0), 1)},
+			},
+			expScan:   true,
+			expNotice: true,
+		},
+		"cross numa; vmd backing device": {
+			engines: []*engine.Config{
+				basicEngineCfg(0).WithStorage(pmemTier(0),
+					storage.NewTierConfig().WithStorageClass(storage.ClassNvme.String()).
+						WithBdevDeviceList(vmdAddr)).
+					WithPinnedNumaNode(0),
+			},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr(vmdBackingAddr, 1)},
+			},
+			expScan: true,
+			expErr:  FaultBdevCrossNUMA(0, vmdAddr, 1, 0),
+		},
+		"engine numa node unknown": {
+			engines: []*engine.Config{pmemEngine(0)},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr(test.MockPCIAddr(0), 1)},
+			},
+			expScan: true,
+		},
+		"ssd numa node unknown": {
+			engines: []*engine.Config{pmemEngine(0).WithPinnedNumaNode(0)},
+			scanResp: &storage.BdevScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr(test.MockPCIAddr(0), -1)},
+			},
+			expScan: true,
+		},
+		"scan fails": {
+			engines: []*engine.Config{pmemEngine(0).WithPinnedNumaNode(0)},
+			scanErr: errors.New("scan failed"),
+			expScan: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer().WithEngines(tc.engines...).
+				WithAllowCrossNUMABdevs(tc.allowCrossNUMA)
+
+			var scanned bool
+			scan := func(storage.BdevScanRequest) (*storage.BdevScanResponse, error) {
+				scanned = true
+				return tc.scanResp, tc.scanErr
+			}
+
+			test.CmpErr(t, tc.expErr, checkBdevNUMAAffinity(log, cfg, scan))
+			test.AssertEqual(t, tc.expScan, scanned, "unexpected scan state")
+			test.AssertEqual(t, tc.expNotice, strings.Contains(buf.String(), "allowed by config"),
+				"unexpected notice state")
+		})
+	}
+}
+
 func TestServer_checkEngineTmpfsMem(t *testing.T) {
 	for name, tc := range map[string]struct {
 		srvCfgExtra  func(*config.Server) *config.Server
@@ -0,0 +1,593 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/lib/hardware"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	simMaxEngines       = 8
+	simTargetCount      = 8
+	simFabricProvider   = "ofi+tcp"
+	simFabricIface      = "lo"
+	simFabricPortBase   = 31416
+	simFabricPortStride = 1000
+	simClusterSize      = 32 * humanize.MiByte
+	simRootDir          = "simulated"
+	simMemTotal         = 256 * humanize.GiByte
+	simMemAvail         = 192 * humanize.GiByte
+	simHugepages        = 32768
+)
+
+// simPCIAddr returns the address of the simulated NVMe SSD with the given
+// index attached to the given engine.
+func simPCIAddr(engineIdx, bdevIdx int) string {
+	return fmt.Sprintf("0000:%02x:00.0", 0x10*(engineIdx+1)+bdevIdx)
+}
+
+// prepSimulatedConfig replaces any engines in the server config with the
+// requested number of simulated engines, each with a ramdisk SCM tier and a
+// tier of simulated NVMe SSDs, then validates the result. Hardware specific
+// settings such as affinity are not derived from the host and hugepages are
+// sized against simulated memory.
+func prepSimulatedConfig(log logging.Logger, cfg *config.Server) error {
+	n := cfg.SimulateEngines
+	if n < 1 || n > simMaxEngines {
+		return errors.Errorf("number of simulated engines must be between 1 and %d",
+			simMaxEngines)
+	}
+	if len(cfg.Engines) > 0 {
+		log.Noticef("ignoring %d engine(s) in config, simulating %d", len(cfg.Engines), n)
+	}
+	if cfg.Metadata.Path != "" {
+		log.Noticef("ignoring control_metadata in config, simulated engines use ramdisk SCM")
+		cfg.Metadata = storage.ControlMetadata{}
+	}
+
+	if cfg.Fabric.Provider == "" {
+		cfg.Fabric.Provider = simFabricProvider
+	}
+	cfg.WithDisableVMD(true)
+	cfg.DisablePortCheck = true
+
+	engines := make([]*engine.Config, n)
+	for i := range engines {
+		bdevs := make([]string, simBdevsPerEngine)
+		for j := range bdevs {
+			bdevs[j] = simPCIAddr(i, j)
+		}
+
+		engines[i] = engine.NewConfig().
+			WithTargetCount(simTargetCount).
+			WithFabricInterface(simFabricIface).
+			WithFabricInterfacePort(simFabricPortBase+i*simFabricPortStride).
+			WithStorage(
+				storage.NewTierConfig().
+					WithStorageClass(storage.ClassRam.String()).
+					WithScmMountPoint(filepath.Join(simRoot(cfg), fmt.Sprintf("daos%d", i))).
+					WithScmRamdiskSize(simRamdiskSizeGiB),
+				storage.NewTierConfig().
+					WithStorageClass(storage.ClassNvme.String()).
+					WithBdevDeviceList(bdevs...),
+			)
+	}
+	cfg.WithEngines(engines...)
+
+	if err := processFabricProvider(cfg); err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(log); err != nil {
+		return errors.Wrap(err, "simulated config validation failed")
+	}
+
+	return cfg.SetNrHugepages(log, simMemInfo())
+}
+
+// simNetDevClass reports each configured fabric provider as using an
+// ethernet device, as the simulated engines are bound to loopback.
+func simNetDevClass(cfg *config.Server) ([]hardware.NetDevClass, error) {
+	providers, err := cfg.Fabric.GetProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]hardware.NetDevClass, len(providers))
+	for i := range classes {
+		classes[i] = hardware.Ether
+	}
+	return classes, nil
+}
+
+// simMemInfo describes the memory of a simulated host, with enough hugepages
+// and RAM available for the maximum number of simulated engines.
+func simMemInfo() *common.MemInfo {
+	return &common.MemInfo{
+		HugepagesTotal:  simHugepages,
+		HugepagesFree:   simHugepages,
+		HugepageSizeKiB: 2048,
+		MemTotalKiB:     simMemTotal / humanize.KiByte,
+		MemFreeKiB:      simMemAvail / humanize.KiByte,
+		MemAvailableKiB: simMemAvail / humanize.KiByte,
+	}
+}
+
+// simRoot returns the directory under which the simulated SCM of all engines
+// is mounted.
+func simRoot(cfg *config.Server) string {
+	return filepath.Join(cfg.SocketDir, simRootDir)
+}
+
+// simulation holds the state shared by the engines and storage simulated
+// when daos_server is started with --simulate.
+type simulation struct {
+	log     logging.Logger
+	root    string
+	storage *simStorage
+	pools   *simPools
+}
+
+func newSimulation(log logging.Logger, cfg *config.Server) (*simulation, error) {
+	root := simRoot(cfg)
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, errors.Wrap(err, "create simulation directory")
+	}
+	log.Noticef("simulating %d engine(s) with storage under %s", len(cfg.Engines), root)
+
+	sim := &simulation{
+		log:     log,
+		root:    root,
+		storage: newSimStorage(),
+		pools:   newSimPools(),
+	}
+
+	for i, ec := range cfg.Engines {
+		for _, tc := range ec.Storage.Tiers.BdevConfigs() {
+			for _, addr := range tc.Bdev.DeviceList.Devices() {
+				sim.storage.addController(addr, int32(i%2))
+			}
+		}
+	}
+
+	return sim, nil
+}
+
+func (sim *simulation) getMemInfo() (*common.MemInfo, error) {
+	return simMemInfo(), nil
+}
+
+// storageProvider returns a storage provider backed by simulated devices.
+func (sim *simulation) storageProvider(log logging.Logger, idx int, cfg *storage.Config) *storage.Provider {
+	return storage.NewProvider(log, idx, cfg, sim.storage, &simScmProvider{ss: sim.storage},
+		&simBdevProvider{ss: sim.storage}, &simMetadataProvider{ss: sim.storage})
+}
+
+// attachStorageControl points the storage control service at simulated
+// storage so that scan and format requests are serviced by the simulation.
+func (sim *simulation) attachStorageControl(scs *StorageControlService, ecs []*engine.Config) {
+	topCfg := &storage.Config{}
+	if len(ecs) > 0 {
+		topCfg.ControlMetadata = ecs[0].Storage.ControlMetadata
+	}
+
+	scs.storage = sim.storageProvider(logging.ForSubsystem(sim.log, logging.SubsystemStorage), 0, topCfg)
+	scs.getMemInfo = sim.getMemInfo
+}
+
+// newEngine returns a simulated engine process for the given engine config.
+func (sim *simulation) newEngine(cfg *engine.Config) *simEngine {
+	return &simEngine{
+		log:  sim.log,
+		sim:  sim,
+		cfg:  cfg,
+		rank: ranklist.NilRank,
+	}
+}
+
+// attachEngine replaces the runner and dRPC client of an instance with a
+// simulated engine.
+func (sim *simulation) attachEngine(ei *EngineInstance, cfg *engine.Config) {
+	se := sim.newEngine(cfg)
+	se.notifyReady = ei.NotifyDrpcReady
+
+	ei.runner = se
+	ei.getDrpcClientFn = func(sock string) drpc.DomainSocketClient {
+		return &simDrpcClient{se: se, socket: sock}
+	}
+}
+
+// simEngine stands in for a daos_engine process. When started it notifies
+// the instance that it is ready, as the engine would over dRPC, and it then
+// answers the instance's dRPC calls until stopped.
+type simEngine struct {
+	sync.Mutex
+	log         logging.Logger
+	sim         *simulation
+	cfg         *engine.Config
+	notifyReady func(*srvpb.NotifyReadyReq)
+	running     atm.Bool
+	stop        chan os.Signal
+	rank        ranklist.Rank
+}
+
+// Start implements EngineRunner.
+func (se *simEngine) Start(ctx context.Context) (engine.RunnerExitChan, error) {
+	se.Lock()
+	defer se.Unlock()
+
+	if se.running.IsTrue() {
+		return nil, errors.Errorf("simulated engine %d already running", se.cfg.Index)
+	}
+
+	provider, err := se.cfg.Fabric.GetPrimaryProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	exitCh := make(engine.RunnerExitChan, 1)
+	se.stop = make(chan os.Signal, 1)
+	se.running.SetTrue()
+
+	go func(stop chan os.Signal) {
+		var err error
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case sig := <-stop:
+			err = errors.Errorf("simulated engine stopped by %s", sig)
+		}
+		se.running.SetFalse()
+		exitCh <- &engine.RunnerExitInfo{Error: err}
+	}(se.stop)
+
+	se.log.Debugf("simulated engine %d started", se.cfg.Index)
+	se.notifyReady(&srvpb.NotifyReadyReq{
+		Uri:              fmt.Sprintf("%s://127.0.0.1:%d", provider, se.cfg.Fabric.InterfacePort),
+		Nctxs:            uint32(se.cfg.TargetCount + 1),
+		DrpcListenerSock: filepath.Join(se.cfg.SocketDir, fmt.Sprintf("daos_engine_sim%d.sock", se.cfg.Index)),
+		InstanceIdx:      se.cfg.Index,
+		Ntgts:            uint32(se.cfg.TargetCount),
+		Incarnation:      uint64(time.Now().UnixNano()),
+	})
+
+	return exitCh, nil
+}
+
+// IsRunning implements EngineRunner.
+func (se *simEngine) IsRunning() bool {
+	return se.running.IsTrue()
+}
+
+// Signal implements EngineRunner. Any signal stops the simulated engine.
+func (se *simEngine) Signal(sig os.Signal) {
+	se.Lock()
+	defer se.Unlock()
+
+	if se.stop == nil {
+		return
+	}
+	select {
+	case se.stop <- sig:
+	default:
+	}
+}
+
+// GetLastPid implements EngineRunner. Simulated engines have no process.
+func (se *simEngine) GetLastPid() uint64 {
+	return 0
+}
+
+// GetConfig implements EngineRunner.
+func (se *simEngine) GetConfig() *engine.Config {
+	return se.cfg
+}
+
+func (se *simEngine) getRank() ranklist.Rank {
+	se.Lock()
+	defer se.Unlock()
+	return se.rank
+}
+
+// handleCall answers a dRPC call as the engine would. Methods that return no
+// data beyond a status are answered with an empty successful response.
+func (se *simEngine) handleCall(call *drpc.Call) (*drpc.Response, error) {
+	resp := &drpc.Response{Sequence: call.Sequence}
+
+	method, err := drpc.ModuleID(call.Module).GetMethod(call.Method)
+	if err != nil {
+		resp.Status = drpc.Status_UNKNOWN_METHOD
+		return resp, nil
+	}
+
+	unmarshal := func(msg proto.Message) bool {
+		if err := proto.Unmarshal(call.Body, msg); err != nil {
+			se.log.Errorf("simulated engine %d: unmarshal %s: %s", se.cfg.Index, method, err)
+			resp.Status = drpc.Status_FAILED_UNMARSHAL_PAYLOAD
+			return false
+		}
+		return true
+	}
+
+	var msg proto.Message
+	switch method {
+	case drpc.MethodSetRank:
+		req := new(mgmtpb.SetRankReq)
+		if !unmarshal(req) {
+			return resp, nil
+		}
+		se.Lock()
+		se.rank = ranklist.Rank(req.Rank)
+		se.Unlock()
+	case drpc.MethodSmdDevs:
+		msg = &ctlpb.SmdDevResp{Devices: se.smdDevices()}
+	case drpc.MethodBioHealth:
+		req := new(ctlpb.BioHealthReq)
+		if !unmarshal(req) {
+			return resp, nil
+		}
+		msg = se.bioHealth(req.DevUuid)
+	case drpc.MethodBioTrim:
+		trimResp := new(ctlpb.BioTrimResp)
+		for _, dev := range se.smdDevices() {
+			trimResp.Devices = append(trimResp.Devices, &ctlpb.BioTrimResp_Device{
+				Uuid:      dev.Uuid,
+				BlockSize: 4096,
+			})
+		}
+		msg = trimResp
+	case drpc.MethodPoolCreate:
+		req := new(mgmtpb.PoolCreateReq)
+		if !unmarshal(req) {
+			return resp, nil
+		}
+		msg = se.sim.pools.create(req)
+	case drpc.MethodPoolDestroy:
+		req := new(mgmtpb.PoolDestroyReq)
+		if !unmarshal(req) {
+			return resp, nil
+		}
+		msg = &mgmtpb.PoolDestroyResp{Status: se.sim.pools.destroy(req.Id)}
+	case drpc.MethodPoolQuery:
+		req := new(mgmtpb.PoolQueryReq)
+		if !unmarshal(req) {
+			return resp, nil
+		}
+		msg = se.sim.pools.query(req.Id, se.cfg.TargetCount)
+	}
+
+	if msg != nil {
+		if resp.Body, err = proto.Marshal(msg); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// smdDevices returns a blobstore per simulated SSD assigned to the engine,
+// with the engine's targets distributed across the SSDs of the first tier.
+func (se *simEngine) smdDevices() []*ctlpb.SmdDevice {
+	rank := se.getRank()
+
+	var devs []*ctlpb.SmdDevice
+	for tierIdx, tc := range se.cfg.Storage.Tiers.BdevConfigs() {
+		ctrlrs := se.sim.storage.controllers(tc.Bdev.DeviceList.Devices())
+		for i, c := range ctrlrs {
+			dev := &ctlpb.SmdDevice{
+				Uuid:        se.devUUID(c.PciAddr),
+				TotalBytes:  simBdevSize,
+				AvailBytes:  simBdevSize,
+				UsableBytes: simBdevSize,
+				ClusterSize: simClusterSize,
+				Rank:        rank.Uint32(),
+				Ctrlr: &ctlpb.NvmeController{
+					Model:    c.Model,
+					Serial:   c.Serial,
+					PciAddr:  c.PciAddr,
+					FwRev:    c.FwRev,
+					SocketId: c.SocketID,
+					DevState: ctlpb.NvmeDevState_NORMAL,
+					Namespaces: []*ctlpb.NvmeController_Namespace{
+						{Id: 1, Size: simBdevSize, CtrlrPciAddr: c.PciAddr},
+					},
+				},
+				CtrlrNamespaceId: 1,
+			}
+			if tierIdx == 0 {
+				for tgt := i; tgt < se.cfg.TargetCount; tgt += len(ctrlrs) {
+					dev.TgtIds = append(dev.TgtIds, int32(tgt))
+				}
+			}
+			devs = append(devs, dev)
+		}
+	}
+
+	return devs
+}
+
+func (se *simEngine) devUUID(pciAddr string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(se.sim.root+pciAddr)).String()
+}
+
+func (se *simEngine) bioHealth(devUUID string) *ctlpb.BioHealthResp {
+	for _, dev := range se.smdDevices() {
+		if dev.Uuid != devUUID {
+			continue
+		}
+		return &ctlpb.BioHealthResp{
+			Timestamp:   uint64(time.Now().Unix()),
+			DevUuid:     dev.Uuid,
+			Temperature: 300,
+			TotalBytes:  dev.TotalBytes,
+			AvailBytes:  dev.AvailBytes,
+			ClusterSize: dev.ClusterSize,
+		}
+	}
+
+	return &ctlpb.BioHealthResp{Status: int32(daos.Nonexistent)}
+}
+
+// simDrpcClient implements drpc.DomainSocketClient by passing calls directly
+// to a simulated engine.
+type simDrpcClient struct {
+	sync.Mutex
+	se        *simEngine
+	socket    string
+	connected bool
+}
+
+func (c *simDrpcClient) IsConnected() bool {
+	return c.connected
+}
+
+func (c *simDrpcClient) Connect(_ context.Context) error {
+	if !c.se.IsRunning() {
+		return errors.Errorf("simulated engine %d not running", c.se.cfg.Index)
+	}
+	c.connected = true
+	return nil
+}
+
+func (c *simDrpcClient) Close() error {
+	c.connected = false
+	return nil
+}
+
+func (c *simDrpcClient) SendMsg(_ context.Context, call *drpc.Call) (*drpc.Response, error) {
+	if !c.connected {
+		return nil, errors.New("not connected")
+	}
+	return c.se.handleCall(call)
+}
+
+func (c *simDrpcClient) GetSocketPath() string {
+	return c.socket
+}
+
+type simPool struct {
+	ranks     []uint32
+	svcReps   []uint32
+	tierBytes []uint64
+}
+
+// simPools records the pools created on simulated engines so that they can
+// be queried and destroyed. Pools are held in memory only.
+type simPools struct {
+	sync.RWMutex
+	pools map[string]*simPool
+}
+
+func newSimPools() *simPools {
+	return &simPools{pools: make(map[string]*simPool)}
+}
+
+func (sp *simPools) create(req *mgmtpb.PoolCreateReq) *mgmtpb.PoolCreateResp {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if _, exists := sp.pools[req.Uuid]; exists {
+		return &mgmtpb.PoolCreateResp{Status: int32(daos.Exists)}
+	}
+	if len(req.Ranks) == 0 {
+		return &mgmtpb.PoolCreateResp{Status: int32(daos.InvalidInput)}
+	}
+
+	nReps := int(req.Numsvcreps)
+	if nReps < 1 {
+		nReps = 1
+	}
+	if nReps > len(req.Ranks) {
+		nReps = len(req.Ranks)
+	}
+
+	pool := &simPool{
+		ranks:     req.Ranks,
+		svcReps:   req.Ranks[:nReps],
+		tierBytes: req.Tierbytes,
+	}
+	sp.pools[req.Uuid] = pool
+
+	return &mgmtpb.PoolCreateResp{
+		SvcLdr:       pool.svcReps[0],
+		SvcReps:      pool.svcReps,
+		TgtRanks:     pool.ranks,
+		TierBytes:    pool.tierBytes,
+		MetaBlobSize: req.MetaBlobSize,
+	}
+}
+
+func (sp *simPools) destroy(id string) int32 {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if _, exists := sp.pools[id]; !exists {
+		return int32(daos.Nonexistent)
+	}
+	delete(sp.pools, id)
+
+	return 0
+}
+
+func (sp *simPools) query(id string, tgtsPerRank int) *mgmtpb.PoolQueryResp {
+	sp.RLock()
+	defer sp.RUnlock()
+
+	pool, exists := sp.pools[id]
+	if !exists {
+		return &mgmtpb.PoolQueryResp{Status: int32(daos.Nonexistent)}
+	}
+
+	nTgts := uint32(len(pool.ranks) * tgtsPerRank)
+	resp := &mgmtpb.PoolQueryResp{
+		Uuid:          id,
+		TotalTargets:  nTgts,
+		ActiveTargets: nTgts,
+		TotalEngines:  uint32(len(pool.ranks)),
+		Version:       1,
+		State:         mgmtpb.PoolServiceState_Ready,
+		SvcLdr:        pool.svcReps[0],
+		SvcReps:       pool.svcReps,
+		Rebuild:       &mgmtpb.PoolRebuildStatus{State: mgmtpb.PoolRebuildStatus_IDLE},
+	}
+	for i, tb := range pool.tierBytes {
+		total := tb * uint64(len(pool.ranks))
+		resp.TierStats = append(resp.TierStats, &mgmtpb.StorageUsageStats{
+			Total:     total,
+			Free:      total,
+			Min:       tb,
+			Max:       tb,
+			Mean:      tb,
+			MediaType: mgmtpb.StorageMediaType(i),
+		})
+	}
+
+	return resp
+}
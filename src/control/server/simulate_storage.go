@@ -0,0 +1,288 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	simBdevsPerEngine  = 2
+	simBdevSize        = 2 * humanize.TByte
+	simRamdiskSizeGiB  = 4
+	simNvmeModel       = "DAOS Simulated NVMe"
+	simNvmeFirmwareRev = "SIM0001"
+)
+
+// simStorage backs the storage providers of a simulation. SCM mounts are
+// tracked in memory and formatted mountpoints are represented by plain
+// directories, so that superblocks written by the control plane persist
+// across engine restarts in the same way as for a tmpfs that stays mounted.
+type simStorage struct {
+	sync.RWMutex
+	mounts  map[string]uint64 // mountpoint -> size in bytes
+	ctrlrs  map[string]*storage.NvmeController
+	ramSize uint64
+}
+
+func newSimStorage() *simStorage {
+	return &simStorage{
+		mounts:  make(map[string]uint64),
+		ctrlrs:  make(map[string]*storage.NvmeController),
+		ramSize: simRamdiskSizeGiB * humanize.GiByte,
+	}
+}
+
+// addController registers a simulated NVMe controller at the given address.
+func (ss *simStorage) addController(pciAddr string, socketID int32) {
+	ss.Lock()
+	defer ss.Unlock()
+
+	ss.ctrlrs[pciAddr] = &storage.NvmeController{
+		Model:    simNvmeModel,
+		Serial:   fmt.Sprintf("SIM%s", pciAddr),
+		PciAddr:  pciAddr,
+		FwRev:    simNvmeFirmwareRev,
+		SocketID: socketID,
+		Namespaces: []*storage.NvmeNamespace{
+			{ID: 1, Size: simBdevSize},
+		},
+		NvmeState: storage.NvmeStateNormal,
+	}
+}
+
+func (ss *simStorage) controllers(addrs []string) storage.NvmeControllers {
+	ss.RLock()
+	defer ss.RUnlock()
+
+	if len(addrs) == 0 {
+		for addr := range ss.ctrlrs {
+			addrs = append(addrs, addr)
+		}
+		sort.Strings(addrs)
+	}
+
+	ctrlrs := make(storage.NvmeControllers, 0, len(addrs))
+	for _, addr := range addrs {
+		if c, found := ss.ctrlrs[addr]; found {
+			cc := *c
+			ctrlrs = append(ctrlrs, &cc)
+		}
+	}
+
+	return ctrlrs
+}
+
+func (ss *simStorage) mount(target string, rp *storage.RamdiskParams) error {
+	if err := os.MkdirAll(target, 0700); err != nil {
+		return errors.Wrapf(err, "create simulated mountpoint %s", target)
+	}
+
+	size := ss.ramSize
+	if rp != nil && rp.Size > 0 {
+		size = uint64(rp.Size) * humanize.GiByte
+	}
+
+	ss.Lock()
+	defer ss.Unlock()
+	ss.mounts[target] = size
+
+	return nil
+}
+
+func (ss *simStorage) unmount(target string) {
+	ss.Lock()
+	defer ss.Unlock()
+	delete(ss.mounts, target)
+}
+
+func (ss *simStorage) isMounted(target string) bool {
+	ss.RLock()
+	defer ss.RUnlock()
+	_, mounted := ss.mounts[target]
+	return mounted
+}
+
+func (ss *simStorage) isFormatted(target string) bool {
+	fi, err := os.Stat(target)
+	return err == nil && fi.IsDir()
+}
+
+// IsMounted implements storage.SystemProvider.
+func (ss *simStorage) IsMounted(target string) (bool, error) {
+	return ss.isMounted(target), nil
+}
+
+// GetfsUsage implements storage.SystemProvider. Simulated filesystems are
+// always reported as empty.
+func (ss *simStorage) GetfsUsage(target string) (uint64, uint64, error) {
+	ss.RLock()
+	defer ss.RUnlock()
+
+	size, mounted := ss.mounts[target]
+	if !mounted {
+		return 0, 0, errors.Errorf("%s is not mounted", target)
+	}
+	return size, size, nil
+}
+
+// ReadFile implements storage.SystemProvider.
+func (ss *simStorage) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// simScmProvider implements storage.ScmProvider on top of simStorage.
+type simScmProvider struct {
+	ss *simStorage
+}
+
+func (p *simScmProvider) Mount(req storage.ScmMountRequest) (*storage.MountResponse, error) {
+	if err := p.ss.mount(req.Target, req.Ramdisk); err != nil {
+		return nil, err
+	}
+	return &storage.MountResponse{Target: req.Target, Mounted: true}, nil
+}
+
+func (p *simScmProvider) Unmount(req storage.ScmMountRequest) (*storage.MountResponse, error) {
+	p.ss.unmount(req.Target)
+	return &storage.MountResponse{Target: req.Target}, nil
+}
+
+func (p *simScmProvider) Format(req storage.ScmFormatRequest) (*storage.ScmFormatResponse, error) {
+	if err := os.RemoveAll(req.Mountpoint); err != nil {
+		return nil, errors.Wrapf(err, "clear simulated mountpoint %s", req.Mountpoint)
+	}
+	if err := p.ss.mount(req.Mountpoint, req.Ramdisk); err != nil {
+		return nil, err
+	}
+
+	return &storage.ScmFormatResponse{
+		Mountpoint: req.Mountpoint,
+		Formatted:  true,
+		Mounted:    true,
+	}, nil
+}
+
+func (p *simScmProvider) CheckFormat(req storage.ScmFormatRequest) (*storage.ScmFormatResponse, error) {
+	mounted := p.ss.isMounted(req.Mountpoint)
+	formatted := p.ss.isFormatted(req.Mountpoint)
+
+	return &storage.ScmFormatResponse{
+		Mountpoint: req.Mountpoint,
+		Formatted:  formatted,
+		Mounted:    mounted,
+		Mountable:  formatted && !mounted,
+	}, nil
+}
+
+func (p *simScmProvider) Scan(storage.ScmScanRequest) (*storage.ScmScanResponse, error) {
+	return &storage.ScmScanResponse{}, nil
+}
+
+func (p *simScmProvider) Prepare(storage.ScmPrepareRequest) (*storage.ScmPrepareResponse, error) {
+	return &storage.ScmPrepareResponse{}, nil
+}
+
+func (p *simScmProvider) QueryHealth(storage.ScmHealthQueryRequest) (*storage.ScmHealthQueryResponse, error) {
+	return &storage.ScmHealthQueryResponse{}, nil
+}
+
+func (p *simScmProvider) QueryFirmware(storage.ScmFirmwareQueryRequest) (*storage.ScmFirmwareQueryResponse, error) {
+	return &storage.ScmFirmwareQueryResponse{}, nil
+}
+
+func (p *simScmProvider) UpdateFirmware(storage.ScmFirmwareUpdateRequest) (*storage.ScmFirmwareUpdateResponse, error) {
+	return nil, errors.New("firmware update not supported by simulated SCM")
+}
+
+// simBdevProvider implements storage.BdevProvider on top of simStorage.
+type simBdevProvider struct {
+	ss *simStorage
+}
+
+func (p *simBdevProvider) Prepare(storage.BdevPrepareRequest) (*storage.BdevPrepareResponse, error) {
+	return &storage.BdevPrepareResponse{}, nil
+}
+
+func (p *simBdevProvider) Scan(req storage.BdevScanRequest) (*storage.BdevScanResponse, error) {
+	var addrs []string
+	if req.DeviceList != nil {
+		addrs = req.DeviceList.Devices()
+	}
+
+	return &storage.BdevScanResponse{Controllers: p.ss.controllers(addrs)}, nil
+}
+
+func (p *simBdevProvider) Format(req storage.BdevFormatRequest) (*storage.BdevFormatResponse, error) {
+	resp := &storage.BdevFormatResponse{
+		DeviceResponses: make(storage.BdevDeviceFormatResponses),
+	}
+	if req.Properties.DeviceList == nil {
+		return resp, nil
+	}
+
+	for _, c := range p.ss.controllers(req.Properties.DeviceList.Devices()) {
+		resp.DeviceResponses[c.PciAddr] = &storage.BdevDeviceFormatResponse{Formatted: true}
+	}
+
+	return resp, nil
+}
+
+func (p *simBdevProvider) WriteConfig(storage.BdevWriteConfigRequest) (*storage.BdevWriteConfigResponse, error) {
+	return &storage.BdevWriteConfigResponse{}, nil
+}
+
+func (p *simBdevProvider) QueryFirmware(storage.NVMeFirmwareQueryRequest) (*storage.NVMeFirmwareQueryResponse, error) {
+	return &storage.NVMeFirmwareQueryResponse{}, nil
+}
+
+func (p *simBdevProvider) UpdateFirmware(storage.NVMeFirmwareUpdateRequest) (*storage.NVMeFirmwareUpdateResponse, error) {
+	return nil, errors.New("firmware update not supported by simulated NVMe")
+}
+
+// simMetadataProvider implements storage.MetadataProvider on top of
+// simStorage for configurations with control metadata paths.
+type simMetadataProvider struct {
+	ss *simStorage
+}
+
+func (p *simMetadataProvider) Format(req storage.MetadataFormatRequest) error {
+	if err := os.RemoveAll(req.RootPath); err != nil {
+		return errors.Wrapf(err, "clear simulated metadata path %s", req.RootPath)
+	}
+	for _, idx := range req.EngineIdxs {
+		engDir := storage.ControlMetadataEngineDir(req.DataPath, idx)
+		if err := os.MkdirAll(engDir, 0700); err != nil {
+			return errors.Wrapf(err, "create simulated metadata path %s", engDir)
+		}
+	}
+	return p.ss.mount(req.RootPath, nil)
+}
+
+func (p *simMetadataProvider) NeedsFormat(req storage.MetadataFormatRequest) (bool, error) {
+	return !p.ss.isFormatted(req.DataPath), nil
+}
+
+func (p *simMetadataProvider) Mount(req storage.MetadataMountRequest) (*storage.MountResponse, error) {
+	if err := p.ss.mount(req.RootPath, nil); err != nil {
+		return nil, err
+	}
+	return &storage.MountResponse{Target: req.RootPath, Mounted: true}, nil
+}
+
+func (p *simMetadataProvider) Unmount(req storage.MetadataMountRequest) (*storage.MountResponse, error) {
+	p.ss.unmount(req.RootPath)
+	return &storage.MountResponse{Target: req.RootPath}, nil
+}
@@ -0,0 +1,296 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/daos"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func simTestConfig(sockDir string, nrEngines int) *config.Server {
+	cfg := config.DefaultServer().WithSocketDir(sockDir)
+	cfg.SimulateEngines = nrEngines
+	return cfg
+}
+
+func TestServer_prepSimulatedConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nrEngines int
+		metadata  bool
+		expErr    error
+	}{
+		"too few engines": {
+			expErr: errors.New("must be between 1"),
+		},
+		"too many engines": {
+			nrEngines: simMaxEngines + 1,
+			expErr:    errors.New("must be between 1"),
+		},
+		"single engine": {
+			nrEngines: 1,
+		},
+		"multiple engines": {
+			nrEngines: 3,
+		},
+		"control metadata ignored": {
+			nrEngines: 2,
+			metadata:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			cfg := simTestConfig(testDir, tc.nrEngines)
+			if tc.metadata {
+				cfg.Metadata = storage.ControlMetadata{Path: "/var/daos/md"}
+			}
+
+			gotErr := prepSimulatedConfig(log, cfg)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			test.AssertEqual(t, tc.nrEngines, len(cfg.Engines), "unexpected engine count")
+			test.AssertEqual(t, "", cfg.Metadata.Path, "control metadata should be cleared")
+			test.AssertTrue(t, cfg.NrHugepages > 0, "expected hugepages to be set")
+
+			ports := make(map[int]bool)
+			for i, ec := range cfg.Engines {
+				test.AssertEqual(t, simTargetCount, ec.TargetCount, "unexpected target count")
+
+				scmCfgs := ec.Storage.Tiers.ScmConfigs()
+				test.AssertEqual(t, 1, len(scmCfgs), "expected one SCM tier")
+				test.AssertEqual(t, storage.ClassRam, scmCfgs[0].Class, "unexpected SCM class")
+				test.AssertEqual(t, filepath.Join(testDir, simRootDir), filepath.Dir(scmCfgs[0].Scm.MountPoint),
+					"SCM not mounted under simulation root")
+
+				bdevCfgs := ec.Storage.Tiers.BdevConfigs()
+				test.AssertEqual(t, 1, len(bdevCfgs), "expected one bdev tier")
+				test.AssertEqual(t, simBdevsPerEngine, bdevCfgs[0].Bdev.DeviceList.Len(), "unexpected bdev count")
+
+				if ports[ec.Fabric.InterfacePort] {
+					t.Fatalf("engine %d reuses fabric port %d", i, ec.Fabric.InterfacePort)
+				}
+				ports[ec.Fabric.InterfacePort] = true
+			}
+		})
+	}
+}
+
+func newTestSimulation(t *testing.T, log logging.Logger, nrEngines int) (*simulation, *config.Server) {
+	t.Helper()
+
+	cfg := simTestConfig(t.TempDir(), nrEngines)
+	if err := prepSimulatedConfig(log, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	sim, err := newSimulation(log, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sim, cfg
+}
+
+func TestServer_simulation_storage(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sim, cfg := newTestSimulation(t, log, 1)
+	ec := cfg.Engines[0]
+	sp := sim.storageProvider(log, 0, &ec.Storage)
+
+	needsFormat, err := sp.ScmNeedsFormat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, needsFormat, "expected unformatted SCM to need format")
+
+	if err := sp.FormatScm(false); err != nil {
+		t.Fatal(err)
+	}
+
+	mounted, err := sp.ScmIsMounted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, mounted, "expected SCM to be mounted after format")
+
+	usage, err := sp.GetScmUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, uint64(simRamdiskSizeGiB)*1<<30, usage.TotalBytes, "unexpected SCM size")
+
+	// Simulated SCM contents persist while unmounted so formatted SCM is remounted.
+	if err := sp.UnmountTmpfs(); err != nil {
+		t.Fatal(err)
+	}
+	if needsFormat, err = sp.ScmNeedsFormat(); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertFalse(t, needsFormat, "expected formatted SCM not to need format")
+
+	scanResp, err := sp.ScanBdevs(storage.BdevScanRequest{DeviceList: ec.Storage.Tiers.BdevConfigs()[0].Bdev.DeviceList})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, simBdevsPerEngine, len(scanResp.Controllers), "unexpected controller count")
+
+	results := sp.FormatBdevTiers(scanResp.Controllers)
+	for _, res := range results {
+		if res.Error != nil {
+			t.Fatal(res.Error)
+		}
+		for addr, devResp := range res.Result.DeviceResponses {
+			test.AssertTrue(t, devResp.Formatted, "expected "+addr+" to be formatted")
+		}
+	}
+}
+
+func TestServer_simEngine_Start(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sim, cfg := newTestSimulation(t, log, 1)
+	se := sim.newEngine(cfg.Engines[0])
+
+	readyCh := make(chan *srvpb.NotifyReadyReq, 1)
+	se.notifyReady = func(req *srvpb.NotifyReadyReq) {
+		readyCh <- req
+	}
+
+	exitCh, err := se.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, se.IsRunning(), "expected engine to be running")
+
+	if _, err := se.Start(context.Background()); err == nil {
+		t.Fatal("expected error starting running engine")
+	}
+
+	req := <-readyCh
+	test.AssertEqual(t, uint32(simTargetCount), req.Ntgts, "unexpected target count")
+	test.AssertTrue(t, req.DrpcListenerSock != "", "expected dRPC socket path")
+
+	se.Signal(syscall.SIGINT)
+	select {
+	case exitInfo := <-exitCh:
+		if exitInfo.Error == nil {
+			t.Fatal("expected non-nil exit error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("simulated engine did not stop")
+	}
+	test.AssertFalse(t, se.IsRunning(), "expected engine to be stopped")
+}
+
+func TestServer_simEngine_handleCall(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	sim, cfg := newTestSimulation(t, log, 1)
+	se := sim.newEngine(cfg.Engines[0])
+
+	call := func(t *testing.T, method drpc.Method, req, resp proto.Message) *drpc.Response {
+		t.Helper()
+
+		body, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dresp, err := se.handleCall(&drpc.Call{
+			Module: int32(method.Module()),
+			Method: method.ID(),
+			Body:   body,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp != nil {
+			if err := proto.Unmarshal(dresp.Body, resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dresp
+	}
+
+	call(t, drpc.MethodSetRank, &mgmtpb.SetRankReq{Rank: 3}, nil)
+
+	smdResp := new(ctlpb.SmdDevResp)
+	call(t, drpc.MethodSmdDevs, new(ctlpb.SmdDevReq), smdResp)
+	test.AssertEqual(t, simBdevsPerEngine, len(smdResp.Devices), "unexpected device count")
+	var nrTgts int
+	for _, dev := range smdResp.Devices {
+		test.AssertEqual(t, uint32(3), dev.Rank, "unexpected device rank")
+		nrTgts += len(dev.TgtIds)
+	}
+	test.AssertEqual(t, simTargetCount, nrTgts, "targets not assigned to devices")
+
+	healthResp := new(ctlpb.BioHealthResp)
+	call(t, drpc.MethodBioHealth, &ctlpb.BioHealthReq{DevUuid: smdResp.Devices[0].Uuid}, healthResp)
+	test.AssertEqual(t, int32(0), healthResp.Status, "unexpected health status")
+	test.AssertEqual(t, uint64(simBdevSize), healthResp.TotalBytes, "unexpected device size")
+
+	poolUUID := test.MockUUID(1)
+	createResp := new(mgmtpb.PoolCreateResp)
+	call(t, drpc.MethodPoolCreate, &mgmtpb.PoolCreateReq{
+		Uuid:       poolUUID,
+		Ranks:      []uint32{0, 1, 2},
+		Numsvcreps: 5,
+		Tierbytes:  []uint64{1 << 30, 1 << 40},
+	}, createResp)
+	test.AssertEqual(t, int32(0), createResp.Status, "unexpected create status")
+	test.AssertEqual(t, []uint32{0, 1, 2}, createResp.SvcReps, "unexpected service replicas")
+
+	dupResp := new(mgmtpb.PoolCreateResp)
+	call(t, drpc.MethodPoolCreate, &mgmtpb.PoolCreateReq{Uuid: poolUUID, Ranks: []uint32{0}}, dupResp)
+	test.AssertEqual(t, int32(daos.Exists), dupResp.Status, "unexpected duplicate create status")
+
+	queryResp := new(mgmtpb.PoolQueryResp)
+	call(t, drpc.MethodPoolQuery, &mgmtpb.PoolQueryReq{Id: poolUUID}, queryResp)
+	test.AssertEqual(t, int32(0), queryResp.Status, "unexpected query status")
+	test.AssertEqual(t, uint32(3*simTargetCount), queryResp.TotalTargets, "unexpected target count")
+	test.AssertEqual(t, 2, len(queryResp.TierStats), "unexpected tier stats")
+	test.AssertEqual(t, uint64(3<<40), queryResp.TierStats[1].Total, "unexpected NVMe total")
+
+	destroyResp := new(mgmtpb.PoolDestroyResp)
+	call(t, drpc.MethodPoolDestroy, &mgmtpb.PoolDestroyReq{Id: poolUUID}, destroyResp)
+	test.AssertEqual(t, int32(0), destroyResp.Status, "unexpected destroy status")
+
+	call(t, drpc.MethodPoolQuery, &mgmtpb.PoolQueryReq{Id: poolUUID}, queryResp)
+	test.AssertEqual(t, int32(daos.Nonexistent), queryResp.Status, "expected destroyed pool to be gone")
+
+	unknown, err := se.handleCall(&drpc.Call{Module: 255, Method: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, drpc.Status_UNKNOWN_METHOD, unknown.Status, "unexpected status for unknown method")
+}
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -233,6 +234,28 @@ func (nch *NvmeHealth) TempF() float32 {
 	return (nch.TempC() * (9.0 / 5.0)) + 32.0
 }
 
+// BdevIOStats contains the I/O rates and latencies of a bdev over a sampling
+// period.
+type BdevIOStats struct {
+	PeriodMs     uint64  `json:"period_ms"`
+	ReadIOPS     float64 `json:"read_iops"`
+	WriteIOPS    float64 `json:"write_iops"`
+	ReadBW       float64 `json:"read_bw"`
+	WriteBW      float64 `json:"write_bw"`
+	QueueDepth   uint32  `json:"queue_depth"`
+	ReadLatP50   uint64  `json:"read_lat_p50"`
+	ReadLatP99   uint64  `json:"read_lat_p99"`
+	ReadLatP999  uint64  `json:"read_lat_p999"`
+	WriteLatP50  uint64  `json:"write_lat_p50"`
+	WriteLatP99  uint64  `json:"write_lat_p99"`
+	WriteLatP999 uint64  `json:"write_lat_p999"`
+}
+
+// Period returns the sampling period of the stats.
+func (bis *BdevIOStats) Period() time.Duration {
+	return time.Duration(bis.PeriodMs) * time.Millisecond
+}
+
 // NvmeNamespace represents an individual NVMe namespace on a device and
 // mirrors C.struct_ns_t.
 type NvmeNamespace struct {
@@ -258,6 +281,7 @@ type SmdDevice struct {
 	HasSysXS         bool           `json:"has_sys_xs"`
 	Ctrlr            NvmeController `json:"ctrlr"`
 	CtrlrNamespaceID uint32         `json:"ctrlr_namespace_id"`
+	IOStats          *BdevIOStats   `json:"io_stats,omitempty"`
 }
 
 func (sd *SmdDevice) String() string {
@@ -496,6 +520,9 @@ type (
 		Reset_             bool
 		DisableVFIO        bool
 		EnableVMD          bool
+		OpalDevices        []string
+		OpalProvision      bool
+		EncryptionKey      EncryptionKey
 	}
 
 	// BdevPrepareResponse contains the results of a successful Prepare operation.
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -128,8 +128,47 @@ func (mb *MockBackend) WriteConfig(req storage.BdevWriteConfigRequest) (*storage
 	}
 }
 
+// MockOpalConfig specifies the behavior of a MockOpalProvider.
+type MockOpalConfig struct {
+	ProvisionErr error
+	UnlockErr    error
+}
+
+// MockOpalProvider records the operations performed on Opal drives.
+type MockOpalProvider struct {
+	mu    sync.Mutex
+	cfg   MockOpalConfig
+	Calls []string
+}
+
+func (mop *MockOpalProvider) Provision(pciAddr string, _ storage.EncryptionKey) error {
+	mop.mu.Lock()
+	defer mop.mu.Unlock()
+	mop.Calls = append(mop.Calls, "provision "+pciAddr)
+	return mop.cfg.ProvisionErr
+}
+
+func (mop *MockOpalProvider) Unlock(pciAddr string, _ storage.EncryptionKey) error {
+	mop.mu.Lock()
+	defer mop.mu.Unlock()
+	mop.Calls = append(mop.Calls, "unlock "+pciAddr)
+	return mop.cfg.UnlockErr
+}
+
+// NewMockOpalProvider returns a MockOpalProvider with the supplied behavior.
+func NewMockOpalProvider(cfg *MockOpalConfig) *MockOpalProvider {
+	if cfg == nil {
+		cfg = &MockOpalConfig{}
+	}
+	return &MockOpalProvider{
+		cfg: *cfg,
+	}
+}
+
 func NewMockProvider(log logging.Logger, mbc *MockBackendConfig) *Provider {
-	return NewProvider(log, NewMockBackend(mbc))
+	p := NewProvider(log, NewMockBackend(mbc))
+	p.opal = NewMockOpalProvider(nil)
+	return p
 }
 
 func DefaultMockProvider(log logging.Logger) *Provider {
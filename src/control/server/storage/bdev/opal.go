@@ -0,0 +1,262 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// Definitions from the kernel's include/uapi/linux/sed-opal.h.
+const (
+	opalMaxKeyLen = 256
+	opalMaxLRs    = 9
+
+	opalAdmin1 = 0 // session user
+	opalRW     = 2 // lock state
+
+	opalFlLockingEnabled = 0x4
+
+	iocTypeOpal = 'p'
+
+	iocNrOpalLockUnlock    = 221
+	iocNrOpalTakeOwnership = 222
+	iocNrOpalActivateLSP   = 223
+	iocNrOpalLRSetup       = 227
+	iocNrOpalGetStatus     = 236
+
+	iocWrite = 1
+	iocRead  = 2
+)
+
+var (
+	sysPCIDevicesDir = "/sys/bus/pci/devices"
+
+	errNotKernelBound = errors.New("not bound to the kernel nvme driver")
+)
+
+type (
+	opalKey struct {
+		lr      uint8
+		keyLen  uint8
+		keyType uint8
+		_       [5]uint8
+		key     [opalMaxKeyLen]uint8
+	}
+
+	opalSessionInfo struct {
+		sum uint32
+		who uint32
+		key opalKey
+	}
+
+	opalLockUnlock struct {
+		session opalSessionInfo
+		lState  uint32
+		flags   uint8
+		_       [3]uint8
+	}
+
+	opalLRAct struct {
+		key    opalKey
+		sum    uint32
+		numLRs uint8
+		lr     [opalMaxLRs]uint8
+		_      [2]uint8
+	}
+
+	opalUserLRSetup struct {
+		rangeStart  uint64
+		rangeLength uint64
+		rle         uint32
+		wle         uint32
+		session     opalSessionInfo
+	}
+
+	opalStatus struct {
+		flags    uint32
+		reserved uint32
+	}
+
+	// OpalProvider provides management of TCG Opal self-encrypting NVMe
+	// SSDs, identified by PCI address, while they are bound to the kernel
+	// NVMe driver.
+	OpalProvider interface {
+		Provision(pciAddr string, key storage.EncryptionKey) error
+		Unlock(pciAddr string, key storage.EncryptionKey) error
+	}
+
+	sedOpal struct {
+		log logging.Logger
+	}
+)
+
+func iocNum(dir, nr uintptr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | iocTypeOpal<<8 | nr
+}
+
+func newOpalKey(key storage.EncryptionKey) (opalKey, error) {
+	var ok opalKey
+	if len(key) == 0 || len(key) > opalMaxKeyLen {
+		return ok, errors.Errorf("opal key length must be between 1 and %d bytes", opalMaxKeyLen)
+	}
+	ok.keyLen = uint8(len(key) & 0xff)
+	copy(ok.key[:], key)
+	return ok, nil
+}
+
+func defaultOpalProvider(log logging.Logger) *sedOpal {
+	return &sedOpal{log: log}
+}
+
+// blockDevice returns the path of the first namespace block device of the
+// NVMe controller at the given PCI address.
+func (so *sedOpal) blockDevice(pciAddr string) (string, error) {
+	pattern := filepath.Join(sysPCIDevicesDir, pciAddr, "nvme", "nvme*", "nvme*n*")
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", errors.Wrapf(errNotKernelBound, "no namespace block device found for %s", pciAddr)
+	}
+	sort.Strings(matches)
+
+	return filepath.Join("/dev", filepath.Base(matches[0])), nil
+}
+
+func (so *sedOpal) ioctl(dev string, nr uintptr, dir uintptr, arg unsafe.Pointer, size uintptr) error {
+	f, err := os.OpenFile(dev, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), iocNum(dir, nr, size),
+		uintptr(arg)); errno != 0 {
+		return errors.Wrapf(errno, "sed-opal ioctl %d on %s", nr, dev)
+	}
+
+	return nil
+}
+
+func (so *sedOpal) lockingEnabled(dev string) (bool, error) {
+	var status opalStatus
+	if err := so.ioctl(dev, iocNrOpalGetStatus, iocRead, unsafe.Pointer(&status),
+		unsafe.Sizeof(status)); err != nil {
+		return false, errors.Wrap(err, "getting opal status")
+	}
+
+	return status.flags&opalFlLockingEnabled != 0, nil
+}
+
+// Provision takes ownership of the drive and enables locking of the global
+// locking range with the supplied key. Drives which already have locking enabled
+// are left unchanged.
+func (so *sedOpal) Provision(pciAddr string, key storage.EncryptionKey) error {
+	dev, err := so.blockDevice(pciAddr)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := so.lockingEnabled(dev)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		so.log.Debugf("%s: opal locking already enabled", pciAddr)
+		return nil
+	}
+
+	ok, err := newOpalKey(key)
+	if err != nil {
+		return err
+	}
+
+	so.log.Debugf("%s: taking opal ownership", pciAddr)
+	if err := so.ioctl(dev, iocNrOpalTakeOwnership, iocWrite, unsafe.Pointer(&ok),
+		unsafe.Sizeof(ok)); err != nil {
+		return errors.Wrap(err, "taking opal ownership")
+	}
+
+	act := opalLRAct{key: ok, numLRs: 1}
+	if err := so.ioctl(dev, iocNrOpalActivateLSP, iocWrite, unsafe.Pointer(&act),
+		unsafe.Sizeof(act)); err != nil {
+		return errors.Wrap(err, "activating opal locking SP")
+	}
+
+	// a zero-length range from zero configures the global locking range
+	setup := opalUserLRSetup{
+		rle:     1,
+		wle:     1,
+		session: opalSessionInfo{who: opalAdmin1, key: ok},
+	}
+	if err := so.ioctl(dev, iocNrOpalLRSetup, iocWrite, unsafe.Pointer(&setup),
+		unsafe.Sizeof(setup)); err != nil {
+		return errors.Wrap(err, "setting up opal locking range")
+	}
+
+	return nil
+}
+
+// Unlock sets the global locking range of the drive to read-write. Drives which
+// are already bound to a userspace driver can't be accessed and are assumed to
+// have been unlocked before they were unbound from the kernel driver.
+func (so *sedOpal) Unlock(pciAddr string, key storage.EncryptionKey) error {
+	dev, err := so.blockDevice(pciAddr)
+	if err != nil {
+		if errors.Cause(err) == errNotKernelBound {
+			so.log.Debugf("%s: skipping opal unlock: %s", pciAddr, err)
+			return nil
+		}
+		return err
+	}
+
+	ok, err := newOpalKey(key)
+	if err != nil {
+		return err
+	}
+
+	lu := opalLockUnlock{
+		session: opalSessionInfo{who: opalAdmin1, key: ok},
+		lState:  opalRW,
+	}
+	if err := so.ioctl(dev, iocNrOpalLockUnlock, iocWrite, unsafe.Pointer(&lu),
+		unsafe.Sizeof(lu)); err != nil {
+		return errors.Wrap(err, "unlocking opal drive")
+	}
+
+	return nil
+}
+
+// prepareOpal provisions (if requested) and unlocks the Opal drives in the
+// request. This must happen before the drives are unbound from the kernel
+// driver, after which the sed-opal ioctls are no longer available.
+func (p *Provider) prepareOpal(req storage.BdevPrepareRequest) error {
+	for _, addr := range req.OpalDevices {
+		if req.OpalProvision {
+			if err := p.opal.Provision(addr, req.EncryptionKey); err != nil {
+				return errors.Wrapf(err, "provisioning opal drive %s", addr)
+			}
+		}
+
+		p.log.Debugf("unlocking opal drive %s", addr)
+		if err := p.opal.Unlock(addr, req.EncryptionKey); err != nil {
+			return errors.Wrapf(err, "unlocking opal drive %s", addr)
+		}
+	}
+
+	return nil
+}
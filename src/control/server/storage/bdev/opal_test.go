@@ -0,0 +1,41 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// The ioctl request numbers encode the structure sizes, so these must match
+// the kernel definitions exactly.
+func TestBdev_opalStructSizes(t *testing.T) {
+	for name, tc := range map[string]struct {
+		size    uintptr
+		expSize uintptr
+	}{
+		"opal_key":           {unsafe.Sizeof(opalKey{}), 264},
+		"opal_session_info":  {unsafe.Sizeof(opalSessionInfo{}), 272},
+		"opal_lock_unlock":   {unsafe.Sizeof(opalLockUnlock{}), 280},
+		"opal_lr_act":        {unsafe.Sizeof(opalLRAct{}), 280},
+		"opal_user_lr_setup": {unsafe.Sizeof(opalUserLRSetup{}), 296},
+		"opal_status":        {unsafe.Sizeof(opalStatus{}), 8},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.size != tc.expSize {
+				t.Fatalf("expected size %d, got %d", tc.expSize, tc.size)
+			}
+		})
+	}
+}
+
+func TestBdev_iocNum(t *testing.T) {
+	// IOC_OPAL_LOCK_UNLOCK as defined by _IOW('p', 221, struct opal_lock_unlock)
+	if got := iocNum(iocWrite, iocNrOpalLockUnlock, unsafe.Sizeof(opalLockUnlock{})); got != 0x411870dd {
+		t.Fatalf("unexpected IOC_OPAL_LOCK_UNLOCK %#x", got)
+	}
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -31,6 +31,7 @@ type (
 	Provider struct {
 		log     logging.Logger
 		backend Backend
+		opal    OpalProvider
 	}
 )
 
@@ -44,6 +45,7 @@ func NewProvider(log logging.Logger, backend Backend) *Provider {
 	p := &Provider{
 		log:     log,
 		backend: backend,
+		opal:    defaultOpalProvider(log),
 	}
 	return p
 }
@@ -65,6 +67,10 @@ func (p *Provider) Prepare(req storage.BdevPrepareRequest) (*storage.BdevPrepare
 		return p.backend.Reset(req)
 	}
 
+	if err := p.prepareOpal(req); err != nil {
+		return nil, err
+	}
+
 	return p.backend.Prepare(req)
 }
 
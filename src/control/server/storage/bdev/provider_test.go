@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2022 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -94,7 +94,9 @@ func TestProvider_Prepare(t *testing.T) {
 		shouldForward bool
 		mbc           *MockBackendConfig
 		vmdDetectErr  error
+		moc           *MockOpalConfig
 		expRes        *storage.BdevPrepareResponse
+		expOpalCalls  []string
 		expErr        error
 	}{
 		"reset fails": {
@@ -121,15 +123,70 @@ func TestProvider_Prepare(t *testing.T) {
 			req:    storage.BdevPrepareRequest{},
 			expRes: &storage.BdevPrepareResponse{},
 		},
+		"reset skips opal": {
+			req: storage.BdevPrepareRequest{
+				Reset_:      true,
+				OpalDevices: []string{test.MockPCIAddr(1)},
+			},
+			expRes: &storage.BdevPrepareResponse{},
+		},
+		"opal unlock fails": {
+			req: storage.BdevPrepareRequest{
+				OpalDevices: []string{test.MockPCIAddr(1), test.MockPCIAddr(2)},
+			},
+			moc: &MockOpalConfig{
+				UnlockErr: errors.New("bad key"),
+			},
+			expOpalCalls: []string{"unlock " + test.MockPCIAddr(1)},
+			expErr:       errors.New("bad key"),
+		},
+		"opal unlock succeeds": {
+			req: storage.BdevPrepareRequest{
+				OpalDevices: []string{test.MockPCIAddr(1), test.MockPCIAddr(2)},
+			},
+			expRes: &storage.BdevPrepareResponse{},
+			expOpalCalls: []string{
+				"unlock " + test.MockPCIAddr(1),
+				"unlock " + test.MockPCIAddr(2),
+			},
+		},
+		"opal provision fails": {
+			req: storage.BdevPrepareRequest{
+				OpalDevices:   []string{test.MockPCIAddr(1)},
+				OpalProvision: true,
+			},
+			moc: &MockOpalConfig{
+				ProvisionErr: errors.New("take ownership failed"),
+			},
+			expOpalCalls: []string{"provision " + test.MockPCIAddr(1)},
+			expErr:       errors.New("take ownership failed"),
+		},
+		"opal provision succeeds": {
+			req: storage.BdevPrepareRequest{
+				OpalDevices:   []string{test.MockPCIAddr(1)},
+				OpalProvision: true,
+			},
+			expRes: &storage.BdevPrepareResponse{},
+			expOpalCalls: []string{
+				"provision " + test.MockPCIAddr(1),
+				"unlock " + test.MockPCIAddr(1),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(name)
 			defer test.ShowBufferOnFailure(t, buf)
 
 			p := NewMockProvider(log, tc.mbc)
+			mop := NewMockOpalProvider(tc.moc)
+			p.opal = mop
 
 			gotRes, gotErr := p.Prepare(tc.req)
 			test.CmpErr(t, tc.expErr, gotErr)
+
+			if diff := cmp.Diff(tc.expOpalCalls, mop.Calls); diff != "" {
+				t.Fatalf("\nunexpected opal calls (-want, +got):\n%s\n", diff)
+			}
 			if gotErr != nil {
 				return
 			}
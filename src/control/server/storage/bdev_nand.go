@@ -0,0 +1,107 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"sort"
+	"strings"
+)
+
+// NandType indicates the number of bits stored in each cell of the NAND media
+// of an SSD, which largely determines its write endurance.
+type NandType uint8
+
+// NandType values in order of decreasing write endurance.
+const (
+	NandTypeUnknown NandType = iota
+	NandTypeSLC
+	NandTypeMLC
+	NandTypeTLC
+	NandTypeQLC
+)
+
+func (nt NandType) String() string {
+	switch nt {
+	case NandTypeSLC:
+		return "SLC"
+	case NandTypeMLC:
+		return "MLC"
+	case NandTypeTLC:
+		return "TLC"
+	case NandTypeQLC:
+		return "QLC"
+	default:
+		return "unknown"
+	}
+}
+
+// endurance returns a value that is higher for media with better write
+// endurance. Media of unknown type is assumed to be TLC, the most common type
+// in data center SSDs, so that it is neither favoured nor penalised.
+func (nt NandType) endurance() int {
+	if nt == NandTypeUnknown {
+		nt = NandTypeTLC
+	}
+	return int(NandTypeQLC - nt)
+}
+
+// nandTypeModels maps substrings of SSD model names to the type of NAND media
+// used by the model. NVMe does not report the media type, so it is inferred
+// from the model name.
+var nandTypeModels = []struct {
+	substr   string
+	nandType NandType
+}{
+	{"QLC", NandTypeQLC},
+	{"TLC", NandTypeTLC},
+	{"SSDPE2NV", NandTypeQLC}, // D5-P4326
+	{"SSDPF2NV", NandTypeQLC}, // D5-P5316
+	{"SBFPF2BV", NandTypeQLC}, // D5-P5336
+	{"SSDPE2KX", NandTypeTLC}, // D7-P4510
+	{"SSDPE2KE", NandTypeTLC}, // D7-P4610
+	{"SSDPF2KX", NandTypeTLC}, // D7-P5510
+	{"SSDPF2KE", NandTypeTLC}, // D7-P5620
+	{"SBFPF2BU", NandTypeTLC}, // D7-P5520
+}
+
+// NandTypeFromModel returns the type of NAND media used by the SSD model.
+func NandTypeFromModel(model string) NandType {
+	model = strings.ToUpper(model)
+	for _, ntm := range nandTypeModels {
+		if strings.Contains(model, ntm.substr) {
+			return ntm.nandType
+		}
+	}
+
+	return NandTypeUnknown
+}
+
+// NandType returns the type of NAND media used by the controller's SSD.
+func (nc *NvmeController) NandType() NandType {
+	if nc == nil {
+		return NandTypeUnknown
+	}
+	return NandTypeFromModel(nc.Model)
+}
+
+// WriteAmplification returns the ratio of bytes written to NAND media to bytes
+// written by the host, or zero if the SSD does not report the counters.
+func (nch *NvmeHealth) WriteAmplification() float64 {
+	if nch == nil || nch.HostBytesWritten == 0 {
+		return 0
+	}
+	return float64(nch.NandBytesWritten) / float64(nch.HostBytesWritten)
+}
+
+// SortByEndurance orders the PCI addresses so that those of SSDs with the best
+// write endurance come first, otherwise preserving the supplied order. The
+// supplied map gives the NAND type of each SSD keyed by PCI address.
+func SortByEndurance(addrs []string, nandTypes map[string]NandType) {
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return nandTypes[addrs[i]].endurance() > nandTypes[addrs[j]].endurance()
+	})
+}
@@ -0,0 +1,94 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestStorage_NandTypeFromModel(t *testing.T) {
+	for name, tc := range map[string]struct {
+		model  string
+		expNT  NandType
+		expStr string
+	}{
+		"empty": {
+			expNT:  NandTypeUnknown,
+			expStr: "unknown",
+		},
+		"unrecognized model": {
+			model:  "model-1",
+			expNT:  NandTypeUnknown,
+			expStr: "unknown",
+		},
+		"qlc model": {
+			model:  "INTEL SSDPF2NV153TZ",
+			expNT:  NandTypeQLC,
+			expStr: "QLC",
+		},
+		"tlc model": {
+			model:  "INTEL SSDPE2KX040T8",
+			expNT:  NandTypeTLC,
+			expStr: "TLC",
+		},
+		"media type in model name": {
+			model:  "Vendor 7.68TB qlc SSD",
+			expNT:  NandTypeQLC,
+			expStr: "QLC",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nc := &NvmeController{Model: tc.model}
+
+			test.AssertEqual(t, tc.expNT, nc.NandType(), "unexpected nand type")
+			test.AssertEqual(t, tc.expStr, nc.NandType().String(), "unexpected nand type string")
+		})
+	}
+}
+
+func TestStorage_NvmeHealth_WriteAmplification(t *testing.T) {
+	for name, tc := range map[string]struct {
+		health *NvmeHealth
+		expWA  float64
+	}{
+		"nil": {},
+		"no host writes": {
+			health: &NvmeHealth{NandBytesWritten: 10},
+		},
+		"amplified": {
+			health: &NvmeHealth{NandBytesWritten: 30, HostBytesWritten: 12},
+			expWA:  2.5,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.AssertEqual(t, tc.expWA, tc.health.WriteAmplification(),
+				"unexpected write amplification")
+		})
+	}
+}
+
+func TestStorage_SortByEndurance(t *testing.T) {
+	addrs := []string{"a", "b", "c", "d", "e"}
+	nandTypes := map[string]NandType{
+		"a": NandTypeQLC,
+		"b": NandTypeTLC,
+		"d": NandTypeSLC,
+		"e": NandTypeQLC,
+	}
+
+	SortByEndurance(addrs, nandTypes)
+
+	// Unknown types rank alongside TLC and the original order is otherwise
+	// preserved.
+	if diff := cmp.Diff([]string{"d", "b", "c", "a", "e"}, addrs); diff != "" {
+		t.Fatalf("unexpected order (-want, +got):\n%s\n", diff)
+	}
+}
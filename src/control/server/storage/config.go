@@ -60,6 +60,7 @@ const (
 type ControlMetadata struct {
 	Path       string `yaml:"path,omitempty"`
 	DevicePath string `yaml:"device,omitempty"`
+	MirrorPath string `yaml:"mirror_path,omitempty"`
 }
 
 // Directory returns the full path to the directory where the control plane metadata is saved.
@@ -80,6 +81,26 @@ func (cm ControlMetadata) HasPath() bool {
 	return cm.Path != ""
 }
 
+// MirrorDirectory returns the full path to the directory where the mirrored copy of the control
+// plane metadata is saved.
+func (cm ControlMetadata) MirrorDirectory() string {
+	if cm.MirrorPath == "" {
+		return ""
+	}
+	return filepath.Join(cm.MirrorPath, ControlMetadataSubdir)
+}
+
+// MirrorEngineDirectory returns the full path to the directory where the mirrored copy of the
+// per-engine metadata is saved.
+func (cm ControlMetadata) MirrorEngineDirectory(idx uint) string {
+	return ControlMetadataEngineDir(cm.MirrorDirectory(), idx)
+}
+
+// HasMirror returns true if a mirror location is set for the ControlMetadata.
+func (cm ControlMetadata) HasMirror() bool {
+	return cm.HasPath() && cm.MirrorPath != ""
+}
+
 // Class indicates a specific type of storage.
 type Class string
 
@@ -195,6 +216,12 @@ func (tc *TierConfig) WithScmDeviceList(devices ...string) *TierConfig {
 	return tc
 }
 
+// WithScmEncryption sets the data-at-rest encryption to be used for SCM storage.
+func (tc *TierConfig) WithScmEncryption(enc ScmEncryption) *TierConfig {
+	tc.Scm.Encryption = enc
+	return tc
+}
+
 // WithBdevDeviceList sets the list of block devices to be used.
 func (tc *TierConfig) WithBdevDeviceList(devices ...string) *TierConfig {
 	if set, err := NewBdevDeviceList(devices...); err == nil {
@@ -232,6 +259,13 @@ func (tc *TierConfig) WithBdevDeviceRoles(bits int) *TierConfig {
 	return tc
 }
 
+// WithBdevEncryption sets the data-at-rest encryption to be used for the
+// block devices.
+func (tc *TierConfig) WithBdevEncryption(enc BdevEncryption) *TierConfig {
+	tc.Bdev.Encryption = enc
+	return tc
+}
+
 // WithNumaNodeIndex sets the NUMA node index to be used for this tier.
 func (tc *TierConfig) WithNumaNodeIndex(idx uint) *TierConfig {
 	tc.SetNumaNodeIndex(idx)
@@ -328,6 +362,31 @@ func (tcs TierConfigs) HasBdevRoleMeta() bool {
 	return false
 }
 
+// HaveEncryption returns true if data-at-rest encryption is configured for any
+// of the tiers.
+func (tcs TierConfigs) HaveEncryption() bool {
+	for _, tc := range tcs {
+		if tc.Scm.Encryption != "" || tc.Bdev.Encryption != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OpalDevices returns the PCI addresses of the NVMe SSDs in tiers which use
+// Opal self-encryption.
+func (tcs TierConfigs) OpalDevices() []string {
+	var addrs []string
+	for _, bc := range tcs.BdevConfigs() {
+		if bc.Class == ClassNvme && bc.Bdev.Encryption == BdevEncryptionOpal {
+			addrs = append(addrs, bc.Bdev.DeviceList.Devices()...)
+		}
+	}
+
+	return addrs
+}
+
 func (tcs TierConfigs) Validate() error {
 	if len(tcs) == 0 {
 		return errors.New("no storage tiers configured")
@@ -571,11 +630,12 @@ func (tcs *TierConfigs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // ScmConfig represents a SCM (Storage Class Memory) configuration entry.
 type ScmConfig struct {
-	MountPoint       string   `yaml:"scm_mount,omitempty" cmdLongFlag:"--storage" cmdShortFlag:"-s"`
-	RamdiskSize      uint     `yaml:"scm_size,omitempty"`
-	DisableHugepages bool     `yaml:"scm_hugepages_disabled,omitempty"`
-	DeviceList       []string `yaml:"scm_list,omitempty"`
-	NumaNodeIndex    uint     `yaml:"-"`
+	MountPoint       string        `yaml:"scm_mount,omitempty" cmdLongFlag:"--storage" cmdShortFlag:"-s"`
+	RamdiskSize      uint          `yaml:"scm_size,omitempty"`
+	DisableHugepages bool          `yaml:"scm_hugepages_disabled,omitempty"`
+	DeviceList       []string      `yaml:"scm_list,omitempty"`
+	Encryption       ScmEncryption `yaml:"scm_encryption,omitempty"`
+	NumaNodeIndex    uint          `yaml:"-"`
 }
 
 // Validate sanity checks engine scm config parameters.
@@ -612,7 +672,7 @@ func (sc *ScmConfig) Validate(class Class) error {
 	if len(sc.DeviceList) > maxScmDeviceLen {
 		return errors.Errorf("scm_list may have at most %d devices", maxScmDeviceLen)
 	}
-	return nil
+	return sc.Encryption.Validate(class)
 }
 
 // BdevDeviceList represents a set of block device addresses.
@@ -949,6 +1009,7 @@ type BdevConfig struct {
 	FileSize      int             `yaml:"bdev_size,omitempty"`
 	BusidRange    *BdevBusRange   `yaml:"bdev_busid_range,omitempty"`
 	DeviceRoles   BdevRoles       `yaml:"bdev_roles,omitempty"`
+	Encryption    BdevEncryption  `yaml:"bdev_encryption,omitempty"`
 	NumaNodeIndex uint            `yaml:"-"`
 }
 
@@ -995,7 +1056,7 @@ func (bc *BdevConfig) Validate(class Class) error {
 		return errors.Errorf("class value %q not supported (valid: nvme/kdev/file)", class)
 	}
 
-	return nil
+	return bc.Encryption.Validate(class)
 }
 
 // parsePCIBusRange takes a string of format <Begin-End> and returns the begin and end values.
@@ -1106,16 +1167,17 @@ type BdevAutoFaulty struct {
 }
 
 type Config struct {
-	ControlMetadata  ControlMetadata `yaml:"-"` // inherited from server
-	EngineIdx        uint            `yaml:"-"`
-	Tiers            TierConfigs     `yaml:"storage" cmdLongFlag:"--storage_tiers,nonzero" cmdShortFlag:"-T,nonzero"`
-	ConfigOutputPath string          `yaml:"-" cmdLongFlag:"--nvme" cmdShortFlag:"-n"`
-	VosEnv           string          `yaml:"-" cmdEnv:"VOS_BDEV_CLASS"`
-	EnableHotplug    bool            `yaml:"-"`
-	NumaNodeIndex    uint            `yaml:"-"`
-	AccelProps       AccelProps      `yaml:"acceleration,omitempty"`
-	SpdkRpcSrvProps  SpdkRpcServer   `yaml:"spdk_rpc_server,omitempty"`
-	AutoFaultyProps  BdevAutoFaulty  `yaml:"bdev_auto_faulty,omitempty"`
+	ControlMetadata  ControlMetadata      `yaml:"-"` // inherited from server
+	EncryptionKey    *EncryptionKeyConfig `yaml:"-"` // inherited from server
+	EngineIdx        uint                 `yaml:"-"`
+	Tiers            TierConfigs          `yaml:"storage" cmdLongFlag:"--storage_tiers,nonzero" cmdShortFlag:"-T,nonzero"`
+	ConfigOutputPath string               `yaml:"-" cmdLongFlag:"--nvme" cmdShortFlag:"-n"`
+	VosEnv           string               `yaml:"-" cmdEnv:"VOS_BDEV_CLASS"`
+	EnableHotplug    bool                 `yaml:"-"`
+	NumaNodeIndex    uint                 `yaml:"-"`
+	AccelProps       AccelProps           `yaml:"acceleration,omitempty"`
+	SpdkRpcSrvProps  SpdkRpcServer        `yaml:"spdk_rpc_server,omitempty"`
+	AutoFaultyProps  BdevAutoFaulty       `yaml:"bdev_auto_faulty,omitempty"`
 }
 
 func (c *Config) SetNUMAAffinity(node uint) {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -9,6 +9,8 @@ package storage
 type (
 	// DeviceParams defines the sub-parameters of a Format operation that will use a storage device.
 	DeviceParams struct {
-		Device string
+		Device     string
+		Encryption ScmEncryption
+		Key        EncryptionKey
 	}
 )
@@ -0,0 +1,189 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ScmEncryptionDmCrypt indicates that a DCPM namespace is encrypted with
+	// LUKS/dm-crypt and mounted via its device-mapper mapping.
+	ScmEncryptionDmCrypt ScmEncryption = "dm-crypt"
+
+	// BdevEncryptionOpal indicates that the NVMe SSDs of a tier are
+	// self-encrypting drives which are locked with the TCG Opal
+	// security subsystem.
+	BdevEncryptionOpal BdevEncryption = "opal"
+
+	// MaxEncryptionKeyLen is the longest key that can be used, limited by
+	// the size of the Opal key buffer.
+	MaxEncryptionKeyLen = 256
+
+	keyCommandTimeout = 30 * time.Second
+	redactedKey       = "<redacted>"
+)
+
+type (
+	// ScmEncryption describes the data-at-rest encryption to be applied to
+	// an SCM tier.
+	ScmEncryption string
+
+	// BdevEncryption describes the data-at-rest encryption to be applied to
+	// a bdev tier.
+	BdevEncryption string
+
+	// EncryptionKey is the secret used to unlock encrypted storage. Its
+	// value is never included in formatted output so that it can't leak
+	// via request logging.
+	EncryptionKey []byte
+
+	// EncryptionKeyConfig describes where the key used to unlock encrypted
+	// storage is retrieved from. Exactly one of File or Command may be set.
+	EncryptionKeyConfig struct {
+		// File is the path to a file containing the key, which must
+		// not be accessible by group or other users.
+		File string `yaml:"file,omitempty"`
+		// Command is the path to an executable, with optional
+		// space-separated arguments, which writes the key to stdout
+		// (e.g. a KMS client).
+		Command string `yaml:"command,omitempty"`
+	}
+)
+
+// String implements fmt.Stringer without revealing the key.
+func (ek EncryptionKey) String() string {
+	return redactedKey
+}
+
+// GoString implements fmt.GoStringer without revealing the key.
+func (ek EncryptionKey) GoString() string {
+	return redactedKey
+}
+
+// Validate returns an error if the SCM encryption type is not supported for
+// the supplied storage class.
+func (se ScmEncryption) Validate(class Class) error {
+	switch se {
+	case "":
+		return nil
+	case ScmEncryptionDmCrypt:
+		if class != ClassDcpm {
+			return errors.Errorf("scm_encryption %q may only be set when class is dcpm", se)
+		}
+		return nil
+	default:
+		return errors.Errorf("scm_encryption value %q not supported (valid: %s)", se,
+			ScmEncryptionDmCrypt)
+	}
+}
+
+// Validate returns an error if the bdev encryption type is not supported for
+// the supplied storage class.
+func (be BdevEncryption) Validate(class Class) error {
+	switch be {
+	case "":
+		return nil
+	case BdevEncryptionOpal:
+		if class != ClassNvme {
+			return errors.Errorf("bdev_encryption %q may only be set when class is nvme", be)
+		}
+		return nil
+	default:
+		return errors.Errorf("bdev_encryption value %q not supported (valid: %s)", be,
+			BdevEncryptionOpal)
+	}
+}
+
+// Validate returns an error if the key configuration is invalid.
+func (ekc *EncryptionKeyConfig) Validate() error {
+	if ekc == nil {
+		return errors.New("no encryption key source set")
+	}
+
+	cmdArgs := strings.Fields(ekc.Command)
+	switch {
+	case ekc.File == "" && len(cmdArgs) == 0:
+		return errors.New("one of file or command must be set")
+	case ekc.File != "" && len(cmdArgs) != 0:
+		return errors.New("file and command may not both be set")
+	case ekc.File != "" && !filepath.IsAbs(ekc.File):
+		return errors.Errorf("key file %q is not an absolute path", ekc.File)
+	case len(cmdArgs) != 0 && !filepath.IsAbs(cmdArgs[0]):
+		return errors.Errorf("key command %q is not an absolute path", cmdArgs[0])
+	}
+
+	return nil
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, errors.Errorf("key file %q must not be accessible by group or others (mode %s)",
+			path, fi.Mode().Perm())
+	}
+
+	return os.ReadFile(path)
+}
+
+func runKeyCommand(command string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyCommandTimeout)
+	defer cancel()
+
+	args := strings.Fields(command)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "key command %q failed: %s", args[0],
+			strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// GetKey retrieves the encryption key from its configured source. A single
+// trailing newline is removed from the key.
+func (ekc *EncryptionKeyConfig) GetKey() (EncryptionKey, error) {
+	if err := ekc.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption key config")
+	}
+
+	var key []byte
+	var err error
+	if ekc.File != "" {
+		key, err = readKeyFile(ekc.File)
+	} else {
+		key, err = runKeyCommand(ekc.Command)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving encryption key")
+	}
+
+	key = bytes.TrimSuffix(bytes.TrimSuffix(key, []byte("\n")), []byte("\r"))
+	switch {
+	case len(key) == 0:
+		return nil, errors.New("retrieved encryption key is empty")
+	case len(key) > MaxEncryptionKeyLen:
+		return nil, errors.Errorf("retrieved encryption key is longer than %d bytes",
+			MaxEncryptionKeyLen)
+	}
+
+	return EncryptionKey(key), nil
+}
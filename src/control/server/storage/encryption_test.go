@@ -0,0 +1,206 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+)
+
+func TestStorage_EncryptionKey_String(t *testing.T) {
+	key := EncryptionKey("secret")
+
+	for name, out := range map[string]string{
+		"string":     key.String(),
+		"value":      fmt.Sprintf("%v", key),
+		"go syntax":  fmt.Sprintf("%#v", key),
+		"in request": fmt.Sprintf("%+v", ScmMountRequest{Key: key}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if strings.Contains(out, "secret") {
+				t.Fatalf("key revealed in %q", out)
+			}
+		})
+	}
+}
+
+func TestStorage_ScmEncryption_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		enc    ScmEncryption
+		class  Class
+		expErr error
+	}{
+		"unset": {
+			class: ClassRam,
+		},
+		"dm-crypt on dcpm": {
+			enc:   ScmEncryptionDmCrypt,
+			class: ClassDcpm,
+		},
+		"dm-crypt on ram": {
+			enc:    ScmEncryptionDmCrypt,
+			class:  ClassRam,
+			expErr: errors.New("only be set when class is dcpm"),
+		},
+		"unknown": {
+			enc:    "rot13",
+			class:  ClassDcpm,
+			expErr: errors.New("not supported"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.enc.Validate(tc.class))
+		})
+	}
+}
+
+func TestStorage_BdevEncryption_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		enc    BdevEncryption
+		class  Class
+		expErr error
+	}{
+		"unset": {
+			class: ClassFile,
+		},
+		"opal on nvme": {
+			enc:   BdevEncryptionOpal,
+			class: ClassNvme,
+		},
+		"opal on file": {
+			enc:    BdevEncryptionOpal,
+			class:  ClassFile,
+			expErr: errors.New("only be set when class is nvme"),
+		},
+		"unknown": {
+			enc:    "rot13",
+			class:  ClassNvme,
+			expErr: errors.New("not supported"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.CmpErr(t, tc.expErr, tc.enc.Validate(tc.class))
+		})
+	}
+}
+
+func TestStorage_TierConfigs_OpalDevices(t *testing.T) {
+	tcs := TierConfigs{
+		NewTierConfig().
+			WithStorageClass(ClassDcpm.String()).
+			WithScmEncryption(ScmEncryptionDmCrypt),
+		NewTierConfig().
+			WithStorageClass(ClassNvme.String()).
+			WithBdevDeviceList(test.MockPCIAddr(1), test.MockPCIAddr(2)).
+			WithBdevEncryption(BdevEncryptionOpal),
+		NewTierConfig().
+			WithStorageClass(ClassNvme.String()).
+			WithBdevDeviceList(test.MockPCIAddr(3)),
+	}
+
+	if !tcs.HaveEncryption() {
+		t.Fatal("expected encryption to be reported")
+	}
+	if TierConfigs(tcs[2:]).HaveEncryption() {
+		t.Fatal("unexpected encryption reported")
+	}
+
+	exp := []string{test.MockPCIAddr(1), test.MockPCIAddr(2)}
+	if diff := cmp.Diff(exp, tcs.OpalDevices()); diff != "" {
+		t.Fatalf("unexpected opal devices (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestStorage_EncryptionKeyConfig_GetKey(t *testing.T) {
+	testDir, cleanup := test.CreateTestDir(t)
+	defer cleanup()
+
+	keyFile := test.CreateTestFile(t, testDir, "secret\n")
+	emptyFile := test.CreateTestFile(t, testDir, "\n")
+	longFile := test.CreateTestFile(t, testDir, strings.Repeat("k", MaxEncryptionKeyLen+1))
+	openFile := test.CreateTestFile(t, testDir, "secret")
+	if err := os.Chmod(openFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cfg    *EncryptionKeyConfig
+		expKey EncryptionKey
+		expErr error
+	}{
+		"nil config": {
+			expErr: errors.New("no encryption key source"),
+		},
+		"no source": {
+			cfg:    &EncryptionKeyConfig{},
+			expErr: errors.New("one of file or command"),
+		},
+		"both sources": {
+			cfg: &EncryptionKeyConfig{
+				File:    keyFile,
+				Command: "/bin/echo secret",
+			},
+			expErr: errors.New("may not both be set"),
+		},
+		"relative file": {
+			cfg:    &EncryptionKeyConfig{File: filepath.Base(keyFile)},
+			expErr: errors.New("not an absolute path"),
+		},
+		"relative command": {
+			cfg:    &EncryptionKeyConfig{Command: "echo secret"},
+			expErr: errors.New("not an absolute path"),
+		},
+		"missing file": {
+			cfg:    &EncryptionKeyConfig{File: filepath.Join(testDir, "missing")},
+			expErr: errors.New("no such file"),
+		},
+		"file readable by others": {
+			cfg:    &EncryptionKeyConfig{File: openFile},
+			expErr: errors.New("must not be accessible"),
+		},
+		"empty file": {
+			cfg:    &EncryptionKeyConfig{File: emptyFile},
+			expErr: errors.New("key is empty"),
+		},
+		"file key too long": {
+			cfg:    &EncryptionKeyConfig{File: longFile},
+			expErr: errors.New("longer than"),
+		},
+		"file": {
+			cfg:    &EncryptionKeyConfig{File: keyFile},
+			expKey: EncryptionKey("secret"),
+		},
+		"command": {
+			cfg:    &EncryptionKeyConfig{Command: "/bin/echo secret"},
+			expKey: EncryptionKey("secret"),
+		},
+		"command fails": {
+			cfg:    &EncryptionKeyConfig{Command: "/bin/false"},
+			expErr: errors.New("key command \"/bin/false\" failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotKey, gotErr := tc.cfg.GetKey()
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expKey, gotKey); diff != "" {
+				t.Fatalf("unexpected key (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
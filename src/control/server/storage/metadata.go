@@ -8,8 +8,13 @@ package storage
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/pbin"
 )
@@ -111,3 +116,41 @@ func ControlMetadataEngineDir(mdPath string, engineIdx uint) string {
 	}
 	return filepath.Join(mdPath, fmt.Sprintf("engine%d", engineIdx))
 }
+
+// copyMetadataDir copies the regular files and directories under src into dst, replacing any
+// existing files of the same name. Each file is written atomically so that an interrupted copy
+// never leaves a partially written file in dst.
+func copyMetadataDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case !info.Mode().IsRegular():
+			// Skip sockets, symlinks and other special files.
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrapf(common.WriteFileAtomic(target, data, info.Mode().Perm()),
+			"copying %s to %s", path, target)
+	})
+}
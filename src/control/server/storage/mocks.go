@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -412,6 +412,8 @@ type MockScmProvider struct {
 	ScanErr           error
 	PrepareRes        *ScmPrepareResponse
 	PrepareErr        error
+	HealthQueryRes    *ScmHealthQueryResponse
+	HealthQueryErr    error
 	FirmwareQueryRes  *ScmFirmwareQueryResponse
 	FirmwareQueryErr  error
 	FirmwareUpdateRes *ScmFirmwareUpdateResponse
@@ -442,6 +444,10 @@ func (m *MockScmProvider) Prepare(ScmPrepareRequest) (*ScmPrepareResponse, error
 	return m.PrepareRes, m.PrepareErr
 }
 
+func (m *MockScmProvider) QueryHealth(ScmHealthQueryRequest) (*ScmHealthQueryResponse, error) {
+	return m.HealthQueryRes, m.HealthQueryErr
+}
+
 func (m *MockScmProvider) QueryFirmware(ScmFirmwareQueryRequest) (*ScmFirmwareQueryResponse, error) {
 	return m.FirmwareQueryRes, m.FirmwareQueryErr
 }
@@ -38,6 +38,7 @@ type Provider struct {
 	engineStorage *Config
 	Sys           SystemProvider
 	metadata      MetadataProvider
+	primaryMeta   *ControlMetadata // set while failed over to the metadata mirror
 	scm           ScmProvider
 	bdev          BdevProvider
 	vmdEnabled    bool
@@ -73,7 +74,25 @@ func (p *Provider) FormatControlMetadata(engineIdxs []uint) error {
 		EngineIdxs: engineIdxs,
 	}
 	p.log.Debugf("calling metadata storage provider format: %+v", req)
-	return p.metadata.Format(req)
+	if err := p.metadata.Format(req); err != nil {
+		return err
+	}
+
+	if !p.engineStorage.ControlMetadata.HasMirror() {
+		return nil
+	}
+
+	// Reset the mirror so that stale metadata can't be restored over the newly formatted
+	// primary location.
+	mirrorReq := MetadataFormatRequest{
+		RootPath:   p.engineStorage.ControlMetadata.MirrorPath,
+		DataPath:   p.engineStorage.ControlMetadata.MirrorDirectory(),
+		OwnerUID:   req.OwnerUID,
+		OwnerGID:   req.OwnerGID,
+		EngineIdxs: engineIdxs,
+	}
+	p.log.Debugf("calling metadata storage provider format for mirror: %+v", mirrorReq)
+	return errors.Wrap(p.metadata.Format(mirrorReq), "formatting control metadata mirror")
 }
 
 // ControlMetadataNeedsFormat checks whether we need to format the control metadata storage before
@@ -178,6 +197,91 @@ func (p *Provider) MountControlMetadata() error {
 	return err
 }
 
+// ControlMetadataMirrorConfigured checks whether a mirror location is configured for the control
+// metadata and is not currently in use as the primary location.
+func (p *Provider) ControlMetadataMirrorConfigured() bool {
+	if p == nil {
+		return false
+	}
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.engineStorage.ControlMetadata.HasMirror()
+}
+
+// ControlMetadataMirrorEnginePath returns the path where the mirrored copy of the control plane
+// metadata for the engine is stored, or an empty string if no mirror is configured.
+func (p *Provider) ControlMetadataMirrorEnginePath() string {
+	if !p.ControlMetadataMirrorConfigured() {
+		return ""
+	}
+
+	return p.engineStorage.ControlMetadata.MirrorEngineDirectory(uint(p.engineIndex))
+}
+
+// SyncControlMetadataMirror copies the engine's control metadata from the primary location to the
+// mirror location. It is a no-op if no mirror is configured.
+func (p *Provider) SyncControlMetadataMirror() error {
+	if !p.ControlMetadataMirrorConfigured() {
+		return nil
+	}
+
+	src := p.ControlMetadataEnginePath()
+	dst := p.ControlMetadataMirrorEnginePath()
+	p.log.Debugf("syncing control metadata mirror %s -> %s", src, dst)
+
+	return errors.Wrap(copyMetadataDir(src, dst), "syncing control metadata mirror")
+}
+
+// FailoverControlMetadata switches the provider to use the mirror location for control metadata
+// in place of the primary location, e.g. because the primary location could not be verified.
+// The mirror is no longer kept in sync once it is in use.
+func (p *Provider) FailoverControlMetadata() error {
+	if !p.ControlMetadataMirrorConfigured() {
+		return errors.New("no control metadata mirror configured")
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	primary := p.engineStorage.ControlMetadata
+	p.primaryMeta = &primary
+	p.engineStorage.ControlMetadata = ControlMetadata{
+		Path: primary.MirrorPath,
+	}
+	p.log.Noticef("control metadata failed over from %s to mirror %s", primary.Path, primary.MirrorPath)
+
+	return nil
+}
+
+// ControlMetadataFailedOver indicates whether the mirror location is in use in place of the
+// primary control metadata location.
+func (p *Provider) ControlMetadataFailedOver() bool {
+	if p == nil {
+		return false
+	}
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.primaryMeta != nil
+}
+
+// RestoreControlMetadataPrimary reverts a previous failover so that the primary control metadata
+// location is used again, e.g. after it has been reformatted.
+func (p *Provider) RestoreControlMetadataPrimary() {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+
+	if p.primaryMeta == nil {
+		return
+	}
+	p.engineStorage.ControlMetadata = *p.primaryMeta
+	p.primaryMeta = nil
+}
+
 // ControlMetadataIsMounted determines whether the control metadata storage is already mounted.
 func (p *Provider) ControlMetadataIsMounted() (bool, error) {
 	if p == nil {
@@ -254,6 +358,20 @@ func (p *Provider) ScmIsMounted() (bool, error) {
 	return p.Sys.IsMounted(cfg.Scm.MountPoint)
 }
 
+// getScmKey retrieves the key needed to unlock the SCM device if it is
+// encrypted.
+func (p *Provider) getScmKey(cfg *TierConfig) (EncryptionKey, error) {
+	if cfg.Scm.Encryption == "" {
+		return nil, nil
+	}
+
+	key, err := p.engineStorage.EncryptionKey.GetKey()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unlocking %s encrypted SCM", cfg.Scm.Encryption)
+	}
+	return key, nil
+}
+
 // MountScm mounts SCM based on provider config.
 func (p *Provider) MountScm() error {
 	cfg, err := p.GetScmConfig()
@@ -261,9 +379,16 @@ func (p *Provider) MountScm() error {
 		return err
 	}
 
+	key, err := p.getScmKey(cfg)
+	if err != nil {
+		return err
+	}
+
 	req := ScmMountRequest{
-		Class:  cfg.Class,
-		Target: cfg.Scm.MountPoint,
+		Class:      cfg.Class,
+		Target:     cfg.Scm.MountPoint,
+		Encryption: cfg.Scm.Encryption,
+		Key:        key,
 	}
 
 	switch cfg.Class {
@@ -320,7 +445,7 @@ func (p *Provider) UnmountTmpfs() error {
 	return nil
 }
 
-func createScmFormatRequest(class Class, scmCfg ScmConfig, force bool) (*ScmFormatRequest, error) {
+func createScmFormatRequest(class Class, scmCfg ScmConfig, key EncryptionKey, force bool) (*ScmFormatRequest, error) {
 	req := ScmFormatRequest{
 		Mountpoint: scmCfg.MountPoint,
 		Force:      force,
@@ -340,7 +465,9 @@ func createScmFormatRequest(class Class, scmCfg ScmConfig, force bool) (*ScmForm
 			return nil, ErrInvalidDcpmCount
 		}
 		req.Dcpm = &DeviceParams{
-			Device: scmCfg.DeviceList[0],
+			Device:     scmCfg.DeviceList[0],
+			Encryption: scmCfg.Encryption,
+			Key:        key,
 		}
 	default:
 		return nil, errors.New(ScmMsgClassNotSupported)
@@ -358,7 +485,12 @@ func (p *Provider) ScmNeedsFormat() (bool, error) {
 
 	p.log.Debugf("%s: checking formatting", cfg.Scm.MountPoint)
 
-	req, err := createScmFormatRequest(cfg.Class, cfg.Scm, false)
+	key, err := p.getScmKey(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := createScmFormatRequest(cfg.Class, cfg.Scm, key, false)
 	if err != nil {
 		return false, err
 	}
@@ -380,7 +512,12 @@ func (p *Provider) FormatScm(force bool) error {
 		return err
 	}
 
-	req, err := createScmFormatRequest(cfg.Class, cfg.Scm, force)
+	key, err := p.getScmKey(cfg)
+	if err != nil {
+		return err
+	}
+
+	req, err := createScmFormatRequest(cfg.Class, cfg.Scm, key, force)
 	if err != nil {
 		return errors.Wrap(err, "generate format request")
 	}
@@ -406,6 +543,11 @@ func (p *Provider) GetBdevConfigs() []*TierConfig {
 	return p.engineStorage.Tiers.BdevConfigs()
 }
 
+// QueryScmHealth queries PMem module health sensors.
+func (p *Provider) QueryScmHealth(req ScmHealthQueryRequest) (*ScmHealthQueryResponse, error) {
+	return p.scm.QueryHealth(req)
+}
+
 // QueryScmFirmware queries PMem SSD firmware.
 func (p *Provider) QueryScmFirmware(req ScmFirmwareQueryRequest) (*ScmFirmwareQueryResponse, error) {
 	return p.scm.QueryFirmware(req)
@@ -8,6 +8,7 @@ package storage
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -300,6 +301,15 @@ func TestStorage_FormatControlMetadata(t *testing.T) {
 			},
 			metadataProv: &MockMetadataProvider{},
 		},
+		"success; with mirror": {
+			cfg: &Config{
+				ControlMetadata: ControlMetadata{
+					Path:       "something",
+					MirrorPath: "mirror",
+				},
+			},
+			metadataProv: &MockMetadataProvider{},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(name)
@@ -677,3 +687,108 @@ func TestStorage_ControlMetadataEnginePath(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_SyncControlMetadataMirror(t *testing.T) {
+	for name, tc := range map[string]struct {
+		noMirror  bool
+		failover  bool
+		expSynced bool
+	}{
+		"no mirror": {
+			noMirror: true,
+		},
+		"failed over": {
+			failover: true,
+		},
+		"success": {
+			expSynced: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer test.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := test.CreateTestDir(t)
+			defer cleanup()
+
+			cm := ControlMetadata{
+				Path:       filepath.Join(testDir, "primary"),
+				MirrorPath: filepath.Join(testDir, "mirror"),
+			}
+			if tc.noMirror {
+				cm.MirrorPath = ""
+			}
+			p := NewProvider(log, 1, &Config{ControlMetadata: cm}, nil, nil, nil, nil)
+
+			engDir := cm.EngineDirectory(1)
+			if err := os.MkdirAll(filepath.Join(engDir, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(engDir, "superblock"), []byte("sb"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(engDir, "sub", "file"), []byte("data"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.failover {
+				if err := p.FailoverControlMetadata(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := p.SyncControlMetadataMirror(); err != nil {
+				t.Fatal(err)
+			}
+
+			mirrorDir := ControlMetadata{MirrorPath: filepath.Join(testDir, "mirror")}.MirrorEngineDirectory(1)
+			for file, expData := range map[string]string{
+				"superblock":                 "sb",
+				filepath.Join("sub", "file"): "data",
+			} {
+				data, err := os.ReadFile(filepath.Join(mirrorDir, file))
+				if !tc.expSynced {
+					if !os.IsNotExist(err) {
+						t.Fatalf("expected %s not to be mirrored (err: %v)", file, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.AssertEqual(t, expData, string(data), file)
+			}
+		})
+	}
+}
+
+func TestStorage_FailoverControlMetadata(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	cfg := &Config{
+		ControlMetadata: ControlMetadata{
+			Path:       "/metadata",
+			DevicePath: "/dev/sdb1",
+			MirrorPath: "/mirror",
+		},
+	}
+	p := NewProvider(log, 2, cfg, nil, nil, nil, nil)
+
+	test.AssertTrue(t, p.ControlMetadataMirrorConfigured(), "expected mirror")
+	test.AssertEqual(t, "/mirror/daos_control/engine2", p.ControlMetadataMirrorEnginePath(), "")
+
+	if err := p.FailoverControlMetadata(); err != nil {
+		t.Fatal(err)
+	}
+	test.AssertTrue(t, p.ControlMetadataFailedOver(), "expected failover")
+	test.AssertEqual(t, "/mirror/daos_control/engine2", p.ControlMetadataEnginePath(), "")
+	test.AssertEqual(t, "", p.GetControlMetadata().DevicePath, "")
+	test.AssertFalse(t, p.ControlMetadataMirrorConfigured(), "expected mirror to be in use")
+	test.CmpErr(t, errors.New("no control metadata mirror"), p.FailoverControlMetadata())
+
+	p.RestoreControlMetadataPrimary()
+	test.AssertFalse(t, p.ControlMetadataFailedOver(), "expected primary")
+	test.AssertEqual(t, "/metadata/daos_control/engine2", p.ControlMetadataEnginePath(), "")
+	test.AssertEqual(t, "/dev/sdb1", p.GetControlMetadata().DevicePath, "")
+}
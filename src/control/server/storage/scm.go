@@ -99,6 +99,17 @@ type (
 	// ScmModules is a type alias for []ScmModule that implements fmt.Stringer.
 	ScmModules []*ScmModule
 
+	// ScmModuleHealth contains health sensor readings for a PMem DIMM.
+	ScmModuleHealth struct {
+		UID                string
+		SocketID           uint32
+		PhysicalID         uint32
+		HealthState        string
+		MediaTemperature   uint32 // degrees Celsius
+		SpareCapacity      uint32 // percentage of spare capacity remaining
+		DirtyShutdownCount uint64 // latched dirty shutdown count
+	}
+
 	// ScmMountPoint represents location PMem filesystem is mounted.
 	ScmMountPoint struct {
 		Class       Class         `json:"class"`
@@ -324,6 +335,7 @@ type (
 		CheckFormat(ScmFormatRequest) (*ScmFormatResponse, error)
 		Scan(ScmScanRequest) (*ScmScanResponse, error)
 		Prepare(ScmPrepareRequest) (*ScmPrepareResponse, error)
+		QueryHealth(ScmHealthQueryRequest) (*ScmHealthQueryResponse, error)
 		QueryFirmware(ScmFirmwareQueryRequest) (*ScmFirmwareQueryResponse, error)
 		UpdateFirmware(ScmFirmwareUpdateRequest) (*ScmFirmwareUpdateResponse, error)
 	}
@@ -385,10 +397,22 @@ type (
 	// ScmMountRequest represents an SCM mount request.
 	ScmMountRequest struct {
 		pbin.ForwardableRequest
-		Class   Class
-		Device  string
-		Target  string
-		Ramdisk *RamdiskParams
+		Class      Class
+		Device     string
+		Target     string
+		Ramdisk    *RamdiskParams
+		Encryption ScmEncryption
+		Key        EncryptionKey
+	}
+
+	// ScmHealthQueryRequest defines the parameters for a PMem health query.
+	ScmHealthQueryRequest struct {
+		pbin.ForwardableRequest
+	}
+
+	// ScmHealthQueryResponse contains the results of a successful PMem health query.
+	ScmHealthQueryResponse struct {
+		Modules []*ScmModuleHealth
 	}
 
 	// ScmFirmwareQueryRequest defines the parameters for a firmware query.
@@ -532,6 +556,18 @@ func (f *ScmAdminForwarder) Prepare(req ScmPrepareRequest) (*ScmPrepareResponse,
 	return res, nil
 }
 
+// QueryHealth forwards a request to query PMem module health.
+func (f *ScmAdminForwarder) QueryHealth(req ScmHealthQueryRequest) (*ScmHealthQueryResponse, error) {
+	req.Forwarded = true
+
+	res := new(ScmHealthQueryResponse)
+	if err := f.SendReq("ScmHealthQuery", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 const (
 	// ScmFirmwareQueryMethod is the method name used when forwarding the request
 	// to query SCM firmware.
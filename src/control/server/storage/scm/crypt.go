@@ -0,0 +1,186 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/provider/system"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	cryptsetupCmd     = "cryptsetup"
+	cryptMapperDir    = "/dev/mapper"
+	cryptMapperPrefix = "daos_"
+
+	// cryptsetup isLuks exits with this status if the device is not LUKS.
+	cryptNotLuksStatus = 1
+)
+
+type (
+	// CryptProvider provides management of LUKS/dm-crypt encrypted devices.
+	CryptProvider interface {
+		IsLuks(device string) (bool, error)
+		Format(device string, key storage.EncryptionKey) error
+		Open(device, name string, key storage.EncryptionKey) error
+		Close(name string) error
+		IsOpen(name string) (bool, error)
+	}
+
+	cryptsetup struct {
+		log logging.Logger
+	}
+)
+
+// cryptMapping returns the name and path of the device-mapper mapping used for
+// an encrypted device.
+func cryptMapping(device string) (string, string) {
+	name := cryptMapperPrefix + filepath.Base(device)
+	return name, filepath.Join(cryptMapperDir, name)
+}
+
+func defaultCryptProvider(log logging.Logger) *cryptsetup {
+	return &cryptsetup{log: log}
+}
+
+// run executes cryptsetup, supplying the key (if any) on stdin so that it
+// doesn't appear in the process arguments.
+func (cs *cryptsetup) run(key storage.EncryptionKey, args ...string) error {
+	cmdPath, err := exec.LookPath(cryptsetupCmd)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find %s", cryptsetupCmd)
+	}
+
+	cs.log.Debugf("running %s %v", cryptsetupCmd, args)
+	cmd := exec.Command(cmdPath, args...)
+	if key != nil {
+		cmd.Stdin = bytes.NewReader(key)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return &system.RunCmdError{
+			Wrapped: err,
+			Stdout:  string(out),
+		}
+	}
+
+	return nil
+}
+
+// IsLuks returns true if the device contains a LUKS header.
+func (cs *cryptsetup) IsLuks(device string) (bool, error) {
+	if _, err := os.Stat(device); err != nil {
+		return false, err
+	}
+
+	err := cs.run(nil, "isLuks", device)
+	if err == nil {
+		return true, nil
+	}
+	if rce, ok := err.(*system.RunCmdError); ok {
+		if ee, ok := rce.Wrapped.(*exec.ExitError); ok && ee.ExitCode() == cryptNotLuksStatus {
+			return false, nil
+		}
+	}
+	return false, errors.Wrapf(err, "checking %s for LUKS header", device)
+}
+
+// Format initializes a LUKS2 header on the device, destroying its contents.
+func (cs *cryptsetup) Format(device string, key storage.EncryptionKey) error {
+	return errors.Wrapf(cs.run(key, "luksFormat", "--batch-mode", "--type", "luks2",
+		"--key-file", "-", device), "encrypting %s", device)
+}
+
+// Open creates a device-mapper mapping with the given name for the decrypted
+// contents of the device.
+func (cs *cryptsetup) Open(device, name string, key storage.EncryptionKey) error {
+	return errors.Wrapf(cs.run(key, "open", "--type", "luks", "--key-file", "-", device, name),
+		"unlocking %s", device)
+}
+
+// Close removes the named device-mapper mapping.
+func (cs *cryptsetup) Close(name string) error {
+	return errors.Wrapf(cs.run(nil, "close", name), "closing %s", name)
+}
+
+// IsOpen returns true if the named device-mapper mapping exists.
+func (cs *cryptsetup) IsOpen(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(cryptMapperDir, name))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// unlockDevice returns the path of the device to be used for the filesystem,
+// opening the mapping of an encrypted device if it isn't already open.
+func (p *Provider) unlockDevice(device string, enc storage.ScmEncryption, key storage.EncryptionKey) (string, error) {
+	switch enc {
+	case "":
+		return device, nil
+	case storage.ScmEncryptionDmCrypt:
+	default:
+		return "", errors.Errorf("unsupported scm encryption %q", enc)
+	}
+
+	name, mapped := cryptMapping(device)
+	isOpen, err := p.crypt.IsOpen(name)
+	if err != nil {
+		return "", err
+	}
+	if isOpen {
+		return mapped, nil
+	}
+
+	isLuks, err := p.crypt.IsLuks(device)
+	if err != nil {
+		return "", err
+	}
+	if !isLuks {
+		return "", errors.Errorf("%s is not a LUKS-encrypted device", device)
+	}
+
+	p.log.Debugf("unlocking %s as %s", device, mapped)
+	if err := p.crypt.Open(device, name, key); err != nil {
+		return "", err
+	}
+	return mapped, nil
+}
+
+// encryptDevice initializes encryption on the device, removing any existing
+// mapping first, and returns the path of the mapped device to be formatted.
+func (p *Provider) encryptDevice(params *storage.DeviceParams) (string, error) {
+	name, mapped := cryptMapping(params.Device)
+	isOpen, err := p.crypt.IsOpen(name)
+	if err != nil {
+		return "", err
+	}
+	if isOpen {
+		if err := p.crypt.Close(name); err != nil {
+			return "", err
+		}
+	}
+
+	p.log.Debugf("encrypting %s", params.Device)
+	if err := p.crypt.Format(params.Device, params.Key); err != nil {
+		return "", err
+	}
+	if err := p.crypt.Open(params.Device, name, params.Key); err != nil {
+		return "", err
+	}
+	return mapped, nil
+}
@@ -0,0 +1,282 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/provider/system"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	cryptTestDevice = "/dev/pmem0"
+	cryptTestMapped = "/dev/mapper/daos_pmem0"
+)
+
+// sourceMountProvider records the source device of each mount.
+type sourceMountProvider struct {
+	*storage.MockMountProvider
+	sources map[string]string
+}
+
+func newSourceMountProvider() *sourceMountProvider {
+	return &sourceMountProvider{
+		MockMountProvider: storage.NewMockMountProvider(nil),
+		sources:           make(map[string]string),
+	}
+}
+
+func (smp *sourceMountProvider) Mount(req storage.MountRequest) (*storage.MountResponse, error) {
+	smp.sources[req.Target] = req.Source
+	return smp.MockMountProvider.Mount(req)
+}
+
+func cryptTestParams() *storage.DeviceParams {
+	return &storage.DeviceParams{
+		Device:     cryptTestDevice,
+		Encryption: storage.ScmEncryptionDmCrypt,
+		Key:        storage.EncryptionKey("secret"),
+	}
+}
+
+func TestProvider_CheckFormat_Encrypted(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mcc         *MockCryptConfig
+		getFsStr    string
+		expResponse *storage.ScmFormatResponse
+		expCalls    []string
+		expErr      error
+	}{
+		"isLuks fails": {
+			mcc: &MockCryptConfig{
+				IsLuksErr: errors.New("isLuks failed"),
+			},
+			expCalls: []string{"isLuks " + cryptTestDevice},
+			expErr:   errors.New("isLuks failed"),
+		},
+		"not encrypted; not formatted": {
+			getFsStr: system.FsTypeNone,
+			expResponse: &storage.ScmFormatResponse{
+				Mountpoint: "/mnt/daos",
+			},
+			expCalls: []string{"isLuks " + cryptTestDevice},
+		},
+		"not encrypted; formatted": {
+			getFsStr: system.FsTypeExt4,
+			expResponse: &storage.ScmFormatResponse{
+				Mountpoint: "/mnt/daos",
+				Formatted:  true,
+			},
+			expCalls: []string{"isLuks " + cryptTestDevice},
+		},
+		"encrypted; unlock fails": {
+			mcc: &MockCryptConfig{
+				IsLuksRes: true,
+				OpenErr:   errors.New("bad key"),
+			},
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+				"isLuks " + cryptTestDevice,
+				"open " + cryptTestDevice + " daos_pmem0",
+			},
+			expErr: errors.New("bad key"),
+		},
+		"encrypted; mountable": {
+			mcc: &MockCryptConfig{
+				IsLuksRes: true,
+			},
+			getFsStr: system.FsTypeExt4,
+			expResponse: &storage.ScmFormatResponse{
+				Mountpoint: "/mnt/daos",
+				Formatted:  true,
+				Mountable:  true,
+			},
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+				"isLuks " + cryptTestDevice,
+				"open " + cryptTestDevice + " daos_pmem0",
+			},
+		},
+		"encrypted; already open": {
+			mcc: &MockCryptConfig{
+				IsLuksRes: true,
+				IsOpenRes: true,
+			},
+			getFsStr: system.FsTypeNone,
+			expResponse: &storage.ScmFormatResponse{
+				Mountpoint: "/mnt/daos",
+			},
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			p := NewMockProvider(log, nil, &system.MockSysConfig{
+				GetfsStr: tc.getFsStr,
+			})
+			mcp := NewMockCryptProvider(tc.mcc)
+			p.crypt = mcp
+
+			res, err := p.CheckFormat(storage.ScmFormatRequest{
+				Mountpoint: "/mnt/daos",
+				Dcpm:       cryptTestParams(),
+			})
+			test.CmpErr(t, tc.expErr, err)
+
+			if diff := cmp.Diff(tc.expCalls, mcp.Calls); diff != "" {
+				t.Fatalf("unexpected crypt calls (-want, +got):\n%s\n", diff)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResponse, res); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_Format_Encrypted(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mcc      *MockCryptConfig
+		expCalls []string
+		expErr   error
+	}{
+		"luksFormat fails": {
+			mcc: &MockCryptConfig{
+				FormatErr: errors.New("luksFormat failed"),
+			},
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+				"format " + cryptTestDevice,
+			},
+			expErr: errors.New("luksFormat failed"),
+		},
+		"existing mapping closed": {
+			mcc: &MockCryptConfig{
+				IsOpenRes: true,
+			},
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+				"close daos_pmem0",
+				"format " + cryptTestDevice,
+				"open " + cryptTestDevice + " daos_pmem0",
+			},
+		},
+		"success": {
+			expCalls: []string{
+				"isLuks " + cryptTestDevice,
+				"isOpen daos_pmem0",
+				"format " + cryptTestDevice,
+				"open " + cryptTestDevice + " daos_pmem0",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			testDir, clean := test.CreateTestDir(t)
+			defer clean()
+
+			p := NewMockProvider(log, nil, &system.MockSysConfig{
+				GetfsStr: system.FsTypeNone,
+			})
+			mmp := newSourceMountProvider()
+			p.mounter = mmp
+			mcp := NewMockCryptProvider(tc.mcc)
+			p.crypt = mcp
+
+			mountPoint := filepath.Join(testDir, "daos")
+			_, err := p.Format(storage.ScmFormatRequest{
+				Mountpoint: mountPoint,
+				OwnerUID:   os.Getuid(),
+				OwnerGID:   os.Getgid(),
+				Dcpm:       cryptTestParams(),
+			})
+			test.CmpErr(t, tc.expErr, err)
+
+			if diff := cmp.Diff(tc.expCalls, mcp.Calls); diff != "" {
+				t.Fatalf("unexpected crypt calls (-want, +got):\n%s\n", diff)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(cryptTestMapped, mmp.sources[mountPoint]); diff != "" {
+				t.Fatalf("unexpected mount source (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_Mount_Encrypted(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mcc    *MockCryptConfig
+		expErr error
+	}{
+		"not encrypted": {
+			expErr: errors.New("not a LUKS-encrypted device"),
+		},
+		"unlock fails": {
+			mcc: &MockCryptConfig{
+				IsLuksRes: true,
+				OpenErr:   errors.New("bad key"),
+			},
+			expErr: errors.New("bad key"),
+		},
+		"success": {
+			mcc: &MockCryptConfig{
+				IsLuksRes: true,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			p := NewMockProvider(log, nil, nil)
+			mmp := newSourceMountProvider()
+			p.mounter = mmp
+			p.crypt = NewMockCryptProvider(tc.mcc)
+
+			_, err := p.Mount(storage.ScmMountRequest{
+				Class:      storage.ClassDcpm,
+				Device:     cryptTestDevice,
+				Target:     "/mnt/daos",
+				Encryption: storage.ScmEncryptionDmCrypt,
+				Key:        storage.EncryptionKey("secret"),
+			})
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(cryptTestMapped, mmp.sources["/mnt/daos"]); diff != "" {
+				t.Fatalf("unexpected mount source (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// <SensorList>
+//  <Dimm>
+//   <DimmID>0x0001</DimmID>
+//   <Sensor>
+//    <Type>Health</Type>
+//    <CurrentValue>Healthy</CurrentValue>
+//   </Sensor>
+//   <Sensor>
+//    <Type>MediaTemperature</Type>
+//    <CurrentValue>36C</CurrentValue>
+//   </Sensor>
+//   <Sensor>
+//    <Type>PercentageRemaining</Type>
+//    <CurrentValue>100%</CurrentValue>
+//   </Sensor>
+//   <Sensor>
+//    <Type>LatchedDirtyShutdownCount</Type>
+//    <CurrentValue>3</CurrentValue>
+//   </Sensor>
+//  </Dimm>
+// </SensorList>
+
+type (
+	// Sensor represents a single PMem DIMM health sensor reading.
+	Sensor struct {
+		Type  string `xml:"Type"`
+		Value string `xml:"CurrentValue"`
+	}
+
+	// DIMMSensors contains the health sensor readings for a PMem DIMM.
+	DIMMSensors struct {
+		ID      hexShort `xml:"DimmID"`
+		Sensors []Sensor `xml:"Sensor"`
+	}
+
+	// SensorList struct contains the health sensor readings for all PMem DIMMs.
+	SensorList struct {
+		XMLName xml.Name      `xml:"SensorList"`
+		DIMMs   []DIMMSensors `xml:"Dimm"`
+	}
+)
+
+const (
+	sensorHealth         = "Health"
+	sensorMediaTemp      = "MediaTemperature"
+	sensorSpareCapacity  = "PercentageRemaining"
+	sensorDirtyShutdowns = "LatchedDirtyShutdownCount"
+)
+
+var (
+	sensorTypes = []string{
+		sensorHealth, sensorMediaTemp, sensorSpareCapacity, sensorDirtyShutdowns,
+	}
+	cmdShowSensors = pmemCmd{
+		BinaryName: ipmctlName,
+		Args: []string{
+			"show", "-o nvmxml", "-sensor " + strings.Join(sensorTypes, ","), "-dimm",
+		},
+	}
+)
+
+// parseSensorValue parses a numeric sensor reading with an optional unit
+// suffix (e.g. "36C" or "100%").
+func parseSensorValue(sensor Sensor, suffix string) (uint64, error) {
+	val := strings.TrimSuffix(strings.TrimSpace(sensor.Value), suffix)
+	n, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse %s sensor value %q", sensor.Type, sensor.Value)
+	}
+
+	return n, nil
+}
+
+// getModuleHealth uses XML output from `ipmctl show -o nvmxml -sensor -dimm [-socket X]` to
+// gather PMem DIMM health sensor readings.
+func (cr *cmdRunner) getModuleHealth(sockID int) ([]*storage.ScmModuleHealth, error) {
+	dimms, err := cr.dimmInfoFromXML(sockID)
+	if err != nil {
+		return nil, err
+	}
+	if len(dimms) == 0 {
+		return []*storage.ScmModuleHealth{}, nil
+	}
+
+	dimmsByID := make(map[hexShort]DIMM)
+	for _, d := range dimms {
+		dimmsByID[d.ID] = d
+	}
+
+	out, err := cr.runSockAwareCmd(sockID, cmdShowSensors)
+	if err != nil {
+		return nil, err
+	}
+
+	var sl SensorList
+	if err := xml.Unmarshal([]byte(out), &sl); err != nil {
+		return nil, errors.Wrap(err, "parse show sensor cmd output")
+	}
+
+	health := make([]*storage.ScmModuleHealth, 0, len(sl.DIMMs))
+	for _, ds := range sl.DIMMs {
+		d, found := dimmsByID[ds.ID]
+		if !found {
+			cr.log.Debugf("skipping sensor readings for unknown pmem module %#x", ds.ID)
+			continue
+		}
+
+		mh := &storage.ScmModuleHealth{
+			UID:         string(d.UID),
+			SocketID:    uint32(d.SocketID),
+			PhysicalID:  uint32(d.PhysicalID),
+			HealthState: string(d.HealthState),
+		}
+		for _, sensor := range ds.Sensors {
+			switch sensor.Type {
+			case sensorHealth:
+				mh.HealthState = strings.TrimSpace(sensor.Value)
+			case sensorMediaTemp:
+				n, err := parseSensorValue(sensor, "C")
+				if err != nil {
+					return nil, err
+				}
+				mh.MediaTemperature = uint32(n)
+			case sensorSpareCapacity:
+				n, err := parseSensorValue(sensor, "%")
+				if err != nil {
+					return nil, err
+				}
+				mh.SpareCapacity = uint32(n)
+			case sensorDirtyShutdowns:
+				n, err := parseSensorValue(sensor, "")
+				if err != nil {
+					return nil, err
+				}
+				mh.DirtyShutdownCount = n
+			}
+		}
+		health = append(health, mh)
+	}
+	cr.log.Tracef("discovered pmem module health: %+v", health)
+
+	return health, nil
+}
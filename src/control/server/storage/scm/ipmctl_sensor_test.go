@@ -0,0 +1,212 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/provider/system"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestIpmctl_getModuleHealth(t *testing.T) {
+	type OutputMap map[string]string
+	type ErrorMap map[string]error
+
+	dimmsOut := `
+<?xml version="1.0"?>
+ <DimmList>
+  <Dimm>
+   <DimmID>0x0001</DimmID>
+   <Capacity>502.599 GiB</Capacity>
+   <HealthState>Healthy</HealthState>
+   <FWVersion>01.00.00.5127</FWVersion>
+   <PhysicalID>0x001e</PhysicalID>
+   <DimmUID>8089-a2-1839-000010ce</DimmUID>
+   <SocketID>0x0000</SocketID>
+   <MemControllerID>0x0000</MemControllerID>
+   <ChannelID>0x0000</ChannelID>
+   <ChannelPos>1</ChannelPos>
+   <PartNumber>NMA1XXD512GQS</PartNumber>
+  </Dimm>
+  <Dimm>
+   <DimmID>0x1001</DimmID>
+   <Capacity>502.599 GiB</Capacity>
+   <HealthState>Healthy</HealthState>
+   <FWVersion>01.00.00.5127</FWVersion>
+   <PhysicalID>0x002a</PhysicalID>
+   <DimmUID>8089-a2-1839-00001105</DimmUID>
+   <SocketID>0x0001</SocketID>
+   <MemControllerID>0x0000</MemControllerID>
+   <ChannelID>0x0000</ChannelID>
+   <ChannelPos>1</ChannelPos>
+   <PartNumber>NMA1XXD512GQS</PartNumber>
+  </Dimm>
+ </DimmList>`
+	sensorsFmt := `
+<?xml version="1.0"?>
+ <SensorList>
+  <Dimm>
+   <DimmID>0x0001</DimmID>
+   <Sensor>
+    <Type>Health</Type>
+    <CurrentValue>Healthy</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>MediaTemperature</Type>
+    <CurrentValue>%s</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>PercentageRemaining</Type>
+    <CurrentValue>100%%</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>LatchedDirtyShutdownCount</Type>
+    <CurrentValue>1</CurrentValue>
+   </Sensor>
+  </Dimm>
+  <Dimm>
+   <DimmID>0x1001</DimmID>
+   <Sensor>
+    <Type>Health</Type>
+    <CurrentValue>Non-critical failure</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>MediaTemperature</Type>
+    <CurrentValue>84C</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>PercentageRemaining</Type>
+    <CurrentValue>8%%</CurrentValue>
+   </Sensor>
+   <Sensor>
+    <Type>LatchedDirtyShutdownCount</Type>
+    <CurrentValue>12</CurrentValue>
+   </Sensor>
+  </Dimm>
+  <Dimm>
+   <DimmID>0x2001</DimmID>
+   <Sensor>
+    <Type>MediaTemperature</Type>
+    <CurrentValue>40C</CurrentValue>
+   </Sensor>
+  </Dimm>
+ </SensorList>`
+	genCmdOutputMap := func() OutputMap {
+		return OutputMap{
+			cmdShowIpmctlVersion.String(): `
+Intel(R) Optane(TM) Persistent Memory Command Line Interface Version 03.00.00.0468`,
+			cmdShowDIMMs.String():   dimmsOut,
+			cmdShowSensors.String(): fmt.Sprintf(sensorsFmt, "36C"),
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cmdOutputMap OutputMap
+		cmdErrorMap  ErrorMap
+		expHealth    []*storage.ScmModuleHealth
+		expErr       error
+	}{
+		"show dimms command fails": {
+			cmdErrorMap: ErrorMap{
+				cmdShowDIMMs.String(): errors.New("dimms failed"),
+			},
+			expErr: errors.New("dimms failed"),
+		},
+		"no modules": {
+			cmdErrorMap: ErrorMap{
+				cmdShowDIMMs.String(): errors.Wrap(&system.RunCmdError{
+					Wrapped: &exec.ExitError{
+						ProcessState: &os.ProcessState{},
+					},
+					Stdout: outNoPMemDIMMs,
+				}, cmdShowDIMMs.String()),
+			},
+			expHealth: []*storage.ScmModuleHealth{},
+		},
+		"show sensors command fails": {
+			cmdErrorMap: ErrorMap{
+				cmdShowSensors.String(): errors.New("sensors failed"),
+			},
+			expErr: errors.New("sensors failed"),
+		},
+		"invalid xml": {
+			cmdOutputMap: func() OutputMap {
+				om := genCmdOutputMap()
+				om[cmdShowSensors.String()] = `text that is invalid xml`
+				return om
+			}(),
+			expErr: errors.New("parse show sensor cmd"),
+		},
+		"invalid sensor value": {
+			cmdOutputMap: func() OutputMap {
+				om := genCmdOutputMap()
+				om[cmdShowSensors.String()] = fmt.Sprintf(sensorsFmt, "hot")
+				return om
+			}(),
+			expErr: errors.New("parse MediaTemperature sensor value"),
+		},
+		"success": {
+			expHealth: []*storage.ScmModuleHealth{
+				{
+					UID:                "8089-a2-1839-000010ce",
+					PhysicalID:         0x1e,
+					HealthState:        "Healthy",
+					MediaTemperature:   36,
+					SpareCapacity:      100,
+					DirtyShutdownCount: 1,
+				},
+				{
+					UID:                "8089-a2-1839-00001105",
+					SocketID:           1,
+					PhysicalID:         0x2a,
+					HealthState:        "Non-critical failure",
+					MediaTemperature:   84,
+					SpareCapacity:      8,
+					DirtyShutdownCount: 12,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			if tc.cmdOutputMap == nil {
+				tc.cmdOutputMap = genCmdOutputMap()
+			}
+
+			mockRun := func(_ logging.Logger, cmd pmemCmd) (string, error) {
+				cmdStr := cmd.String()
+				return tc.cmdOutputMap[cmdStr], tc.cmdErrorMap[cmdStr]
+			}
+
+			cr, err := newCmdRunner(log, mockRun, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotHealth, gotErr := cr.getModuleHealth(sockAny)
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expHealth, gotHealth); diff != "" {
+				t.Errorf("unexpected module health (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -345,6 +345,8 @@ type MockBackendConfig struct {
 	GetModulesErr        error
 	GetNamespacesRes     storage.ScmNamespaces
 	GetNamespacesErr     error
+	GetModuleHealthRes   []*storage.ScmModuleHealth
+	GetModuleHealthErr   error
 	PrepRes              *storage.ScmPrepareResponse
 	PrepErr              error
 	PrepResetRes         *storage.ScmPrepareResponse
@@ -377,6 +379,10 @@ func (mb *MockBackend) getNamespaces(sockID int) (storage.ScmNamespaces, error)
 	return mb.cfg.GetNamespacesRes, mb.cfg.GetNamespacesErr
 }
 
+func (mb *MockBackend) getModuleHealth(sockID int) ([]*storage.ScmModuleHealth, error) {
+	return mb.cfg.GetModuleHealthRes, mb.cfg.GetModuleHealthErr
+}
+
 func (mb *MockBackend) prep(req storage.ScmPrepareRequest, _ *storage.ScmScanResponse) (*storage.ScmPrepareResponse, error) {
 	mb.Lock()
 	mb.PrepareCalls = append(mb.PrepareCalls, req)
@@ -443,3 +449,64 @@ func DefaultMockProvider(log logging.Logger) *Provider {
 	mountProv := mount.NewProvider(log, sysProv)
 	return NewProvider(log, DefaultMockBackend(), sysProv, mountProv)
 }
+
+type (
+	// MockCryptConfig specifies the behavior of a MockCryptProvider.
+	MockCryptConfig struct {
+		IsLuksRes bool
+		IsLuksErr error
+		FormatErr error
+		OpenErr   error
+		CloseErr  error
+		IsOpenRes bool
+		IsOpenErr error
+	}
+
+	// MockCryptProvider records the operations performed on encrypted devices.
+	MockCryptProvider struct {
+		sync.Mutex
+		cfg   MockCryptConfig
+		Calls []string
+	}
+)
+
+func (mcp *MockCryptProvider) addCall(call string) {
+	mcp.Lock()
+	defer mcp.Unlock()
+	mcp.Calls = append(mcp.Calls, call)
+}
+
+func (mcp *MockCryptProvider) IsLuks(device string) (bool, error) {
+	mcp.addCall("isLuks " + device)
+	return mcp.cfg.IsLuksRes, mcp.cfg.IsLuksErr
+}
+
+func (mcp *MockCryptProvider) Format(device string, _ storage.EncryptionKey) error {
+	mcp.addCall("format " + device)
+	return mcp.cfg.FormatErr
+}
+
+func (mcp *MockCryptProvider) Open(device, name string, _ storage.EncryptionKey) error {
+	mcp.addCall("open " + device + " " + name)
+	return mcp.cfg.OpenErr
+}
+
+func (mcp *MockCryptProvider) Close(name string) error {
+	mcp.addCall("close " + name)
+	return mcp.cfg.CloseErr
+}
+
+func (mcp *MockCryptProvider) IsOpen(name string) (bool, error) {
+	mcp.addCall("isOpen " + name)
+	return mcp.cfg.IsOpenRes, mcp.cfg.IsOpenErr
+}
+
+// NewMockCryptProvider returns a MockCryptProvider with the supplied behavior.
+func NewMockCryptProvider(cfg *MockCryptConfig) *MockCryptProvider {
+	if cfg == nil {
+		cfg = &MockCryptConfig{}
+	}
+	return &MockCryptProvider{
+		cfg: *cfg,
+	}
+}
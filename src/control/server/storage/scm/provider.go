@@ -37,6 +37,7 @@ type (
 	Backend interface {
 		getModules(int) (storage.ScmModules, error)
 		getNamespaces(int) (storage.ScmNamespaces, error)
+		getModuleHealth(int) ([]*storage.ScmModuleHealth, error)
 		prep(storage.ScmPrepareRequest, *storage.ScmScanResponse) (*storage.ScmPrepareResponse, error)
 		prepReset(storage.ScmPrepareRequest, *storage.ScmScanResponse) (*storage.ScmPrepareResponse, error)
 		GetFirmwareStatus(deviceUID string) (*storage.ScmFirmwareInfo, error)
@@ -57,6 +58,7 @@ type (
 		backend Backend
 		sys     SystemProvider
 		mounter storage.MountProvider
+		crypt   CryptProvider
 	}
 )
 
@@ -100,6 +102,7 @@ func NewProvider(log logging.Logger, backend Backend, sys SystemProvider, mounte
 		backend: backend,
 		sys:     sys,
 		mounter: mounter,
+		crypt:   defaultCryptProvider(log),
 	}
 	return p
 }
@@ -150,6 +153,18 @@ func (p *Provider) Scan(req storage.ScmScanRequest) (_ *storage.ScmScanResponse,
 	return resp, nil
 }
 
+// QueryHealth retrieves health sensor readings for PMem modules.
+func (p *Provider) QueryHealth(req storage.ScmHealthQueryRequest) (*storage.ScmHealthQueryResponse, error) {
+	modules, err := p.backend.getModuleHealth(sockAny)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.ScmHealthQueryResponse{
+		Modules: modules,
+	}, nil
+}
+
 type scanFn func(storage.ScmScanRequest) (*storage.ScmScanResponse, error)
 
 func (p *Provider) prepare(req storage.ScmPrepareRequest, scan scanFn) (*storage.ScmPrepareResponse, error) {
@@ -235,15 +250,41 @@ func (p *Provider) CheckFormat(req storage.ScmFormatRequest) (*storage.ScmFormat
 		return res, nil
 	}
 
-	fsType, err := p.sys.Getfs(req.Dcpm.Device)
+	fsDevice := req.Dcpm.Device
+	if req.Dcpm.Encryption != "" {
+		isLuks, err := p.crypt.IsLuks(req.Dcpm.Device)
+		if err != nil {
+			if os.IsNotExist(errors.Cause(err)) {
+				return nil, FaultFormatMissingDevice(req.Dcpm.Device)
+			}
+			return nil, errors.Wrapf(err, "failed to check if %s is encrypted", req.Dcpm.Device)
+		}
+		if isLuks {
+			fsDevice, err = p.unlockDevice(req.Dcpm.Device, req.Dcpm.Encryption, req.Dcpm.Key)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// an unencrypted filesystem is not mountable when encryption is required
+			p.log.Debugf("device %s has no LUKS header", req.Dcpm.Device)
+			fsType, err := p.sys.Getfs(req.Dcpm.Device)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to check if %s is formatted", req.Dcpm.Device)
+			}
+			res.Formatted = fsType != system.FsTypeNone
+			return res, nil
+		}
+	}
+
+	fsType, err := p.sys.Getfs(fsDevice)
 	if err != nil {
 		if os.IsNotExist(errors.Cause(err)) {
-			return nil, FaultFormatMissingDevice(req.Dcpm.Device)
+			return nil, FaultFormatMissingDevice(fsDevice)
 		}
-		return nil, errors.Wrapf(err, "failed to check if %s is formatted", req.Dcpm.Device)
+		return nil, errors.Wrapf(err, "failed to check if %s is formatted", fsDevice)
 	}
 
-	p.log.Debugf("device %s filesystem: %s", req.Dcpm.Device, fsType)
+	p.log.Debugf("device %s filesystem: %s", fsDevice, fsType)
 
 	switch fsType {
 	case system.FsTypeExt4:
@@ -384,17 +425,29 @@ func (p *Provider) formatDcpm(req storage.ScmFormatRequest) (*storage.ScmFormatR
 	}
 	opts = append(opts, getDistroArgs()...)
 
-	p.log.Debugf("running mkfs.%s %s", dcpmFsType, req.Dcpm.Device)
+	device := req.Dcpm.Device
+	switch req.Dcpm.Encryption {
+	case "":
+	case storage.ScmEncryptionDmCrypt:
+		var err error
+		if device, err = p.encryptDevice(req.Dcpm); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unsupported scm encryption %q", req.Dcpm.Encryption)
+	}
+
+	p.log.Debugf("running mkfs.%s %s", dcpmFsType, device)
 	if err := p.sys.Mkfs(system.MkfsReq{
 		Filesystem: dcpmFsType,
-		Device:     req.Dcpm.Device,
+		Device:     device,
 		Options:    opts,
 		Force:      req.Force,
 	}); err != nil {
-		return nil, errors.Wrapf(err, "failed to format %s", req.Dcpm.Device)
+		return nil, errors.Wrapf(err, "failed to format %s", device)
 	}
 
-	res, err := p.mountDcpm(req.Dcpm.Device, req.Mountpoint)
+	res, err := p.mountDcpm(device, req.Mountpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +512,11 @@ func (p *Provider) mountRamdisk(target string, params *storage.RamdiskParams) (*
 func (p *Provider) Mount(req storage.ScmMountRequest) (*storage.MountResponse, error) {
 	switch req.Class {
 	case storage.ClassDcpm:
-		return p.mountDcpm(req.Device, req.Target)
+		device, err := p.unlockDevice(req.Device, req.Encryption, req.Key)
+		if err != nil {
+			return nil, err
+		}
+		return p.mountDcpm(device, req.Target)
 	case storage.ClassRam:
 		return p.mountRamdisk(req.Target, req.Ramdisk)
 	default:
@@ -0,0 +1,289 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	superblockVerifyInterval = 10 * time.Minute
+	superblockQueryTimeout   = 10 * time.Second
+)
+
+type (
+	// superblockEngine is the subset of the engine instance methods used
+	// to verify its superblock.
+	superblockEngine interface {
+		Index() uint32
+		getSuperblock() *Superblock
+		readStoredSuperblock() (*Superblock, error)
+	}
+
+	// superblockMemberQueryFn returns the MS database entries for the
+	// given ranks.
+	superblockMemberQueryFn func(*ranklist.RankSet) (system.Members, error)
+
+	// superblockVerifier periodically reads the superblock on the storage
+	// of each engine and checks it against the configured system name, the
+	// identity that the engine is running with and the MS database, raising
+	// RAS events on mismatch. This catches storage that was restored from
+	// the wrong backup or cloned from another host before the engine is
+	// next started with the wrong identity.
+	superblockVerifier struct {
+		sync.Mutex
+		log          logging.Logger
+		hostname     string
+		sysName      string
+		engines      []superblockEngine
+		queryMembers superblockMemberQueryFn
+		publish      func(*events.RASEvent)
+		reported     map[uint32]string
+	}
+)
+
+func newSuperblockVerifier(log logging.Logger, hostname, sysName string, engines []superblockEngine, query superblockMemberQueryFn, publish func(*events.RASEvent)) *superblockVerifier {
+	if sysName == "" {
+		sysName = defaultGroupName
+	}
+
+	return &superblockVerifier{
+		log:          log,
+		hostname:     hostname,
+		sysName:      sysName,
+		engines:      engines,
+		queryMembers: query,
+		publish:      publish,
+		reported:     make(map[uint32]string),
+	}
+}
+
+// readStoredSuperblock reads the instance's superblock from storage without
+// replacing the in-memory superblock.
+func (ei *EngineInstance) readStoredSuperblock() (*Superblock, error) {
+	return ei.readSuperblockFrom(ei.superblockPath())
+}
+
+// startSuperblockVerifier verifies the superblock of each engine on the host
+// when its storage becomes ready, before the engine is started, and then
+// periodically while the server is running.
+//
+// MS replicas consult their local copy of the MS database. Other hosts request
+// the member UUIDs recorded for their ranks from the MS leader.
+func startSuperblockVerifier(ctx context.Context, srv *server) {
+	var engines []superblockEngine
+	for _, ei := range srv.harness.Instances() {
+		if sbe, ok := ei.(superblockEngine); ok {
+			engines = append(engines, sbe)
+		}
+	}
+
+	query := func(ranks *ranklist.RankSet) (system.Members, error) {
+		if !srv.sysdb.IsReplica() {
+			return querySuperblockMembers(ctx, srv.mgmtSvc.rpcClient, srv.cfg.SystemName,
+				srv.cfg.AccessPoints, ranks)
+		}
+
+		var members system.Members
+		for _, rank := range ranks.Ranks() {
+			m, err := srv.sysdb.FindMemberByRank(rank)
+			if err != nil {
+				if system.IsMemberNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			members = append(members, m)
+		}
+		return members, nil
+	}
+
+	sbv := newSuperblockVerifier(srv.log, srv.hostname, srv.cfg.SystemName, engines, query,
+		srv.pubSub.Publish)
+
+	for _, e := range engines {
+		ei, ok := e.(*EngineInstance)
+		if !ok {
+			continue
+		}
+		sbe := e
+		// Keep an engine with a mismatched superblock from starting
+		// and joining the system with another engine's identity.
+		ei.OnStorageReady(func(_ context.Context) error {
+			return sbv.check(sbe)
+		})
+	}
+
+	go sbv.run(ctx)
+}
+
+// querySuperblockMembers requests the member UUIDs recorded in the MS database
+// for the given ranks from the MS leader.
+func querySuperblockMembers(ctx context.Context, rpcClient control.UnaryInvoker, sysName string, accessPoints []string, ranks *ranklist.RankSet) (system.Members, error) {
+	req := &control.SystemRankUUIDsReq{Ranks: ranks}
+	req.SetHostList(accessPoints)
+	req.SetSystem(sysName)
+	// Don't hold up engine start for long if the MS is not yet available.
+	req.SetTimeout(superblockQueryTimeout)
+
+	resp, err := control.SystemRankUUIDs(ctx, rpcClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var members system.Members
+	for rank, id := range resp.UUIDs {
+		members = append(members, &system.Member{Rank: rank, UUID: id})
+	}
+	return members, nil
+}
+
+// run verifies the superblocks at a fixed interval until the context is
+// canceled.
+func (sbv *superblockVerifier) run(ctx context.Context) {
+	ticker := time.NewTicker(superblockVerifyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = sbv.check(sbv.engines...)
+		}
+	}
+}
+
+// verifyLocal checks the stored superblock against the configured system name
+// and the superblock that the engine is running with, if any.
+func (sbv *superblockVerifier) verifyLocal(stored, cur *Superblock) []string {
+	var problems []string
+
+	if stored.System != sbv.sysName {
+		problems = append(problems, fmt.Sprintf("system name %q does not match configured system %q",
+			stored.System, sbv.sysName))
+	}
+	if cur != nil && !stored.matches(cur) {
+		problems = append(problems, fmt.Sprintf("rank %s, uuid %s on storage differs from rank %s, uuid %s in use",
+			stored.Rank, stored.UUID, cur.Rank, cur.UUID))
+	}
+
+	return problems
+}
+
+// check verifies the stored superblock of each of the given engines and
+// publishes a RAS event for each engine with a new or changed mismatch. An
+// error is returned if a mismatch was found for any of the engines.
+func (sbv *superblockVerifier) check(engines ...superblockEngine) error {
+	sbv.Lock()
+	defer sbv.Unlock()
+
+	type engineCheck struct {
+		idx      uint32
+		stored   *Superblock
+		problems []string
+	}
+
+	var checks []*engineCheck
+	ranks := ranklist.MustCreateRankSet("")
+	for _, e := range engines {
+		stored, err := e.readStoredSuperblock()
+		if err != nil {
+			// No superblock until the engine storage is formatted.
+			if os.IsNotExist(errors.Cause(err)) {
+				delete(sbv.reported, e.Index())
+				continue
+			}
+			checks = append(checks, &engineCheck{
+				idx:      e.Index(),
+				problems: []string{fmt.Sprintf("unreadable superblock: %s", err)},
+			})
+			continue
+		}
+
+		ec := &engineCheck{
+			idx:      e.Index(),
+			stored:   stored,
+			problems: sbv.verifyLocal(stored, e.getSuperblock()),
+		}
+		checks = append(checks, ec)
+		if stored.ValidRank && stored.Rank != nil {
+			ranks.Add(*stored.Rank)
+		}
+	}
+
+	var msUnknown bool
+	if ranks.Count() > 0 {
+		members, err := sbv.queryMembers(ranks)
+		if err != nil {
+			// The MS may not be available yet when the server
+			// starts.
+			sbv.log.Debugf("skipping MS superblock verification: %s", err)
+			msUnknown = true
+		}
+
+		byRank := make(map[ranklist.Rank]*system.Member)
+		for _, m := range members {
+			byRank[m.Rank] = m
+		}
+		for _, ec := range checks {
+			if ec.stored == nil || !ec.stored.ValidRank || ec.stored.Rank == nil {
+				continue
+			}
+			m, found := byRank[*ec.stored.Rank]
+			if !found || m.UUID.String() == ec.stored.UUID {
+				continue
+			}
+			ec.problems = append(ec.problems, fmt.Sprintf("rank %d is assigned to uuid %s in the MS database, not %s",
+				m.Rank, m.UUID, ec.stored.UUID))
+		}
+	}
+
+	var mismatches []string
+	for _, ec := range checks {
+		if len(ec.problems) == 0 {
+			// A mismatch with the MS database persists until it
+			// can be checked again.
+			if !msUnknown {
+				delete(sbv.reported, ec.idx)
+			}
+			continue
+		}
+
+		details := strings.Join(ec.problems, "; ")
+		mismatches = append(mismatches, fmt.Sprintf("instance %d: %s", ec.idx, details))
+		if sbv.reported[ec.idx] == details {
+			continue
+		}
+		sbv.reported[ec.idx] = details
+
+		rank := ranklist.NilRank
+		if ec.stored != nil && ec.stored.Rank != nil {
+			rank = *ec.stored.Rank
+		}
+		sbv.log.Errorf("instance %d: superblock mismatch: %s", ec.idx, details)
+		sbv.publish(events.NewEngineSuperblockMismatchEvent(sbv.hostname, ec.idx, rank.Uint32(), details))
+	}
+
+	if len(mismatches) > 0 {
+		return errors.Errorf("superblock mismatch: %s", strings.Join(mismatches, "; "))
+	}
+	return nil
+}
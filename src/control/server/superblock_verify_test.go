@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type mockSuperblockEngine struct {
+	idx     uint32
+	cur     *Superblock
+	stored  *Superblock
+	readErr error
+}
+
+func (m *mockSuperblockEngine) Index() uint32 {
+	return m.idx
+}
+
+func (m *mockSuperblockEngine) getSuperblock() *Superblock {
+	return m.cur
+}
+
+func (m *mockSuperblockEngine) readStoredSuperblock() (*Superblock, error) {
+	return m.stored, m.readErr
+}
+
+func TestServer_superblockVerifier_check(t *testing.T) {
+	mockSB := func(rank uint32, uuidIdx int32) *Superblock {
+		return &Superblock{
+			System:    build.DefaultSystemName,
+			UUID:      test.MockUUID(uuidIdx),
+			Rank:      ranklist.NewRankPtr(rank),
+			ValidRank: true,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		engines   []*mockSuperblockEngine
+		members   system.Members
+		queryErr  error
+		expEvents []string
+	}{
+		"superblocks match": {
+			engines: []*mockSuperblockEngine{
+				{idx: 0, cur: mockSB(1, 1), stored: mockSB(1, 1)},
+				{idx: 1, stored: mockSB(2, 2)},
+			},
+			members: system.Members{
+				system.MockMember(t, 1, system.MemberStateJoined),
+				system.MockMember(t, 2, system.MemberStateJoined),
+			},
+		},
+		"not formatted": {
+			engines: []*mockSuperblockEngine{
+				{idx: 0, readErr: errors.Wrap(os.ErrNotExist, "failed to read Superblock")},
+			},
+		},
+		"unreadable superblock": {
+			engines: []*mockSuperblockEngine{
+				{idx: 0, readErr: errors.New("bad yaml")},
+			},
+			expEvents: []string{
+				"DAOS engine 0 (rank 4294967295) superblock mismatch: unreadable superblock: bad yaml",
+			},
+		},
+		"wrong system name": {
+			engines: []*mockSuperblockEngine{
+				{
+					idx: 0,
+					stored: &Superblock{
+						System:    "other",
+						UUID:      test.MockUUID(1),
+						Rank:      ranklist.NewRankPtr(1),
+						ValidRank: true,
+					},
+				},
+			},
+			members: system.Members{
+				system.MockMember(t, 1, system.MemberStateJoined),
+			},
+			expEvents: []string{
+				`DAOS engine 0 (rank 1) superblock mismatch: system name "other" does not match configured system "daos_server"`,
+			},
+		},
+		"stored differs from running": {
+			engines: []*mockSuperblockEngine{
+				{idx: 1, cur: mockSB(1, 1), stored: mockSB(2, 2)},
+			},
+			members: system.Members{
+				system.MockMember(t, 2, system.MemberStateJoined),
+			},
+			expEvents: []string{
+				"DAOS engine 1 (rank 2) superblock mismatch: rank 2, uuid " + test.MockUUID(2) +
+					" on storage differs from rank 1, uuid " + test.MockUUID(1) + " in use",
+			},
+		},
+		"uuid differs from MS": {
+			engines: []*mockSuperblockEngine{
+				{idx: 0, stored: mockSB(1, 3)},
+			},
+			members: system.Members{
+				system.MockMember(t, 1, system.MemberStateJoined),
+			},
+			expEvents: []string{
+				"DAOS engine 0 (rank 1) superblock mismatch: rank 1 is assigned to uuid " +
+					test.MockUUID(1) + " in the MS database, not " + test.MockUUID(3),
+			},
+		},
+		"MS unavailable": {
+			engines: []*mockSuperblockEngine{
+				{idx: 0, stored: mockSB(1, 3)},
+			},
+			queryErr: errors.New("no MS"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			var engines []superblockEngine
+			for _, e := range tc.engines {
+				engines = append(engines, e)
+			}
+			query := func(*ranklist.RankSet) (system.Members, error) {
+				return tc.members, tc.queryErr
+			}
+			var gotEvents []string
+			publish := func(evt *events.RASEvent) {
+				gotEvents = append(gotEvents, evt.Msg)
+			}
+
+			sbv := newSuperblockVerifier(log, "foo", build.DefaultSystemName, engines, query, publish)
+			gotErr := sbv.check(engines...)
+			test.AssertEqual(t, len(tc.expEvents) > 0, gotErr != nil,
+				"expected an error only for a mismatch")
+
+			if diff := cmp.Diff(tc.expEvents, gotEvents); diff != "" {
+				t.Fatalf("unexpected events (-want, +got):\n%s\n", diff)
+			}
+
+			// Unchanged mismatches are only reported once.
+			_ = sbv.check(engines...)
+			if diff := cmp.Diff(tc.expEvents, gotEvents); diff != "" {
+				t.Fatalf("unexpected events after repeat check (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_superblockVerifier_resolved(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	engine := &mockSuperblockEngine{readErr: errors.New("bad yaml")}
+	var gotEvents int
+	sbv := newSuperblockVerifier(log, "foo", "", []superblockEngine{engine},
+		func(*ranklist.RankSet) (system.Members, error) {
+			return nil, nil
+		},
+		func(*events.RASEvent) {
+			gotEvents++
+		})
+
+	_ = sbv.check(engine)
+	test.AssertEqual(t, 1, gotEvents, "unexpected number of events")
+
+	engine.readErr = nil
+	engine.stored = &Superblock{System: defaultGroupName, UUID: test.MockUUID(1)}
+	_ = sbv.check(engine)
+	test.AssertEqual(t, 0, len(sbv.reported), "expected report to be cleared")
+
+	// The problem is reported again if it recurs.
+	engine.readErr = errors.New("bad yaml")
+	_ = sbv.check(engine)
+	test.AssertEqual(t, 2, gotEvents, "unexpected number of events")
+}
+
+func TestServer_querySuperblockMembers(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic        *control.MockInvokerConfig
+		expMembers system.Members
+		expErr     error
+	}{
+		"MS unavailable": {
+			mic: &control.MockInvokerConfig{
+				UnaryError: errors.New("no leader"),
+			},
+			expErr: errors.New("no leader"),
+		},
+		"recorded uuids": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponseSet: []*control.UnaryResponse{
+					control.MockMSResponse("", nil, &mgmtpb.SystemRankUUIDsResp{
+						Uuids: map[uint32]string{1: test.MockUUID(1)},
+					}),
+				},
+			},
+			expMembers: system.Members{
+				{Rank: 1, UUID: uuid.MustParse(test.MockUUID(1))},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			mi := control.NewMockInvoker(log, tc.mic)
+			gotMembers, gotErr := querySuperblockMembers(test.Context(t), mi,
+				build.DefaultSystemName, []string{"localhost"}, ranklist.MustCreateRankSet("1-2"))
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expMembers, gotMembers); diff != "" {
+				t.Fatalf("unexpected members (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,10 @@ package server
 
 import (
 	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,8 +19,65 @@ import (
 	"github.com/daos-stack/daos/src/control/lib/ranklist"
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
 )
 
+// engineUsageCollector is a prometheus.Collector which reports the procfs
+// resource usage of each running engine process.
+type engineUsageCollector struct {
+	log     logging.Logger
+	engines []Engine
+	rss     *prometheus.Desc
+	hp      *prometheus.Desc
+	fds     *prometheus.Desc
+	cpu     *prometheus.Desc
+}
+
+func newEngineUsageCollector(log logging.Logger, engines []Engine) *engineUsageCollector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("engine", "process", name), help,
+			append([]string{"rank"}, labels...), nil)
+	}
+
+	return &engineUsageCollector{
+		log:     log,
+		engines: engines,
+		rss:     desc("rss_bytes", "Resident set size of the engine process"),
+		hp:      desc("hugepage_bytes", "Hugetlb memory in use by the engine process"),
+		fds:     desc("open_fds", "Number of open file descriptors in the engine process"),
+		cpu: desc("cpu_seconds_total", "Cumulative CPU time consumed by engine threads",
+			"thread"),
+	}
+}
+
+func (c *engineUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{c.rss, c.hp, c.fds, c.cpu} {
+		ch <- d
+	}
+}
+
+func (c *engineUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, ei := range c.engines {
+		rank, err := ei.GetRank()
+		if err != nil {
+			continue
+		}
+		ru, err := ei.GetResourceUsage()
+		if err != nil {
+			c.log.Tracef("instance %d: failed to get resource usage: %s", ei.Index(), err)
+			continue
+		}
+
+		rl := rank.String()
+		ch <- prometheus.MustNewConstMetric(c.rss, prometheus.GaugeValue, float64(ru.RSSBytes), rl)
+		ch <- prometheus.MustNewConstMetric(c.hp, prometheus.GaugeValue, float64(ru.HugepageBytes), rl)
+		ch <- prometheus.MustNewConstMetric(c.fds, prometheus.GaugeValue, float64(ru.OpenFDs), rl)
+		for thread, secs := range ru.CPUSeconds {
+			ch <- prometheus.MustNewConstMetric(c.cpu, prometheus.CounterValue, secs, rl, thread)
+		}
+	}
+}
+
 func regPromEngineSources(ctx context.Context, log logging.Logger, engines []Engine) error {
 	numEngines := len(engines)
 	if numEngines == 0 {
@@ -28,6 +89,7 @@ func regPromEngineSources(ctx context.Context, log logging.Logger, engines []Eng
 		return err
 	}
 	prometheus.MustRegister(c)
+	prometheus.MustRegister(newEngineUsageCollector(log, engines))
 
 	addFn := func(idx uint32, rank ranklist.Rank) func(context.Context) error {
 		return func(context.Context) error {
@@ -36,6 +98,7 @@ func regPromEngineSources(ctx context.Context, log logging.Logger, engines []Eng
 			if err != nil {
 				return errors.Wrapf(err, "failed to create EngineSource for idx %d", idx)
 			}
+			es.TargetCount = engines[idx].GetTargetCount()
 			c.AddSource(es, cleanup)
 			return nil
 		}
@@ -79,3 +142,39 @@ func startPrometheusExporter(ctx context.Context, log logging.Logger, port int,
 
 	return promexp.StartExporter(ctx, log, expCfg)
 }
+
+// startRemoteWrite starts pushing the metrics registered with the default
+// Prometheus registry to a remote-write receiver. Series are labeled with the
+// job and instance that a scrape of the telemetry port would have added,
+// unless they are overridden by the configured external labels.
+func startRemoteWrite(ctx context.Context, log logging.Logger, port int, cfg *config.TelemetryRemoteWrite) (func(), error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting hostname")
+	}
+
+	labels := map[string]string{
+		"job":      "daos_server",
+		"instance": net.JoinHostPort(hostname, strconv.Itoa(port)),
+	}
+	for k, v := range cfg.ExternalLabels {
+		labels[k] = v
+	}
+
+	rwCfg := &promexp.RemoteWriteConfig{
+		URL:            cfg.Endpoint,
+		Interval:       cfg.PushInterval,
+		MaxPending:     cfg.MaxPending,
+		ExternalLabels: labels,
+		Gatherer:       prometheus.DefaultGatherer,
+	}
+	if cfg.BearerTokenFile != "" {
+		token, err := os.ReadFile(cfg.BearerTokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading remote-write bearer token")
+		}
+		rwCfg.BearerToken = strings.TrimSpace(string(token))
+	}
+
+	return promexp.StartRemoteWriter(ctx, log, rwCfg)
+}
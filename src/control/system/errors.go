@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -95,6 +96,26 @@ func IsNotLeader(err error) bool {
 	return ok
 }
 
+// ErrBusy indicates that the Management Service leader has too many
+// requests of the given kind outstanding and that the request should be
+// retried after the given period.
+type ErrBusy struct {
+	Operation  string
+	RetryAfter time.Duration
+}
+
+func (err *ErrBusy) Error() string {
+	return fmt.Sprintf("%s busy processing %s requests (retry after %s)",
+		build.ManagementServiceName, err.Operation, err.RetryAfter)
+}
+
+// IsBusy returns a boolean indicating whether or not the
+// supplied error is an instance of ErrBusy.
+func IsBusy(err error) bool {
+	_, ok := errors.Cause(err).(*ErrBusy)
+	return ok
+}
+
 // ErrMemberExists indicates the failure of an operation that
 // expected the given member to not exist.
 type ErrMemberExists struct {
@@ -131,13 +152,15 @@ func IsMemberExists(err error) bool {
 // ErrJoinFailure indicates the failure of a Join request due
 // to some structured error condition.
 type ErrJoinFailure struct {
-	rankChanged bool
-	uuidChanged bool
-	isExcluded  bool
-	newUUID     *uuid.UUID
-	curUUID     *uuid.UUID
-	newRank     *ranklist.Rank
-	curRank     *ranklist.Rank
+	rankChanged   bool
+	uuidChanged   bool
+	hostIDChanged bool
+	isExcluded    bool
+	isUnstable    bool
+	newUUID       *uuid.UUID
+	curUUID       *uuid.UUID
+	newRank       *ranklist.Rank
+	curRank       *ranklist.Rank
 }
 
 func (err *ErrJoinFailure) Error() string {
@@ -146,8 +169,12 @@ func (err *ErrJoinFailure) Error() string {
 		return fmt.Sprintf("can't rejoin member with uuid %s: rank changed from %d -> %d", *err.curUUID, *err.curRank, *err.newRank)
 	case err.uuidChanged:
 		return fmt.Sprintf("can't rejoin member with rank %d: uuid changed from %s -> %s", *err.curRank, *err.curUUID, *err.newUUID)
+	case err.hostIDChanged:
+		return fmt.Sprintf("can't rejoin member with rank %d: host ID changed from %s -> %s", *err.curRank, *err.curUUID, *err.newUUID)
 	case err.isExcluded:
 		return fmt.Sprintf("member %s (rank %d) has been administratively excluded", err.curUUID, *err.curRank)
+	case err.isUnstable:
+		return fmt.Sprintf("member %s (rank %d) is unstable and must be cleared before rejoining", err.curUUID, *err.curRank)
 	default:
 		return "unknown join failure"
 	}
@@ -171,6 +198,15 @@ func ErrUuidChanged(new, cur uuid.UUID, rank ranklist.Rank) *ErrJoinFailure {
 	}
 }
 
+func ErrHostIDChanged(new, cur uuid.UUID, rank ranklist.Rank) *ErrJoinFailure {
+	return &ErrJoinFailure{
+		hostIDChanged: true,
+		newUUID:       &new,
+		curUUID:       &cur,
+		curRank:       &rank,
+	}
+}
+
 func ErrAdminExcluded(uuid uuid.UUID, rank ranklist.Rank) *ErrJoinFailure {
 	return &ErrJoinFailure{
 		isExcluded: true,
@@ -179,6 +215,14 @@ func ErrAdminExcluded(uuid uuid.UUID, rank ranklist.Rank) *ErrJoinFailure {
 	}
 }
 
+func ErrMemberUnstable(uuid uuid.UUID, rank ranklist.Rank) *ErrJoinFailure {
+	return &ErrJoinFailure{
+		isUnstable: true,
+		curUUID:    &uuid,
+		curRank:    &rank,
+	}
+}
+
 // IsJoinFailure returns a boolean indicating whether or not the
 // supplied error is an instance of ErrJoinFailure.
 func IsJoinFailure(err error) bool {
@@ -186,6 +230,14 @@ func IsJoinFailure(err error) bool {
 	return ok
 }
 
+// IsIdentityMismatch returns a boolean indicating whether or not the
+// supplied error is a join failure caused by a rank, UUID or host ID that
+// doesn't match the one recorded for the member.
+func IsIdentityMismatch(err error) bool {
+	jf, ok := errors.Cause(err).(*ErrJoinFailure)
+	return ok && (jf.rankChanged || jf.uuidChanged || jf.hostIDChanged)
+}
+
 // ErrMemberNotFound indicates a failure to find a member with the
 // given search criterion.
 type ErrMemberNotFound struct {
@@ -297,6 +349,24 @@ func ErrPoolLabelNotFound(l string) *ErrPoolNotFound {
 	return &ErrPoolNotFound{byLabel: &l}
 }
 
+// ErrPoolLabelExists indicates the failure of an operation that
+// expected the given pool label to be unused by any other pool.
+type ErrPoolLabelExists struct {
+	Label    string
+	PoolUUID uuid.UUID
+}
+
+func (err *ErrPoolLabelExists) Error() string {
+	return fmt.Sprintf("pool label %q is already in use by pool %s", err.Label, err.PoolUUID)
+}
+
+// IsPoolLabelExists returns a boolean indicating whether or not the
+// supplied error is an instance of ErrPoolLabelExists.
+func IsPoolLabelExists(err error) bool {
+	_, ok := errors.Cause(err).(*ErrPoolLabelExists)
+	return ok
+}
+
 type errSystemAttrNotFound struct {
 	key string
 }
@@ -313,3 +383,20 @@ func IsErrSystemAttrNotFound(err error) bool {
 	_, ok := errors.Cause(err).(*errSystemAttrNotFound)
 	return ok
 }
+
+type errEventNotFound struct {
+	id uint64
+}
+
+func (err *errEventNotFound) Error() string {
+	return fmt.Sprintf("unable to find event with id %d", err.id)
+}
+
+func ErrEventNotFound(id uint64) *errEventNotFound {
+	return &errEventNotFound{id: id}
+}
+
+func IsErrEventNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*errEventNotFound)
+	return ok
+}
@@ -0,0 +1,80 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+)
+
+// PersistentEvent is a record of a critical RAS event that is kept in the MS
+// database. The event remains active until it has been acknowledged by an
+// administrator.
+type PersistentEvent struct {
+	ID        uint64    `json:"id"`
+	RASID     string    `json:"ras_id"`
+	Severity  string    `json:"severity"`
+	Msg       string    `json:"msg"`
+	Hostname  string    `json:"hostname"`
+	Rank      uint32    `json:"rank"`
+	PoolUUID  string    `json:"pool_uuid,omitempty"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// Count is the number of times the event has been raised while active.
+	Count        uint32    `json:"count"`
+	Acknowledged bool      `json:"acknowledged"`
+	AckTime      time.Time `json:"ack_time"`
+}
+
+// IsCriticalEvent returns true if the supplied RAS event should be recorded
+// as a persistent event.
+func IsCriticalEvent(evt *events.RASEvent) bool {
+	return evt != nil && evt.Severity == events.RASSeverityError
+}
+
+// NewPersistentEvent creates a new, active PersistentEvent from the supplied
+// RAS event. The ID is assigned when the event is added to the MS database.
+func NewPersistentEvent(evt *events.RASEvent) *PersistentEvent {
+	ts, err := evt.GetTimestamp()
+	if err != nil {
+		ts = time.Now()
+	}
+
+	return &PersistentEvent{
+		RASID:     evt.ID.String(),
+		Severity:  evt.Severity.String(),
+		Msg:       evt.Msg,
+		Hostname:  evt.Hostname,
+		Rank:      evt.Rank,
+		PoolUUID:  evt.PoolUUID,
+		FirstSeen: ts,
+		LastSeen:  ts,
+		Count:     1,
+	}
+}
+
+// IsActive returns true if the event has not been acknowledged.
+func (pe *PersistentEvent) IsActive() bool {
+	return pe != nil && !pe.Acknowledged
+}
+
+// Matches returns true if the other event reports the same condition from the
+// same source. The message is not compared, as it may vary between repeated
+// reports, e.g. when they have been summarized.
+func (pe *PersistentEvent) Matches(other *PersistentEvent) bool {
+	if pe == nil || other == nil {
+		return false
+	}
+	return pe.RASID == other.RASID && pe.Hostname == other.Hostname &&
+		pe.Rank == other.Rank && pe.PoolUUID == other.PoolUUID
+}
+
+func (pe *PersistentEvent) String() string {
+	return fmt.Sprintf("event %d (%s on %s rank %d)", pe.ID, pe.RASID, pe.Hostname, pe.Rank)
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2021-2022 Intel Corporation.
+// (C) Copyright 2021-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -33,6 +33,26 @@ func FaultPoolLocked(poolUUID, lockID uuid.UUID, lockTime time.Time) *fault.Faul
 		"retry the pool operation")
 }
 
+// FaultDatabaseLogStoreCorrupt generates a fault indicating that the system database log
+// store failed verification on startup.
+func FaultDatabaseLogStoreCorrupt(path string, cause error) *fault.Fault {
+	return systemFault(code.SystemDatabaseLogStoreCorrupt,
+		fmt.Sprintf("system database log store %s is corrupt: %s", path, cause),
+		"if other MS replicas are healthy, remove the raft directory of this replica and "+
+			"restart it to resync from the leader; otherwise restore this replica from a valid "+
+			"snapshot with 'daos_server ms restore' (see 'daos_server ms status' for snapshots)")
+}
+
+// FaultDatabaseSnapshotsCorrupt generates a fault indicating that the system database could
+// not be recovered from any of its snapshots on startup.
+func FaultDatabaseSnapshotsCorrupt(dir, reason string) *fault.Fault {
+	return systemFault(code.SystemDatabaseSnapshotsCorrupt,
+		fmt.Sprintf("system database in %s can't be recovered from local snapshots: %s", dir, reason),
+		"if other MS replicas are healthy, remove the raft directory of this replica and "+
+			"restart it to resync from the leader; otherwise restore this replica from a "+
+			"snapshot backup with 'daos_server ms restore'")
+}
+
 func systemFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "system",
@@ -0,0 +1,129 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+)
+
+type (
+	// PoolTierUsage is a record of the capacity of a single storage tier
+	// in a pool at the time of a health snapshot.
+	PoolTierUsage struct {
+		MediaType  string `json:"media_type"`
+		TotalBytes uint64 `json:"total_bytes"`
+		FreeBytes  uint64 `json:"free_bytes"`
+	}
+
+	// PoolHealthSummary is a compact record of a pool's health and
+	// capacity at the time of a health snapshot.
+	PoolHealthSummary struct {
+		PoolUUID        uuid.UUID        `json:"uuid"`
+		PoolLabel       string           `json:"label"`
+		State           string           `json:"state"`
+		TotalTargets    uint32           `json:"total_targets"`
+		DisabledTargets uint32           `json:"disabled_targets"`
+		RebuildState    string           `json:"rebuild_state"`
+		TotalBytes      uint64           `json:"total_bytes"`
+		FreeBytes       uint64           `json:"free_bytes"`
+		QueryError      string           `json:"query_error,omitempty"`
+		Tiers           []*PoolTierUsage `json:"tiers,omitempty"`
+	}
+
+	// HealthSnapshot is a point-in-time summary of system health that
+	// is periodically recorded by the MS leader.
+	HealthSnapshot struct {
+		Timestamp time.Time `json:"timestamp"`
+		// MemberStates maps a member state name to the ranged string
+		// representation of the set of ranks in that state.
+		MemberStates map[string]string    `json:"member_states"`
+		Pools        []*PoolHealthSummary `json:"pools"`
+	}
+
+	// MSBackupStatus records the outcome of the most recent scheduled
+	// backup of the MS database taken by the MS leader.
+	MSBackupStatus struct {
+		Time        time.Time `json:"time"`
+		Path        string    `json:"path,omitempty"`
+		Index       uint64    `json:"index,omitempty"`
+		Error       string    `json:"error,omitempty"`
+		LastSuccess time.Time `json:"last_success"`
+	}
+)
+
+// NewHealthSnapshot creates a HealthSnapshot from the supplied members
+// and pool summaries. Pool summaries are sorted by UUID in order to
+// provide a stable ordering between snapshots.
+func NewHealthSnapshot(ts time.Time, members []*Member, pools []*PoolHealthSummary) *HealthSnapshot {
+	rankSets := make(map[MemberState]*ranklist.RankSet)
+	for _, m := range members {
+		if _, found := rankSets[m.State]; !found {
+			rankSets[m.State] = ranklist.NewRankSet()
+		}
+		rankSets[m.State].Add(m.Rank)
+	}
+
+	hs := &HealthSnapshot{
+		Timestamp:    ts,
+		MemberStates: make(map[string]string),
+		Pools:        pools,
+	}
+	for state, rs := range rankSets {
+		hs.MemberStates[state.String()] = rs.RangedString()
+	}
+	sort.Slice(hs.Pools, func(i, j int) bool {
+		return hs.Pools[i].PoolUUID.String() < hs.Pools[j].PoolUUID.String()
+	})
+
+	return hs
+}
+
+// FindPool returns the summary of the pool with the given UUID or label, or
+// nil if the pool was not recorded in the snapshot.
+func (hs *HealthSnapshot) FindPool(id string) *PoolHealthSummary {
+	for _, ps := range hs.Pools {
+		if ps.PoolUUID.String() == id || (ps.PoolLabel != "" && ps.PoolLabel == id) {
+			return ps
+		}
+	}
+	return nil
+}
+
+// MemberCount returns the number of ranks recorded in the given state.
+func (hs *HealthSnapshot) MemberCount(state MemberState) int {
+	rs, err := ranklist.CreateRankSet(hs.MemberStates[state.String()])
+	if err != nil {
+		return 0
+	}
+	return rs.Count()
+}
+
+// TotalBytes returns the sum of the total capacity of all pools in the snapshot.
+func (hs *HealthSnapshot) TotalBytes() (total uint64) {
+	for _, ps := range hs.Pools {
+		total += ps.TotalBytes
+	}
+	return
+}
+
+// FreeBytes returns the sum of the free capacity of all pools in the snapshot.
+func (hs *HealthSnapshot) FreeBytes() (free uint64) {
+	for _, ps := range hs.Pools {
+		free += ps.FreeBytes
+	}
+	return
+}
+
+// Failed returns true if the most recent backup failed.
+func (bs *MSBackupStatus) Failed() bool {
+	return bs != nil && bs.Error != ""
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2019-2023 Intel Corporation.
+// (C) Copyright 2019-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -49,19 +49,33 @@ const (
 	MemberStateAdminExcluded MemberState = 0x0200
 	// MemberStateCheckerStarted indicates that the rank is running in checker mode.
 	MemberStateCheckerStarted MemberState = 0x0400
+	// MemberStateUnstable indicates that the rank has been held out of the system
+	// after repeatedly flapping between dead and alive states.
+	MemberStateUnstable MemberState = 0x0800
+	// MemberStateQuarantined indicates that the rank remains in the system
+	// for existing I/O but is excluded from new pool placements.
+	MemberStateQuarantined MemberState = 0x1000
 	// MemberStateMax is the last entry indicating end of list.
-	MemberStateMax MemberState = 0x0800
+	MemberStateMax MemberState = 0x2000
 
 	// ExcludedMemberFilter defines the state(s) to be used when determining
 	// whether or not a member should be excluded from CaRT group map updates.
-	ExcludedMemberFilter = MemberStateAwaitFormat | MemberStateExcluded | MemberStateAdminExcluded
+	ExcludedMemberFilter = MemberStateAwaitFormat | MemberStateExcluded | MemberStateAdminExcluded | MemberStateUnstable
 	// AvailableMemberFilter defines the state(s) to be used when determining
-	// whether or not a member is available for the purposes of pool creation, etc.
-	AvailableMemberFilter = MemberStateReady | MemberStateJoined
+	// whether or not a member is available for the purposes of pool service, etc.
+	AvailableMemberFilter = MemberStateReady | MemberStateJoined | MemberStateQuarantined
+	// PlacementMemberFilter defines the state(s) to be used when determining
+	// whether or not a member may be selected for new pool placements, when
+	// creating or extending pools.
+	PlacementMemberFilter = MemberStateReady | MemberStateJoined
 	// AllMemberFilter will match all valid member states.
 	AllMemberFilter = MemberState(0xFFFF)
 	// NonExcludedMemberFilter matches all members that don't match the ExcludedMemberFilter.
 	NonExcludedMemberFilter = AllMemberFilter ^ ExcludedMemberFilter
+	// RunningMemberFilter defines the state(s) in which a member's engine is
+	// running, used when counting engine stops and calculating uptime.
+	RunningMemberFilter = MemberStateStarting | MemberStateReady | MemberStateJoined |
+		MemberStateStopping | MemberStateCheckerStarted | MemberStateQuarantined
 )
 
 func (ms MemberState) String() string {
@@ -88,6 +102,10 @@ func (ms MemberState) String() string {
 		return "Unresponsive"
 	case MemberStateCheckerStarted:
 		return "CheckerStarted"
+	case MemberStateUnstable:
+		return "Unstable"
+	case MemberStateQuarantined:
+		return "Quarantined"
 	default:
 		return "Unknown"
 	}
@@ -117,6 +135,10 @@ func MemberStateFromString(in string) MemberState {
 		return MemberStateUnresponsive
 	case "checkerstarted":
 		return MemberStateCheckerStarted
+	case "unstable":
+		return MemberStateUnstable
+	case "quarantined":
+		return MemberStateQuarantined
 	default:
 		return MemberStateUnknown
 	}
@@ -127,7 +149,7 @@ func MemberStateFromString(in string) MemberState {
 // Map state combinations to true (illegal) or false (legal) and return negated
 // value.
 func (ms MemberState) isTransitionIllegal(to MemberState) bool {
-	if ms == MemberStateUnknown || ms == MemberStateAdminExcluded {
+	if ms == MemberStateUnknown || ms == MemberStateAdminExcluded || ms == MemberStateUnstable {
 		return true // no legal transitions
 	}
 	if ms == to {
@@ -137,12 +159,15 @@ func (ms MemberState) isTransitionIllegal(to MemberState) bool {
 	return map[MemberState]map[MemberState]bool{
 		MemberStateAwaitFormat: {
 			MemberStateExcluded: true,
+			MemberStateUnstable: true,
 		},
 		MemberStateStarting: {
 			MemberStateExcluded: true,
+			MemberStateUnstable: true,
 		},
 		MemberStateReady: {
 			MemberStateExcluded: true,
+			MemberStateUnstable: true,
 		},
 		MemberStateJoined: {
 			MemberStateReady: true,
@@ -161,6 +186,12 @@ func (ms MemberState) isTransitionIllegal(to MemberState) bool {
 			MemberStateReady:  true,
 			MemberStateJoined: true,
 		},
+		// Quarantine is only cleared administratively or when the
+		// member leaves the system.
+		MemberStateQuarantined: {
+			MemberStateReady:  true,
+			MemberStateJoined: true,
+		},
 	}[ms][to]
 }
 
@@ -193,6 +224,7 @@ type Member struct {
 	Incarnation             uint64        `json:"incarnation"`
 	UUID                    uuid.UUID     `json:"uuid"`
 	Addr                    *net.TCPAddr  `json:"addr"`
+	HostID                  uuid.UUID     `json:"host_id"` // persistent identity of the host
 	PrimaryFabricURI        string        `json:"fabric_uri"`
 	SecondaryFabricURIs     []string      `json:"secondary_fabric_uris"`
 	PrimaryFabricContexts   uint32        `json:"fabric_contexts"`
@@ -201,6 +233,24 @@ type Member struct {
 	Info                    string        `json:"info"`
 	FaultDomain             *FaultDomain  `json:"fault_domain"`
 	LastUpdate              time.Time     `json:"last_update"`
+	// Starts is the number of engine incarnations that have joined.
+	Starts    uint32    `json:"starts,omitempty"`
+	LastStart time.Time `json:"-"`
+	// Exits, Deaths and Stops count the stops of running engines by
+	// reason: engine process exits, SWIM dead events and controlled stops.
+	Exits          uint32 `json:"exits,omitempty"`
+	Deaths         uint32 `json:"deaths,omitempty"`
+	Stops          uint32 `json:"stops,omitempty"`
+	LastStopReason string `json:"last_stop_reason,omitempty"`
+	// StateCause describes why the member entered its current state, at
+	// LastStateChange.
+	StateCause      string    `json:"state_cause,omitempty"`
+	LastStateChange time.Time `json:"-"`
+	// Quarantined is set while the member is quarantined, including while
+	// its engine is not running, so that it is quarantined again when it
+	// rejoins. QuarantineReason records why.
+	Quarantined      bool   `json:"quarantined,omitempty"`
+	QuarantineReason string `json:"quarantine_reason,omitempty"`
 }
 
 // MarshalJSON marshals system.Member to JSON.
@@ -212,16 +262,27 @@ func (sm *Member) MarshalJSON() ([]byte, error) {
 	// use a type alias to leverage the default marshal for
 	// most fields
 	type toJSON Member
+	var lastStart, lastStateChange string
+	if !sm.LastStart.IsZero() {
+		lastStart = sm.LastStart.Format(time.RFC3339Nano)
+	}
+	if !sm.LastStateChange.IsZero() {
+		lastStateChange = sm.LastStateChange.Format(time.RFC3339Nano)
+	}
 	return json.Marshal(&struct {
-		Addr        string `json:"addr"`
-		State       string `json:"state"`
-		FaultDomain string `json:"fault_domain"`
+		Addr            string `json:"addr"`
+		State           string `json:"state"`
+		FaultDomain     string `json:"fault_domain"`
+		LastStart       string `json:"last_start,omitempty"`
+		LastStateChange string `json:"last_state_change,omitempty"`
 		*toJSON
 	}{
-		Addr:        sm.Addr.String(),
-		State:       strings.ToLower(sm.State.String()),
-		FaultDomain: sm.FaultDomain.String(),
-		toJSON:      (*toJSON)(sm),
+		Addr:            sm.Addr.String(),
+		State:           strings.ToLower(sm.State.String()),
+		FaultDomain:     sm.FaultDomain.String(),
+		LastStart:       lastStart,
+		LastStateChange: lastStateChange,
+		toJSON:          (*toJSON)(sm),
 	})
 }
 
@@ -235,9 +296,11 @@ func (sm *Member) UnmarshalJSON(data []byte) error {
 	// most fields
 	type fromJSON Member
 	from := &struct {
-		Addr        string `json:"addr"`
-		State       string `json:"state"`
-		FaultDomain string `json:"fault_domain"`
+		Addr            string `json:"addr"`
+		State           string `json:"state"`
+		FaultDomain     string `json:"fault_domain"`
+		LastStart       string `json:"last_start"`
+		LastStateChange string `json:"last_state_change"`
 		*fromJSON
 	}{
 		fromJSON: (*fromJSON)(sm),
@@ -261,6 +324,17 @@ func (sm *Member) UnmarshalJSON(data []byte) error {
 	}
 	sm.FaultDomain = fd
 
+	if from.LastStart != "" {
+		if sm.LastStart, err = time.Parse(time.RFC3339Nano, from.LastStart); err != nil {
+			return errors.Wrap(err, "invalid last_start")
+		}
+	}
+	if from.LastStateChange != "" {
+		if sm.LastStateChange, err = time.Parse(time.RFC3339Nano, from.LastStateChange); err != nil {
+			return errors.Wrap(err, "invalid last_state_change")
+		}
+	}
+
 	return nil
 }
 
@@ -285,6 +359,47 @@ func (sm *Member) FabricURIs() []string {
 	return append([]string{sm.PrimaryFabricURI}, sm.SecondaryFabricURIs...)
 }
 
+// Uptime returns the time since the member's current engine incarnation
+// joined, or zero if the engine is not running.
+func (sm *Member) Uptime(now time.Time) time.Duration {
+	if sm.State&RunningMemberFilter == 0 || sm.LastStart.IsZero() || now.Before(sm.LastStart) {
+		return 0
+	}
+	return now.Sub(sm.LastStart)
+}
+
+// SetState sets the member state and, if the state changes, records the time
+// of the change and the cause given.
+func (sm *Member) SetState(state MemberState, cause string) {
+	if state != sm.State {
+		sm.recordStateChange(time.Now(), cause)
+	}
+	sm.State = state
+}
+
+// recordStateChange records the time and cause of a change of state.
+func (sm *Member) recordStateChange(now time.Time, cause string) {
+	sm.LastStateChange = now
+	sm.StateCause = cause
+}
+
+// recordStart records the join of a new engine incarnation.
+func (sm *Member) recordStart(now time.Time) {
+	sm.Starts++
+	sm.LastStart = now
+}
+
+// recordStop records the reason that a running engine stopped. Nothing is
+// recorded if the member was not running, so that a stop reported by more
+// than one source is only counted once.
+func (sm *Member) recordStop(counter *uint32, reason string) {
+	if sm.State&RunningMemberFilter == 0 {
+		return
+	}
+	*counter++
+	sm.LastStopReason = reason
+}
+
 // NewMember returns a reference to a new member struct.
 func NewMember(rank ranklist.Rank, uuidStr string, uris []string, addr *net.TCPAddr, state MemberState) *Member {
 	// FIXME: Either require a valid uuid.UUID to be supplied
@@ -321,6 +436,7 @@ type MemberResult struct {
 	Errored bool
 	Msg     string
 	State   MemberState `json:"state"`
+	UUID    string      `json:"uuid,omitempty"`
 }
 
 // MarshalJSON marshals system.MemberResult to JSON.
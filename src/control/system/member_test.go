@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -8,6 +8,7 @@ package system_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -74,6 +75,25 @@ func TestSystem_Member_MarshalUnmarshalJSON(t *testing.T) {
 			member: MockMember(t, 3, MemberStateStopped, "info").
 				WithFaultDomain(MustCreateFaultDomainFromString("/test/fault/domain")),
 		},
+		"with lifecycle counters": {
+			member: func() *Member {
+				m := MockMember(t, 4, MemberStateJoined)
+				m.Starts = 3
+				m.LastStart = time.Now()
+				m.Exits = 1
+				m.Deaths = 1
+				m.LastStopReason = "marked dead"
+				return m
+			}(),
+		},
+		"with state change": {
+			member: func() *Member {
+				m := MockMember(t, 5, MemberStateExcluded)
+				m.StateCause = "marked dead"
+				m.LastStateChange = time.Now()
+				return m
+			}(),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			marshaled, err := tc.member.MarshalJSON()
@@ -96,8 +116,44 @@ func TestSystem_Member_MarshalUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestSystem_Member_Uptime(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		state     MemberState
+		lastStart time.Time
+		expUptime time.Duration
+	}{
+		"joined": {
+			state:     MemberStateJoined,
+			lastStart: now.Add(-time.Hour),
+			expUptime: time.Hour,
+		},
+		"never started": {
+			state: MemberStateJoined,
+		},
+		"stopped": {
+			state:     MemberStateStopped,
+			lastStart: now.Add(-time.Hour),
+		},
+		"start in future": {
+			state:     MemberStateJoined,
+			lastStart: now.Add(time.Minute),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := MockMember(t, 1, tc.state)
+			m.LastStart = tc.lastStart
+
+			test.AssertEqual(t, tc.expUptime, m.Uptime(now), "unexpected uptime")
+		})
+	}
+}
+
 func TestSystem_Member_Convert(t *testing.T) {
 	membersIn := Members{MockMember(t, 1, MemberStateJoined)}
+	membersIn[0].StateCause = "joined"
+	membersIn[0].LastStateChange = time.Now()
 	membersOut := Members{}
 	if err := convert.Types(membersIn, &membersOut); err != nil {
 		t.Fatal(err)
@@ -106,6 +162,9 @@ func TestSystem_Member_Convert(t *testing.T) {
 	if diff := cmp.Diff(membersIn, membersOut, memberCmpOpts...); diff != "" {
 		t.Fatalf("unexpected members: (-want, +got)\n%s\n", diff)
 	}
+	test.AssertEqual(t, membersIn[0].StateCause, membersOut[0].StateCause, "unexpected state cause")
+	test.AssertTrue(t, membersIn[0].LastStateChange.Equal(membersOut[0].LastStateChange),
+		"unexpected last state change")
 }
 
 func TestSystem_MemberResult_Convert(t *testing.T) {
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -41,12 +42,30 @@ type MemberStore interface {
 	FaultDomainTree() *FaultDomainTree
 }
 
+// FlapDamping defines the parameters used to detect members that repeatedly
+// transition between dead and alive states. A member that is marked dead
+// Threshold times within Window is held in the Unstable state or, if
+// Quarantine is set, quarantined when it next joins.
+type FlapDamping struct {
+	Threshold  int           `yaml:"threshold"`
+	Window     time.Duration `yaml:"window"`
+	Quarantine bool          `yaml:"quarantine,omitempty"`
+}
+
+// Enabled returns true if flap damping is configured.
+func (fd *FlapDamping) Enabled() bool {
+	return fd != nil && fd.Threshold > 0 && fd.Window > 0
+}
+
 // Membership tracks details of system members.
 type Membership struct {
 	sync.RWMutex
-	log        logging.Logger
-	db         MemberStore
-	resolveTCP TCPResolver
+	log         logging.Logger
+	db          MemberStore
+	resolveTCP  TCPResolver
+	publisher   events.Publisher
+	flapDamping *FlapDamping
+	rankDeaths  map[Rank][]time.Time
 }
 
 // NewMembership returns a reference to a new DAOS system membership.
@@ -55,9 +74,25 @@ func NewMembership(log logging.Logger, mdb MemberStore) *Membership {
 		db:         mdb,
 		log:        log,
 		resolveTCP: net.ResolveTCPAddr,
+		rankDeaths: make(map[Rank][]time.Time),
 	}
 }
 
+// WithFlapDamping sets the parameters used to hold repeatedly failing
+// members in the Unstable state.
+func (m *Membership) WithFlapDamping(fd *FlapDamping) *Membership {
+	m.flapDamping = fd
+
+	return m
+}
+
+// WithEventPublisher sets the publisher used to raise membership events.
+func (m *Membership) WithEventPublisher(publisher events.Publisher) *Membership {
+	m.publisher = publisher
+
+	return m
+}
+
 // WithTCPResolver adds a resolveTCPFn to the membership structure.
 func (m *Membership) WithTCPResolver(resolver TCPResolver) *Membership {
 	m.resolveTCP = resolver
@@ -98,6 +133,7 @@ type JoinRequest struct {
 	Rank                    Rank
 	UUID                    uuid.UUID
 	ControlAddr             *net.TCPAddr
+	HostID                  uuid.UUID
 	PrimaryFabricURI        string
 	SecondaryFabricURIs     []string
 	FabricContexts          uint32
@@ -146,6 +182,9 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		if curMember.State == MemberStateAdminExcluded {
 			return nil, ErrAdminExcluded(curMember.UUID, curMember.Rank)
 		}
+		if curMember.State == MemberStateUnstable {
+			return nil, ErrMemberUnstable(curMember.UUID, curMember.Rank)
+		}
 		// If the member is already in the membership, don't allow rejoining
 		// with a different rank, as this may indicate something strange
 		// has happened on the node. The only exception is if the rejoin
@@ -157,6 +196,14 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		if curMember.UUID != req.UUID {
 			return nil, ErrUuidChanged(req.UUID, curMember.UUID, curMember.Rank)
 		}
+		// The member is identified by the host ID of its server rather
+		// than by its address, which may change. A rejoin from another
+		// host indicates that the engine storage has been moved or
+		// cloned. Servers without a host ID (e.g. older versions) are
+		// matched on rank and UUID alone, as before.
+		if req.HostID != uuid.Nil && curMember.HostID != uuid.Nil && curMember.HostID != req.HostID {
+			return nil, ErrHostIDChanged(req.HostID, curMember.HostID, curMember.Rank)
+		}
 
 		if !curMember.FaultDomain.Equals(req.FaultDomain) {
 			m.log.Infof("fault domain for rank %d changed from %q to %q",
@@ -165,19 +212,43 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 				req.FaultDomain.String())
 		}
 
+		// The host ID stays the same across address changes, so a
+		// changed address is only worth a notice.
+		if curMember.Addr != nil && req.ControlAddr != nil && curMember.Addr.String() != req.ControlAddr.String() {
+			m.log.Noticef("control address for rank %d changed from %s to %s", curMember.Rank,
+				curMember.Addr, req.ControlAddr)
+		}
+
 		resp.PrevState = curMember.State
-		if req.CheckMode {
-			curMember.State = MemberStateCheckerStarted
-		} else {
-			curMember.State = MemberStateJoined
+		info := ""
+		switch {
+		case req.CheckMode:
+			curMember.SetState(MemberStateCheckerStarted, "rejoined in checker mode")
+		case curMember.Quarantined:
+			if curMember.State != MemberStateQuarantined {
+				m.log.Noticef("quarantining rank %d on rejoin: %s", curMember.Rank,
+					curMember.QuarantineReason)
+				curMember.SetState(MemberStateQuarantined, "rejoined while quarantined")
+			}
+			info = curMember.QuarantineReason
+		default:
+			curMember.SetState(MemberStateJoined, "rejoined")
 		}
-		curMember.Info = ""
+		curMember.Info = info
 		curMember.Addr = req.ControlAddr
+		if req.HostID != uuid.Nil {
+			curMember.HostID = req.HostID
+		}
 		curMember.PrimaryFabricURI = req.PrimaryFabricURI
 		curMember.SecondaryFabricURIs = req.SecondaryFabricURIs
 		curMember.PrimaryFabricContexts = req.FabricContexts
 		curMember.SecondaryFabricContexts = req.SecondaryFabricContexts
 		curMember.FaultDomain = req.FaultDomain
+		if curMember.Incarnation != req.Incarnation {
+			now := time.Now()
+			curMember.recordStart(now)
+			curMember.recordStateChange(now, "joined with new incarnation")
+		}
 		curMember.Incarnation = req.Incarnation
 		if err := m.db.UpdateMember(curMember); err != nil {
 			return nil, err
@@ -205,6 +276,7 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		Incarnation:             req.Incarnation,
 		UUID:                    req.UUID,
 		Addr:                    req.ControlAddr,
+		HostID:                  req.HostID,
 		PrimaryFabricURI:        req.PrimaryFabricURI,
 		SecondaryFabricURIs:     req.SecondaryFabricURIs,
 		PrimaryFabricContexts:   req.FabricContexts,
@@ -212,6 +284,9 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		FaultDomain:             req.FaultDomain,
 		State:                   MemberStateJoined,
 	}
+	now := time.Now()
+	newMember.recordStart(now)
+	newMember.recordStateChange(now, "joined")
 	if err := m.db.AddMember(newMember); err != nil {
 		return nil, errors.Wrap(err, "failed to add new member")
 	}
@@ -429,7 +504,14 @@ func (m *Membership) UpdateMemberStates(results MemberResults, updateOnFail bool
 			m.log.Debugf("skipping %s", msgBadStateTransition(member, result.State))
 			continue
 		}
-		member.State = result.State
+		if result.State == MemberStateStopped {
+			member.recordStop(&member.Stops, "stopped")
+		}
+		cause := result.Action
+		if result.Errored {
+			cause = fmt.Sprintf("%s failed", result.Action)
+		}
+		member.SetState(result.State, cause)
 		member.Info = result.Msg
 
 		if err := m.db.UpdateMember(member); err != nil {
@@ -544,14 +626,121 @@ func (m *Membership) MarkRankDead(rank Rank, incarnation uint64) error {
 		return errors.New(msg)
 	}
 
-	if member.State == MemberStateJoined && member.Incarnation > incarnation {
+	if member.State&(MemberStateJoined|MemberStateQuarantined) != 0 && member.Incarnation > incarnation {
 		m.log.Debugf("ignoring rank dead event for previous incarnation of %d (%x < %x)", rank, incarnation, member.Incarnation)
 		return errors.Errorf("event is for previous incarnation of %d", rank)
 	}
 
+	cause := "marked dead"
+	deaths := m.recordRankDeath(rank)
+	if m.flapDamping.Enabled() && deaths >= m.flapDamping.Threshold {
+		info := fmt.Sprintf("marked dead %d times within %s", deaths, m.flapDamping.Window)
+		if m.flapDamping.Quarantine {
+			// Quarantine the member when it next joins.
+			if !member.Quarantined {
+				member.Quarantined = true
+				member.QuarantineReason = info
+			}
+		} else {
+			ns = MemberStateUnstable
+			member.Info = info
+			cause = info
+		}
+		// Start counting afresh once the member has been cleared.
+		delete(m.rankDeaths, rank)
+	}
+
 	m.log.Infof("marking rank %d as %s in response to rank dead event", rank, ns)
-	member.State = ns
-	return m.db.UpdateMember(member)
+	member.recordStop(&member.Deaths, "marked dead")
+	member.SetState(ns, cause)
+	if err := m.db.UpdateMember(member); err != nil {
+		return err
+	}
+
+	if ns == MemberStateUnstable && m.publisher != nil {
+		m.publisher.Publish(events.NewMemberUnstableEvent(rank.Uint32(), incarnation,
+			deaths, m.flapDamping.Window))
+	}
+
+	return nil
+}
+
+// Quarantine sets the state of the member with the given rank to Quarantined,
+// recording the reason in the member's info. Quarantined members remain in the
+// system for existing I/O but are excluded from new pool placements. Only
+// members that are available for new placements can be quarantined.
+func (m *Membership) Quarantine(rank Rank, reason string) (*Member, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.State&PlacementMemberFilter == 0 {
+		return nil, errors.Errorf("rank %d in state %s can't be quarantined", rank, member.State)
+	}
+
+	member.SetState(MemberStateQuarantined, "set quarantined state")
+	member.Info = reason
+	member.Quarantined = true
+	member.QuarantineReason = reason
+	if err := m.db.UpdateMember(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ClearQuarantine returns the member with the given rank from the Quarantined
+// state to the Joined state. A member that is quarantined but not running is
+// no longer quarantined when it next joins.
+func (m *Membership) ClearQuarantine(rank Rank) (*Member, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.Quarantined && member.State != MemberStateQuarantined {
+		return nil, errors.Errorf("rank %d is not quarantined", rank)
+	}
+
+	member.Quarantined = false
+	member.QuarantineReason = ""
+	if member.State == MemberStateQuarantined {
+		member.SetState(MemberStateJoined, "clear quarantined state")
+		member.Info = ""
+	}
+	if err := m.db.UpdateMember(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// recordRankDeath records a dead event for the rank and returns the number of
+// dead events recorded for the rank within the flap damping window.
+func (m *Membership) recordRankDeath(rank Rank) int {
+	if !m.flapDamping.Enabled() {
+		return 0
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-m.flapDamping.Window)
+
+	recent := []time.Time{}
+	for _, ts := range m.rankDeaths[rank] {
+		if ts.After(cutoff) {
+			recent = append(recent, ts)
+		}
+	}
+	m.rankDeaths[rank] = append(recent, now)
+
+	return len(m.rankDeaths[rank])
 }
 
 func (m *Membership) handleEngineFailure(evt *events.RASEvent) {
@@ -583,8 +772,14 @@ func (m *Membership) handleEngineFailure(evt *events.RASEvent) {
 		return
 	}
 
+	reason := "exited"
+	if evt.Msg != "" {
+		reason = evt.Msg
+	}
+	member.recordStop(&member.Exits, reason)
+
 	oldState := member.State
-	member.State = newState
+	member.SetState(newState, reason)
 	member.Info = evt.Msg
 
 	if err := m.db.UpdateMember(member); err != nil {
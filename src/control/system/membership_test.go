@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,6 +33,8 @@ var (
 	memberCmpOpts = []cmp.Option{
 		cmpopts.IgnoreUnexported(Member{}),
 		cmpopts.EquateApproxTime(time.Second),
+		// state change causes are verified by TestSystem_Membership_StateCause
+		cmpopts.IgnoreFields(Member{}, "StateCause", "LastStateChange"),
 	}
 )
 
@@ -608,6 +611,13 @@ func TestSystem_Membership_UpdateMemberStates(t *testing.T) {
 	expMrDiffAddr1.Addr = "192.168.1.1:10001"
 	expMrDiffAddr2 := mrDiffAddr2
 
+	// joined members that are stopped have the stop recorded
+	withStop := func(m *Member) *Member {
+		m.Stops = 1
+		m.LastStopReason = "stopped"
+		return m
+	}
+
 	for name, tc := range map[string]struct {
 		ignoreErrs bool
 		members    Members
@@ -652,12 +662,12 @@ func TestSystem_Membership_UpdateMemberStates(t *testing.T) {
 				&MemberResult{Rank: 6, Msg: "exit 1", State: MemberStateStopped},
 			},
 			expMembers: Members{
-				MockMember(t, 1, MemberStateStopped),
+				withStop(MockMember(t, 1, MemberStateStopped)),
 				MockMember(t, 2, MemberStateStopped), // errored results don't change member state
 				MockMember(t, 3, MemberStateExcluded),
 				MockMember(t, 4, MemberStateReady),
 				MockMember(t, 5, MemberStateJoined), // "Joined" will not be updated to "Ready"
-				MockMember(t, 6, MemberStateStopped, "exit 1"),
+				withStop(MockMember(t, 6, MemberStateStopped, "exit 1")),
 			},
 		},
 		"don't ignore errored results": {
@@ -677,7 +687,7 @@ func TestSystem_Membership_UpdateMemberStates(t *testing.T) {
 				&MemberResult{Rank: 6, Msg: "exit 1", State: MemberStateStopped},
 			},
 			expMembers: Members{
-				MockMember(t, 1, MemberStateStopped),
+				withStop(MockMember(t, 1, MemberStateStopped)),
 				MockMember(t, 2, MemberStateErrored, "can't stop"),
 				MockMember(t, 3, MemberStateExcluded),
 				MockMember(t, 4, MemberStateReady),
@@ -751,7 +761,21 @@ func TestSystem_Membership_Join(t *testing.T) {
 	curMember := defaultCurMembers[0]
 	newUUID := uuid.New()
 	newMember := MockMember(t, 2, MemberStateJoined).WithFaultDomain(fd2)
+	newMember.Starts = 1
+	newMember.LastStart = time.Now()
+	restartedMember := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+	restartedMember.Incarnation = 2
+	restartedMember.Starts = 1
+	restartedMember.LastStart = time.Now()
 	newMemberShallowFD := MockMember(t, 3, MemberStateJoined).WithFaultDomain(shallowFD)
+	hostID := uuid.New()
+	movedAddr := MockControlAddr(t, 42)
+	movedMember := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+	movedMember.Addr = movedAddr
+	movedMember.HostID = hostID
+	hostedMember := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+	hostedMember.HostID = hostID
+	otherHostID := uuid.New()
 
 	expMapVer := uint32(len(defaultCurMembers) + 1)
 
@@ -784,6 +808,21 @@ func TestSystem_Membership_Join(t *testing.T) {
 				MapVersion: expMapVer,
 			},
 		},
+		"successful rejoin with new incarnation": {
+			req: &JoinRequest{
+				Rank:             curMember.Rank,
+				UUID:             curMember.UUID,
+				Incarnation:      2,
+				ControlAddr:      curMember.Addr,
+				PrimaryFabricURI: curMember.Addr.String(),
+				FaultDomain:      curMember.FaultDomain,
+			},
+			expResp: &JoinResponse{
+				Member:     restartedMember,
+				PrevState:  curMember.State,
+				MapVersion: expMapVer,
+			},
+		},
 		"successful rejoin with different fault domain": {
 			req: &JoinRequest{
 				Rank:             curMember.Rank,
@@ -798,6 +837,33 @@ func TestSystem_Membership_Join(t *testing.T) {
 				MapVersion: expMapVer,
 			},
 		},
+		"successful rejoin with new address": {
+			req: &JoinRequest{
+				Rank:             curMember.Rank,
+				UUID:             curMember.UUID,
+				ControlAddr:      movedAddr,
+				HostID:           hostID,
+				PrimaryFabricURI: curMember.Addr.String(),
+				FaultDomain:      curMember.FaultDomain,
+			},
+			expResp: &JoinResponse{
+				Member:     movedMember,
+				PrevState:  curMember.State,
+				MapVersion: expMapVer,
+			},
+		},
+		"rejoin from another host": {
+			curMembers: []*Member{hostedMember},
+			req: &JoinRequest{
+				Rank:             curMember.Rank,
+				UUID:             curMember.UUID,
+				ControlAddr:      curMember.Addr,
+				HostID:           otherHostID,
+				PrimaryFabricURI: curMember.Addr.String(),
+				FaultDomain:      curMember.FaultDomain,
+			},
+			expErr: ErrHostIDChanged(otherHostID, hostID, curMember.Rank),
+		},
 		"rejoin with existing UUID and unknown rank": {
 			req: &JoinRequest{
 				Rank:             Rank(42),
@@ -950,9 +1016,14 @@ func TestSystem_Membership_OnEvent(t *testing.T) {
 			event:   mockEvtEngineDied(t, 1),
 			expMembers: Members{
 				MockMember(t, 0, MemberStateJoined),
-				MockMember(t, 1, MemberStateErrored).WithInfo(
-					errors.Wrap(common.NormalExit,
-						"DAOS engine 0 exited unexpectedly").Error()),
+				func() *Member {
+					msg := errors.Wrap(common.NormalExit,
+						"DAOS engine 0 exited unexpectedly").Error()
+					m := MockMember(t, 1, MemberStateErrored).WithInfo(msg)
+					m.Exits = 1
+					m.LastStopReason = msg
+					return m
+				}(),
 				MockMember(t, 2, MemberStateStopped),
 				MockMember(t, 3, MemberStateExcluded),
 			},
@@ -992,6 +1063,7 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 	for name, tc := range map[string]struct {
 		rank        Rank
 		incarnation uint64
+		expDeaths   uint32
 		expErr      error
 	}{
 		"unknown member": {
@@ -1010,6 +1082,7 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 		"new event for joined member": {
 			rank:        0,
 			incarnation: 2,
+			expDeaths:   1,
 		},
 		"event for stopped member": {
 			rank:        1,
@@ -1034,10 +1107,304 @@ func TestSystem_Membership_MarkDead(t *testing.T) {
 
 			gotErr := ms.MarkRankDead(tc.rank, tc.incarnation)
 			CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			m, err := ms.Get(tc.rank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertEqual(t, tc.expDeaths, m.Deaths, "unexpected death count")
 		})
 	}
 }
 
+func TestSystem_Membership_MarkDead_FlapDamping(t *testing.T) {
+	for name, tc := range map[string]struct {
+		flapDamping *FlapDamping
+		deaths      int
+		interval    time.Duration
+		expState    MemberState
+		expEvent    bool
+	}{
+		"damping disabled": {
+			deaths:   5,
+			expState: MemberStateExcluded,
+		},
+		"below threshold": {
+			flapDamping: &FlapDamping{Threshold: 3, Window: time.Hour},
+			deaths:      2,
+			expState:    MemberStateExcluded,
+		},
+		"threshold reached": {
+			flapDamping: &FlapDamping{Threshold: 3, Window: time.Hour},
+			deaths:      3,
+			expState:    MemberStateUnstable,
+			expEvent:    true,
+		},
+		"deaths outside window": {
+			flapDamping: &FlapDamping{Threshold: 2, Window: 10 * time.Millisecond},
+			deaths:      3,
+			interval:    20 * time.Millisecond,
+			expState:    MemberStateExcluded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer ShowBufferOnFailure(t, buf)
+
+			ps := events.NewPubSub(test.Context(t), log)
+			defer ps.Close()
+
+			var gotEvents []*events.RASEvent
+			var evtMu sync.Mutex
+			ps.Subscribe(events.RASTypeInfoOnly, events.HandlerFunc(func(_ context.Context, evt *events.RASEvent) {
+				evtMu.Lock()
+				defer evtMu.Unlock()
+				gotEvents = append(gotEvents, evt)
+			}))
+
+			member := MockMember(t, 0, MemberStateJoined)
+			ms := populateMembership(t, log, member).
+				WithFlapDamping(tc.flapDamping).
+				WithEventPublisher(ps)
+
+			for i := 0; i < tc.deaths; i++ {
+				if i > 0 {
+					time.Sleep(tc.interval)
+				}
+
+				cur, err := ms.Get(member.Rank)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if cur.State == MemberStateUnstable {
+					t.Fatalf("member unstable after %d deaths", i)
+				}
+
+				// Simulate a successful rejoin between dead events.
+				if _, err := ms.Join(&JoinRequest{
+					Rank:             member.Rank,
+					UUID:             member.UUID,
+					ControlAddr:      member.Addr,
+					PrimaryFabricURI: member.PrimaryFabricURI,
+					FaultDomain:      member.FaultDomain,
+					Incarnation:      uint64(i + 1),
+				}); err != nil {
+					t.Fatal(err)
+				}
+				if err := ms.MarkRankDead(member.Rank, uint64(i+1)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cur, err := ms.Get(member.Rank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertEqual(t, tc.expState, cur.State, "unexpected member state")
+
+			// Allow time for any published events to be delivered.
+			time.Sleep(50 * time.Millisecond)
+			evtMu.Lock()
+			defer evtMu.Unlock()
+			AssertEqual(t, tc.expEvent, len(gotEvents) == 1, "unexpected unstable event")
+			if !tc.expEvent {
+				return
+			}
+			AssertEqual(t, events.RASSystemMemberUnstable, gotEvents[0].ID, "unexpected event ID")
+
+			// An unstable member must be cleared before rejoining.
+			_, err = ms.Join(&JoinRequest{
+				Rank:             member.Rank,
+				UUID:             member.UUID,
+				ControlAddr:      member.Addr,
+				PrimaryFabricURI: member.PrimaryFabricURI,
+				FaultDomain:      member.FaultDomain,
+			})
+			CmpErr(t, ErrMemberUnstable(member.UUID, member.Rank), err)
+		})
+	}
+}
+
+func TestSystem_Membership_Quarantine(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer ShowBufferOnFailure(t, buf)
+
+	joined := MockMember(t, 0, MemberStateJoined)
+	stopped := MockMember(t, 1, MemberStateStopped)
+	db := raft.MockDatabase(t, log)
+	newMembership := func() *Membership {
+		return MockMembership(t, log, db, mockResolveFn).
+			WithFlapDamping(&FlapDamping{Threshold: 1, Window: time.Hour, Quarantine: true})
+	}
+	ms := newMembership()
+	for _, m := range []*Member{joined, stopped} {
+		if _, err := ms.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rejoin := func(m *Member, incarnation uint64) *Member {
+		t.Helper()
+		resp, err := ms.Join(&JoinRequest{
+			Rank:             m.Rank,
+			UUID:             m.UUID,
+			ControlAddr:      m.Addr,
+			PrimaryFabricURI: m.PrimaryFabricURI,
+			FaultDomain:      m.FaultDomain,
+			Incarnation:      incarnation,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Member
+	}
+
+	if _, err := ms.Quarantine(stopped.Rank, "suspect"); err == nil {
+		t.Fatal("expected stopped member not to be quarantined")
+	}
+	if _, err := ms.ClearQuarantine(joined.Rank); err == nil {
+		t.Fatal("expected error clearing member that is not quarantined")
+	}
+
+	m, err := ms.Quarantine(joined.Rank, "suspect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertEqual(t, MemberStateQuarantined, m.State, "unexpected state")
+	AssertEqual(t, "suspect", m.Info, "unexpected info")
+
+	placement, err := ms.Members(nil, PlacementMemberFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertEqual(t, 0, len(placement), "quarantined member available for placement")
+
+	// Quarantine persists across a rejoin, including after being marked dead.
+	m = rejoin(joined, 1)
+	AssertEqual(t, MemberStateQuarantined, m.State, "quarantine lost on rejoin")
+	if err := ms.MarkRankDead(joined.Rank, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The quarantine is recorded in the database, so it also persists
+	// across a change of MS leader while the member is not running.
+	ms = newMembership()
+	m = rejoin(joined, 2)
+	AssertEqual(t, MemberStateQuarantined, m.State, "quarantine lost on rejoin after death")
+	AssertEqual(t, "suspect", m.Info, "unexpected info")
+
+	m, err = ms.ClearQuarantine(joined.Rank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertEqual(t, MemberStateJoined, m.State, "unexpected state")
+	AssertEqual(t, "", m.Info, "unexpected info")
+
+	// Flap damping quarantines the member when it next joins.
+	if err := ms.MarkRankDead(joined.Rank, 2); err != nil {
+		t.Fatal(err)
+	}
+	m, err = ms.Get(joined.Rank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertEqual(t, MemberStateExcluded, m.State, "unexpected state")
+	m = rejoin(joined, 3)
+	AssertEqual(t, MemberStateQuarantined, m.State, "flapping member not quarantined on rejoin")
+	AssertEqual(t, "marked dead 1 times within 1h0m0s", m.Info, "unexpected info")
+}
+
+func TestSystem_Membership_StateCause(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer ShowBufferOnFailure(t, buf)
+
+	member := MockMember(t, 0, MemberStateStopped)
+	ms := populateMembership(t, log, member)
+
+	joinReq := func(incarnation uint64) *JoinRequest {
+		return &JoinRequest{
+			Rank:             member.Rank,
+			UUID:             member.UUID,
+			ControlAddr:      member.Addr,
+			PrimaryFabricURI: member.Addr.String(),
+			FaultDomain:      member.FaultDomain,
+			Incarnation:      incarnation,
+		}
+	}
+
+	var lastChange time.Time
+	for _, step := range []struct {
+		desc     string
+		update   func() error
+		expState MemberState
+		expCause string
+	}{
+		{
+			desc: "rejoin with same incarnation",
+			update: func() error {
+				_, err := ms.Join(joinReq(member.Incarnation))
+				return err
+			},
+			expState: MemberStateJoined,
+			expCause: "rejoined",
+		},
+		{
+			desc: "marked dead",
+			update: func() error {
+				return ms.MarkRankDead(member.Rank, member.Incarnation)
+			},
+			expState: MemberStateExcluded,
+			expCause: "marked dead",
+		},
+		{
+			desc: "join with new incarnation",
+			update: func() error {
+				_, err := ms.Join(joinReq(member.Incarnation + 1))
+				return err
+			},
+			expState: MemberStateJoined,
+			expCause: "joined with new incarnation",
+		},
+		{
+			desc: "unchanged state keeps cause",
+			update: func() error {
+				return ms.UpdateMemberStates(MemberResults{
+					MockMemberResult(member.Rank, "ping", nil, MemberStateJoined),
+				}, true)
+			},
+			expState: MemberStateJoined,
+			expCause: "joined with new incarnation",
+		},
+		{
+			desc: "stopped",
+			update: func() error {
+				return ms.UpdateMemberStates(MemberResults{
+					MockMemberResult(member.Rank, "stop", nil, MemberStateStopped),
+				}, true)
+			},
+			expState: MemberStateStopped,
+			expCause: "stop",
+		},
+	} {
+		if err := step.update(); err != nil {
+			t.Fatalf("%s: %s", step.desc, err)
+		}
+
+		m, err := ms.Get(member.Rank)
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertEqual(t, step.expState, m.State, step.desc)
+		AssertEqual(t, step.expCause, m.StateCause, step.desc)
+		AssertTrue(t, !m.LastStateChange.IsZero(), step.desc+": expected change time")
+		AssertTrue(t, !m.LastStateChange.Before(lastChange), step.desc+": change time went backwards")
+		lastChange = m.LastStateChange
+	}
+}
+
 func TestSystem_Membership_CompressedFaultDomainTree(t *testing.T) {
 	testMemberWithFaultDomain := func(rank Rank, faultDomain *FaultDomain) *Member {
 		return &Member{
@@ -39,9 +39,11 @@ type (
 	PoolService struct {
 		PoolUUID   uuid.UUID
 		PoolLabel  string
+		Owner      string // owner principal (user@[domain]) set at creation
 		State      PoolServiceState
 		Replicas   []ranklist.Rank
 		Storage    *PoolServiceStorage
+		CreatedAt  time.Time
 		LastUpdate time.Time
 	}
 )
@@ -0,0 +1,79 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+)
+
+// PoolActivityKind identifies the type of operation or event recorded in a
+// pool's activity log.
+type PoolActivityKind string
+
+// PoolActivityKind values.
+const (
+	PoolActivityCreate      PoolActivityKind = "create"
+	PoolActivityExtend      PoolActivityKind = "extend"
+	PoolActivityExclude     PoolActivityKind = "exclude"
+	PoolActivityDrain       PoolActivityKind = "drain"
+	PoolActivityReintegrate PoolActivityKind = "reintegrate"
+	PoolActivityRebuild     PoolActivityKind = "rebuild"
+	PoolActivitySetProp     PoolActivityKind = "set-prop"
+	PoolActivityUpgrade     PoolActivityKind = "upgrade"
+)
+
+// PoolActivity is a record of an operation or event affecting a single pool,
+// kept in the MS database so that the history of a pool can be reviewed
+// without searching the system-wide event logs.
+type PoolActivity struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Kind      PoolActivityKind `json:"kind"`
+	Msg       string           `json:"msg"`
+}
+
+// NewPoolActivity creates a PoolActivity record with the current time.
+func NewPoolActivity(kind PoolActivityKind, format string, args ...interface{}) *PoolActivity {
+	return &PoolActivity{
+		Timestamp: time.Now(),
+		Kind:      kind,
+		Msg:       fmt.Sprintf(format, args...),
+	}
+}
+
+// PoolActivityFromEvent returns a PoolActivity record for a RAS event raised
+// on behalf of a pool, or nil if the event is not recorded in the activity log.
+func PoolActivityFromEvent(evt *events.RASEvent) *PoolActivity {
+	if evt == nil || evt.PoolUUID == "" {
+		return nil
+	}
+
+	switch evt.ID {
+	case events.RASPoolRebuildStart, events.RASPoolRebuildEnd, events.RASPoolRebuildFailed:
+	default:
+		return nil
+	}
+
+	ts, err := evt.GetTimestamp()
+	if err != nil {
+		ts = time.Now()
+	}
+
+	msg := strings.TrimSuffix(evt.Msg, ".")
+	if si := evt.GetStrInfo(); si != nil && *si != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, *si)
+	}
+
+	return &PoolActivity{
+		Timestamp: ts,
+		Kind:      PoolActivityRebuild,
+		Msg:       fmt.Sprintf("rank %d: %s", evt.Rank, msg),
+	}
+}
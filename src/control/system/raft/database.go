@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2023 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -41,16 +41,19 @@ type (
 	onLeadershipGainedFn func(context.Context) error
 	onLeadershipLostFn   func() error
 	onRaftShutdownFn     func() error
+	onEventsEvictedFn    func([]*system.PersistentEvent)
 
 	raftService interface {
 		Apply([]byte, time.Duration) raft.ApplyFuture
 		AddVoter(raft.ServerID, raft.ServerAddress, uint64, time.Duration) raft.IndexFuture
 		RemoveServer(raft.ServerID, uint64, time.Duration) raft.IndexFuture
 		BootstrapCluster(raft.Configuration) raft.Future
+		GetConfiguration() raft.ConfigurationFuture
 		Leader() raft.ServerAddress
 		LeaderCh() <-chan bool
 		LeadershipTransfer() raft.Future
 		Barrier(time.Duration) raft.Future
+		Snapshot() raft.SnapshotFuture
 		Shutdown() raft.Future
 		State() raft.RaftState
 	}
@@ -68,7 +71,8 @@ type (
 	// all participating replicas.
 	dbData struct {
 		sync.RWMutex
-		log logging.Logger
+		log      logging.Logger
+		groupMap groupMapCache
 
 		Version       uint64
 		NextRank      ranklist.Rank
@@ -77,6 +81,11 @@ type (
 		Pools         *PoolDatabase
 		Checker       *CheckerDatabase
 		System        *SystemDatabase
+		History       HealthHistory          `json:",omitempty"`
+		Events        EventLog               `json:",omitempty"`
+		NextEventID   uint64                 `json:",omitempty"`
+		PoolActivity  PoolActivityMap        `json:",omitempty"`
+		MSBackup      *system.MSBackupStatus `json:",omitempty"`
 		SchemaVersion uint
 	}
 
@@ -89,15 +98,19 @@ type (
 		cfg                *DatabaseConfig
 		initialized        atm.Bool
 		replicaAddr        *net.TCPAddr
+		localID            raft.ServerID
 		raftTransport      raft.Transport
 		raft               syncRaft
 		raftLeaderNotifyCh chan bool
 		onLeadershipGained []onLeadershipGainedFn
 		onLeadershipLost   []onLeadershipLostFn
 		onRaftShutdown     []onRaftShutdownFn
+		onEventsEvicted    []onEventsEvictedFn
 		shutdownCb         context.CancelFunc
 		shutdownErrCh      chan error
 		poolLocks          poolLockMap
+		hashWaiters        stateHashWaiters
+		eventQueue         eventQueue
 
 		data *dbData // raft-backed system data
 	}
@@ -108,8 +121,10 @@ type (
 		RaftDir               string
 		RaftSnapshotThreshold uint64
 		RaftSnapshotInterval  time.Duration
+		RaftElectionTier      uint
 		SystemName            string
 		ReadOnly              bool
+		HostID                uuid.UUID
 	}
 
 	// GroupMap represents a version of the system membership map.
@@ -119,6 +134,15 @@ type (
 		MSRanks     []ranklist.Rank
 	}
 
+	// groupMapCache holds the group map generated for the current map
+	// version, so that it is not regenerated for every request. It has
+	// its own lock, as the map is generated while the database is only
+	// read-locked.
+	groupMapCache struct {
+		sync.Mutex
+		gm *GroupMap
+	}
+
 	// RankEntry comprises the information about a rank in GroupMap.
 	RankEntry struct {
 		PrimaryURI       string
@@ -262,6 +286,7 @@ func NewDatabase(log logging.Logger, cfg *DatabaseConfig) (*Database, error) {
 				Ranks:  make(PoolRankMap),
 				Uuids:  make(PoolUuidMap),
 				Labels: make(PoolLabelMap),
+				Owners: make(PoolOwnerMap),
 			},
 			Checker: &CheckerDatabase{
 				Findings: make(CheckerFindingMap),
@@ -290,6 +315,22 @@ func (db *Database) isReplica(ctrlAddr *net.TCPAddr) bool {
 	return false
 }
 
+// IsReplicaIP returns true if the supplied IP address matches that of
+// a known replica.
+func (db *Database) IsReplicaIP(ip net.IP) bool {
+	if db == nil || db.cfg == nil {
+		return false
+	}
+
+	for _, candidate := range db.cfg.Replicas {
+		if candidate.IP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SystemName returns the system name set in the configuration.
 func (db *Database) SystemName() string {
 	return db.cfg.SystemName
@@ -313,6 +354,13 @@ func (db *Database) ReplicaAddr() (*net.TCPAddr, error) {
 	return db.replicaAddr, nil
 }
 
+// SetHostID sets the persistent identity of the local server, which is used
+// as the raft server ID of a new replica. It must be called before the
+// database is started.
+func (db *Database) SetHostID(id uuid.UUID) {
+	db.cfg.HostID = id
+}
+
 // PeerAddrs returns the addresses of this system's replication peers.
 func (db *Database) PeerAddrs() ([]*net.TCPAddr, error) {
 	myAddr, err := db.ReplicaAddr()
@@ -444,6 +492,12 @@ func (db *Database) Start(parent context.Context) error {
 		return err
 	}
 
+	if dbExists {
+		if err := verifyRaftStore(db.log, db.cfg); err != nil {
+			return err
+		}
+	}
+
 	if err := db.initRaft(); err != nil {
 		return errors.Wrap(err, "unable to initialize raft service")
 	}
@@ -561,7 +615,28 @@ func newGroupMap(version uint32) *GroupMap {
 	}
 }
 
-// GroupMap returns the latest system group map.
+// get returns the cached group map if it was generated for the version.
+func (gmc *groupMapCache) get(version uint32) *GroupMap {
+	gmc.Lock()
+	defer gmc.Unlock()
+
+	if gmc.gm == nil || gmc.gm.Version != version {
+		return nil
+	}
+	return gmc.gm
+}
+
+// set replaces the cached group map.
+func (gmc *groupMapCache) set(gm *GroupMap) {
+	gmc.Lock()
+	defer gmc.Unlock()
+
+	gmc.gm = gm
+}
+
+// GroupMap returns the latest system group map. The map is generated once
+// for each map version and shared between callers, so it must not be
+// modified.
 func (db *Database) GroupMap() (*GroupMap, error) {
 	if err := db.CheckReplica(); err != nil {
 		return nil, err
@@ -569,6 +644,10 @@ func (db *Database) GroupMap() (*GroupMap, error) {
 	db.data.RLock()
 	defer db.data.RUnlock()
 
+	if gm := db.data.groupMap.get(db.data.MapVersion); gm != nil {
+		return gm, nil
+	}
+
 	gm := newGroupMap(db.data.MapVersion)
 	for _, srv := range db.data.Members.Ranks {
 		// Only members that have been auto-excluded or administratively
@@ -601,6 +680,7 @@ func (db *Database) GroupMap() (*GroupMap, error) {
 	if len(gm.RankEntries) == 0 {
 		return nil, system.ErrEmptyGroupMap
 	}
+	db.data.groupMap.set(gm)
 
 	return gm, nil
 }
@@ -722,6 +802,61 @@ func (db *Database) RemoveMember(m *system.Member) error {
 	return db.submitMemberUpdate(raftOpRemoveMember, &memberUpdate{Member: m})
 }
 
+// voterID returns the raft server ID of the replica hosting the member. The
+// ID is the persistent host ID of the server, unless the server has no host
+// ID or is already a voter identified by its address, as replicas created
+// before host IDs were introduced keep their address as ID.
+func (db *Database) voterID(m *system.Member) raft.ServerID {
+	addrID := raft.ServerID(m.Addr.String())
+	if m.HostID == uuid.Nil {
+		return addrID
+	}
+
+	var cfg raft.Configuration
+	if err := db.raft.withReadLock(func(svc raftService) error {
+		f := svc.GetConfiguration()
+		if err := f.Error(); err != nil {
+			return err
+		}
+		cfg = f.Configuration()
+		return nil
+	}); err != nil {
+		db.log.Errorf("failed to get raft configuration: %s", err)
+	}
+	for _, srv := range cfg.Servers {
+		if srv.ID == addrID {
+			return addrID
+		}
+	}
+
+	return raft.ServerID(m.HostID.String())
+}
+
+// moveVoter updates the address of a replica whose host has been re-addressed.
+// Its raft server ID is unchanged, so the voter is simply re-added at the new
+// address rather than having to be removed from the cluster.
+func (db *Database) moveVoter(prev, cur *system.Member) error {
+	if common.CmpTCPAddr(prev.Addr, cur.Addr) {
+		return nil
+	}
+
+	// Ignore self and non-replica candidates.
+	if common.CmpTCPAddr(db.replicaAddr, cur.Addr) || !db.isReplica(cur.Addr) {
+		return nil
+	}
+
+	rsi := db.voterID(prev)
+	rsa := raft.ServerAddress(cur.Addr.String())
+	db.log.Noticef("moving raft voter %s from %s to %s", rsi, prev.Addr, cur.Addr)
+	if err := db.raft.withReadLock(func(svc raftService) error {
+		return svc.AddVoter(rsi, rsa, 0, 0).Error()
+	}); err != nil {
+		return errors.Wrapf(err, "failed to move raft replica %q to %q", prev.Addr, cur.Addr)
+	}
+
+	return nil
+}
+
 func (db *Database) manageVoter(vc *system.Member, op raftOp) error {
 	// Ignore self as a voter candidate.
 	if common.CmpTCPAddr(db.replicaAddr, vc.Addr) {
@@ -733,7 +868,7 @@ func (db *Database) manageVoter(vc *system.Member, op raftOp) error {
 		return nil
 	}
 
-	rsi := raft.ServerID(vc.Addr.String())
+	rsi := db.voterID(vc)
 	rsa := raft.ServerAddress(vc.Addr.String())
 
 	switch op {
@@ -803,11 +938,22 @@ func (db *Database) UpdateMember(m *system.Member) error {
 
 	db.log.Tracef("updating member: %+v", m)
 
-	_, err := db.FindMemberByUUID(m.UUID)
+	prev, err := db.FindMemberByUUID(m.UUID)
 	if err != nil {
 		return err
 	}
 
+	if prev.Addr != nil && m.Addr != nil {
+		// Look up the voter under the previous address but with the
+		// latest host ID, which may not have been recorded before.
+		if prev.HostID == uuid.Nil {
+			prev.HostID = m.HostID
+		}
+		if err := db.moveVoter(prev, m); err != nil {
+			return err
+		}
+	}
+
 	return db.submitMemberUpdate(raftOpUpdateMember, &memberUpdate{Member: m})
 }
 
@@ -864,6 +1010,29 @@ func (db *Database) FindMembersByAddr(addr *net.TCPAddr) ([]*system.Member, erro
 	return nil, system.ErrMemberAddrNotFound(addr)
 }
 
+// CompactMemberAddrs removes entries from the member address map that no
+// longer refer to a current member at that address, and returns the number
+// of entries removed. No update is submitted if there is nothing to remove.
+func (db *Database) CompactMemberAddrs() (int, error) {
+	if err := db.CheckLeader(); err != nil {
+		return 0, err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	db.data.RLock()
+	stale := db.data.Members.compactAddrs(true)
+	db.data.RUnlock()
+	if stale == 0 {
+		return 0, nil
+	}
+
+	if err := db.submitMemberAddrCompaction(); err != nil {
+		return 0, err
+	}
+	return stale, nil
+}
+
 // FaultDomainTree returns the tree of fault domains of joined members.
 func (db *Database) FaultDomainTree() *system.FaultDomainTree {
 	db.data.RLock()
@@ -903,6 +1072,27 @@ func (db *Database) PoolServiceList(all bool) ([]*system.PoolService, error) {
 	return dbCopy, nil
 }
 
+// FilterPoolServices returns a list of pool services registered with
+// the system that match the supplied filter. If the all parameter is
+// not true, only pool services in the "Ready" state are returned.
+func (db *Database) FilterPoolServices(all bool, filter *PoolServiceFilter) ([]*system.PoolService, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	matches := db.data.Pools.filterServices(filter)
+	dbCopy := make([]*system.PoolService, 0, len(matches))
+	for _, ps := range matches {
+		if ps.State != system.PoolServiceStateReady && !all {
+			continue
+		}
+		dbCopy = append(dbCopy, copyPoolService(ps))
+	}
+	return dbCopy, nil
+}
+
 // FindPoolServiceByUUID searches the pool database by UUID. If no
 // pool service is found, an error is returned.
 func (db *Database) FindPoolServiceByUUID(uuid uuid.UUID) (*system.PoolService, error) {
@@ -984,7 +1174,7 @@ func (db *Database) AddPoolService(ctx context.Context, ps *system.PoolService)
 		return errors.Errorf("pool %s already exists", p.PoolUUID)
 	}
 
-	if err := db.submitPoolUpdate(raftOpAddPoolService, ps); err != nil {
+	if err := db.submitPoolUpdate(ctx, raftOpAddPoolService, ps); err != nil {
 		return err
 	}
 
@@ -1008,7 +1198,7 @@ func (db *Database) RemovePoolService(ctx context.Context, poolUUID uuid.UUID) e
 		return errors.Wrapf(err, "failed to retrieve pool %s", poolUUID)
 	}
 
-	if err := db.submitPoolUpdate(raftOpRemovePoolService, ps); err != nil {
+	if err := db.submitPoolUpdate(ctx, raftOpRemovePoolService, ps); err != nil {
 		return err
 	}
 
@@ -1048,13 +1238,45 @@ func (db *Database) UpdatePoolService(ctx context.Context, ps *system.PoolServic
 		return nil
 	}
 
-	if err := db.submitPoolUpdate(raftOpUpdatePoolService, ps); err != nil {
+	if err := db.submitPoolUpdate(ctx, raftOpUpdatePoolService, ps); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// RenamePoolService atomically changes the label of an existing pool
+// database entry. The rename is rejected if the new label is already in
+// use by another pool.
+func (db *Database) RenamePoolService(ctx context.Context, poolUUID uuid.UUID, label string) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	if err := db.poolLocks.checkLockCtx(ctx); err != nil {
+		return err
+	}
+
+	ps, err := db.FindPoolServiceByUUID(poolUUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve pool %s", poolUUID)
+	}
+
+	if label != "" {
+		if other, err := db.FindPoolServiceByLabel(label); err == nil && other.PoolUUID != poolUUID {
+			return &system.ErrPoolLabelExists{Label: label, PoolUUID: other.PoolUUID}
+		}
+	}
+
+	return db.submitPoolRename(&poolRename{
+		PoolUUID: poolUUID,
+		OldLabel: ps.PoolLabel,
+		NewLabel: label,
+	})
+}
+
 func (db *Database) handlePoolRepsUpdate(evt *events.RASEvent) {
 	ei := evt.GetPoolSvcInfo()
 	if ei == nil {
@@ -1115,6 +1337,12 @@ func (db *Database) OnEvent(_ context.Context, evt *events.RASEvent) {
 	switch evt.ID {
 	case events.RASPoolRepsUpdate:
 		db.handlePoolRepsUpdate(evt)
+	case events.RASPoolRebuildStart, events.RASPoolRebuildEnd, events.RASPoolRebuildFailed:
+		db.recordPoolEvent(evt)
+	}
+
+	if system.IsCriticalEvent(evt) {
+		db.recordEvent(evt)
 	}
 }
 
@@ -0,0 +1,183 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"encoding/json"
+	"hash/crc64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// openLatestSnapshot opens the newest snapshot in the snapshot store.
+func (db *Database) openLatestSnapshot() (*raft.SnapshotMeta, io.ReadCloser, error) {
+	store, err := getSnapshotStore(newHcLogger(db.log), db.cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snaps, err := store.List()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list snapshots")
+	}
+	if len(snaps) == 0 {
+		return nil, nil, ErrNoRaftSnapshots
+	}
+
+	return store.Open(snaps[0].ID)
+}
+
+// takeSnapshot forces a snapshot of the database to be taken and opens it.
+// If nothing has changed since the last snapshot, that snapshot is opened
+// instead.
+func (db *Database) takeSnapshot() (meta *raft.SnapshotMeta, rc io.ReadCloser, err error) {
+	err = db.raft.withReadLock(func(svc raftService) error {
+		future := svc.Snapshot()
+		if err := future.Error(); err != nil {
+			if err != raft.ErrNothingNewToSnapshot {
+				return errors.Wrap(err, "failed to take snapshot")
+			}
+			meta, rc, err = db.openLatestSnapshot()
+			return err
+		}
+
+		meta, rc, err = future.Open()
+		return err
+	})
+
+	return
+}
+
+// writeBackup writes the snapshot to the directory in the layout of the
+// file snapshot store, including the CRC of the data, so that it can be
+// verified and restored like any other snapshot.
+func writeBackup(path string, meta *raft.SnapshotMeta, rc io.Reader) error {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create %s", path)
+	}
+
+	dataPath := filepath.Join(path, snapshotDataFile)
+	f, err := os.OpenFile(dataPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", dataPath)
+	}
+	defer f.Close()
+
+	hash := crc64.New(crc64.MakeTable(crc64.ECMA))
+	size, err := io.Copy(io.MultiWriter(f, hash), rc)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", dataPath)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync %s", dataPath)
+	}
+
+	fileMeta := struct {
+		raft.SnapshotMeta
+		CRC []byte
+	}{
+		SnapshotMeta: *meta,
+		CRC:          hash.Sum(nil),
+	}
+	fileMeta.Size = size
+	data, err := json.Marshal(fileMeta)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode snapshot metadata")
+	}
+	metaPath := filepath.Join(path, snapshotMetaFile)
+	if err := ioutil.WriteFile(metaPath, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", metaPath)
+	}
+
+	return nil
+}
+
+// Backup takes a snapshot of the system database and writes it to a new
+// directory with the given name under dir, from which the database can be
+// restored with RestoreLocalReplica. The backup is written under a temporary
+// name and is only renamed once it has been verified, so an incomplete or
+// corrupt backup is never left in place.
+func (db *Database) Backup(dir, name string) (*SnapshotDetails, error) {
+	if err := db.CheckLeader(); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("backup %s already exists", path)
+	}
+
+	meta, rc, err := db.takeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	tmpPath := path + tmpSnapshotSuffix
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return nil, errors.Wrapf(err, "failed to remove %s", tmpPath)
+	}
+	if err := writeBackup(tmpPath, meta, rc); err != nil {
+		os.RemoveAll(tmpPath)
+		return nil, err
+	}
+
+	if _, err := verifySnapshot(tmpPath); err != nil {
+		os.RemoveAll(tmpPath)
+		return nil, errors.Wrapf(err, "failed to verify backup %s", tmpPath)
+	}
+	details, err := ReadSnapshotInfo(tmpPath)
+	if err != nil {
+		os.RemoveAll(tmpPath)
+		return nil, errors.Wrapf(err, "failed to verify backup %s", tmpPath)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.RemoveAll(tmpPath)
+		return nil, errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+	details.Path = path
+
+	return details, nil
+}
+
+// SetMSBackupStatus records the status of the latest MS backup.
+func (db *Database) SetMSBackupStatus(bs *system.MSBackupStatus) error {
+	if bs == nil {
+		return errors.New("nil MS backup status")
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitMSBackupStatus(bs)
+}
+
+// MSBackupStatus returns the status of the latest MS backup, or nil if no
+// backup has been taken.
+func (db *Database) MSBackupStatus() (*system.MSBackupStatus, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	if db.data.MSBackup == nil {
+		return nil, nil
+	}
+	bs := *db.data.MSBackup
+	return &bs, nil
+}
@@ -0,0 +1,111 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/lib/ranklist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestRaft_Database_Backup(t *testing.T) {
+	for name, tc := range map[string]struct {
+		existing bool
+		expErr   error
+	}{
+		"success": {},
+		"backup exists": {
+			existing: true,
+			expErr:   errors.New("already exists"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+			for i := 0; i < 2; i++ {
+				if err := db.AddMember(&system.Member{
+					Rank:  ranklist.Rank(i),
+					UUID:  uuid.New(),
+					Addr:  &net.TCPAddr{IP: net.IPv4(127, 0, 0, byte(i+1)), Port: 10001},
+					State: system.MemberStateJoined,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			dir := t.TempDir()
+			if tc.existing {
+				if err := os.Mkdir(filepath.Join(dir, "backup"), 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			details, gotErr := db.Backup(dir, "backup")
+			test.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			expPath := filepath.Join(dir, "backup")
+			test.AssertEqual(t, expPath, details.Path, "unexpected backup path")
+			test.AssertEqual(t, "0-1", details.MemberRanks.RangedString(), "unexpected ranks in backup")
+
+			// The backup must be verifiable in the same way as a snapshot.
+			if _, err := verifySnapshot(expPath); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(expPath + tmpSnapshotSuffix); !os.IsNotExist(err) {
+				t.Fatalf("temporary backup directory not removed (err: %v)", err)
+			}
+		})
+	}
+}
+
+func TestRaft_Database_MSBackupStatus(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+
+	got, err := db.MSBackupStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("expected no backup status, got %+v", got)
+	}
+
+	exp := &system.MSBackupStatus{
+		Time:        time.Unix(2, 0).UTC(),
+		Error:       "disk full",
+		LastSuccess: time.Unix(1, 0).UTC(),
+	}
+	if err := db.SetMSBackupStatus(exp); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = db.MSBackupStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Fatalf("unexpected backup status (-want, +got):\n%s\n", diff)
+	}
+}
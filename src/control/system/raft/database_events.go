@@ -0,0 +1,251 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// MaxPersistentEvents is the maximum number of persistent events
+	// retained in the database. Once the limit is reached, the oldest
+	// acknowledged event is discarded when a new one is added.
+	MaxPersistentEvents = 1000
+
+	// eventFlushInterval is the interval for which critical events are
+	// queued before being recorded, so that a burst of events results in
+	// a single raft update rather than one per event.
+	eventFlushInterval = time.Second
+)
+
+type (
+	// EventLog contains the persistent events recorded by the MS
+	// leader, ordered by ID.
+	EventLog []*system.PersistentEvent
+
+	// eventAck is the raft update to acknowledge a set of events.
+	eventAck struct {
+		IDs []uint64
+	}
+
+	// eventRemove is the raft update to remove a set of acknowledged
+	// events.
+	eventRemove struct {
+		IDs []uint64
+	}
+
+	// eventQueue holds the critical events waiting to be recorded.
+	eventQueue struct {
+		sync.Mutex
+		pending []*system.PersistentEvent
+		dropped int
+		timer   *time.Timer // non-nil while a flush is scheduled
+	}
+)
+
+// find returns the event with the given ID, or nil if not found.
+func (el EventLog) find(id uint64) *system.PersistentEvent {
+	for _, pe := range el {
+		if pe.ID == id {
+			return pe
+		}
+	}
+	return nil
+}
+
+// add appends the event to the log. If the log would exceed the supplied
+// maximum length, the oldest acknowledged events are evicted first, and
+// then the oldest active events. The evicted events are returned.
+func (el EventLog) add(pe *system.PersistentEvent, max int) (EventLog, EventLog) {
+	var evicted EventLog
+	el = append(el, pe)
+	for len(el) > max {
+		drop := 0
+		for i, old := range el {
+			if !old.IsActive() {
+				drop = i
+				break
+			}
+		}
+		evicted = append(evicted, el[drop])
+		el = append(el[:drop:drop], el[drop+1:]...)
+	}
+	return el, evicted
+}
+
+// remove returns the log without the acknowledged events with the given
+// IDs. Active events are retained.
+func (el EventLog) remove(ids []uint64) EventLog {
+	rm := make(map[uint64]struct{}, len(ids))
+	for _, id := range ids {
+		rm[id] = struct{}{}
+	}
+
+	out := el[:0]
+	for _, pe := range el {
+		if _, found := rm[pe.ID]; found && !pe.IsActive() {
+			continue
+		}
+		out = append(out, pe)
+	}
+	return out
+}
+
+// OnEventsEvicted registers callbacks to be run on the MS leader with the
+// events evicted from the bounded event log to make room for new ones, e.g.
+// so that they can be archived rather than lost.
+func (db *Database) OnEventsEvicted(fns ...onEventsEvictedFn) {
+	db.onEventsEvicted = append(db.onEventsEvicted, fns...)
+}
+
+// eventsEvicted passes the evicted events to the registered callbacks if
+// this replica is the leader. The callbacks are run asynchronously so that
+// they do not hold up the application of raft updates.
+func (db *Database) eventsEvicted(evicted EventLog) {
+	if len(evicted) == 0 || len(db.onEventsEvicted) == 0 || !db.IsLeader() {
+		return
+	}
+
+	for _, fn := range db.onEventsEvicted {
+		go fn(evicted)
+	}
+}
+
+// recordEvent queues a critical RAS event to be added to the persistent
+// event log. Repeats of a queued event are merged into it, and the queue is
+// flushed in a single update after eventFlushInterval.
+func (db *Database) recordEvent(evt *events.RASEvent) {
+	pe := system.NewPersistentEvent(evt)
+
+	q := &db.eventQueue
+	q.Lock()
+	defer q.Unlock()
+
+	for _, cur := range q.pending {
+		if cur.Matches(pe) {
+			cur.Msg = pe.Msg
+			cur.LastSeen = pe.LastSeen
+			cur.Count++
+			return
+		}
+	}
+
+	if len(q.pending) >= MaxPersistentEvents {
+		// The oldest events would be discarded from the log anyway.
+		q.pending = q.pending[1:]
+		q.dropped++
+	}
+	q.pending = append(q.pending, pe)
+
+	if q.timer == nil {
+		q.timer = time.AfterFunc(eventFlushInterval, db.flushEvents)
+	}
+}
+
+// flushEvents adds the queued events to the persistent event log.
+func (db *Database) flushEvents() {
+	q := &db.eventQueue
+	q.Lock()
+	pending, dropped := q.pending, q.dropped
+	q.pending, q.dropped, q.timer = nil, 0, nil
+	q.Unlock()
+
+	if dropped > 0 {
+		db.log.Noticef("%d queued events were discarded before being recorded", dropped)
+	}
+	if err := db.AddEvents(pending...); err != nil {
+		db.log.Errorf("failed to record %d events: %s", len(pending), err)
+	}
+}
+
+// AddEvent adds a persistent event to the bounded event log. If an active
+// event reporting the same condition already exists, its count is increased
+// instead.
+func (db *Database) AddEvent(pe *system.PersistentEvent) error {
+	if pe == nil {
+		return errors.New("nil persistent event")
+	}
+
+	return db.AddEvents(pe)
+}
+
+// AddEvents adds a set of persistent events to the bounded event log in a
+// single update. Each event is added as if by AddEvent.
+func (db *Database) AddEvents(pes ...*system.PersistentEvent) error {
+	if len(pes) == 0 {
+		return nil
+	}
+	for _, pe := range pes {
+		if pe == nil {
+			return errors.New("nil persistent event")
+		}
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitEventsAdd(pes)
+}
+
+// AckEvents marks the events with the given IDs as acknowledged.
+func (db *Database) AckEvents(ids ...uint64) error {
+	if len(ids) == 0 {
+		return errors.New("no event IDs to acknowledge")
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitEventAck(&eventAck{IDs: ids})
+}
+
+// RemoveEvents removes the acknowledged events with the given IDs from the
+// event log, e.g. once they have been archived. IDs of unknown or active
+// events are ignored.
+func (db *Database) RemoveEvents(ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitEventRemove(&eventRemove{IDs: ids})
+}
+
+// Events returns copies of the persistent events, ordered by ID. If
+// activeOnly is true, acknowledged events are not included.
+func (db *Database) Events(activeOnly bool) ([]*system.PersistentEvent, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	out := make([]*system.PersistentEvent, 0, len(db.data.Events))
+	for _, pe := range db.data.Events {
+		if activeOnly && !pe.IsActive() {
+			continue
+		}
+		cp := *pe
+		out = append(out, &cp)
+	}
+
+	return out, nil
+}
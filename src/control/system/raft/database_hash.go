@@ -0,0 +1,201 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// ErrStateIndexPassed indicates that the replica applied updates beyond the
+// requested index before the state at that index could be hashed.
+var ErrStateIndexPassed = errors.New("replica state has advanced past the requested index")
+
+type (
+	// StateHash is the content hash of the applied system database state
+	// at a given data version.
+	StateHash struct {
+		Index uint64 `json:"index"`
+		Hash  string `json:"hash"`
+	}
+
+	// hashableData is the subset of the system data used to compute the
+	// state hash. The lookup indexes are derived from the UUID maps and
+	// may be ordered differently on each replica after a snapshot is
+	// restored, so only the UUID maps are included.
+	hashableData struct {
+		Version       uint64
+		NextRank      uint32
+		MapVersion    uint32
+		Members       MemberUuidMap
+		FaultDomains  *system.FaultDomainTree
+		Pools         PoolUuidMap
+		Checker       *CheckerDatabase
+		System        *SystemDatabase
+		History       HealthHistory
+		Events        EventLog
+		NextEventID   uint64
+		PoolActivity  PoolActivityMap
+		MSBackup      *system.MSBackupStatus
+		SchemaVersion uint
+	}
+
+	stateHashResult struct {
+		hash *StateHash
+		err  error
+	}
+
+	// stateHashWaiters tracks the callers waiting for the state to be
+	// hashed when a given index is applied.
+	stateHashWaiters struct {
+		sync.Mutex
+		waiters map[uint64][]chan *stateHashResult
+	}
+)
+
+// stateHash computes the hash of the current state. Must be called with the
+// lock held.
+func (d *dbData) stateHash() (*StateHash, error) {
+	hd := &hashableData{
+		Version:       d.Version,
+		NextRank:      uint32(d.NextRank),
+		MapVersion:    d.MapVersion,
+		Checker:       d.Checker,
+		System:        d.System,
+		History:       d.History,
+		Events:        d.Events,
+		NextEventID:   d.NextEventID,
+		PoolActivity:  d.PoolActivity,
+		MSBackup:      d.MSBackup,
+		SchemaVersion: d.SchemaVersion,
+	}
+	if d.Members != nil {
+		hd.Members = d.Members.Uuids
+		hd.FaultDomains = d.Members.FaultDomains
+	}
+	if d.Pools != nil {
+		hd.Pools = d.Pools.Uuids
+	}
+
+	// NB: Map keys are sorted when encoded, so the encoding of
+	// identical states is identical.
+	data, err := json.Marshal(hd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode system data")
+	}
+	sum := sha256.Sum256(data)
+
+	return &StateHash{
+		Index: d.Version,
+		Hash:  hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// StateHash returns the content hash of the currently-applied system
+// database state.
+func (db *Database) StateHash() (*StateHash, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+
+	db.data.RLock()
+	defer db.data.RUnlock()
+	return db.data.stateHash()
+}
+
+// StateHashAt returns the content hash of the system database state as of
+// the given data version, waiting for the update at that index to be applied
+// if necessary. Returns ErrStateIndexPassed if the state has already moved
+// beyond the requested index.
+func (db *Database) StateHashAt(ctx context.Context, index uint64) (*StateHash, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+
+	// Register the waiter before checking the current version so that
+	// the update at the requested index can't be missed.
+	ch := make(chan *stateHashResult, 1)
+	db.hashWaiters.Lock()
+	if db.hashWaiters.waiters == nil {
+		db.hashWaiters.waiters = make(map[uint64][]chan *stateHashResult)
+	}
+	db.hashWaiters.waiters[index] = append(db.hashWaiters.waiters[index], ch)
+	db.hashWaiters.Unlock()
+	defer db.removeHashWaiter(index, ch)
+
+	db.notifyHashWaiters()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.hash, res.err
+	}
+}
+
+func (db *Database) removeHashWaiter(index uint64, ch chan *stateHashResult) {
+	db.hashWaiters.Lock()
+	defer db.hashWaiters.Unlock()
+
+	waiters := db.hashWaiters.waiters[index]
+	for i, cur := range waiters {
+		if cur == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(db.hashWaiters.waiters, index)
+		return
+	}
+	db.hashWaiters.waiters[index] = waiters
+}
+
+// notifyHashWaiters hashes the current state for any callers waiting on the
+// current index, and fails those waiting on an index that has been passed.
+// Called after each update is applied, before any subsequent update can
+// modify the state.
+func (db *Database) notifyHashWaiters() {
+	db.hashWaiters.Lock()
+	defer db.hashWaiters.Unlock()
+
+	if len(db.hashWaiters.waiters) == 0 {
+		return
+	}
+
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	var curHash *stateHashResult
+	for index, waiters := range db.hashWaiters.waiters {
+		var res *stateHashResult
+		switch {
+		case index > db.data.Version:
+			continue
+		case index < db.data.Version:
+			res = &stateHashResult{err: ErrStateIndexPassed}
+		default:
+			if curHash == nil {
+				hash, err := db.data.stateHash()
+				curHash = &stateHashResult{hash: hash, err: err}
+			}
+			res = curHash
+		}
+
+		for _, ch := range waiters {
+			ch <- res
+		}
+		delete(db.hashWaiters.waiters, index)
+	}
+}
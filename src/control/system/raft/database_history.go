@@ -0,0 +1,67 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// MaxHealthSnapshots is the maximum number of health snapshots
+	// retained in the database. Once the limit is reached, the oldest
+	// snapshot is discarded when a new one is added.
+	MaxHealthSnapshots = 720
+)
+
+// HealthHistory contains the bounded history of system health
+// snapshots, ordered from oldest to newest.
+type HealthHistory []*system.HealthSnapshot
+
+// add appends the snapshot to the history, discarding the oldest entries
+// if the history would exceed the supplied maximum length.
+func (hh HealthHistory) add(hs *system.HealthSnapshot, max int) HealthHistory {
+	hh = append(hh, hs)
+	if excess := len(hh) - max; excess > 0 {
+		hh = append(HealthHistory{}, hh[excess:]...)
+	}
+	return hh
+}
+
+// AddHealthSnapshot adds a health snapshot to the bounded history.
+func (db *Database) AddHealthSnapshot(hs *system.HealthSnapshot) error {
+	if hs == nil {
+		return errors.New("nil health snapshot")
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitHealthSnapshot(hs)
+}
+
+// HealthSnapshots returns up to max of the most recent health snapshots,
+// ordered from oldest to newest. If max is 0, the full history is returned.
+func (db *Database) HealthSnapshots(max int) ([]*system.HealthSnapshot, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	snaps := db.data.History
+	if max > 0 && len(snaps) > max {
+		snaps = snaps[len(snaps)-max:]
+	}
+
+	// NB: The snapshots are never modified after being added
+	// to the history, so a shallow copy of the slice is sufficient.
+	return append([]*system.HealthSnapshot{}, snaps...), nil
+}
@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -68,7 +68,9 @@ func (mam MemberAddrMap) removeMember(m *system.Member) {
 	}
 	if len(members) == 0 {
 		delete(mam, m.Addr.String())
+		return
 	}
+	mam[m.Addr.String()] = members
 }
 
 // MarshalJSON creates a serialized representation of the MemberAddrMap.
@@ -164,6 +166,27 @@ func (mdb *MemberDatabase) updateMember(m *system.Member) {
 	cur.Incarnation = m.Incarnation
 	cur.PrimaryFabricURI = m.PrimaryFabricURI
 	cur.SecondaryFabricURIs = m.SecondaryFabricURIs
+	cur.Starts = m.Starts
+	cur.LastStart = m.LastStart
+	cur.Exits = m.Exits
+	cur.Deaths = m.Deaths
+	cur.Stops = m.Stops
+	cur.LastStopReason = m.LastStopReason
+	cur.StateCause = m.StateCause
+	cur.LastStateChange = m.LastStateChange
+	if m.HostID != uuid.Nil {
+		cur.HostID = m.HostID
+	}
+
+	// A member whose host has been re-addressed keeps its identity, so
+	// move it to the new address rather than leaving a stale entry.
+	if m.Addr != nil && (cur.Addr == nil || cur.Addr.String() != m.Addr.String()) {
+		if cur.Addr != nil {
+			mdb.Addrs.removeMember(cur)
+		}
+		cur.Addr = m.Addr
+		mdb.Addrs.addMember(cur.Addr, cur)
+	}
 
 	mdb.removeFromFaultDomainTree(cur)
 	cur.FaultDomain = m.FaultDomain
@@ -179,6 +202,38 @@ func (mdb *MemberDatabase) removeMember(m *system.Member) {
 	mdb.removeFromFaultDomainTree(m)
 }
 
+// compactAddrs removes entries from the address map that do not refer to a
+// current member at that address, e.g. those left behind when a host has
+// been re-addressed, and returns the number of entries removed. If dryRun is
+// true, the entries are counted but not removed. The order of the remaining
+// entries is preserved so that the result is the same on every replica.
+func (mdb *MemberDatabase) compactAddrs(dryRun bool) int {
+	var removed int
+	for addr, members := range mdb.Addrs {
+		seen := make(map[uuid.UUID]bool)
+		kept := make([]*system.Member, 0, len(members))
+		for _, m := range members {
+			cur, found := mdb.Uuids[m.UUID]
+			if !found || cur != m || cur.Addr.String() != addr || seen[m.UUID] {
+				removed++
+				continue
+			}
+			seen[m.UUID] = true
+			kept = append(kept, m)
+		}
+
+		switch {
+		case dryRun || len(kept) == len(members):
+		case len(kept) == 0:
+			delete(mdb.Addrs, addr)
+		default:
+			mdb.Addrs[addr] = kept
+		}
+	}
+
+	return removed
+}
+
 func (mdb *MemberDatabase) removeFromFaultDomainTree(m *system.Member) {
 	if err := mdb.FaultDomains.RemoveDomain(system.MemberFaultDomain(m)); err != nil {
 		panic(err)
@@ -0,0 +1,102 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// MaxPoolActivity is the maximum number of activity records retained
+	// for each pool. Once the limit is reached, the oldest record is
+	// discarded when a new one is added.
+	MaxPoolActivity = 100
+)
+
+type (
+	// PoolActivityLog contains the bounded activity log of a pool,
+	// ordered from oldest to newest.
+	PoolActivityLog []*system.PoolActivity
+
+	// PoolActivityMap provides a map of pool UUID->PoolActivityLog.
+	PoolActivityMap map[uuid.UUID]PoolActivityLog
+
+	// poolActivityAdd is the raft update to add a record to the
+	// activity log of a pool.
+	poolActivityAdd struct {
+		PoolUUID uuid.UUID
+		Activity *system.PoolActivity
+	}
+)
+
+// add appends the record to the log, discarding the oldest entries if the
+// log would exceed the supplied maximum length.
+func (pal PoolActivityLog) add(pa *system.PoolActivity, max int) PoolActivityLog {
+	pal = append(pal, pa)
+	if excess := len(pal) - max; excess > 0 {
+		pal = append(PoolActivityLog{}, pal[excess:]...)
+	}
+	return pal
+}
+
+// recordPoolEvent adds a RAS event raised on behalf of a pool to the pool's
+// activity log, if it is of a type that is recorded.
+func (db *Database) recordPoolEvent(evt *events.RASEvent) {
+	pa := system.PoolActivityFromEvent(evt)
+	if pa == nil {
+		return
+	}
+
+	poolUUID, err := uuid.Parse(evt.PoolUUID)
+	if err != nil {
+		db.log.Errorf("invalid pool UUID in %s event: %s", evt.ID, err)
+		return
+	}
+	if err := db.AddPoolActivity(poolUUID, pa); err != nil {
+		db.log.Errorf("failed to record %s event for pool %s: %s", evt.ID, poolUUID, err)
+	}
+}
+
+// AddPoolActivity adds a record to the bounded activity log of the pool.
+// Records for pools that are not in the database are discarded.
+func (db *Database) AddPoolActivity(poolUUID uuid.UUID, pa *system.PoolActivity) error {
+	if pa == nil {
+		return errors.New("nil pool activity")
+	}
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	return db.submitPoolActivity(&poolActivityAdd{
+		PoolUUID: poolUUID,
+		Activity: pa,
+	})
+}
+
+// PoolActivity returns the activity log of the pool, ordered from oldest
+// to newest.
+func (db *Database) PoolActivity(poolUUID uuid.UUID) ([]*system.PoolActivity, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	if _, found := db.data.Pools.Uuids[poolUUID]; !found {
+		return nil, system.ErrPoolUUIDNotFound(poolUUID)
+	}
+
+	// NB: The records are never modified after being added
+	// to the log, so a shallow copy of the slice is sufficient.
+	return append([]*system.PoolActivity{}, db.data.PoolActivity[poolUUID]...), nil
+}
@@ -8,6 +8,9 @@ package raft
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -23,16 +26,113 @@ type (
 	PoolUuidMap map[uuid.UUID]*system.PoolService
 	// PoolLabelMap provides a map of Label->*PoolService.
 	PoolLabelMap map[string]*system.PoolService
+	// PoolOwnerMap provides a map of Owner->PoolUuidMap.
+	PoolOwnerMap map[string]PoolUuidMap
+	// PoolCreationIndex provides a list of *PoolService sorted
+	// by creation time.
+	PoolCreationIndex []*system.PoolService
 
 	// PoolDatabase contains a set of maps for looking up DAOS Pool
 	// Service instances and methods for managing the pool membership.
+	//
+	// NB: The Owners and Created indexes are not serialized; they
+	// are rebuilt from the PoolUuidMap when the database is restored.
 	PoolDatabase struct {
-		Ranks  PoolRankMap
-		Uuids  PoolUuidMap
-		Labels PoolLabelMap
+		Ranks   PoolRankMap
+		Uuids   PoolUuidMap
+		Labels  PoolLabelMap
+		Owners  PoolOwnerMap      `json:"-"`
+		Created PoolCreationIndex `json:"-"`
+	}
+
+	// PoolServiceFilter specifies the criteria used to select a
+	// subset of pool services from the pool database. Zero-valued
+	// fields are ignored.
+	PoolServiceFilter struct {
+		Owner         string
+		CreatedBefore time.Time
+		CreatedAfter  time.Time
 	}
 )
 
+// normalizeOwner returns the owner principal in the form stored in the
+// pool database (user@[domain]), so that "alice" and "alice@" are equivalent.
+func normalizeOwner(owner string) string {
+	if owner != "" && !strings.Contains(owner, "@") {
+		return owner + "@"
+	}
+	return owner
+}
+
+// less orders pool services by creation time, then by UUID in
+// order to provide a stable ordering for identical timestamps.
+func (pci PoolCreationIndex) less(a, b *system.PoolService) bool {
+	if a.CreatedAt.Equal(b.CreatedAt) {
+		return a.PoolUUID.String() < b.PoolUUID.String()
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// insert adds the pool service to the index, preserving sort order.
+func (pci PoolCreationIndex) insert(ps *system.PoolService) PoolCreationIndex {
+	idx := sort.Search(len(pci), func(i int) bool {
+		return !pci.less(pci[i], ps)
+	})
+	pci = append(pci, nil)
+	copy(pci[idx+1:], pci[idx:])
+	pci[idx] = ps
+	return pci
+}
+
+// remove removes the pool service from the index.
+func (pci PoolCreationIndex) remove(ps *system.PoolService) PoolCreationIndex {
+	for idx, cur := range pci {
+		if cur.PoolUUID == ps.PoolUUID {
+			return append(pci[:idx], pci[idx+1:]...)
+		}
+	}
+	return pci
+}
+
+// between returns the subset of the index with creation times
+// after the first time and before the second time. Zero-valued
+// times are treated as unbounded.
+func (pci PoolCreationIndex) between(after, before time.Time) PoolCreationIndex {
+	start := 0
+	if !after.IsZero() {
+		start = sort.Search(len(pci), func(i int) bool {
+			return pci[i].CreatedAt.After(after)
+		})
+	}
+	end := len(pci)
+	if !before.IsZero() {
+		end = sort.Search(len(pci), func(i int) bool {
+			return !pci[i].CreatedAt.Before(before)
+		})
+	}
+	if start >= end {
+		return nil
+	}
+	return pci[start:end]
+}
+
+// matches returns true if the pool service satisfies the filter.
+func (psf *PoolServiceFilter) matches(ps *system.PoolService) bool {
+	if psf == nil {
+		return true
+	}
+	if psf.Owner != "" && normalizeOwner(psf.Owner) != ps.Owner {
+		return false
+	}
+	if !psf.CreatedAfter.IsZero() && !ps.CreatedAt.After(psf.CreatedAfter) {
+		return false
+	}
+	if !psf.CreatedBefore.IsZero() && !ps.CreatedAt.Before(psf.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
 // MarshalJSON creates a serialized representation of the PoolRankMap.
 // The pool's UUID is used to represent the pool service in order to
 // avoid duplicating pool service details in the serialized format.
@@ -110,13 +210,70 @@ func (pdb *PoolDatabase) UnmarshalJSON(data []byte) error {
 		pdb.Labels[label] = svc
 	}
 
+	for _, svc := range pdb.Uuids {
+		pdb.indexService(svc)
+	}
+
 	return nil
 }
 
+// indexService adds the PoolService to the secondary indexes.
+func (pdb *PoolDatabase) indexService(ps *system.PoolService) {
+	if pdb.Owners == nil {
+		pdb.Owners = make(PoolOwnerMap)
+	}
+	if ps.Owner != "" {
+		if _, exists := pdb.Owners[ps.Owner]; !exists {
+			pdb.Owners[ps.Owner] = make(PoolUuidMap)
+		}
+		pdb.Owners[ps.Owner][ps.PoolUUID] = ps
+	}
+	pdb.Created = pdb.Created.insert(ps)
+}
+
+// unindexService removes the PoolService from the secondary indexes.
+func (pdb *PoolDatabase) unindexService(ps *system.PoolService) {
+	if owned, exists := pdb.Owners[ps.Owner]; exists {
+		delete(owned, ps.PoolUUID)
+		if len(owned) == 0 {
+			delete(pdb.Owners, ps.Owner)
+		}
+	}
+	pdb.Created = pdb.Created.remove(ps)
+}
+
+// filterServices returns the set of pool services matching the
+// supplied filter, using the secondary indexes to avoid a scan of
+// the full database where possible.
+func (pdb *PoolDatabase) filterServices(filter *PoolServiceFilter) []*system.PoolService {
+	var candidates []*system.PoolService
+	switch {
+	case filter != nil && filter.Owner != "":
+		for _, ps := range pdb.Owners[normalizeOwner(filter.Owner)] {
+			candidates = append(candidates, ps)
+		}
+	case filter != nil && !(filter.CreatedAfter.IsZero() && filter.CreatedBefore.IsZero()):
+		candidates = pdb.Created.between(filter.CreatedAfter, filter.CreatedBefore)
+	default:
+		for _, ps := range pdb.Uuids {
+			candidates = append(candidates, ps)
+		}
+	}
+
+	var result []*system.PoolService
+	for _, ps := range candidates {
+		if filter.matches(ps) {
+			result = append(result, ps)
+		}
+	}
+	return result
+}
+
 // addService is responsible for adding a new PoolService entry and
 // updating all of the relevant maps.
 func (pdb *PoolDatabase) addService(ps *system.PoolService) {
 	pdb.Uuids[ps.PoolUUID] = ps
+	pdb.indexService(ps)
 	if ps.PoolLabel != "" {
 		pdb.Labels[ps.PoolLabel] = ps
 	}
@@ -132,8 +289,12 @@ func (pdb *PoolDatabase) updateService(cur, new *system.PoolService) {
 	if cur != pdb.Uuids[cur.PoolUUID] {
 		panic("PoolDatabase.updateService() called with non-member pointer")
 	}
+	pdb.unindexService(cur)
 	cur.State = new.State
+	cur.Owner = new.Owner
+	cur.CreatedAt = new.CreatedAt
 	cur.LastUpdate = new.LastUpdate
+	pdb.indexService(cur)
 
 	// TODO: Update svc rank map
 	cur.Replicas = new.Replicas
@@ -147,9 +308,29 @@ func (pdb *PoolDatabase) updateService(cur, new *system.PoolService) {
 	}
 }
 
+// renameService is responsible for updating the label maps after
+// a pool service label change. The cur PoolService pointer must exist
+// in the database.
+func (pdb *PoolDatabase) renameService(cur *system.PoolService, label string) {
+	if cur != pdb.Uuids[cur.PoolUUID] {
+		panic("PoolDatabase.renameService() called with non-member pointer")
+	}
+
+	if cur.PoolLabel != "" {
+		delete(pdb.Labels, cur.PoolLabel)
+	}
+	cur.PoolLabel = label
+	if cur.PoolLabel != "" {
+		pdb.Labels[cur.PoolLabel] = cur
+	}
+}
+
 // removeService is responsible for removing a PoolService entry and
 // updating all of the relevant maps.
 func (pdb *PoolDatabase) removeService(ps *system.PoolService) {
+	if cur, found := pdb.Uuids[ps.PoolUUID]; found {
+		pdb.unindexService(cur)
+	}
 	delete(pdb.Uuids, ps.PoolUUID)
 	if ps.PoolLabel != "" {
 		delete(pdb.Labels, ps.PoolLabel)
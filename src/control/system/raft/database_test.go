@@ -236,6 +236,8 @@ func TestSystem_Database_SnapshotRestore(t *testing.T) {
 	maxPools := 1024
 	maxAttrs := 4096
 	maxFindings := 512
+	maxSnapshots := 64
+	maxEvents := 64
 
 	log, buf := logging.NewTestLogger(t.Name())
 	defer test.ShowBufferOnFailure(t, buf)
@@ -302,6 +304,40 @@ func TestSystem_Database_SnapshotRestore(t *testing.T) {
 		(*fsm)(db0).Apply(rl)
 	}
 
+	for i := 0; i < maxSnapshots; i++ {
+		hs := NewHealthSnapshot(time.Now(), []*Member{
+			{Rank: Rank(i), State: MemberStateJoined},
+		}, []*PoolHealthSummary{
+			{PoolUUID: uuid.New(), State: "Ready", TotalBytes: uint64(i)},
+		})
+		data, err := createRaftUpdate(raftOpAddHealthSnapshot, hs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rl := &raft.Log{
+			Data: data,
+		}
+		(*fsm)(db0).Apply(rl)
+	}
+
+	for i := 0; i < maxEvents; i++ {
+		data, err := createRaftUpdate(raftOpAddEvent, &PersistentEvent{
+			RASID:     "engine_died",
+			Hostname:  fmt.Sprintf("host%04d", i),
+			Rank:      uint32(i),
+			FirstSeen: time.Now(),
+			LastSeen:  time.Now(),
+			Count:     1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rl := &raft.Log{
+			Data: data,
+		}
+		(*fsm)(db0).Apply(rl)
+	}
+
 	attrs := make(map[string]string)
 	for i := 0; i < maxAttrs; i++ {
 		attrs[fmt.Sprintf("prop%04d", i)] = fmt.Sprintf("value%04d", i)
@@ -592,6 +628,236 @@ func TestSystem_Database_memberRaftOps(t *testing.T) {
 	}
 }
 
+func TestSystem_Database_CompactMemberAddrs(t *testing.T) {
+	sharedAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 10001}
+	otherAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 10001}
+	oldAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 3), Port: 10001}
+
+	newMembers := func() []*Member {
+		return []*Member{
+			{Rank: 0, UUID: uuid.New(), Addr: sharedAddr, State: MemberStateJoined},
+			{Rank: 1, UUID: uuid.New(), Addr: sharedAddr, State: MemberStateJoined},
+			{Rank: 2, UUID: uuid.New(), Addr: otherAddr, State: MemberStateJoined},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		setup      func(*Database, []*Member)
+		expRemoved int
+		expAddrs   func([]*Member) map[string][]uuid.UUID
+	}{
+		"nothing to compact": {
+			expAddrs: func(ms []*Member) map[string][]uuid.UUID {
+				return map[string][]uuid.UUID{
+					sharedAddr.String(): {ms[0].UUID, ms[1].UUID},
+					otherAddr.String():  {ms[2].UUID},
+				}
+			},
+		},
+		"member removed from shared address": {
+			setup: func(db *Database, ms []*Member) {
+				raftUpdateTestMember(t, db, raftOpRemoveMember, ms[0])
+			},
+			expAddrs: func(ms []*Member) map[string][]uuid.UUID {
+				return map[string][]uuid.UUID{
+					sharedAddr.String(): {ms[1].UUID},
+					otherAddr.String():  {ms[2].UUID},
+				}
+			},
+		},
+		"entry for unknown member": {
+			setup: func(db *Database, _ []*Member) {
+				db.data.Members.Addrs[oldAddr.String()] = []*Member{
+					{Rank: 5, UUID: uuid.New(), Addr: oldAddr},
+				}
+			},
+			expRemoved: 1,
+			expAddrs: func(ms []*Member) map[string][]uuid.UUID {
+				return map[string][]uuid.UUID{
+					sharedAddr.String(): {ms[0].UUID, ms[1].UUID},
+					otherAddr.String():  {ms[2].UUID},
+				}
+			},
+		},
+		"entry for re-addressed member": {
+			setup: func(db *Database, ms []*Member) {
+				cur := db.data.Members.Uuids[ms[2].UUID]
+				db.data.Members.Addrs[oldAddr.String()] = []*Member{cur}
+			},
+			expRemoved: 1,
+			expAddrs: func(ms []*Member) map[string][]uuid.UUID {
+				return map[string][]uuid.UUID{
+					sharedAddr.String(): {ms[0].UUID, ms[1].UUID},
+					otherAddr.String():  {ms[2].UUID},
+				}
+			},
+		},
+		"stale copy and duplicate entries": {
+			setup: func(db *Database, ms []*Member) {
+				shared := db.data.Members.Addrs[sharedAddr.String()]
+				db.data.Members.Addrs[sharedAddr.String()] = append(shared,
+					shared[0], copyMember(shared[1]))
+			},
+			expRemoved: 2,
+			expAddrs: func(ms []*Member) map[string][]uuid.UUID {
+				return map[string][]uuid.UUID{
+					sharedAddr.String(): {ms[0].UUID, ms[1].UUID},
+					otherAddr.String():  {ms[2].UUID},
+				}
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+			members := newMembers()
+			for _, m := range members {
+				raftUpdateTestMember(t, db, raftOpAddMember, m)
+			}
+			if tc.setup != nil {
+				tc.setup(db, members)
+			}
+			startVersion := db.data.Version
+
+			gotRemoved, err := db.CompactMemberAddrs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expRemoved, gotRemoved, "unexpected number of entries removed")
+
+			gotAddrs := make(map[string][]uuid.UUID)
+			for addr, ms := range db.data.Members.Addrs {
+				for _, m := range ms {
+					gotAddrs[addr] = append(gotAddrs[addr], m.UUID)
+				}
+			}
+			if diff := cmp.Diff(tc.expAddrs(members), gotAddrs); diff != "" {
+				t.Fatalf("unexpected address map (-want, +got):\n%s\n", diff)
+			}
+
+			// An update is only submitted if there was something to remove.
+			expVersion := startVersion
+			if tc.expRemoved > 0 {
+				expVersion++
+			}
+			test.AssertEqual(t, expVersion, db.data.Version, "unexpected db version")
+
+			if removed, err := db.CompactMemberAddrs(); err != nil || removed != 0 {
+				t.Fatalf("second compaction removed %d entries (err: %v)", removed, err)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_UpdateMemberAddr(t *testing.T) {
+	leaderAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 10001}
+	oldAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 10001}
+	newAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 3), Port: 10001}
+	hostID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		memberHostID uuid.UUID
+		reqHostID    uuid.UUID
+		replicas     []*net.TCPAddr
+		voters       []raft.Server
+		expVoters    []raft.Server
+	}{
+		"not a replica": {
+			memberHostID: hostID,
+			reqHostID:    hostID,
+		},
+		"voter identified by host ID": {
+			memberHostID: hostID,
+			reqHostID:    hostID,
+			replicas:     []*net.TCPAddr{newAddr},
+			voters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(hostID.String()), Address: raft.ServerAddress(oldAddr.String())},
+			},
+			expVoters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(hostID.String()), Address: raft.ServerAddress(newAddr.String())},
+			},
+		},
+		"voter identified by address": {
+			memberHostID: hostID,
+			reqHostID:    hostID,
+			replicas:     []*net.TCPAddr{newAddr},
+			voters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(oldAddr.String()), Address: raft.ServerAddress(oldAddr.String())},
+			},
+			expVoters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(oldAddr.String()), Address: raft.ServerAddress(newAddr.String())},
+			},
+		},
+		"host ID recorded on rejoin": {
+			reqHostID: hostID,
+			replicas:  []*net.TCPAddr{newAddr},
+			voters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(hostID.String()), Address: raft.ServerAddress(oldAddr.String())},
+			},
+			expVoters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(hostID.String()), Address: raft.ServerAddress(newAddr.String())},
+			},
+		},
+		"no host ID": {
+			replicas: []*net.TCPAddr{newAddr},
+			voters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(oldAddr.String()), Address: raft.ServerAddress(oldAddr.String())},
+			},
+			expVoters: []raft.Server{
+				{Suffrage: raft.Voter, ID: raft.ServerID(oldAddr.String()), Address: raft.ServerAddress(newAddr.String())},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabaseWithAddr(t, log, leaderAddr)
+			db.cfg.Replicas = append(db.cfg.Replicas, tc.replicas...)
+			svc := db.raft.svc.(*mockRaftService)
+			svc.cfg.Servers = tc.voters
+
+			member := &Member{
+				Rank:   1,
+				UUID:   uuid.New(),
+				Addr:   oldAddr,
+				HostID: tc.memberHostID,
+				State:  MemberStateJoined,
+			}
+			raftUpdateTestMember(t, db, raftOpAddMember, member)
+
+			updated := copyMember(member)
+			updated.Addr = newAddr
+			updated.HostID = tc.reqHostID
+			if err := db.UpdateMember(updated); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := db.FindMemberByUUID(member.UUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, newAddr.String(), got.Addr.String(), "unexpected member address")
+			test.AssertEqual(t, tc.reqHostID, got.HostID, "unexpected member host ID")
+
+			if ms, err := db.FindMembersByAddr(oldAddr); err == nil {
+				t.Fatalf("expected no members at old address, got %v", ms)
+			}
+			ms, err := db.FindMembersByAddr(newAddr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, 1, len(ms), "unexpected number of members at new address")
+
+			if diff := cmp.Diff(tc.expVoters, svc.cfg.Servers, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("unexpected raft voters (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestSystem_Database_memberFaultDomain(t *testing.T) {
 	for name, tc := range map[string]struct {
 		rank        Rank
@@ -717,6 +983,348 @@ func TestSystem_Database_SystemAttrs(t *testing.T) {
 	}
 }
 
+func TestSystem_Database_HealthSnapshots(t *testing.T) {
+	mockSnapshot := func(i int) *HealthSnapshot {
+		return NewHealthSnapshot(time.Unix(int64(i), 0), []*Member{
+			{Rank: Rank(i), State: MemberStateJoined},
+		}, nil)
+	}
+
+	for name, tc := range map[string]struct {
+		numAdded int
+		max      int
+		expFirst int
+		expCount int
+	}{
+		"empty history": {},
+		"full history": {
+			numAdded: 10,
+			expCount: 10,
+		},
+		"most recent": {
+			numAdded: 10,
+			max:      3,
+			expFirst: 7,
+			expCount: 3,
+		},
+		"max exceeds history": {
+			numAdded: 2,
+			max:      5,
+			expCount: 2,
+		},
+		"oldest discarded": {
+			numAdded: MaxHealthSnapshots + 5,
+			expFirst: 5,
+			expCount: MaxHealthSnapshots,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+			for i := 0; i < tc.numAdded; i++ {
+				if err := db.AddHealthSnapshot(mockSnapshot(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotSnaps, err := db.HealthSnapshots(tc.max)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(gotSnaps) != tc.expCount {
+				t.Fatalf("expected %d snapshots, got %d", tc.expCount, len(gotSnaps))
+			}
+			for i, hs := range gotSnaps {
+				if diff := cmp.Diff(mockSnapshot(tc.expFirst+i), hs); diff != "" {
+					t.Fatalf("unexpected snapshot %d (-want, +got):\n%s\n", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSystem_Database_Events(t *testing.T) {
+	mockEvent := func(host string, rank uint32) *PersistentEvent {
+		return &PersistentEvent{
+			RASID:     "engine_died",
+			Severity:  "ERROR",
+			Msg:       "engine died",
+			Hostname:  host,
+			Rank:      rank,
+			FirstSeen: time.Unix(1, 0),
+			LastSeen:  time.Unix(1, 0),
+			Count:     1,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		added      []*PersistentEvent
+		batched    bool
+		acks       [][]uint64
+		removed    []uint64
+		activeOnly bool
+		expErr     error
+		expIDs     []uint64
+		expCounts  []uint32
+		expAcked   []bool
+	}{
+		"empty log": {},
+		"distinct events": {
+			added:     []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1), mockEvent("bar", 0)},
+			expIDs:    []uint64{1, 2, 3},
+			expCounts: []uint32{1, 1, 1},
+			expAcked:  []bool{false, false, false},
+		},
+		"repeated event while active": {
+			added:     []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1), mockEvent("foo", 0)},
+			expIDs:    []uint64{1, 2},
+			expCounts: []uint32{2, 1},
+			expAcked:  []bool{false, false},
+		},
+		"batched events": {
+			added: []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1), mockEvent("foo", 0),
+				mockEvent("bar", 0)},
+			batched:   true,
+			expIDs:    []uint64{1, 2, 3},
+			expCounts: []uint32{2, 1, 1},
+			expAcked:  []bool{false, false, false},
+		},
+		"ack events": {
+			added:     []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1), mockEvent("foo", 2)},
+			acks:      [][]uint64{{1, 3}},
+			expIDs:    []uint64{1, 2, 3},
+			expCounts: []uint32{1, 1, 1},
+			expAcked:  []bool{true, false, true},
+		},
+		"active only": {
+			added:      []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1)},
+			acks:       [][]uint64{{1}},
+			activeOnly: true,
+			expIDs:     []uint64{2},
+			expCounts:  []uint32{1},
+			expAcked:   []bool{false},
+		},
+		"remove acknowledged events": {
+			added:     []*PersistentEvent{mockEvent("foo", 0), mockEvent("foo", 1), mockEvent("foo", 2)},
+			acks:      [][]uint64{{1, 2}},
+			removed:   []uint64{2, 3, 4},
+			expIDs:    []uint64{1, 3},
+			expCounts: []uint32{1, 1},
+			expAcked:  []bool{true, false},
+		},
+		"ack unknown event": {
+			added:     []*PersistentEvent{mockEvent("foo", 0)},
+			acks:      [][]uint64{{1, 2}},
+			expErr:    ErrEventNotFound(2),
+			expIDs:    []uint64{1},
+			expCounts: []uint32{1},
+			expAcked:  []bool{false},
+		},
+		"ack already acknowledged": {
+			added:     []*PersistentEvent{mockEvent("foo", 0)},
+			acks:      [][]uint64{{1}, {1}},
+			expErr:    errors.New("already acknowledged"),
+			expIDs:    []uint64{1},
+			expCounts: []uint32{1},
+			expAcked:  []bool{true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+			if tc.batched {
+				if err := db.AddEvents(tc.added...); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				for _, pe := range tc.added {
+					if err := db.AddEvent(pe); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var ackErr error
+			for _, ids := range tc.acks {
+				if ackErr = db.AckEvents(ids...); ackErr != nil {
+					break
+				}
+			}
+			test.CmpErr(t, tc.expErr, ackErr)
+
+			if err := db.RemoveEvents(tc.removed...); err != nil {
+				t.Fatal(err)
+			}
+
+			gotEvts, err := db.Events(tc.activeOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotIDs []uint64
+			var gotCounts []uint32
+			var gotAcked []bool
+			for _, pe := range gotEvts {
+				gotIDs = append(gotIDs, pe.ID)
+				gotCounts = append(gotCounts, pe.Count)
+				gotAcked = append(gotAcked, pe.Acknowledged)
+				if pe.Acknowledged == pe.AckTime.IsZero() {
+					t.Errorf("event %d: unexpected ack time %s", pe.ID, pe.AckTime)
+				}
+			}
+			if diff := cmp.Diff(tc.expIDs, gotIDs); diff != "" {
+				t.Fatalf("unexpected event IDs (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expCounts, gotCounts); diff != "" {
+				t.Fatalf("unexpected event counts (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expAcked, gotAcked); diff != "" {
+				t.Fatalf("unexpected event ack states (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_EventLog_add(t *testing.T) {
+	mockLog := func(acked ...bool) EventLog {
+		el := EventLog{}
+		for i, a := range acked {
+			el = append(el, &PersistentEvent{ID: uint64(i + 1), Acknowledged: a})
+		}
+		return el
+	}
+	ids := func(el EventLog) (out []uint64) {
+		for _, pe := range el {
+			out = append(out, pe.ID)
+		}
+		return
+	}
+
+	for name, tc := range map[string]struct {
+		el         EventLog
+		max        int
+		expIDs     []uint64
+		expEvicted []uint64
+	}{
+		"below max": {
+			el:     mockLog(false, false),
+			max:    4,
+			expIDs: []uint64{1, 2, 99},
+		},
+		"oldest acknowledged discarded": {
+			el:         mockLog(false, true, true),
+			max:        3,
+			expIDs:     []uint64{1, 3, 99},
+			expEvicted: []uint64{2},
+		},
+		"oldest active discarded": {
+			el:         mockLog(false, false, false),
+			max:        3,
+			expIDs:     []uint64{2, 3, 99},
+			expEvicted: []uint64{1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, evicted := tc.el.add(&PersistentEvent{ID: 99}, tc.max)
+			if diff := cmp.Diff(tc.expIDs, ids(got)); diff != "" {
+				t.Fatalf("unexpected event IDs (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expEvicted, ids(evicted)); diff != "" {
+				t.Fatalf("unexpected evicted event IDs (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_recordEvent(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+	mockRASEvent := func(rank uint32) *events.RASEvent {
+		return &events.RASEvent{
+			ID:        events.RASEngineDied,
+			Timestamp: common.FormatTime(time.Now()),
+			Severity:  events.RASSeverityError,
+			Msg:       "engine died",
+			Hostname:  "foo",
+			Rank:      rank,
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		db.recordEvent(mockRASEvent(0))
+	}
+	db.recordEvent(mockRASEvent(1))
+
+	db.eventQueue.Lock()
+	if db.eventQueue.timer == nil {
+		t.Fatal("expected flush to be scheduled")
+	}
+	db.eventQueue.timer.Stop()
+	test.AssertEqual(t, 2, len(db.eventQueue.pending), "repeated events not merged in queue")
+	db.eventQueue.Unlock()
+
+	gotEvts, err := db.Events(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, 0, len(gotEvts), "events recorded before flush")
+
+	db.flushEvents()
+
+	gotEvts, err = db.Events(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotCounts []uint32
+	for _, pe := range gotEvts {
+		gotCounts = append(gotCounts, pe.Count)
+	}
+	if diff := cmp.Diff([]uint32{5, 1}, gotCounts); diff != "" {
+		t.Fatalf("unexpected event counts (-want, +got):\n%s\n", diff)
+	}
+	test.AssertEqual(t, 0, len(db.eventQueue.pending), "queue not emptied by flush")
+	test.AssertTrue(t, db.eventQueue.timer == nil, "flush still scheduled")
+}
+
+func TestSystem_Database_OnEventsEvicted(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+	evictedCh := make(chan []*PersistentEvent, 1)
+	db.OnEventsEvicted(func(evicted []*PersistentEvent) {
+		evictedCh <- evicted
+	})
+
+	pes := make([]*PersistentEvent, MaxPersistentEvents+1)
+	for i := range pes {
+		pes[i] = &PersistentEvent{
+			RASID:    "engine_died",
+			Severity: "ERROR",
+			Hostname: "foo",
+			Rank:     uint32(i),
+		}
+	}
+	if err := db.AddEvents(pes...); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case evicted := <-evictedCh:
+		test.AssertEqual(t, 1, len(evicted), "unexpected number of evicted events")
+		test.AssertEqual(t, uint64(1), evicted[0].ID, "unexpected evicted event")
+	case <-time.After(time.Second):
+		t.Fatal("evicted events not passed to callback")
+	}
+}
+
 func TestSystem_Database_OnEvent(t *testing.T) {
 	puuid := uuid.New()
 	puuidAnother := uuid.New()
@@ -820,6 +1428,66 @@ func TestSystem_Database_OnEvent(t *testing.T) {
 	}
 }
 
+func TestSystem_Database_PoolActivity(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ctx := test.Context(t)
+	db := MockDatabase(t, log)
+
+	poolUUID := uuid.New()
+	lock, err := db.TakePoolLock(ctx, poolUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	lockCtx := lock.InContext(ctx)
+	if err := db.AddPoolService(lockCtx, &PoolService{
+		PoolUUID:  poolUUID,
+		PoolLabel: "pool0001",
+		State:     system.PoolServiceStateReady,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < MaxPoolActivity+2; i++ {
+		if err := db.AddPoolActivity(poolUUID, NewPoolActivity(PoolActivityExclude, "rank %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Records for unknown pools are discarded.
+	if err := db.AddPoolActivity(uuid.New(), NewPoolActivity(PoolActivityCreate, "unknown")); err != nil {
+		t.Fatal(err)
+	}
+
+	db.OnEvent(ctx, &events.RASEvent{
+		ID:           events.RASPoolRebuildStart,
+		Timestamp:    common.FormatTime(time.Now()),
+		Msg:          "Pool rebuild started.",
+		Rank:         3,
+		PoolUUID:     poolUUID.String(),
+		ExtendedInfo: events.NewStrInfo("map_ver: [4] op: [Exclude]"),
+	})
+
+	gotActivity, err := db.PoolActivity(poolUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, MaxPoolActivity, len(gotActivity), "unexpected number of records")
+	test.AssertEqual(t, "rank 3", gotActivity[0].Msg, "oldest records not discarded")
+	last := gotActivity[len(gotActivity)-1]
+	test.AssertEqual(t, PoolActivityRebuild, last.Kind, "unexpected kind")
+	test.AssertEqual(t, "rank 3: Pool rebuild started (map_ver: [4] op: [Exclude])", last.Msg,
+		"unexpected message")
+
+	if err := db.RemovePoolService(lockCtx, poolUUID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.PoolActivity(poolUUID)
+	test.CmpErr(t, ErrPoolUUIDNotFound(poolUUID), err)
+	test.AssertEqual(t, 0, len(db.data.PoolActivity), "activity not removed with pool")
+}
+
 func TestSystemDatabase_PoolServiceList(t *testing.T) {
 	ready := &PoolService{
 		PoolUUID:   uuid.New(),
@@ -898,6 +1566,295 @@ func TestSystemDatabase_PoolServiceList(t *testing.T) {
 	}
 }
 
+func TestSystemDatabase_FilterPoolServices(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockPoolSvc := func(idx int, owner string, state system.PoolServiceState) *PoolService {
+		return &PoolService{
+			PoolUUID:   uuid.New(),
+			PoolLabel:  fmt.Sprintf("pool%04d", idx),
+			Owner:      owner,
+			State:      state,
+			Replicas:   []Rank{1, 2, 3},
+			CreatedAt:  baseTime.Add(time.Duration(idx) * time.Hour),
+			LastUpdate: time.Now(),
+		}
+	}
+	alice1 := mockPoolSvc(1, "alice@", system.PoolServiceStateReady)
+	bob2 := mockPoolSvc(2, "bob@", system.PoolServiceStateReady)
+	alice3 := mockPoolSvc(3, "alice@", system.PoolServiceStateReady)
+	alice4 := mockPoolSvc(4, "alice@", system.PoolServiceStateCreating)
+	allPools := []*PoolService{alice1, bob2, alice3, alice4}
+
+	for name, tc := range map[string]struct {
+		poolSvcs    []*PoolService
+		all         bool
+		filter      *PoolServiceFilter
+		expPoolSvcs []*PoolService
+	}{
+		"empty": {
+			filter:      &PoolServiceFilter{Owner: "alice"},
+			expPoolSvcs: []*PoolService{},
+		},
+		"nil filter": {
+			poolSvcs:    allPools,
+			expPoolSvcs: []*PoolService{alice1, bob2, alice3},
+		},
+		"owner without domain": {
+			poolSvcs:    allPools,
+			filter:      &PoolServiceFilter{Owner: "alice"},
+			expPoolSvcs: []*PoolService{alice1, alice3},
+		},
+		"owner with domain; all": {
+			poolSvcs:    allPools,
+			all:         true,
+			filter:      &PoolServiceFilter{Owner: "alice@"},
+			expPoolSvcs: []*PoolService{alice1, alice3, alice4},
+		},
+		"unknown owner": {
+			poolSvcs:    allPools,
+			filter:      &PoolServiceFilter{Owner: "carol"},
+			expPoolSvcs: []*PoolService{},
+		},
+		"created before": {
+			poolSvcs:    allPools,
+			all:         true,
+			filter:      &PoolServiceFilter{CreatedBefore: baseTime.Add(3 * time.Hour)},
+			expPoolSvcs: []*PoolService{alice1, bob2},
+		},
+		"created after": {
+			poolSvcs:    allPools,
+			all:         true,
+			filter:      &PoolServiceFilter{CreatedAfter: baseTime.Add(2 * time.Hour)},
+			expPoolSvcs: []*PoolService{alice3, alice4},
+		},
+		"created between": {
+			poolSvcs: allPools,
+			all:      true,
+			filter: &PoolServiceFilter{
+				CreatedAfter:  baseTime,
+				CreatedBefore: baseTime.Add(3 * time.Hour),
+			},
+			expPoolSvcs: []*PoolService{alice1, bob2},
+		},
+		"owner and created after": {
+			poolSvcs: allPools,
+			all:      true,
+			filter: &PoolServiceFilter{
+				Owner:        "alice",
+				CreatedAfter: baseTime.Add(time.Hour),
+			},
+			expPoolSvcs: []*PoolService{alice3, alice4},
+		},
+		"empty time range": {
+			poolSvcs: allPools,
+			all:      true,
+			filter: &PoolServiceFilter{
+				CreatedAfter:  baseTime.Add(3 * time.Hour),
+				CreatedBefore: baseTime.Add(2 * time.Hour),
+			},
+			expPoolSvcs: []*PoolService{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ctx := test.Context(t)
+			db := MockDatabase(t, log)
+			for _, ps := range tc.poolSvcs {
+				lock, err := db.TakePoolLock(ctx, ps.PoolUUID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := db.AddPoolService(lock.InContext(ctx), ps); err != nil {
+					t.Fatal(err)
+				}
+				lock.Release()
+			}
+
+			poolSvcs, err := db.FilterPoolServices(tc.all, tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmpOpts := []cmp.Option{
+				cmpopts.SortSlices(func(x, y *PoolService) bool {
+					return x.PoolLabel < y.PoolLabel
+				}),
+				cmpopts.IgnoreUnexported(PoolService{}),
+				cmpopts.EquateApproxTime(time.Second),
+			}
+			if diff := cmp.Diff(tc.expPoolSvcs, poolSvcs, cmpOpts...); diff != "" {
+				t.Errorf("unexpected pool services (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_PoolIndexesRemove(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ctx := test.Context(t)
+	db := MockDatabase(t, log)
+
+	ps := &PoolService{
+		PoolUUID:  uuid.New(),
+		PoolLabel: "pool0001",
+		Owner:     "alice@",
+		State:     system.PoolServiceStateReady,
+		CreatedAt: time.Now(),
+	}
+	lock, err := db.TakePoolLock(ctx, ps.PoolUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+
+	if err := db.AddPoolService(lock.InContext(ctx), ps); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RemovePoolService(lock.InContext(ctx), ps.PoolUUID); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(db.data.Pools.Owners) != 0 {
+		t.Fatalf("expected empty owner index, got %+v", db.data.Pools.Owners)
+	}
+	if len(db.data.Pools.Created) != 0 {
+		t.Fatalf("expected empty creation index, got %+v", db.data.Pools.Created)
+	}
+}
+
+func TestSystem_Database_RenamePoolService(t *testing.T) {
+	pool1 := test.MockPoolUUID(1)
+	pool2 := test.MockPoolUUID(2)
+
+	for name, tc := range map[string]struct {
+		poolUUID  uuid.UUID
+		newLabel  string
+		expLabels map[string]uuid.UUID
+		expErr    error
+	}{
+		"unknown pool": {
+			poolUUID: test.MockPoolUUID(3),
+			newLabel: "new",
+			expLabels: map[string]uuid.UUID{
+				"pool1": pool1,
+				"pool2": pool2,
+			},
+			expErr: system.ErrPoolUUIDNotFound(test.MockPoolUUID(3)),
+		},
+		"label in use": {
+			poolUUID: pool1,
+			newLabel: "pool2",
+			expLabels: map[string]uuid.UUID{
+				"pool1": pool1,
+				"pool2": pool2,
+			},
+			expErr: &system.ErrPoolLabelExists{Label: "pool2", PoolUUID: pool2},
+		},
+		"same label": {
+			poolUUID: pool1,
+			newLabel: "pool1",
+			expLabels: map[string]uuid.UUID{
+				"pool1": pool1,
+				"pool2": pool2,
+			},
+		},
+		"success": {
+			poolUUID: pool1,
+			newLabel: "new",
+			expLabels: map[string]uuid.UUID{
+				"new":   pool1,
+				"pool2": pool2,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			ctx := test.Context(t)
+			db := MockDatabase(t, log)
+			for i, poolUUID := range []uuid.UUID{pool1, pool2} {
+				ps := &PoolService{
+					PoolUUID:  poolUUID,
+					PoolLabel: fmt.Sprintf("pool%d", i+1),
+					State:     system.PoolServiceStateReady,
+				}
+				lock, err := db.TakePoolLock(ctx, poolUUID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := db.AddPoolService(lock.InContext(ctx), ps); err != nil {
+					t.Fatal(err)
+				}
+				lock.Release()
+			}
+
+			lock, err := db.TakePoolLock(ctx, tc.poolUUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lock.Release()
+
+			gotErr := db.RenamePoolService(lock.InContext(ctx), tc.poolUUID, tc.newLabel)
+			test.CmpErr(t, tc.expErr, gotErr)
+
+			gotLabels := make(map[string]uuid.UUID)
+			for label, ps := range db.data.Pools.Labels {
+				if ps.PoolLabel != label {
+					t.Fatalf("label map entry %q points to pool with label %q", label, ps.PoolLabel)
+				}
+				gotLabels[label] = ps.PoolUUID
+			}
+			if diff := cmp.Diff(tc.expLabels, gotLabels); diff != "" {
+				t.Fatalf("unexpected labels (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_applyPoolRename_Stale(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	ctx := test.Context(t)
+	db := MockDatabase(t, log)
+	ps := &PoolService{
+		PoolUUID:  test.MockPoolUUID(1),
+		PoolLabel: "pool1",
+		State:     system.PoolServiceStateReady,
+	}
+	lock, err := db.TakePoolLock(ctx, ps.PoolUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	if err := db.AddPoolService(lock.InContext(ctx), ps); err != nil {
+		t.Fatal(err)
+	}
+	startVer := db.data.Version
+
+	// Simulate a rename that was validated against a label that has
+	// since been changed by another update.
+	gotErr := db.submitPoolRename(&poolRename{
+		PoolUUID: ps.PoolUUID,
+		OldLabel: "stale",
+		NewLabel: "new",
+	})
+	test.CmpErr(t, errors.New("label changed during rename"), gotErr)
+
+	if _, found := db.data.Pools.Labels["pool1"]; !found {
+		t.Fatal("expected original label to be preserved")
+	}
+	if db.data.Version != startVer {
+		t.Fatalf("expected version %d, got %d", startVer, db.data.Version)
+	}
+}
+
 func TestSystem_Database_GroupMap(t *testing.T) {
 	membersWithStates := func(states ...MemberState) []*Member {
 		members := make([]*Member, len(states))
@@ -1062,6 +2019,47 @@ func TestSystem_Database_GroupMap(t *testing.T) {
 	}
 }
 
+func TestSystem_Database_GroupMap_Cached(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+	for i := 0; i < 3; i++ {
+		if err := db.AddMember(MockMember(t, uint32(i), MemberStateJoined)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gm1, err := db.GroupMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gm2, err := db.GroupMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gm1 != gm2 {
+		t.Fatal("expected group map to be reused for the same map version")
+	}
+
+	m, err := db.FindMemberByRank(Rank(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.State = MemberStateExcluded
+	if err := db.UpdateMember(m); err != nil {
+		t.Fatal(err)
+	}
+
+	gm3, err := db.GroupMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, gm1.Version+1, gm3.Version, "unexpected map version")
+	test.AssertEqual(t, 2, len(gm3.RankEntries), "unexpected number of ranks in new map")
+	test.AssertEqual(t, 3, len(gm1.RankEntries), "previous map was modified")
+}
+
 func Test_Database_ResignLeadership(t *testing.T) {
 	for name, tc := range map[string]struct {
 		cause     error
@@ -1189,3 +2187,156 @@ func TestDatabase_TakePoolLock(t *testing.T) {
 		})
 	}
 }
+
+func TestSystem_Database_StateHash(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	apply := func(t *testing.T, db *Database, op raftOp, inner interface{}) {
+		t.Helper()
+		data, err := createRaftUpdate(op, inner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		(*fsm)(db).Apply(&raft.Log{Data: data})
+	}
+	populate := func(t *testing.T, db *Database) {
+		t.Helper()
+		for i := 0; i < 8; i++ {
+			apply(t, db, raftOpAddMember, &memberUpdate{
+				Member: &Member{
+					Rank:        Rank(i),
+					UUID:        uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-%012d", i)),
+					Addr:        &net.TCPAddr{IP: net.IPv4(127, 0, 0, byte(i/2+1)), Port: 10001},
+					State:       MemberStateJoined,
+					FaultDomain: MustCreateFaultDomainFromString("/my/test/domain"),
+				},
+				NextRank: true,
+			})
+		}
+		for i := 0; i < 4; i++ {
+			apply(t, db, raftOpAddPoolService, &PoolService{
+				PoolUUID:  uuid.MustParse(fmt.Sprintf("10000000-0000-0000-0000-%012d", i)),
+				PoolLabel: fmt.Sprintf("pool%04d", i),
+				State:     system.PoolServiceStateReady,
+				Replicas:  []Rank{0, 1, 2},
+			})
+		}
+		apply(t, db, raftOpUpdateSystemAttrs, map[string]string{"foo": "bar"})
+	}
+
+	db0 := MockDatabase(t, log)
+	populate(t, db0)
+	hash0, err := db0.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, uint64(13), hash0.Index, "unexpected state index")
+
+	// The same updates applied to another replica produce the same hash.
+	db1 := MockDatabase(t, log)
+	populate(t, db1)
+	hash1, err := db1.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(hash0, hash1); diff != "" {
+		t.Fatalf("unexpected hash (-want, +got):\n%s\n", diff)
+	}
+
+	// As does a replica restored from a snapshot.
+	snap, err := (*fsm)(db0).Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &testSnapshotSink{}
+	if err := snap.Persist(sink); err != nil {
+		t.Fatal(err)
+	}
+	db2 := MockDatabase(t, log)
+	if err := (*fsm)(db2).Restore(sink.Reader()); err != nil {
+		t.Fatal(err)
+	}
+	hash2, err := db2.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(hash0, hash2); diff != "" {
+		t.Fatalf("unexpected hash after restore (-want, +got):\n%s\n", diff)
+	}
+
+	// A divergent replica with the same version produces a different hash.
+	db1.data.System.Attributes["foo"] = "baz"
+	hash1, err = db1.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, hash0.Index, hash1.Index, "unexpected state index")
+	if hash0.Hash == hash1.Hash {
+		t.Fatal("expected divergent state to produce a different hash")
+	}
+}
+
+func TestSystem_Database_StateHashAt(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+	apply := func(t *testing.T, attrs map[string]string) {
+		t.Helper()
+		data, err := createRaftUpdate(raftOpUpdateSystemAttrs, attrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		(*fsm)(db).Apply(&raft.Log{Data: data})
+	}
+	apply(t, map[string]string{"foo": "bar"})
+
+	// Current index.
+	cur, err := db.StateHashAt(test.Context(t), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEqual(t, uint64(1), cur.Index, "unexpected state index")
+
+	// Future index, hashed when applied.
+	resCh := make(chan *stateHashResult)
+	go func() {
+		hash, err := db.StateHashAt(test.Context(t), 2)
+		resCh <- &stateHashResult{hash: hash, err: err}
+	}()
+	for {
+		db.hashWaiters.Lock()
+		waiting := len(db.hashWaiters.waiters)
+		db.hashWaiters.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	apply(t, map[string]string{"foo": "baz"})
+	expHash, err := db.StateHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apply(t, map[string]string{"foo": "qux"})
+
+	res := <-resCh
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+	if diff := cmp.Diff(expHash, res.hash); diff != "" {
+		t.Fatalf("unexpected hash (-want, +got):\n%s\n", diff)
+	}
+
+	// Passed index.
+	_, err = db.StateHashAt(test.Context(t), 1)
+	test.CmpErr(t, ErrStateIndexPassed, err)
+
+	// Timed out waiting.
+	ctx, cancel := context.WithTimeout(test.Context(t), 10*time.Millisecond)
+	defer cancel()
+	_, err = db.StateHashAt(ctx, 10)
+	test.CmpErr(t, context.DeadlineExceeded, err)
+	test.AssertEqual(t, 0, len(db.hashWaiters.waiters), "expected waiter to be removed")
+}
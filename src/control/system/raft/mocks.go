@@ -7,6 +7,9 @@
 package raft
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -25,37 +28,82 @@ type (
 		err      error
 		index    uint64
 		response interface{}
+		config   raft.Configuration
 	}
 	mockRaftServiceConfig struct {
 		LeaderCh              <-chan bool
 		ServerAddress         raft.ServerAddress
 		State                 raft.RaftState
 		LeadershipTransferErr error
+		Servers               []raft.Server
 	}
 	mockRaftService struct {
 		cfg mockRaftServiceConfig
 		fsm raft.FSM
 	}
+	mockSnapshotSink struct {
+		bytes.Buffer
+	}
+	mockSnapshotFuture struct {
+		mockRaftFuture
+		meta *raft.SnapshotMeta
+		data []byte
+	}
 )
 
+// mockSnapshotSink implements raft.SnapshotSink
+func (mss *mockSnapshotSink) ID() string    { return "mock" }
+func (mss *mockSnapshotSink) Cancel() error { return nil }
+func (mss *mockSnapshotSink) Close() error  { return nil }
+
+// mockSnapshotFuture implements raft.SnapshotFuture
+func (msf *mockSnapshotFuture) Open() (*raft.SnapshotMeta, io.ReadCloser, error) {
+	return msf.meta, io.NopCloser(bytes.NewReader(msf.data)), nil
+}
+
 // mockRaftFuture implements raft.Future, raft.IndexFuture, and raft.ApplyFuture
 func (mrf *mockRaftFuture) Error() error          { return mrf.err }
 func (mrf *mockRaftFuture) Index() uint64         { return mrf.index }
 func (mrf *mockRaftFuture) Response() interface{} { return mrf.response }
 
+// mockRaftFuture also implements raft.ConfigurationFuture
+func (mrf *mockRaftFuture) Configuration() raft.Configuration { return mrf.config }
+
 func (mrs *mockRaftService) Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture {
-	mrs.fsm.Apply(&raft.Log{Data: cmd})
-	return &mockRaftFuture{}
+	return &mockRaftFuture{
+		response: mrs.fsm.Apply(&raft.Log{Data: cmd}),
+	}
 }
 
-func (mr *mockRaftService) AddVoter(_ raft.ServerID, _ raft.ServerAddress, _ uint64, _ time.Duration) raft.IndexFuture {
+func (mr *mockRaftService) AddVoter(id raft.ServerID, addr raft.ServerAddress, _ uint64, _ time.Duration) raft.IndexFuture {
+	for i, srv := range mr.cfg.Servers {
+		if srv.ID == id {
+			mr.cfg.Servers[i].Address = addr
+			return &mockRaftFuture{}
+		}
+	}
+	mr.cfg.Servers = append(mr.cfg.Servers, raft.Server{Suffrage: raft.Voter, ID: id, Address: addr})
 	return &mockRaftFuture{}
 }
 
-func (mr *mockRaftService) RemoveServer(_ raft.ServerID, _ uint64, _ time.Duration) raft.IndexFuture {
+func (mr *mockRaftService) RemoveServer(id raft.ServerID, _ uint64, _ time.Duration) raft.IndexFuture {
+	for i, srv := range mr.cfg.Servers {
+		if srv.ID == id {
+			mr.cfg.Servers = append(mr.cfg.Servers[:i], mr.cfg.Servers[i+1:]...)
+			break
+		}
+	}
 	return &mockRaftFuture{}
 }
 
+func (mr *mockRaftService) GetConfiguration() raft.ConfigurationFuture {
+	return &mockRaftFuture{
+		config: raft.Configuration{
+			Servers: append([]raft.Server{}, mr.cfg.Servers...),
+		},
+	}
+}
+
 func (mrs *mockRaftService) BootstrapCluster(cfg raft.Configuration) raft.Future {
 	return &mockRaftFuture{}
 }
@@ -88,6 +136,28 @@ func (mrs *mockRaftService) Barrier(time.Duration) raft.Future {
 	return &mockRaftFuture{}
 }
 
+func (mrs *mockRaftService) Snapshot() raft.SnapshotFuture {
+	snap, err := mrs.fsm.Snapshot()
+	if err != nil {
+		return &mockSnapshotFuture{mockRaftFuture: mockRaftFuture{err: err}}
+	}
+	sink := new(mockSnapshotSink)
+	if err := snap.Persist(sink); err != nil {
+		return &mockSnapshotFuture{mockRaftFuture: mockRaftFuture{err: err}}
+	}
+
+	return &mockSnapshotFuture{
+		meta: &raft.SnapshotMeta{
+			Version: raft.SnapshotVersionMax,
+			ID:      fmt.Sprintf("1-1-%d", time.Now().UnixNano()/int64(time.Millisecond)),
+			Index:   1,
+			Term:    1,
+			Size:    int64(sink.Len()),
+		},
+		data: sink.Bytes(),
+	}
+}
+
 func newMockRaftService(cfg *mockRaftServiceConfig, fsm raft.FSM) *mockRaftService {
 	if cfg == nil {
 		cfg = &mockRaftServiceConfig{
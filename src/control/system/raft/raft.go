@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2020-2022 Intel Corporation.
+// (C) Copyright 2020-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -7,6 +7,7 @@
 package raft
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	transport "github.com/Jille/raft-grpc-transport"
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/raft"
 	boltdb "github.com/hashicorp/raft-boltdb/v2"
@@ -23,6 +25,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/tracing"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/system"
 	"github.com/daos-stack/daos/src/control/system/checker"
@@ -51,8 +54,23 @@ const (
 	raftOpUpdateCheckerFinding
 	raftOpRemoveCheckerFinding
 	raftOpClearCheckerFindings
+	raftOpRenamePoolService
+	raftOpAddHealthSnapshot
+	raftOpAddEvent
+	raftOpAckEvents
+	raftOpAddPoolActivity
+	raftOpRemoveEvents
+	raftOpCompactMemberAddrs
+	raftOpSetMSBackupStatus
+	raftOpAddEvents
 
 	sysDBFile = "daos_system.db"
+	// localServerIDKey is the stable store key of the local raft server ID.
+	localServerIDKey = "daos_local_server_id"
+
+	baseHeartbeatTimeout = 2000 * time.Millisecond
+	baseElectionTimeout  = 2000 * time.Millisecond
+	maxElectionTier      = 2
 )
 
 type (
@@ -73,6 +91,15 @@ type (
 		Member   *system.Member
 		NextRank bool
 	}
+
+	// poolRename specifies a pool label change. The change is only
+	// applied if the pool's current label matches OldLabel and
+	// NewLabel is not in use by any other pool.
+	poolRename struct {
+		PoolUUID uuid.UUID
+		OldLabel string
+		NewLabel string
+	}
 )
 
 func (ro raftOp) String() string {
@@ -90,6 +117,15 @@ func (ro raftOp) String() string {
 		"updateCheckerFinding",
 		"removeCheckerFinding",
 		"clearCheckerFindings",
+		"renamePoolService",
+		"addHealthSnapshot",
+		"addEvent",
+		"ackEvents",
+		"addPoolActivity",
+		"removeEvents",
+		"compactMemberAddrs",
+		"setMSBackupStatus",
+		"addEvents",
 	}[ro]
 }
 
@@ -209,6 +245,20 @@ func getSnapshotStore(log hclog.Logger, dbCfg *DatabaseConfig) (raft.SnapshotSto
 	return store, nil
 }
 
+// electionTimeouts returns the heartbeat and election timeouts for a replica in
+// the given campaign delay tier. Raft waits for a random period of between one
+// and two times these timeouts before campaigning, so doubling them for each
+// tier means that, when a leader is lost, replicas in a lower tier will have
+// started an election before those in a higher tier are able to.
+func electionTimeouts(tier uint) (heartbeat, election time.Duration, err error) {
+	if tier > maxElectionTier {
+		return 0, 0, errors.Errorf("raft election tier %d exceeds maximum of %d", tier,
+			maxElectionTier)
+	}
+
+	return baseHeartbeatTimeout << tier, baseElectionTimeout << tier, nil
+}
+
 // ConfigureComponents configures the raft components of the database.
 func ConfigureComponents(log logging.Logger, dbCfg *DatabaseConfig) (*RaftComponents, error) {
 	if _, err := os.Stat(dbCfg.RaftDir); err != nil {
@@ -234,11 +284,11 @@ func ConfigureComponents(log logging.Logger, dbCfg *DatabaseConfig) (*RaftCompon
 	if dbCfg.RaftSnapshotInterval > 0 {
 		raftCfg.SnapshotInterval = dbCfg.RaftSnapshotInterval
 	}
-	raftCfg.HeartbeatTimeout = 2000 * time.Millisecond
-	raftCfg.ElectionTimeout = 2000 * time.Millisecond
+	raftCfg.HeartbeatTimeout, raftCfg.ElectionTimeout, err = electionTimeouts(dbCfg.RaftElectionTier)
+	if err != nil {
+		return nil, err
+	}
 	raftCfg.LeaderLeaseTimeout = 1000 * time.Millisecond
-	// Set the local ID to the address of the replica.
-	raftCfg.LocalID = raft.ServerID(repAddr.String())
 
 	snaps, err := getSnapshotStore(raftCfg.Logger, dbCfg)
 	if err != nil {
@@ -255,6 +305,12 @@ func ConfigureComponents(log logging.Logger, dbCfg *DatabaseConfig) (*RaftCompon
 		return nil, errors.Wrapf(err, "failed to init boltdb at %s", dbCfg.DBFilePath())
 	}
 
+	raftCfg.LocalID, err = localServerID(boltDB, snaps, repAddr, dbCfg)
+	if err != nil {
+		boltDB.Close()
+		return nil, err
+	}
+
 	return &RaftComponents{
 		Logger:        log,
 		Config:        raftCfg,
@@ -311,6 +367,8 @@ func (db *Database) initRaft() error {
 	// at rank 1 and increment from there as memberUpdate logs are applied.
 	db.data.NextRank = 1
 
+	db.localID = cmps.Config.LocalID
+
 	// Set the channel to be used for monitoring leadership changes.
 	cmps.Config.NotifyCh = db.raftLeaderNotifyCh
 	// Set a closure to properly close the boltDB store when the raft
@@ -338,12 +396,52 @@ func (db *Database) initRaft() error {
 	return nil
 }
 
-func genBootstrapCfg(localReplicaAddr *net.TCPAddr) raft.Configuration {
+// raftStore is a combined log and stable store, as provided by boltdb.
+type raftStore interface {
+	raft.LogStore
+	raft.StableStore
+}
+
+// localServerID returns the raft server ID of the local replica. Once chosen,
+// the ID is kept in the stable store so that it survives changes to the
+// replica's address. New replicas use the host ID of the server, whereas
+// replicas created before host IDs were introduced keep using the address
+// they were created with.
+func localServerID(store raftStore, snaps raft.SnapshotStore, repAddr *net.TCPAddr, dbCfg *DatabaseConfig) (raft.ServerID, error) {
+	id, err := store.Get([]byte(localServerIDKey))
+	switch {
+	case err == nil && len(id) > 0:
+		return raft.ServerID(id), nil
+	// NB: Like raft itself, match the stores' "not found" error by text.
+	case err != nil && err.Error() != "not found":
+		return "", errors.Wrap(err, "failed to read local raft server ID")
+	}
+
+	existing, err := raft.HasExistingState(store, store, snaps)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to check for existing state")
+	}
+
+	id = []byte(repAddr.String())
+	if !existing && dbCfg.HostID != uuid.Nil {
+		id = []byte(dbCfg.HostID.String())
+	}
+
+	if !dbCfg.ReadOnly {
+		if err := store.Set([]byte(localServerIDKey), id); err != nil {
+			return "", errors.Wrap(err, "failed to store local raft server ID")
+		}
+	}
+
+	return raft.ServerID(id), nil
+}
+
+func genBootstrapCfg(localID raft.ServerID, localReplicaAddr *net.TCPAddr) raft.Configuration {
 	return raft.Configuration{
 		Servers: []raft.Server{
 			{
 				Suffrage: raft.Voter,
-				ID:       raft.ServerID(localReplicaAddr.String()),
+				ID:       localID,
 				Address:  raft.ServerAddress(localReplicaAddr.String()),
 			},
 		},
@@ -359,7 +457,7 @@ func (db *Database) bootstrapRaft(newDB bool) error {
 	if db.IsBootstrap() && newDB {
 		db.log.Debugf("bootstrapping MS on %s", db.replicaAddr)
 		if err := db.raft.withReadLock(func(svc raftService) error {
-			if f := svc.BootstrapCluster(genBootstrapCfg(db.replicaAddr)); f.Error() != nil {
+			if f := svc.BootstrapCluster(genBootstrapCfg(db.localID, db.replicaAddr)); f.Error() != nil {
 				return errors.Wrapf(f.Error(), "failed to bootstrap raft instance on %s", db.replicaAddr)
 			}
 			return nil
@@ -449,7 +547,13 @@ func (db *Database) submitMemberUpdate(op raftOp, m *memberUpdate) error {
 
 // submitPoolUpdate submits the given pool service update operation to
 // the raft service.
-func (db *Database) submitPoolUpdate(op raftOp, ps *system.PoolService) error {
+func (db *Database) submitPoolUpdate(ctx context.Context, op raftOp, ps *system.PoolService) (err error) {
+	_, span := tracing.StartSpan(ctx, "raft apply "+op.String(), tracing.SpanKindInternal)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
 	ps.LastUpdate = time.Now()
 	data, err := createRaftUpdate(op, ps)
 	if err != nil {
@@ -459,6 +563,16 @@ func (db *Database) submitPoolUpdate(op raftOp, ps *system.PoolService) error {
 	return db.submitRaftUpdate(data)
 }
 
+// submitPoolRename submits the given pool label change to the raft service.
+func (db *Database) submitPoolRename(pr *poolRename) error {
+	data, err := createRaftUpdate(raftOpRenamePoolService, pr)
+	if err != nil {
+		return err
+	}
+	db.log.Debugf("pool %s label %q -> %q", dbgUuidStr(pr.PoolUUID), pr.OldLabel, pr.NewLabel)
+	return db.submitRaftUpdate(data)
+}
+
 // submitSystemAttrsUpdate submits the given system properties update
 // the raft service.
 func (db *Database) submitSystemAttrsUpdate(props map[string]string) error {
@@ -469,6 +583,78 @@ func (db *Database) submitSystemAttrsUpdate(props map[string]string) error {
 	return db.submitRaftUpdate(data)
 }
 
+// submitHealthSnapshot submits the given system health snapshot.
+func (db *Database) submitHealthSnapshot(hs *system.HealthSnapshot) error {
+	data, err := createRaftUpdate(raftOpAddHealthSnapshot, hs)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitEventAdd submits the given persistent event.
+func (db *Database) submitEventAdd(pe *system.PersistentEvent) error {
+	data, err := createRaftUpdate(raftOpAddEvent, pe)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitEventsAdd submits the given set of persistent events.
+func (db *Database) submitEventsAdd(pes []*system.PersistentEvent) error {
+	data, err := createRaftUpdate(raftOpAddEvents, pes)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitEventAck submits the given event acknowledgment.
+func (db *Database) submitEventAck(ack *eventAck) error {
+	data, err := createRaftUpdate(raftOpAckEvents, ack)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitMemberAddrCompaction submits a compaction of the member address map.
+func (db *Database) submitMemberAddrCompaction() error {
+	data, err := createRaftUpdate(raftOpCompactMemberAddrs, nil)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitMSBackupStatus submits the status of the latest MS backup.
+func (db *Database) submitMSBackupStatus(bs *system.MSBackupStatus) error {
+	data, err := createRaftUpdate(raftOpSetMSBackupStatus, bs)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitEventRemove submits the given event removal.
+func (db *Database) submitEventRemove(rm *eventRemove) error {
+	data, err := createRaftUpdate(raftOpRemoveEvents, rm)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitPoolActivity submits the given pool activity record.
+func (db *Database) submitPoolActivity(pa *poolActivityAdd) error {
+	data, err := createRaftUpdate(raftOpAddPoolActivity, pa)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
 // submitCheckerUpdate submits the given system checker update.
 func (db *Database) submitCheckerUpdate(op raftOp, f *checker.Finding) error {
 	data, err := createRaftUpdate(op, f)
@@ -481,7 +667,8 @@ func (db *Database) submitCheckerUpdate(op raftOp, f *checker.Finding) error {
 // submitRaftUpdate submits the serialized operation to the raft service.
 func (db *Database) submitRaftUpdate(data []byte) error {
 	return db.raft.withReadLock(func(svc raftService) error {
-		err := svc.Apply(data, 0).Error()
+		future := svc.Apply(data, 0)
+		err := future.Error()
 
 		// In the case that leadership is lost while trying to
 		// apply an update, return a sentinel error that may
@@ -490,8 +677,17 @@ func (db *Database) submitRaftUpdate(data []byte) error {
 		if IsRaftLeadershipError(err) {
 			return errNotSysLeader(svc, db)
 		}
+		if err != nil {
+			return err
+		}
 
-		return err
+		// Some operations are validated by the FSM at apply time
+		// and may be rejected; surface the rejection to the caller.
+		if applyErr, ok := future.Response().(error); ok {
+			return applyErr
+		}
+
+		return nil
 	})
 }
 
@@ -537,6 +733,30 @@ func (f *fsm) Apply(l *raft.Log) interface{} {
 		f.data.applySystemUpdate(c.Op, c.Data, f.EmergencyShutdown)
 	case raftOpAddCheckerFinding, raftOpUpdateCheckerFinding, raftOpRemoveCheckerFinding, raftOpClearCheckerFindings:
 		f.data.applyCheckerUpdate(c.Op, c.Data, f.EmergencyShutdown)
+	case raftOpRenamePoolService:
+		// NB: A rejected rename is not fatal; it is rejected
+		// identically on every replica and leaves the data unchanged.
+		if err := f.data.applyPoolRename(c.Time, c.Data, f.EmergencyShutdown); err != nil {
+			return err
+		}
+	case raftOpAddHealthSnapshot:
+		f.data.applyHealthSnapshot(c.Data, f.EmergencyShutdown)
+	case raftOpAddEvent:
+		(*Database)(f).eventsEvicted(f.data.applyEventAdd(c.Data, f.EmergencyShutdown))
+	case raftOpAddEvents:
+		(*Database)(f).eventsEvicted(f.data.applyEventsAdd(c.Data, f.EmergencyShutdown))
+	case raftOpAckEvents:
+		if err := f.data.applyEventAck(c.Time, c.Data, f.EmergencyShutdown); err != nil {
+			return err
+		}
+	case raftOpAddPoolActivity:
+		f.data.applyPoolActivity(c.Data, f.EmergencyShutdown)
+	case raftOpRemoveEvents:
+		f.data.applyEventRemove(c.Data, f.EmergencyShutdown)
+	case raftOpCompactMemberAddrs:
+		f.data.applyMemberAddrCompaction()
+	case raftOpSetMSBackupStatus:
+		f.data.applyMSBackupStatus(c.Data, f.EmergencyShutdown)
 	default:
 		f.EmergencyShutdown(errors.Errorf("unhandled Apply operation: %d", c.Op))
 		return nil
@@ -545,6 +765,7 @@ func (f *fsm) Apply(l *raft.Log) interface{} {
 	f.data.Lock()
 	f.data.Version++ // Successful updates should increment this value.
 	f.data.Unlock()
+	(*Database)(f).notifyHashWaiters()
 
 	return nil
 }
@@ -556,6 +777,14 @@ func (d *dbData) applyMapVersionIncrement() {
 	d.MapVersion++
 }
 
+// applyMemberAddrCompaction is responsible for removing stale entries from the
+// member address map.
+func (d *dbData) applyMemberAddrCompaction() {
+	d.Lock()
+	defer d.Unlock()
+	d.Members.compactAddrs(false)
+}
+
 // applyMemberUpdate is responsible for applying the membership update
 // operation to the database.
 func (d *dbData) applyMemberUpdate(op raftOp, data []byte, panicFn func(error)) {
@@ -610,12 +839,45 @@ func (d *dbData) applyPoolUpdate(op raftOp, data []byte, panicFn func(error)) {
 		d.Pools.updateService(cur, ps)
 	case raftOpRemovePoolService:
 		d.Pools.removeService(ps)
+		delete(d.PoolActivity, ps.PoolUUID)
 	default:
 		panicFn(errors.Errorf("unhandled Pool Service Apply operation: %d", op))
 		return
 	}
 }
 
+// applyPoolRename is responsible for atomically validating and applying a
+// pool label change to the database. A non-nil error is returned if the
+// rename is rejected.
+func (d *dbData) applyPoolRename(ts time.Time, data []byte, panicFn func(error)) error {
+	pr := new(poolRename)
+	if err := json.Unmarshal(data, pr); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode pool rename"))
+		return nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	cur, found := d.Pools.Uuids[pr.PoolUUID]
+	if !found {
+		return system.ErrPoolUUIDNotFound(pr.PoolUUID)
+	}
+	if cur.PoolLabel != pr.OldLabel {
+		return errors.Errorf("pool %s label changed during rename (%q != %q)",
+			pr.PoolUUID, cur.PoolLabel, pr.OldLabel)
+	}
+	if pr.NewLabel != "" {
+		if other, exists := d.Pools.Labels[pr.NewLabel]; exists && other.PoolUUID != cur.PoolUUID {
+			return &system.ErrPoolLabelExists{Label: pr.NewLabel, PoolUUID: other.PoolUUID}
+		}
+	}
+
+	d.Pools.renameService(cur, pr.NewLabel)
+	cur.LastUpdate = ts
+	return nil
+}
+
 // applySystemUpdate is responsible for applying the system properties update
 // operation to the database.
 func (d *dbData) applySystemUpdate(op raftOp, data []byte, panicFn func(error)) {
@@ -685,6 +947,166 @@ func (d *dbData) applyCheckerUpdate(op raftOp, data []byte, panicFn func(error))
 	}
 }
 
+// applyHealthSnapshot is responsible for adding the health snapshot
+// to the bounded history in the database.
+func (d *dbData) applyHealthSnapshot(data []byte, panicFn func(error)) {
+	hs := new(system.HealthSnapshot)
+	if err := json.Unmarshal(data, hs); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode health snapshot"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.History = d.History.add(hs, MaxHealthSnapshots)
+}
+
+// applyMSBackupStatus is responsible for recording the status of the
+// latest MS backup in the database.
+func (d *dbData) applyMSBackupStatus(data []byte, panicFn func(error)) {
+	bs := new(system.MSBackupStatus)
+	if err := json.Unmarshal(data, bs); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode MS backup status"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.MSBackup = bs
+}
+
+// applyEventAdd is responsible for adding the persistent event to the
+// bounded event log in the database. A repeat of an event that is still
+// active updates the existing entry rather than adding a new one.
+func (d *dbData) applyEventAdd(data []byte, panicFn func(error)) EventLog {
+	pe := new(system.PersistentEvent)
+	if err := json.Unmarshal(data, pe); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode persistent event"))
+		return nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	return d.addEvent(pe)
+}
+
+// applyEventsAdd is responsible for adding a set of persistent events to
+// the event log, in order.
+func (d *dbData) applyEventsAdd(data []byte, panicFn func(error)) EventLog {
+	var pes []*system.PersistentEvent
+	if err := json.Unmarshal(data, &pes); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode persistent events"))
+		return nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	var evicted EventLog
+	for _, pe := range pes {
+		evicted = append(evicted, d.addEvent(pe)...)
+	}
+	return evicted
+}
+
+// addEvent adds the event to the log, or merges it into a matching active
+// event, and returns any events evicted from the log to make room for it.
+// The caller must hold the lock.
+func (d *dbData) addEvent(pe *system.PersistentEvent) EventLog {
+	count := pe.Count
+	if count == 0 {
+		count = 1
+	}
+
+	for _, cur := range d.Events {
+		if cur.IsActive() && cur.Matches(pe) {
+			cur.Msg = pe.Msg
+			cur.LastSeen = pe.LastSeen
+			cur.Count += count
+			return nil
+		}
+	}
+
+	d.NextEventID++
+	pe.ID = d.NextEventID
+	var evicted EventLog
+	d.Events, evicted = d.Events.add(pe, MaxPersistentEvents)
+	return evicted
+}
+
+// applyEventAck is responsible for atomically validating and applying an
+// acknowledgment of events in the database. A non-nil error is returned
+// if any of the events are unknown or already acknowledged.
+func (d *dbData) applyEventAck(ts time.Time, data []byte, panicFn func(error)) error {
+	ack := new(eventAck)
+	if err := json.Unmarshal(data, ack); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode event ack"))
+		return nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	for _, id := range ack.IDs {
+		pe := d.Events.find(id)
+		if pe == nil {
+			return system.ErrEventNotFound(id)
+		}
+		if !pe.IsActive() {
+			return errors.Errorf("%s already acknowledged", pe)
+		}
+	}
+	for _, id := range ack.IDs {
+		pe := d.Events.find(id)
+		pe.Acknowledged = true
+		pe.AckTime = ts
+	}
+
+	return nil
+}
+
+// applyEventRemove is responsible for removing acknowledged events from
+// the event log in the database. Events that are unknown, e.g. because
+// they have already been discarded, or still active are left alone.
+func (d *dbData) applyEventRemove(data []byte, panicFn func(error)) {
+	rm := new(eventRemove)
+	if err := json.Unmarshal(data, rm); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode event removal"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.Events = d.Events.remove(rm.IDs)
+}
+
+// applyPoolActivity is responsible for adding the record to the bounded
+// activity log of the pool in the database. Records for unknown pools
+// are discarded, e.g. if the pool was destroyed before the record was
+// applied.
+func (d *dbData) applyPoolActivity(data []byte, panicFn func(error)) {
+	pa := new(poolActivityAdd)
+	if err := json.Unmarshal(data, pa); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode pool activity"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if _, found := d.Pools.Uuids[pa.PoolUUID]; !found {
+		return
+	}
+	if d.PoolActivity == nil {
+		d.PoolActivity = make(PoolActivityMap)
+	}
+	d.PoolActivity[pa.PoolUUID] = d.PoolActivity[pa.PoolUUID].add(pa.Activity, MaxPoolActivity)
+}
+
 // Snapshot is called to support log compaction, so that we don't have to keep
 // every log entry from the start of the system. Instead, the raft service periodically
 // creates a point-in-time snapshot which can be used to restore the current state, or
@@ -721,8 +1143,17 @@ func (f *fsm) Restore(rc io.ReadCloser) error {
 	f.data.MapVersion = db.data.MapVersion
 	f.data.System = db.data.System
 	f.data.Checker = db.data.Checker
+	f.data.History = db.data.History
+	f.data.Events = db.data.Events
+	f.data.NextEventID = db.data.NextEventID
+	f.data.PoolActivity = db.data.PoolActivity
+	f.data.MSBackup = db.data.MSBackup
 	f.data.Version = db.data.Version
+	// The restored members may differ from those of a cached
+	// group map with the same version.
+	f.data.groupMap.set(nil)
 	f.data.Unlock()
+	(*Database)(f).notifyHashWaiters()
 	f.log.Debugf("db snapshot loaded (map version %d; data version %d)", db.data.MapVersion, db.data.Version)
 	return nil
 }
@@ -0,0 +1,280 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/raft"
+	boltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	snapshotsDir        = "snapshots"
+	corruptSnapshotsDir = "snapshots-corrupt"
+	tmpSnapshotSuffix   = ".tmp"
+	boltOpenTimeout     = 5 * time.Second
+)
+
+// snapshotCheck is the result of verifying a single snapshot in the snapshot store.
+type snapshotCheck struct {
+	id   string
+	meta *raft.SnapshotMeta
+	err  error
+}
+
+func (sc *snapshotCheck) String() string {
+	if sc.meta == nil {
+		return sc.id
+	}
+	return fmt.Sprintf("%s (index %d)", sc.id, sc.meta.Index)
+}
+
+// verifySnapshot checks that the snapshot metadata can be read, and that the size and CRC
+// recorded in it match the snapshot data.
+func verifySnapshot(path string) (*raft.SnapshotMeta, error) {
+	// The file snapshot store records the CRC alongside the snapshot metadata.
+	meta := new(struct {
+		raft.SnapshotMeta
+		CRC []byte
+	})
+	metaPath := filepath.Join(path, snapshotMetaFile)
+	data, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read metadata")
+	}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, errors.Wrap(err, "failed to parse metadata")
+	}
+
+	f, err := os.Open(filepath.Join(path, snapshotDataFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open data")
+	}
+	defer f.Close()
+
+	hash := crc64.New(crc64.MakeTable(crc64.ECMA))
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read data")
+	}
+	if size != meta.Size {
+		return &meta.SnapshotMeta, errors.Errorf("data size %d does not match metadata size %d (torn write?)",
+			size, meta.Size)
+	}
+	if !bytes.Equal(hash.Sum(nil), meta.CRC) {
+		return &meta.SnapshotMeta, errors.New("data does not match metadata CRC")
+	}
+
+	return &meta.SnapshotMeta, nil
+}
+
+// checkSnapshots verifies each snapshot in the snapshot store, and returns the results sorted
+// from newest to oldest. Snapshots that are still being written are ignored, as they are by
+// raft.
+func checkSnapshots(raftDir string) ([]*snapshotCheck, error) {
+	dir := filepath.Join(raftDir, snapshotsDir)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read snapshot directory %s", dir)
+	}
+
+	var checks []*snapshotCheck
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasSuffix(entry.Name(), tmpSnapshotSuffix) {
+			continue
+		}
+
+		sc := &snapshotCheck{id: entry.Name()}
+		sc.meta, sc.err = verifySnapshot(filepath.Join(dir, entry.Name()))
+		checks = append(checks, sc)
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		// Snapshots with unreadable metadata sort last.
+		if checks[i].meta == nil || checks[j].meta == nil {
+			return checks[j].meta == nil && checks[i].meta != nil
+		}
+		if checks[i].meta.Term != checks[j].meta.Term {
+			return checks[i].meta.Term > checks[j].meta.Term
+		}
+		return checks[i].meta.Index > checks[j].meta.Index
+	})
+
+	return checks, nil
+}
+
+// checkBoltDB opens the BoltDB file read-only and checks the consistency of its pages.
+// Corruption can cause bbolt to panic rather than return an error, so panics are converted
+// into errors.
+func checkBoltDB(path string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("panic while checking database: %v", r)
+		}
+	}()
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{ReadOnly: true, Timeout: boltOpenTimeout})
+	if err != nil {
+		return errors.Wrap(err, "failed to open database")
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bbolt.Tx) error {
+		var errs []string
+		for err := range tx.Check() {
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			return errors.Errorf("consistency check failed: %s", strings.Join(errs, "; "))
+		}
+		return nil
+	})
+}
+
+// checkLogStore verifies the BoltDB log store and that every log entry between the first and
+// last indexes can be read and decoded. It returns the range of indexes in the log.
+func checkLogStore(path string) (first, last uint64, err error) {
+	if err := checkBoltDB(path); err != nil {
+		return 0, 0, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("panic while reading log entries: %v", r)
+		}
+	}()
+
+	store, err := boltdb.New(boltdb.Options{
+		Path:        path,
+		BoltOptions: &bbolt.Options{ReadOnly: true, Timeout: boltOpenTimeout},
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to open log store")
+	}
+	defer store.Close()
+
+	if first, err = store.FirstIndex(); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read first log index")
+	}
+	if last, err = store.LastIndex(); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read last log index")
+	}
+
+	var entry raft.Log
+	for idx := first; idx != 0 && idx <= last; idx++ {
+		if err := store.GetLog(idx, &entry); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to read log entry %d of %d-%d", idx, first, last)
+		}
+	}
+
+	return first, last, nil
+}
+
+// quarantineSnapshots moves snapshots out of the snapshot store so that raft neither tries to
+// restore them nor counts them towards the number of snapshots to retain, which could cause
+// the valid snapshots to be reaped in favor of the corrupt ones.
+func quarantineSnapshots(log logging.Logger, raftDir string, snaps []*snapshotCheck) error {
+	if len(snaps) == 0 {
+		return nil
+	}
+
+	dst := filepath.Join(raftDir, corruptSnapshotsDir)
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create %s", dst)
+	}
+
+	for _, sc := range snaps {
+		log.Noticef("moving corrupt system database snapshot %s to %s: %s", sc, dst, sc.err)
+		if err := os.Rename(filepath.Join(raftDir, snapshotsDir, sc.id), filepath.Join(dst, sc.id)); err != nil {
+			return errors.Wrapf(err, "failed to move corrupt snapshot %s", sc.id)
+		}
+	}
+
+	return nil
+}
+
+// logCovers reports whether the log contains every entry after the given snapshot index.
+func logCovers(snapIndex, first, last uint64) bool {
+	return last <= snapIndex || first <= snapIndex+1
+}
+
+// verifyRaftStore checks the integrity of the on-disk raft state before it is handed to raft,
+// which would otherwise fail or panic deep in its internals when it encountered damage left by
+// an unclean shutdown. Corrupt snapshots are set aside if the state can be rebuilt from an
+// older snapshot and the log; any other damage results in a fault describing how to recover.
+func verifyRaftStore(log logging.Logger, cfg *DatabaseConfig) error {
+	first, last, err := checkLogStore(cfg.DBFilePath())
+	if err != nil {
+		return system.FaultDatabaseLogStoreCorrupt(cfg.DBFilePath(), err)
+	}
+	log.Debugf("system database log store verified (entries %d-%d)", first, last)
+
+	snaps, err := checkSnapshots(cfg.RaftDir)
+	if err != nil {
+		return err
+	}
+
+	var corrupt []*snapshotCheck
+	for _, sc := range snaps {
+		if sc.err != nil {
+			corrupt = append(corrupt, sc)
+			continue
+		}
+
+		// This is the newest valid snapshot, which raft will restore from.
+		if !logCovers(sc.meta.Index, first, last) {
+			return system.FaultDatabaseSnapshotsCorrupt(cfg.RaftDir,
+				fmt.Sprintf("log entries %d-%d do not follow on from latest valid snapshot %s",
+					first, last, sc))
+		}
+		if len(corrupt) > 0 {
+			log.Noticef("recovering system database from snapshot %s and log entries %d-%d",
+				sc, sc.meta.Index+1, last)
+		}
+		// Snapshots with unreadable metadata sort last but can't be placed relative to this
+		// one, so they are set aside as well.
+		for _, old := range snaps {
+			if old.meta == nil {
+				corrupt = append(corrupt, old)
+			}
+		}
+		return quarantineSnapshots(log, cfg.RaftDir, corrupt)
+	}
+
+	if len(corrupt) == 0 {
+		return nil
+	}
+
+	// Without a valid snapshot the state can only be rebuilt from a complete log.
+	if first > 1 || last == 0 {
+		return system.FaultDatabaseSnapshotsCorrupt(cfg.RaftDir,
+			fmt.Sprintf("no valid snapshot (newest %s: %s) and log entries %d-%d are incomplete",
+				corrupt[0], corrupt[0].err, first, last))
+	}
+	log.Noticef("recovering system database from log entries %d-%d", first, last)
+
+	return quarantineSnapshots(log, cfg.RaftDir, corrupt)
+}
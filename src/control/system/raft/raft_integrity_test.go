@@ -0,0 +1,186 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	boltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	fixOldSnap = "2-20-1713295643181"
+	fixNewSnap = "2-44-1713295644569"
+)
+
+func corruptSnapshotData(t *testing.T, cfg *DatabaseConfig, id string) {
+	t.Helper()
+
+	path := filepath.Join(cfg.RaftDir, snapshotsDir, id, snapshotDataFile)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)/2] ^= 0xff
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func trimLogs(t *testing.T, cfg *DatabaseConfig, max uint64) {
+	t.Helper()
+
+	store, err := boltdb.NewBoltStore(cfg.DBFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	first, err := store.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteRange(first, max); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRaft_verifyRaftStore(t *testing.T) {
+	for name, tc := range map[string]struct {
+		setup      func(t *testing.T, cfg *DatabaseConfig)
+		expErr     error
+		expSnaps   []string
+		expCorrupt []string
+	}{
+		"intact": {
+			expSnaps: []string{fixOldSnap, fixNewSnap},
+		},
+		"newest snapshot corrupt": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				corruptSnapshotData(t, cfg, fixNewSnap)
+			},
+			expSnaps:   []string{fixOldSnap},
+			expCorrupt: []string{fixNewSnap},
+		},
+		"newest snapshot torn": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				path := filepath.Join(cfg.RaftDir, snapshotsDir, fixNewSnap, snapshotDataFile)
+				if err := os.Truncate(path, 16); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expSnaps:   []string{fixOldSnap},
+			expCorrupt: []string{fixNewSnap},
+		},
+		"unreadable snapshot metadata": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				path := filepath.Join(cfg.RaftDir, snapshotsDir, fixNewSnap, snapshotMetaFile)
+				if err := ioutil.WriteFile(path, []byte("{"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expSnaps:   []string{fixOldSnap},
+			expCorrupt: []string{fixNewSnap},
+		},
+		"in-progress snapshot ignored": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				path := filepath.Join(cfg.RaftDir, snapshotsDir, "2-50-1713295645000"+tmpSnapshotSuffix)
+				if err := os.Mkdir(path, 0700); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expSnaps: []string{fixOldSnap, fixNewSnap, "2-50-1713295645000" + tmpSnapshotSuffix},
+		},
+		"all snapshots corrupt; complete log": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				corruptSnapshotData(t, cfg, fixOldSnap)
+				corruptSnapshotData(t, cfg, fixNewSnap)
+			},
+			expSnaps:   []string{},
+			expCorrupt: []string{fixOldSnap, fixNewSnap},
+		},
+		"all snapshots corrupt; compacted log": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				corruptSnapshotData(t, cfg, fixOldSnap)
+				corruptSnapshotData(t, cfg, fixNewSnap)
+				trimLogs(t, cfg, 30)
+			},
+			expErr:   errors.New("can't be recovered from local snapshots"),
+			expSnaps: []string{fixOldSnap, fixNewSnap},
+		},
+		"newest snapshot corrupt; log does not follow older snapshot": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				corruptSnapshotData(t, cfg, fixNewSnap)
+				trimLogs(t, cfg, 30)
+			},
+			expErr:   errors.New("can't be recovered from local snapshots"),
+			expSnaps: []string{fixOldSnap, fixNewSnap},
+		},
+		"truncated log store": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				if err := os.Truncate(cfg.DBFilePath(), 4096); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expErr:   errors.New("is corrupt"),
+			expSnaps: []string{fixOldSnap, fixNewSnap},
+		},
+		"garbage log store": {
+			setup: func(t *testing.T, cfg *DatabaseConfig) {
+				if err := ioutil.WriteFile(cfg.DBFilePath(), []byte("not a database"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expErr:   errors.New("is corrupt"),
+			expSnaps: []string{fixOldSnap, fixNewSnap},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			dbCfg := testDbCfg()
+			srcDir := dbCfg.RaftDir
+			dbCfg.RaftDir = filepath.Join(t.TempDir(), filepath.Base(srcDir))
+			test.CopyDir(t, srcDir, dbCfg.RaftDir)
+			if tc.setup != nil {
+				tc.setup(t, dbCfg)
+			}
+
+			gotErr := verifyRaftStore(log, dbCfg)
+			test.CmpErr(t, tc.expErr, gotErr)
+
+			listDir := func(dir string) []string {
+				t.Helper()
+				names := []string{}
+				entries, err := ioutil.ReadDir(filepath.Join(dbCfg.RaftDir, dir))
+				if err != nil && !os.IsNotExist(err) {
+					t.Fatal(err)
+				}
+				for _, entry := range entries {
+					if !entry.IsDir() {
+						continue
+					}
+					names = append(names, entry.Name())
+				}
+				return names
+			}
+			test.AssertEqual(t, tc.expSnaps, listDir(snapshotsDir), "unexpected snapshots")
+			if tc.expCorrupt == nil {
+				tc.expCorrupt = []string{}
+			}
+			test.AssertEqual(t, tc.expCorrupt, listDir(corruptSnapshotsDir), "unexpected quarantined snapshots")
+		})
+	}
+}
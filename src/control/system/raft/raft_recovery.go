@@ -1,5 +1,5 @@
 //
-// (C) Copyright 2022 Intel Corporation.
+// (C) Copyright 2022-2024 Intel Corporation.
 //
 // SPDX-License-Identifier: BSD-2-Clause-Patent
 //
@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -83,7 +84,7 @@ func RecoverLocalReplica(log logging.Logger, cfg *DatabaseConfig) error {
 
 	return raft.RecoverCluster(cmps.Config, (*fsm)(db),
 		cmps.LogStore, cmps.StableStore, cmps.SnapshotStore,
-		transport, genBootstrapCfg(db.replicaAddr))
+		transport, genBootstrapCfg(cmps.Config.LocalID, db.replicaAddr))
 }
 
 func createRaftDir(dbPath string) error {
@@ -206,7 +207,7 @@ func RestoreLocalReplica(log logging.Logger, cfg *DatabaseConfig, snapPath strin
 		return errors.Wrapf(err, "failed to read snapshot data from %q", snapPath)
 	}
 	log.Info("Bootstrapping new raft service; waiting for completion")
-	if f := svc.BootstrapCluster(genBootstrapCfg(db.replicaAddr)); f.Error() != nil {
+	if f := svc.BootstrapCluster(genBootstrapCfg(cmps.Config.LocalID, db.replicaAddr)); f.Error() != nil {
 		return errors.Wrap(f.Error(), "failed to bootstrap cluster")
 	}
 
@@ -473,20 +474,106 @@ func readSnapshotData(path string) ([]byte, error) {
 	return data, nil
 }
 
-// ReadSnapshotInfo reads the snapshot metadata and data from the given path.
-func ReadSnapshotInfo(path string) (*SnapshotDetails, error) {
+func readSnapshot(path string) (*SnapshotDetails, []byte, error) {
 	details := &SnapshotDetails{
 		Path:     path,
 		Metadata: new(raft.SnapshotMeta),
 	}
 	if err := readSnapshotMeta(path, details.Metadata); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	data, err := readSnapshotData(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return details, data, errors.Wrapf(details.DecodeSnapshot(data), "failed to decode snapshot data in %s", path)
+}
+
+// ReadSnapshotInfo reads the snapshot metadata and data from the given path.
+func ReadSnapshotInfo(path string) (*SnapshotDetails, error) {
+	details, _, err := readSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// Time returns the time at which the snapshot was taken, as recorded in the
+// snapshot ID. The zero time is returned if the ID is not in the expected
+// format.
+func (sd *SnapshotDetails) Time() time.Time {
+	if sd == nil || sd.Metadata == nil {
+		return time.Time{}
+	}
+
+	// Snapshot IDs are of the form term-index-msec.
+	fields := strings.Split(sd.Metadata.ID, "-")
+	if len(fields) != 3 {
+		return time.Time{}
+	}
+	msec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, msec*int64(time.Millisecond))
+}
+
+// SnapshotView provides read-only queries of the system database state
+// recorded in a raft snapshot, without requiring a running replica.
+type SnapshotView struct {
+	Details *SnapshotDetails
+	data    *dbData
+}
+
+// LoadSnapshotView loads the snapshot at the given path for inspection.
+func LoadSnapshotView(path string) (*SnapshotView, error) {
+	details, data, err := readSnapshot(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return details, errors.Wrapf(details.DecodeSnapshot(data), "failed to decode snapshot data in %s", path)
+	db, _ := NewDatabase(nil, nil)
+	if err := json.Unmarshal(data, db.data); err != nil {
+		return nil, errors.Wrapf(err, "failed to load snapshot data in %s", path)
+	}
+	if db.data.SchemaVersion != CurrentSchemaVersion {
+		return nil, errors.Errorf("snapshot schema version %d != %d",
+			db.data.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	return &SnapshotView{
+		Details: details,
+		data:    db.data,
+	}, nil
+}
+
+// Members returns the system members recorded in the snapshot, sorted by rank.
+func (sv *SnapshotView) Members() []*system.Member {
+	members := make([]*system.Member, 0, len(sv.data.Members.Ranks))
+	for _, m := range sv.data.Members.Ranks {
+		members = append(members, copyMember(m))
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Rank < members[j].Rank })
+
+	return members
+}
+
+// Pools returns the pool services recorded in the snapshot, sorted by label.
+func (sv *SnapshotView) Pools() []*system.PoolService {
+	pools := make([]*system.PoolService, 0, len(sv.data.Pools.Uuids))
+	for _, ps := range sv.data.Pools.Uuids {
+		pools = append(pools, copyPoolService(ps))
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].PoolLabel < pools[j].PoolLabel })
+
+	return pools
+}
+
+// FaultDomainTree returns the tree of fault domains recorded in the snapshot.
+func (sv *SnapshotView) FaultDomainTree() *system.FaultDomainTree {
+	return sv.data.Members.FaultDomains.Copy()
 }
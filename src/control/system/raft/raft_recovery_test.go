@@ -485,3 +485,53 @@ func Test_Raft_ReadSnapshotInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_Raft_LoadSnapshotView(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer test.ShowBufferOnFailure(t, buf)
+
+	latest, err := GetLatestSnapshot(log, testDbCfg())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSnapshotView("bad/path"); err == nil {
+		t.Fatal("expected error for bad snapshot path")
+	}
+
+	sv, err := LoadSnapshotView(latest.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.AssertEqual(t, time.UnixMilli(1713295644569), sv.Details.Time(), "unexpected snapshot time")
+
+	var ranks []Rank
+	for _, m := range sv.Members() {
+		ranks = append(ranks, m.Rank)
+	}
+	if diff := cmp.Diff(latest.MemberRanks.Ranks(), ranks); diff != "" {
+		t.Fatalf("unexpected member ranks (-want +got):\n%s", diff)
+	}
+
+	var labels []string
+	for _, ps := range sv.Pools() {
+		labels = append(labels, ps.PoolLabel)
+	}
+	if diff := cmp.Diff(latest.Pools, labels); diff != "" {
+		t.Fatalf("unexpected pool labels (-want +got):\n%s", diff)
+	}
+
+	var expDomains []*system.FaultDomain
+	for _, rank := range ranks {
+		expDomains = append(expDomains,
+			system.MustCreateFaultDomainFromString(fmt.Sprintf("/my/test/domain/rank%d", rank)))
+	}
+	if diff := cmp.Diff(expDomains, sv.FaultDomainTree().Domains()); diff != "" {
+		t.Fatalf("unexpected fault domains (-want +got):\n%s", diff)
+	}
+
+	// Changes to the returned members must not modify the view.
+	sv.Members()[0].State = system.MemberStateExcluded
+	test.AssertEqual(t, system.MemberStateJoined, sv.Members()[0].State, "view modified by caller")
+}
@@ -0,0 +1,139 @@
+//
+// (C) Copyright 2024 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package raft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/raft"
+	boltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/test"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestRaft_ConfigureComponents_ElectionTier(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tier         uint
+		expHeartbeat time.Duration
+		expElection  time.Duration
+		expErr       error
+	}{
+		"default": {
+			expHeartbeat: 2 * time.Second,
+			expElection:  2 * time.Second,
+		},
+		"tier 1": {
+			tier:         1,
+			expHeartbeat: 4 * time.Second,
+			expElection:  4 * time.Second,
+		},
+		"max tier": {
+			tier:         maxElectionTier,
+			expHeartbeat: 8 * time.Second,
+			expElection:  8 * time.Second,
+		},
+		"tier too high": {
+			tier:   maxElectionTier + 1,
+			expErr: errors.New("exceeds maximum"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer test.ShowBufferOnFailure(t, buf)
+
+			dbCfg := testDbCfg()
+			dbCfg.RaftDir = t.TempDir()
+			dbCfg.RaftElectionTier = tc.tier
+
+			cmps, err := ConfigureComponents(log, dbCfg)
+			test.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			defer cmps.LogStore.(*boltdb.BoltStore).Close()
+
+			test.AssertEqual(t, tc.expHeartbeat, cmps.Config.HeartbeatTimeout, "unexpected heartbeat timeout")
+			test.AssertEqual(t, tc.expElection, cmps.Config.ElectionTimeout, "unexpected election timeout")
+			if cmps.Config.LeaderLeaseTimeout > cmps.Config.HeartbeatTimeout {
+				t.Fatal("leader lease timeout exceeds heartbeat timeout")
+			}
+		})
+	}
+}
+
+func TestRaft_localServerID(t *testing.T) {
+	repAddr := common.LocalhostCtrlAddr()
+	hostID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		storedID    string
+		existing    bool
+		hostID      uuid.UUID
+		readOnly    bool
+		expID       raft.ServerID
+		expStoredID string
+	}{
+		"no host ID": {
+			expID:       raft.ServerID(repAddr.String()),
+			expStoredID: repAddr.String(),
+		},
+		"new replica": {
+			hostID:      hostID,
+			expID:       raft.ServerID(hostID.String()),
+			expStoredID: hostID.String(),
+		},
+		"existing replica keeps address": {
+			existing:    true,
+			hostID:      hostID,
+			expID:       raft.ServerID(repAddr.String()),
+			expStoredID: repAddr.String(),
+		},
+		"stored ID": {
+			storedID:    "10.0.0.1:10001",
+			hostID:      hostID,
+			expID:       "10.0.0.1:10001",
+			expStoredID: "10.0.0.1:10001",
+		},
+		"read-only": {
+			hostID:   hostID,
+			readOnly: true,
+			expID:    raft.ServerID(hostID.String()),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := raft.NewInmemStore()
+			if tc.storedID != "" {
+				if err := store.Set([]byte(localServerIDKey), []byte(tc.storedID)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.existing {
+				if err := store.StoreLog(&raft.Log{Index: 1, Term: 1}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			dbCfg := testDbCfg()
+			dbCfg.HostID = tc.hostID
+			dbCfg.ReadOnly = tc.readOnly
+
+			gotID, err := localServerID(store, raft.NewInmemSnapshotStore(), repAddr, dbCfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.AssertEqual(t, tc.expID, gotID, "unexpected server ID")
+
+			storedID, _ := store.Get([]byte(localServerIDKey))
+			test.AssertEqual(t, tc.expStoredID, string(storedID), "unexpected stored server ID")
+		})
+	}
+}
@@ -172,17 +172,13 @@ drpc_hdlr_unregister_all(struct dss_drpc_handler *handlers)
 }
 
 /*
- * Top-level handler for incoming dRPC messages. Looks up the appropriate
- * registered dRPC handler and runs it on the message.
+ * Looks up the registered dRPC handler for the message's module and runs it.
  */
-void
-drpc_hdlr_process_msg(Drpc__Call *request, Drpc__Response *resp)
+static void
+process_call(Drpc__Call *request, Drpc__Response *resp)
 {
 	drpc_handler_t handler;
 
-	D_ASSERT(request != NULL);
-	D_ASSERT(resp != NULL);
-
 	handler = drpc_hdlr_get_handler(request->module);
 	if (handler == NULL) {
 		D_ERROR("Message for unregistered dRPC module: %d\n",
@@ -193,3 +189,127 @@ drpc_hdlr_process_msg(Drpc__Call *request, Drpc__Response *resp)
 
 	handler(request, resp);
 }
+
+/*
+ * Room to leave in a batch response for the fields of the Drpc__Response
+ * carrying the batch: a tag and a varint of at most 10 bytes for each of its
+ * sequence, status and body length.
+ */
+#define BATCH_RESP_OVERHEAD	(3 * (1 + 10))
+
+/* Upper bound of the size that a response adds to a Drpc__ResponseBatch. */
+static size_t
+batch_entry_size(Drpc__Response *resp)
+{
+	return 1 + 10 + drpc__response__get_packed_size(resp);
+}
+
+/*
+ * Processes each of the calls packed into a batch call in turn, and packs their
+ * responses into the body of the batch response. Batches may not be nested, as
+ * no handler is registered for the batch module.
+ *
+ * Once a call has been handled, a response that doesn't fit into the batch
+ * response is failed on its own rather than failing the whole batch, which the
+ * client might then resend.
+ */
+static void
+process_batch(Drpc__Call *request, Drpc__Response *resp)
+{
+	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Drpc__CallBatch		*batch;
+	Drpc__ResponseBatch	resp_batch = DRPC__RESPONSE_BATCH__INIT;
+	Drpc__Response		**resps = NULL;
+	size_t			avail = UNIXCOMM_MAXMSGSIZE - BATCH_RESP_OVERHEAD;
+	size_t			size;
+	size_t			len;
+	uint8_t			*body = NULL;
+	size_t			i;
+
+	if (request->method != DRPC_METHOD_BATCH_CALLS) {
+		D_ERROR("Unknown dRPC batch method: %d\n", request->method);
+		resp->status = DRPC__STATUS__UNKNOWN_METHOD;
+		return;
+	}
+
+	batch = drpc__call_batch__unpack(&alloc.alloc, request->body.len, request->body.data);
+	if (alloc.oom || batch == NULL) {
+		D_ERROR("Failed to unpack dRPC call batch\n");
+		resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		return;
+	}
+
+	if (batch->n_calls > 0) {
+		D_ALLOC_ARRAY(resps, batch->n_calls);
+		if (resps == NULL) {
+			resp->status = DRPC__STATUS__FAILURE;
+			goto out_batch;
+		}
+	}
+
+	for (i = 0; i < batch->n_calls; i++) {
+		Drpc__Call *call = batch->calls[i];
+
+		resps[i] = drpc_response_create(call);
+		if (resps[i] == NULL) {
+			resp->status = DRPC__STATUS__FAILURE;
+			goto out_resps;
+		}
+		process_call(call, resps[i]);
+
+		size = batch_entry_size(resps[i]);
+		if (size > avail && resps[i]->body.len > 0) {
+			D_ERROR("dRPC response to batched call %d:%d is too large\n",
+				call->module, call->method);
+			D_FREE(resps[i]->body.data);
+			resps[i]->body.len = 0;
+			if (resps[i]->status == DRPC__STATUS__SUCCESS)
+				resps[i]->status = DRPC__STATUS__FAILED_MARSHAL;
+			size = batch_entry_size(resps[i]);
+		}
+		avail = size < avail ? avail - size : 0;
+	}
+
+	resp_batch.n_responses = batch->n_calls;
+	resp_batch.responses = resps;
+	len = drpc__response_batch__get_packed_size(&resp_batch);
+	if (len > 0) {
+		D_ALLOC(body, len);
+		if (body == NULL) {
+			resp->status = DRPC__STATUS__FAILURE;
+			goto out_resps;
+		}
+		drpc__response_batch__pack(&resp_batch, body);
+	}
+	resp->body.data = body;
+	resp->body.len = len;
+	resp->status = DRPC__STATUS__SUCCESS;
+
+out_resps:
+	for (i = 0; resps != NULL && i < batch->n_calls; i++) {
+		if (resps[i] != NULL)
+			drpc_response_free(resps[i]);
+	}
+	D_FREE(resps);
+out_batch:
+	drpc__call_batch__free_unpacked(batch, &alloc.alloc);
+}
+
+/*
+ * Top-level handler for incoming dRPC messages. Looks up the appropriate
+ * registered dRPC handler and runs it on the message, or runs each of the
+ * messages of a batch in turn.
+ */
+void
+drpc_hdlr_process_msg(Drpc__Call *request, Drpc__Response *resp)
+{
+	D_ASSERT(request != NULL);
+	D_ASSERT(resp != NULL);
+
+	if (request->module == DRPC_MODULE_BATCH) {
+		process_batch(request, resp);
+		return;
+	}
+
+	process_call(request, resp);
+}
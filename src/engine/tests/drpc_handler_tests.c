@@ -405,6 +405,156 @@ drpc_hdlr_process_msg_unregistered_module(void **state)
 	drpc__response__free_unpacked(resp, NULL);
 }
 
+static Drpc__Call *
+new_batch_call(Drpc__Call **calls, size_t n_calls)
+{
+	Drpc__CallBatch	batch = DRPC__CALL_BATCH__INIT;
+	Drpc__Call	*call = new_drpc_call_with_module(DRPC_MODULE_BATCH);
+
+	call->method = DRPC_METHOD_BATCH_CALLS;
+	batch.n_calls = n_calls;
+	batch.calls = calls;
+	call->body.len = drpc__call_batch__get_packed_size(&batch);
+	D_ALLOC(call->body.data, call->body.len);
+	assert_non_null(call->body.data);
+	drpc__call_batch__pack(&batch, call->body.data);
+
+	return call;
+}
+
+static void
+set_mock_resp_body(size_t len)
+{
+	mock_drpc_handler_resp_return->status = DRPC__STATUS__SUCCESS;
+	mock_drpc_handler_resp_return->body.len = len;
+	D_ALLOC(mock_drpc_handler_resp_return->body.data, len);
+	assert_non_null(mock_drpc_handler_resp_return->body.data);
+}
+
+static void
+drpc_hdlr_process_msg_batch(void **state)
+{
+	Drpc__Call		*calls[4];
+	Drpc__Call		*request;
+	Drpc__Response		*resp = new_drpc_response();
+	Drpc__ResponseBatch	*batch;
+	int			i;
+
+	calls[0] = new_drpc_call();
+	calls[1] = new_drpc_call();
+	calls[1]->method = 5;
+	calls[2] = new_drpc_call_with_module(2);
+	calls[3] = new_batch_call(calls, 2);
+	request = new_batch_call(calls, 4);
+
+	set_mock_resp_body(16);
+	drpc_hdlr_register(calls[0]->module, mock_drpc_handler);
+
+	drpc_hdlr_process_msg(request, resp);
+
+	assert_int_equal(resp->status, DRPC__STATUS__SUCCESS);
+
+	/* Each call is handled once, and nested batches are not handled */
+	assert_int_equal(mock_drpc_handler_call_count, 2);
+	assert_int_equal(mock_drpc_handler_call->method, calls[1]->method);
+
+	batch = drpc__response_batch__unpack(NULL, resp->body.len, resp->body.data);
+	assert_non_null(batch);
+	assert_int_equal(batch->n_responses, 4);
+	assert_int_equal(batch->responses[0]->status, DRPC__STATUS__SUCCESS);
+	assert_int_equal(batch->responses[0]->body.len, 16);
+	assert_int_equal(batch->responses[1]->status, DRPC__STATUS__SUCCESS);
+	assert_int_equal(batch->responses[2]->status, DRPC__STATUS__UNKNOWN_MODULE);
+	assert_int_equal(batch->responses[3]->status, DRPC__STATUS__UNKNOWN_MODULE);
+
+	drpc__response_batch__free_unpacked(batch, NULL);
+	for (i = 0; i < 4; i++)
+		drpc__call__free_unpacked(calls[i], NULL);
+	drpc__call__free_unpacked(request, NULL);
+	drpc__response__free_unpacked(resp, NULL);
+}
+
+static void
+drpc_hdlr_process_msg_batch_response_too_large(void **state)
+{
+	Drpc__Call		*calls[2];
+	Drpc__Call		*request;
+	Drpc__Response		*resp = new_drpc_response();
+	Drpc__ResponseBatch	*batch;
+	int			i;
+
+	calls[0] = new_drpc_call();
+	calls[1] = new_drpc_call();
+	request = new_batch_call(calls, 2);
+
+	set_mock_resp_body(UNIXCOMM_MAXMSGSIZE / 2 + 1);
+	drpc_hdlr_register(calls[0]->module, mock_drpc_handler);
+
+	drpc_hdlr_process_msg(request, resp);
+
+	/* Both calls were handled, the second response didn't fit */
+	assert_int_equal(resp->status, DRPC__STATUS__SUCCESS);
+	assert_int_equal(mock_drpc_handler_call_count, 2);
+
+	batch = drpc__response_batch__unpack(NULL, resp->body.len, resp->body.data);
+	assert_non_null(batch);
+	assert_int_equal(batch->n_responses, 2);
+	assert_int_equal(batch->responses[0]->status, DRPC__STATUS__SUCCESS);
+	assert_int_equal(batch->responses[0]->body.len, UNIXCOMM_MAXMSGSIZE / 2 + 1);
+	assert_int_equal(batch->responses[1]->status, DRPC__STATUS__FAILED_MARSHAL);
+	assert_int_equal(batch->responses[1]->body.len, 0);
+
+	drpc__response_batch__free_unpacked(batch, NULL);
+	for (i = 0; i < 2; i++)
+		drpc__call__free_unpacked(calls[i], NULL);
+	drpc__call__free_unpacked(request, NULL);
+	drpc__response__free_unpacked(resp, NULL);
+}
+
+static void
+drpc_hdlr_process_msg_batch_unknown_method(void **state)
+{
+	Drpc__Call	*calls[1];
+	Drpc__Call	*request;
+	Drpc__Response	*resp = new_drpc_response();
+
+	calls[0] = new_drpc_call();
+	request = new_batch_call(calls, 1);
+	request->method = DRPC_METHOD_BATCH_CALLS + 1;
+
+	drpc_hdlr_register(calls[0]->module, mock_drpc_handler);
+
+	drpc_hdlr_process_msg(request, resp);
+
+	assert_int_equal(resp->status, DRPC__STATUS__UNKNOWN_METHOD);
+	assert_int_equal(mock_drpc_handler_call_count, 0);
+
+	drpc__call__free_unpacked(calls[0], NULL);
+	drpc__call__free_unpacked(request, NULL);
+	drpc__response__free_unpacked(resp, NULL);
+}
+
+static void
+drpc_hdlr_process_msg_batch_bad_body(void **state)
+{
+	Drpc__Call	*request = new_drpc_call_with_module(DRPC_MODULE_BATCH);
+	Drpc__Response	*resp = new_drpc_response();
+
+	request->method = DRPC_METHOD_BATCH_CALLS;
+	request->body.len = 4;
+	D_ALLOC(request->body.data, request->body.len);
+	assert_non_null(request->body.data);
+	memset(request->body.data, 0xff, request->body.len);
+
+	drpc_hdlr_process_msg(request, resp);
+
+	assert_int_equal(resp->status, DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD);
+	assert_int_equal(mock_drpc_handler_call_count, 0);
+
+	drpc__call__free_unpacked(request, NULL);
+	drpc__response__free_unpacked(resp, NULL);
+}
+
 /*
  * Tests for when the registry table is uninitialized.
  * Don't use the standard setup/teardown functions with these.
@@ -480,6 +630,10 @@ main(void)
 		UTEST(drpc_hdlr_unregister_all_with_multiple_items),
 		UTEST(drpc_hdlr_process_msg_success),
 		UTEST(drpc_hdlr_process_msg_unregistered_module),
+		UTEST(drpc_hdlr_process_msg_batch),
+		UTEST(drpc_hdlr_process_msg_batch_response_too_large),
+		UTEST(drpc_hdlr_process_msg_batch_unknown_method),
+		UTEST(drpc_hdlr_process_msg_batch_bad_body),
 
 		/* Uninitialized cases */
 		UTEST_NO_INIT(drpc_hdlr_register_uninitialized),
@@ -17,6 +17,8 @@ PROTOBUF_C__BEGIN_DECLS
 
 typedef struct _Drpc__Call Drpc__Call;
 typedef struct _Drpc__Response Drpc__Response;
+typedef struct _Drpc__CallBatch Drpc__CallBatch;
+typedef struct _Drpc__ResponseBatch Drpc__ResponseBatch;
 
 
 /* --- enums --- */
@@ -84,10 +86,14 @@ struct  _Drpc__Call
    * Input payload to be used by the method.
    */
   ProtobufCBinaryData body;
+  /*
+   * W3C trace context of the caller, if the call is traced.
+   */
+  char *traceparent;
 };
 #define DRPC__CALL__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&drpc__call__descriptor) \
-    , 0, 0, 0, {0,NULL} }
+    , 0, 0, 0, {0,NULL}, (char *)protobuf_c_empty_string }
 
 
 /*
@@ -114,6 +120,42 @@ struct  _Drpc__Response
     , 0, DRPC__STATUS__SUCCESS, {0,NULL} }
 
 
+/*
+ * CallBatch packs several Calls into the body of a single Call to the batch
+ * module, so that they reach the server in one message.
+ */
+struct  _Drpc__CallBatch
+{
+  ProtobufCMessage base;
+  /*
+   * Calls to be processed, in order.
+   */
+  size_t n_calls;
+  Drpc__Call **calls;
+};
+#define DRPC__CALL_BATCH__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&drpc__call_batch__descriptor) \
+    , 0,NULL }
+
+
+/*
+ * ResponseBatch is the body of the Response to a batch Call, holding the
+ * Responses to the Calls of the CallBatch in the same order.
+ */
+struct  _Drpc__ResponseBatch
+{
+  ProtobufCMessage base;
+  /*
+   * Responses to the batched Calls.
+   */
+  size_t n_responses;
+  Drpc__Response **responses;
+};
+#define DRPC__RESPONSE_BATCH__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&drpc__response_batch__descriptor) \
+    , 0,NULL }
+
+
 /* Drpc__Call methods */
 void   drpc__call__init
                      (Drpc__Call         *message);
@@ -152,6 +194,44 @@ Drpc__Response *
 void   drpc__response__free_unpacked
                      (Drpc__Response *message,
                       ProtobufCAllocator *allocator);
+/* Drpc__CallBatch methods */
+void   drpc__call_batch__init
+                     (Drpc__CallBatch         *message);
+size_t drpc__call_batch__get_packed_size
+                     (const Drpc__CallBatch   *message);
+size_t drpc__call_batch__pack
+                     (const Drpc__CallBatch   *message,
+                      uint8_t             *out);
+size_t drpc__call_batch__pack_to_buffer
+                     (const Drpc__CallBatch   *message,
+                      ProtobufCBuffer     *buffer);
+Drpc__CallBatch *
+       drpc__call_batch__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   drpc__call_batch__free_unpacked
+                     (Drpc__CallBatch *message,
+                      ProtobufCAllocator *allocator);
+/* Drpc__ResponseBatch methods */
+void   drpc__response_batch__init
+                     (Drpc__ResponseBatch         *message);
+size_t drpc__response_batch__get_packed_size
+                     (const Drpc__ResponseBatch   *message);
+size_t drpc__response_batch__pack
+                     (const Drpc__ResponseBatch   *message,
+                      uint8_t             *out);
+size_t drpc__response_batch__pack_to_buffer
+                     (const Drpc__ResponseBatch   *message,
+                      ProtobufCBuffer     *buffer);
+Drpc__ResponseBatch *
+       drpc__response_batch__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   drpc__response_batch__free_unpacked
+                     (Drpc__ResponseBatch *message,
+                      ProtobufCAllocator *allocator);
 /* --- per-message closures --- */
 
 typedef void (*Drpc__Call_Closure)
@@ -160,6 +240,12 @@ typedef void (*Drpc__Call_Closure)
 typedef void (*Drpc__Response_Closure)
                  (const Drpc__Response *message,
                   void *closure_data);
+typedef void (*Drpc__CallBatch_Closure)
+                 (const Drpc__CallBatch *message,
+                  void *closure_data);
+typedef void (*Drpc__ResponseBatch_Closure)
+                 (const Drpc__ResponseBatch *message,
+                  void *closure_data);
 
 /* --- services --- */
 
@@ -169,6 +255,8 @@ typedef void (*Drpc__Response_Closure)
 extern const ProtobufCEnumDescriptor    drpc__status__descriptor;
 extern const ProtobufCMessageDescriptor drpc__call__descriptor;
 extern const ProtobufCMessageDescriptor drpc__response__descriptor;
+extern const ProtobufCMessageDescriptor drpc__call_batch__descriptor;
+extern const ProtobufCMessageDescriptor drpc__response_batch__descriptor;
 
 PROTOBUF_C__END_DECLS
 
@@ -22,12 +22,15 @@ enum drpc_module {
 	DRPC_MODULE_MGMT		= 2,	/* daos_server mgmt */
 	DRPC_MODULE_SRV			= 3,	/* daos_server */
 	DRPC_MODULE_SEC			= 4,	/* daos_server security */
+	DRPC_MODULE_BATCH		= 5,	/* batched calls */
 
 	NUM_DRPC_MODULES			/* Must be last */
 };
 
 enum drpc_sec_agent_method {
 	DRPC_METHOD_SEC_AGENT_REQUEST_CREDS	= 101,
+	DRPC_METHOD_SEC_AGENT_REQUEST_DELEGATION_TOKEN	= 102,
+	DRPC_METHOD_SEC_AGENT_REDEEM_DELEGATION_TOKEN	= 103,
 
 	NUM_DRPC_SEC_AGENT_METHODS		/* Must be last */
 };
@@ -74,6 +77,14 @@ enum drpc_mgmt_method {
 	DRPC_METHOD_MGMT_CHK_PROP               = 245,
 	DRPC_METHOD_MGMT_CHK_ACT                = 246,
 	DRPC_METHOD_MGMT_SETUP_CLIENT_TELEM     = 247,
+	DRPC_METHOD_MGMT_BIO_TRIM               = 248,
+	DRPC_METHOD_MGMT_JOB_PROLOG             = 249,
+	DRPC_METHOD_MGMT_JOB_EPILOG             = 250,
+	DRPC_METHOD_MGMT_BIO_IO_STATS           = 251,
+	DRPC_METHOD_MGMT_POD_PREPARE            = 252,
+	DRPC_METHOD_MGMT_POD_RELEASE            = 253,
+	DRPC_METHOD_MGMT_SWIM_VIEW              = 254,
+	DRPC_METHOD_MGMT_AGENT_STATUS           = 255,
 
 	NUM_DRPC_MGMT_METHODS /* Must be last */
 };
@@ -97,4 +108,10 @@ enum drpc_sec_method {
 	NUM_DRPC_SEC_METHODS			/* Must be last */
 };
 
+enum drpc_batch_method {
+	DRPC_METHOD_BATCH_CALLS			= 501,
+
+	NUM_DRPC_BATCH_METHODS			/* Must be last */
+};
+
 #endif /* __DAOS_DRPC_MODULES_H__ */
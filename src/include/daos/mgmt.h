@@ -41,6 +41,8 @@ struct dc_mgmt_sys_info {
 	d_rank_list_t  *ms_ranks;
 	char		system_name[DAOS_SYS_INFO_STRING_MAX + 1];
 	uint32_t        provider_idx; /* Provider index (if more than one available) */
+	uint32_t        numa_node;    /* NUMA node of the fabric interface */
+	char            core_set[DAOS_SYS_INFO_STRING_MAX + 1]; /* Cores near the interface */
 };
 
 /** Client system handle */
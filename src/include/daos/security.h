@@ -1,5 +1,5 @@
 /*
- * (C) Copyright 2018-2023 Intel Corporation.
+ * (C) Copyright 2018-2024 Intel Corporation.
  *
  * SPDX-License-Identifier: BSD-2-Clause-Patent
  */
@@ -18,6 +18,13 @@ struct d_ownership {
 	char *group;	/** name of the group owner */
 };
 
+/**
+ * Environment variable holding a delegation token obtained by the job launcher
+ * (see `daos_agent delegation-token`). If set, the token is redeemed with the
+ * local agent instead of requesting credentials based on the local account.
+ */
+#define DAOS_DELEGATION_TOKEN_ENV "DAOS_DELEGATION_TOKEN"
+
 /**
  * Request the security credentials for the current user from the DAOS agent.
  *
@@ -26,6 +33,9 @@ struct d_ownership {
  *
  * The DAOS agent must be alive and listening on the configured agent socket.
  *
+ * If \ref DAOS_DELEGATION_TOKEN_ENV is set, the agent redeems the delegation
+ * token for a credential on behalf of the user it was issued to.
+ *
  * \param[out]	creds		Returned security credentials for current user.
  *
  * \return	0		Success. The security credential has
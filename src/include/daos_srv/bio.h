@@ -374,6 +374,24 @@ bio_free_dev_info(struct bio_dev_info *dev_info)
 	D_FREE(dev_info);
 }
 
+/* Number of log2 (in microseconds) latency buckets of bio_dev_io_stats */
+#define BIO_IO_LAT_BUCKETS	24
+
+/*
+ * Device I/O statistics inquired from BIO, counters are cumulative and the
+ * latency histograms cover the last complete sampling window.
+ */
+struct bio_dev_io_stats {
+	uint64_t		bis_read_ops;
+	uint64_t		bis_write_ops;
+	uint64_t		bis_unmap_ops;
+	uint64_t		bis_read_bytes;
+	uint64_t		bis_write_bytes;
+	uint32_t		bis_queue_depth;	/* In-flight I/Os */
+	uint64_t		bis_read_lat[BIO_IO_LAT_BUCKETS];
+	uint64_t		bis_write_lat[BIO_IO_LAT_BUCKETS];
+};
+
 /**
  * List all devices.
  *
@@ -768,6 +786,30 @@ int bio_get_dev_state(struct nvme_stats *dev_state, uuid_t dev_uuid,
  */
 int bio_get_bs_state(int *blobstore_state, uuid_t dev_uuid, struct bio_xs_context *xs);
 
+/*
+ * Helper function to accumulate the I/O statistics of a device for a given
+ * xstream. Used for querying the device I/O stats from the control plane,
+ * the caller sums up the stats of all the xstreams sharing the device.
+ *
+ * \param stats		[IN/OUT]	Device I/O stats to add to
+ * \param dev_uuid	[IN]		UUID of device
+ * \param xs		[IN]		xstream context
+ *
+ * \return			Zero on success, negative value on error
+ */
+int bio_get_io_stats(struct bio_dev_io_stats *stats, uuid_t dev_uuid,
+		     struct bio_xs_context *xs);
+
+/*
+ * Estimate a latency percentile from the histogram of bio_dev_io_stats.
+ *
+ * \param buckets	[IN]	Latency histogram, BIO_IO_LAT_BUCKETS entries
+ * \param permille	[IN]	Percentile in per mille, e.g. 999 for p99.9
+ *
+ * \return			Latency in microseconds, zero for empty histogram
+ */
+uint64_t bio_io_lat_percentile(const uint64_t *buckets, unsigned int permille);
+
 
 /*
  * Helper function to set the device health state to FAULTY, and trigger device
@@ -258,6 +258,8 @@ int ds_pool_child_start(uuid_t pool_uuid, bool recreate);
 int ds_pool_child_stop(uuid_t pool_uuid, bool free);
 /* Query pool child state */
 uint32_t ds_pool_child_state(uuid_t pool_uuid, uint32_t tgt_id);
+/* Trim free NVMe space of the pool children on current xstream */
+int ds_pool_child_trim(uint64_t max_blks, uint64_t *trimmed_blks);
 
 int ds_pool_bcast_create(crt_context_t ctx, struct ds_pool *pool,
 			 enum daos_module_id module, crt_opcode_t opcode,
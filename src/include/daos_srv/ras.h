@@ -1,5 +1,5 @@
 /**
- * (C) Copyright 2020-2023 Intel Corporation.
+ * (C) Copyright 2020-2024 Intel Corporation.
  *
  * SPDX-License-Identifier: BSD-2-Clause-Patent
  */
@@ -61,7 +61,19 @@
 	X(RAS_DEVICE_PLUGGED, "device_plugged")                                                    \
 	X(RAS_DEVICE_REPLACE, "device_replace")                                                    \
 	X(RAS_SYSTEM_FABRIC_PROV_CHANGED, "system_fabric_provider_changed")                        \
-	X(RAS_ENGINE_JOIN_FAILED, "engine_join_failed")
+	X(RAS_ENGINE_JOIN_FAILED, "engine_join_failed")                                            \
+	X(RAS_SYSTEM_MEMBER_UNSTABLE, "system_member_unstable")                                    \
+	X(RAS_ENGINE_MEMORY_PRESSURE, "engine_memory_pressure")                                    \
+	X(RAS_DEVICE_SCM_HEALTH_WARNING, "device_scm_health_warning")                              \
+	X(RAS_SYSTEM_DESTRUCTIVE_OP, "system_destructive_op")                                      \
+	X(RAS_POOL_AUTO_REINTEGRATE, "pool_auto_reintegrate")                                      \
+	X(RAS_AGENT_FABRIC_FAILOVER, "agent_fabric_failover")                                      \
+	X(RAS_SYSTEM_CLOCK_SKEW, "system_clock_skew")                                              \
+	X(RAS_ENGINE_SUPERBLOCK_MISMATCH, "engine_superblock_mismatch")                            \
+	X(RAS_ENGINE_STARTUP_TIMEOUT, "engine_startup_timeout")                                    \
+	X(RAS_AGENT_FABRIC_IFACE_ADDED, "agent_fabric_iface_added")                                \
+	X(RAS_POOL_SPACE_PRESSURE, "pool_space_pressure")                                          \
+	X(RAS_POOL_SPACE_FORECAST, "pool_space_forecast")
 
 /** Define RAS event enum */
 typedef enum {
@@ -324,6 +324,18 @@ int vea_query(struct vea_space_info *vsi, struct vea_attr *attr,
  */
 int vea_flush(struct vea_space_info *vsi, uint32_t nr_flush, uint32_t *nr_flushed);
 
+/**
+ * Unmap (TRIM) the free extents, so that the SSD can reclaim the blocks which were
+ * freed before the device supported unmap or when an unmap failed.
+ *
+ * \param vsi          [IN]	In-memory compound index
+ * \param max_blks     [IN]	Unmap at most @max_blks blocks (0 means no limit)
+ * \param trimmed_blks [OUT]	How many blocks are actually unmapped (optional)
+ *
+ * \return			Zero on success; Appropriated negative value on error
+ */
+int vea_trim(struct vea_space_info *vsi, uint64_t max_blks, uint64_t *trimmed_blks);
+
 /**
  * Free metrcis
  *
@@ -1262,6 +1262,8 @@ vos_gc_pool(daos_handle_t poh, int credits, int (*yield_func)(void *arg),
 	    void *yield_arg);
 int
 vos_flush_pool(daos_handle_t poh, uint32_t nr_flush, uint32_t *nr_flushed);
+int
+vos_pool_trim(daos_handle_t poh, uint64_t max_blks, uint64_t *trimmed_blks);
 
 bool
 vos_gc_pool_idle(daos_handle_t poh);
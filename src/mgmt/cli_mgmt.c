@@ -194,12 +194,21 @@ fill_sys_info(Mgmt__GetAttachInfoResp *resp, struct dc_mgmt_sys_info *info)
 
 	info->provider_idx = resp->client_net_hint->provider_idx;
 
+	/* The core binding hint is advisory; ignore it if it can't be used. */
+	info->numa_node = hint->numa_node;
+	if (hint->core_set != NULL && copy_str(info->core_set, hint->core_set)) {
+		D_NOTE("GetAttachInfo core set too long, ignoring it\n");
+		info->core_set[0] = '\0';
+	}
+
 	D_DEBUG(DB_MGMT,
 		"GetAttachInfo Provider: %s, Interface: %s, Domain: %s,"
 		"CRT_TIMEOUT: %u, "
-		"FI_OFI_RXM_USE_SRX: %d, CRT_SECONDARY_PROVIDER: %d\n",
+		"FI_OFI_RXM_USE_SRX: %d, CRT_SECONDARY_PROVIDER: %d, "
+		"NUMA node: %u, Core set: %s\n",
 		info->provider, info->interface, info->domain,
-		info->crt_timeout, info->srv_srx_set, info->provider_idx);
+		info->crt_timeout, info->srv_srx_set, info->provider_idx,
+		info->numa_node, info->core_set);
 
 	return 0;
 }
@@ -600,6 +609,23 @@ dc_mgmt_net_cfg(const char *name, crt_init_options_t *crt_info)
 	if (NULL == crt_info->cio_domain)
 		D_GOTO(cleanup, rc = -DER_NOMEM);
 
+	/*
+	 * Advertise the agent's core binding hint for the interface so that
+	 * runtimes and launch scripts can place network progress threads near
+	 * the NIC. Values already set by the user take precedence.
+	 */
+	if (strnlen(info->core_set, sizeof(info->core_set)) > 0) {
+		sprintf(buf, "%u", info->numa_node);
+		rc = d_setenv("DAOS_NET_NUMA_NODE", buf, 0);
+		if (rc != 0)
+			D_GOTO(cleanup, rc = d_errno2der(errno));
+		rc = d_setenv("DAOS_NET_CORE_SET", info->core_set, 0);
+		if (rc != 0)
+			D_GOTO(cleanup, rc = d_errno2der(errno));
+		D_INFO("Network interface NUMA node: %u, recommended cores: %s\n",
+		       info->numa_node, info->core_set);
+	}
+
 	D_INFO("Network interface: %s, Domain: %s\n", info->interface, info->domain);
 	D_DEBUG(DB_MGMT,
 		"CaRT initialization with:\n"
@@ -97,123 +97,1998 @@ void   ctl__set_log_masks_resp__free_unpacked
   assert(message->base.descriptor == &ctl__set_log_masks_resp__descriptor);
   protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
 }
+void   ctl__set_control_log_levels_req__subsystems_entry__init
+                     (Ctl__SetControlLogLevelsReq__SubsystemsEntry         *message)
+{
+  static const Ctl__SetControlLogLevelsReq__SubsystemsEntry init_value = CTL__SET_CONTROL_LOG_LEVELS_REQ__SUBSYSTEMS_ENTRY__INIT;
+  *message = init_value;
+}
+void   ctl__set_control_log_levels_req__init
+                     (Ctl__SetControlLogLevelsReq         *message)
+{
+  static const Ctl__SetControlLogLevelsReq init_value = CTL__SET_CONTROL_LOG_LEVELS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__set_control_log_levels_req__get_packed_size
+                     (const Ctl__SetControlLogLevelsReq *message)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__set_control_log_levels_req__pack
+                     (const Ctl__SetControlLogLevelsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__set_control_log_levels_req__pack_to_buffer
+                     (const Ctl__SetControlLogLevelsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SetControlLogLevelsReq *
+       ctl__set_control_log_levels_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SetControlLogLevelsReq *)
+     protobuf_c_message_unpack (&ctl__set_control_log_levels_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__set_control_log_levels_req__free_unpacked
+                     (Ctl__SetControlLogLevelsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__set_control_log_levels_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__set_control_log_levels_resp__subsystems_entry__init
+                     (Ctl__SetControlLogLevelsResp__SubsystemsEntry         *message)
+{
+  static const Ctl__SetControlLogLevelsResp__SubsystemsEntry init_value = CTL__SET_CONTROL_LOG_LEVELS_RESP__SUBSYSTEMS_ENTRY__INIT;
+  *message = init_value;
+}
+void   ctl__set_control_log_levels_resp__init
+                     (Ctl__SetControlLogLevelsResp         *message)
+{
+  static const Ctl__SetControlLogLevelsResp init_value = CTL__SET_CONTROL_LOG_LEVELS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__set_control_log_levels_resp__get_packed_size
+                     (const Ctl__SetControlLogLevelsResp *message)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__set_control_log_levels_resp__pack
+                     (const Ctl__SetControlLogLevelsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__set_control_log_levels_resp__pack_to_buffer
+                     (const Ctl__SetControlLogLevelsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__set_control_log_levels_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SetControlLogLevelsResp *
+       ctl__set_control_log_levels_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SetControlLogLevelsResp *)
+     protobuf_c_message_unpack (&ctl__set_control_log_levels_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__set_control_log_levels_resp__free_unpacked
+                     (Ctl__SetControlLogLevelsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__set_control_log_levels_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__set_profiling_req__init
+                     (Ctl__SetProfilingReq         *message)
+{
+  static const Ctl__SetProfilingReq init_value = CTL__SET_PROFILING_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__set_profiling_req__get_packed_size
+                     (const Ctl__SetProfilingReq *message)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__set_profiling_req__pack
+                     (const Ctl__SetProfilingReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__set_profiling_req__pack_to_buffer
+                     (const Ctl__SetProfilingReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SetProfilingReq *
+       ctl__set_profiling_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SetProfilingReq *)
+     protobuf_c_message_unpack (&ctl__set_profiling_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__set_profiling_req__free_unpacked
+                     (Ctl__SetProfilingReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__set_profiling_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__set_profiling_resp__init
+                     (Ctl__SetProfilingResp         *message)
+{
+  static const Ctl__SetProfilingResp init_value = CTL__SET_PROFILING_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__set_profiling_resp__get_packed_size
+                     (const Ctl__SetProfilingResp *message)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__set_profiling_resp__pack
+                     (const Ctl__SetProfilingResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__set_profiling_resp__pack_to_buffer
+                     (const Ctl__SetProfilingResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__set_profiling_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SetProfilingResp *
+       ctl__set_profiling_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SetProfilingResp *)
+     protobuf_c_message_unpack (&ctl__set_profiling_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__set_profiling_resp__free_unpacked
+                     (Ctl__SetProfilingResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__set_profiling_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_usage_req__init
+                     (Ctl__EngineUsageReq         *message)
+{
+  static const Ctl__EngineUsageReq init_value = CTL__ENGINE_USAGE_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_usage_req__get_packed_size
+                     (const Ctl__EngineUsageReq *message)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_usage_req__pack
+                     (const Ctl__EngineUsageReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_usage_req__pack_to_buffer
+                     (const Ctl__EngineUsageReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineUsageReq *
+       ctl__engine_usage_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineUsageReq *)
+     protobuf_c_message_unpack (&ctl__engine_usage_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_usage_req__free_unpacked
+                     (Ctl__EngineUsageReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_usage_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_usage__cpu_seconds_entry__init
+                     (Ctl__EngineUsage__CpuSecondsEntry         *message)
+{
+  static const Ctl__EngineUsage__CpuSecondsEntry init_value = CTL__ENGINE_USAGE__CPU_SECONDS_ENTRY__INIT;
+  *message = init_value;
+}
+void   ctl__engine_usage__init
+                     (Ctl__EngineUsage         *message)
+{
+  static const Ctl__EngineUsage init_value = CTL__ENGINE_USAGE__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_usage__get_packed_size
+                     (const Ctl__EngineUsage *message)
+{
+  assert(message->base.descriptor == &ctl__engine_usage__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_usage__pack
+                     (const Ctl__EngineUsage *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_usage__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_usage__pack_to_buffer
+                     (const Ctl__EngineUsage *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_usage__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineUsage *
+       ctl__engine_usage__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineUsage *)
+     protobuf_c_message_unpack (&ctl__engine_usage__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_usage__free_unpacked
+                     (Ctl__EngineUsage *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_usage__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_usage_resp__init
+                     (Ctl__EngineUsageResp         *message)
+{
+  static const Ctl__EngineUsageResp init_value = CTL__ENGINE_USAGE_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_usage_resp__get_packed_size
+                     (const Ctl__EngineUsageResp *message)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_usage_resp__pack
+                     (const Ctl__EngineUsageResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_usage_resp__pack_to_buffer
+                     (const Ctl__EngineUsageResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_usage_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineUsageResp *
+       ctl__engine_usage_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineUsageResp *)
+     protobuf_c_message_unpack (&ctl__engine_usage_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_usage_resp__free_unpacked
+                     (Ctl__EngineUsageResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_usage_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_tunables_req__init
+                     (Ctl__EngineTunablesReq         *message)
+{
+  static const Ctl__EngineTunablesReq init_value = CTL__ENGINE_TUNABLES_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_tunables_req__get_packed_size
+                     (const Ctl__EngineTunablesReq *message)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_tunables_req__pack
+                     (const Ctl__EngineTunablesReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_tunables_req__pack_to_buffer
+                     (const Ctl__EngineTunablesReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineTunablesReq *
+       ctl__engine_tunables_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineTunablesReq *)
+     protobuf_c_message_unpack (&ctl__engine_tunables_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_tunables_req__free_unpacked
+                     (Ctl__EngineTunablesReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_tunables_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_tunables__init
+                     (Ctl__EngineTunables         *message)
+{
+  static const Ctl__EngineTunables init_value = CTL__ENGINE_TUNABLES__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_tunables__get_packed_size
+                     (const Ctl__EngineTunables *message)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_tunables__pack
+                     (const Ctl__EngineTunables *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_tunables__pack_to_buffer
+                     (const Ctl__EngineTunables *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineTunables *
+       ctl__engine_tunables__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineTunables *)
+     protobuf_c_message_unpack (&ctl__engine_tunables__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_tunables__free_unpacked
+                     (Ctl__EngineTunables *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_tunables__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_tunables_resp__init
+                     (Ctl__EngineTunablesResp         *message)
+{
+  static const Ctl__EngineTunablesResp init_value = CTL__ENGINE_TUNABLES_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_tunables_resp__get_packed_size
+                     (const Ctl__EngineTunablesResp *message)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_tunables_resp__pack
+                     (const Ctl__EngineTunablesResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_tunables_resp__pack_to_buffer
+                     (const Ctl__EngineTunablesResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_tunables_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineTunablesResp *
+       ctl__engine_tunables_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineTunablesResp *)
+     protobuf_c_message_unpack (&ctl__engine_tunables_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_tunables_resp__free_unpacked
+                     (Ctl__EngineTunablesResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_tunables_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__server_config_apply_req__init
+                     (Ctl__ServerConfigApplyReq         *message)
+{
+  static const Ctl__ServerConfigApplyReq init_value = CTL__SERVER_CONFIG_APPLY_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__server_config_apply_req__get_packed_size
+                     (const Ctl__ServerConfigApplyReq *message)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__server_config_apply_req__pack
+                     (const Ctl__ServerConfigApplyReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__server_config_apply_req__pack_to_buffer
+                     (const Ctl__ServerConfigApplyReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__ServerConfigApplyReq *
+       ctl__server_config_apply_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__ServerConfigApplyReq *)
+     protobuf_c_message_unpack (&ctl__server_config_apply_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__server_config_apply_req__free_unpacked
+                     (Ctl__ServerConfigApplyReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__server_config_apply_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__server_config_apply_resp__init
+                     (Ctl__ServerConfigApplyResp         *message)
+{
+  static const Ctl__ServerConfigApplyResp init_value = CTL__SERVER_CONFIG_APPLY_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__server_config_apply_resp__get_packed_size
+                     (const Ctl__ServerConfigApplyResp *message)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__server_config_apply_resp__pack
+                     (const Ctl__ServerConfigApplyResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__server_config_apply_resp__pack_to_buffer
+                     (const Ctl__ServerConfigApplyResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__server_config_apply_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__ServerConfigApplyResp *
+       ctl__server_config_apply_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__ServerConfigApplyResp *)
+     protobuf_c_message_unpack (&ctl__server_config_apply_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__server_config_apply_resp__free_unpacked
+                     (Ctl__ServerConfigApplyResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__server_config_apply_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__swim_member__init
+                     (Ctl__SwimMember         *message)
+{
+  static const Ctl__SwimMember init_value = CTL__SWIM_MEMBER__INIT;
+  *message = init_value;
+}
+size_t ctl__swim_member__get_packed_size
+                     (const Ctl__SwimMember *message)
+{
+  assert(message->base.descriptor == &ctl__swim_member__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__swim_member__pack
+                     (const Ctl__SwimMember *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__swim_member__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__swim_member__pack_to_buffer
+                     (const Ctl__SwimMember *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__swim_member__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SwimMember *
+       ctl__swim_member__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SwimMember *)
+     protobuf_c_message_unpack (&ctl__swim_member__descriptor,
+                                allocator, len, data);
+}
+void   ctl__swim_member__free_unpacked
+                     (Ctl__SwimMember *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__swim_member__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__swim_view_req__init
+                     (Ctl__SwimViewReq         *message)
+{
+  static const Ctl__SwimViewReq init_value = CTL__SWIM_VIEW_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__swim_view_req__get_packed_size
+                     (const Ctl__SwimViewReq *message)
+{
+  assert(message->base.descriptor == &ctl__swim_view_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__swim_view_req__pack
+                     (const Ctl__SwimViewReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__swim_view_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__swim_view_req__pack_to_buffer
+                     (const Ctl__SwimViewReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__swim_view_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SwimViewReq *
+       ctl__swim_view_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SwimViewReq *)
+     protobuf_c_message_unpack (&ctl__swim_view_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__swim_view_req__free_unpacked
+                     (Ctl__SwimViewReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__swim_view_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__swim_view_resp__init
+                     (Ctl__SwimViewResp         *message)
+{
+  static const Ctl__SwimViewResp init_value = CTL__SWIM_VIEW_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__swim_view_resp__get_packed_size
+                     (const Ctl__SwimViewResp *message)
+{
+  assert(message->base.descriptor == &ctl__swim_view_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__swim_view_resp__pack
+                     (const Ctl__SwimViewResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__swim_view_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__swim_view_resp__pack_to_buffer
+                     (const Ctl__SwimViewResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__swim_view_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SwimViewResp *
+       ctl__swim_view_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SwimViewResp *)
+     protobuf_c_message_unpack (&ctl__swim_view_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__swim_view_resp__free_unpacked
+                     (Ctl__SwimViewResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__swim_view_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_swim_view__init
+                     (Ctl__EngineSwimView         *message)
+{
+  static const Ctl__EngineSwimView init_value = CTL__ENGINE_SWIM_VIEW__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_swim_view__get_packed_size
+                     (const Ctl__EngineSwimView *message)
+{
+  assert(message->base.descriptor == &ctl__engine_swim_view__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_swim_view__pack
+                     (const Ctl__EngineSwimView *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_swim_view__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_swim_view__pack_to_buffer
+                     (const Ctl__EngineSwimView *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_swim_view__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineSwimView *
+       ctl__engine_swim_view__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineSwimView *)
+     protobuf_c_message_unpack (&ctl__engine_swim_view__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_swim_view__free_unpacked
+                     (Ctl__EngineSwimView *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_swim_view__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__swim_view_query_resp__init
+                     (Ctl__SwimViewQueryResp         *message)
+{
+  static const Ctl__SwimViewQueryResp init_value = CTL__SWIM_VIEW_QUERY_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__swim_view_query_resp__get_packed_size
+                     (const Ctl__SwimViewQueryResp *message)
+{
+  assert(message->base.descriptor == &ctl__swim_view_query_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__swim_view_query_resp__pack
+                     (const Ctl__SwimViewQueryResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__swim_view_query_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__swim_view_query_resp__pack_to_buffer
+                     (const Ctl__SwimViewQueryResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__swim_view_query_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__SwimViewQueryResp *
+       ctl__swim_view_query_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__SwimViewQueryResp *)
+     protobuf_c_message_unpack (&ctl__swim_view_query_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__swim_view_query_resp__free_unpacked
+                     (Ctl__SwimViewQueryResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__swim_view_query_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
 static const ProtobufCFieldDescriptor ctl__set_log_masks_req__field_descriptors[7] =
 {
   {
-    "sys",
-    1,
+    "sys",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, sys),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "masks",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, masks),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "streams",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, streams),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "subsystems",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, subsystems),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "reset_masks",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, reset_masks),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "reset_streams",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, reset_streams),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "reset_subsystems",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksReq, reset_subsystems),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_log_masks_req__field_indices_by_name[] = {
+  1,   /* field[1] = masks */
+  4,   /* field[4] = reset_masks */
+  5,   /* field[5] = reset_streams */
+  6,   /* field[6] = reset_subsystems */
+  2,   /* field[2] = streams */
+  3,   /* field[3] = subsystems */
+  0,   /* field[0] = sys */
+};
+static const ProtobufCIntRange ctl__set_log_masks_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 7 }
+};
+const ProtobufCMessageDescriptor ctl__set_log_masks_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetLogMasksReq",
+  "SetLogMasksReq",
+  "Ctl__SetLogMasksReq",
+  "ctl",
+  sizeof(Ctl__SetLogMasksReq),
+  7,
+  ctl__set_log_masks_req__field_descriptors,
+  ctl__set_log_masks_req__field_indices_by_name,
+  1,  ctl__set_log_masks_req__number_ranges,
+  (ProtobufCMessageInit) ctl__set_log_masks_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_log_masks_resp__field_descriptors[2] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetLogMasksResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "errors",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_STRING,
+    offsetof(Ctl__SetLogMasksResp, n_errors),
+    offsetof(Ctl__SetLogMasksResp, errors),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_log_masks_resp__field_indices_by_name[] = {
+  1,   /* field[1] = errors */
+  0,   /* field[0] = status */
+};
+static const ProtobufCIntRange ctl__set_log_masks_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__set_log_masks_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetLogMasksResp",
+  "SetLogMasksResp",
+  "Ctl__SetLogMasksResp",
+  "ctl",
+  sizeof(Ctl__SetLogMasksResp),
+  2,
+  ctl__set_log_masks_resp__field_descriptors,
+  ctl__set_log_masks_resp__field_indices_by_name,
+  1,  ctl__set_log_masks_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__set_log_masks_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_control_log_levels_req__subsystems_entry__field_descriptors[2] =
+{
+  {
+    "key",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsReq__SubsystemsEntry, key),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "value",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsReq__SubsystemsEntry, value),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_control_log_levels_req__subsystems_entry__field_indices_by_name[] = {
+  0,   /* field[0] = key */
+  1,   /* field[1] = value */
+};
+static const ProtobufCIntRange ctl__set_control_log_levels_req__subsystems_entry__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__set_control_log_levels_req__subsystems_entry__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetControlLogLevelsReq.SubsystemsEntry",
+  "SubsystemsEntry",
+  "Ctl__SetControlLogLevelsReq__SubsystemsEntry",
+  "ctl",
+  sizeof(Ctl__SetControlLogLevelsReq__SubsystemsEntry),
+  2,
+  ctl__set_control_log_levels_req__subsystems_entry__field_descriptors,
+  ctl__set_control_log_levels_req__subsystems_entry__field_indices_by_name,
+  1,  ctl__set_control_log_levels_req__subsystems_entry__number_ranges,
+  (ProtobufCMessageInit) ctl__set_control_log_levels_req__subsystems_entry__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_control_log_levels_req__field_descriptors[4] =
+{
+  {
+    "sys",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsReq, sys),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "level",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsReq, level),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "subsystems",
+    3,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__SetControlLogLevelsReq, n_subsystems),
+    offsetof(Ctl__SetControlLogLevelsReq, subsystems),
+    &ctl__set_control_log_levels_req__subsystems_entry__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "reset_levels",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsReq, reset_levels),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_control_log_levels_req__field_indices_by_name[] = {
+  1,   /* field[1] = level */
+  3,   /* field[3] = reset_levels */
+  2,   /* field[2] = subsystems */
+  0,   /* field[0] = sys */
+};
+static const ProtobufCIntRange ctl__set_control_log_levels_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 4 }
+};
+const ProtobufCMessageDescriptor ctl__set_control_log_levels_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetControlLogLevelsReq",
+  "SetControlLogLevelsReq",
+  "Ctl__SetControlLogLevelsReq",
+  "ctl",
+  sizeof(Ctl__SetControlLogLevelsReq),
+  4,
+  ctl__set_control_log_levels_req__field_descriptors,
+  ctl__set_control_log_levels_req__field_indices_by_name,
+  1,  ctl__set_control_log_levels_req__number_ranges,
+  (ProtobufCMessageInit) ctl__set_control_log_levels_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_control_log_levels_resp__subsystems_entry__field_descriptors[2] =
+{
+  {
+    "key",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsResp__SubsystemsEntry, key),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "value",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsResp__SubsystemsEntry, value),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_control_log_levels_resp__subsystems_entry__field_indices_by_name[] = {
+  0,   /* field[0] = key */
+  1,   /* field[1] = value */
+};
+static const ProtobufCIntRange ctl__set_control_log_levels_resp__subsystems_entry__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__set_control_log_levels_resp__subsystems_entry__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetControlLogLevelsResp.SubsystemsEntry",
+  "SubsystemsEntry",
+  "Ctl__SetControlLogLevelsResp__SubsystemsEntry",
+  "ctl",
+  sizeof(Ctl__SetControlLogLevelsResp__SubsystemsEntry),
+  2,
+  ctl__set_control_log_levels_resp__subsystems_entry__field_descriptors,
+  ctl__set_control_log_levels_resp__subsystems_entry__field_indices_by_name,
+  1,  ctl__set_control_log_levels_resp__subsystems_entry__number_ranges,
+  (ProtobufCMessageInit) ctl__set_control_log_levels_resp__subsystems_entry__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_control_log_levels_resp__field_descriptors[2] =
+{
+  {
+    "level",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetControlLogLevelsResp, level),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "subsystems",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__SetControlLogLevelsResp, n_subsystems),
+    offsetof(Ctl__SetControlLogLevelsResp, subsystems),
+    &ctl__set_control_log_levels_resp__subsystems_entry__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_control_log_levels_resp__field_indices_by_name[] = {
+  0,   /* field[0] = level */
+  1,   /* field[1] = subsystems */
+};
+static const ProtobufCIntRange ctl__set_control_log_levels_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__set_control_log_levels_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetControlLogLevelsResp",
+  "SetControlLogLevelsResp",
+  "Ctl__SetControlLogLevelsResp",
+  "ctl",
+  sizeof(Ctl__SetControlLogLevelsResp),
+  2,
+  ctl__set_control_log_levels_resp__field_descriptors,
+  ctl__set_control_log_levels_resp__field_indices_by_name,
+  1,  ctl__set_control_log_levels_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__set_control_log_levels_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_profiling_req__field_descriptors[3] =
+{
+  {
+    "sys",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetProfilingReq, sys),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "enable",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetProfilingReq, enable),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "address",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetProfilingReq, address),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_profiling_req__field_indices_by_name[] = {
+  2,   /* field[2] = address */
+  1,   /* field[1] = enable */
+  0,   /* field[0] = sys */
+};
+static const ProtobufCIntRange ctl__set_profiling_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 3 }
+};
+const ProtobufCMessageDescriptor ctl__set_profiling_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetProfilingReq",
+  "SetProfilingReq",
+  "Ctl__SetProfilingReq",
+  "ctl",
+  sizeof(Ctl__SetProfilingReq),
+  3,
+  ctl__set_profiling_req__field_descriptors,
+  ctl__set_profiling_req__field_indices_by_name,
+  1,  ctl__set_profiling_req__number_ranges,
+  (ProtobufCMessageInit) ctl__set_profiling_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__set_profiling_resp__field_descriptors[2] =
+{
+  {
+    "enabled",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetProfilingResp, enabled),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "address",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SetProfilingResp, address),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__set_profiling_resp__field_indices_by_name[] = {
+  1,   /* field[1] = address */
+  0,   /* field[0] = enabled */
+};
+static const ProtobufCIntRange ctl__set_profiling_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__set_profiling_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SetProfilingResp",
+  "SetProfilingResp",
+  "Ctl__SetProfilingResp",
+  "ctl",
+  sizeof(Ctl__SetProfilingResp),
+  2,
+  ctl__set_profiling_resp__field_descriptors,
+  ctl__set_profiling_resp__field_indices_by_name,
+  1,  ctl__set_profiling_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__set_profiling_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+#define ctl__engine_usage_req__field_descriptors NULL
+#define ctl__engine_usage_req__field_indices_by_name NULL
+#define ctl__engine_usage_req__number_ranges NULL
+const ProtobufCMessageDescriptor ctl__engine_usage_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineUsageReq",
+  "EngineUsageReq",
+  "Ctl__EngineUsageReq",
+  "ctl",
+  sizeof(Ctl__EngineUsageReq),
+  0,
+  ctl__engine_usage_req__field_descriptors,
+  ctl__engine_usage_req__field_indices_by_name,
+  0,  ctl__engine_usage_req__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_usage_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_usage__cpu_seconds_entry__field_descriptors[2] =
+{
+  {
+    "key",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage__CpuSecondsEntry, key),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "value",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_DOUBLE,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage__CpuSecondsEntry, value),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_usage__cpu_seconds_entry__field_indices_by_name[] = {
+  0,   /* field[0] = key */
+  1,   /* field[1] = value */
+};
+static const ProtobufCIntRange ctl__engine_usage__cpu_seconds_entry__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__engine_usage__cpu_seconds_entry__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineUsage.CpuSecondsEntry",
+  "CpuSecondsEntry",
+  "Ctl__EngineUsage__CpuSecondsEntry",
+  "ctl",
+  sizeof(Ctl__EngineUsage__CpuSecondsEntry),
+  2,
+  ctl__engine_usage__cpu_seconds_entry__field_descriptors,
+  ctl__engine_usage__cpu_seconds_entry__field_indices_by_name,
+  1,  ctl__engine_usage__cpu_seconds_entry__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_usage__cpu_seconds_entry__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_usage__field_descriptors[8] =
+{
+  {
+    "index",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, index),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "rank",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "pid",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, pid),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "rss_bytes",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, rss_bytes),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "hugepage_bytes",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, hugepage_bytes),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "open_fds",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, open_fds),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "cpu_seconds",
+    7,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineUsage, n_cpu_seconds),
+    offsetof(Ctl__EngineUsage, cpu_seconds),
+    &ctl__engine_usage__cpu_seconds_entry__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "error",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineUsage, error),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_usage__field_indices_by_name[] = {
+  6,   /* field[6] = cpu_seconds */
+  7,   /* field[7] = error */
+  4,   /* field[4] = hugepage_bytes */
+  0,   /* field[0] = index */
+  5,   /* field[5] = open_fds */
+  2,   /* field[2] = pid */
+  1,   /* field[1] = rank */
+  3,   /* field[3] = rss_bytes */
+};
+static const ProtobufCIntRange ctl__engine_usage__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 8 }
+};
+const ProtobufCMessageDescriptor ctl__engine_usage__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineUsage",
+  "EngineUsage",
+  "Ctl__EngineUsage",
+  "ctl",
+  sizeof(Ctl__EngineUsage),
+  8,
+  ctl__engine_usage__field_descriptors,
+  ctl__engine_usage__field_indices_by_name,
+  1,  ctl__engine_usage__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_usage__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_usage_resp__field_descriptors[1] =
+{
+  {
+    "engines",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineUsageResp, n_engines),
+    offsetof(Ctl__EngineUsageResp, engines),
+    &ctl__engine_usage__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_usage_resp__field_indices_by_name[] = {
+  0,   /* field[0] = engines */
+};
+static const ProtobufCIntRange ctl__engine_usage_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__engine_usage_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineUsageResp",
+  "EngineUsageResp",
+  "Ctl__EngineUsageResp",
+  "ctl",
+  sizeof(Ctl__EngineUsageResp),
+  1,
+  ctl__engine_usage_resp__field_descriptors,
+  ctl__engine_usage_resp__field_indices_by_name,
+  1,  ctl__engine_usage_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_usage_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+#define ctl__engine_tunables_req__field_descriptors NULL
+#define ctl__engine_tunables_req__field_indices_by_name NULL
+#define ctl__engine_tunables_req__number_ranges NULL
+const ProtobufCMessageDescriptor ctl__engine_tunables_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineTunablesReq",
+  "EngineTunablesReq",
+  "Ctl__EngineTunablesReq",
+  "ctl",
+  sizeof(Ctl__EngineTunablesReq),
+  0,
+  ctl__engine_tunables_req__field_descriptors,
+  ctl__engine_tunables_req__field_indices_by_name,
+  0,  ctl__engine_tunables_req__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_tunables_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_tunables__field_descriptors[15] =
+{
+  {
+    "index",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, index),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "rank",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "running",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, running),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "args",
+    4,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_STRING,
+    offsetof(Ctl__EngineTunables, n_args),
+    offsetof(Ctl__EngineTunables, args),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "env_vars",
+    5,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_STRING,
+    offsetof(Ctl__EngineTunables, n_env_vars),
+    offsetof(Ctl__EngineTunables, env_vars),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "ult_stack_size",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, ult_stack_size),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "deep_ult_stack_size",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, deep_ult_stack_size),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "mmap_ult_stacks",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, mmap_ult_stacks),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "targets",
+    9,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, targets),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "helper_streams",
+    10,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, helper_streams),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "mem_size_mib",
+    11,
     PROTOBUF_C_LABEL_NONE,
-    PROTOBUF_C_TYPE_STRING,
+    PROTOBUF_C_TYPE_UINT32,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, sys),
+    offsetof(Ctl__EngineTunables, mem_size_mib),
+    NULL,
     NULL,
-    &protobuf_c_empty_string,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
   {
-    "masks",
-    2,
+    "hugepage_size_mib",
+    12,
     PROTOBUF_C_LABEL_NONE,
-    PROTOBUF_C_TYPE_STRING,
+    PROTOBUF_C_TYPE_UINT32,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, masks),
+    offsetof(Ctl__EngineTunables, hugepage_size_mib),
+    NULL,
     NULL,
-    &protobuf_c_empty_string,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
   {
-    "streams",
-    3,
+    "pinned_numa_node",
+    13,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, pinned_numa_node),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "first_core",
+    14,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineTunables, first_core),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "error",
+    15,
     PROTOBUF_C_LABEL_NONE,
     PROTOBUF_C_TYPE_STRING,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, streams),
+    offsetof(Ctl__EngineTunables, error),
     NULL,
     &protobuf_c_empty_string,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+};
+static const unsigned ctl__engine_tunables__field_indices_by_name[] = {
+  3,   /* field[3] = args */
+  6,   /* field[6] = deep_ult_stack_size */
+  4,   /* field[4] = env_vars */
+  14,   /* field[14] = error */
+  13,   /* field[13] = first_core */
+  9,   /* field[9] = helper_streams */
+  11,   /* field[11] = hugepage_size_mib */
+  0,   /* field[0] = index */
+  10,   /* field[10] = mem_size_mib */
+  7,   /* field[7] = mmap_ult_stacks */
+  12,   /* field[12] = pinned_numa_node */
+  1,   /* field[1] = rank */
+  2,   /* field[2] = running */
+  8,   /* field[8] = targets */
+  5,   /* field[5] = ult_stack_size */
+};
+static const ProtobufCIntRange ctl__engine_tunables__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 15 }
+};
+const ProtobufCMessageDescriptor ctl__engine_tunables__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineTunables",
+  "EngineTunables",
+  "Ctl__EngineTunables",
+  "ctl",
+  sizeof(Ctl__EngineTunables),
+  15,
+  ctl__engine_tunables__field_descriptors,
+  ctl__engine_tunables__field_indices_by_name,
+  1,  ctl__engine_tunables__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_tunables__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_tunables_resp__field_descriptors[1] =
+{
   {
-    "subsystems",
-    4,
+    "engines",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineTunablesResp, n_engines),
+    offsetof(Ctl__EngineTunablesResp, engines),
+    &ctl__engine_tunables__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_tunables_resp__field_indices_by_name[] = {
+  0,   /* field[0] = engines */
+};
+static const ProtobufCIntRange ctl__engine_tunables_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__engine_tunables_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineTunablesResp",
+  "EngineTunablesResp",
+  "Ctl__EngineTunablesResp",
+  "ctl",
+  sizeof(Ctl__EngineTunablesResp),
+  1,
+  ctl__engine_tunables_resp__field_descriptors,
+  ctl__engine_tunables_resp__field_indices_by_name,
+  1,  ctl__engine_tunables_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_tunables_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__server_config_apply_req__field_descriptors[2] =
+{
+  {
+    "config",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BYTES,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__ServerConfigApplyReq, config),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "commit",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__ServerConfigApplyReq, commit),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__server_config_apply_req__field_indices_by_name[] = {
+  1,   /* field[1] = commit */
+  0,   /* field[0] = config */
+};
+static const ProtobufCIntRange ctl__server_config_apply_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__server_config_apply_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.ServerConfigApplyReq",
+  "ServerConfigApplyReq",
+  "Ctl__ServerConfigApplyReq",
+  "ctl",
+  sizeof(Ctl__ServerConfigApplyReq),
+  2,
+  ctl__server_config_apply_req__field_descriptors,
+  ctl__server_config_apply_req__field_indices_by_name,
+  1,  ctl__server_config_apply_req__number_ranges,
+  (ProtobufCMessageInit) ctl__server_config_apply_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__server_config_apply_resp__field_descriptors[2] =
+{
+  {
+    "path",
+    1,
     PROTOBUF_C_LABEL_NONE,
     PROTOBUF_C_TYPE_STRING,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, subsystems),
+    offsetof(Ctl__ServerConfigApplyResp, path),
     NULL,
     &protobuf_c_empty_string,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
   {
-    "reset_masks",
-    5,
+    "committed",
+    2,
     PROTOBUF_C_LABEL_NONE,
     PROTOBUF_C_TYPE_BOOL,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, reset_masks),
+    offsetof(Ctl__ServerConfigApplyResp, committed),
     NULL,
     NULL,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
+};
+static const unsigned ctl__server_config_apply_resp__field_indices_by_name[] = {
+  1,   /* field[1] = committed */
+  0,   /* field[0] = path */
+};
+static const ProtobufCIntRange ctl__server_config_apply_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__server_config_apply_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.ServerConfigApplyResp",
+  "ServerConfigApplyResp",
+  "Ctl__ServerConfigApplyResp",
+  "ctl",
+  sizeof(Ctl__ServerConfigApplyResp),
+  2,
+  ctl__server_config_apply_resp__field_descriptors,
+  ctl__server_config_apply_resp__field_indices_by_name,
+  1,  ctl__server_config_apply_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__server_config_apply_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__swim_member__field_descriptors[3] =
+{
   {
-    "reset_streams",
-    6,
+    "rank",
+    1,
     PROTOBUF_C_LABEL_NONE,
-    PROTOBUF_C_TYPE_BOOL,
+    PROTOBUF_C_TYPE_UINT32,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, reset_streams),
+    offsetof(Ctl__SwimMember, rank),
     NULL,
     NULL,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
   {
-    "reset_subsystems",
-    7,
+    "state",
+    2,
     PROTOBUF_C_LABEL_NONE,
-    PROTOBUF_C_TYPE_BOOL,
+    PROTOBUF_C_TYPE_ENUM,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksReq, reset_subsystems),
+    offsetof(Ctl__SwimMember, state),
+    &ctl__swim_state__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "incarnation",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__SwimMember, incarnation),
     NULL,
     NULL,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
 };
-static const unsigned ctl__set_log_masks_req__field_indices_by_name[] = {
-  1,   /* field[1] = masks */
-  4,   /* field[4] = reset_masks */
-  5,   /* field[5] = reset_streams */
-  6,   /* field[6] = reset_subsystems */
-  2,   /* field[2] = streams */
-  3,   /* field[3] = subsystems */
-  0,   /* field[0] = sys */
+static const unsigned ctl__swim_member__field_indices_by_name[] = {
+  2,   /* field[2] = incarnation */
+  0,   /* field[0] = rank */
+  1,   /* field[1] = state */
 };
-static const ProtobufCIntRange ctl__set_log_masks_req__number_ranges[1 + 1] =
+static const ProtobufCIntRange ctl__swim_member__number_ranges[1 + 1] =
 {
   { 1, 0 },
-  { 0, 7 }
+  { 0, 3 }
 };
-const ProtobufCMessageDescriptor ctl__set_log_masks_req__descriptor =
+const ProtobufCMessageDescriptor ctl__swim_member__descriptor =
 {
   PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
-  "ctl.SetLogMasksReq",
-  "SetLogMasksReq",
-  "Ctl__SetLogMasksReq",
+  "ctl.SwimMember",
+  "SwimMember",
+  "Ctl__SwimMember",
   "ctl",
-  sizeof(Ctl__SetLogMasksReq),
-  7,
-  ctl__set_log_masks_req__field_descriptors,
-  ctl__set_log_masks_req__field_indices_by_name,
-  1,  ctl__set_log_masks_req__number_ranges,
-  (ProtobufCMessageInit) ctl__set_log_masks_req__init,
+  sizeof(Ctl__SwimMember),
+  3,
+  ctl__swim_member__field_descriptors,
+  ctl__swim_member__field_indices_by_name,
+  1,  ctl__swim_member__number_ranges,
+  (ProtobufCMessageInit) ctl__swim_member__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
-static const ProtobufCFieldDescriptor ctl__set_log_masks_resp__field_descriptors[2] =
+#define ctl__swim_view_req__field_descriptors NULL
+#define ctl__swim_view_req__field_indices_by_name NULL
+#define ctl__swim_view_req__number_ranges NULL
+const ProtobufCMessageDescriptor ctl__swim_view_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SwimViewReq",
+  "SwimViewReq",
+  "Ctl__SwimViewReq",
+  "ctl",
+  sizeof(Ctl__SwimViewReq),
+  0,
+  ctl__swim_view_req__field_descriptors,
+  ctl__swim_view_req__field_indices_by_name,
+  0,  ctl__swim_view_req__number_ranges,
+  (ProtobufCMessageInit) ctl__swim_view_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__swim_view_resp__field_descriptors[2] =
 {
   {
     "status",
@@ -221,46 +2096,193 @@ static const ProtobufCFieldDescriptor ctl__set_log_masks_resp__field_descriptors
     PROTOBUF_C_LABEL_NONE,
     PROTOBUF_C_TYPE_INT32,
     0,   /* quantifier_offset */
-    offsetof(Ctl__SetLogMasksResp, status),
+    offsetof(Ctl__SwimViewResp, status),
     NULL,
     NULL,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
   {
-    "errors",
+    "members",
     2,
     PROTOBUF_C_LABEL_REPEATED,
-    PROTOBUF_C_TYPE_STRING,
-    offsetof(Ctl__SetLogMasksResp, n_errors),
-    offsetof(Ctl__SetLogMasksResp, errors),
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__SwimViewResp, n_members),
+    offsetof(Ctl__SwimViewResp, members),
+    &ctl__swim_member__descriptor,
     NULL,
-    &protobuf_c_empty_string,
     0,             /* flags */
     0,NULL,NULL    /* reserved1,reserved2, etc */
   },
 };
-static const unsigned ctl__set_log_masks_resp__field_indices_by_name[] = {
-  1,   /* field[1] = errors */
+static const unsigned ctl__swim_view_resp__field_indices_by_name[] = {
+  1,   /* field[1] = members */
   0,   /* field[0] = status */
 };
-static const ProtobufCIntRange ctl__set_log_masks_resp__number_ranges[1 + 1] =
+static const ProtobufCIntRange ctl__swim_view_resp__number_ranges[1 + 1] =
 {
   { 1, 0 },
   { 0, 2 }
 };
-const ProtobufCMessageDescriptor ctl__set_log_masks_resp__descriptor =
+const ProtobufCMessageDescriptor ctl__swim_view_resp__descriptor =
 {
   PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
-  "ctl.SetLogMasksResp",
-  "SetLogMasksResp",
-  "Ctl__SetLogMasksResp",
+  "ctl.SwimViewResp",
+  "SwimViewResp",
+  "Ctl__SwimViewResp",
   "ctl",
-  sizeof(Ctl__SetLogMasksResp),
+  sizeof(Ctl__SwimViewResp),
   2,
-  ctl__set_log_masks_resp__field_descriptors,
-  ctl__set_log_masks_resp__field_indices_by_name,
-  1,  ctl__set_log_masks_resp__number_ranges,
-  (ProtobufCMessageInit) ctl__set_log_masks_resp__init,
+  ctl__swim_view_resp__field_descriptors,
+  ctl__swim_view_resp__field_indices_by_name,
+  1,  ctl__swim_view_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__swim_view_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_swim_view__field_descriptors[4] =
+{
+  {
+    "index",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineSwimView, index),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "rank",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineSwimView, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "members",
+    3,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineSwimView, n_members),
+    offsetof(Ctl__EngineSwimView, members),
+    &ctl__swim_member__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "error",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineSwimView, error),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_swim_view__field_indices_by_name[] = {
+  3,   /* field[3] = error */
+  0,   /* field[0] = index */
+  2,   /* field[2] = members */
+  1,   /* field[1] = rank */
+};
+static const ProtobufCIntRange ctl__engine_swim_view__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 4 }
+};
+const ProtobufCMessageDescriptor ctl__engine_swim_view__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineSwimView",
+  "EngineSwimView",
+  "Ctl__EngineSwimView",
+  "ctl",
+  sizeof(Ctl__EngineSwimView),
+  4,
+  ctl__engine_swim_view__field_descriptors,
+  ctl__engine_swim_view__field_indices_by_name,
+  1,  ctl__engine_swim_view__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_swim_view__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__swim_view_query_resp__field_descriptors[1] =
+{
+  {
+    "engines",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__SwimViewQueryResp, n_engines),
+    offsetof(Ctl__SwimViewQueryResp, engines),
+    &ctl__engine_swim_view__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__swim_view_query_resp__field_indices_by_name[] = {
+  0,   /* field[0] = engines */
+};
+static const ProtobufCIntRange ctl__swim_view_query_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__swim_view_query_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.SwimViewQueryResp",
+  "SwimViewQueryResp",
+  "Ctl__SwimViewQueryResp",
+  "ctl",
+  sizeof(Ctl__SwimViewQueryResp),
+  1,
+  ctl__swim_view_query_resp__field_descriptors,
+  ctl__swim_view_query_resp__field_indices_by_name,
+  1,  ctl__swim_view_query_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__swim_view_query_resp__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
+static const ProtobufCEnumValue ctl__swim_state__enum_values_by_number[4] =
+{
+  { "SWIM_ALIVE", "CTL__SWIM_STATE__SWIM_ALIVE", 0 },
+  { "SWIM_SUSPECT", "CTL__SWIM_STATE__SWIM_SUSPECT", 1 },
+  { "SWIM_DEAD", "CTL__SWIM_STATE__SWIM_DEAD", 2 },
+  { "SWIM_INACTIVE", "CTL__SWIM_STATE__SWIM_INACTIVE", 3 },
+};
+static const ProtobufCIntRange ctl__swim_state__value_ranges[] = {
+{0, 0},{0, 4}
+};
+static const ProtobufCEnumValueIndex ctl__swim_state__enum_values_by_name[4] =
+{
+  { "SWIM_ALIVE", 0 },
+  { "SWIM_DEAD", 2 },
+  { "SWIM_INACTIVE", 3 },
+  { "SWIM_SUSPECT", 1 },
+};
+const ProtobufCEnumDescriptor ctl__swim_state__descriptor =
+{
+  PROTOBUF_C__ENUM_DESCRIPTOR_MAGIC,
+  "ctl.SwimState",
+  "SwimState",
+  "Ctl__SwimState",
+  "ctl",
+  4,
+  ctl__swim_state__enum_values_by_number,
+  4,
+  ctl__swim_state__enum_values_by_name,
+  1,
+  ctl__swim_state__value_ranges,
+  NULL,NULL,NULL,NULL   /* reserved[1234] */
+};
@@ -17,10 +17,40 @@ PROTOBUF_C__BEGIN_DECLS
 
 typedef struct _Ctl__SetLogMasksReq Ctl__SetLogMasksReq;
 typedef struct _Ctl__SetLogMasksResp Ctl__SetLogMasksResp;
+typedef struct _Ctl__SetControlLogLevelsReq Ctl__SetControlLogLevelsReq;
+typedef struct _Ctl__SetControlLogLevelsReq__SubsystemsEntry Ctl__SetControlLogLevelsReq__SubsystemsEntry;
+typedef struct _Ctl__SetControlLogLevelsResp Ctl__SetControlLogLevelsResp;
+typedef struct _Ctl__SetControlLogLevelsResp__SubsystemsEntry Ctl__SetControlLogLevelsResp__SubsystemsEntry;
+typedef struct _Ctl__SetProfilingReq Ctl__SetProfilingReq;
+typedef struct _Ctl__SetProfilingResp Ctl__SetProfilingResp;
+typedef struct _Ctl__EngineUsageReq Ctl__EngineUsageReq;
+typedef struct _Ctl__EngineUsage Ctl__EngineUsage;
+typedef struct _Ctl__EngineUsage__CpuSecondsEntry Ctl__EngineUsage__CpuSecondsEntry;
+typedef struct _Ctl__EngineUsageResp Ctl__EngineUsageResp;
+typedef struct _Ctl__EngineTunablesReq Ctl__EngineTunablesReq;
+typedef struct _Ctl__EngineTunables Ctl__EngineTunables;
+typedef struct _Ctl__EngineTunablesResp Ctl__EngineTunablesResp;
+typedef struct _Ctl__ServerConfigApplyReq Ctl__ServerConfigApplyReq;
+typedef struct _Ctl__ServerConfigApplyResp Ctl__ServerConfigApplyResp;
+typedef struct _Ctl__SwimMember Ctl__SwimMember;
+typedef struct _Ctl__SwimViewReq Ctl__SwimViewReq;
+typedef struct _Ctl__SwimViewResp Ctl__SwimViewResp;
+typedef struct _Ctl__EngineSwimView Ctl__EngineSwimView;
+typedef struct _Ctl__SwimViewQueryResp Ctl__SwimViewQueryResp;
 
 
 /* --- enums --- */
 
+/*
+ * SwimState mirrors the SWIM member status of the engine's group membership.
+ */
+typedef enum _Ctl__SwimState {
+  CTL__SWIM_STATE__SWIM_ALIVE = 0,
+  CTL__SWIM_STATE__SWIM_SUSPECT = 1,
+  CTL__SWIM_STATE__SWIM_DEAD = 2,
+  CTL__SWIM_STATE__SWIM_INACTIVE = 3
+    PROTOBUF_C__FORCE_ENUM_TO_BE_INT_SIZE(CTL__SWIM_STATE)
+} Ctl__SwimState;
 
 /* --- messages --- */
 
@@ -85,6 +115,431 @@ struct  _Ctl__SetLogMasksResp
     , 0, 0,NULL }
 
 
+struct  _Ctl__SetControlLogLevelsReq__SubsystemsEntry
+{
+  ProtobufCMessage base;
+  char *key;
+  char *value;
+};
+#define CTL__SET_CONTROL_LOG_LEVELS_REQ__SUBSYSTEMS_ENTRY__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_control_log_levels_req__subsystems_entry__descriptor) \
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string }
+
+
+/*
+ * SetControlLogLevelsReq sets daos_server log levels at runtime.
+ */
+struct  _Ctl__SetControlLogLevelsReq
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS system name
+   */
+  char *sys;
+  /*
+   * set control plane log level, leave unchanged if empty
+   */
+  char *level;
+  /*
+   * set log levels for individual subsystems
+   */
+  size_t n_subsystems;
+  Ctl__SetControlLogLevelsReq__SubsystemsEntry **subsystems;
+  /*
+   * reset log levels to the values in config before applying changes
+   */
+  protobuf_c_boolean reset_levels;
+};
+#define CTL__SET_CONTROL_LOG_LEVELS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_control_log_levels_req__descriptor) \
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0,NULL, 0 }
+
+
+struct  _Ctl__SetControlLogLevelsResp__SubsystemsEntry
+{
+  ProtobufCMessage base;
+  char *key;
+  char *value;
+};
+#define CTL__SET_CONTROL_LOG_LEVELS_RESP__SUBSYSTEMS_ENTRY__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_control_log_levels_resp__subsystems_entry__descriptor) \
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string }
+
+
+/*
+ * SetControlLogLevelsResp returns the daos_server log levels after the change.
+ */
+struct  _Ctl__SetControlLogLevelsResp
+{
+  ProtobufCMessage base;
+  /*
+   * control plane log level
+   */
+  char *level;
+  /*
+   * subsystem log levels set independently of the control plane level
+   */
+  size_t n_subsystems;
+  Ctl__SetControlLogLevelsResp__SubsystemsEntry **subsystems;
+};
+#define CTL__SET_CONTROL_LOG_LEVELS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_control_log_levels_resp__descriptor) \
+    , (char *)protobuf_c_empty_string, 0,NULL }
+
+
+/*
+ * SetProfilingReq enables or disables the daos_server profiling endpoint at runtime.
+ */
+struct  _Ctl__SetProfilingReq
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS system name
+   */
+  char *sys;
+  /*
+   * enable the profiling endpoint, disable if false
+   */
+  protobuf_c_boolean enable;
+  /*
+   * listen address, use configured or default address if empty
+   */
+  char *address;
+};
+#define CTL__SET_PROFILING_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_profiling_req__descriptor) \
+    , (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string }
+
+
+/*
+ * SetProfilingResp returns the state of the daos_server profiling endpoint after the change.
+ */
+struct  _Ctl__SetProfilingResp
+{
+  ProtobufCMessage base;
+  /*
+   * profiling endpoint is running
+   */
+  protobuf_c_boolean enabled;
+  /*
+   * address the profiling endpoint is listening on
+   */
+  char *address;
+};
+#define CTL__SET_PROFILING_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__set_profiling_resp__descriptor) \
+    , 0, (char *)protobuf_c_empty_string }
+
+
+/*
+ * EngineUsageReq requests resource usage details for DAOS I/O Engines on a host.
+ */
+struct  _Ctl__EngineUsageReq
+{
+  ProtobufCMessage base;
+};
+#define CTL__ENGINE_USAGE_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_usage_req__descriptor) \
+     }
+
+
+struct  _Ctl__EngineUsage__CpuSecondsEntry
+{
+  ProtobufCMessage base;
+  char *key;
+  double value;
+};
+#define CTL__ENGINE_USAGE__CPU_SECONDS_ENTRY__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_usage__cpu_seconds_entry__descriptor) \
+    , (char *)protobuf_c_empty_string, 0 }
+
+
+/*
+ * EngineUsage contains resource usage details for a DAOS I/O Engine process.
+ */
+struct  _Ctl__EngineUsage
+{
+  ProtobufCMessage base;
+  /*
+   * engine instance index
+   */
+  uint32_t index;
+  /*
+   * engine rank
+   */
+  uint32_t rank;
+  /*
+   * engine process ID
+   */
+  int32_t pid;
+  /*
+   * resident set size in bytes
+   */
+  uint64_t rss_bytes;
+  /*
+   * hugetlb memory in use by the process, in bytes
+   */
+  uint64_t hugepage_bytes;
+  /*
+   * number of open file descriptors
+   */
+  uint32_t open_fds;
+  /*
+   * cumulative CPU time by thread (xstream) name
+   */
+  size_t n_cpu_seconds;
+  Ctl__EngineUsage__CpuSecondsEntry **cpu_seconds;
+  /*
+   * error encountered while collecting usage, if any
+   */
+  char *error;
+};
+#define CTL__ENGINE_USAGE__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_usage__descriptor) \
+    , 0, 0, 0, 0, 0, 0, 0,NULL, (char *)protobuf_c_empty_string }
+
+
+/*
+ * EngineUsageResp returns resource usage details for DAOS I/O Engines on a host.
+ */
+struct  _Ctl__EngineUsageResp
+{
+  ProtobufCMessage base;
+  size_t n_engines;
+  Ctl__EngineUsage **engines;
+};
+#define CTL__ENGINE_USAGE_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_usage_resp__descriptor) \
+    , 0,NULL }
+
+
+/*
+ * EngineTunablesReq requests the tuning parameters of DAOS I/O Engines on a host.
+ */
+struct  _Ctl__EngineTunablesReq
+{
+  ProtobufCMessage base;
+};
+#define CTL__ENGINE_TUNABLES_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_tunables_req__descriptor) \
+     }
+
+
+/*
+ * EngineTunables contains the effective tuning parameters of a DAOS I/O Engine process.
+ */
+struct  _Ctl__EngineTunables
+{
+  ProtobufCMessage base;
+  /*
+   * engine instance index
+   */
+  uint32_t index;
+  /*
+   * engine rank
+   */
+  uint32_t rank;
+  /*
+   * engine process is running
+   */
+  protobuf_c_boolean running;
+  /*
+   * engine command line arguments
+   */
+  size_t n_args;
+  char **args;
+  /*
+   * engine environment, sensitive values redacted
+   */
+  size_t n_env_vars;
+  char **env_vars;
+  /*
+   * default ULT stack size in bytes
+   */
+  uint64_t ult_stack_size;
+  /*
+   * deep ULT stack size in bytes
+   */
+  uint64_t deep_ult_stack_size;
+  /*
+   * ULT stacks are allocated with mmap()
+   */
+  protobuf_c_boolean mmap_ult_stacks;
+  /*
+   * number of VOS targets
+   */
+  uint32_t targets;
+  /*
+   * number of helper xstreams
+   */
+  uint32_t helper_streams;
+  /*
+   * memory allocated for the engine in MiB
+   */
+  uint32_t mem_size_mib;
+  /*
+   * hugepage size in MiB
+   */
+  uint32_t hugepage_size_mib;
+  /*
+   * NUMA node the engine is pinned to, -1 if not set
+   */
+  int32_t pinned_numa_node;
+  /*
+   * first core used by the engine, -1 if not set
+   */
+  int32_t first_core;
+  /*
+   * error encountered while collecting tunables, if any
+   */
+  char *error;
+};
+#define CTL__ENGINE_TUNABLES__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_tunables__descriptor) \
+    , 0, 0, 0, 0,NULL, 0,NULL, 0, 0, 0, 0, 0, 0, 0, 0, 0, (char *)protobuf_c_empty_string }
+
+
+/*
+ * EngineTunablesResp returns the tuning parameters of DAOS I/O Engines on a host.
+ */
+struct  _Ctl__EngineTunablesResp
+{
+  ProtobufCMessage base;
+  size_t n_engines;
+  Ctl__EngineTunables **engines;
+};
+#define CTL__ENGINE_TUNABLES_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_tunables_resp__descriptor) \
+    , 0,NULL }
+
+
+/*
+ * ServerConfigApplyReq supplies a candidate server config file to be validated
+ * against the hardware of a host and optionally saved for the next restart.
+ */
+struct  _Ctl__ServerConfigApplyReq
+{
+  ProtobufCMessage base;
+  /*
+   * contents of the candidate config file
+   */
+  ProtobufCBinaryData config;
+  /*
+   * replace the config file of the server if valid
+   */
+  protobuf_c_boolean commit;
+};
+#define CTL__SERVER_CONFIG_APPLY_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__server_config_apply_req__descriptor) \
+    , {0,NULL}, 0 }
+
+
+/*
+ * ServerConfigApplyResp returns the result of applying a candidate server config.
+ */
+struct  _Ctl__ServerConfigApplyResp
+{
+  ProtobufCMessage base;
+  /*
+   * path of the config file of the server
+   */
+  char *path;
+  /*
+   * config file has been replaced
+   */
+  protobuf_c_boolean committed;
+};
+#define CTL__SERVER_CONFIG_APPLY_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__server_config_apply_resp__descriptor) \
+    , (char *)protobuf_c_empty_string, 0 }
+
+
+/*
+ * SwimMember is the state of a rank as seen by an engine's SWIM protocol.
+ */
+struct  _Ctl__SwimMember
+{
+  ProtobufCMessage base;
+  uint32_t rank;
+  Ctl__SwimState state;
+  uint64_t incarnation;
+};
+#define CTL__SWIM_MEMBER__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__swim_member__descriptor) \
+    , 0, CTL__SWIM_STATE__SWIM_ALIVE, 0 }
+
+
+/*
+ * Request for the local SWIM view of engines, used both for the gRPC fanout
+ * to hosts and for the dRPC call to each engine.
+ */
+struct  _Ctl__SwimViewReq
+{
+  ProtobufCMessage base;
+};
+#define CTL__SWIM_VIEW_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__swim_view_req__descriptor) \
+     }
+
+
+/*
+ * Response from an engine describing its local SWIM view.
+ */
+struct  _Ctl__SwimViewResp
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS error code
+   */
+  int32_t status;
+  size_t n_members;
+  Ctl__SwimMember **members;
+};
+#define CTL__SWIM_VIEW_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__swim_view_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+/*
+ * EngineSwimView is the local SWIM view of one of the engines on a host.
+ */
+struct  _Ctl__EngineSwimView
+{
+  ProtobufCMessage base;
+  /*
+   * engine instance index
+   */
+  uint32_t index;
+  /*
+   * rank of the engine holding the view
+   */
+  uint32_t rank;
+  size_t n_members;
+  Ctl__SwimMember **members;
+  /*
+   * set if the view could not be retrieved
+   */
+  char *error;
+};
+#define CTL__ENGINE_SWIM_VIEW__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_swim_view__descriptor) \
+    , 0, 0, 0,NULL, (char *)protobuf_c_empty_string }
+
+
+/*
+ * Response containing the local SWIM views of the engines on a host.
+ */
+struct  _Ctl__SwimViewQueryResp
+{
+  ProtobufCMessage base;
+  size_t n_engines;
+  Ctl__EngineSwimView **engines;
+};
+#define CTL__SWIM_VIEW_QUERY_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__swim_view_query_resp__descriptor) \
+    , 0,NULL }
+
+
 /* Ctl__SetLogMasksReq methods */
 void   ctl__set_log_masks_req__init
                      (Ctl__SetLogMasksReq         *message);
@@ -123,6 +578,338 @@ Ctl__SetLogMasksResp *
 void   ctl__set_log_masks_resp__free_unpacked
                      (Ctl__SetLogMasksResp *message,
                       ProtobufCAllocator *allocator);
+/* Ctl__SetControlLogLevelsReq__SubsystemsEntry methods */
+void   ctl__set_control_log_levels_req__subsystems_entry__init
+                     (Ctl__SetControlLogLevelsReq__SubsystemsEntry         *message);
+/* Ctl__SetControlLogLevelsReq methods */
+void   ctl__set_control_log_levels_req__init
+                     (Ctl__SetControlLogLevelsReq         *message);
+size_t ctl__set_control_log_levels_req__get_packed_size
+                     (const Ctl__SetControlLogLevelsReq   *message);
+size_t ctl__set_control_log_levels_req__pack
+                     (const Ctl__SetControlLogLevelsReq   *message,
+                      uint8_t             *out);
+size_t ctl__set_control_log_levels_req__pack_to_buffer
+                     (const Ctl__SetControlLogLevelsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SetControlLogLevelsReq *
+       ctl__set_control_log_levels_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__set_control_log_levels_req__free_unpacked
+                     (Ctl__SetControlLogLevelsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SetControlLogLevelsResp__SubsystemsEntry methods */
+void   ctl__set_control_log_levels_resp__subsystems_entry__init
+                     (Ctl__SetControlLogLevelsResp__SubsystemsEntry         *message);
+/* Ctl__SetControlLogLevelsResp methods */
+void   ctl__set_control_log_levels_resp__init
+                     (Ctl__SetControlLogLevelsResp         *message);
+size_t ctl__set_control_log_levels_resp__get_packed_size
+                     (const Ctl__SetControlLogLevelsResp   *message);
+size_t ctl__set_control_log_levels_resp__pack
+                     (const Ctl__SetControlLogLevelsResp   *message,
+                      uint8_t             *out);
+size_t ctl__set_control_log_levels_resp__pack_to_buffer
+                     (const Ctl__SetControlLogLevelsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SetControlLogLevelsResp *
+       ctl__set_control_log_levels_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__set_control_log_levels_resp__free_unpacked
+                     (Ctl__SetControlLogLevelsResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SetProfilingReq methods */
+void   ctl__set_profiling_req__init
+                     (Ctl__SetProfilingReq         *message);
+size_t ctl__set_profiling_req__get_packed_size
+                     (const Ctl__SetProfilingReq   *message);
+size_t ctl__set_profiling_req__pack
+                     (const Ctl__SetProfilingReq   *message,
+                      uint8_t             *out);
+size_t ctl__set_profiling_req__pack_to_buffer
+                     (const Ctl__SetProfilingReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SetProfilingReq *
+       ctl__set_profiling_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__set_profiling_req__free_unpacked
+                     (Ctl__SetProfilingReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SetProfilingResp methods */
+void   ctl__set_profiling_resp__init
+                     (Ctl__SetProfilingResp         *message);
+size_t ctl__set_profiling_resp__get_packed_size
+                     (const Ctl__SetProfilingResp   *message);
+size_t ctl__set_profiling_resp__pack
+                     (const Ctl__SetProfilingResp   *message,
+                      uint8_t             *out);
+size_t ctl__set_profiling_resp__pack_to_buffer
+                     (const Ctl__SetProfilingResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SetProfilingResp *
+       ctl__set_profiling_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__set_profiling_resp__free_unpacked
+                     (Ctl__SetProfilingResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineUsageReq methods */
+void   ctl__engine_usage_req__init
+                     (Ctl__EngineUsageReq         *message);
+size_t ctl__engine_usage_req__get_packed_size
+                     (const Ctl__EngineUsageReq   *message);
+size_t ctl__engine_usage_req__pack
+                     (const Ctl__EngineUsageReq   *message,
+                      uint8_t             *out);
+size_t ctl__engine_usage_req__pack_to_buffer
+                     (const Ctl__EngineUsageReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineUsageReq *
+       ctl__engine_usage_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_usage_req__free_unpacked
+                     (Ctl__EngineUsageReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineUsage__CpuSecondsEntry methods */
+void   ctl__engine_usage__cpu_seconds_entry__init
+                     (Ctl__EngineUsage__CpuSecondsEntry         *message);
+/* Ctl__EngineUsage methods */
+void   ctl__engine_usage__init
+                     (Ctl__EngineUsage         *message);
+size_t ctl__engine_usage__get_packed_size
+                     (const Ctl__EngineUsage   *message);
+size_t ctl__engine_usage__pack
+                     (const Ctl__EngineUsage   *message,
+                      uint8_t             *out);
+size_t ctl__engine_usage__pack_to_buffer
+                     (const Ctl__EngineUsage   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineUsage *
+       ctl__engine_usage__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_usage__free_unpacked
+                     (Ctl__EngineUsage *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineUsageResp methods */
+void   ctl__engine_usage_resp__init
+                     (Ctl__EngineUsageResp         *message);
+size_t ctl__engine_usage_resp__get_packed_size
+                     (const Ctl__EngineUsageResp   *message);
+size_t ctl__engine_usage_resp__pack
+                     (const Ctl__EngineUsageResp   *message,
+                      uint8_t             *out);
+size_t ctl__engine_usage_resp__pack_to_buffer
+                     (const Ctl__EngineUsageResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineUsageResp *
+       ctl__engine_usage_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_usage_resp__free_unpacked
+                     (Ctl__EngineUsageResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineTunablesReq methods */
+void   ctl__engine_tunables_req__init
+                     (Ctl__EngineTunablesReq         *message);
+size_t ctl__engine_tunables_req__get_packed_size
+                     (const Ctl__EngineTunablesReq   *message);
+size_t ctl__engine_tunables_req__pack
+                     (const Ctl__EngineTunablesReq   *message,
+                      uint8_t             *out);
+size_t ctl__engine_tunables_req__pack_to_buffer
+                     (const Ctl__EngineTunablesReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineTunablesReq *
+       ctl__engine_tunables_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_tunables_req__free_unpacked
+                     (Ctl__EngineTunablesReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineTunables methods */
+void   ctl__engine_tunables__init
+                     (Ctl__EngineTunables         *message);
+size_t ctl__engine_tunables__get_packed_size
+                     (const Ctl__EngineTunables   *message);
+size_t ctl__engine_tunables__pack
+                     (const Ctl__EngineTunables   *message,
+                      uint8_t             *out);
+size_t ctl__engine_tunables__pack_to_buffer
+                     (const Ctl__EngineTunables   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineTunables *
+       ctl__engine_tunables__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_tunables__free_unpacked
+                     (Ctl__EngineTunables *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineTunablesResp methods */
+void   ctl__engine_tunables_resp__init
+                     (Ctl__EngineTunablesResp         *message);
+size_t ctl__engine_tunables_resp__get_packed_size
+                     (const Ctl__EngineTunablesResp   *message);
+size_t ctl__engine_tunables_resp__pack
+                     (const Ctl__EngineTunablesResp   *message,
+                      uint8_t             *out);
+size_t ctl__engine_tunables_resp__pack_to_buffer
+                     (const Ctl__EngineTunablesResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineTunablesResp *
+       ctl__engine_tunables_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_tunables_resp__free_unpacked
+                     (Ctl__EngineTunablesResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__ServerConfigApplyReq methods */
+void   ctl__server_config_apply_req__init
+                     (Ctl__ServerConfigApplyReq         *message);
+size_t ctl__server_config_apply_req__get_packed_size
+                     (const Ctl__ServerConfigApplyReq   *message);
+size_t ctl__server_config_apply_req__pack
+                     (const Ctl__ServerConfigApplyReq   *message,
+                      uint8_t             *out);
+size_t ctl__server_config_apply_req__pack_to_buffer
+                     (const Ctl__ServerConfigApplyReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__ServerConfigApplyReq *
+       ctl__server_config_apply_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__server_config_apply_req__free_unpacked
+                     (Ctl__ServerConfigApplyReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__ServerConfigApplyResp methods */
+void   ctl__server_config_apply_resp__init
+                     (Ctl__ServerConfigApplyResp         *message);
+size_t ctl__server_config_apply_resp__get_packed_size
+                     (const Ctl__ServerConfigApplyResp   *message);
+size_t ctl__server_config_apply_resp__pack
+                     (const Ctl__ServerConfigApplyResp   *message,
+                      uint8_t             *out);
+size_t ctl__server_config_apply_resp__pack_to_buffer
+                     (const Ctl__ServerConfigApplyResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__ServerConfigApplyResp *
+       ctl__server_config_apply_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__server_config_apply_resp__free_unpacked
+                     (Ctl__ServerConfigApplyResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SwimMember methods */
+void   ctl__swim_member__init
+                     (Ctl__SwimMember         *message);
+size_t ctl__swim_member__get_packed_size
+                     (const Ctl__SwimMember   *message);
+size_t ctl__swim_member__pack
+                     (const Ctl__SwimMember   *message,
+                      uint8_t             *out);
+size_t ctl__swim_member__pack_to_buffer
+                     (const Ctl__SwimMember   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SwimMember *
+       ctl__swim_member__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__swim_member__free_unpacked
+                     (Ctl__SwimMember *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SwimViewReq methods */
+void   ctl__swim_view_req__init
+                     (Ctl__SwimViewReq         *message);
+size_t ctl__swim_view_req__get_packed_size
+                     (const Ctl__SwimViewReq   *message);
+size_t ctl__swim_view_req__pack
+                     (const Ctl__SwimViewReq   *message,
+                      uint8_t             *out);
+size_t ctl__swim_view_req__pack_to_buffer
+                     (const Ctl__SwimViewReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SwimViewReq *
+       ctl__swim_view_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__swim_view_req__free_unpacked
+                     (Ctl__SwimViewReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SwimViewResp methods */
+void   ctl__swim_view_resp__init
+                     (Ctl__SwimViewResp         *message);
+size_t ctl__swim_view_resp__get_packed_size
+                     (const Ctl__SwimViewResp   *message);
+size_t ctl__swim_view_resp__pack
+                     (const Ctl__SwimViewResp   *message,
+                      uint8_t             *out);
+size_t ctl__swim_view_resp__pack_to_buffer
+                     (const Ctl__SwimViewResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SwimViewResp *
+       ctl__swim_view_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__swim_view_resp__free_unpacked
+                     (Ctl__SwimViewResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineSwimView methods */
+void   ctl__engine_swim_view__init
+                     (Ctl__EngineSwimView         *message);
+size_t ctl__engine_swim_view__get_packed_size
+                     (const Ctl__EngineSwimView   *message);
+size_t ctl__engine_swim_view__pack
+                     (const Ctl__EngineSwimView   *message,
+                      uint8_t             *out);
+size_t ctl__engine_swim_view__pack_to_buffer
+                     (const Ctl__EngineSwimView   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineSwimView *
+       ctl__engine_swim_view__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_swim_view__free_unpacked
+                     (Ctl__EngineSwimView *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__SwimViewQueryResp methods */
+void   ctl__swim_view_query_resp__init
+                     (Ctl__SwimViewQueryResp         *message);
+size_t ctl__swim_view_query_resp__get_packed_size
+                     (const Ctl__SwimViewQueryResp   *message);
+size_t ctl__swim_view_query_resp__pack
+                     (const Ctl__SwimViewQueryResp   *message,
+                      uint8_t             *out);
+size_t ctl__swim_view_query_resp__pack_to_buffer
+                     (const Ctl__SwimViewQueryResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__SwimViewQueryResp *
+       ctl__swim_view_query_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__swim_view_query_resp__free_unpacked
+                     (Ctl__SwimViewQueryResp *message,
+                      ProtobufCAllocator *allocator);
 /* --- per-message closures --- */
 
 typedef void (*Ctl__SetLogMasksReq_Closure)
@@ -131,14 +918,95 @@ typedef void (*Ctl__SetLogMasksReq_Closure)
 typedef void (*Ctl__SetLogMasksResp_Closure)
                  (const Ctl__SetLogMasksResp *message,
                   void *closure_data);
+typedef void (*Ctl__SetControlLogLevelsReq__SubsystemsEntry_Closure)
+                 (const Ctl__SetControlLogLevelsReq__SubsystemsEntry *message,
+                  void *closure_data);
+typedef void (*Ctl__SetControlLogLevelsReq_Closure)
+                 (const Ctl__SetControlLogLevelsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__SetControlLogLevelsResp__SubsystemsEntry_Closure)
+                 (const Ctl__SetControlLogLevelsResp__SubsystemsEntry *message,
+                  void *closure_data);
+typedef void (*Ctl__SetControlLogLevelsResp_Closure)
+                 (const Ctl__SetControlLogLevelsResp *message,
+                  void *closure_data);
+typedef void (*Ctl__SetProfilingReq_Closure)
+                 (const Ctl__SetProfilingReq *message,
+                  void *closure_data);
+typedef void (*Ctl__SetProfilingResp_Closure)
+                 (const Ctl__SetProfilingResp *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineUsageReq_Closure)
+                 (const Ctl__EngineUsageReq *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineUsage__CpuSecondsEntry_Closure)
+                 (const Ctl__EngineUsage__CpuSecondsEntry *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineUsage_Closure)
+                 (const Ctl__EngineUsage *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineUsageResp_Closure)
+                 (const Ctl__EngineUsageResp *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineTunablesReq_Closure)
+                 (const Ctl__EngineTunablesReq *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineTunables_Closure)
+                 (const Ctl__EngineTunables *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineTunablesResp_Closure)
+                 (const Ctl__EngineTunablesResp *message,
+                  void *closure_data);
+typedef void (*Ctl__ServerConfigApplyReq_Closure)
+                 (const Ctl__ServerConfigApplyReq *message,
+                  void *closure_data);
+typedef void (*Ctl__ServerConfigApplyResp_Closure)
+                 (const Ctl__ServerConfigApplyResp *message,
+                  void *closure_data);
+typedef void (*Ctl__SwimMember_Closure)
+                 (const Ctl__SwimMember *message,
+                  void *closure_data);
+typedef void (*Ctl__SwimViewReq_Closure)
+                 (const Ctl__SwimViewReq *message,
+                  void *closure_data);
+typedef void (*Ctl__SwimViewResp_Closure)
+                 (const Ctl__SwimViewResp *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineSwimView_Closure)
+                 (const Ctl__EngineSwimView *message,
+                  void *closure_data);
+typedef void (*Ctl__SwimViewQueryResp_Closure)
+                 (const Ctl__SwimViewQueryResp *message,
+                  void *closure_data);
 
 /* --- services --- */
 
 
 /* --- descriptors --- */
 
+extern const ProtobufCEnumDescriptor    ctl__swim_state__descriptor;
 extern const ProtobufCMessageDescriptor ctl__set_log_masks_req__descriptor;
 extern const ProtobufCMessageDescriptor ctl__set_log_masks_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_control_log_levels_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_control_log_levels_req__subsystems_entry__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_control_log_levels_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_control_log_levels_resp__subsystems_entry__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_profiling_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__set_profiling_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_usage_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_usage__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_usage__cpu_seconds_entry__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_usage_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_tunables_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_tunables__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_tunables_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__server_config_apply_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__server_config_apply_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__swim_member__descriptor;
+extern const ProtobufCMessageDescriptor ctl__swim_view_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__swim_view_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_swim_view__descriptor;
+extern const ProtobufCMessageDescriptor ctl__swim_view_query_resp__descriptor;
 
 PROTOBUF_C__END_DECLS
 
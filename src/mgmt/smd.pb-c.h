@@ -37,6 +37,12 @@ typedef struct _Ctl__SmdManageReq Ctl__SmdManageReq;
 typedef struct _Ctl__SmdManageResp Ctl__SmdManageResp;
 typedef struct _Ctl__SmdManageResp__Result Ctl__SmdManageResp__Result;
 typedef struct _Ctl__SmdManageResp__RankResp Ctl__SmdManageResp__RankResp;
+typedef struct _Ctl__BioTrimReq Ctl__BioTrimReq;
+typedef struct _Ctl__BioTrimResp Ctl__BioTrimResp;
+typedef struct _Ctl__BioTrimResp__Device Ctl__BioTrimResp__Device;
+typedef struct _Ctl__BioIOStatsReq Ctl__BioIOStatsReq;
+typedef struct _Ctl__BioIOStatsResp Ctl__BioIOStatsResp;
+typedef struct _Ctl__DeviceIOStats Ctl__DeviceIOStats;
 
 
 /* --- enums --- */
@@ -372,10 +378,14 @@ struct  _Ctl__SmdDevice
    * NVMe namespace id hosting SMD blobstore
    */
   uint32_t ctrlr_namespace_id;
+  /*
+   * I/O statistics of SMD device
+   */
+  Ctl__DeviceIOStats *io_stats;
 };
 #define CTL__SMD_DEVICE__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&ctl__smd_device__descriptor) \
-    , (char *)protobuf_c_empty_string, 0,NULL, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, NULL, 0 }
+    , (char *)protobuf_c_empty_string, 0,NULL, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, NULL, 0, NULL }
 
 
 struct  _Ctl__SmdDevReq
@@ -466,10 +476,18 @@ struct  _Ctl__SmdQueryReq
    * Restrict response to only include info about this rank
    */
   uint32_t rank;
+  /*
+   * Indicate query should include I/O stats for devices
+   */
+  protobuf_c_boolean include_io_stats;
+  /*
+   * Period over which I/O rates are sampled
+   */
+  uint32_t io_stats_period_ms;
 };
 #define CTL__SMD_QUERY_REQ__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&ctl__smd_query_req__descriptor) \
-    , 0, 0, 0, (char *)protobuf_c_empty_string, 0 }
+    , 0, 0, 0, (char *)protobuf_c_empty_string, 0, 0, 0 }
 
 
 struct  _Ctl__SmdQueryResp__Pool
@@ -690,6 +708,180 @@ struct  _Ctl__SmdManageResp
     , 0,NULL }
 
 
+struct  _Ctl__BioTrimReq
+{
+  ProtobufCMessage base;
+  /*
+   * Max blocks to deallocate per device in one pass (0 = no limit)
+   */
+  uint64_t max_blocks;
+};
+#define CTL__BIO_TRIM_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_trim_req__descriptor) \
+    , 0 }
+
+
+struct  _Ctl__BioTrimResp__Device
+{
+  ProtobufCMessage base;
+  /*
+   * UUID of blobstore/device
+   */
+  char *uuid;
+  /*
+   * Number of blocks deallocated in this pass
+   */
+  uint64_t trimmed_blocks;
+  /*
+   * Size in bytes of each block
+   */
+  uint32_t block_size;
+};
+#define CTL__BIO_TRIM_RESP__DEVICE__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_trim_resp__device__descriptor) \
+    , (char *)protobuf_c_empty_string, 0, 0 }
+
+
+struct  _Ctl__BioTrimResp
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS error code
+   */
+  int32_t status;
+  /*
+   * Per-device results
+   */
+  size_t n_devices;
+  Ctl__BioTrimResp__Device **devices;
+};
+#define CTL__BIO_TRIM_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_trim_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+struct  _Ctl__BioIOStatsReq
+{
+  ProtobufCMessage base;
+  /*
+   * UUID of blobstore/device
+   */
+  char *dev_uuid;
+};
+#define CTL__BIO_IOSTATS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_iostats_req__descriptor) \
+    , (char *)protobuf_c_empty_string }
+
+
+/*
+ * BioIOStatsResp contains the cumulative I/O counters of a device as maintained by the
+ * SPDK bdev layer, and latency percentiles over the engine's most recent sampling window.
+ */
+struct  _Ctl__BioIOStatsResp
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS error code
+   */
+  int32_t status;
+  /*
+   * UUID of blobstore/device
+   */
+  char *dev_uuid;
+  /*
+   * Time of sample in nanoseconds since the epoch
+   */
+  uint64_t timestamp;
+  /*
+   * Completed read operations
+   */
+  uint64_t read_ops;
+  /*
+   * Completed write operations
+   */
+  uint64_t write_ops;
+  /*
+   * Completed unmap operations
+   */
+  uint64_t unmap_ops;
+  /*
+   * Bytes read
+   */
+  uint64_t read_bytes;
+  /*
+   * Bytes written
+   */
+  uint64_t write_bytes;
+  /*
+   * I/O operations currently in flight
+   */
+  uint32_t queue_depth;
+  /*
+   * Read latency percentiles in microseconds
+   */
+  uint64_t read_lat_p50;
+  uint64_t read_lat_p99;
+  uint64_t read_lat_p999;
+  /*
+   * Write latency percentiles in microseconds
+   */
+  uint64_t write_lat_p50;
+  uint64_t write_lat_p99;
+  uint64_t write_lat_p999;
+};
+#define CTL__BIO_IOSTATS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_iostats_resp__descriptor) \
+    , 0, (char *)protobuf_c_empty_string, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0 }
+
+
+/*
+ * DeviceIOStats contains device I/O rates derived from two BioIOStatsResp samples.
+ */
+struct  _Ctl__DeviceIOStats
+{
+  ProtobufCMessage base;
+  /*
+   * Time between samples
+   */
+  uint64_t period_ms;
+  /*
+   * Read operations per second
+   */
+  double read_iops;
+  /*
+   * Write operations per second
+   */
+  double write_iops;
+  /*
+   * Bytes read per second
+   */
+  double read_bw;
+  /*
+   * Bytes written per second
+   */
+  double write_bw;
+  /*
+   * I/O operations in flight at the second sample
+   */
+  uint32_t queue_depth;
+  /*
+   * Read latency percentiles in microseconds
+   */
+  uint64_t read_lat_p50;
+  uint64_t read_lat_p99;
+  uint64_t read_lat_p999;
+  /*
+   * Write latency percentiles in microseconds
+   */
+  uint64_t write_lat_p50;
+  uint64_t write_lat_p99;
+  uint64_t write_lat_p999;
+};
+#define CTL__DEVICE_IOSTATS__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__device_iostats__descriptor) \
+    , 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0 }
+
+
 /* Ctl__BioHealthReq methods */
 void   ctl__bio_health_req__init
                      (Ctl__BioHealthReq         *message);
@@ -1012,6 +1204,104 @@ Ctl__SmdManageResp *
 void   ctl__smd_manage_resp__free_unpacked
                      (Ctl__SmdManageResp *message,
                       ProtobufCAllocator *allocator);
+/* Ctl__BioTrimReq methods */
+void   ctl__bio_trim_req__init
+                     (Ctl__BioTrimReq         *message);
+size_t ctl__bio_trim_req__get_packed_size
+                     (const Ctl__BioTrimReq   *message);
+size_t ctl__bio_trim_req__pack
+                     (const Ctl__BioTrimReq   *message,
+                      uint8_t             *out);
+size_t ctl__bio_trim_req__pack_to_buffer
+                     (const Ctl__BioTrimReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__BioTrimReq *
+       ctl__bio_trim_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__bio_trim_req__free_unpacked
+                     (Ctl__BioTrimReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__BioTrimResp__Device methods */
+void   ctl__bio_trim_resp__device__init
+                     (Ctl__BioTrimResp__Device         *message);
+/* Ctl__BioTrimResp methods */
+void   ctl__bio_trim_resp__init
+                     (Ctl__BioTrimResp         *message);
+size_t ctl__bio_trim_resp__get_packed_size
+                     (const Ctl__BioTrimResp   *message);
+size_t ctl__bio_trim_resp__pack
+                     (const Ctl__BioTrimResp   *message,
+                      uint8_t             *out);
+size_t ctl__bio_trim_resp__pack_to_buffer
+                     (const Ctl__BioTrimResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__BioTrimResp *
+       ctl__bio_trim_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__bio_trim_resp__free_unpacked
+                     (Ctl__BioTrimResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__BioIOStatsReq methods */
+void   ctl__bio_iostats_req__init
+                     (Ctl__BioIOStatsReq         *message);
+size_t ctl__bio_iostats_req__get_packed_size
+                     (const Ctl__BioIOStatsReq   *message);
+size_t ctl__bio_iostats_req__pack
+                     (const Ctl__BioIOStatsReq   *message,
+                      uint8_t             *out);
+size_t ctl__bio_iostats_req__pack_to_buffer
+                     (const Ctl__BioIOStatsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__BioIOStatsReq *
+       ctl__bio_iostats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__bio_iostats_req__free_unpacked
+                     (Ctl__BioIOStatsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__BioIOStatsResp methods */
+void   ctl__bio_iostats_resp__init
+                     (Ctl__BioIOStatsResp         *message);
+size_t ctl__bio_iostats_resp__get_packed_size
+                     (const Ctl__BioIOStatsResp   *message);
+size_t ctl__bio_iostats_resp__pack
+                     (const Ctl__BioIOStatsResp   *message,
+                      uint8_t             *out);
+size_t ctl__bio_iostats_resp__pack_to_buffer
+                     (const Ctl__BioIOStatsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__BioIOStatsResp *
+       ctl__bio_iostats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__bio_iostats_resp__free_unpacked
+                     (Ctl__BioIOStatsResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__DeviceIOStats methods */
+void   ctl__device_iostats__init
+                     (Ctl__DeviceIOStats         *message);
+size_t ctl__device_iostats__get_packed_size
+                     (const Ctl__DeviceIOStats   *message);
+size_t ctl__device_iostats__pack
+                     (const Ctl__DeviceIOStats   *message,
+                      uint8_t             *out);
+size_t ctl__device_iostats__pack_to_buffer
+                     (const Ctl__DeviceIOStats   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__DeviceIOStats *
+       ctl__device_iostats__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__device_iostats__free_unpacked
+                     (Ctl__DeviceIOStats *message,
+                      ProtobufCAllocator *allocator);
 /* --- per-message closures --- */
 
 typedef void (*Ctl__BioHealthReq_Closure)
@@ -1080,6 +1370,24 @@ typedef void (*Ctl__SmdManageResp__RankResp_Closure)
 typedef void (*Ctl__SmdManageResp_Closure)
                  (const Ctl__SmdManageResp *message,
                   void *closure_data);
+typedef void (*Ctl__BioTrimReq_Closure)
+                 (const Ctl__BioTrimReq *message,
+                  void *closure_data);
+typedef void (*Ctl__BioTrimResp__Device_Closure)
+                 (const Ctl__BioTrimResp__Device *message,
+                  void *closure_data);
+typedef void (*Ctl__BioTrimResp_Closure)
+                 (const Ctl__BioTrimResp *message,
+                  void *closure_data);
+typedef void (*Ctl__BioIOStatsReq_Closure)
+                 (const Ctl__BioIOStatsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__BioIOStatsResp_Closure)
+                 (const Ctl__BioIOStatsResp *message,
+                  void *closure_data);
+typedef void (*Ctl__DeviceIOStats_Closure)
+                 (const Ctl__DeviceIOStats *message,
+                  void *closure_data);
 
 /* --- services --- */
 
@@ -1111,6 +1419,12 @@ extern const ProtobufCMessageDescriptor ctl__smd_manage_req__descriptor;
 extern const ProtobufCMessageDescriptor ctl__smd_manage_resp__descriptor;
 extern const ProtobufCMessageDescriptor ctl__smd_manage_resp__result__descriptor;
 extern const ProtobufCMessageDescriptor ctl__smd_manage_resp__rank_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__bio_trim_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__bio_trim_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__bio_trim_resp__device__descriptor;
+extern const ProtobufCMessageDescriptor ctl__bio_iostats_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__bio_iostats_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__device_iostats__descriptor;
 
 PROTOBUF_C__END_DECLS
 
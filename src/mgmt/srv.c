@@ -102,6 +102,12 @@ process_drpc_request(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 	case DRPC_METHOD_MGMT_BIO_HEALTH_QUERY:
 		ds_mgmt_drpc_bio_health_query(drpc_req, drpc_resp);
 		break;
+	case DRPC_METHOD_MGMT_BIO_TRIM:
+		ds_mgmt_drpc_bio_trim(drpc_req, drpc_resp);
+		break;
+	case DRPC_METHOD_MGMT_BIO_IO_STATS:
+		ds_mgmt_drpc_bio_io_stats(drpc_req, drpc_resp);
+		break;
 	case DRPC_METHOD_MGMT_SMD_LIST_DEVS:
 		ds_mgmt_drpc_smd_list_devs(drpc_req, drpc_resp);
 		break;
@@ -147,6 +153,9 @@ process_drpc_request(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 	case DRPC_METHOD_MGMT_GROUP_UPDATE:
 		ds_mgmt_drpc_group_update(drpc_req, drpc_resp);
 		break;
+	case DRPC_METHOD_MGMT_SWIM_VIEW:
+		ds_mgmt_drpc_swim_view(drpc_req, drpc_resp);
+		break;
 	case DRPC_METHOD_MGMT_LED_MANAGE:
 		ds_mgmt_drpc_dev_manage_led(drpc_req, drpc_resp);
 		break;
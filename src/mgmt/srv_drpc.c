@@ -230,6 +230,110 @@ out:
 	mgmt__group_update_req__free_unpacked(req, &alloc.alloc);
 }
 
+static Ctl__SwimState
+swim_status2pb(enum swim_member_status status)
+{
+	switch (status) {
+	case SWIM_MEMBER_ALIVE:
+		return CTL__SWIM_STATE__SWIM_ALIVE;
+	case SWIM_MEMBER_SUSPECT:
+		return CTL__SWIM_STATE__SWIM_SUSPECT;
+	case SWIM_MEMBER_DEAD:
+		return CTL__SWIM_STATE__SWIM_DEAD;
+	default:
+		return CTL__SWIM_STATE__SWIM_INACTIVE;
+	}
+}
+
+static void
+free_swim_members(Ctl__SwimMember **members, size_t nr)
+{
+	int i;
+
+	if (members == NULL)
+		return;
+
+	for (i = 0; i < nr; i++)
+		D_FREE(members[i]);
+	D_FREE(members);
+}
+
+void
+ds_mgmt_drpc_swim_view(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
+{
+	struct drpc_alloc	 alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Ctl__SwimViewReq	*req = NULL;
+	Ctl__SwimViewResp	 resp = CTL__SWIM_VIEW_RESP__INIT;
+	d_rank_list_t		*ranks = NULL;
+	crt_group_t		*grp;
+	uint8_t			*body;
+	size_t			 len;
+	int			 rc, i;
+
+	/* Unpack the inner request from the drpc call body */
+	req = ctl__swim_view_req__unpack(&alloc.alloc, drpc_req->body.len, drpc_req->body.data);
+
+	if (alloc.oom || req == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		D_ERROR("Failed to unpack req (swim view)\n");
+		return;
+	}
+
+	D_DEBUG(DB_MGMT, "Received request to query local SWIM view\n");
+
+	grp = crt_group_lookup(NULL);
+	rc = crt_group_ranks_get(grp, &ranks);
+	if (rc != 0) {
+		DL_ERROR(rc, "Failed to get group ranks");
+		goto out;
+	}
+
+	D_ALLOC_ARRAY(resp.members, ranks->rl_nr);
+	if (resp.members == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+
+	for (i = 0; i < ranks->rl_nr; i++) {
+		struct swim_member_state	 state;
+		Ctl__SwimMember			*member;
+
+		rc = crt_rank_state_get(grp, ranks->rl_ranks[i], &state);
+		if (rc == -DER_NONEXIST) {
+			/* Removed from the group since the ranks were listed */
+			rc = 0;
+			continue;
+		}
+		if (rc != 0) {
+			DL_ERROR(rc, "Failed to get SWIM state of rank %u", ranks->rl_ranks[i]);
+			goto out;
+		}
+
+		D_ALLOC_PTR(member);
+		if (member == NULL)
+			D_GOTO(out, rc = -DER_NOMEM);
+		ctl__swim_member__init(member);
+		member->rank = ranks->rl_ranks[i];
+		member->state = swim_status2pb(state.sms_status);
+		member->incarnation = state.sms_incarnation;
+		resp.members[resp.n_members++] = member;
+	}
+
+out:
+	resp.status = rc;
+	len = ctl__swim_view_resp__get_packed_size(&resp);
+	D_ALLOC(body, len);
+	if (body == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_MARSHAL;
+	} else {
+		ctl__swim_view_resp__pack(&resp, body);
+		drpc_resp->body.len = len;
+		drpc_resp->body.data = body;
+	}
+
+	free_swim_members(resp.members, resp.n_members);
+	d_rank_list_free(ranks);
+	ctl__swim_view_req__free_unpacked(req, &alloc.alloc);
+}
+
 static int
 conv_req_props(daos_prop_t **out_prop, bool set_props,
 	       Mgmt__PoolProperty **req_props, size_t n_props)
@@ -2248,6 +2352,148 @@ out:
 		D_FREE(bio_health);
 }
 
+void
+ds_mgmt_drpc_bio_io_stats(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
+{
+	struct drpc_alloc	 alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Ctl__BioIOStatsReq	*req = NULL;
+	Ctl__BioIOStatsResp	*resp = NULL;
+	struct bio_dev_io_stats	*stats = NULL;
+	struct timespec		 now;
+	uuid_t			 uuid;
+	uint8_t			*body;
+	size_t			 len;
+	int			 rc = 0;
+
+	/* Unpack the inner request from the drpc call body */
+	req = ctl__bio_iostats_req__unpack(&alloc.alloc, drpc_req->body.len,
+					   drpc_req->body.data);
+
+	if (alloc.oom || req == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		D_ERROR("Failed to unpack req (bio I/O stats query)\n");
+		return;
+	}
+
+	D_DEBUG(DB_MGMT, "Received request to query BIO I/O stats\n");
+
+	D_ALLOC_PTR(resp);
+	if (resp == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILURE;
+		ctl__bio_iostats_req__free_unpacked(req, &alloc.alloc);
+		return;
+	}
+
+	/* Response status is populated with SUCCESS on init. */
+	ctl__bio_iostats_resp__init(resp);
+
+	if (uuid_parse(req->dev_uuid, uuid) != 0) {
+		rc = -DER_INVAL;
+		DL_ERROR(rc, "Device UUID is invalid");
+		goto out;
+	}
+
+	D_ALLOC_PTR(stats);
+	if (stats == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+
+	rc = ds_mgmt_bio_io_stats(uuid, stats);
+	if (rc != 0) {
+		DL_ERROR(rc, "Failed to query BIO I/O stats");
+		goto out;
+	}
+
+	D_ALLOC(resp->dev_uuid, DAOS_UUID_STR_SIZE);
+	if (resp->dev_uuid == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+	uuid_unparse_lower(uuid, resp->dev_uuid);
+
+	clock_gettime(CLOCK_REALTIME, &now);
+	resp->timestamp = now.tv_sec * NSEC_PER_SEC + now.tv_nsec;
+	resp->read_ops = stats->bis_read_ops;
+	resp->write_ops = stats->bis_write_ops;
+	resp->unmap_ops = stats->bis_unmap_ops;
+	resp->read_bytes = stats->bis_read_bytes;
+	resp->write_bytes = stats->bis_write_bytes;
+	resp->queue_depth = stats->bis_queue_depth;
+	resp->read_lat_p50 = bio_io_lat_percentile(stats->bis_read_lat, 500);
+	resp->read_lat_p99 = bio_io_lat_percentile(stats->bis_read_lat, 990);
+	resp->read_lat_p999 = bio_io_lat_percentile(stats->bis_read_lat, 999);
+	resp->write_lat_p50 = bio_io_lat_percentile(stats->bis_write_lat, 500);
+	resp->write_lat_p99 = bio_io_lat_percentile(stats->bis_write_lat, 990);
+	resp->write_lat_p999 = bio_io_lat_percentile(stats->bis_write_lat, 999);
+
+out:
+	resp->status = rc;
+	len = ctl__bio_iostats_resp__get_packed_size(resp);
+	D_ALLOC(body, len);
+	if (body == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_MARSHAL;
+	} else {
+		ctl__bio_iostats_resp__pack(resp, body);
+		drpc_resp->body.len = len;
+		drpc_resp->body.data = body;
+	}
+
+	ctl__bio_iostats_req__free_unpacked(req, &alloc.alloc);
+	if (resp->dev_uuid != protobuf_c_empty_string)
+		D_FREE(resp->dev_uuid);
+	D_FREE(resp);
+	D_FREE(stats);
+}
+
+void
+ds_mgmt_drpc_bio_trim(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
+{
+	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Ctl__BioTrimReq		*req = NULL;
+	Ctl__BioTrimResp	*resp = NULL;
+	uint8_t			*body;
+	size_t			 len;
+	int			 rc = 0;
+
+	/* Unpack the inner request from the drpc call body */
+	req = ctl__bio_trim_req__unpack(&alloc.alloc, drpc_req->body.len, drpc_req->body.data);
+
+	if (alloc.oom || req == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		D_ERROR("Failed to unpack req (bio trim)\n");
+		return;
+	}
+
+	D_INFO("Received request to trim NVMe free space, max blocks "DF_U64"\n",
+	       req->max_blocks);
+
+	D_ALLOC_PTR(resp);
+	if (resp == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILURE;
+		ctl__bio_trim_req__free_unpacked(req, &alloc.alloc);
+		return;
+	}
+
+	/* Response status is populated with SUCCESS on init. */
+	ctl__bio_trim_resp__init(resp);
+
+	rc = ds_mgmt_bio_trim(req->max_blocks, resp);
+	if (rc != 0)
+		DL_ERROR(rc, "Failed to trim NVMe free space");
+
+	resp->status = rc;
+	len = ctl__bio_trim_resp__get_packed_size(resp);
+	D_ALLOC(body, len);
+	if (body == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_MARSHAL;
+	} else {
+		ctl__bio_trim_resp__pack(resp, body);
+		drpc_resp->body.len = len;
+		drpc_resp->body.data = body;
+	}
+
+	ctl__bio_trim_req__free_unpacked(req, &alloc.alloc);
+	ds_mgmt_bio_trim_free(resp);
+	D_FREE(resp);
+}
+
 static void
 drpc_dev_manage_pack(Ctl__DevManageResp *resp, Drpc__Response *drpc_resp)
 {
@@ -152,6 +152,9 @@ int ds_mgmt_dev_manage_led(Ctl__LedManageReq *req, Ctl__DevManageResp *resp);
 int ds_mgmt_get_bs_state(uuid_t bs_uuid, int *bs_state);
 void ds_mgmt_hdlr_get_bs_state(crt_rpc_t *rpc_req);
 int ds_mgmt_dev_replace(uuid_t old_uuid, uuid_t new_uuid, Ctl__DevManageResp *resp);
+int ds_mgmt_bio_io_stats(uuid_t dev_uuid, struct bio_dev_io_stats *stats);
+int ds_mgmt_bio_trim(uint64_t max_blks, Ctl__BioTrimResp *resp);
+void ds_mgmt_bio_trim_free(Ctl__BioTrimResp *resp);
 
 /** srv_target.c */
 int ds_mgmt_tgt_setup(void);
@@ -10,6 +10,7 @@
 
 #include <daos_srv/bio.h>
 #include <daos_srv/smd.h>
+#include <daos_srv/pool.h>
 #include <daos_srv/control.h>
 
 #include "srv_internal.h"
@@ -221,6 +222,224 @@ out:
 	return rc;
 }
 
+struct bio_io_stats_arg {
+	uuid_t			bia_dev_id;
+	struct bio_dev_io_stats	bia_stats;
+	int			bia_rc;
+};
+
+static void
+bio_io_stats_query(void *arg)
+{
+	struct bio_io_stats_arg	*bia = arg;
+	struct dss_module_info	*info = dss_get_module_info();
+	struct bio_xs_context	*bxc;
+
+	D_ASSERT(info != NULL);
+	D_DEBUG(DB_MGMT, "BIO I/O stats query on xs:%d, tgt:%d\n",
+		info->dmi_xs_id, info->dmi_tgt_id);
+
+	bxc = info->dmi_nvme_ctxt;
+	if (bxc == NULL) {
+		D_ERROR("BIO NVMe context not initialized for xs:%d, tgt:%d\n",
+			info->dmi_xs_id, info->dmi_tgt_id);
+		bia->bia_rc = -DER_INVAL;
+		return;
+	}
+
+	bia->bia_rc = bio_get_io_stats(&bia->bia_stats, bia->bia_dev_id, bxc);
+}
+
+/*
+ * Sum up the I/O stats of a device over all the targets mapped to it, each
+ * target keeps its own stats so they are queried one after another.
+ */
+int
+ds_mgmt_bio_io_stats(uuid_t dev_uuid, struct bio_dev_io_stats *stats)
+{
+	struct bio_io_stats_arg	*bia;
+	struct smd_dev_info	*dev_info;
+	ABT_thread		 thread;
+	int			 i, tgt_id;
+	int			 rc;
+
+	if (uuid_is_null(dev_uuid)) {
+		D_ERROR("dev_uuid is required for BIO I/O stats query\n");
+		return -DER_INVAL;
+	}
+
+	rc = smd_dev_get_by_id(dev_uuid, &dev_info);
+	if (rc != 0) {
+		D_ERROR("Device UUID:"DF_UUID" not found\n", DP_UUID(dev_uuid));
+		return rc;
+	}
+	if (dev_info->sdi_tgts == NULL) {
+		D_ERROR("No targets mapped to device\n");
+		rc = -DER_NONEXIST;
+		goto out;
+	}
+
+	D_ALLOC_PTR(bia);
+	if (bia == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+	uuid_copy(bia->bia_dev_id, dev_uuid);
+
+	for (i = 0; i < dev_info->sdi_tgt_cnt; i++) {
+		tgt_id = dev_info->sdi_tgts[i];
+		rc = dss_ult_create(bio_io_stats_query, bia, tgt2xs_type(tgt_id), tgt_id, 0,
+				    &thread);
+		if (rc != 0) {
+			D_ERROR("Unable to create a ULT on tgt_id:%d\n", tgt_id);
+			goto free;
+		}
+
+		ABT_thread_join(thread);
+		ABT_thread_free(&thread);
+
+		rc = bia->bia_rc;
+		if (rc != 0) {
+			DL_ERROR(rc, "BIO I/O stats query on tgt_id:%d failed", tgt_id);
+			goto free;
+		}
+	}
+	*stats = bia->bia_stats;
+free:
+	D_FREE(bia);
+out:
+	smd_dev_free_info(dev_info);
+	return rc;
+}
+
+/* Trim is accounted in VOS blocks (VOS_BLK_SZ) */
+#define MGMT_TRIM_BLK_SZ	(1UL << 12)
+
+struct bio_trim_tgt {
+	uuid_t		btt_dev_id;
+	uint64_t	btt_budget;
+	uint64_t	btt_trimmed;
+	int		btt_dev_idx;
+	bool		btt_nvme;
+};
+
+static int
+bio_trim_one(void *arg)
+{
+	struct bio_trim_tgt	*tgts = arg;
+	struct dss_module_info	*info = dss_get_module_info();
+	struct bio_trim_tgt	*btt;
+
+	D_ASSERT(info->dmi_tgt_id < dss_tgt_nr);
+	btt = &tgts[info->dmi_tgt_id];
+	if (!btt->btt_nvme)
+		return 0;
+
+	D_DEBUG(DB_MGMT, "BIO trim on xs:%d, tgt:%d, budget:"DF_U64"\n",
+		info->dmi_xs_id, info->dmi_tgt_id, btt->btt_budget);
+
+	return ds_pool_child_trim(btt->btt_budget, &btt->btt_trimmed);
+}
+
+void
+ds_mgmt_bio_trim_free(Ctl__BioTrimResp *resp)
+{
+	int	i;
+
+	for (i = 0; i < resp->n_devices; i++) {
+		if (resp->devices[i] != NULL) {
+			D_FREE(resp->devices[i]->uuid);
+			D_FREE(resp->devices[i]);
+		}
+	}
+	D_FREE(resp->devices);
+	resp->n_devices = 0;
+}
+
+/*
+ * Discard free NVMe space of all the targets, at most \a max_blks blocks
+ * (0 means no limit) per device. The budget of a device is split evenly
+ * among the targets mapped to it, results are reported per device.
+ */
+int
+ds_mgmt_bio_trim(uint64_t max_blks, Ctl__BioTrimResp *resp)
+{
+	struct bio_trim_tgt	*tgts;
+	struct smd_dev_info	*dev_info;
+	Ctl__BioTrimResp__Device *dev;
+	int			 i, j;
+	int			 rc;
+
+	D_ALLOC_ARRAY(tgts, dss_tgt_nr);
+	if (tgts == NULL)
+		return -DER_NOMEM;
+
+	for (i = 0; i < dss_tgt_nr; i++) {
+		rc = smd_dev_get_by_tgt(i, SMD_DEV_TYPE_DATA, &dev_info);
+		if (rc == -DER_NONEXIST)
+			continue;
+		if (rc != 0) {
+			DL_ERROR(rc, "Failed to get SMD device for tgt:%d", i);
+			goto out;
+		}
+
+		tgts[i].btt_nvme = true;
+		uuid_copy(tgts[i].btt_dev_id, dev_info->sdi_id);
+		if (max_blks != 0 && dev_info->sdi_tgt_cnt != 0) {
+			tgts[i].btt_budget = max_blks / dev_info->sdi_tgt_cnt;
+			if (tgts[i].btt_budget == 0)
+				tgts[i].btt_budget = 1;
+		}
+		smd_dev_free_info(dev_info);
+	}
+
+	rc = dss_thread_collective(bio_trim_one, tgts, 0);
+	if (rc != 0) {
+		DL_ERROR(rc, "BIO trim collective failed");
+		goto out;
+	}
+
+	D_ALLOC_ARRAY(resp->devices, dss_tgt_nr);
+	if (resp->devices == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+
+	for (i = 0; i < dss_tgt_nr; i++) {
+		if (!tgts[i].btt_nvme)
+			continue;
+
+		/* Targets sharing a device are accounted to the same entry */
+		for (j = 0; j < i; j++) {
+			if (tgts[j].btt_nvme &&
+			    uuid_compare(tgts[j].btt_dev_id, tgts[i].btt_dev_id) == 0)
+				break;
+		}
+		if (j < i) {
+			tgts[i].btt_dev_idx = tgts[j].btt_dev_idx;
+			resp->devices[tgts[i].btt_dev_idx]->trimmed_blocks += tgts[i].btt_trimmed;
+			continue;
+		}
+
+		D_ALLOC_PTR(dev);
+		if (dev == NULL)
+			D_GOTO(out, rc = -DER_NOMEM);
+		ctl__bio_trim_resp__device__init(dev);
+		dev->uuid = NULL;
+		tgts[i].btt_dev_idx = resp->n_devices;
+		resp->devices[resp->n_devices++] = dev;
+
+		D_ALLOC(dev->uuid, DAOS_UUID_STR_SIZE);
+		if (dev->uuid == NULL)
+			D_GOTO(out, rc = -DER_NOMEM);
+		uuid_unparse_lower(tgts[i].btt_dev_id, dev->uuid);
+		dev->trimmed_blocks = tgts[i].btt_trimmed;
+		dev->block_size = MGMT_TRIM_BLK_SZ;
+	}
+
+out:
+	if (rc != 0)
+		ds_mgmt_bio_trim_free(resp);
+	D_FREE(tgts);
+	return rc;
+}
+
 struct bio_led_manage_info {
 	uuid_t		 dev_uuid;
 	char		*tr_addr;
@@ -295,10 +295,21 @@ struct  _Mgmt__ClientNetHint
    * Provider index - anything > 0 is a secondary provider
    */
   uint32_t provider_idx;
+  /*
+   * NUMA node of the client fabric interface
+   */
+  uint32_t numa_node;
+  /*
+   * Cores on numa_node recommended for network
+   */
+  /*
+   * progress threads (cpulist format, empty if unknown)
+   */
+  char *core_set;
 };
 #define MGMT__CLIENT_NET_HINT__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__client_net_hint__descriptor) \
-    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0, 0, 0,NULL, 0 }
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0, 0, 0,NULL, 0, 0, (char *)protobuf_c_empty_string }
 
 
 struct  _Mgmt__GetAttachInfoResp__RankUri
@@ -357,10 +368,19 @@ struct  _Mgmt__GetAttachInfoResp
    */
   size_t n_secondary_client_net_hints;
   Mgmt__ClientNetHint **secondary_client_net_hints;
+  /*
+   * Version of the MS replica that handled the request
+   */
+  char *ms_version;
+  /*
+   * Optional management API features supported by the MS
+   */
+  size_t n_features;
+  char **features;
 };
 #define MGMT__GET_ATTACH_INFO_RESP__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__get_attach_info_resp__descriptor) \
-    , 0, 0,NULL, 0,NULL, NULL, 0, (char *)protobuf_c_empty_string, 0,NULL, 0,NULL }
+    , 0, 0,NULL, 0,NULL, NULL, 0, (char *)protobuf_c_empty_string, 0,NULL, 0,NULL, (char *)protobuf_c_empty_string, 0,NULL }
 
 
 struct  _Mgmt__PrepShutdownReq
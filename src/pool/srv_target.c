@@ -281,6 +281,63 @@ ds_pool_child_state(uuid_t pool_uuid, uint32_t tgt_id)
 	return child->spc_pool->sp_states[info->dmi_tgt_id];
 }
 
+/*
+ * Discard free NVMe space of all the started pool children on the current
+ * xstream, at most \a max_blks blocks (0 means no limit) are trimmed.
+ */
+int
+ds_pool_child_trim(uint64_t max_blks, uint64_t *trimmed_blks)
+{
+	struct pool_tls		*tls = pool_tls_get();
+	struct ds_pool_child	*child;
+	uuid_t			*uuids;
+	uint64_t		 trimmed, budget;
+	int			 nr = 0, i, rc = 0;
+
+	D_ASSERT(trimmed_blks != NULL);
+	*trimmed_blks = 0;
+
+	d_list_for_each_entry(child, &tls->dt_pool_list, spc_list)
+		nr++;
+	if (nr == 0)
+		return 0;
+
+	/* Trimming yields, collect the pool UUIDs before walking the list */
+	D_ALLOC_ARRAY(uuids, nr);
+	if (uuids == NULL)
+		return -DER_NOMEM;
+
+	i = 0;
+	d_list_for_each_entry(child, &tls->dt_pool_list, spc_list) {
+		if (*child->spc_state == POOL_CHILD_STARTED)
+			uuid_copy(uuids[i++], child->spc_uuid);
+	}
+	nr = i;
+
+	for (i = 0; i < nr; i++) {
+		if (max_blks != 0 && *trimmed_blks >= max_blks)
+			break;
+
+		child = pool_child_lookup_noref(uuids[i]);
+		if (child == NULL || *child->spc_state != POOL_CHILD_STARTED)
+			continue;
+		child->spc_ref++;
+
+		budget = max_blks != 0 ? max_blks - *trimmed_blks : 0;
+		rc = vos_pool_trim(child->spc_hdl, budget, &trimmed);
+		ds_pool_child_put(child);
+		if (rc) {
+			D_ERROR(DF_UUID": Trim pool failed. "DF_RC"\n",
+				DP_UUID(uuids[i]), DP_RC(rc));
+			break;
+		}
+		*trimmed_blks += trimmed;
+	}
+
+	D_FREE(uuids);
+	return rc;
+}
+
 static void
 pool_child_free(struct ds_pool_child *child)
 {
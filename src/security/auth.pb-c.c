@@ -277,6 +277,186 @@ void   auth__validate_cred_resp__free_unpacked
   assert(message->base.descriptor == &auth__validate_cred_resp__descriptor);
   protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
 }
+void   auth__delegation_token_req__init
+                     (Auth__DelegationTokenReq         *message)
+{
+  static const Auth__DelegationTokenReq init_value = AUTH__DELEGATION_TOKEN_REQ__INIT;
+  *message = init_value;
+}
+size_t auth__delegation_token_req__get_packed_size
+                     (const Auth__DelegationTokenReq *message)
+{
+  assert(message->base.descriptor == &auth__delegation_token_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t auth__delegation_token_req__pack
+                     (const Auth__DelegationTokenReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &auth__delegation_token_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t auth__delegation_token_req__pack_to_buffer
+                     (const Auth__DelegationTokenReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &auth__delegation_token_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Auth__DelegationTokenReq *
+       auth__delegation_token_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Auth__DelegationTokenReq *)
+     protobuf_c_message_unpack (&auth__delegation_token_req__descriptor,
+                                allocator, len, data);
+}
+void   auth__delegation_token_req__free_unpacked
+                     (Auth__DelegationTokenReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &auth__delegation_token_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   auth__delegation_token_resp__init
+                     (Auth__DelegationTokenResp         *message)
+{
+  static const Auth__DelegationTokenResp init_value = AUTH__DELEGATION_TOKEN_RESP__INIT;
+  *message = init_value;
+}
+size_t auth__delegation_token_resp__get_packed_size
+                     (const Auth__DelegationTokenResp *message)
+{
+  assert(message->base.descriptor == &auth__delegation_token_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t auth__delegation_token_resp__pack
+                     (const Auth__DelegationTokenResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &auth__delegation_token_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t auth__delegation_token_resp__pack_to_buffer
+                     (const Auth__DelegationTokenResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &auth__delegation_token_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Auth__DelegationTokenResp *
+       auth__delegation_token_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Auth__DelegationTokenResp *)
+     protobuf_c_message_unpack (&auth__delegation_token_resp__descriptor,
+                                allocator, len, data);
+}
+void   auth__delegation_token_resp__free_unpacked
+                     (Auth__DelegationTokenResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &auth__delegation_token_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   auth__delegation_token__init
+                     (Auth__DelegationToken         *message)
+{
+  static const Auth__DelegationToken init_value = AUTH__DELEGATION_TOKEN__INIT;
+  *message = init_value;
+}
+size_t auth__delegation_token__get_packed_size
+                     (const Auth__DelegationToken *message)
+{
+  assert(message->base.descriptor == &auth__delegation_token__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t auth__delegation_token__pack
+                     (const Auth__DelegationToken *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &auth__delegation_token__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t auth__delegation_token__pack_to_buffer
+                     (const Auth__DelegationToken *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &auth__delegation_token__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Auth__DelegationToken *
+       auth__delegation_token__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Auth__DelegationToken *)
+     protobuf_c_message_unpack (&auth__delegation_token__descriptor,
+                                allocator, len, data);
+}
+void   auth__delegation_token__free_unpacked
+                     (Auth__DelegationToken *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &auth__delegation_token__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   auth__redeem_delegation_token_req__init
+                     (Auth__RedeemDelegationTokenReq         *message)
+{
+  static const Auth__RedeemDelegationTokenReq init_value = AUTH__REDEEM_DELEGATION_TOKEN_REQ__INIT;
+  *message = init_value;
+}
+size_t auth__redeem_delegation_token_req__get_packed_size
+                     (const Auth__RedeemDelegationTokenReq *message)
+{
+  assert(message->base.descriptor == &auth__redeem_delegation_token_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t auth__redeem_delegation_token_req__pack
+                     (const Auth__RedeemDelegationTokenReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &auth__redeem_delegation_token_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t auth__redeem_delegation_token_req__pack_to_buffer
+                     (const Auth__RedeemDelegationTokenReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &auth__redeem_delegation_token_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Auth__RedeemDelegationTokenReq *
+       auth__redeem_delegation_token_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Auth__RedeemDelegationTokenReq *)
+     protobuf_c_message_unpack (&auth__redeem_delegation_token_req__descriptor,
+                                allocator, len, data);
+}
+void   auth__redeem_delegation_token_req__free_unpacked
+                     (Auth__RedeemDelegationTokenReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &auth__redeem_delegation_token_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
 static const ProtobufCFieldDescriptor auth__token__field_descriptors[2] =
 {
   {
@@ -635,16 +815,274 @@ const ProtobufCMessageDescriptor auth__validate_cred_resp__descriptor =
   (ProtobufCMessageInit) auth__validate_cred_resp__init,
   NULL,NULL,NULL    /* reserved[123] */
 };
-static const ProtobufCEnumValue auth__flavor__enum_values_by_number[2] =
+static const ProtobufCFieldDescriptor auth__delegation_token_req__field_descriptors[2] =
+{
+  {
+    "jobid",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationTokenReq, jobid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "lifetime",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationTokenReq, lifetime),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned auth__delegation_token_req__field_indices_by_name[] = {
+  0,   /* field[0] = jobid */
+  1,   /* field[1] = lifetime */
+};
+static const ProtobufCIntRange auth__delegation_token_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor auth__delegation_token_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "auth.DelegationTokenReq",
+  "DelegationTokenReq",
+  "Auth__DelegationTokenReq",
+  "auth",
+  sizeof(Auth__DelegationTokenReq),
+  2,
+  auth__delegation_token_req__field_descriptors,
+  auth__delegation_token_req__field_indices_by_name,
+  1,  auth__delegation_token_req__number_ranges,
+  (ProtobufCMessageInit) auth__delegation_token_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor auth__delegation_token_resp__field_descriptors[3] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationTokenResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "token",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationTokenResp, token),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "expires",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationTokenResp, expires),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned auth__delegation_token_resp__field_indices_by_name[] = {
+  2,   /* field[2] = expires */
+  0,   /* field[0] = status */
+  1,   /* field[1] = token */
+};
+static const ProtobufCIntRange auth__delegation_token_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 3 }
+};
+const ProtobufCMessageDescriptor auth__delegation_token_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "auth.DelegationTokenResp",
+  "DelegationTokenResp",
+  "Auth__DelegationTokenResp",
+  "auth",
+  sizeof(Auth__DelegationTokenResp),
+  3,
+  auth__delegation_token_resp__field_descriptors,
+  auth__delegation_token_resp__field_indices_by_name,
+  1,  auth__delegation_token_resp__number_ranges,
+  (ProtobufCMessageInit) auth__delegation_token_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor auth__delegation_token__field_descriptors[5] =
+{
+  {
+    "token",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_MESSAGE,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationToken, token),
+    &auth__token__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "uid",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationToken, uid),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "jobid",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationToken, jobid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "expires",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationToken, expires),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "issuer",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__DelegationToken, issuer),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned auth__delegation_token__field_indices_by_name[] = {
+  3,   /* field[3] = expires */
+  4,   /* field[4] = issuer */
+  2,   /* field[2] = jobid */
+  0,   /* field[0] = token */
+  1,   /* field[1] = uid */
+};
+static const ProtobufCIntRange auth__delegation_token__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 5 }
+};
+const ProtobufCMessageDescriptor auth__delegation_token__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "auth.DelegationToken",
+  "DelegationToken",
+  "Auth__DelegationToken",
+  "auth",
+  sizeof(Auth__DelegationToken),
+  5,
+  auth__delegation_token__field_descriptors,
+  auth__delegation_token__field_indices_by_name,
+  1,  auth__delegation_token__number_ranges,
+  (ProtobufCMessageInit) auth__delegation_token__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor auth__redeem_delegation_token_req__field_descriptors[2] =
+{
+  {
+    "token",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__RedeemDelegationTokenReq, token),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "jobid",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Auth__RedeemDelegationTokenReq, jobid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned auth__redeem_delegation_token_req__field_indices_by_name[] = {
+  1,   /* field[1] = jobid */
+  0,   /* field[0] = token */
+};
+static const ProtobufCIntRange auth__redeem_delegation_token_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor auth__redeem_delegation_token_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "auth.RedeemDelegationTokenReq",
+  "RedeemDelegationTokenReq",
+  "Auth__RedeemDelegationTokenReq",
+  "auth",
+  sizeof(Auth__RedeemDelegationTokenReq),
+  2,
+  auth__redeem_delegation_token_req__field_descriptors,
+  auth__redeem_delegation_token_req__field_indices_by_name,
+  1,  auth__redeem_delegation_token_req__number_ranges,
+  (ProtobufCMessageInit) auth__redeem_delegation_token_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCEnumValue auth__flavor__enum_values_by_number[3] =
 {
   { "AUTH_NONE", "AUTH__FLAVOR__AUTH_NONE", 0 },
   { "AUTH_SYS", "AUTH__FLAVOR__AUTH_SYS", 1 },
+  { "AUTH_DELEGATION", "AUTH__FLAVOR__AUTH_DELEGATION", 2 },
 };
 static const ProtobufCIntRange auth__flavor__value_ranges[] = {
-{0, 0},{0, 2}
+{0, 0},{0, 3}
 };
-static const ProtobufCEnumValueIndex auth__flavor__enum_values_by_name[2] =
+static const ProtobufCEnumValueIndex auth__flavor__enum_values_by_name[3] =
 {
+  { "AUTH_DELEGATION", 2 },
   { "AUTH_NONE", 0 },
   { "AUTH_SYS", 1 },
 };
@@ -655,9 +1093,9 @@ const ProtobufCEnumDescriptor auth__flavor__descriptor =
   "Flavor",
   "Auth__Flavor",
   "auth",
-  2,
+  3,
   auth__flavor__enum_values_by_number,
-  2,
+  3,
   auth__flavor__enum_values_by_name,
   1,
   auth__flavor__value_ranges,
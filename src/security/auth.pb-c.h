@@ -21,6 +21,10 @@ typedef struct _Auth__Credential Auth__Credential;
 typedef struct _Auth__GetCredResp Auth__GetCredResp;
 typedef struct _Auth__ValidateCredReq Auth__ValidateCredReq;
 typedef struct _Auth__ValidateCredResp Auth__ValidateCredResp;
+typedef struct _Auth__DelegationTokenReq Auth__DelegationTokenReq;
+typedef struct _Auth__DelegationTokenResp Auth__DelegationTokenResp;
+typedef struct _Auth__DelegationToken Auth__DelegationToken;
+typedef struct _Auth__RedeemDelegationTokenReq Auth__RedeemDelegationTokenReq;
 
 
 /* --- enums --- */
@@ -30,7 +34,11 @@ typedef struct _Auth__ValidateCredResp Auth__ValidateCredResp;
  */
 typedef enum _Auth__Flavor {
   AUTH__FLAVOR__AUTH_NONE = 0,
-  AUTH__FLAVOR__AUTH_SYS = 1
+  AUTH__FLAVOR__AUTH_SYS = 1,
+  /*
+   * delegation token, never valid as a credential
+   */
+  AUTH__FLAVOR__AUTH_DELEGATION = 2
     PROTOBUF_C__FORCE_ENUM_TO_BE_INT_SIZE(AUTH__FLAVOR)
 } Auth__Flavor;
 
@@ -173,6 +181,107 @@ struct  _Auth__ValidateCredResp
     , 0, NULL }
 
 
+/*
+ * DelegationTokenReq represents a request by a job launcher for a token that
+ * processes of the same job on other nodes can redeem for the caller's
+ * credential.
+ */
+struct  _Auth__DelegationTokenReq
+{
+  ProtobufCMessage base;
+  /*
+   * ID of the job the token is issued for
+   */
+  char *jobid;
+  /*
+   * Requested lifetime of the token in seconds
+   */
+  uint32_t lifetime;
+};
+#define AUTH__DELEGATION_TOKEN_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&auth__delegation_token_req__descriptor) \
+    , (char *)protobuf_c_empty_string, 0 }
+
+
+/*
+ * DelegationTokenResp represents the result of a request for a delegation
+ * token.
+ */
+struct  _Auth__DelegationTokenResp
+{
+  ProtobufCMessage base;
+  /*
+   * Status of the request
+   */
+  int32_t status;
+  /*
+   * Encoded delegation token
+   */
+  char *token;
+  /*
+   * Expiration time, in seconds since the epoch
+   */
+  uint64_t expires;
+};
+#define AUTH__DELEGATION_TOKEN_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&auth__delegation_token_resp__descriptor) \
+    , 0, (char *)protobuf_c_empty_string, 0 }
+
+
+/*
+ * DelegationToken is the content of a delegation token. It is carried as the
+ * token of a signed Credential.
+ */
+struct  _Auth__DelegationToken
+{
+  ProtobufCMessage base;
+  /*
+   * AUTH_SYS token of the delegating user
+   */
+  Auth__Token *token;
+  /*
+   * UID allowed to redeem the token
+   */
+  uint32_t uid;
+  /*
+   * ID of the job the token was issued for
+   */
+  char *jobid;
+  /*
+   * Expiration time, in seconds since the epoch
+   */
+  uint64_t expires;
+  /*
+   * Machine name of the issuing agent
+   */
+  char *issuer;
+};
+#define AUTH__DELEGATION_TOKEN__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&auth__delegation_token__descriptor) \
+    , NULL, 0, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string }
+
+
+/*
+ * RedeemDelegationTokenReq represents a request to exchange a delegation
+ * token for a credential. The result is returned as a GetCredResp.
+ */
+struct  _Auth__RedeemDelegationTokenReq
+{
+  ProtobufCMessage base;
+  /*
+   * Encoded delegation token
+   */
+  char *token;
+  /*
+   * ID of the job the caller belongs to
+   */
+  char *jobid;
+};
+#define AUTH__REDEEM_DELEGATION_TOKEN_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&auth__redeem_delegation_token_req__descriptor) \
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string }
+
+
 /* Auth__Token methods */
 void   auth__token__init
                      (Auth__Token         *message);
@@ -287,6 +396,82 @@ Auth__ValidateCredResp *
 void   auth__validate_cred_resp__free_unpacked
                      (Auth__ValidateCredResp *message,
                       ProtobufCAllocator *allocator);
+/* Auth__DelegationTokenReq methods */
+void   auth__delegation_token_req__init
+                     (Auth__DelegationTokenReq         *message);
+size_t auth__delegation_token_req__get_packed_size
+                     (const Auth__DelegationTokenReq   *message);
+size_t auth__delegation_token_req__pack
+                     (const Auth__DelegationTokenReq   *message,
+                      uint8_t             *out);
+size_t auth__delegation_token_req__pack_to_buffer
+                     (const Auth__DelegationTokenReq   *message,
+                      ProtobufCBuffer     *buffer);
+Auth__DelegationTokenReq *
+       auth__delegation_token_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   auth__delegation_token_req__free_unpacked
+                     (Auth__DelegationTokenReq *message,
+                      ProtobufCAllocator *allocator);
+/* Auth__DelegationTokenResp methods */
+void   auth__delegation_token_resp__init
+                     (Auth__DelegationTokenResp         *message);
+size_t auth__delegation_token_resp__get_packed_size
+                     (const Auth__DelegationTokenResp   *message);
+size_t auth__delegation_token_resp__pack
+                     (const Auth__DelegationTokenResp   *message,
+                      uint8_t             *out);
+size_t auth__delegation_token_resp__pack_to_buffer
+                     (const Auth__DelegationTokenResp   *message,
+                      ProtobufCBuffer     *buffer);
+Auth__DelegationTokenResp *
+       auth__delegation_token_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   auth__delegation_token_resp__free_unpacked
+                     (Auth__DelegationTokenResp *message,
+                      ProtobufCAllocator *allocator);
+/* Auth__DelegationToken methods */
+void   auth__delegation_token__init
+                     (Auth__DelegationToken         *message);
+size_t auth__delegation_token__get_packed_size
+                     (const Auth__DelegationToken   *message);
+size_t auth__delegation_token__pack
+                     (const Auth__DelegationToken   *message,
+                      uint8_t             *out);
+size_t auth__delegation_token__pack_to_buffer
+                     (const Auth__DelegationToken   *message,
+                      ProtobufCBuffer     *buffer);
+Auth__DelegationToken *
+       auth__delegation_token__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   auth__delegation_token__free_unpacked
+                     (Auth__DelegationToken *message,
+                      ProtobufCAllocator *allocator);
+/* Auth__RedeemDelegationTokenReq methods */
+void   auth__redeem_delegation_token_req__init
+                     (Auth__RedeemDelegationTokenReq         *message);
+size_t auth__redeem_delegation_token_req__get_packed_size
+                     (const Auth__RedeemDelegationTokenReq   *message);
+size_t auth__redeem_delegation_token_req__pack
+                     (const Auth__RedeemDelegationTokenReq   *message,
+                      uint8_t             *out);
+size_t auth__redeem_delegation_token_req__pack_to_buffer
+                     (const Auth__RedeemDelegationTokenReq   *message,
+                      ProtobufCBuffer     *buffer);
+Auth__RedeemDelegationTokenReq *
+       auth__redeem_delegation_token_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   auth__redeem_delegation_token_req__free_unpacked
+                     (Auth__RedeemDelegationTokenReq *message,
+                      ProtobufCAllocator *allocator);
 /* --- per-message closures --- */
 
 typedef void (*Auth__Token_Closure)
@@ -307,6 +492,18 @@ typedef void (*Auth__ValidateCredReq_Closure)
 typedef void (*Auth__ValidateCredResp_Closure)
                  (const Auth__ValidateCredResp *message,
                   void *closure_data);
+typedef void (*Auth__DelegationTokenReq_Closure)
+                 (const Auth__DelegationTokenReq *message,
+                  void *closure_data);
+typedef void (*Auth__DelegationTokenResp_Closure)
+                 (const Auth__DelegationTokenResp *message,
+                  void *closure_data);
+typedef void (*Auth__DelegationToken_Closure)
+                 (const Auth__DelegationToken *message,
+                  void *closure_data);
+typedef void (*Auth__RedeemDelegationTokenReq_Closure)
+                 (const Auth__RedeemDelegationTokenReq *message,
+                  void *closure_data);
 
 /* --- services --- */
 
@@ -320,6 +517,10 @@ extern const ProtobufCMessageDescriptor auth__credential__descriptor;
 extern const ProtobufCMessageDescriptor auth__get_cred_resp__descriptor;
 extern const ProtobufCMessageDescriptor auth__validate_cred_req__descriptor;
 extern const ProtobufCMessageDescriptor auth__validate_cred_resp__descriptor;
+extern const ProtobufCMessageDescriptor auth__delegation_token_req__descriptor;
+extern const ProtobufCMessageDescriptor auth__delegation_token_resp__descriptor;
+extern const ProtobufCMessageDescriptor auth__delegation_token__descriptor;
+extern const ProtobufCMessageDescriptor auth__redeem_delegation_token_req__descriptor;
 
 PROTOBUF_C__END_DECLS
 
@@ -1,5 +1,5 @@
 /*
- * (C) Copyright 2018-2023 Intel Corporation.
+ * (C) Copyright 2018-2024 Intel Corporation.
  *
  * SPDX-License-Identifier: BSD-2-Clause-Patent
  */
@@ -16,6 +16,7 @@
 #include <daos/drpc_modules.h>
 #include <daos/agent.h>
 #include <daos/security.h>
+#include <daos/job.h>
 
 #include "auth.pb-c.h"
 #include "acl.h"
@@ -48,11 +49,39 @@ dc_sec_request_creds(d_iov_t *creds)
 	return rc;
 }
 
+/*
+ * Pack a request to exchange a delegation token, issued by the agent on the
+ * node where the job was launched, for a credential. The body is freed along
+ * with the call.
+ */
+static int
+pack_redeem_token_req(char *token, Drpc__Call *request)
+{
+	Auth__RedeemDelegationTokenReq	req = AUTH__REDEEM_DELEGATION_TOKEN_REQ__INIT;
+	uint8_t				*reqb;
+	size_t				reqb_size;
+
+	req.token = token;
+	if (dc_jobid != NULL)
+		req.jobid = dc_jobid;
+	reqb_size = auth__redeem_delegation_token_req__get_packed_size(&req);
+	D_ALLOC(reqb, reqb_size);
+	if (reqb == NULL)
+		return -DER_NOMEM;
+	auth__redeem_delegation_token_req__pack(&req, reqb);
+
+	request->body.len = reqb_size;
+	request->body.data = reqb;
+	return 0;
+}
+
 static int
 request_credentials_via_drpc(Drpc__Response **response)
 {
 	Drpc__Call	*request;
 	struct drpc	*agent_socket;
+	char		*token = NULL;
+	int		method;
 	int		rc;
 
 	if (dc_agent_sockpath == NULL) {
@@ -66,20 +95,30 @@ request_credentials_via_drpc(Drpc__Response **response)
 		return rc;
 	}
 
-	rc = drpc_call_create(agent_socket,
-			      DRPC_MODULE_SEC_AGENT,
-			      DRPC_METHOD_SEC_AGENT_REQUEST_CREDS,
-			      &request);
+	d_agetenv_str(&token, DAOS_DELEGATION_TOKEN_ENV);
+	method = token != NULL ? DRPC_METHOD_SEC_AGENT_REDEEM_DELEGATION_TOKEN :
+				 DRPC_METHOD_SEC_AGENT_REQUEST_CREDS;
+
+	rc = drpc_call_create(agent_socket, DRPC_MODULE_SEC_AGENT, method, &request);
 	if (rc != -DER_SUCCESS) {
 		D_ERROR("Couldn't allocate dRPC call "DF_RC"\n", DP_RC(rc));
-		drpc_close(agent_socket);
-		return rc;
+		D_GOTO(out_socket, rc);
+	}
+
+	if (token != NULL) {
+		D_DEBUG(DB_SEC, "redeeming delegation token from %s\n", DAOS_DELEGATION_TOKEN_ENV);
+		rc = pack_redeem_token_req(token, request);
+		if (rc != 0)
+			D_GOTO(out_request, rc);
 	}
 
 	rc = drpc_call(agent_socket, R_SYNC, request, response);
 
-	drpc_close(agent_socket);
+out_request:
 	drpc_call_free(request);
+out_socket:
+	drpc_close(agent_socket);
+	d_freeenv_str(&token);
 	return rc;
 }
 
@@ -136,6 +175,11 @@ get_cred_from_response(Drpc__Response *response, d_iov_t *cred)
 		return -DER_PROTO;
 	}
 
+	if (cred_resp->status == -DER_BUSY) {
+		D_ERROR("Credential request throttled by daos_agent rate limit\n");
+		D_GOTO(out, rc = cred_resp->status);
+	}
+
 	if (cred_resp->status != 0) {
 		D_ERROR("dRPC call reported failure, status=%d\n",
 			cred_resp->status);
@@ -1,5 +1,5 @@
 /**
- * (C) Copyright 2018-2023 Intel Corporation.
+ * (C) Copyright 2018-2024 Intel Corporation.
  *
  * SPDX-License-Identifier: BSD-2-Clause-Patent
  */
@@ -32,6 +32,7 @@
 /* unpacked content of response body */
 static Auth__Credential *drpc_call_resp_return_auth_cred;
 char *dc_agent_sockpath;
+char *dc_jobid;
 
 static void
 init_default_drpc_resp_auth_credential(void)
@@ -195,6 +196,38 @@ test_request_credentials_calls_drpc_call(void **state)
 	daos_iov_free(&creds);
 }
 
+static void
+test_request_credentials_redeems_delegation_token(void **state)
+{
+	d_iov_t				 creds;
+	Auth__RedeemDelegationTokenReq	*req;
+	char				*token = "delegation-token";
+
+	memset(&creds, 0, sizeof(d_iov_t));
+	dc_jobid = "job1";
+	assert_int_equal(setenv(DAOS_DELEGATION_TOKEN_ENV, token, 1), 0);
+
+	assert_rc_equal(dc_sec_request_creds(&creds), DER_SUCCESS);
+
+	assert_int_equal(drpc_call_msg_content.module,
+			DRPC_MODULE_SEC_AGENT);
+	assert_int_equal(drpc_call_msg_content.method,
+			DRPC_METHOD_SEC_AGENT_REDEEM_DELEGATION_TOKEN);
+
+	/* Token and job ID are sent to the agent for redemption */
+	req = auth__redeem_delegation_token_req__unpack(NULL,
+							drpc_call_msg_content.body.len,
+							drpc_call_msg_content.body.data);
+	assert_non_null(req);
+	assert_string_equal(req->token, token);
+	assert_string_equal(req->jobid, dc_jobid);
+	auth__redeem_delegation_token_req__free_unpacked(req, NULL);
+
+	unsetenv(DAOS_DELEGATION_TOKEN_ENV);
+	dc_jobid = NULL;
+	daos_iov_free(&creds);
+}
+
 static void
 test_request_credentials_closes_socket_when_call_ok(void **state)
 {
@@ -735,6 +768,8 @@ main(void)
 			test_request_credentials_fails_if_drpc_call_fails),
 		SECURITY_UTEST(
 			test_request_credentials_calls_drpc_call),
+		SECURITY_UTEST(
+			test_request_credentials_redeems_delegation_token),
 		SECURITY_UTEST(
 			test_request_credentials_closes_socket_when_call_ok),
 		SECURITY_UTEST(
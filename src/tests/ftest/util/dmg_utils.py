@@ -223,7 +223,7 @@ class DmgCommand(DmgCommandBase):
         # }
         return self._get_json_result(("storage", "scan"), verbose=verbose)
 
-    def storage_format(self, force=False, timeout=30, verbose=False):
+    def storage_format(self, force=False, timeout=30, verbose=False, confirm=None):
         """Get the result of the dmg storage format command.
 
         Args:
@@ -232,6 +232,8 @@ class DmgCommand(DmgCommandBase):
                 This will create control-plane related metadata i.e. superblock
                 file and reformat if the storage media is available and
                 formattable.  Defaults to False
+            confirm (str, optional): token returned when a forced format of hosts in a
+                running system was armed. Defaults to None.
             timeout: seconds after which the format is considered a failure and
                 times out.
             verbose (bool): show results of each SCM & NVMe device format
@@ -248,7 +250,7 @@ class DmgCommand(DmgCommandBase):
         saved_timeout = self.timeout
         self.timeout = timeout
         self._get_result(
-            ("storage", "format"), force=force, verbose=verbose)
+            ("storage", "format"), force=force, verbose=verbose, confirm=confirm)
         self.timeout = saved_timeout
         return self.result
 
@@ -1123,6 +1125,8 @@ class DmgCommand(DmgCommandBase):
     def system_erase(self):
         """Erase system metadata prior to reformat.
 
+        The erase is first armed on the MS and then performed using the returned token.
+
         Raises:
             CommandFailure: if the dmg system erase command fails.
 
@@ -1130,7 +1134,8 @@ class DmgCommand(DmgCommandBase):
             dict: the dmg json command output converted to a python dictionary
 
         """
-        return self._get_json_result(("system", "erase"))
+        armed = self._get_json_result(("system", "erase"), confirm=None)
+        return self._get_json_result(("system", "erase"), confirm=armed["response"]["token"])
 
     def system_exclude(self, ranks, rank_hosts):
         """Exclude ranks from system.
@@ -1,5 +1,5 @@
 """
-  (C) Copyright 2020-2023 Intel Corporation.
+  (C) Copyright 2020-2024 Intel Corporation.
 
   SPDX-License-Identifier: BSD-2-Clause-Patent
 """
@@ -730,6 +730,7 @@ class DmgCommandBase(YamlCommand):
                 super().__init__("/run/dmg/storage/format/*", "format")
                 self.verbose = FormattedParameter("--verbose", False)
                 self.force = FormattedParameter("--force", False)
+                self.confirm = FormattedParameter("--confirm={}")
 
         class QuerySubCommand(CommandWithSubCommand):
             """Defines an object for the dmg storage query command."""
@@ -875,6 +876,7 @@ class DmgCommandBase(YamlCommand):
             def __init__(self):
                 """Create a dmg system erase command object."""
                 super().__init__("/run/dmg/system/erase/*", "erase")
+                self.confirm = FormattedParameter("--confirm={}")
 
         class ExcludeSubCommand(CommandWithParameters):
             """Defines an object for the dmg system exclude command."""
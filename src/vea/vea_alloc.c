@@ -10,7 +10,7 @@
 #include <daos/dtx.h>
 #include "vea_internal.h"
 
-static int
+int
 compound_alloc_extent(struct vea_space_info *vsi, struct vea_free_extent *vfe,
 		      struct vea_extent_entry *entry)
 {
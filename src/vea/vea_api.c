@@ -974,6 +974,24 @@ vea_flush(struct vea_space_info *vsi, uint32_t nr_flush, uint32_t *nr_flushed)
 	return trigger_aging_flush(vsi, false, nr_flush, nr_flushed);
 }
 
+int
+vea_trim(struct vea_space_info *vsi, uint64_t max_blks, uint64_t *trimmed_blks)
+{
+	if (!umem_tx_none(vsi->vsi_umem)) {
+		D_ERROR("This function isn't supposed to be called in transaction!\n");
+		return -DER_INVAL;
+	}
+
+	/* Device doesn't support unmap, e.g. AIO */
+	if (vsi->vsi_unmap_ctxt.vnc_unmap == NULL) {
+		if (trimmed_blks != NULL)
+			*trimmed_blks = 0;
+		return 0;
+	}
+
+	return trim_free_extents(vsi, max_blks == 0 ? UINT64_MAX : max_blks, trimmed_blks);
+}
+
 struct vea_cb_args {
 	vea_free_callback_t	 vca_cb;
 	void			*vca_cb_args;
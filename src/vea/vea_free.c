@@ -1035,3 +1035,134 @@ out:
 
 	return rc;
 }
+
+/*
+ * Detach free extents large enough to be worth unmapping, starting from offset @start,
+ * from the in-memory compound index, so that they can't be reserved while the unmap
+ * yields. The persistent free tree isn't touched, so no space is leaked if the engine
+ * goes down before the extents are returned to the compound index.
+ */
+static int
+trim_detach(struct vea_space_info *vsi, uint64_t *start, uint64_t max_blks,
+	    d_sg_list_t *trim_sgl)
+{
+	struct vea_extent_entry	*entry;
+	struct vea_free_extent	 vfe;
+	d_iov_t			 key, key_out, val;
+	d_iov_t			*ext_iov;
+	uint64_t		 off, tot_blks = 0;
+	uint32_t		 min_blks = UNMAP_SIZE_THRESH / vsi->vsi_md->vsd_blk_sz;
+	int			 rc;
+
+	D_ASSERT(trim_sgl->sg_nr_out == 0);
+	D_ASSERT(daos_handle_is_valid(vsi->vsi_free_btr));
+
+	while (trim_sgl->sg_nr_out < MAX_FLUSH_FRAGS && tot_blks < max_blks) {
+		off = *start;
+		d_iov_set(&key, &off, sizeof(off));
+		d_iov_set(&key_out, NULL, 0);
+		d_iov_set(&val, NULL, 0);
+
+		rc = dbtree_fetch(vsi->vsi_free_btr, BTR_PROBE_GE, DAOS_INTENT_DEFAULT, &key,
+				  &key_out, &val);
+		if (rc == -DER_NONEXIST)
+			return 0;
+		else if (rc)
+			return rc;
+
+		entry = (struct vea_extent_entry *)val.iov_buf;
+		vfe = entry->vee_ext;
+		*start = vfe.vfe_blk_off + vfe.vfe_blk_cnt;
+
+		if (vfe.vfe_blk_cnt < min_blks)
+			continue;
+		if (vfe.vfe_blk_cnt > max_blks - tot_blks) {
+			/* Trim the head of the extent, the rest is left for the next pass */
+			vfe.vfe_blk_cnt = max_blks - tot_blks;
+			if (vfe.vfe_blk_cnt < min_blks)
+				return 0;
+			*start = vfe.vfe_blk_off + vfe.vfe_blk_cnt;
+		}
+
+		rc = compound_alloc_extent(vsi, &vfe, entry);
+		if (rc) {
+			D_ERROR("Detach ["DF_U64", %u] for trim failed. "DF_RC"\n",
+				vfe.vfe_blk_off, vfe.vfe_blk_cnt, DP_RC(rc));
+			return rc;
+		}
+
+		ext_iov = &trim_sgl->sg_iovs[trim_sgl->sg_nr_out];
+		ext_iov->iov_buf = (void *)vfe.vfe_blk_off;
+		ext_iov->iov_len = vfe.vfe_blk_cnt;
+		trim_sgl->sg_nr_out++;
+		tot_blks += vfe.vfe_blk_cnt;
+	}
+
+	return 0;
+}
+
+int
+trim_free_extents(struct vea_space_info *vsi, uint64_t max_blks, uint64_t *trimmed_blks)
+{
+	struct vea_free_entry	 free_entry;
+	d_sg_list_t		 trim_sgl;
+	d_iov_t			*ext_iov;
+	uint64_t		 start = 0, tot_detached = 0, tot_trimmed = 0;
+	uint32_t		 cur_time;
+	int			 i, rc, unmap_rc, free_rc;
+
+	D_ASSERT(umem_tx_none(vsi->vsi_umem));
+	D_ASSERT(vsi->vsi_unmap_ctxt.vnc_unmap != NULL);
+
+	rc = d_sgl_init(&trim_sgl, MAX_FLUSH_FRAGS);
+	if (rc)
+		goto out;
+
+	cur_time = get_current_age();
+	while (tot_detached < max_blks) {
+		trim_sgl.sg_nr_out = 0;
+		rc = trim_detach(vsi, &start, max_blks - tot_detached, &trim_sgl);
+		if (trim_sgl.sg_nr_out == 0)
+			break;
+
+		unmap_rc = vsi->vsi_unmap_ctxt.vnc_unmap(&trim_sgl, vsi->vsi_md->vsd_blk_sz,
+							 vsi->vsi_unmap_ctxt.vnc_data);
+		if (unmap_rc)
+			D_ERROR("Trim %u frags failed: "DF_RC"\n", trim_sgl.sg_nr_out,
+				DP_RC(unmap_rc));
+
+		/* Return the detached extents whether or not the unmap succeeded */
+		for (i = 0; i < trim_sgl.sg_nr_out; i++) {
+			ext_iov = &trim_sgl.sg_iovs[i];
+			tot_detached += ext_iov->iov_len;
+			if (unmap_rc == 0)
+				tot_trimmed += ext_iov->iov_len;
+
+			free_entry.vfe_ext.vfe_blk_off = (uint64_t)ext_iov->iov_buf;
+			free_entry.vfe_ext.vfe_blk_cnt = ext_iov->iov_len;
+			free_entry.vfe_ext.vfe_age = cur_time;
+			free_entry.vfe_bitmap = NULL;
+
+			free_rc = compound_free(vsi, &free_entry, VEA_FL_NO_ACCOUNTING);
+			if (free_rc) {
+				D_ERROR("Compound free ["DF_U64", %u] error: "DF_RC"\n",
+					free_entry.vfe_ext.vfe_blk_off,
+					free_entry.vfe_ext.vfe_blk_cnt, DP_RC(free_rc));
+				if (rc == 0)
+					rc = free_rc;
+			}
+		}
+
+		if (rc == 0)
+			rc = unmap_rc;
+		if (rc)
+			break;
+	}
+
+	d_sgl_fini(&trim_sgl, false);
+out:
+	if (trimmed_blks != NULL)
+		*trimmed_blks = tot_trimmed;
+
+	return rc;
+}
@@ -286,6 +286,8 @@ int reserve_hint(struct vea_space_info *vsi, uint32_t blk_cnt,
 int reserve_single(struct vea_space_info *vsi, uint32_t blk_cnt,
 		   struct vea_resrvd_ext *resrvd);
 int persistent_alloc(struct vea_space_info *vsi, struct vea_free_entry *vfe);
+int compound_alloc_extent(struct vea_space_info *vsi, struct vea_free_extent *vfe,
+			  struct vea_extent_entry *entry);
 int
 bitmap_tx_add_ptr(struct umem_instance *vsi_umem, uint64_t *bitmap,
 		  uint32_t bit_at, uint32_t bits_nr);
@@ -303,6 +305,7 @@ int persistent_free(struct vea_space_info *vsi, struct vea_free_entry *vfe);
 int aggregated_free(struct vea_space_info *vsi, struct vea_free_entry *vfe);
 int trigger_aging_flush(struct vea_space_info *vsi, bool force,
 			uint32_t nr_flush, uint32_t *nr_flushed);
+int trim_free_extents(struct vea_space_info *vsi, uint64_t max_blks, uint64_t *trimmed_blks);
 int bitmap_entry_insert(struct vea_space_info *vsi, struct vea_free_bitmap *vfb,
 			int state, struct vea_bitmap_entry **ret_entry, unsigned int flags);
 int free_type(struct vea_space_info *vsi, uint64_t blk_off, uint32_t blk_cnt,
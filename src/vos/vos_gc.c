@@ -1272,6 +1272,31 @@ vos_flush_pool(daos_handle_t poh, uint32_t nr_flush, uint32_t *nr_flushed)
 	return rc;
 }
 
+/**
+ * Discard (unmap) up to \a max_blks blocks of the free space tracked by the
+ * VEA of the pool, the number of discarded blocks is returned in
+ * \a trimmed_blks. Pools without VEA (pmem only) trim nothing.
+ */
+int
+vos_pool_trim(daos_handle_t poh, uint64_t max_blks, uint64_t *trimmed_blks)
+{
+	struct vos_pool	*pool = vos_hdl2pool(poh);
+	int		 rc;
+
+	D_ASSERT(daos_handle_is_valid(poh));
+	D_ASSERT(trimmed_blks != NULL);
+
+	*trimmed_blks = 0;
+	if (pool->vp_vea_info == NULL)
+		return 0;
+
+	rc = vea_trim(pool->vp_vea_info, max_blks, trimmed_blks);
+	if (rc)
+		D_ERROR("VEA trim failed. "DF_RC"\n", DP_RC(rc));
+
+	return rc;
+}
+
 #define VOS_GC_DIR "vos_gc"
 void
 vos_gc_metrics_init(struct vos_gc_metrics *vgm, const char *path, int tgt_id)
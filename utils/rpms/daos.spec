@@ -15,7 +15,7 @@
 
 Name:          daos
 Version:       2.7.100
-Release:       1%{?relval}%{?dist}
+Release:       2%{?relval}%{?dist}
 Summary:       DAOS Storage Engine
 
 License:       BSD-2-Clause-Patent
@@ -479,6 +479,8 @@ getent passwd daos_agent >/dev/null || useradd -s /sbin/nologin -r -g daos_agent
 %{_bindir}/cart_ctl
 %{_bindir}/self_test
 %{_bindir}/daos_agent
+%dir %{_libexecdir}/%{name}
+%{_libexecdir}/%{name}/daos_sockmon
 %{_bindir}/dfuse
 %{_bindir}/daos
 %{_libdir}/libdaos_cmd_hdlrs.so
@@ -588,6 +590,9 @@ getent passwd daos_agent >/dev/null || useradd -s /sbin/nologin -r -g daos_agent
 # No files in a shim package
 
 %changelog
+* Tue Oct 15 2024 agent <agent@local> 2.7.100-2
+- Add daos_sockmon job connection correlation helper for daos_agent
+
 * Mon May 20 2024 Phillip Henderson <phillip.henderson@intel.com> 2.7.100-1
 - Bump version to 2.7.100
 